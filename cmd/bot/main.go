@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/ad/gitelegram-prediction-market/internal/bot"
 	"github.com/ad/gitelegram-prediction-market/internal/config"
@@ -24,9 +26,30 @@ import (
 )
 
 func main() {
+	seedPath := flag.String("seed", "", "wipe the database at this path, fill it with sample data for local development and exit")
+	seedConfirm := flag.Bool("seed-confirm", false, "confirm that the database given to -seed may be wiped")
+	seedUserID := flag.Int64("seed-user", 0, "Telegram user ID to add to the sample groups of -seed (optional)")
+	flag.Parse()
+
 	// Load .env file (ignore error if file doesn't exist)
 	_ = godotenv.Load()
 
+	// Seeding needs neither the bot token nor the configured database, so it runs before config.Load.
+	// The path is never taken from the configuration, and wiping it needs an explicit confirmation.
+	if *seedPath != "" {
+		if !*seedConfirm {
+			fmt.Fprintf(os.Stderr, "Refusing to seed %s: it deletes all data in the database, rerun with -seed-confirm\n", *seedPath)
+			os.Exit(2)
+		}
+		log := logger.New(logger.ParseLevel(os.Getenv("LOG_LEVEL")))
+		if err := runSeed(context.Background(), *seedPath, *seedUserID, log); err != nil {
+			log.Error("Failed to seed database", "path", *seedPath, "error", err)
+			os.Exit(1)
+		}
+		log.Info("Database seeded", "path", *seedPath)
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -34,19 +57,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize Localizer (fail-fast if initialization fails)
-	localizer, errLocaleInit := locale.NewLocalizer(context.Background(), locale.NewLocale(cfg.Locale))
-	if errLocaleInit != nil {
-		fmt.Fprintf(os.Stderr, "Failed to initialize Localizer: %v\n", errLocaleInit)
-		os.Exit(1)
-	}
-
 	// Initialize logger
 	logLevel := logger.ParseLevel(cfg.LogLevel)
 	log := logger.New(logLevel)
 
-	log.Info(localizer.MustLocalize(locale.StartingTelegramPredictionBot))
-
 	// Create database directory if it doesn't exist
 	dbDir := filepath.Dir(cfg.DatabasePath)
 	if err := os.MkdirAll(dbDir, 0755); err != nil {
@@ -72,6 +86,7 @@ func main() {
 
 	// Initialize DBQueue for safe concurrent access
 	dbQueue := storage.NewDBQueue(db)
+	dbQueue.SetOperationTimeout(cfg.DBOperationTimeout)
 	defer dbQueue.Close()
 
 	// Initialize database schema
@@ -88,22 +103,58 @@ func main() {
 	}
 	log.Info("Database migrations completed")
 
+	// Initialize Localizer with the languages chosen via /language (fail-fast if initialization fails)
+	languageRepo := storage.NewLanguageRepository(dbQueue)
+	localizerResolver, errLocaleInit := locale.NewLocalizerResolver(cfg.Locale, languageRepo)
+	if errLocaleInit != nil {
+		log.Error("Failed to initialize Localizer", "error", errLocaleInit)
+		os.Exit(1)
+	}
+	localizer := localizerResolver.Default()
+
+	log.Info(localizer.MustLocalize(locale.StartingTelegramPredictionBot))
+
 	// Create repositories
 	eventRepo := storage.NewEventRepository(dbQueue)
 	predictionRepo := storage.NewPredictionRepository(dbQueue)
 	ratingRepo := storage.NewRatingRepository(dbQueue)
 	achievementRepo := storage.NewAchievementRepository(dbQueue)
 	reminderRepo := storage.NewReminderRepository(dbQueue)
-	groupRepo := storage.NewGroupRepository(dbQueue)
-	groupMembershipRepo := storage.NewGroupMembershipRepository(dbQueue)
+	// Group and membership lookups run several times per update, so they are cached briefly;
+	// both repositories share the cache and drop it on every write
+	groupCache := storage.NewGroupCache(storage.DefaultGroupCacheTTL)
+	groupRepo := storage.NewCachedGroupRepository(storage.NewGroupRepository(dbQueue), groupCache)
+	groupMembershipRepo := storage.NewCachedGroupMembershipRepository(storage.NewGroupMembershipRepository(dbQueue), groupCache)
 	forumTopicRepo := storage.NewForumTopicRepository(dbQueue)
+	userRepo := storage.NewUserRepository(dbQueue)
+	seasonRepo := storage.NewSeasonRepository(dbQueue)
 
 	log.Info("Repositories created")
 
 	// Create domain managers
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
-	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, log)
-	achievementTracker := domain.NewAchievementTracker(achievementRepo, ratingRepo, predictionRepo, eventRepo, log)
+	domain.SetEventDeadlineLimits(domain.DeadlineLimits{Min: cfg.MinDeadlineOffset, Max: cfg.MaxDeadlineOffset})
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, groupMembershipRepo, log)
+	participationCap := domain.NewParticipationBonusCap(cfg.ParticipationBonusCap, time.Duration(cfg.ParticipationBonusPeriodDays)*24*time.Hour)
+	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, participationCap, log)
+	ratingCalculator.SetGroupRepository(groupRepo)
+	ratingCalculator.SetSeasonRepository(seasonRepo)
+	ratingCalculator.SetParticipationAtVote(cfg.ParticipationPointsAtVote)
+	achievementThresholds := domain.AchievementThresholds{
+		SharpshooterStreak: cfg.AchievementSharpshooter,
+		ProphetStreak:      cfg.AchievementProphet,
+		RiskTakerStreak:    cfg.AchievementRiskTaker,
+		VeteranCount:       cfg.AchievementVeteran,
+		GlobeTrotterGroups: cfg.AchievementGlobeTrotter,
+		EventOrganizer:     cfg.AchievementOrganizerTiers[0],
+		ActiveOrganizer:    cfg.AchievementOrganizerTiers[1],
+		MasterOrganizer:    cfg.AchievementOrganizerTiers[2],
+	}
+	if err := achievementThresholds.Validate(); err != nil {
+		log.Error("Invalid achievement thresholds", "error", err)
+		os.Exit(1)
+	}
+	achievementTracker := domain.NewAchievementTracker(achievementRepo, ratingRepo, predictionRepo, eventRepo, &achievementThresholds, log)
+	achievementTracker.SetGroupRepository(groupRepo)
 	groupContextResolver := domain.NewGroupContextResolver(groupRepo)
 
 	log.Info("Domain managers created")
@@ -143,6 +194,23 @@ func main() {
 			}
 			// Default handler for other unhandled updates
 		}),
+		tgbot.WithMiddlewares(func(next tgbot.HandlerFunc) tgbot.HandlerFunc {
+			return func(ctx context.Context, b *tgbot.Bot, update *models.Update) {
+				// Cache usernames of every user the bot sees
+				if handler != nil {
+					handler.RememberUser(ctx, update)
+
+					// Reply in the language chosen by the user or for the chat
+					ctx = handler.WithRequestLocalizer(ctx, update)
+
+					// Only admins can use the bot during maintenance (poll answers still pass)
+					if handler.BlockedByMaintenance(ctx, b, update) {
+						return
+					}
+				}
+				next(ctx, b, update)
+			}
+		}),
 	}
 
 	b, err := tgbot.New(cfg.TelegramToken, opts...)
@@ -153,14 +221,6 @@ func main() {
 
 	log.Info("Telegram bot created")
 
-	// Get bot info for deep-link service
-	botInfo, err := b.GetMe(ctx)
-	if err != nil {
-		log.Error("Failed to get bot info", "error", err)
-		os.Exit(1)
-	}
-	log.Info("Bot info retrieved", "username", botInfo.Username)
-
 	// Create ID encoder for deep-link service
 	idEncoder, err := encoding.NewBaseNEncoder(cfg.IDEncodingAlphabet)
 	if err != nil {
@@ -169,10 +229,29 @@ func main() {
 	}
 	log.Info("ID encoder created", "alphabet_length", len(cfg.IDEncodingAlphabet))
 
-	// Create deep-link service
-	deepLinkService := domain.NewDeepLinkService(botInfo.Username, idEncoder)
+	// Create deep-link service; the bot username is filled in from the bot info below
+	deepLinkService := domain.NewDeepLinkService("", idEncoder)
 	log.Info("Deep-link service created")
 
+	// Get bot info for deep-link service. A transient network failure doesn't stop the bot:
+	// the username is fetched in the background and invite links are unavailable until then.
+	fetchBotUsername := func(ctx context.Context) (string, error) {
+		botInfo, err := b.GetMe(ctx)
+		if err != nil {
+			return "", err
+		}
+		return botInfo.Username, nil
+	}
+	if err := deepLinkService.FetchUsername(ctx, fetchBotUsername, domain.UsernameFetchAttempts, domain.UsernameFetchBackoff); err != nil {
+		log.Warn("Failed to get bot info, invite links are unavailable until it succeeds", "error", err)
+		deepLinkService.StartUsernameRefresh(ctx, fetchBotUsername, domain.UsernameRefreshInterval, log)
+	} else {
+		log.Info("Bot info retrieved", "username", deepLinkService.Username())
+	}
+
+	// Register the command menu (failures are logged and don't stop the bot)
+	bot.RegisterBotCommands(ctx, b, cfg.AdminUserIDs, cfg.Locale, log)
+
 	// Create notification service
 	notificationService := domain.NewNotificationService(
 		b,
@@ -184,8 +263,31 @@ func main() {
 		localizer,
 	)
 
+	notificationService.SetResolutionNagPolicy(domain.ResolutionNagPolicy{
+		Delay:         cfg.ResolutionNagDelay,
+		Interval:      cfg.ResolutionNagInterval,
+		MaxCount:      cfg.ResolutionNagMaxCount,
+		EscalateAfter: cfg.ResolutionNagEscalation,
+		Admins:        cfg.AdminUserIDs,
+	})
+
+	// Outcome messages to voters, immediately or as a daily digest per user preference
+	notificationSettingsRepo := storage.NewNotificationSettingsRepository(dbQueue)
+	notificationService.SetOutcomeNotifications(notificationSettingsRepo, predictionRepo)
+	notificationService.SetGroupRepository(groupRepo)
+	notificationService.SetPodiumMedals(cfg.LeaderboardMedals)
+
+	// Skip direct messages to users who never started the bot until they write to it
+	notificationService.SetReachabilityRepository(userRepo)
+
+	// Signed POST to integrators when an event resolves (disabled without a URL)
+	notificationService.SetResolutionWebhook(cfg.ResolutionWebhookURL, cfg.WebhookSecret)
+
 	log.Info("Notification service created")
 
+	// Create subscription service (direct messages about new events to subscribed members)
+	subscriptionService := domain.NewSubscriptionService(storage.NewSubscriptionRepository(dbQueue), groupMembershipRepo, notificationService, log)
+
 	// Create event creation FSM
 	eventCreationFSM := bot.NewEventCreationFSM(
 		fsmStorage,
@@ -196,10 +298,16 @@ func main() {
 		groupRepo,
 		forumTopicRepo,
 		ratingRepo,
+		groupMembershipRepo,
+		userRepo,
+		domain.NewNoopContentValidator(),
 		cfg,
 		log,
 		localizer,
 	)
+	eventCreationFSM.SetNotificationService(notificationService)
+	eventCreationFSM.SetSubscriptionService(subscriptionService)
+	eventCreationFSM.SetLocalizerResolver(localizerResolver)
 	log.Info("Event creation FSM created")
 
 	// Create event permission validator
@@ -210,7 +318,8 @@ func main() {
 		cfg.MinEventsToCreate,
 		log,
 	)
-	log.Info("Event permission validator created")
+	eventPermissionValidator.SetParticipationMode(domain.ParticipationMode(cfg.ParticipationMode))
+	log.Info("Event permission validator created", "participation_mode", cfg.ParticipationMode)
 
 	// Create event resolution FSM
 	eventResolutionFSM := bot.NewEventResolutionFSM(
@@ -228,6 +337,7 @@ func main() {
 		log,
 		localizer,
 	)
+	eventResolutionFSM.SetLocalizerResolver(localizerResolver)
 	log.Info("Event resolution FSM created")
 
 	// Create group creation FSM
@@ -241,6 +351,7 @@ func main() {
 		log,
 		localizer,
 	)
+	groupCreationFSM.SetLocalizerResolver(localizerResolver)
 	log.Info("Group creation FSM created")
 
 	// Create rename FSM
@@ -265,8 +376,47 @@ func main() {
 		log,
 		localizer,
 	)
+	eventEditFSM.SetNotificationService(notificationService)
+	eventEditFSM.SetLocalizerResolver(localizerResolver)
 	log.Info("Event edit FSM created")
 
+	// Create live poll stats syncer (optional)
+	var pollStatsSyncer *bot.PollStatsSyncer
+	if cfg.LivePollStats {
+		pollStatsSyncer = bot.NewPollStatsSyncer(
+			b,
+			eventRepo,
+			predictionRepo,
+			groupRepo,
+			forumTopicRepo,
+			time.Duration(cfg.LivePollStatsInterval)*time.Second,
+			log,
+			localizer,
+		)
+		pollStatsSyncer.SetLocalizerResolver(localizerResolver)
+		log.Info("Live poll stats syncer created", "interval_seconds", cfg.LivePollStatsInterval)
+	}
+
+	// Create stats service
+	statsService := domain.NewStatsService(storage.NewStatsRepository(dbQueue), ratingRepo, eventRepo, predictionRepo, log)
+
+	// Load maintenance mode (persisted across restarts, can be forced on via config)
+	settingsRepo := storage.NewSettingsRepository(dbQueue)
+	maintenance, err := domain.NewMaintenanceMode(ctx, settingsRepo, cfg.MaintenanceMode, log)
+	if err != nil {
+		log.Error("Failed to load maintenance mode", "error", err)
+		os.Exit(1)
+	}
+	if maintenance.Enabled() {
+		log.Warn("Maintenance mode is enabled, only admins can use the bot")
+	}
+
+	// Create feedback service (stores user feedback and forwards it to admins)
+	feedbackService := domain.NewFeedbackService(storage.NewFeedbackRepository(dbQueue), notificationService, cfg.AdminUserIDs, log)
+
+	// Create duel service (private 1:1 duels between users)
+	duelService := domain.NewDuelService(storage.NewDuelRepository(dbQueue), notificationService, log)
+
 	// Create bot handler
 	handler = bot.NewBotHandler(
 		b,
@@ -288,7 +438,19 @@ func main() {
 		deepLinkService,
 		groupContextResolver,
 		ratingRepo,
+		userRepo,
+		pollStatsSyncer,
+		statsService,
+		maintenance,
+		feedbackService,
+		duelService,
+		subscriptionService,
+		notificationSettingsRepo,
+		notificationService,
+		settingsRepo,
+		languageRepo,
 		localizer,
+		localizerResolver,
 	)
 
 	log.Info("Bot handler created")
@@ -296,13 +458,51 @@ func main() {
 	// Register command handlers
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/start", tgbot.MatchTypePrefix, handler.HandleStart)
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/help", tgbot.MatchTypeExact, handler.HandleHelp)
-	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/rating", tgbot.MatchTypeExact, handler.HandleRating)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/rating", tgbot.MatchTypePrefix, handler.HandleRating)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/streaks", tgbot.MatchTypeExact, handler.HandleStreaks)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/calibration", tgbot.MatchTypeExact, handler.HandleCalibration)
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/my", tgbot.MatchTypeExact, handler.HandleMy)
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/events", tgbot.MatchTypeExact, handler.HandleEvents)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/hot", tgbot.MatchTypeExact, handler.HandleHot)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/upcoming", tgbot.MatchTypeExact, handler.HandleUpcoming)
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/groups", tgbot.MatchTypeExact, handler.HandleGroups)
+	// /feedback_list must be registered before the /feedback prefix match
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/feedback_list", tgbot.MatchTypeExact, handler.HandleFeedbackList)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/feedback", tgbot.MatchTypePrefix, handler.HandleFeedback)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/duel", tgbot.MatchTypePrefix, handler.HandleDuel)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/subscribe", tgbot.MatchTypeExact, handler.HandleSubscribe)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/unsubscribe", tgbot.MatchTypeExact, handler.HandleUnsubscribe)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/notifications", tgbot.MatchTypeExact, handler.HandleNotifications)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/language", tgbot.MatchTypePrefix, handler.HandleLanguage)
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/create_event", tgbot.MatchTypeExact, handler.HandleCreateEvent)
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/resolve_event", tgbot.MatchTypeExact, handler.HandleResolveEvent)
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/edit_event", tgbot.MatchTypeExact, handler.HandleEditEvent)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/archive", tgbot.MatchTypeExact, handler.HandleArchive)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/group_stats", tgbot.MatchTypeExact, handler.HandleGroupStats)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/pin_polls", tgbot.MatchTypeExact, handler.HandlePinPolls)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/default_event_type", tgbot.MatchTypeExact, handler.HandleDefaultEventType)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/require_rules", tgbot.MatchTypeExact, handler.HandleRequireRules)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/auto_remove_inactive", tgbot.MatchTypeExact, handler.HandleAutoRemoveInactive)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/reputation_weighting", tgbot.MatchTypeExact, handler.HandleReputationWeighting)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/require_approval", tgbot.MatchTypeExact, handler.HandleRequireApproval)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/first_vote_final", tgbot.MatchTypeExact, handler.HandleFirstVoteFinal)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/exclude_creator_scoring", tgbot.MatchTypeExact, handler.HandleExcludeCreatorScoring)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/achievement_settings", tgbot.MatchTypeExact, handler.HandleAchievementSettings)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/maintenance", tgbot.MatchTypePrefix, handler.HandleMaintenance)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/import_predictions", tgbot.MatchTypeExact, handler.HandleImportPredictions)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/transfer_event", tgbot.MatchTypePrefix, handler.HandleTransferEvent)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/merge_groups", tgbot.MatchTypePrefix, handler.HandleMergeGroups)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/recompute", tgbot.MatchTypePrefix, handler.HandleRecompute)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/season_history", tgbot.MatchTypeExact, handler.HandleSeasonHistory)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/season", tgbot.MatchTypePrefix, handler.HandleSeason)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/resync_usernames", tgbot.MatchTypePrefix, handler.HandleResyncUsernames)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/points_label", tgbot.MatchTypePrefix, handler.HandlePointsLabel)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/session", tgbot.MatchTypePrefix, handler.HandleSession)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/orphans", tgbot.MatchTypeExact, handler.HandleOrphans)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/poll_sync", tgbot.MatchTypePrefix, handler.HandlePollSync)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/diag", tgbot.MatchTypeExact, handler.HandleDiag)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/max_members", tgbot.MatchTypePrefix, handler.HandleMaxMembers)
+	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/archive_days", tgbot.MatchTypePrefix, handler.HandleArchiveDays)
 
 	// Register admin group management commands
 	b.RegisterHandler(tgbot.HandlerTypeMessageText, "/create_group", tgbot.MatchTypeExact, handler.HandleCreateGroup)
@@ -326,6 +526,37 @@ func main() {
 
 	log.Info("Notification scheduler started")
 
+	// Start event archiver (archives resolved events after the retention window of their group)
+	eventArchiver := domain.NewEventArchiver(eventRepo, groupRepo, time.Duration(cfg.EventArchiveDays)*24*time.Hour, log)
+	eventArchiver.StartScheduler(ctx)
+
+	// Start inactive member remover (removes long inactive members from groups that opted in)
+	inactiveMemberRemover := domain.NewInactiveMemberRemover(groupRepo, groupMembershipRepo, deepLinkService, notificationService,
+		cfg.AdminUserIDs, time.Duration(cfg.InactiveMemberDays)*24*time.Hour, log)
+	inactiveMemberRemover.StartScheduler(ctx)
+
+	// Start audit purger (deletes audit rows older than the retention window of their table)
+	auditPurger := domain.NewAuditPurger(storage.NewAuditRetentionRepository(dbQueue), map[string]time.Duration{
+		"admin_logs": time.Duration(cfg.AdminLogRetentionDays) * 24 * time.Hour,
+	}, storage.DefaultAuditPurgeChunkSize, log)
+	auditPurger.StartScheduler(ctx)
+
+	// Start duel scheduler (expires unanswered and unreported duels)
+	duelService.StartScheduler(ctx)
+
+	// Start quiz resolver (quizzes resolve themselves with their correct answer at the deadline)
+	quizResolver := bot.NewQuizResolver(eventRepo, eventResolutionFSM, log)
+	quizResolver.StartScheduler(ctx)
+
+	// Start poll countdown updater (optional, edits the time left under each poll)
+	if cfg.PollCountdown {
+		pollCountdownUpdater := bot.NewPollCountdownUpdater(b, eventRepo, groupRepo, forumTopicRepo, log, localizer)
+		pollCountdownUpdater.StartScheduler(ctx)
+	}
+
+	// Start vote rejection pruner (optional, forgets rejected vote notices once their cooldown is over)
+	handler.StartVoteRejectionPruner(ctx)
+
 	// Start bot polling in a goroutine
 	go func() {
 		log.Info("Starting bot polling")
@@ -340,8 +571,9 @@ func main() {
 	log.Info("Shutdown signal received, stopping bot...")
 
 	// Graceful shutdown
-	// The context cancellation will stop the bot polling and scheduler
-	// DBQueue will be closed by defer
+	// The context cancellation will stop the bot polling; wait for the notification
+	// scheduler to finish its current pass before the DBQueue is closed by defer
+	notificationService.Stop()
 
 	log.Info("Bot stopped successfully")
 }
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+)
+
+// seedUser is a sample member of the seeded groups
+type seedUser struct {
+	id        int64
+	username  string
+	firstName string
+}
+
+// seedUsers are the sample members. Their IDs are far below real Telegram IDs of
+// people likely to test the bot, so they never collide with the developer's account.
+var seedUsers = []seedUser{
+	{id: 1001, username: "alice", firstName: "Alice"},
+	{id: 1002, username: "bob", firstName: "Bob"},
+	{id: 1003, username: "carol", firstName: "Carol"},
+	{id: 1004, username: "dave", firstName: "Dave"},
+	{id: 1005, username: "eve", firstName: "Eve"},
+}
+
+// seedEvent is a sample event. Resolved events are created in the past and resolved with
+// correctOption, active events end in the future. Every member votes on resolved events,
+// only every second member on active ones, so /upcoming has something to show.
+type seedEvent struct {
+	question      string
+	eventType     domain.EventType
+	options       []string
+	correctOption int // -1 for active events
+}
+
+// seedGroup is a sample group with its events
+type seedGroup struct {
+	chatID int64
+	name   string
+	events []seedEvent
+}
+
+var seedGroups = []seedGroup{
+	{
+		chatID: -1000000000001,
+		name:   "Dev Predictions",
+		events: []seedEvent{
+			{question: "Will it rain in the city on Saturday?", eventType: domain.EventTypeBinary, options: []string{"Yes", "No"}, correctOption: 0},
+			{question: "Which language will the next service be written in?", eventType: domain.EventTypeMultiOption, options: []string{"Go", "Rust", "Python"}, correctOption: 0},
+			{question: "Will the release ship before Friday?", eventType: domain.EventTypeBinary, options: []string{"Yes", "No"}, correctOption: -1},
+			{question: "How many bugs will be reported this week?", eventType: domain.EventTypeMultiOption, options: []string{"0-5", "6-10", "More than 10"}, correctOption: -1},
+		},
+	},
+	{
+		chatID: -1000000000002,
+		name:   "Sports Club",
+		events: []seedEvent{
+			{question: "Will the home team win the derby?", eventType: domain.EventTypeBinary, options: []string{"Yes", "No"}, correctOption: 1},
+			{question: "Who will score first?", eventType: domain.EventTypeMultiOption, options: []string{"Forward", "Midfielder", "Defender", "Nobody"}, correctOption: 2},
+			{question: "Will the final go to penalties?", eventType: domain.EventTypeBinary, options: []string{"Yes", "No"}, correctOption: -1},
+		},
+	},
+}
+
+// runSeed wipes the database at dbPath and fills it with sample groups, members, events,
+// votes and resolutions for local development. extraUserID, when non-zero, is added to every
+// sample group, so the developer's own account sees the data in the bot.
+func runSeed(ctx context.Context, dbPath string, extraUserID int64, log *logger.Logger) error {
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return fmt.Errorf("create database directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := storage.NewDBQueue(db)
+	defer queue.Close()
+
+	if err := storage.InitSchema(queue); err != nil {
+		return fmt.Errorf("initialize schema: %w", err)
+	}
+	if err := storage.RunMigrations(queue); err != nil {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+	if err := storage.ClearAllData(ctx, queue); err != nil {
+		return fmt.Errorf("clear database: %w", err)
+	}
+	log.Info("Database cleared", "path", dbPath)
+
+	return seedSampleData(ctx, queue, extraUserID, time.Now(), log)
+}
+
+// seedSampleData creates the sample data through the repositories and domain managers,
+// the same way the bot does
+func seedSampleData(ctx context.Context, queue *storage.DBQueue, extraUserID int64, now time.Time, log *logger.Logger) error {
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	groupRepo := storage.NewGroupRepository(queue)
+	groupMembershipRepo := storage.NewGroupMembershipRepository(queue)
+	userRepo := storage.NewUserRepository(queue)
+
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, groupMembershipRepo, log)
+	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log)
+	ratingCalculator.SetGroupRepository(groupRepo)
+
+	memberIDs := make([]int64, 0, len(seedUsers)+1)
+	for _, user := range seedUsers {
+		if err := userRepo.UpsertUserProfile(ctx, user.id, user.username, user.firstName, ""); err != nil {
+			return fmt.Errorf("create user %s: %w", user.username, err)
+		}
+		memberIDs = append(memberIDs, user.id)
+	}
+	if extraUserID != 0 {
+		memberIDs = append(memberIDs, extraUserID)
+	}
+
+	for _, sg := range seedGroups {
+		group := &domain.Group{
+			TelegramChatID: sg.chatID,
+			Name:           sg.name,
+			CreatedAt:      now.AddDate(0, -1, 0),
+			CreatedBy:      memberIDs[0],
+			Status:         domain.GroupStatusActive,
+		}
+		if err := groupRepo.CreateGroup(ctx, group); err != nil {
+			return fmt.Errorf("create group %s: %w", sg.name, err)
+		}
+
+		for _, userID := range memberIDs {
+			membership := &domain.GroupMembership{
+				GroupID:  group.ID,
+				UserID:   userID,
+				JoinedAt: group.CreatedAt,
+				Status:   domain.MembershipStatusActive,
+			}
+			if err := groupMembershipRepo.CreateMembership(ctx, membership); err != nil {
+				return fmt.Errorf("create membership of user %d in %s: %w", userID, sg.name, err)
+			}
+		}
+
+		for i, se := range sg.events {
+			if err := seedGroupEvent(ctx, eventManager, ratingCalculator, predictionRepo, group.ID, memberIDs, i, se, now); err != nil {
+				return fmt.Errorf("create event %q: %w", se.question, err)
+			}
+		}
+
+		log.Info("Seeded group", "group_id", group.ID, "name", sg.name, "members", len(memberIDs), "events", len(sg.events))
+	}
+
+	return nil
+}
+
+// seedGroupEvent creates one sample event with its votes and, for resolved events, resolves
+// and scores it. index spreads the creators, dates and votes over the sample events.
+func seedGroupEvent(ctx context.Context, eventManager *domain.EventManager, ratingCalculator *domain.RatingCalculator,
+	predictionRepo domain.PredictionRepository, groupID int64, memberIDs []int64, index int, se seedEvent, now time.Time) error {
+	resolved := se.correctOption >= 0
+
+	createdAt := now.Add(-time.Duration(index+1) * time.Hour)
+	deadline := now.AddDate(0, 0, index+2)
+	if resolved {
+		createdAt = now.AddDate(0, 0, -14+index)
+		deadline = createdAt.AddDate(0, 0, 3)
+	}
+
+	event := &domain.Event{
+		GroupID:   groupID,
+		Question:  se.question,
+		Options:   se.options,
+		CreatedAt: createdAt,
+		Deadline:  deadline,
+		Status:    domain.EventStatusActive,
+		EventType: se.eventType,
+		CreatedBy: memberIDs[index%len(memberIDs)],
+		// Seeded events have no Telegram poll; a unique poll ID keeps poll lookups apart
+		PollID:         fmt.Sprintf("seed-%d-%d", groupID, index),
+		AllowsRevoting: true,
+	}
+	if err := eventManager.CreateEvent(ctx, event); err != nil {
+		return err
+	}
+
+	for i, userID := range memberIDs {
+		if !resolved && i%2 == 1 {
+			continue
+		}
+		prediction := &domain.Prediction{
+			EventID:   event.ID,
+			UserID:    userID,
+			Option:    (i + index) % len(se.options),
+			Timestamp: createdAt.Add(time.Duration(i+1) * time.Minute),
+		}
+		if err := predictionRepo.SavePrediction(ctx, prediction); err != nil {
+			return err
+		}
+	}
+
+	if !resolved {
+		return nil
+	}
+
+	if err := eventManager.ResolveEvent(ctx, event.ID, se.correctOption); err != nil {
+		return err
+	}
+	return ratingCalculator.CalculateScores(ctx, event.ID, se.correctOption)
+}
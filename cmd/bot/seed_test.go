@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+)
+
+func TestRunSeed(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "dev", "seed.db")
+	log := logger.NewWithWriter(logger.ParseLevel("ERROR"), io.Discard)
+
+	counts := func() map[string]int {
+		db, err := sql.Open("sqlite", dbPath)
+		if err != nil {
+			t.Fatalf("Failed to open database: %v", err)
+		}
+		defer func() { _ = db.Close() }()
+
+		result := make(map[string]int)
+		for _, query := range []string{
+			`SELECT COUNT(*) FROM groups`,
+			`SELECT COUNT(*) FROM group_memberships`,
+			`SELECT COUNT(*) FROM events`,
+			`SELECT COUNT(*) FROM events WHERE status = 'resolved'`,
+			`SELECT COUNT(*) FROM predictions`,
+			`SELECT COUNT(*) FROM ratings`,
+			`SELECT COUNT(*) FROM group_memberships WHERE user_id = 42`,
+		} {
+			var count int
+			if err := db.QueryRow(query).Scan(&count); err != nil {
+				t.Fatalf("Query %q failed: %v", query, err)
+			}
+			result[query] = count
+		}
+		return result
+	}
+
+	if err := runSeed(ctx, dbPath, 42, log); err != nil {
+		t.Fatalf("runSeed failed: %v", err)
+	}
+	first := counts()
+
+	for query, count := range first {
+		if count == 0 {
+			t.Errorf("Expected seeded rows for %q", query)
+		}
+	}
+
+	// Seeding again replaces the data instead of adding to it
+	if err := runSeed(ctx, dbPath, 42, log); err != nil {
+		t.Fatalf("second runSeed failed: %v", err)
+	}
+	second := counts()
+
+	for query, count := range first {
+		if second[query] != count {
+			t.Errorf("%q: expected %d after reseeding, got %d", query, count, second[query])
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package bot
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// botCommand is a command advertised in the Telegram command menu
+type botCommand struct {
+	command string
+	helpKey string // Help line the description is taken from
+}
+
+// userBotCommands are advertised to everyone, in /help order
+var userBotCommands = []botCommand{
+	{"help", locale.HelpCommandHelp},
+	{"rating", locale.HelpCommandRating},
+	{"streaks", locale.HelpCommandStreaks},
+	{"season_history", locale.HelpCommandSeasonHistory},
+	{"calibration", locale.HelpCommandCalibration},
+	{"my", locale.HelpCommandMy},
+	{"events", locale.HelpCommandEvents},
+	{"hot", locale.HelpCommandHot},
+	{"upcoming", locale.HelpCommandUpcoming},
+	{"groups", locale.HelpCommandGroups},
+	{"feedback", locale.HelpCommandFeedback},
+	{"duel", locale.HelpCommandDuel},
+	{"subscribe", locale.HelpCommandSubscribe},
+	{"unsubscribe", locale.HelpCommandUnsubscribe},
+	{"notifications", locale.HelpCommandNotifications},
+	{"language", locale.HelpCommandLanguage},
+}
+
+// adminBotCommands are advertised only in the private chats of admins, after the user commands
+var adminBotCommands = []botCommand{
+	{"create_group", locale.HelpCommandCreateGroup},
+	{"list_groups", locale.HelpCommandListGroups},
+	{"group_members", locale.HelpCommandGroupMembers},
+	{"remove_member", locale.HelpCommandRemoveMember},
+	{"create_event", locale.HelpCommandCreateEvent},
+	{"resolve_event", locale.HelpCommandResolveEvent},
+	{"edit_event", locale.HelpCommandEditEvent},
+	{"archive", locale.HelpCommandArchive},
+	{"group_stats", locale.HelpCommandGroupStats},
+	{"pin_polls", locale.HelpCommandPinPolls},
+	{"default_event_type", locale.HelpCommandDefaultEventType},
+	{"require_rules", locale.HelpCommandRequireRules},
+	{"auto_remove_inactive", locale.HelpCommandAutoRemoveInactive},
+	{"reputation_weighting", locale.HelpCommandReputationWeighting},
+	{"require_approval", locale.HelpCommandRequireApproval},
+	{"first_vote_final", locale.HelpCommandFirstVoteFinal},
+	{"exclude_creator_scoring", locale.HelpCommandExcludeCreatorScoring},
+	{"achievement_settings", locale.HelpCommandAchievementSettings},
+	{"import_predictions", locale.HelpCommandImportPredictions},
+	{"transfer_event", locale.HelpCommandTransferEvent},
+	{"merge_groups", locale.HelpCommandMergeGroups},
+	{"recompute", locale.HelpCommandRecompute},
+	{"season", locale.HelpCommandSeason},
+	{"resync_usernames", locale.HelpCommandResyncUsernames},
+	{"max_members", locale.HelpCommandMaxMembers},
+	{"archive_days", locale.HelpCommandArchiveDays},
+	{"points_label", locale.HelpCommandPointsLabel},
+	{"maintenance", locale.HelpCommandMaintenance},
+	{"session", locale.HelpCommandSession},
+	{"orphans", locale.HelpCommandOrphans},
+	{"poll_sync", locale.HelpCommandPollSync},
+	{"diag", locale.HelpCommandDiag},
+	{"feedback_list", locale.HelpCommandFeedbackList},
+}
+
+// commandLanguages are the languages command descriptions are registered for
+var commandLanguages = []string{locale.En, locale.Ru}
+
+// RegisterBotCommands registers the command menu with Telegram: user commands for everyone and
+// user plus admin commands in the private chats of admins. Descriptions are registered for every
+// supported language, with defaultLanguage used for users whose language is not supported.
+// Failures are logged and do not stop the bot.
+func RegisterBotCommands(ctx context.Context, b *bot.Bot, adminUserIDs []int64, defaultLanguage string, logger domain.Logger) {
+	scopes := []models.BotCommandScope{&models.BotCommandScopeDefault{}}
+	for _, adminID := range adminUserIDs {
+		scopes = append(scopes, &models.BotCommandScopeChat{ChatID: adminID})
+	}
+
+	// An empty language code registers the fallback for all other languages
+	languages := append([]string{""}, commandLanguages...)
+	for _, language := range languages {
+		localeName := language
+		if localeName == "" {
+			localeName = defaultLanguage
+		}
+		localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(localeName))
+		if err != nil {
+			logger.Error("failed to create localizer for bot commands", "language", localeName, "error", err)
+			continue
+		}
+
+		userCommands := buildBotCommands(localizer, userBotCommands)
+		adminCommands := append(buildBotCommands(localizer, userBotCommands), buildBotCommands(localizer, adminBotCommands)...)
+
+		for i, scope := range scopes {
+			commands := userCommands
+			if i > 0 {
+				commands = adminCommands
+			}
+			_, err := b.SetMyCommands(ctx, &bot.SetMyCommandsParams{
+				Commands:     commands,
+				Scope:        scope,
+				LanguageCode: language,
+			})
+			if err != nil {
+				logger.Error("failed to register bot commands", "language", language, "scope", i, "error", err)
+			}
+		}
+	}
+
+	logger.Info("bot commands registered", "languages", len(languages), "admins", len(adminUserIDs))
+}
+
+// buildBotCommands builds the command menu entries with localized descriptions
+func buildBotCommands(localizer locale.Localizer, commands []botCommand) []models.BotCommand {
+	result := make([]models.BotCommand, 0, len(commands))
+	for _, cmd := range commands {
+		result = append(result, models.BotCommand{
+			Command:     cmd.command,
+			Description: commandDescription(localizer.MustLocalize(cmd.helpKey)),
+		})
+	}
+	return result
+}
+
+// commandDescription extracts the description from a help line such as "  /rating [N] — Top participants"
+func commandDescription(helpLine string) string {
+	if _, description, found := strings.Cut(helpLine, "—"); found {
+		return strings.TrimSpace(description)
+	}
+	return strings.TrimSpace(helpLine)
+}
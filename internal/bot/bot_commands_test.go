@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+
+	tgbot "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+func TestRegisterBotCommands(t *testing.T) {
+	type registration struct {
+		scope    string
+		language string
+		commands []models.BotCommand
+	}
+	var mu sync.Mutex
+	var registrations []registration
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/getMe") {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"id": 1, "is_bot": true, "first_name": "Test"},
+			})
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/setMyCommands") {
+			_ = r.ParseMultipartForm(1 << 20)
+			reg := registration{scope: r.FormValue("scope"), language: r.FormValue("language_code")}
+			_ = json.Unmarshal([]byte(r.FormValue("commands")), &reg.commands)
+			mu.Lock()
+			registrations = append(registrations, reg)
+			mu.Unlock()
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": true})
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := tgbot.New("test-token", tgbot.WithServerURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create bot: %v", err)
+	}
+
+	RegisterBotCommands(context.Background(), b, []int64{42}, "en", logger.New(logger.ERROR))
+
+	// Default and admin scopes for the fallback, English and Russian
+	if len(registrations) != 6 {
+		t.Fatalf("expected 6 registrations, got %d", len(registrations))
+	}
+
+	for _, reg := range registrations {
+		isAdminScope := strings.Contains(reg.scope, `"chat_id":42`)
+		expected := len(userBotCommands)
+		if isAdminScope {
+			expected += len(adminBotCommands)
+		} else if !strings.Contains(reg.scope, `"default"`) {
+			t.Errorf("unexpected scope %s", reg.scope)
+		}
+		if len(reg.commands) != expected {
+			t.Errorf("scope %s language %q: expected %d commands, got %d", reg.scope, reg.language, expected, len(reg.commands))
+		}
+		for _, cmd := range reg.commands {
+			if !isAdminScope && cmd.Command == "create_event" {
+				t.Errorf("admin command advertised in scope %s", reg.scope)
+			}
+			if cmd.Description == "" || strings.Contains(cmd.Description, "/") {
+				t.Errorf("bad description %q for %s", cmd.Description, cmd.Command)
+			}
+		}
+	}
+
+	for _, reg := range registrations {
+		if reg.language == "ru" && reg.commands[0].Description != "Показать эту справку" {
+			t.Errorf("expected Russian description, got %q", reg.commands[0].Description)
+		}
+		if reg.language == "" && reg.commands[0].Description != "Show this help" {
+			t.Errorf("expected fallback in the default language, got %q", reg.commands[0].Description)
+		}
+	}
+}
+
+func TestCommandDescription(t *testing.T) {
+	tests := []struct {
+		helpLine string
+		expected string
+	}{
+		{"  /help — Show this help", "Show this help"},
+		{"  /transfer_event <event_id> <user_id> — Transfer an event to another member", "Transfer an event to another member"},
+		{"Plain text", "Plain text"},
+	}
+	for _, tt := range tests {
+		if got := commandDescription(tt.helpLine); got != tt.expected {
+			t.Errorf("commandDescription(%q) = %q, want %q", tt.helpLine, got, tt.expected)
+		}
+	}
+}
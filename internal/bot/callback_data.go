@@ -0,0 +1,272 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxCallbackDataLength is Telegram's limit for inline button callback data, in bytes
+const maxCallbackDataLength = 64
+
+// callbackSeparator separates the namespace and fields in encoded callback data
+const callbackSeparator = ":"
+
+// Callback namespaces. The wire format is "<namespace>[:<field>...]" and must stay
+// stable: buttons already posted in chats keep sending the data they were created with.
+const (
+	cbSessionConflict = "session_conflict"
+
+	// Event creation FSM
+	cbSelectGroup    = "select_group"
+	cbEventType      = "event_type"
+	cbDeadlinePreset = "deadline_preset"
+	cbEventReminders = "event_reminders"
+	cbEventLockVotes = "event_lock_votes"
+	cbEventPhoto     = "event_photo"
+	cbPollSetting    = "poll_setting"
+	cbQuizAnswer     = "quiz_answer"
+	cbParticipants   = "participants"
+	cbReorderOption  = "reorder"
+	cbConfirm        = "confirm"
+
+	// Group creation FSM
+	cbGroupIsForum = "group_is_forum"
+
+	// Event resolution
+	cbResolve     = "resolve"
+	cbResolvePage = "resolve_page"
+
+	// Event editing
+	cbEditEvent          = "edit_event"
+	cbEditField          = "edit_field"
+	cbEditDeadlinePreset = "edit_deadline_preset"
+
+	// Closing voting before resolution
+	cbCloseVoting = "close_voting"
+
+	// Group and member management
+	cbLeaveGroup             = "leave_group"
+	cbGroupMembers           = "group_members"
+	cbRemoveMemberGroup      = "remove_member_group"
+	cbRemoveMemberUser       = "remove_member_user"
+	cbDeleteGroupSelect      = "delete_group_select"
+	cbDeleteGroupConfirm     = "delete_group_confirm"
+	cbDeleteTopicSelect      = "delete_topic_select"
+	cbDeleteTopicGroup       = "delete_topic_group"
+	cbDeleteTopicConfirm     = "delete_topic_confirm"
+	cbSoftDeleteGroupSelect  = "soft_delete_group_select"
+	cbSoftDeleteGroupConfirm = "soft_delete_group_confirm"
+	cbRestoreGroupSelect     = "restore_group_select"
+	cbRestoreGroupConfirm    = "restore_group_confirm"
+	cbPauseGroupSelect       = "pause_group_select"
+	cbPauseGroupConfirm      = "pause_group_confirm"
+	cbResumeGroupSelect      = "resume_group_select"
+	cbResumeGroupConfirm     = "resume_group_confirm"
+	cbRenameGroupSelect      = "rename_group_select"
+	cbRenameGroupInput       = "rename_group_input"
+	cbRenameTopicSelect      = "rename_topic_select"
+	cbRenameTopicGroup       = "rename_topic_group"
+	cbRenameTopicInput       = "rename_topic_input"
+	cbActivateGroup          = "activate_group"
+
+	// Event archive
+	cbArchivePage    = "archive_page"
+	cbUnarchiveEvent = "unarchive_event"
+
+	// Group analytics
+	cbGroupStats = "group_stats"
+
+	// Poll pinning
+	cbPinPollsToggle = "pin_polls_toggle"
+
+	// Default event type
+	cbDefaultTypeGroup = "default_type_group"
+	cbDefaultTypeSet   = "default_type_set"
+
+	// Rules acceptance
+	cbRequireRulesToggle = "require_rules_toggle"
+	cbAcceptRules        = "accept_rules"
+
+	// Inactive member removal
+	cbAutoRemoveInactiveToggle = "auto_remove_inactive"
+
+	// Reputation weighting
+	cbReputationWeightingToggle = "reputation_weighting"
+
+	// Event approval queue
+	cbRequireApprovalToggle = "require_approval"
+	cbApproveEvent          = "approve_event"
+	cbRejectEvent           = "reject_event"
+
+	// First vote is final
+	cbFirstVoteFinalToggle = "first_vote_final"
+
+	// Creators excluded from scoring their own events
+	cbExcludeCreatorScoringToggle = "exclude_creator_scoring"
+
+	// Achievements enabled per group
+	cbAchievementsGroup  = "achievements_group"
+	cbAchievementsToggle = "achievements_toggle"
+	cbAchievementsBack   = "achievements_back"
+
+	// Duels
+	cbDuel = "duel"
+
+	// New event subscriptions
+	cbSubscription = "subscription"
+
+	// Session inspection
+	cbSessionDelete = "session_delete"
+
+	// Outcome notification preferences
+	cbOutcomeNotifications = "outcome_notifications"
+
+	// Language preferences
+	cbLanguage = "language"
+
+	// Upcoming deadlines
+	cbUpcomingPage = "upcoming_page"
+
+	// Leaderboard refresh
+	cbRatingRefresh = "rating_refresh"
+)
+
+var (
+	// ErrCallbackDataEmpty is returned when callback data is empty
+	ErrCallbackDataEmpty = errors.New("callback data is empty")
+	// ErrCallbackDataTooLong is returned when callback data exceeds Telegram's 64-byte limit
+	ErrCallbackDataTooLong = errors.New("callback data exceeds 64 bytes")
+	// ErrCallbackDataMalformed is returned when callback data has an invalid namespace or field
+	ErrCallbackDataMalformed = errors.New("callback data is malformed")
+)
+
+// Callback is decoded inline button callback data: a namespace followed by positional fields
+type Callback struct {
+	Namespace string
+	Fields    []string
+}
+
+// EncodeCallback encodes a namespace and fields into callback data.
+// Fields are formatted with fmt's default format and must not be empty or contain the separator.
+func EncodeCallback(namespace string, fields ...interface{}) (string, error) {
+	if !isValidCallbackNamespace(namespace) {
+		return "", fmt.Errorf("%w: invalid namespace %q", ErrCallbackDataMalformed, namespace)
+	}
+
+	parts := make([]string, 0, len(fields)+1)
+	parts = append(parts, namespace)
+	for i, field := range fields {
+		s := fmt.Sprint(field)
+		if s == "" || strings.Contains(s, callbackSeparator) {
+			return "", fmt.Errorf("%w: invalid field %d %q", ErrCallbackDataMalformed, i, s)
+		}
+		parts = append(parts, s)
+	}
+
+	data := strings.Join(parts, callbackSeparator)
+	if len(data) > maxCallbackDataLength {
+		return "", fmt.Errorf("%w: %d bytes", ErrCallbackDataTooLong, len(data))
+	}
+
+	return data, nil
+}
+
+// mustEncodeCallback encodes callback data for keyboard buttons built from known namespaces
+// and numeric IDs. It panics on error, which indicates a programming mistake.
+func mustEncodeCallback(namespace string, fields ...interface{}) string {
+	data, err := EncodeCallback(namespace, fields...)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// DecodeCallback decodes and validates callback data
+func DecodeCallback(data string) (*Callback, error) {
+	if data == "" {
+		return nil, ErrCallbackDataEmpty
+	}
+	if len(data) > maxCallbackDataLength {
+		return nil, fmt.Errorf("%w: %d bytes", ErrCallbackDataTooLong, len(data))
+	}
+
+	parts := strings.Split(data, callbackSeparator)
+	if !isValidCallbackNamespace(parts[0]) {
+		return nil, fmt.Errorf("%w: invalid namespace %q", ErrCallbackDataMalformed, parts[0])
+	}
+	for i, field := range parts[1:] {
+		if field == "" {
+			return nil, fmt.Errorf("%w: empty field %d", ErrCallbackDataMalformed, i)
+		}
+	}
+
+	return &Callback{
+		Namespace: parts[0],
+		Fields:    parts[1:],
+	}, nil
+}
+
+// String returns the encoded callback data
+func (c *Callback) String() string {
+	return strings.Join(append([]string{c.Namespace}, c.Fields...), callbackSeparator)
+}
+
+// Expect checks that the callback has the given namespace and number of fields
+func (c *Callback) Expect(namespace string, fieldCount int) error {
+	if c.Namespace != namespace {
+		return fmt.Errorf("%w: expected namespace %q, got %q", ErrCallbackDataMalformed, namespace, c.Namespace)
+	}
+	if len(c.Fields) != fieldCount {
+		return fmt.Errorf("%w: %s expects %d fields, got %d", ErrCallbackDataMalformed, namespace, fieldCount, len(c.Fields))
+	}
+	return nil
+}
+
+// Field returns the field at index i
+func (c *Callback) Field(i int) (string, error) {
+	if i < 0 || i >= len(c.Fields) {
+		return "", fmt.Errorf("%w: missing field %d", ErrCallbackDataMalformed, i)
+	}
+	return c.Fields[i], nil
+}
+
+// Int64 returns the field at index i parsed as int64
+func (c *Callback) Int64(i int) (int64, error) {
+	field, err := c.Field(i)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(field, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: field %d is not an integer: %q", ErrCallbackDataMalformed, i, field)
+	}
+	return v, nil
+}
+
+// Int returns the field at index i parsed as int
+func (c *Callback) Int(i int) (int, error) {
+	field, err := c.Field(i)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, fmt.Errorf("%w: field %d is not an integer: %q", ErrCallbackDataMalformed, i, field)
+	}
+	return v, nil
+}
+
+// isValidCallbackNamespace reports whether namespace consists of lowercase letters and underscores
+func isValidCallbackNamespace(namespace string) bool {
+	if namespace == "" {
+		return false
+	}
+	for _, r := range namespace {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
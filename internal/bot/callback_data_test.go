@@ -0,0 +1,146 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCallbackData_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name      string
+		namespace string
+		fields    []interface{}
+		expected  string
+	}{
+		{"no fields", cbDeleteGroupSelect, nil, "delete_group_select"},
+		{"single int", cbResolve, []interface{}{int64(42)}, "resolve:42"},
+		{"negative chat id", cbLeaveGroup, []interface{}{int64(-1001234567890)}, "leave_group:-1001234567890"},
+		{"string and int", cbEditField, []interface{}{"question", int64(7)}, "edit_field:question:7"},
+		{"two ints", cbRemoveMemberUser, []interface{}{int64(3), int64(123456789)}, "remove_member_user:3:123456789"},
+		{"nested action", cbSessionConflict, []interface{}{"restart", "event_creation"}, "session_conflict:restart:event_creation"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := EncodeCallback(tc.namespace, tc.fields...)
+			if err != nil {
+				t.Fatalf("encode failed: %v", err)
+			}
+			if data != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, data)
+			}
+
+			cb, err := DecodeCallback(data)
+			if err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if cb.Namespace != tc.namespace {
+				t.Errorf("expected namespace %q, got %q", tc.namespace, cb.Namespace)
+			}
+			if err := cb.Expect(tc.namespace, len(tc.fields)); err != nil {
+				t.Errorf("expect failed: %v", err)
+			}
+			if cb.String() != data {
+				t.Errorf("expected String() %q, got %q", data, cb.String())
+			}
+		})
+	}
+}
+
+func TestCallbackData_TypedFields(t *testing.T) {
+	cb, err := DecodeCallback("archive_page:12:-5")
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil || groupID != 12 {
+		t.Errorf("expected group ID 12, got %d (err: %v)", groupID, err)
+	}
+
+	offset, err := cb.Int(1)
+	if err != nil || offset != -5 {
+		t.Errorf("expected offset -5, got %d (err: %v)", offset, err)
+	}
+
+	if _, err := cb.Field(2); !errors.Is(err, ErrCallbackDataMalformed) {
+		t.Errorf("expected malformed error for missing field, got %v", err)
+	}
+
+	cb, err = DecodeCallback("resolve:abc")
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if _, err := cb.Int64(0); !errors.Is(err, ErrCallbackDataMalformed) {
+		t.Errorf("expected malformed error for non-numeric field, got %v", err)
+	}
+
+	if err := cb.Expect(cbEditEvent, 1); !errors.Is(err, ErrCallbackDataMalformed) {
+		t.Errorf("expected malformed error for namespace mismatch, got %v", err)
+	}
+	if err := cb.Expect(cbResolve, 2); !errors.Is(err, ErrCallbackDataMalformed) {
+		t.Errorf("expected malformed error for field count mismatch, got %v", err)
+	}
+}
+
+func TestDecodeCallback_RejectsMalformedData(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected error
+	}{
+		{"empty", "", ErrCallbackDataEmpty},
+		{"oversized", "resolve:" + strings.Repeat("1", 60), ErrCallbackDataTooLong},
+		{"empty namespace", ":42", ErrCallbackDataMalformed},
+		{"uppercase namespace", "Resolve:42", ErrCallbackDataMalformed},
+		{"namespace with spaces", "resolve event:42", ErrCallbackDataMalformed},
+		{"trailing separator", "resolve:", ErrCallbackDataMalformed},
+		{"empty middle field", "remove_member_user::5", ErrCallbackDataMalformed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cb, err := DecodeCallback(tc.data)
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+			if cb != nil {
+				t.Errorf("expected nil callback on error, got %+v", cb)
+			}
+		})
+	}
+}
+
+func TestEncodeCallback_RejectsInvalidInput(t *testing.T) {
+	testCases := []struct {
+		name      string
+		namespace string
+		fields    []interface{}
+		expected  error
+	}{
+		{"empty namespace", "", nil, ErrCallbackDataMalformed},
+		{"namespace with separator", "resolve:option", nil, ErrCallbackDataMalformed},
+		{"field with separator", cbEditField, []interface{}{"a:b", 1}, ErrCallbackDataMalformed},
+		{"empty field", cbEditField, []interface{}{"", 1}, ErrCallbackDataMalformed},
+		{"oversized", cbSessionConflict, []interface{}{strings.Repeat("x", 60)}, ErrCallbackDataTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := EncodeCallback(tc.namespace, tc.fields...); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestMustEncodeCallback_PanicsOnInvalidInput(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for oversized callback data")
+		}
+	}()
+
+	mustEncodeCallback(cbResolve, strings.Repeat("9", 64))
+}
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+)
+
+// errorMessageKeys lists domain errors with a dedicated user message, checked in order before the kind fallback
+var errorMessageKeys = []struct {
+	err error
+	key string
+}{
+	{domain.ErrNoGroupMembership, locale.GroupContextNoMembership},
+	{domain.ErrMultipleGroupsNeedChoice, locale.GroupContextMultipleGroups},
+}
+
+// errorKindMessageKeys maps each error kind to its generic user message
+var errorKindMessageKeys = map[domain.ErrorKind]string{
+	domain.ErrorKindInternal:   locale.ErrorGeneric,
+	domain.ErrorKindValidation: locale.ErrorInvalidRequest,
+	domain.ErrorKindPermission: locale.ErrorUnauthorized,
+	domain.ErrorKindNotFound:   locale.ErrorNotFound,
+	domain.ErrorKindConflict:   locale.ErrorConflict,
+}
+
+// errorMessageKey returns the locale key of the user message for err
+func errorMessageKey(err error) string {
+	for _, entry := range errorMessageKeys {
+		if errors.Is(err, entry.err) {
+			return entry.key
+		}
+	}
+	return errorKindMessageKeys[domain.KindOf(err)]
+}
+
+// userErrorMessage logs err with msg and args at the level matching its kind
+// and returns the localized message to show the user
+func (h *BotHandler) userErrorMessage(ctx context.Context, err error, msg string, args ...interface{}) string {
+	return h.userErrorMessageWith(h.logger, h.requestLocalizer(ctx), err, msg, args...)
+}
+
+// userErrorMessageWith is userErrorMessage logging to the given logger and localizing with loc
+func (h *BotHandler) userErrorMessageWith(log domain.Logger, loc locale.Localizer, err error, msg string, args ...interface{}) string {
+	kind := domain.KindOf(err)
+	args = append(args, "error_kind", kind.String(), "error", err)
+	switch kind {
+	case domain.ErrorKindInternal:
+		log.Error(msg, args...)
+	case domain.ErrorKindPermission, domain.ErrorKindConflict:
+		log.Warn(msg, args...)
+	default:
+		log.Debug(msg, args...)
+	}
+
+	return localizeDynamic(loc, log, errorMessageKey(err))
+}
+
+// replyError logs err to the request logger and sends the matching message, localized for the request, to the chat
+func (h *BotHandler) replyError(ctx context.Context, b *bot.Bot, chatID int64, err error, msg string, args ...interface{}) {
+	log := h.requestLogger(ctx)
+	_, sendErr := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   h.userErrorMessageWith(log, h.requestLocalizer(ctx), err, msg, args...),
+	})
+	if sendErr != nil {
+		log.Error("failed to send error message", "chat_id", chatID, "error", sendErr)
+	}
+}
+
+// skippedItemsFooter returns the footer of a list that tells how many items could not be loaded,
+// so a shorter list doesn't look complete. It is empty when nothing was skipped.
+func (h *BotHandler) skippedItemsFooter(ctx context.Context, skipped int) string {
+	if skipped == 0 {
+		return ""
+	}
+	return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ErrorItemsNotLoaded, strconv.Itoa(skipped))
+}
@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+)
+
+func TestErrorMessageKey(t *testing.T) {
+	tests := []struct {
+		err error
+		key string
+	}{
+		{nil, locale.ErrorGeneric},
+		{errors.New("database is locked"), locale.ErrorGeneric},
+		{domain.ErrNoGroupMembership, locale.GroupContextNoMembership},
+		{fmt.Errorf("resolve: %w", domain.ErrMultipleGroupsNeedChoice), locale.GroupContextMultipleGroups},
+		{domain.ErrDuelInvalidQuestion, locale.ErrorInvalidRequest},
+		{domain.ErrUnauthorized, locale.ErrorUnauthorized},
+		{domain.ErrEventNotFound, locale.ErrorNotFound},
+		{fmt.Errorf("vote: %w", domain.ErrEventNotActive), locale.ErrorConflict},
+	}
+
+	for _, tt := range tests {
+		if key := errorMessageKey(tt.err); key != tt.key {
+			t.Errorf("errorMessageKey(%v) = %s, want %s", tt.err, key, tt.key)
+		}
+	}
+}
+
+func TestErrorKindMessageKeys_Complete(t *testing.T) {
+	kinds := []domain.ErrorKind{
+		domain.ErrorKindInternal,
+		domain.ErrorKindValidation,
+		domain.ErrorKindPermission,
+		domain.ErrorKindNotFound,
+		domain.ErrorKindConflict,
+	}
+	for _, kind := range kinds {
+		if errorKindMessageKeys[kind] == "" {
+			t.Errorf("no user message for error kind %s", kind)
+		}
+	}
+}
+
+func TestUserErrorMessage(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	h := &BotHandler{logger: logger.New(logger.ERROR), localizer: localizer}
+
+	text := h.userErrorMessage(context.Background(), domain.ErrEventNotFound, "failed to get event", "event_id", 1)
+	if expected := localizer.MustLocalize(locale.ErrorNotFound); text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+
+	text = h.userErrorMessage(context.Background(), errors.New("database is locked"), "failed to get event", "event_id", 1)
+	if expected := localizer.MustLocalize(locale.ErrorGeneric); text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+}
@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// rejectReason is a reason an admin can give when rejecting an event waiting for approval
+type rejectReason struct {
+	code string
+	key  string
+}
+
+// rejectReasons are the reasons offered when rejecting an event, in button order
+var rejectReasons = []rejectReason{
+	{"duplicate", locale.EventRejectReasonDuplicate},
+	{"unclear", locale.EventRejectReasonUnclear},
+	{"unverifiable", locale.EventRejectReasonUnverifiable},
+	{"off_topic", locale.EventRejectReasonOffTopic},
+	{"other", locale.EventRejectReasonOther},
+}
+
+// rejectReasonBack is the reject callback field that returns to the approve/reject buttons
+const rejectReasonBack = "back"
+
+// findRejectReason looks up a rejection reason by its callback code
+func findRejectReason(code string) (rejectReason, bool) {
+	for _, reason := range rejectReasons {
+		if reason.code == code {
+			return reason, true
+		}
+	}
+	return rejectReason{}, false
+}
+
+// approvalKeyboard builds the approve/reject buttons of an event waiting for approval
+func approvalKeyboard(localizer locale.Localizer, eventID int64) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: localizer.MustLocalize(locale.EventApprovalButtonApprove), CallbackData: mustEncodeCallback(cbApproveEvent, eventID)},
+				{Text: localizer.MustLocalize(locale.EventApprovalButtonReject), CallbackData: mustEncodeCallback(cbRejectEvent, eventID)},
+			},
+		},
+	}
+}
+
+// rejectReasonsKeyboard builds one button per rejection reason and a button back to approval
+func rejectReasonsKeyboard(localizer locale.Localizer, eventID int64) *models.InlineKeyboardMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	for _, reason := range rejectReasons {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: localizer.MustLocalize(reason.key), CallbackData: mustEncodeCallback(cbRejectEvent, eventID, reason.code)},
+		})
+	}
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: localizer.MustLocalize(locale.EventApprovalButtonBack), CallbackData: mustEncodeCallback(cbRejectEvent, eventID, rejectReasonBack)},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// needsApproval reports whether an event created by the user must be approved before its poll is posted.
+// Admins approve events themselves, so their own events are published right away.
+func (f *EventCreationFSM) needsApproval(group *domain.Group, userID int64) bool {
+	if !group.RequireApproval {
+		return false
+	}
+	for _, adminID := range f.config.AdminUserIDs {
+		if adminID == userID {
+			return false
+		}
+	}
+	return true
+}
+
+// submitForApproval saves a new event as waiting for approval instead of posting its poll
+// and asks the admins to review it
+func (f *EventCreationFSM) submitForApproval(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext, event *domain.Event, group *domain.Group) error {
+	event.Status = domain.EventStatusPendingApproval
+
+	// The topic is stored now, the poll is posted there once the event is approved
+	if context.MessageThreadID != nil {
+		event.ForumTopicID = f.resolveForumTopic(ctx, context.GroupID, *context.MessageThreadID, userID)
+	}
+
+	if err := f.eventManager.CreateEvent(ctx, event); err != nil {
+		f.logger.Error("failed to create event for approval", "user_id", userID, "group_id", group.ID, "error", err)
+		_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorGeneric), nil, false)
+		// Delete session
+		_ = f.storage.Delete(ctx, userID)
+		return err
+	}
+
+	f.sendApprovalRequest(ctx, event, group)
+
+	_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventApprovalSubmitted, event.Question, group.Name), nil, false)
+
+	f.logger.Info("event submitted for approval", "user_id", userID, "event_id", event.ID, "group_id", group.ID)
+
+	// Delete session
+	if err := f.storage.Delete(ctx, userID); err != nil {
+		f.logger.Error("failed to delete session after submitting for approval", "user_id", userID, "error", err)
+	}
+
+	return nil
+}
+
+// sendApprovalRequest sends an event waiting for approval to every admin with approve/reject buttons,
+// each in the admin's language
+func (f *EventCreationFSM) sendApprovalRequest(ctx context.Context, event *domain.Event, group *domain.Group) {
+	creatorName := f.memberDisplayName(ctx, event.CreatedBy)
+	for _, adminID := range f.config.AdminUserIDs {
+		localizer := f.chatLocalizer(ctx, adminID)
+
+		var options strings.Builder
+		for i, opt := range event.Options {
+			options.WriteString(localizer.MustLocalizeWithTemplate(locale.OptionListItem, fmt.Sprintf("%d", i+1), escapeHTML(opt)))
+			options.WriteString("\n")
+		}
+
+		text := localizer.MustLocalizeWithTemplate(locale.EventApprovalRequest,
+			truncateHTML(group.Name, htmlNameMaxLength),
+			truncateHTML(creatorName, htmlNameMaxLength),
+			escapeHTML(event.Question),
+			options.String(),
+			event.Deadline.In(f.config.Timezone).Format("02.01.2006 15:04"),
+		)
+
+		_, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      adminID,
+			Text:        text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: approvalKeyboard(localizer, event.ID),
+		})
+		if err != nil {
+			f.logger.Error("failed to send approval request", "admin_id", adminID, "event_id", event.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreationCompactMode_EditsFormInPlace(t *testing.T) {
+	ctx := context.Background()
+	rec, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+
+	userID := int64(42)
+	chatID := int64(42)
+	formMessageID := 5
+	userMessageID := 55
+
+	sessionContext := &domain.EventCreationContext{
+		ChatID:           chatID,
+		GroupID:          1,
+		LastBotMessageID: formMessageID,
+		CompactMode:      true,
+	}
+	if err := fsm.storage.Set(ctx, userID, StateAskQuestion, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	// Question input: only the user's message is deleted, the form is edited
+	if err := fsm.handleQuestionInput(ctx, userID, chatID, "Will it rain?", userMessageID, sessionContext); err != nil {
+		t.Fatalf("handleQuestionInput returned error: %v", err)
+	}
+
+	if deleted := rec.deletedIDs(); len(deleted) != 1 || deleted[0] != userMessageID {
+		t.Errorf("expected only user message %d to be deleted, got %v", userMessageID, deleted)
+	}
+	if texts := rec.texts(); len(texts) != 0 {
+		t.Errorf("expected no new messages in compact mode, got %v", texts)
+	}
+	if edited := rec.editedIDs(); len(edited) != 1 || edited[0] != formMessageID {
+		t.Errorf("expected form message %d to be edited, got %v", formMessageID, edited)
+	}
+
+	// Event type selection: the form keeps its message ID through the next step
+	_, data, err := fsm.storage.Get(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	restored := &domain.EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("failed to restore context: %v", err)
+	}
+	if !restored.CompactMode || restored.LastBotMessageID != formMessageID {
+		t.Fatalf("expected compact form %d to be tracked, got compact=%t id=%d", formMessageID, restored.CompactMode, restored.LastBotMessageID)
+	}
+
+	callback := &models.CallbackQuery{
+		ID:   "cb",
+		From: models.User{ID: userID},
+		Data: mustEncodeCallback(cbEventType, "binary"),
+		Message: models.MaybeInaccessibleMessage{
+			Message: &models.Message{ID: formMessageID, Chat: models.Chat{ID: chatID}},
+		},
+	}
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		t.Fatalf("failed to decode callback: %v", err)
+	}
+	if err := fsm.handleEventTypeCallback(ctx, userID, callback, cb, restored); err != nil {
+		t.Fatalf("handleEventTypeCallback returned error: %v", err)
+	}
+
+	if deleted := rec.deletedIDs(); len(deleted) != 1 {
+		t.Errorf("expected form message not to be deleted, got %v", deleted)
+	}
+	if texts := rec.texts(); len(texts) != 0 {
+		t.Errorf("expected no new messages in compact mode, got %v", texts)
+	}
+	if edited := rec.editedIDs(); len(edited) != 2 || edited[1] != formMessageID {
+		t.Errorf("expected form message %d to be edited again, got %v", formMessageID, edited)
+	}
+
+	state, data, err := fsm.storage.Get(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if state != StateAskDeadline {
+		t.Errorf("expected state %s, got %s", StateAskDeadline, state)
+	}
+	restored = &domain.EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("failed to restore context: %v", err)
+	}
+	if restored.LastBotMessageID != formMessageID {
+		t.Errorf("expected form message ID %d to be kept, got %d", formMessageID, restored.LastBotMessageID)
+	}
+}
+
+func TestEventCreationClassicMode_SendsNewMessages(t *testing.T) {
+	ctx := context.Background()
+	rec, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+
+	userID := int64(42)
+	chatID := int64(42)
+	sessionContext := &domain.EventCreationContext{
+		ChatID:           chatID,
+		GroupID:          1,
+		LastBotMessageID: 5,
+	}
+	if err := fsm.storage.Set(ctx, userID, StateAskQuestion, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	if err := fsm.handleQuestionInput(ctx, userID, chatID, "Will it rain?", 55, sessionContext); err != nil {
+		t.Fatalf("handleQuestionInput returned error: %v", err)
+	}
+
+	if deleted := rec.deletedIDs(); len(deleted) != 2 {
+		t.Errorf("expected prompt and user message to be deleted, got %v", deleted)
+	}
+	if texts := rec.texts(); len(texts) != 1 {
+		t.Errorf("expected a new prompt message, got %v", texts)
+	}
+	if edited := rec.editedIDs(); len(edited) != 0 {
+		t.Errorf("expected no edits in classic mode, got %v", edited)
+	}
+}
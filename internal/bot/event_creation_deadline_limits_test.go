@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreationDeadlineLimits(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	cfg := &config.Config{Timezone: time.UTC, MinDeadlineOffset: time.Hour, MaxDeadlineOffset: 48 * time.Hour}
+	fsm := NewEventCreationFSM(storage.NewFSMStorage(queue, log), b, nil, nil, nil, storage.NewGroupRepository(queue), nil, nil, nil, nil, nil, cfg, log, localizer)
+
+	// enterDeadline types a deadline and returns the resulting state and the last sent text
+	enterDeadline := func(deadline time.Time) (string, string) {
+		t.Helper()
+		sessionContext := &domain.EventCreationContext{ChatID: userID, GroupID: groupID, Question: "Q?"}
+		if err := fsm.storage.Set(ctx, userID, StateAskDeadline, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+		if err := fsm.handleDeadlineInput(ctx, userID, userID, deadline.In(time.UTC).Format("02.01.2006 15:04"), 50, sessionContext); err != nil {
+			t.Fatalf("handleDeadlineInput failed: %v", err)
+		}
+		state, _, err := fsm.storage.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		texts := rec.texts()
+		return state, texts[len(texts)-1]
+	}
+
+	t.Run("just under the minimum is rejected", func(t *testing.T) {
+		state, text := enterDeadline(time.Now().Add(time.Hour - 2*time.Minute))
+		if state != StateAskDeadline {
+			t.Errorf("expected to stay in %s, got %s", StateAskDeadline, state)
+		}
+		if expected := localizer.MustLocalizeWithTemplate(locale.EventCreationErrorDeadlineTooSoon, "1h"); text != expected {
+			t.Errorf("expected %q, got %q", expected, text)
+		}
+	})
+
+	t.Run("just over the maximum is rejected", func(t *testing.T) {
+		state, text := enterDeadline(time.Now().Add(48*time.Hour + 2*time.Minute))
+		if state != StateAskDeadline {
+			t.Errorf("expected to stay in %s, got %s", StateAskDeadline, state)
+		}
+		if text == "" || text == localizer.MustLocalize(locale.EventCreationErrorDeadlinePast) {
+			t.Errorf("expected the too far error, got %q", text)
+		}
+	})
+
+	t.Run("a deadline within the range is accepted", func(t *testing.T) {
+		state, _ := enterDeadline(time.Now().Add(24 * time.Hour))
+		if state != StateAskReminders {
+			t.Errorf("expected %s, got %s", StateAskReminders, state)
+		}
+	})
+
+	t.Run("presets beyond the range are hidden", func(t *testing.T) {
+		kb := fsm.getDeadlinePresetKeyboard(ctx)
+		if len(kb.InlineKeyboard) != 1 || kb.InlineKeyboard[0][0].CallbackData != mustEncodeCallback(cbDeadlinePreset, "1d") {
+			t.Errorf("expected only the 1 day preset, got %v", kb.InlineKeyboard)
+		}
+	})
+
+	t.Run("a stale out of range preset is rejected", func(t *testing.T) {
+		sessionContext := &domain.EventCreationContext{ChatID: userID, GroupID: groupID, Question: "Q?"}
+		if err := fsm.storage.Set(ctx, userID, StateAskDeadline, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+		data := mustEncodeCallback(cbDeadlinePreset, "7d")
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		callback := &models.CallbackQuery{
+			ID:      "cb",
+			From:    models.User{ID: userID},
+			Data:    data,
+			Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}}},
+		}
+		if err := fsm.handleDeadlinePresetCallback(ctx, userID, callback, cb, sessionContext); err != nil {
+			t.Fatalf("handleDeadlinePresetCallback failed: %v", err)
+		}
+		if state, _, _ := fsm.storage.Get(ctx, userID); state != StateAskDeadline {
+			t.Errorf("expected to stay in %s, got %s", StateAskDeadline, state)
+		}
+	})
+}
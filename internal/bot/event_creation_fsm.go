@@ -2,7 +2,9 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,16 +20,54 @@ import (
 
 // FSM state constants
 const (
-	StateSelectGroup  = "select_group"
-	StateAskQuestion  = "ask_question"
-	StateAskEventType = "ask_event_type"
-	StateAskOptions   = "ask_options"
-	StateAskDeadline  = "ask_deadline"
-	StatePollSettings = "poll_settings"
-	StateConfirm      = "confirm"
-	StateComplete     = "complete"
+	StateSelectGroup        = "select_group"
+	StateAskQuestion        = "ask_question"
+	StateAskEventType       = "ask_event_type"
+	StateAskOptions         = "ask_options"
+	StateAskDeadline        = "ask_deadline"
+	StateAskReminders       = "ask_reminders"
+	StateAskLockVotes       = "ask_lock_votes"
+	StateAskPhoto           = "ask_photo"
+	StatePollSettings       = "poll_settings"
+	StateAskQuizAnswer      = "ask_quiz_answer"
+	StateSelectParticipants = "select_participants"
+	StateReorderOptions     = "reorder_options"
+	StateConfirm            = "confirm"
+	StateComplete           = "complete"
 )
 
+// Steps that can be reopened from the event preview
+const (
+	previewStepQuestion     = "question"
+	previewStepType         = "type"
+	previewStepOptions      = "options"
+	previewStepReorder      = "reorder"
+	previewStepDeadline     = "deadline"
+	previewStepReminders    = "reminders"
+	previewStepLockVotes    = "lock_votes"
+	previewStepPhoto        = "photo"
+	previewStepSettings     = "settings"
+	previewStepParticipants = "participants"
+)
+
+// participantsPageSize is the number of group members shown per page in the participant selection step
+const participantsPageSize = 8
+
+// reminderPresets are the custom reminder schedules offered in the reminders step
+var reminderPresets = [][]time.Duration{
+	{24 * time.Hour, time.Hour},
+	{time.Hour},
+	{72 * time.Hour, 24 * time.Hour},
+	{7 * 24 * time.Hour, 24 * time.Hour},
+}
+
+// lockVotesPresets are the offsets before the deadline offered in the votes lock step
+var lockVotesPresets = []time.Duration{
+	time.Hour,
+	24 * time.Hour,
+	72 * time.Hour,
+}
+
 // EventCreationFSM manages the event creation state machine
 type EventCreationFSM struct {
 	storage              *storage.FSMStorage
@@ -38,9 +78,15 @@ type EventCreationFSM struct {
 	groupRepo            domain.GroupRepository
 	forumTopicRepo       domain.ForumTopicRepository
 	ratingRepo           domain.RatingRepository
+	groupMembershipRepo  domain.GroupMembershipRepository
+	userRepo             domain.UserRepository
+	contentValidator     domain.ContentValidator
 	config               *config.Config
+	notificationService  *domain.NotificationService
+	subscriptionService  *domain.SubscriptionService
 	logger               domain.Logger
 	localizer            locale.Localizer
+	localizerResolver    *locale.LocalizerResolver
 }
 
 // NewEventCreationFSM creates a new FSM for event creation
@@ -53,10 +99,17 @@ func NewEventCreationFSM(
 	groupRepo domain.GroupRepository,
 	forumTopicRepo domain.ForumTopicRepository,
 	ratingRepo domain.RatingRepository,
+	groupMembershipRepo domain.GroupMembershipRepository,
+	userRepo domain.UserRepository,
+	contentValidator domain.ContentValidator,
 	cfg *config.Config,
 	logger domain.Logger,
 	localizer locale.Localizer,
 ) *EventCreationFSM {
+	if contentValidator == nil {
+		contentValidator = domain.NewNoopContentValidator()
+	}
+
 	return &EventCreationFSM{
 		storage:              storage,
 		bot:                  b,
@@ -66,17 +119,111 @@ func NewEventCreationFSM(
 		groupRepo:            groupRepo,
 		forumTopicRepo:       forumTopicRepo,
 		ratingRepo:           ratingRepo,
+		groupMembershipRepo:  groupMembershipRepo,
+		userRepo:             userRepo,
+		contentValidator:     contentValidator,
 		config:               cfg,
 		logger:               logger,
 		localizer:            localizer,
 	}
 }
 
+// SetNotificationService enables custom reminder schedules of created events (not set by default)
+func (f *EventCreationFSM) SetNotificationService(notificationService *domain.NotificationService) {
+	f.notificationService = notificationService
+}
+
+// SetSubscriptionService enables direct messages about created events to subscribed members (not set by default)
+func (f *EventCreationFSM) SetSubscriptionService(subscriptionService *domain.SubscriptionService) {
+	f.subscriptionService = subscriptionService
+}
+
+// SetLocalizerResolver enables messages to other chats, e.g. to admins or group chats,
+// in the language chosen for them (the FSM localizer is used by default)
+func (f *EventCreationFSM) SetLocalizerResolver(localizerResolver *locale.LocalizerResolver) {
+	f.localizerResolver = localizerResolver
+}
+
+// notifySubscribers sends direct messages about a published event to subscribed members in the
+// background, so large groups don't delay the creator's confirmation
+func (f *EventCreationFSM) notifySubscribers(ctx context.Context, event *domain.Event, group *domain.Group) {
+	if f.subscriptionService == nil {
+		return
+	}
+
+	bgCtx := context.WithoutCancel(ctx)
+	go func() {
+		_, _ = f.subscriptionService.NotifyNewEvent(bgCtx, event, group)
+	}()
+}
+
+// announcePublishedEvent enqueues the custom reminders of a published event and tells subscribed members about it.
+// Failures are logged and never undo the publication.
+func (f *EventCreationFSM) announcePublishedEvent(ctx context.Context, event *domain.Event, group *domain.Group) {
+	if f.notificationService != nil {
+		_ = f.notificationService.ScheduleEventReminders(ctx, event)
+	}
+	f.notifySubscribers(ctx, event, group)
+}
+
+// awardCreatorAchievements checks and announces the achievements a creator earned by publishing an event.
+// Failures are logged and never block the publication.
+func (f *EventCreationFSM) awardCreatorAchievements(ctx context.Context, userID int64, groupID int64) {
+	achievements, err := f.achievementTracker.CheckCreatorAchievements(ctx, userID, groupID)
+	if err != nil {
+		f.logger.Error("failed to check creator achievements", "user_id", userID, "group_id", groupID, "error", err)
+		return
+	}
+
+	for _, ach := range achievements {
+		if err := f.sendAchievementNotification(ctx, userID, ach); err != nil {
+			f.logger.Error("failed to send achievement notification", "user_id", userID, "achievement", ach.Code, "error", err)
+			// Continue - notification failure should not block event creation
+		}
+	}
+}
+
+// resolveForumTopic finds or creates the forum topic of a message thread and returns its ID (nil on failure)
+func (f *EventCreationFSM) resolveForumTopic(ctx context.Context, groupID int64, messageThreadID int, userID int64) *int64 {
+	topic, err := f.forumTopicRepo.GetForumTopicByGroupAndThread(ctx, groupID, messageThreadID)
+	if err != nil {
+		f.logger.Error("failed to get forum topic", "group_id", groupID, "message_thread_id", messageThreadID, "error", err)
+		return nil
+	}
+
+	if topic != nil {
+		f.logger.Info("using existing forum topic for event", "topic_id", topic.ID, "message_thread_id", messageThreadID)
+		return &topic.ID
+	}
+
+	// Create new forum topic
+	topic = &domain.ForumTopic{
+		GroupID:         groupID,
+		MessageThreadID: messageThreadID,
+		Name:            fmt.Sprintf("Topic %d", messageThreadID),
+		CreatedAt:       time.Now(),
+		CreatedBy:       userID,
+	}
+	if err := f.forumTopicRepo.CreateForumTopic(ctx, topic); err != nil {
+		f.logger.Error("failed to create forum topic", "error", err)
+		return nil
+	}
+
+	f.logger.Info("forum topic created for event", "topic_id", topic.ID, "message_thread_id", messageThreadID)
+	return &topic.ID
+}
+
 // Start initializes a new FSM session for a user
 func (f *EventCreationFSM) Start(ctx context.Context, userID int64, chatID int64) error {
 	// Initialize context with chat ID
 	initialContext := &domain.EventCreationContext{
-		ChatID: chatID,
+		ChatID:        chatID,
+		SummaryChatID: chatID,
+		CompactMode:   f.config != nil && f.config.CompactEventCreation,
+	}
+	// The summary with the management buttons can go to the creator's private chat instead
+	if f.config != nil && f.config.EventSummaryToDM {
+		initialContext.SummaryChatID = userID
 	}
 
 	// Try to resolve group for user
@@ -126,7 +273,7 @@ func (f *EventCreationFSM) HasSession(ctx context.Context, userID int64) (bool,
 
 	// Only return true if the state is an event creation state
 	switch state {
-	case StateSelectGroup, StateAskQuestion, StateAskEventType, StateAskOptions, StateAskDeadline, StatePollSettings, StateConfirm, StateComplete:
+	case StateSelectGroup, StateAskQuestion, StateAskEventType, StateAskOptions, StateAskDeadline, StateAskReminders, StateAskLockVotes, StateAskPhoto, StatePollSettings, StateAskQuizAnswer, StateSelectParticipants, StateConfirm, StateComplete:
 		return true, nil
 	default:
 		return false, nil
@@ -154,7 +301,7 @@ func (f *EventCreationFSM) HandleMessage(ctx context.Context, update *models.Upd
 			// Send expiration message
 			_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   f.localizer.MustLocalize(locale.SessionExpiredLong),
+				Text:   f.requestLocalizer(ctx).MustLocalize(locale.SessionExpiredLong),
 			})
 			return nil
 		}
@@ -179,6 +326,12 @@ func (f *EventCreationFSM) HandleMessage(ctx context.Context, update *models.Upd
 		return f.handleOptionsInput(ctx, userID, chatID, update.Message.Text, update.Message.ID, context)
 	case StateAskDeadline:
 		return f.handleDeadlineInput(ctx, userID, chatID, update.Message.Text, update.Message.ID, context)
+	case StateAskReminders:
+		return f.handleRemindersInput(ctx, userID, chatID, update.Message.Text, update.Message.ID, context)
+	case StateAskLockVotes:
+		return f.handleLockVotesInput(ctx, userID, chatID, update.Message.Text, update.Message.ID, context)
+	case StateAskPhoto:
+		return f.handlePhotoInput(ctx, userID, chatID, update.Message, context)
 	default:
 		f.logger.Warn("unexpected state for message", "user_id", userID, "state", state)
 		return nil
@@ -188,7 +341,12 @@ func (f *EventCreationFSM) HandleMessage(ctx context.Context, update *models.Upd
 // HandleCallback routes callback queries to the appropriate handler
 func (f *EventCreationFSM) HandleCallback(ctx context.Context, callback *models.CallbackQuery) error {
 	userID := callback.From.ID
-	data := callback.Data
+
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		f.logger.Error("invalid callback data", "user_id", userID, "error", err)
+		return err
+	}
 
 	// Get current state
 	state, contextData, err := f.storage.Get(ctx, userID)
@@ -202,12 +360,12 @@ func (f *EventCreationFSM) HandleCallback(ctx context.Context, callback *models.
 			// Answer callback query and send expiration message
 			_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: callback.ID,
-				Text:            f.localizer.MustLocalize(locale.SessionExpiredShort),
+				Text:            f.requestLocalizer(ctx).MustLocalize(locale.SessionExpiredShort),
 			})
 			if callback.Message.Message != nil {
 				_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 					ChatID: callback.Message.Message.Chat.ID,
-					Text:   f.localizer.MustLocalize(locale.SessionExpiredLong),
+					Text:   f.requestLocalizer(ctx).MustLocalize(locale.SessionExpiredLong),
 				})
 			}
 			return nil
@@ -226,27 +384,51 @@ func (f *EventCreationFSM) HandleCallback(ctx context.Context, callback *models.
 	}
 
 	// Route based on callback data and state
-	if strings.HasPrefix(data, "select_group:") && state == StateSelectGroup {
-		return f.handleGroupSelectionCallback(ctx, userID, callback, context)
+	if cb.Namespace == cbSelectGroup && state == StateSelectGroup {
+		return f.handleGroupSelectionCallback(ctx, userID, callback, cb, context)
+	}
+
+	if cb.Namespace == cbEventType && state == StateAskEventType {
+		return f.handleEventTypeCallback(ctx, userID, callback, cb, context)
+	}
+
+	if cb.Namespace == cbDeadlinePreset && state == StateAskDeadline {
+		return f.handleDeadlinePresetCallback(ctx, userID, callback, cb, context)
+	}
+
+	if cb.Namespace == cbEventReminders && state == StateAskReminders {
+		return f.handleRemindersCallback(ctx, userID, callback, cb, context)
+	}
+
+	if cb.Namespace == cbEventLockVotes && state == StateAskLockVotes {
+		return f.handleLockVotesCallback(ctx, userID, callback, cb, context)
+	}
+
+	if cb.Namespace == cbEventPhoto && state == StateAskPhoto {
+		return f.handlePhotoCallback(ctx, userID, callback, cb, context)
+	}
+
+	if cb.Namespace == cbPollSetting && state == StatePollSettings {
+		return f.handlePollSettingsCallback(ctx, userID, callback, cb, context)
 	}
 
-	if strings.HasPrefix(data, "event_type:") && state == StateAskEventType {
-		return f.handleEventTypeCallback(ctx, userID, callback, context)
+	if cb.Namespace == cbQuizAnswer && state == StateAskQuizAnswer {
+		return f.handleQuizAnswerCallback(ctx, userID, callback, cb, context)
 	}
 
-	if strings.HasPrefix(data, "deadline_preset:") && state == StateAskDeadline {
-		return f.handleDeadlinePresetCallback(ctx, userID, callback, context)
+	if cb.Namespace == cbParticipants && state == StateSelectParticipants {
+		return f.handleParticipantsCallback(ctx, userID, callback, cb, context)
 	}
 
-	if strings.HasPrefix(data, "poll_setting:") && state == StatePollSettings {
-		return f.handlePollSettingsCallback(ctx, userID, callback, context)
+	if cb.Namespace == cbReorderOption && state == StateReorderOptions {
+		return f.handleReorderCallback(ctx, userID, callback, cb, context)
 	}
 
-	if strings.HasPrefix(data, "confirm:") && state == StateConfirm {
-		return f.handleConfirmCallback(ctx, userID, callback, context)
+	if cb.Namespace == cbConfirm && state == StateConfirm {
+		return f.handleConfirmCallback(ctx, userID, callback, cb, context)
 	}
 
-	f.logger.Warn("unexpected callback", "user_id", userID, "state", state, "data", data)
+	f.logger.Warn("unexpected callback", "user_id", userID, "state", state, "data", cb.String())
 	return nil
 }
 
@@ -284,6 +466,49 @@ func (f *EventCreationFSM) sendMessageHTML(ctx context.Context, chatID int64, te
 	return msg.ID, nil
 }
 
+// showStep presents the next dialog step and returns the ID of the message showing it.
+// In compact mode the form message (LastBotMessageID) is edited in place; if that fails
+// (e.g. the message was deleted), the stale form is removed and a new message is sent.
+func (f *EventCreationFSM) showStep(ctx context.Context, chatID int64, context *domain.EventCreationContext, text string, replyMarkup models.ReplyMarkup, useHTML bool) (int, error) {
+	if context.CompactMode && context.LastBotMessageID != 0 {
+		params := &bot.EditMessageTextParams{
+			ChatID:      chatID,
+			MessageID:   context.LastBotMessageID,
+			Text:        text,
+			ReplyMarkup: replyMarkup,
+		}
+		if useHTML {
+			params.ParseMode = models.ParseModeHTML
+		}
+		_, err := f.bot.EditMessageText(ctx, params)
+		if err == nil {
+			return context.LastBotMessageID, nil
+		}
+		f.logger.Warn("failed to edit form message, sending a new one", "chat_id", chatID, "message_id", context.LastBotMessageID, "error", err)
+		f.deleteMessages(ctx, chatID, context.LastBotMessageID)
+	}
+
+	if useHTML {
+		return f.sendMessageHTML(ctx, chatID, text, replyMarkup)
+	}
+	return f.sendMessage(ctx, chatID, text, replyMarkup)
+}
+
+// stepMessagesToDelete returns the messages to clean up after valid user input:
+// the input itself, any previous error message and the bot prompt.
+// In compact mode the prompt is kept because the next step is edited into it.
+func (f *EventCreationFSM) stepMessagesToDelete(context *domain.EventCreationContext, userMessageID int) []int {
+	messagesToDelete := []int{userMessageID}
+	if !context.CompactMode {
+		messagesToDelete = append(messagesToDelete, context.LastBotMessageID)
+	}
+	if context.LastErrorMessageID != 0 {
+		messagesToDelete = append(messagesToDelete, context.LastErrorMessageID)
+		context.LastErrorMessageID = 0 // Clear error message ID
+	}
+	return messagesToDelete
+}
+
 // handleSelectGroup sends the group selection prompt with inline keyboard
 func (f *EventCreationFSM) handleSelectGroup(ctx context.Context, userID int64, chatID int64) error {
 	// Get user's group choices
@@ -295,7 +520,7 @@ func (f *EventCreationFSM) handleSelectGroup(ctx context.Context, userID int64,
 
 	if len(groups) == 0 {
 		// This shouldn't happen as we check in Start, but handle it gracefully
-		_, _ = f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationNoGroupsAvailable), nil)
+		_, _ = f.sendMessage(ctx, chatID, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationNoGroupsAvailable), nil)
 		_ = f.storage.Delete(ctx, userID)
 		return fmt.Errorf("no groups available for user")
 	}
@@ -309,7 +534,7 @@ func (f *EventCreationFSM) handleSelectGroup(ctx context.Context, userID int64,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         group.Name,
-					CallbackData: fmt.Sprintf("select_group:%d", group.ID),
+					CallbackData: mustEncodeCallback(cbSelectGroup, group.ID),
 				},
 			})
 
@@ -325,7 +550,7 @@ func (f *EventCreationFSM) handleSelectGroup(ctx context.Context, userID int64,
 				buttons = append(buttons, []models.InlineKeyboardButton{
 					{
 						Text:         fmt.Sprintf("  ↳ %s", topic.Name),
-						CallbackData: fmt.Sprintf("select_group:%d:%d", group.ID, topic.MessageThreadID),
+						CallbackData: mustEncodeCallback(cbSelectGroup, group.ID, topic.MessageThreadID),
 					},
 				})
 			}
@@ -334,7 +559,7 @@ func (f *EventCreationFSM) handleSelectGroup(ctx context.Context, userID int64,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         group.Name,
-					CallbackData: fmt.Sprintf("select_group:%d", group.ID),
+					CallbackData: mustEncodeCallback(cbSelectGroup, group.ID),
 				},
 			})
 		}
@@ -346,8 +571,8 @@ func (f *EventCreationFSM) handleSelectGroup(ctx context.Context, userID int64,
 
 	// Send message
 	messageText := fmt.Sprintf("%s\n\n%s",
-		f.localizer.MustLocalize(locale.EventCreationTitle),
-		f.localizer.MustLocalize(locale.EventCreationSelectGroup))
+		f.requestLocalizer(ctx).MustLocalize(locale.EventCreationTitle),
+		f.requestLocalizer(ctx).MustLocalize(locale.EventCreationSelectGroup))
 	messageID, err := f.sendMessage(ctx, chatID, messageText, kb)
 	if err != nil {
 		return err
@@ -377,7 +602,7 @@ func (f *EventCreationFSM) handleSelectGroup(ctx context.Context, userID int64,
 }
 
 // handleGroupSelectionCallback processes the group selection
-func (f *EventCreationFSM) handleGroupSelectionCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, context *domain.EventCreationContext) error {
+func (f *EventCreationFSM) handleGroupSelectionCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
 	// Answer callback query to remove loading state
 	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 		CallbackQueryID: callback.ID,
@@ -385,16 +610,13 @@ func (f *EventCreationFSM) handleGroupSelectionCallback(ctx context.Context, use
 
 	// Parse group ID and optional thread ID from callback data
 	// Format: "select_group:ID" or "select_group:ID:ThreadID"
-	dataStr := strings.TrimPrefix(callback.Data, "select_group:")
-	parts := strings.Split(dataStr, ":")
-
-	if len(parts) == 0 {
+	if len(cb.Fields) != 1 && len(cb.Fields) != 2 {
 		f.logger.Error("invalid callback data format", "user_id", userID, "data", callback.Data)
 		return fmt.Errorf("invalid callback data format")
 	}
 
 	// Parse group ID
-	groupID, err := strconv.ParseInt(parts[0], 10, 64)
+	groupID, err := cb.Int64(0)
 	if err != nil {
 		f.logger.Error("failed to parse group ID", "user_id", userID, "data", callback.Data, "error", err)
 		return err
@@ -404,8 +626,8 @@ func (f *EventCreationFSM) handleGroupSelectionCallback(ctx context.Context, use
 	context.GroupID = groupID
 
 	// Parse thread ID if present
-	if len(parts) > 1 {
-		threadID, err := strconv.Atoi(parts[1])
+	if len(cb.Fields) > 1 {
+		threadID, err := cb.Int(1)
 		if err != nil {
 			f.logger.Error("failed to parse thread ID", "user_id", userID, "data", callback.Data, "error", err)
 			return err
@@ -417,9 +639,13 @@ func (f *EventCreationFSM) handleGroupSelectionCallback(ctx context.Context, use
 		f.logger.Debug("group selected (no topic)", "user_id", userID, "group_id", groupID)
 	}
 
-	// Delete the group selection message
+	// Delete the group selection message (kept and edited in compact mode)
 	if callback.Message.Message != nil {
-		f.deleteMessages(ctx, callback.Message.Message.Chat.ID, callback.Message.Message.ID)
+		if context.CompactMode {
+			context.LastBotMessageID = callback.Message.Message.ID
+		} else {
+			f.deleteMessages(ctx, callback.Message.Message.Chat.ID, callback.Message.Message.ID)
+		}
 	}
 
 	// Transition to ask_question state
@@ -436,16 +662,6 @@ func (f *EventCreationFSM) handleGroupSelectionCallback(ctx context.Context, use
 
 // handleAskQuestion sends the initial question prompt
 func (f *EventCreationFSM) handleAskQuestion(ctx context.Context, userID int64, chatID int64) error {
-	// Send message
-	messageText := fmt.Sprintf("%s\n\n%s",
-		f.localizer.MustLocalize(locale.EventCreationTitle),
-		f.localizer.MustLocalize(locale.EventCreationAskQuestion))
-	messageID, err := f.sendMessage(ctx, chatID, messageText, nil)
-	if err != nil {
-		return err
-	}
-
-	// Update context with message ID
 	state, data, err := f.storage.Get(ctx, userID)
 	if err != nil {
 		return err
@@ -456,6 +672,16 @@ func (f *EventCreationFSM) handleAskQuestion(ctx context.Context, userID int64,
 		return err
 	}
 
+	// Send message
+	messageText := fmt.Sprintf("%s\n\n%s",
+		f.requestLocalizer(ctx).MustLocalize(locale.EventCreationTitle),
+		f.requestLocalizer(ctx).MustLocalize(locale.EventCreationAskQuestion))
+	messageID, err := f.showStep(ctx, chatID, context, messageText, nil, false)
+	if err != nil {
+		return err
+	}
+
+	// Update context with message ID
 	context.LastBotMessageID = messageID
 
 	// Save updated context
@@ -473,34 +699,12 @@ func (f *EventCreationFSM) handleQuestionInput(ctx context.Context, userID int64
 	// Validate question is not empty
 	question := strings.TrimSpace(text)
 	if question == "" {
-		// Delete previous error message if it exists
-		if context.LastErrorMessageID != 0 {
-			f.deleteMessages(ctx, chatID, context.LastErrorMessageID)
-		}
-
-		// Delete invalid user input message
-		f.deleteMessages(ctx, chatID, userMessageID)
-
-		// Send error message and store its ID
-		errorMessageID, err := f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationErrorInvalidQuestion), nil)
-		if err != nil {
-			return err
-		}
-
-		// Store error message ID in context
-		context.LastErrorMessageID = errorMessageID
-
-		// Save updated context
-		state, _, err := f.storage.Get(ctx, userID)
-		if err != nil {
-			return err
-		}
-		if err := f.storage.Set(ctx, userID, state, context.ToMap()); err != nil {
-			f.logger.Error("failed to update context with error message ID", "user_id", userID, "error", err)
-			return err
-		}
+		return f.sendInputError(ctx, userID, chatID, userMessageID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorInvalidQuestion))
+	}
 
-		return nil
+	// Validate question length and content
+	if errorText := f.validateQuestion(ctx, userID, question); errorText != "" {
+		return f.sendInputError(ctx, userID, chatID, userMessageID, context, errorText)
 	}
 
 	// Store question in context
@@ -508,29 +712,16 @@ func (f *EventCreationFSM) handleQuestionInput(ctx context.Context, userID int64
 	context.LastUserMessageID = userMessageID
 
 	// Delete bot message, user message, and any previous error message
-	messagesToDelete := []int{context.LastBotMessageID, userMessageID}
-	if context.LastErrorMessageID != 0 {
-		messagesToDelete = append(messagesToDelete, context.LastErrorMessageID)
-		context.LastErrorMessageID = 0 // Clear error message ID
+	f.deleteMessages(ctx, chatID, f.stepMessagesToDelete(context, userMessageID)...)
+
+	if context.ReturnToConfirm {
+		return f.showConfirm(ctx, userID, chatID, context, StateAskQuestion)
 	}
-	f.deleteMessages(ctx, chatID, messagesToDelete...)
 
 	// Send event type selection with inline keyboard
-	kb := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: f.localizer.MustLocalize(locale.EventTypeBinaryButton), CallbackData: "event_type:binary"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.EventTypeMultiOptionButton), CallbackData: "event_type:multi"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.EventTypeProbabilityButton), CallbackData: "event_type:probability"},
-			},
-		},
-	}
+	kb := f.buildEventTypeKeyboard(ctx, context.GroupID, question)
 
-	messageID, err := f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationSelectType), kb)
+	messageID, err := f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationSelectType), kb, false)
 	if err != nil {
 		return err
 	}
@@ -549,19 +740,197 @@ func (f *EventCreationFSM) handleQuestionInput(ctx context.Context, userID int64
 	return nil
 }
 
+// buildEventTypeKeyboard builds the event type buttons. The group's default event type,
+// or else the type suggested by the wording of the question, is listed first and marked
+// so it can be picked with one tap.
+func (f *EventCreationFSM) buildEventTypeKeyboard(ctx context.Context, groupID int64, question string) *models.InlineKeyboardMarkup {
+	types := []struct {
+		eventType domain.EventType
+		labelKey  string
+	}{
+		{domain.EventTypeBinary, locale.EventTypeBinaryButton},
+		{domain.EventTypeMultiOption, locale.EventTypeMultiOptionButton},
+		{domain.EventTypeProbability, locale.EventTypeProbabilityButton},
+	}
+
+	var defaultType domain.EventType
+	if f.groupRepo != nil {
+		group, err := f.groupRepo.GetGroup(ctx, groupID)
+		if err != nil {
+			f.logger.Warn("failed to get group default event type", "group_id", groupID, "error", err)
+		} else if group != nil && group.DefaultEventType.IsValid() {
+			defaultType = group.DefaultEventType
+		}
+	}
+
+	markKey := locale.EventTypeDefaultButton
+	if defaultType == "" {
+		defaultType = domain.SuggestEventType(question)
+		markKey = locale.EventTypeSuggestedButton
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, t := range types {
+		button := models.InlineKeyboardButton{
+			Text:         localizeDynamic(f.requestLocalizer(ctx), f.logger, t.labelKey),
+			CallbackData: mustEncodeCallback(cbEventType, eventTypeCallbackValue(t.eventType)),
+		}
+		if t.eventType == defaultType {
+			button.Text = f.requestLocalizer(ctx).MustLocalizeWithTemplate(markKey, button.Text)
+			buttons = append([][]models.InlineKeyboardButton{{button}}, buttons...)
+			continue
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{button})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// validateQuestion checks the question against the configured length limits and content validator.
+// Returns a localized error message, or an empty string if the question is acceptable.
+func (f *EventCreationFSM) validateQuestion(ctx context.Context, userID int64, question string) string {
+	if f.config != nil {
+		lengthValidator := domain.NewLengthValidator(f.config.MinQuestionLength, f.config.MaxQuestionLength)
+		switch lengthValidator.Validate(question) {
+		case domain.ErrContentTooShort:
+			f.logger.Debug("question too short", "user_id", userID, "min_length", lengthValidator.MinLength())
+			return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationErrorQuestionTooShort, strconv.Itoa(lengthValidator.MinLength()))
+		case domain.ErrContentTooLong:
+			f.logger.Debug("question too long", "user_id", userID, "max_length", lengthValidator.MaxLength())
+			return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationErrorQuestionTooLong, strconv.Itoa(lengthValidator.MaxLength()))
+		}
+	}
+
+	if f.contentValidator != nil {
+		if err := f.contentValidator.Validate(question); err != nil {
+			f.logger.Info("question rejected by content validator", "user_id", userID, "error", err)
+			return f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorQuestionRejected)
+		}
+	}
+
+	return ""
+}
+
+// validateOptions checks parsed options for blank lines and repeats.
+// Returns the localized error text listing the valid options, or empty string if the options are valid.
+func (f *EventCreationFSM) validateOptions(ctx context.Context, userID int64, options []string) string {
+	errorText := optionsErrorText(f.requestLocalizer(ctx), options)
+	if errorText != "" {
+		f.logger.Debug("options rejected", "user_id", userID, "error", domain.ValidateOptions(options))
+	}
+	return errorText
+}
+
+// optionsErrorText returns the localized error for blank or repeated options listing the valid ones,
+// or empty string if the options are valid
+func optionsErrorText(localizer locale.Localizer, options []string) string {
+	switch domain.ValidateOptions(options) {
+	case nil:
+		return ""
+	case domain.ErrEmptyOption:
+		return localizer.MustLocalizeWithTemplate(locale.EventCreationErrorEmptyOption, strings.Join(domain.CleanOptions(options), "\n"))
+	case domain.ErrOptionTooLong:
+		var fitting []string
+		for _, opt := range domain.CleanOptions(options) {
+			if !domain.IsOptionTooLong(opt) {
+				fitting = append(fitting, opt)
+			}
+		}
+		return localizer.MustLocalizeWithTemplate(locale.EventCreationErrorOptionTooLong,
+			truncateOption(firstTooLongOption(options)),
+			strconv.Itoa(domain.MaxPollOptionLength),
+			strings.Join(fitting, "\n"))
+	default:
+		return localizer.MustLocalizeWithTemplate(locale.EventCreationErrorDuplicateOption, firstDuplicateOption(options), strings.Join(domain.CleanOptions(options), "\n"))
+	}
+}
+
+// firstDuplicateOption returns the first option repeating an earlier one (ignoring case and surrounding whitespace)
+func firstDuplicateOption(options []string) string {
+	seen := make(map[string]bool, len(options))
+	for _, opt := range options {
+		opt = strings.TrimSpace(opt)
+		key := strings.ToLower(opt)
+		if seen[key] {
+			return opt
+		}
+		seen[key] = true
+	}
+	return ""
+}
+
+// optionTooLongPreviewLength is the number of characters of an over-limit option quoted in the error
+const optionTooLongPreviewLength = 30
+
+// firstTooLongOption returns the first option exceeding the poll option limit
+func firstTooLongOption(options []string) string {
+	for _, opt := range options {
+		if domain.IsOptionTooLong(opt) {
+			return strings.TrimSpace(opt)
+		}
+	}
+	return ""
+}
+
+// truncateOption shortens an over-limit option for quoting in an error message
+func truncateOption(option string) string {
+	runes := []rune(option)
+	if len(runes) <= optionTooLongPreviewLength {
+		return option
+	}
+	return strings.TrimSpace(string(runes[:optionTooLongPreviewLength])) + "…"
+}
+
+// sendInputError deletes the invalid user input and the previous error message,
+// sends a new error message and stores its ID in the session context
+func (f *EventCreationFSM) sendInputError(ctx context.Context, userID int64, chatID int64, userMessageID int, context *domain.EventCreationContext, errorText string) error {
+	// Delete previous error message if it exists
+	if context.LastErrorMessageID != 0 {
+		f.deleteMessages(ctx, chatID, context.LastErrorMessageID)
+	}
+
+	// Delete invalid user input message
+	f.deleteMessages(ctx, chatID, userMessageID)
+
+	// Send error message and store its ID
+	errorMessageID, err := f.sendMessage(ctx, chatID, errorText, nil)
+	if err != nil {
+		return err
+	}
+
+	// Store error message ID in context
+	context.LastErrorMessageID = errorMessageID
+
+	// Save updated context
+	state, _, err := f.storage.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := f.storage.Set(ctx, userID, state, context.ToMap()); err != nil {
+		f.logger.Error("failed to update context with error message ID", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // handleEventTypeCallback processes the event type selection
-func (f *EventCreationFSM) handleEventTypeCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, context *domain.EventCreationContext) error {
+func (f *EventCreationFSM) handleEventTypeCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
 	// Answer callback query to remove loading state
 	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 		CallbackQueryID: callback.ID,
 	})
 
 	// Parse event type from callback data
-	eventType := strings.TrimPrefix(callback.Data, "event_type:")
+	eventType, _ := cb.Field(0)
 
-	// Delete bot message
+	// Delete bot message (kept and edited in compact mode)
 	if callback.Message.Message != nil {
-		f.deleteMessages(ctx, callback.Message.Message.Chat.ID, callback.Message.Message.ID)
+		if context.CompactMode {
+			context.LastBotMessageID = callback.Message.Message.ID
+		} else {
+			f.deleteMessages(ctx, callback.Message.Message.Chat.ID, callback.Message.Message.ID)
+		}
 	}
 
 	var nextState string
@@ -573,29 +942,29 @@ func (f *EventCreationFSM) handleEventTypeCallback(ctx context.Context, userID i
 	case "binary":
 		context.EventType = domain.EventTypeBinary
 		context.Options = []string{
-			f.localizer.MustLocalize(locale.EventOptionYes),
-			f.localizer.MustLocalize(locale.EventOptionNo),
+			f.requestLocalizer(ctx).MustLocalize(locale.EventOptionYes),
+			f.requestLocalizer(ctx).MustLocalize(locale.EventOptionNo),
 		}
 		nextState = StateAskDeadline
-		messageText = f.localizer.MustLocalize(locale.EventCreationTypeBinarySelected) + "\n\n" + f.getDeadlinePromptMessage()
+		messageText = f.requestLocalizer(ctx).MustLocalize(locale.EventCreationTypeBinarySelected) + "\n\n" + f.getDeadlinePromptMessage(ctx)
 		useHTML = true
 
 	case "probability":
 		context.EventType = domain.EventTypeProbability
 		context.Options = []string{
-			f.localizer.MustLocalize(locale.EventOptionProbability0to25),
-			f.localizer.MustLocalize(locale.EventOptionProbability25to50),
-			f.localizer.MustLocalize(locale.EventOptionProbability50to75),
-			f.localizer.MustLocalize(locale.EventOptionProbability75to100),
+			f.requestLocalizer(ctx).MustLocalize(locale.EventOptionProbability0to25),
+			f.requestLocalizer(ctx).MustLocalize(locale.EventOptionProbability25to50),
+			f.requestLocalizer(ctx).MustLocalize(locale.EventOptionProbability50to75),
+			f.requestLocalizer(ctx).MustLocalize(locale.EventOptionProbability75to100),
 		}
 		nextState = StateAskDeadline
-		messageText = f.localizer.MustLocalize(locale.EventCreationTypeProbabilitySelected) + "\n\n" + f.getDeadlinePromptMessage()
+		messageText = f.requestLocalizer(ctx).MustLocalize(locale.EventCreationTypeProbabilitySelected) + "\n\n" + f.getDeadlinePromptMessage(ctx)
 		useHTML = true
 
 	case "multi":
 		context.EventType = domain.EventTypeMultiOption
 		nextState = StateAskOptions
-		messageText = f.localizer.MustLocalize(locale.EventCreationTypeMultiOptionSelected) + "\n\n" + f.localizer.MustLocalize(locale.EventCreationAskOptions)
+		messageText = f.requestLocalizer(ctx).MustLocalize(locale.EventCreationTypeMultiOptionSelected) + "\n\n" + f.requestLocalizer(ctx).MustLocalize(locale.EventCreationAskOptions)
 		useHTML = false
 
 	default:
@@ -605,20 +974,22 @@ func (f *EventCreationFSM) handleEventTypeCallback(ctx context.Context, userID i
 
 	// Send next message
 	chatID := callback.Message.Message.Chat.ID
+
+	// Reopened from the preview: binary and probability events need no more input
+	if context.ReturnToConfirm && nextState == StateAskDeadline {
+		return f.confirmAfterOptionsChange(ctx, userID, chatID, context, StateAskEventType)
+	}
+
 	var messageID int
 	var err error
 	var replyMarkup models.ReplyMarkup
 
 	// Add deadline preset buttons for states that need deadline
 	if nextState == StateAskDeadline {
-		replyMarkup = f.getDeadlinePresetKeyboard()
+		replyMarkup = f.getDeadlinePresetKeyboard(ctx)
 	}
 
-	if useHTML {
-		messageID, err = f.sendMessageHTML(ctx, chatID, messageText, replyMarkup)
-	} else {
-		messageID, err = f.sendMessage(ctx, chatID, messageText, replyMarkup)
-	}
+	messageID, err = f.showStep(ctx, chatID, context, messageText, replyMarkup, useHTML)
 	if err != nil {
 		return err
 	}
@@ -650,7 +1021,7 @@ func (f *EventCreationFSM) handleOptionsInput(ctx context.Context, userID int64,
 		f.deleteMessages(ctx, chatID, userMessageID)
 
 		// Send error message and store its ID
-		errorMessageID, err := f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationErrorInvalidOptions), nil)
+		errorMessageID, err := f.sendMessage(ctx, chatID, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorInvalidOptions), nil)
 		if err != nil {
 			return err
 		}
@@ -673,13 +1044,10 @@ func (f *EventCreationFSM) handleOptionsInput(ctx context.Context, userID int64,
 
 	// Parse options (one per line)
 	options := strings.Split(optionsText, "\n")
-	var cleanOptions []string
-	for _, opt := range options {
-		opt = strings.TrimSpace(opt)
-		if opt != "" {
-			cleanOptions = append(cleanOptions, opt)
-		}
+	if errorText := f.validateOptions(ctx, userID, options); errorText != "" {
+		return f.sendInputError(ctx, userID, chatID, userMessageID, context, errorText)
 	}
+	cleanOptions := domain.CleanOptions(options)
 
 	// Validate options count (2-6)
 	if len(cleanOptions) < 2 || len(cleanOptions) > 6 {
@@ -692,7 +1060,7 @@ func (f *EventCreationFSM) handleOptionsInput(ctx context.Context, userID int64,
 		f.deleteMessages(ctx, chatID, userMessageID)
 
 		// Send error message and store its ID
-		errorMessageID, err := f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationErrorOptionsCount), nil)
+		errorMessageID, err := f.sendMessage(ctx, chatID, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorOptionsCount), nil)
 		if err != nil {
 			return err
 		}
@@ -718,15 +1086,14 @@ func (f *EventCreationFSM) handleOptionsInput(ctx context.Context, userID int64,
 	context.LastUserMessageID = userMessageID
 
 	// Delete bot message, user message, and any previous error message
-	messagesToDelete := []int{context.LastBotMessageID, userMessageID}
-	if context.LastErrorMessageID != 0 {
-		messagesToDelete = append(messagesToDelete, context.LastErrorMessageID)
-		context.LastErrorMessageID = 0 // Clear error message ID
+	f.deleteMessages(ctx, chatID, f.stepMessagesToDelete(context, userMessageID)...)
+
+	if context.ReturnToConfirm {
+		return f.confirmAfterOptionsChange(ctx, userID, chatID, context, StateAskOptions)
 	}
-	f.deleteMessages(ctx, chatID, messagesToDelete...)
 
 	// Send deadline request (with HTML for example date and preset buttons)
-	messageID, err := f.sendMessageHTML(ctx, chatID, f.getDeadlinePromptMessage(), f.getDeadlinePresetKeyboard())
+	messageID, err := f.showStep(ctx, chatID, context, f.getDeadlinePromptMessage(ctx), f.getDeadlinePresetKeyboard(ctx), true)
 	if err != nil {
 		return err
 	}
@@ -749,8 +1116,11 @@ func (f *EventCreationFSM) handleOptionsInput(ctx context.Context, userID int64,
 func (f *EventCreationFSM) handleDeadlineInput(ctx context.Context, userID int64, chatID int64, text string, userMessageID int, context *domain.EventCreationContext) error {
 	deadlineText := strings.TrimSpace(text)
 
-	// Parse deadline in the configured timezone
-	deadline, err := time.ParseInLocation("02.01.2006 15:04", deadlineText, f.config.Timezone)
+	// Parse deadline in the configured timezone: a relative phrase ("in 3 days") or an exact date
+	deadline, err := domain.ParseRelativeDeadline(deadlineText, time.Now(), f.config.Timezone)
+	if err != nil {
+		deadline, err = time.ParseInLocation("02.01.2006 15:04", deadlineText, f.config.Timezone)
+	}
 	if err != nil {
 		// Delete previous error message if it exists
 		if context.LastErrorMessageID != 0 {
@@ -764,7 +1134,7 @@ func (f *EventCreationFSM) handleDeadlineInput(ctx context.Context, userID int64
 		exampleDate := time.Now().In(f.config.Timezone).AddDate(0, 0, 7)
 		exampleDate = time.Date(exampleDate.Year(), exampleDate.Month(), exampleDate.Day(), 12, 0, 0, 0, f.config.Timezone)
 		exampleStr := exampleDate.Format("02.01.2006 15:04")
-		errorMessageID, sendErr := f.sendMessageHTML(ctx, chatID, f.localizer.MustLocalizeWithTemplate(locale.EventCreationErrorDeadlineFormat, exampleStr), nil)
+		errorMessageID, sendErr := f.sendMessageHTML(ctx, chatID, f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationErrorDeadlineFormat, exampleStr), nil)
 		if sendErr != nil {
 			return sendErr
 		}
@@ -785,8 +1155,15 @@ func (f *EventCreationFSM) handleDeadlineInput(ctx context.Context, userID int64
 		return nil
 	}
 
-	// Validate deadline is in future
-	if deadline.Before(time.Now()) {
+	// Validate deadline is in future and within the configured range
+	now := time.Now()
+	errorText := ""
+	if deadline.Before(now) {
+		errorText = f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorDeadlinePast)
+	} else if err := f.deadlineLimits().Check(now, deadline); err != nil {
+		errorText = f.deadlineRangeError(ctx, err)
+	}
+	if errorText != "" {
 		// Delete previous error message if it exists
 		if context.LastErrorMessageID != 0 {
 			f.deleteMessages(ctx, chatID, context.LastErrorMessageID)
@@ -796,7 +1173,7 @@ func (f *EventCreationFSM) handleDeadlineInput(ctx context.Context, userID int64
 		f.deleteMessages(ctx, chatID, userMessageID)
 
 		// Send error message and store its ID
-		errorMessageID, sendErr := f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationErrorDeadlinePast), nil)
+		errorMessageID, sendErr := f.sendMessage(ctx, chatID, errorText, nil)
 		if sendErr != nil {
 			return sendErr
 		}
@@ -817,284 +1194,1072 @@ func (f *EventCreationFSM) handleDeadlineInput(ctx context.Context, userID int64
 		return nil
 	}
 
-	// Store deadline in context
+	// Store deadline in context; a votes lock that no longer fits before it is dropped
 	context.Deadline = deadline
+	if context.LockVotesAt != nil && !context.LockVotesAt.Before(deadline) {
+		context.LockVotesAt = nil
+	}
 	context.LastUserMessageID = userMessageID
 
 	// Delete bot message, user message, and any previous error message
-	messagesToDelete := []int{context.LastBotMessageID, userMessageID}
-	if context.LastErrorMessageID != 0 {
-		messagesToDelete = append(messagesToDelete, context.LastErrorMessageID)
-		context.LastErrorMessageID = 0 // Clear error message ID
-	}
-	f.deleteMessages(ctx, chatID, messagesToDelete...)
+	f.deleteMessages(ctx, chatID, f.stepMessagesToDelete(context, userMessageID)...)
 
-	// Transition to poll settings
-	return f.showPollSettings(ctx, userID, chatID, context)
+	// Transition to the reminders step
+	return f.showAskReminders(ctx, userID, chatID, context)
+}
+
+// deadlineRangeError returns the localized error for a deadline outside the configured range
+func (f *EventCreationFSM) deadlineRangeError(ctx context.Context, err error) string {
+	limits := f.deadlineLimits()
+	if errors.Is(err, domain.ErrDeadlineTooFar) {
+		latest := time.Now().Add(limits.Max).In(f.config.Timezone)
+		return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationErrorDeadlineTooFar, latest.Format("02.01.2006 15:04"))
+	}
+	return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationErrorDeadlineTooSoon, domain.FormatReminderOffsets([]time.Duration{limits.Min}))
 }
 
 // getDeadlinePromptMessage returns the deadline prompt message with a dynamic example
-func (f *EventCreationFSM) getDeadlinePromptMessage() string {
+func (f *EventCreationFSM) getDeadlinePromptMessage(ctx context.Context) string {
 	// Calculate example date: current date + 7 days at 12:00
 	exampleDate := time.Now().In(f.config.Timezone).AddDate(0, 0, 7)
 	exampleDate = time.Date(exampleDate.Year(), exampleDate.Month(), exampleDate.Day(), 12, 0, 0, 0, f.config.Timezone)
 	exampleStr := exampleDate.Format("02.01.2006 15:04")
 
-	return f.localizer.MustLocalizeWithTemplate(locale.DeadlinePromptMessage, exampleStr)
+	return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DeadlinePromptMessage, exampleStr)
 }
 
-// getDeadlinePresetKeyboard returns inline keyboard with preset deadline options
-func (f *EventCreationFSM) getDeadlinePresetKeyboard() *models.InlineKeyboardMarkup {
-	return &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: f.localizer.MustLocalize(locale.DeadlinePreset1Day), CallbackData: "deadline_preset:1d"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.DeadlinePreset3Days), CallbackData: "deadline_preset:3d"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.DeadlinePreset1Week), CallbackData: "deadline_preset:7d"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.DeadlinePreset2Weeks), CallbackData: "deadline_preset:14d"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.DeadlinePreset1Month), CallbackData: "deadline_preset:30d"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.DeadlinePreset3Months), CallbackData: "deadline_preset:90d"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.DeadlinePreset6Months), CallbackData: "deadline_preset:180d"},
-			},
-			{
-				{Text: f.localizer.MustLocalize(locale.DeadlinePreset1Year), CallbackData: "deadline_preset:365d"},
-			},
-		},
-	}
+// deadlinePreset is a deadline button: the deadline is the given date offset at 12:00
+type deadlinePreset struct {
+	code                string
+	label               string
+	years, months, days int
 }
 
-// handleDeadlinePresetCallback processes the deadline preset selection
-func (f *EventCreationFSM) handleDeadlinePresetCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, context *domain.EventCreationContext) error {
-	// Answer callback query to remove loading state
-	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-		CallbackQueryID: callback.ID,
-	})
+// deadlinePresets lists the deadline buttons in order
+var deadlinePresets = []deadlinePreset{
+	{code: "1d", label: locale.DeadlinePreset1Day, days: 1},
+	{code: "3d", label: locale.DeadlinePreset3Days, days: 3},
+	{code: "7d", label: locale.DeadlinePreset1Week, days: 7},
+	{code: "14d", label: locale.DeadlinePreset2Weeks, days: 14},
+	{code: "30d", label: locale.DeadlinePreset1Month, months: 1},
+	{code: "90d", label: locale.DeadlinePreset3Months, months: 3},
+	{code: "180d", label: locale.DeadlinePreset6Months, months: 6},
+	{code: "365d", label: locale.DeadlinePreset1Year, years: 1},
+}
 
-	// Parse preset from callback data
-	preset := strings.TrimPrefix(callback.Data, "deadline_preset:")
-
-	// Calculate deadline based on preset
-	var deadline time.Time
-	now := time.Now().In(f.config.Timezone)
-
-	switch preset {
-	case "1d":
-		deadline = now.AddDate(0, 0, 1)
-	case "3d":
-		deadline = now.AddDate(0, 0, 3)
-	case "7d":
-		deadline = now.AddDate(0, 0, 7)
-	case "14d":
-		deadline = now.AddDate(0, 0, 14)
-	case "30d":
-		deadline = now.AddDate(0, 1, 0)
-	case "90d":
-		deadline = now.AddDate(0, 3, 0)
-	case "180d":
-		deadline = now.AddDate(0, 6, 0)
-	case "365d":
-		deadline = now.AddDate(1, 0, 0)
-	default:
-		f.logger.Error("unknown deadline preset", "user_id", userID, "preset", preset)
-		return fmt.Errorf("unknown deadline preset: %s", preset)
+// deadline returns the preset deadline for now, at 12:00 in loc
+func (p deadlinePreset) deadline(now time.Time, loc *time.Location) time.Time {
+	d := now.In(loc).AddDate(p.years, p.months, p.days)
+	return time.Date(d.Year(), d.Month(), d.Day(), 12, 0, 0, 0, loc)
+}
+
+// findDeadlinePreset returns the preset with the given code
+func findDeadlinePreset(code string) (deadlinePreset, bool) {
+	for _, preset := range deadlinePresets {
+		if preset.code == code {
+			return preset, true
+		}
+	}
+	return deadlinePreset{}, false
+}
+
+// deadlineLimits returns the configured range of deadlines from now
+func (f *EventCreationFSM) deadlineLimits() domain.DeadlineLimits {
+	return domain.DeadlineLimits{Min: f.config.MinDeadlineOffset, Max: f.config.MaxDeadlineOffset}
+}
+
+// getDeadlinePresetKeyboard returns inline keyboard with the preset deadline options
+// that fall within the configured deadline range
+func (f *EventCreationFSM) getDeadlinePresetKeyboard(ctx context.Context) *models.InlineKeyboardMarkup {
+	now := time.Now()
+	limits := f.deadlineLimits()
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, preset := range deadlinePresets {
+		if limits.Check(now, preset.deadline(now, f.config.Timezone)) != nil {
+			continue
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: localizeDynamic(f.requestLocalizer(ctx), f.logger, preset.label), CallbackData: mustEncodeCallback(cbDeadlinePreset, preset.code)},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// handleDeadlinePresetCallback processes the deadline preset selection
+func (f *EventCreationFSM) handleDeadlinePresetCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
+	// Parse preset from callback data
+	code, _ := cb.Field(0)
+	preset, ok := findDeadlinePreset(code)
+	if !ok {
+		_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		f.logger.Error("unknown deadline preset", "user_id", userID, "preset", code)
+		return fmt.Errorf("unknown deadline preset: %s", code)
+	}
+
+	// Calculate deadline based on preset; a stale keyboard may offer a preset out of range
+	deadline := preset.deadline(time.Now(), f.config.Timezone)
+	if err := f.deadlineLimits().Check(time.Now(), deadline); err != nil {
+		_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            f.deadlineRangeError(ctx, err),
+			ShowAlert:       true,
+		})
+		return nil
+	}
+
+	// Answer callback query to remove loading state
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	// Store deadline in context; a votes lock that no longer fits before it is dropped
+	context.Deadline = deadline
+	if context.LockVotesAt != nil && !context.LockVotesAt.Before(deadline) {
+		context.LockVotesAt = nil
+	}
+
+	// Delete bot message (kept and edited in compact mode)
+	if callback.Message.Message != nil {
+		if context.CompactMode {
+			context.LastBotMessageID = callback.Message.Message.ID
+		} else {
+			f.deleteMessages(ctx, callback.Message.Message.Chat.ID, callback.Message.Message.ID)
+		}
+	}
+
+	chatID := callback.Message.Message.Chat.ID
+
+	// Transition to the reminders step
+	return f.showAskReminders(ctx, userID, chatID, context)
+}
+
+// showAskReminders offers reminder schedules that fit before the deadline and transitions to StateAskReminders
+func (f *EventCreationFSM) showAskReminders(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext) error {
+	context.ReminderOffsets = nil
+
+	messageID, err := f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventRemindersPrompt), f.buildRemindersKeyboard(ctx, context.Deadline), false)
+	if err != nil {
+		return err
+	}
+
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateAskDeadline, "new_state", StateAskReminders)
+	if err := f.storage.Set(ctx, userID, StateAskReminders, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to reminders step", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// buildRemindersKeyboard returns the default option and the presets that fit before the deadline
+func (f *EventCreationFSM) buildRemindersKeyboard(ctx context.Context, deadline time.Time) *models.InlineKeyboardMarkup {
+	buttons := [][]models.InlineKeyboardButton{
+		{
+			{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventRemindersButtonDefault), CallbackData: mustEncodeCallback(cbEventReminders, "default")},
+		},
+	}
+
+	now := time.Now()
+	for i, preset := range reminderPresets {
+		if domain.ValidateReminderOffsets(preset, now, deadline) != nil {
+			continue
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventRemindersButtonPreset, domain.FormatReminderOffsets(preset)),
+				CallbackData: mustEncodeCallback(cbEventReminders, "preset", i),
+			},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// handleRemindersInput parses custom reminder offsets sent as text, e.g. "2d 3h 30m"
+func (f *EventCreationFSM) handleRemindersInput(ctx context.Context, userID int64, chatID int64, text string, userMessageID int, context *domain.EventCreationContext) error {
+	offsets, err := domain.ParseReminderOffsets(text)
+	if err != nil {
+		return f.sendInputError(ctx, userID, chatID, userMessageID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventRemindersErrorInvalid))
+	}
+	if err := domain.ValidateReminderOffsets(offsets, time.Now(), context.Deadline); err != nil {
+		return f.sendInputError(ctx, userID, chatID, userMessageID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventRemindersErrorTooLate))
+	}
+
+	context.ReminderOffsets = offsets
+	context.LastUserMessageID = userMessageID
+
+	// Delete bot message, user message, and any previous error message
+	f.deleteMessages(ctx, chatID, f.stepMessagesToDelete(context, userMessageID)...)
+
+	if context.ReturnToConfirm {
+		return f.showConfirm(ctx, userID, chatID, context, StateAskReminders)
+	}
+
+	// Transition to the optional votes lock step
+	return f.showAskLockVotes(ctx, userID, chatID, context)
+}
+
+// handleRemindersCallback processes the default or a preset reminder schedule
+func (f *EventCreationFSM) handleRemindersCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	if callback.Message.Message == nil {
+		return nil
+	}
+	chatID := callback.Message.Message.Chat.ID
+
+	action, _ := cb.Field(0)
+	switch action {
+	case "default":
+		context.ReminderOffsets = nil
+	case "preset":
+		index, err := cb.Int(1)
+		if err != nil || index < 0 || index >= len(reminderPresets) {
+			f.logger.Error("invalid reminders callback", "user_id", userID, "data", cb.String(), "error", err)
+			return nil
+		}
+		context.ReminderOffsets = reminderPresets[index]
+	default:
+		f.logger.Error("unknown reminders action", "user_id", userID, "action", action)
+		return nil
+	}
+
+	// Delete the prompt and any error message (the prompt is kept and edited in compact mode)
+	if context.CompactMode {
+		context.LastBotMessageID = callback.Message.Message.ID
+	} else {
+		f.deleteMessages(ctx, chatID, callback.Message.Message.ID)
+	}
+	if context.LastErrorMessageID != 0 {
+		f.deleteMessages(ctx, chatID, context.LastErrorMessageID)
+		context.LastErrorMessageID = 0
+	}
+
+	if context.ReturnToConfirm {
+		return f.showConfirm(ctx, userID, chatID, context, StateAskReminders)
+	}
+
+	// Transition to the optional votes lock step
+	return f.showAskLockVotes(ctx, userID, chatID, context)
+}
+
+// showAskLockVotes offers locking votes before the deadline and transitions to StateAskLockVotes
+func (f *EventCreationFSM) showAskLockVotes(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext) error {
+	context.LockVotesAt = nil
+
+	deadline := context.Deadline.In(f.config.Timezone).Format("02.01.2006 15:04")
+	messageID, err := f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventLockVotesPrompt, deadline), f.buildLockVotesKeyboard(ctx, context.Deadline), false)
+	if err != nil {
+		return err
+	}
+
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateAskReminders, "new_state", StateAskLockVotes)
+	if err := f.storage.Set(ctx, userID, StateAskLockVotes, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to votes lock step", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// buildLockVotesKeyboard returns the no-lock option and the presets that fit between now and the deadline
+func (f *EventCreationFSM) buildLockVotesKeyboard(ctx context.Context, deadline time.Time) *models.InlineKeyboardMarkup {
+	buttons := [][]models.InlineKeyboardButton{
+		{
+			{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventLockVotesButtonNone), CallbackData: mustEncodeCallback(cbEventLockVotes, "none")},
+		},
+	}
+
+	now := time.Now()
+	for i, preset := range lockVotesPresets {
+		if domain.ValidateLockVotesAt(deadline.Add(-preset), now, deadline) != nil {
+			continue
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventLockVotesButtonPreset, domain.FormatReminderOffsets([]time.Duration{preset})),
+				CallbackData: mustEncodeCallback(cbEventLockVotes, "preset", i),
+			},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// handleLockVotesInput parses a votes lock time sent as text, either a relative phrase or an exact date
+func (f *EventCreationFSM) handleLockVotesInput(ctx context.Context, userID int64, chatID int64, text string, userMessageID int, context *domain.EventCreationContext) error {
+	lockText := strings.TrimSpace(text)
+	now := time.Now()
+
+	lockVotesAt, err := domain.ParseRelativeDeadline(lockText, now, f.config.Timezone)
+	if err != nil {
+		lockVotesAt, err = time.ParseInLocation("02.01.2006 15:04", lockText, f.config.Timezone)
+	}
+	if err != nil {
+		return f.sendInputError(ctx, userID, chatID, userMessageID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventLockVotesErrorFormat))
+	}
+	if err := domain.ValidateLockVotesAt(lockVotesAt, now, context.Deadline); err != nil {
+		deadline := context.Deadline.In(f.config.Timezone).Format("02.01.2006 15:04")
+		return f.sendInputError(ctx, userID, chatID, userMessageID, context, f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventLockVotesErrorRange, deadline))
+	}
+
+	context.LockVotesAt = &lockVotesAt
+	context.LastUserMessageID = userMessageID
+
+	// Delete bot message, user message, and any previous error message
+	f.deleteMessages(ctx, chatID, f.stepMessagesToDelete(context, userMessageID)...)
+
+	if context.ReturnToConfirm {
+		return f.showConfirm(ctx, userID, chatID, context, StateAskLockVotes)
+	}
+
+	// Transition to the optional photo step
+	return f.showAskPhoto(ctx, userID, chatID, context)
+}
+
+// handleLockVotesCallback processes keeping votes open until the deadline or a preset lock time
+func (f *EventCreationFSM) handleLockVotesCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	if callback.Message.Message == nil {
+		return nil
+	}
+	chatID := callback.Message.Message.Chat.ID
+
+	action, _ := cb.Field(0)
+	switch action {
+	case "none":
+		context.LockVotesAt = nil
+	case "preset":
+		index, err := cb.Int(1)
+		if err != nil || index < 0 || index >= len(lockVotesPresets) {
+			f.logger.Error("invalid votes lock callback", "user_id", userID, "data", cb.String(), "error", err)
+			return nil
+		}
+		lockVotesAt := context.Deadline.Add(-lockVotesPresets[index])
+		if domain.ValidateLockVotesAt(lockVotesAt, time.Now(), context.Deadline) != nil {
+			// The preset no longer fits, e.g. the prompt was left open for a while
+			return f.showAskLockVotes(ctx, userID, chatID, context)
+		}
+		context.LockVotesAt = &lockVotesAt
+	default:
+		f.logger.Error("unknown votes lock action", "user_id", userID, "action", action)
+		return nil
+	}
+
+	// Delete the prompt and any error message (the prompt is kept and edited in compact mode)
+	if context.CompactMode {
+		context.LastBotMessageID = callback.Message.Message.ID
+	} else {
+		f.deleteMessages(ctx, chatID, callback.Message.Message.ID)
+	}
+	if context.LastErrorMessageID != 0 {
+		f.deleteMessages(ctx, chatID, context.LastErrorMessageID)
+		context.LastErrorMessageID = 0
+	}
+
+	if context.ReturnToConfirm {
+		return f.showConfirm(ctx, userID, chatID, context, StateAskLockVotes)
+	}
+
+	// Transition to the optional photo step
+	return f.showAskPhoto(ctx, userID, chatID, context)
+}
+
+// showAskPhoto asks for an optional event photo and transitions to StateAskPhoto
+func (f *EventCreationFSM) showAskPhoto(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext) error {
+	context.PhotoFileID = ""
+
+	kb := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventPhotoButtonSkip), CallbackData: mustEncodeCallback(cbEventPhoto, "skip")},
+			},
+		},
+	}
+
+	messageID, err := f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventPhotoPrompt), kb, false)
+	if err != nil {
+		return err
+	}
+
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateAskLockVotes, "new_state", StateAskPhoto)
+	if err := f.storage.Set(ctx, userID, StateAskPhoto, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to photo step", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// handlePhotoInput stores the photo sent for the event; text messages get a hint to send a photo or skip
+func (f *EventCreationFSM) handlePhotoInput(ctx context.Context, userID int64, chatID int64, message *models.Message, context *domain.EventCreationContext) error {
+	fileID := largestPhotoFileID(message)
+	if fileID == "" {
+		return f.sendInputError(ctx, userID, chatID, message.ID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventPhotoErrorNotPhoto))
+	}
+
+	context.PhotoFileID = fileID
+	context.LastUserMessageID = message.ID
+
+	// Delete bot message, user message, and any previous error message
+	f.deleteMessages(ctx, chatID, f.stepMessagesToDelete(context, message.ID)...)
+
+	f.logger.Info("event photo attached", "user_id", userID)
+
+	if context.ReturnToConfirm {
+		return f.showConfirm(ctx, userID, chatID, context, StateAskPhoto)
+	}
+
+	// Transition to poll settings
+	return f.showPollSettings(ctx, userID, chatID, context)
+}
+
+// handlePhotoCallback processes skipping the photo step
+func (f *EventCreationFSM) handlePhotoCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	action, _ := cb.Field(0)
+	if action != "skip" {
+		f.logger.Error("unknown photo action", "user_id", userID, "action", action)
+		return nil
+	}
+
+	if callback.Message.Message == nil {
+		return nil
+	}
+	chatID := callback.Message.Message.Chat.ID
+
+	context.PhotoFileID = ""
+
+	// Delete the prompt and any error message (the prompt is kept and edited in compact mode)
+	if context.CompactMode {
+		context.LastBotMessageID = callback.Message.Message.ID
+	} else {
+		f.deleteMessages(ctx, chatID, callback.Message.Message.ID)
+	}
+	if context.LastErrorMessageID != 0 {
+		f.deleteMessages(ctx, chatID, context.LastErrorMessageID)
+		context.LastErrorMessageID = 0
+	}
+
+	if context.ReturnToConfirm {
+		return f.showConfirm(ctx, userID, chatID, context, StateAskPhoto)
+	}
+
+	// Transition to poll settings
+	return f.showPollSettings(ctx, userID, chatID, context)
+}
+
+// showPollSettings sends the poll settings toggle keyboard and transitions to StatePollSettings
+func (f *EventCreationFSM) showPollSettings(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext) error {
+	// Set defaults for new events; settings reopened from the preview keep their values
+	if !context.ReturnToConfirm {
+		context.IsQuiz = f.config.DefaultPollType == "quiz" && context.EventType != domain.EventTypeProbability
+		context.AllowsRevoting = !context.IsQuiz
+		context.ShuffleOptions = false
+		context.HideResultsUntilClose = false
+	}
+
+	kb := f.buildPollSettingsKeyboard(ctx, context)
+
+	messageID, err := f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.PollSettingsTitle), kb, false)
+	if err != nil {
+		return err
+	}
+
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateAskPhoto, "new_state", StatePollSettings)
+	if err := f.storage.Set(ctx, userID, StatePollSettings, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to poll settings", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (f *EventCreationFSM) buildPollSettingsKeyboard(ctx context.Context, context *domain.EventCreationContext) *models.InlineKeyboardMarkup {
+	toggleIcon := func(enabled bool) string {
+		if enabled {
+			return " ✅"
+		}
+		return " ❌"
+	}
+
+	buttons := [][]models.InlineKeyboardButton{
+		{
+			{
+				Text:         f.requestLocalizer(ctx).MustLocalize(locale.PollSettingAllowsRevoting) + toggleIcon(context.AllowsRevoting),
+				CallbackData: mustEncodeCallback(cbPollSetting, "allows_revoting"),
+			},
+		},
+	}
+	// Telegram quizzes have a single correct option, which probability ranges don't
+	if context.EventType != domain.EventTypeProbability {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         f.requestLocalizer(ctx).MustLocalize(locale.PollSettingQuiz) + toggleIcon(context.IsQuiz),
+				CallbackData: mustEncodeCallback(cbPollSetting, "quiz"),
+			},
+		})
+	}
+
+	buttons = append(buttons,
+		[]models.InlineKeyboardButton{
+			{
+				Text:         f.requestLocalizer(ctx).MustLocalize(locale.PollSettingShuffleOptions) + toggleIcon(context.ShuffleOptions),
+				CallbackData: mustEncodeCallback(cbPollSetting, "shuffle_options"),
+			},
+		},
+		[]models.InlineKeyboardButton{
+			{
+				Text:         f.requestLocalizer(ctx).MustLocalize(locale.PollSettingHideResults) + toggleIcon(context.HideResultsUntilClose),
+				CallbackData: mustEncodeCallback(cbPollSetting, "hide_results"),
+			},
+		},
+		[]models.InlineKeyboardButton{
+			{
+				Text:         f.requestLocalizer(ctx).MustLocalize(locale.PollSettingDone),
+				CallbackData: mustEncodeCallback(cbPollSetting, "done"),
+			},
+		},
+	)
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+func (f *EventCreationFSM) handlePollSettingsCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	setting, _ := cb.Field(0)
+
+	switch setting {
+	case "allows_revoting":
+		context.AllowsRevoting = !context.AllowsRevoting
+		// Quiz votes are final, so revoting turns the quiz off
+		if context.AllowsRevoting {
+			context.IsQuiz = false
+		}
+	case "quiz":
+		if context.EventType == domain.EventTypeProbability {
+			return nil
+		}
+		context.IsQuiz = !context.IsQuiz
+		if context.IsQuiz {
+			context.AllowsRevoting = false
+		}
+	case "shuffle_options":
+		context.ShuffleOptions = !context.ShuffleOptions
+	case "hide_results":
+		context.HideResultsUntilClose = !context.HideResultsUntilClose
+	case "done":
+		// Transition to participant selection
+		chatID := callback.Message.Message.Chat.ID
+
+		// Delete poll settings message (kept and edited in compact mode)
+		if context.CompactMode {
+			context.LastBotMessageID = callback.Message.Message.ID
+		} else {
+			f.deleteMessages(ctx, chatID, callback.Message.Message.ID)
+		}
+
+		// Quizzes need their correct answer before going on
+		if context.IsQuiz {
+			return f.showAskQuizAnswer(ctx, userID, chatID, context)
+		}
+
+		if context.ReturnToConfirm {
+			return f.showConfirm(ctx, userID, chatID, context, StatePollSettings)
+		}
+
+		return f.showParticipants(ctx, userID, chatID, context)
+	default:
+		f.logger.Error("unknown poll setting", "user_id", userID, "setting", setting)
+		return nil
+	}
+
+	// Update keyboard with new toggle states
+	kb := f.buildPollSettingsKeyboard(ctx, context)
+	if callback.Message.Message != nil {
+		_, _ = f.bot.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:      callback.Message.Message.Chat.ID,
+			MessageID:   callback.Message.Message.ID,
+			ReplyMarkup: kb,
+		})
+	}
+
+	// Save updated context
+	if err := f.storage.Set(ctx, userID, StatePollSettings, context.ToMap()); err != nil {
+		f.logger.Error("failed to save poll settings", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// showParticipants sends the participant selection step and transitions to StateSelectParticipants.
+// Groups without other active members skip straight to confirmation.
+func (f *EventCreationFSM) showParticipants(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext) error {
+	members, err := f.activeGroupMembers(ctx, context.GroupID)
+	if err != nil {
+		f.logger.Error("failed to get group members for participant selection", "group_id", context.GroupID, "error", err)
+		_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.ParticipantsErrorMembers), nil, false)
+		// Delete session
+		_ = f.storage.Delete(ctx, userID)
+		return err
+	}
+	if len(members) == 0 {
+		return f.showConfirm(ctx, userID, chatID, context, StatePollSettings)
+	}
+
+	messageID, err := f.showStep(ctx, chatID, context, f.buildParticipantsText(ctx, context), f.buildParticipantsKeyboard(ctx, context, members, 0), false)
+	if err != nil {
+		return err
+	}
+
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StatePollSettings, "new_state", StateSelectParticipants)
+	if err := f.storage.Set(ctx, userID, StateSelectParticipants, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to participant selection", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// activeGroupMembers returns user IDs of active group members in a stable order
+func (f *EventCreationFSM) activeGroupMembers(ctx context.Context, groupID int64) ([]int64, error) {
+	memberships, err := f.groupMembershipRepo.GetGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []int64
+	for _, membership := range memberships {
+		if membership.Status == domain.MembershipStatusActive {
+			members = append(members, membership.UserID)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i] < members[j] })
+
+	return members, nil
+}
+
+// memberDisplayName returns the cached profile name of a user or "User id[UserID]" if unknown
+func (f *EventCreationFSM) memberDisplayName(ctx context.Context, userID int64) string {
+	if f.userRepo != nil {
+		profile, err := f.userRepo.GetUserProfile(ctx, userID)
+		if err != nil {
+			f.logger.Warn("failed to get user profile", "user_id", userID, "error", err)
+		} else if profile != nil {
+			if displayName := profile.DisplayName(); displayName != "" {
+				return displayName
+			}
+		}
+	}
+	return fmt.Sprintf("User id%d", userID)
+}
+
+// participantsLabel describes who can vote: everyone or the number of selected members
+func (f *EventCreationFSM) participantsLabel(ctx context.Context, context *domain.EventCreationContext) string {
+	if len(context.Participants) == 0 {
+		return f.requestLocalizer(ctx).MustLocalize(locale.ParticipantsEveryone)
+	}
+	return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ParticipantsSelectedCount, fmt.Sprintf("%d", len(context.Participants)))
+}
+
+func (f *EventCreationFSM) buildParticipantsText(ctx context.Context, context *domain.EventCreationContext) string {
+	return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ParticipantsTitle, f.participantsLabel(ctx, context))
+}
+
+func (f *EventCreationFSM) buildParticipantsKeyboard(ctx context.Context, context *domain.EventCreationContext, members []int64, page int) *models.InlineKeyboardMarkup {
+	pages := (len(members) + participantsPageSize - 1) / participantsPageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	start := page * participantsPageSize
+	end := start + participantsPageSize
+	if end > len(members) {
+		end = len(members)
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, memberID := range members[start:end] {
+		text := f.memberDisplayName(ctx, memberID)
+		if context.HasParticipant(memberID) {
+			text += " ✅"
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         text,
+				CallbackData: mustEncodeCallback(cbParticipants, "toggle", memberID, page),
+			},
+		})
 	}
 
-	// Set time to 12:00
-	deadline = time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 12, 0, 0, 0, f.config.Timezone)
+	var navRow []models.InlineKeyboardButton
+	if page > 0 {
+		navRow = append(navRow, models.InlineKeyboardButton{
+			Text:         f.requestLocalizer(ctx).MustLocalize(locale.ParticipantsButtonPrev),
+			CallbackData: mustEncodeCallback(cbParticipants, "page", page-1),
+		})
+	}
+	if page < pages-1 {
+		navRow = append(navRow, models.InlineKeyboardButton{
+			Text:         f.requestLocalizer(ctx).MustLocalize(locale.ParticipantsButtonNext),
+			CallbackData: mustEncodeCallback(cbParticipants, "page", page+1),
+		})
+	}
+	if len(navRow) > 0 {
+		buttons = append(buttons, navRow)
+	}
 
-	// Store deadline in context
-	context.Deadline = deadline
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{
+			Text:         f.requestLocalizer(ctx).MustLocalize(locale.ParticipantsButtonEveryone),
+			CallbackData: mustEncodeCallback(cbParticipants, "all"),
+		},
+		{
+			Text:         f.requestLocalizer(ctx).MustLocalize(locale.ParticipantsButtonDone),
+			CallbackData: mustEncodeCallback(cbParticipants, "done"),
+		},
+	})
 
-	// Delete bot message
-	if callback.Message.Message != nil {
-		f.deleteMessages(ctx, callback.Message.Message.Chat.ID, callback.Message.Message.ID)
-	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// handleParticipantsCallback toggles participants, switches pages and finishes the selection
+func (f *EventCreationFSM) handleParticipantsCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
 
+	if callback.Message.Message == nil {
+		return nil
+	}
 	chatID := callback.Message.Message.Chat.ID
 
-	// Transition to poll settings
-	return f.showPollSettings(ctx, userID, chatID, context)
+	action, _ := cb.Field(0)
+	page := 0
+
+	switch action {
+	case "toggle":
+		memberID, err := cb.Int64(1)
+		if err != nil {
+			f.logger.Error("invalid participant callback", "user_id", userID, "data", cb.String(), "error", err)
+			return err
+		}
+		if p, err := cb.Int(2); err == nil {
+			page = p
+		}
+		context.ToggleParticipant(memberID)
+	case "page":
+		p, err := cb.Int(1)
+		if err != nil {
+			f.logger.Error("invalid participant callback", "user_id", userID, "data", cb.String(), "error", err)
+			return err
+		}
+		page = p
+	case "all":
+		context.Participants = nil
+	case "done":
+		// Delete participant selection message (kept and edited in compact mode)
+		if context.CompactMode {
+			context.LastBotMessageID = callback.Message.Message.ID
+		} else {
+			f.deleteMessages(ctx, chatID, callback.Message.Message.ID)
+		}
+		return f.showConfirm(ctx, userID, chatID, context, StateSelectParticipants)
+	default:
+		f.logger.Error("unknown participant action", "user_id", userID, "action", action)
+		return nil
+	}
+
+	members, err := f.activeGroupMembers(ctx, context.GroupID)
+	if err != nil {
+		f.logger.Error("failed to get group members for participant selection", "group_id", context.GroupID, "error", err)
+		return err
+	}
+
+	// Update message with new selection and page
+	_, _ = f.bot.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        f.buildParticipantsText(ctx, context),
+		ReplyMarkup: f.buildParticipantsKeyboard(ctx, context, members, page),
+	})
+
+	// Save updated context
+	if err := f.storage.Set(ctx, userID, StateSelectParticipants, context.ToMap()); err != nil {
+		f.logger.Error("failed to save participants", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
 }
 
-// showPollSettings sends the poll settings toggle keyboard and transitions to StatePollSettings
-func (f *EventCreationFSM) showPollSettings(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext) error {
-	// Set defaults for new events
-	context.AllowsRevoting = true
-	context.ShuffleOptions = false
-	context.HideResultsUntilClose = false
+// showConfirm sends the poll preview and the event summary with confirmation buttons and transitions to StateConfirm
+func (f *EventCreationFSM) showConfirm(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext, oldState string) error {
+	context.ReturnToConfirm = false
+
+	// Replace any earlier preview so only the current one is shown
+	f.deleteMessages(ctx, chatID, context.PreviewMessageIDs...)
+	context.PreviewMessageIDs = f.sendEventPreview(ctx, chatID, context)
 
-	kb := f.buildPollSettingsKeyboard(context)
+	summary := f.buildEventSummary(ctx, context)
 
-	messageID, err := f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.PollSettingsTitle), kb)
+	messageID, err := f.showStep(ctx, chatID, context, summary, f.buildConfirmKeyboard(ctx, context), false)
 	if err != nil {
 		return err
 	}
 
+	context.ConfirmationMessageID = messageID
 	context.LastBotMessageID = messageID
 
-	f.logger.Info("state transition", "user_id", userID, "old_state", StateAskDeadline, "new_state", StatePollSettings)
-	if err := f.storage.Set(ctx, userID, StatePollSettings, context.ToMap()); err != nil {
-		f.logger.Error("failed to transition to poll settings", "user_id", userID, "error", err)
+	f.logger.Info("state transition", "user_id", userID, "old_state", oldState, "new_state", StateConfirm)
+	if err := f.storage.Set(ctx, userID, StateConfirm, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to confirm", "user_id", userID, "error", err)
 		return err
 	}
-
 	return nil
 }
 
-func (f *EventCreationFSM) buildPollSettingsKeyboard(context *domain.EventCreationContext) *models.InlineKeyboardMarkup {
-	toggleIcon := func(enabled bool) string {
-		if enabled {
-			return " ✅"
-		}
-		return " ❌"
+// buildConfirmKeyboard returns the confirm and cancel buttons followed by buttons reopening each step
+func (f *EventCreationFSM) buildConfirmKeyboard(ctx context.Context, context *domain.EventCreationContext) *models.InlineKeyboardMarkup {
+	steps := []struct {
+		step     string
+		labelKey string
+	}{
+		{previewStepQuestion, locale.EventPreviewEditQuestion},
+		{previewStepType, locale.EventPreviewEditType},
+		{previewStepOptions, locale.EventPreviewEditOptions},
+		{previewStepReorder, locale.EventPreviewReorderOptions},
+		{previewStepDeadline, locale.EventPreviewEditDeadline},
+		{previewStepReminders, locale.EventPreviewEditReminders},
+		{previewStepLockVotes, locale.EventPreviewEditLockVotes},
+		{previewStepPhoto, locale.EventPreviewEditPhoto},
+		{previewStepSettings, locale.EventPreviewEditSettings},
+		{previewStepParticipants, locale.EventPreviewEditParticipants},
+	}
+
+	buttons := [][]models.InlineKeyboardButton{
+		{
+			{Text: f.requestLocalizer(ctx).MustLocalize(locale.ConfirmButtonYes), CallbackData: mustEncodeCallback(cbConfirm, "yes")},
+			{Text: f.requestLocalizer(ctx).MustLocalize(locale.ConfirmButtonNo), CallbackData: mustEncodeCallback(cbConfirm, "no")},
+		},
 	}
 
-	return &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{
-					Text:         f.localizer.MustLocalize(locale.PollSettingAllowsRevoting) + toggleIcon(context.AllowsRevoting),
-					CallbackData: "poll_setting:allows_revoting",
-				},
-			},
-			{
-				{
-					Text:         f.localizer.MustLocalize(locale.PollSettingShuffleOptions) + toggleIcon(context.ShuffleOptions),
-					CallbackData: "poll_setting:shuffle_options",
-				},
-			},
-			{
-				{
-					Text:         f.localizer.MustLocalize(locale.PollSettingHideResults) + toggleIcon(context.HideResultsUntilClose),
-					CallbackData: "poll_setting:hide_results",
-				},
-			},
-			{
-				{
-					Text:         f.localizer.MustLocalize(locale.PollSettingDone),
-					CallbackData: "poll_setting:done",
-				},
-			},
-		},
+	var row []models.InlineKeyboardButton
+	for _, s := range steps {
+		// Only multi-option events have options entered by the creator
+		if s.step == previewStepOptions && context.EventType != domain.EventTypeMultiOption {
+			continue
+		}
+		// Probability ranges keep their natural order
+		if s.step == previewStepReorder && context.EventType == domain.EventTypeProbability {
+			continue
+		}
+		row = append(row, models.InlineKeyboardButton{
+			Text:         localizeDynamic(f.requestLocalizer(ctx), f.logger, s.labelKey),
+			CallbackData: mustEncodeCallback(cbConfirm, "edit", s.step),
+		})
+		if len(row) == 2 {
+			buttons = append(buttons, row)
+			row = nil
+		}
 	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
 }
 
-func (f *EventCreationFSM) handlePollSettingsCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, context *domain.EventCreationContext) error {
-	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-		CallbackQueryID: callback.ID,
-	})
+// sendEventPreview sends the photo posted before the poll (if any) and the poll as group members will see it.
+// Failures are logged and never block the confirmation. Returns the IDs of the sent messages.
+func (f *EventCreationFSM) sendEventPreview(ctx context.Context, chatID int64, context *domain.EventCreationContext) []int {
+	var messageIDs []int
+	if photoMessageID := sendEventPhoto(ctx, f.bot, f.logger, chatID, 0, context.PhotoFileID); photoMessageID != 0 {
+		messageIDs = append(messageIDs, photoMessageID)
+	}
+	if messageID, err := f.sendMessage(ctx, chatID, f.buildEventPreview(ctx, context), nil); err == nil {
+		messageIDs = append(messageIDs, messageID)
+	}
+	return messageIDs
+}
 
-	setting := strings.TrimPrefix(callback.Data, "poll_setting:")
+// buildEventPreview renders the poll content: question, options, closing time in the configured timezone and poll settings
+func (f *EventCreationFSM) buildEventPreview(ctx context.Context, context *domain.EventCreationContext) string {
+	var sb strings.Builder
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventPreviewTitle))
+	sb.WriteString("\n\n")
 
-	switch setting {
-	case "allows_revoting":
-		context.AllowsRevoting = !context.AllowsRevoting
-	case "shuffle_options":
-		context.ShuffleOptions = !context.ShuffleOptions
-	case "hide_results":
-		context.HideResultsUntilClose = !context.HideResultsUntilClose
-	case "done":
-		// Transition to confirm
-		chatID := callback.Message.Message.Chat.ID
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventPreviewQuestion, context.Question))
+	sb.WriteString("\n")
+	for _, opt := range context.Options {
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventPreviewOption, opt))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
 
-		// Delete poll settings message
-		f.deleteMessages(ctx, chatID, callback.Message.Message.ID)
+	localDeadline := context.Deadline.In(f.config.Timezone)
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventPreviewDeadline, localDeadline.Format("02.01.2006 15:04"), f.config.Timezone.String()))
+	sb.WriteString("\n")
+	if context.LockVotesAt != nil {
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventPreviewLockVotes, context.LockVotesAt.In(f.config.Timezone).Format("02.01.2006 15:04")))
+		sb.WriteString("\n")
+	}
 
-		summary := f.buildEventSummary(context)
+	if context.AllowsRevoting {
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventPreviewRevoting))
+	} else {
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventPreviewNoRevoting))
+	}
+	if context.ShuffleOptions {
+		sb.WriteString("\n")
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventPreviewShuffled))
+	}
+	if context.HideResultsUntilClose {
+		sb.WriteString("\n")
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventPreviewResultsHidden))
+	}
+	if context.IsQuiz && context.QuizAnswer < len(context.Options) {
+		sb.WriteString("\n")
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventPreviewQuiz, context.Options[context.QuizAnswer]))
+	}
 
-		kb := &models.InlineKeyboardMarkup{
-			InlineKeyboard: [][]models.InlineKeyboardButton{
-				{
-					{Text: f.localizer.MustLocalize(locale.ConfirmButtonYes), CallbackData: "confirm:yes"},
-					{Text: f.localizer.MustLocalize(locale.ConfirmButtonNo), CallbackData: "confirm:no"},
-				},
-			},
-		}
+	return sb.String()
+}
 
-		messageID, err := f.sendMessage(ctx, chatID, summary, kb)
-		if err != nil {
-			return err
-		}
+// reopenStep goes back from the confirmation to a single step, keeping everything entered so far.
+// Finishing the step shows the confirmation again.
+func (f *EventCreationFSM) reopenStep(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext, step string) error {
+	context.ReturnToConfirm = true
 
-		context.ConfirmationMessageID = messageID
-		context.LastBotMessageID = messageID
+	var nextState string
+	var messageText string
+	var replyMarkup models.ReplyMarkup
+	var useHTML bool
 
-		f.logger.Info("state transition", "user_id", userID, "old_state", StatePollSettings, "new_state", StateConfirm)
-		if err := f.storage.Set(ctx, userID, StateConfirm, context.ToMap()); err != nil {
-			f.logger.Error("failed to transition to confirm", "user_id", userID, "error", err)
-			return err
-		}
-		return nil
+	switch step {
+	case previewStepQuestion:
+		nextState = StateAskQuestion
+		messageText = f.requestLocalizer(ctx).MustLocalize(locale.EventCreationAskQuestion)
+	case previewStepType:
+		nextState = StateAskEventType
+		messageText = f.requestLocalizer(ctx).MustLocalize(locale.EventCreationSelectType)
+		replyMarkup = f.buildEventTypeKeyboard(ctx, context.GroupID, context.Question)
+	case previewStepOptions:
+		nextState = StateAskOptions
+		messageText = f.requestLocalizer(ctx).MustLocalize(locale.EventCreationAskOptions)
+	case previewStepReorder:
+		return f.showReorderOptions(ctx, userID, chatID, context)
+	case previewStepDeadline:
+		// A new deadline may invalidate the reminders, so they are asked again after it
+		nextState = StateAskDeadline
+		messageText = f.getDeadlinePromptMessage(ctx)
+		replyMarkup = f.getDeadlinePresetKeyboard(ctx)
+		useHTML = true
+	case previewStepReminders:
+		return f.showAskReminders(ctx, userID, chatID, context)
+	case previewStepLockVotes:
+		return f.showAskLockVotes(ctx, userID, chatID, context)
+	case previewStepPhoto:
+		return f.showAskPhoto(ctx, userID, chatID, context)
+	case previewStepSettings:
+		return f.showPollSettings(ctx, userID, chatID, context)
+	case previewStepParticipants:
+		return f.showParticipants(ctx, userID, chatID, context)
 	default:
-		f.logger.Error("unknown poll setting", "user_id", userID, "setting", setting)
-		return nil
+		f.logger.Warn("unknown preview step", "user_id", userID, "step", step)
+		return f.showConfirm(ctx, userID, chatID, context, StateConfirm)
 	}
 
-	// Update keyboard with new toggle states
-	kb := f.buildPollSettingsKeyboard(context)
-	if callback.Message.Message != nil {
-		_, _ = f.bot.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
-			ChatID:      callback.Message.Message.Chat.ID,
-			MessageID:   callback.Message.Message.ID,
-			ReplyMarkup: kb,
-		})
+	messageID, err := f.showStep(ctx, chatID, context, messageText, replyMarkup, useHTML)
+	if err != nil {
+		return err
 	}
 
-	// Save updated context
-	if err := f.storage.Set(ctx, userID, StatePollSettings, context.ToMap()); err != nil {
-		f.logger.Error("failed to save poll settings", "user_id", userID, "error", err)
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateConfirm, "new_state", nextState)
+	if err := f.storage.Set(ctx, userID, nextState, context.ToMap()); err != nil {
+		f.logger.Error("failed to reopen step", "user_id", userID, "step", step, "error", err)
 		return err
 	}
-
 	return nil
 }
 
 // buildEventSummary creates a summary message with all event details (for confirmation)
-func (f *EventCreationFSM) buildEventSummary(context *domain.EventCreationContext) string {
+func (f *EventCreationFSM) buildEventSummary(ctx context.Context, context *domain.EventCreationContext) string {
 	var sb strings.Builder
-	sb.WriteString(f.localizer.MustLocalize(locale.EventSummaryTitle))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryTitle))
 	sb.WriteString("\n\n")
 
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryQuestion, context.Question))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryQuestion, context.Question))
 	sb.WriteString("\n\n")
 
 	// Event type
 	typeStr := ""
 	switch context.EventType {
 	case domain.EventTypeBinary:
-		typeStr = f.localizer.MustLocalize(locale.EventTypeBinaryLabel)
+		typeStr = f.requestLocalizer(ctx).MustLocalize(locale.EventTypeBinaryLabel)
 	case domain.EventTypeMultiOption:
-		typeStr = f.localizer.MustLocalize(locale.EventTypeMultiOptionLabel)
+		typeStr = f.requestLocalizer(ctx).MustLocalize(locale.EventTypeMultiOptionLabel)
 	case domain.EventTypeProbability:
-		typeStr = f.localizer.MustLocalize(locale.EventTypeProbabilityLabel)
+		typeStr = f.requestLocalizer(ctx).MustLocalize(locale.EventTypeProbabilityLabel)
 	}
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryType, typeStr))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryType, typeStr))
 	sb.WriteString("\n\n")
 
 	// Options
-	sb.WriteString(f.localizer.MustLocalize(locale.EventSummaryOptions))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryOptions))
 	sb.WriteString("\n")
 	for i, opt := range context.Options {
-		sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.OptionListItem, fmt.Sprintf("%d", i+1), opt))
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.OptionListItem, fmt.Sprintf("%d", i+1), opt))
 		sb.WriteString("\n")
 	}
 	sb.WriteString("\n")
 
 	// Deadline
 	localDeadline := context.Deadline.In(f.config.Timezone)
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryDeadline, localDeadline.Format("02.01.2006 15:04")))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryDeadline, localDeadline.Format("02.01.2006 15:04")))
+	sb.WriteString("\n")
+
+	// Reminders
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryReminders, f.remindersLabel(ctx, context.ReminderOffsets)))
+	sb.WriteString("\n")
+
+	// Votes lock
+	lockLabel := f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryLockVotesNone)
+	if context.LockVotesAt != nil {
+		lockLabel = context.LockVotesAt.In(f.config.Timezone).Format("02.01.2006 15:04")
+	}
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryLockVotes, lockLabel))
 	sb.WriteString("\n\n")
 
 	// Poll settings
@@ -1104,59 +2269,83 @@ func (f *EventCreationFSM) buildEventSummary(context *domain.EventCreationContex
 		}
 		return "❌"
 	}
-	sb.WriteString(f.localizer.MustLocalize(locale.EventSummaryPollSettings))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryPollSettings))
 	sb.WriteString("\n")
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryAllowsRevoting, yesNo(context.AllowsRevoting)))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryAllowsRevoting, yesNo(context.AllowsRevoting)))
 	sb.WriteString("\n")
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryShuffleOptions, yesNo(context.ShuffleOptions)))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryShuffleOptions, yesNo(context.ShuffleOptions)))
 	sb.WriteString("\n")
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryHideResults, yesNo(context.HideResultsUntilClose)))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryHideResults, yesNo(context.HideResultsUntilClose)))
+	sb.WriteString("\n")
+	if context.IsQuiz && context.QuizAnswer < len(context.Options) {
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryQuiz, context.Options[context.QuizAnswer]))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryAutoClose))
+	sb.WriteString("\n\n")
+
+	// Participants
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryParticipants, f.participantsLabel(ctx, context)))
 	sb.WriteString("\n")
-	sb.WriteString(f.localizer.MustLocalize(locale.EventSummaryAutoClose))
+
+	// Photo
+	photoLabel := f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryPhotoNone)
+	if context.PhotoFileID != "" {
+		photoLabel = f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryPhotoAttached)
+	}
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryPhoto, photoLabel))
 	sb.WriteString("\n\n")
 
 	return sb.String()
 }
 
+// remindersLabel describes the reminder schedule: the default or the custom offsets
+func (f *EventCreationFSM) remindersLabel(ctx context.Context, offsets []time.Duration) string {
+	if len(offsets) == 0 {
+		return f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryRemindersDefault)
+	}
+	return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryRemindersCustom, domain.FormatReminderOffsets(offsets))
+}
+
 // buildFinalEventSummary creates a final summary message with event ID and poll reference
-func (f *EventCreationFSM) buildFinalEventSummary(event *domain.Event, pollReference string) string {
+func (f *EventCreationFSM) buildFinalEventSummary(ctx context.Context, event *domain.Event, pollReference string) string {
 	var sb strings.Builder
-	sb.WriteString(f.localizer.MustLocalize(locale.EventFinalSummaryTitle))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventFinalSummaryTitle))
 	sb.WriteString("\n\n")
 
 	// Event ID
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventFinalSummaryID, fmt.Sprintf("%d", event.ID)))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventFinalSummaryID, fmt.Sprintf("%d", event.ID)))
 	sb.WriteString("\n\n")
 
 	// Question
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryQuestion, event.Question))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryQuestion, event.Question))
 	sb.WriteString("\n\n")
 
 	// Event type
 	typeStr := ""
 	switch event.EventType {
 	case domain.EventTypeBinary:
-		typeStr = f.localizer.MustLocalize(locale.EventTypeBinaryLabel)
+		typeStr = f.requestLocalizer(ctx).MustLocalize(locale.EventTypeBinaryLabel)
 	case domain.EventTypeMultiOption:
-		typeStr = f.localizer.MustLocalize(locale.EventTypeMultiOptionLabel)
+		typeStr = f.requestLocalizer(ctx).MustLocalize(locale.EventTypeMultiOptionLabel)
 	case domain.EventTypeProbability:
-		typeStr = f.localizer.MustLocalize(locale.EventTypeProbabilityLabel)
+		typeStr = f.requestLocalizer(ctx).MustLocalize(locale.EventTypeProbabilityLabel)
 	}
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryType, typeStr))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryType, typeStr))
 	sb.WriteString("\n\n")
 
 	// Options
-	sb.WriteString(f.localizer.MustLocalize(locale.EventSummaryOptions))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryOptions))
 	sb.WriteString("\n")
 	for i, opt := range event.Options {
-		sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.OptionListItem, fmt.Sprintf("%d", i+1), opt))
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.OptionListItem, fmt.Sprintf("%d", i+1), opt))
 		sb.WriteString("\n")
 	}
 	sb.WriteString("\n")
 
 	// Deadline (formatted in configured timezone)
 	localDeadline := event.Deadline.In(f.config.Timezone)
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryDeadline, localDeadline.Format("02.01.2006 15:04")))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryDeadline, localDeadline.Format("02.01.2006 15:04")))
 	sb.WriteString("\n\n")
 
 	// Poll reference
@@ -1168,22 +2357,36 @@ func (f *EventCreationFSM) buildFinalEventSummary(event *domain.Event, pollRefer
 }
 
 // handleConfirmCallback processes the confirmation or cancellation
-func (f *EventCreationFSM) handleConfirmCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, context *domain.EventCreationContext) error {
+func (f *EventCreationFSM) handleConfirmCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
 	// Answer callback query to remove loading state
 	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 		CallbackQueryID: callback.ID,
 	})
 
 	chatID := callback.Message.Message.Chat.ID
-	action := strings.TrimPrefix(callback.Data, "confirm:")
+	action, _ := cb.Field(0)
 
-	// Delete the confirmation message (with buttons)
+	// Remove the preview; it is sent again when the creator comes back to the confirmation
+	f.deleteMessages(ctx, chatID, context.PreviewMessageIDs...)
+	context.PreviewMessageIDs = nil
+
+	// Delete the confirmation message (with buttons); in compact mode the outcome is edited into it
 	if context.ConfirmationMessageID != 0 {
-		f.deleteMessages(ctx, chatID, context.ConfirmationMessageID)
+		if context.CompactMode {
+			context.LastBotMessageID = context.ConfirmationMessageID
+		} else {
+			f.deleteMessages(ctx, chatID, context.ConfirmationMessageID)
+		}
+	}
+
+	if action == "edit" {
+		step, _ := cb.Field(1)
+		return f.reopenStep(ctx, userID, chatID, context, step)
 	}
 
-	if action == "yes" {
-		// Create the event
+	// "duplicate" confirms an event after the warning about a similar active one
+	if action == "yes" || action == "duplicate" {
+		// Build the event; it is persisted only after the poll is published
 		event := &domain.Event{
 			GroupID:               context.GroupID,
 			Question:              context.Question,
@@ -1196,11 +2399,19 @@ func (f *EventCreationFSM) handleConfirmCallback(ctx context.Context, userID int
 			AllowsRevoting:        context.AllowsRevoting,
 			ShuffleOptions:        context.ShuffleOptions,
 			HideResultsUntilClose: context.HideResultsUntilClose,
+			Participants:          context.Participants,
+			PhotoFileID:           context.PhotoFileID,
+			ReminderOffsets:       context.ReminderOffsets,
+			LockVotesAt:           context.LockVotesAt,
+		}
+		if context.IsQuiz {
+			quizAnswer := context.QuizAnswer
+			event.QuizAnswer = &quizAnswer
 		}
 
-		if err := f.eventManager.CreateEvent(ctx, event); err != nil {
+		if err := event.Validate(); err != nil {
 			f.logger.Error("failed to create event", "user_id", userID, "error", err)
-			_, _ = f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationErrorGeneric), nil)
+			_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorGeneric), nil, false)
 			// Delete session
 			_ = f.storage.Delete(ctx, userID)
 			return err
@@ -1210,116 +2421,81 @@ func (f *EventCreationFSM) handleConfirmCallback(ctx context.Context, userID int
 		group, err := f.groupRepo.GetGroup(ctx, context.GroupID)
 		if err != nil {
 			f.logger.Error("failed to get group for poll", "group_id", context.GroupID, "error", err)
-			_, _ = f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationErrorGroupInfo), nil)
+			_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorGroupInfo), nil, false)
 			// Delete session
 			_ = f.storage.Delete(ctx, userID)
 			return err
 		}
-
-		// Publish poll to group using Telegram chat ID
-		pollOptions := make([]models.InputPollOption, len(event.Options))
-		for i, opt := range event.Options {
-			pollOptions[i] = models.InputPollOption{Text: opt}
+		if group == nil {
+			f.logger.Error("group for poll not found", "group_id", context.GroupID)
+			_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorGroupInfo), nil, false)
+			// Delete session
+			_ = f.storage.Delete(ctx, userID)
+			return fmt.Errorf("group %d not found", context.GroupID)
+		}
+		// The group may have been paused while the event was being created
+		if group.Status == domain.GroupStatusPaused {
+			f.logger.Info("event creation rejected: group paused", "user_id", userID, "group_id", group.ID)
+			_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupPausedCreateRejected, group.Name), nil, false)
+			// Delete session
+			_ = f.storage.Delete(ctx, userID)
+			return nil
 		}
 
-		// Handle forum topic if MessageThreadID is provided
-		var messageThreadID *int
-		if context.MessageThreadID != nil {
-			messageThreadID = context.MessageThreadID
-
-			// Find or create forum topic
-			topic, err := f.forumTopicRepo.GetForumTopicByGroupAndThread(ctx, context.GroupID, *context.MessageThreadID)
-			if err != nil {
-				f.logger.Error("failed to get forum topic", "group_id", context.GroupID, "message_thread_id", *context.MessageThreadID, "error", err)
-			} else if topic == nil {
-				// Create new forum topic
-				topic = &domain.ForumTopic{
-					GroupID:         context.GroupID,
-					MessageThreadID: *context.MessageThreadID,
-					Name:            fmt.Sprintf("Topic %d", *context.MessageThreadID),
-					CreatedAt:       time.Now(),
-					CreatedBy:       userID,
-				}
-				if err := f.forumTopicRepo.CreateForumTopic(ctx, topic); err != nil {
-					f.logger.Error("failed to create forum topic", "error", err)
-				} else {
-					event.ForumTopicID = &topic.ID
-					f.logger.Info("forum topic created for event", "topic_id", topic.ID, "message_thread_id", *context.MessageThreadID)
-				}
-			} else {
-				event.ForumTopicID = &topic.ID
-				f.logger.Info("using existing forum topic for event", "topic_id", topic.ID, "message_thread_id", *context.MessageThreadID)
+		// Warn before posting the same question again, unless the creator already chose to
+		if action == "yes" {
+			if duplicate := f.findDuplicateEvent(ctx, event); duplicate != nil {
+				return f.showDuplicateWarning(ctx, userID, chatID, context, group, duplicate)
 			}
 		}
 
-		isAnonymous := false
-		allowsRevoting := event.AllowsRevoting
-		pollParams := &ExtendedSendPollParams{
-			ChatID:                 group.TelegramChatID,
-			Question:               event.Question,
-			Options:                pollOptions,
-			IsAnonymous:            &isAnonymous,
-			ProtectContent:         true,
-			AllowsRevoting:         &allowsRevoting,
-			ShuffleOptions:         event.ShuffleOptions,
-			CloseDate:              event.Deadline.Unix(),
-			HideResultsUntilCloses: event.HideResultsUntilClose,
+		// Groups with the approval queue hold member-created events until an admin approves them
+		if f.needsApproval(group, userID) {
+			return f.submitForApproval(ctx, userID, chatID, context, event, group)
+		}
+
+		// Publish poll to group using Telegram chat ID
+		if err := publishEventPoll(ctx, f.bot, f.logger, f.chatLocalizer(ctx, group.TelegramChatID), group, event, context.MessageThreadID); err != nil {
+			f.logger.Error("failed to send poll", "group_id", context.GroupID, "telegram_chat_id", group.TelegramChatID, "message_thread_id", context.MessageThreadID, "error", err)
+			errorText := f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorPollPublish)
+			if isPollPermissionError(err) {
+				errorText = f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationErrorPollPermission, group.Name)
+			}
+			_, _ = f.showStep(ctx, chatID, context, errorText, nil, false)
+			// Delete session
+			_ = f.storage.Delete(ctx, userID)
+			return err
 		}
 
-		// Add MessageThreadID if this is a forum group
-		if messageThreadID != nil {
-			pollParams.MessageThreadID = *messageThreadID
+		// Handle forum topic if MessageThreadID is provided
+		if context.MessageThreadID != nil {
+			event.ForumTopicID = f.resolveForumTopic(ctx, context.GroupID, *context.MessageThreadID, userID)
 		}
 
-		pollMsg, err := sendPollExtended(ctx, f.bot, pollParams)
-		if err != nil {
-			f.logger.Error("failed to send poll", "event_id", event.ID, "group_id", context.GroupID, "telegram_chat_id", group.TelegramChatID, "message_thread_id", messageThreadID, "error", err)
-			_, _ = f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationErrorPollPublish), nil)
+		// Persist the event together with its poll ID and message ID
+		if err := f.eventManager.CreateEvent(ctx, event); err != nil {
+			f.logger.Error("failed to create event", "user_id", userID, "poll_id", event.PollID, "error", err)
+			// Roll back: remove the published poll (and its photo) so it doesn't collect votes for a missing event
+			unpublishEventPoll(ctx, f.bot, f.logger, group, event)
+			_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorGeneric), nil, false)
 			// Delete session
 			_ = f.storage.Delete(ctx, userID)
 			return err
 		}
 
-		// Update event with poll ID and message ID
-		event.PollID = pollMsg.Poll.ID
-		event.PollMessageID = pollMsg.ID
-		if err := f.eventManager.UpdateEvent(ctx, event); err != nil {
-			f.logger.Error("failed to update event with poll ID and message ID", "event_id", event.ID, "error", err)
-		}
+		// Enqueue reminders and tell subscribers (failures never block creation)
+		f.announcePublishedEvent(ctx, event, group)
 
 		// Send final summary to admin with poll reference and action buttons
-		pollReference := f.localizer.MustLocalize(locale.EventCreationPollReference)
-		summary := f.buildFinalEventSummary(event, pollReference)
-
-		// Add action buttons for editing and resolving
-		kb := &models.InlineKeyboardMarkup{
-			InlineKeyboard: [][]models.InlineKeyboardButton{
-				{
-					{Text: f.localizer.MustLocalize(locale.ActionButtonEdit), CallbackData: fmt.Sprintf("edit_event:%d", event.ID)},
-					{Text: f.localizer.MustLocalize(locale.ActionButtonResolve), CallbackData: fmt.Sprintf("resolve:%d", event.ID)},
-				},
-			},
-		}
+		pollReference := f.requestLocalizer(ctx).MustLocalize(locale.EventCreationPollReference)
+		summary := f.buildFinalEventSummary(ctx, event, pollReference)
 
-		_, _ = f.sendMessage(ctx, chatID, summary, kb)
+		f.showEventSummary(ctx, chatID, context, summary, eventActionsKeyboard(f.requestLocalizer(ctx), event.ID))
 
 		f.logger.Info("event created and published", "user_id", userID, "event_id", event.ID, "poll_id", event.PollID)
 
 		// Check and award creator achievements (non-blocking)
-		// Handle errors gracefully - don't block event creation
-		achievements, err := f.achievementTracker.CheckCreatorAchievements(ctx, userID, event.GroupID)
-		if err != nil {
-			f.logger.Error("failed to check creator achievements", "user_id", userID, "group_id", event.GroupID, "error", err)
-			// Continue - achievement check failure should not block event creation
-		} else if len(achievements) > 0 {
-			// Send achievement notifications
-			for _, ach := range achievements {
-				if err := f.sendAchievementNotification(ctx, userID, ach); err != nil {
-					f.logger.Error("failed to send achievement notification", "user_id", userID, "achievement", ach.Code, "error", err)
-					// Continue - notification failure should not block event creation
-				}
-			}
-		}
+		f.awardCreatorAchievements(ctx, userID, event.GroupID)
 
 		// Delete session
 		if err := f.storage.Delete(ctx, userID); err != nil {
@@ -1331,7 +2507,7 @@ func (f *EventCreationFSM) handleConfirmCallback(ctx context.Context, userID int
 
 	if action == "no" {
 		// Send cancellation message
-		_, _ = f.sendMessage(ctx, chatID, f.localizer.MustLocalize(locale.EventCreationCancelled), nil)
+		_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationCancelled), nil, false)
 
 		f.logger.Info("event creation cancelled", "user_id", userID)
 
@@ -1348,22 +2524,119 @@ func (f *EventCreationFSM) handleConfirmCallback(ctx context.Context, userID int
 	return nil
 }
 
+// showEventSummary shows the summary of a created event with its management buttons in the summary chat.
+// When that is not the dialog chat, the dialog only gets a short note; if the summary can't be delivered
+// there (e.g. the creator never started a private chat with the bot), it is shown in the dialog instead.
+func (f *EventCreationFSM) showEventSummary(ctx context.Context, chatID int64, context *domain.EventCreationContext, summary string, replyMarkup models.ReplyMarkup) {
+	if summaryChatID := context.SummaryChat(); summaryChatID != chatID {
+		if _, err := f.sendMessage(ctx, summaryChatID, summary, replyMarkup); err == nil {
+			_, _ = f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventCreationSummarySentToDM), nil, false)
+			return
+		}
+		f.logger.Warn("failed to send event summary to the summary chat, showing it in the dialog", "chat_id", chatID, "summary_chat_id", summaryChatID)
+	}
+
+	_, _ = f.showStep(ctx, chatID, context, summary, replyMarkup, false)
+}
+
+// publishEventPoll posts the event's photo and poll to the group chat, links the poll to its discussion
+// and pins it when the group asks for it. The poll fields of the event are filled in;
+// if the poll can't be sent, the photo is removed again and nothing stays posted.
+func publishEventPoll(ctx context.Context, b *bot.Bot, logger domain.Logger, localizer locale.Localizer, group *domain.Group, event *domain.Event, messageThreadID *int) error {
+	pollOptions := make([]models.InputPollOption, len(event.Options))
+	for i, opt := range event.Options {
+		pollOptions[i] = models.InputPollOption{Text: opt}
+	}
+
+	isAnonymous := false
+	allowsRevoting := event.AllowsRevoting
+	pollParams := &ExtendedSendPollParams{
+		ChatID:                 group.TelegramChatID,
+		Question:               event.Question,
+		Options:                pollOptions,
+		IsAnonymous:            &isAnonymous,
+		ProtectContent:         true,
+		AllowsRevoting:         &allowsRevoting,
+		ShuffleOptions:         event.ShuffleOptions,
+		CloseDate:              event.Deadline.Unix(),
+		HideResultsUntilCloses: event.HideResultsUntilClose,
+	}
+	if event.IsQuiz() {
+		pollParams.Type = "quiz"
+		pollParams.CorrectOptionID = event.QuizAnswer
+	}
+
+	// Add MessageThreadID if this is a forum group
+	if messageThreadID != nil {
+		pollParams.MessageThreadID = *messageThreadID
+	}
+
+	// Post the attached photo right before the poll
+	event.PhotoMessageID = sendEventPhoto(ctx, b, logger, group.TelegramChatID, pollParams.MessageThreadID, event.PhotoFileID)
+
+	pollMsg, err := sendPollExtended(ctx, b, pollParams)
+	if err != nil {
+		if event.PhotoMessageID != 0 {
+			deleteMessages(ctx, b, logger, group.TelegramChatID, event.PhotoMessageID)
+			event.PhotoMessageID = 0
+		}
+		return err
+	}
+
+	// Link the poll to its discussion (omitted for private chats)
+	attachDiscussButton(ctx, b, logger, localizer, pollMsg, pollParams.MessageThreadID)
+
+	// Pin the poll if the group asks for it (failures never block creation)
+	if group.PinPolls {
+		event.PollPinned = pinPollMessage(ctx, b, logger, group.TelegramChatID, pollMsg.ID)
+	}
+
+	event.PollID = pollMsg.Poll.ID
+	event.PollMessageID = pollMsg.ID
+	return nil
+}
+
+// unpublishEventPoll removes a published poll and its photo, so it doesn't collect votes for an event that wasn't saved
+func unpublishEventPoll(ctx context.Context, b *bot.Bot, logger domain.Logger, group *domain.Group, event *domain.Event) {
+	deleteMessages(ctx, b, logger, group.TelegramChatID, event.PollMessageID)
+	if event.PhotoMessageID != 0 {
+		deleteMessages(ctx, b, logger, group.TelegramChatID, event.PhotoMessageID)
+	}
+}
+
+// eventActionsKeyboard builds the buttons for editing, resolving and closing voting of a published event
+func eventActionsKeyboard(localizer locale.Localizer, eventID int64) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: localizer.MustLocalize(locale.ActionButtonEdit), CallbackData: mustEncodeCallback(cbEditEvent, eventID)},
+				{Text: localizer.MustLocalize(locale.ActionButtonResolve), CallbackData: mustEncodeCallback(cbResolve, eventID)},
+			},
+			{
+				{Text: localizer.MustLocalize(locale.ActionButtonCloseVoting), CallbackData: mustEncodeCallback(cbCloseVoting, eventID)},
+			},
+		},
+	}
+}
+
 // sendAchievementNotification sends achievement notification to user and group
 func (f *EventCreationFSM) sendAchievementNotification(ctx context.Context, userID int64, achievement *domain.Achievement) error {
 	achievementNames := map[domain.AchievementCode]string{
-		domain.AchievementSharpshooter:    f.localizer.MustLocalize(locale.AchievementSharpshooterName),
-		domain.AchievementProphet:         f.localizer.MustLocalize(locale.AchievementProphetName),
-		domain.AchievementRiskTaker:       f.localizer.MustLocalize(locale.AchievementRiskTakerName),
-		domain.AchievementWeeklyAnalyst:   f.localizer.MustLocalize(locale.AchievementWeeklyAnalystName),
-		domain.AchievementVeteran:         f.localizer.MustLocalize(locale.AchievementVeteranName),
-		domain.AchievementEventOrganizer:  f.localizer.MustLocalize(locale.AchievementEventOrganizerName),
-		domain.AchievementActiveOrganizer: f.localizer.MustLocalize(locale.AchievementActiveOrganizerName),
-		domain.AchievementMasterOrganizer: f.localizer.MustLocalize(locale.AchievementMasterOrganizerName),
-	}
-
-	name := achievementNames[achievement.Code]
-	if name == "" {
-		name = string(achievement.Code)
+		domain.AchievementSharpshooter:    locale.AchievementSharpshooterName,
+		domain.AchievementProphet:         locale.AchievementProphetName,
+		domain.AchievementRiskTaker:       locale.AchievementRiskTakerName,
+		domain.AchievementWeeklyAnalyst:   locale.AchievementWeeklyAnalystName,
+		domain.AchievementVeteran:         locale.AchievementVeteranName,
+		domain.AchievementEventOrganizer:  locale.AchievementEventOrganizerName,
+		domain.AchievementActiveOrganizer: locale.AchievementActiveOrganizerName,
+		domain.AchievementMasterOrganizer: locale.AchievementMasterOrganizerName,
+		domain.AchievementGlobeTrotter:    locale.AchievementGlobeTrotterName,
+	}
+	achievementName := func(localizer locale.Localizer) string {
+		if key, ok := achievementNames[achievement.Code]; ok {
+			return localizer.MustLocalize(key)
+		}
+		return string(achievement.Code)
 	}
 
 	// Get group information
@@ -1379,9 +2652,10 @@ func (f *EventCreationFSM) sendAchievementNotification(ctx context.Context, user
 	}
 
 	// Send to user with group context
+	userLocalizer := f.chatLocalizer(ctx, userID)
 	_, err = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: userID,
-		Text:   f.localizer.MustLocalizeWithTemplate(locale.AchievementNotificationUser, groupName, name),
+		Text:   userLocalizer.MustLocalizeWithTemplate(locale.AchievementNotificationUser, groupName, achievementName(userLocalizer)),
 	})
 	if err != nil {
 		f.logger.Error("failed to send achievement notification to user", "user_id", userID, "error", err)
@@ -1411,9 +2685,10 @@ func (f *EventCreationFSM) sendAchievementNotification(ctx context.Context, user
 	// Announce in group
 	// Note: Achievement notifications for event organizers are sent to the main group chat,
 	// not to specific forum topics, as they are not tied to a specific event
+	groupLocalizer := f.chatLocalizer(ctx, telegramChatID)
 	msgParams := &bot.SendMessageParams{
 		ChatID: telegramChatID,
-		Text:   f.localizer.MustLocalizeWithTemplate(locale.AchievementNotificationGroup, displayName, name),
+		Text:   groupLocalizer.MustLocalizeWithTemplate(locale.AchievementNotificationGroup, displayName, achievementName(groupLocalizer)),
 	}
 
 	_, err = f.bot.SendMessage(ctx, msgParams)
@@ -70,7 +70,7 @@ func TestProperty_FSMSummaryUsesLocalizer(t *testing.T) {
 			}
 
 			// Build summary
-			summary := fsm.buildEventSummary(ctx)
+			summary := fsm.buildEventSummary(context.Background(), ctx)
 
 			// Verify no Cyrillic characters in summary
 			if containsCyrillic(summary) {
@@ -151,7 +151,7 @@ func TestProperty_FSMFinalSummaryUsesLocalizer(t *testing.T) {
 
 			// Build final summary
 			pollReference := "Poll published in group"
-			summary := fsm.buildFinalEventSummary(event, pollReference)
+			summary := fsm.buildFinalEventSummary(context.Background(), event, pollReference)
 
 			// Verify no Cyrillic characters in summary
 			if containsCyrillic(summary) {
@@ -216,7 +216,7 @@ func TestProperty_FSMDeadlinePromptUsesLocalizer(t *testing.T) {
 			}
 
 			// Get deadline prompt message
-			prompt := fsm.getDeadlinePromptMessage()
+			prompt := fsm.getDeadlinePromptMessage(context.Background())
 
 			// Verify no Cyrillic characters in prompt
 			if containsCyrillic(prompt) {
@@ -270,7 +270,7 @@ func TestProperty_FSMEventTypeLabelsUseLocalizer(t *testing.T) {
 			}
 
 			// Build summary which includes event type label
-			summary := fsm.buildEventSummary(ctx)
+			summary := fsm.buildEventSummary(context.Background(), ctx)
 
 			// Verify no Cyrillic characters in summary
 			if containsCyrillic(summary) {
@@ -132,7 +132,7 @@ func TestProperty_SummaryContentCompleteness(t *testing.T) {
 			}
 
 			// Build summary
-			summary := fsm.buildEventSummary(context)
+			summary := fsm.buildEventSummary(t.Context(), context)
 
 			// Verify all required fields are present
 			if !containsString(summary, question) {
@@ -1500,7 +1500,7 @@ func TestProperty_DeadlineTimezoneFormatting(t *testing.T) {
 			}
 
 			// Build final summary
-			summary := fsm.buildFinalEventSummary(event, "Опрос опубликован в группе")
+			summary := fsm.buildFinalEventSummary(context.Background(), event, "Опрос опубликован в группе")
 
 			// Convert deadline to the configured timezone
 			localDeadline := deadlineUTC.In(tz)
@@ -1552,7 +1552,7 @@ func TestProperty_PollReferenceInSummary(t *testing.T) {
 			}
 
 			// Build final summary with poll reference
-			summary := fsm.buildFinalEventSummary(event, pollReference)
+			summary := fsm.buildFinalEventSummary(context.Background(), event, pollReference)
 
 			// Verify the summary contains the poll reference
 			if pollReference != "" && !containsString(summary, pollReference) {
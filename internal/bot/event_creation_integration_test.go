@@ -111,7 +111,7 @@ func TestIntegration_CompleteEventCreationFlow(t *testing.T) {
 	predictionRepo := storage.NewPredictionRepository(queue)
 
 	// Create event manager
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
 
 	// Create FSM storage
 	fsmStorage := storage.NewFSMStorage(queue, log)
@@ -220,7 +220,7 @@ func TestIntegration_ConcurrentSessions(t *testing.T) {
 	predictionRepo := storage.NewPredictionRepository(queue)
 
 	// Create event manager
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
 
 	// Create FSM storage
 	fsmStorage := storage.NewFSMStorage(queue, log)
@@ -395,7 +395,7 @@ func TestIntegration_RestartRecovery(t *testing.T) {
 	predictionRepo := storage.NewPredictionRepository(queue)
 
 	// Create event manager
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
 
 	// Create FSM storage (before "restart")
 	fsmStorage1 := storage.NewFSMStorage(queue, log)
@@ -544,7 +544,7 @@ func TestIntegration_CancellationFlow(t *testing.T) {
 	predictionRepo := storage.NewPredictionRepository(queue)
 
 	// Create event manager
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
 
 	// Create FSM storage
 	fsmStorage := storage.NewFSMStorage(queue, log)
@@ -677,7 +677,7 @@ func TestIntegration_EventCreationPermissionFlow(t *testing.T) {
 	}
 
 	// Create event manager
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
 
 	// Create event permission validator with min events = 3
 	minEventsToCreate := 3
@@ -935,7 +935,7 @@ func TestIntegration_EventResolutionPermissions(t *testing.T) {
 	groupMembershipRepo := storage.NewGroupMembershipRepository(queue)
 
 	// Create event manager
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
 
 	// Create event permission validator
 	eventPermissionValidator := domain.NewEventPermissionValidator(
@@ -1149,7 +1149,7 @@ func TestIntegration_CreatorAchievementFlow(t *testing.T) {
 	achievementRepo := storage.NewAchievementRepository(queue)
 
 	// Create event manager
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
 
 	// Create achievement tracker
 	achievementTracker := domain.NewAchievementTracker(
@@ -1157,6 +1157,7 @@ func TestIntegration_CreatorAchievementFlow(t *testing.T) {
 		ratingRepo,
 		predictionRepo,
 		eventRepo,
+		nil,
 		log,
 	)
 
@@ -1526,3 +1527,137 @@ func TestIntegration_DeadlinePresetButtons(t *testing.T) {
 		})
 	}
 }
+
+// Integration test for both participation definitions of the event creation permission
+func TestIntegration_EventCreationParticipationModes(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := storage.NewDBQueue(db)
+	defer queue.Close()
+
+	if err := storage.InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := storage.RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	log := logger.New(logger.ERROR)
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	groupMembershipRepo := storage.NewGroupMembershipRepository(queue)
+	groupRepo := storage.NewGroupRepository(queue)
+
+	userID := int64(11111)
+	adminUserID := int64(99999)
+	adminIDs := []int64{adminUserID}
+
+	group := &domain.Group{
+		TelegramChatID: 67890,
+		Name:           "Test Group",
+		CreatedBy:      adminUserID,
+		CreatedAt:      time.Now(),
+	}
+	if err := groupRepo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+	groupID := group.ID
+
+	membership := &domain.GroupMembership{
+		GroupID:  groupID,
+		UserID:   userID,
+		JoinedAt: time.Now(),
+		Status:   domain.MembershipStatusActive,
+	}
+	if err := groupMembershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("Failed to create membership: %v", err)
+	}
+
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
+
+	resolvedValidator := domain.NewEventPermissionValidator(eventRepo, predictionRepo, groupMembershipRepo, 3, log)
+	votedValidator := domain.NewEventPermissionValidator(eventRepo, predictionRepo, groupMembershipRepo, 3, log)
+	votedValidator.SetParticipationMode(domain.ParticipationVoted)
+
+	// The user votes on 3 events and on a fourth one that gets cancelled
+	var eventIDs []int64
+	for i := 0; i < 4; i++ {
+		event := &domain.Event{
+			Question:  fmt.Sprintf("Test event %d", i+1),
+			EventType: domain.EventTypeBinary,
+			Options:   []string{"Yes", "No"},
+			Deadline:  time.Now().Add(24 * time.Hour),
+			CreatedAt: time.Now(),
+			Status:    domain.EventStatusActive,
+			CreatedBy: adminUserID,
+			GroupID:   groupID,
+		}
+		if err := eventManager.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create test event %d: %v", i+1, err)
+		}
+		prediction := &domain.Prediction{
+			EventID:   event.ID,
+			UserID:    userID,
+			Option:    0,
+			Timestamp: time.Now(),
+		}
+		if err := predictionRepo.SavePrediction(ctx, prediction); err != nil {
+			t.Fatalf("Failed to save prediction for event %d: %v", i+1, err)
+		}
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	cancelled, err := eventRepo.GetEvent(ctx, eventIDs[3])
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	cancelled.Status = domain.EventStatusCancelled
+	if err := eventRepo.UpdateEvent(ctx, cancelled); err != nil {
+		t.Fatalf("Failed to cancel event: %v", err)
+	}
+
+	check := func(t *testing.T, validator *domain.EventPermissionValidator, wantAllowed bool, wantCount int) {
+		t.Helper()
+		canCreate, count, err := validator.CanCreateEvent(ctx, userID, groupID, adminIDs)
+		if err != nil {
+			t.Fatalf("Failed to check event creation permission: %v", err)
+		}
+		if canCreate != wantAllowed {
+			t.Errorf("Expected canCreate %v, got %v", wantAllowed, canCreate)
+		}
+		if count != wantCount {
+			t.Errorf("Expected participation count %d, got %d", wantCount, count)
+		}
+	}
+
+	t.Run("Default mode is resolved", func(t *testing.T) {
+		if mode := resolvedValidator.ParticipationMode(); mode != domain.ParticipationResolved {
+			t.Errorf("Expected default mode %q, got %q", domain.ParticipationResolved, mode)
+		}
+	})
+
+	t.Run("Resolved mode ignores unresolved events", func(t *testing.T) {
+		check(t, resolvedValidator, false, 0)
+	})
+
+	t.Run("Voted mode counts unresolved events except cancelled ones", func(t *testing.T) {
+		check(t, votedValidator, true, 3)
+	})
+
+	for _, eventID := range eventIDs[:3] {
+		if err := eventManager.ResolveEvent(ctx, eventID, 0); err != nil {
+			t.Fatalf("Failed to resolve event %d: %v", eventID, err)
+		}
+	}
+
+	t.Run("Both modes count resolved events", func(t *testing.T) {
+		check(t, resolvedValidator, true, 3)
+		check(t, votedValidator, true, 3)
+	})
+}
@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestProperty_HandleOptionsInputRejectsDuplicateAndBlankOptions(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+	properties.Property("duplicate or blank options keep the session at ask_options and list the valid options", prop.ForAll(
+		func(options []string, blank bool) bool {
+			ctx := context.Background()
+			rec, b := newRecordingTelegramServer(t)
+			fsm := newQuestionValidationFSM(t, b, nil)
+
+			userID := int64(42)
+			sessionContext := &domain.EventCreationContext{ChatID: userID, GroupID: 1, EventType: domain.EventTypeMultiOption}
+			if err := fsm.storage.Set(ctx, userID, StateAskOptions, sessionContext.ToMap()); err != nil {
+				t.Logf("failed to set session: %v", err)
+				return false
+			}
+
+			// Repeat the first option in upper case or put a blank line between the options
+			input := strings.Join(options, "\n") + "\n" + strings.ToUpper(options[0])
+			expected := localizer.MustLocalizeWithTemplate(locale.EventCreationErrorDuplicateOption, strings.ToUpper(options[0]), strings.Join(options, "\n"))
+			if blank {
+				input = options[0] + "\n \n" + strings.Join(options[1:], "\n")
+				expected = localizer.MustLocalizeWithTemplate(locale.EventCreationErrorEmptyOption, strings.Join(options, "\n"))
+			}
+
+			if err := fsm.handleOptionsInput(ctx, userID, userID, input, 10, sessionContext); err != nil {
+				t.Logf("handleOptionsInput returned error: %v", err)
+				return false
+			}
+
+			if texts := rec.texts(); len(texts) != 1 || texts[0] != expected {
+				t.Logf("expected error message %q, got %v", expected, texts)
+				return false
+			}
+
+			state, data, err := fsm.storage.Get(ctx, userID)
+			if err != nil || state != StateAskOptions {
+				t.Logf("expected state %s, got %s (%v)", StateAskOptions, state, err)
+				return false
+			}
+			restored := &domain.EventCreationContext{}
+			if err := restored.FromMap(data); err != nil {
+				t.Logf("failed to restore context: %v", err)
+				return false
+			}
+			return len(restored.Options) == 0
+		},
+		gen.SliceOfN(3, gen.Identifier()).SuchThat(func(options []string) bool {
+			return domain.ValidateOptions(options) == nil
+		}),
+		gen.Bool(),
+	))
+
+	properties.TestingRun(t)
+}
+
+func TestHandleOptionsInput_TrimsValidOptions(t *testing.T) {
+	ctx := context.Background()
+	_, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+
+	userID := int64(42)
+	sessionContext := &domain.EventCreationContext{ChatID: userID, GroupID: 1, EventType: domain.EventTypeMultiOption}
+	if err := fsm.storage.Set(ctx, userID, StateAskOptions, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	if err := fsm.handleOptionsInput(ctx, userID, userID, "  Red \nGreen\r\n Blue", 10, sessionContext); err != nil {
+		t.Fatalf("handleOptionsInput returned error: %v", err)
+	}
+
+	state, data, err := fsm.storage.Get(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if state != StateAskDeadline {
+		t.Errorf("expected state %s, got %s", StateAskDeadline, state)
+	}
+	restored := &domain.EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("failed to restore context: %v", err)
+	}
+	if got := strings.Join(restored.Options, ","); got != "Red,Green,Blue" {
+		t.Errorf("expected trimmed options Red,Green,Blue, got %s", got)
+	}
+}
+
+func TestHandleOptionsInput_RejectsTooLongOption(t *testing.T) {
+	ctx := context.Background()
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	rec, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+
+	userID := int64(42)
+	sessionContext := &domain.EventCreationContext{ChatID: userID, GroupID: 1, EventType: domain.EventTypeMultiOption}
+	if err := fsm.storage.Set(ctx, userID, StateAskOptions, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	long := strings.Repeat("a", domain.MaxPollOptionLength+1)
+	if err := fsm.handleOptionsInput(ctx, userID, userID, "Red\n"+long+"\nBlue", 10, sessionContext); err != nil {
+		t.Fatalf("handleOptionsInput returned error: %v", err)
+	}
+
+	expected := localizer.MustLocalizeWithTemplate(locale.EventCreationErrorOptionTooLong,
+		strings.Repeat("a", optionTooLongPreviewLength)+"…", "100", "Red\nBlue")
+	if texts := rec.texts(); len(texts) != 1 || texts[0] != expected {
+		t.Errorf("expected error message %q, got %v", expected, rec.texts())
+	}
+
+	state, _, err := fsm.storage.Get(ctx, userID)
+	if err != nil || state != StateAskOptions {
+		t.Errorf("expected state %s, got %s (%v)", StateAskOptions, state, err)
+	}
+}
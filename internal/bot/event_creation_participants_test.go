@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreation_ParticipantSelection(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(12345)
+	rec, b := newPollTelegramServer(t, nil)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	for _, memberID := range []int64{userID, 200, 300} {
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: memberID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+	userRepo := storage.NewUserRepository(queue)
+	if err := userRepo.UpsertUserProfile(ctx, 200, "alice", "Alice", ""); err != nil {
+		t.Fatalf("failed to upsert profile: %v", err)
+	}
+
+	fsmStorage := storage.NewFSMStorage(queue, log)
+	fsm := NewEventCreationFSM(fsmStorage, b, nil, nil, nil, storage.NewGroupRepository(queue), nil, nil,
+		membershipRepo, userRepo, nil, &config.Config{Timezone: time.UTC}, log, localizer)
+
+	sessionContext := &domain.EventCreationContext{
+		ChatID:    userID,
+		GroupID:   groupID,
+		Question:  "Will it rain tomorrow?",
+		EventType: domain.EventTypeBinary,
+		Options:   []string{"Yes", "No"},
+		Deadline:  time.Now().Add(48 * time.Hour),
+	}
+	if err := fsmStorage.Set(ctx, userID, StatePollSettings, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	press := func(data string) {
+		t.Helper()
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+			},
+		}
+		if err := fsm.HandleCallback(ctx, callback); err != nil {
+			t.Fatalf("HandleCallback(%s) failed: %v", data, err)
+		}
+	}
+	session := func(expectedState string) *domain.EventCreationContext {
+		t.Helper()
+		state, data, err := fsmStorage.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		if state != expectedState {
+			t.Fatalf("expected state %s, got %s", expectedState, state)
+		}
+		loaded := &domain.EventCreationContext{}
+		if err := loaded.FromMap(data); err != nil {
+			t.Fatalf("failed to load context: %v", err)
+		}
+		return loaded
+	}
+
+	// Poll settings lead to the participant step instead of confirmation
+	press(mustEncodeCallback(cbPollSetting, "done"))
+	session(StateSelectParticipants)
+
+	press(mustEncodeCallback(cbParticipants, "toggle", 200, 0))
+	press(mustEncodeCallback(cbParticipants, "toggle", 300, 0))
+	press(mustEncodeCallback(cbParticipants, "toggle", 300, 0))
+	if participants := session(StateSelectParticipants).Participants; len(participants) != 1 || participants[0] != 200 {
+		t.Fatalf("expected participants [200], got %v", participants)
+	}
+
+	press(mustEncodeCallback(cbParticipants, "done"))
+	if participants := session(StateConfirm).Participants; len(participants) != 1 || participants[0] != 200 {
+		t.Errorf("expected participants [200] at confirmation, got %v", participants)
+	}
+
+	texts := rec.texts()
+	summary := texts[len(texts)-1]
+	expected := localizer.MustLocalizeWithTemplate(locale.EventSummaryParticipants,
+		localizer.MustLocalizeWithTemplate(locale.ParticipantsSelectedCount, "1"))
+	if !strings.Contains(summary, expected) {
+		t.Errorf("expected summary to contain %q, got:\n%s", expected, summary)
+	}
+}
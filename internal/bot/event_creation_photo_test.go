@@ -0,0 +1,168 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreation_PhotoStep(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(12345)
+	rec, b := newPollTelegramServer(t, nil)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	fsmStorage := storage.NewFSMStorage(queue, log)
+	fsm := NewEventCreationFSM(
+		fsmStorage,
+		b,
+		domain.NewEventManager(eventRepo, predictionRepo, nil, log),
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		nil,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		ratingRepo,
+		storage.NewGroupMembershipRepository(queue),
+		storage.NewUserRepository(queue),
+		nil,
+		&config.Config{Timezone: time.UTC},
+		log,
+		localizer,
+	)
+
+	startAtPhotoStep := func() {
+		t.Helper()
+		sessionContext := &domain.EventCreationContext{
+			ChatID:    userID,
+			GroupID:   groupID,
+			Question:  "Will the chart go up?",
+			EventType: domain.EventTypeBinary,
+			Options:   []string{"Yes", "No"},
+			Deadline:  time.Now().Add(48 * time.Hour),
+		}
+		if err := fsmStorage.Set(ctx, userID, StateAskPhoto, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+	}
+	send := func(message *models.Message) {
+		t.Helper()
+		message.From = &models.User{ID: userID}
+		message.Chat = models.Chat{ID: userID}
+		if err := fsm.HandleMessage(ctx, &models.Update{Message: message}); err != nil {
+			t.Fatalf("HandleMessage failed: %v", err)
+		}
+	}
+	press := func(state string, data string) {
+		t.Helper()
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+			},
+		}
+		if err := fsm.HandleCallback(ctx, callback); err != nil {
+			t.Fatalf("HandleCallback(%s) in %s failed: %v", data, state, err)
+		}
+	}
+	session := func(expectedState string) *domain.EventCreationContext {
+		t.Helper()
+		state, data, err := fsmStorage.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		if state != expectedState {
+			t.Fatalf("expected state %s, got %s", expectedState, state)
+		}
+		loaded := &domain.EventCreationContext{}
+		if err := loaded.FromMap(data); err != nil {
+			t.Fatalf("failed to load context: %v", err)
+		}
+		return loaded
+	}
+
+	t.Run("text instead of photo keeps the step", func(t *testing.T) {
+		startAtPhotoStep()
+		send(&models.Message{ID: 20, Text: "here is my chart"})
+
+		if loaded := session(StateAskPhoto); loaded.PhotoFileID != "" || loaded.LastErrorMessageID == 0 {
+			t.Errorf("expected no photo and an error message, got %q/%d", loaded.PhotoFileID, loaded.LastErrorMessageID)
+		}
+		texts := rec.texts()
+		if len(texts) == 0 || texts[len(texts)-1] != localizer.MustLocalize(locale.EventPhotoErrorNotPhoto) {
+			t.Errorf("expected photo hint, got %v", texts)
+		}
+	})
+
+	t.Run("photo is stored with its largest size", func(t *testing.T) {
+		startAtPhotoStep()
+		send(&models.Message{ID: 21, Photo: []models.PhotoSize{
+			{FileID: "small", Width: 90, Height: 90},
+			{FileID: "large", Width: 1280, Height: 1280},
+		}})
+
+		if loaded := session(StatePollSettings); loaded.PhotoFileID != "large" {
+			t.Errorf("expected photo file_id large, got %q", loaded.PhotoFileID)
+		}
+	})
+
+	t.Run("skip leaves the event without a photo", func(t *testing.T) {
+		startAtPhotoStep()
+		press(StateAskPhoto, mustEncodeCallback(cbEventPhoto, "skip"))
+
+		if loaded := session(StatePollSettings); loaded.PhotoFileID != "" {
+			t.Errorf("expected no photo after skip, got %q", loaded.PhotoFileID)
+		}
+	})
+
+	t.Run("photo is posted before the poll", func(t *testing.T) {
+		sessionContext := &domain.EventCreationContext{
+			ChatID:      userID,
+			GroupID:     groupID,
+			Question:    "Will the chart go up?",
+			EventType:   domain.EventTypeBinary,
+			Options:     []string{"Yes", "No"},
+			Deadline:    time.Now().Add(48 * time.Hour),
+			PhotoFileID: "large",
+		}
+		if err := fsmStorage.Set(ctx, userID, StateConfirm, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+		press(StateConfirm, mustEncodeCallback(cbConfirm, "yes"))
+
+		rec.mu.Lock()
+		photos := append([]string(nil), rec.sentPhotos...)
+		rec.mu.Unlock()
+		if len(photos) != 1 || photos[0] != "large" {
+			t.Fatalf("expected the photo to be sent once by file_id, got %v", photos)
+		}
+
+		event, err := eventRepo.GetEventByPollID(ctx, "poll_900")
+		if err != nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		if event.PhotoFileID != "large" || event.PhotoMessageID != 800 {
+			t.Errorf("expected photo large/800 on the event, got %q/%d", event.PhotoFileID, event.PhotoMessageID)
+		}
+	})
+}
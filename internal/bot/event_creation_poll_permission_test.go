@@ -0,0 +1,261 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	tgbot "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// pollTelegramServer is a fake Telegram Bot API with a configurable sendPoll response
+type pollTelegramServer struct {
+	mu         sync.Mutex
+	pollError  *telegramAPIResponse
+	sentTexts  []string
+	sentChats  []string // Chat of each sent text
+	sentPhotos []string
+	sentPolls  []map[string]interface{}
+	pollSent   int
+}
+
+func newPollTelegramServer(t *testing.T, pollError *telegramAPIResponse) (*pollTelegramServer, *tgbot.Bot) {
+	rec := &pollTelegramServer{pollError: pollError}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"id": 1, "is_bot": true, "first_name": "Test"},
+			})
+		case strings.HasSuffix(r.URL.Path, "/sendPoll"):
+			if rec.pollError != nil {
+				_ = json.NewEncoder(w).Encode(rec.pollError)
+				return
+			}
+			rec.pollSent++
+			var params map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&params)
+			rec.sentPolls = append(rec.sentPolls, params)
+			_ = json.NewEncoder(w).Encode(telegramAPIResponse{
+				OK:     true,
+				Result: json.RawMessage(`{"message_id": 900, "date": 0, "chat": {"id": 1}, "poll": {"id": "poll_900", "question": "Q", "options": []}}`),
+			})
+		case strings.HasSuffix(r.URL.Path, "/sendPhoto"):
+			_ = r.ParseMultipartForm(1 << 20)
+			rec.sentPhotos = append(rec.sentPhotos, r.FormValue("photo"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"message_id": 800, "date": 0, "chat": map[string]interface{}{"id": 1}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			_ = r.ParseMultipartForm(1 << 20)
+			rec.sentTexts = append(rec.sentTexts, r.FormValue("text"))
+			rec.sentChats = append(rec.sentChats, r.FormValue("chat_id"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"message_id": 100 + len(rec.sentTexts), "date": 0, "chat": map[string]interface{}{"id": 1}},
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": true})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	origURL := telegramAPIBaseURL
+	telegramAPIBaseURL = server.URL
+	t.Cleanup(func() { telegramAPIBaseURL = origURL })
+
+	b, err := tgbot.New("test-token", tgbot.WithServerURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create bot: %v", err)
+	}
+
+	return rec, b
+}
+
+func (r *pollTelegramServer) texts() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.sentTexts...)
+}
+
+func (r *pollTelegramServer) chats() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.sentChats...)
+}
+
+func (r *pollTelegramServer) polls() []map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]map[string]interface{}(nil), r.sentPolls...)
+}
+
+// confirmEventCreation runs the confirmation step of the event creation FSM against a fresh database
+func confirmEventCreation(t *testing.T, b *tgbot.Bot) (*storage.EventRepository, *storage.FSMStorage, int64, error) {
+	ctx := context.Background()
+	userID := int64(12345)
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	fsmStorage := storage.NewFSMStorage(queue, log)
+
+	fsm := NewEventCreationFSM(
+		fsmStorage,
+		b,
+		domain.NewEventManager(eventRepo, predictionRepo, nil, log),
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		nil,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		ratingRepo,
+		storage.NewGroupMembershipRepository(queue),
+		storage.NewUserRepository(queue),
+		nil,
+		&config.Config{Timezone: time.UTC},
+		log,
+		localizer,
+	)
+
+	sessionContext := &domain.EventCreationContext{
+		ChatID:    userID,
+		GroupID:   groupID,
+		Question:  "Will it rain tomorrow?",
+		EventType: domain.EventTypeBinary,
+		Options:   []string{"Yes", "No"},
+		Deadline:  time.Now().Add(48 * time.Hour),
+	}
+	if err := fsmStorage.Set(ctx, userID, StateConfirm, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	callback := &models.CallbackQuery{
+		ID:   "cb1",
+		From: models.User{ID: userID},
+		Data: mustEncodeCallback(cbConfirm, "yes"),
+		Message: models.MaybeInaccessibleMessage{
+			Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+		},
+	}
+
+	return eventRepo, fsmStorage, userID, fsm.HandleCallback(ctx, callback)
+}
+
+func TestConfirmEventCreation_PollPermissionDenied(t *testing.T) {
+	ctx := context.Background()
+	rec, b := newPollTelegramServer(t, &telegramAPIResponse{
+		OK:          false,
+		ErrorCode:   400,
+		Description: "Bad Request: not enough rights to send polls to the chat",
+	})
+
+	eventRepo, fsmStorage, userID, err := confirmEventCreation(t, b)
+	if err == nil {
+		t.Fatal("expected error when poll cannot be sent")
+	}
+	if !isPollPermissionError(err) {
+		t.Errorf("expected poll permission error, got %v", err)
+	}
+
+	// No event row must be left behind
+	events, err := eventRepo.GetEventsByDeadlineRange(ctx, time.Now(), time.Now().Add(72*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events to be persisted, got %d", len(events))
+	}
+
+	// Session must be cleaned up
+	if _, _, err := fsmStorage.Get(ctx, userID); err != storage.ErrSessionNotFound {
+		t.Errorf("expected session to be deleted, got %v", err)
+	}
+
+	// Creator must receive the localized permission error
+	localizer, _ := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	expected := localizer.MustLocalizeWithTemplate(locale.EventCreationErrorPollPermission, "Test Group")
+	texts := rec.texts()
+	if len(texts) != 1 || texts[0] != expected {
+		t.Errorf("expected permission error message %q, got %v", expected, texts)
+	}
+}
+
+func TestConfirmEventCreation_PersistsEventAfterPoll(t *testing.T) {
+	ctx := context.Background()
+	rec, b := newPollTelegramServer(t, nil)
+
+	eventRepo, fsmStorage, userID, err := confirmEventCreation(t, b)
+	if err != nil {
+		t.Fatalf("expected successful confirmation, got %v", err)
+	}
+
+	if rec.pollSent != 1 {
+		t.Fatalf("expected 1 poll to be sent, got %d", rec.pollSent)
+	}
+
+	event, err := eventRepo.GetEventByPollID(ctx, "poll_900")
+	if err != nil {
+		t.Fatalf("failed to get event by poll ID: %v", err)
+	}
+	if event == nil {
+		t.Fatal("expected event to be persisted with poll ID")
+	}
+	if event.PollMessageID != 900 {
+		t.Errorf("expected poll message ID 900, got %d", event.PollMessageID)
+	}
+
+	if _, _, err := fsmStorage.Get(ctx, userID); err != storage.ErrSessionNotFound {
+		t.Errorf("expected session to be deleted, got %v", err)
+	}
+}
+
+func TestIsPollPermissionError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"forbidden", &telegramAPIError{Code: 403, Description: "Forbidden: bot was kicked from the supergroup chat"}, true},
+		{"not enough rights", &telegramAPIError{Code: 400, Description: "Bad Request: not enough rights to send polls to the chat"}, true},
+		{"polls forbidden", &telegramAPIError{Code: 400, Description: "Bad Request: CHAT_SEND_POLLS_FORBIDDEN"}, true},
+		{"other bad request", &telegramAPIError{Code: 400, Description: "Bad Request: poll options must be non-empty"}, false},
+		{"network error", errors.New("send poll request: connection refused"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isPollPermissionError(tc.err); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestBuildEventPreview(t *testing.T) {
+	_, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+	moscow := time.FixedZone("MSK", 3*60*60)
+	fsm.config.Timezone = moscow
+
+	preview := fsm.buildEventPreview(context.Background(), &domain.EventCreationContext{
+		Question:              "Will it rain?",
+		EventType:             domain.EventTypeBinary,
+		Options:               []string{"Yes", "No"},
+		Deadline:              time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		HideResultsUntilClose: true,
+	})
+
+	for _, expected := range []string{
+		fsm.localizer.MustLocalizeWithTemplate(locale.EventPreviewQuestion, "Will it rain?"),
+		fsm.localizer.MustLocalizeWithTemplate(locale.EventPreviewOption, "Yes"),
+		fsm.localizer.MustLocalizeWithTemplate(locale.EventPreviewOption, "No"),
+		fsm.localizer.MustLocalizeWithTemplate(locale.EventPreviewDeadline, "01.05.2024 12:00", "MSK"),
+		fsm.localizer.MustLocalize(locale.EventPreviewNoRevoting),
+		fsm.localizer.MustLocalize(locale.EventPreviewResultsHidden),
+	} {
+		if !strings.Contains(preview, expected) {
+			t.Errorf("expected preview to contain %q, got:\n%s", expected, preview)
+		}
+	}
+	if strings.Contains(preview, fsm.localizer.MustLocalize(locale.EventPreviewShuffled)) {
+		t.Errorf("expected no shuffle note for a poll without shuffling, got:\n%s", preview)
+	}
+}
+
+func TestEventPreview_ReopenStepKeepsData(t *testing.T) {
+	ctx := context.Background()
+	rec, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+
+	userID := int64(42)
+	chatID := int64(42)
+	deadline := time.Now().Add(48 * time.Hour).Truncate(time.Minute).UTC()
+	sessionContext := &domain.EventCreationContext{
+		ChatID:          chatID,
+		GroupID:         1,
+		Question:        "Will it rain?",
+		EventType:       domain.EventTypeBinary,
+		Options:         []string{"Yes", "No"},
+		Deadline:        deadline,
+		ShuffleOptions:  true,
+		ReminderOffsets: []time.Duration{time.Hour},
+	}
+
+	if err := fsm.showConfirm(ctx, userID, chatID, sessionContext, StateSelectParticipants); err != nil {
+		t.Fatalf("showConfirm returned error: %v", err)
+	}
+
+	// The preview comes right before the summary
+	texts := rec.texts()
+	if len(texts) != 2 || texts[0] != fsm.buildEventPreview(ctx, sessionContext) {
+		t.Fatalf("expected the preview and the summary, got %q", texts)
+	}
+	markup := rec.markups[len(rec.markups)-1]
+	if !strings.Contains(markup, mustEncodeCallback(cbConfirm, "edit", previewStepQuestion)) {
+		t.Errorf("expected a button reopening the question, got %s", markup)
+	}
+	if strings.Contains(markup, mustEncodeCallback(cbConfirm, "edit", previewStepOptions)) {
+		t.Errorf("expected no options button for a binary event, got %s", markup)
+	}
+	previewID := sessionContext.PreviewMessageIDs[0]
+	confirmationID := sessionContext.ConfirmationMessageID
+
+	// Go back to the question
+	data := mustEncodeCallback(cbConfirm, "edit", previewStepQuestion)
+	cb, err := DecodeCallback(data)
+	if err != nil {
+		t.Fatalf("failed to decode callback: %v", err)
+	}
+	callback := &models.CallbackQuery{
+		ID:      "cb",
+		From:    models.User{ID: userID},
+		Data:    data,
+		Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: confirmationID, Chat: models.Chat{ID: chatID}}},
+	}
+	if err := fsm.handleConfirmCallback(ctx, userID, callback, cb, sessionContext); err != nil {
+		t.Fatalf("handleConfirmCallback returned error: %v", err)
+	}
+
+	deleted := rec.deletedIDs()
+	if len(deleted) != 2 || deleted[0] != previewID || deleted[1] != confirmationID {
+		t.Errorf("expected preview %d and confirmation %d to be deleted, got %v", previewID, confirmationID, deleted)
+	}
+	state, stored, err := fsm.storage.Get(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if state != StateAskQuestion {
+		t.Fatalf("expected state %s, got %s", StateAskQuestion, state)
+	}
+	restored := &domain.EventCreationContext{}
+	if err := restored.FromMap(stored); err != nil {
+		t.Fatalf("failed to restore context: %v", err)
+	}
+
+	// A new question returns straight to the confirmation with everything else intact
+	if err := fsm.handleQuestionInput(ctx, userID, chatID, "Will it snow?", 77, restored); err != nil {
+		t.Fatalf("handleQuestionInput returned error: %v", err)
+	}
+
+	state, stored, err = fsm.storage.Get(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if state != StateConfirm {
+		t.Fatalf("expected state %s, got %s", StateConfirm, state)
+	}
+	restored = &domain.EventCreationContext{}
+	if err := restored.FromMap(stored); err != nil {
+		t.Fatalf("failed to restore context: %v", err)
+	}
+	if restored.Question != "Will it snow?" || restored.ReturnToConfirm {
+		t.Errorf("expected the new question and no pending return, got %q, %t", restored.Question, restored.ReturnToConfirm)
+	}
+	if !restored.Deadline.Equal(deadline) || !restored.ShuffleOptions || len(restored.ReminderOffsets) != 1 || len(restored.Options) != 2 {
+		t.Errorf("expected the other answers to be kept, got %+v", restored)
+	}
+	if len(restored.PreviewMessageIDs) != 1 {
+		t.Errorf("expected a new preview, got %v", restored.PreviewMessageIDs)
+	}
+}
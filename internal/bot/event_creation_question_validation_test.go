@@ -0,0 +1,268 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+
+	tgbot "github.com/go-telegram/bot"
+)
+
+// recordingTelegramServer is a fake Telegram Bot API that records sent and deleted messages
+type recordingTelegramServer struct {
+	mu        sync.Mutex
+	server    *httptest.Server
+	nextID    int
+	sentTexts []string
+	markups   []string
+	deleted   []int
+	edited    []int
+	stopped   []int
+	// stopPollError, when set, is returned as the description of a failed stopPoll
+	stopPollError string
+	// editError, when set, is returned as the description of a failed editMessageText
+	editError string
+}
+
+func newRecordingTelegramServer(t *testing.T) (*recordingTelegramServer, *tgbot.Bot) {
+	rec := &recordingTelegramServer{nextID: 100}
+
+	rec.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"id": 1, "is_bot": true, "first_name": "Test"},
+			})
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			_ = r.ParseMultipartForm(1 << 20)
+			rec.sentTexts = append(rec.sentTexts, r.FormValue("text"))
+			rec.markups = append(rec.markups, r.FormValue("reply_markup"))
+			rec.nextID++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"message_id": rec.nextID, "date": 0, "chat": map[string]interface{}{"id": 1}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/editMessageText") && rec.editError != "":
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error_code": 400, "description": rec.editError})
+		case strings.HasSuffix(r.URL.Path, "/editMessageText"):
+			_ = r.ParseMultipartForm(1 << 20)
+			var id int
+			_ = json.Unmarshal([]byte(r.FormValue("message_id")), &id)
+			rec.edited = append(rec.edited, id)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"message_id": id, "date": 0, "chat": map[string]interface{}{"id": 1}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/stopPoll") && rec.stopPollError != "":
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error_code": 400, "description": rec.stopPollError})
+		case strings.HasSuffix(r.URL.Path, "/stopPoll"):
+			_ = r.ParseMultipartForm(1 << 20)
+			var id int
+			_ = json.Unmarshal([]byte(r.FormValue("message_id")), &id)
+			rec.stopped = append(rec.stopped, id)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"id": "poll", "question": "", "is_closed": true},
+			})
+		case strings.HasSuffix(r.URL.Path, "/deleteMessage"):
+			_ = r.ParseMultipartForm(1 << 20)
+			var id int
+			_ = json.Unmarshal([]byte(r.FormValue("message_id")), &id)
+			rec.deleted = append(rec.deleted, id)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": true})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": true})
+		}
+	}))
+	t.Cleanup(rec.server.Close)
+
+	b, err := tgbot.New("test-token", tgbot.WithServerURL(rec.server.URL))
+	if err != nil {
+		t.Fatalf("failed to create bot: %v", err)
+	}
+
+	return rec, b
+}
+
+func (r *recordingTelegramServer) texts() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.sentTexts...)
+}
+
+func (r *recordingTelegramServer) deletedIDs() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.deleted...)
+}
+
+func (r *recordingTelegramServer) stoppedIDs() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.stopped...)
+}
+
+func (r *recordingTelegramServer) editedIDs() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.edited...)
+}
+
+// rejectingContentValidator rejects any text containing the configured word
+type rejectingContentValidator struct {
+	word string
+}
+
+func (v *rejectingContentValidator) Validate(text string) error {
+	if strings.Contains(strings.ToLower(text), v.word) {
+		return domain.ErrContentRejected
+	}
+	return nil
+}
+
+func newQuestionValidationFSM(t *testing.T, b *tgbot.Bot, validator domain.ContentValidator) *EventCreationFSM {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	cfg := &config.Config{
+		Timezone:          time.UTC,
+		MinQuestionLength: 5,
+		MaxQuestionLength: 20,
+	}
+
+	return NewEventCreationFSM(
+		createTestFSMStorage(t),
+		b,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		validator,
+		cfg,
+		logger.New(logger.ERROR),
+		localizer,
+	)
+}
+
+func TestHandleQuestionInput_LengthAndContentValidation(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		question      string
+		expectedError string
+	}{
+		{"too short", "Hi?", localizer.MustLocalizeWithTemplate(locale.EventCreationErrorQuestionTooShort, "5")},
+		{"too long", strings.Repeat("a", 21), localizer.MustLocalizeWithTemplate(locale.EventCreationErrorQuestionTooLong, "20")},
+		{"rejected content", "Is this spam?", localizer.MustLocalize(locale.EventCreationErrorQuestionRejected)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			rec, b := newRecordingTelegramServer(t)
+			fsm := newQuestionValidationFSM(t, b, &rejectingContentValidator{word: "spam"})
+
+			userID := int64(42)
+			chatID := int64(42)
+			previousErrorID := 7
+			userMessageID := 55
+
+			sessionContext := &domain.EventCreationContext{
+				ChatID:             chatID,
+				GroupID:            1,
+				LastBotMessageID:   5,
+				LastErrorMessageID: previousErrorID,
+			}
+			if err := fsm.storage.Set(ctx, userID, StateAskQuestion, sessionContext.ToMap()); err != nil {
+				t.Fatalf("failed to set session: %v", err)
+			}
+
+			if err := fsm.handleQuestionInput(ctx, userID, chatID, tc.question, userMessageID, sessionContext); err != nil {
+				t.Fatalf("handleQuestionInput returned error: %v", err)
+			}
+
+			// Error message must be sent
+			texts := rec.texts()
+			if len(texts) != 1 || texts[0] != tc.expectedError {
+				t.Fatalf("expected error message %q, got %v", tc.expectedError, texts)
+			}
+
+			// Previous error and invalid user input must be cleaned up
+			deleted := rec.deletedIDs()
+			if len(deleted) != 2 || deleted[0] != previousErrorID || deleted[1] != userMessageID {
+				t.Errorf("expected deleted messages [%d %d], got %v", previousErrorID, userMessageID, deleted)
+			}
+
+			// State must remain at ask_question with the new error message tracked
+			state, data, err := fsm.storage.Get(ctx, userID)
+			if err != nil {
+				t.Fatalf("failed to get session: %v", err)
+			}
+			if state != StateAskQuestion {
+				t.Errorf("expected state %s, got %s", StateAskQuestion, state)
+			}
+			restored := &domain.EventCreationContext{}
+			if err := restored.FromMap(data); err != nil {
+				t.Fatalf("failed to restore context: %v", err)
+			}
+			if restored.LastErrorMessageID == 0 || restored.LastErrorMessageID == previousErrorID {
+				t.Errorf("expected new error message ID to be stored, got %d", restored.LastErrorMessageID)
+			}
+			if restored.Question != "" {
+				t.Errorf("expected question not to be stored, got %q", restored.Question)
+			}
+		})
+	}
+}
+
+func TestHandleQuestionInput_ValidQuestionAccepted(t *testing.T) {
+	ctx := context.Background()
+	_, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+
+	userID := int64(42)
+	sessionContext := &domain.EventCreationContext{ChatID: userID, GroupID: 1}
+	if err := fsm.storage.Set(ctx, userID, StateAskQuestion, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	if err := fsm.handleQuestionInput(ctx, userID, userID, "Will it rain?", 10, sessionContext); err != nil {
+		t.Fatalf("handleQuestionInput returned error: %v", err)
+	}
+
+	state, _, err := fsm.storage.Get(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if state != StateAskEventType {
+		t.Errorf("expected state %s, got %s", StateAskEventType, state)
+	}
+}
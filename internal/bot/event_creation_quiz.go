@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// showAskQuizAnswer asks for the correct option of a quiz and transitions to StateAskQuizAnswer
+func (f *EventCreationFSM) showAskQuizAnswer(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext) error {
+	buttons := make([][]models.InlineKeyboardButton, 0, len(context.Options))
+	for i, opt := range context.Options {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: opt, CallbackData: mustEncodeCallback(cbQuizAnswer, i)},
+		})
+	}
+
+	messageID, err := f.showStep(ctx, chatID, context, f.requestLocalizer(ctx).MustLocalize(locale.EventQuizAnswerPrompt), &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, false)
+	if err != nil {
+		return err
+	}
+
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StatePollSettings, "new_state", StateAskQuizAnswer)
+	if err := f.storage.Set(ctx, userID, StateAskQuizAnswer, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to quiz answer step", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// handleQuizAnswerCallback stores the correct option of a quiz
+func (f *EventCreationFSM) handleQuizAnswerCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	if callback.Message.Message == nil {
+		return nil
+	}
+	chatID := callback.Message.Message.Chat.ID
+
+	if err := cb.Expect(cbQuizAnswer, 1); err != nil {
+		f.logger.Error("invalid quiz answer callback", "user_id", userID, "data", cb.String(), "error", err)
+		return nil
+	}
+	index, err := cb.Int(0)
+	if err != nil || index < 0 || index >= len(context.Options) {
+		f.logger.Error("invalid quiz answer", "user_id", userID, "data", cb.String(), "error", err)
+		return nil
+	}
+	context.QuizAnswer = index
+
+	// Delete the prompt (kept and edited in compact mode)
+	if context.CompactMode {
+		context.LastBotMessageID = callback.Message.Message.ID
+	} else {
+		f.deleteMessages(ctx, chatID, callback.Message.Message.ID)
+	}
+
+	if context.ReturnToConfirm {
+		return f.showConfirm(ctx, userID, chatID, context, StateAskQuizAnswer)
+	}
+
+	return f.showParticipants(ctx, userID, chatID, context)
+}
+
+// confirmAfterOptionsChange returns to the confirmation after the options were replaced from the preview.
+// The correct answer of a quiz is asked again, since it pointed into the old options;
+// probability events can't be quizzes.
+func (f *EventCreationFSM) confirmAfterOptionsChange(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext, oldState string) error {
+	if context.EventType == domain.EventTypeProbability {
+		context.IsQuiz = false
+	}
+	if context.IsQuiz {
+		return f.showAskQuizAnswer(ctx, userID, chatID, context)
+	}
+	return f.showConfirm(ctx, userID, chatID, context, oldState)
+}
+
+// followMovedOption returns the quiz answer after the option at index moved one place in direction,
+// so the answer stays on the same option
+func followMovedOption(answer, index int, direction string) int {
+	target := index - 1
+	if direction == "down" {
+		target = index + 1
+	}
+	switch answer {
+	case index:
+		return target
+	case target:
+		return index
+	}
+	return answer
+}
@@ -0,0 +1,191 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreation_QuizStep(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(12345)
+	rec, b := newPollTelegramServer(t, nil)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	fsmStorage := storage.NewFSMStorage(queue, log)
+	fsm := NewEventCreationFSM(
+		fsmStorage,
+		b,
+		domain.NewEventManager(eventRepo, predictionRepo, nil, log),
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		nil,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		ratingRepo,
+		storage.NewGroupMembershipRepository(queue),
+		storage.NewUserRepository(queue),
+		nil,
+		&config.Config{Timezone: time.UTC, DefaultPollType: "quiz"},
+		log,
+		localizer,
+	)
+
+	startAt := func(state string, sessionContext *domain.EventCreationContext) {
+		t.Helper()
+		sessionContext.ChatID = userID
+		sessionContext.GroupID = groupID
+		sessionContext.Question = "Which planet is the largest?"
+		if sessionContext.EventType == "" {
+			sessionContext.EventType = domain.EventTypeMultiOption
+		}
+		if sessionContext.Options == nil {
+			sessionContext.Options = []string{"Mars", "Jupiter", "Venus"}
+		}
+		sessionContext.Deadline = time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+		if err := fsmStorage.Set(ctx, userID, state, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+	}
+	press := func(data string) {
+		t.Helper()
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+			},
+		}
+		if err := fsm.HandleCallback(ctx, callback); err != nil {
+			t.Fatalf("HandleCallback(%s) failed: %v", data, err)
+		}
+	}
+	session := func(expectedState string) *domain.EventCreationContext {
+		t.Helper()
+		state, data, err := fsmStorage.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		if state != expectedState {
+			t.Fatalf("expected state %s, got %s", expectedState, state)
+		}
+		loaded := &domain.EventCreationContext{}
+		if err := loaded.FromMap(data); err != nil {
+			t.Fatalf("failed to load context: %v", err)
+		}
+		return loaded
+	}
+
+	t.Run("default poll type preselects a quiz without revoting", func(t *testing.T) {
+		startAt(StateAskPhoto, &domain.EventCreationContext{})
+		press(mustEncodeCallback(cbEventPhoto, "skip"))
+		loaded := session(StatePollSettings)
+		if !loaded.IsQuiz || loaded.AllowsRevoting {
+			t.Errorf("expected a quiz without revoting, got quiz=%v revoting=%v", loaded.IsQuiz, loaded.AllowsRevoting)
+		}
+	})
+
+	t.Run("probability events are never quizzes", func(t *testing.T) {
+		startAt(StateAskPhoto, &domain.EventCreationContext{EventType: domain.EventTypeProbability, Options: []string{"0-25%", "25-50%", "50-75%", "75-100%"}})
+		press(mustEncodeCallback(cbEventPhoto, "skip"))
+		press(mustEncodeCallback(cbPollSetting, "quiz"))
+		if loaded := session(StatePollSettings); loaded.IsQuiz || !loaded.AllowsRevoting {
+			t.Errorf("expected a regular poll, got quiz=%v revoting=%v", loaded.IsQuiz, loaded.AllowsRevoting)
+		}
+	})
+
+	t.Run("revoting and quiz exclude each other", func(t *testing.T) {
+		startAt(StatePollSettings, &domain.EventCreationContext{IsQuiz: true})
+		press(mustEncodeCallback(cbPollSetting, "allows_revoting"))
+		if loaded := session(StatePollSettings); loaded.IsQuiz || !loaded.AllowsRevoting {
+			t.Errorf("expected revoting to turn the quiz off, got quiz=%v revoting=%v", loaded.IsQuiz, loaded.AllowsRevoting)
+		}
+		press(mustEncodeCallback(cbPollSetting, "quiz"))
+		if loaded := session(StatePollSettings); !loaded.IsQuiz || loaded.AllowsRevoting {
+			t.Errorf("expected the quiz to turn revoting off, got quiz=%v revoting=%v", loaded.IsQuiz, loaded.AllowsRevoting)
+		}
+	})
+
+	t.Run("quiz asks for the correct answer", func(t *testing.T) {
+		startAt(StatePollSettings, &domain.EventCreationContext{IsQuiz: true})
+		press(mustEncodeCallback(cbPollSetting, "done"))
+		session(StateAskQuizAnswer)
+
+		// Answers outside the options are ignored
+		press(mustEncodeCallback(cbQuizAnswer, 3))
+		session(StateAskQuizAnswer)
+
+		press(mustEncodeCallback(cbQuizAnswer, 1))
+		if loaded := session(StateConfirm); loaded.QuizAnswer != 1 {
+			t.Errorf("expected answer 1, got %d", loaded.QuizAnswer)
+		}
+	})
+
+	t.Run("reordering keeps the answer on its option", func(t *testing.T) {
+		startAt(StateReorderOptions, &domain.EventCreationContext{IsQuiz: true, QuizAnswer: 1, ReturnToConfirm: true})
+		press(mustEncodeCallback(cbReorderOption, 1, "up"))
+		loaded := session(StateReorderOptions)
+		if loaded.Options[loaded.QuizAnswer] != "Jupiter" {
+			t.Errorf("expected the answer to follow Jupiter, got %q in %v", loaded.Options[loaded.QuizAnswer], loaded.Options)
+		}
+	})
+
+	t.Run("created quiz sends a quiz poll", func(t *testing.T) {
+		startAt(StateConfirm, &domain.EventCreationContext{IsQuiz: true, QuizAnswer: 1})
+		press(mustEncodeCallback(cbConfirm, "yes"))
+
+		event, err := eventRepo.GetEventByPollID(ctx, "poll_900")
+		if err != nil || event == nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		if !event.IsQuiz() || *event.QuizAnswer != 1 || event.AllowsRevoting {
+			t.Errorf("expected a quiz with answer 1 and no revoting, got answer %v revoting %v", event.QuizAnswer, event.AllowsRevoting)
+		}
+
+		polls := rec.polls()
+		if len(polls) == 0 {
+			t.Fatal("expected a poll to be sent")
+		}
+		poll := polls[len(polls)-1]
+		if poll["type"] != "quiz" || poll["correct_option_id"] != float64(1) {
+			t.Errorf("expected a quiz poll with correct option 1, got type %v correct option %v", poll["type"], poll["correct_option_id"])
+		}
+	})
+}
+
+func TestFollowMovedOption(t *testing.T) {
+	tests := []struct {
+		answer, index int
+		direction     string
+		want          int
+	}{
+		{answer: 1, index: 1, direction: "up", want: 0},
+		{answer: 0, index: 1, direction: "up", want: 1},
+		{answer: 2, index: 1, direction: "down", want: 1},
+		{answer: 2, index: 0, direction: "down", want: 2},
+	}
+	for _, tt := range tests {
+		if got := followMovedOption(tt.answer, tt.index, tt.direction); got != tt.want {
+			t.Errorf("followMovedOption(%d, %d, %s) = %d, want %d", tt.answer, tt.index, tt.direction, got, tt.want)
+		}
+	}
+}
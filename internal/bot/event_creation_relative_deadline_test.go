@@ -0,0 +1,93 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+)
+
+func TestEventCreationRelativeDeadline(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	cfg := &config.Config{Timezone: time.UTC, MinDeadlineOffset: 10 * time.Minute, MaxDeadlineOffset: 365 * 24 * time.Hour}
+	fsm := NewEventCreationFSM(storage.NewFSMStorage(queue, log), b, nil, nil, nil, storage.NewGroupRepository(queue), nil, nil, nil, nil, nil, cfg, log, localizer)
+
+	// enterDeadline types a deadline and returns the resulting state and session context
+	enterDeadline := func(text string) (string, *domain.EventCreationContext) {
+		t.Helper()
+		sessionContext := &domain.EventCreationContext{ChatID: userID, GroupID: groupID, Question: "Q?"}
+		if err := fsm.storage.Set(ctx, userID, StateAskDeadline, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+		if err := fsm.handleDeadlineInput(ctx, userID, userID, text, 50, sessionContext); err != nil {
+			t.Fatalf("handleDeadlineInput failed: %v", err)
+		}
+		state, _, err := fsm.storage.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		return state, sessionContext
+	}
+
+	t.Run("a relative deadline is accepted", func(t *testing.T) {
+		state, sessionContext := enterDeadline("in 3 days 18:00")
+		if state != StateAskReminders {
+			t.Fatalf("expected %s, got %s", StateAskReminders, state)
+		}
+		expected := time.Now().UTC().AddDate(0, 0, 3)
+		expected = time.Date(expected.Year(), expected.Month(), expected.Day(), 18, 0, 0, 0, time.UTC)
+		if !sessionContext.Deadline.Equal(expected) {
+			t.Errorf("expected deadline %v, got %v", expected, sessionContext.Deadline)
+		}
+	})
+
+	t.Run("a Russian relative deadline is accepted", func(t *testing.T) {
+		state, _ := enterDeadline("через неделю")
+		if state != StateAskReminders {
+			t.Errorf("expected %s, got %s", StateAskReminders, state)
+		}
+	})
+
+	t.Run("the absolute format still works", func(t *testing.T) {
+		state, _ := enterDeadline(time.Now().UTC().Add(48 * time.Hour).Format("02.01.2006 15:04"))
+		if state != StateAskReminders {
+			t.Errorf("expected %s, got %s", StateAskReminders, state)
+		}
+	})
+
+	t.Run("relative deadlines go through the range check", func(t *testing.T) {
+		state, _ := enterDeadline("in 2 years")
+		if state != StateAskDeadline {
+			t.Errorf("expected to stay in %s, got %s", StateAskDeadline, state)
+		}
+	})
+
+	t.Run("unparseable input is rejected with examples", func(t *testing.T) {
+		state, _ := enterDeadline("sometime soon")
+		if state != StateAskDeadline {
+			t.Errorf("expected to stay in %s, got %s", StateAskDeadline, state)
+		}
+		texts := rec.texts()
+		if text := texts[len(texts)-1]; !strings.Contains(text, "in 3 days") || !strings.Contains(text, "tomorrow 18:00") {
+			t.Errorf("expected relative examples in the error, got %q", text)
+		}
+	})
+}
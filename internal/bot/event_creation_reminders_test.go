@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreation_RemindersStep(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(12345)
+	rec, b := newPollTelegramServer(t, nil)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	reminderRepo := storage.NewReminderRepository(queue)
+	fsmStorage := storage.NewFSMStorage(queue, log)
+	fsm := NewEventCreationFSM(
+		fsmStorage,
+		b,
+		domain.NewEventManager(eventRepo, predictionRepo, nil, log),
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		nil,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		ratingRepo,
+		storage.NewGroupMembershipRepository(queue),
+		storage.NewUserRepository(queue),
+		nil,
+		&config.Config{Timezone: time.UTC},
+		log,
+		localizer,
+	)
+	fsm.SetNotificationService(domain.NewNotificationService(b, eventRepo, predictionRepo, ratingRepo, reminderRepo, log, localizer))
+
+	startAt := func(state string, sessionContext *domain.EventCreationContext) {
+		t.Helper()
+		sessionContext.ChatID = userID
+		sessionContext.GroupID = groupID
+		sessionContext.Question = "Will it rain tomorrow?"
+		sessionContext.EventType = domain.EventTypeBinary
+		sessionContext.Options = []string{"Yes", "No"}
+		if err := fsmStorage.Set(ctx, userID, state, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+	}
+	send := func(text string) {
+		t.Helper()
+		message := &models.Message{ID: 20, Text: text, From: &models.User{ID: userID}, Chat: models.Chat{ID: userID}}
+		if err := fsm.HandleMessage(ctx, &models.Update{Message: message}); err != nil {
+			t.Fatalf("HandleMessage failed: %v", err)
+		}
+	}
+	press := func(data string) {
+		t.Helper()
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+			},
+		}
+		if err := fsm.HandleCallback(ctx, callback); err != nil {
+			t.Fatalf("HandleCallback(%s) failed: %v", data, err)
+		}
+	}
+	session := func(expectedState string) *domain.EventCreationContext {
+		t.Helper()
+		state, data, err := fsmStorage.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		if state != expectedState {
+			t.Fatalf("expected state %s, got %s", expectedState, state)
+		}
+		loaded := &domain.EventCreationContext{}
+		if err := loaded.FromMap(data); err != nil {
+			t.Fatalf("failed to load context: %v", err)
+		}
+		return loaded
+	}
+	lastText := func() string {
+		texts := rec.texts()
+		if len(texts) == 0 {
+			return ""
+		}
+		return texts[len(texts)-1]
+	}
+
+	t.Run("deadline leads to the reminders step", func(t *testing.T) {
+		startAt(StateAskDeadline, &domain.EventCreationContext{})
+		press(mustEncodeCallback(cbDeadlinePreset, "3d"))
+		session(StateAskReminders)
+	})
+
+	t.Run("custom offsets are parsed", func(t *testing.T) {
+		startAt(StateAskReminders, &domain.EventCreationContext{Deadline: time.Now().Add(48 * time.Hour)})
+		send("30m, 2h")
+
+		expected := []time.Duration{2 * time.Hour, 30 * time.Minute}
+		if offsets := session(StateAskLockVotes).ReminderOffsets; !reflect.DeepEqual(offsets, expected) {
+			t.Errorf("expected offsets %v, got %v", expected, offsets)
+		}
+	})
+
+	t.Run("invalid offsets keep the step", func(t *testing.T) {
+		startAt(StateAskReminders, &domain.EventCreationContext{Deadline: time.Now().Add(48 * time.Hour)})
+		send("soon")
+
+		session(StateAskReminders)
+		if text := lastText(); text != localizer.MustLocalize(locale.EventRemindersErrorInvalid) {
+			t.Errorf("expected invalid reminders error, got %q", text)
+		}
+	})
+
+	t.Run("offsets past the deadline are rejected", func(t *testing.T) {
+		startAt(StateAskReminders, &domain.EventCreationContext{Deadline: time.Now().Add(48 * time.Hour)})
+		send("1d 3d")
+
+		session(StateAskReminders)
+		if text := lastText(); text != localizer.MustLocalize(locale.EventRemindersErrorTooLate) {
+			t.Errorf("expected too late reminders error, got %q", text)
+		}
+	})
+
+	t.Run("default and preset buttons", func(t *testing.T) {
+		startAt(StateAskReminders, &domain.EventCreationContext{Deadline: time.Now().Add(48 * time.Hour)})
+		press(mustEncodeCallback(cbEventReminders, "preset", 0))
+		if offsets := session(StateAskLockVotes).ReminderOffsets; !reflect.DeepEqual(offsets, reminderPresets[0]) {
+			t.Errorf("expected preset offsets %v, got %v", reminderPresets[0], offsets)
+		}
+
+		startAt(StateAskReminders, &domain.EventCreationContext{Deadline: time.Now().Add(48 * time.Hour)})
+		press(mustEncodeCallback(cbEventReminders, "default"))
+		if offsets := session(StateAskLockVotes).ReminderOffsets; len(offsets) != 0 {
+			t.Errorf("expected default reminders, got %v", offsets)
+		}
+	})
+
+	t.Run("created event schedules its reminders", func(t *testing.T) {
+		deadline := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+		startAt(StateConfirm, &domain.EventCreationContext{
+			Deadline:        deadline,
+			ReminderOffsets: []time.Duration{24 * time.Hour, time.Hour},
+		})
+		press(mustEncodeCallback(cbConfirm, "yes"))
+
+		event, err := eventRepo.GetEventByPollID(ctx, "poll_900")
+		if err != nil || event == nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		if !reflect.DeepEqual(event.ReminderOffsets, []time.Duration{24 * time.Hour, time.Hour}) {
+			t.Errorf("expected offsets to be stored on the event, got %v", event.ReminderOffsets)
+		}
+
+		reminders, err := reminderRepo.ClaimScheduledReminders(ctx, "test", deadline.Add(-time.Minute), 10)
+		if err != nil {
+			t.Fatalf("ClaimScheduledReminders failed: %v", err)
+		}
+		if len(reminders) != 2 {
+			t.Fatalf("expected 2 scheduled reminders, got %v", reminders)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// moveOption moves the option at index one place up or down and reports whether it moved.
+// Binary events have two options, so any move swaps them.
+func moveOption(options []string, index int, direction string) bool {
+	target := index
+	switch direction {
+	case "up":
+		target = index - 1
+	case "down":
+		target = index + 1
+	default:
+		return false
+	}
+	if index < 0 || index >= len(options) || target < 0 || target >= len(options) {
+		return false
+	}
+
+	options[index], options[target] = options[target], options[index]
+	return true
+}
+
+// showReorderOptions sends the options in their current order with buttons moving them and
+// transitions to StateReorderOptions. Finishing returns to the confirmation.
+func (f *EventCreationFSM) showReorderOptions(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext) error {
+	messageID, err := f.showStep(ctx, chatID, context, f.buildReorderText(ctx, context), f.buildReorderKeyboard(ctx, context), false)
+	if err != nil {
+		return err
+	}
+
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateConfirm, "new_state", StateReorderOptions)
+	if err := f.storage.Set(ctx, userID, StateReorderOptions, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to reorder options", "user_id", userID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// buildReorderText lists the options in the order the poll will show them
+func (f *EventCreationFSM) buildReorderText(ctx context.Context, context *domain.EventCreationContext) string {
+	var options strings.Builder
+	for i, opt := range context.Options {
+		options.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.OptionListItem, fmt.Sprintf("%d", i+1), opt))
+		options.WriteString("\n")
+	}
+	return f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventReorderTitle, options.String())
+}
+
+// buildReorderKeyboard returns one row per option with buttons moving it up and down, then the done button
+func (f *EventCreationFSM) buildReorderKeyboard(ctx context.Context, context *domain.EventCreationContext) *models.InlineKeyboardMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	for i, opt := range context.Options {
+		var row []models.InlineKeyboardButton
+		if i > 0 {
+			row = append(row, models.InlineKeyboardButton{
+				Text:         f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventReorderMoveUp, opt),
+				CallbackData: mustEncodeCallback(cbReorderOption, i, "up"),
+			})
+		}
+		if i < len(context.Options)-1 {
+			row = append(row, models.InlineKeyboardButton{
+				Text:         f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventReorderMoveDown, opt),
+				CallbackData: mustEncodeCallback(cbReorderOption, i, "down"),
+			})
+		}
+		buttons = append(buttons, row)
+	}
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventReorderDone), CallbackData: mustEncodeCallback(cbReorderOption, "done")},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// handleReorderCallback moves an option and refreshes the reorder message, or returns to the confirmation
+func (f *EventCreationFSM) handleReorderCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.EventCreationContext) error {
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	chatID := callback.Message.Message.Chat.ID
+
+	// Callback data: reorder:done or reorder:INDEX:up|down
+	if action, _ := cb.Field(0); action == "done" {
+		// Delete the reorder message (kept and edited in compact mode)
+		if context.CompactMode {
+			context.LastBotMessageID = callback.Message.Message.ID
+		} else {
+			f.deleteMessages(ctx, chatID, callback.Message.Message.ID)
+		}
+		return f.showConfirm(ctx, userID, chatID, context, StateReorderOptions)
+	}
+
+	if err := cb.Expect(cbReorderOption, 2); err != nil {
+		return err
+	}
+	index, err := cb.Int(0)
+	if err != nil {
+		return err
+	}
+	direction, _ := cb.Field(1)
+
+	// Buttons of an outdated message may point past the ends
+	if !moveOption(context.Options, index, direction) {
+		return nil
+	}
+	if context.IsQuiz {
+		context.QuizAnswer = followMovedOption(context.QuizAnswer, index, direction)
+	}
+
+	_, err = f.bot.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        f.buildReorderText(ctx, context),
+		ReplyMarkup: f.buildReorderKeyboard(ctx, context),
+	})
+	if err != nil {
+		f.logger.Warn("failed to update reorder message", "user_id", userID, "error", err)
+	}
+
+	if err := f.storage.Set(ctx, userID, StateReorderOptions, context.ToMap()); err != nil {
+		f.logger.Error("failed to save option order", "user_id", userID, "error", err)
+		return err
+	}
+
+	f.logger.Debug("option moved", "user_id", userID, "index", index, "direction", direction)
+	return nil
+}
@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestMoveOption(t *testing.T) {
+	tests := []struct {
+		index     int
+		direction string
+		want      []string
+		moved     bool
+	}{
+		{1, "up", []string{"B", "A", "C"}, true},
+		{1, "down", []string{"A", "C", "B"}, true},
+		{0, "up", []string{"A", "B", "C"}, false},
+		{2, "down", []string{"A", "B", "C"}, false},
+		{5, "up", []string{"A", "B", "C"}, false},
+		{1, "left", []string{"A", "B", "C"}, false},
+	}
+
+	for _, tt := range tests {
+		options := []string{"A", "B", "C"}
+		if moved := moveOption(options, tt.index, tt.direction); moved != tt.moved || !slices.Equal(options, tt.want) {
+			t.Errorf("moveOption(%d, %q) = %v %v, want %v %v", tt.index, tt.direction, moved, options, tt.moved, tt.want)
+		}
+	}
+
+	// Binary events just swap Yes and No
+	options := []string{"Yes", "No"}
+	if !moveOption(options, 0, "down") || !slices.Equal(options, []string{"No", "Yes"}) {
+		t.Errorf("expected a swap, got %v", options)
+	}
+}
+
+func TestEventReorder_ReturnsToConfirmWithNewOrder(t *testing.T) {
+	ctx := context.Background()
+	rec, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+
+	userID := int64(42)
+	chatID := int64(42)
+	sessionContext := &domain.EventCreationContext{
+		ChatID:    chatID,
+		GroupID:   1,
+		Question:  "Who wins?",
+		EventType: domain.EventTypeMultiOption,
+		Options:   []string{"Red", "Green", "Blue"},
+		Deadline:  time.Now().Add(48 * time.Hour).UTC(),
+	}
+
+	if err := fsm.showConfirm(ctx, userID, chatID, sessionContext, StateSelectParticipants); err != nil {
+		t.Fatalf("showConfirm returned error: %v", err)
+	}
+	if markup := rec.markups[len(rec.markups)-1]; !strings.Contains(markup, mustEncodeCallback(cbConfirm, "edit", previewStepReorder)) {
+		t.Fatalf("expected a reorder button, got %s", markup)
+	}
+
+	callback := func(data string, messageID int) error {
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		state, stored, err := fsm.storage.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		restored := &domain.EventCreationContext{}
+		if err := restored.FromMap(stored); err != nil {
+			t.Fatalf("failed to restore context: %v", err)
+		}
+		query := &models.CallbackQuery{
+			ID:      "cb",
+			From:    models.User{ID: userID},
+			Data:    data,
+			Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: messageID, Chat: models.Chat{ID: chatID}}},
+		}
+		if state == StateConfirm {
+			return fsm.handleConfirmCallback(ctx, userID, query, cb, restored)
+		}
+		return fsm.handleReorderCallback(ctx, userID, query, cb, restored)
+	}
+
+	if err := callback(mustEncodeCallback(cbConfirm, "edit", previewStepReorder), sessionContext.ConfirmationMessageID); err != nil {
+		t.Fatalf("failed to open reorder: %v", err)
+	}
+	state, _, _ := fsm.storage.Get(ctx, userID)
+	if state != StateReorderOptions {
+		t.Fatalf("expected state %s, got %s", StateReorderOptions, state)
+	}
+	texts := rec.texts()
+	reorderMessageID := rec.nextID
+	if !strings.Contains(texts[len(texts)-1], "1) Red") {
+		t.Errorf("expected the current order, got %q", texts[len(texts)-1])
+	}
+
+	// Move Blue to the top
+	for _, data := range []string{mustEncodeCallback(cbReorderOption, 2, "up"), mustEncodeCallback(cbReorderOption, 1, "up")} {
+		if err := callback(data, reorderMessageID); err != nil {
+			t.Fatalf("failed to move option: %v", err)
+		}
+	}
+	if err := callback(mustEncodeCallback(cbReorderOption, "done"), reorderMessageID); err != nil {
+		t.Fatalf("failed to finish reorder: %v", err)
+	}
+
+	state, stored, err := fsm.storage.Get(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if state != StateConfirm {
+		t.Fatalf("expected state %s, got %s", StateConfirm, state)
+	}
+	restored := &domain.EventCreationContext{}
+	if err := restored.FromMap(stored); err != nil {
+		t.Fatalf("failed to restore context: %v", err)
+	}
+	if want := []string{"Blue", "Red", "Green"}; !slices.Equal(restored.Options, want) {
+		t.Errorf("expected options %v, got %v", want, restored.Options)
+	}
+	if !slices.Contains(rec.deletedIDs(), reorderMessageID) {
+		t.Errorf("expected the reorder message %d to be deleted, got %v", reorderMessageID, rec.deletedIDs())
+	}
+	// The new preview shows the poll in the new order
+	if preview := fsm.buildEventPreview(ctx, restored); !strings.Contains(preview, "○ Blue\n○ Red\n○ Green") {
+		t.Errorf("expected the preview in the new order, got %q", preview)
+	}
+}
+
+func TestEventReorder_NotOfferedForProbabilityEvents(t *testing.T) {
+	_, b := newRecordingTelegramServer(t)
+	fsm := newQuestionValidationFSM(t, b, nil)
+
+	kb := fsm.buildConfirmKeyboard(context.Background(), &domain.EventCreationContext{EventType: domain.EventTypeProbability})
+	for _, row := range kb.InlineKeyboard {
+		for _, button := range row {
+			if button.CallbackData == mustEncodeCallback(cbConfirm, "edit", previewStepReorder) {
+				t.Fatal("expected no reorder button for a probability event")
+			}
+		}
+	}
+}
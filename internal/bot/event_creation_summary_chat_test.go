@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreation_SummaryChat(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(12345)
+	groupChatID := int64(-100500)
+
+	for _, summaryToDM := range []bool{false, true} {
+		t.Run("summary to DM "+strconv.FormatBool(summaryToDM), func(t *testing.T) {
+			rec, b := newPollTelegramServer(t, nil)
+			queue, groupID := setupTestGroupAndDB(t, groupChatID, userID)
+			t.Cleanup(queue.Close)
+
+			log := logger.New(logger.ERROR)
+			localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+			if err != nil {
+				t.Fatalf("failed to create localizer: %v", err)
+			}
+
+			membershipRepo := storage.NewGroupMembershipRepository(queue)
+			membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+			if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+				t.Fatalf("failed to create membership: %v", err)
+			}
+
+			groupRepo := storage.NewGroupRepository(queue)
+			eventRepo := storage.NewEventRepository(queue)
+			predictionRepo := storage.NewPredictionRepository(queue)
+			ratingRepo := storage.NewRatingRepository(queue)
+			fsmStorage := storage.NewFSMStorage(queue, log)
+			fsm := NewEventCreationFSM(
+				fsmStorage,
+				b,
+				domain.NewEventManager(eventRepo, predictionRepo, nil, log),
+				domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+				domain.NewGroupContextResolver(groupRepo),
+				groupRepo,
+				storage.NewForumTopicRepository(queue),
+				ratingRepo,
+				membershipRepo,
+				storage.NewUserRepository(queue),
+				nil,
+				&config.Config{Timezone: time.UTC, EventSummaryToDM: summaryToDM},
+				log,
+				localizer,
+			)
+
+			// The dialog is started in the group chat
+			if err := fsm.Start(ctx, userID, groupChatID); err != nil {
+				t.Fatalf("failed to start session: %v", err)
+			}
+			_, data, err := fsmStorage.Get(ctx, userID)
+			if err != nil {
+				t.Fatalf("failed to get session: %v", err)
+			}
+			sessionContext := &domain.EventCreationContext{}
+			if err := sessionContext.FromMap(data); err != nil {
+				t.Fatalf("failed to load context: %v", err)
+			}
+			wantSummaryChat := groupChatID
+			if summaryToDM {
+				wantSummaryChat = userID
+			}
+			if sessionContext.ChatID != groupChatID || sessionContext.SummaryChat() != wantSummaryChat {
+				t.Fatalf("expected chat %d with summary chat %d, got %d and %d", groupChatID, wantSummaryChat, sessionContext.ChatID, sessionContext.SummaryChat())
+			}
+
+			sessionContext.Question = "Will it rain tomorrow?"
+			sessionContext.EventType = domain.EventTypeBinary
+			sessionContext.Options = []string{"Yes", "No"}
+			sessionContext.Deadline = time.Now().Add(48 * time.Hour)
+			if err := fsmStorage.Set(ctx, userID, StateConfirm, sessionContext.ToMap()); err != nil {
+				t.Fatalf("failed to set session: %v", err)
+			}
+
+			before := len(rec.texts())
+			err = fsm.HandleCallback(ctx, &models.CallbackQuery{
+				ID:   "cb",
+				From: models.User{ID: userID},
+				Data: mustEncodeCallback(cbConfirm, "yes"),
+				Message: models.MaybeInaccessibleMessage{
+					Message: &models.Message{ID: 10, Chat: models.Chat{ID: groupChatID}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("failed to confirm: %v", err)
+			}
+
+			// The poll goes to the group either way
+			if polls := rec.polls(); len(polls) != 1 || polls[0]["chat_id"] != float64(groupChatID) {
+				t.Fatalf("expected the poll in the group chat, got %v", polls)
+			}
+
+			// Achievement notifications are sent too, so the messages are joined per chat
+			sent := map[string]string{}
+			texts, chats := rec.texts()[before:], rec.chats()[before:]
+			for i := range texts {
+				sent[chats[i]] += texts[i] + "\n"
+			}
+			group := strconv.FormatInt(groupChatID, 10)
+			dm := strconv.FormatInt(userID, 10)
+			pollReference := localizer.MustLocalize(locale.EventCreationPollReference)
+			if summaryToDM {
+				if !strings.Contains(sent[dm], pollReference) {
+					t.Errorf("expected the summary in the private chat, got %v", sent)
+				}
+				if !strings.Contains(sent[group], localizer.MustLocalize(locale.EventCreationSummarySentToDM)) || strings.Contains(sent[group], pollReference) {
+					t.Errorf("expected a short note in the group, got %v", sent)
+				}
+			} else {
+				if !strings.Contains(sent[group], pollReference) || strings.Contains(sent[dm], pollReference) {
+					t.Errorf("expected the summary in the group only, got %v", sent)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	tgbot "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// eventDiscussURL builds a t.me link where members can discuss an event: the forum topic
+// for forum events, otherwise the poll message itself so members can reply to it.
+// Returns "" for chats without a public username, since private supergroups have no shareable deep links.
+func eventDiscussURL(chat models.Chat, messageThreadID int, pollMessageID int) string {
+	if chat.Username == "" {
+		return ""
+	}
+	if messageThreadID != 0 {
+		return fmt.Sprintf("https://t.me/%s/%d", chat.Username, messageThreadID)
+	}
+	return fmt.Sprintf("https://t.me/%s/%d", chat.Username, pollMessageID)
+}
+
+// attachDiscussButton adds a "Discuss" button to a published poll.
+// The poll message ID is only known after sending, so the button is added by editing the message.
+// Failures are logged and never returned: the poll stays published without the button.
+func attachDiscussButton(ctx context.Context, b *tgbot.Bot, logger domain.Logger, localizer locale.Localizer, pollMsg *models.Message, messageThreadID int) {
+	url := eventDiscussURL(pollMsg.Chat, messageThreadID, pollMsg.ID)
+	if url == "" {
+		return
+	}
+
+	_, err := b.EditMessageReplyMarkup(ctx, &tgbot.EditMessageReplyMarkupParams{
+		ChatID:    pollMsg.Chat.ID,
+		MessageID: pollMsg.ID,
+		ReplyMarkup: &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{
+				{
+					{Text: localizer.MustLocalize(locale.EventDiscussButton), URL: url},
+				},
+			},
+		},
+	})
+	if err != nil {
+		logger.Warn("failed to attach discuss button to poll", "telegram_chat_id", pollMsg.Chat.ID, "message_id", pollMsg.ID, "error", err)
+	}
+}
@@ -0,0 +1,29 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventDiscussURL(t *testing.T) {
+	tests := []struct {
+		name            string
+		chat            models.Chat
+		messageThreadID int
+		pollMessageID   int
+		expected        string
+	}{
+		{"forum topic", models.Chat{ID: -100123, Username: "predictions"}, 42, 900, "https://t.me/predictions/42"},
+		{"regular group links to the poll", models.Chat{ID: -100123, Username: "predictions"}, 0, 900, "https://t.me/predictions/900"},
+		{"private supergroup has no link", models.Chat{ID: -100123}, 42, 900, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventDiscussURL(tt.chat, tt.messageThreadID, tt.pollMessageID); got != tt.expected {
+				t.Errorf("eventDiscussURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// findDuplicateEvent returns an active event of the group that looks like the one being confirmed
+// (same normalized question, deadline within DUPLICATE_EVENT_TOLERANCE_MINUTES), or nil.
+// Lookup failures are logged and never block creation.
+func (f *EventCreationFSM) findDuplicateEvent(ctx context.Context, event *domain.Event) *domain.Event {
+	if f.config == nil || f.config.DuplicateEventTolerance < 0 {
+		return nil
+	}
+
+	tolerance := time.Duration(f.config.DuplicateEventTolerance) * time.Minute
+	duplicate, err := f.eventManager.FindSimilarActiveEvent(ctx, event.GroupID, event.Question, event.Deadline, tolerance)
+	if err != nil {
+		f.logger.Error("failed to look for duplicate events", "group_id", event.GroupID, "error", err)
+		return nil
+	}
+
+	return duplicate
+}
+
+// showDuplicateWarning shows the active event the new one duplicates and lets the creator create it anyway,
+// change the question or cancel. The session stays in StateConfirm with the warning as the confirmation message.
+func (f *EventCreationFSM) showDuplicateWarning(ctx context.Context, userID int64, chatID int64, context *domain.EventCreationContext, group *domain.Group, duplicate *domain.Event) error {
+	text := f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationDuplicateWarning,
+		group.Name,
+		fmt.Sprintf("%d", duplicate.ID),
+		duplicate.Question,
+		duplicate.Deadline.In(f.config.Timezone).Format("02.01.2006 15:04"),
+	)
+	kb := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventCreationDuplicateCreate), CallbackData: mustEncodeCallback(cbConfirm, "duplicate")},
+			},
+			{
+				{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventPreviewEditQuestion), CallbackData: mustEncodeCallback(cbConfirm, "edit", previewStepQuestion)},
+				{Text: f.requestLocalizer(ctx).MustLocalize(locale.ConfirmButtonNo), CallbackData: mustEncodeCallback(cbConfirm, "no")},
+			},
+		},
+	}
+
+	messageID, err := f.showStep(ctx, chatID, context, text, kb, false)
+	if err != nil {
+		return err
+	}
+
+	context.ConfirmationMessageID = messageID
+	context.LastBotMessageID = messageID
+
+	f.logger.Info("duplicate event warning shown", "user_id", userID, "group_id", group.ID, "duplicate_event_id", duplicate.ID)
+	if err := f.storage.Set(ctx, userID, StateConfirm, context.ToMap()); err != nil {
+		f.logger.Error("failed to save duplicate warning", "user_id", userID, "error", err)
+		return err
+	}
+	return nil
+}
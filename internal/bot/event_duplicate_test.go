@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreation_DuplicateWarning(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(12345)
+	rec, b := newPollTelegramServer(t, nil)
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	groupRepo := storage.NewGroupRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	fsmStorage := storage.NewFSMStorage(queue, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
+	fsm := NewEventCreationFSM(
+		fsmStorage,
+		b,
+		eventManager,
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		domain.NewGroupContextResolver(groupRepo),
+		groupRepo,
+		storage.NewForumTopicRepository(queue),
+		ratingRepo,
+		membershipRepo,
+		storage.NewUserRepository(queue),
+		nil,
+		&config.Config{Timezone: time.UTC, DuplicateEventTolerance: 60},
+		log,
+		localizer,
+	)
+
+	deadline := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	existing := &domain.Event{
+		GroupID:   groupID,
+		Question:  "Will it rain tomorrow?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  deadline,
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: userID,
+	}
+	if err := eventManager.CreateEvent(ctx, existing); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	press := func(data string) {
+		t.Helper()
+		err := fsm.HandleCallback(ctx, &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("HandleCallback(%s) failed: %v", data, err)
+		}
+	}
+	confirm := func(question string, deadline time.Time) {
+		t.Helper()
+		sessionContext := &domain.EventCreationContext{
+			GroupID:   groupID,
+			ChatID:    userID,
+			Question:  question,
+			EventType: domain.EventTypeBinary,
+			Options:   []string{"Yes", "No"},
+			Deadline:  deadline,
+		}
+		if err := fsmStorage.Set(ctx, userID, StateConfirm, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+		press(mustEncodeCallback(cbConfirm, "yes"))
+	}
+	eventCount := func() int {
+		t.Helper()
+		events, err := eventManager.GetActiveEvents(ctx, groupID)
+		if err != nil {
+			t.Fatalf("failed to get events: %v", err)
+		}
+		return len(events)
+	}
+	state := func() string {
+		t.Helper()
+		state, _, err := fsmStorage.Get(ctx, userID)
+		if err == storage.ErrSessionNotFound {
+			return ""
+		}
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		return state
+	}
+	warning := localizer.MustLocalizeWithTemplate(locale.EventCreationDuplicateWarning,
+		"Test Group", "1", existing.Question, deadline.Format("02.01.2006 15:04"))
+	warningShown := func(before int) bool {
+		for _, text := range rec.texts()[before:] {
+			if text == warning {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("near duplicate is held until confirmed", func(t *testing.T) {
+		before := len(rec.texts())
+		confirm("  will it RAIN   tomorrow? ", deadline.Add(30*time.Minute))
+
+		if !warningShown(before) {
+			t.Fatalf("expected the duplicate warning, got %v", rec.texts()[before:])
+		}
+		if got := state(); got != StateConfirm {
+			t.Fatalf("expected state %s, got %q", StateConfirm, got)
+		}
+		if len(rec.polls()) != 0 || eventCount() != 1 {
+			t.Fatalf("expected nothing published before the creator decides")
+		}
+
+		press(mustEncodeCallback(cbConfirm, "duplicate"))
+		if len(rec.polls()) != 1 || eventCount() != 2 {
+			t.Errorf("expected the duplicate created, got %d polls and %d events", len(rec.polls()), eventCount())
+		}
+	})
+
+	t.Run("cancelling the duplicate creates nothing", func(t *testing.T) {
+		before := len(rec.texts())
+		// Only the first event is within the tolerance of this deadline
+		confirm("Will it rain tomorrow?", deadline.Add(-45*time.Minute))
+		if !warningShown(before) {
+			t.Fatalf("expected the duplicate warning, got %v", rec.texts()[before:])
+		}
+
+		press(mustEncodeCallback(cbConfirm, "no"))
+		if got := state(); got != "" {
+			t.Errorf("expected the session to end, got %q", got)
+		}
+		if len(rec.polls()) != 1 || eventCount() != 2 {
+			t.Errorf("expected no new event, got %d polls and %d events", len(rec.polls()), eventCount())
+		}
+	})
+
+	t.Run("distinct event is created without a warning", func(t *testing.T) {
+		before := len(rec.texts())
+		confirm("Will it rain tomorrow?", deadline.Add(3*time.Hour))
+		if warningShown(before) {
+			t.Errorf("expected no warning for a distant deadline")
+		}
+		if len(rec.polls()) != 2 || eventCount() != 3 {
+			t.Errorf("expected the event created, got %d polls and %d events", len(rec.polls()), eventCount())
+		}
+	})
+}
@@ -3,8 +3,11 @@ package bot
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ad/gitelegram-prediction-market/internal/config"
 	"github.com/ad/gitelegram-prediction-market/internal/domain"
@@ -37,6 +40,14 @@ type EventEditContext struct {
 	LastErrorMessageID int              `json:"last_error_message_id"`
 	GroupID            int64            `json:"group_id"`
 	EventType          domain.EventType `json:"event_type"`
+	IsQuiz             bool             `json:"is_quiz"`
+	HasVotes           bool             `json:"has_votes"`
+}
+
+// optionsEditable reports whether the options of the event can be edited: only multi-option events
+// have free-form options, and the correct answer of a quiz refers to its options as created
+func (c *EventEditContext) optionsEditable() bool {
+	return c.EventType == domain.EventTypeMultiOption && !c.IsQuiz
 }
 
 // ToMap converts EventEditContext to a map for JSON serialization
@@ -54,6 +65,8 @@ func (c *EventEditContext) ToMap() map[string]interface{} {
 		"last_error_message_id": c.LastErrorMessageID,
 		"group_id":              c.GroupID,
 		"event_type":            string(c.EventType),
+		"is_quiz":               c.IsQuiz,
+		"has_votes":             c.HasVotes,
 	}
 }
 
@@ -81,6 +94,12 @@ func (c *EventEditContext) FromMap(data map[string]interface{}) error {
 	if eventType, ok := data["event_type"].(string); ok {
 		c.EventType = domain.EventType(eventType)
 	}
+	if isQuiz, ok := data["is_quiz"].(bool); ok {
+		c.IsQuiz = isQuiz
+	}
+	if hasVotes, ok := data["has_votes"].(bool); ok {
+		c.HasVotes = hasVotes
+	}
 
 	// Parse options
 	if options, ok := data["original_options"].([]interface{}); ok {
@@ -132,6 +151,9 @@ type EventEditFSM struct {
 	config         *config.Config
 	logger         domain.Logger
 	localizer      locale.Localizer
+
+	notificationService *domain.NotificationService
+	localizerResolver   *locale.LocalizerResolver
 }
 
 // NewEventEditFSM creates a new FSM for event editing
@@ -157,7 +179,19 @@ func NewEventEditFSM(
 	}
 }
 
-// Start initializes a new FSM session for editing an event
+// SetNotificationService enables direct messages to voters about edited events (not set by default)
+func (f *EventEditFSM) SetNotificationService(notificationService *domain.NotificationService) {
+	f.notificationService = notificationService
+}
+
+// SetLocalizerResolver enables messages to other chats, e.g. to admins or group chats,
+// in the language chosen for them (the FSM localizer is used by default)
+func (f *EventEditFSM) SetLocalizerResolver(localizerResolver *locale.LocalizerResolver) {
+	f.localizerResolver = localizerResolver
+}
+
+// Start initializes a new FSM session for editing an event.
+// Events that already have votes can only get fixes: see domain.CheckVotedEventEdit.
 func (f *EventEditFSM) Start(ctx context.Context, userID int64, chatID int64, eventID int64) error {
 	// Get the event
 	event, err := f.eventManager.GetEvent(ctx, eventID)
@@ -166,16 +200,14 @@ func (f *EventEditFSM) Start(ctx context.Context, userID int64, chatID int64, ev
 		return err
 	}
 
-	// Check if event can be edited (no votes)
+	// Events without votes can be edited freely
 	canEdit, err := f.eventManager.CanEditEvent(ctx, eventID)
 	if err != nil {
 		f.logger.Error("failed to check if event can be edited", "event_id", eventID, "error", err)
 		return err
 	}
-
 	if !canEdit {
-		f.logger.Info("event cannot be edited - has votes", "event_id", eventID)
-		return domain.ErrEventHasVotes
+		f.logger.Info("event has votes - only fixes allowed", "event_id", eventID)
 	}
 
 	// Initialize context
@@ -190,6 +222,8 @@ func (f *EventEditFSM) Start(ctx context.Context, userID int64, chatID int64, ev
 		NewDeadline:      event.Deadline,
 		GroupID:          event.GroupID,
 		EventType:        event.EventType,
+		IsQuiz:           event.IsQuiz(),
+		HasVotes:         !canEdit,
 	}
 
 	if err := f.storage.Set(ctx, userID, StateEditSelectField, editContext.ToMap()); err != nil {
@@ -207,12 +241,15 @@ func (f *EventEditFSM) Start(ctx context.Context, userID int64, chatID int64, ev
 func (f *EventEditFSM) sendFieldSelectionMenu(ctx context.Context, userID int64, chatID int64, editCtx *EventEditContext) error {
 	// Build current state summary
 	var sb strings.Builder
-	sb.WriteString(f.localizer.MustLocalize(locale.EventEditTitle) + "\n\n")
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventEditCurrentQuestion, editCtx.NewQuestion) + "\n\n")
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventEditTitle) + "\n\n")
+	if editCtx.HasVotes {
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventEditVotedNote) + "\n\n")
+	}
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventEditCurrentQuestion, editCtx.NewQuestion) + "\n\n")
 
 	// Only show options for multi-option events
-	if editCtx.EventType == domain.EventTypeMultiOption {
-		sb.WriteString(f.localizer.MustLocalize(locale.EventEditCurrentOptions) + "\n")
+	if editCtx.optionsEditable() {
+		sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventEditCurrentOptions) + "\n")
 		for i, opt := range editCtx.NewOptions {
 			sb.WriteString(fmt.Sprintf("  %d) %s\n", i+1, opt))
 		}
@@ -220,28 +257,28 @@ func (f *EventEditFSM) sendFieldSelectionMenu(ctx context.Context, userID int64,
 	}
 
 	localDeadline := editCtx.NewDeadline.In(f.config.Timezone)
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventEditCurrentDeadline, localDeadline.Format("02.01.2006 15:04")) + "\n\n")
-	sb.WriteString(f.localizer.MustLocalize(locale.EventEditSelectFieldPrompt))
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventEditCurrentDeadline, localDeadline.Format("02.01.2006 15:04")) + "\n\n")
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventEditSelectFieldPrompt))
 
 	// Build keyboard based on event type
 	var buttons [][]models.InlineKeyboardButton
 	buttons = append(buttons, []models.InlineKeyboardButton{
-		{Text: f.localizer.MustLocalize(locale.EventEditButtonQuestion), CallbackData: fmt.Sprintf("edit_field:question:%d", editCtx.EventID)},
+		{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventEditButtonQuestion), CallbackData: mustEncodeCallback(cbEditField, "question", editCtx.EventID)},
 	})
 
 	// Only allow editing options for multi-option events
-	if editCtx.EventType == domain.EventTypeMultiOption {
+	if editCtx.optionsEditable() {
 		buttons = append(buttons, []models.InlineKeyboardButton{
-			{Text: f.localizer.MustLocalize(locale.EventEditButtonOptions), CallbackData: fmt.Sprintf("edit_field:options:%d", editCtx.EventID)},
+			{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventEditButtonOptions), CallbackData: mustEncodeCallback(cbEditField, "options", editCtx.EventID)},
 		})
 	}
 
 	buttons = append(buttons, []models.InlineKeyboardButton{
-		{Text: f.localizer.MustLocalize(locale.EventEditButtonDeadline), CallbackData: fmt.Sprintf("edit_field:deadline:%d", editCtx.EventID)},
+		{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventEditButtonDeadline), CallbackData: mustEncodeCallback(cbEditField, "deadline", editCtx.EventID)},
 	})
 	buttons = append(buttons, []models.InlineKeyboardButton{
-		{Text: f.localizer.MustLocalize(locale.EventEditButtonSave), CallbackData: fmt.Sprintf("edit_field:save:%d", editCtx.EventID)},
-		{Text: f.localizer.MustLocalize(locale.EventEditButtonCancel), CallbackData: fmt.Sprintf("edit_field:cancel:%d", editCtx.EventID)},
+		{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventEditButtonSave), CallbackData: mustEncodeCallback(cbEditField, "save", editCtx.EventID)},
+		{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventEditButtonCancel), CallbackData: mustEncodeCallback(cbEditField, "cancel", editCtx.EventID)},
 	})
 
 	kb := &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
@@ -285,7 +322,11 @@ func (f *EventEditFSM) HasSession(ctx context.Context, userID int64) (bool, erro
 // HandleCallback routes callback queries to the appropriate handler
 func (f *EventEditFSM) HandleCallback(ctx context.Context, callback *models.CallbackQuery) error {
 	userID := callback.From.ID
-	data := callback.Data
+
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		return err
+	}
 
 	// Get current state
 	state, contextData, err := f.storage.Get(ctx, userID)
@@ -296,7 +337,7 @@ func (f *EventEditFSM) HandleCallback(ctx context.Context, callback *models.Call
 		if err == storage.ErrSessionExpired {
 			_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: callback.ID,
-				Text:            f.localizer.MustLocalize(locale.SessionExpiredShort),
+				Text:            f.requestLocalizer(ctx).MustLocalize(locale.SessionExpiredShort),
 			})
 			return nil
 		}
@@ -311,30 +352,29 @@ func (f *EventEditFSM) HandleCallback(ctx context.Context, callback *models.Call
 	}
 
 	// Handle field selection callbacks
-	if strings.HasPrefix(data, "edit_field:") && state == StateEditSelectField {
-		return f.handleFieldSelectionCallback(ctx, userID, callback, editCtx)
+	if cb.Namespace == cbEditField && state == StateEditSelectField {
+		return f.handleFieldSelectionCallback(ctx, userID, callback, cb, editCtx)
 	}
 
 	// Handle deadline preset callbacks
-	if strings.HasPrefix(data, "edit_deadline_preset:") && state == StateEditDeadline {
-		return f.handleDeadlinePresetCallback(ctx, userID, callback, editCtx)
+	if cb.Namespace == cbEditDeadlinePreset && state == StateEditDeadline {
+		return f.handleDeadlinePresetCallback(ctx, userID, callback, cb, editCtx)
 	}
 
 	return nil
 }
 
 // handleFieldSelectionCallback processes field selection
-func (f *EventEditFSM) handleFieldSelectionCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, editCtx *EventEditContext) error {
+func (f *EventEditFSM) handleFieldSelectionCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, editCtx *EventEditContext) error {
 	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 		CallbackQueryID: callback.ID,
 	})
 
 	// Parse field from callback data: edit_field:FIELD:EVENT_ID
-	parts := strings.Split(callback.Data, ":")
-	if len(parts) < 3 {
-		return fmt.Errorf("invalid callback data")
+	if err := cb.Expect(cbEditField, 2); err != nil {
+		return err
 	}
-	field := parts[1]
+	field := cb.Fields[0]
 	chatID := callback.Message.Message.Chat.ID
 
 	// Delete previous message
@@ -361,7 +401,7 @@ func (f *EventEditFSM) handleFieldSelectionCallback(ctx context.Context, userID
 func (f *EventEditFSM) promptEditQuestion(ctx context.Context, userID int64, chatID int64, editCtx *EventEditContext) error {
 	msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   f.localizer.MustLocalizeWithTemplate(locale.EventEditPromptQuestion, editCtx.NewQuestion),
+		Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventEditPromptQuestion, editCtx.NewQuestion),
 	})
 	if err != nil {
 		return err
@@ -373,11 +413,11 @@ func (f *EventEditFSM) promptEditQuestion(ctx context.Context, userID int64, cha
 
 func (f *EventEditFSM) promptEditOptions(ctx context.Context, userID int64, chatID int64, editCtx *EventEditContext) error {
 	var sb strings.Builder
-	sb.WriteString(f.localizer.MustLocalize(locale.EventEditCurrentOptions) + "\n")
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventEditCurrentOptions) + "\n")
 	for i, opt := range editCtx.NewOptions {
 		sb.WriteString(fmt.Sprintf("  %d) %s\n", i+1, opt))
 	}
-	sb.WriteString("\n" + f.localizer.MustLocalize(locale.EventEditPromptOptionsHelp))
+	sb.WriteString("\n" + f.requestLocalizer(ctx).MustLocalize(locale.EventEditPromptOptionsHelp))
 
 	msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
@@ -396,7 +436,7 @@ func (f *EventEditFSM) promptEditDeadline(ctx context.Context, userID int64, cha
 	exampleDate := time.Now().In(f.config.Timezone).AddDate(0, 0, 7)
 	exampleDate = time.Date(exampleDate.Year(), exampleDate.Month(), exampleDate.Day(), 12, 0, 0, 0, f.config.Timezone)
 
-	text := f.localizer.MustLocalizeWithTemplate(
+	text := f.requestLocalizer(ctx).MustLocalizeWithTemplate(
 		locale.EventEditPromptDeadline,
 		localDeadline.Format("02.01.2006 15:04"),
 		exampleDate.Format("02.01.2006 15:04"),
@@ -404,11 +444,11 @@ func (f *EventEditFSM) promptEditDeadline(ctx context.Context, userID int64, cha
 
 	kb := &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{{Text: f.localizer.MustLocalize(locale.DeadlinePreset1Day), CallbackData: fmt.Sprintf("edit_deadline_preset:1d:%d", editCtx.EventID)}},
-			{{Text: f.localizer.MustLocalize(locale.DeadlinePreset3Days), CallbackData: fmt.Sprintf("edit_deadline_preset:3d:%d", editCtx.EventID)}},
-			{{Text: f.localizer.MustLocalize(locale.DeadlinePreset1Week), CallbackData: fmt.Sprintf("edit_deadline_preset:7d:%d", editCtx.EventID)}},
-			{{Text: f.localizer.MustLocalize(locale.DeadlinePreset2Weeks), CallbackData: fmt.Sprintf("edit_deadline_preset:2d:%d", editCtx.EventID)}},
-			{{Text: f.localizer.MustLocalize(locale.DeadlinePreset1Month), CallbackData: fmt.Sprintf("edit_deadline_preset:30d:%d", editCtx.EventID)}},
+			{{Text: f.requestLocalizer(ctx).MustLocalize(locale.DeadlinePreset1Day), CallbackData: mustEncodeCallback(cbEditDeadlinePreset, "1d", editCtx.EventID)}},
+			{{Text: f.requestLocalizer(ctx).MustLocalize(locale.DeadlinePreset3Days), CallbackData: mustEncodeCallback(cbEditDeadlinePreset, "3d", editCtx.EventID)}},
+			{{Text: f.requestLocalizer(ctx).MustLocalize(locale.DeadlinePreset1Week), CallbackData: mustEncodeCallback(cbEditDeadlinePreset, "7d", editCtx.EventID)}},
+			{{Text: f.requestLocalizer(ctx).MustLocalize(locale.DeadlinePreset2Weeks), CallbackData: mustEncodeCallback(cbEditDeadlinePreset, "14d", editCtx.EventID)}},
+			{{Text: f.requestLocalizer(ctx).MustLocalize(locale.DeadlinePreset1Month), CallbackData: mustEncodeCallback(cbEditDeadlinePreset, "30d", editCtx.EventID)}},
 		},
 	}
 
@@ -426,17 +466,16 @@ func (f *EventEditFSM) promptEditDeadline(ctx context.Context, userID int64, cha
 	return f.storage.Set(ctx, userID, StateEditDeadline, editCtx.ToMap())
 }
 
-func (f *EventEditFSM) handleDeadlinePresetCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, editCtx *EventEditContext) error {
+func (f *EventEditFSM) handleDeadlinePresetCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, editCtx *EventEditContext) error {
 	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 		CallbackQueryID: callback.ID,
 	})
 
 	// Parse preset: edit_deadline_preset:PRESET:EVENT_ID
-	parts := strings.Split(callback.Data, ":")
-	if len(parts) < 3 {
-		return fmt.Errorf("invalid callback data")
+	if err := cb.Expect(cbEditDeadlinePreset, 2); err != nil {
+		return err
 	}
-	preset := parts[1]
+	preset := cb.Fields[0]
 	chatID := callback.Message.Message.Chat.ID
 
 	now := time.Now().In(f.config.Timezone)
@@ -458,6 +497,9 @@ func (f *EventEditFSM) handleDeadlinePresetCallback(ctx context.Context, userID
 	}
 
 	deadline = time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 12, 0, 0, 0, f.config.Timezone)
+	if editCtx.HasVotes && deadline.Before(editCtx.OriginalDeadline) {
+		return f.sendVotedDeadlineError(ctx, userID, chatID, editCtx)
+	}
 	editCtx.NewDeadline = deadline
 
 	// Delete previous message
@@ -509,10 +551,21 @@ func (f *EventEditFSM) handleQuestionInput(ctx context.Context, userID int64, ch
 	// Delete bot and user messages
 	deleteMessages(ctx, f.bot, f.logger, chatID, editCtx.LastBotMessageID, userMsgID)
 
+	text = strings.TrimSpace(text)
 	if text == "" {
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.EventEditErrorEmptyQuestion),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventEditErrorEmptyQuestion),
+		})
+		editCtx.LastErrorMessageID = msg.ID
+		return f.storage.Set(ctx, userID, StateEditQuestion, editCtx.ToMap())
+	}
+
+	// The question becomes the poll question, so it must fit Telegram's limit
+	if utf8.RuneCountInString(text) > domain.MaxPollQuestionLength {
+		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationErrorQuestionTooLong, strconv.Itoa(domain.MaxPollQuestionLength)),
 		})
 		editCtx.LastErrorMessageID = msg.ID
 		return f.storage.Set(ctx, userID, StateEditQuestion, editCtx.ToMap())
@@ -529,26 +582,38 @@ func (f *EventEditFSM) handleOptionsInput(ctx context.Context, userID int64, cha
 	if text == "" {
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.EventEditErrorEmptyOptions),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventEditErrorEmptyOptions),
 		})
 		editCtx.LastErrorMessageID = msg.ID
 		return f.storage.Set(ctx, userID, StateEditOptions, editCtx.ToMap())
 	}
 
 	// Parse options
-	lines := strings.Split(text, "\n")
-	var options []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			options = append(options, line)
-		}
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if errorText := optionsErrorText(f.requestLocalizer(ctx), lines); errorText != "" {
+		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   errorText,
+		})
+		editCtx.LastErrorMessageID = msg.ID
+		return f.storage.Set(ctx, userID, StateEditOptions, editCtx.ToMap())
 	}
+	options := domain.CleanOptions(lines)
 
 	if len(options) < 2 || len(options) > 6 {
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.EventEditErrorOptionsCount),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventEditErrorOptionsCount),
+		})
+		editCtx.LastErrorMessageID = msg.ID
+		return f.storage.Set(ctx, userID, StateEditOptions, editCtx.ToMap())
+	}
+
+	// Votes refer to options by position, so the options of voted events only get typo fixes
+	if editCtx.HasVotes && !domain.IsOptionTextFix(editCtx.OriginalOptions, options) {
+		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventEditErrorVotedOptions, strconv.Itoa(len(editCtx.OriginalOptions))),
 		})
 		editCtx.LastErrorMessageID = msg.ID
 		return f.storage.Set(ctx, userID, StateEditOptions, editCtx.ToMap())
@@ -568,7 +633,7 @@ func (f *EventEditFSM) handleDeadlineInput(ctx context.Context, userID int64, ch
 		exampleStr := exampleDate.Format("02.01.2006 15:04")
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    chatID,
-			Text:      f.localizer.MustLocalizeWithTemplate(locale.EventEditErrorInvalidDeadline, exampleStr),
+			Text:      f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventEditErrorInvalidDeadline, exampleStr),
 			ParseMode: models.ParseModeHTML,
 		})
 		editCtx.LastErrorMessageID = msg.ID
@@ -578,34 +643,55 @@ func (f *EventEditFSM) handleDeadlineInput(ctx context.Context, userID int64, ch
 	if deadline.Before(time.Now()) {
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.EventEditErrorDeadlinePast),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventEditErrorDeadlinePast),
 		})
 		editCtx.LastErrorMessageID = msg.ID
 		return f.storage.Set(ctx, userID, StateEditDeadline, editCtx.ToMap())
 	}
 
+	if editCtx.HasVotes && deadline.Before(editCtx.OriginalDeadline) {
+		return f.sendVotedDeadlineError(ctx, userID, chatID, editCtx)
+	}
+
 	editCtx.NewDeadline = deadline
 	return f.sendFieldSelectionMenu(ctx, userID, chatID, editCtx)
 }
 
+// sendVotedDeadlineError tells the editor that the deadline of a voted event can only be extended
+// and waits for another deadline
+func (f *EventEditFSM) sendVotedDeadlineError(ctx context.Context, userID int64, chatID int64, editCtx *EventEditContext) error {
+	msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text: f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventEditErrorVotedDeadline,
+			editCtx.OriginalDeadline.In(f.config.Timezone).Format("02.01.2006 15:04")),
+	})
+	if msg != nil {
+		editCtx.LastErrorMessageID = msg.ID
+	}
+	return f.storage.Set(ctx, userID, StateEditDeadline, editCtx.ToMap())
+}
+
 func (f *EventEditFSM) saveChanges(ctx context.Context, userID int64, chatID int64, editCtx *EventEditContext) error {
 	// Get the event
 	event, err := f.eventManager.GetEvent(ctx, editCtx.EventID)
 	if err != nil {
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.EventEditErrorGetEvent),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventEditErrorGetEvent),
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return err
 	}
 
-	// Check again if event can be edited
+	// Check again for votes, they may have been cast during the edit
 	canEdit, err := f.eventManager.CanEditEvent(ctx, editCtx.EventID)
-	if err != nil || !canEdit {
+	if err == nil && !canEdit {
+		err = domain.CheckVotedEventEdit(event, editCtx.NewOptions, editCtx.NewDeadline)
+	}
+	if err != nil {
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.EventEditErrorHasVotes),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventEditErrorHasVotes),
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return domain.ErrEventHasVotes
@@ -615,47 +701,57 @@ func (f *EventEditFSM) saveChanges(ctx context.Context, userID int64, chatID int
 	event.Question = editCtx.NewQuestion
 	event.Options = editCtx.NewOptions
 	event.Deadline = editCtx.NewDeadline
+	// A votes lock that no longer fits before the new deadline is dropped
+	if event.LockVotesAt != nil && !event.LockVotesAt.Before(event.Deadline) {
+		event.LockVotesAt = nil
+	}
 
 	if err := f.eventManager.UpdateEvent(ctx, event); err != nil {
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.EventEditErrorSave),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventEditErrorSave),
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return err
 	}
 
-	// Update poll in group if needed
-	if err := f.updatePollInGroup(ctx, event); err != nil {
-		f.logger.Error("failed to update poll in group", "event_id", event.ID, "error", err)
-		// Don't fail - event is already updated
+	// Telegram can't edit a poll: events without votes get a new one, voted events keep theirs
+	// with the votes cast in it and get a correction notice under it
+	if canEdit {
+		if err := f.updatePollInGroup(ctx, event); err != nil {
+			f.logger.Error("failed to update poll in group", "event_id", event.ID, "error", err)
+			// Don't fail - event is already updated
+		}
+	} else if err := f.postCorrectionNotice(ctx, event, editCtx); err != nil {
+		f.logger.Error("failed to post correction notice", "event_id", event.ID, "error", err)
+	}
+
+	// Tell everyone who already voted what changed, in the background so large events don't delay the editor
+	if f.notificationService != nil && !canEdit {
+		if changes := f.describeChanges(f.localizer, editCtx); changes != "" {
+			bgCtx := context.WithoutCancel(ctx)
+			go func() {
+				_, _ = f.notificationService.NotifyVotersOfChange(bgCtx, event.ID, changes)
+			}()
+		}
 	}
 
 	// Send success message
 	var sb strings.Builder
-	sb.WriteString(f.localizer.MustLocalize(locale.EventEditSuccess) + "\n\n")
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventFinalSummaryID, fmt.Sprintf("%d", event.ID)) + "\n\n")
-	sb.WriteString(f.localizer.MustLocalizeWithTemplate(locale.EventSummaryQuestion, event.Question) + "\n\n")
-	sb.WriteString(f.localizer.MustLocalize(locale.EventSummaryOptions) + "\n")
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventEditSuccess) + "\n\n")
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventFinalSummaryID, fmt.Sprintf("%d", event.ID)) + "\n\n")
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryQuestion, event.Question) + "\n\n")
+	sb.WriteString(f.requestLocalizer(ctx).MustLocalize(locale.EventSummaryOptions) + "\n")
 	for i, opt := range event.Options {
 		sb.WriteString(fmt.Sprintf("  %d) %s\n", i+1, opt))
 	}
 	localDeadline := event.Deadline.In(f.config.Timezone)
-	sb.WriteString("\n" + f.localizer.MustLocalizeWithTemplate(locale.EventSummaryDeadline, localDeadline.Format("02.01.2006 15:04")) + "\n")
-
-	kb := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: f.localizer.MustLocalize(locale.ActionButtonEdit), CallbackData: fmt.Sprintf("edit_event:%d", event.ID)},
-				{Text: f.localizer.MustLocalize(locale.ActionButtonResolve), CallbackData: fmt.Sprintf("resolve:%d", event.ID)},
-			},
-		},
-	}
+	sb.WriteString("\n" + f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventSummaryDeadline, localDeadline.Format("02.01.2006 15:04")) + "\n")
 
 	_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
 		Text:        sb.String(),
-		ReplyMarkup: kb,
+		ReplyMarkup: eventActionsKeyboard(f.requestLocalizer(ctx), event.ID),
 	})
 
 	f.logger.Info("event edited successfully", "user_id", userID, "event_id", editCtx.EventID)
@@ -663,6 +759,77 @@ func (f *EventEditFSM) saveChanges(ctx context.Context, userID int64, chatID int
 	return nil
 }
 
+// describeChanges lists the question, options and deadline changes of an edit, one per line.
+// Returns an empty string when the edit changed nothing voters would notice.
+func (f *EventEditFSM) describeChanges(localizer locale.Localizer, editCtx *EventEditContext) string {
+	var changes []string
+	if editCtx.NewQuestion != editCtx.OriginalQuestion {
+		changes = append(changes, localizer.MustLocalizeWithTemplate(locale.VoterChangeQuestion, editCtx.OriginalQuestion, editCtx.NewQuestion))
+	}
+	if !slices.Equal(editCtx.NewOptions, editCtx.OriginalOptions) {
+		changes = append(changes, localizer.MustLocalizeWithTemplate(locale.VoterChangeOptions,
+			strings.Join(editCtx.OriginalOptions, ", "), strings.Join(editCtx.NewOptions, ", ")))
+	}
+	if !editCtx.NewDeadline.Equal(editCtx.OriginalDeadline) {
+		changes = append(changes, localizer.MustLocalizeWithTemplate(locale.VoterChangeDeadline,
+			editCtx.OriginalDeadline.In(f.config.Timezone).Format("02.01.2006 15:04"),
+			editCtx.NewDeadline.In(f.config.Timezone).Format("02.01.2006 15:04")))
+	}
+	return strings.Join(changes, "\n")
+}
+
+// postCorrectionNotice replies to the poll of a voted event with what the edit changed, in the language of the group chat
+func (f *EventEditFSM) postCorrectionNotice(ctx context.Context, event *domain.Event, editCtx *EventEditContext) error {
+	group, err := f.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil {
+		return err
+	}
+
+	localizer := f.chatLocalizer(ctx, group.TelegramChatID)
+	changes := f.describeChanges(localizer, editCtx)
+	if changes == "" {
+		return nil
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID: group.TelegramChatID,
+		Text:   localizer.MustLocalizeWithTemplate(locale.EventEditCorrectionNotice, changes),
+	}
+	if messageThreadID := f.messageThreadID(ctx, event); messageThreadID != nil {
+		params.MessageThreadID = *messageThreadID
+	}
+	if event.PollMessageID != 0 {
+		params.ReplyParameters = &models.ReplyParameters{
+			MessageID:                event.PollMessageID,
+			AllowSendingWithoutReply: true,
+		}
+	}
+
+	if _, err := f.bot.SendMessage(ctx, params); err != nil {
+		return err
+	}
+
+	f.logger.Info("correction notice posted", "event_id", event.ID)
+	return nil
+}
+
+// messageThreadID returns the forum topic thread of an event, nil outside forum topics
+func (f *EventEditFSM) messageThreadID(ctx context.Context, event *domain.Event) *int {
+	if event.ForumTopicID == nil {
+		return nil
+	}
+	topic, err := f.forumTopicRepo.GetForumTopic(ctx, *event.ForumTopicID)
+	if err != nil {
+		f.logger.Error("failed to get forum topic", "forum_topic_id", *event.ForumTopicID, "error", err)
+		return nil
+	}
+	if topic == nil {
+		return nil
+	}
+	f.logger.Debug("found forum topic for event", "event_id", event.ID, "message_thread_id", topic.MessageThreadID)
+	return &topic.MessageThreadID
+}
+
 func (f *EventEditFSM) updatePollInGroup(ctx context.Context, event *domain.Event) error {
 	// Get group to retrieve Telegram chat ID
 	group, err := f.groupRepo.GetGroup(ctx, event.GroupID)
@@ -671,16 +838,7 @@ func (f *EventEditFSM) updatePollInGroup(ctx context.Context, event *domain.Even
 	}
 
 	// Get MessageThreadID from ForumTopic if event has one
-	var messageThreadID *int
-	if event.ForumTopicID != nil {
-		topic, err := f.forumTopicRepo.GetForumTopic(ctx, *event.ForumTopicID)
-		if err != nil {
-			f.logger.Error("failed to get forum topic", "forum_topic_id", *event.ForumTopicID, "error", err)
-		} else if topic != nil {
-			messageThreadID = &topic.MessageThreadID
-			f.logger.Debug("found forum topic for event", "event_id", event.ID, "message_thread_id", topic.MessageThreadID)
-		}
-	}
+	messageThreadID := f.messageThreadID(ctx, event)
 
 	// Delete the old poll message (cleaner than stopping it)
 	if event.PollMessageID != 0 {
@@ -690,10 +848,21 @@ func (f *EventEditFSM) updatePollInGroup(ctx context.Context, event *domain.Even
 		})
 		if err != nil {
 			f.logger.Warn("failed to delete old poll", "event_id", event.ID, "error", err)
+			// Reported by /orphans until the new poll below is posted
+			if isMessageNotFoundError(err) && !event.PollMessageMissing {
+				if f.eventManager.MarkPollMessageMissing(ctx, event.ID) == nil {
+					event.PollMessageMissing = true
+				}
+			}
 			// Continue - we'll try to send a new poll anyway
 		}
 	}
 
+	// Delete the old photo message; the photo is re-posted above the new poll
+	if event.PhotoMessageID != 0 {
+		deleteMessages(ctx, f.bot, f.logger, group.TelegramChatID, event.PhotoMessageID)
+	}
+
 	// Create new poll with updated data
 	pollOptions := make([]models.InputPollOption, len(event.Options))
 	for i, opt := range event.Options {
@@ -714,6 +883,10 @@ func (f *EventEditFSM) updatePollInGroup(ctx context.Context, event *domain.Even
 		CloseDate:              event.Deadline.Unix(),
 		HideResultsUntilCloses: event.HideResultsUntilClose,
 	}
+	if event.IsQuiz() {
+		pollParams.Type = "quiz"
+		pollParams.CorrectOptionID = event.QuizAnswer
+	}
 
 	// Add MessageThreadID for forum groups
 	if messageThreadID != nil && *messageThreadID != 0 {
@@ -721,11 +894,23 @@ func (f *EventEditFSM) updatePollInGroup(ctx context.Context, event *domain.Even
 		f.logger.Debug("sending updated poll to forum topic", "event_id", event.ID, "message_thread_id", *messageThreadID)
 	}
 
+	// Re-post the attached photo using the same file_id
+	event.PhotoMessageID = sendEventPhoto(ctx, f.bot, f.logger, group.TelegramChatID, pollParams.MessageThreadID, event.PhotoFileID)
+
 	pollMsg, err := sendPollExtended(ctx, f.bot, pollParams)
 	if err != nil {
 		return err
 	}
 
+	// Link the new poll to its discussion (omitted for private chats)
+	attachDiscussButton(ctx, f.bot, f.logger, f.chatLocalizer(ctx, group.TelegramChatID), pollMsg, pollParams.MessageThreadID)
+
+	// Re-pin the new poll (the deleted one is unpinned by Telegram)
+	event.PollPinned = false
+	if group.PinPolls {
+		event.PollPinned = pinPollMessage(ctx, f.bot, f.logger, group.TelegramChatID, pollMsg.ID)
+	}
+
 	// Update event with new poll ID and message ID
 	event.PollID = pollMsg.Poll.ID
 	event.PollMessageID = pollMsg.ID
@@ -733,6 +918,13 @@ func (f *EventEditFSM) updatePollInGroup(ctx context.Context, event *domain.Even
 		f.logger.Error("failed to update event with new poll ID", "event_id", event.ID, "error", err)
 	}
 
+	// The re-posted poll replaces a missing one
+	if event.PollMessageMissing {
+		if err := f.eventManager.ClearPollMessageMissing(ctx, event.ID); err == nil {
+			event.PollMessageMissing = false
+		}
+	}
+
 	f.logger.Info("poll updated in group", "event_id", event.ID, "new_poll_id", event.PollID, "new_message_id", event.PollMessageID)
 	return nil
 }
@@ -740,7 +932,7 @@ func (f *EventEditFSM) updatePollInGroup(ctx context.Context, event *domain.Even
 func (f *EventEditFSM) cancelEdit(ctx context.Context, userID int64, chatID int64) error {
 	_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   f.localizer.MustLocalize(locale.EventEditCancelled),
+		Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventEditCancelled),
 	})
 	f.logger.Info("event edit cancelled", "user_id", userID)
 	return f.storage.Delete(ctx, userID)
@@ -0,0 +1,223 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+)
+
+func TestEventEditFSM_DescribeChanges(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	f := &EventEditFSM{config: &config.Config{Timezone: time.UTC}, localizer: localizer}
+
+	deadline := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	editCtx := &EventEditContext{
+		OriginalQuestion: "Will it rain?",
+		OriginalOptions:  []string{"Yes", "No"},
+		OriginalDeadline: deadline,
+		NewQuestion:      "Will it rain?",
+		NewOptions:       []string{"Yes", "No"},
+		NewDeadline:      deadline,
+	}
+
+	// Saving without changes is not worth a message
+	if got := f.describeChanges(localizer, editCtx); got != "" {
+		t.Errorf("expected no changes, got %q", got)
+	}
+
+	editCtx.NewQuestion = "Will it rain on Monday?"
+	editCtx.NewDeadline = deadline.AddDate(0, 0, 7)
+	want := "Question: Will it rain? → Will it rain on Monday?\nDeadline: 01.05.2026 12:00 → 08.05.2026 12:00"
+	if got := f.describeChanges(localizer, editCtx); got != want {
+		t.Errorf("describeChanges() = %q, want %q", got, want)
+	}
+
+	editCtx.NewQuestion = editCtx.OriginalQuestion
+	editCtx.NewDeadline = deadline
+	editCtx.NewOptions = []string{"No", "Yes"}
+	if got, want := f.describeChanges(localizer, editCtx), "Options: Yes, No → No, Yes"; got != want {
+		t.Errorf("describeChanges() = %q, want %q", got, want)
+	}
+}
+
+func TestEventEditFSM_QuestionOverPollLimitRejected(t *testing.T) {
+	ctx := context.Background()
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	rec, b := newRecordingTelegramServer(t)
+	f := &EventEditFSM{
+		storage:   createTestFSMStorage(t),
+		bot:       b,
+		config:    &config.Config{Timezone: time.UTC},
+		logger:    logger.New(logger.ERROR),
+		localizer: localizer,
+	}
+
+	userID := int64(42)
+	editCtx := &EventEditContext{OriginalQuestion: "Will it rain?", NewQuestion: "Will it rain?"}
+	long := strings.Repeat("?", domain.MaxPollQuestionLength+1)
+	if err := f.handleQuestionInput(ctx, userID, userID, long, 10, editCtx); err != nil {
+		t.Fatalf("handleQuestionInput returned error: %v", err)
+	}
+
+	expected := localizer.MustLocalizeWithTemplate(locale.EventCreationErrorQuestionTooLong, "300")
+	if texts := rec.texts(); len(texts) != 1 || texts[0] != expected {
+		t.Errorf("expected error message %q, got %v", expected, rec.texts())
+	}
+	if editCtx.NewQuestion != "Will it rain?" {
+		t.Errorf("expected the question to stay unchanged, got %q", editCtx.NewQuestion)
+	}
+
+	state, _, err := f.storage.Get(ctx, userID)
+	if err != nil || state != StateEditQuestion {
+		t.Errorf("expected state %s, got %s (%v)", StateEditQuestion, state, err)
+	}
+}
+
+func TestEventEditFSM_VotedEventFixes(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	voterID := int64(300)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+
+	deadline := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	event := &domain.Event{
+		GroupID:       groupID,
+		Question:      "Who wins?",
+		Options:       []string{"Red", "Blu", "Green"},
+		CreatedAt:     time.Now(),
+		Deadline:      deadline,
+		Status:        domain.EventStatusActive,
+		EventType:     domain.EventTypeMultiOption,
+		CreatedBy:     adminID,
+		PollID:        "poll-1",
+		PollMessageID: 55,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: voterID, Option: 1, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("failed to save prediction: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	origURL := telegramAPIBaseURL
+	telegramAPIBaseURL = rec.server.URL
+	t.Cleanup(func() { telegramAPIBaseURL = origURL })
+	f := NewEventEditFSM(createTestFSMStorage(t), b, domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		storage.NewGroupRepository(queue), storage.NewForumTopicRepository(queue), &config.Config{Timezone: time.UTC}, log, localizer)
+	f.SetNotificationService(domain.NewNotificationService(b, eventRepo, predictionRepo, storage.NewRatingRepository(queue),
+		storage.NewReminderRepository(queue), log, localizer))
+
+	// Voted events can be edited, with a note about what's allowed
+	if err := f.Start(ctx, adminID, adminID, event.ID); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	if texts := rec.texts(); len(texts) != 1 || !strings.Contains(texts[0], localizer.MustLocalize(locale.EventEditVotedNote)) {
+		t.Fatalf("expected the edit menu with the voted note, got %v", texts)
+	}
+
+	load := func() *EventEditContext {
+		t.Helper()
+		_, data, err := f.storage.Get(ctx, adminID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		editCtx := &EventEditContext{}
+		if err := editCtx.FromMap(data); err != nil {
+			t.Fatalf("failed to load context: %v", err)
+		}
+		return editCtx
+	}
+	lastText := func() string {
+		texts := rec.texts()
+		return texts[len(texts)-1]
+	}
+
+	// Options can't be added, removed or renamed
+	for _, options := range []string{"Red\nBlue", "Red\nPurple\nGreen"} {
+		if err := f.handleOptionsInput(ctx, adminID, adminID, options, 10, load()); err != nil {
+			t.Fatalf("handleOptionsInput returned error: %v", err)
+		}
+		if want := localizer.MustLocalizeWithTemplate(locale.EventEditErrorVotedOptions, "3"); lastText() != want {
+			t.Errorf("expected %q for options %q, got %q", want, options, lastText())
+		}
+	}
+
+	// The deadline can't be moved earlier
+	earlier := deadline.Add(-24 * time.Hour).Format("02.01.2006 15:04")
+	if err := f.handleDeadlineInput(ctx, adminID, adminID, earlier, 11, load()); err != nil {
+		t.Fatalf("handleDeadlineInput returned error: %v", err)
+	}
+	if want := localizer.MustLocalizeWithTemplate(locale.EventEditErrorVotedDeadline, deadline.Format("02.01.2006 15:04")); lastText() != want {
+		t.Errorf("expected %q, got %q", want, lastText())
+	}
+
+	// Fixing typos in the options and extending the deadline is allowed
+	if err := f.handleOptionsInput(ctx, adminID, adminID, "Red\nBlue\nGreen", 12, load()); err != nil {
+		t.Fatalf("handleOptionsInput returned error: %v", err)
+	}
+	extended := deadline.Add(24 * time.Hour)
+	if err := f.handleDeadlineInput(ctx, adminID, adminID, extended.Format("02.01.2006 15:04"), 13, load()); err != nil {
+		t.Fatalf("handleDeadlineInput returned error: %v", err)
+	}
+
+	editCtx := load()
+	changes := f.describeChanges(localizer, editCtx)
+	if err := f.saveChanges(ctx, adminID, adminID, editCtx); err != nil {
+		t.Fatalf("saveChanges returned error: %v", err)
+	}
+
+	saved, err := eventRepo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if saved.Options[1] != "Blue" || !saved.Deadline.Equal(extended) {
+		t.Errorf("expected the fixes to be saved, got options %v and deadline %v", saved.Options, saved.Deadline)
+	}
+
+	// The poll with the votes is kept, a correction notice is posted under it
+	if saved.PollID != "poll-1" || saved.PollMessageID != 55 || slices.Contains(rec.deletedIDs(), 55) {
+		t.Errorf("expected the voted poll to be kept, got poll %q in message %d (deleted %v)", saved.PollID, saved.PollMessageID, rec.deletedIDs())
+	}
+	if correction := localizer.MustLocalizeWithTemplate(locale.EventEditCorrectionNotice, changes); !slices.Contains(rec.texts(), correction) {
+		t.Errorf("expected the correction notice %q, got %v", correction, rec.texts())
+	}
+
+	// The voter is told what changed in the background and keeps their vote
+	notice := localizer.MustLocalizeWithTemplate(locale.VoterChangeNotification, "Who wins?", changes)
+	for wait := time.Now().Add(5 * time.Second); !slices.Contains(rec.texts(), notice); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(wait) {
+			t.Fatalf("expected the voter to be notified with %q, got %v", notice, rec.texts())
+		}
+	}
+	prediction, err := predictionRepo.GetPredictionByUserAndEvent(ctx, voterID, event.ID)
+	if err != nil || prediction == nil || prediction.Option != 1 {
+		t.Errorf("expected the vote to be kept, got %+v (%v)", prediction, err)
+	}
+}
@@ -0,0 +1,270 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventCreation_LockVotesStep(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(12345)
+	rec, b := newPollTelegramServer(t, nil)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	fsmStorage := storage.NewFSMStorage(queue, log)
+	fsm := NewEventCreationFSM(
+		fsmStorage,
+		b,
+		domain.NewEventManager(eventRepo, predictionRepo, nil, log),
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		nil,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		ratingRepo,
+		storage.NewGroupMembershipRepository(queue),
+		storage.NewUserRepository(queue),
+		nil,
+		&config.Config{Timezone: time.UTC},
+		log,
+		localizer,
+	)
+
+	deadline := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	startAt := func(state string, sessionContext *domain.EventCreationContext) {
+		t.Helper()
+		sessionContext.ChatID = userID
+		sessionContext.GroupID = groupID
+		sessionContext.Question = "Will it rain tomorrow?"
+		sessionContext.EventType = domain.EventTypeBinary
+		sessionContext.Options = []string{"Yes", "No"}
+		sessionContext.Deadline = deadline
+		if err := fsmStorage.Set(ctx, userID, state, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+	}
+	send := func(text string) {
+		t.Helper()
+		message := &models.Message{ID: 20, Text: text, From: &models.User{ID: userID}, Chat: models.Chat{ID: userID}}
+		if err := fsm.HandleMessage(ctx, &models.Update{Message: message}); err != nil {
+			t.Fatalf("HandleMessage failed: %v", err)
+		}
+	}
+	press := func(data string) {
+		t.Helper()
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+			},
+		}
+		if err := fsm.HandleCallback(ctx, callback); err != nil {
+			t.Fatalf("HandleCallback(%s) failed: %v", data, err)
+		}
+	}
+	session := func(expectedState string) *domain.EventCreationContext {
+		t.Helper()
+		state, data, err := fsmStorage.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		if state != expectedState {
+			t.Fatalf("expected state %s, got %s", expectedState, state)
+		}
+		loaded := &domain.EventCreationContext{}
+		if err := loaded.FromMap(data); err != nil {
+			t.Fatalf("failed to load context: %v", err)
+		}
+		return loaded
+	}
+	lastText := func() string {
+		texts := rec.texts()
+		if len(texts) == 0 {
+			return ""
+		}
+		return texts[len(texts)-1]
+	}
+
+	t.Run("reminders lead to the votes lock step", func(t *testing.T) {
+		startAt(StateAskReminders, &domain.EventCreationContext{})
+		press(mustEncodeCallback(cbEventReminders, "default"))
+		session(StateAskLockVotes)
+	})
+
+	t.Run("votes stay open until the deadline by default", func(t *testing.T) {
+		startAt(StateAskLockVotes, &domain.EventCreationContext{})
+		press(mustEncodeCallback(cbEventLockVotes, "none"))
+		if lock := session(StateAskPhoto).LockVotesAt; lock != nil {
+			t.Errorf("expected no votes lock, got %v", lock)
+		}
+	})
+
+	t.Run("preset locks before the deadline", func(t *testing.T) {
+		startAt(StateAskLockVotes, &domain.EventCreationContext{})
+		press(mustEncodeCallback(cbEventLockVotes, "preset", 1))
+		lock := session(StateAskPhoto).LockVotesAt
+		if lock == nil || !lock.Equal(deadline.Add(-lockVotesPresets[1])) {
+			t.Errorf("expected votes to lock %v before the deadline, got %v", lockVotesPresets[1], lock)
+		}
+	})
+
+	t.Run("exact time is parsed", func(t *testing.T) {
+		lockAt := deadline.Add(-2 * time.Hour)
+		startAt(StateAskLockVotes, &domain.EventCreationContext{})
+		send(lockAt.Format("02.01.2006 15:04"))
+		if lock := session(StateAskPhoto).LockVotesAt; lock == nil || !lock.Equal(lockAt) {
+			t.Errorf("expected votes to lock at %v, got %v", lockAt, lock)
+		}
+	})
+
+	t.Run("time outside now and the deadline is rejected", func(t *testing.T) {
+		want := localizer.MustLocalizeWithTemplate(locale.EventLockVotesErrorRange, deadline.Format("02.01.2006 15:04"))
+		for _, lockAt := range []time.Time{deadline.Add(time.Hour), time.Now().Add(-time.Hour)} {
+			startAt(StateAskLockVotes, &domain.EventCreationContext{})
+			send(lockAt.Format("02.01.2006 15:04"))
+			session(StateAskLockVotes)
+			if text := lastText(); text != want {
+				t.Errorf("expected range error for %v, got %q", lockAt, text)
+			}
+		}
+	})
+
+	t.Run("unreadable time keeps the step", func(t *testing.T) {
+		startAt(StateAskLockVotes, &domain.EventCreationContext{})
+		send("soon")
+		session(StateAskLockVotes)
+		if text := lastText(); text != localizer.MustLocalize(locale.EventLockVotesErrorFormat) {
+			t.Errorf("expected format error, got %q", text)
+		}
+	})
+
+	t.Run("created event stores the votes lock", func(t *testing.T) {
+		lockAt := deadline.Add(-time.Hour)
+		startAt(StateConfirm, &domain.EventCreationContext{LockVotesAt: &lockAt})
+		press(mustEncodeCallback(cbConfirm, "yes"))
+
+		event, err := eventRepo.GetEventByPollID(ctx, "poll_900")
+		if err != nil || event == nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		if event.LockVotesAt == nil || !event.LockVotesAt.Equal(lockAt) {
+			t.Errorf("expected the votes lock to be stored on the event, got %v", event.LockVotesAt)
+		}
+	})
+}
+
+func TestHandlePollAnswer_VotesLocked(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	voterID := int64(200)
+	lateVoterID := int64(300)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	for _, userID := range []int64{voterID, lateVoterID} {
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	lockAt := time.Now().Add(time.Hour)
+	event := &domain.Event{
+		GroupID:        groupID,
+		Question:       "Will it rain?",
+		Options:        []string{"Yes", "No"},
+		CreatedAt:      time.Now(),
+		Deadline:       time.Now().Add(24 * time.Hour),
+		LockVotesAt:    &lockAt,
+		Status:         domain.EventStatusActive,
+		EventType:      domain.EventTypeBinary,
+		CreatedBy:      adminID,
+		PollID:         "poll_1",
+		AllowsRevoting: true,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	h := &BotHandler{
+		bot:                 b,
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC, VoteRejectionNotices: true},
+		groupRepo:           storage.NewGroupRepository(queue),
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      predictionRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		logger:              log,
+		localizer:           localizer,
+	}
+
+	vote := func(userID int64, option int) {
+		t.Helper()
+		h.HandlePollAnswer(ctx, b, &models.Update{PollAnswer: &models.PollAnswer{
+			PollID:    "poll_1",
+			User:      &models.User{ID: userID, Username: "voter"},
+			OptionIDs: []int{option},
+		}})
+	}
+
+	// Before the lock votes count as usual
+	vote(voterID, 0)
+
+	// Move the lock into the past; the deadline is still ahead
+	lockAt = time.Now().Add(-time.Minute)
+	event.LockVotesAt = &lockAt
+	if err := eventRepo.UpdateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+
+	vote(voterID, 1)
+	vote(lateVoterID, 1)
+
+	predictions, err := predictionRepo.GetPredictionsByEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("failed to get predictions: %v", err)
+	}
+	if len(predictions) != 1 || predictions[0].UserID != voterID || predictions[0].Option != 0 {
+		t.Errorf("expected only the vote made before the lock, got %+v", predictions)
+	}
+
+	want := localizer.MustLocalizeWithTemplate(locale.VotesLockedRejected, "Will it rain?", lockAt.In(time.UTC).Format("02.01.2006 15:04"))
+	texts := rec.texts()
+	if len(texts) != 2 || texts[0] != want || texts[1] != want {
+		t.Errorf("expected both late voters to be told votes are locked, got %v", texts)
+	}
+}
@@ -0,0 +1,40 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+
+	tgbot "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// largestPhotoFileID returns the file_id of the largest size of a photo message (empty if none).
+// Telegram lists photo sizes in ascending order.
+func largestPhotoFileID(message *models.Message) string {
+	if message == nil || len(message.Photo) == 0 {
+		return ""
+	}
+	return message.Photo[len(message.Photo)-1].FileID
+}
+
+// sendEventPhoto posts the photo attached to an event right before its poll, reusing the file_id.
+// Failures are logged and never returned: the poll is still published without the photo.
+// Returns the ID of the photo message (0 if nothing was sent).
+func sendEventPhoto(ctx context.Context, b *tgbot.Bot, logger domain.Logger, chatID int64, messageThreadID int, fileID string) int {
+	if fileID == "" {
+		return 0
+	}
+
+	msg, err := b.SendPhoto(ctx, &tgbot.SendPhotoParams{
+		ChatID:              chatID,
+		MessageThreadID:     messageThreadID,
+		Photo:               &models.InputFileString{Data: fileID},
+		DisableNotification: true,
+	})
+	if err != nil {
+		logger.Error("failed to send event photo", "telegram_chat_id", chatID, "message_thread_id", messageThreadID, "error", err)
+		return 0
+	}
+	return msg.ID
+}
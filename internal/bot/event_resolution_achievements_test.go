@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+)
+
+func TestSendAchievementNotifications_PausesBetweenBatches(t *testing.T) {
+	ctx := context.Background()
+	queue, groupID := setupTestGroupAndDB(t, -100500, 1)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	rec, b := newRecordingTelegramServer(t)
+	f := NewEventResolutionFSM(
+		createTestFSMStorage(t),
+		b,
+		nil,
+		nil,
+		nil,
+		nil,
+		storage.NewGroupRepository(queue),
+		nil,
+		nil,
+		nil,
+		&config.Config{},
+		logger.New(logger.ERROR),
+		localizer,
+	)
+
+	// 30 users with 1 achievement and 1 user with 15
+	var awarded []domain.UserAchievements
+	for userID := int64(1); userID <= 30; userID++ {
+		awarded = append(awarded, domain.UserAchievements{
+			UserID:       userID,
+			Achievements: []*domain.Achievement{{UserID: userID, GroupID: groupID, Code: domain.AchievementSharpshooter}},
+		})
+	}
+	var many []*domain.Achievement
+	for i := 0; i < 15; i++ {
+		many = append(many, &domain.Achievement{UserID: 31, GroupID: groupID, Code: domain.AchievementVeteran})
+	}
+	awarded = append(awarded, domain.UserAchievements{UserID: 31, Achievements: many})
+
+	var pauses []time.Duration
+	f.sendAchievementNotifications(ctx, awarded, func(d time.Duration) { pauses = append(pauses, d) })
+
+	if texts := rec.texts(); len(texts) != 45 {
+		t.Errorf("expected 45 achievement messages, got %d", len(texts))
+	}
+	// 45 messages in batches of 20 pause twice
+	if len(pauses) != 2 || pauses[0] != achievementNotificationPause {
+		t.Errorf("expected 2 pauses of %v, got %v", achievementNotificationPause, pauses)
+	}
+}
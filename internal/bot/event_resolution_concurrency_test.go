@@ -0,0 +1,115 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+)
+
+func TestCompleteResolution_ConcurrentResolutionsScoreOnce(t *testing.T) {
+	ctx := context.Background()
+	creatorID := int64(1)
+	adminID := int64(2)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, creatorID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC}
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+
+	fsm := NewEventResolutionFSM(
+		storage.NewFSMStorage(queue, log),
+		b,
+		domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		predictionRepo,
+		groupRepo,
+		storage.NewForumTopicRepository(queue),
+		domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		domain.NewNotificationService(b, eventRepo, predictionRepo, ratingRepo, storage.NewReminderRepository(queue), log, localizer),
+		cfg,
+		log,
+		localizer,
+	)
+
+	now := time.Now()
+	event := &domain.Event{
+		GroupID:   groupID,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: now.Add(-2 * time.Hour),
+		Deadline:  now.Add(-time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: creatorID,
+		PollID:    "poll_race",
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	for userID, option := range map[int64]int{100: 0, 200: 1} {
+		if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: userID, Option: option, Timestamp: now.Add(-90 * time.Minute)}); err != nil {
+			t.Fatalf("failed to save prediction: %v", err)
+		}
+	}
+
+	// The creator and an admin resolve the event at the same time
+	var wg sync.WaitGroup
+	for _, userID := range []int64{creatorID, adminID} {
+		wg.Add(1)
+		go func(userID int64) {
+			defer wg.Done()
+			resolutionContext := &domain.EventResolutionContext{EventID: event.ID, ChatID: userID}
+			if err := fsm.completeResolution(ctx, userID, resolutionContext, 0, nil); err != nil {
+				t.Errorf("completeResolution by %d failed: %v", userID, err)
+			}
+		}(userID)
+	}
+	wg.Wait()
+
+	correct, err := ratingRepo.GetRating(ctx, 100, groupID)
+	if err != nil {
+		t.Fatalf("failed to get rating: %v", err)
+	}
+	if correct.CorrectCount != 1 || correct.WrongCount != 0 {
+		t.Errorf("expected the correct vote to be scored once, got %d correct and %d wrong", correct.CorrectCount, correct.WrongCount)
+	}
+	wrong, err := ratingRepo.GetRating(ctx, 200, groupID)
+	if err != nil {
+		t.Fatalf("failed to get rating: %v", err)
+	}
+	if wrong.CorrectCount != 0 || wrong.WrongCount != 1 {
+		t.Errorf("expected the wrong vote to be scored once, got %d correct and %d wrong", wrong.CorrectCount, wrong.WrongCount)
+	}
+
+	// One manager gets the success message, the other the friendly notice
+	counts := make(map[string]int)
+	for _, text := range rec.texts() {
+		counts[text]++
+	}
+	if counts[localizer.MustLocalize(locale.EventResolutionSuccess)] != 1 {
+		t.Errorf("expected exactly one successful resolution, got %v", rec.texts())
+	}
+	if counts[localizer.MustLocalize(locale.EventResolutionErrorAlreadyResolved)] != 1 {
+		t.Errorf("expected exactly one already resolved notice, got %v", rec.texts())
+	}
+}
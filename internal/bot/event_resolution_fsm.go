@@ -2,9 +2,10 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ad/gitelegram-prediction-market/internal/config"
 	"github.com/ad/gitelegram-prediction-market/internal/domain"
@@ -17,9 +18,20 @@ import (
 
 // FSM state constants for event resolution
 const (
-	StateResolveSelectEvent  = "resolve_select_event"
-	StateResolveSelectOption = "resolve_select_option"
-	StateResolveComplete     = "resolve_complete"
+	StateResolveSelectEvent   = "resolve_select_event"
+	StateResolveSelectOption  = "resolve_select_option"
+	StateResolveConfirmOption = "resolve_confirm_option"
+	StateResolveEnterAnswer   = "resolve_enter_answer"
+	StateResolveEnterOutcome  = "resolve_enter_outcome"
+	StateResolveEnterNote     = "resolve_enter_note"
+	StateResolveComplete      = "resolve_complete"
+)
+
+const (
+	// achievementNotificationBatch is the number of achievement messages sent before pausing
+	achievementNotificationBatch = 20
+	// achievementNotificationPause is the pause between batches of achievement messages
+	achievementNotificationPause = time.Second
 )
 
 // EventResolutionFSM manages the event resolution state machine
@@ -37,6 +49,7 @@ type EventResolutionFSM struct {
 	config                   *config.Config
 	logger                   domain.Logger
 	localizer                locale.Localizer
+	localizerResolver        *locale.LocalizerResolver
 }
 
 // NewEventResolutionFSM creates a new FSM for event resolution
@@ -72,6 +85,12 @@ func NewEventResolutionFSM(
 	}
 }
 
+// SetLocalizerResolver enables messages to other chats, e.g. to admins or group chats,
+// in the language chosen for them (the FSM localizer is used by default)
+func (f *EventResolutionFSM) SetLocalizerResolver(localizerResolver *locale.LocalizerResolver) {
+	f.localizerResolver = localizerResolver
+}
+
 // Start initializes a new FSM session for event resolution
 func (f *EventResolutionFSM) Start(ctx context.Context, userID int64, chatID int64) error {
 	// Initialize context with chat ID
@@ -102,7 +121,7 @@ func (f *EventResolutionFSM) HasSession(ctx context.Context, userID int64) (bool
 
 	// Only return true if the state is an event resolution state
 	switch state {
-	case StateResolveSelectEvent, StateResolveSelectOption, StateResolveComplete:
+	case StateResolveSelectEvent, StateResolveSelectOption, StateResolveConfirmOption, StateResolveEnterAnswer, StateResolveEnterOutcome, StateResolveEnterNote, StateResolveComplete:
 		return true, nil
 	default:
 		return false, nil
@@ -134,8 +153,13 @@ func (f *EventResolutionFSM) HandleCallback(ctx context.Context, callback *model
 	switch state {
 	case StateResolveSelectEvent:
 		return f.handleEventSelection(ctx, callback, userID, resolutionContext)
-	case StateResolveSelectOption:
+	case StateResolveSelectOption, StateResolveEnterAnswer:
+		// The option keyboard stays usable while a typed answer is awaited
 		return f.handleOptionSelection(ctx, callback, userID, resolutionContext)
+	case StateResolveConfirmOption:
+		return f.handleMajorityConfirmation(ctx, callback, userID, resolutionContext)
+	case StateResolveEnterOutcome:
+		return f.handleOutcomeSelection(ctx, callback, userID, resolutionContext)
 	default:
 		f.logger.Warn("unknown resolution state", "user_id", userID, "state", state)
 		return nil
@@ -150,12 +174,15 @@ func (f *EventResolutionFSM) handleEventSelection(ctx context.Context, callback
 	})
 
 	// Parse event ID from callback data (format: "resolve:eventID")
-	parts := strings.Split(callback.Data, ":")
-	if len(parts) < 2 {
-		return fmt.Errorf("invalid callback data format")
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		return err
+	}
+	if err := cb.Expect(cbResolve, 1); err != nil {
+		return err
 	}
 
-	eventID, err := strconv.ParseInt(parts[1], 10, 64)
+	eventID, err := cb.Int64(0)
 	if err != nil {
 		f.logger.Error("failed to parse event ID", "error", err)
 		return err
@@ -167,7 +194,7 @@ func (f *EventResolutionFSM) handleEventSelection(ctx context.Context, callback
 		f.logger.Error("failed to check event management permission", "user_id", userID, "event_id", eventID, "error", err)
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: context.ChatID,
-			Text:   f.localizer.MustLocalize(locale.EventResolutionErrorPermissionCheck),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorPermissionCheck),
 		})
 		if msg != nil {
 			context.MessageIDs = append(context.MessageIDs, msg.ID)
@@ -179,7 +206,7 @@ func (f *EventResolutionFSM) handleEventSelection(ctx context.Context, callback
 		f.logger.Warn("unauthorized event management attempt", "user_id", userID, "event_id", eventID)
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: context.ChatID,
-			Text:   f.localizer.MustLocalize(locale.EventResolutionErrorUnauthorized),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorUnauthorized),
 		})
 		if msg != nil {
 			context.MessageIDs = append(context.MessageIDs, msg.ID)
@@ -193,7 +220,7 @@ func (f *EventResolutionFSM) handleEventSelection(ctx context.Context, callback
 		f.logger.Error("failed to get event", "event_id", eventID, "error", err)
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: context.ChatID,
-			Text:   f.localizer.MustLocalize(locale.EventResolutionErrorGetEvent),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorGetEvent),
 		})
 		if msg != nil {
 			context.MessageIDs = append(context.MessageIDs, msg.ID)
@@ -204,13 +231,33 @@ func (f *EventResolutionFSM) handleEventSelection(ctx context.Context, callback
 	// Store event ID in context
 	context.EventID = eventID
 
+	// A quiz closed early resolves with the correct answer set at its creation
+	if event.IsQuiz() {
+		return f.completeResolution(ctx, userID, context, *event.QuizAnswer, nil)
+	}
+
+	// Probability events are resolved against the realized outcome percentage
+	if event.EventType == domain.EventTypeProbability {
+		return f.askProbabilityOutcome(ctx, userID, context, event)
+	}
+
 	// Build inline keyboard with options
 	var buttons [][]models.InlineKeyboardButton
 	for i, option := range event.Options {
 		buttons = append(buttons, []models.InlineKeyboardButton{
 			{
 				Text:         option,
-				CallbackData: fmt.Sprintf("resolve:option:%d", i),
+				CallbackData: mustEncodeCallback(cbResolve, "option", i),
+			},
+		})
+	}
+
+	// Answers of multi-option events can also be typed and matched to an option
+	if event.EventType == domain.EventTypeMultiOption {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionTypeAnswerButton),
+				CallbackData: mustEncodeCallback(cbResolve, "text"),
 			},
 		})
 	}
@@ -221,7 +268,7 @@ func (f *EventResolutionFSM) handleEventSelection(ctx context.Context, callback
 
 	msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      context.ChatID,
-		Text:        f.localizer.MustLocalizeWithTemplate(locale.EventResolutionSelectCorrectAnswer, event.Question),
+		Text:        f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionSelectCorrectAnswer, event.Question),
 		ReplyMarkup: kb,
 	})
 	if err != nil {
@@ -250,33 +297,467 @@ func (f *EventResolutionFSM) handleOptionSelection(ctx context.Context, callback
 		CallbackQueryID: callback.ID,
 	})
 
-	// Parse option index from callback data (format: "resolve:option:index")
-	parts := strings.Split(callback.Data, ":")
-	if len(parts) < 3 {
-		return fmt.Errorf("invalid callback data format")
+	// Parse option index from callback data (format: "resolve:option:index" or "resolve:text")
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		return err
+	}
+	if cb.Expect(cbResolve, 1) == nil && cb.Fields[0] == "text" {
+		return f.askTypedAnswer(ctx, userID, context)
+	}
+	if err := cb.Expect(cbResolve, 2); err != nil {
+		return err
+	}
+	if cb.Fields[0] != "option" {
+		return fmt.Errorf("%w: expected option selection, got %q", ErrCallbackDataMalformed, cb.String())
 	}
 
-	optionIndex, err := strconv.Atoi(parts[2])
+	optionIndex, err := cb.Int(1)
 	if err != nil {
 		f.logger.Error("failed to parse option index", "error", err)
 		return err
 	}
 
+	return f.selectOption(ctx, userID, context, optionIndex)
+}
+
+// selectOption resolves the event with the selected option, asking for confirmation
+// first when the option differs from the majority vote
+func (f *EventResolutionFSM) selectOption(ctx context.Context, userID int64, context *domain.EventResolutionContext, optionIndex int) error {
+	// Ask again before resolving against the option most participants voted for
+	if f.config.ResolveMajorityConfirmation {
+		majorityOption, ok, err := f.eventManager.MajorityOption(ctx, context.EventID)
+		if err != nil {
+			f.logger.Warn("failed to get majority option, resolving without confirmation", "event_id", context.EventID, "error", err)
+		} else if ok && majorityOption != optionIndex {
+			return f.askMajorityConfirmation(ctx, userID, context, majorityOption, optionIndex)
+		}
+	}
+
+	return f.completeResolution(ctx, userID, context, optionIndex, nil)
+}
+
+// askMajorityConfirmation asks the manager to confirm an answer that differs from the majority vote
+func (f *EventResolutionFSM) askMajorityConfirmation(ctx context.Context, userID int64, context *domain.EventResolutionContext, majorityOption int, optionIndex int) error {
+	event, err := f.eventManager.GetEvent(ctx, context.EventID)
+	if err != nil {
+		f.logger.Error("failed to get event", "event_id", context.EventID, "error", err)
+		return err
+	}
+	if optionIndex < 0 || optionIndex >= len(event.Options) || majorityOption < 0 || majorityOption >= len(event.Options) {
+		// Out of range options are rejected by the resolution itself
+		return f.completeResolution(ctx, userID, context, optionIndex, nil)
+	}
+
+	kb := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionMajorityConfirm), CallbackData: mustEncodeCallback(cbResolve, "confirm", optionIndex)}},
+			{{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionMajorityBack), CallbackData: mustEncodeCallback(cbResolve, "back")}},
+		},
+	}
+
+	msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      context.ChatID,
+		Text:        f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionMajorityWarning, event.Options[majorityOption], event.Options[optionIndex]),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		f.logger.Error("failed to send majority confirmation", "error", err)
+		return err
+	}
+
+	if msg != nil {
+		context.MessageIDs = append(context.MessageIDs, msg.ID)
+	}
+
+	// Transition to confirmation state
+	if err := f.storage.Set(ctx, userID, StateResolveConfirmOption, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to majority confirmation", "user_id", userID, "error", err)
+		return err
+	}
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateResolveSelectOption, "new_state", StateResolveConfirmOption)
+	return nil
+}
+
+// handleMajorityConfirmation processes the answer to the majority confirmation
+func (f *EventResolutionFSM) handleMajorityConfirmation(ctx context.Context, callback *models.CallbackQuery, userID int64, context *domain.EventResolutionContext) error {
+	// Answer callback query
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	// Parse callback data (format: "resolve:confirm:index", "resolve:back" or "resolve:option:index")
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		return err
+	}
+	if cb.Namespace != cbResolve || len(cb.Fields) == 0 {
+		return fmt.Errorf("%w: expected majority confirmation, got %q", ErrCallbackDataMalformed, cb.String())
+	}
+
+	switch cb.Fields[0] {
+	case "confirm", "option":
+		if err := cb.Expect(cbResolve, 2); err != nil {
+			return err
+		}
+		optionIndex, err := cb.Int(1)
+		if err != nil {
+			f.logger.Error("failed to parse option index", "error", err)
+			return err
+		}
+		if cb.Fields[0] == "option" {
+			// Another option was picked from the keyboard above instead of answering
+			return f.selectOption(ctx, userID, context, optionIndex)
+		}
+		return f.completeResolution(ctx, userID, context, optionIndex, nil)
+	case "back":
+		// The option keyboard is still shown above, so just accept another selection
+		if err := f.storage.Set(ctx, userID, StateResolveSelectOption, context.ToMap()); err != nil {
+			f.logger.Error("failed to return to option selection", "user_id", userID, "error", err)
+			return err
+		}
+		f.logger.Info("state transition", "user_id", userID, "old_state", StateResolveConfirmOption, "new_state", StateResolveSelectOption)
+		return nil
+	default:
+		return fmt.Errorf("%w: expected majority confirmation, got %q", ErrCallbackDataMalformed, cb.String())
+	}
+}
+
+// askTypedAnswer asks for the correct answer as text, to be matched to an option of the event
+func (f *EventResolutionFSM) askTypedAnswer(ctx context.Context, userID int64, context *domain.EventResolutionContext) error {
+	event, err := f.eventManager.GetEvent(ctx, context.EventID)
+	if err != nil {
+		f.logger.Error("failed to get event", "event_id", context.EventID, "error", err)
+		return err
+	}
+
+	msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: context.ChatID,
+		Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionTypeAnswerPrompt, event.Question),
+	})
+	if err != nil {
+		f.logger.Error("failed to send typed answer prompt", "error", err)
+		return err
+	}
+
+	if msg != nil {
+		context.MessageIDs = append(context.MessageIDs, msg.ID)
+	}
+
+	// Transition to typed answer input state
+	if err := f.storage.Set(ctx, userID, StateResolveEnterAnswer, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to typed answer input", "user_id", userID, "error", err)
+		return err
+	}
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateResolveSelectOption, "new_state", StateResolveEnterAnswer)
+	return nil
+}
+
+// handleTypedAnswer matches a typed answer to the options of the event. A single match is
+// offered for confirmation, an ambiguous answer lists the matching options to pick from, and
+// an answer that matches nothing is asked for again. Nothing is resolved until an option is picked.
+func (f *EventResolutionFSM) handleTypedAnswer(ctx context.Context, userID int64, context *domain.EventResolutionContext, text string) error {
+	event, err := f.eventManager.GetEvent(ctx, context.EventID)
+	if err != nil {
+		f.logger.Error("failed to get event", "event_id", context.EventID, "error", err)
+		return err
+	}
+
+	answer := strings.TrimSpace(text)
+	retryRow := []models.InlineKeyboardButton{
+		{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionTypeAnswerRetry), CallbackData: mustEncodeCallback(cbResolve, "text")},
+	}
+
+	var params *bot.SendMessageParams
+	if optionIndex, ok := domain.MatchOption(event.Options, answer); ok {
+		params = &bot.SendMessageParams{
+			ChatID: context.ChatID,
+			Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionTypeAnswerConfirm, answer, event.Options[optionIndex]),
+			ReplyMarkup: &models.InlineKeyboardMarkup{
+				InlineKeyboard: [][]models.InlineKeyboardButton{
+					{{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionTypeAnswerYes), CallbackData: mustEncodeCallback(cbResolve, "option", optionIndex)}},
+					retryRow,
+				},
+			},
+		}
+	} else if candidates := domain.MatchOptionCandidates(event.Options, answer); len(candidates) > 0 {
+		var list strings.Builder
+		var buttons [][]models.InlineKeyboardButton
+		for _, optionIndex := range candidates {
+			list.WriteString("\n• " + event.Options[optionIndex])
+			buttons = append(buttons, []models.InlineKeyboardButton{
+				{Text: event.Options[optionIndex], CallbackData: mustEncodeCallback(cbResolve, "option", optionIndex)},
+			})
+		}
+		params = &bot.SendMessageParams{
+			ChatID:      context.ChatID,
+			Text:        f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionTypeAnswerAmbiguous, answer, strings.TrimPrefix(list.String(), "\n")),
+			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: append(buttons, retryRow)},
+		}
+	} else {
+		f.logger.Debug("typed answer matches no option", "user_id", userID, "event_id", context.EventID)
+		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: context.ChatID,
+			Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionTypeAnswerNoMatch, answer),
+		})
+		if msg != nil {
+			context.MessageIDs = append(context.MessageIDs, msg.ID)
+		}
+		return f.storage.Set(ctx, userID, StateResolveEnterAnswer, context.ToMap())
+	}
+
+	msg, err := f.bot.SendMessage(ctx, params)
+	if err != nil {
+		f.logger.Error("failed to send typed answer match", "error", err)
+		return err
+	}
+
+	if msg != nil {
+		context.MessageIDs = append(context.MessageIDs, msg.ID)
+	}
+
+	// The matched options are picked like any other option
+	if err := f.storage.Set(ctx, userID, StateResolveSelectOption, context.ToMap()); err != nil {
+		f.logger.Error("failed to return to option selection", "user_id", userID, "error", err)
+		return err
+	}
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateResolveEnterAnswer, "new_state", StateResolveSelectOption)
+	return nil
+}
+
+// askProbabilityOutcome asks for the realized outcome percentage of a probability event
+func (f *EventResolutionFSM) askProbabilityOutcome(ctx context.Context, userID int64, context *domain.EventResolutionContext, event *domain.Event) error {
+	kb := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionOutcomeHappened), CallbackData: mustEncodeCallback(cbResolve, "outcome", 100)},
+				{Text: f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionOutcomeNotHappened), CallbackData: mustEncodeCallback(cbResolve, "outcome", 0)},
+			},
+		},
+	}
+
+	msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      context.ChatID,
+		Text:        f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionEnterOutcome, event.Question),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		f.logger.Error("failed to send outcome prompt", "error", err)
+		return err
+	}
+
+	if msg != nil {
+		context.MessageIDs = append(context.MessageIDs, msg.ID)
+	}
+
+	// Transition to outcome input state
+	if err := f.storage.Set(ctx, userID, StateResolveEnterOutcome, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to outcome input", "user_id", userID, "error", err)
+		return err
+	}
+
+	f.logger.Info("state transition", "user_id", userID, "old_state", StateResolveSelectEvent, "new_state", StateResolveEnterOutcome)
+	return nil
+}
+
+// handleOutcomeSelection processes the happened / did not happen buttons of the outcome prompt
+func (f *EventResolutionFSM) handleOutcomeSelection(ctx context.Context, callback *models.CallbackQuery, userID int64, context *domain.EventResolutionContext) error {
+	// Answer callback query
+	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	// Parse outcome from callback data (format: "resolve:outcome:percent")
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		return err
+	}
+	if err := cb.Expect(cbResolve, 2); err != nil {
+		return err
+	}
+	if cb.Fields[0] != "outcome" {
+		return fmt.Errorf("%w: expected outcome selection, got %q", ErrCallbackDataMalformed, cb.String())
+	}
+
+	outcomePercent, err := cb.Int(1)
+	if err != nil {
+		f.logger.Error("failed to parse outcome", "error", err)
+		return err
+	}
+
+	return f.resolveProbabilityOutcome(ctx, userID, context, float64(outcomePercent))
+}
+
+// HandleMessage processes the realized outcome percentage, the typed answer and the resolution note typed by the user
+func (f *EventResolutionFSM) HandleMessage(ctx context.Context, update *models.Update) error {
+	userID := update.Message.From.ID
+
+	state, contextData, err := f.storage.Get(ctx, userID)
+	if err != nil {
+		if err == storage.ErrSessionNotFound {
+			return nil
+		}
+		return err
+	}
+
+	// Only the outcome, typed answer and note steps accept text input
+	if state != StateResolveEnterOutcome && state != StateResolveEnterAnswer && state != StateResolveEnterNote {
+		return nil
+	}
+
+	resolutionContext := &domain.EventResolutionContext{}
+	if err := resolutionContext.FromMap(contextData); err != nil {
+		f.logger.Error("failed to parse resolution context", "user_id", userID, "error", err)
+		return err
+	}
+
+	// Track user message for cleanup
+	resolutionContext.MessageIDs = append(resolutionContext.MessageIDs, update.Message.ID)
+
+	if state == StateResolveEnterNote {
+		return f.handleResolutionNote(ctx, userID, resolutionContext, update.Message.Text)
+	}
+	if state == StateResolveEnterAnswer {
+		return f.handleTypedAnswer(ctx, userID, resolutionContext, update.Message.Text)
+	}
+
+	outcomePercent, err := domain.ParseProbabilityOutcome(update.Message.Text)
+	if err != nil {
+		f.logger.Debug("invalid probability outcome", "user_id", userID, "text", update.Message.Text)
+		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: resolutionContext.ChatID,
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorInvalidOutcome),
+		})
+		if msg != nil {
+			resolutionContext.MessageIDs = append(resolutionContext.MessageIDs, msg.ID)
+		}
+		return f.storage.Set(ctx, userID, StateResolveEnterOutcome, resolutionContext.ToMap())
+	}
+
+	return f.resolveProbabilityOutcome(ctx, userID, resolutionContext, outcomePercent)
+}
+
+// resolveProbabilityOutcome resolves a probability event to the range containing the outcome
+func (f *EventResolutionFSM) resolveProbabilityOutcome(ctx context.Context, userID int64, context *domain.EventResolutionContext, outcomePercent float64) error {
+	if err := domain.ValidateProbabilityOutcome(outcomePercent); err != nil {
+		return err
+	}
+
+	return f.completeResolution(ctx, userID, context, domain.ProbabilityOutcomeOption(outcomePercent), &outcomePercent)
+}
+
+// requiresResolutionNote reports whether the event has more votes than the configured threshold,
+// so its outcome needs a resolution note
+func (f *EventResolutionFSM) requiresResolutionNote(ctx context.Context, eventID int64) bool {
+	if f.config.ResolutionNoteVoteThreshold <= 0 {
+		return false
+	}
+
+	event, err := f.eventManager.GetEvent(ctx, eventID)
+	if err != nil {
+		f.logger.Warn("failed to get event, resolving without a note", "event_id", eventID, "error", err)
+		return false
+	}
+
+	required, err := f.eventManager.RequiresResolutionNote(ctx, event, f.config.ResolutionNoteVoteThreshold)
+	if err != nil {
+		f.logger.Warn("failed to count votes, resolving without a note", "event_id", eventID, "error", err)
+		return false
+	}
+	return required
+}
+
+// askResolutionNote keeps the selected outcome and asks for the evidence or source of the outcome
+func (f *EventResolutionFSM) askResolutionNote(ctx context.Context, userID int64, context *domain.EventResolutionContext, optionIndex int, outcomePercent *float64) error {
+	context.PendingOption = optionIndex
+	context.PendingOutcome = outcomePercent
+
+	msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: context.ChatID,
+		Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionNotePrompt, fmt.Sprintf("%d", f.config.ResolutionNoteVoteThreshold)),
+	})
+	if err != nil {
+		f.logger.Error("failed to send resolution note prompt", "error", err)
+		return err
+	}
+
+	if msg != nil {
+		context.MessageIDs = append(context.MessageIDs, msg.ID)
+	}
+
+	// Transition to note input state
+	if err := f.storage.Set(ctx, userID, StateResolveEnterNote, context.ToMap()); err != nil {
+		f.logger.Error("failed to transition to resolution note input", "user_id", userID, "error", err)
+		return err
+	}
+
+	f.logger.Info("state transition", "user_id", userID, "new_state", StateResolveEnterNote, "event_id", context.EventID)
+	return nil
+}
+
+// handleResolutionNote resolves the event with the pending outcome once a valid note is entered,
+// and asks again otherwise
+func (f *EventResolutionFSM) handleResolutionNote(ctx context.Context, userID int64, context *domain.EventResolutionContext, text string) error {
+	note, err := domain.NormalizeResolutionNote(text)
+	if err != nil {
+		f.logger.Debug("invalid resolution note", "user_id", userID, "event_id", context.EventID)
+		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: context.ChatID,
+			Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionNoteInvalid, fmt.Sprintf("%d", domain.MaxResolutionNoteLength)),
+		})
+		if msg != nil {
+			context.MessageIDs = append(context.MessageIDs, msg.ID)
+		}
+		return f.storage.Set(ctx, userID, StateResolveEnterNote, context.ToMap())
+	}
+
+	context.ResolutionNote = note
+	return f.completeResolution(ctx, userID, context, context.PendingOption, context.PendingOutcome)
+}
+
+// completeResolution resolves the event, updates scores and achievements, stops the poll and publishes results.
+// outcomePercent is set for probability events resolved against the realized outcome.
+// Events with more votes than the configured threshold are resolved only once a resolution note is given.
+func (f *EventResolutionFSM) completeResolution(ctx context.Context, userID int64, context *domain.EventResolutionContext, optionIndex int, outcomePercent *float64) error {
+	if context.ResolutionNote == "" && f.requiresResolutionNote(ctx, context.EventID) {
+		return f.askResolutionNote(ctx, userID, context, optionIndex, outcomePercent)
+	}
+
 	// Delete all accumulated messages
 	f.deleteMessages(ctx, context.ChatID, context.MessageIDs...)
 
 	// Resolve the event
 	if err := f.eventManager.ResolveEvent(ctx, context.EventID, optionIndex); err != nil {
+		if errors.Is(err, domain.ErrEventAlreadyResolved) {
+			// Another manager resolved it first; scores were applied by that resolution
+			f.logger.Info("event already resolved", "user_id", userID, "event_id", context.EventID)
+			_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: context.ChatID,
+				Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorAlreadyResolved),
+			})
+			_ = f.storage.Delete(ctx, userID)
+			return nil
+		}
 		f.logger.Error("failed to resolve event", "event_id", context.EventID, "error", err)
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: context.ChatID,
-			Text:   f.localizer.MustLocalize(locale.EventResolutionErrorResolve),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorResolve),
 		})
 		// Clean up session
 		_ = f.storage.Delete(ctx, userID)
 		return err
 	}
 
+	// Store the note before the results are published with it
+	if context.ResolutionNote != "" {
+		if err := f.eventManager.SetResolutionNote(ctx, context.EventID, context.ResolutionNote); err != nil {
+			f.logger.Error("failed to store resolution note", "event_id", context.EventID, "error", err)
+		}
+	}
+
 	// Get the event to show details
 	event, err := f.eventManager.GetEvent(ctx, context.EventID)
 	if err != nil {
@@ -301,48 +782,81 @@ func (f *EventResolutionFSM) handleOptionSelection(ctx context.Context, callback
 		f.logger.Info("creator resolved event", "user_id", userID, "event_id", context.EventID, "correct_option", optionIndex)
 	}
 
+	f.finishResolution(ctx, event, optionIndex, outcomePercent)
+
+	// Send confirmation to user (final message - not deleted)
+	_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: context.ChatID,
+		Text:   f.requestLocalizer(ctx).MustLocalize(locale.EventResolutionSuccess),
+	})
+
+	// Clean up session
+	if err := f.storage.Delete(ctx, userID); err != nil {
+		f.logger.Error("failed to delete resolution session", "user_id", userID, "error", err)
+	}
+
+	f.logger.Info("resolution FSM session completed", "user_id", userID, "event_id", context.EventID)
+	return nil
+}
+
+// finishResolution applies the outcome of a just resolved event: it updates scores and achievements,
+// tells voters the outcome, stops the poll and publishes the results.
+// outcomePercent is set for probability events resolved against the realized outcome.
+func (f *EventResolutionFSM) finishResolution(ctx context.Context, event *domain.Event, optionIndex int, outcomePercent *float64) {
 	// Calculate scores
-	if err := f.ratingCalculator.CalculateScores(ctx, context.EventID, optionIndex); err != nil {
-		f.logger.Error("failed to calculate scores", "event_id", context.EventID, "error", err)
+	var err error
+	if outcomePercent != nil {
+		err = f.ratingCalculator.CalculateProbabilityScores(ctx, event.ID, *outcomePercent)
+	} else {
+		err = f.ratingCalculator.CalculateScores(ctx, event.ID, optionIndex)
+	}
+	if err != nil {
+		f.logger.Error("failed to calculate scores", "event_id", event.ID, "error", err)
 	}
 
-	// Check and award achievements for all participants
-	predictions, err := f.predictionRepo.GetPredictionsByEvent(ctx, context.EventID)
+	predictions, err := f.predictionRepo.GetPredictionsByEvent(ctx, event.ID)
 	if err == nil {
+		predictions = event.ParticipantPredictions(predictions)
+
+		// Tell voters the outcome (digest users get it with their next digest)
+		f.notificationService.SendOutcomeNotifications(ctx, event, predictions)
+
 		for _, pred := range predictions {
 			// Check if user just gained event creation permission
 			f.checkAndNotifyEventCreationPermission(ctx, pred.UserID, event.GroupID)
-
-			achievements, err := f.achievementTracker.CheckAndAwardAchievements(ctx, pred.UserID, event.GroupID)
-			if err != nil {
-				f.logger.Error("failed to check achievements", "user_id", pred.UserID, "group_id", event.GroupID, "error", err)
-				continue
-			}
-
-			// Send achievement notifications
-			for _, ach := range achievements {
-				f.sendAchievementNotification(ctx, pred.UserID, ach)
-			}
 		}
 	}
 
+	// Check and award achievements for all participants
+	f.awardResolutionAchievements(ctx, event)
+
 	// Stop the poll
 	if event.PollID != "" && event.PollMessageID != 0 {
 		// Get the group to obtain Telegram chat ID
 		group, err := f.groupRepo.GetGroup(ctx, event.GroupID)
 		if err != nil {
 			f.logger.Error("failed to get group for stopping poll", "event_id", event.ID, "group_id", event.GroupID, "error", err)
-		} else {
+		} else if event.VotingClosedAt == nil {
+			// A poll whose voting was closed early is stopped already
 			_, err := f.bot.StopPoll(ctx, &bot.StopPollParams{
 				ChatID:    group.TelegramChatID,
 				MessageID: event.PollMessageID,
 			})
 			if err != nil {
 				f.logger.Error("failed to stop poll", "event_id", event.ID, "poll_id", event.PollID, "message_id", event.PollMessageID, "telegram_chat_id", group.TelegramChatID, "error", err)
+				// A deleted poll is reported by /orphans
+				if isMessageNotFoundError(err) {
+					_ = f.eventManager.MarkPollMessageMissing(ctx, event.ID)
+				}
 			} else {
 				f.logger.Info("poll stopped", "event_id", event.ID, "poll_id", event.PollID, "message_id", event.PollMessageID, "telegram_chat_id", group.TelegramChatID)
 			}
 		}
+
+		// Unpin the poll if it was pinned at creation
+		if err == nil && event.PollPinned {
+			unpinPollMessage(ctx, f.bot, f.logger, group.TelegramChatID, event.PollMessageID)
+		}
 	}
 
 	// Publish event results to the group
@@ -350,23 +864,43 @@ func (f *EventResolutionFSM) handleOptionSelection(ctx context.Context, callback
 	if err != nil {
 		f.logger.Error("failed to get group for publishing results", "event_id", event.ID, "group_id", event.GroupID, "error", err)
 	} else {
-		if err := f.notificationService.PublishEventResults(ctx, context.EventID, optionIndex, group.TelegramChatID, f.forumTopicRepo); err != nil {
-			f.logger.Error("failed to publish event results", "event_id", context.EventID, "error", err)
+		if outcomePercent != nil {
+			err = f.notificationService.PublishProbabilityEventResults(ctx, event.ID, *outcomePercent, group.TelegramChatID, f.forumTopicRepo)
+		} else {
+			err = f.notificationService.PublishEventResults(ctx, event.ID, optionIndex, group.TelegramChatID, f.forumTopicRepo)
 		}
+		if err != nil {
+			f.logger.Error("failed to publish event results", "event_id", event.ID, "error", err)
+		}
+
+		// Tell integrators about the outcome (no-op without a configured webhook)
+		f.notificationService.SendResolutionWebhook(event, optionIndex, outcomePercent, group.TelegramChatID)
 	}
+}
 
-	// Send confirmation to user (final message - not deleted)
-	_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: context.ChatID,
-		Text:   f.localizer.MustLocalize(locale.EventResolutionSuccess),
-	})
+// ResolveQuiz resolves a quiz event with the correct answer set at its creation and applies the outcome
+// like a manual resolution. Quizzes resolved in the meantime are skipped.
+func (f *EventResolutionFSM) ResolveQuiz(ctx context.Context, event *domain.Event) error {
+	if !event.IsQuiz() {
+		return nil
+	}
 
-	// Clean up session
-	if err := f.storage.Delete(ctx, userID); err != nil {
-		f.logger.Error("failed to delete resolution session", "user_id", userID, "error", err)
+	if err := f.eventManager.ResolveEvent(ctx, event.ID, *event.QuizAnswer); err != nil {
+		if errors.Is(err, domain.ErrEventAlreadyResolved) {
+			return nil
+		}
+		f.logger.Error("failed to resolve quiz", "event_id", event.ID, "error", err)
+		return err
 	}
 
-	f.logger.Info("resolution FSM session completed", "user_id", userID, "event_id", context.EventID)
+	resolved, err := f.eventManager.GetEvent(ctx, event.ID)
+	if err != nil {
+		f.logger.Error("failed to get event", "event_id", event.ID, "error", err)
+		return err
+	}
+
+	f.logger.Info("quiz resolved", "event_id", event.ID, "correct_option", *event.QuizAnswer)
+	f.finishResolution(ctx, resolved, *event.QuizAnswer, nil)
 	return nil
 }
 
@@ -390,6 +924,11 @@ func (f *EventResolutionFSM) checkAndNotifyEventCreationPermission(ctx context.C
 		return
 	}
 
+	// When any vote counts, the threshold is reached when voting, not on resolution
+	if f.eventPermissionValidator.ParticipationMode() == domain.ParticipationVoted {
+		return
+	}
+
 	// Check current participation count
 	canCreate, participationCount, err := f.eventPermissionValidator.CanCreateEvent(ctx, userID, groupID, f.config.AdminUserIDs)
 	if err != nil {
@@ -406,17 +945,18 @@ func (f *EventResolutionFSM) checkAndNotifyEventCreationPermission(ctx context.C
 			return
 		}
 
-		groupName := f.localizer.MustLocalize(locale.GroupReferenceDefault)
+		localizer := f.chatLocalizer(ctx, userID)
+		groupName := localizer.MustLocalize(locale.GroupReferenceDefault)
 		if group != nil && group.Name != "" {
-			groupName = f.localizer.MustLocalizeWithTemplate(locale.GroupReferenceNamed, group.Name)
+			groupName = localizer.MustLocalizeWithTemplate(locale.GroupReferenceNamed, group.Name)
 		}
 
 		// Send notification with instructions
-		message := f.localizer.MustLocalizeWithTemplate(
+		message := localizer.MustLocalizeWithTemplate(
 			locale.EventResolutionPermissionGranted,
 			fmt.Sprintf("%d", participationCount),
 			groupName,
-		) + "\n\n" + f.localizer.MustLocalize(locale.EventResolutionPermissionInstructions)
+		) + "\n\n" + localizer.MustLocalize(locale.EventResolutionPermissionInstructions)
 
 		_, err = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: userID,
@@ -430,17 +970,48 @@ func (f *EventResolutionFSM) checkAndNotifyEventCreationPermission(ctx context.C
 	}
 }
 
+// awardResolutionAchievements re-evaluates the achievements of every participant of a resolved event
+// and sends the newly unlocked ones in the background, so large groups don't delay the resolution
+func (f *EventResolutionFSM) awardResolutionAchievements(ctx context.Context, event *domain.Event) {
+	awarded, err := f.achievementTracker.CheckEventAchievements(ctx, event)
+	if err != nil || len(awarded) == 0 {
+		return
+	}
+
+	bgCtx := context.WithoutCancel(ctx)
+	go f.sendAchievementNotifications(bgCtx, awarded, defaultSleep)
+}
+
+// sendAchievementNotifications sends the awarded achievements, pausing after every batch of
+// messages to stay below Telegram's broadcast limits
+func (f *EventResolutionFSM) sendAchievementNotifications(ctx context.Context, awarded []domain.UserAchievements, sleep sleepFunc) {
+	sent := 0
+	for _, user := range awarded {
+		for _, ach := range user.Achievements {
+			if sent > 0 && sent%achievementNotificationBatch == 0 {
+				sleep(achievementNotificationPause)
+			}
+			f.sendAchievementNotification(ctx, user.UserID, ach)
+			sent++
+		}
+	}
+
+	f.logger.Info("achievement notifications sent", "users", len(awarded), "count", sent)
+}
+
 // sendAchievementNotification sends achievement notification to user
 func (f *EventResolutionFSM) sendAchievementNotification(ctx context.Context, userID int64, achievement *domain.Achievement) {
+	localizer := f.chatLocalizer(ctx, userID)
 	achievementNames := map[domain.AchievementCode]string{
-		domain.AchievementSharpshooter:    f.localizer.MustLocalize(locale.AchievementSharpshooterName),
-		domain.AchievementProphet:         f.localizer.MustLocalize(locale.AchievementProphetName),
-		domain.AchievementRiskTaker:       f.localizer.MustLocalize(locale.AchievementRiskTakerName),
-		domain.AchievementWeeklyAnalyst:   f.localizer.MustLocalize(locale.AchievementWeeklyAnalystName),
-		domain.AchievementVeteran:         f.localizer.MustLocalize(locale.AchievementVeteranName),
-		domain.AchievementEventOrganizer:  f.localizer.MustLocalize(locale.AchievementEventOrganizerName),
-		domain.AchievementActiveOrganizer: f.localizer.MustLocalize(locale.AchievementActiveOrganizerName),
-		domain.AchievementMasterOrganizer: f.localizer.MustLocalize(locale.AchievementMasterOrganizerName),
+		domain.AchievementSharpshooter:    localizer.MustLocalize(locale.AchievementSharpshooterName),
+		domain.AchievementProphet:         localizer.MustLocalize(locale.AchievementProphetName),
+		domain.AchievementRiskTaker:       localizer.MustLocalize(locale.AchievementRiskTakerName),
+		domain.AchievementWeeklyAnalyst:   localizer.MustLocalize(locale.AchievementWeeklyAnalystName),
+		domain.AchievementVeteran:         localizer.MustLocalize(locale.AchievementVeteranName),
+		domain.AchievementEventOrganizer:  localizer.MustLocalize(locale.AchievementEventOrganizerName),
+		domain.AchievementActiveOrganizer: localizer.MustLocalize(locale.AchievementActiveOrganizerName),
+		domain.AchievementMasterOrganizer: localizer.MustLocalize(locale.AchievementMasterOrganizerName),
+		domain.AchievementGlobeTrotter:    localizer.MustLocalize(locale.AchievementGlobeTrotterName),
 	}
 
 	name := achievementNames[achievement.Code]
@@ -454,14 +1025,14 @@ func (f *EventResolutionFSM) sendAchievementNotification(ctx context.Context, us
 		f.logger.Error("failed to get group for achievement notification", "group_id", achievement.GroupID, "error", err)
 	}
 
-	groupName := f.localizer.MustLocalize(locale.LabelGroup)
+	groupName := localizer.MustLocalize(locale.LabelGroup)
 	if group != nil && group.Name != "" {
-		groupName = f.localizer.MustLocalizeWithTemplate(locale.AchievementNotificationGroup, group.Name)
+		groupName = localizer.MustLocalizeWithTemplate(locale.AchievementNotificationGroup, group.Name)
 	}
 
 	// Send to user with group context
 	_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: userID,
-		Text:   f.localizer.MustLocalizeWithTemplate(locale.EventResolutionAchievementNotification, groupName, name),
+		Text:   localizer.MustLocalizeWithTemplate(locale.EventResolutionAchievementNotification, groupName, name),
 	})
 }
@@ -0,0 +1,181 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventResolution_MajorityConfirmation(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC, ResolveMajorityConfirmation: true}
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log)
+	fsm := NewEventResolutionFSM(
+		storage.NewFSMStorage(queue, log),
+		b,
+		eventManager,
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		predictionRepo,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		domain.NewNotificationService(b, eventRepo, predictionRepo, ratingRepo, storage.NewReminderRepository(queue), log, localizer),
+		cfg,
+		log,
+		localizer,
+	)
+
+	// Two of three voters picked "No"
+	createEvent := func() int64 {
+		t.Helper()
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  "Will it rain tomorrow?",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: time.Now(),
+			Deadline:  time.Now().Add(time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: adminID,
+		}
+		if err := eventManager.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		for i, option := range []int{1, 1, 0} {
+			prediction := &domain.Prediction{EventID: event.ID, UserID: int64(100 + i), Option: option, Timestamp: time.Now()}
+			if err := predictionRepo.SavePrediction(ctx, prediction); err != nil {
+				t.Fatalf("failed to save prediction: %v", err)
+			}
+		}
+		return event.ID
+	}
+	startAtOptionStep := func(eventID int64) {
+		t.Helper()
+		sessionContext := &domain.EventResolutionContext{EventID: eventID, ChatID: adminID, MessageIDs: []int{}}
+		if err := fsm.storage.Set(ctx, adminID, StateResolveSelectOption, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+	}
+	press := func(data string) {
+		t.Helper()
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: adminID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}},
+			},
+		}
+		if err := fsm.HandleCallback(ctx, callback); err != nil {
+			t.Fatalf("HandleCallback(%s) failed: %v", data, err)
+		}
+	}
+	state := func() string {
+		t.Helper()
+		state, _, err := fsm.storage.Get(ctx, adminID)
+		if err == storage.ErrSessionNotFound {
+			return ""
+		}
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		return state
+	}
+	status := func(eventID int64) domain.EventStatus {
+		t.Helper()
+		event, err := eventManager.GetEvent(ctx, eventID)
+		if err != nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		return event.Status
+	}
+	warning := localizer.MustLocalizeWithTemplate(locale.EventResolutionMajorityWarning, "No", "Yes")
+
+	t.Run("option against the majority asks for confirmation", func(t *testing.T) {
+		eventID := createEvent()
+		startAtOptionStep(eventID)
+		before := len(rec.texts())
+
+		press(mustEncodeCallback(cbResolve, "option", 0))
+
+		if got := state(); got != StateResolveConfirmOption {
+			t.Fatalf("expected state %s, got %q", StateResolveConfirmOption, got)
+		}
+		if texts := rec.texts()[before:]; len(texts) != 1 || texts[0] != warning {
+			t.Errorf("expected majority warning, got %v", texts)
+		}
+		if got := status(eventID); got != domain.EventStatusActive {
+			t.Errorf("expected event to stay active, got %s", got)
+		}
+
+		// Going back allows another selection
+		press(mustEncodeCallback(cbResolve, "back"))
+		if got := state(); got != StateResolveSelectOption {
+			t.Fatalf("expected state %s, got %q", StateResolveSelectOption, got)
+		}
+
+		// Confirming resolves with the selected option
+		press(mustEncodeCallback(cbResolve, "option", 0))
+		press(mustEncodeCallback(cbResolve, "confirm", 0))
+		event, err := eventManager.GetEvent(ctx, eventID)
+		if err != nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		if event.Status != domain.EventStatusResolved || event.CorrectOption == nil || *event.CorrectOption != 0 {
+			t.Errorf("expected event resolved with option 0, got %s/%v", event.Status, event.CorrectOption)
+		}
+		if got := state(); got != "" {
+			t.Errorf("expected session to be finished, got %q", got)
+		}
+	})
+
+	t.Run("majority option resolves directly", func(t *testing.T) {
+		eventID := createEvent()
+		startAtOptionStep(eventID)
+
+		press(mustEncodeCallback(cbResolve, "option", 1))
+
+		if got := status(eventID); got != domain.EventStatusResolved {
+			t.Errorf("expected event to be resolved, got %s", got)
+		}
+	})
+
+	t.Run("confirmation can be disabled", func(t *testing.T) {
+		cfg.ResolveMajorityConfirmation = false
+		defer func() { cfg.ResolveMajorityConfirmation = true }()
+
+		eventID := createEvent()
+		startAtOptionStep(eventID)
+
+		press(mustEncodeCallback(cbResolve, "option", 0))
+
+		if got := status(eventID); got != domain.EventStatusResolved {
+			t.Errorf("expected event to be resolved without confirmation, got %s", got)
+		}
+	})
+}
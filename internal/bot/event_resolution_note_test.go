@@ -0,0 +1,185 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventResolution_ResolutionNote(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	// Events with more than 2 votes need a note
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC, ResolutionNoteVoteThreshold: 2}
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log)
+	fsm := NewEventResolutionFSM(
+		storage.NewFSMStorage(queue, log),
+		b,
+		eventManager,
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		predictionRepo,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		domain.NewNotificationService(b, eventRepo, predictionRepo, ratingRepo, storage.NewReminderRepository(queue), log, localizer),
+		cfg,
+		log,
+		localizer,
+	)
+
+	createEvent := func(votes int) int64 {
+		t.Helper()
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  "Will it rain tomorrow?",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: time.Now(),
+			Deadline:  time.Now().Add(time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: adminID,
+		}
+		if err := eventManager.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		for i := 0; i < votes; i++ {
+			prediction := &domain.Prediction{EventID: event.ID, UserID: int64(100 + i), Option: 0, Timestamp: time.Now()}
+			if err := predictionRepo.SavePrediction(ctx, prediction); err != nil {
+				t.Fatalf("failed to save prediction: %v", err)
+			}
+		}
+		return event.ID
+	}
+	selectOption := func(eventID int64) {
+		t.Helper()
+		sessionContext := &domain.EventResolutionContext{EventID: eventID, ChatID: adminID, MessageIDs: []int{}}
+		if err := fsm.storage.Set(ctx, adminID, StateResolveSelectOption, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+		callback := &models.CallbackQuery{
+			ID:      "cb",
+			From:    models.User{ID: adminID},
+			Data:    mustEncodeCallback(cbResolve, "option", 0),
+			Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}}},
+		}
+		if err := fsm.HandleCallback(ctx, callback); err != nil {
+			t.Fatalf("HandleCallback failed: %v", err)
+		}
+	}
+	sendNote := func(text string) {
+		t.Helper()
+		update := &models.Update{Message: &models.Message{ID: 20, From: &models.User{ID: adminID}, Chat: models.Chat{ID: adminID}, Text: text}}
+		if err := fsm.HandleMessage(ctx, update); err != nil {
+			t.Fatalf("HandleMessage failed: %v", err)
+		}
+	}
+	state := func() string {
+		t.Helper()
+		state, _, err := fsm.storage.Get(ctx, adminID)
+		if err == storage.ErrSessionNotFound {
+			return ""
+		}
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		return state
+	}
+	getEvent := func(eventID int64) *domain.Event {
+		t.Helper()
+		event, err := eventManager.GetEvent(ctx, eventID)
+		if err != nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		return event
+	}
+
+	t.Run("at the threshold the note stays optional", func(t *testing.T) {
+		eventID := createEvent(2)
+		selectOption(eventID)
+
+		event := getEvent(eventID)
+		if event.Status != domain.EventStatusResolved {
+			t.Errorf("expected the event to be resolved right away, got %s", event.Status)
+		}
+		if event.ResolutionNote != "" {
+			t.Errorf("expected no resolution note, got %q", event.ResolutionNote)
+		}
+		if got := state(); got != "" {
+			t.Errorf("expected the session to end, got %q", got)
+		}
+	})
+
+	t.Run("above the threshold the note is required", func(t *testing.T) {
+		eventID := createEvent(3)
+		before := len(rec.texts())
+		selectOption(eventID)
+
+		if got := state(); got != StateResolveEnterNote {
+			t.Fatalf("expected state %s, got %q", StateResolveEnterNote, got)
+		}
+		prompt := localizer.MustLocalizeWithTemplate(locale.EventResolutionNotePrompt, "2")
+		if texts := rec.texts()[before:]; len(texts) != 1 || texts[0] != prompt {
+			t.Errorf("expected the note prompt, got %v", texts)
+		}
+		if got := getEvent(eventID).Status; got != domain.EventStatusActive {
+			t.Fatalf("expected the event to stay active without a note, got %s", got)
+		}
+
+		// An empty note loops back to the note step
+		sendNote("   ")
+		if got := state(); got != StateResolveEnterNote {
+			t.Fatalf("expected to stay in %s, got %q", StateResolveEnterNote, got)
+		}
+		invalid := localizer.MustLocalizeWithTemplate(locale.EventResolutionNoteInvalid, "1000")
+		if texts := rec.texts(); texts[len(texts)-1] != invalid {
+			t.Errorf("expected %q, got %q", invalid, texts[len(texts)-1])
+		}
+		if got := getEvent(eventID).Status; got != domain.EventStatusActive {
+			t.Fatalf("expected the event to stay active with an empty note, got %s", got)
+		}
+
+		sendNote("  https://weather.example/report  ")
+		event := getEvent(eventID)
+		if event.Status != domain.EventStatusResolved || event.CorrectOption == nil || *event.CorrectOption != 0 {
+			t.Fatalf("expected the event to be resolved with option 0, got %s %v", event.Status, event.CorrectOption)
+		}
+		if event.ResolutionNote != "https://weather.example/report" {
+			t.Errorf("expected the trimmed note to be stored, got %q", event.ResolutionNote)
+		}
+		if got := state(); got != "" {
+			t.Errorf("expected the session to end, got %q", got)
+		}
+
+		// The note is published with the results
+		note := localizer.MustLocalizeWithTemplate(locale.NotificationResultsNote, "https://weather.example/report")
+		if !slices.ContainsFunc(rec.texts(), func(text string) bool { return strings.Contains(text, note) }) {
+			t.Errorf("expected the results to contain %q", note)
+		}
+	})
+}
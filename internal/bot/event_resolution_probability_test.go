@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+
+	tgbot "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+func newProbabilityResolutionFSM(t *testing.T, b *tgbot.Bot) *EventResolutionFSM {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	return NewEventResolutionFSM(
+		createTestFSMStorage(t),
+		b,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		&config.Config{},
+		logger.New(logger.ERROR),
+		localizer,
+	)
+}
+
+func TestEventResolutionHandleMessage_RejectsInvalidOutcome(t *testing.T) {
+	ctx := context.Background()
+	rec, b := newRecordingTelegramServer(t)
+	fsm := newProbabilityResolutionFSM(t, b)
+
+	userID := int64(42)
+	sessionContext := &domain.EventResolutionContext{
+		EventID:    7,
+		ChatID:     userID,
+		MessageIDs: []int{5},
+	}
+	if err := fsm.storage.Set(ctx, userID, StateResolveEnterOutcome, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	for i, text := range []string{"150", "-5", "maybe"} {
+		update := &models.Update{
+			Message: &models.Message{
+				ID:   100 + i,
+				From: &models.User{ID: userID},
+				Chat: models.Chat{ID: userID},
+				Text: text,
+			},
+		}
+		if err := fsm.HandleMessage(ctx, update); err != nil {
+			t.Fatalf("HandleMessage(%q) returned error: %v", text, err)
+		}
+	}
+
+	texts := rec.texts()
+	if len(texts) != 3 {
+		t.Fatalf("expected 3 validation errors, got %v", texts)
+	}
+	for _, text := range texts {
+		if text != "❌ Enter a number from 0 to 100" {
+			t.Errorf("unexpected validation message %q", text)
+		}
+	}
+
+	// The session stays on the outcome step and tracks all messages for cleanup
+	state, data, err := fsm.storage.Get(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	if state != StateResolveEnterOutcome {
+		t.Errorf("expected state %s, got %s", StateResolveEnterOutcome, state)
+	}
+	restored := &domain.EventResolutionContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("failed to restore context: %v", err)
+	}
+	if len(restored.MessageIDs) != 7 {
+		t.Errorf("expected prompt, user and error messages to be tracked, got %v", restored.MessageIDs)
+	}
+}
+
+func TestEventResolutionHandleMessage_IgnoresOtherStates(t *testing.T) {
+	ctx := context.Background()
+	rec, b := newRecordingTelegramServer(t)
+	fsm := newProbabilityResolutionFSM(t, b)
+
+	userID := int64(42)
+	sessionContext := &domain.EventResolutionContext{ChatID: userID}
+	if err := fsm.storage.Set(ctx, userID, StateResolveSelectOption, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	update := &models.Update{
+		Message: &models.Message{ID: 100, From: &models.User{ID: userID}, Chat: models.Chat{ID: userID}, Text: "50"},
+	}
+	if err := fsm.HandleMessage(ctx, update); err != nil {
+		t.Fatalf("HandleMessage returned error: %v", err)
+	}
+
+	if texts := rec.texts(); len(texts) != 0 {
+		t.Errorf("expected text to be ignored outside the outcome step, got %v", texts)
+	}
+}
@@ -0,0 +1,200 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEventResolution_TypedAnswer(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC}
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log)
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: adminID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	fsm := NewEventResolutionFSM(
+		storage.NewFSMStorage(queue, log),
+		b,
+		eventManager,
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		predictionRepo,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		domain.NewNotificationService(b, eventRepo, predictionRepo, ratingRepo, storage.NewReminderRepository(queue), log, localizer),
+		cfg,
+		log,
+		localizer,
+	)
+
+	createEvent := func() int64 {
+		t.Helper()
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  "Who wins the league?",
+			Options:   []string{"Real Madrid", "Real Sociedad", "Barcelona"},
+			CreatedAt: time.Now(),
+			Deadline:  time.Now().Add(time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeMultiOption,
+			CreatedBy: adminID,
+		}
+		if err := eventManager.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		return event.ID
+	}
+	startAtEventStep := func() {
+		t.Helper()
+		if err := fsm.Start(ctx, adminID, adminID); err != nil {
+			t.Fatalf("failed to start session: %v", err)
+		}
+	}
+	press := func(data string) {
+		t.Helper()
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: adminID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}},
+			},
+		}
+		if err := fsm.HandleCallback(ctx, callback); err != nil {
+			t.Fatalf("HandleCallback(%s) failed: %v", data, err)
+		}
+	}
+	send := func(text string) string {
+		t.Helper()
+		before := len(rec.texts())
+		update := &models.Update{Message: &models.Message{ID: 20, From: &models.User{ID: adminID}, Chat: models.Chat{ID: adminID}, Text: text}}
+		if err := fsm.HandleMessage(ctx, update); err != nil {
+			t.Fatalf("HandleMessage(%q) failed: %v", text, err)
+		}
+		texts := rec.texts()[before:]
+		if len(texts) != 1 {
+			t.Fatalf("expected one reply to %q, got %v", text, texts)
+		}
+		return texts[0]
+	}
+	state := func() string {
+		t.Helper()
+		state, _, err := fsm.storage.Get(ctx, adminID)
+		if err == storage.ErrSessionNotFound {
+			return ""
+		}
+		if err != nil {
+			t.Fatalf("failed to get session: %v", err)
+		}
+		return state
+	}
+	event := func(eventID int64) *domain.Event {
+		t.Helper()
+		event, err := eventManager.GetEvent(ctx, eventID)
+		if err != nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		return event
+	}
+
+	t.Run("single match is confirmed before resolving", func(t *testing.T) {
+		eventID := createEvent()
+		startAtEventStep()
+		press(mustEncodeCallback(cbResolve, eventID))
+
+		// Multi-option events offer typing the answer next to the option buttons
+		if markup := rec.markups[len(rec.markups)-1]; !strings.Contains(markup, mustEncodeCallback(cbResolve, "text")) {
+			t.Fatalf("expected a typed answer button, got %s", markup)
+		}
+
+		press(mustEncodeCallback(cbResolve, "text"))
+		if got := state(); got != StateResolveEnterAnswer {
+			t.Fatalf("expected state %s, got %q", StateResolveEnterAnswer, got)
+		}
+
+		reply := send("barcelona")
+		if want := localizer.MustLocalizeWithTemplate(locale.EventResolutionTypeAnswerConfirm, "barcelona", "Barcelona"); reply != want {
+			t.Errorf("expected confirmation %q, got %q", want, reply)
+		}
+		if got := event(eventID).Status; got != domain.EventStatusActive {
+			t.Fatalf("expected the event to wait for confirmation, got %s", got)
+		}
+
+		press(mustEncodeCallback(cbResolve, "option", 2))
+		resolved := event(eventID)
+		if resolved.Status != domain.EventStatusResolved || resolved.CorrectOption == nil || *resolved.CorrectOption != 2 {
+			t.Errorf("expected the event resolved with option 2, got %s/%v", resolved.Status, resolved.CorrectOption)
+		}
+	})
+
+	t.Run("ambiguous answer lists the candidates", func(t *testing.T) {
+		eventID := createEvent()
+		startAtEventStep()
+		press(mustEncodeCallback(cbResolve, eventID))
+		press(mustEncodeCallback(cbResolve, "text"))
+
+		reply := send("real")
+		if want := localizer.MustLocalizeWithTemplate(locale.EventResolutionTypeAnswerAmbiguous, "real", "• Real Madrid\n• Real Sociedad"); reply != want {
+			t.Errorf("expected candidates %q, got %q", want, reply)
+		}
+		markup := rec.markups[len(rec.markups)-1]
+		if !strings.Contains(markup, mustEncodeCallback(cbResolve, "option", 0)) || !strings.Contains(markup, mustEncodeCallback(cbResolve, "option", 1)) ||
+			strings.Contains(markup, mustEncodeCallback(cbResolve, "option", 2)) {
+			t.Errorf("expected buttons for the candidates only, got %s", markup)
+		}
+
+		press(mustEncodeCallback(cbResolve, "option", 1))
+		if resolved := event(eventID); resolved.CorrectOption == nil || *resolved.CorrectOption != 1 {
+			t.Errorf("expected the event resolved with option 1, got %v", resolved.CorrectOption)
+		}
+	})
+
+	t.Run("unmatched answer is asked again", func(t *testing.T) {
+		eventID := createEvent()
+		startAtEventStep()
+		press(mustEncodeCallback(cbResolve, eventID))
+		press(mustEncodeCallback(cbResolve, "text"))
+
+		reply := send("Juventus")
+		if want := localizer.MustLocalizeWithTemplate(locale.EventResolutionTypeAnswerNoMatch, "Juventus"); reply != want {
+			t.Errorf("expected no match reply %q, got %q", want, reply)
+		}
+		if got := state(); got != StateResolveEnterAnswer {
+			t.Errorf("expected state %s, got %q", StateResolveEnterAnswer, got)
+		}
+
+		// The option buttons still work while an answer is awaited
+		press(mustEncodeCallback(cbResolve, "option", 0))
+		if resolved := event(eventID); resolved.CorrectOption == nil || *resolved.CorrectOption != 0 {
+			t.Errorf("expected the event resolved with option 0, got %v", resolved.CorrectOption)
+		}
+	})
+}
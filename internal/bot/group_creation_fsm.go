@@ -2,6 +2,7 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -27,14 +28,15 @@ const (
 
 // GroupCreationFSM manages the group creation state machine
 type GroupCreationFSM struct {
-	storage         *storage.FSMStorage
-	bot             *bot.Bot
-	groupRepo       domain.GroupRepository
-	forumTopicRepo  domain.ForumTopicRepository
-	deepLinkService *domain.DeepLinkService
-	config          *config.Config
-	logger          domain.Logger
-	localizer       locale.Localizer
+	storage           *storage.FSMStorage
+	bot               *bot.Bot
+	groupRepo         domain.GroupRepository
+	forumTopicRepo    domain.ForumTopicRepository
+	deepLinkService   *domain.DeepLinkService
+	config            *config.Config
+	logger            domain.Logger
+	localizer         locale.Localizer
+	localizerResolver *locale.LocalizerResolver
 }
 
 // NewGroupCreationFSM creates a new FSM for group creation
@@ -60,6 +62,12 @@ func NewGroupCreationFSM(
 	}
 }
 
+// SetLocalizerResolver enables messages to other chats, e.g. to admins or group chats,
+// in the language chosen for them (the FSM localizer is used by default)
+func (f *GroupCreationFSM) SetLocalizerResolver(localizerResolver *locale.LocalizerResolver) {
+	f.localizerResolver = localizerResolver
+}
+
 // Start initializes a new FSM session for group creation
 func (f *GroupCreationFSM) Start(ctx context.Context, userID int64, chatID int64) error {
 	return f.StartWithForumInfo(ctx, userID, chatID, nil, false)
@@ -153,7 +161,7 @@ func (f *GroupCreationFSM) handleGroupNameInput(ctx context.Context, update *mod
 	if input == "" {
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.GroupCreationErrorInvalidName),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationErrorInvalidName),
 		})
 		if msg != nil {
 			context.MessageIDs = append(context.MessageIDs, msg.ID)
@@ -169,9 +177,9 @@ func (f *GroupCreationFSM) handleGroupNameInput(ctx context.Context, update *mod
 	// Send confirmation and ask for chat ID
 	msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text: f.localizer.MustLocalizeWithTemplate(locale.GroupCreationNameSaved, input) + "\n\n" +
-			f.localizer.MustLocalize(locale.GroupCreationAskChatID) + "\n\n" +
-			f.localizer.MustLocalizeWithTemplate(locale.GroupCreationAskChatIDInstructions, input),
+		Text: f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationNameSaved, input) + "\n\n" +
+			f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationAskChatID) + "\n\n" +
+			f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationAskChatIDInstructions, input),
 	})
 	if err != nil {
 		f.logger.Error("failed to send chat ID prompt", "error", err)
@@ -202,7 +210,7 @@ func (f *GroupCreationFSM) handleChatIDInput(ctx context.Context, update *models
 	if err != nil || telegramChatID == 0 {
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.GroupCreationErrorInvalidChatID),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationErrorInvalidChatID),
 		})
 		if msg != nil {
 			context.MessageIDs = append(context.MessageIDs, msg.ID)
@@ -225,15 +233,15 @@ func (f *GroupCreationFSM) handleChatIDInput(ctx context.Context, update *models
 		kb := &models.InlineKeyboardMarkup{
 			InlineKeyboard: [][]models.InlineKeyboardButton{
 				{
-					{Text: f.localizer.MustLocalize(locale.GroupCreationButtonForum), CallbackData: "group_is_forum:yes"},
-					{Text: f.localizer.MustLocalize(locale.GroupCreationButtonRegular), CallbackData: "group_is_forum:no"},
+					{Text: f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationButtonForum), CallbackData: mustEncodeCallback(cbGroupIsForum, "yes")},
+					{Text: f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationButtonRegular), CallbackData: mustEncodeCallback(cbGroupIsForum, "no")},
 				},
 			},
 		}
 
 		msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        f.localizer.MustLocalize(locale.GroupCreationAskIsForum),
+			Text:        f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationAskIsForum),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -269,7 +277,7 @@ func (f *GroupCreationFSM) createGroup(ctx context.Context, userID int64, chatID
 		f.logger.Error("failed to check existing group", "error", err)
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalizeWithTemplate(locale.GroupCreationErrorCheckExisting, err.Error()),
+			Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationErrorCheckExisting, err.Error()),
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return err
@@ -277,6 +285,7 @@ func (f *GroupCreationFSM) createGroup(ctx context.Context, userID int64, chatID
 
 	var group *domain.Group
 	isNewGroup := existingGroup == nil
+	var isRestoredGroup bool
 
 	if isNewGroup {
 		// Create new group
@@ -292,7 +301,7 @@ func (f *GroupCreationFSM) createGroup(ctx context.Context, userID int64, chatID
 			f.logger.Error("group validation failed", "error", err)
 			_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   f.localizer.MustLocalizeWithTemplate(locale.GroupCreationErrorValidation, err.Error()),
+				Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationErrorValidation, err.Error()),
 			})
 			_ = f.storage.Delete(ctx, userID)
 			return err
@@ -302,7 +311,7 @@ func (f *GroupCreationFSM) createGroup(ctx context.Context, userID int64, chatID
 			f.logger.Error("failed to create group", "error", err)
 			_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   f.localizer.MustLocalizeWithTemplate(locale.GroupCreationErrorCreate, err.Error()),
+				Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationErrorCreate, err.Error()),
 			})
 			_ = f.storage.Delete(ctx, userID)
 			return err
@@ -310,6 +319,44 @@ func (f *GroupCreationFSM) createGroup(ctx context.Context, userID int64, chatID
 
 		f.logger.Info("group created", "user_id", userID, "group_id", group.ID, "group_name", context.GroupName)
 		f.notifyAdminsAboutGroupCreation(ctx, userID, group)
+	} else if existingGroup.Status == domain.GroupStatusPending {
+		// Activate the draft created when the bot was added to the chat, using the entered name
+		group = existingGroup
+		if err := f.groupRepo.UpdateGroupName(ctx, group.ID, context.GroupName); err != nil {
+			f.logger.Error("failed to rename draft group", "group_id", group.ID, "error", err)
+		} else {
+			group.Name = context.GroupName
+		}
+		if err := f.groupRepo.UpdateGroupStatus(ctx, group.ID, domain.GroupStatusActive); err != nil {
+			f.logger.Error("failed to activate draft group", "error", err)
+			_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationErrorCreate, err.Error()),
+			})
+			_ = f.storage.Delete(ctx, userID)
+			return err
+		}
+		group.Status = domain.GroupStatusActive
+		isNewGroup = true
+
+		f.logger.Info("draft group activated", "user_id", userID, "group_id", group.ID, "group_name", group.Name)
+		f.notifyAdminsAboutGroupCreation(ctx, userID, group)
+	} else if existingGroup.Status == domain.GroupStatusDeleted {
+		// Restore the deleted group instead of registering the chat again, keeping its history
+		group = existingGroup
+		if err := f.groupRepo.UpdateGroupStatus(ctx, group.ID, domain.GroupStatusActive); err != nil {
+			f.logger.Error("failed to restore deleted group", "group_id", group.ID, "error", err)
+			_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationErrorCreate, err.Error()),
+			})
+			_ = f.storage.Delete(ctx, userID)
+			return err
+		}
+		group.Status = domain.GroupStatusActive
+		isRestoredGroup = true
+
+		f.logger.Info("deleted group restored", "user_id", userID, "group_id", group.ID, "group_name", group.Name)
 	} else {
 		// Use existing group
 		group = existingGroup
@@ -359,9 +406,13 @@ func (f *GroupCreationFSM) createGroup(ctx context.Context, userID int64, chatID
 	deepLink, err := f.deepLinkService.GenerateGroupInviteLink(group.ID)
 	if err != nil {
 		f.logger.Error("failed to generate deep-link", "error", err)
+		text := f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationErrorInviteLink)
+		if errors.Is(err, domain.ErrBotUsernameUnknown) {
+			text = f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationInviteLinkPending, group.Name)
+		}
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.GroupCreationErrorInviteLink),
+			Text:   text,
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return err
@@ -369,14 +420,17 @@ func (f *GroupCreationFSM) createGroup(ctx context.Context, userID int64, chatID
 
 	// Build success message
 	var successMsg string
-	if isNewGroup {
-		successMsg = f.localizer.MustLocalize(locale.GroupCreationSuccessNew)
-	} else {
-		successMsg = f.localizer.MustLocalize(locale.GroupCreationSuccessExisting)
+	switch {
+	case isNewGroup:
+		successMsg = f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationSuccessNew)
+	case isRestoredGroup:
+		successMsg = f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationSuccessRestored)
+	default:
+		successMsg = f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationSuccessExisting)
 	}
 
 	// Add group details
-	successMsg += f.localizer.MustLocalizeWithTemplate(
+	successMsg += f.requestLocalizer(ctx).MustLocalizeWithTemplate(
 		locale.GroupCreationSuccessDetails,
 		group.Name,
 		fmt.Sprintf("%d", group.ID),
@@ -384,18 +438,18 @@ func (f *GroupCreationFSM) createGroup(ctx context.Context, userID int64, chatID
 	)
 
 	if context.IsForum {
-		successMsg += "\n" + f.localizer.MustLocalize(locale.GroupCreationSuccessForumType)
+		successMsg += "\n" + f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationSuccessForumType)
 		if context.MessageThreadID != nil {
-			successMsg += "\n" + f.localizer.MustLocalizeWithTemplate(locale.GroupCreationSuccessThreadID, fmt.Sprintf("%d", *context.MessageThreadID))
+			successMsg += "\n" + f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationSuccessThreadID, fmt.Sprintf("%d", *context.MessageThreadID))
 			if topicCreated {
-				successMsg += "\n\n" + f.localizer.MustLocalize(locale.GroupCreationSuccessTopicRegistered)
+				successMsg += "\n\n" + f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationSuccessTopicRegistered)
 			}
 		}
 	} else {
-		successMsg += "\n" + f.localizer.MustLocalize(locale.GroupCreationSuccessRegularType)
+		successMsg += "\n" + f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationSuccessRegularType)
 	}
 
-	successMsg += f.localizer.MustLocalizeWithTemplate(locale.GroupCreationInviteLink, deepLink)
+	successMsg += f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationInviteLink, deepLink)
 
 	// Send success message (final message - not deleted)
 	_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
@@ -435,16 +489,15 @@ func (f *GroupCreationFSM) notifyAdminsAboutGroupCreation(ctx context.Context, c
 		}
 	}
 
-	notificationMsg := f.localizer.MustLocalizeWithTemplate(
-		locale.GroupCreationAdminNotification,
-		creatorName,
-		group.Name,
-		fmt.Sprintf("%d", group.ID),
-		fmt.Sprintf("%d", group.TelegramChatID),
-	)
-
-	// Send notification to all admins
+	// Send notification to all admins, each in their language
 	for _, adminID := range f.config.AdminUserIDs {
+		notificationMsg := f.chatLocalizer(ctx, adminID).MustLocalizeWithTemplate(
+			locale.GroupCreationAdminNotification,
+			creatorName,
+			group.Name,
+			fmt.Sprintf("%d", group.ID),
+			fmt.Sprintf("%d", group.TelegramChatID),
+		)
 		_, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: adminID,
 			Text:   notificationMsg,
@@ -458,7 +511,12 @@ func (f *GroupCreationFSM) notifyAdminsAboutGroupCreation(ctx context.Context, c
 // HandleCallback handles callback queries for group creation flow
 func (f *GroupCreationFSM) HandleCallback(ctx context.Context, callback *models.CallbackQuery) error {
 	userID := callback.From.ID
-	data := callback.Data
+
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		f.logger.Error("invalid callback data in group creation", "user_id", userID, "error", err)
+		return err
+	}
 
 	// Get current state
 	state, contextData, err := f.storage.Get(ctx, userID)
@@ -478,18 +536,18 @@ func (f *GroupCreationFSM) HandleCallback(ctx context.Context, callback *models.
 	}
 
 	// Route based on callback data and state
-	if strings.HasPrefix(data, "group_is_forum:") && state == StateGroupAskIsForum {
-		return f.handleIsForumCallback(ctx, userID, callback, groupContext)
+	if cb.Namespace == cbGroupIsForum && state == StateGroupAskIsForum {
+		return f.handleIsForumCallback(ctx, userID, callback, cb, groupContext)
 	}
 
-	f.logger.Warn("unexpected callback in group creation", "user_id", userID, "state", state, "data", data)
+	f.logger.Warn("unexpected callback in group creation", "user_id", userID, "state", state, "data", cb.String())
 	return nil
 }
 
 // handleIsForumCallback handles the forum yes/no callback
-func (f *GroupCreationFSM) handleIsForumCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, context *domain.GroupCreationContext) error {
+func (f *GroupCreationFSM) handleIsForumCallback(ctx context.Context, userID int64, callback *models.CallbackQuery, cb *Callback, context *domain.GroupCreationContext) error {
 	chatID := callback.Message.Message.Chat.ID
-	answer := strings.TrimPrefix(callback.Data, "group_is_forum:")
+	answer, _ := cb.Field(0)
 
 	// Answer callback query
 	_, _ = f.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
@@ -507,8 +565,8 @@ func (f *GroupCreationFSM) handleIsForumCallback(ctx context.Context, userID int
 
 		msg, err := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text: f.localizer.MustLocalize(locale.GroupCreationAskForumTopicID) + "\n\n" +
-				f.localizer.MustLocalize(locale.GroupCreationAskThreadIDInstructions),
+			Text: f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationAskForumTopicID) + "\n\n" +
+				f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationAskThreadIDInstructions),
 		})
 		if err != nil {
 			f.logger.Error("failed to send thread ID prompt", "error", err)
@@ -545,7 +603,7 @@ func (f *GroupCreationFSM) handleThreadIDInput(ctx context.Context, update *mode
 	if err != nil {
 		msg, _ := f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.GroupCreationErrorInvalidTopicID),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.GroupCreationErrorInvalidTopicID),
 		})
 		if msg != nil {
 			context.MessageIDs = append(context.MessageIDs, msg.ID)
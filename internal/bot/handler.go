@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ad/gitelegram-prediction-market/internal/config"
@@ -37,7 +38,23 @@ type BotHandler struct {
 	deepLinkService          *domain.DeepLinkService
 	groupContextResolver     *domain.GroupContextResolver
 	ratingRepo               domain.RatingRepository
+	userRepo                 domain.UserRepository
+	pollStatsSyncer          *PollStatsSyncer
+	statsService             *domain.StatsService
+	maintenance              *domain.MaintenanceMode
+	feedbackService          *domain.FeedbackService
+	duelService              *domain.DuelService
+	subscriptionService      *domain.SubscriptionService
+	notificationSettingsRepo domain.NotificationSettingsRepository
+	notificationService      *domain.NotificationService
+	settingsRepo             *storage.SettingsRepository
+	languageRepo             *storage.LanguageRepository
 	localizer                locale.Localizer
+	localizerResolver        *locale.LocalizerResolver
+	recomputeRunning         sync.Map // Group IDs with a rating recomputation in progress
+	approvalsRunning         sync.Map // Event IDs with an approval review in progress
+	ratingRefreshes          sync.Map // Last refresh time of rating messages, by ratingMessageKey
+	voteRejectionNotices     sync.Map // Last rejected vote notice time, by voteRejectionKey
 }
 
 // NewBotHandler creates a new BotHandler with all dependencies
@@ -61,7 +78,19 @@ func NewBotHandler(
 	deepLinkService *domain.DeepLinkService,
 	groupContextResolver *domain.GroupContextResolver,
 	ratingRepo domain.RatingRepository,
+	userRepo domain.UserRepository,
+	pollStatsSyncer *PollStatsSyncer,
+	statsService *domain.StatsService,
+	maintenance *domain.MaintenanceMode,
+	feedbackService *domain.FeedbackService,
+	duelService *domain.DuelService,
+	subscriptionService *domain.SubscriptionService,
+	notificationSettingsRepo domain.NotificationSettingsRepository,
+	notificationService *domain.NotificationService,
+	settingsRepo *storage.SettingsRepository,
+	languageRepo *storage.LanguageRepository,
 	localizer locale.Localizer,
+	localizerResolver *locale.LocalizerResolver,
 ) *BotHandler {
 	return &BotHandler{
 		bot:                      b,
@@ -83,7 +112,19 @@ func NewBotHandler(
 		deepLinkService:          deepLinkService,
 		groupContextResolver:     groupContextResolver,
 		ratingRepo:               ratingRepo,
+		userRepo:                 userRepo,
+		pollStatsSyncer:          pollStatsSyncer,
+		statsService:             statsService,
+		maintenance:              maintenance,
+		feedbackService:          feedbackService,
+		duelService:              duelService,
+		subscriptionService:      subscriptionService,
+		notificationSettingsRepo: notificationSettingsRepo,
+		notificationService:      notificationService,
+		settingsRepo:             settingsRepo,
+		languageRepo:             languageRepo,
 		localizer:                localizer,
+		localizerResolver:        localizerResolver,
 	}
 }
 
@@ -97,13 +138,28 @@ func (h *BotHandler) isAdmin(userID int64) bool {
 	return false
 }
 
-// getUserDisplayName retrieves user display name (username, first name, or ID)
-// It tries username first (format: @username), falls back to first name if username not available,
-// and falls back to "User [UserID]" if neither available
+// getUserDisplayName retrieves user display name (username, full name, or ID)
+// It tries the cached user profile first (shared across all groups and refreshed whenever the bot sees the user),
+// falls back to the username stored in the group rating, and falls back to "User id[UserID]" if neither is available.
+// With ASCII_DISPLAY_NAMES enabled, full names are transliterated to ASCII.
 func (h *BotHandler) getUserDisplayName(ctx context.Context, userID int64, groupID int64) string {
-	// Try to get user information from the bot API
-	// Since we don't have direct access to the bot API's GetChat method for users,
-	// we'll use the rating repository which stores username information
+	// Try the cached profile first, it holds the most recent username and name
+	if h.userRepo != nil {
+		profile, err := h.userRepo.GetUserProfile(ctx, userID)
+		if err != nil {
+			h.logger.Warn("failed to get user profile", "user_id", userID, "error", err)
+		} else if profile != nil {
+			displayName := profile.DisplayName()
+			if h.config.ASCIIDisplayNames {
+				displayName = domain.TransliterateToASCII(displayName)
+			}
+			if displayName != "" {
+				return displayName
+			}
+		}
+	}
+
+	// Fall back to the username stored in the rating repository
 	rating, err := h.ratingCalculator.GetUserRating(ctx, userID, groupID)
 	if err != nil {
 		// If we can't get the rating, fall back to user ID
@@ -123,6 +179,39 @@ func (h *BotHandler) getUserDisplayName(ctx context.Context, userID int64, group
 	return fmt.Sprintf("User id%d", userID)
 }
 
+// RememberUser caches the username and name of the user who sent an update
+// (message, callback or poll answer) so display names stay current. A message or callback
+// in the private chat also clears the user's unreachable flag, direct messages reach them now.
+func (h *BotHandler) RememberUser(ctx context.Context, update *models.Update) {
+	if h.userRepo == nil {
+		return
+	}
+
+	var user *models.User
+	private := false
+	switch {
+	case update.Message != nil && update.Message.From != nil:
+		user = update.Message.From
+		private = update.Message.Chat.Type == models.ChatTypePrivate
+	case update.CallbackQuery != nil:
+		user = &update.CallbackQuery.From
+		private = update.CallbackQuery.Message.Message != nil && update.CallbackQuery.Message.Message.Chat.Type == models.ChatTypePrivate
+	case update.PollAnswer != nil && update.PollAnswer.User != nil:
+		user = update.PollAnswer.User
+	}
+	if user == nil || user.IsBot {
+		return
+	}
+
+	if err := h.userRepo.UpsertUserProfile(ctx, user.ID, user.Username, user.FirstName, user.LastName); err != nil {
+		h.logger.Error("failed to update user profile", "user_id", user.ID, "error", err)
+	}
+
+	if private && h.notificationService != nil {
+		h.notificationService.MarkUserReachable(ctx, user.ID)
+	}
+}
+
 // requireAdmin is a middleware that checks if the user is an admin
 // Returns true if authorized, false otherwise (and sends error message)
 func (h *BotHandler) requireAdmin(ctx context.Context, update *models.Update) bool {
@@ -143,7 +232,7 @@ func (h *BotHandler) requireAdmin(ctx context.Context, update *models.Update) bo
 		if update.Message != nil {
 			_, err := h.bot.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: update.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ErrorUnauthorized),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 			})
 			if err != nil {
 				h.logger.Error("failed to send unauthorized message", "error", err)
@@ -167,9 +256,12 @@ func (h *BotHandler) logAdminAction(userID int64, action string, eventID int64,
 	)
 }
 
-// notifyAdminsWithKeyboard sends a notification message with inline keyboard to all bot admins
-func (h *BotHandler) notifyAdminsWithKeyboard(ctx context.Context, message string, keyboard *models.InlineKeyboardMarkup) {
+// notifyAdminsWithKeyboard sends a notification message with inline keyboard to all bot admins,
+// each built by build in the admin's language.
+// The message uses HTML parse mode, so user-provided parts must be escaped with escapeHTML or truncateHTML.
+func (h *BotHandler) notifyAdminsWithKeyboard(ctx context.Context, build func(localizer locale.Localizer) (string, *models.InlineKeyboardMarkup)) {
 	for _, adminID := range h.config.AdminUserIDs {
+		message, keyboard := build(h.chatLocalizer(ctx, adminID))
 		_, err := h.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      adminID,
 			Text:        message,
@@ -183,10 +275,10 @@ func (h *BotHandler) notifyAdminsWithKeyboard(ctx context.Context, message strin
 }
 
 // handleSessionConflictCallback handles user's choice when there's a conflicting session
-func (h *BotHandler) handleSessionConflictCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+func (h *BotHandler) handleSessionConflictCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, cb *Callback) {
 	userID := callback.From.ID
 	chatID := callback.Message.Message.Chat.ID
-	data := callback.Data
+	action, _ := cb.Field(0)
 
 	// Answer callback query to remove loading state
 	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
@@ -201,26 +293,26 @@ func (h *BotHandler) handleSessionConflictCallback(ctx context.Context, b *bot.B
 		})
 	}
 
-	if data == "session_conflict:continue" {
+	if action == "continue" && len(cb.Fields) == 1 {
 		// User wants to continue the existing session
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.SessionContinuePrevious),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.SessionContinuePrevious),
 		})
 		h.logger.Info("user chose to continue existing session", "user_id", userID)
 		return
 	}
 
-	if strings.HasPrefix(data, "session_conflict:restart:") {
+	if action == "restart" && len(cb.Fields) == 2 {
 		// User wants to restart with a new session
-		sessionType := strings.TrimPrefix(data, "session_conflict:restart:")
+		sessionType := cb.Fields[1]
 
 		// Delete the old session
 		if err := h.eventCreationFSM.storage.Delete(ctx, userID); err != nil {
 			h.logger.Error("failed to delete old session", "user_id", userID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.SessionErrorDelete),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.SessionErrorDelete),
 			})
 			return
 		}
@@ -266,7 +358,7 @@ func (h *BotHandler) handleSessionConflictCallback(ctx context.Context, b *bot.B
 			h.logger.Error("unknown session type for restart", "type", sessionType)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.SessionErrorUnknown),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.SessionErrorUnknown),
 			})
 		}
 	}
@@ -301,57 +393,95 @@ func (h *BotHandler) displayHelp(ctx context.Context, b *bot.Bot, update *models
 	isAdmin := h.isAdmin(userID)
 
 	var helpText strings.Builder
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpBotTitle) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpBotTitle) + "\n\n")
 
 	// User commands section
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpUserCommands) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandHelp) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandRating) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandMy) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandEvents) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandGroups) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpUserCommands) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandHelp) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandRating) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandStreaks) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandSeasonHistory) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandCalibration) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandMy) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandEvents) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandHot) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandUpcoming) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandGroups) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandFeedback) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandDuel) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandSubscribe) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandUnsubscribe) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandNotifications) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandLanguage) + "\n\n")
 
 	// Admin commands section (only for admins)
 	if isAdmin {
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpAdminCommands) + "\n")
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandCreateGroup) + "\n")
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandListGroups) + "\n")
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandGroupMembers) + "\n")
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandRemoveMember) + "\n")
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandCreateEvent) + "\n")
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandResolveEvent) + "\n")
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpCommandEditEvent) + "\n\n")
-		helpText.WriteString(h.localizer.MustLocalize(locale.HelpListGroupsHint) + "\n\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpAdminCommands) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandCreateGroup) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandListGroups) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandGroupMembers) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandRemoveMember) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandCreateEvent) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandResolveEvent) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandEditEvent) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandArchive) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandGroupStats) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandPinPolls) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandDefaultEventType) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandRequireRules) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandAutoRemoveInactive) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandReputationWeighting) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandRequireApproval) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandFirstVoteFinal) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandExcludeCreatorScoring) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandAchievementSettings) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandImportPredictions) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandTransferEvent) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandMergeGroups) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandRecompute) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandSeason) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandResyncUsernames) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandMaxMembers) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandArchiveDays) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandPointsLabel) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandMaintenance) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandSession) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandOrphans) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandPollSync) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandDiag) + "\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpCommandFeedbackList) + "\n\n")
+		helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpListGroupsHint) + "\n\n")
 	}
 
 	// Rules and scoring information
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringRules) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringCorrect) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringBinary) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringMultiOption) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringProbability) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringBonuses) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringMinority) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringEarlyVote) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringParticipation) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringPenalties) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpScoringWrongPrediction) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringRules) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringCorrect) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringBinary) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringMultiOption) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringProbability) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringBonuses) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringMinority) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringEarlyVote) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringParticipation) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringPenalties) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpScoringWrongPrediction) + "\n\n")
 
 	// Achievements
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpAchievements) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpAchievementSharpshooter) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpAchievementProphet) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpAchievementRiskTaker) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpAchievementWeeklyAnalyst) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpAchievementVeteran) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpAchievements) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpAchievementSharpshooter) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpAchievementProphet) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpAchievementRiskTaker) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpAchievementWeeklyAnalyst) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpAchievementVeteran) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpAchievementGlobeTrotter) + "\n\n")
 
 	// Event types
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpEventTypes) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpEventTypeBinary) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpEventTypeMultiOption) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpEventTypeProbability) + "\n\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpEventVoteReminder) + "\n")
-	helpText.WriteString(h.localizer.MustLocalize(locale.HelpEventDeadlineReminder))
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpEventTypes) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpEventTypeBinary) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpEventTypeMultiOption) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpEventTypeProbability) + "\n\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpEventVoteReminder) + "\n")
+	helpText.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.HelpEventDeadlineReminder))
 
 	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: update.Message.Chat.ID,
@@ -373,7 +503,7 @@ func (h *BotHandler) handleDeepLinkJoin(ctx context.Context, b *bot.Bot, update
 		h.logger.Warn("invalid deep-link parameter", "user_id", userID, "param", startParam, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.DeepLinkInvalidLink),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkInvalidLink),
 		})
 		return
 	}
@@ -384,16 +514,17 @@ func (h *BotHandler) handleDeepLinkJoin(ctx context.Context, b *bot.Bot, update
 		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.DeepLinkErrorCheck),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkErrorCheck),
 		})
 		return
 	}
 
-	if group == nil {
+	// Pending drafts are hidden until an admin activates them
+	if group == nil || group.Status == domain.GroupStatusPending {
 		h.logger.Warn("group not found", "group_id", groupID, "user_id", userID)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.DeepLinkGroupNotFound),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkGroupNotFound),
 		})
 		return
 	}
@@ -404,7 +535,7 @@ func (h *BotHandler) handleDeepLinkJoin(ctx context.Context, b *bot.Bot, update
 		h.logger.Error("failed to check membership", "group_id", groupID, "user_id", userID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.DeepLinkErrorMembership),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkErrorMembership),
 		})
 		return
 	}
@@ -413,7 +544,45 @@ func (h *BotHandler) handleDeepLinkJoin(ctx context.Context, b *bot.Bot, update
 	if existingMembership != nil && existingMembership.Status == domain.MembershipStatusActive {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.DeepLinkAlreadyMember, group.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DeepLinkAlreadyMember, group.Name),
+		})
+		return
+	}
+
+	// Users the bot has only just met can't join yet
+	wait, err := h.joinGateRemaining(ctx, userID, time.Now())
+	if err != nil {
+		h.logger.Error("failed to check join gate", "group_id", groupID, "user_id", userID, "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkErrorCheck),
+		})
+		return
+	}
+	if wait > 0 {
+		h.logger.Info("join rejected, user is too new", "group_id", groupID, "user_id", userID, "wait", wait)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DeepLinkUserTooNew, group.Name, h.formatCooldownWait(ctx, wait)),
+		})
+		return
+	}
+
+	// New members and removed members coming back both need a free place
+	full, err := h.isGroupFull(ctx, group)
+	if err != nil {
+		h.logger.Error("failed to check group member limit", "group_id", groupID, "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkErrorCheck),
+		})
+		return
+	}
+	if full {
+		h.logger.Info("join rejected, group is full", "group_id", groupID, "user_id", userID, "max_members", *group.MaxMembers)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DeepLinkGroupFull, group.Name),
 		})
 		return
 	}
@@ -425,32 +594,33 @@ func (h *BotHandler) handleDeepLinkJoin(ctx context.Context, b *bot.Bot, update
 			h.logger.Error("failed to reactivate membership", "group_id", groupID, "user_id", userID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.DeepLinkErrorReactivate),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkErrorReactivate),
 			})
 			return
 		}
 
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.DeepLinkWelcomeBack, group.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DeepLinkWelcomeBack, group.Name),
 		})
 		h.logger.Info("membership reactivated", "group_id", groupID, "user_id", userID)
 		return
 	}
 
-	// Create new membership
+	// Create new membership; groups with the rules gate count votes only after the rules are accepted
 	membership := &domain.GroupMembership{
-		GroupID:  groupID,
-		UserID:   userID,
-		JoinedAt: time.Now(),
-		Status:   domain.MembershipStatusActive,
+		GroupID:      groupID,
+		UserID:       userID,
+		JoinedAt:     time.Now(),
+		Status:       domain.MembershipStatusActive,
+		RulesPending: group.RequireRules,
 	}
 
 	if err := membership.Validate(); err != nil {
 		h.logger.Error("membership validation failed", "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.DeepLinkErrorValidation),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkErrorValidation),
 		})
 		return
 	}
@@ -459,7 +629,7 @@ func (h *BotHandler) handleDeepLinkJoin(ctx context.Context, b *bot.Bot, update
 		h.logger.Error("failed to create membership", "group_id", groupID, "user_id", userID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.DeepLinkErrorCreate),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeepLinkErrorCreate),
 		})
 		return
 	}
@@ -497,87 +667,102 @@ func (h *BotHandler) handleDeepLinkJoin(ctx context.Context, b *bot.Bot, update
 	// Send welcome message
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   h.localizer.MustLocalizeWithTemplate(locale.DeepLinkWelcome, group.Name),
+		Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DeepLinkWelcome, group.Name),
 	})
 	if err != nil {
 		h.logger.Error("failed to send welcome message", "error", err)
 	}
 
+	if membership.RulesPending {
+		h.sendRulesPrompt(ctx, b, userID, group, "")
+	}
+
 	h.logger.Info("user joined group", "group_id", groupID, "user_id", userID, "group_name", group.Name)
 }
 
-// HandleRating handles the /rating command
+const (
+	// ratingCommand shows the group leaderboard
+	ratingCommand = "/rating"
+	// defaultRatingEntries is the number of leaderboard entries shown by /rating without an argument
+	defaultRatingEntries = 10
+)
+
+// HandleRating handles the /rating command (/rating [count])
 func (h *BotHandler) HandleRating(ctx context.Context, b *bot.Bot, update *models.Update) {
 	userID := update.Message.From.ID
 	chatID := update.Message.Chat.ID
 
-	// Determine user's current group context
-	groupID, err := h.groupContextResolver.ResolveGroupForUser(ctx, userID)
-	if err != nil {
-		if err == domain.ErrNoGroupMembership {
-			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.GroupContextNoMembership),
-			})
-			return
-		}
-		if err == domain.ErrMultipleGroupsNeedChoice {
-			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.GroupContextMultipleGroups),
-			})
-			return
-		}
-		h.logger.Error("failed to resolve group context", "user_id", userID, "error", err)
+	limit, ok := parseRatingLimit(update.Message.Text, h.config.MaxRatingEntries)
+	if !ok {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ErrorGeneric),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingErrorInvalidCount, fmt.Sprintf("%d", h.config.MaxRatingEntries)),
 		})
 		return
 	}
 
+	// Determine user's current group context
+	groupID, err := h.groupContextResolver.ResolveGroupForUser(ctx, userID)
+	if err != nil {
+		h.replyError(ctx, b, chatID, err, "failed to resolve group context", "user_id", userID)
+		return
+	}
+
 	// Get group information
 	group, err := h.groupRepo.GetGroup(ctx, groupID)
 	if err != nil || group == nil {
-		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
-		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ErrorGeneric),
-		})
+		h.replyError(ctx, b, chatID, err, "failed to get group", "group_id", groupID)
 		return
 	}
 
-	// Get top 10 ratings for this group
-	ratings, err := h.ratingCalculator.GetTopRatings(ctx, groupID, 10)
+	texts, err := h.buildRatingMessages(ctx, group, limit)
 	if err != nil {
-		h.logger.Error("failed to get top ratings", "group_id", groupID, "error", err)
-		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ErrorGeneric),
-		})
+		h.replyError(ctx, b, chatID, err, "failed to get top ratings", "group_id", groupID)
 		return
 	}
 
-	if len(ratings) == 0 {
-		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.RatingEmpty),
+	// A leaderboard that fits in one message gets a refresh button
+	var kb models.ReplyMarkup
+	if len(texts) == 1 {
+		kb = h.ratingRefreshKeyboard(ctx, groupID, limit)
+	}
+
+	// Long leaderboards are split across several messages
+	for _, text := range texts {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        text,
+			ReplyMarkup: kb,
 		})
-		return
+		if err != nil {
+			h.logger.Error("failed to send rating message", "error", err)
+			return
+		}
 	}
+}
 
-	// Build rating message
-	var sb strings.Builder
-	sb.WriteString(h.localizer.MustLocalize(locale.RatingTop10Title) + "\n")
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.RatingGroupName, group.Name) + "\n\n")
+// buildRatingMessages renders the top limit entries of the group leaderboard, split into
+// messages that fit Telegram's limit
+func (h *BotHandler) buildRatingMessages(ctx context.Context, group *domain.Group, limit int) ([]string, error) {
+	ratings, err := h.ratingCalculator.GetTopRatings(ctx, group.ID, limit)
+	if err != nil {
+		return nil, err
+	}
 
-	medals := []string{"🥇", "🥈", "🥉"}
+	if len(ratings) == 0 {
+		return []string{h.requestLocalizer(ctx).MustLocalize(locale.RatingEmpty)}, nil
+	}
+
+	// Build rating message, one entry per participant
+	header := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingTopNTitle, fmt.Sprintf("%d", limit)) + "\n" +
+		h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingGroupName, group.Name) + "\n\n"
+	entries := make([]string, 0, len(ratings))
+
+	medals := h.podiumMedals()
 	for i, rating := range ratings {
-		medal := ""
-		if i < 3 {
+		medal := fmt.Sprintf("%d. ", i+1)
+		if i < len(medals) {
 			medal = medals[i] + " "
-		} else {
-			medal = fmt.Sprintf("%d. ", i+1)
 		}
 
 		total := rating.CorrectCount + rating.WrongCount
@@ -594,20 +779,36 @@ func (h *BotHandler) HandleRating(ctx context.Context, b *bot.Bot, update *model
 			displayName = fmt.Sprintf("@%s", displayName)
 		}
 
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.RatingUserPoints, medal, displayName, fmt.Sprintf("%d", rating.Score)) + "\n")
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.RatingUserAccuracy, fmt.Sprintf("%.1f", accuracy)) + "\n")
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.RatingUserStreak, fmt.Sprintf("%d", rating.Streak)) + "\n")
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.RatingUserCorrect, fmt.Sprintf("%d", rating.CorrectCount)) + "\n")
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.RatingUserWrong, fmt.Sprintf("%d", rating.WrongCount)) + "\n\n")
+		var sb strings.Builder
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingUserPoints, medal, displayName, domain.FormatPoints(h.requestLocalizer(ctx), rating.Score, group.PointsLabel)) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingUserAccuracy, fmt.Sprintf("%.1f", accuracy)) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingUserStreak, fmt.Sprintf("%d", rating.Streak)) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingUserCorrect, fmt.Sprintf("%d", rating.CorrectCount)) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingUserWrong, fmt.Sprintf("%d", rating.WrongCount)) + "\n\n")
+		entries = append(entries, sb.String())
 	}
 
-	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: chatID,
-		Text:   sb.String(),
-	})
-	if err != nil {
-		h.logger.Error("failed to send rating message", "error", err)
+	return chunkMessage(header, entries, telegramMessageLimit), nil
+}
+
+// parseRatingLimit parses "/rating [count]", defaulting to defaultRatingEntries.
+// The count must be between 1 and maxEntries.
+func parseRatingLimit(text string, maxEntries int) (int, bool) {
+	command, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != ratingCommand && !strings.HasPrefix(command, ratingCommand+"@") {
+		return 0, false
 	}
+
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return defaultRatingEntries, true
+	}
+
+	limit, err := strconv.Atoi(arg)
+	if err != nil || limit <= 0 || limit > maxEntries {
+		return 0, false
+	}
+	return limit, true
 }
 
 // HandleMy handles the /my command
@@ -618,47 +819,21 @@ func (h *BotHandler) HandleMy(ctx context.Context, b *bot.Bot, update *models.Up
 	// Determine user's current group context
 	groupID, err := h.groupContextResolver.ResolveGroupForUser(ctx, userID)
 	if err != nil {
-		if err == domain.ErrNoGroupMembership {
-			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.GroupContextNoMembership),
-			})
-			return
-		}
-		if err == domain.ErrMultipleGroupsNeedChoice {
-			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.GroupContextMultipleGroups),
-			})
-			return
-		}
-		h.logger.Error("failed to resolve group context", "user_id", userID, "error", err)
-		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ErrorGeneric),
-		})
+		h.replyError(ctx, b, chatID, err, "failed to resolve group context", "user_id", userID)
 		return
 	}
 
 	// Get group information
 	group, err := h.groupRepo.GetGroup(ctx, groupID)
 	if err != nil || group == nil {
-		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
-		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ErrorGeneric),
-		})
+		h.replyError(ctx, b, chatID, err, "failed to get group", "group_id", groupID)
 		return
 	}
 
 	// Get user rating for this group
 	rating, err := h.ratingCalculator.GetUserRating(ctx, userID, groupID)
 	if err != nil {
-		h.logger.Error("failed to get user rating", "user_id", userID, "group_id", groupID, "error", err)
-		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ErrorGeneric),
-		})
+		h.replyError(ctx, b, chatID, err, "failed to get user rating", "user_id", userID, "group_id", groupID)
 		return
 	}
 
@@ -671,8 +846,8 @@ func (h *BotHandler) HandleMy(ctx context.Context, b *bot.Bot, update *models.Up
 
 	// Build stats message
 	var sb strings.Builder
-	sb.WriteString(h.localizer.MustLocalize(locale.MyStatsTitle2) + "\n")
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.MyStatsGroupName, group.Name) + "\n\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.MyStatsTitle2) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsGroupName, group.Name) + "\n\n")
 
 	total := rating.CorrectCount + rating.WrongCount
 	accuracy := 0.0
@@ -680,22 +855,31 @@ func (h *BotHandler) HandleMy(ctx context.Context, b *bot.Bot, update *models.Up
 		accuracy = float64(rating.CorrectCount) / float64(total) * 100
 	}
 
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.MyStatsPoints2, fmt.Sprintf("%d", rating.Score)) + "\n")
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.MyStatsCorrect2, fmt.Sprintf("%d", rating.CorrectCount)) + "\n")
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.MyStatsWrong2, fmt.Sprintf("%d", rating.WrongCount)) + "\n")
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.MyStatsAccuracy2, fmt.Sprintf("%.1f", accuracy)) + "\n")
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.MyStatsCurrentStreak, fmt.Sprintf("%d", rating.Streak)) + "\n")
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.MyStatsTotalPreds, fmt.Sprintf("%d", total)) + "\n\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsPoints2, domain.FormatPoints(h.requestLocalizer(ctx), rating.Score, group.PointsLabel)) + "\n")
+	if rank, rankTotal, err := h.ratingCalculator.GetUserRank(ctx, userID, groupID); err != nil {
+		h.logger.Error("failed to get user rank", "user_id", userID, "group_id", groupID, "error", err)
+	} else if rank > 0 {
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsRank, fmt.Sprintf("%d", rank), fmt.Sprintf("%d", rankTotal)) + "\n")
+	} else {
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.MyStatsRankNone) + "\n")
+	}
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsCorrect2, fmt.Sprintf("%d", rating.CorrectCount)) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsWrong2, fmt.Sprintf("%d", rating.WrongCount)) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsAccuracy2, fmt.Sprintf("%.1f", accuracy)) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsCurrentStreak, fmt.Sprintf("%d", rating.Streak)) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsBestStreak, fmt.Sprintf("%d", rating.BestStreak)) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MyStatsTotalPreds, fmt.Sprintf("%d", total)) + "\n\n")
 
 	// Add achievements
 	if len(achievements) > 0 {
-		sb.WriteString(h.localizer.MustLocalize(locale.MyStatsAchievements) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.MyStatsAchievements) + "\n")
 		achievementNames := map[domain.AchievementCode]string{
-			domain.AchievementSharpshooter:  h.localizer.MustLocalize(locale.AchievementSharpshooterName),
-			domain.AchievementProphet:       h.localizer.MustLocalize(locale.AchievementProphetName),
-			domain.AchievementRiskTaker:     h.localizer.MustLocalize(locale.AchievementRiskTakerName),
-			domain.AchievementWeeklyAnalyst: h.localizer.MustLocalize(locale.AchievementWeeklyAnalystName),
-			domain.AchievementVeteran:       h.localizer.MustLocalize(locale.AchievementVeteranName),
+			domain.AchievementSharpshooter:  h.requestLocalizer(ctx).MustLocalize(locale.AchievementSharpshooterName),
+			domain.AchievementProphet:       h.requestLocalizer(ctx).MustLocalize(locale.AchievementProphetName),
+			domain.AchievementRiskTaker:     h.requestLocalizer(ctx).MustLocalize(locale.AchievementRiskTakerName),
+			domain.AchievementWeeklyAnalyst: h.requestLocalizer(ctx).MustLocalize(locale.AchievementWeeklyAnalystName),
+			domain.AchievementVeteran:       h.requestLocalizer(ctx).MustLocalize(locale.AchievementVeteranName),
+			domain.AchievementGlobeTrotter:  h.requestLocalizer(ctx).MustLocalize(locale.AchievementGlobeTrotterName),
 		}
 		for _, ach := range achievements {
 			name := achievementNames[ach.Code]
@@ -705,8 +889,8 @@ func (h *BotHandler) HandleMy(ctx context.Context, b *bot.Bot, update *models.Up
 			sb.WriteString(fmt.Sprintf("  • %s\n", name))
 		}
 	} else {
-		sb.WriteString(h.localizer.MustLocalize(locale.MyStatsAchievements) + "\n")
-		sb.WriteString(h.localizer.MustLocalize(locale.MyStatsNoAchievements2))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.MyStatsAchievements) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.MyStatsNoAchievements2))
 	}
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
@@ -726,68 +910,71 @@ func (h *BotHandler) HandleEvents(ctx context.Context, b *bot.Bot, update *model
 	// Get all groups where user has membership
 	groups, err := h.groupRepo.GetUserGroups(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to get user groups", "user_id", userID, "error", err)
-		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ErrorGeneric),
-		})
+		h.replyError(ctx, b, chatID, err, "failed to get user groups", "user_id", userID)
 		return
 	}
 
 	if len(groups) == 0 {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.GroupContextNoMembership),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupContextNoMembership),
 		})
 		return
 	}
 
-	// Collect all active events from all user's groups
+	// Collect active events visible to the user from all user's groups
 	var allEvents []*domain.Event
-	groupNames := make(map[int64]string)
+	groupsByID := make(map[int64]*domain.Group)
+	skippedGroups := 0
 	for _, group := range groups {
-		groupNames[group.ID] = group.Name
-		events, err := h.eventManager.GetActiveEvents(ctx, group.ID)
+		groupsByID[group.ID] = group
+		events, err := h.eventManager.GetVisibleActiveEvents(ctx, group.ID, userID)
 		if err != nil {
 			h.logger.Error("failed to get active events for group", "group_id", group.ID, "error", err)
+			skippedGroups++
 			continue
 		}
 		allEvents = append(allEvents, events...)
 	}
 
 	if len(allEvents) == 0 {
+		text := h.requestLocalizer(ctx).MustLocalize(locale.EventsNoActive)
+		if footer := h.skippedItemsFooter(ctx, skippedGroups); footer != "" {
+			text += "\n\n" + footer
+		}
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.EventsNoActive),
+			Text:   text,
 		})
 		return
 	}
 
 	// Build events list message
 	var sb strings.Builder
-	sb.WriteString(h.localizer.MustLocalize(locale.EventsActiveTitle) + "\n\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.EventsActiveTitle) + "\n\n")
 
 	for i, event := range allEvents {
 		// Include group name for context
-		groupName := groupNames[event.GroupID]
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.EventsItemNumber, fmt.Sprintf("%d", i+1), event.Question) + "\n")
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.EventsItemGroup, groupName) + "\n\n")
+		group := groupsByID[event.GroupID]
+		groupName := group.Name
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemNumber, fmt.Sprintf("%d", i+1), event.Question) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemGroup, groupName) + "\n\n")
 
 		// Event type
 		typeStr := ""
 		typeIcon := ""
 		switch event.EventType {
 		case domain.EventTypeBinary:
-			typeStr = h.localizer.MustLocalize(locale.EventTypeBinaryLabel)
-			typeIcon = h.localizer.MustLocalize(locale.EventTypeBinaryIcon)
+			typeStr = h.requestLocalizer(ctx).MustLocalize(locale.EventTypeBinaryLabel)
+			typeIcon = h.requestLocalizer(ctx).MustLocalize(locale.EventTypeBinaryIcon)
 		case domain.EventTypeMultiOption:
-			typeStr = h.localizer.MustLocalize(locale.EventTypeMultiOptionLabel)
-			typeIcon = h.localizer.MustLocalize(locale.EventTypeMultiOptionIcon)
+			typeStr = h.requestLocalizer(ctx).MustLocalize(locale.EventTypeMultiOptionLabel)
+			typeIcon = h.requestLocalizer(ctx).MustLocalize(locale.EventTypeMultiOptionIcon)
 		case domain.EventTypeProbability:
-			typeStr = h.localizer.MustLocalize(locale.EventTypeProbabilityLabel)
-			typeIcon = h.localizer.MustLocalize(locale.EventTypeProbabilityIcon)
+			typeStr = h.requestLocalizer(ctx).MustLocalize(locale.EventTypeProbabilityLabel)
+			typeIcon = h.requestLocalizer(ctx).MustLocalize(locale.EventTypeProbabilityIcon)
 		}
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.EventsItemType, typeIcon, typeStr) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemType, typeIcon, typeStr) + "\n")
 
 		// Get vote distribution for this event
 		predictions, err := h.predictionRepo.GetPredictionsByEvent(ctx, event.ID)
@@ -796,23 +983,46 @@ func (h *BotHandler) HandleEvents(ctx context.Context, b *bot.Bot, update *model
 			predictions = []*domain.Prediction{} // Continue with empty predictions
 		}
 
-		// Calculate vote distribution
-		voteDistribution := h.calculateVoteDistribution(predictions, len(event.Options))
+		// Calculate vote distribution, weighted by the voters' ratings when the group opted in
+		var weights map[int64]float64
+		if group.ReputationWeighting {
+			weights, err = domain.VoteWeights(ctx, h.ratingRepo, event.GroupID, predictions)
+			if err != nil {
+				h.logger.Error("failed to get vote weights", "event_id", event.ID, "error", err)
+				weights = nil // Fall back to one vote per person
+			}
+		}
+		voteDistribution := h.calculateVoteDistribution(predictions, len(event.Options), weights)
 		totalVotes := len(predictions)
 
-		// Options with vote percentages
-		sb.WriteString("\n" + h.localizer.MustLocalize(locale.EventsItemOptions) + "\n")
+		// Options with vote percentages (and implied odds when enabled)
+		sb.WriteString("\n" + h.requestLocalizer(ctx).MustLocalize(locale.EventsItemOptions) + "\n")
 		for j, opt := range event.Options {
+			if h.config.EventsShowOdds && totalVotes == 0 {
+				sb.WriteString(fmt.Sprintf("  %d) %s\n", j+1, opt))
+				continue
+			}
+
 			percentage := voteDistribution[j]
 			// Create a simple progress bar
 			barLength := int(percentage / 10)
 			if barLength > 10 {
 				barLength = 10
 			}
-			bar := strings.Repeat("▰", barLength) + strings.Repeat("▱", 10-barLength)
-			sb.WriteString(fmt.Sprintf("  %d) %s\n     %s %.1f%%\n", j+1, opt, bar, percentage))
+			bar := h.progressBar(barLength)
+			sb.WriteString(fmt.Sprintf("  %d) %s\n     %s %.1f%%", j+1, opt, bar, percentage))
+			if h.config.EventsShowOdds {
+				sb.WriteString(" · " + h.formatOdds(ctx, percentage))
+			}
+			sb.WriteString("\n")
 		}
-		sb.WriteString("\n" + h.localizer.MustLocalizeWithTemplate(locale.EventsItemVotes, fmt.Sprintf("%d", totalVotes)) + "\n")
+		if h.config.EventsShowOdds && totalVotes == 0 {
+			sb.WriteString("\n" + h.requestLocalizer(ctx).MustLocalize(locale.EventsItemNoOddsYet) + "\n")
+		}
+		if weights != nil && totalVotes > 0 {
+			sb.WriteString("\n" + h.requestLocalizer(ctx).MustLocalize(locale.EventsItemWeightedVotes) + "\n")
+		}
+		sb.WriteString("\n" + h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemVotes, fmt.Sprintf("%d", totalVotes)) + "\n")
 
 		// Deadline
 		timeUntil := time.Until(event.Deadline)
@@ -822,20 +1032,21 @@ func (h *BotHandler) HandleEvents(ctx context.Context, b *bot.Bot, update *model
 			minutes := int(timeUntil.Minutes()) % 60
 			if hours > 24 {
 				days := hours / 24
-				deadlineStr = h.localizer.MustLocalizeWithTemplate(locale.EventsItemTimeRemainingDays, fmt.Sprintf("%d", days), fmt.Sprintf("%d", hours%24))
+				deadlineStr = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemTimeRemainingDays, fmt.Sprintf("%d", days), fmt.Sprintf("%d", hours%24))
 			} else if hours > 0 {
-				deadlineStr = h.localizer.MustLocalizeWithTemplate(locale.EventsItemTimeRemainingHours, fmt.Sprintf("%d", hours), fmt.Sprintf("%d", minutes))
+				deadlineStr = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemTimeRemainingHours, fmt.Sprintf("%d", hours), fmt.Sprintf("%d", minutes))
 			} else {
-				deadlineStr = h.localizer.MustLocalizeWithTemplate(locale.EventsItemTimeRemainingMinutes, fmt.Sprintf("%d", minutes))
+				deadlineStr = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemTimeRemainingMinutes, fmt.Sprintf("%d", minutes))
 			}
 			// Show deadline in local timezone
 			localDeadline := event.Deadline.In(h.config.Timezone)
-			deadlineStr += h.localizer.MustLocalizeWithTemplate(locale.EventsItemDeadlineFormat, localDeadline.Format("02.01 15:04"))
+			deadlineStr += h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemDeadlineFormat, localDeadline.Format("02.01 15:04"))
 		} else {
-			deadlineStr = h.localizer.MustLocalize(locale.EventsItemDeadlineExpired)
+			deadlineStr = h.requestLocalizer(ctx).MustLocalize(locale.EventsItemDeadlineExpired)
 		}
 		sb.WriteString(deadlineStr + "\n\n")
 	}
+	sb.WriteString(h.skippedItemsFooter(ctx, skippedGroups))
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
@@ -846,9 +1057,39 @@ func (h *BotHandler) HandleEvents(ctx context.Context, b *bot.Bot, update *model
 	}
 }
 
-// calculateVoteDistribution calculates the percentage of votes for each option
+// formatOdds formats the decimal odds implied by an option's share of votes (40% of votes = 2.50).
+// Options nobody voted for have no odds.
+func (h *BotHandler) formatOdds(ctx context.Context, percentage float64) string {
+	if percentage <= 0 {
+		return h.requestLocalizer(ctx).MustLocalize(locale.EventsItemOddsNone)
+	}
+	return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemOdds, fmt.Sprintf("%.2f", 100.0/percentage))
+}
+
+// podiumMedals returns the emojis for the top three leaderboard places, the defaults unless configured
+func (h *BotHandler) podiumMedals() []string {
+	if len(h.config.LeaderboardMedals) == 0 {
+		return strings.Split(config.DefaultLeaderboardMedals, ",")
+	}
+	return h.config.LeaderboardMedals
+}
+
+// progressBar renders a ten-segment bar with the given number of filled segments
+func (h *BotHandler) progressBar(filled int) string {
+	filledSegment, emptySegment := h.config.ProgressBarFilled, h.config.ProgressBarEmpty
+	if filledSegment == "" {
+		filledSegment = config.DefaultProgressBarFilled
+	}
+	if emptySegment == "" {
+		emptySegment = config.DefaultProgressBarEmpty
+	}
+	return strings.Repeat(filledSegment, filled) + strings.Repeat(emptySegment, 10-filled)
+}
+
+// calculateVoteDistribution calculates the percentage of votes for each option.
+// With nil weights every vote counts once, otherwise votes are weighted per voter.
 // Returns a map of option index to percentage
-func (h *BotHandler) calculateVoteDistribution(predictions []*domain.Prediction, numOptions int) map[int]float64 {
+func (h *BotHandler) calculateVoteDistribution(predictions []*domain.Prediction, numOptions int, weights map[int64]float64) map[int]float64 {
 	distribution := make(map[int]float64)
 
 	// Initialize all options to 0%
@@ -856,21 +1097,9 @@ func (h *BotHandler) calculateVoteDistribution(predictions []*domain.Prediction,
 		distribution[i] = 0.0
 	}
 
-	// If no votes, return all zeros
-	if len(predictions) == 0 {
-		return distribution
-	}
-
-	// Count votes for each option
-	voteCounts := make(map[int]int)
-	for _, pred := range predictions {
-		voteCounts[pred.Option]++
-	}
-
-	// Calculate percentages
-	totalVotes := float64(len(predictions))
-	for option, count := range voteCounts {
-		distribution[option] = (float64(count) / totalVotes) * 100.0
+	// Convert shares to percentages
+	for option, share := range domain.VoteShares(predictions, weights) {
+		distribution[option] = share * 100.0
 	}
 
 	return distribution
@@ -885,6 +1114,9 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 	pollAnswer := update.PollAnswer
 	userID := pollAnswer.User.ID
 	pollID := pollAnswer.PollID
+	log := withLogFields(h.loggerFor(update), "poll_id", pollID)
+	ctx = contextWithLogger(ctx, log)
+	ctx = contextWithLocalizer(ctx, h.localizerFor(ctx, update))
 
 	// Get event by poll ID
 	// event, err := h.eventManager.GetEvent(ctx, 0) // We need to find by poll ID
@@ -896,20 +1128,22 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 	// Find event by poll ID - we need to search through user's groups
 	groups, err := h.groupRepo.GetUserGroups(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to get user groups", "user_id", userID, "error", err)
+		log.Error("failed to get user groups", "error", err)
 		return
 	}
 
 	var matchedEvent *domain.Event
+	var matchedGroup *domain.Group
 	for _, group := range groups {
 		events, err := h.eventManager.GetActiveEvents(ctx, group.ID)
 		if err != nil {
-			h.logger.Error("failed to get active events for group", "group_id", group.ID, "error", err)
+			log.Error("failed to get active events for group", "group_id", group.ID, "error", err)
 			continue
 		}
 		for _, e := range events {
 			if e.PollID == pollID {
 				matchedEvent = e
+				matchedGroup = group
 				break
 			}
 		}
@@ -919,7 +1153,14 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 	}
 
 	if matchedEvent == nil {
-		h.logger.Warn("poll answer for unknown or inaccessible event", "poll_id", pollID, "user_id", userID)
+		// Paused groups keep their polls open in Telegram, but votes aren't counted
+		if event, group := h.pausedGroupOfPoll(ctx, pollID); group != nil {
+			log.Warn("vote rejected: group paused", "group_id", group.ID)
+			h.notifyGroupPaused(ctx, b, userID, event, group)
+			return
+		}
+		log.Warn("poll answer for unknown or inaccessible event")
+		h.notifyUnmatchedVote(ctx, b, userID, pollID)
 		return
 	}
 
@@ -928,26 +1169,63 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 	// Verify user has active membership in the event's group
 	hasActiveMembership, err := h.groupMembershipRepo.HasActiveMembership(ctx, event.GroupID, userID)
 	if err != nil {
-		h.logger.Error("failed to check group membership", "user_id", userID, "group_id", event.GroupID, "error", err)
+		log.Error("failed to check group membership", "group_id", event.GroupID, "error", err)
 		return
 	}
 
 	if !hasActiveMembership {
-		h.logger.Warn("vote rejected: user not member of group", "user_id", userID, "event_id", event.ID, "group_id", event.GroupID)
+		log.Warn("vote rejected: user not member of group", "event_id", event.ID, "group_id", event.GroupID)
 		// Note: Telegram doesn't allow us to reject the vote in the UI, but we won't save it
+		h.notifyVoteNotMember(ctx, b, userID, event, matchedGroup)
+		return
+	}
+
+	// Verify user has accepted the rules in groups that require it
+	if matchedGroup.RequireRules {
+		membership, err := h.groupMembershipRepo.GetMembership(ctx, event.GroupID, userID)
+		if err != nil {
+			log.Error("failed to get group membership", "group_id", event.GroupID, "error", err)
+			return
+		}
+		if membership != nil && membership.RulesPending {
+			log.Warn("vote rejected: rules not accepted", "event_id", event.ID, "group_id", event.GroupID)
+			h.sendRulesPrompt(ctx, b, userID, matchedGroup, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RulesVoteRejected, matchedGroup.Name))
+			return
+		}
+	}
+
+	// Verify user is allowed to vote on events restricted to specific members
+	if !event.IsParticipant(userID) {
+		log.Warn("vote rejected: user not a participant of restricted event", "event_id", event.ID, "group_id", event.GroupID)
+		// Note: the native poll is visible to the whole group, but we won't save the vote
 		return
 	}
 
 	// Check if deadline has passed
 	if time.Now().After(event.Deadline) {
-		h.logger.Warn("vote after deadline", "user_id", userID, "event_id", event.ID)
+		log.Warn("vote after deadline", "event_id", event.ID)
 		// Note: Telegram doesn't allow us to reject the vote, but we won't save it
+		h.notifyVotingClosed(ctx, b, userID, event)
+		return
+	}
+
+	// Check if a manager closed voting early (the poll is stopped, but answers may still be in flight)
+	if event.VotingClosedAt != nil {
+		log.Warn("vote after voting was closed", "event_id", event.ID)
+		h.notifyVotingClosed(ctx, b, userID, event)
 		return
 	}
 
 	// Get the selected option (poll answers can have multiple options, but we use single-answer polls)
 	if len(pollAnswer.OptionIDs) == 0 {
-		h.logger.Warn("poll answer with no options", "user_id", userID, "event_id", event.ID)
+		log.Warn("poll answer with no options", "event_id", event.ID)
+		return
+	}
+
+	// Votes locked before the deadline: the poll is still open, but votes can't be cast or changed
+	if event.VotesLocked(time.Now()) {
+		log.Warn("vote after votes were locked", "event_id", event.ID)
+		h.notifyVotesLocked(ctx, b, userID, event)
 		return
 	}
 
@@ -956,13 +1234,22 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 	// Check if prediction already exists
 	existingPrediction, err := h.predictionRepo.GetPredictionByUserAndEvent(ctx, userID, event.ID)
 	if err != nil {
-		h.logger.Error("failed to check existing prediction", "user_id", userID, "event_id", event.ID, "error", err)
+		log.Error("failed to check existing prediction", "event_id", event.ID, "error", err)
+		return
+	}
+
+	// In groups where the first vote is final a changed vote keeps the original prediction
+	if existingPrediction != nil && matchedGroup.FirstVoteFinal {
+		if existingPrediction.Option != selectedOption {
+			log.Info("revote ignored: first vote is final", "event_id", event.ID, "group_id", event.GroupID, "option", existingPrediction.Option)
+			h.sendFirstVoteKept(ctx, b, userID, matchedGroup, event, existingPrediction.Option)
+		}
 		return
 	}
 
 	if existingPrediction != nil {
 		if !event.AllowsRevoting {
-			h.logger.Info("revote rejected: revoting disabled", "user_id", userID, "event_id", event.ID)
+			log.Info("revote rejected: revoting disabled", "event_id", event.ID)
 			return
 		}
 
@@ -971,11 +1258,11 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 		existingPrediction.Timestamp = time.Now()
 
 		if err := h.predictionRepo.UpdatePrediction(ctx, existingPrediction); err != nil {
-			h.logger.Error("failed to update prediction", "user_id", userID, "event_id", event.ID, "error", err)
+			log.Error("failed to update prediction", "event_id", event.ID, "error", err)
 			return
 		}
 
-		h.logger.Info("prediction updated", "user_id", userID, "event_id", event.ID, "group_id", event.GroupID, "option", selectedOption)
+		log.Info("prediction updated", "event_id", event.ID, "group_id", event.GroupID, "option", selectedOption)
 	} else {
 		// Create new prediction
 		prediction := &domain.Prediction{
@@ -986,11 +1273,21 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 		}
 
 		if err := h.predictionRepo.SavePrediction(ctx, prediction); err != nil {
-			h.logger.Error("failed to save prediction", "user_id", userID, "event_id", event.ID, "error", err)
+			log.Error("failed to save prediction", "event_id", event.ID, "error", err)
 			return
 		}
 
-		h.logger.Info("prediction saved", "user_id", userID, "event_id", event.ID, "group_id", event.GroupID, "option", selectedOption)
+		log.Info("prediction saved", "event_id", event.ID, "group_id", event.GroupID, "option", selectedOption)
+
+		// Credits the participation point right away when it's awarded at vote time
+		if err := h.ratingCalculator.AwardVoteParticipation(ctx, prediction, event.GroupID); err != nil {
+			log.Error("failed to award participation point", "event_id", event.ID, "error", err)
+		}
+	}
+
+	// Refresh live poll stats (debounced per event)
+	if h.pollStatsSyncer != nil {
+		h.pollStatsSyncer.ScheduleUpdate(event.ID)
 	}
 
 	// Update or create user rating with username
@@ -1012,7 +1309,7 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 	// Get or create rating to ensure username is saved
 	rating, err := h.ratingCalculator.GetUserRating(ctx, userID, event.GroupID)
 	if err != nil {
-		h.logger.Error("failed to get user rating", "user_id", userID, "group_id", event.GroupID, "error", err)
+		log.Error("failed to get user rating", "group_id", event.GroupID, "error", err)
 		return
 	}
 
@@ -1020,11 +1317,28 @@ func (h *BotHandler) HandlePollAnswer(ctx context.Context, b *bot.Bot, update *m
 	if rating.Username != username && username != "" {
 		rating.Username = username
 		if err := h.ratingCalculator.UpdateRatingUsername(ctx, rating); err != nil {
-			h.logger.Error("failed to update username", "user_id", userID, "group_id", event.GroupID, "error", err)
+			log.Error("failed to update username", "group_id", event.GroupID, "error", err)
 		}
 	}
 }
 
+// sendFirstVoteKept tells a member that the vote change was ignored and which vote stands
+func (h *BotHandler) sendFirstVoteKept(ctx context.Context, b *bot.Bot, userID int64, group *domain.Group, event *domain.Event, option int) {
+	optionText := ""
+	if option >= 0 && option < len(event.Options) {
+		optionText = event.Options[option]
+	}
+
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: userID,
+		Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.FirstVoteFinalVoteKept, group.Name, event.Question, optionText),
+	})
+	if err != nil {
+		// Users who never started the bot can't get direct messages
+		h.requestLogger(ctx).Warn("failed to send first vote notice", "event_id", event.ID, "error", err)
+	}
+}
+
 // checkConflictingSession checks if user has an active session of a different type
 // Returns the conflicting session type name or empty string if no conflict
 func (h *BotHandler) checkConflictingSession(ctx context.Context, userID int64, requestedType string) (string, error) {
@@ -1040,15 +1354,15 @@ func (h *BotHandler) checkConflictingSession(ctx context.Context, userID int64,
 	switch state {
 	case StateSelectGroup, StateAskQuestion, StateAskEventType, StateAskOptions, StateAskDeadline, StateConfirm, StateComplete:
 		if requestedType != "event_creation" {
-			return h.localizer.MustLocalize(locale.SessionTypeEventCreation), nil
+			return h.requestLocalizer(ctx).MustLocalize(locale.SessionTypeEventCreation), nil
 		}
 	case StateGroupAskName, StateGroupAskChatID, StateGroupComplete:
 		if requestedType != "group_creation" {
-			return h.localizer.MustLocalize(locale.SessionTypeGroupCreation), nil
+			return h.requestLocalizer(ctx).MustLocalize(locale.SessionTypeGroupCreation), nil
 		}
 	case StateResolveSelectEvent, StateResolveSelectOption, StateResolveComplete:
 		if requestedType != "event_resolution" {
-			return h.localizer.MustLocalize(locale.SessionTypeEventResolution), nil
+			return h.requestLocalizer(ctx).MustLocalize(locale.SessionTypeEventResolution), nil
 		}
 	}
 
@@ -1069,17 +1383,17 @@ func (h *BotHandler) HandleCreateEvent(ctx context.Context, b *bot.Bot, update *
 		kb := &models.InlineKeyboardMarkup{
 			InlineKeyboard: [][]models.InlineKeyboardButton{
 				{
-					{Text: h.localizer.MustLocalize(locale.SessionConflictContinueButton), CallbackData: "session_conflict:continue"},
+					{Text: h.requestLocalizer(ctx).MustLocalize(locale.SessionConflictContinueButton), CallbackData: mustEncodeCallback(cbSessionConflict, "continue")},
 				},
 				{
-					{Text: h.localizer.MustLocalize(locale.SessionConflictRestartButton), CallbackData: "session_conflict:restart:event_creation"},
+					{Text: h.requestLocalizer(ctx).MustLocalize(locale.SessionConflictRestartButton), CallbackData: mustEncodeCallback(cbSessionConflict, "restart", "event_creation")},
 				},
 			},
 		}
 
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        h.localizer.MustLocalizeWithTemplate(locale.SessionConflictWarning, conflictType),
+			Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SessionConflictWarning, conflictType),
 			ReplyMarkup: kb,
 		})
 		return
@@ -1093,22 +1407,28 @@ func (h *BotHandler) HandleCreateEvent(ctx context.Context, b *bot.Bot, update *
 			h.logger.Error("failed to get user groups", "user_id", userID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.EventResolutionErrorPermissionCheckRetry),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorPermissionCheckRetry),
 			})
 			return
 		}
 
 		if len(groups) == 0 {
+			if h.replyGroupsPaused(ctx, b, chatID, userID) {
+				return
+			}
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.EventCreationErrorNoGroups),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorNoGroups),
 			})
 			return
 		}
 
 		// Check if user has sufficient participation in at least one group
+		// and has been a member there longer than the new member cooldown
 		hasPermissionInAnyGroup := false
 		maxParticipation := 0
+		var cooldownWait time.Duration
+		now := time.Now()
 		for _, group := range groups {
 			canCreate, participationCount, err := h.eventPermissionValidator.CanCreateEvent(ctx, userID, group.ID, h.config.AdminUserIDs)
 			if err != nil {
@@ -1118,17 +1438,45 @@ func (h *BotHandler) HandleCreateEvent(ctx context.Context, b *bot.Bot, update *
 			if participationCount > maxParticipation {
 				maxParticipation = participationCount
 			}
-			if canCreate {
-				hasPermissionInAnyGroup = true
-				break
+			if !canCreate {
+				continue
 			}
+
+			remaining, err := h.newMemberCooldownRemaining(ctx, userID, group.ID, now)
+			if err != nil {
+				h.logger.Error("failed to check new member cooldown", "user_id", userID, "group_id", group.ID, "error", err)
+				continue
+			}
+			if remaining > 0 {
+				if cooldownWait == 0 || remaining < cooldownWait {
+					cooldownWait = remaining
+				}
+				continue
+			}
+
+			hasPermissionInAnyGroup = true
+			break
+		}
+
+		if !hasPermissionInAnyGroup && cooldownWait > 0 {
+			// User meets the participation requirement but joined too recently
+			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationNewMemberCooldown, h.formatCooldownWait(ctx, cooldownWait)),
+			})
+			h.logger.Info("event creation denied due to new member cooldown", "user_id", userID, "remaining", cooldownWait.String())
+			return
 		}
 
 		if !hasPermissionInAnyGroup {
 			// User doesn't have enough participation in any group
+			deniedKey := locale.EventCreationPermissionDenied
+			if h.eventPermissionValidator.ParticipationMode() == domain.ParticipationVoted {
+				deniedKey = locale.EventCreationPermissionDeniedVoted
+			}
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   h.localizer.MustLocalizeWithTemplate(locale.EventCreationPermissionDenied, fmt.Sprintf("%d", h.config.MinEventsToCreate), fmt.Sprintf("%d", maxParticipation)),
+				Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(deniedKey, fmt.Sprintf("%d", h.config.MinEventsToCreate), fmt.Sprintf("%d", maxParticipation)),
 			})
 			h.logger.Info("event creation denied due to insufficient participation", "user_id", userID, "max_participation", maxParticipation, "required", h.config.MinEventsToCreate)
 			return
@@ -1139,12 +1487,16 @@ func (h *BotHandler) HandleCreateEvent(ctx context.Context, b *bot.Bot, update *
 	if err := h.eventCreationFSM.Start(ctx, userID, chatID); err != nil {
 		h.logger.Error("failed to start FSM session", "user_id", userID, "error", err)
 
+		if err == domain.ErrNoGroupMembership && h.replyGroupsPaused(ctx, b, chatID, userID) {
+			return
+		}
+
 		// Provide user-friendly error message based on error type
 		var errorMsg string
 		if err == domain.ErrNoGroupMembership {
-			errorMsg = h.localizer.MustLocalize(locale.EventCreationErrorNoGroupsHelp)
+			errorMsg = h.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorNoGroupsHelp)
 		} else {
-			errorMsg = h.localizer.MustLocalize(locale.EventCreationErrorStart)
+			errorMsg = h.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorStart)
 		}
 
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
@@ -1159,18 +1511,28 @@ func (h *BotHandler) HandleCreateEvent(ctx context.Context, b *bot.Bot, update *
 
 // HandleMessage handles regular text messages (for conversation flows)
 func (h *BotHandler) HandleMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
-	if update.Message == nil || update.Message.Text == "" {
+	// CSV uploads for /import_predictions carry the command in the caption
+	if isImportPredictionsDocument(update.Message) {
+		h.handleImportPredictionsDocument(ctx, b, update)
 		return
 	}
 
+	// Photos (without text) are accepted as the optional event image during event creation
+	if update.Message == nil || (update.Message.Text == "" && len(update.Message.Photo) == 0) {
+		return
+	}
+
+	log := h.loggerFor(update)
+	ctx = contextWithLogger(ctx, log)
+	ctx = contextWithLocalizer(ctx, h.localizerFor(ctx, update))
+
 	// Log message_thread_id if this is a forum topic message
 	if update.Message.MessageThreadID != 0 {
 		textPreview := update.Message.Text
 		if len(textPreview) > 50 {
 			textPreview = textPreview[:50]
 		}
-		h.logger.Info("message received in forum topic",
-			"chat_id", update.Message.Chat.ID,
+		log.Info("message received in forum topic",
 			"chat_title", update.Message.Chat.Title,
 			"message_thread_id", update.Message.MessageThreadID,
 			"is_forum", update.Message.Chat.IsForum,
@@ -1183,16 +1545,16 @@ func (h *BotHandler) HandleMessage(ctx context.Context, b *bot.Bot, update *mode
 	// Check if user has active group creation FSM session
 	hasGroupSession, err := h.groupCreationFSM.HasSession(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to check group creation FSM session", "user_id", userID, "error", err)
+		log.Error("failed to check group creation FSM session", "error", err)
 	} else if hasGroupSession {
 		// Route to group creation FSM
 		if err := h.groupCreationFSM.HandleMessage(ctx, update); err != nil {
-			h.logger.Error("group creation FSM message handling failed", "user_id", userID, "error", err)
+			log.Error("group creation FSM message handling failed", "error", err)
 
 			// Inform user to restart
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: update.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.FSMErrorRestartGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.FSMErrorRestartGroup),
 			})
 		}
 		return
@@ -1201,19 +1563,19 @@ func (h *BotHandler) HandleMessage(ctx context.Context, b *bot.Bot, update *mode
 	// Check if user has active event creation FSM session
 	hasEventSession, err := h.eventCreationFSM.HasSession(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to check event creation FSM session", "user_id", userID, "error", err)
+		log.Error("failed to check event creation FSM session", "error", err)
 		return
 	}
 
 	if hasEventSession {
 		// Route to event creation FSM
 		if err := h.eventCreationFSM.HandleMessage(ctx, update); err != nil {
-			h.logger.Error("event creation FSM message handling failed", "user_id", userID, "error", err)
+			log.Error("event creation FSM message handling failed", "error", err)
 
 			// Inform user to restart
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: update.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.FSMErrorRestartEvent),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.FSMErrorRestartEvent),
 			})
 		}
 		return
@@ -1222,16 +1584,16 @@ func (h *BotHandler) HandleMessage(ctx context.Context, b *bot.Bot, update *mode
 	// Check if user has active rename FSM session
 	hasRenameSession, err := h.renameFSM.HasSession(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to check rename FSM session", "user_id", userID, "error", err)
+		log.Error("failed to check rename FSM session", "error", err)
 	} else if hasRenameSession {
 		// Route to rename FSM
 		if err := h.renameFSM.HandleMessage(ctx, update); err != nil {
-			h.logger.Error("rename FSM message handling failed", "user_id", userID, "error", err)
+			log.Error("rename FSM message handling failed", "error", err)
 
 			// Inform user to restart
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: update.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.FSMErrorRestartRename),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.FSMErrorRestartRename),
 			})
 		}
 		return
@@ -1240,16 +1602,34 @@ func (h *BotHandler) HandleMessage(ctx context.Context, b *bot.Bot, update *mode
 	// Check if user has active event edit FSM session
 	hasEditSession, err := h.eventEditFSM.HasSession(ctx, userID)
 	if err != nil {
-		h.logger.Error("failed to check event edit FSM session", "user_id", userID, "error", err)
+		log.Error("failed to check event edit FSM session", "error", err)
 	} else if hasEditSession {
 		// Route to event edit FSM
 		if err := h.eventEditFSM.HandleMessage(ctx, update); err != nil {
-			h.logger.Error("event edit FSM message handling failed", "user_id", userID, "error", err)
+			log.Error("event edit FSM message handling failed", "error", err)
+
+			// Inform user to restart
+			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: update.Message.Chat.ID,
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.FSMErrorRestartEdit),
+			})
+		}
+		return
+	}
+
+	// Check if user has active event resolution FSM session (probability outcome input)
+	hasResolutionSession, err := h.eventResolutionFSM.HasSession(ctx, userID)
+	if err != nil {
+		log.Error("failed to check resolution FSM session", "error", err)
+	} else if hasResolutionSession {
+		// Route to event resolution FSM
+		if err := h.eventResolutionFSM.HandleMessage(ctx, update); err != nil {
+			log.Error("resolution FSM message handling failed", "error", err)
 
 			// Inform user to restart
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: update.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.FSMErrorRestartEdit),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.FSMErrorRestart),
 			})
 		}
 		return
@@ -1266,20 +1646,31 @@ func (h *BotHandler) HandleCallback(ctx context.Context, b *bot.Bot, update *mod
 
 	callback := update.CallbackQuery
 	userID := callback.From.ID
-	data := callback.Data
+	log := h.loggerFor(update)
+	ctx = contextWithLogger(ctx, log)
+	ctx = contextWithLocalizer(ctx, h.localizerFor(ctx, update))
 
-	// Handle session conflict resolution callbacks
-	if strings.HasPrefix(data, "session_conflict:") {
-		h.handleSessionConflictCallback(ctx, b, callback)
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		log.Warn("rejected malformed callback data", "data_length", len(callback.Data), "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidDataFormat),
+		})
 		return
 	}
 
-	// Check if this is an event creation FSM callback (group selection, event_type selection, deadline preset, poll settings or confirmation)
-	if strings.HasPrefix(data, "select_group:") || strings.HasPrefix(data, "event_type:") || strings.HasPrefix(data, "deadline_preset:") || strings.HasPrefix(data, "poll_setting:") || strings.HasPrefix(data, "confirm:") {
-		// Check if user has active FSM session
+	switch cb.Namespace {
+	case cbSessionConflict:
+		// Handle session conflict resolution callbacks
+		h.handleSessionConflictCallback(ctx, b, callback, cb)
+		return
+
+	case cbSelectGroup, cbEventType, cbDeadlinePreset, cbEventReminders, cbEventPhoto, cbPollSetting, cbParticipants, cbReorderOption, cbConfirm:
+		// Event creation FSM callback (group selection, event_type selection, deadline preset, photo, poll settings, participants or confirmation)
 		hasSession, err := h.eventCreationFSM.HasSession(ctx, userID)
 		if err != nil {
-			h.logger.Error("failed to check FSM session for callback", "user_id", userID, "error", err)
+			log.Error("failed to check FSM session for callback", "error", err)
 			// Answer callback query to remove loading state
 			_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: callback.ID,
@@ -1290,18 +1681,16 @@ func (h *BotHandler) HandleCallback(ctx context.Context, b *bot.Bot, update *mod
 		if hasSession {
 			// Route to FSM
 			if err := h.eventCreationFSM.HandleCallback(ctx, callback); err != nil {
-				h.logger.Error("FSM callback handling failed", "user_id", userID, "error", err)
+				log.Error("FSM callback handling failed", "error", err)
 			}
 			return
 		}
-	}
 
-	// Check if this is a group creation FSM callback
-	if strings.HasPrefix(data, "group_is_forum:") {
-		// Check if user has active group creation FSM session
+	case cbGroupIsForum:
+		// Group creation FSM callback
 		hasSession, err := h.groupCreationFSM.HasSession(ctx, userID)
 		if err != nil {
-			h.logger.Error("failed to check group creation FSM session for callback", "user_id", userID, "error", err)
+			log.Error("failed to check group creation FSM session for callback", "error", err)
 			// Answer callback query to remove loading state
 			_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: callback.ID,
@@ -1312,18 +1701,20 @@ func (h *BotHandler) HandleCallback(ctx context.Context, b *bot.Bot, update *mod
 		if hasSession {
 			// Route to group creation FSM
 			if err := h.groupCreationFSM.HandleCallback(ctx, callback); err != nil {
-				h.logger.Error("group creation FSM callback handling failed", "user_id", userID, "error", err)
+				log.Error("group creation FSM callback handling failed", "error", err)
 			}
 			return
 		}
-	}
 
-	// Check if this is an event resolution FSM callback
-	if strings.HasPrefix(data, "resolve:") {
-		// Check if user has active resolution FSM session
+	case cbResolvePage:
+		h.handleResolvePageCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbResolve:
+		// Event resolution FSM callback
 		hasSession, err := h.eventResolutionFSM.HasSession(ctx, userID)
 		if err != nil {
-			h.logger.Error("failed to check resolution FSM session for callback", "user_id", userID, "error", err)
+			log.Error("failed to check resolution FSM session for callback", "error", err)
 			// Answer callback query to remove loading state
 			_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: callback.ID,
@@ -1334,81 +1725,144 @@ func (h *BotHandler) HandleCallback(ctx context.Context, b *bot.Bot, update *mod
 		if hasSession {
 			// Route to resolution FSM
 			if err := h.eventResolutionFSM.HandleCallback(ctx, callback); err != nil {
-				h.logger.Error("resolution FSM callback handling failed", "user_id", userID, "error", err)
+				log.Error("resolution FSM callback handling failed", "error", err)
 			}
 			return
 		}
 
 		// No active session - start a new resolution session for this event
-		h.handleResolveEventFromCallback(ctx, b, callback)
+		h.handleResolveEventFromCallback(ctx, b, callback, cb)
 		return
-	}
 
-	// Handle edit_event callbacks
-	if strings.HasPrefix(data, "edit_event:") {
-		h.handleEditEventCallback(ctx, b, callback)
+	case cbEditEvent:
+		h.handleEditEventCallback(ctx, b, callback, cb)
+		return
+
+	case cbCloseVoting:
+		h.handleCloseVotingCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle edit_field callbacks (from event edit FSM)
-	if strings.HasPrefix(data, "edit_field:") || strings.HasPrefix(data, "edit_deadline_preset:") {
+	case cbEditField, cbEditDeadlinePreset:
+		// Event edit FSM callbacks
 		if err := h.eventEditFSM.HandleCallback(ctx, callback); err != nil {
-			h.logger.Error("event edit FSM callback handling failed", "user_id", userID, "error", err)
+			log.Error("event edit FSM callback handling failed", "error", err)
 		}
 		return
-	}
 
-	// Handle leave_group callbacks
-	if strings.HasPrefix(data, "leave_group:") {
-		h.handleLeaveGroupCallback(ctx, b, callback, userID, data)
+	case cbLeaveGroup:
+		h.handleLeaveGroupCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle group_members callbacks
-	if strings.HasPrefix(data, "group_members:") {
-		h.handleGroupMembersCallback(ctx, b, callback, userID, data)
+	case cbGroupMembers:
+		h.handleGroupMembersCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle remove_member callbacks
-	if strings.HasPrefix(data, "remove_member_group:") || strings.HasPrefix(data, "remove_member_user:") {
-		h.handleRemoveMemberCallback(ctx, b, callback, userID, data)
+	case cbRemoveMemberGroup, cbRemoveMemberUser:
+		h.handleRemoveMemberCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle delete_group callbacks
-	if strings.HasPrefix(data, "delete_group_") {
-		h.handleDeleteGroupCallback(ctx, b, callback, userID, data)
+	case cbDeleteGroupSelect, cbDeleteGroupConfirm:
+		h.handleDeleteGroupCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle delete_topic callbacks
-	if strings.HasPrefix(data, "delete_topic_") {
-		h.handleDeleteTopicCallback(ctx, b, callback, userID, data)
+	case cbDeleteTopicSelect, cbDeleteTopicGroup, cbDeleteTopicConfirm:
+		h.handleDeleteTopicCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle soft_delete_group callbacks
-	if strings.HasPrefix(data, "soft_delete_group_") {
-		h.handleSoftDeleteGroupCallback(ctx, b, callback, userID, data)
+	case cbSoftDeleteGroupSelect, cbSoftDeleteGroupConfirm:
+		h.handleSoftDeleteGroupCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle restore_group callbacks
-	if strings.HasPrefix(data, "restore_group_") {
-		h.handleRestoreGroupCallback(ctx, b, callback, userID, data)
+	case cbRestoreGroupSelect, cbRestoreGroupConfirm:
+		h.handleRestoreGroupCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle rename_group callbacks
-	if strings.HasPrefix(data, "rename_group_") {
-		h.handleRenameGroupCallback(ctx, b, callback, userID, data)
+	case cbPauseGroupSelect, cbPauseGroupConfirm, cbResumeGroupSelect, cbResumeGroupConfirm:
+		h.handlePauseGroupCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbActivateGroup:
+		h.handleActivateGroupCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbRenameGroupSelect, cbRenameGroupInput:
+		h.handleRenameGroupCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbRenameTopicSelect, cbRenameTopicGroup, cbRenameTopicInput:
+		h.handleRenameTopicCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbArchivePage, cbUnarchiveEvent:
+		h.handleArchiveCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbGroupStats:
+		h.handleGroupStatsCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbPinPollsToggle:
+		h.handlePinPollsCallback(ctx, b, callback, userID, cb)
 		return
-	}
 
-	// Handle rename_topic callbacks
-	if strings.HasPrefix(data, "rename_topic_") {
-		h.handleRenameTopicCallback(ctx, b, callback, userID, data)
+	case cbDefaultTypeGroup, cbDefaultTypeSet:
+		h.handleDefaultEventTypeCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbRequireRulesToggle:
+		h.handleRequireRulesCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbAcceptRules:
+		h.handleAcceptRulesCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbAutoRemoveInactiveToggle:
+		h.handleAutoRemoveInactiveCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbReputationWeightingToggle:
+		h.handleReputationWeightingCallback(ctx, b, callback, userID, cb)
+	case cbRequireApprovalToggle:
+		h.handleRequireApprovalCallback(ctx, b, callback, userID, cb)
+	case cbFirstVoteFinalToggle:
+		h.handleFirstVoteFinalCallback(ctx, b, callback, userID, cb)
+	case cbExcludeCreatorScoringToggle:
+		h.handleExcludeCreatorScoringCallback(ctx, b, callback, userID, cb)
+	case cbAchievementsGroup, cbAchievementsToggle, cbAchievementsBack:
+		h.handleAchievementSettingsCallback(ctx, b, callback, userID, cb)
+	case cbApproveEvent:
+		h.handleApproveEventCallback(ctx, b, callback, userID, cb)
+	case cbRejectEvent:
+		h.handleRejectEventCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbDuel:
+		h.handleDuelCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbSubscription:
+		h.handleSubscriptionCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbSessionDelete:
+		h.handleSessionDeleteCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbOutcomeNotifications:
+		h.handleOutcomeNotificationsCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbLanguage:
+		h.handleLanguageCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbUpcomingPage:
+		h.handleUpcomingCallback(ctx, b, callback, userID, cb)
+		return
+
+	case cbRatingRefresh:
+		h.handleRatingRefreshCallback(ctx, b, callback, userID, cb)
 		return
 	}
 
@@ -1432,17 +1886,17 @@ func (h *BotHandler) HandleResolveEvent(ctx context.Context, b *bot.Bot, update
 		kb := &models.InlineKeyboardMarkup{
 			InlineKeyboard: [][]models.InlineKeyboardButton{
 				{
-					{Text: h.localizer.MustLocalize(locale.SessionConflictContinueButton), CallbackData: "session_conflict:continue"},
+					{Text: h.requestLocalizer(ctx).MustLocalize(locale.SessionConflictContinueButton), CallbackData: mustEncodeCallback(cbSessionConflict, "continue")},
 				},
 				{
-					{Text: h.localizer.MustLocalize(locale.SessionConflictRestartButton), CallbackData: "session_conflict:restart:event_resolution"},
+					{Text: h.requestLocalizer(ctx).MustLocalize(locale.SessionConflictRestartButton), CallbackData: mustEncodeCallback(cbSessionConflict, "restart", "event_resolution")},
 				},
 			},
 		}
 
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        h.localizer.MustLocalizeWithTemplate(locale.SessionConflictWarning, conflictType),
+			Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SessionConflictWarning, conflictType),
 			ReplyMarkup: kb,
 		})
 		return
@@ -1453,85 +1907,44 @@ func (h *BotHandler) HandleResolveEvent(ctx context.Context, b *bot.Bot, update
 		h.logger.Error("failed to start resolution FSM session", "user_id", userID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.EventResolutionErrorStart),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorStart),
 		})
 		return
 	}
 
-	// Get all groups where user has access (admin sees all, others see their groups)
-	var groups []*domain.Group
-	if h.isAdmin(userID) {
-		groups, err = h.groupRepo.GetAllGroups(ctx)
-	} else {
-		groups, err = h.groupRepo.GetUserGroups(ctx, userID)
-	}
+	// Get active events from accessible groups that the user can manage
+	manageableEvents, hasActiveEvents, err := h.manageableActiveEvents(ctx, userID)
 	if err != nil {
 		h.logger.Error("failed to get groups", "user_id", userID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.EventResolutionErrorGroups),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorGroups),
 		})
 		return
 	}
 
-	// Get all active events from accessible groups
-	var allEvents []*domain.Event
-	for _, group := range groups {
-		events, err := h.eventManager.GetActiveEvents(ctx, group.ID)
-		if err != nil {
-			h.logger.Error("failed to get active events for group", "group_id", group.ID, "error", err)
-			continue
-		}
-		allEvents = append(allEvents, events...)
-	}
-
-	if len(allEvents) == 0 {
+	if !hasActiveEvents {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.EventResolutionNoEvents),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionNoEvents),
 		})
 		return
 	}
 
-	// Filter events that user can manage
-	var manageableEvents []*domain.Event
-	for _, event := range allEvents {
-		canManage, err := h.eventPermissionValidator.CanManageEvent(ctx, userID, event.ID, h.config.AdminUserIDs)
-		if err != nil {
-			h.logger.Error("failed to check event management permission", "user_id", userID, "event_id", event.ID, "error", err)
-			continue
-		}
-		if canManage {
-			manageableEvents = append(manageableEvents, event)
-		}
-	}
-
 	if len(manageableEvents) == 0 {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.EventResolutionNoPermission),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionNoPermission),
 		})
 		return
 	}
 
-	// Build inline keyboard with manageable events
-	var buttons [][]models.InlineKeyboardButton
-	for _, event := range manageableEvents {
-		buttons = append(buttons, []models.InlineKeyboardButton{
-			{
-				Text:         fmt.Sprintf("%s (ID: %d)", event.Question, event.ID),
-				CallbackData: fmt.Sprintf("resolve:%d", event.ID),
-			},
-		})
-	}
-
-	kb := &models.InlineKeyboardMarkup{
-		InlineKeyboard: buttons,
-	}
+	// Build inline keyboard with the first page of manageable events
+	text, kb := h.buildResolveEventsPage(ctx, manageableEvents, 0)
 
 	msg, err := b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        h.localizer.MustLocalize(locale.EventResolutionTitle2) + "\n\n" + h.localizer.MustLocalize(locale.EventResolutionSelectPrompt),
+		Text:        text,
 		ReplyMarkup: kb,
 	})
 	if err != nil {
@@ -1566,7 +1979,7 @@ func (h *BotHandler) HandleEditEvent(ctx context.Context, b *bot.Bot, update *mo
 
 	_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: update.Message.Chat.ID,
-		Text:   h.localizer.MustLocalize(locale.EditEventUnavailable),
+		Text:   h.requestLocalizer(ctx).MustLocalize(locale.EditEventUnavailable),
 	})
 }
 
@@ -1589,17 +2002,17 @@ func (h *BotHandler) HandleCreateGroup(ctx context.Context, b *bot.Bot, update *
 		kb := &models.InlineKeyboardMarkup{
 			InlineKeyboard: [][]models.InlineKeyboardButton{
 				{
-					{Text: h.localizer.MustLocalize(locale.SessionConflictContinueButton), CallbackData: "session_conflict:continue"},
+					{Text: h.requestLocalizer(ctx).MustLocalize(locale.SessionConflictContinueButton), CallbackData: mustEncodeCallback(cbSessionConflict, "continue")},
 				},
 				{
-					{Text: h.localizer.MustLocalize(locale.SessionConflictRestartButton), CallbackData: "session_conflict:restart:group_creation"},
+					{Text: h.requestLocalizer(ctx).MustLocalize(locale.SessionConflictRestartButton), CallbackData: mustEncodeCallback(cbSessionConflict, "restart", "group_creation")},
 				},
 			},
 		}
 
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        h.localizer.MustLocalizeWithTemplate(locale.SessionConflictWarning, conflictType),
+			Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SessionConflictWarning, conflictType),
 			ReplyMarkup: kb,
 		})
 		return
@@ -1624,17 +2037,17 @@ func (h *BotHandler) HandleCreateGroup(ctx context.Context, b *bot.Bot, update *
 		h.logger.Error("failed to start group creation FSM session", "user_id", userID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.CreateGroupErrorStart),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.CreateGroupErrorStart),
 		})
 		return
 	}
 
 	// Build prompt message
-	promptText := h.localizer.MustLocalize(locale.GroupCreationTitle) + "\n\n"
+	promptText := h.requestLocalizer(ctx).MustLocalize(locale.GroupCreationTitle) + "\n\n"
 	if isForum && messageThreadID != nil {
-		promptText += h.localizer.MustLocalizeWithTemplate(locale.GroupCreationForumDetectedFull, fmt.Sprintf("%d", *messageThreadID))
+		promptText += h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupCreationForumDetectedFull, fmt.Sprintf("%d", *messageThreadID))
 	}
-	promptText += h.localizer.MustLocalize(locale.GroupCreationPromptName)
+	promptText += h.requestLocalizer(ctx).MustLocalize(locale.GroupCreationPromptName)
 
 	// Prompt for group name
 	msg, err := b.SendMessage(ctx, &bot.SendMessageParams{
@@ -1675,7 +2088,7 @@ func (h *BotHandler) HandleListGroups(ctx context.Context, b *bot.Bot, update *m
 		h.logger.Error("failed to get all groups", "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 		})
 		return
 	}
@@ -1683,20 +2096,24 @@ func (h *BotHandler) HandleListGroups(ctx context.Context, b *bot.Bot, update *m
 	if len(groups) == 0 {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.ListGroupsEmpty),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
 		})
 		return
 	}
 
+	h.sortGroupsByName(ctx, groups)
+
 	// Build groups list message with deep-links and topics
 	var sb strings.Builder
-	sb.WriteString(h.localizer.MustLocalize(locale.ListGroupsTitle) + "\n\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsTitle) + "\n\n")
 
+	skippedGroups := 0
 	for i, group := range groups {
 		// Get member count
 		members, err := h.groupMembershipRepo.GetGroupMembers(ctx, group.ID)
 		if err != nil {
 			h.logger.Error("failed to get group members", "group_id", group.ID, "error", err)
+			skippedGroups++
 			continue
 		}
 
@@ -1712,55 +2129,64 @@ func (h *BotHandler) HandleListGroups(ctx context.Context, b *bot.Bot, update *m
 		deepLink, err := h.deepLinkService.GenerateGroupInviteLink(group.ID)
 		if err != nil {
 			h.logger.Error("failed to generate deep-link", "group_id", group.ID, "error", err)
-			deepLink = h.localizer.MustLocalize(locale.ListGroupsLinkError)
+			deepLink = h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsLinkError)
 		}
 
 		// Add status indicator
 		statusIcon := "✅"
 		statusText := ""
-		if group.Status == domain.GroupStatusDeleted {
+		switch group.Status {
+		case domain.GroupStatusDeleted:
 			statusIcon = "🗑"
-			statusText = h.localizer.MustLocalize(locale.ListGroupsItemDeleted)
+			statusText = h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsItemDeleted)
+		case domain.GroupStatusPaused:
+			statusIcon = "⏸"
+			statusText = h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsItemPaused)
 		}
 
-		sb.WriteString(fmt.Sprintf("%d. %s %s%s\n", i+1, statusIcon, group.Name, statusText))
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.ListGroupsItemMembersFormat, fmt.Sprintf("%d", activeCount)))
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.ListGroupsItemLinkFormat, deepLink))
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.ListGroupsItemID, fmt.Sprintf("%d", group.ID)))
+		sb.WriteString(fmt.Sprintf("%d. %s %s%s\n", i+1, statusIcon, truncateHTML(group.Name, htmlNameMaxLength), statusText))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ListGroupsItemMembersFormat, fmt.Sprintf("%d", activeCount)))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ListGroupsItemLinkFormat, deepLink))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ListGroupsItemID, fmt.Sprintf("%d", group.ID)))
 
 		// If this is a forum, show topics
 		if group.IsForum {
-			sb.WriteString(h.localizer.MustLocalize(locale.ListGroupsItemTypeFormat))
+			sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsItemTypeFormat))
 
 			// Get forum topics for this group
 			topics, err := h.forumTopicRepo.GetForumTopicsByGroup(ctx, group.ID)
 			if err != nil {
 				h.logger.Error("failed to get forum topics", "group_id", group.ID, "error", err)
 			} else if len(topics) > 0 {
-				sb.WriteString(h.localizer.MustLocalize(locale.ListGroupsItemTopicsHeader))
+				sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsItemTopicsHeader))
 				for _, topic := range topics {
-					sb.WriteString(fmt.Sprintf("      • %s (Thread ID: %d, ID: %d)\n", topic.Name, topic.MessageThreadID, topic.ID))
+					sb.WriteString(fmt.Sprintf("      • %s (Thread ID: %d, ID: %d)\n", truncateHTML(topic.Name, htmlNameMaxLength), topic.MessageThreadID, topic.ID))
 				}
 			} else {
-				sb.WriteString(h.localizer.MustLocalize(locale.ListGroupsItemNoTopics))
+				sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsItemNoTopics))
 			}
 		}
 
 		sb.WriteString("\n")
 	}
+	sb.WriteString(h.skippedItemsFooter(ctx, skippedGroups))
 
 	// Add management buttons
 	var buttons [][]models.InlineKeyboardButton
 	buttons = append(buttons, []models.InlineKeyboardButton{
-		{Text: h.localizer.MustLocalize(locale.ListGroupsButtonRenameGroup), CallbackData: "rename_group_select"},
-		{Text: h.localizer.MustLocalize(locale.ListGroupsButtonRenameTopic), CallbackData: "rename_topic_select"},
+		{Text: h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsButtonRenameGroup), CallbackData: cbRenameGroupSelect},
+		{Text: h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsButtonRenameTopic), CallbackData: cbRenameTopicSelect},
+	})
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsButtonSoftDelete), CallbackData: cbSoftDeleteGroupSelect},
+		{Text: h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsButtonRestore), CallbackData: cbRestoreGroupSelect},
 	})
 	buttons = append(buttons, []models.InlineKeyboardButton{
-		{Text: h.localizer.MustLocalize(locale.ListGroupsButtonSoftDelete), CallbackData: "soft_delete_group_select"},
-		{Text: h.localizer.MustLocalize(locale.ListGroupsButtonRestore), CallbackData: "restore_group_select"},
+		{Text: h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsButtonPause), CallbackData: cbPauseGroupSelect},
+		{Text: h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsButtonResume), CallbackData: cbResumeGroupSelect},
 	})
 	buttons = append(buttons, []models.InlineKeyboardButton{
-		{Text: h.localizer.MustLocalize(locale.ListGroupsButtonDeleteTopic), CallbackData: "delete_topic_select"},
+		{Text: h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsButtonDeleteTopic), CallbackData: cbDeleteTopicSelect},
 	})
 
 	kb := &models.InlineKeyboardMarkup{
@@ -1791,7 +2217,7 @@ func (h *BotHandler) HandleGroupMembers(ctx context.Context, b *bot.Bot, update
 		h.logger.Error("failed to get all groups", "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 		})
 		return
 	}
@@ -1799,18 +2225,20 @@ func (h *BotHandler) HandleGroupMembers(ctx context.Context, b *bot.Bot, update
 	if len(groups) == 0 {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.ListGroupsEmpty),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
 		})
 		return
 	}
 
+	h.sortGroupsByName(ctx, groups)
+
 	// Build inline keyboard with groups
 	var buttons [][]models.InlineKeyboardButton
 	for _, group := range groups {
 		buttons = append(buttons, []models.InlineKeyboardButton{
 			{
 				Text:         group.Name,
-				CallbackData: fmt.Sprintf("group_members:%d", group.ID),
+				CallbackData: mustEncodeCallback(cbGroupMembers, group.ID),
 			},
 		})
 	}
@@ -1821,7 +2249,7 @@ func (h *BotHandler) HandleGroupMembers(ctx context.Context, b *bot.Bot, update
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      update.Message.Chat.ID,
-		Text:        h.localizer.MustLocalize(locale.GroupMembersTitle) + "\n\n" + h.localizer.MustLocalize(locale.GroupMembersSelectGroup),
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersTitle) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersSelectGroup),
 		ReplyMarkup: kb,
 	})
 	if err != nil {
@@ -1842,7 +2270,7 @@ func (h *BotHandler) HandleRemoveMember(ctx context.Context, b *bot.Bot, update
 		h.logger.Error("failed to get all groups", "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 		})
 		return
 	}
@@ -1850,7 +2278,7 @@ func (h *BotHandler) HandleRemoveMember(ctx context.Context, b *bot.Bot, update
 	if len(groups) == 0 {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.ListGroupsEmpty),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
 		})
 		return
 	}
@@ -1861,7 +2289,7 @@ func (h *BotHandler) HandleRemoveMember(ctx context.Context, b *bot.Bot, update
 		buttons = append(buttons, []models.InlineKeyboardButton{
 			{
 				Text:         group.Name,
-				CallbackData: fmt.Sprintf("remove_member_group:%d", group.ID),
+				CallbackData: mustEncodeCallback(cbRemoveMemberGroup, group.ID),
 			},
 		})
 	}
@@ -1872,7 +2300,7 @@ func (h *BotHandler) HandleRemoveMember(ctx context.Context, b *bot.Bot, update
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      update.Message.Chat.ID,
-		Text:        h.localizer.MustLocalize(locale.RemoveMemberTitle) + "\n\n" + h.localizer.MustLocalize(locale.RemoveMemberSelectGroup),
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.RemoveMemberTitle) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.RemoveMemberSelectGroup),
 		ReplyMarkup: kb,
 	})
 	if err != nil {
@@ -1881,24 +2309,23 @@ func (h *BotHandler) HandleRemoveMember(ctx context.Context, b *bot.Bot, update
 }
 
 // handleGroupMembersCallback handles the callback for viewing group members
-func (h *BotHandler) handleGroupMembersCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleGroupMembersCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
 	// Parse group ID
-	parts := strings.Split(data, ":")
-	if len(parts) != 2 {
-		h.logger.Error("invalid group_members callback data", "data", data)
+	if err := cb.Expect(cbGroupMembers, 1); err != nil {
+		h.logger.Error("invalid group_members callback data", "data", cb.String(), "error", err)
 		return
 	}
 
-	groupID, err := strconv.ParseInt(parts[1], 10, 64)
+	groupID, err := cb.Int64(0)
 	if err != nil {
 		h.logger.Error("failed to parse group ID", "error", err)
 		return
@@ -1910,7 +2337,7 @@ func (h *BotHandler) handleGroupMembersCallback(ctx context.Context, b *bot.Bot,
 		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 		})
 		return
 	}
@@ -1918,7 +2345,7 @@ func (h *BotHandler) handleGroupMembersCallback(ctx context.Context, b *bot.Bot,
 	if group == nil {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 		})
 		return
 	}
@@ -1929,7 +2356,7 @@ func (h *BotHandler) handleGroupMembersCallback(ctx context.Context, b *bot.Bot,
 		h.logger.Error("failed to get group members", "group_id", groupID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGet),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGet),
 		})
 		return
 	}
@@ -1937,16 +2364,17 @@ func (h *BotHandler) handleGroupMembersCallback(ctx context.Context, b *bot.Bot,
 	if len(members) == 0 {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.GroupEmptyMembers, group.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupEmptyMembers, group.Name),
 		})
 		return
 	}
 
-	// Build members list message
-	var sb strings.Builder
-	sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.GroupMembersTitleWithName, group.Name))
+	// Members whose rating or achievements failed to load are listed with defaults and counted
+	incompleteMembers := 0
+	rows := make([]groupMemberRow, 0, len(members))
+	for _, member := range members {
+		incomplete := false
 
-	for i, member := range members {
 		// Get user rating for this group
 		rating, err := h.ratingRepo.GetRating(ctx, member.UserID, groupID)
 		if err != nil {
@@ -1957,6 +2385,7 @@ func (h *BotHandler) handleGroupMembersCallback(ctx context.Context, b *bot.Bot,
 				GroupID: groupID,
 				Score:   0,
 			}
+			incomplete = true
 		}
 
 		// Get achievements count for this group
@@ -1964,22 +2393,38 @@ func (h *BotHandler) handleGroupMembersCallback(ctx context.Context, b *bot.Bot,
 		if err != nil {
 			h.logger.Error("failed to get user achievements", "user_id", member.UserID, "group_id", groupID, "error", err)
 			achievements = []*domain.Achievement{}
+			incomplete = true
+		}
+		if incomplete {
+			incompleteMembers++
 		}
 
-		// Get display name
-		displayName := h.getUserDisplayName(ctx, member.UserID, groupID)
+		rows = append(rows, groupMemberRow{
+			member:       member,
+			displayName:  h.getUserDisplayName(ctx, member.UserID, groupID),
+			score:        rating.Score,
+			achievements: len(achievements),
+		})
+	}
+	h.sortGroupMemberRows(ctx, group, rows)
+
+	// Build members list message
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupMembersTitleWithName, group.Name))
 
+	for i, row := range rows {
 		// Status indicator
 		statusIcon := "✅"
-		if member.Status == domain.MembershipStatusRemoved {
+		if row.member.Status == domain.MembershipStatusRemoved {
 			statusIcon = "🚫"
 		}
 
-		sb.WriteString(fmt.Sprintf("%d. %s %s\n", i+1, statusIcon, displayName))
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.GroupMembersItemPointsFormat, fmt.Sprintf("%d", rating.Score)))
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.GroupMembersItemAchievementsFormat, fmt.Sprintf("%d", len(achievements))))
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.GroupMembersItemJoinedFormat, member.JoinedAt.Format("02.01.2006")))
+		sb.WriteString(fmt.Sprintf("%d. %s %s\n", i+1, statusIcon, row.displayName))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupMembersItemPointsFormat, fmt.Sprintf("%d", row.score)))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupMembersItemAchievementsFormat, fmt.Sprintf("%d", row.achievements)))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupMembersItemJoinedFormat, row.member.JoinedAt.Format("02.01.2006")))
 	}
+	sb.WriteString(h.skippedItemsFooter(ctx, incompleteMembers))
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: callback.Message.Message.Chat.ID,
@@ -2006,7 +2451,7 @@ func (h *BotHandler) HandleGroups(ctx context.Context, b *bot.Bot, update *model
 		h.logger.Error("failed to get user groups", "user_id", userID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 		})
 		return
 	}
@@ -2015,22 +2460,21 @@ func (h *BotHandler) HandleGroups(ctx context.Context, b *bot.Bot, update *model
 	if len(groups) == 0 {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text: h.localizer.MustLocalize(locale.GroupsNoGroups) + "\n\n" +
-				h.localizer.MustLocalize(locale.GroupsJoinInstructions),
+			Text: h.requestLocalizer(ctx).MustLocalize(locale.GroupsNoGroups) + "\n\n" +
+				h.requestLocalizer(ctx).MustLocalize(locale.GroupsJoinInstructions),
 		})
 		return
 	}
 
-	// Build groups list message
-	var sb strings.Builder
-	sb.WriteString(h.localizer.MustLocalize(locale.GroupsYourGroups) + "\n\n")
-
 	// Get memberships to access join dates (groups are already ordered by join date DESC)
-	for i, group := range groups {
+	skippedGroups := 0
+	rows := make([]userGroupRow, 0, len(groups))
+	for _, group := range groups {
 		// Get membership to access join date
 		membership, err := h.groupMembershipRepo.GetMembership(ctx, group.ID, userID)
 		if err != nil {
 			h.logger.Error("failed to get membership", "group_id", group.ID, "user_id", userID, "error", err)
+			skippedGroups++
 			continue
 		}
 
@@ -2042,6 +2486,7 @@ func (h *BotHandler) HandleGroups(ctx context.Context, b *bot.Bot, update *model
 		members, err := h.groupMembershipRepo.GetGroupMembers(ctx, group.ID)
 		if err != nil {
 			h.logger.Error("failed to get group members", "group_id", group.ID, "error", err)
+			skippedGroups++
 			continue
 		}
 
@@ -2053,10 +2498,29 @@ func (h *BotHandler) HandleGroups(ctx context.Context, b *bot.Bot, update *model
 			}
 		}
 
-		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, group.Name))
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.GroupsItemMembersFormat, fmt.Sprintf("%d", activeCount)))
-		sb.WriteString(h.localizer.MustLocalizeWithTemplate(locale.GroupsItemJoinedFormat, membership.JoinedAt.Format("02.01.2006")))
+		row := userGroupRow{group: group, membership: membership, activeCount: activeCount}
+		if h.config.ListSortOrder == listSortScore {
+			rating, err := h.ratingRepo.GetRating(ctx, userID, group.ID)
+			if err != nil {
+				h.logger.Error("failed to get user rating", "group_id", group.ID, "user_id", userID, "error", err)
+			} else {
+				row.score = rating.Score
+			}
+		}
+		rows = append(rows, row)
+	}
+	h.sortUserGroupRows(ctx, rows)
+
+	// Build groups list message
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.GroupsYourGroups) + "\n\n")
+
+	for i, row := range rows {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, row.group.Name))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupsItemMembersFormat, fmt.Sprintf("%d", row.activeCount)))
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupsItemJoinedFormat, row.membership.JoinedAt.Format("02.01.2006")))
 	}
+	sb.WriteString(h.skippedItemsFooter(ctx, skippedGroups))
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
@@ -2113,16 +2577,7 @@ func (h *BotHandler) HandleMyChatMember(ctx context.Context, b *bot.Bot, update
 			displayName = fmt.Sprintf("@%s", displayName)
 		}
 
-		// Build notification message
-		notificationMsg := h.localizer.MustLocalize(locale.BotAddedTitle) + "\n\n" +
-			h.localizer.MustLocalizeWithTemplate(locale.BotAddedBy, displayName) +
-			h.localizer.MustLocalizeWithTemplate(locale.BotAddedGroupNameFormat, chat.Title) +
-			h.localizer.MustLocalizeWithTemplate(locale.BotAddedChatIDFormat, fmt.Sprintf("%d", chat.ID))
-
-		// Add forum information if this is a forum
 		if chat.IsForum {
-			notificationMsg += "\n" + h.localizer.MustLocalize(locale.BotAddedTypeForum) + "\n"
-
 			// Try to get forum topics using GetForumTopicIconStickers
 			// Note: We can't directly list topics, but we can get chat info
 			chatInfo, err := h.bot.GetChat(ctx, &bot.GetChatParams{
@@ -2137,32 +2592,34 @@ func (h *BotHandler) HandleMyChatMember(ctx context.Context, b *bot.Bot, update
 					"active_usernames", chatInfo.ActiveUsernames,
 				)
 			}
-
-			notificationMsg += "\n" + h.localizer.MustLocalize(locale.BotAddedForumInstructions) + "\n"
-			notificationMsg += h.localizer.MustLocalize(locale.BotAddedForumInstructionsStep1)
-			notificationMsg += h.localizer.MustLocalize(locale.BotAddedForumInstructionsStep2)
-			notificationMsg += h.localizer.MustLocalize(locale.BotAddedForumInstructionsStep3)
-			notificationMsg += h.localizer.MustLocalize(locale.BotAddedForumSetup)
-			notificationMsg += h.localizer.MustLocalize(locale.BotAddedForumEvents)
-		} else {
-			notificationMsg += h.localizer.MustLocalize(locale.BotAddedTypeRegular) + "\n"
 		}
 
-		notificationMsg += "\n" + h.localizer.MustLocalize(locale.BotAddedRegisterCommand)
+		// Register the chat as a draft group, or find the group it already belongs to
+		group, created := h.registerDraftGroup(ctx, chat, addedBy.ID)
 
-		// Create inline keyboard with "Leave Group" button
-		kb := &models.InlineKeyboardMarkup{
-			InlineKeyboard: [][]models.InlineKeyboardButton{
-				{
-					{
-						Text:         h.localizer.MustLocalize(locale.LeaveGroupButton),
-						CallbackData: fmt.Sprintf("leave_group:%d", chat.ID),
-					},
-				},
-			},
-		}
+		// Build the notification in the language of each admin (HTML, so the user and chat names are escaped)
+		h.notifyAdminsWithKeyboard(ctx, func(localizer locale.Localizer) (string, *models.InlineKeyboardMarkup) {
+			notificationMsg := localizer.MustLocalize(locale.BotAddedTitle) + "\n\n" +
+				localizer.MustLocalizeWithTemplate(locale.BotAddedBy, truncateHTML(displayName, htmlNameMaxLength)) +
+				localizer.MustLocalizeWithTemplate(locale.BotAddedGroupNameFormat, truncateHTML(chat.Title, htmlNameMaxLength)) +
+				localizer.MustLocalizeWithTemplate(locale.BotAddedChatIDFormat, fmt.Sprintf("%d", chat.ID))
+
+			// Add forum information if this is a forum
+			if chat.IsForum {
+				notificationMsg += "\n" + localizer.MustLocalize(locale.BotAddedTypeForum) + "\n"
+				notificationMsg += "\n" + localizer.MustLocalize(locale.BotAddedForumInstructions) + "\n"
+				notificationMsg += localizer.MustLocalize(locale.BotAddedForumInstructionsStep1)
+				notificationMsg += localizer.MustLocalize(locale.BotAddedForumInstructionsStep2)
+				notificationMsg += localizer.MustLocalize(locale.BotAddedForumInstructionsStep3)
+				notificationMsg += localizer.MustLocalize(locale.BotAddedForumSetup)
+				notificationMsg += localizer.MustLocalize(locale.BotAddedForumEvents)
+			} else {
+				notificationMsg += localizer.MustLocalize(locale.BotAddedTypeRegular) + "\n"
+			}
 
-		h.notifyAdminsWithKeyboard(ctx, notificationMsg, kb)
+			statusMsg, kb := draftGroupStatus(localizer, chat.ID, group, created)
+			return notificationMsg + "\n" + statusMsg, kb
+		})
 
 		// Check if the user who added the bot is a member of any group
 		groups, err := h.groupRepo.GetUserGroups(ctx, addedBy.ID)
@@ -2173,20 +2630,20 @@ func (h *BotHandler) HandleMyChatMember(ctx context.Context, b *bot.Bot, update
 
 		// If user is a member, send them a notification
 		if len(groups) > 0 {
-			userNotificationMsg := h.localizer.MustLocalizeWithTemplate(locale.BotAddedUserNotification, chat.Title, fmt.Sprintf("%d", chat.ID)) + "\n\n"
+			userNotificationMsg := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.BotAddedUserNotification, truncateHTML(chat.Title, htmlNameMaxLength), fmt.Sprintf("%d", chat.ID)) + "\n\n"
 
 			if chat.IsForum {
-				userNotificationMsg += h.localizer.MustLocalize(locale.BotAddedTypeForum) + "\n\n"
-				userNotificationMsg += h.localizer.MustLocalize(locale.BotAddedUserForumInstructions) + "\n"
-				userNotificationMsg += h.localizer.MustLocalize(locale.BotAddedForumInstructionsStep1)
-				userNotificationMsg += h.localizer.MustLocalize(locale.BotAddedUserForumStep2)
-				userNotificationMsg += h.localizer.MustLocalize(locale.BotAddedForumInstructionsStep3)
-				userNotificationMsg += h.localizer.MustLocalize(locale.BotAddedUserForumEvents)
+				userNotificationMsg += h.requestLocalizer(ctx).MustLocalize(locale.BotAddedTypeForum) + "\n\n"
+				userNotificationMsg += h.requestLocalizer(ctx).MustLocalize(locale.BotAddedUserForumInstructions) + "\n"
+				userNotificationMsg += h.requestLocalizer(ctx).MustLocalize(locale.BotAddedForumInstructionsStep1)
+				userNotificationMsg += h.requestLocalizer(ctx).MustLocalize(locale.BotAddedUserForumStep2)
+				userNotificationMsg += h.requestLocalizer(ctx).MustLocalize(locale.BotAddedForumInstructionsStep3)
+				userNotificationMsg += h.requestLocalizer(ctx).MustLocalize(locale.BotAddedUserForumEvents)
 			} else {
-				userNotificationMsg += h.localizer.MustLocalize(locale.BotAddedTypeRegular) + "\n\n"
+				userNotificationMsg += h.requestLocalizer(ctx).MustLocalize(locale.BotAddedTypeRegular) + "\n\n"
 			}
 
-			userNotificationMsg += h.localizer.MustLocalize(locale.BotAddedUserRegisterCommand)
+			userNotificationMsg += h.requestLocalizer(ctx).MustLocalize(locale.BotAddedUserRegisterCommand)
 
 			_, err = h.bot.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID:    addedBy.ID,
@@ -2201,33 +2658,32 @@ func (h *BotHandler) HandleMyChatMember(ctx context.Context, b *bot.Bot, update
 }
 
 // handleLeaveGroupCallback handles the callback for leaving a telegram group
-func (h *BotHandler) handleLeaveGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleLeaveGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
 	// Parse chat ID
-	parts := strings.Split(data, ":")
-	if len(parts) != 2 {
-		h.logger.Error("invalid leave_group callback data", "data", data)
+	if err := cb.Expect(cbLeaveGroup, 1); err != nil {
+		h.logger.Error("invalid leave_group callback data", "data", cb.String(), "error", err)
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorInvalidDataFormat),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidDataFormat),
 		})
 		return
 	}
 
-	chatID, err := strconv.ParseInt(parts[1], 10, 64)
+	chatID, err := cb.Int64(0)
 	if err != nil {
 		h.logger.Error("failed to parse chat ID", "error", err)
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorInvalidChatID),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidChatID),
 		})
 		return
 	}
@@ -2240,7 +2696,7 @@ func (h *BotHandler) handleLeaveGroupCallback(ctx context.Context, b *bot.Bot, c
 		h.logger.Error("failed to leave chat", "chat_id", chatID, "error", err)
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.LeaveGroupError),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.LeaveGroupError),
 		})
 
 		// Edit message to show error
@@ -2248,7 +2704,7 @@ func (h *BotHandler) handleLeaveGroupCallback(ctx context.Context, b *bot.Bot, c
 			_, _ = b.EditMessageText(ctx, &bot.EditMessageTextParams{
 				ChatID:    callback.Message.Message.Chat.ID,
 				MessageID: callback.Message.Message.ID,
-				Text:      callback.Message.Message.Text + "\n\n" + h.localizer.MustLocalize(locale.LeaveGroupError),
+				Text:      callback.Message.Message.Text + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.LeaveGroupError),
 			})
 		}
 		return
@@ -2259,7 +2715,7 @@ func (h *BotHandler) handleLeaveGroupCallback(ctx context.Context, b *bot.Bot, c
 	// Answer callback query
 	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 		CallbackQueryID: callback.ID,
-		Text:            h.localizer.MustLocalize(locale.LeaveGroupSuccess),
+		Text:            h.requestLocalizer(ctx).MustLocalize(locale.LeaveGroupSuccess),
 	})
 
 	// Edit message to show success
@@ -2267,32 +2723,31 @@ func (h *BotHandler) handleLeaveGroupCallback(ctx context.Context, b *bot.Bot, c
 		_, _ = b.EditMessageText(ctx, &bot.EditMessageTextParams{
 			ChatID:    callback.Message.Message.Chat.ID,
 			MessageID: callback.Message.Message.ID,
-			Text:      callback.Message.Message.Text + "\n\n" + h.localizer.MustLocalize(locale.LeaveGroupSuccess),
+			Text:      callback.Message.Message.Text + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.LeaveGroupSuccess),
 		})
 	}
 }
 
 // handleRemoveMemberCallback handles the callback for removing a member
-func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
 	// Check if this is group selection or user selection
-	if strings.HasPrefix(data, "remove_member_group:") {
+	if cb.Namespace == cbRemoveMemberGroup {
 		// Parse group ID
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid remove_member_group callback data", "data", data)
+		if err := cb.Expect(cbRemoveMemberGroup, 1); err != nil {
+			h.logger.Error("invalid remove_member_group callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		groupID, err := strconv.ParseInt(parts[1], 10, 64)
+		groupID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse group ID", "error", err)
 			return
@@ -2304,7 +2759,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 			})
 			return
 		}
@@ -2312,7 +2767,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 		if group == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 			})
 			return
 		}
@@ -2323,7 +2778,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get group members", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGet),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGet),
 			})
 			return
 		}
@@ -2339,7 +2794,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 		if len(activeMembers) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalizeWithTemplate(locale.GroupEmptyActiveMembers, group.Name),
+				Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupEmptyActiveMembers, group.Name),
 			})
 			return
 		}
@@ -2351,7 +2806,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         displayName,
-					CallbackData: fmt.Sprintf("remove_member_user:%d:%d", groupID, member.UserID),
+					CallbackData: mustEncodeCallback(cbRemoveMemberUser, groupID, member.UserID),
 				},
 			})
 		}
@@ -2362,7 +2817,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalizeWithTemplate(locale.RemoveMemberPromptWithName, group.Name),
+			Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RemoveMemberPromptWithName, group.Name),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -2377,21 +2832,20 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 	}
 
 	// This is user selection
-	if strings.HasPrefix(data, "remove_member_user:") {
+	if cb.Namespace == cbRemoveMemberUser {
 		// Parse group ID and user ID
-		parts := strings.Split(data, ":")
-		if len(parts) != 3 {
-			h.logger.Error("invalid remove_member_user callback data", "data", data)
+		if err := cb.Expect(cbRemoveMemberUser, 2); err != nil {
+			h.logger.Error("invalid remove_member_user callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		groupID, err := strconv.ParseInt(parts[1], 10, 64)
+		groupID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse group ID", "error", err)
 			return
 		}
 
-		memberUserID, err := strconv.ParseInt(parts[2], 10, 64)
+		memberUserID, err := cb.Int64(1)
 		if err != nil {
 			h.logger.Error("failed to parse user ID", "error", err)
 			return
@@ -2403,7 +2857,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 			})
 			return
 		}
@@ -2411,7 +2865,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 		if group == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 			})
 			return
 		}
@@ -2422,7 +2876,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to update membership status", "group_id", groupID, "user_id", memberUserID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.RemoveMemberErrorUpdate),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.RemoveMemberErrorUpdate),
 			})
 			return
 		}
@@ -2436,7 +2890,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 		// Send confirmation
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.RemoveMemberSuccessFormat, displayName, group.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RemoveMemberSuccessFormat, displayName, group.Name),
 		})
 		if err != nil {
 			h.logger.Error("failed to send confirmation", "error", err)
@@ -2451,7 +2905,7 @@ func (h *BotHandler) handleRemoveMemberCallback(ctx context.Context, b *bot.Bot,
 }
 
 // handleResolveEventFromCallback handles the resolve button click from event creation summary
-func (h *BotHandler) handleResolveEventFromCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+func (h *BotHandler) handleResolveEventFromCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, cb *Callback) {
 	userID := callback.From.ID
 	chatID := callback.Message.Message.Chat.ID
 
@@ -2461,13 +2915,15 @@ func (h *BotHandler) handleResolveEventFromCallback(ctx context.Context, b *bot.
 	})
 
 	// Parse event ID from callback data
-	eventIDStr := strings.TrimPrefix(callback.Data, "resolve:")
-	eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
+	eventID, err := cb.Int64(0)
+	if err == nil {
+		err = cb.Expect(cbResolve, 1)
+	}
 	if err != nil {
 		h.logger.Error("failed to parse event ID from callback", "user_id", userID, "data", callback.Data, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.ErrorRequestProcessing),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorRequestProcessing),
 		})
 		return
 	}
@@ -2478,7 +2934,7 @@ func (h *BotHandler) handleResolveEventFromCallback(ctx context.Context, b *bot.
 		h.logger.Error("failed to check event management permission", "user_id", userID, "event_id", eventID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.EventResolutionErrorPermissionCheck),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorPermissionCheck),
 		})
 		return
 	}
@@ -2486,7 +2942,7 @@ func (h *BotHandler) handleResolveEventFromCallback(ctx context.Context, b *bot.
 	if !canManage {
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.EventResolutionErrorUnauthorized),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorUnauthorized),
 		})
 		return
 	}
@@ -2496,7 +2952,7 @@ func (h *BotHandler) handleResolveEventFromCallback(ctx context.Context, b *bot.
 		h.logger.Error("failed to start resolution FSM session", "user_id", userID, "error", err)
 		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   h.localizer.MustLocalize(locale.EventResolutionErrorStart),
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorStart),
 		})
 		return
 	}
@@ -2506,7 +2962,7 @@ func (h *BotHandler) handleResolveEventFromCallback(ctx context.Context, b *bot.
 }
 
 // handleEditEventCallback handles the edit button click from event creation summary
-func (h *BotHandler) handleEditEventCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+func (h *BotHandler) handleEditEventCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, cb *Callback) {
 	userID := callback.From.ID
 	chatID := callback.Message.Message.Chat.ID
 
@@ -2514,49 +2970,27 @@ func (h *BotHandler) handleEditEventCallback(ctx context.Context, b *bot.Bot, ca
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorEditEventNoPermission),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorEditEventNoPermission),
 			ShowAlert:       true,
 		})
 		return
 	}
 
 	// Parse event ID from callback data: edit_event:EVENT_ID
-	parts := strings.Split(callback.Data, ":")
-	if len(parts) < 2 {
-		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorInvalidDataFormat),
-			ShowAlert:       true,
-		})
-		return
-	}
-
-	eventID, err := strconv.ParseInt(parts[1], 10, 64)
-	if err != nil {
+	if err := cb.Expect(cbEditEvent, 1); err != nil {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorInvalidEventID),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidDataFormat),
 			ShowAlert:       true,
 		})
 		return
 	}
 
-	// Check if event can be edited (no votes)
-	canEdit, err := h.eventManager.CanEditEvent(ctx, eventID)
+	eventID, err := cb.Int64(0)
 	if err != nil {
-		h.logger.Error("failed to check if event can be edited", "event_id", eventID, "error", err)
-		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorRequestProcessing),
-			ShowAlert:       true,
-		})
-		return
-	}
-
-	if !canEdit {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorEditEventHasVotes),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidEventID),
 			ShowAlert:       true,
 		})
 		return
@@ -2578,17 +3012,10 @@ func (h *BotHandler) handleEditEventCallback(ctx context.Context, b *bot.Bot, ca
 	// Start edit FSM
 	if err := h.eventEditFSM.Start(ctx, userID, chatID, eventID); err != nil {
 		h.logger.Error("failed to start edit FSM", "user_id", userID, "event_id", eventID, "error", err)
-		if err == domain.ErrEventHasVotes {
-			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.ErrorEditEventHasVotes),
-			})
-		} else {
-			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   h.localizer.MustLocalize(locale.ErrorEditEventStart),
-			})
-		}
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorEditEventStart),
+		})
 		return
 	}
 
@@ -2596,25 +3023,25 @@ func (h *BotHandler) handleEditEventCallback(ctx context.Context, b *bot.Bot, ca
 }
 
 // handleDeleteGroupCallback handles the callback for deleting a group
-func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
 	// Check if this is group selection or confirmation
-	if data == "delete_group_select" {
+	if cb.Namespace == cbDeleteGroupSelect {
 		// Get all groups
 		groups, err := h.groupRepo.GetAllGroups(ctx)
 		if err != nil {
 			h.logger.Error("failed to get all groups", "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 			})
 			return
 		}
@@ -2622,7 +3049,7 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 		if len(groups) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.DeleteGroupEmpty),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeleteGroupEmpty),
 			})
 			return
 		}
@@ -2633,7 +3060,7 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         group.Name,
-					CallbackData: fmt.Sprintf("delete_group_confirm:%d", group.ID),
+					CallbackData: mustEncodeCallback(cbDeleteGroupConfirm, group.ID),
 				},
 			})
 		}
@@ -2644,7 +3071,7 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalize(locale.DeleteGroupTitle) + "\n\n" + h.localizer.MustLocalize(locale.DeleteGroupSelectPrompt),
+			Text:        h.requestLocalizer(ctx).MustLocalize(locale.DeleteGroupTitle) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.DeleteGroupSelectPrompt),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -2659,15 +3086,14 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 	}
 
 	// This is confirmation
-	if strings.HasPrefix(data, "delete_group_confirm:") {
+	if cb.Namespace == cbDeleteGroupConfirm {
 		// Parse group ID
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid delete_group_confirm callback data", "data", data)
+		if err := cb.Expect(cbDeleteGroupConfirm, 1); err != nil {
+			h.logger.Error("invalid delete_group_confirm callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		groupID, err := strconv.ParseInt(parts[1], 10, 64)
+		groupID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse group ID", "error", err)
 			return
@@ -2679,7 +3105,7 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 			})
 			return
 		}
@@ -2687,7 +3113,7 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 		if group == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 			})
 			return
 		}
@@ -2698,7 +3124,7 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to delete group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.DeleteGroupError),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeleteGroupError),
 			})
 			return
 		}
@@ -2709,7 +3135,7 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 		// Send confirmation
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.GroupDeletedSuccess, group.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupDeletedSuccess, group.Name),
 		})
 		if err != nil {
 			h.logger.Error("failed to send confirmation", "error", err)
@@ -2724,25 +3150,25 @@ func (h *BotHandler) handleDeleteGroupCallback(ctx context.Context, b *bot.Bot,
 }
 
 // handleDeleteTopicCallback handles the callback for deleting a forum topic
-func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
 	// Check if this is group selection, topic selection, or confirmation
-	if data == "delete_topic_select" {
+	if cb.Namespace == cbDeleteTopicSelect {
 		// Get all forum groups
 		groups, err := h.groupRepo.GetAllGroups(ctx)
 		if err != nil {
 			h.logger.Error("failed to get all groups", "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 			})
 			return
 		}
@@ -2758,7 +3184,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 		if len(forumGroups) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.RenameTopicEmpty),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.RenameTopicEmpty),
 			})
 			return
 		}
@@ -2769,7 +3195,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         group.Name,
-					CallbackData: fmt.Sprintf("delete_topic_group:%d", group.ID),
+					CallbackData: mustEncodeCallback(cbDeleteTopicGroup, group.ID),
 				},
 			})
 		}
@@ -2780,7 +3206,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalize(locale.DeleteTopicTitle) + "\n\n" + h.localizer.MustLocalize(locale.DeleteTopicSelectForum),
+			Text:        h.requestLocalizer(ctx).MustLocalize(locale.DeleteTopicTitle) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.DeleteTopicSelectForum),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -2795,15 +3221,14 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 	}
 
 	// This is group selection
-	if strings.HasPrefix(data, "delete_topic_group:") {
+	if cb.Namespace == cbDeleteTopicGroup {
 		// Parse group ID
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid delete_topic_group callback data", "data", data)
+		if err := cb.Expect(cbDeleteTopicGroup, 1); err != nil {
+			h.logger.Error("invalid delete_topic_group callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		groupID, err := strconv.ParseInt(parts[1], 10, 64)
+		groupID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse group ID", "error", err)
 			return
@@ -2815,7 +3240,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 			})
 			return
 		}
@@ -2823,7 +3248,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 		if group == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 			})
 			return
 		}
@@ -2834,7 +3259,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get forum topics", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ErrorRequestProcessing),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorRequestProcessing),
 			})
 			return
 		}
@@ -2842,7 +3267,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 		if len(topics) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalizeWithTemplate(locale.RenameTopicNoTopics, group.Name),
+				Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RenameTopicNoTopics, group.Name),
 			})
 			return
 		}
@@ -2853,7 +3278,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         fmt.Sprintf("%s (Thread ID: %d)", topic.Name, topic.MessageThreadID),
-					CallbackData: fmt.Sprintf("delete_topic_confirm:%d", topic.ID),
+					CallbackData: mustEncodeCallback(cbDeleteTopicConfirm, topic.ID),
 				},
 			})
 		}
@@ -2864,7 +3289,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalizeWithTemplate(locale.DeleteTopicPromptWithName, group.Name),
+			Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DeleteTopicPromptWithName, group.Name),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -2879,15 +3304,14 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 	}
 
 	// This is confirmation
-	if strings.HasPrefix(data, "delete_topic_confirm:") {
+	if cb.Namespace == cbDeleteTopicConfirm {
 		// Parse topic ID
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid delete_topic_confirm callback data", "data", data)
+		if err := cb.Expect(cbDeleteTopicConfirm, 1); err != nil {
+			h.logger.Error("invalid delete_topic_confirm callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		topicID, err := strconv.ParseInt(parts[1], 10, 64)
+		topicID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse topic ID", "error", err)
 			return
@@ -2899,7 +3323,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get topic", "topic_id", topicID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ErrorRequestProcessing),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorRequestProcessing),
 			})
 			return
 		}
@@ -2907,7 +3331,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 		if topic == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ErrorTopicNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorTopicNotFound),
 			})
 			return
 		}
@@ -2918,7 +3342,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to delete topic", "topic_id", topicID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.DeleteTopicError),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.DeleteTopicError),
 			})
 			return
 		}
@@ -2929,7 +3353,7 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 		// Send confirmation
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.TopicDeletedSuccess, topic.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.TopicDeletedSuccess, topic.Name),
 		})
 		if err != nil {
 			h.logger.Error("failed to send confirmation", "error", err)
@@ -2944,24 +3368,24 @@ func (h *BotHandler) handleDeleteTopicCallback(ctx context.Context, b *bot.Bot,
 }
 
 // handleSoftDeleteGroupCallback handles soft delete (marking as deleted)
-func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
-	if data == "soft_delete_group_select" {
+	if cb.Namespace == cbSoftDeleteGroupSelect {
 		// Get all active groups
 		groups, err := h.groupRepo.GetAllGroups(ctx)
 		if err != nil {
 			h.logger.Error("failed to get all groups", "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 			})
 			return
 		}
@@ -2977,7 +3401,7 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 		if len(activeGroups) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.SoftDeleteGroupEmpty),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.SoftDeleteGroupEmpty),
 			})
 			return
 		}
@@ -2988,7 +3412,7 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         group.Name,
-					CallbackData: fmt.Sprintf("soft_delete_group_confirm:%d", group.ID),
+					CallbackData: mustEncodeCallback(cbSoftDeleteGroupConfirm, group.ID),
 				},
 			})
 		}
@@ -2999,7 +3423,7 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalize(locale.SoftDeleteGroupTitle) + "\n\n" + h.localizer.MustLocalize(locale.SoftDeleteGroupSelectPrompt),
+			Text:        h.requestLocalizer(ctx).MustLocalize(locale.SoftDeleteGroupTitle) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.SoftDeleteGroupSelectPrompt),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -3012,14 +3436,13 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 		return
 	}
 
-	if strings.HasPrefix(data, "soft_delete_group_confirm:") {
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid soft_delete_group_confirm callback data", "data", data)
+	if cb.Namespace == cbSoftDeleteGroupConfirm {
+		if err := cb.Expect(cbSoftDeleteGroupConfirm, 1); err != nil {
+			h.logger.Error("invalid soft_delete_group_confirm callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		groupID, err := strconv.ParseInt(parts[1], 10, 64)
+		groupID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse group ID", "error", err)
 			return
@@ -3030,7 +3453,7 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 			})
 			return
 		}
@@ -3038,7 +3461,7 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 		if group == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 			})
 			return
 		}
@@ -3049,7 +3472,7 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 			h.logger.Error("failed to update group status", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.SoftDeleteGroupError),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.SoftDeleteGroupError),
 			})
 			return
 		}
@@ -3058,7 +3481,7 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.GroupMarkedDeleted, group.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupMarkedDeleted, group.Name),
 		})
 		if err != nil {
 			h.logger.Error("failed to send confirmation", "error", err)
@@ -3072,24 +3495,24 @@ func (h *BotHandler) handleSoftDeleteGroupCallback(ctx context.Context, b *bot.B
 }
 
 // handleRestoreGroupCallback handles restoring deleted groups
-func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
-	if data == "restore_group_select" {
+	if cb.Namespace == cbRestoreGroupSelect {
 		// Get all groups
 		groups, err := h.groupRepo.GetAllGroups(ctx)
 		if err != nil {
 			h.logger.Error("failed to get all groups", "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 			})
 			return
 		}
@@ -3105,7 +3528,7 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 		if len(deletedGroups) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.RestoreGroupEmpty),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.RestoreGroupEmpty),
 			})
 			return
 		}
@@ -3116,7 +3539,7 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         group.Name,
-					CallbackData: fmt.Sprintf("restore_group_confirm:%d", group.ID),
+					CallbackData: mustEncodeCallback(cbRestoreGroupConfirm, group.ID),
 				},
 			})
 		}
@@ -3127,7 +3550,7 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalize(locale.RestoreGroupTitle) + "\n\n" + h.localizer.MustLocalize(locale.RestoreGroupSelectPrompt),
+			Text:        h.requestLocalizer(ctx).MustLocalize(locale.RestoreGroupTitle) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.RestoreGroupSelectPrompt),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -3140,14 +3563,13 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 		return
 	}
 
-	if strings.HasPrefix(data, "restore_group_confirm:") {
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid restore_group_confirm callback data", "data", data)
+	if cb.Namespace == cbRestoreGroupConfirm {
+		if err := cb.Expect(cbRestoreGroupConfirm, 1); err != nil {
+			h.logger.Error("invalid restore_group_confirm callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		groupID, err := strconv.ParseInt(parts[1], 10, 64)
+		groupID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse group ID", "error", err)
 			return
@@ -3158,7 +3580,7 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 			})
 			return
 		}
@@ -3166,7 +3588,7 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 		if group == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 			})
 			return
 		}
@@ -3177,7 +3599,7 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to update group status", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.SoftDeleteGroupError),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.SoftDeleteGroupError),
 			})
 			return
 		}
@@ -3186,7 +3608,7 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.GroupRestored, group.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupRestored, group.Name),
 		})
 		if err != nil {
 			h.logger.Error("failed to send confirmation", "error", err)
@@ -3200,23 +3622,23 @@ func (h *BotHandler) handleRestoreGroupCallback(ctx context.Context, b *bot.Bot,
 }
 
 // handleRenameGroupCallback handles renaming groups
-func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
-	if data == "rename_group_select" {
+	if cb.Namespace == cbRenameGroupSelect {
 		groups, err := h.groupRepo.GetAllGroups(ctx)
 		if err != nil {
 			h.logger.Error("failed to get all groups", "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 			})
 			return
 		}
@@ -3224,7 +3646,7 @@ func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot,
 		if len(groups) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.RenameGroupEmpty),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.RenameGroupEmpty),
 			})
 			return
 		}
@@ -3234,7 +3656,7 @@ func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         group.Name,
-					CallbackData: fmt.Sprintf("rename_group_input:%d", group.ID),
+					CallbackData: mustEncodeCallback(cbRenameGroupInput, group.ID),
 				},
 			})
 		}
@@ -3245,7 +3667,7 @@ func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalize(locale.RenameGroupTitle) + "\n\n" + h.localizer.MustLocalize(locale.RenameGroupSelectPrompt),
+			Text:        h.requestLocalizer(ctx).MustLocalize(locale.RenameGroupTitle) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.RenameGroupSelectPrompt),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -3258,14 +3680,13 @@ func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot,
 		return
 	}
 
-	if strings.HasPrefix(data, "rename_group_input:") {
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid rename_group_input callback data", "data", data)
+	if cb.Namespace == cbRenameGroupInput {
+		if err := cb.Expect(cbRenameGroupInput, 1); err != nil {
+			h.logger.Error("invalid rename_group_input callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		groupID, err := strconv.ParseInt(parts[1], 10, 64)
+		groupID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse group ID", "error", err)
 			return
@@ -3276,7 +3697,7 @@ func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 			})
 			return
 		}
@@ -3284,7 +3705,7 @@ func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot,
 		if group == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 			})
 			return
 		}
@@ -3295,14 +3716,14 @@ func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to start rename FSM", "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.RenameGroupErrorStart),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.RenameGroupErrorStart),
 			})
 			return
 		}
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.RenameGroupPromptWithName, group.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RenameGroupPromptWithName, group.Name),
 		})
 		if err != nil {
 			h.logger.Error("failed to send rename prompt", "error", err)
@@ -3316,23 +3737,23 @@ func (h *BotHandler) handleRenameGroupCallback(ctx context.Context, b *bot.Bot,
 }
 
 // handleRenameTopicCallback handles renaming forum topics
-func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, data string) {
+func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
 	// Check admin authorization
 	if !h.isAdmin(userID) {
 		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: callback.ID,
-			Text:            h.localizer.MustLocalize(locale.ErrorUnauthorized),
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
 		})
 		return
 	}
 
-	if data == "rename_topic_select" {
+	if cb.Namespace == cbRenameTopicSelect {
 		groups, err := h.groupRepo.GetAllGroups(ctx)
 		if err != nil {
 			h.logger.Error("failed to get all groups", "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ListGroupsErrorGet),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
 			})
 			return
 		}
@@ -3347,7 +3768,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 		if len(forumGroups) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.RenameTopicEmpty),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.RenameTopicEmpty),
 			})
 			return
 		}
@@ -3357,7 +3778,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         group.Name,
-					CallbackData: fmt.Sprintf("rename_topic_group:%d", group.ID),
+					CallbackData: mustEncodeCallback(cbRenameTopicGroup, group.ID),
 				},
 			})
 		}
@@ -3368,7 +3789,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalize(locale.RenameTopicTitle) + "\n\n" + h.localizer.MustLocalize(locale.RenameTopicSelectForum),
+			Text:        h.requestLocalizer(ctx).MustLocalize(locale.RenameTopicTitle) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.RenameTopicSelectForum),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -3381,14 +3802,13 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 		return
 	}
 
-	if strings.HasPrefix(data, "rename_topic_group:") {
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid rename_topic_group callback data", "data", data)
+	if cb.Namespace == cbRenameTopicGroup {
+		if err := cb.Expect(cbRenameTopicGroup, 1); err != nil {
+			h.logger.Error("invalid rename_topic_group callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		groupID, err := strconv.ParseInt(parts[1], 10, 64)
+		groupID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse group ID", "error", err)
 			return
@@ -3399,7 +3819,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupMembersErrorGroup),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup),
 			})
 			return
 		}
@@ -3407,7 +3827,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 		if group == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.GroupErrorNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
 			})
 			return
 		}
@@ -3418,7 +3838,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get forum topics", "group_id", groupID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ErrorRequestProcessing),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorRequestProcessing),
 			})
 			return
 		}
@@ -3426,7 +3846,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 		if len(topics) == 0 {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalizeWithTemplate(locale.RenameTopicNoTopics, group.Name),
+				Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RenameTopicNoTopics, group.Name),
 			})
 			return
 		}
@@ -3437,7 +3857,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 			buttons = append(buttons, []models.InlineKeyboardButton{
 				{
 					Text:         fmt.Sprintf("%s (Thread ID: %d)", topic.Name, topic.MessageThreadID),
-					CallbackData: fmt.Sprintf("rename_topic_input:%d", topic.ID),
+					CallbackData: mustEncodeCallback(cbRenameTopicInput, topic.ID),
 				},
 			})
 		}
@@ -3448,7 +3868,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:      callback.Message.Message.Chat.ID,
-			Text:        h.localizer.MustLocalizeWithTemplate(locale.RenameTopicPromptWithName, group.Name),
+			Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RenameTopicPromptWithName, group.Name),
 			ReplyMarkup: kb,
 		})
 		if err != nil {
@@ -3461,14 +3881,13 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 		return
 	}
 
-	if strings.HasPrefix(data, "rename_topic_input:") {
-		parts := strings.Split(data, ":")
-		if len(parts) != 2 {
-			h.logger.Error("invalid rename_topic_input callback data", "data", data)
+	if cb.Namespace == cbRenameTopicInput {
+		if err := cb.Expect(cbRenameTopicInput, 1); err != nil {
+			h.logger.Error("invalid rename_topic_input callback data", "data", cb.String(), "error", err)
 			return
 		}
 
-		topicID, err := strconv.ParseInt(parts[1], 10, 64)
+		topicID, err := cb.Int64(0)
 		if err != nil {
 			h.logger.Error("failed to parse topic ID", "error", err)
 			return
@@ -3479,7 +3898,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to get topic", "topic_id", topicID, "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ErrorRequestProcessing),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorRequestProcessing),
 			})
 			return
 		}
@@ -3487,7 +3906,7 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 		if topic == nil {
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.ErrorTopicNotFound),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.ErrorTopicNotFound),
 			})
 			return
 		}
@@ -3498,14 +3917,14 @@ func (h *BotHandler) handleRenameTopicCallback(ctx context.Context, b *bot.Bot,
 			h.logger.Error("failed to start rename FSM", "error", err)
 			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: callback.Message.Message.Chat.ID,
-				Text:   h.localizer.MustLocalize(locale.RenameGroupErrorStart),
+				Text:   h.requestLocalizer(ctx).MustLocalize(locale.RenameGroupErrorStart),
 			})
 			return
 		}
 
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: callback.Message.Message.Chat.ID,
-			Text:   h.localizer.MustLocalizeWithTemplate(locale.RenameTopicPromptWithName, topic.Name),
+			Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RenameTopicPromptWithName, topic.Name),
 		})
 		if err != nil {
 			h.logger.Error("failed to send rename prompt", "error", err)
@@ -53,7 +53,7 @@ func TestUsernameInAchievementNotification(t *testing.T) {
 			eventRepo := storage.NewEventRepository(queue)
 			logger := &mockLogger{}
 
-			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 			cfg := &config.Config{}
 
@@ -167,7 +167,7 @@ func TestAchievementMessageFormat(t *testing.T) {
 			eventRepo := storage.NewEventRepository(queue)
 			logger := &mockLogger{}
 
-			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 			cfg := &config.Config{}
 
@@ -256,7 +256,7 @@ func TestSendAchievementNotification_WithUsername(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 
@@ -318,7 +318,7 @@ func TestSendAchievementNotification_WithoutUsername(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 
@@ -378,7 +378,7 @@ func TestSendAchievementNotification_EmojiAndName(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 
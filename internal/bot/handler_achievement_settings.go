@@ -0,0 +1,268 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// achievementLabel returns the localized name of an achievement
+func (h *BotHandler) achievementLabel(ctx context.Context, code domain.AchievementCode) string {
+	switch code {
+	case domain.AchievementSharpshooter:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementSharpshooterName)
+	case domain.AchievementProphet:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementProphetName)
+	case domain.AchievementRiskTaker:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementRiskTakerName)
+	case domain.AchievementWeeklyAnalyst:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementWeeklyAnalystName)
+	case domain.AchievementVeteran:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementVeteranName)
+	case domain.AchievementGlobeTrotter:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementGlobeTrotterName)
+	case domain.AchievementEventOrganizer:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementEventOrganizerName)
+	case domain.AchievementActiveOrganizer:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementActiveOrganizerName)
+	case domain.AchievementMasterOrganizer:
+		return h.requestLocalizer(ctx).MustLocalize(locale.AchievementMasterOrganizerName)
+	default:
+		return string(code)
+	}
+}
+
+// HandleAchievementSettings handles the /achievement_settings command (achievements enabled per group)
+func (h *BotHandler) HandleAchievementSettings(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	kb, err := h.buildAchievementSettingsGroupsKeyboard(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	if kb == nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.AchievementSettingsTitle),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to send achievement settings", "error", err)
+	}
+}
+
+// buildAchievementSettingsGroupsKeyboard builds a button with the number of enabled achievements for each active group.
+// Returns nil keyboard if there are no active groups.
+func (h *BotHandler) buildAchievementSettingsGroupsKeyboard(ctx context.Context) (*models.InlineKeyboardMarkup, error) {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+
+		enabled := 0
+		for _, code := range domain.AchievementCodes {
+			if group.AchievementEnabled(code) {
+				enabled++
+			}
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("🏆 %s (%d/%d)", group.Name, enabled, len(domain.AchievementCodes)),
+				CallbackData: mustEncodeCallback(cbAchievementsGroup, group.ID),
+			},
+		})
+	}
+
+	if len(buttons) == 0 {
+		return nil, nil
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, nil
+}
+
+// buildAchievementSettingsKeyboard builds a toggle button for each achievement of a group
+func (h *BotHandler) buildAchievementSettingsKeyboard(ctx context.Context, group *domain.Group) *models.InlineKeyboardMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	for _, code := range domain.AchievementCodes {
+		state := "❌ "
+		if group.AchievementEnabled(code) {
+			state = "✅ "
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: state + h.achievementLabel(ctx, code), CallbackData: mustEncodeCallback(cbAchievementsToggle, group.ID, code)},
+		})
+	}
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		{Text: h.requestLocalizer(ctx).MustLocalize(locale.AchievementSettingsBack), CallbackData: mustEncodeCallback(cbAchievementsBack)},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// handleAchievementSettingsCallback shows the achievements of the selected group, toggles one of them
+// or returns to the group list
+func (h *BotHandler) handleAchievementSettingsCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	if cb.Namespace == cbAchievementsBack {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		h.showAchievementSettingsGroups(ctx, b, callback)
+		return
+	}
+
+	fieldCount := 1
+	if cb.Namespace == cbAchievementsToggle {
+		fieldCount = 2
+	}
+	if err := cb.Expect(cb.Namespace, fieldCount); err != nil {
+		h.logger.Error("invalid achievement settings callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status == domain.GroupStatusDeleted {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	if cb.Namespace == cbAchievementsGroup {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		h.editAchievementSettingsMessage(ctx, b, callback,
+			h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.AchievementSettingsGroup, group.Name),
+			h.buildAchievementSettingsKeyboard(ctx, group))
+		return
+	}
+
+	value, _ := cb.Field(1)
+	code := domain.AchievementCode(value)
+	if !slices.Contains(domain.AchievementCodes, code) {
+		h.logger.Error("unknown achievement code", "group_id", groupID, "code", value)
+		return
+	}
+
+	// Keep the disabled set in display order
+	enable := !group.AchievementEnabled(code)
+	var disabled []domain.AchievementCode
+	for _, c := range domain.AchievementCodes {
+		if c == code {
+			if !enable {
+				disabled = append(disabled, c)
+			}
+			continue
+		}
+		if !group.AchievementEnabled(c) {
+			disabled = append(disabled, c)
+		}
+	}
+
+	if err := h.groupRepo.UpdateGroupDisabledAchievements(ctx, groupID, disabled); err != nil {
+		h.logger.Error("failed to update disabled achievements", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.AchievementSettingsErrorUpdate),
+		})
+		return
+	}
+	group.DisabledAchievements = disabled
+
+	answerKey := locale.AchievementSettingsDisabled
+	if enable {
+		answerKey = locale.AchievementSettingsEnabled
+	}
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(answerKey, h.achievementLabel(ctx, code), group.Name),
+	})
+
+	// Update keyboard with new toggle states
+	if callback.Message.Message != nil {
+		_, _ = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+			ChatID:      callback.Message.Message.Chat.ID,
+			MessageID:   callback.Message.Message.ID,
+			ReplyMarkup: h.buildAchievementSettingsKeyboard(ctx, group),
+		})
+	}
+
+	h.logAdminAction(userID, "toggle_achievement", groupID, fmt.Sprintf("Set achievement %s enabled to %t for group %s", code, enable, group.Name))
+}
+
+// showAchievementSettingsGroups replaces the settings message with the group list
+func (h *BotHandler) showAchievementSettingsGroups(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	kb, err := h.buildAchievementSettingsGroupsKeyboard(ctx)
+	if err != nil {
+		h.logger.Error("failed to rebuild achievement settings keyboard", "error", err)
+		return
+	}
+	if kb == nil {
+		return
+	}
+
+	h.editAchievementSettingsMessage(ctx, b, callback, h.requestLocalizer(ctx).MustLocalize(locale.AchievementSettingsTitle), kb)
+}
+
+// editAchievementSettingsMessage replaces the settings message with new text and buttons
+func (h *BotHandler) editAchievementSettingsMessage(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, text string, kb *models.InlineKeyboardMarkup) {
+	if callback.Message.Message == nil {
+		return
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to edit achievement settings message", "error", err)
+	}
+}
@@ -0,0 +1,274 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// archivePageSize is the number of archived events shown per page
+const archivePageSize = 5
+
+// HandleArchive handles the /archive command (browse archived events)
+func (h *BotHandler) HandleArchive(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	if len(groups) == 0 {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	// Single group - show its archive right away
+	if len(groups) == 1 {
+		text, kb := h.buildArchivePage(ctx, groups[0], 0)
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        text,
+			ReplyMarkup: kb,
+		})
+		if err != nil {
+			h.logger.Error("failed to send archive page", "error", err)
+		}
+		return
+	}
+
+	// Multiple groups - ask which archive to browse
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         group.Name,
+				CallbackData: mustEncodeCallback(cbArchivePage, group.ID, 0),
+			},
+		})
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.ArchiveSelectGroup),
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: buttons},
+	})
+	if err != nil {
+		h.logger.Error("failed to send archive group selection", "error", err)
+	}
+}
+
+// handleArchiveCallback handles archive pagination and unarchive callbacks
+func (h *BotHandler) handleArchiveCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	if callback.Message.Message == nil {
+		return
+	}
+	chatID := callback.Message.Message.Chat.ID
+	messageID := callback.Message.Message.ID
+
+	if cb.Namespace == cbArchivePage {
+		if err := cb.Expect(cbArchivePage, 2); err != nil {
+			h.logger.Error("invalid archive_page callback data", "data", cb.String(), "error", err)
+			return
+		}
+
+		groupID, err := cb.Int64(0)
+		if err != nil {
+			h.logger.Error("failed to parse group ID", "data", cb.String(), "error", err)
+			return
+		}
+
+		offset, err := cb.Int(1)
+		if err != nil || offset < 0 {
+			h.logger.Error("failed to parse archive offset", "data", cb.String(), "error", err)
+			return
+		}
+
+		group, err := h.groupRepo.GetGroup(ctx, groupID)
+		if err != nil || group == nil {
+			h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+			_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			})
+			return
+		}
+
+		text, kb := h.buildArchivePage(ctx, group, offset)
+		_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      chatID,
+			MessageID:   messageID,
+			Text:        text,
+			ReplyMarkup: kb,
+		})
+		if err != nil {
+			h.logger.Error("failed to edit archive page", "group_id", groupID, "error", err)
+		}
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		return
+	}
+
+	if cb.Namespace == cbUnarchiveEvent {
+		if err := cb.Expect(cbUnarchiveEvent, 1); err != nil {
+			h.logger.Error("invalid unarchive_event callback data", "data", cb.String(), "error", err)
+			return
+		}
+
+		eventID, err := cb.Int64(0)
+		if err != nil {
+			h.logger.Error("failed to parse event ID", "data", cb.String(), "error", err)
+			return
+		}
+
+		if err := h.eventManager.UnarchiveEvent(ctx, eventID); err != nil {
+			h.logger.Error("failed to unarchive event", "event_id", eventID, "error", err)
+			_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            h.requestLocalizer(ctx).MustLocalize(locale.ArchiveErrorRestore),
+			})
+			return
+		}
+
+		h.logAdminAction(userID, "unarchive_event", eventID, "Restored event from archive")
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveEventRestored, fmt.Sprintf("%d", eventID)),
+		})
+
+		// Refresh the first page of the event's group archive
+		event, err := h.eventManager.GetEvent(ctx, eventID)
+		if err != nil {
+			return
+		}
+		group, err := h.groupRepo.GetGroup(ctx, event.GroupID)
+		if err != nil || group == nil {
+			return
+		}
+		text, kb := h.buildArchivePage(ctx, group, 0)
+		_, _ = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      chatID,
+			MessageID:   messageID,
+			Text:        text,
+			ReplyMarkup: kb,
+		})
+		return
+	}
+}
+
+// buildArchivePage builds the archive page text and keyboard for a group starting at offset
+func (h *BotHandler) buildArchivePage(ctx context.Context, group *domain.Group, offset int) (string, *models.InlineKeyboardMarkup) {
+	// Fetch one extra event to know whether there is a next page
+	events, err := h.eventManager.GetArchivedEvents(ctx, group.ID, archivePageSize+1, offset)
+	if err != nil {
+		return h.requestLocalizer(ctx).MustLocalize(locale.ArchiveErrorLoad), nil
+	}
+
+	hasNext := len(events) > archivePageSize
+	if hasNext {
+		events = events[:archivePageSize]
+	}
+
+	if len(events) == 0 && offset == 0 {
+		return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveEmpty, group.Name), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveTitle, group.Name))
+	sb.WriteString("\n\n")
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, event := range events {
+		answer := "-"
+		if event.CorrectOption != nil && *event.CorrectOption >= 0 && *event.CorrectOption < len(event.Options) {
+			answer = event.Options[*event.CorrectOption]
+		}
+		deadline := event.Deadline.In(h.config.Timezone).Format("02.01.2006 15:04")
+
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveItem, fmt.Sprintf("%d", event.ID), event.Question, answer, deadline))
+		if difficulty, ok := h.eventDifficulty(ctx, event.ID); ok {
+			sb.WriteString("\n")
+			sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveItemDifficulty, difficulty))
+		}
+		sb.WriteString("\n\n")
+
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveButtonRestore, fmt.Sprintf("%d", event.ID)),
+				CallbackData: mustEncodeCallback(cbUnarchiveEvent, event.ID),
+			},
+		})
+	}
+
+	var navRow []models.InlineKeyboardButton
+	if offset > 0 {
+		prevOffset := offset - archivePageSize
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		navRow = append(navRow, models.InlineKeyboardButton{
+			Text:         h.requestLocalizer(ctx).MustLocalize(locale.ArchiveButtonPrev),
+			CallbackData: mustEncodeCallback(cbArchivePage, group.ID, prevOffset),
+		})
+	}
+	if hasNext {
+		navRow = append(navRow, models.InlineKeyboardButton{
+			Text:         h.requestLocalizer(ctx).MustLocalize(locale.ArchiveButtonNext),
+			CallbackData: mustEncodeCallback(cbArchivePage, group.ID, offset+archivePageSize),
+		})
+	}
+	if len(navRow) > 0 {
+		buttons = append(buttons, navRow)
+	}
+
+	return sb.String(), &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// eventDifficulty formats the difficulty of a resolved event as a percentage.
+// Events without votes have no difficulty.
+func (h *BotHandler) eventDifficulty(ctx context.Context, eventID int64) (string, bool) {
+	if h.statsService == nil {
+		return "", false
+	}
+
+	difficulty, ok, err := h.statsService.EventDifficulty(ctx, eventID)
+	if err != nil {
+		h.logger.Warn("failed to calculate event difficulty", "event_id", eventID, "error", err)
+		return "", false
+	}
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%.0f", difficulty*100), true
+}
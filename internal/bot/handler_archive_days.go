@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// archiveDaysCommand sets after how many days resolved events of a group are archived
+const archiveDaysCommand = "/archive_days"
+
+// HandleArchiveDays handles the /archive_days command (/archive_days <group_id> <days|off|default>).
+// Without arguments it shows the usage together with the archival setting of every group.
+func (h *BotHandler) HandleArchiveDays(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send archive days reply", "error", err)
+		}
+	}
+
+	groupID, archiveDays, ok := parseArchiveDaysArgs(update.Message.Text)
+	if !ok {
+		reply(h.archiveDaysUsage(ctx))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+	}
+	if group == nil || group.Status == domain.GroupStatusDeleted {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound))
+		return
+	}
+
+	if err := h.groupRepo.UpdateGroupArchiveDays(ctx, groupID, archiveDays); err != nil {
+		h.logger.Error("failed to update archive days", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.ArchiveDaysError))
+		return
+	}
+
+	setting := h.archiveDaysSetting(ctx, archiveDays)
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveDaysSet, group.Name, setting))
+	h.logAdminAction(userID, "set_archive_days", groupID, fmt.Sprintf("Set the event archival of group %s to %s", group.Name, setting))
+}
+
+// archiveDaysSetting describes a group archival setting, resolving the default to EVENT_ARCHIVE_DAYS
+func (h *BotHandler) archiveDaysSetting(ctx context.Context, archiveDays *int) string {
+	localizer := h.requestLocalizer(ctx)
+	if archiveDays == nil {
+		days := localizer.MustLocalize(locale.ArchiveDaysNever)
+		if h.config.EventArchiveDays > 0 {
+			days = localizer.MustLocalizeWithTemplate(locale.ArchiveDaysAfter, strconv.Itoa(h.config.EventArchiveDays))
+		}
+		return localizer.MustLocalizeWithTemplate(locale.ArchiveDaysDefault, days)
+	}
+	if *archiveDays == 0 {
+		return localizer.MustLocalize(locale.ArchiveDaysNever)
+	}
+	return localizer.MustLocalizeWithTemplate(locale.ArchiveDaysAfter, strconv.Itoa(*archiveDays))
+}
+
+// archiveDaysUsage builds the usage text followed by the archival setting of every group
+func (h *BotHandler) archiveDaysUsage(ctx context.Context) string {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+	}
+
+	var lines []string
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+		lines = append(lines, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveDaysGroupItem,
+			group.Name, fmt.Sprintf("%d", group.ID), h.archiveDaysSetting(ctx, group.ArchiveDays)))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty))
+	}
+
+	return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ArchiveDaysUsage, strings.Join(lines, "\n"))
+}
+
+// parseArchiveDaysArgs parses "/archive_days <group_id> <days|off|default>".
+// "off" gives 0 (never archive) and "default" gives nil (use EVENT_ARCHIVE_DAYS).
+func parseArchiveDaysArgs(text string) (groupID int64, archiveDays *int, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != archiveDaysCommand && !strings.HasPrefix(command, archiveDaysCommand+"@") {
+		return 0, nil, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		return 0, nil, false
+	}
+
+	groupID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || groupID <= 0 {
+		return 0, nil, false
+	}
+
+	switch {
+	case strings.EqualFold(fields[1], "default"):
+		return groupID, nil, true
+	case strings.EqualFold(fields[1], "off"):
+		days := 0
+		return groupID, &days, true
+	}
+
+	days, err := strconv.Atoi(fields[1])
+	if err != nil || days <= 0 {
+		return 0, nil, false
+	}
+
+	return groupID, &days, true
+}
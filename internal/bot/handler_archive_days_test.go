@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParseArchiveDaysArgs(t *testing.T) {
+	tests := []struct {
+		text        string
+		groupID     int64
+		archiveDays int // -1 means the default
+		ok          bool
+	}{
+		{"/archive_days 3 30", 3, 30, true},
+		{"/archive_days@PredictionBot 3  OFF ", 3, 0, true},
+		{"/archive_days 3 default", 3, -1, true},
+		{"/archive_days", 0, -1, false},
+		{"/archive_days 3", 0, -1, false},
+		{"/archive_days 3 0", 0, -1, false},
+		{"/archive_days 3 -1", 0, -1, false},
+		{"/archive_days x 30", 0, -1, false},
+		{"/archive_daysx 3 30", 0, -1, false},
+	}
+
+	for _, tt := range tests {
+		groupID, archiveDays, ok := parseArchiveDaysArgs(tt.text)
+		days := -1
+		if archiveDays != nil {
+			days = *archiveDays
+		}
+		if ok != tt.ok || groupID != tt.groupID || days != tt.archiveDays {
+			t.Errorf("parseArchiveDaysArgs(%q) = %d, %d, %t; want %d, %d, %t", tt.text, groupID, days, ok, tt.groupID, tt.archiveDays, tt.ok)
+		}
+	}
+}
+
+func TestHandleArchiveDays(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	groupRepo := storage.NewGroupRepository(queue)
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:    &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC, EventArchiveDays: 90},
+		groupRepo: groupRepo,
+		logger:    logger.New(logger.ERROR),
+		localizer: localizer,
+	}
+	send := func(text string) string {
+		t.Helper()
+		h.HandleArchiveDays(ctx, b, &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: adminID},
+				Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		})
+		texts := rec.texts()
+		if len(texts) == 0 {
+			t.Fatal("expected a reply")
+		}
+		return texts[len(texts)-1]
+	}
+	archiveDays := func() *int {
+		t.Helper()
+		group, err := groupRepo.GetGroup(ctx, groupID)
+		if err != nil || group == nil {
+			t.Fatalf("failed to get group: %v", err)
+		}
+		return group.ArchiveDays
+	}
+
+	// The usage lists every group with the global setting it follows
+	defaultSetting := localizer.MustLocalizeWithTemplate(locale.ArchiveDaysDefault, localizer.MustLocalizeWithTemplate(locale.ArchiveDaysAfter, "90"))
+	item := localizer.MustLocalizeWithTemplate(locale.ArchiveDaysGroupItem, "Test Group", fmt.Sprintf("%d", groupID), defaultSetting)
+	if text := send("/archive_days"); !strings.Contains(text, item) {
+		t.Errorf("expected usage to list %q, got %q", item, text)
+	}
+
+	if text := send("/archive_days 99 30"); text != localizer.MustLocalize(locale.GroupErrorNotFound) {
+		t.Errorf("expected group not found, got %q", text)
+	}
+
+	send(fmt.Sprintf("/archive_days %d 7", groupID))
+	if days := archiveDays(); days == nil || *days != 7 {
+		t.Errorf("expected 7 archive days, got %v", days)
+	}
+
+	text := send(fmt.Sprintf("/archive_days %d off", groupID))
+	if days := archiveDays(); days == nil || *days != 0 {
+		t.Errorf("expected archival to be off, got %v", days)
+	}
+	if expected := localizer.MustLocalizeWithTemplate(locale.ArchiveDaysSet, "Test Group", localizer.MustLocalize(locale.ArchiveDaysNever)); text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+
+	send(fmt.Sprintf("/archive_days %d default", groupID))
+	if days := archiveDays(); days != nil {
+		t.Errorf("expected the default setting, got %d", *days)
+	}
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleAutoRemoveInactive handles the /auto_remove_inactive command (toggle removal of inactive members per group)
+func (h *BotHandler) HandleAutoRemoveInactive(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	if h.config.InactiveMemberDays <= 0 {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.AutoRemoveInactiveDisabledAll),
+		})
+		return
+	}
+
+	kb, err := h.buildAutoRemoveInactiveKeyboard(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	if kb == nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.AutoRemoveInactiveTitle, strconv.Itoa(h.config.InactiveMemberDays)),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to send auto remove inactive settings", "error", err)
+	}
+}
+
+// buildAutoRemoveInactiveKeyboard builds toggle buttons for all active groups.
+// Returns nil keyboard if there are no active groups.
+func (h *BotHandler) buildAutoRemoveInactiveKeyboard(ctx context.Context) (*models.InlineKeyboardMarkup, error) {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+
+		state := " ❌"
+		if group.AutoRemoveInactive {
+			state = " ✅"
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         "🧹 " + group.Name + state,
+				CallbackData: mustEncodeCallback(cbAutoRemoveInactiveToggle, group.ID),
+			},
+		})
+	}
+
+	if len(buttons) == 0 {
+		return nil, nil
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, nil
+}
+
+// handleAutoRemoveInactiveCallback toggles the removal of inactive members for the selected group
+func (h *BotHandler) handleAutoRemoveInactiveCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	if err := cb.Expect(cbAutoRemoveInactiveToggle, 1); err != nil {
+		h.logger.Error("invalid auto_remove_inactive callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status == domain.GroupStatusDeleted {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	autoRemove := !group.AutoRemoveInactive
+	if err := h.groupRepo.UpdateGroupAutoRemoveInactive(ctx, groupID, autoRemove); err != nil {
+		h.logger.Error("failed to update auto remove inactive setting", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.AutoRemoveInactiveErrorUpdate),
+		})
+		return
+	}
+
+	answerKey := locale.AutoRemoveInactiveDisabled
+	if autoRemove {
+		answerKey = locale.AutoRemoveInactiveEnabled
+	}
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(answerKey, group.Name),
+	})
+
+	// Update keyboard with new toggle states
+	if callback.Message.Message != nil {
+		kb, err := h.buildAutoRemoveInactiveKeyboard(ctx)
+		if err != nil {
+			h.logger.Error("failed to rebuild auto remove inactive keyboard", "error", err)
+		} else if kb != nil {
+			_, _ = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+				ChatID:      callback.Message.Message.Chat.ID,
+				MessageID:   callback.Message.Message.ID,
+				ReplyMarkup: kb,
+			})
+		}
+	}
+
+	h.logAdminAction(userID, "toggle_auto_remove_inactive", groupID, fmt.Sprintf("Set auto remove inactive to %t for group %s", autoRemove, group.Name))
+}
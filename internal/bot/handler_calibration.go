@@ -0,0 +1,112 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleCalibration handles the /calibration command (how well the user's probability forecasts match outcomes)
+func (h *BotHandler) HandleCalibration(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send calibration message", "error", err)
+		}
+	}
+
+	// Determine user's current group context
+	groupID, err := h.groupContextResolver.ResolveGroupForUser(ctx, userID)
+	if err != nil {
+		reply(h.userErrorMessage(ctx, err, "failed to resolve group context", "user_id", userID))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		reply(h.userErrorMessage(ctx, err, "failed to get group", "group_id", groupID))
+		return
+	}
+
+	report, err := h.ratingCalculator.CalibrationReport(ctx, userID, groupID)
+	if err != nil {
+		reply(h.userErrorMessage(ctx, err, "failed to build calibration report", "user_id", userID, "group_id", groupID))
+		return
+	}
+
+	if report.Predictions == 0 {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.CalibrationEmpty))
+		return
+	}
+
+	reply(h.buildCalibrationMessage(ctx, group, report))
+}
+
+// buildCalibrationMessage formats a calibration report as a compact table with a summary
+func (h *BotHandler) buildCalibrationMessage(ctx context.Context, group *domain.Group, report *domain.CalibrationReport) string {
+	percent := func(v float64) string {
+		return fmt.Sprintf("%d", int(math.Round(v*100)))
+	}
+	rangeWidth := 100 / domain.ProbabilityOptionCount
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.CalibrationTitle) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingGroupName, group.Name) + "\n\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.CalibrationHeader) + "\n")
+
+	hasLowConfidence := false
+	for _, bucket := range report.Buckets {
+		low := fmt.Sprintf("%d", bucket.Option*rangeWidth)
+		high := fmt.Sprintf("%d", (bucket.Option+1)*rangeWidth)
+		if bucket.Predictions == 0 {
+			sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CalibrationRowEmpty, low, high) + "\n")
+			continue
+		}
+
+		marker := ""
+		if bucket.LowConfidence() {
+			marker = " ⚠️"
+			hasLowConfidence = true
+		}
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CalibrationRow,
+			low,
+			high,
+			fmt.Sprintf("%d", bucket.Predictions),
+			percent(bucket.HitRate()),
+			percent(bucket.AverageOutcome()),
+			marker,
+		) + "\n")
+	}
+	if hasLowConfidence {
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CalibrationLowConfidence, fmt.Sprintf("%d", domain.MinCalibrationBucketPredictions)) + "\n")
+	}
+
+	sb.WriteString("\n" + h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CalibrationTotal, fmt.Sprintf("%d", report.Predictions)) + "\n")
+
+	// Draw conclusions only with enough data overall
+	bias := report.Bias()
+	switch {
+	case report.Predictions < domain.MinCalibrationBucketPredictions:
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.CalibrationNotEnoughData))
+	case report.IsCalibrated():
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.CalibrationWellCalibrated))
+	case bias > 0:
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CalibrationOverestimate, percent(bias)))
+	default:
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CalibrationUnderestimate, percent(-bias)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+)
+
+func TestBuildCalibrationMessage(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	h := &BotHandler{localizer: localizer}
+
+	report := &domain.CalibrationReport{
+		Buckets: []*domain.CalibrationBucket{
+			{Option: 0},
+			{Option: 1, Predictions: 2, Hits: 1},
+			{Option: 2},
+			{Option: 3, Predictions: 6, Hits: 6},
+		},
+		Predictions: 8,
+	}
+	message := h.buildCalibrationMessage(context.Background(), &domain.Group{Name: "Friends"}, report)
+
+	for _, expected := range []string{
+		localizer.MustLocalizeWithTemplate(locale.CalibrationRowEmpty, "0", "25"),
+		"25–50% │ 2 │ 50% │",
+		"75–100% │ 6 │ 100% │",
+		localizer.MustLocalizeWithTemplate(locale.CalibrationLowConfidence, "5"),
+		localizer.MustLocalizeWithTemplate(locale.CalibrationTotal, "8"),
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("expected message to contain %q, got:\n%s", expected, message)
+		}
+	}
+
+	// Only the low-confidence bucket is flagged
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "75–100%") && strings.Contains(line, "⚠️") {
+			t.Errorf("expected 75-100%% bucket not to be flagged, got %q", line)
+		}
+		if strings.HasPrefix(line, "25–50%") && !strings.Contains(line, "⚠️") {
+			t.Errorf("expected 25-50%% bucket to be flagged, got %q", line)
+		}
+	}
+}
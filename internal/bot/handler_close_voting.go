@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handleCloseVotingCallback stops accepting votes for an event before its deadline without resolving it.
+// The predictions made so far are locked in and the event is resolved later.
+func (h *BotHandler) handleCloseVotingCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	answer := func(key string) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.localizeKey(ctx, key),
+			ShowAlert:       true,
+		})
+	}
+
+	// Parse event ID from callback data: close_voting:EVENT_ID
+	if err := cb.Expect(cbCloseVoting, 1); err != nil {
+		answer(locale.ErrorInvalidDataFormat)
+		return
+	}
+	eventID, err := cb.Int64(0)
+	if err != nil {
+		answer(locale.ErrorInvalidEventID)
+		return
+	}
+
+	canManage, err := h.eventPermissionValidator.CanManageEvent(ctx, userID, eventID, h.config.AdminUserIDs)
+	if err != nil {
+		h.logger.Error("failed to check event management permission", "user_id", userID, "event_id", eventID, "error", err)
+		answer(locale.EventResolutionErrorPermissionCheck)
+		return
+	}
+	if !canManage {
+		answer(locale.EventResolutionErrorUnauthorized)
+		return
+	}
+
+	if err := h.eventManager.CloseVoting(ctx, eventID); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrVotingClosed):
+			answer(locale.CloseVotingAlreadyClosed)
+		case errors.Is(err, domain.ErrEventNotActive):
+			answer(locale.CloseVotingNotActive)
+		default:
+			answer(locale.CloseVotingError)
+		}
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	event, err := h.eventManager.GetEvent(ctx, eventID)
+	if err != nil {
+		h.logger.Error("failed to get event after closing voting", "event_id", eventID, "error", err)
+		return
+	}
+
+	h.stopClosedPoll(ctx, b, event)
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: callback.Message.Message.Chat.ID,
+		Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CloseVotingSuccess, event.Question),
+		ReplyMarkup: &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{
+				{{Text: h.requestLocalizer(ctx).MustLocalize(locale.ActionButtonResolve), CallbackData: mustEncodeCallback(cbResolve, event.ID)}},
+			},
+		},
+	})
+	if err != nil {
+		h.logger.Error("failed to send close voting confirmation", "event_id", eventID, "error", err)
+	}
+
+	h.logger.Info("voting closed by manager", "user_id", userID, "event_id", eventID)
+}
+
+// stopClosedPoll stops the poll of an event whose voting was closed, so the chat shows it as final.
+// Votes are rejected either way; a failure here is only logged.
+func (h *BotHandler) stopClosedPoll(ctx context.Context, b *bot.Bot, event *domain.Event) {
+	if event.PollID == "" || event.PollMessageID == 0 {
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil || group == nil {
+		h.logger.Error("failed to get group for stopping poll", "event_id", event.ID, "group_id", event.GroupID, "error", err)
+		return
+	}
+
+	_, err = b.StopPoll(ctx, &bot.StopPollParams{
+		ChatID:    group.TelegramChatID,
+		MessageID: event.PollMessageID,
+	})
+	if err != nil {
+		h.logger.Error("failed to stop poll", "event_id", event.ID, "message_id", event.PollMessageID, "telegram_chat_id", group.TelegramChatID, "error", err)
+		// A deleted poll is reported by /orphans
+		if isMessageNotFoundError(err) {
+			_ = h.eventManager.MarkPollMessageMissing(ctx, event.ID)
+		}
+		return
+	}
+
+	h.logger.Info("poll stopped after closing voting", "event_id", event.ID, "message_id", event.PollMessageID)
+}
@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestCloseVoting_LocksInPredictions(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	creatorID := int64(100)
+	voterID := int64(200)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	for _, userID := range []int64{creatorID, voterID} {
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	event := &domain.Event{
+		GroupID:       groupID,
+		Question:      "Will it rain?",
+		Options:       []string{"Yes", "No"},
+		CreatedAt:     time.Now(),
+		Deadline:      time.Now().Add(24 * time.Hour),
+		Status:        domain.EventStatusActive,
+		EventType:     domain.EventTypeBinary,
+		CreatedBy:     creatorID,
+		PollID:        "poll_1",
+		PollMessageID: 77,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	h := &BotHandler{
+		bot:                      b,
+		config:                   &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:                storage.NewGroupRepository(queue),
+		groupMembershipRepo:      membershipRepo,
+		eventManager:             domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		eventPermissionValidator: domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		predictionRepo:           predictionRepo,
+		ratingRepo:               ratingRepo,
+		ratingCalculator:         domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		logger:                   log,
+		localizer:                localizer,
+	}
+
+	closeVoting := func(userID int64) {
+		t.Helper()
+		h.HandleCallback(ctx, b, &models.Update{CallbackQuery: &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: mustEncodeCallback(cbCloseVoting, event.ID),
+			Message: models.MaybeInaccessibleMessage{
+				Type:    models.MaybeInaccessibleMessageTypeMessage,
+				Message: &models.Message{ID: 5, Chat: models.Chat{ID: userID}},
+			},
+		}})
+	}
+	vote := func(userID int64, option int) {
+		t.Helper()
+		h.HandlePollAnswer(ctx, b, &models.Update{PollAnswer: &models.PollAnswer{
+			PollID:    "poll_1",
+			User:      &models.User{ID: userID, Username: "voter"},
+			OptionIDs: []int{option},
+		}})
+	}
+	votingClosed := func() bool {
+		t.Helper()
+		stored, err := eventRepo.GetEvent(ctx, event.ID)
+		if err != nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		return stored.VotingClosedAt != nil
+	}
+
+	vote(voterID, 0)
+
+	// Only managers can close voting
+	closeVoting(voterID)
+	if votingClosed() {
+		t.Fatal("expected a regular member not to close voting")
+	}
+
+	closeVoting(creatorID)
+	if !votingClosed() {
+		t.Fatal("expected the creator to close voting")
+	}
+	if stopped := rec.stoppedIDs(); len(stopped) != 1 || stopped[0] != 77 {
+		t.Errorf("expected poll message 77 to be stopped, got %v", stopped)
+	}
+	texts := rec.texts()
+	if want := localizer.MustLocalizeWithTemplate(locale.CloseVotingSuccess, "Will it rain?"); len(texts) == 0 || texts[len(texts)-1] != want {
+		t.Errorf("expected confirmation %q, got %v", want, texts)
+	}
+
+	// Closing again changes nothing
+	closeVoting(creatorID)
+	if len(rec.texts()) != len(texts) || len(rec.stoppedIDs()) != 1 {
+		t.Error("expected no second confirmation or poll stop")
+	}
+
+	// Votes arriving after the close are ignored, before the deadline too
+	vote(voterID, 1)
+	vote(creatorID, 1)
+	predictions, err := predictionRepo.GetPredictionsByEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("failed to get predictions: %v", err)
+	}
+	if len(predictions) != 1 || predictions[0].UserID != voterID || predictions[0].Option != 0 {
+		t.Errorf("expected only the vote made before closing, got %+v", predictions)
+	}
+
+	// The event stays active until it is resolved
+	stored, _ := eventRepo.GetEvent(ctx, event.ID)
+	if stored.Status != domain.EventStatusActive {
+		t.Errorf("expected the event to stay active, got %s", stored.Status)
+	}
+}
@@ -0,0 +1,45 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+)
+
+// newMemberCooldownRemaining returns how long a user still has to wait after joining a group
+// before creating events there (0 if the cooldown is disabled or already over)
+func (h *BotHandler) newMemberCooldownRemaining(ctx context.Context, userID int64, groupID int64, now time.Time) (time.Duration, error) {
+	if h.config.NewMemberCreateCooldown <= 0 {
+		return 0, nil
+	}
+
+	membership, err := h.groupMembershipRepo.GetMembership(ctx, groupID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if membership == nil {
+		return 0, nil
+	}
+
+	remaining := membership.JoinedAt.Add(h.config.NewMemberCreateCooldown).Sub(now)
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// formatCooldownWait formats a remaining wait as days and hours, hours and minutes, or minutes (rounded up)
+func (h *BotHandler) formatCooldownWait(ctx context.Context, wait time.Duration) string {
+	minutes := int((wait + time.Minute - 1) / time.Minute)
+	switch {
+	case minutes >= 24*60:
+		hours := (minutes + 59) / 60
+		return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CooldownWaitDays, fmt.Sprintf("%d", hours/24), fmt.Sprintf("%d", hours%24))
+	case minutes >= 60:
+		return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CooldownWaitHours, fmt.Sprintf("%d", minutes/60), fmt.Sprintf("%d", minutes%60))
+	default:
+		return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.CooldownWaitMinutes, fmt.Sprintf("%d", minutes))
+	}
+}
@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestHandleCreateEvent_NewMemberCooldown(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	cooldown := 24 * time.Hour
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	cfg := &config.Config{
+		AdminUserIDs:            []int64{adminID},
+		Timezone:                time.UTC,
+		NewMemberCreateCooldown: cooldown,
+	}
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	fsmStorage := storage.NewFSMStorage(queue, log)
+
+	rec, b := newRecordingTelegramServer(t)
+	fsm := NewEventCreationFSM(fsmStorage, b, nil, nil, domain.NewGroupContextResolver(groupRepo), groupRepo, nil, nil,
+		membershipRepo, storage.NewUserRepository(queue), nil, cfg, log, localizer)
+	h := &BotHandler{
+		config:                   cfg,
+		groupRepo:                groupRepo,
+		groupMembershipRepo:      membershipRepo,
+		eventPermissionValidator: domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		eventCreationFSM:         fsm,
+		logger:                   log,
+		localizer:                localizer,
+	}
+
+	createEvent := func(userID int64, joinedAgo time.Duration) (started bool, texts []string) {
+		t.Helper()
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now().Add(-joinedAgo), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+
+		before := len(rec.texts())
+		h.HandleCreateEvent(ctx, b, &models.Update{Message: &models.Message{
+			From: &models.User{ID: userID},
+			Chat: models.Chat{ID: userID},
+			Text: "/create_event",
+		}})
+
+		_, _, err := fsmStorage.Get(ctx, userID)
+		return err == nil, rec.texts()[before:]
+	}
+
+	t.Run("just joined member is told how long to wait", func(t *testing.T) {
+		started, texts := createEvent(100, time.Hour)
+		if started {
+			t.Fatal("expected no event creation session for a new member")
+		}
+		if len(texts) != 1 || !strings.Contains(texts[0], localizer.MustLocalizeWithTemplate(locale.CooldownWaitHours, "23", "0")) {
+			t.Errorf("expected cooldown message with 23 h 0 min remaining, got %v", texts)
+		}
+	})
+
+	t.Run("member past the cooldown can create events", func(t *testing.T) {
+		started, texts := createEvent(200, cooldown+time.Minute)
+		if !started {
+			t.Fatalf("expected event creation session, got messages %v", texts)
+		}
+	})
+
+	t.Run("admins are exempt", func(t *testing.T) {
+		started, texts := createEvent(adminID, 0)
+		if !started {
+			t.Fatalf("expected event creation session for admin, got messages %v", texts)
+		}
+	})
+}
+
+func TestFormatCooldownWait(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	h := &BotHandler{localizer: localizer}
+
+	tests := []struct {
+		wait     time.Duration
+		expected string
+	}{
+		{30 * time.Second, "1 min"},
+		{59 * time.Minute, "59 min"},
+		{90 * time.Minute, "1 h 30 min"},
+		{23*time.Hour + 58*time.Minute + 30*time.Second, "23 h 59 min"},
+		{23*time.Hour + 59*time.Minute + 30*time.Second, "1 d 0 h"},
+		{50 * time.Hour, "2 d 2 h"},
+	}
+	for _, tt := range tests {
+		if got := h.formatCooldownWait(context.Background(), tt.wait); got != tt.expected {
+			t.Errorf("formatCooldownWait(%s) = %q, want %q", tt.wait, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// defaultEventTypeNone is the callback value that clears a group's default event type
+const defaultEventTypeNone = "none"
+
+// eventTypeCallbackValue returns the callback value of an event type
+func eventTypeCallbackValue(eventType domain.EventType) string {
+	switch eventType {
+	case domain.EventTypeMultiOption:
+		return "multi"
+	default:
+		return string(eventType)
+	}
+}
+
+// eventTypeFromCallbackValue parses an event type callback value; "none" yields an empty type
+func eventTypeFromCallbackValue(value string) (domain.EventType, bool) {
+	switch value {
+	case "binary":
+		return domain.EventTypeBinary, true
+	case "multi":
+		return domain.EventTypeMultiOption, true
+	case "probability":
+		return domain.EventTypeProbability, true
+	case defaultEventTypeNone:
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// eventTypeLabel returns the localized label of an event type, or of "no default" for an empty type
+func (h *BotHandler) eventTypeLabel(ctx context.Context, eventType domain.EventType) string {
+	switch eventType {
+	case domain.EventTypeBinary:
+		return h.requestLocalizer(ctx).MustLocalize(locale.EventTypeBinaryLabel)
+	case domain.EventTypeMultiOption:
+		return h.requestLocalizer(ctx).MustLocalize(locale.EventTypeMultiOptionLabel)
+	case domain.EventTypeProbability:
+		return h.requestLocalizer(ctx).MustLocalize(locale.EventTypeProbabilityLabel)
+	default:
+		return h.requestLocalizer(ctx).MustLocalize(locale.DefaultEventTypeNone)
+	}
+}
+
+// HandleDefaultEventType handles the /default_event_type command (default event type per group)
+func (h *BotHandler) HandleDefaultEventType(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	kb, err := h.buildDefaultEventTypeGroupsKeyboard(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	if kb == nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.DefaultEventTypeTitle),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to send default event type settings", "error", err)
+	}
+}
+
+// buildDefaultEventTypeGroupsKeyboard builds a button with the current default for each active group.
+// Returns nil keyboard if there are no active groups.
+func (h *BotHandler) buildDefaultEventTypeGroupsKeyboard(ctx context.Context) (*models.InlineKeyboardMarkup, error) {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         "🗂 " + group.Name + " — " + h.eventTypeLabel(ctx, group.DefaultEventType),
+				CallbackData: mustEncodeCallback(cbDefaultTypeGroup, group.ID),
+			},
+		})
+	}
+
+	if len(buttons) == 0 {
+		return nil, nil
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, nil
+}
+
+// buildDefaultEventTypeKeyboard builds the event type choice for a group
+func (h *BotHandler) buildDefaultEventTypeKeyboard(ctx context.Context, groupID int64) *models.InlineKeyboardMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	for _, eventType := range []domain.EventType{domain.EventTypeBinary, domain.EventTypeMultiOption, domain.EventTypeProbability, ""} {
+		value := defaultEventTypeNone
+		if eventType != "" {
+			value = eventTypeCallbackValue(eventType)
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: h.eventTypeLabel(ctx, eventType), CallbackData: mustEncodeCallback(cbDefaultTypeSet, groupID, value)},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// handleDefaultEventTypeCallback shows the type choice for the selected group or stores the selected type
+func (h *BotHandler) handleDefaultEventTypeCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	fieldCount := 1
+	if cb.Namespace == cbDefaultTypeSet {
+		fieldCount = 2
+	}
+	if err := cb.Expect(cb.Namespace, fieldCount); err != nil {
+		h.logger.Error("invalid default event type callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status == domain.GroupStatusDeleted {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	if cb.Namespace == cbDefaultTypeGroup {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		h.editDefaultEventTypeMessage(ctx, b, callback,
+			h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DefaultEventTypeSelect, group.Name, h.eventTypeLabel(ctx, group.DefaultEventType)),
+			h.buildDefaultEventTypeKeyboard(ctx, groupID))
+		return
+	}
+
+	value, _ := cb.Field(1)
+	eventType, ok := eventTypeFromCallbackValue(value)
+	if !ok {
+		h.logger.Error("unknown default event type", "group_id", groupID, "value", value)
+		return
+	}
+
+	if err := h.groupRepo.UpdateGroupDefaultEventType(ctx, groupID, eventType); err != nil {
+		h.logger.Error("failed to update default event type", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.DefaultEventTypeErrorUpdate),
+		})
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DefaultEventTypeUpdated, group.Name, h.eventTypeLabel(ctx, eventType)),
+	})
+
+	// Return to the group list with the updated defaults
+	kb, err := h.buildDefaultEventTypeGroupsKeyboard(ctx)
+	if err != nil {
+		h.logger.Error("failed to rebuild default event type keyboard", "error", err)
+	} else if kb != nil {
+		h.editDefaultEventTypeMessage(ctx, b, callback, h.requestLocalizer(ctx).MustLocalize(locale.DefaultEventTypeTitle), kb)
+	}
+
+	h.logAdminAction(userID, "set_default_event_type", groupID, fmt.Sprintf("Set default event type to %q for group %s", eventType, group.Name))
+}
+
+// editDefaultEventTypeMessage replaces the settings message with new text and buttons
+func (h *BotHandler) editDefaultEventTypeMessage(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, text string, kb *models.InlineKeyboardMarkup) {
+	if callback.Message.Message == nil {
+		return
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to edit default event type message", "error", err)
+	}
+}
@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestDefaultEventType(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC}
+	groupRepo := storage.NewGroupRepository(queue)
+	h := &BotHandler{config: cfg, groupRepo: groupRepo, logger: log, localizer: localizer}
+	fsm := NewEventCreationFSM(storage.NewFSMStorage(queue, log), b, nil, nil, nil, groupRepo, nil, nil, nil, nil, nil, cfg, log, localizer)
+
+	press := func(data string) {
+		t.Helper()
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: adminID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}},
+			},
+		}
+		h.handleDefaultEventTypeCallback(ctx, b, callback, adminID, cb)
+	}
+	// firstTypeButton asks the question and returns the first event type button offered
+	firstTypeButton := func(question string) models.InlineKeyboardButton {
+		t.Helper()
+		sessionContext := &domain.EventCreationContext{ChatID: adminID, GroupID: groupID}
+		if err := fsm.storage.Set(ctx, adminID, StateAskQuestion, sessionContext.ToMap()); err != nil {
+			t.Fatalf("failed to set session: %v", err)
+		}
+		if err := fsm.handleQuestionInput(ctx, adminID, adminID, question, 50, sessionContext); err != nil {
+			t.Fatalf("handleQuestionInput failed: %v", err)
+		}
+
+		rec.mu.Lock()
+		markup := rec.markups[len(rec.markups)-1]
+		rec.mu.Unlock()
+		var kb models.InlineKeyboardMarkup
+		if err := json.Unmarshal([]byte(markup), &kb); err != nil {
+			t.Fatalf("failed to parse keyboard %q: %v", markup, err)
+		}
+		if len(kb.InlineKeyboard) != 3 {
+			t.Fatalf("expected 3 event types, got %v", kb.InlineKeyboard)
+		}
+		return kb.InlineKeyboard[0][0]
+	}
+
+	t.Run("without a default the usual order is kept", func(t *testing.T) {
+		button := firstTypeButton("Tomorrow's weather in the city center")
+		if button.Text != localizer.MustLocalize(locale.EventTypeBinaryButton) {
+			t.Errorf("expected binary first, got %q", button.Text)
+		}
+	})
+
+	t.Run("without a default the suggested type is offered first", func(t *testing.T) {
+		button := firstTypeButton("Which team wins the cup?")
+		expected := localizer.MustLocalizeWithTemplate(locale.EventTypeSuggestedButton, localizer.MustLocalize(locale.EventTypeMultiOptionButton))
+		if button.Text != expected || button.CallbackData != mustEncodeCallback(cbEventType, eventTypeCallbackValue(domain.EventTypeMultiOption)) {
+			t.Errorf("expected suggested multi-option button first, got %q/%q", button.Text, button.CallbackData)
+		}
+	})
+
+	t.Run("admin sets a default that is offered first", func(t *testing.T) {
+		press(mustEncodeCallback(cbDefaultTypeSet, groupID, "probability"))
+
+		group, err := groupRepo.GetGroup(ctx, groupID)
+		if err != nil {
+			t.Fatalf("failed to get group: %v", err)
+		}
+		if group.DefaultEventType != domain.EventTypeProbability {
+			t.Fatalf("expected default %q, got %q", domain.EventTypeProbability, group.DefaultEventType)
+		}
+
+		button := firstTypeButton("Will it rain tomorrow?")
+		expected := localizer.MustLocalizeWithTemplate(locale.EventTypeDefaultButton, localizer.MustLocalize(locale.EventTypeProbabilityButton))
+		if button.Text != expected || button.CallbackData != mustEncodeCallback(cbEventType, "probability") {
+			t.Errorf("expected default probability button first, got %q/%q", button.Text, button.CallbackData)
+		}
+	})
+
+	t.Run("unknown types are ignored and none clears the default", func(t *testing.T) {
+		press(mustEncodeCallback(cbDefaultTypeSet, groupID, "poll"))
+		if group, _ := groupRepo.GetGroup(ctx, groupID); group.DefaultEventType != domain.EventTypeProbability {
+			t.Errorf("expected default to stay %q, got %q", domain.EventTypeProbability, group.DefaultEventType)
+		}
+
+		press(mustEncodeCallback(cbDefaultTypeSet, groupID, defaultEventTypeNone))
+		if group, _ := groupRepo.GetGroup(ctx, groupID); group.DefaultEventType != "" {
+			t.Errorf("expected default to be cleared, got %q", group.DefaultEventType)
+		}
+	})
+}
+
+func TestEventTypeCallbackValue(t *testing.T) {
+	for _, eventType := range []domain.EventType{domain.EventTypeBinary, domain.EventTypeMultiOption, domain.EventTypeProbability} {
+		parsed, ok := eventTypeFromCallbackValue(eventTypeCallbackValue(eventType))
+		if !ok || parsed != eventType {
+			t.Errorf("round trip of %q gave %q, %v", eventType, parsed, ok)
+		}
+	}
+	if _, ok := eventTypeFromCallbackValue("multi_option"); ok {
+		t.Error("expected the stored type name not to be a callback value")
+	}
+}
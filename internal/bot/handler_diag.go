@@ -0,0 +1,181 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// diagCheckTimeout bounds every /diag check, so one hanging check doesn't block the report
+	diagCheckTimeout = 5 * time.Second
+	// diagSchedulerMissedRuns is the number of missed scheduler runs after which the scheduler counts as stuck
+	diagSchedulerMissedRuns = 3
+)
+
+// diagCheck is one /diag check. run returns a short detail shown when the check passes.
+type diagCheck struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+// diagResult is the outcome of a diagCheck
+type diagResult struct {
+	detail  string
+	err     error
+	elapsed time.Duration
+}
+
+// HandleDiag handles the /diag command: it runs the self-checks in parallel and reports pass/fail for each
+func (h *BotHandler) HandleDiag(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	checks := h.diagChecks(ctx, b)
+	results := runDiagChecks(ctx, checks, diagCheckTimeout)
+
+	failed := 0
+	lines := make([]string, 0, len(checks))
+	for i, check := range checks {
+		result := results[i]
+		elapsed := result.elapsed.Round(time.Millisecond).String()
+		switch {
+		case result.err == nil:
+			lines = append(lines, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagCheckPassed, check.name, result.detail, elapsed))
+		case errors.Is(result.err, context.DeadlineExceeded):
+			failed++
+			lines = append(lines, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagCheckFailed, check.name,
+				h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagTimedOut, diagCheckTimeout.String())))
+		default:
+			failed++
+			lines = append(lines, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagCheckFailed, check.name, result.err.Error()))
+		}
+	}
+
+	summary := h.requestLocalizer(ctx).MustLocalize(locale.DiagAllPassed)
+	if failed > 0 {
+		summary = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagSomeFailed, fmt.Sprintf("%d", failed), fmt.Sprintf("%d", len(checks)))
+	}
+
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagReport, strings.Join(lines, "\n"), summary),
+	})
+	if err != nil {
+		h.logger.Error("failed to send diag report", "error", err)
+	}
+
+	h.logger.Info("diag checks completed", "user_id", update.Message.From.ID, "checks", len(checks), "failed", failed)
+}
+
+// diagChecks returns the checks of the available components. The scheduler check is skipped
+// when the bot runs without a notification service.
+func (h *BotHandler) diagChecks(ctx context.Context, b *bot.Bot) []diagCheck {
+	var checks []diagCheck
+
+	if h.settingsRepo != nil {
+		checks = append(checks, diagCheck{
+			name: h.requestLocalizer(ctx).MustLocalize(locale.DiagCheckDatabase),
+			run: func(ctx context.Context) (string, error) {
+				if err := h.settingsRepo.CheckRoundTrip(ctx); err != nil {
+					return "", err
+				}
+				return h.requestLocalizer(ctx).MustLocalize(locale.DiagDatabaseOK), nil
+			},
+		})
+	}
+
+	if h.notificationService != nil {
+		checks = append(checks, diagCheck{
+			name: h.requestLocalizer(ctx).MustLocalize(locale.DiagCheckScheduler),
+			run: func(ctx context.Context) (string, error) {
+				lastRun := h.notificationService.LastSchedulerRun()
+				if lastRun.IsZero() {
+					return "", errors.New(h.requestLocalizer(ctx).MustLocalize(locale.DiagSchedulerNotStarted))
+				}
+				age := time.Since(lastRun).Round(time.Second).String()
+				if time.Since(lastRun) > diagSchedulerMissedRuns*domain.SchedulerInterval {
+					return "", errors.New(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagSchedulerStuck, age))
+				}
+				return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagSchedulerLastRun, age), nil
+			},
+		})
+	}
+
+	if h.eventCreationFSM != nil {
+		checks = append(checks, diagCheck{
+			name: h.requestLocalizer(ctx).MustLocalize(locale.DiagCheckSessions),
+			run: func(ctx context.Context) (string, error) {
+				count, err := h.eventCreationFSM.storage.CountStale(ctx)
+				if err != nil {
+					return "", err
+				}
+				return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DiagSessionsStale, fmt.Sprintf("%d", count)), nil
+			},
+		})
+	}
+
+	checks = append(checks, diagCheck{
+		name: h.requestLocalizer(ctx).MustLocalize(locale.DiagCheckTelegram),
+		run: func(ctx context.Context) (string, error) {
+			me, err := b.GetMe(ctx)
+			if err != nil {
+				return "", err
+			}
+			return "@" + me.Username, nil
+		},
+	})
+
+	return checks
+}
+
+// runDiagChecks runs the checks in parallel, each bounded by timeout, and returns the results in
+// the order of the checks
+func runDiagChecks(ctx context.Context, checks []diagCheck, timeout time.Duration) []diagResult {
+	results := make([]diagResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runDiagCheck(ctx, check, timeout)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runDiagCheck runs one check and gives up after timeout, also when the check ignores its context
+func runDiagCheck(ctx context.Context, check diagCheck, timeout time.Duration) diagResult {
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan diagResult, 1)
+	go func() {
+		detail, err := check.run(checkCtx)
+		done <- diagResult{detail: detail, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		result.elapsed = time.Since(start)
+		return result
+	case <-checkCtx.Done():
+		// The buffered channel lets an abandoned check finish without blocking
+		return diagResult{err: checkCtx.Err(), elapsed: time.Since(start)}
+	}
+}
@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestRunDiagChecks_TimeboxesEachCheck(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	checks := []diagCheck{
+		{name: "ok", run: func(ctx context.Context) (string, error) { return "fine", nil }},
+		// Ignores its context, the report must not wait for it
+		{name: "hang", run: func(ctx context.Context) (string, error) { <-release; return "", nil }},
+		{name: "broken", run: func(ctx context.Context) (string, error) { return "", errors.New("boom") }},
+	}
+
+	start := time.Now()
+	results := runDiagChecks(context.Background(), checks, 50*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the checks to be timeboxed, took %v", elapsed)
+	}
+
+	if results[0].err != nil || results[0].detail != "fine" {
+		t.Errorf("expected the first check to pass, got %+v", results[0])
+	}
+	if !errors.Is(results[1].err, context.DeadlineExceeded) {
+		t.Errorf("expected the hanging check to time out, got %+v", results[1])
+	}
+	if results[2].err == nil || results[2].err.Error() != "boom" {
+		t.Errorf("expected the broken check to fail, got %+v", results[2])
+	}
+}
+
+func TestHandleDiag(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, _ := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:           &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		eventCreationFSM: newQuestionValidationFSM(t, b, nil),
+		settingsRepo:     storage.NewSettingsRepository(queue),
+		logger:           logger.New(logger.ERROR),
+		localizer:        localizer,
+	}
+
+	h.HandleDiag(ctx, b, &models.Update{
+		Message: &models.Message{
+			ID:   1,
+			From: &models.User{ID: adminID},
+			Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+			Text: "/diag",
+		},
+	})
+
+	texts := rec.texts()
+	if len(texts) != 1 {
+		t.Fatalf("expected one report, got %v", texts)
+	}
+	report := texts[0]
+	for _, want := range []string{
+		"✅ " + localizer.MustLocalize(locale.DiagCheckDatabase),
+		"✅ " + localizer.MustLocalize(locale.DiagCheckSessions) + ": " + localizer.MustLocalizeWithTemplate(locale.DiagSessionsStale, "0"),
+		"✅ " + localizer.MustLocalize(locale.DiagCheckTelegram),
+		localizer.MustLocalize(locale.DiagAllPassed),
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected the report to contain %q, got %q", want, report)
+		}
+	}
+	// The write of the database check is rolled back
+	if _, found, _ := storage.NewSettingsRepository(queue).GetSetting(ctx, "diag_roundtrip"); found {
+		t.Error("expected the database check to leave no setting behind")
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/ad/gitelegram-prediction-market/internal/domain"
 	"github.com/ad/gitelegram-prediction-market/internal/storage"
 
+	"github.com/go-telegram/bot/models"
+
 	_ "modernc.org/sqlite"
 )
 
@@ -39,7 +41,7 @@ func TestGetUserDisplayName_WithUsername(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 	handler := &BotHandler{
@@ -100,7 +102,7 @@ func TestGetUserDisplayName_WithUsernameWithAtSign(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 	handler := &BotHandler{
@@ -161,7 +163,7 @@ func TestGetUserDisplayName_WithOnlyUserID(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 	handler := &BotHandler{
@@ -222,7 +224,7 @@ func TestGetUserDisplayName_WithOnlyFirstName(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 	handler := &BotHandler{
@@ -283,7 +285,7 @@ func TestGetUserDisplayName_UserNotFound(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 	handler := &BotHandler{
@@ -304,3 +306,114 @@ func TestGetUserDisplayName_UserNotFound(t *testing.T) {
 		t.Errorf("Expected display name %q, got %q", expected, displayName)
 	}
 }
+
+// TestGetUserDisplayName_PrefersCachedProfile tests that the cached user profile overrides stale rating usernames
+func TestGetUserDisplayName_PrefersCachedProfile(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := storage.NewDBQueue(db)
+	defer queue.Close()
+
+	if err := storage.InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := storage.RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ratingRepo := storage.NewRatingRepository(queue)
+	logger := &mockLogger{}
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, storage.NewPredictionRepository(queue), storage.NewEventRepository(queue), nil, logger)
+
+	handler := &BotHandler{
+		ratingCalculator: ratingCalc,
+		userRepo:         storage.NewUserRepository(queue),
+		config:           &config.Config{},
+		logger:           logger,
+	}
+
+	ctx := context.Background()
+	userID := int64(12345)
+
+	// Rating in group 1 still holds the old username
+	if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: userID, GroupID: 1, Username: "old_name"}); err != nil {
+		t.Fatalf("Failed to create rating: %v", err)
+	}
+
+	// The bot sees the user with a new username in another chat
+	handler.RememberUser(ctx, &models.Update{
+		Message: &models.Message{From: &models.User{ID: userID, Username: "new_name", FirstName: "Ivan"}},
+	})
+	if got := handler.getUserDisplayName(ctx, userID, 1); got != "@new_name" {
+		t.Errorf("Expected display name %q, got %q", "@new_name", got)
+	}
+
+	// The user removes their username: full name from the latest poll answer is used
+	handler.RememberUser(ctx, &models.Update{
+		PollAnswer: &models.PollAnswer{User: &models.User{ID: userID, FirstName: "Ivan", LastName: "Petrov"}},
+	})
+	if got := handler.getUserDisplayName(ctx, userID, 1); got != "Ivan Petrov" {
+		t.Errorf("Expected display name %q, got %q", "Ivan Petrov", got)
+	}
+
+	// Users never seen by the bot still fall back to the rating
+	if got := handler.getUserDisplayName(ctx, 999, 1); got != "User id999" {
+		t.Errorf("Expected display name %q, got %q", "User id999", got)
+	}
+}
+
+func TestGetUserDisplayName_ASCIIMode(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := storage.NewDBQueue(db)
+	defer queue.Close()
+
+	if err := storage.InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := storage.RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ratingRepo := storage.NewRatingRepository(queue)
+	logger := &mockLogger{}
+	userRepo := storage.NewUserRepository(queue)
+	handler := &BotHandler{
+		ratingCalculator: domain.NewRatingCalculator(ratingRepo, storage.NewPredictionRepository(queue), storage.NewEventRepository(queue), nil, logger),
+		userRepo:         userRepo,
+		config:           &config.Config{},
+		logger:           logger,
+	}
+
+	ctx := context.Background()
+	if err := userRepo.UpsertUserProfile(ctx, 1, "", "Иван", "Петров"); err != nil {
+		t.Fatalf("Failed to create profile: %v", err)
+	}
+	if err := userRepo.UpsertUserProfile(ctx, 2, "", "🚀", ""); err != nil {
+		t.Fatalf("Failed to create profile: %v", err)
+	}
+
+	// Chat messages keep the original unicode by default
+	if got := handler.getUserDisplayName(ctx, 1, 1); got != "Иван Петров" {
+		t.Errorf("Expected display name %q, got %q", "Иван Петров", got)
+	}
+
+	handler.config.ASCIIDisplayNames = true
+	if got := handler.getUserDisplayName(ctx, 1, 1); got != "Ivan Petrov" {
+		t.Errorf("Expected display name %q, got %q", "Ivan Petrov", got)
+	}
+
+	// Names with nothing to transliterate fall back to the user ID
+	if got := handler.getUserDisplayName(ctx, 2, 1); got != "User id2" {
+		t.Errorf("Expected display name %q, got %q", "User id2", got)
+	}
+}
@@ -0,0 +1,333 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// duelCommand challenges another user to a private yes/no duel
+const duelCommand = "/duel"
+
+// Duel callback actions
+const (
+	duelActionPick    = "pick"    // challenger's answer: duel:pick:<id>:<0|1>
+	duelActionAccept  = "accept"  // opponent's answer: duel:accept:<id>:<0|1>
+	duelActionDecline = "decline" // duel:decline:<id>
+	duelActionReport  = "report"  // outcome reported after the deadline: duel:report:<id>:<0|1>
+)
+
+// HandleDuel handles the /duel command (/duel @username [duration] <question>).
+// Without arguments it shows the usage and the user's duel record.
+func (h *BotHandler) HandleDuel(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string, kb *models.InlineKeyboardMarkup) {
+		params := &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		}
+		if kb != nil {
+			params.ReplyMarkup = kb
+		}
+		if _, err := b.SendMessage(ctx, params); err != nil {
+			h.logger.Error("failed to send duel reply", "user_id", userID, "error", err)
+		}
+	}
+
+	username, duration, question, ok := parseDuelArgs(update.Message.Text)
+	if !ok {
+		text := h.requestLocalizer(ctx).MustLocalize(locale.DuelUsage)
+		if record, err := h.duelService.Record(ctx, userID); err != nil {
+			h.logger.Error("failed to get duel record", "user_id", userID, "error", err)
+		} else {
+			text += "\n\n" + h.duelRecordText(ctx, record)
+		}
+		reply(text, nil)
+		return
+	}
+
+	opponent, err := h.userRepo.GetUserProfileByUsername(ctx, username)
+	if err != nil {
+		h.logger.Error("failed to find duel opponent", "username", username, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.DuelError), nil)
+		return
+	}
+	if opponent == nil {
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelOpponentUnknown, "@"+username), nil)
+		return
+	}
+
+	duel, err := h.duelService.Challenge(ctx, userID, opponent.UserID, question, duration)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrDuelSelf):
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.DuelErrorSelf), nil)
+		return
+	case errors.Is(err, domain.ErrDuelInvalidQuestion):
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelErrorQuestion, strconv.Itoa(domain.MaxDuelQuestionLength)), nil)
+		return
+	case errors.Is(err, domain.ErrDuelInvalidDuration):
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelErrorDuration,
+			domain.FormatReminderOffsets([]time.Duration{domain.MinDuelDuration}),
+			domain.FormatReminderOffsets([]time.Duration{domain.MaxDuelDuration}),
+		), nil)
+		return
+	default:
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.DuelError), nil)
+		return
+	}
+
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelPickPrompt,
+		h.getUserDisplayName(ctx, opponent.UserID, 0), duel.Question, h.formatDuelDeadline(duel)),
+		h.duelAnswerKeyboard(ctx, duel.ID, duelActionPick, locale.DuelButtonYes, locale.DuelButtonNo))
+}
+
+// parseDuelArgs parses "/duel @username [duration] <question>". A duration is only recognized
+// as the first word after the username; without it the default duration is used.
+func parseDuelArgs(text string) (username string, duration time.Duration, question string, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != duelCommand && !strings.HasPrefix(command, duelCommand+"@") {
+		return "", 0, "", false
+	}
+
+	mention, rest, _ := strings.Cut(strings.TrimSpace(args), " ")
+	username = strings.TrimPrefix(mention, "@")
+	if !strings.HasPrefix(mention, "@") || username == "" {
+		return "", 0, "", false
+	}
+
+	duration = domain.DefaultDuelDuration
+	rest = strings.TrimSpace(rest)
+	if first, tail, _ := strings.Cut(rest, " "); tail != "" {
+		if parsed, err := domain.ParseDuelDuration(first); err == nil {
+			duration = parsed
+			rest = strings.TrimSpace(tail)
+		}
+	}
+
+	if rest == "" {
+		return "", 0, "", false
+	}
+	return username, duration, rest, true
+}
+
+// handleDuelCallback handles the duel buttons: the challenger's answer, the opponent's
+// acceptance or refusal and the outcome reports after the deadline
+func (h *BotHandler) handleDuelCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	action, err := cb.Field(0)
+	if err != nil {
+		h.logger.Error("invalid duel callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	fieldCount := 3
+	if action == duelActionDecline {
+		fieldCount = 2
+	}
+	if err := cb.Expect(cbDuel, fieldCount); err != nil {
+		h.logger.Error("invalid duel callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	duelID, err := cb.Int64(1)
+	if err != nil {
+		h.logger.Error("failed to parse duel ID", "error", err)
+		return
+	}
+	var answer bool
+	if fieldCount == 3 {
+		value, err := cb.Int(2)
+		if err != nil {
+			h.logger.Error("failed to parse duel answer", "error", err)
+			return
+		}
+		answer = value == 1
+	}
+
+	var duel *domain.Duel
+	switch action {
+	case duelActionPick:
+		duel, err = h.duelService.Pick(ctx, duelID, userID, answer)
+	case duelActionAccept:
+		duel, err = h.duelService.Accept(ctx, duelID, userID, answer)
+	case duelActionDecline:
+		duel, err = h.duelService.Decline(ctx, duelID, userID)
+	case duelActionReport:
+		duel, err = h.duelService.Report(ctx, duelID, userID, answer)
+	default:
+		h.logger.Error("unknown duel callback action", "data", cb.String())
+		return
+	}
+
+	if err != nil {
+		answerKey := locale.DuelError
+		switch {
+		case errors.Is(err, domain.ErrDuelNotFound), errors.Is(err, domain.ErrDuelClosed):
+			answerKey = locale.DuelClosed
+		case errors.Is(err, domain.ErrDuelNotParticipant):
+			answerKey = locale.DuelNotParticipant
+		case errors.Is(err, domain.ErrDuelTooEarly):
+			answerKey = locale.DuelReportTooEarly
+		}
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.localizeKey(ctx, answerKey),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	edit := func(text string, kb *models.InlineKeyboardMarkup) {
+		if callback.Message.Message == nil {
+			return
+		}
+		params := &bot.EditMessageTextParams{
+			ChatID:    callback.Message.Message.Chat.ID,
+			MessageID: callback.Message.Message.ID,
+			Text:      text,
+		}
+		if kb != nil {
+			params.ReplyMarkup = kb
+		}
+		if _, err := b.EditMessageText(ctx, params); err != nil {
+			h.logger.Error("failed to edit duel message", "duel_id", duel.ID, "error", err)
+		}
+	}
+	send := func(chatID int64, text string, kb *models.InlineKeyboardMarkup) error {
+		params := &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		}
+		if kb != nil {
+			params.ReplyMarkup = kb
+		}
+		_, err := b.SendMessage(ctx, params)
+		if err != nil {
+			h.logger.Warn("failed to send duel message", "duel_id", duel.ID, "user_id", chatID, "error", err)
+		}
+		return err
+	}
+
+	challengerName := h.getUserDisplayName(ctx, duel.ChallengerID, 0)
+	opponentName := h.getUserDisplayName(ctx, duel.OpponentID, 0)
+
+	switch action {
+	case duelActionPick:
+		edit(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelChallengeSent,
+			opponentName, duel.Question, h.duelAnswerLabel(ctx, *duel.ChallengerPick), h.formatDuelDeadline(duel)), nil)
+
+		opponentCtx := h.contextForChat(ctx, duel.OpponentID)
+		invitation := h.requestLocalizer(opponentCtx).MustLocalizeWithTemplate(locale.DuelInvitation,
+			challengerName, duel.Question, h.duelAnswerLabel(opponentCtx, *duel.ChallengerPick), h.formatDuelDeadline(duel))
+		kb := h.duelAnswerKeyboard(opponentCtx, duel.ID, duelActionAccept, locale.DuelButtonAcceptYes, locale.DuelButtonAcceptNo)
+		kb.InlineKeyboard = append(kb.InlineKeyboard, []models.InlineKeyboardButton{
+			{Text: h.requestLocalizer(opponentCtx).MustLocalize(locale.DuelButtonDecline), CallbackData: mustEncodeCallback(cbDuel, duelActionDecline, duel.ID)},
+		})
+		if err := send(duel.OpponentID, invitation, kb); err != nil {
+			_ = send(duel.ChallengerID, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelInvitationFailed, opponentName), nil)
+		}
+
+	case duelActionAccept:
+		accepted := func(ctx context.Context) (string, *models.InlineKeyboardMarkup) {
+			text := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelAccepted,
+				duel.Question,
+				challengerName, h.duelAnswerLabel(ctx, *duel.ChallengerPick),
+				opponentName, h.duelAnswerLabel(ctx, *duel.OpponentPick),
+				h.formatDuelDeadline(duel),
+			)
+			return text, h.duelAnswerKeyboard(ctx, duel.ID, duelActionReport, locale.DuelButtonReportYes, locale.DuelButtonReportNo)
+		}
+		edit(accepted(ctx))
+		text, kb := accepted(h.contextForChat(ctx, duel.ChallengerID))
+		_ = send(duel.ChallengerID, text, kb)
+
+	case duelActionDecline:
+		edit(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelDeclined, duel.Question), nil)
+		_ = send(duel.ChallengerID, h.chatLocalizer(ctx, duel.ChallengerID).MustLocalizeWithTemplate(locale.DuelDeclinedNotice, opponentName, duel.Question), nil)
+
+	case duelActionReport:
+		if duel.Status == domain.DuelStatusActive {
+			otherName := opponentName
+			if userID == duel.OpponentID {
+				otherName = challengerName
+			}
+			edit(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelReportWaiting, h.duelAnswerLabel(ctx, answer), otherName, duel.Question), nil)
+			return
+		}
+
+		edit(h.duelResultText(ctx, duel, userID), nil)
+		otherID := duel.OtherParticipant(userID)
+		_ = send(otherID, h.duelResultText(h.contextForChat(ctx, otherID), duel, otherID), nil)
+	}
+}
+
+// duelAnswerKeyboard builds a yes/no keyboard for a duel action
+func (h *BotHandler) duelAnswerKeyboard(ctx context.Context, duelID int64, action string, yesKey, noKey string) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: h.localizeKey(ctx, yesKey), CallbackData: mustEncodeCallback(cbDuel, action, duelID, 1)},
+				{Text: h.localizeKey(ctx, noKey), CallbackData: mustEncodeCallback(cbDuel, action, duelID, 0)},
+			},
+		},
+	}
+}
+
+// duelResultText describes the result of a finished duel from the user's point of view,
+// followed by the user's duel record
+func (h *BotHandler) duelResultText(ctx context.Context, duel *domain.Duel, userID int64) string {
+	var text string
+	switch {
+	case duel.Status == domain.DuelStatusDisputed || duel.Outcome == nil:
+		text = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelResultDisputed, duel.Question)
+	case duel.Winner() == 0:
+		text = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelResultDraw, duel.Question, h.duelAnswerLabel(ctx, *duel.Outcome))
+	case duel.Winner() == userID:
+		text = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelResultWin, duel.Question, h.duelAnswerLabel(ctx, *duel.Outcome))
+	default:
+		text = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelResultLoss, duel.Question, h.duelAnswerLabel(ctx, *duel.Outcome))
+	}
+
+	record, err := h.duelService.Record(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get duel record", "user_id", userID, "error", err)
+		return text
+	}
+	return text + "\n\n" + h.duelRecordText(ctx, record)
+}
+
+// duelRecordText formats a user's duel record
+func (h *BotHandler) duelRecordText(ctx context.Context, record *domain.DuelRecord) string {
+	return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.DuelRecord,
+		strconv.Itoa(record.Wins), strconv.Itoa(record.Losses), strconv.Itoa(record.Draws))
+}
+
+// duelAnswerLabel returns the localized yes/no label of an answer
+func (h *BotHandler) duelAnswerLabel(ctx context.Context, answer bool) string {
+	if answer {
+		return h.requestLocalizer(ctx).MustLocalize(locale.EventOptionYes)
+	}
+	return h.requestLocalizer(ctx).MustLocalize(locale.EventOptionNo)
+}
+
+// formatDuelDeadline formats the duel deadline in the configured timezone
+func (h *BotHandler) formatDuelDeadline(duel *domain.Duel) string {
+	return duel.Deadline.In(h.config.Timezone).Format("02.01.2006 15:04")
+}
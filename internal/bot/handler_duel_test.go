@@ -0,0 +1,183 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+	_ "modernc.org/sqlite"
+)
+
+func TestParseDuelArgs(t *testing.T) {
+	tests := []struct {
+		text     string
+		username string
+		duration time.Duration
+		question string
+		ok       bool
+	}{
+		{"/duel @bob Will it rain?", "bob", domain.DefaultDuelDuration, "Will it rain?", true},
+		{"/duel@PredictionBot  @bob 3d  Will it rain? ", "bob", 72 * time.Hour, "Will it rain?", true},
+		{"/duel @bob 2h", "bob", domain.DefaultDuelDuration, "2h", true},
+		{"/duel @bob 10 goals?", "bob", domain.DefaultDuelDuration, "10 goals?", true},
+		{"/duel", "", 0, "", false},
+		{"/duel bob Will it rain?", "", 0, "", false},
+		{"/duel @bob", "", 0, "", false},
+		{"/duel @ Will it rain?", "", 0, "", false},
+		{"/duelx @bob Will it rain?", "", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		username, duration, question, ok := parseDuelArgs(tt.text)
+		if ok != tt.ok || username != tt.username || duration != tt.duration || question != tt.question {
+			t.Errorf("parseDuelArgs(%q) = %q, %v, %q, %t; want %q, %v, %q, %t",
+				tt.text, username, duration, question, ok, tt.username, tt.duration, tt.question, tt.ok)
+		}
+	}
+}
+
+func TestHandleDuel_Flow(t *testing.T) {
+	ctx := context.Background()
+	aliceID := int64(1)
+	bobID := int64(2)
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := storage.NewDBQueue(db)
+	defer queue.Close()
+
+	if err := storage.InitSchema(queue); err != nil {
+		t.Fatalf("failed to initialize schema: %v", err)
+	}
+	if err := storage.RunMigrations(queue); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	userRepo := storage.NewUserRepository(queue)
+	if err := userRepo.UpsertUserProfile(ctx, aliceID, "alice", "Alice", ""); err != nil {
+		t.Fatalf("failed to save profile: %v", err)
+	}
+	if err := userRepo.UpsertUserProfile(ctx, bobID, "bob", "Bob", ""); err != nil {
+		t.Fatalf("failed to save profile: %v", err)
+	}
+
+	log := logger.New(logger.ERROR)
+	rec, b := newRecordingTelegramServer(t)
+	notificationService := domain.NewNotificationService(b, nil, nil, nil, nil, log, localizer)
+
+	h := &BotHandler{
+		config:      &config.Config{Timezone: time.UTC},
+		userRepo:    userRepo,
+		logger:      log,
+		duelService: domain.NewDuelService(storage.NewDuelRepository(queue), notificationService, log),
+		localizer:   localizer,
+	}
+	command := func(userID int64, text string) string {
+		t.Helper()
+		h.HandleDuel(ctx, b, &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: userID},
+				Chat: models.Chat{ID: userID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		})
+		texts := rec.texts()
+		return texts[len(texts)-1]
+	}
+	press := func(userID int64, data string) {
+		t.Helper()
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		h.handleDuelCallback(ctx, b, &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+			},
+		}, userID, cb)
+	}
+
+	if text := command(aliceID, "/duel @carol Will it rain?"); text != localizer.MustLocalizeWithTemplate(locale.DuelOpponentUnknown, "@carol") {
+		t.Errorf("expected unknown opponent, got %q", text)
+	}
+	if text := command(aliceID, "/duel @alice Will it rain?"); text != localizer.MustLocalize(locale.DuelErrorSelf) {
+		t.Errorf("expected self challenge error, got %q", text)
+	}
+
+	deadline := time.Now().Add(2 * time.Hour).UTC().Format("02.01.2006 15:04")
+	text := command(aliceID, "/duel @Bob 2h Will it rain?")
+	if expected := localizer.MustLocalizeWithTemplate(locale.DuelPickPrompt, "@bob", "Will it rain?", deadline); text != expected {
+		t.Fatalf("expected pick prompt %q, got %q", expected, text)
+	}
+
+	// Bob can't answer for Alice
+	sent := len(rec.texts())
+	press(bobID, mustEncodeCallback(cbDuel, duelActionPick, 1, 1))
+	if len(rec.texts()) != sent {
+		t.Fatalf("expected no messages for a foreign pick, got %q", rec.texts()[sent:])
+	}
+
+	// Alice's pick invites Bob
+	press(aliceID, mustEncodeCallback(cbDuel, duelActionPick, 1, 1))
+	texts := rec.texts()
+	if expected := localizer.MustLocalizeWithTemplate(locale.DuelInvitation, "@alice", "Will it rain?", "Yes", deadline); texts[len(texts)-1] != expected {
+		t.Fatalf("expected invitation %q, got %q", expected, texts[len(texts)-1])
+	}
+	if markup := rec.markups[len(rec.markups)-1]; !strings.Contains(markup, mustEncodeCallback(cbDuel, duelActionDecline, 1)) {
+		t.Errorf("expected a decline button, got %s", markup)
+	}
+
+	// Bob accepts with the opposite answer, Alice is told the duel is on
+	press(bobID, mustEncodeCallback(cbDuel, duelActionAccept, 1, 0))
+	texts = rec.texts()
+	if expected := localizer.MustLocalizeWithTemplate(locale.DuelAccepted, "Will it rain?", "@alice", "Yes", "@bob", "No", deadline); texts[len(texts)-1] != expected {
+		t.Fatalf("expected accepted notice %q, got %q", expected, texts[len(texts)-1])
+	}
+
+	// Reports are accepted after the deadline
+	if _, err := db.Exec(`UPDATE duels SET deadline = ? WHERE id = 1`, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("failed to move the deadline: %v", err)
+	}
+	sent = len(rec.texts())
+	press(aliceID, mustEncodeCallback(cbDuel, duelActionReport, 1, 0))
+	if len(rec.texts()) != sent {
+		t.Fatalf("expected no messages after the first report, got %q", rec.texts()[sent:])
+	}
+
+	// The second matching report resolves the duel and tells Alice she lost
+	press(bobID, mustEncodeCallback(cbDuel, duelActionReport, 1, 0))
+	texts = rec.texts()
+	expected := localizer.MustLocalizeWithTemplate(locale.DuelResultLoss, "Will it rain?", "No") + "\n\n" +
+		localizer.MustLocalizeWithTemplate(locale.DuelRecord, "0", "1", "0")
+	if texts[len(texts)-1] != expected {
+		t.Fatalf("expected result %q, got %q", expected, texts[len(texts)-1])
+	}
+
+	// The record is shown with the usage
+	expected = localizer.MustLocalize(locale.DuelUsage) + "\n\n" + localizer.MustLocalizeWithTemplate(locale.DuelRecord, "1", "0", "0")
+	if text := command(bobID, "/duel"); text != expected {
+		t.Errorf("expected usage with record %q, got %q", expected, text)
+	}
+}
@@ -0,0 +1,263 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handleApproveEventCallback publishes the poll of an event waiting for approval and makes the event active
+func (h *BotHandler) handleApproveEventCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	answer := func(text string) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            text,
+			ShowAlert:       true,
+		})
+	}
+
+	if !h.isAdmin(userID) {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized))
+		return
+	}
+
+	// Parse event ID from callback data: approve_event:EVENT_ID
+	if err := cb.Expect(cbApproveEvent, 1); err != nil {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidDataFormat))
+		return
+	}
+	eventID, err := cb.Int64(0)
+	if err != nil {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidEventID))
+		return
+	}
+
+	// Every admin gets the request; only one of them may publish the poll
+	if _, running := h.approvalsRunning.LoadOrStore(eventID, struct{}{}); running {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalInProgress))
+		return
+	}
+	defer h.approvalsRunning.Delete(eventID)
+
+	event, err := h.eventManager.GetEvent(ctx, eventID)
+	if err != nil {
+		h.logger.Error("failed to get event for approval", "event_id", eventID, "error", err)
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalError))
+		return
+	}
+	if event.Status != domain.EventStatusPendingApproval {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalAlreadyReviewed))
+		h.clearApprovalButtons(ctx, b, callback)
+		return
+	}
+	if !event.Deadline.After(time.Now()) {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalDeadlinePassed))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil || group == nil {
+		h.logger.Error("failed to get group for approval", "event_id", eventID, "group_id", event.GroupID, "error", err)
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorGroupInfo))
+		return
+	}
+
+	// Post the poll to the topic chosen when the event was created
+	var messageThreadID *int
+	if event.ForumTopicID != nil {
+		topic, err := h.forumTopicRepo.GetForumTopic(ctx, *event.ForumTopicID)
+		if err != nil {
+			h.logger.Error("failed to get forum topic for approval", "event_id", eventID, "topic_id", *event.ForumTopicID, "error", err)
+		} else if topic != nil {
+			messageThreadID = &topic.MessageThreadID
+		}
+	}
+
+	if err := publishEventPoll(ctx, b, h.logger, h.chatLocalizer(ctx, group.TelegramChatID), group, event, messageThreadID); err != nil {
+		h.logger.Error("failed to send poll of approved event", "event_id", eventID, "telegram_chat_id", group.TelegramChatID, "error", err)
+		if isPollPermissionError(err) {
+			answer(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventCreationErrorPollPermission, group.Name))
+		} else {
+			answer(h.requestLocalizer(ctx).MustLocalize(locale.EventCreationErrorPollPublish))
+		}
+		return
+	}
+
+	if err := h.eventManager.ApproveEvent(ctx, event); err != nil {
+		// Roll back: remove the published poll so it doesn't collect votes for an event that isn't active
+		unpublishEventPoll(ctx, b, h.logger, group, event)
+		if errors.Is(err, domain.ErrEventNotPending) {
+			answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalAlreadyReviewed))
+			h.clearApprovalButtons(ctx, b, callback)
+		} else {
+			answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalError))
+		}
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	h.editApprovalMessage(ctx, b, callback, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventApprovalApproved,
+		truncateHTML(group.Name, htmlNameMaxLength), escapeHTML(event.Question)))
+
+	// Tell the creator and hand over the management buttons
+	creatorLocalizer := h.chatLocalizer(ctx, event.CreatedBy)
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      event.CreatedBy,
+		Text:        creatorLocalizer.MustLocalizeWithTemplate(locale.EventApprovalApprovedCreator, group.Name, event.Question),
+		ReplyMarkup: eventActionsKeyboard(creatorLocalizer, event.ID),
+	})
+	if err != nil {
+		h.logger.Error("failed to notify creator about approval", "event_id", eventID, "user_id", event.CreatedBy, "error", err)
+	}
+
+	// Reminders, subscribers and creator achievements follow the publication like for any new event
+	if h.eventCreationFSM != nil {
+		h.eventCreationFSM.announcePublishedEvent(ctx, event, group)
+		h.eventCreationFSM.awardCreatorAchievements(ctx, event.CreatedBy, event.GroupID)
+	}
+
+	h.logAdminAction(userID, "approve_event", eventID, fmt.Sprintf("Approved event in group %s", group.Name))
+}
+
+// handleRejectEventCallback asks for a rejection reason, then cancels an event waiting for approval
+// and tells the creator why
+func (h *BotHandler) handleRejectEventCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	answer := func(text string) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            text,
+			ShowAlert:       true,
+		})
+	}
+
+	if !h.isAdmin(userID) {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized))
+		return
+	}
+
+	// Callback data: reject_event:EVENT_ID (choose a reason) or reject_event:EVENT_ID:REASON
+	if cb.Expect(cbRejectEvent, 1) != nil && cb.Expect(cbRejectEvent, 2) != nil {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidDataFormat))
+		return
+	}
+	eventID, err := cb.Int64(0)
+	if err != nil {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidEventID))
+		return
+	}
+
+	code, _ := cb.Field(1)
+	if code == "" || code == rejectReasonBack {
+		kb := approvalKeyboard(h.requestLocalizer(ctx), eventID)
+		answerText := ""
+		if code == "" {
+			kb = rejectReasonsKeyboard(h.requestLocalizer(ctx), eventID)
+			answerText = h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalChooseReason)
+		}
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            answerText,
+		})
+		if callback.Message.Message != nil {
+			_, _ = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+				ChatID:      callback.Message.Message.Chat.ID,
+				MessageID:   callback.Message.Message.ID,
+				ReplyMarkup: kb,
+			})
+		}
+		return
+	}
+
+	reason, ok := findRejectReason(code)
+	if !ok {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.ErrorInvalidDataFormat))
+		return
+	}
+
+	if _, running := h.approvalsRunning.LoadOrStore(eventID, struct{}{}); running {
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalInProgress))
+		return
+	}
+	defer h.approvalsRunning.Delete(eventID)
+
+	event, err := h.eventManager.GetEvent(ctx, eventID)
+	if err != nil {
+		h.logger.Error("failed to get event for rejection", "event_id", eventID, "error", err)
+		answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalError))
+		return
+	}
+
+	if err := h.eventManager.RejectEvent(ctx, eventID); err != nil {
+		if errors.Is(err, domain.ErrEventNotPending) {
+			answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalAlreadyReviewed))
+			h.clearApprovalButtons(ctx, b, callback)
+		} else {
+			answer(h.requestLocalizer(ctx).MustLocalize(locale.EventApprovalError))
+		}
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	groupName := fmt.Sprintf("group %d", event.GroupID)
+	if group, err := h.groupRepo.GetGroup(ctx, event.GroupID); err == nil && group != nil {
+		groupName = group.Name
+	}
+
+	h.editApprovalMessage(ctx, b, callback, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventApprovalRejected,
+		truncateHTML(groupName, htmlNameMaxLength), escapeHTML(event.Question), escapeHTML(h.localizeKey(ctx, reason.key))))
+
+	creatorLocalizer := h.chatLocalizer(ctx, event.CreatedBy)
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: event.CreatedBy,
+		Text: creatorLocalizer.MustLocalizeWithTemplate(locale.EventApprovalRejectedCreator, groupName, event.Question,
+			localizeDynamic(creatorLocalizer, h.logger, reason.key)),
+	})
+	if err != nil {
+		h.logger.Error("failed to notify creator about rejection", "event_id", eventID, "user_id", event.CreatedBy, "error", err)
+	}
+
+	h.logAdminAction(userID, "reject_event", eventID, fmt.Sprintf("Rejected event in group %s: %s", groupName, reason.code))
+}
+
+// editApprovalMessage replaces an approval request with the outcome of the review (HTML text)
+func (h *BotHandler) editApprovalMessage(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, text string) {
+	if callback.Message.Message == nil {
+		return
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    callback.Message.Message.Chat.ID,
+		MessageID: callback.Message.Message.ID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+	if err != nil {
+		h.logger.Error("failed to edit approval request", "message_id", callback.Message.Message.ID, "error", err)
+	}
+}
+
+// clearApprovalButtons removes the buttons of an approval request that another admin already reviewed
+func (h *BotHandler) clearApprovalButtons(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	if callback.Message.Message == nil {
+		return
+	}
+
+	_, _ = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
+	})
+}
@@ -0,0 +1,230 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	tgbot "github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const approvalAdminID = int64(1)
+
+// approvalTestEnv wires the event creation FSM and the handler to one database with the approval queue enabled
+type approvalTestEnv struct {
+	h         *BotHandler
+	fsm       *EventCreationFSM
+	eventRepo *storage.EventRepository
+	fsmStore  *storage.FSMStorage
+	localizer locale.Localizer
+	groupID   int64
+}
+
+func newApprovalTestEnv(t *testing.T, b *tgbot.Bot) *approvalTestEnv {
+	ctx := context.Background()
+	queue, groupID := setupTestGroupAndDB(t, -100500, approvalAdminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	groupRepo := storage.NewGroupRepository(queue)
+	if err := groupRepo.UpdateGroupRequireApproval(ctx, groupID, true); err != nil {
+		t.Fatalf("failed to enable approval: %v", err)
+	}
+
+	cfg := &config.Config{AdminUserIDs: []int64{approvalAdminID}, Timezone: time.UTC}
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	fsmStore := storage.NewFSMStorage(queue, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
+
+	fsm := NewEventCreationFSM(
+		fsmStore,
+		b,
+		eventManager,
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		nil,
+		groupRepo,
+		storage.NewForumTopicRepository(queue),
+		ratingRepo,
+		storage.NewGroupMembershipRepository(queue),
+		storage.NewUserRepository(queue),
+		nil,
+		cfg,
+		log,
+		localizer,
+	)
+
+	h := &BotHandler{
+		bot:              b,
+		config:           cfg,
+		eventManager:     eventManager,
+		eventCreationFSM: fsm,
+		groupRepo:        groupRepo,
+		forumTopicRepo:   storage.NewForumTopicRepository(queue),
+		logger:           log,
+		localizer:        localizer,
+	}
+
+	return &approvalTestEnv{h: h, fsm: fsm, eventRepo: eventRepo, fsmStore: fsmStore, localizer: localizer, groupID: groupID}
+}
+
+// confirm runs the confirmation step of the event creation FSM for the user
+func (e *approvalTestEnv) confirm(t *testing.T, userID int64) {
+	t.Helper()
+	ctx := context.Background()
+
+	sessionContext := &domain.EventCreationContext{
+		ChatID:    userID,
+		GroupID:   e.groupID,
+		Question:  "Will it rain tomorrow?",
+		EventType: domain.EventTypeBinary,
+		Options:   []string{"Yes", "No"},
+		Deadline:  time.Now().Add(48 * time.Hour),
+	}
+	if err := e.fsmStore.Set(ctx, userID, StateConfirm, sessionContext.ToMap()); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	err := e.fsm.HandleCallback(ctx, &models.CallbackQuery{
+		ID:   "confirm",
+		From: models.User{ID: userID},
+		Data: mustEncodeCallback(cbConfirm, "yes"),
+		Message: models.MaybeInaccessibleMessage{
+			Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to confirm event: %v", err)
+	}
+}
+
+// review sends an approval or rejection callback of the user
+func (e *approvalTestEnv) review(userID int64, data string) {
+	e.h.HandleCallback(context.Background(), e.h.bot, &models.Update{CallbackQuery: &models.CallbackQuery{
+		ID:   "review",
+		From: models.User{ID: userID},
+		Data: data,
+		Message: models.MaybeInaccessibleMessage{
+			Type:    models.MaybeInaccessibleMessageTypeMessage,
+			Message: &models.Message{ID: 20, Chat: models.Chat{ID: userID}},
+		},
+	}})
+}
+
+// onlyEvent returns the single event of the test group
+func (e *approvalTestEnv) onlyEvent(t *testing.T) *domain.Event {
+	t.Helper()
+	events, err := e.eventRepo.GetEventsByDeadlineRange(context.Background(), time.Now(), time.Now().Add(72*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected one event, got %d", len(events))
+	}
+	return events[0]
+}
+
+func TestEventApproval_ApprovePublishesPoll(t *testing.T) {
+	rec, b := newPollTelegramServer(t, nil)
+	env := newApprovalTestEnv(t, b)
+	creatorID := int64(200)
+
+	// A member's event waits for approval, no poll is posted yet
+	env.confirm(t, creatorID)
+	event := env.onlyEvent(t)
+	if event.Status != domain.EventStatusPendingApproval || event.PollID != "" {
+		t.Fatalf("expected a pending event without a poll, got status %s and poll %q", event.Status, event.PollID)
+	}
+	if rec.pollSent != 0 {
+		t.Fatalf("expected no poll before approval, got %d", rec.pollSent)
+	}
+	texts := rec.texts()
+	if len(texts) != 2 {
+		t.Fatalf("expected an approval request and a confirmation, got %v", texts)
+	}
+	if want := env.localizer.MustLocalizeWithTemplate(locale.EventApprovalSubmitted, "Will it rain tomorrow?", "Test Group"); texts[1] != want {
+		t.Errorf("expected confirmation %q, got %q", want, texts[1])
+	}
+
+	// Only admins can approve
+	env.review(creatorID, mustEncodeCallback(cbApproveEvent, event.ID))
+	if rec.pollSent != 0 {
+		t.Fatal("expected a member not to approve the event")
+	}
+
+	env.review(approvalAdminID, mustEncodeCallback(cbApproveEvent, event.ID))
+	event = env.onlyEvent(t)
+	if event.Status != domain.EventStatusActive || event.PollID != "poll_900" || event.PollMessageID != 900 {
+		t.Fatalf("expected an active event with the posted poll, got status %s, poll %q, message %d", event.Status, event.PollID, event.PollMessageID)
+	}
+	if rec.pollSent != 1 {
+		t.Errorf("expected one poll after approval, got %d", rec.pollSent)
+	}
+	// The creator is told first, then earns the organizer achievement like for any published event
+	texts = rec.texts()
+	if want := env.localizer.MustLocalizeWithTemplate(locale.EventApprovalApprovedCreator, "Test Group", "Will it rain tomorrow?"); len(texts) < 3 || texts[2] != want {
+		t.Errorf("expected creator notification %q, got %v", want, texts)
+	}
+
+	// A second review changes nothing
+	env.review(approvalAdminID, mustEncodeCallback(cbApproveEvent, event.ID))
+	env.review(approvalAdminID, mustEncodeCallback(cbRejectEvent, event.ID, "duplicate"))
+	if rec.pollSent != 1 || env.onlyEvent(t).Status != domain.EventStatusActive {
+		t.Error("expected a reviewed event to stay published once")
+	}
+}
+
+func TestEventApproval_RejectNotifiesCreator(t *testing.T) {
+	rec, b := newPollTelegramServer(t, nil)
+	env := newApprovalTestEnv(t, b)
+	creatorID := int64(200)
+
+	env.confirm(t, creatorID)
+	event := env.onlyEvent(t)
+
+	// Tapping Reject only asks for a reason
+	env.review(approvalAdminID, mustEncodeCallback(cbRejectEvent, event.ID))
+	if env.onlyEvent(t).Status != domain.EventStatusPendingApproval {
+		t.Fatal("expected the event to wait until a reason is chosen")
+	}
+
+	env.review(approvalAdminID, mustEncodeCallback(cbRejectEvent, event.ID, "unclear"))
+	if status := env.onlyEvent(t).Status; status != domain.EventStatusCancelled {
+		t.Fatalf("expected a cancelled event, got %s", status)
+	}
+	if rec.pollSent != 0 {
+		t.Errorf("expected no poll for a rejected event, got %d", rec.pollSent)
+	}
+
+	reason := env.localizer.MustLocalize(locale.EventRejectReasonUnclear)
+	want := env.localizer.MustLocalizeWithTemplate(locale.EventApprovalRejectedCreator, "Test Group", "Will it rain tomorrow?", reason)
+	if texts := rec.texts(); texts[len(texts)-1] != want {
+		t.Errorf("expected creator notification %q, got %q", want, texts[len(texts)-1])
+	}
+}
+
+func TestEventApproval_AdminEventsSkipQueue(t *testing.T) {
+	rec, b := newPollTelegramServer(t, nil)
+	env := newApprovalTestEnv(t, b)
+
+	env.confirm(t, approvalAdminID)
+	if event := env.onlyEvent(t); event.Status != domain.EventStatusActive || event.PollID == "" {
+		t.Errorf("expected an admin's event to be published right away, got status %s", event.Status)
+	}
+	if rec.pollSent != 1 {
+		t.Errorf("expected one poll, got %d", rec.pollSent)
+	}
+}
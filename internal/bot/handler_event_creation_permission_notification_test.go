@@ -52,8 +52,8 @@ func TestEventCreationPermissionNotification(t *testing.T) {
 	ratingRepo := storage.NewRatingRepository(queue)
 
 	// Create services
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
-	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
+	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log)
 
 	// Create config with min events = 3
 	cfg := &config.Config{
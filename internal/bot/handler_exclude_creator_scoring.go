@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleExcludeCreatorScoring handles the /exclude_creator_scoring command (toggle whether creators score their own events per group)
+func (h *BotHandler) HandleExcludeCreatorScoring(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	kb, err := h.buildExcludeCreatorScoringKeyboard(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	if kb == nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.ExcludeCreatorScoringTitle),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to send creator scoring settings", "error", err)
+	}
+}
+
+// buildExcludeCreatorScoringKeyboard builds toggle buttons for all active groups.
+// Returns nil keyboard if there are no active groups.
+func (h *BotHandler) buildExcludeCreatorScoringKeyboard(ctx context.Context) (*models.InlineKeyboardMarkup, error) {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+
+		state := " ❌"
+		if group.ExcludeCreatorScoring {
+			state = " ✅"
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         "🙈 " + group.Name + state,
+				CallbackData: mustEncodeCallback(cbExcludeCreatorScoringToggle, group.ID),
+			},
+		})
+	}
+
+	if len(buttons) == 0 {
+		return nil, nil
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, nil
+}
+
+// handleExcludeCreatorScoringCallback toggles whether creators score their own events in the selected group
+func (h *BotHandler) handleExcludeCreatorScoringCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	if err := cb.Expect(cbExcludeCreatorScoringToggle, 1); err != nil {
+		h.logger.Error("invalid exclude_creator_scoring callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status == domain.GroupStatusDeleted {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	exclude := !group.ExcludeCreatorScoring
+	if err := h.groupRepo.UpdateGroupExcludeCreatorScoring(ctx, groupID, exclude); err != nil {
+		h.logger.Error("failed to update creator scoring setting", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ExcludeCreatorScoringErrorUpdate),
+		})
+		return
+	}
+
+	answerKey := locale.ExcludeCreatorScoringDisabled
+	if exclude {
+		answerKey = locale.ExcludeCreatorScoringEnabled
+	}
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(answerKey, group.Name),
+	})
+
+	// Update keyboard with new toggle states
+	if callback.Message.Message != nil {
+		kb, err := h.buildExcludeCreatorScoringKeyboard(ctx)
+		if err != nil {
+			h.logger.Error("failed to rebuild creator scoring keyboard", "error", err)
+		} else if kb != nil {
+			_, _ = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+				ChatID:      callback.Message.Message.Chat.ID,
+				MessageID:   callback.Message.Message.ID,
+				ReplyMarkup: kb,
+			})
+		}
+	}
+
+	h.logAdminAction(userID, "toggle_exclude_creator_scoring", groupID, fmt.Sprintf("Set exclude creator scoring to %t for group %s", exclude, group.Name))
+}
@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestExcludeCreatorScoring(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	creatorID := int64(200)
+	voterID := int64(300)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	for _, userID := range []int64{creatorID, voterID} {
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	event := &domain.Event{
+		GroupID:   groupID,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  time.Now().Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: creatorID,
+		PollID:    "poll-1",
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log)
+	ratingCalculator.SetGroupRepository(groupRepo)
+
+	_, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:           groupRepo,
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      predictionRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    ratingCalculator,
+		logger:              log,
+		localizer:           localizer,
+	}
+
+	data := mustEncodeCallback(cbExcludeCreatorScoringToggle, groupID)
+	cb, err := DecodeCallback(data)
+	if err != nil {
+		t.Fatalf("failed to decode callback: %v", err)
+	}
+	h.handleExcludeCreatorScoringCallback(ctx, b, &models.CallbackQuery{
+		ID:      "cb",
+		From:    models.User{ID: adminID},
+		Data:    data,
+		Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}}},
+	}, adminID, cb)
+	if group, _ := groupRepo.GetGroup(ctx, groupID); !group.ExcludeCreatorScoring {
+		t.Fatal("expected creators to be excluded from scoring")
+	}
+
+	for _, userID := range []int64{creatorID, voterID} {
+		h.HandlePollAnswer(ctx, b, &models.Update{
+			PollAnswer: &models.PollAnswer{PollID: "poll-1", User: &models.User{ID: userID}, OptionIDs: []int{0}},
+		})
+	}
+
+	// The creator's vote is recorded and shown like any other
+	prediction, err := predictionRepo.GetPredictionByUserAndEvent(ctx, creatorID, event.ID)
+	if err != nil || prediction == nil {
+		t.Fatalf("expected the creator's vote to be recorded, got %v (err %v)", prediction, err)
+	}
+
+	if err := ratingCalculator.CalculateScores(ctx, event.ID, 0); err != nil {
+		t.Fatalf("failed to calculate scores: %v", err)
+	}
+
+	creatorRating, err := ratingRepo.GetRating(ctx, creatorID, groupID)
+	if err != nil {
+		t.Fatalf("failed to get rating: %v", err)
+	}
+	if creatorRating.Score != 0 || creatorRating.CorrectCount != 0 {
+		t.Errorf("expected the creator to earn nothing on their own event, got %+v", creatorRating)
+	}
+
+	voterRating, err := ratingRepo.GetRating(ctx, voterID, groupID)
+	if err != nil {
+		t.Fatalf("failed to get rating: %v", err)
+	}
+	if voterRating.Score <= 0 || voterRating.CorrectCount != 1 {
+		t.Errorf("expected the other voter to be scored, got %+v", voterRating)
+	}
+}
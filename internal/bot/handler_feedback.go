@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// feedbackListLimit is the number of entries shown by /feedback_list
+	feedbackListLimit = 10
+	// feedbackListPreviewLength limits each entry's text in /feedback_list to keep the message under Telegram's limit
+	feedbackListPreviewLength = 300
+)
+
+// HandleFeedback handles the /feedback command (/feedback <text>)
+func (h *BotHandler) HandleFeedback(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	user := update.Message.From
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send feedback reply", "user_id", user.ID, "error", err)
+		}
+	}
+
+	text, ok := parseFeedbackText(update.Message.Text)
+	if !ok {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.FeedbackUsage))
+		return
+	}
+
+	profile := &domain.UserProfile{UserID: user.ID, Username: user.Username, FirstName: user.FirstName, LastName: user.LastName}
+	_, err := h.feedbackService.SubmitFeedback(ctx, user.ID, profile.DisplayName(), text)
+	switch {
+	case err == nil:
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.FeedbackReceived))
+	case errors.Is(err, domain.ErrFeedbackEmpty):
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.FeedbackUsage))
+	case errors.Is(err, domain.ErrFeedbackTooLong):
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.FeedbackTooLong, fmt.Sprintf("%d", domain.MaxFeedbackLength)))
+	case errors.Is(err, domain.ErrFeedbackRateLimited):
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.FeedbackRateLimited))
+	default:
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.FeedbackError))
+	}
+}
+
+// parseFeedbackText extracts the text after /feedback (or /feedback@botname)
+func parseFeedbackText(message string) (string, bool) {
+	command, text, _ := strings.Cut(strings.TrimSpace(message), " ")
+	if command != "/feedback" && !strings.HasPrefix(command, "/feedback@") {
+		return "", false
+	}
+
+	text = strings.TrimSpace(text)
+	return text, text != ""
+}
+
+// HandleFeedbackList handles the /feedback_list command (shows recent feedback)
+func (h *BotHandler) HandleFeedbackList(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+
+	feedbacks, err := h.feedbackService.RecentFeedback(ctx, feedbackListLimit)
+	if err != nil {
+		h.logger.Error("failed to get recent feedback", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.FeedbackListError),
+		})
+		return
+	}
+
+	if len(feedbacks) == 0 {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.FeedbackListEmpty),
+		})
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.FeedbackListTitle) + "\n")
+	for _, feedback := range feedbacks {
+		author := feedback.DisplayName
+		if author == "" {
+			author = fmt.Sprintf("User id%d", feedback.UserID)
+		}
+		sb.WriteString("\n" + h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.FeedbackListItem,
+			fmt.Sprintf("%d", feedback.ID),
+			feedback.CreatedAt.In(h.config.Timezone).Format("02.01.2006 15:04"),
+			author,
+			fmt.Sprintf("%d", feedback.UserID),
+			feedbackPreview(feedback.Text),
+		) + "\n")
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   strings.TrimRight(sb.String(), "\n"),
+	})
+	if err != nil {
+		h.logger.Error("failed to send feedback list", "error", err)
+	}
+}
+
+// feedbackPreview shortens feedback text to feedbackListPreviewLength characters
+func feedbackPreview(text string) string {
+	runes := []rune(text)
+	if len(runes) <= feedbackListPreviewLength {
+		return text
+	}
+	return string(runes[:feedbackListPreviewLength]) + "…"
+}
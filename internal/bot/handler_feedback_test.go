@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+	_ "modernc.org/sqlite"
+)
+
+func TestParseFeedbackText(t *testing.T) {
+	tests := []struct {
+		message string
+		text    string
+		ok      bool
+	}{
+		{"/feedback the button is broken", "the button is broken", true},
+		{"/feedback@PredictionBot  idea ", "idea", true},
+		{"/feedback", "", false},
+		{"/feedback   ", "", false},
+		{"/feedbackx text", "", false},
+	}
+
+	for _, tt := range tests {
+		text, ok := parseFeedbackText(tt.message)
+		if ok != tt.ok || text != tt.text {
+			t.Errorf("parseFeedbackText(%q) = %q, %t; want %q, %t", tt.message, text, ok, tt.text, tt.ok)
+		}
+	}
+}
+
+func TestHandleFeedback_ForwardsAndLists(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	userID := int64(42)
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := storage.NewDBQueue(db)
+	defer queue.Close()
+
+	if err := storage.InitSchema(queue); err != nil {
+		t.Fatalf("failed to initialize schema: %v", err)
+	}
+	if err := storage.RunMigrations(queue); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	log := logger.New(logger.ERROR)
+	rec, b := newRecordingTelegramServer(t)
+	notificationService := domain.NewNotificationService(b, nil, nil, nil, nil, log, localizer)
+	adminIDs := []int64{adminID}
+
+	h := &BotHandler{
+		config:          &config.Config{AdminUserIDs: adminIDs, Timezone: time.UTC},
+		logger:          log,
+		feedbackService: domain.NewFeedbackService(storage.NewFeedbackRepository(queue), notificationService, adminIDs, log),
+		localizer:       localizer,
+	}
+
+	update := &models.Update{
+		Message: &models.Message{
+			ID:   1,
+			From: &models.User{ID: userID, Username: "alice"},
+			Chat: models.Chat{ID: userID, Type: models.ChatTypePrivate},
+			Text: "/feedback the button is broken",
+		},
+	}
+	h.HandleFeedback(ctx, b, update)
+
+	texts := rec.texts()
+	if len(texts) != 2 {
+		t.Fatalf("expected an admin notification and an acknowledgement, got %q", texts)
+	}
+	if texts[0] != "💬 Feedback #1\nFrom: @alice (ID: 42)\n\nthe button is broken" {
+		t.Errorf("unexpected admin notification: %q", texts[0])
+	}
+	if texts[1] != localizer.MustLocalize(locale.FeedbackReceived) {
+		t.Errorf("unexpected acknowledgement: %q", texts[1])
+	}
+
+	// Without text the usage is shown and nothing is stored
+	update.Message.Text = "/feedback"
+	h.HandleFeedback(ctx, b, update)
+	if texts := rec.texts(); texts[len(texts)-1] != localizer.MustLocalize(locale.FeedbackUsage) {
+		t.Errorf("expected usage, got %q", texts[len(texts)-1])
+	}
+
+	// Admins can review stored feedback
+	h.HandleFeedbackList(ctx, b, &models.Update{
+		Message: &models.Message{
+			ID:   2,
+			From: &models.User{ID: adminID},
+			Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+			Text: "/feedback_list",
+		},
+	})
+	texts = rec.texts()
+	list := texts[len(texts)-1]
+	if !strings.HasPrefix(list, "💬 RECENT FEEDBACK\n\n#1 · ") || !strings.Contains(list, "@alice (ID: 42)\nthe button is broken") {
+		t.Errorf("unexpected feedback list: %q", list)
+	}
+}
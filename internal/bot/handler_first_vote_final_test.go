@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestFirstVoteFinal(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	voterID := int64(300)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: voterID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	event := &domain.Event{
+		GroupID:        groupID,
+		Question:       "Will it rain?",
+		Options:        []string{"Yes", "No"},
+		CreatedAt:      time.Now(),
+		Deadline:       time.Now().Add(24 * time.Hour),
+		Status:         domain.EventStatusActive,
+		EventType:      domain.EventTypeBinary,
+		CreatedBy:      adminID,
+		PollID:         "poll-1",
+		AllowsRevoting: true,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:           groupRepo,
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      predictionRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		logger:              log,
+		localizer:           localizer,
+	}
+	toggle := func() {
+		t.Helper()
+		data := mustEncodeCallback(cbFirstVoteFinalToggle, groupID)
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		h.handleFirstVoteFinalCallback(ctx, b, &models.CallbackQuery{
+			ID:      "cb",
+			From:    models.User{ID: adminID},
+			Data:    data,
+			Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}}},
+		}, adminID, cb)
+	}
+	vote := func(option int) int {
+		t.Helper()
+		h.HandlePollAnswer(ctx, b, &models.Update{
+			PollAnswer: &models.PollAnswer{PollID: "poll-1", User: &models.User{ID: voterID}, OptionIDs: []int{option}},
+		})
+		prediction, err := predictionRepo.GetPredictionByUserAndEvent(ctx, voterID, event.ID)
+		if err != nil {
+			t.Fatalf("failed to get prediction: %v", err)
+		}
+		if prediction == nil {
+			t.Fatal("expected a prediction")
+		}
+		return prediction.Option
+	}
+
+	toggle()
+	if group, _ := groupRepo.GetGroup(ctx, groupID); !group.FirstVoteFinal {
+		t.Fatal("expected the first vote to be final")
+	}
+
+	// The first vote is recorded
+	if option := vote(0); option != 0 {
+		t.Errorf("expected the first vote to be saved, got option %d", option)
+	}
+
+	// A change is ignored and the voter is told which vote stands
+	sent := len(rec.texts())
+	if option := vote(1); option != 0 {
+		t.Errorf("expected the first vote to stand, got option %d", option)
+	}
+	texts := rec.texts()
+	kept := localizer.MustLocalizeWithTemplate(locale.FirstVoteFinalVoteKept, "Test Group", "Will it rain?", "Yes")
+	if len(texts) != sent+1 || texts[len(texts)-1] != kept {
+		t.Errorf("expected the notice %q, got %v", kept, texts[sent:])
+	}
+
+	// Changes are allowed again once the setting is off
+	toggle()
+	if option := vote(1); option != 1 {
+		t.Errorf("expected the vote to change, got option %d", option)
+	}
+}
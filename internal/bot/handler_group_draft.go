@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// registerDraftGroup creates a pending draft group for a chat the bot was added to.
+// If the chat already has a group, it is left untouched and returned with created false.
+// The group is nil when it could be neither found nor created.
+func (h *BotHandler) registerDraftGroup(ctx context.Context, chat models.Chat, addedBy int64) (group *domain.Group, created bool) {
+	group, err := h.groupRepo.GetGroupByTelegramChatID(ctx, chat.ID)
+	if err != nil {
+		h.logger.Error("failed to check existing group for chat", "chat_id", chat.ID, "error", err)
+		return nil, false
+	}
+	if group != nil {
+		h.logger.Info("bot re-added to chat with existing group", "group_id", group.ID, "chat_id", chat.ID, "status", group.Status)
+		return group, false
+	}
+
+	name := chat.Title
+	if name == "" {
+		name = fmt.Sprintf("Chat %d", chat.ID)
+	}
+	group = &domain.Group{
+		TelegramChatID: chat.ID,
+		Name:           name,
+		CreatedAt:      time.Now(),
+		CreatedBy:      addedBy,
+		IsForum:        chat.IsForum,
+		Status:         domain.GroupStatusPending,
+	}
+	if err := group.Validate(); err != nil {
+		h.logger.Error("draft group validation failed", "chat_id", chat.ID, "error", err)
+		return nil, false
+	}
+	if err := h.groupRepo.CreateGroup(ctx, group); err != nil {
+		h.logger.Error("failed to create draft group", "chat_id", chat.ID, "error", err)
+		return nil, false
+	}
+	h.logger.Info("draft group created", "group_id", group.ID, "chat_id", chat.ID, "group_name", group.Name)
+	return group, true
+}
+
+// draftGroupStatus returns the status line of the group registered by registerDraftGroup for the admin
+// notification and the matching action keyboard. A nil group falls back to the /create_group hint.
+func draftGroupStatus(localizer locale.Localizer, chatID int64, group *domain.Group, created bool) (string, *models.InlineKeyboardMarkup) {
+	leaveRow := []models.InlineKeyboardButton{
+		{
+			Text:         localizer.MustLocalize(locale.LeaveGroupButton),
+			CallbackData: mustEncodeCallback(cbLeaveGroup, chatID),
+		},
+	}
+	if group == nil {
+		return localizer.MustLocalize(locale.BotAddedRegisterCommand), &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{leaveRow},
+		}
+	}
+
+	name := truncateHTML(group.Name, htmlNameMaxLength)
+	groupID := fmt.Sprintf("%d", group.ID)
+	switch {
+	case created:
+		return localizer.MustLocalizeWithTemplate(locale.BotAddedDraftCreated, name, groupID),
+			draftGroupKeyboard(localizer, group.ID, leaveRow)
+	case group.Status == domain.GroupStatusPending:
+		return localizer.MustLocalizeWithTemplate(locale.BotAddedDraftPending, name, groupID),
+			draftGroupKeyboard(localizer, group.ID, leaveRow)
+	case group.Status == domain.GroupStatusDeleted:
+		return localizer.MustLocalizeWithTemplate(locale.BotAddedDeletedGroup, name, groupID), &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{
+				{
+					{
+						Text:         localizer.MustLocalize(locale.BotAddedButtonRestore),
+						CallbackData: mustEncodeCallback(cbRestoreGroupConfirm, group.ID),
+					},
+				},
+				leaveRow,
+			},
+		}
+	default:
+		return localizer.MustLocalizeWithTemplate(locale.BotAddedExistingGroup, name, groupID), &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{leaveRow},
+		}
+	}
+}
+
+// draftGroupKeyboard builds the activate/rename/leave keyboard for a pending draft group
+func draftGroupKeyboard(localizer locale.Localizer, groupID int64, leaveRow []models.InlineKeyboardButton) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{
+					Text:         localizer.MustLocalize(locale.GroupDraftButtonActivate),
+					CallbackData: mustEncodeCallback(cbActivateGroup, groupID),
+				},
+				{
+					Text:         localizer.MustLocalize(locale.GroupDraftButtonRename),
+					CallbackData: mustEncodeCallback(cbRenameGroupInput, groupID),
+				},
+			},
+			leaveRow,
+		},
+	}
+}
+
+// handleActivateGroupCallback activates a pending draft group
+func (h *BotHandler) handleActivateGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	if callback.Message.Message == nil {
+		return
+	}
+	chatID := callback.Message.Message.Chat.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send group activation reply", "error", err)
+		}
+	}
+
+	if err := cb.Expect(cbActivateGroup, 1); err != nil {
+		h.logger.Error("invalid activate_group callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup))
+		return
+	}
+	if group == nil {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound))
+		return
+	}
+
+	if group.Status != domain.GroupStatusPending {
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupDraftNotPending, group.Name))
+		return
+	}
+
+	if err := h.groupRepo.UpdateGroupStatus(ctx, groupID, domain.GroupStatusActive); err != nil {
+		h.logger.Error("failed to activate group", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupDraftActivateError))
+		return
+	}
+
+	h.logAdminAction(userID, "activate_group", groupID, fmt.Sprintf("Activated draft group %s", group.Name))
+
+	// Remove the activation buttons from the notification
+	_, _ = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:    chatID,
+		MessageID: callback.Message.Message.ID,
+	})
+
+	deepLink, err := h.deepLinkService.GenerateGroupInviteLink(groupID)
+	if err != nil {
+		h.logger.Error("failed to generate deep-link", "group_id", groupID, "error", err)
+		deepLink = h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsLinkError)
+	}
+
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupDraftActivated, group.Name, deepLink))
+}
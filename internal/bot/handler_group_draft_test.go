@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/encoding"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func botAddedUpdate(chatID int64, title string, isForum bool) *models.Update {
+	return &models.Update{
+		MyChatMember: &models.ChatMemberUpdated{
+			Chat:          models.Chat{ID: chatID, Type: models.ChatTypeSupergroup, Title: title, IsForum: isForum},
+			From:          models.User{ID: 42, Username: "alice"},
+			OldChatMember: models.ChatMember{Type: models.ChatMemberTypeLeft},
+			NewChatMember: models.ChatMember{Type: models.ChatMemberTypeMember},
+		},
+	}
+}
+
+func TestHandleMyChatMember_DraftGroup(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	chatID := int64(-100500)
+
+	queue, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	encoder, err := encoding.NewBaseNEncoder("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	groupRepo := storage.NewGroupRepository(queue)
+	h := &BotHandler{
+		bot:             b,
+		config:          &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:       groupRepo,
+		deepLinkService: domain.NewDeepLinkService("testbot", encoder),
+		logger:          logger.New(logger.ERROR),
+		localizer:       localizer,
+	}
+
+	// Adding the bot creates a hidden draft prefilled from the chat
+	h.HandleMyChatMember(ctx, b, botAddedUpdate(chatID, "Forecasters", true))
+
+	group, err := groupRepo.GetGroupByTelegramChatID(ctx, chatID)
+	if err != nil || group == nil {
+		t.Fatalf("expected draft group to be created, got %v, %v", group, err)
+	}
+	if group.Name != "Forecasters" || !group.IsForum || group.Status != domain.GroupStatusPending || group.CreatedBy != 42 {
+		t.Errorf("unexpected draft group: %+v", group)
+	}
+	if groups, _ := groupRepo.GetAllGroups(ctx); len(groups) != 0 {
+		t.Errorf("expected pending group to be hidden from group lists, got %d groups", len(groups))
+	}
+
+	texts := rec.texts()
+	expected := localizer.MustLocalizeWithTemplate(locale.BotAddedDraftCreated, "Forecasters", "1")
+	if len(texts) == 0 || !strings.Contains(texts[0], expected) {
+		t.Errorf("expected admin notification about the draft, got %q", texts)
+	}
+
+	// Re-adding the bot reuses the pending draft
+	h.HandleMyChatMember(ctx, b, botAddedUpdate(chatID, "Forecasters", true))
+	texts = rec.texts()
+	if !strings.Contains(texts[len(texts)-1], localizer.MustLocalizeWithTemplate(locale.BotAddedDraftPending, "Forecasters", "1")) {
+		t.Errorf("expected pending draft notice, got %q", texts[len(texts)-1])
+	}
+
+	// An admin confirmation activates the group
+	callback := &models.CallbackQuery{
+		ID:   "cb",
+		From: models.User{ID: adminID},
+		Data: mustEncodeCallback(cbActivateGroup, group.ID),
+		Message: models.MaybeInaccessibleMessage{
+			Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}},
+		},
+	}
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		t.Fatalf("failed to decode callback: %v", err)
+	}
+	h.handleActivateGroupCallback(ctx, b, callback, adminID, cb)
+
+	activated, err := groupRepo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("failed to get group: %v", err)
+	}
+	if activated.Status != domain.GroupStatusActive {
+		t.Errorf("expected group to be active, got %s", activated.Status)
+	}
+	texts = rec.texts()
+	if !strings.HasPrefix(texts[len(texts)-1], "✅ Group \"Forecasters\" activated!") {
+		t.Errorf("expected activation confirmation, got %q", texts[len(texts)-1])
+	}
+
+	// Re-adding the bot to an active group does not create another group
+	h.HandleMyChatMember(ctx, b, botAddedUpdate(chatID, "Renamed chat", false))
+	groups, err := groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		t.Fatalf("failed to get groups: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Name != "Forecasters" {
+		t.Errorf("expected the existing group to be kept, got %d groups", len(groups))
+	}
+	texts = rec.texts()
+	if !strings.Contains(texts[len(texts)-1], localizer.MustLocalizeWithTemplate(locale.BotAddedExistingGroup, "Forecasters", "1")) {
+		t.Errorf("expected existing group notice, got %q", texts[len(texts)-1])
+	}
+}
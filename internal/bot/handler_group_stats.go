@@ -0,0 +1,197 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleGroupStats handles the /group_stats command (analytics for any group)
+func (h *BotHandler) HandleGroupStats(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	// Get all groups (including soft-deleted ones)
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	if len(groups) == 0 {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	// Build inline keyboard with groups
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		text := group.Name
+		if group.Status == domain.GroupStatusDeleted {
+			text = "🗑 " + group.Name
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         text,
+				CallbackData: mustEncodeCallback(cbGroupStats, group.ID),
+			},
+		})
+	}
+
+	kb := &models.InlineKeyboardMarkup{
+		InlineKeyboard: buttons,
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.GroupStatsSelectGroup),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to send group selection", "error", err)
+	}
+}
+
+// handleGroupStatsCallback handles the callback for viewing a group's statistics
+func (h *BotHandler) handleGroupStatsCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	if callback.Message.Message == nil {
+		return
+	}
+	chatID := callback.Message.Message.Chat.ID
+
+	// Parse group ID
+	if err := cb.Expect(cbGroupStats, 1); err != nil {
+		h.logger.Error("invalid group_stats callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	// Get group (it may have been deleted after the list was shown)
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	stats, err := h.statsService.GetGroupStats(ctx, groupID)
+	if err != nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.GroupStatsErrorLoad),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   h.buildGroupStatsMessage(ctx, group, stats),
+	})
+	if err != nil {
+		h.logger.Error("failed to send group stats", "group_id", groupID, "error", err)
+	}
+
+	h.logAdminAction(userID, "view_group_stats", groupID, fmt.Sprintf("Viewed stats for group %s", group.Name))
+}
+
+// buildGroupStatsMessage formats group analytics
+func (h *BotHandler) buildGroupStatsMessage(ctx context.Context, group *domain.Group, stats *domain.GroupStats) string {
+	deletedMarker := ""
+	if group.Status == domain.GroupStatusDeleted {
+		deletedMarker = h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsItemDeleted)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupStatsTitle, group.Name, deletedMarker) + "\n\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupStatsEvents,
+		fmt.Sprintf("%d", stats.TotalEvents),
+		fmt.Sprintf("%d", stats.ActiveEvents),
+		fmt.Sprintf("%d", stats.ResolvedEvents),
+		fmt.Sprintf("%d", stats.CancelledEvents),
+	) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupStatsPredictions,
+		fmt.Sprintf("%d", stats.TotalPredictions),
+		fmt.Sprintf("%.1f", stats.AveragePredictionsPerEvent()),
+	) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupStatsParticipants,
+		fmt.Sprintf("%d", stats.UniqueParticipants),
+		fmt.Sprintf("%d", stats.ActiveMembers),
+	) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupStatsAccuracy,
+		fmt.Sprintf("%.1f", stats.AccuracyPercent()),
+		fmt.Sprintf("%d", stats.CorrectPredictions),
+		fmt.Sprintf("%d", stats.ResolvedPredictions),
+	) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupStatsContrarianWins, fmt.Sprintf("%d", stats.ContrarianWins)) + "\n")
+	for _, win := range stats.RecentContrarianWins {
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupStatsContrarianItem,
+			win.Question,
+			fmt.Sprintf("%d", win.CorrectCount),
+			fmt.Sprintf("%d", win.TotalCount),
+		) + "\n")
+	}
+	sb.WriteString("\n")
+
+	if len(stats.TopPredictors) == 0 {
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.GroupStatsNoTopPredictors))
+		return sb.String()
+	}
+
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.GroupStatsTopPredictors) + "\n")
+	medals := h.podiumMedals()
+	for i, rating := range stats.TopPredictors {
+		medal := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			medal = medals[i]
+		}
+
+		// Display username or user ID if username is not available
+		displayName := rating.Username
+		if displayName == "" {
+			displayName = fmt.Sprintf("ID: %d", rating.UserID)
+		} else {
+			displayName = fmt.Sprintf("@%s", displayName)
+		}
+
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingTopEntry, medal, displayName, domain.FormatPoints(h.requestLocalizer(ctx), rating.Score, group.PointsLabel)) + "\n")
+	}
+
+	return sb.String()
+}
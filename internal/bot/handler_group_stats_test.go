@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func newGroupStatsTestHandler(t *testing.T, queue *storage.DBQueue, adminID int64) *BotHandler {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	log := logger.New(logger.ERROR)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	return &BotHandler{
+		config:       &config.Config{AdminUserIDs: []int64{adminID}},
+		logger:       log,
+		groupRepo:    storage.NewGroupRepository(queue),
+		ratingRepo:   ratingRepo,
+		statsService: domain.NewStatsService(storage.NewStatsRepository(queue), ratingRepo, storage.NewEventRepository(queue), storage.NewPredictionRepository(queue), log),
+		localizer:    localizer,
+	}
+}
+
+func groupStatsCallback(adminID int64, groupID int64) *models.CallbackQuery {
+	return &models.CallbackQuery{
+		ID:   "cb",
+		From: models.User{ID: adminID},
+		Data: mustEncodeCallback(cbGroupStats, groupID),
+		Message: models.MaybeInaccessibleMessage{
+			Type:    models.MaybeInaccessibleMessageTypeMessage,
+			Message: &models.Message{ID: 1, Chat: models.Chat{ID: adminID}},
+		},
+	}
+}
+
+func TestHandleGroupStatsCallback_SoftDeletedGroupMarked(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(42)
+	queue, groupID := setupTestGroupAndDB(t, -1001, adminID)
+	defer queue.Close()
+
+	h := newGroupStatsTestHandler(t, queue, adminID)
+	if err := h.groupRepo.UpdateGroupStatus(ctx, groupID, domain.GroupStatusDeleted); err != nil {
+		t.Fatalf("failed to soft-delete group: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	callback := groupStatsCallback(adminID, groupID)
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		t.Fatalf("failed to decode callback: %v", err)
+	}
+
+	h.handleGroupStatsCallback(ctx, b, callback, adminID, cb)
+
+	if len(rec.sentTexts) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(rec.sentTexts))
+	}
+	text := rec.sentTexts[0]
+	if !strings.Contains(text, "Test Group") || !strings.Contains(text, "(deleted)") {
+		t.Errorf("expected stats for soft-deleted group to be marked, got %q", text)
+	}
+}
+
+func TestHandleGroupStatsCallback_GroupDeletedAfterListing(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(42)
+	queue, groupID := setupTestGroupAndDB(t, -1001, adminID)
+	defer queue.Close()
+
+	h := newGroupStatsTestHandler(t, queue, adminID)
+	if err := h.groupRepo.DeleteGroup(ctx, groupID); err != nil {
+		t.Fatalf("failed to delete group: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	callback := groupStatsCallback(adminID, groupID)
+	cb, err := DecodeCallback(callback.Data)
+	if err != nil {
+		t.Fatalf("failed to decode callback: %v", err)
+	}
+
+	h.handleGroupStatsCallback(ctx, b, callback, adminID, cb)
+
+	if len(rec.sentTexts) != 0 {
+		t.Errorf("expected no stats message for a deleted group, got %v", rec.sentTexts)
+	}
+}
+
+func TestBuildGroupStatsMessage_ContrarianWins(t *testing.T) {
+	queue, _ := setupTestGroupAndDB(t, -1001, 1)
+	h := newGroupStatsTestHandler(t, queue, 1)
+
+	group := &domain.Group{Name: "Test Group", Status: domain.GroupStatusActive}
+	stats := &domain.GroupStats{
+		ContrarianWins: 4,
+		RecentContrarianWins: []*domain.ContrarianWin{
+			{EventID: 7, Question: "Will it rain?", CorrectCount: 2, TotalCount: 9},
+		},
+	}
+
+	text := h.buildGroupStatsMessage(context.Background(), group, stats)
+	for _, want := range []string{"Contrarian wins: 4", "Will it rain? — 2 of 9 were right"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected stats to contain %q, got:\n%s", want, text)
+		}
+	}
+}
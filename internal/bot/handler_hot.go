@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// hotEventsLimit is the number of events shown by /hot
+const hotEventsLimit = 10
+
+// HandleHot handles the /hot command (active events of the user's groups ranked by recent votes)
+func (h *BotHandler) HandleHot(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send hot events message", "error", err)
+		}
+	}
+
+	groups, err := h.groupRepo.GetUserGroups(ctx, userID)
+	if err != nil {
+		reply(h.userErrorMessage(ctx, err, "failed to get user groups", "user_id", userID))
+		return
+	}
+
+	if len(groups) == 0 {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupContextNoMembership))
+		return
+	}
+
+	groupIDs := make([]int64, 0, len(groups))
+	groupNames := make(map[int64]string, len(groups))
+	for _, group := range groups {
+		groupIDs = append(groupIDs, group.ID)
+		groupNames[group.ID] = group.Name
+	}
+
+	windowHours := strconv.Itoa(h.config.HotEventsWindowHours)
+	window := time.Duration(h.config.HotEventsWindowHours) * time.Hour
+	hotEvents, err := h.eventManager.GetHotEvents(ctx, userID, groupIDs, window, time.Now(), hotEventsLimit)
+	if err != nil {
+		reply(h.userErrorMessage(ctx, err, "failed to get hot events", "user_id", userID))
+		return
+	}
+
+	if len(hotEvents) == 0 {
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.HotEmpty, windowHours))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.HotTitle, windowHours) + "\n\n")
+
+	medals := h.podiumMedals()
+	for i, hot := range hotEvents {
+		medal := fmt.Sprintf("%d. ", i+1)
+		if i < len(medals) {
+			medal = medals[i] + " "
+		}
+
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.HotItem, medal, hot.Event.Question) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemGroup, groupNames[hot.Event.GroupID]) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.HotItemVotes,
+			strconv.Itoa(hot.RecentVotes), strconv.Itoa(hot.TotalVotes)) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.HotItemDeadline,
+			hot.Event.Deadline.In(h.config.Timezone).Format("02.01 15:04")) + "\n\n")
+	}
+
+	reply(strings.TrimRight(sb.String(), "\n"))
+}
@@ -0,0 +1,258 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// importPredictionsCommand is the command used both on its own and as a CSV file caption
+	importPredictionsCommand = "/import_predictions"
+	// maxImportFileSize limits the size of an uploaded predictions CSV file
+	maxImportFileSize = 1 << 20
+	// maxImportReportSkips limits how many skipped rows are listed in the report
+	maxImportReportSkips = 20
+)
+
+// HandleImportPredictions handles the /import_predictions command (shows the file format and group IDs)
+func (h *BotHandler) HandleImportPredictions(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	var sb strings.Builder
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ImportPredictionsGroupItem, group.Name, fmt.Sprintf("%d", group.ID)) + "\n")
+	}
+	if sb.Len() == 0 {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ImportPredictionsUsage, sb.String()),
+	})
+	if err != nil {
+		h.logger.Error("failed to send import usage", "error", err)
+	}
+}
+
+// isImportPredictionsDocument reports whether a message is a CSV upload for /import_predictions
+func isImportPredictionsDocument(message *models.Message) bool {
+	return message != nil && message.Document != nil && strings.HasPrefix(message.Caption, importPredictionsCommand)
+}
+
+// parseImportPredictionsCaption parses "/import_predictions <group_id> [dry_run]"
+func parseImportPredictionsCaption(caption string) (groupID int64, dryRun bool, ok bool) {
+	args := strings.Fields(strings.TrimPrefix(caption, importPredictionsCommand))
+	if len(args) < 1 || len(args) > 2 {
+		return 0, false, false
+	}
+
+	groupID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || groupID <= 0 {
+		return 0, false, false
+	}
+
+	if len(args) == 2 {
+		switch strings.ToLower(args[1]) {
+		case "dry_run", "dry-run", "dry":
+			dryRun = true
+		default:
+			return 0, false, false
+		}
+	}
+
+	return groupID, dryRun, true
+}
+
+// handleImportPredictionsDocument imports predictions from an uploaded CSV file
+func (h *BotHandler) handleImportPredictionsDocument(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+	}
+
+	groupID, dryRun, ok := parseImportPredictionsCaption(update.Message.Caption)
+	if !ok {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsErrorCaption))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status != domain.GroupStatusActive {
+		h.logger.Warn("import target group not found", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsErrorGroup))
+		return
+	}
+
+	document := update.Message.Document
+	if document.FileSize > maxImportFileSize {
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ImportPredictionsErrorFileTooLarge, fmt.Sprintf("%d", maxImportFileSize/1024)))
+		return
+	}
+
+	data, err := downloadTelegramFile(ctx, b, document.FileID, maxImportFileSize)
+	if err != nil {
+		h.logger.Error("failed to download import file", "file_id", document.FileID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsErrorDownload))
+		return
+	}
+
+	rows, parseReport, err := domain.ParsePredictionImportCSV(bytes.NewReader(data), h.config.Timezone)
+	if err != nil {
+		h.logger.Warn("failed to parse import file", "group_id", groupID, "error", err)
+		reply(h.importParseErrorMessage(ctx, err))
+		return
+	}
+
+	report, err := h.predictionRepo.ImportPredictions(ctx, groupID, rows, dryRun)
+	if err != nil {
+		h.logger.Error("failed to import predictions", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsErrorImport))
+		return
+	}
+	report.Merge(parseReport)
+
+	reply(h.buildImportReportMessage(ctx, group, report))
+
+	h.logger.Info("predictions imported",
+		"group_id", groupID,
+		"dry_run", dryRun,
+		"total", report.Total,
+		"imported", report.Imported,
+		"skipped", len(report.Skipped),
+	)
+	if !dryRun {
+		h.logAdminAction(userID, "import_predictions", 0, fmt.Sprintf("Imported %d of %d predictions into group %s", report.Imported, report.Total, group.Name))
+	}
+}
+
+// importParseErrorMessage returns a localized message for a CSV parse error
+func (h *BotHandler) importParseErrorMessage(ctx context.Context, err error) string {
+	switch {
+	case errors.Is(err, domain.ErrImportMissingColumn):
+		return h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsErrorColumns)
+	case errors.Is(err, domain.ErrImportTooManyRows):
+		return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ImportPredictionsErrorTooManyRows, fmt.Sprintf("%d", domain.MaxPredictionImportRows))
+	case errors.Is(err, domain.ErrImportEmpty):
+		return h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsErrorEmpty)
+	default:
+		return h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsErrorParse)
+	}
+}
+
+// buildImportReportMessage formats the import report
+func (h *BotHandler) buildImportReportMessage(ctx context.Context, group *domain.Group, report *domain.PredictionImportReport) string {
+	reasons := map[domain.PredictionImportSkipReason]string{
+		domain.ImportSkipInvalidRow:     h.requestLocalizer(ctx).MustLocalize(locale.ImportSkipInvalidRow),
+		domain.ImportSkipUnknownEvent:   h.requestLocalizer(ctx).MustLocalize(locale.ImportSkipUnknownEvent),
+		domain.ImportSkipAmbiguousEvent: h.requestLocalizer(ctx).MustLocalize(locale.ImportSkipAmbiguousEvent),
+		domain.ImportSkipUnknownUser:    h.requestLocalizer(ctx).MustLocalize(locale.ImportSkipUnknownUser),
+		domain.ImportSkipNotParticipant: h.requestLocalizer(ctx).MustLocalize(locale.ImportSkipNotParticipant),
+		domain.ImportSkipInvalidOption:  h.requestLocalizer(ctx).MustLocalize(locale.ImportSkipInvalidOption),
+		domain.ImportSkipDuplicate:      h.requestLocalizer(ctx).MustLocalize(locale.ImportSkipDuplicate),
+	}
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ImportPredictionsReportTitle, group.Name) + "\n")
+	if report.DryRun {
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsReportDryRun) + "\n")
+	}
+	sb.WriteString("\n" + h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ImportPredictionsReportStats,
+		fmt.Sprintf("%d", report.Total),
+		fmt.Sprintf("%d", report.Imported),
+		fmt.Sprintf("%d", len(report.Skipped)),
+	))
+
+	if len(report.Skipped) == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.ImportPredictionsReportSkipped) + "\n")
+	for i, skip := range report.Skipped {
+		if i == maxImportReportSkips {
+			sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ImportPredictionsReportMore, fmt.Sprintf("%d", len(report.Skipped)-maxImportReportSkips)) + "\n")
+			break
+		}
+		reason := reasons[skip.Reason]
+		if reason == "" {
+			reason = string(skip.Reason)
+		}
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ImportPredictionsReportSkippedItem, fmt.Sprintf("%d", skip.Line), reason) + "\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// downloadTelegramFile downloads a file sent to the bot, refusing files larger than maxSize bytes
+func downloadTelegramFile(ctx context.Context, b *bot.Bot, fileID string, maxSize int64) ([]byte, error) {
+	file, err := b.GetFile(ctx, &bot.GetFileParams{FileID: fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d downloading file", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", maxSize)
+	}
+
+	return data, nil
+}
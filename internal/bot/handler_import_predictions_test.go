@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+)
+
+func TestParseImportPredictionsCaption(t *testing.T) {
+	tests := []struct {
+		caption string
+		groupID int64
+		dryRun  bool
+		ok      bool
+	}{
+		{"/import_predictions 5", 5, false, true},
+		{"/import_predictions 5 dry_run", 5, true, true},
+		{"/import_predictions  7  DRY", 7, true, true},
+		{"/import_predictions", 0, false, false},
+		{"/import_predictions abc", 0, false, false},
+		{"/import_predictions -1", 0, false, false},
+		{"/import_predictions 5 now", 0, false, false},
+	}
+
+	for _, tt := range tests {
+		groupID, dryRun, ok := parseImportPredictionsCaption(tt.caption)
+		if ok != tt.ok || groupID != tt.groupID || dryRun != tt.dryRun {
+			t.Errorf("parseImportPredictionsCaption(%q) = %d, %t, %t; want %d, %t, %t",
+				tt.caption, groupID, dryRun, ok, tt.groupID, tt.dryRun, tt.ok)
+		}
+	}
+}
+
+func TestBuildImportReportMessage(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	h := &BotHandler{logger: logger.New(logger.ERROR), localizer: localizer}
+
+	report := &domain.PredictionImportReport{DryRun: true, Total: 30, Imported: 5}
+	for line := 2; line < 27; line++ {
+		report.Skip(line, domain.ImportSkipUnknownUser)
+	}
+
+	message := h.buildImportReportMessage(context.Background(), &domain.Group{Name: "Test Group"}, report)
+
+	for _, expected := range []string{
+		"Import into Test Group",
+		"Dry run",
+		"Rows: 30\nImported: 5\nSkipped: 25",
+		"• line 2: user is not a group member",
+		"…and 5 more",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("expected report to contain %q, got:\n%s", expected, message)
+		}
+	}
+	if strings.Contains(message, "line 22:") {
+		t.Errorf("expected skipped rows beyond the limit to be collapsed, got:\n%s", message)
+	}
+}
@@ -0,0 +1,32 @@
+package bot
+
+import (
+	"context"
+	"time"
+)
+
+// joinGateRemaining returns how long a user still has to wait before joining groups via a deep link
+// (0 if the gate is disabled, the user is an admin, or the bot has known the user long enough).
+// Telegram doesn't expose account age, so the wait counts from the user's first cached profile.
+func (h *BotHandler) joinGateRemaining(ctx context.Context, userID int64, now time.Time) (time.Duration, error) {
+	if h.config.JoinMinKnownAge <= 0 || h.userRepo == nil || h.isAdmin(userID) {
+		return 0, nil
+	}
+
+	profile, err := h.userRepo.GetUserProfile(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// A user without a profile is being seen for the first time
+	firstSeen := now
+	if profile != nil {
+		firstSeen = profile.FirstSeenAt
+	}
+
+	remaining := firstSeen.Add(h.config.JoinMinKnownAge).Sub(now)
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
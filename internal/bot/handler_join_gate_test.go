@@ -0,0 +1,138 @@
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/encoding"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestJoinMinKnownAgeGatesDeepLinkJoin(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	newUserID := int64(200)
+	knownUserID := int64(300)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	encoder, err := encoding.NewBaseNEncoder("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	userRepo := storage.NewUserRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+
+	// The known user was first seen four days ago, the new user just now
+	for _, userID := range []int64{newUserID, knownUserID, adminID} {
+		if err := userRepo.UpsertUserProfile(ctx, userID, "", "User", ""); err != nil {
+			t.Fatalf("failed to create profile: %v", err)
+		}
+	}
+	err = queue.Execute(func(db *sql.DB) error {
+		_, err := db.Exec(`UPDATE user_profiles SET first_seen_at = ? WHERE user_id = ?`, time.Now().Add(-96*time.Hour), knownUserID)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to backdate profile: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC, JoinMinKnownAge: 72 * time.Hour},
+		groupRepo:           storage.NewGroupRepository(queue),
+		groupMembershipRepo: membershipRepo,
+		userRepo:            userRepo,
+		predictionRepo:      predictionRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		deepLinkService:     domain.NewDeepLinkService("testbot", encoder),
+		logger:              log,
+		localizer:           localizer,
+	}
+	encodedID, err := encoder.Encode(groupID)
+	if err != nil {
+		t.Fatalf("failed to encode group ID: %v", err)
+	}
+	join := func(userID int64) string {
+		t.Helper()
+		h.handleDeepLinkJoin(ctx, b, &models.Update{
+			Message: &models.Message{
+				From: &models.User{ID: userID, FirstName: "User"},
+				Chat: models.Chat{ID: userID, Type: models.ChatTypePrivate},
+			},
+		}, "group_"+encodedID)
+		texts := rec.texts()
+		return texts[len(texts)-1]
+	}
+
+	// A user the bot has just met is told how long to wait
+	text := join(newUserID)
+	want := localizer.MustLocalizeWithTemplate(locale.DeepLinkUserTooNew, "Test Group", h.formatCooldownWait(ctx, 72*time.Hour))
+	if text != want {
+		t.Errorf("expected too new rejection %q, got %q", want, text)
+	}
+	if membership, _ := membershipRepo.GetMembership(ctx, groupID, newUserID); membership != nil {
+		t.Fatalf("expected no membership for a new user, got %+v", membership)
+	}
+
+	// A user known for longer joins
+	if text := join(knownUserID); text != localizer.MustLocalizeWithTemplate(locale.DeepLinkWelcome, "Test Group") {
+		t.Errorf("expected welcome for a known user, got %q", text)
+	}
+	if membership, _ := membershipRepo.GetMembership(ctx, groupID, knownUserID); membership == nil || membership.Status != domain.MembershipStatusActive {
+		t.Fatalf("expected an active membership for a known user, got %+v", membership)
+	}
+
+	// Without the gate everyone can join
+	h.config.JoinMinKnownAge = 0
+	if text := join(newUserID); text != localizer.MustLocalizeWithTemplate(locale.DeepLinkWelcome, "Test Group") {
+		t.Errorf("expected welcome with the gate disabled, got %q", text)
+	}
+}
+
+func TestJoinGateRemaining(t *testing.T) {
+	ctx := context.Background()
+	queue, _ := setupTestGroupAndDB(t, -100500, 1)
+	t.Cleanup(queue.Close)
+
+	userRepo := storage.NewUserRepository(queue)
+	h := &BotHandler{
+		config:   &config.Config{AdminUserIDs: []int64{1}, JoinMinKnownAge: time.Hour},
+		userRepo: userRepo,
+	}
+	now := time.Now()
+
+	// Users without a profile wait the full period, admins are exempt
+	if wait, err := h.joinGateRemaining(ctx, 2, now); err != nil || wait != time.Hour {
+		t.Errorf("expected a full wait for an unseen user, got %s (%v)", wait, err)
+	}
+	if wait, err := h.joinGateRemaining(ctx, 1, now); err != nil || wait != 0 {
+		t.Errorf("expected no wait for an admin, got %s (%v)", wait, err)
+	}
+
+	if err := userRepo.UpsertUserProfile(ctx, 2, "bob", "", ""); err != nil {
+		t.Fatalf("failed to create profile: %v", err)
+	}
+	if wait, err := h.joinGateRemaining(ctx, 2, now.Add(2*time.Hour)); err != nil || wait != 0 {
+		t.Errorf("expected no wait once the period has passed, got %s (%v)", wait, err)
+	}
+}
@@ -0,0 +1,167 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// languageDefault is the callback value of the button that removes the stored language
+const languageDefault = "default"
+
+// languageNameKeys are the keys of the names of the supported languages, each written in its own language
+var languageNameKeys = map[string]string{
+	locale.En: locale.LanguageNameEn,
+	locale.Ru: locale.LanguageNameRu,
+}
+
+// HandleLanguage handles the /language command: in a private chat users choose the bot language for
+// themselves, in a group chat bot admins choose it for the chat
+func (h *BotHandler) HandleLanguage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	private := update.Message.Chat.Type == models.ChatTypePrivate
+	reply := func(text string, kb *models.InlineKeyboardMarkup) {
+		params := &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		}
+		if kb != nil {
+			params.ReplyMarkup = kb
+		}
+		if _, err := b.SendMessage(ctx, params); err != nil {
+			h.logger.Error("failed to send language settings", "chat_id", chatID, "error", err)
+		}
+	}
+
+	if !private && !h.isAdmin(userID) {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.LanguageSettingsAdminsOnly), nil)
+		return
+	}
+
+	var current string
+	var err error
+	if private {
+		current, err = h.languageRepo.UserLanguage(ctx, userID)
+	} else {
+		current, err = h.languageRepo.ChatLanguage(ctx, chatID)
+	}
+	if err != nil {
+		h.logger.Error("failed to get language", "user_id", userID, "chat_id", chatID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.LanguageSettingsErrorGet), nil)
+		return
+	}
+
+	reply(h.languageSettingsText(h.requestLocalizer(ctx), private, current), h.buildLanguageKeyboard(h.requestLocalizer(ctx), current))
+}
+
+// languageSettingsText describes the language stored for the user of a private chat or for a group chat
+func (h *BotHandler) languageSettingsText(localizer locale.Localizer, private bool, current string) string {
+	title := locale.LanguageSettingsTitleChat
+	if private {
+		title = locale.LanguageSettingsTitleUser
+	}
+	return localizer.MustLocalizeWithTemplate(title, h.languageLabel(localizer, current))
+}
+
+// buildLanguageKeyboard builds one button per supported language and one for the default, marking the current one
+func (h *BotHandler) buildLanguageKeyboard(localizer locale.Localizer, current string) *models.InlineKeyboardMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	for _, language := range append(append([]string{}, locale.SupportedLanguages...), "") {
+		text := h.languageLabel(localizer, language)
+		if language == current {
+			text += " ✓"
+		}
+		value := language
+		if value == "" {
+			value = languageDefault
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         text,
+				CallbackData: mustEncodeCallback(cbLanguage, value),
+			},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// languageLabel returns the name of a language, or the default label naming the bot-wide language when empty
+func (h *BotHandler) languageLabel(localizer locale.Localizer, language string) string {
+	if language == "" {
+		return localizer.MustLocalizeWithTemplate(locale.LanguageSettingsDefault, localizer.MustLocalize(languageNameKeys[h.localizer.GetLocale()]))
+	}
+	return localizer.MustLocalize(languageNameKeys[language])
+}
+
+// handleLanguageCallback stores the selected language and confirms it in that language
+func (h *BotHandler) handleLanguageCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	if err := cb.Expect(cbLanguage, 1); err != nil {
+		h.logger.Error("invalid language callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	language, err := cb.Field(0)
+	if err != nil {
+		h.logger.Error("failed to parse language", "error", err)
+		return
+	}
+	if language == languageDefault {
+		language = ""
+	} else if locale.NormalizeLanguage(language) != language {
+		h.logger.Error("unsupported language in callback", "language", language)
+		return
+	}
+
+	if callback.Message.Message == nil {
+		return
+	}
+	chatID := callback.Message.Message.Chat.ID
+	private := callback.Message.Message.Chat.Type == models.ChatTypePrivate
+	alert := func(text string) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            text,
+			ShowAlert:       true,
+		})
+	}
+
+	if !private && !h.isAdmin(userID) {
+		alert(h.requestLocalizer(ctx).MustLocalize(locale.LanguageSettingsAdminsOnly))
+		return
+	}
+
+	if private {
+		err = h.languageRepo.SetUserLanguage(ctx, userID, language)
+	} else {
+		err = h.languageRepo.SetChatLanguage(ctx, chatID, language)
+	}
+	if err != nil {
+		h.logger.Error("failed to update language", "user_id", userID, "chat_id", chatID, "language", language, "error", err)
+		alert(h.requestLocalizer(ctx).MustLocalize(locale.LanguageSettingsErrorUpdate))
+		return
+	}
+
+	h.logger.Info("language updated", "user_id", userID, "chat_id", chatID, "language", language)
+
+	// The request was localized before the change, the confirmation already uses the new language
+	localizer := h.chatLocalizer(ctx, chatID)
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            localizer.MustLocalizeWithTemplate(locale.LanguageSettingsUpdated, h.languageLabel(localizer, language)),
+	})
+	_, _ = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        h.languageSettingsText(localizer, private, language),
+		ReplyMarkup: h.buildLanguageKeyboard(localizer, language),
+	})
+}
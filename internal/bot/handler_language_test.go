@@ -0,0 +1,119 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestHandleLanguage(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	userID := int64(200)
+	groupChatID := int64(-100500)
+
+	queue, _ := setupTestGroupAndDB(t, groupChatID, adminID)
+	t.Cleanup(queue.Close)
+
+	languageRepo := storage.NewLanguageRepository(queue)
+	resolver, err := locale.NewLocalizerResolver(locale.En, languageRepo)
+	if err != nil {
+		t.Fatalf("failed to create localizer resolver: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:            &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		languageRepo:      languageRepo,
+		logger:            logger.New(logger.ERROR),
+		localizer:         resolver.Default(),
+		localizerResolver: resolver,
+	}
+
+	command := func(fromID int64, chat models.Chat) string {
+		t.Helper()
+		update := &models.Update{Message: &models.Message{
+			ID:   1,
+			From: &models.User{ID: fromID},
+			Chat: chat,
+			Text: "/language",
+		}}
+		h.HandleLanguage(h.WithRequestLocalizer(ctx, update), b, update)
+		texts := rec.texts()
+		if len(texts) == 0 {
+			t.Fatal("expected a reply to /language")
+		}
+		return texts[len(texts)-1]
+	}
+	choose := func(fromID int64, chat models.Chat, language string) {
+		t.Helper()
+		data := mustEncodeCallback(cbLanguage, language)
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		callback := &models.CallbackQuery{
+			ID:      "cb",
+			From:    models.User{ID: fromID},
+			Data:    data,
+			Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: chat}},
+		}
+		update := &models.Update{CallbackQuery: callback}
+		h.handleLanguageCallback(h.WithRequestLocalizer(ctx, update), b, callback, fromID, cb)
+	}
+
+	private := models.Chat{ID: userID, Type: models.ChatTypePrivate}
+	group := models.Chat{ID: groupChatID, Type: models.ChatTypeSupergroup}
+
+	// Without a stored language the default one is used
+	if text := command(userID, private); !strings.Contains(text, "Bot language for you") {
+		t.Errorf("expected the English settings, got %q", text)
+	}
+
+	// A user chooses the language for themselves
+	choose(userID, private, locale.Ru)
+	if lang, _ := languageRepo.UserLanguage(ctx, userID); lang != locale.Ru {
+		t.Fatalf("expected user language ru, got %q", lang)
+	}
+	if text := command(userID, private); !strings.Contains(text, "Язык бота для вас") {
+		t.Errorf("expected the Russian settings after the change, got %q", text)
+	}
+	if markup := rec.markups[len(rec.markups)-1]; !strings.Contains(markup, "Русский ✓") {
+		t.Errorf("expected the current language to be marked, got %s", markup)
+	}
+
+	// Users who aren't bot admins can't change the language of a group chat
+	if text := command(userID, group); !strings.Contains(text, "только админы") {
+		t.Errorf("expected the admins-only notice in the user's language, got %q", text)
+	}
+	choose(userID, group, locale.Ru)
+	if lang, _ := languageRepo.ChatLanguage(ctx, groupChatID); lang != "" {
+		t.Errorf("expected no chat language after a non-admin choice, got %q", lang)
+	}
+
+	// An admin chooses the language of the group chat, used by members without a language of their own
+	choose(adminID, group, locale.Ru)
+	if lang, _ := languageRepo.ChatLanguage(ctx, groupChatID); lang != locale.Ru {
+		t.Fatalf("expected chat language ru, got %q", lang)
+	}
+	if text := command(adminID, group); !strings.Contains(text, "Язык бота в этом чате") {
+		t.Errorf("expected the Russian chat settings, got %q", text)
+	}
+
+	// The default button removes the stored language
+	choose(userID, private, languageDefault)
+	if lang, _ := languageRepo.UserLanguage(ctx, userID); lang != "" {
+		t.Errorf("expected the user language to be removed, got %q", lang)
+	}
+	if text := command(userID, private); !strings.Contains(text, "Bot language for you") {
+		t.Errorf("expected the default language after the reset, got %q", text)
+	}
+}
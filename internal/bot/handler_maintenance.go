@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleMaintenance handles the /maintenance command (/maintenance on|off)
+func (h *BotHandler) HandleMaintenance(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+
+	var enabled bool
+	switch argument := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/maintenance"))); argument {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		// No or unknown argument - show current state and usage
+		statusKey := locale.MaintenanceStatusOff
+		if h.maintenance.Enabled() {
+			statusKey = locale.MaintenanceStatusOn
+		}
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.localizeKey(ctx, statusKey),
+		})
+		return
+	}
+
+	if err := h.maintenance.SetEnabled(ctx, enabled); err != nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.MaintenanceErrorUpdate),
+		})
+		return
+	}
+
+	resultKey := locale.MaintenanceDisabled
+	if enabled {
+		resultKey = locale.MaintenanceEnabled
+	}
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   h.localizeKey(ctx, resultKey),
+	})
+	if err != nil {
+		h.logger.Error("failed to send maintenance confirmation", "error", err)
+	}
+
+	h.logAdminAction(userID, "set_maintenance_mode", 0, fmt.Sprintf("Set maintenance mode to %t", enabled))
+}
+
+// BlockedByMaintenance reports whether an update must be dropped because maintenance mode is on.
+// Admins are never blocked, and poll answers and membership updates always pass so no votes are lost.
+// Blocked users get a "temporarily unavailable" reply: an alert for callbacks, a message for
+// private chats and commands (other group messages are dropped silently to avoid spamming groups).
+func (h *BotHandler) BlockedByMaintenance(ctx context.Context, b *bot.Bot, update *models.Update) bool {
+	if !h.maintenance.Enabled() {
+		return false
+	}
+
+	switch {
+	case update.CallbackQuery != nil:
+		if h.isAdmin(update.CallbackQuery.From.ID) {
+			return false
+		}
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: update.CallbackQuery.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.MaintenanceUnavailable),
+			ShowAlert:       true,
+		})
+		return true
+
+	case update.Message != nil:
+		// Any message counts, photos and documents too: they reach the event photo step and the predictions import
+		if update.Message.From == nil || h.isAdmin(update.Message.From.ID) {
+			return false
+		}
+		if update.Message.Chat.Type == models.ChatTypePrivate || strings.HasPrefix(update.Message.Text, "/") {
+			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:          update.Message.Chat.ID,
+				MessageThreadID: update.Message.MessageThreadID,
+				Text:            h.requestLocalizer(ctx).MustLocalize(locale.MaintenanceUnavailable),
+			})
+		}
+		h.logger.Debug("update blocked by maintenance mode", "user_id", update.Message.From.ID, "chat_id", update.Message.Chat.ID)
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// memorySettingsRepo keeps settings in memory
+type memorySettingsRepo struct {
+	values map[string]string
+}
+
+func (m *memorySettingsRepo) GetSetting(ctx context.Context, key string) (string, bool, error) {
+	value, ok := m.values[key]
+	return value, ok, nil
+}
+
+func (m *memorySettingsRepo) SetSetting(ctx context.Context, key string, value string) error {
+	m.values[key] = value
+	return nil
+}
+
+func newMaintenanceTestHandler(t *testing.T, adminID int64) (*BotHandler, *memorySettingsRepo) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	log := logger.New(logger.ERROR)
+	settings := &memorySettingsRepo{values: make(map[string]string)}
+	maintenance, err := domain.NewMaintenanceMode(context.Background(), settings, false, log)
+	if err != nil {
+		t.Fatalf("failed to create maintenance mode: %v", err)
+	}
+
+	return &BotHandler{
+		config:      &config.Config{AdminUserIDs: []int64{adminID}},
+		logger:      log,
+		maintenance: maintenance,
+		localizer:   localizer,
+	}, settings
+}
+
+func maintenanceTextUpdate(userID int64, chatType models.ChatType, text string) *models.Update {
+	return &models.Update{
+		Message: &models.Message{
+			ID:   1,
+			From: &models.User{ID: userID},
+			Chat: models.Chat{ID: userID, Type: chatType},
+			Text: text,
+		},
+	}
+}
+
+func TestHandleMaintenance_TogglesAndPersists(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	h, settings := newMaintenanceTestHandler(t, adminID)
+	rec, b := newRecordingTelegramServer(t)
+
+	h.HandleMaintenance(ctx, b, maintenanceTextUpdate(adminID, models.ChatTypePrivate, "/maintenance on"))
+	if !h.maintenance.Enabled() {
+		t.Fatal("expected maintenance mode to be enabled")
+	}
+	if settings.values[domain.SettingMaintenanceMode] != "true" {
+		t.Errorf("expected maintenance flag to be persisted, got %v", settings.values)
+	}
+
+	h.HandleMaintenance(ctx, b, maintenanceTextUpdate(adminID, models.ChatTypePrivate, "/maintenance OFF"))
+	if h.maintenance.Enabled() {
+		t.Fatal("expected maintenance mode to be disabled")
+	}
+
+	// Without an argument the current state is shown and nothing changes
+	h.HandleMaintenance(ctx, b, maintenanceTextUpdate(adminID, models.ChatTypePrivate, "/maintenance"))
+	if h.maintenance.Enabled() {
+		t.Error("expected status request to keep maintenance mode disabled")
+	}
+
+	texts := rec.texts()
+	if len(texts) != 3 || texts[2] != "Maintenance mode is off.\n\nUsage: /maintenance on|off" {
+		t.Errorf("unexpected replies: %q", texts)
+	}
+}
+
+func TestBlockedByMaintenance(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	userID := int64(2)
+	h, _ := newMaintenanceTestHandler(t, adminID)
+	rec, b := newRecordingTelegramServer(t)
+
+	// Maintenance off: nothing is blocked
+	if h.BlockedByMaintenance(ctx, b, maintenanceTextUpdate(userID, models.ChatTypePrivate, "/rating")) {
+		t.Fatal("expected updates to pass when maintenance mode is off")
+	}
+
+	if err := h.maintenance.SetEnabled(ctx, true); err != nil {
+		t.Fatalf("failed to enable maintenance mode: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		update  *models.Update
+		blocked bool
+	}{
+		{"user command", maintenanceTextUpdate(userID, models.ChatTypePrivate, "/rating"), true},
+		{"user group chatter", maintenanceTextUpdate(userID, models.ChatTypeSupergroup, "hello"), true},
+		{"admin command", maintenanceTextUpdate(adminID, models.ChatTypePrivate, "/rating"), false},
+		{"user callback", &models.Update{CallbackQuery: &models.CallbackQuery{ID: "cb", From: models.User{ID: userID}}}, true},
+		{"admin callback", &models.Update{CallbackQuery: &models.CallbackQuery{ID: "cb", From: models.User{ID: adminID}}}, false},
+		{"poll answer", &models.Update{PollAnswer: &models.PollAnswer{PollID: "poll", User: &models.User{ID: userID}}}, false},
+		{"user photo", &models.Update{Message: &models.Message{
+			From:  &models.User{ID: userID},
+			Chat:  models.Chat{ID: userID, Type: models.ChatTypePrivate},
+			Photo: []models.PhotoSize{{FileID: "photo"}},
+		}}, true},
+		{"user group document", &models.Update{Message: &models.Message{
+			From:     &models.User{ID: userID},
+			Chat:     models.Chat{ID: -100, Type: models.ChatTypeSupergroup},
+			Document: &models.Document{FileID: "predictions.csv"},
+			Caption:  "predictions",
+		}}, true},
+		{"admin document", &models.Update{Message: &models.Message{
+			From:     &models.User{ID: adminID},
+			Chat:     models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+			Document: &models.Document{FileID: "predictions.csv"},
+		}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := h.BlockedByMaintenance(ctx, b, tt.update); got != tt.blocked {
+			t.Errorf("%s: expected blocked=%t, got %t", tt.name, tt.blocked, got)
+		}
+	}
+
+	// Only the private command and photo got a reply, group messages are dropped silently
+	texts := rec.texts()
+	unavailable := h.localizer.MustLocalize(locale.MaintenanceUnavailable)
+	if len(texts) != 2 || texts[0] != unavailable || texts[1] != unavailable {
+		t.Errorf("expected two unavailable replies, got %q", texts)
+	}
+}
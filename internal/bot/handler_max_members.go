@@ -0,0 +1,159 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// maxMembersCommand caps the number of active members of a group
+const maxMembersCommand = "/max_members"
+
+// HandleMaxMembers handles the /max_members command (/max_members <group_id> <count|off>).
+// Without arguments it shows the usage together with the member count and limit of every group.
+func (h *BotHandler) HandleMaxMembers(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send max members reply", "error", err)
+		}
+	}
+
+	groupID, maxMembers, ok := parseMaxMembersArgs(update.Message.Text)
+	if !ok {
+		reply(h.maxMembersUsage(ctx))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+	}
+	if group == nil || group.Status == domain.GroupStatusDeleted {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound))
+		return
+	}
+
+	// The limit can't be lowered below the members the group already has
+	if maxMembers != nil {
+		activeMembers, err := h.groupMembershipRepo.CountActiveMembers(ctx, groupID)
+		if err != nil {
+			h.logger.Error("failed to count active members", "group_id", groupID, "error", err)
+			reply(h.requestLocalizer(ctx).MustLocalize(locale.MaxMembersError))
+			return
+		}
+		if activeMembers > *maxMembers {
+			reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MaxMembersBelowActive, group.Name, strconv.Itoa(activeMembers)))
+			return
+		}
+	}
+
+	if err := h.groupRepo.UpdateGroupMaxMembers(ctx, groupID, maxMembers); err != nil {
+		h.logger.Error("failed to update max members", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.MaxMembersError))
+		return
+	}
+
+	if maxMembers == nil {
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MaxMembersRemoved, group.Name))
+		h.logAdminAction(userID, "remove_max_members", groupID, fmt.Sprintf("Removed the member limit of group %s", group.Name))
+		return
+	}
+
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MaxMembersSet, group.Name, strconv.Itoa(*maxMembers)))
+	h.logAdminAction(userID, "set_max_members", groupID, fmt.Sprintf("Set the member limit of group %s to %d", group.Name, *maxMembers))
+}
+
+// maxMembersUsage builds the usage text followed by the member count and limit of every group
+func (h *BotHandler) maxMembersUsage(ctx context.Context) string {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+	}
+
+	var lines []string
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+
+		activeMembers, err := h.groupMembershipRepo.CountActiveMembers(ctx, group.ID)
+		if err != nil {
+			h.logger.Error("failed to count active members", "group_id", group.ID, "error", err)
+			continue
+		}
+
+		limit := h.requestLocalizer(ctx).MustLocalize(locale.MaxMembersUnlimited)
+		if group.MaxMembers != nil {
+			limit = strconv.Itoa(*group.MaxMembers)
+		}
+		lines = append(lines, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MaxMembersGroupItem,
+			group.Name, fmt.Sprintf("%d", group.ID), strconv.Itoa(activeMembers), limit))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty))
+	}
+
+	return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MaxMembersUsage, strings.Join(lines, "\n"))
+}
+
+// parseMaxMembersArgs parses "/max_members <group_id> <count|off>". A nil limit means "off".
+func parseMaxMembersArgs(text string) (groupID int64, maxMembers *int, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != maxMembersCommand && !strings.HasPrefix(command, maxMembersCommand+"@") {
+		return 0, nil, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		return 0, nil, false
+	}
+
+	groupID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || groupID <= 0 {
+		return 0, nil, false
+	}
+
+	if strings.EqualFold(fields[1], "off") {
+		return groupID, nil, true
+	}
+
+	limit, err := strconv.Atoi(fields[1])
+	if err != nil || limit <= 0 {
+		return 0, nil, false
+	}
+
+	return groupID, &limit, true
+}
+
+// isGroupFull reports whether a group with a member limit has no free places left
+func (h *BotHandler) isGroupFull(ctx context.Context, group *domain.Group) (bool, error) {
+	if group.MaxMembers == nil {
+		return false, nil
+	}
+
+	activeMembers, err := h.groupMembershipRepo.CountActiveMembers(ctx, group.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return group.IsFull(activeMembers), nil
+}
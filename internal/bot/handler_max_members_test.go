@@ -0,0 +1,168 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/encoding"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParseMaxMembersArgs(t *testing.T) {
+	tests := []struct {
+		text       string
+		groupID    int64
+		maxMembers int // 0 means no limit
+		ok         bool
+	}{
+		{"/max_members 3 10", 3, 10, true},
+		{"/max_members@PredictionBot 3  OFF ", 3, 0, true},
+		{"/max_members", 0, 0, false},
+		{"/max_members 3", 0, 0, false},
+		{"/max_members 3 0", 0, 0, false},
+		{"/max_members 3 -1", 0, 0, false},
+		{"/max_members x 10", 0, 0, false},
+		{"/max_membersx 3 10", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		groupID, maxMembers, ok := parseMaxMembersArgs(tt.text)
+		limit := 0
+		if maxMembers != nil {
+			limit = *maxMembers
+		}
+		if ok != tt.ok || groupID != tt.groupID || limit != tt.maxMembers {
+			t.Errorf("parseMaxMembersArgs(%q) = %d, %d, %t; want %d, %d, %t", tt.text, groupID, limit, ok, tt.groupID, tt.maxMembers, tt.ok)
+		}
+	}
+}
+
+func TestMaxMembersLimitsDeepLinkJoin(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	existingMemberID := int64(300)
+	newMemberID := int64(200)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	encoder, err := encoding.NewBaseNEncoder("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: existingMemberID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:           groupRepo,
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      predictionRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		deepLinkService:     domain.NewDeepLinkService("testbot", encoder),
+		logger:              log,
+		localizer:           localizer,
+	}
+	send := func(text string) {
+		t.Helper()
+		h.HandleMaxMembers(ctx, b, &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: adminID},
+				Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		})
+	}
+	lastText := func() string {
+		texts := rec.texts()
+		if len(texts) == 0 {
+			return ""
+		}
+		return texts[len(texts)-1]
+	}
+	encodedID, err := encoder.Encode(groupID)
+	if err != nil {
+		t.Fatalf("failed to encode group ID: %v", err)
+	}
+	join := func() {
+		t.Helper()
+		h.handleDeepLinkJoin(ctx, b, &models.Update{
+			Message: &models.Message{
+				From: &models.User{ID: newMemberID, Username: "newbie"},
+				Chat: models.Chat{ID: newMemberID, Type: models.ChatTypePrivate},
+			},
+		}, "group_"+encodedID)
+	}
+
+	// The usage lists every group with its member count and limit
+	send("/max_members")
+	item := localizer.MustLocalizeWithTemplate(locale.MaxMembersGroupItem, "Test Group", fmt.Sprintf("%d", groupID), "1", localizer.MustLocalize(locale.MaxMembersUnlimited))
+	if text := lastText(); !strings.Contains(text, item) {
+		t.Errorf("expected usage to list %q, got %q", item, text)
+	}
+
+	send("/max_members 99 5")
+	if text := lastText(); text != localizer.MustLocalize(locale.GroupErrorNotFound) {
+		t.Errorf("expected group not found, got %q", text)
+	}
+
+	send(fmt.Sprintf("/max_members %d 1", groupID))
+	if text := lastText(); text != localizer.MustLocalizeWithTemplate(locale.MaxMembersSet, "Test Group", "1") {
+		t.Errorf("expected limit to be set, got %q", text)
+	}
+
+	// A full group rejects new members
+	join()
+	if text := lastText(); text != localizer.MustLocalizeWithTemplate(locale.DeepLinkGroupFull, "Test Group") {
+		t.Errorf("expected full group rejection, got %q", text)
+	}
+	if joined, _ := membershipRepo.GetMembership(ctx, groupID, newMemberID); joined != nil {
+		t.Fatalf("expected no membership for a full group, got %+v", joined)
+	}
+
+	// Removing the limit lets the member join
+	send(fmt.Sprintf("/max_members %d off", groupID))
+	if text := lastText(); text != localizer.MustLocalizeWithTemplate(locale.MaxMembersRemoved, "Test Group") {
+		t.Errorf("expected limit to be removed, got %q", text)
+	}
+	join()
+	if joined, _ := membershipRepo.GetMembership(ctx, groupID, newMemberID); joined == nil || joined.Status != domain.MembershipStatusActive {
+		t.Fatalf("expected an active membership after removing the limit, got %+v", joined)
+	}
+
+	// The limit can't be lowered below the current members
+	send(fmt.Sprintf("/max_members %d 1", groupID))
+	if text := lastText(); text != localizer.MustLocalizeWithTemplate(locale.MaxMembersBelowActive, "Test Group", "2") {
+		t.Errorf("expected rejection of a limit below the member count, got %q", text)
+	}
+	if group, _ := groupRepo.GetGroup(ctx, groupID); group.MaxMembers != nil {
+		t.Errorf("expected no limit to be stored, got %d", *group.MaxMembers)
+	}
+}
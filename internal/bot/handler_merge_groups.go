@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// mergeGroupsCommand merges a duplicate group into the canonical one
+const mergeGroupsCommand = "/merge_groups"
+
+// HandleMergeGroups handles the /merge_groups command (/merge_groups <source_id> <target_id>).
+// Without arguments it shows the usage together with groups that look like duplicates.
+func (h *BotHandler) HandleMergeGroups(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send merge groups reply", "error", err)
+		}
+	}
+
+	sourceID, targetID, ok := parseMergeGroupsArgs(update.Message.Text)
+	if !ok {
+		reply(h.mergeGroupsUsage(ctx))
+		return
+	}
+	if sourceID == targetID {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.MergeGroupsSameGroup))
+		return
+	}
+
+	source, err := h.groupRepo.GetGroup(ctx, sourceID)
+	if err != nil {
+		h.logger.Error("failed to get source group", "group_id", sourceID, "error", err)
+	}
+	target, err := h.groupRepo.GetGroup(ctx, targetID)
+	if err != nil {
+		h.logger.Error("failed to get target group", "group_id", targetID, "error", err)
+	}
+	if source == nil || target == nil {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.MergeGroupsNotFound))
+		return
+	}
+	if target.Status == domain.GroupStatusDeleted {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.MergeGroupsTargetDeleted))
+		return
+	}
+
+	if err := h.groupRepo.MergeGroups(ctx, sourceID, targetID); err != nil {
+		h.logger.Error("failed to merge groups", "source_group_id", sourceID, "target_group_id", targetID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.MergeGroupsError))
+		return
+	}
+
+	h.logger.Info("groups merged", "source_group_id", sourceID, "target_group_id", targetID, "admin_id", userID)
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MergeGroupsSuccess,
+		source.Name, fmt.Sprintf("%d", sourceID), target.Name, fmt.Sprintf("%d", targetID)))
+
+	h.logAdminAction(userID, "merge_groups", sourceID, fmt.Sprintf("Merged group %s (ID %d) into group %s (ID %d)", source.Name, sourceID, target.Name, targetID))
+}
+
+// mergeGroupsUsage builds the usage text followed by the detected duplicate groups
+func (h *BotHandler) mergeGroupsUsage(ctx context.Context) string {
+	var text strings.Builder
+	text.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.MergeGroupsUsage))
+	text.WriteString("\n\n")
+
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		return strings.TrimSpace(text.String())
+	}
+
+	duplicates := findDuplicateGroups(groups)
+	if len(duplicates) == 0 {
+		text.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.MergeGroupsNoDuplicates))
+		return text.String()
+	}
+
+	text.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.MergeGroupsDuplicatesTitle))
+	for _, duplicate := range duplicates {
+		ids := make([]string, 0, len(duplicate))
+		for _, group := range duplicate {
+			ids = append(ids, fmt.Sprintf("%d", group.ID))
+		}
+		text.WriteString("\n" + h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.MergeGroupsDuplicateItem, duplicate[0].Name, strings.Join(ids, ", ")))
+	}
+
+	return text.String()
+}
+
+// findDuplicateGroups returns not deleted groups sharing the same name (ignoring case), which
+// usually means one chat was registered twice, e.g. before and after an upgrade to a supergroup.
+// Sets are ordered by the ID of their first group, groups within a set by ID.
+func findDuplicateGroups(groups []*domain.Group) [][]*domain.Group {
+	byName := make(map[string][]*domain.Group)
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(group.Name))
+		byName[name] = append(byName[name], group)
+	}
+
+	var duplicates [][]*domain.Group
+	for _, set := range byName {
+		if len(set) < 2 {
+			continue
+		}
+		sort.Slice(set, func(i, j int) bool { return set[i].ID < set[j].ID })
+		duplicates = append(duplicates, set)
+	}
+	sort.Slice(duplicates, func(i, j int) bool { return duplicates[i][0].ID < duplicates[j][0].ID })
+
+	return duplicates
+}
+
+// parseMergeGroupsArgs parses "/merge_groups <source_id> <target_id>"
+func parseMergeGroupsArgs(text string) (sourceID int64, targetID int64, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != mergeGroupsCommand && !strings.HasPrefix(command, mergeGroupsCommand+"@") {
+		return 0, 0, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+
+	sourceID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || sourceID <= 0 {
+		return 0, 0, false
+	}
+	targetID, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || targetID <= 0 {
+		return 0, 0, false
+	}
+
+	return sourceID, targetID, true
+}
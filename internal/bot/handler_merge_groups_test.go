@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParseMergeGroupsArgs(t *testing.T) {
+	tests := []struct {
+		text     string
+		sourceID int64
+		targetID int64
+		ok       bool
+	}{
+		{"/merge_groups 3 7", 3, 7, true},
+		{"/merge_groups@PredictionBot  3   7 ", 3, 7, true},
+		{"/merge_groups", 0, 0, false},
+		{"/merge_groups 3", 0, 0, false},
+		{"/merge_groups 3 x", 0, 0, false},
+		{"/merge_groups 0 7", 0, 0, false},
+		{"/merge_groupsx 3 7", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		sourceID, targetID, ok := parseMergeGroupsArgs(tt.text)
+		if ok != tt.ok || sourceID != tt.sourceID || targetID != tt.targetID {
+			t.Errorf("parseMergeGroupsArgs(%q) = %d, %d, %t; want %d, %d, %t",
+				tt.text, sourceID, targetID, ok, tt.sourceID, tt.targetID, tt.ok)
+		}
+	}
+}
+
+func TestFindDuplicateGroups(t *testing.T) {
+	groups := []*domain.Group{
+		{ID: 5, Name: "Friends", Status: domain.GroupStatusActive},
+		{ID: 2, Name: "Work", Status: domain.GroupStatusActive},
+		{ID: 3, Name: "friends ", Status: domain.GroupStatusPending},
+		{ID: 4, Name: "Work", Status: domain.GroupStatusDeleted},
+		{ID: 1, Name: "Family", Status: domain.GroupStatusActive},
+	}
+
+	duplicates := findDuplicateGroups(groups)
+	if len(duplicates) != 1 {
+		t.Fatalf("expected one set of duplicates, got %d", len(duplicates))
+	}
+	if len(duplicates[0]) != 2 || duplicates[0][0].ID != 3 || duplicates[0][1].ID != 5 {
+		t.Errorf("expected groups 3 and 5, got %+v", duplicates[0])
+	}
+}
+
+func TestHandleMergeGroups(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, sourceID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	groupRepo := storage.NewGroupRepository(queue)
+	target := &domain.Group{TelegramChatID: -100600, Name: "Test Group", CreatedAt: time.Now(), CreatedBy: adminID}
+	if err := groupRepo.CreateGroup(ctx, target); err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:    &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo: groupRepo,
+		logger:    logger.New(logger.ERROR),
+		localizer: localizer,
+	}
+	send := func(text string) string {
+		t.Helper()
+		h.HandleMergeGroups(ctx, b, &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: adminID},
+				Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		})
+		texts := rec.texts()
+		return texts[len(texts)-1]
+	}
+
+	// Both groups share a name, so they are listed as likely duplicates
+	reply := send("/merge_groups")
+	duplicate := localizer.MustLocalizeWithTemplate(locale.MergeGroupsDuplicateItem, "Test Group", "1, 2")
+	if !strings.HasPrefix(reply, localizer.MustLocalize(locale.MergeGroupsUsage)) || !strings.Contains(reply, duplicate) {
+		t.Errorf("expected usage with duplicate %q, got %q", duplicate, reply)
+	}
+
+	if reply := send("/merge_groups 1 1"); reply != localizer.MustLocalize(locale.MergeGroupsSameGroup) {
+		t.Errorf("expected same group reply, got %q", reply)
+	}
+	if reply := send("/merge_groups 1 99"); reply != localizer.MustLocalize(locale.MergeGroupsNotFound) {
+		t.Errorf("expected not found reply, got %q", reply)
+	}
+
+	reply = send("/merge_groups 1 2")
+	expected := localizer.MustLocalizeWithTemplate(locale.MergeGroupsSuccess, "Test Group", "1", "Test Group", "2")
+	if reply != expected {
+		t.Errorf("expected success reply %q, got %q", expected, reply)
+	}
+	source, err := groupRepo.GetGroup(ctx, sourceID)
+	if err != nil {
+		t.Fatalf("failed to get group: %v", err)
+	}
+	if source.Status != domain.GroupStatusDeleted {
+		t.Errorf("expected merged group to be deleted, got %s", source.Status)
+	}
+
+	// A deleted group cannot receive another merge
+	if reply := send("/merge_groups 2 1"); reply != localizer.MustLocalize(locale.MergeGroupsTargetDeleted) {
+		t.Errorf("expected target deleted reply, got %q", reply)
+	}
+}
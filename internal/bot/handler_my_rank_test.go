@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestHandleMy_Rank(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	for i, score := range []int{40, 25, 25} {
+		userID := int64(100 + i)
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+		if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: userID, GroupID: groupID, Score: score}); err != nil {
+			t.Fatalf("failed to update rating: %v", err)
+		}
+	}
+	newcomer := int64(200)
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: newcomer, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:               &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:            groupRepo,
+		groupContextResolver: domain.NewGroupContextResolver(groupRepo),
+		ratingCalculator:     domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		achievementTracker:   domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		logger:               log,
+		localizer:            localizer,
+	}
+	my := func(userID int64) string {
+		t.Helper()
+		h.HandleMy(ctx, b, &models.Update{
+			Message: &models.Message{Text: "/my", From: &models.User{ID: userID}, Chat: models.Chat{ID: userID}},
+		})
+		texts := rec.texts()
+		return texts[len(texts)-1]
+	}
+
+	tests := []struct {
+		userID   int64
+		expected string
+	}{
+		{100, localizer.MustLocalizeWithTemplate(locale.MyStatsRank, "1", "3")},
+		{101, localizer.MustLocalizeWithTemplate(locale.MyStatsRank, "2", "3")},
+		{102, localizer.MustLocalizeWithTemplate(locale.MyStatsRank, "2", "3")},
+		{newcomer, localizer.MustLocalize(locale.MyStatsRankNone)},
+	}
+	for _, tt := range tests {
+		if text := my(tt.userID); !strings.Contains(text, tt.expected) {
+			t.Errorf("user %d: expected %q in /my, got %q", tt.userID, tt.expected, text)
+		}
+	}
+}
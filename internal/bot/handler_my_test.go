@@ -46,8 +46,8 @@ func TestPersonalStatsCompleteness(t *testing.T) {
 			eventRepo := storage.NewEventRepository(queue)
 			logger := &mockLogger{}
 
-			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
-			achievementTracker := domain.NewAchievementTracker(achievementRepo, ratingRepo, predictionRepo, eventRepo, logger)
+			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
+			achievementTracker := domain.NewAchievementTracker(achievementRepo, ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 			ctx := context.Background()
 
@@ -0,0 +1,119 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// outcomeNotificationModes lists the selectable modes in button order
+var outcomeNotificationModes = []domain.OutcomeNotificationMode{
+	domain.OutcomeNotificationsImmediate,
+	domain.OutcomeNotificationsDigest,
+	domain.OutcomeNotificationsOff,
+}
+
+// HandleNotifications handles the /notifications command (choose how event outcomes are delivered)
+func (h *BotHandler) HandleNotifications(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+
+	mode, err := h.notificationSettingsRepo.GetOutcomeNotificationMode(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get outcome notification mode", "user_id", userID, "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.NotificationSettingsErrorGet),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.NotificationSettingsTitle, h.outcomeNotificationModeLabel(ctx, mode)),
+		ReplyMarkup: h.buildOutcomeNotificationsKeyboard(ctx, mode),
+	})
+	if err != nil {
+		h.logger.Error("failed to send notification settings", "user_id", userID, "error", err)
+	}
+}
+
+// buildOutcomeNotificationsKeyboard builds one button per mode, marking the current one
+func (h *BotHandler) buildOutcomeNotificationsKeyboard(ctx context.Context, current domain.OutcomeNotificationMode) *models.InlineKeyboardMarkup {
+	var buttons [][]models.InlineKeyboardButton
+	for _, mode := range outcomeNotificationModes {
+		text := h.outcomeNotificationModeLabel(ctx, mode)
+		if mode == current {
+			text += " ✓"
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         text,
+				CallbackData: mustEncodeCallback(cbOutcomeNotifications, string(mode)),
+			},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// outcomeNotificationModeLabel returns the localized name of a mode
+func (h *BotHandler) outcomeNotificationModeLabel(ctx context.Context, mode domain.OutcomeNotificationMode) string {
+	switch mode {
+	case domain.OutcomeNotificationsDigest:
+		return h.requestLocalizer(ctx).MustLocalize(locale.NotificationSettingsDigest)
+	case domain.OutcomeNotificationsOff:
+		return h.requestLocalizer(ctx).MustLocalize(locale.NotificationSettingsOff)
+	default:
+		return h.requestLocalizer(ctx).MustLocalize(locale.NotificationSettingsImmediate)
+	}
+}
+
+// handleOutcomeNotificationsCallback stores the selected mode
+func (h *BotHandler) handleOutcomeNotificationsCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	if err := cb.Expect(cbOutcomeNotifications, 1); err != nil {
+		h.logger.Error("invalid outcome notifications callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	field, err := cb.Field(0)
+	if err != nil {
+		h.logger.Error("failed to parse outcome notification mode", "error", err)
+		return
+	}
+	mode := domain.OutcomeNotificationMode(field)
+
+	if err := h.notificationSettingsRepo.SetOutcomeNotificationMode(ctx, userID, mode); err != nil {
+		h.logger.Error("failed to update outcome notification mode", "user_id", userID, "mode", mode, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.NotificationSettingsErrorUpdate),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	h.logger.Info("outcome notification mode updated", "user_id", userID, "mode", mode)
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.NotificationSettingsUpdated, h.outcomeNotificationModeLabel(ctx, mode)),
+	})
+
+	// Update the message with the new current mode
+	if callback.Message.Message != nil {
+		_, _ = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      callback.Message.Message.Chat.ID,
+			MessageID:   callback.Message.Message.ID,
+			Text:        h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.NotificationSettingsTitle, h.outcomeNotificationModeLabel(ctx, mode)),
+			ReplyMarkup: h.buildOutcomeNotificationsKeyboard(ctx, mode),
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleOrphans handles the /orphans command.
+// The bot cannot list the polls of a chat, so this reports events whose poll message was
+// found deleted when stopping or replacing it (e.g. after restoring an older database).
+func (h *BotHandler) HandleOrphans(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+
+	events, err := h.eventManager.GetPollMissingEvents(ctx)
+	if err != nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.OrphansError),
+		})
+		return
+	}
+
+	if len(events) == 0 {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.OrphansEmpty),
+		})
+		return
+	}
+
+	// Active events can still be fixed by re-posting the poll, so they are listed first
+	var active, closed []string
+	groupNames := make(map[int64]string)
+	for _, event := range events {
+		name, ok := groupNames[event.GroupID]
+		if !ok {
+			name = fmt.Sprintf("%d", event.GroupID)
+			group, err := h.groupRepo.GetGroup(ctx, event.GroupID)
+			if err != nil {
+				h.logger.Error("failed to get group for orphaned poll", "event_id", event.ID, "group_id", event.GroupID, "error", err)
+			} else if group != nil {
+				name = group.Name
+			}
+			groupNames[event.GroupID] = name
+		}
+
+		item := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.OrphansItem, fmt.Sprintf("%d", event.ID), name, event.Question)
+		if event.Status == domain.EventStatusActive {
+			active = append(active, item)
+		} else {
+			closed = append(closed, item)
+		}
+	}
+
+	var entries []string
+	if len(active) > 0 {
+		entries = append(entries, h.requestLocalizer(ctx).MustLocalize(locale.OrphansActiveSection))
+		entries = append(entries, active...)
+	}
+	if len(closed) > 0 {
+		entries = append(entries, h.requestLocalizer(ctx).MustLocalize(locale.OrphansClosedSection))
+		entries = append(entries, closed...)
+	}
+
+	header := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.OrphansTitle, fmt.Sprintf("%d", len(events)))
+	for _, text := range chunkMessage(header, entries, telegramMessageLimit) {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send orphaned polls report", "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestHandleOrphans(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	eventManager := domain.NewEventManager(eventRepo, storage.NewPredictionRepository(queue), nil, log)
+	h := &BotHandler{
+		bot:          b,
+		eventManager: eventManager,
+		groupRepo:    storage.NewGroupRepository(queue),
+		config:       &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		logger:       log,
+		localizer:    localizer,
+	}
+
+	command := func() string {
+		before := len(rec.texts())
+		h.HandleOrphans(ctx, b, &models.Update{Message: &models.Message{
+			From: &models.User{ID: adminID},
+			Chat: models.Chat{ID: adminID},
+			Text: "/orphans",
+		}})
+		texts := rec.texts()
+		if len(texts) != before+1 {
+			t.Fatalf("expected one reply, got %v", texts[before:])
+		}
+		return texts[before]
+	}
+
+	if text := command(); text != localizer.MustLocalize(locale.OrphansEmpty) {
+		t.Fatalf("expected empty report, got %q", text)
+	}
+
+	questions := []string{"Active with missing poll?", "Resolved with missing poll?", "Poll still there?"}
+	var ids []int64
+	for _, question := range questions {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  question,
+			Options:   []string{"Yes", "No"},
+			CreatedAt: time.Now(),
+			Deadline:  time.Now().Add(24 * time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: adminID,
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		ids = append(ids, event.ID)
+	}
+	for _, id := range ids[:2] {
+		if err := eventManager.MarkPollMessageMissing(ctx, id); err != nil {
+			t.Fatalf("failed to mark poll missing: %v", err)
+		}
+	}
+	if err := eventRepo.ResolveEvent(ctx, ids[1], 0); err != nil {
+		t.Fatalf("failed to resolve event: %v", err)
+	}
+
+	text := command()
+	active := strings.Index(text, localizer.MustLocalize(locale.OrphansActiveSection))
+	closed := strings.Index(text, localizer.MustLocalize(locale.OrphansClosedSection))
+	if active < 0 || closed < 0 {
+		t.Fatalf("expected active and closed sections, got %q", text)
+	}
+	if i := strings.Index(text, questions[0]); i < active || i > closed {
+		t.Errorf("expected the active event in the active section, got %q", text)
+	}
+	if i := strings.Index(text, questions[1]); i < closed {
+		t.Errorf("expected the resolved event in the closed section, got %q", text)
+	}
+	if strings.Contains(text, questions[2]) {
+		t.Errorf("expected events with a poll not to be listed, got %q", text)
+	}
+	if !strings.Contains(text, "Test Group") {
+		t.Errorf("expected the group name, got %q", text)
+	}
+}
+
+func TestIsMessageNotFoundError_PollMessages(t *testing.T) {
+	for _, description := range []string{
+		"bad request, Bad Request: message to edit not found",
+		"bad request, Bad Request: message to stop not found",
+		"bad request, Bad Request: message with poll to stop not found",
+	} {
+		if !isMessageNotFoundError(&telegramAPIError{Code: 400, Description: description}) {
+			t.Errorf("expected %q to be a missing message", description)
+		}
+	}
+}
+
+func TestCompleteResolution_MarksMissingPoll(t *testing.T) {
+	ctx := context.Background()
+	creatorID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+	rec.stopPollError = "Bad Request: message to stop not found"
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, creatorID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+
+	fsm := NewEventResolutionFSM(
+		storage.NewFSMStorage(queue, log),
+		b,
+		domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		predictionRepo,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		domain.NewNotificationService(b, eventRepo, predictionRepo, ratingRepo, storage.NewReminderRepository(queue), log, localizer),
+		&config.Config{Timezone: time.UTC},
+		log,
+		localizer,
+	)
+
+	now := time.Now()
+	event := &domain.Event{
+		GroupID:       groupID,
+		Question:      "Will it rain?",
+		Options:       []string{"Yes", "No"},
+		CreatedAt:     now.Add(-2 * time.Hour),
+		Deadline:      now.Add(-time.Hour),
+		Status:        domain.EventStatusActive,
+		EventType:     domain.EventTypeBinary,
+		CreatedBy:     creatorID,
+		PollID:        "poll_deleted",
+		PollMessageID: 55,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	resolutionContext := &domain.EventResolutionContext{EventID: event.ID, ChatID: creatorID}
+	if err := fsm.completeResolution(ctx, creatorID, resolutionContext, 0, nil); err != nil {
+		t.Fatalf("completeResolution failed: %v", err)
+	}
+
+	loaded, err := eventRepo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if !loaded.PollMessageMissing {
+		t.Error("expected the event to be flagged with a missing poll")
+	}
+	if loaded.Status != domain.EventStatusResolved {
+		t.Errorf("expected the event to stay resolved, got %s", loaded.Status)
+	}
+}
@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/encoding"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// failingMembersRepo fails to load the members of one group
+type failingMembersRepo struct {
+	domain.GroupMembershipRepository
+	failGroupID int64
+}
+
+func (r *failingMembersRepo) GetGroupMembers(ctx context.Context, groupID int64) ([]*domain.GroupMembership, error) {
+	if groupID == r.failGroupID {
+		return nil, errors.New("database is locked")
+	}
+	return r.GroupMembershipRepository.GetGroupMembers(ctx, groupID)
+}
+
+func TestListsReportSkippedItems(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	encoder, err := encoding.NewBaseNEncoder("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	broken := &domain.Group{TelegramChatID: -100600, Name: "Broken Group", CreatedBy: adminID, CreatedAt: time.Now()}
+	if err := groupRepo.CreateGroup(ctx, broken); err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	for _, id := range []int64{groupID, broken.ID} {
+		membership := &domain.GroupMembership{GroupID: id, UserID: adminID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		bot:                 b,
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:           groupRepo,
+		groupMembershipRepo: &failingMembersRepo{GroupMembershipRepository: membershipRepo, failGroupID: broken.ID},
+		forumTopicRepo:      storage.NewForumTopicRepository(queue),
+		deepLinkService:     domain.NewDeepLinkService("testbot", encoder),
+		logger:              logger.New(logger.ERROR),
+		localizer:           localizer,
+	}
+	footer := localizer.MustLocalizeWithTemplate(locale.ErrorItemsNotLoaded, "1")
+
+	for _, command := range []string{"/list_groups", "/groups"} {
+		message := &models.Message{From: &models.User{ID: adminID}, Chat: models.Chat{ID: adminID}, Text: command}
+		if command == "/list_groups" {
+			h.HandleListGroups(ctx, b, &models.Update{Message: message})
+		} else {
+			h.HandleGroups(ctx, b, &models.Update{Message: message})
+		}
+
+		texts := rec.texts()
+		text := texts[len(texts)-1]
+		if !strings.Contains(text, "Test Group") || strings.Contains(text, "Broken Group") {
+			t.Errorf("%s: expected only the loaded group to be listed, got %q", command, text)
+		}
+		if !strings.HasSuffix(strings.TrimSpace(text), footer) {
+			t.Errorf("%s: expected the skipped items footer, got %q", command, text)
+		}
+	}
+
+	// Nothing skipped, no footer
+	h.groupMembershipRepo = membershipRepo
+	h.HandleGroups(ctx, b, &models.Update{Message: &models.Message{From: &models.User{ID: adminID}, Chat: models.Chat{ID: adminID}, Text: "/groups"}})
+	texts := rec.texts()
+	if text := texts[len(texts)-1]; !strings.Contains(text, "Broken Group") || strings.Contains(text, footer) {
+		t.Errorf("expected the full list without a footer, got %q", text)
+	}
+}
@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// failingSavePredictionRepo is a prediction repository whose saves fail
+type failingSavePredictionRepo struct {
+	domain.PredictionRepository
+}
+
+func (r *failingSavePredictionRepo) SavePrediction(ctx context.Context, prediction *domain.Prediction) error {
+	return errors.New("database is locked")
+}
+
+func TestHandlePollAnswer_ParticipationAtVote(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	voterID := int64(300)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: voterID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	event := &domain.Event{
+		GroupID:   groupID,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  time.Now().Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: adminID,
+		PollID:    "poll-1",
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	_, b := newRecordingTelegramServer(t)
+	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log)
+	ratingCalculator.SetParticipationAtVote(true)
+	h := &BotHandler{
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:           storage.NewGroupRepository(queue),
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      &failingSavePredictionRepo{PredictionRepository: predictionRepo},
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    ratingCalculator,
+		logger:              log,
+		localizer:           localizer,
+	}
+	vote := func() {
+		t.Helper()
+		h.HandlePollAnswer(ctx, b, &models.Update{
+			PollAnswer: &models.PollAnswer{PollID: "poll-1", User: &models.User{ID: voterID}, OptionIDs: []int{0}},
+		})
+	}
+	score := func() int {
+		t.Helper()
+		rating, err := ratingRepo.GetRating(ctx, voterID, groupID)
+		if err != nil {
+			t.Fatalf("failed to get rating: %v", err)
+		}
+		return rating.Score
+	}
+
+	// A vote that couldn't be saved earns nothing
+	vote()
+	if got := score(); got != 0 {
+		t.Errorf("expected the score to stay 0 when the prediction isn't saved, got %d", got)
+	}
+
+	// A saved vote earns the point and is marked, so resolution doesn't credit it again
+	h.predictionRepo = predictionRepo
+	vote()
+	if got := score(); got != domain.ParticipationPoints {
+		t.Errorf("expected score %d after the vote, got %d", domain.ParticipationPoints, got)
+	}
+	prediction, err := predictionRepo.GetPredictionByUserAndEvent(ctx, voterID, event.ID)
+	if err != nil {
+		t.Fatalf("failed to get prediction: %v", err)
+	}
+	if prediction == nil || !prediction.ParticipationAwarded {
+		t.Errorf("expected the saved prediction to be marked as awarded, got %+v", prediction)
+	}
+}
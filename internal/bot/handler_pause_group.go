@@ -0,0 +1,202 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// handlePauseGroupCallback handles pausing active groups and resuming paused ones.
+// Pausing is separate from soft delete: a paused group keeps its status apart from deleted groups
+// and only goes back to active through resume.
+func (h *BotHandler) handlePauseGroupCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	chatID := callback.Message.Message.Chat.ID
+
+	switch cb.Namespace {
+	case cbPauseGroupSelect:
+		h.sendGroupStatusSelection(ctx, b, chatID, domain.GroupStatusActive, cbPauseGroupConfirm,
+			locale.PauseGroupTitle, locale.PauseGroupSelectPrompt, locale.PauseGroupEmpty)
+	case cbResumeGroupSelect:
+		h.sendGroupStatusSelection(ctx, b, chatID, domain.GroupStatusPaused, cbResumeGroupConfirm,
+			locale.ResumeGroupTitle, locale.ResumeGroupSelectPrompt, locale.ResumeGroupEmpty)
+	case cbPauseGroupConfirm:
+		h.changeGroupPause(ctx, b, chatID, userID, cb, domain.GroupStatusActive, domain.GroupStatusPaused)
+	case cbResumeGroupConfirm:
+		h.changeGroupPause(ctx, b, chatID, userID, cb, domain.GroupStatusPaused, domain.GroupStatusActive)
+	}
+}
+
+// sendGroupStatusSelection sends a keyboard of the groups with the given status
+func (h *BotHandler) sendGroupStatusSelection(ctx context.Context, b *bot.Bot, chatID int64, status domain.GroupStatus, confirmNamespace string, titleKey, promptKey, emptyKey string) {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		if group.Status != status {
+			continue
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{Text: group.Name, CallbackData: mustEncodeCallback(confirmNamespace, group.ID)},
+		})
+	}
+
+	if len(buttons) == 0 {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   h.localizeKey(ctx, emptyKey),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        h.localizeKey(ctx, titleKey) + "\n\n" + h.localizeKey(ctx, promptKey),
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: buttons},
+	})
+	if err != nil {
+		h.logger.Error("failed to send group selection", "error", err)
+	}
+}
+
+// changeGroupPause moves a group from one status to the other. Groups that changed status in the
+// meantime (e.g. were soft deleted) are left alone.
+func (h *BotHandler) changeGroupPause(ctx context.Context, b *bot.Bot, chatID int64, userID int64, cb *Callback, from, to domain.GroupStatus) {
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send pause group reply", "error", err)
+		}
+	}
+
+	if err := cb.Expect(cb.Namespace, 1); err != nil {
+		h.logger.Error("invalid pause group callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupMembersErrorGroup))
+		return
+	}
+
+	if group == nil || group.Status != from {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound))
+		return
+	}
+
+	if err := h.groupRepo.UpdateGroupStatus(ctx, groupID, to); err != nil {
+		h.logger.Error("failed to update group status", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.PauseGroupError))
+		return
+	}
+
+	if to == domain.GroupStatusPaused {
+		h.logAdminAction(userID, "pause_group", groupID, fmt.Sprintf("Paused group %s", group.Name))
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.PauseGroupSuccess, group.Name))
+		return
+	}
+
+	h.logAdminAction(userID, "resume_group", groupID, fmt.Sprintf("Resumed group %s", group.Name))
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ResumeGroupSuccess, group.Name))
+}
+
+// pausedGroupOfPoll returns the poll's event and its group when that group is paused.
+// Paused groups are left out of the user's groups, so their polls don't match any active event.
+func (h *BotHandler) pausedGroupOfPoll(ctx context.Context, pollID string) (*domain.Event, *domain.Group) {
+	event, err := h.eventManager.GetEventByPollID(ctx, pollID)
+	if err != nil {
+		return nil, nil
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", event.GroupID, "error", err)
+		return nil, nil
+	}
+	if group == nil || group.Status != domain.GroupStatusPaused {
+		return nil, nil
+	}
+
+	return event, group
+}
+
+// userPausedGroups returns the paused groups where the user is an active member
+func (h *BotHandler) userPausedGroups(ctx context.Context, userID int64) []*domain.Group {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		return nil
+	}
+
+	var paused []*domain.Group
+	for _, group := range groups {
+		if group.Status != domain.GroupStatusPaused {
+			continue
+		}
+		isMember, err := h.groupMembershipRepo.HasActiveMembership(ctx, group.ID, userID)
+		if err != nil {
+			h.logger.Error("failed to check group membership", "group_id", group.ID, "user_id", userID, "error", err)
+			continue
+		}
+		if isMember {
+			paused = append(paused, group)
+		}
+	}
+
+	return paused
+}
+
+// replyGroupsPaused tells a user without active groups that their groups are paused.
+// It reports whether the user had paused groups.
+func (h *BotHandler) replyGroupsPaused(ctx context.Context, b *bot.Bot, chatID int64, userID int64) bool {
+	paused := h.userPausedGroups(ctx, userID)
+	if len(paused) == 0 {
+		return false
+	}
+
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupPausedCreateRejected, paused[0].Name),
+	})
+	if err != nil {
+		h.logger.Error("failed to send group paused message", "error", err)
+	}
+	h.logger.Info("event creation rejected: group paused", "user_id", userID, "group_id", paused[0].ID)
+	return true
+}
@@ -0,0 +1,160 @@
+package bot
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestPauseGroup(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	voterID := int64(300)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: voterID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	event := &domain.Event{
+		GroupID:        groupID,
+		Question:       "Will it rain?",
+		Options:        []string{"Yes", "No"},
+		CreatedAt:      time.Now(),
+		Deadline:       time.Now().Add(24 * time.Hour),
+		Status:         domain.EventStatusActive,
+		EventType:      domain.EventTypeBinary,
+		CreatedBy:      adminID,
+		PollID:         "poll-1",
+		AllowsRevoting: true,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC, VoteRejectionNotices: true}
+	rec, b := newRecordingTelegramServer(t)
+	fsm := NewEventCreationFSM(storage.NewFSMStorage(queue, log), b, nil, nil, domain.NewGroupContextResolver(groupRepo), groupRepo, nil, nil,
+		membershipRepo, storage.NewUserRepository(queue), nil, cfg, log, localizer)
+	h := &BotHandler{
+		config:              cfg,
+		eventCreationFSM:    fsm,
+		groupRepo:           groupRepo,
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      predictionRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		logger:              log,
+		localizer:           localizer,
+	}
+	callback := func(namespace string) {
+		t.Helper()
+		data := mustEncodeCallback(namespace, groupID)
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		h.handlePauseGroupCallback(ctx, b, &models.CallbackQuery{
+			ID:      "cb",
+			From:    models.User{ID: adminID},
+			Data:    data,
+			Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}}},
+		}, adminID, cb)
+	}
+	status := func() domain.GroupStatus {
+		t.Helper()
+		group, err := groupRepo.GetGroup(ctx, groupID)
+		if err != nil {
+			t.Fatalf("failed to get group: %v", err)
+		}
+		return group.Status
+	}
+	vote := func(option int) *domain.Prediction {
+		t.Helper()
+		h.HandlePollAnswer(ctx, b, &models.Update{
+			PollAnswer: &models.PollAnswer{PollID: "poll-1", User: &models.User{ID: voterID}, OptionIDs: []int{option}},
+		})
+		prediction, err := predictionRepo.GetPredictionByUserAndEvent(ctx, voterID, event.ID)
+		if err != nil {
+			t.Fatalf("failed to get prediction: %v", err)
+		}
+		return prediction
+	}
+
+	if prediction := vote(0); prediction == nil {
+		t.Fatal("expected the vote in an active group to be saved")
+	}
+
+	callback(cbPauseGroupConfirm)
+	if got := status(); got != domain.GroupStatusPaused {
+		t.Fatalf("expected status %s, got %s", domain.GroupStatusPaused, got)
+	}
+
+	// Paused groups are hidden from the member's groups
+	if groups, _ := groupRepo.GetUserGroups(ctx, voterID); len(groups) != 0 {
+		t.Errorf("expected no active groups, got %d", len(groups))
+	}
+
+	// Votes are not counted and the voter is told why
+	if prediction := vote(1); prediction == nil || prediction.Option != 0 {
+		t.Errorf("expected the earlier vote to stay, got %+v", prediction)
+	}
+	rejected := localizer.MustLocalizeWithTemplate(locale.GroupPausedVoteRejected, "Test Group")
+	if !slices.Contains(rec.texts(), rejected) {
+		t.Errorf("expected the paused notice %q, got %v", rejected, rec.texts())
+	}
+
+	// Voting again within the cooldown doesn't repeat the notice
+	vote(0)
+	if count := len(slices.DeleteFunc(rec.texts(), func(text string) bool { return text != rejected })); count != 1 {
+		t.Errorf("expected the paused notice once, got it %d times", count)
+	}
+
+	// Event creation is rejected with the paused message
+	h.HandleCreateEvent(ctx, b, &models.Update{
+		Message: &models.Message{Text: "/create_event", From: &models.User{ID: voterID}, Chat: models.Chat{ID: voterID}},
+	})
+	texts := rec.texts()
+	if want := localizer.MustLocalizeWithTemplate(locale.GroupPausedCreateRejected, "Test Group"); texts[len(texts)-1] != want {
+		t.Errorf("expected %q, got %q", want, texts[len(texts)-1])
+	}
+
+	// Pausing is separate from soft delete: restore doesn't touch paused groups
+	callback(cbRestoreGroupConfirm)
+	if got := status(); got != domain.GroupStatusPaused {
+		t.Errorf("expected restore to leave the group paused, got %s", got)
+	}
+
+	callback(cbResumeGroupConfirm)
+	if got := status(); got != domain.GroupStatusActive {
+		t.Fatalf("expected status %s, got %s", domain.GroupStatusActive, got)
+	}
+	if prediction := vote(1); prediction == nil || prediction.Option != 1 {
+		t.Errorf("expected the vote to change after resume, got %+v", prediction)
+	}
+}
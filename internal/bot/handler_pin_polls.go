@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandlePinPolls handles the /pin_polls command (toggle poll pinning per group)
+func (h *BotHandler) HandlePinPolls(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	kb, err := h.buildPinPollsKeyboard(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	if kb == nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.PinPollsTitle),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to send pin polls settings", "error", err)
+	}
+}
+
+// buildPinPollsKeyboard builds toggle buttons for all active groups.
+// Returns nil keyboard if there are no active groups.
+func (h *BotHandler) buildPinPollsKeyboard(ctx context.Context) (*models.InlineKeyboardMarkup, error) {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+
+		state := " ❌"
+		if group.PinPolls {
+			state = " ✅"
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         "📌 " + group.Name + state,
+				CallbackData: mustEncodeCallback(cbPinPollsToggle, group.ID),
+			},
+		})
+	}
+
+	if len(buttons) == 0 {
+		return nil, nil
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, nil
+}
+
+// handlePinPollsCallback toggles poll pinning for the selected group
+func (h *BotHandler) handlePinPollsCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	if err := cb.Expect(cbPinPollsToggle, 1); err != nil {
+		h.logger.Error("invalid pin_polls callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status == domain.GroupStatusDeleted {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	pinPolls := !group.PinPolls
+	if err := h.groupRepo.UpdateGroupPinPolls(ctx, groupID, pinPolls); err != nil {
+		h.logger.Error("failed to update pin polls setting", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.PinPollsErrorUpdate),
+		})
+		return
+	}
+
+	answerKey := locale.PinPollsDisabled
+	if pinPolls {
+		answerKey = locale.PinPollsEnabled
+	}
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(answerKey, group.Name),
+	})
+
+	// Update keyboard with new toggle states
+	if callback.Message.Message != nil {
+		kb, err := h.buildPinPollsKeyboard(ctx)
+		if err != nil {
+			h.logger.Error("failed to rebuild pin polls keyboard", "error", err)
+		} else if kb != nil {
+			_, _ = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+				ChatID:      callback.Message.Message.Chat.ID,
+				MessageID:   callback.Message.Message.ID,
+				ReplyMarkup: kb,
+			})
+		}
+	}
+
+	h.logAdminAction(userID, "toggle_pin_polls", groupID, fmt.Sprintf("Set pin polls to %t for group %s", pinPolls, group.Name))
+}
@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// pointsLabelCommand renames points in the ratings of a group
+const pointsLabelCommand = "/points_label"
+
+// HandlePointsLabel handles the /points_label command (/points_label <group_id> <label|off>).
+// Without arguments it shows the usage together with the label of every group.
+func (h *BotHandler) HandlePointsLabel(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send points label reply", "error", err)
+		}
+	}
+
+	groupID, label, ok := parsePointsLabelArgs(update.Message.Text)
+	if !ok {
+		reply(h.pointsLabelUsage(ctx))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+	}
+	if group == nil || group.Status == domain.GroupStatusDeleted {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound))
+		return
+	}
+
+	if label != "" {
+		label, err = domain.NormalizePointsLabel(label)
+		if err != nil {
+			reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.PointsLabelInvalid, strconv.Itoa(domain.MaxPointsLabelLength)))
+			return
+		}
+	}
+
+	if err := h.groupRepo.UpdateGroupPointsLabel(ctx, groupID, label); err != nil {
+		h.logger.Error("failed to update points label", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.PointsLabelError))
+		return
+	}
+
+	if label == "" {
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.PointsLabelReset, group.Name))
+		h.logAdminAction(userID, "reset_points_label", groupID, fmt.Sprintf("Reset the points label of group %s", group.Name))
+		return
+	}
+
+	// Show the label as members will see it
+	example := []string{domain.FormatPoints(h.requestLocalizer(ctx), 1, label), domain.FormatPoints(h.requestLocalizer(ctx), 3, label), domain.FormatPoints(h.requestLocalizer(ctx), 10, label)}
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.PointsLabelSet, group.Name, strings.Join(example, ", ")))
+	h.logAdminAction(userID, "set_points_label", groupID, fmt.Sprintf("Set the points label of group %s to %q", group.Name, label))
+}
+
+// pointsLabelUsage builds the usage text followed by the points label of every group
+func (h *BotHandler) pointsLabelUsage(ctx context.Context) string {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+	}
+
+	var lines []string
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+
+		label := group.PointsLabel
+		if label == "" {
+			label = h.requestLocalizer(ctx).MustLocalize(locale.PointsLabelDefault)
+		}
+		lines = append(lines, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.PointsLabelGroupItem, group.Name, fmt.Sprintf("%d", group.ID), label))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty))
+	}
+
+	return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.PointsLabelUsage, strings.Join(lines, "\n"))
+}
+
+// parsePointsLabelArgs parses "/points_label <group_id> <label|off>". An empty label means "off".
+func parsePointsLabelArgs(text string) (groupID int64, label string, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != pointsLabelCommand && !strings.HasPrefix(command, pointsLabelCommand+"@") {
+		return 0, "", false
+	}
+
+	id, label, _ := strings.Cut(strings.TrimSpace(args), " ")
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return 0, "", false
+	}
+
+	groupID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || groupID <= 0 {
+		return 0, "", false
+	}
+
+	if strings.EqualFold(label, "off") {
+		return groupID, "", true
+	}
+
+	return groupID, label, true
+}
@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParsePointsLabelArgs(t *testing.T) {
+	tests := []struct {
+		text    string
+		groupID int64
+		label   string // empty means the default label
+		ok      bool
+	}{
+		{"/points_label 3 coins", 3, "coins", true},
+		{"/points_label 3 coin, coins", 3, "coin, coins", true},
+		{"/points_label@PredictionBot 3  OFF ", 3, "", true},
+		{"/points_label", 0, "", false},
+		{"/points_label 3", 0, "", false},
+		{"/points_label x coins", 0, "", false},
+		{"/points_label -3 coins", 0, "", false},
+		{"/points_labelx 3 coins", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		groupID, label, ok := parsePointsLabelArgs(tt.text)
+		if ok != tt.ok || groupID != tt.groupID || label != tt.label {
+			t.Errorf("parsePointsLabelArgs(%q) = %d, %q, %t; want %d, %q, %t", tt.text, groupID, label, ok, tt.groupID, tt.label, tt.ok)
+		}
+	}
+}
+
+func TestHandlePointsLabel(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	groupRepo := storage.NewGroupRepository(queue)
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:    &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo: groupRepo,
+		logger:    logger.New(logger.ERROR),
+		localizer: localizer,
+	}
+	send := func(text string) string {
+		t.Helper()
+		h.HandlePointsLabel(ctx, b, &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: adminID},
+				Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		})
+		texts := rec.texts()
+		if len(texts) == 0 {
+			return ""
+		}
+		return texts[len(texts)-1]
+	}
+
+	// The usage lists every group with its label
+	item := localizer.MustLocalizeWithTemplate(locale.PointsLabelGroupItem, "Test Group", fmt.Sprintf("%d", groupID), localizer.MustLocalize(locale.PointsLabelDefault))
+	if text := send("/points_label"); !strings.Contains(text, item) {
+		t.Errorf("expected usage to list %q, got %q", item, text)
+	}
+
+	if text := send("/points_label 99 coins"); text != localizer.MustLocalize(locale.GroupErrorNotFound) {
+		t.Errorf("expected group not found, got %q", text)
+	}
+
+	invalid := localizer.MustLocalizeWithTemplate(locale.PointsLabelInvalid, fmt.Sprintf("%d", 20))
+	if text := send(fmt.Sprintf("/points_label %d a, b, c, d", groupID)); text != invalid {
+		t.Errorf("expected an invalid label, got %q", text)
+	}
+
+	set := localizer.MustLocalizeWithTemplate(locale.PointsLabelSet, "Test Group", "1 coin, 3 coins, 10 coins")
+	if text := send(fmt.Sprintf("/points_label %d coin,coins", groupID)); text != set {
+		t.Errorf("expected the label to be set, got %q", text)
+	}
+	if group, _ := groupRepo.GetGroup(ctx, groupID); group.PointsLabel != "coin, coins" {
+		t.Errorf("expected the normalized label to be stored, got %q", group.PointsLabel)
+	}
+
+	if text := send(fmt.Sprintf("/points_label %d off", groupID)); text != localizer.MustLocalizeWithTemplate(locale.PointsLabelReset, "Test Group") {
+		t.Errorf("expected the label to be reset, got %q", text)
+	}
+	if group, _ := groupRepo.GetGroup(ctx, groupID); group.PointsLabel != "" {
+		t.Errorf("expected no label to be stored, got %q", group.PointsLabel)
+	}
+}
@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// pollSyncCommand posts the bot's tally of an event under its poll
+const pollSyncCommand = "/poll_sync"
+
+// HandlePollSync handles the /poll_sync command (/poll_sync <event_id> [stop]).
+// Telegram's native poll also counts votes the bot rejected, so the bot's tally from the
+// stored predictions is posted to the group as the authoritative one. With stop, voting is
+// closed and the native poll is stopped so its counts no longer change.
+func (h *BotHandler) HandlePollSync(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send poll sync reply", "error", err)
+		}
+	}
+
+	eventID, stop, ok := parsePollSyncArgs(update.Message.Text)
+	if !ok {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.PollSyncUsage))
+		return
+	}
+
+	event, err := h.eventManager.GetEvent(ctx, eventID)
+	if err != nil {
+		h.logger.Warn("event for poll sync not found", "event_id", eventID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.PollSyncNotFound))
+		return
+	}
+
+	canManage, err := h.eventPermissionValidator.CanManageEvent(ctx, userID, eventID, h.config.AdminUserIDs)
+	if err != nil {
+		h.logger.Error("failed to check event management permission", "user_id", userID, "event_id", eventID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorPermissionCheck))
+		return
+	}
+	if !canManage {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionErrorUnauthorized))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil || group == nil {
+		h.logger.Error("failed to get group for poll sync", "event_id", eventID, "group_id", event.GroupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.PollSyncError))
+		return
+	}
+
+	hasPoll := event.PollID != "" && event.PollMessageID != 0
+	var notes []string
+	stopped := false
+	switch {
+	case !hasPoll:
+		notes = append(notes, h.requestLocalizer(ctx).MustLocalize(locale.PollSyncNoPoll))
+	case stop:
+		// Close voting first, so no vote is accepted that the posted tally doesn't show
+		if err := h.eventManager.CloseVoting(ctx, eventID); err != nil &&
+			!errors.Is(err, domain.ErrVotingClosed) && !errors.Is(err, domain.ErrEventNotActive) {
+			reply(h.requestLocalizer(ctx).MustLocalize(locale.PollSyncError))
+			return
+		}
+		if err := h.stopSyncedPoll(ctx, b, event, group); err != nil {
+			notes = append(notes, h.requestLocalizer(ctx).MustLocalize(locale.PollSyncStopFailed))
+		} else {
+			stopped = true
+			notes = append(notes, h.requestLocalizer(ctx).MustLocalize(locale.PollSyncStopped))
+		}
+	}
+
+	predictions, err := h.predictionRepo.GetPredictionsByEvent(ctx, eventID)
+	if err != nil {
+		h.logger.Error("failed to get predictions for poll sync", "event_id", eventID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.PollSyncError))
+		return
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID: group.TelegramChatID,
+		Text:   buildPollSyncText(h.chatLocalizer(ctx, group.TelegramChatID), event, predictions, stopped),
+	}
+	if hasPoll {
+		params.ReplyParameters = &models.ReplyParameters{
+			MessageID:                event.PollMessageID,
+			AllowSendingWithoutReply: true,
+		}
+	}
+	if event.ForumTopicID != nil {
+		topic, err := h.forumTopicRepo.GetForumTopic(ctx, *event.ForumTopicID)
+		if err != nil {
+			h.logger.Error("failed to get forum topic for poll sync", "event_id", eventID, "forum_topic_id", *event.ForumTopicID, "error", err)
+		} else if topic != nil {
+			params.MessageThreadID = topic.MessageThreadID
+		}
+	}
+	if _, err := b.SendMessage(ctx, params); err != nil {
+		h.logger.Error("failed to post poll tally", "event_id", eventID, "telegram_chat_id", group.TelegramChatID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.PollSyncError))
+		return
+	}
+
+	text := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.PollSyncPosted, strconv.FormatInt(eventID, 10), strconv.Itoa(len(predictions)), group.Name)
+	for _, note := range notes {
+		text += "\n" + note
+	}
+	reply(text)
+
+	h.logAdminAction(userID, "poll_sync", eventID, fmt.Sprintf("Posted the tally of %d votes (poll stopped: %t)", len(predictions), stopped))
+}
+
+// stopSyncedPoll stops the native poll of an event. A poll found deleted is marked missing for /orphans.
+func (h *BotHandler) stopSyncedPoll(ctx context.Context, b *bot.Bot, event *domain.Event, group *domain.Group) error {
+	_, err := b.StopPoll(ctx, &bot.StopPollParams{
+		ChatID:    group.TelegramChatID,
+		MessageID: event.PollMessageID,
+	})
+	if err != nil {
+		h.logger.Error("failed to stop poll", "event_id", event.ID, "message_id", event.PollMessageID, "telegram_chat_id", group.TelegramChatID, "error", err)
+		if isMessageNotFoundError(err) {
+			_ = h.eventManager.MarkPollMessageMissing(ctx, event.ID)
+		}
+		return err
+	}
+
+	h.logger.Info("poll stopped by poll sync", "event_id", event.ID, "message_id", event.PollMessageID)
+	return nil
+}
+
+// buildPollSyncText formats the vote counts of an event from its stored predictions
+func buildPollSyncText(localizer locale.Localizer, event *domain.Event, predictions []*domain.Prediction, stopped bool) string {
+	counts := make([]int, len(event.Options))
+	for _, pred := range predictions {
+		if pred.Option >= 0 && pred.Option < len(counts) {
+			counts[pred.Option]++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(localizer.MustLocalizeWithTemplate(locale.PollSyncTitle, event.Question))
+	sb.WriteString("\n\n")
+
+	for i, opt := range event.Options {
+		percentage := 0.0
+		if len(predictions) > 0 {
+			percentage = float64(counts[i]) / float64(len(predictions)) * 100.0
+		}
+		sb.WriteString(localizer.MustLocalizeWithTemplate(locale.LivePollStatsOption, opt, fmt.Sprintf("%.1f", percentage), strconv.Itoa(counts[i])))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(localizer.MustLocalizeWithTemplate(locale.LivePollStatsTotal, strconv.Itoa(len(predictions))))
+	if stopped {
+		sb.WriteString("\n" + localizer.MustLocalize(locale.PollSyncPollStopped))
+	}
+
+	return sb.String()
+}
+
+// parsePollSyncArgs parses "/poll_sync <event_id> [stop]"
+func parsePollSyncArgs(text string) (eventID int64, stop bool, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != pollSyncCommand && !strings.HasPrefix(command, pollSyncCommand+"@") {
+		return 0, false, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, false, false
+	}
+
+	eventID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || eventID <= 0 {
+		return 0, false, false
+	}
+	if len(fields) == 2 {
+		if !strings.EqualFold(fields[1], "stop") {
+			return 0, false, false
+		}
+		stop = true
+	}
+
+	return eventID, stop, true
+}
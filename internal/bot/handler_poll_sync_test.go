@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestPollSync(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	creatorID := int64(100)
+	voterID := int64(200)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	for _, userID := range []int64{creatorID, voterID} {
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	createEvent := func(pollMessageID int) *domain.Event {
+		t.Helper()
+		event := &domain.Event{
+			GroupID:       groupID,
+			Question:      "Will it rain?",
+			Options:       []string{"Yes", "No"},
+			CreatedAt:     time.Now(),
+			Deadline:      time.Now().Add(24 * time.Hour),
+			Status:        domain.EventStatusActive,
+			EventType:     domain.EventTypeBinary,
+			CreatedBy:     creatorID,
+			PollMessageID: pollMessageID,
+		}
+		if pollMessageID != 0 {
+			event.PollID = fmt.Sprintf("poll_%d", pollMessageID)
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		return event
+	}
+
+	h := &BotHandler{
+		bot:                      b,
+		config:                   &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:                storage.NewGroupRepository(queue),
+		groupMembershipRepo:      membershipRepo,
+		eventManager:             domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		eventPermissionValidator: domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		predictionRepo:           predictionRepo,
+		forumTopicRepo:           storage.NewForumTopicRepository(queue),
+		logger:                   log,
+		localizer:                localizer,
+	}
+
+	// pollSync runs the command and returns the messages it sent
+	pollSync := func(userID int64, args string) []string {
+		t.Helper()
+		before := len(rec.texts())
+		h.HandlePollSync(ctx, b, &models.Update{Message: &models.Message{
+			From: &models.User{ID: userID},
+			Chat: models.Chat{ID: userID},
+			Text: "/poll_sync " + args,
+		}})
+		return rec.texts()[before:]
+	}
+
+	event := createEvent(77)
+	// The stored vote counts, a vote Telegram counted but the bot rejected doesn't
+	if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: voterID, Option: 1, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("failed to save prediction: %v", err)
+	}
+	eventArg := fmt.Sprintf("%d", event.ID)
+
+	if sent := pollSync(creatorID, "abc"); len(sent) != 1 || sent[0] != localizer.MustLocalize(locale.PollSyncUsage) {
+		t.Errorf("expected the usage, got %v", sent)
+	}
+
+	// Only managers of the event can post the tally
+	if sent := pollSync(voterID, eventArg); len(sent) != 1 || sent[0] != localizer.MustLocalize(locale.EventResolutionErrorUnauthorized) {
+		t.Errorf("expected a regular member to be refused, got %v", sent)
+	}
+
+	sent := pollSync(creatorID, eventArg)
+	if len(sent) != 2 {
+		t.Fatalf("expected the tally and a confirmation, got %v", sent)
+	}
+	tally := sent[0]
+	if !strings.HasPrefix(tally, localizer.MustLocalizeWithTemplate(locale.PollSyncTitle, "Will it rain?")) ||
+		!strings.Contains(tally, localizer.MustLocalizeWithTemplate(locale.LivePollStatsOption, "No", "100.0", "1")) ||
+		!strings.Contains(tally, localizer.MustLocalizeWithTemplate(locale.LivePollStatsTotal, "1")) {
+		t.Errorf("expected the stored tally, got %q", tally)
+	}
+	if want := localizer.MustLocalizeWithTemplate(locale.PollSyncPosted, eventArg, "1", "Test Group"); !strings.HasPrefix(sent[1], want) {
+		t.Errorf("expected confirmation %q, got %q", want, sent[1])
+	}
+	if len(rec.stoppedIDs()) != 0 {
+		t.Error("expected the poll to keep running without stop")
+	}
+
+	// With stop, voting is closed and the poll is stopped
+	sent = pollSync(creatorID, eventArg+" stop")
+	if len(sent) != 2 || !strings.HasSuffix(sent[0], localizer.MustLocalize(locale.PollSyncPollStopped)) ||
+		!strings.Contains(sent[1], localizer.MustLocalize(locale.PollSyncStopped)) {
+		t.Errorf("expected a final tally and a stop confirmation, got %v", sent)
+	}
+	if stopped := rec.stoppedIDs(); len(stopped) != 1 || stopped[0] != 77 {
+		t.Errorf("expected poll message 77 to be stopped, got %v", stopped)
+	}
+	if stored, _ := eventRepo.GetEvent(ctx, event.ID); stored.VotingClosedAt == nil {
+		t.Error("expected voting to be closed")
+	}
+
+	// Without a poll message the tally is still posted, and there is nothing to stop
+	withoutPoll := createEvent(0)
+	sent = pollSync(creatorID, fmt.Sprintf("%d stop", withoutPoll.ID))
+	if len(sent) != 2 || !strings.Contains(sent[1], localizer.MustLocalize(locale.PollSyncNoPoll)) {
+		t.Errorf("expected the tally and a note about the missing poll, got %v", sent)
+	}
+	if len(rec.stoppedIDs()) != 1 {
+		t.Error("expected no poll to be stopped")
+	}
+}
+
+func TestParsePollSyncArgs(t *testing.T) {
+	tests := []struct {
+		text    string
+		eventID int64
+		stop    bool
+		ok      bool
+	}{
+		{"/poll_sync 42", 42, false, true},
+		{"/poll_sync@PredictionBot 42 STOP", 42, true, true},
+		{"/poll_sync", 0, false, false},
+		{"/poll_sync 0", 0, false, false},
+		{"/poll_sync 42 now", 0, false, false},
+		{"/poll_sync 42 stop extra", 0, false, false},
+		{"/poll_syncx 42", 0, false, false},
+	}
+
+	for _, tt := range tests {
+		eventID, stop, ok := parsePollSyncArgs(tt.text)
+		if eventID != tt.eventID || stop != tt.stop || ok != tt.ok {
+			t.Errorf("parsePollSyncArgs(%q) = %d, %t, %t; want %d, %t, %t", tt.text, eventID, stop, ok, tt.eventID, tt.stop, tt.ok)
+		}
+	}
+}
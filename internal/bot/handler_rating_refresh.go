@@ -0,0 +1,153 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// ratingRefreshCooldown is how long taps on a rating message's refresh button are ignored after a refresh
+const ratingRefreshCooldown = 5 * time.Second
+
+// ratingMessageKey identifies a rating message for refresh debouncing
+type ratingMessageKey struct {
+	chatID    int64
+	messageID int
+}
+
+// ratingRefreshKeyboard returns the refresh button of a rating message. The group and the number
+// of entries are in the callback data, so the button keeps working after a restart.
+func (h *BotHandler) ratingRefreshKeyboard(ctx context.Context, groupID int64, limit int) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: h.requestLocalizer(ctx).MustLocalize(locale.RatingButtonRefresh), CallbackData: mustEncodeCallback(cbRatingRefresh, groupID, limit)},
+			},
+		},
+	}
+}
+
+// handleRatingRefreshCallback re-renders a rating message in place. Taps within ratingRefreshCooldown
+// of the previous refresh are ignored; a message that can't be edited any more is replaced by a new one.
+func (h *BotHandler) handleRatingRefreshCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	if callback.Message.Message == nil {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		return
+	}
+	chatID := callback.Message.Message.Chat.ID
+	messageID := callback.Message.Message.ID
+
+	if err := cb.Expect(cbRatingRefresh, 2); err != nil {
+		h.logger.Error("invalid rating_refresh callback data", "data", cb.String(), "error", err)
+		return
+	}
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "data", cb.String(), "error", err)
+		return
+	}
+	limit, err := cb.Int(1)
+	if err != nil || limit <= 0 || limit > h.config.MaxRatingEntries {
+		h.logger.Error("failed to parse rating limit", "data", cb.String(), "error", err)
+		return
+	}
+
+	if !h.allowRatingRefresh(ratingMessageKey{chatID: chatID, messageID: messageID}, time.Now()) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.RatingRefreshTooSoon),
+		})
+		return
+	}
+
+	// Only members of the group (and admins) can see its leaderboard
+	if !h.isAdmin(userID) {
+		isMember, err := h.groupMembershipRepo.HasActiveMembership(ctx, groupID, userID)
+		if err != nil || !isMember {
+			if err != nil {
+				h.logger.Error("failed to check group membership", "group_id", groupID, "user_id", userID, "error", err)
+			}
+			_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+			})
+			return
+		}
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	texts, err := h.buildRatingMessages(ctx, group, limit)
+	if err != nil {
+		h.logger.Error("failed to get top ratings", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorGeneric),
+		})
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	// The leaderboard may have outgrown one message since it was sent
+	if len(texts) == 1 {
+		_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      chatID,
+			MessageID:   messageID,
+			Text:        texts[0],
+			ReplyMarkup: h.ratingRefreshKeyboard(ctx, groupID, limit),
+		})
+		if err == nil || strings.Contains(err.Error(), "message is not modified") {
+			return
+		}
+		h.logger.Warn("failed to edit rating message, sending a new one", "group_id", groupID, "message_id", messageID, "error", err)
+	}
+
+	var kb models.ReplyMarkup
+	if len(texts) == 1 {
+		kb = h.ratingRefreshKeyboard(ctx, groupID, limit)
+	}
+	for _, text := range texts {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        text,
+			ReplyMarkup: kb,
+		})
+		if err != nil {
+			h.logger.Error("failed to send rating message", "group_id", groupID, "error", err)
+			return
+		}
+	}
+}
+
+// allowRatingRefresh records a refresh of the rating message at now and reports whether it is allowed,
+// i.e. the message wasn't refreshed within ratingRefreshCooldown. Stale records are dropped on the way.
+func (h *BotHandler) allowRatingRefresh(key ratingMessageKey, now time.Time) bool {
+	h.ratingRefreshes.Range(func(k, v interface{}) bool {
+		if now.Sub(v.(time.Time)) >= ratingRefreshCooldown {
+			h.ratingRefreshes.Delete(k)
+		}
+		return true
+	})
+
+	_, refreshedRecently := h.ratingRefreshes.LoadOrStore(key, now)
+	return !refreshedRecently
+}
@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestRatingRefresh(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	memberID := int64(200)
+	outsiderID := int64(300)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: memberID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: memberID, GroupID: groupID, Username: "alice", Score: 30}); err != nil {
+		t.Fatalf("failed to create rating: %v", err)
+	}
+
+	h := &BotHandler{
+		bot:                 b,
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, MaxRatingEntries: 50, Timezone: time.UTC},
+		groupRepo:           storage.NewGroupRepository(queue),
+		groupMembershipRepo: membershipRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		logger:              log,
+		localizer:           localizer,
+	}
+
+	refresh := func(userID int64, messageID int) {
+		t.Helper()
+		h.HandleCallback(ctx, b, &models.Update{CallbackQuery: &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: mustEncodeCallback(cbRatingRefresh, groupID, 10),
+			Message: models.MaybeInaccessibleMessage{
+				Type:    models.MaybeInaccessibleMessageTypeMessage,
+				Message: &models.Message{ID: messageID, Chat: models.Chat{ID: userID}},
+			},
+		}})
+	}
+
+	// The rating card is edited in place
+	refresh(memberID, 50)
+	if edited := rec.editedIDs(); len(edited) != 1 || edited[0] != 50 {
+		t.Fatalf("expected rating message 50 to be edited, got %v", edited)
+	}
+
+	// Taps right after a refresh are ignored
+	refresh(memberID, 50)
+	if edited := rec.editedIDs(); len(edited) != 1 {
+		t.Errorf("expected a quick second tap to be ignored, got edits %v", edited)
+	}
+
+	// Members of other groups can't read the leaderboard
+	refresh(outsiderID, 51)
+	if edited := rec.editedIDs(); len(edited) != 1 || len(rec.texts()) != 0 {
+		t.Errorf("expected an outsider's tap to change nothing, got edits %v and texts %v", edited, rec.texts())
+	}
+
+	// A message too old to edit is replaced by a new one, with the refresh button
+	rec.editError = "Bad Request: message can't be edited"
+	refresh(memberID, 52)
+	texts := rec.texts()
+	if len(texts) != 1 || !strings.Contains(texts[0], "@alice") {
+		t.Fatalf("expected a fresh rating message, got %v", texts)
+	}
+	if !strings.Contains(rec.markups[0], cbRatingRefresh) {
+		t.Errorf("expected the fresh message to keep the refresh button, got %s", rec.markups[0])
+	}
+}
+
+func TestAllowRatingRefresh(t *testing.T) {
+	h := &BotHandler{}
+	key := ratingMessageKey{chatID: 1, messageID: 10}
+	now := time.Now()
+
+	if !h.allowRatingRefresh(key, now) {
+		t.Fatal("expected the first refresh to be allowed")
+	}
+	if h.allowRatingRefresh(key, now.Add(ratingRefreshCooldown/2)) {
+		t.Error("expected a refresh within the cooldown to be ignored")
+	}
+	if !h.allowRatingRefresh(ratingMessageKey{chatID: 1, messageID: 11}, now) {
+		t.Error("expected other messages to refresh independently")
+	}
+	if !h.allowRatingRefresh(key, now.Add(ratingRefreshCooldown)) {
+		t.Error("expected a refresh after the cooldown to be allowed")
+	}
+}
@@ -52,7 +52,7 @@ func TestRatingDisplayCompleteness(t *testing.T) {
 			predictionRepo := storage.NewPredictionRepository(queue)
 			eventRepo := storage.NewEventRepository(queue)
 			logger := &mockLogger{}
-			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 			ctx := context.Background()
 			groupID := int64(1)
@@ -136,7 +136,7 @@ func TestRatingOrderingConsistency(t *testing.T) {
 			predictionRepo := storage.NewPredictionRepository(queue)
 			eventRepo := storage.NewEventRepository(queue)
 			logger := &mockLogger{}
-			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 			ctx := context.Background()
 			groupID := int64(1)
@@ -180,3 +180,28 @@ func TestRatingOrderingConsistency(t *testing.T) {
 
 	properties.TestingRun(t)
 }
+
+func TestParseRatingLimit(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+		ok       bool
+	}{
+		{"/rating", 10, true},
+		{"/rating@PredictionBot", 10, true},
+		{"/rating 25", 25, true},
+		{"/rating@PredictionBot  50 ", 50, true},
+		{"/rating 51", 0, false},
+		{"/rating 0", 0, false},
+		{"/rating -5", 0, false},
+		{"/rating many", 0, false},
+		{"/ratings 5", 0, false},
+	}
+
+	for _, tt := range tests {
+		limit, ok := parseRatingLimit(tt.text, 50)
+		if limit != tt.expected || ok != tt.ok {
+			t.Errorf("parseRatingLimit(%q) = %d, %v; want %d, %v", tt.text, limit, ok, tt.expected, tt.ok)
+		}
+	}
+}
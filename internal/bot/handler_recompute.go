@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// recomputeCommand rebuilds the ratings of a group from its resolved predictions
+const recomputeCommand = "/recompute"
+
+// HandleRecompute handles the /recompute command (/recompute <group_id>).
+// The recomputation runs in the background and the admin is notified when it finishes.
+func (h *BotHandler) HandleRecompute(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(ctx context.Context, text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send recompute reply", "error", err)
+		}
+	}
+
+	groupID, ok := parseRecomputeArgs(update.Message.Text)
+	if !ok {
+		reply(ctx, h.requestLocalizer(ctx).MustLocalize(locale.RecomputeUsage))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+	}
+	if group == nil || group.Status == domain.GroupStatusDeleted {
+		reply(ctx, h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound))
+		return
+	}
+
+	// Only one recomputation per group at a time
+	if _, running := h.recomputeRunning.LoadOrStore(groupID, struct{}{}); running {
+		reply(ctx, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RecomputeAlreadyRunning, group.Name))
+		return
+	}
+
+	reply(ctx, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RecomputeStarted, group.Name))
+
+	// Replaying all predictions is heavy, keep it off the update handler
+	bgCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer h.recomputeRunning.Delete(groupID)
+
+		result, err := h.ratingCalculator.RecomputeGroup(bgCtx, groupID)
+		if err != nil {
+			h.logger.Error("failed to recompute ratings", "group_id", groupID, "error", err)
+			reply(bgCtx, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RecomputeError, group.Name))
+			return
+		}
+
+		reply(bgCtx, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RecomputeSuccess, group.Name,
+			strconv.Itoa(result.Events), strconv.Itoa(result.Predictions), strconv.Itoa(result.Users)))
+
+		h.logAdminAction(userID, "recompute_ratings", groupID, fmt.Sprintf("Recomputed ratings of group %s from %d events (%d predictions, %d users)",
+			group.Name, result.Events, result.Predictions, result.Users))
+	}()
+}
+
+// parseRecomputeArgs parses "/recompute <group_id>"
+func parseRecomputeArgs(text string) (groupID int64, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != recomputeCommand && !strings.HasPrefix(command, recomputeCommand+"@") {
+		return 0, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) != 1 {
+		return 0, false
+	}
+
+	groupID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || groupID <= 0 {
+		return 0, false
+	}
+
+	return groupID, true
+}
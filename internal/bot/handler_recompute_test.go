@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParseRecomputeArgs(t *testing.T) {
+	tests := []struct {
+		text    string
+		groupID int64
+		ok      bool
+	}{
+		{"/recompute 3", 3, true},
+		{"/recompute@PredictionBot  3 ", 3, true},
+		{"/recompute", 0, false},
+		{"/recompute 3 4", 0, false},
+		{"/recompute x", 0, false},
+		{"/recompute 0", 0, false},
+		{"/recomputex 3", 0, false},
+	}
+
+	for _, tt := range tests {
+		groupID, ok := parseRecomputeArgs(tt.text)
+		if ok != tt.ok || groupID != tt.groupID {
+			t.Errorf("parseRecomputeArgs(%q) = %d, %t; want %d, %t", tt.text, groupID, ok, tt.groupID, tt.ok)
+		}
+	}
+}
+
+func TestHandleRecompute(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	memberID := int64(2)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	event := &domain.Event{
+		GroupID:   groupID,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+		Deadline:  time.Now().Add(-24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: adminID,
+		PollID:    "poll-1",
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	prediction := &domain.Prediction{EventID: event.ID, UserID: memberID, Option: 0, Timestamp: event.CreatedAt.Add(time.Hour)}
+	if err := predictionRepo.SavePrediction(ctx, prediction); err != nil {
+		t.Fatalf("failed to save prediction: %v", err)
+	}
+	if err := eventRepo.ResolveEvent(ctx, event.ID, 0); err != nil {
+		t.Fatalf("failed to resolve event: %v", err)
+	}
+
+	// The stored rating is out of sync with the resolved predictions
+	stale := &domain.Rating{UserID: memberID, GroupID: groupID, Username: "member", Score: 500, CorrectCount: 40}
+	if err := ratingRepo.UpdateRating(ctx, stale); err != nil {
+		t.Fatalf("failed to update rating: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:           &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:        storage.NewGroupRepository(queue),
+		ratingCalculator: domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		logger:           log,
+		localizer:        localizer,
+	}
+	send := func(text string) {
+		t.Helper()
+		h.HandleRecompute(ctx, b, &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: adminID},
+				Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		})
+	}
+	lastText := func() string {
+		texts := rec.texts()
+		if len(texts) == 0 {
+			return ""
+		}
+		return texts[len(texts)-1]
+	}
+
+	send("/recompute")
+	if text := lastText(); text != localizer.MustLocalize(locale.RecomputeUsage) {
+		t.Errorf("expected usage, got %q", text)
+	}
+	send("/recompute 99")
+	if text := lastText(); text != localizer.MustLocalize(locale.GroupErrorNotFound) {
+		t.Errorf("expected group not found, got %q", text)
+	}
+
+	send(fmt.Sprintf("/recompute %d", groupID))
+
+	// The recomputation finishes in the background
+	expected := localizer.MustLocalizeWithTemplate(locale.RecomputeSuccess, "Test Group", "1", "1", "1")
+	deadline := time.Now().Add(5 * time.Second)
+	for lastText() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected success reply %q, got %v", expected, rec.texts())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	texts := rec.texts()
+	if started := localizer.MustLocalizeWithTemplate(locale.RecomputeStarted, "Test Group"); texts[len(texts)-2] != started {
+		t.Errorf("expected started reply %q before completion, got %q", started, texts[len(texts)-2])
+	}
+
+	rating, err := ratingRepo.GetRating(ctx, memberID, groupID)
+	if err != nil {
+		t.Fatalf("failed to get rating: %v", err)
+	}
+	expectedScore := domain.ParticipationPoints + domain.BinaryCorrectPoints + domain.EarlyVotingBonusPoints
+	if rating.Score != expectedScore || rating.CorrectCount != 1 || rating.Username != "member" {
+		t.Errorf("expected recomputed score %d with 1 correct prediction, got %+v", expectedScore, rating)
+	}
+}
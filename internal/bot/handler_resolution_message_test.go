@@ -54,7 +54,7 @@ func TestResolverInformationInMessage(t *testing.T) {
 			eventRepo := storage.NewEventRepository(queue)
 			logger := &mockLogger{}
 
-			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 			cfg := &config.Config{}
 
@@ -178,7 +178,7 @@ func TestCreatorVsAdminDistinction(t *testing.T) {
 			eventRepo := storage.NewEventRepository(queue)
 			logger := &mockLogger{}
 
-			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+			ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 			cfg := &config.Config{
 				AdminUserIDs: []int64{adminID},
@@ -319,7 +319,7 @@ func TestCreatorResolutionMessage(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{
 		AdminUserIDs: []int64{99999},
@@ -410,7 +410,7 @@ func TestAdminResolutionMessage(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	adminID := int64(99999)
 	cfg := &config.Config{
@@ -513,7 +513,7 @@ func TestResolverNameInclusion(t *testing.T) {
 	eventRepo := storage.NewEventRepository(queue)
 	logger := &mockLogger{}
 
-	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, logger)
+	ratingCalc := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, logger)
 
 	cfg := &config.Config{}
 
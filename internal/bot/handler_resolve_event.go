@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// resolveEventsPageSize is the number of event buttons shown per page of the /resolve_event selection
+	resolveEventsPageSize = 10
+	// resolveButtonQuestionLength is the maximum question length in a selection button,
+	// leaving room for the event ID within Telegram's 64 character button text
+	resolveButtonQuestionLength = 45
+)
+
+// manageableActiveEvents returns the active events of the user's groups (all groups for admins)
+// that the user can manage, and whether there are any active events at all
+func (h *BotHandler) manageableActiveEvents(ctx context.Context, userID int64) ([]*domain.Event, bool, error) {
+	var groups []*domain.Group
+	var err error
+	if h.isAdmin(userID) {
+		groups, err = h.groupRepo.GetAllGroups(ctx)
+	} else {
+		groups, err = h.groupRepo.GetUserGroups(ctx, userID)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasActiveEvents := false
+	var manageableEvents []*domain.Event
+	for _, group := range groups {
+		events, err := h.eventManager.GetActiveEvents(ctx, group.ID)
+		if err != nil {
+			h.logger.Error("failed to get active events for group", "group_id", group.ID, "error", err)
+			continue
+		}
+
+		for _, event := range events {
+			hasActiveEvents = true
+			canManage, err := h.eventPermissionValidator.CanManageEvent(ctx, userID, event.ID, h.config.AdminUserIDs)
+			if err != nil {
+				h.logger.Error("failed to check event management permission", "user_id", userID, "event_id", event.ID, "error", err)
+				continue
+			}
+			if canManage {
+				manageableEvents = append(manageableEvents, event)
+			}
+		}
+	}
+
+	return manageableEvents, hasActiveEvents, nil
+}
+
+// buildResolveEventsPage builds the selection text and keyboard for the page of events starting at offset.
+// Offsets past the end show the last page.
+func (h *BotHandler) buildResolveEventsPage(ctx context.Context, events []*domain.Event, offset int) (string, *models.InlineKeyboardMarkup) {
+	if offset >= len(events) {
+		offset = (len(events) - 1) / resolveEventsPageSize * resolveEventsPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	end := offset + resolveEventsPageSize
+	if end > len(events) {
+		end = len(events)
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, event := range events[offset:end] {
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("%s (ID: %d)", truncateButtonText(event.Question, resolveButtonQuestionLength), event.ID),
+				CallbackData: mustEncodeCallback(cbResolve, event.ID),
+			},
+		})
+	}
+
+	var navRow []models.InlineKeyboardButton
+	if offset > 0 {
+		prevOffset := offset - resolveEventsPageSize
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		navRow = append(navRow, models.InlineKeyboardButton{
+			Text:         h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionButtonPrev),
+			CallbackData: mustEncodeCallback(cbResolvePage, prevOffset),
+		})
+	}
+	if end < len(events) {
+		navRow = append(navRow, models.InlineKeyboardButton{
+			Text:         h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionButtonNext),
+			CallbackData: mustEncodeCallback(cbResolvePage, end),
+		})
+	}
+	if len(navRow) > 0 {
+		buttons = append(buttons, navRow)
+	}
+
+	text := h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionTitle2) + "\n\n" + h.requestLocalizer(ctx).MustLocalize(locale.EventResolutionSelectPrompt)
+	if len(events) > resolveEventsPageSize {
+		pages := (len(events) + resolveEventsPageSize - 1) / resolveEventsPageSize
+		text += "\n\n" + h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventResolutionPageInfo,
+			strconv.Itoa(offset/resolveEventsPageSize+1), strconv.Itoa(pages))
+	}
+
+	return text, &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+// handleResolvePageCallback shows another page of the /resolve_event selection, keeping the resolution session
+func (h *BotHandler) handleResolvePageCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	if err := cb.Expect(cbResolvePage, 1); err != nil {
+		h.logger.Error("invalid resolve_page callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	offset, err := cb.Int(0)
+	if err != nil {
+		h.logger.Error("failed to parse resolve page offset", "error", err)
+		return
+	}
+
+	// Pages are only valid while the user is still selecting an event
+	state, _, err := h.eventResolutionFSM.storage.Get(ctx, userID)
+	if err != nil || state != StateResolveSelectEvent {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.SessionExpiredShort),
+		})
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	events, _, err := h.manageableActiveEvents(ctx, userID)
+	if err != nil {
+		h.logger.Error("failed to get groups", "user_id", userID, "error", err)
+		return
+	}
+	if len(events) == 0 || callback.Message.Message == nil {
+		return
+	}
+
+	text, kb := h.buildResolveEventsPage(ctx, events, offset)
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to show resolve events page", "user_id", userID, "offset", offset, "error", err)
+	}
+}
+
+// truncateButtonText shortens text to at most maxLength characters, marking the cut with an ellipsis
+func truncateButtonText(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
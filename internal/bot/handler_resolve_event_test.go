@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestHandleResolveEvent_PaginatesManyEvents(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC}
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log)
+	fsmStorage := storage.NewFSMStorage(queue, log)
+	h := &BotHandler{
+		eventManager:             eventManager,
+		config:                   cfg,
+		logger:                   log,
+		eventCreationFSM:         &EventCreationFSM{storage: fsmStorage},
+		eventResolutionFSM:       &EventResolutionFSM{storage: fsmStorage, logger: log},
+		eventPermissionValidator: domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		groupRepo:                storage.NewGroupRepository(queue),
+		localizer:                localizer,
+	}
+
+	if err := membershipRepo.CreateMembership(ctx, &domain.GroupMembership{
+		GroupID:  groupID,
+		UserID:   adminID,
+		JoinedAt: time.Now(),
+		Status:   domain.MembershipStatusActive,
+	}); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	const eventCount = 100
+	for i := 0; i < eventCount; i++ {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  fmt.Sprintf("Question %d: %s?", i, strings.Repeat("will it happen ", 10)),
+			Options:   []string{"Yes", "No"},
+			CreatedAt: time.Now(),
+			Deadline:  time.Now().Add(time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: adminID,
+		}
+		if err := eventManager.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+	}
+
+	h.HandleResolveEvent(ctx, b, &models.Update{Message: &models.Message{
+		From: &models.User{ID: adminID},
+		Chat: models.Chat{ID: adminID},
+		Text: "/resolve_event",
+	}})
+
+	if len(rec.markups) != 1 {
+		t.Fatalf("expected one selection message, got %d", len(rec.markups))
+	}
+	var kb models.InlineKeyboardMarkup
+	if err := json.Unmarshal([]byte(rec.markups[0]), &kb); err != nil {
+		t.Fatalf("failed to decode keyboard: %v", err)
+	}
+
+	// One page of event buttons plus a navigation row with only "next"
+	if len(kb.InlineKeyboard) != resolveEventsPageSize+1 {
+		t.Fatalf("expected %d rows, got %d", resolveEventsPageSize+1, len(kb.InlineKeyboard))
+	}
+	for _, row := range kb.InlineKeyboard[:resolveEventsPageSize] {
+		if n := utf8.RuneCountInString(row[0].Text); n > 64 {
+			t.Errorf("button text has %d characters, expected at most 64: %q", n, row[0].Text)
+		}
+		if !strings.Contains(row[0].Text, "…") {
+			t.Errorf("expected long question to be truncated: %q", row[0].Text)
+		}
+	}
+	navRow := kb.InlineKeyboard[resolveEventsPageSize]
+	if len(navRow) != 1 || navRow[0].CallbackData != mustEncodeCallback(cbResolvePage, resolveEventsPageSize) {
+		t.Fatalf("expected a single next button, got %+v", navRow)
+	}
+	if expected := localizer.MustLocalizeWithTemplate(locale.EventResolutionPageInfo, "1", "10"); !strings.Contains(rec.texts()[0], expected) {
+		t.Errorf("expected page info %q in %q", expected, rec.texts()[0])
+	}
+
+	// Paging edits the selection message and keeps the resolution session
+	cb, err := DecodeCallback(mustEncodeCallback(cbResolvePage, 90))
+	if err != nil {
+		t.Fatalf("failed to decode callback: %v", err)
+	}
+	callback := &models.CallbackQuery{
+		ID:   "1",
+		From: models.User{ID: adminID},
+		Message: models.MaybeInaccessibleMessage{
+			Message: &models.Message{ID: 101, Chat: models.Chat{ID: adminID}},
+		},
+	}
+	h.handleResolvePageCallback(ctx, b, callback, adminID, cb)
+
+	if edited := rec.editedIDs(); len(edited) != 1 || edited[0] != 101 {
+		t.Errorf("expected the selection message to be edited, got %v", edited)
+	}
+	state, _, err := fsmStorage.Get(ctx, adminID)
+	if err != nil || state != StateResolveSelectEvent {
+		t.Errorf("expected session to stay at %s, got %q (%v)", StateResolveSelectEvent, state, err)
+	}
+
+	// The last page has only a "previous" button
+	events, _, err := h.manageableActiveEvents(ctx, adminID)
+	if err != nil {
+		t.Fatalf("failed to get manageable events: %v", err)
+	}
+	text, lastPage := h.buildResolveEventsPage(ctx, events, 90)
+	rows := lastPage.InlineKeyboard
+	if len(rows) != resolveEventsPageSize+1 || len(rows[len(rows)-1]) != 1 ||
+		rows[len(rows)-1][0].CallbackData != mustEncodeCallback(cbResolvePage, 80) {
+		t.Errorf("expected a last page with a single previous button, got %+v", rows[len(rows)-1])
+	}
+	if expected := localizer.MustLocalizeWithTemplate(locale.EventResolutionPageInfo, "10", "10"); !strings.Contains(text, expected) {
+		t.Errorf("expected page info %q in %q", expected, text)
+	}
+}
+
+func TestTruncateButtonText(t *testing.T) {
+	if got := truncateButtonText("Short", 10); got != "Short" {
+		t.Errorf("expected short text unchanged, got %q", got)
+	}
+	if got := truncateButtonText("Будет ли дождь завтра?", 10); got != "Будет ли …" {
+		t.Errorf("expected rune-aware truncation, got %q", got)
+	}
+}
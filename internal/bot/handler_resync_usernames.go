@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// resyncUsernamesCommand refreshes the names stored for the members of a group
+const resyncUsernamesCommand = "/resync_usernames"
+
+// HandleResyncUsernames handles the /resync_usernames command (/resync_usernames <group_id>).
+// Names are taken from the latest profiles the bot has seen, members it has never seen keep theirs.
+func (h *BotHandler) HandleResyncUsernames(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send resync usernames reply", "error", err)
+		}
+	}
+
+	groupID, ok := parseResyncUsernamesArgs(update.Message.Text)
+	if !ok {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.ResyncUsernamesUsage))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+	}
+	if group == nil || group.Status == domain.GroupStatusDeleted {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound))
+		return
+	}
+
+	if h.userRepo == nil {
+		h.logger.Error("user repository is not configured, can't resync usernames", "group_id", groupID)
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ResyncUsernamesError, group.Name))
+		return
+	}
+
+	result, err := domain.ResyncGroupUsernames(ctx, h.groupMembershipRepo, h.userRepo, h.ratingRepo, groupID)
+	if err != nil {
+		h.logger.Error("failed to resync usernames", "group_id", groupID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ResyncUsernamesError, group.Name))
+		return
+	}
+
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.ResyncUsernamesSuccess, group.Name,
+		strconv.Itoa(result.Updated), strconv.Itoa(result.Members), strconv.Itoa(result.Unseen)))
+
+	h.logAdminAction(userID, "resync_usernames", groupID, fmt.Sprintf("Resynced member names of group %s: %d of %d updated, %d unseen",
+		group.Name, result.Updated, result.Members, result.Unseen))
+}
+
+// parseResyncUsernamesArgs parses "/resync_usernames <group_id>"
+func parseResyncUsernamesArgs(text string) (groupID int64, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != resyncUsernamesCommand && !strings.HasPrefix(command, resyncUsernamesCommand+"@") {
+		return 0, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) != 1 {
+		return 0, false
+	}
+
+	groupID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || groupID <= 0 {
+		return 0, false
+	}
+
+	return groupID, true
+}
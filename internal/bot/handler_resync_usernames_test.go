@@ -0,0 +1,123 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParseResyncUsernamesArgs(t *testing.T) {
+	tests := []struct {
+		text    string
+		groupID int64
+		ok      bool
+	}{
+		{"/resync_usernames 3", 3, true},
+		{"/resync_usernames@PredictionBot 3", 3, true},
+		{"/resync_usernames", 0, false},
+		{"/resync_usernames 3 4", 0, false},
+		{"/resync_usernames -3", 0, false},
+		{"/resync_usernamesx 3", 0, false},
+	}
+
+	for _, tt := range tests {
+		groupID, ok := parseResyncUsernamesArgs(tt.text)
+		if ok != tt.ok || groupID != tt.groupID {
+			t.Errorf("parseResyncUsernamesArgs(%q) = %d, %t; want %d, %t", tt.text, groupID, ok, tt.groupID, tt.ok)
+		}
+	}
+}
+
+func TestHandleResyncUsernames(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	userRepo := storage.NewUserRepository(queue)
+
+	// Member 2 renamed since the rating was stored, member 3 was never seen by the bot
+	for _, userID := range []int64{2, 3} {
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+		if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: userID, GroupID: groupID, Username: fmt.Sprintf("old%d", userID)}); err != nil {
+			t.Fatalf("failed to update rating: %v", err)
+		}
+	}
+	if err := userRepo.UpsertUserProfile(ctx, 2, "new2", "Two", ""); err != nil {
+		t.Fatalf("failed to upsert profile: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:           storage.NewGroupRepository(queue),
+		groupMembershipRepo: membershipRepo,
+		ratingRepo:          ratingRepo,
+		userRepo:            userRepo,
+		logger:              log,
+		localizer:           localizer,
+	}
+	send := func(text string) string {
+		t.Helper()
+		h.HandleResyncUsernames(ctx, b, &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: adminID},
+				Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		})
+		texts := rec.texts()
+		if len(texts) == 0 {
+			return ""
+		}
+		return texts[len(texts)-1]
+	}
+
+	if text := send("/resync_usernames"); text != localizer.MustLocalize(locale.ResyncUsernamesUsage) {
+		t.Errorf("expected usage, got %q", text)
+	}
+	if text := send("/resync_usernames 99"); text != localizer.MustLocalize(locale.GroupErrorNotFound) {
+		t.Errorf("expected group not found, got %q", text)
+	}
+
+	members, err := membershipRepo.GetGroupMembers(ctx, groupID)
+	if err != nil {
+		t.Fatalf("failed to get members: %v", err)
+	}
+	expected := localizer.MustLocalizeWithTemplate(locale.ResyncUsernamesSuccess, "Test Group", "1", fmt.Sprint(len(members)), fmt.Sprint(len(members)-1))
+	if text := send(fmt.Sprintf("/resync_usernames %d", groupID)); text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+
+	for userID, name := range map[int64]string{2: "new2", 3: "old3"} {
+		rating, err := ratingRepo.GetRating(ctx, userID, groupID)
+		if err != nil {
+			t.Fatalf("failed to get rating: %v", err)
+		}
+		if rating.Username != name {
+			t.Errorf("user %d: expected name %q, got %q", userID, name, rating.Username)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleRequireRules handles the /require_rules command (toggle the rules acceptance gate per group)
+func (h *BotHandler) HandleRequireRules(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	kb, err := h.buildRequireRulesKeyboard(ctx)
+	if err != nil {
+		h.logger.Error("failed to get all groups", "error", err)
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsErrorGet),
+		})
+		return
+	}
+
+	if kb == nil {
+		_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: update.Message.Chat.ID,
+			Text:   h.requestLocalizer(ctx).MustLocalize(locale.ListGroupsEmpty),
+		})
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        h.requestLocalizer(ctx).MustLocalize(locale.RequireRulesTitle),
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to send require rules settings", "error", err)
+	}
+}
+
+// buildRequireRulesKeyboard builds toggle buttons for all active groups.
+// Returns nil keyboard if there are no active groups.
+func (h *BotHandler) buildRequireRulesKeyboard(ctx context.Context) (*models.InlineKeyboardMarkup, error) {
+	groups, err := h.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		if group.Status == domain.GroupStatusDeleted {
+			continue
+		}
+
+		state := " ❌"
+		if group.RequireRules {
+			state = " ✅"
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         "📜 " + group.Name + state,
+				CallbackData: mustEncodeCallback(cbRequireRulesToggle, group.ID),
+			},
+		})
+	}
+
+	if len(buttons) == 0 {
+		return nil, nil
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, nil
+}
+
+// handleRequireRulesCallback toggles the rules acceptance gate for the selected group
+func (h *BotHandler) handleRequireRulesCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	if err := cb.Expect(cbRequireRulesToggle, 1); err != nil {
+		h.logger.Error("invalid require_rules callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status == domain.GroupStatusDeleted {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	requireRules := !group.RequireRules
+	if err := h.groupRepo.UpdateGroupRequireRules(ctx, groupID, requireRules); err != nil {
+		h.logger.Error("failed to update require rules setting", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.RequireRulesErrorUpdate),
+		})
+		return
+	}
+
+	answerKey := locale.RequireRulesDisabled
+	if requireRules {
+		answerKey = locale.RequireRulesEnabled
+	}
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(answerKey, group.Name),
+	})
+
+	// Update keyboard with new toggle states
+	if callback.Message.Message != nil {
+		kb, err := h.buildRequireRulesKeyboard(ctx)
+		if err != nil {
+			h.logger.Error("failed to rebuild require rules keyboard", "error", err)
+		} else if kb != nil {
+			_, _ = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+				ChatID:      callback.Message.Message.Chat.ID,
+				MessageID:   callback.Message.Message.ID,
+				ReplyMarkup: kb,
+			})
+		}
+	}
+
+	h.logAdminAction(userID, "toggle_require_rules", groupID, fmt.Sprintf("Set require rules to %t for group %s", requireRules, group.Name))
+}
+
+// sendRulesPrompt sends the group rules with an "I accept" button to the user's private chat.
+// A non-empty notice is shown above the rules.
+func (h *BotHandler) sendRulesPrompt(ctx context.Context, b *bot.Bot, userID int64, group *domain.Group, notice string) {
+	text := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RulesPrompt, group.Name)
+	if notice != "" {
+		text = notice + "\n\n" + text
+	}
+
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: userID,
+		Text:   text,
+		ReplyMarkup: &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{
+				{{Text: h.requestLocalizer(ctx).MustLocalize(locale.RulesAcceptButton), CallbackData: mustEncodeCallback(cbAcceptRules, group.ID)}},
+			},
+		},
+	})
+	if err != nil {
+		h.logger.Warn("failed to send rules prompt", "user_id", userID, "group_id", group.ID, "error", err)
+	}
+}
+
+// handleAcceptRulesCallback records that the user accepted the rules of the group
+func (h *BotHandler) handleAcceptRulesCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	if err := cb.Expect(cbAcceptRules, 1); err != nil {
+		h.logger.Error("invalid accept_rules callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status == domain.GroupStatusDeleted {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	membership, err := h.groupMembershipRepo.GetMembership(ctx, groupID, userID)
+	if err != nil || membership == nil || membership.Status != domain.MembershipStatusActive {
+		h.logger.Warn("rules accepted by non-member", "group_id", groupID, "user_id", userID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	if !membership.RulesPending {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.RulesAlreadyAccepted),
+		})
+		return
+	}
+
+	if err := h.groupMembershipRepo.AcceptRules(ctx, groupID, userID); err != nil {
+		h.logger.Error("failed to accept rules", "group_id", groupID, "user_id", userID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.RulesErrorAccept),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	// Replace the prompt so the button cannot be pressed again
+	if callback.Message.Message != nil {
+		_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    callback.Message.Message.Chat.ID,
+			MessageID: callback.Message.Message.ID,
+			Text:      h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RulesAccepted, group.Name),
+		})
+		if err != nil {
+			h.logger.Error("failed to edit rules prompt", "error", err)
+		}
+	}
+
+	h.logger.Info("rules accepted", "group_id", groupID, "user_id", userID)
+}
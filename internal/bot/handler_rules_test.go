@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/encoding"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestRulesAcceptanceGate(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	existingMemberID := int64(300)
+	newMemberID := int64(200)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	encoder, err := encoding.NewBaseNEncoder("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+
+	// A member who joined before the gate was enabled
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: existingMemberID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	event := &domain.Event{
+		GroupID:   groupID,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  time.Now().Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: adminID,
+		PollID:    "poll-1",
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:           groupRepo,
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      predictionRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		deepLinkService:     domain.NewDeepLinkService("testbot", encoder),
+		logger:              log,
+		localizer:           localizer,
+	}
+	press := func(userID int64, data string) {
+		t.Helper()
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		callback := &models.CallbackQuery{
+			ID:   "cb",
+			From: models.User{ID: userID},
+			Data: data,
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{ID: 10, Chat: models.Chat{ID: userID}},
+			},
+		}
+		switch cb.Namespace {
+		case cbRequireRulesToggle:
+			h.handleRequireRulesCallback(ctx, b, callback, userID, cb)
+		case cbAcceptRules:
+			h.handleAcceptRulesCallback(ctx, b, callback, userID, cb)
+		}
+	}
+	vote := func(userID int64) bool {
+		t.Helper()
+		h.HandlePollAnswer(ctx, b, &models.Update{
+			PollAnswer: &models.PollAnswer{PollID: "poll-1", User: &models.User{ID: userID}, OptionIDs: []int{0}},
+		})
+		prediction, err := predictionRepo.GetPredictionByUserAndEvent(ctx, userID, event.ID)
+		if err != nil {
+			t.Fatalf("failed to get prediction: %v", err)
+		}
+		return prediction != nil
+	}
+
+	press(adminID, mustEncodeCallback(cbRequireRulesToggle, groupID))
+	if group, _ := groupRepo.GetGroup(ctx, groupID); !group.RequireRules {
+		t.Fatal("expected the rules gate to be enabled")
+	}
+
+	// Joining a gated group sends the rules with an accept button
+	encodedID, err := encoder.Encode(groupID)
+	if err != nil {
+		t.Fatalf("failed to encode group ID: %v", err)
+	}
+	h.handleDeepLinkJoin(ctx, b, &models.Update{
+		Message: &models.Message{
+			From: &models.User{ID: newMemberID, Username: "newbie"},
+			Chat: models.Chat{ID: newMemberID, Type: models.ChatTypePrivate},
+		},
+	}, "group_"+encodedID)
+
+	texts := rec.texts()
+	rulesPrompt := localizer.MustLocalizeWithTemplate(locale.RulesPrompt, "Test Group")
+	if texts[len(texts)-1] != rulesPrompt {
+		t.Errorf("expected rules prompt after joining, got %q", texts[len(texts)-1])
+	}
+	if joined, _ := membershipRepo.GetMembership(ctx, groupID, newMemberID); joined == nil || !joined.RulesPending {
+		t.Fatalf("expected a membership with pending rules, got %+v", joined)
+	}
+
+	// Votes are rejected with a prompt until the rules are accepted
+	if vote(newMemberID) {
+		t.Error("expected vote to be rejected before accepting the rules")
+	}
+	texts = rec.texts()
+	if !strings.HasPrefix(texts[len(texts)-1], localizer.MustLocalizeWithTemplate(locale.RulesVoteRejected, "Test Group")) {
+		t.Errorf("expected vote rejection prompt, got %q", texts[len(texts)-1])
+	}
+
+	// Existing members are grandfathered in
+	if !vote(existingMemberID) {
+		t.Error("expected vote of an existing member to be saved")
+	}
+
+	press(newMemberID, mustEncodeCallback(cbAcceptRules, groupID))
+	if joined, _ := membershipRepo.GetMembership(ctx, groupID, newMemberID); joined.RulesPending {
+		t.Error("expected rules to be accepted")
+	}
+	if !vote(newMemberID) {
+		t.Error("expected vote to be saved after accepting the rules")
+	}
+}
@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// seasonCommand manages the seasons of a group
+const seasonCommand = "/season"
+
+// HandleSeason handles the /season command (/season start <group_id>). Starting a season
+// archives the current ratings of the group and resets them; predictions and achievements are kept.
+func (h *BotHandler) HandleSeason(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send season reply", "error", err)
+		}
+	}
+
+	groupID, ok := parseSeasonArgs(update.Message.Text)
+	if !ok {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.SeasonUsage))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+	}
+	if group == nil || group.Status == domain.GroupStatusDeleted {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound))
+		return
+	}
+
+	// A season reset in the middle of a recomputation would be overwritten by it
+	if _, running := h.recomputeRunning.Load(groupID); running {
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RecomputeAlreadyRunning, group.Name))
+		return
+	}
+
+	season, err := h.ratingCalculator.StartNewSeason(ctx, groupID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSeasonsDisabled) {
+			h.logger.Error("failed to start new season", "group_id", groupID, "error", err)
+		}
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SeasonStartError, group.Name))
+		return
+	}
+
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SeasonStarted, group.Name,
+		formatSeasonSummary(h.requestLocalizer(ctx), season, group)))
+
+	// Let the group know its ratings start over
+	groupLocalizer := h.chatLocalizer(ctx, group.TelegramChatID)
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: group.TelegramChatID,
+		Text:   groupLocalizer.MustLocalizeWithTemplate(locale.SeasonStartedAnnouncement, formatSeasonSummary(groupLocalizer, season, group)),
+	})
+	if err != nil {
+		h.logger.Warn("failed to announce new season", "group_id", groupID, "error", err)
+	}
+
+	h.logAdminAction(userID, "start_season", groupID, fmt.Sprintf("Started a new season in group %s, archived season %d with %d players",
+		group.Name, season.Number, season.Players))
+}
+
+// HandleSeasonHistory handles the /season_history command (finished seasons of the user's
+// current group with their champions, newest first)
+func (h *BotHandler) HandleSeasonHistory(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send season history", "error", err)
+		}
+	}
+
+	groupID, err := h.groupContextResolver.ResolveGroupForUser(ctx, userID)
+	if err != nil {
+		reply(h.userErrorMessage(ctx, err, "failed to resolve group context", "user_id", userID))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		reply(h.userErrorMessage(ctx, err, "failed to get group", "group_id", groupID))
+		return
+	}
+
+	seasons, err := h.ratingCalculator.GetSeasons(ctx, groupID)
+	if err != nil {
+		reply(h.userErrorMessage(ctx, err, "failed to get seasons", "group_id", groupID))
+		return
+	}
+
+	if len(seasons) == 0 {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.SeasonHistoryEmpty))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.SeasonHistoryTitle) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingGroupName, group.Name) + "\n\n")
+	for _, season := range seasons {
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SeasonHistoryItem,
+			strconv.Itoa(season.Number),
+			season.EndedAt.In(h.config.Timezone).Format("02.01.2006"),
+			formatSeasonSummary(h.requestLocalizer(ctx), season, group)) + "\n")
+	}
+
+	reply(strings.TrimRight(sb.String(), "\n"))
+}
+
+// formatSeasonSummary describes the champion and the number of players of a finished season
+func formatSeasonSummary(localizer locale.Localizer, season *domain.Season, group *domain.Group) string {
+	if season.Champion == nil {
+		return localizer.MustLocalize(locale.SeasonNoPlayers)
+	}
+
+	displayName := fmt.Sprintf("ID: %d", season.Champion.UserID)
+	if season.Champion.Username != "" {
+		displayName = "@" + strings.TrimPrefix(season.Champion.Username, "@")
+	}
+
+	return localizer.MustLocalizeWithTemplate(locale.SeasonChampion, displayName,
+		domain.FormatPoints(localizer, season.Champion.Score, group.PointsLabel),
+		strconv.Itoa(season.Players))
+}
+
+// parseSeasonArgs parses "/season start <group_id>"
+func parseSeasonArgs(text string) (groupID int64, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != seasonCommand && !strings.HasPrefix(command, seasonCommand+"@") {
+		return 0, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "start") {
+		return 0, false
+	}
+
+	groupID, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || groupID <= 0 {
+		return 0, false
+	}
+
+	return groupID, true
+}
@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParseSeasonArgs(t *testing.T) {
+	tests := []struct {
+		text    string
+		groupID int64
+		ok      bool
+	}{
+		{"/season start 3", 3, true},
+		{"/season@PredictionBot START 3 ", 3, true},
+		{"/season", 0, false},
+		{"/season start", 0, false},
+		{"/season 3", 0, false},
+		{"/season stop 3", 0, false},
+		{"/season start x", 0, false},
+		{"/season start 0", 0, false},
+		{"/season_history", 0, false},
+	}
+
+	for _, tt := range tests {
+		groupID, ok := parseSeasonArgs(tt.text)
+		if ok != tt.ok || groupID != tt.groupID {
+			t.Errorf("parseSeasonArgs(%q) = %d, %t; want %d, %t", tt.text, groupID, ok, tt.groupID, tt.ok)
+		}
+	}
+}
+
+func TestHandleSeason(t *testing.T) {
+	ctx := context.Background()
+	const chatID = int64(-100500)
+	adminID := int64(1)
+	memberID := int64(2)
+
+	queue, groupID := setupTestGroupAndDB(t, chatID, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	ratingRepo := storage.NewRatingRepository(queue)
+	groupRepo := storage.NewGroupRepository(queue)
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: adminID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := storage.NewGroupMembershipRepository(queue).CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: memberID, GroupID: groupID, Username: "member", Score: 42, CorrectCount: 4, Streak: 2}); err != nil {
+		t.Fatalf("failed to update rating: %v", err)
+	}
+
+	ratingCalculator := domain.NewRatingCalculator(ratingRepo, storage.NewPredictionRepository(queue), storage.NewEventRepository(queue), nil, log)
+	ratingCalculator.SetSeasonRepository(storage.NewSeasonRepository(queue))
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:               &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:            groupRepo,
+		groupContextResolver: domain.NewGroupContextResolver(groupRepo),
+		ratingCalculator:     ratingCalculator,
+		logger:               log,
+		localizer:            localizer,
+	}
+	message := func(text string) *models.Update {
+		return &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: adminID},
+				Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		}
+	}
+	lastText := func() string {
+		texts := rec.texts()
+		if len(texts) == 0 {
+			return ""
+		}
+		return texts[len(texts)-1]
+	}
+
+	h.HandleSeasonHistory(ctx, b, message("/season_history"))
+	if text := lastText(); text != localizer.MustLocalize(locale.SeasonHistoryEmpty) {
+		t.Errorf("expected empty season history, got %q", text)
+	}
+
+	h.HandleSeason(ctx, b, message("/season"))
+	if text := lastText(); text != localizer.MustLocalize(locale.SeasonUsage) {
+		t.Errorf("expected usage, got %q", text)
+	}
+
+	h.HandleSeason(ctx, b, message(fmt.Sprintf("/season start %d", groupID)))
+
+	summary := localizer.MustLocalizeWithTemplate(locale.SeasonChampion, "@member",
+		domain.FormatPoints(localizer, 42, ""), "1")
+	texts := rec.texts()
+	if len(texts) < 2 {
+		t.Fatalf("expected a reply and a group announcement, got %v", texts)
+	}
+	if reply := texts[len(texts)-2]; reply != localizer.MustLocalizeWithTemplate(locale.SeasonStarted, "Test Group", summary) {
+		t.Errorf("unexpected season reply %q", reply)
+	}
+	if announcement := texts[len(texts)-1]; announcement != localizer.MustLocalizeWithTemplate(locale.SeasonStartedAnnouncement, summary) {
+		t.Errorf("unexpected group announcement %q", announcement)
+	}
+
+	rating, err := ratingRepo.GetRating(ctx, memberID, groupID)
+	if err != nil {
+		t.Fatalf("failed to get rating: %v", err)
+	}
+	if rating.Score != 0 || rating.CorrectCount != 0 || rating.Streak != 0 {
+		t.Errorf("expected the live rating to be reset, got %+v", rating)
+	}
+
+	h.HandleSeasonHistory(ctx, b, message("/season_history"))
+	if text := lastText(); !strings.Contains(text, summary) || !strings.Contains(text, "Season 1") {
+		t.Errorf("expected the finished season with its champion in the history, got %q", text)
+	}
+}
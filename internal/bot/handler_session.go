@@ -0,0 +1,171 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// sessionCommand shows the FSM session of a user for debugging
+	sessionCommand = "/session"
+	// sessionContextLimit caps the shown context so the message stays within Telegram's limit
+	sessionContextLimit = 3000
+)
+
+// HandleSession handles the /session command (/session <user_id>).
+// Shows the user's FSM session, even if expired, with a button to delete it.
+func (h *BotHandler) HandleSession(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	reply := func(text string, kb *models.InlineKeyboardMarkup) {
+		params := &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		}
+		if kb != nil {
+			params.ReplyMarkup = kb
+		}
+		if _, err := b.SendMessage(ctx, params); err != nil {
+			h.logger.Error("failed to send session reply", "error", err)
+		}
+	}
+
+	targetUserID, ok := parseSessionArgs(update.Message.Text)
+	if !ok {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.SessionInspectUsage), nil)
+		return
+	}
+	targetID := strconv.FormatInt(targetUserID, 10)
+
+	session, err := h.eventCreationFSM.storage.GetRaw(ctx, targetUserID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SessionInspectNotFound, targetID), nil)
+			return
+		}
+		h.logger.Error("failed to get raw session", "target_user_id", targetUserID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SessionInspectError, targetID), nil)
+		return
+	}
+
+	kb := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{
+				Text:         h.requestLocalizer(ctx).MustLocalize(locale.SessionInspectButtonDelete),
+				CallbackData: mustEncodeCallback(cbSessionDelete, targetUserID),
+			}},
+		},
+	}
+	reply(h.formatSession(ctx, session), kb)
+}
+
+// formatSession renders the state, timestamps and decoded context of a session
+func (h *BotHandler) formatSession(ctx context.Context, session *storage.FSMSession) string {
+	status := h.requestLocalizer(ctx).MustLocalize(locale.SessionInspectActive)
+	if session.Expired {
+		status = h.requestLocalizer(ctx).MustLocalize(locale.SessionInspectExpired)
+	}
+
+	contextText := session.ContextJSON
+	if session.Data == nil {
+		contextText = h.requestLocalizer(ctx).MustLocalize(locale.SessionInspectCorrupted) + "\n" + contextText
+	} else if pretty, err := json.MarshalIndent(session.Data, "", "  "); err == nil {
+		contextText = string(pretty)
+	}
+	if runes := []rune(contextText); len(runes) > sessionContextLimit {
+		contextText = string(runes[:sessionContextLimit]) + "…"
+	}
+
+	return h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SessionInspectInfo,
+		strconv.FormatInt(session.UserID, 10),
+		session.State,
+		status,
+		session.CreatedAt.In(h.config.Timezone).Format("02.01.2006 15:04:05"),
+		session.UpdatedAt.In(h.config.Timezone).Format("02.01.2006 15:04:05"),
+		contextText,
+	)
+}
+
+// handleSessionDeleteCallback force-deletes the inspected session
+func (h *BotHandler) handleSessionDeleteCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	// Check admin authorization
+	if !h.isAdmin(userID) {
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.ErrorUnauthorized),
+		})
+		return
+	}
+
+	if err := cb.Expect(cbSessionDelete, 1); err != nil {
+		h.logger.Error("invalid session_delete callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	targetUserID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse target user ID", "error", err)
+		return
+	}
+	targetID := strconv.FormatInt(targetUserID, 10)
+
+	if err := h.eventCreationFSM.storage.Delete(ctx, targetUserID); err != nil {
+		h.logger.Error("failed to delete session", "target_user_id", targetUserID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SessionInspectErrorDelete, targetID),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	text := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SessionInspectDeleted, targetID)
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            text,
+	})
+
+	if callback.Message.Message != nil {
+		_, _ = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    callback.Message.Message.Chat.ID,
+			MessageID: callback.Message.Message.ID,
+			Text:      text,
+		})
+	}
+
+	h.logAdminAction(userID, "delete_session", 0, fmt.Sprintf("Deleted the FSM session of user %d", targetUserID))
+}
+
+// parseSessionArgs parses "/session <user_id>"
+func parseSessionArgs(text string) (userID int64, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != sessionCommand && !strings.HasPrefix(command, sessionCommand+"@") {
+		return 0, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) != 1 {
+		return 0, false
+	}
+
+	userID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, false
+	}
+
+	return userID, true
+}
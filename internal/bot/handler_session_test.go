@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParseSessionArgs(t *testing.T) {
+	tests := []struct {
+		text   string
+		userID int64
+		ok     bool
+	}{
+		{"/session 42", 42, true},
+		{"/session@PredictionBot  42 ", 42, true},
+		{"/session", 0, false},
+		{"/session 42 43", 0, false},
+		{"/session x", 0, false},
+		{"/session -1", 0, false},
+		{"/sessions 42", 0, false},
+	}
+
+	for _, tt := range tests {
+		userID, ok := parseSessionArgs(tt.text)
+		if ok != tt.ok || userID != tt.userID {
+			t.Errorf("parseSessionArgs(%q) = %d, %t; want %d, %t", tt.text, userID, ok, tt.userID, tt.ok)
+		}
+	}
+}
+
+func TestHandleSession(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	targetID := int64(42)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, _ := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	fsmStorage := storage.NewFSMStorage(queue, log)
+	h := &BotHandler{
+		bot:              b,
+		config:           &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		logger:           log,
+		eventCreationFSM: &EventCreationFSM{storage: fsmStorage},
+		localizer:        localizer,
+	}
+
+	if err := fsmStorage.Set(ctx, targetID, StateAskQuestion, map[string]interface{}{"question": "Will it rain?"}); err != nil {
+		t.Fatalf("failed to set session: %v", err)
+	}
+
+	command := func(userID int64, text string) {
+		h.HandleSession(ctx, b, &models.Update{Message: &models.Message{
+			From: &models.User{ID: userID},
+			Chat: models.Chat{ID: userID},
+			Text: text,
+		}})
+	}
+
+	// Non-admins are rejected
+	command(targetID, "/session 42")
+	if texts := rec.texts(); len(texts) != 1 || texts[0] != localizer.MustLocalize(locale.ErrorUnauthorized) {
+		t.Fatalf("expected an unauthorized reply, got %v", texts)
+	}
+
+	command(adminID, "/session 42")
+	texts := rec.texts()
+	if len(texts) != 2 {
+		t.Fatalf("expected the session to be shown, got %v", texts)
+	}
+	for _, expected := range []string{StateAskQuestion, `"question": "Will it rain?"`, localizer.MustLocalize(locale.SessionInspectActive)} {
+		if !strings.Contains(texts[1], expected) {
+			t.Errorf("expected %q in %q", expected, texts[1])
+		}
+	}
+	var kb models.InlineKeyboardMarkup
+	if err := json.Unmarshal([]byte(rec.markups[len(rec.markups)-1]), &kb); err != nil {
+		t.Fatalf("failed to decode keyboard: %v", err)
+	}
+	if len(kb.InlineKeyboard) != 1 || kb.InlineKeyboard[0][0].CallbackData != mustEncodeCallback(cbSessionDelete, targetID) {
+		t.Fatalf("expected a delete button, got %+v", kb.InlineKeyboard)
+	}
+
+	// The delete button removes the session
+	cb, err := DecodeCallback(kb.InlineKeyboard[0][0].CallbackData)
+	if err != nil {
+		t.Fatalf("failed to decode callback: %v", err)
+	}
+	callback := &models.CallbackQuery{
+		ID:   "1",
+		From: models.User{ID: adminID},
+		Message: models.MaybeInaccessibleMessage{
+			Message: &models.Message{ID: 101, Chat: models.Chat{ID: adminID}},
+		},
+	}
+	h.handleSessionDeleteCallback(ctx, b, callback, targetID, cb)
+	if _, err := fsmStorage.GetRaw(ctx, targetID); err != nil {
+		t.Fatalf("expected a non-admin delete to be ignored, got %v", err)
+	}
+	h.handleSessionDeleteCallback(ctx, b, callback, adminID, cb)
+	if _, err := fsmStorage.GetRaw(ctx, targetID); err != storage.ErrSessionNotFound {
+		t.Errorf("expected the session to be deleted, got %v", err)
+	}
+	if edited := rec.editedIDs(); len(edited) != 1 || edited[0] != 101 {
+		t.Errorf("expected the session message to be edited, got %v", edited)
+	}
+
+	command(adminID, "/session 42")
+	if texts := rec.texts(); texts[len(texts)-1] != localizer.MustLocalizeWithTemplate(locale.SessionInspectNotFound, "42") {
+		t.Errorf("expected a not found reply, got %q", texts[len(texts)-1])
+	}
+}
@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleStreaks handles the /streaks command (top members by best streak in the current group)
+func (h *BotHandler) HandleStreaks(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send streaks message", "error", err)
+		}
+	}
+
+	// Determine user's current group context
+	groupID, err := h.groupContextResolver.ResolveGroupForUser(ctx, userID)
+	if err != nil {
+		reply(h.userErrorMessage(ctx, err, "failed to resolve group context", "user_id", userID))
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		reply(h.userErrorMessage(ctx, err, "failed to get group", "group_id", groupID))
+		return
+	}
+
+	ratings, err := h.ratingCalculator.GetTopStreaks(ctx, groupID, 10)
+	if err != nil {
+		reply(h.userErrorMessage(ctx, err, "failed to get top streaks", "group_id", groupID))
+		return
+	}
+
+	if len(ratings) == 0 {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.StreaksEmpty))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.StreaksTitle) + "\n")
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RatingGroupName, group.Name) + "\n\n")
+
+	medals := h.podiumMedals()
+	for i, rating := range ratings {
+		medal := fmt.Sprintf("%d. ", i+1)
+		if i < len(medals) {
+			medal = medals[i] + " "
+		}
+
+		// Display username or user ID if username is not available
+		displayName := fmt.Sprintf("ID: %d", rating.UserID)
+		if rating.Username != "" {
+			displayName = fmt.Sprintf("@%s", rating.Username)
+		}
+
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.StreaksUserEntry, medal, displayName,
+			fmt.Sprintf("%d", rating.BestStreak), fmt.Sprintf("%d", rating.Streak)) + "\n")
+	}
+
+	reply(strings.TrimRight(sb.String(), "\n"))
+}
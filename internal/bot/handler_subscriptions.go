@@ -0,0 +1,157 @@
+package bot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// HandleSubscribe handles the /subscribe command (resume direct messages about new events of a group)
+func (h *BotHandler) HandleSubscribe(ctx context.Context, b *bot.Bot, update *models.Update) {
+	h.showSubscriptionGroups(ctx, b, update, true)
+}
+
+// HandleUnsubscribe handles the /unsubscribe command (stop direct messages about new events of a group)
+func (h *BotHandler) HandleUnsubscribe(ctx context.Context, b *bot.Bot, update *models.Update) {
+	h.showSubscriptionGroups(ctx, b, update, false)
+}
+
+// showSubscriptionGroups offers the user's groups whose subscription can be switched to the given state
+func (h *BotHandler) showSubscriptionGroups(ctx context.Context, b *bot.Bot, update *models.Update, subscribe bool) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	reply := func(text string, kb *models.InlineKeyboardMarkup) {
+		params := &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		}
+		if kb != nil {
+			params.ReplyMarkup = kb
+		}
+		if _, err := b.SendMessage(ctx, params); err != nil {
+			h.logger.Error("failed to send subscription reply", "user_id", userID, "error", err)
+		}
+	}
+
+	groups, err := h.groupRepo.GetUserGroups(ctx, userID)
+	if err != nil {
+		h.replyError(ctx, b, chatID, err, "failed to get user groups", "user_id", userID)
+		return
+	}
+	if len(groups) == 0 {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.GroupContextNoMembership), nil)
+		return
+	}
+
+	unsubscribed, err := h.subscriptionService.UnsubscribedGroups(ctx, userID)
+	if err != nil {
+		h.replyError(ctx, b, chatID, err, "failed to get subscriptions", "user_id", userID)
+		return
+	}
+
+	// Subscribing offers the groups the user left, unsubscribing the ones they still follow
+	icon, state := "🔕 ", 0
+	if subscribe {
+		icon, state = "🔔 ", 1
+	}
+	var buttons [][]models.InlineKeyboardButton
+	for _, group := range groups {
+		if unsubscribed[group.ID] != subscribe {
+			continue
+		}
+		buttons = append(buttons, []models.InlineKeyboardButton{
+			{
+				Text:         icon + group.Name,
+				CallbackData: mustEncodeCallback(cbSubscription, group.ID, state),
+			},
+		})
+	}
+
+	if len(buttons) == 0 {
+		if subscribe {
+			reply(h.requestLocalizer(ctx).MustLocalize(locale.SubscribeAllSubscribed), nil)
+		} else {
+			reply(h.requestLocalizer(ctx).MustLocalize(locale.UnsubscribeAllUnsubscribed), nil)
+		}
+		return
+	}
+
+	title := h.requestLocalizer(ctx).MustLocalize(locale.UnsubscribeTitle)
+	if subscribe {
+		title = h.requestLocalizer(ctx).MustLocalize(locale.SubscribeTitle)
+	}
+	reply(title, &models.InlineKeyboardMarkup{InlineKeyboard: buttons})
+}
+
+// handleSubscriptionCallback subscribes the user to the selected group or unsubscribes them
+func (h *BotHandler) handleSubscriptionCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	if err := cb.Expect(cbSubscription, 2); err != nil {
+		h.logger.Error("invalid subscription callback data", "data", cb.String(), "error", err)
+		return
+	}
+
+	groupID, err := cb.Int64(0)
+	if err != nil {
+		h.logger.Error("failed to parse group ID", "error", err)
+		return
+	}
+	state, err := cb.Int(1)
+	if err != nil {
+		h.logger.Error("failed to parse subscription state", "error", err)
+		return
+	}
+	subscribe := state == 1
+
+	group, err := h.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil || group.Status == domain.GroupStatusDeleted {
+		h.logger.Error("failed to get group", "group_id", groupID, "error", err)
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound),
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	if err := h.subscriptionService.SetSubscribed(ctx, userID, groupID, subscribe); err != nil {
+		text := h.requestLocalizer(ctx).MustLocalize(locale.SubscriptionErrorUpdate)
+		if errors.Is(err, domain.ErrNotSubscribable) {
+			text = h.requestLocalizer(ctx).MustLocalize(locale.GroupErrorNotFound)
+		}
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            text,
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	text := h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.UnsubscribeDone, group.Name)
+	if subscribe {
+		text = h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.SubscribeDone, group.Name)
+	}
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	// Replace the group choice with the confirmation
+	if callback.Message.Message != nil {
+		_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    callback.Message.Message.Chat.ID,
+			MessageID: callback.Message.Message.ID,
+			Text:      text,
+		})
+		if err != nil {
+			h.logger.Error("failed to edit subscription message", "user_id", userID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// transferEventCommand reassigns an event to another group member
+const transferEventCommand = "/transfer_event"
+
+// HandleTransferEvent handles the /transfer_event command (/transfer_event <event_id> <user_id>)
+func (h *BotHandler) HandleTransferEvent(ctx context.Context, b *bot.Bot, update *models.Update) {
+	// Check admin authorization
+	if !h.requireAdmin(ctx, update) {
+		return
+	}
+
+	chatID := update.Message.Chat.ID
+	userID := update.Message.From.ID
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		if err != nil {
+			h.logger.Error("failed to send transfer reply", "error", err)
+		}
+	}
+
+	eventID, newOwnerID, ok := parseTransferEventArgs(update.Message.Text)
+	if !ok {
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.TransferEventUsage))
+		return
+	}
+
+	event, err := h.eventManager.GetEvent(ctx, eventID)
+	if err != nil {
+		h.logger.Warn("event for transfer not found", "event_id", eventID, "error", err)
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.TransferEventNotFound))
+		return
+	}
+	oldOwnerID := event.CreatedBy
+
+	err = h.eventManager.TransferOwnership(ctx, eventID, newOwnerID)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrNewOwnerNotMember):
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.TransferEventNotMember))
+		return
+	case errors.Is(err, domain.ErrAlreadyOwner):
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.TransferEventAlreadyOwner))
+		return
+	default:
+		reply(h.requestLocalizer(ctx).MustLocalize(locale.TransferEventError))
+		return
+	}
+
+	oldOwner := h.getUserDisplayName(ctx, oldOwnerID, event.GroupID)
+	newOwner := h.getUserDisplayName(ctx, newOwnerID, event.GroupID)
+	reply(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.TransferEventSuccess, fmt.Sprintf("%d", eventID), event.Question, oldOwner, newOwner))
+
+	h.logAdminAction(userID, "transfer_event", eventID, fmt.Sprintf("Transferred event from user %d to user %d", oldOwnerID, newOwnerID))
+
+	// Let the new owner know they can now manage the event
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: newOwnerID,
+		Text:   h.chatLocalizer(ctx, newOwnerID).MustLocalizeWithTemplate(locale.TransferEventNewOwnerNotification, fmt.Sprintf("%d", eventID), event.Question),
+	})
+	if err != nil {
+		h.logger.Warn("failed to notify new event owner", "user_id", newOwnerID, "event_id", eventID, "error", err)
+	}
+}
+
+// parseTransferEventArgs parses "/transfer_event <event_id> <user_id>"
+func parseTransferEventArgs(text string) (eventID int64, newOwnerID int64, ok bool) {
+	command, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	if command != transferEventCommand && !strings.HasPrefix(command, transferEventCommand+"@") {
+		return 0, 0, false
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+
+	eventID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || eventID <= 0 {
+		return 0, 0, false
+	}
+	newOwnerID, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || newOwnerID <= 0 {
+		return 0, 0, false
+	}
+
+	return eventID, newOwnerID, true
+}
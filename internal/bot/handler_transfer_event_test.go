@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestParseTransferEventArgs(t *testing.T) {
+	tests := []struct {
+		text    string
+		eventID int64
+		ownerID int64
+		ok      bool
+	}{
+		{"/transfer_event 5 42", 5, 42, true},
+		{"/transfer_event@PredictionBot  7   9 ", 7, 9, true},
+		{"/transfer_event", 0, 0, false},
+		{"/transfer_event 5", 0, 0, false},
+		{"/transfer_event 5 alice", 0, 0, false},
+		{"/transfer_event -1 42", 0, 0, false},
+		{"/transfer_event 5 42 43", 0, 0, false},
+		{"/transfer_eventx 5 42", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		eventID, ownerID, ok := parseTransferEventArgs(tt.text)
+		if ok != tt.ok || eventID != tt.eventID || ownerID != tt.ownerID {
+			t.Errorf("parseTransferEventArgs(%q) = %d, %d, %t; want %d, %d, %t",
+				tt.text, eventID, ownerID, ok, tt.eventID, tt.ownerID, tt.ok)
+		}
+	}
+}
+
+func TestHandleTransferEvent(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	creatorID := int64(100)
+	newOwnerID := int64(200)
+	outsiderID := int64(300)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, creatorID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	userRepo := storage.NewUserRepository(queue)
+	for _, memberID := range []int64{creatorID, newOwnerID} {
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: memberID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+	_ = userRepo.UpsertUserProfile(ctx, creatorID, "creator", "", "")
+	_ = userRepo.UpsertUserProfile(ctx, newOwnerID, "successor", "", "")
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	event := &domain.Event{
+		GroupID:   groupID,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  time.Now().Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: creatorID,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:       &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		eventManager: domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		userRepo:     userRepo,
+		logger:       log,
+		localizer:    localizer,
+	}
+	send := func(text string) string {
+		t.Helper()
+		h.HandleTransferEvent(ctx, b, &models.Update{
+			Message: &models.Message{
+				ID:   1,
+				From: &models.User{ID: adminID},
+				Chat: models.Chat{ID: adminID, Type: models.ChatTypePrivate},
+				Text: text,
+			},
+		})
+		texts := rec.texts()
+		return texts[len(texts)-1]
+	}
+
+	if reply := send("/transfer_event 999 200"); reply != localizer.MustLocalize(locale.TransferEventNotFound) {
+		t.Errorf("expected not found reply, got %q", reply)
+	}
+	if reply := send("/transfer_event 1 300"); reply != localizer.MustLocalize(locale.TransferEventNotMember) {
+		t.Errorf("expected not member reply for user %d, got %q", outsiderID, reply)
+	}
+
+	send("/transfer_event 1 200")
+	texts := rec.texts()
+	expected := localizer.MustLocalizeWithTemplate(locale.TransferEventSuccess, "1", "Will it rain?", "@creator", "@successor")
+	if len(texts) < 2 || texts[len(texts)-2] != expected {
+		t.Errorf("expected success reply %q followed by owner notification, got %q", expected, texts)
+	}
+
+	updated, err := eventRepo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if updated.CreatedBy != newOwnerID {
+		t.Errorf("expected event owner %d, got %d", newOwnerID, updated.CreatedBy)
+	}
+}
@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// upcomingPageSize is the number of events shown per /upcoming page
+const upcomingPageSize = 5
+
+// HandleUpcoming handles the /upcoming command (active events of the user's groups the user
+// hasn't voted on yet, soonest deadline first)
+func (h *BotHandler) HandleUpcoming(ctx context.Context, b *bot.Bot, update *models.Update) {
+	text, kb := h.buildUpcomingPage(ctx, update.Message.From.ID, 0)
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      update.Message.Chat.ID,
+		Text:        text,
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to send upcoming events", "error", err)
+	}
+}
+
+// handleUpcomingCallback shows another /upcoming page
+func (h *BotHandler) handleUpcomingCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, userID int64, cb *Callback) {
+	_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	if callback.Message.Message == nil {
+		return
+	}
+
+	if err := cb.Expect(cbUpcomingPage, 1); err != nil {
+		h.logger.Error("invalid upcoming_page callback data", "data", cb.String(), "error", err)
+		return
+	}
+	offset, err := cb.Int(0)
+	if err != nil || offset < 0 {
+		h.logger.Error("failed to parse upcoming offset", "data", cb.String(), "error", err)
+		return
+	}
+
+	text, kb := h.buildUpcomingPage(ctx, userID, offset)
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		h.logger.Error("failed to edit upcoming events page", "user_id", userID, "error", err)
+	}
+}
+
+// buildUpcomingPage builds the /upcoming page text and keyboard starting at offset. The list is
+// rebuilt on every page, so events voted on in the meantime drop out.
+func (h *BotHandler) buildUpcomingPage(ctx context.Context, userID int64, offset int) (string, *models.InlineKeyboardMarkup) {
+	groups, err := h.groupRepo.GetUserGroups(ctx, userID)
+	if err != nil {
+		return h.userErrorMessage(ctx, err, "failed to get user groups", "user_id", userID), nil
+	}
+
+	if len(groups) == 0 {
+		return h.requestLocalizer(ctx).MustLocalize(locale.GroupContextNoMembership), nil
+	}
+
+	groupIDs := make([]int64, 0, len(groups))
+	groupNames := make(map[int64]string, len(groups))
+	for _, group := range groups {
+		groupIDs = append(groupIDs, group.ID)
+		groupNames[group.ID] = group.Name
+	}
+
+	now := time.Now()
+	events, err := h.eventManager.GetUpcomingEvents(ctx, userID, groupIDs, now)
+	if err != nil {
+		return h.userErrorMessage(ctx, err, "failed to get upcoming events", "user_id", userID), nil
+	}
+
+	if len(events) == 0 {
+		return h.requestLocalizer(ctx).MustLocalize(locale.UpcomingEmpty), nil
+	}
+
+	// Votes cast since the previous page may have shortened the list
+	if offset >= len(events) {
+		offset = (len(events) - 1) / upcomingPageSize * upcomingPageSize
+	}
+	end := offset + upcomingPageSize
+	if end > len(events) {
+		end = len(events)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.UpcomingTitle, fmt.Sprintf("%d", len(events))) + "\n\n")
+
+	for i, event := range events[offset:end] {
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.UpcomingItem, fmt.Sprintf("%d", offset+i+1), event.Question) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.EventsItemGroup, groupNames[event.GroupID]) + "\n")
+		sb.WriteString(h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.UpcomingItemDeadline,
+			h.formatCooldownWait(ctx, event.Deadline.Sub(now)),
+			event.Deadline.In(h.config.Timezone).Format("02.01 15:04")) + "\n\n")
+	}
+
+	sb.WriteString(h.requestLocalizer(ctx).MustLocalize(locale.UpcomingFooter))
+
+	var navRow []models.InlineKeyboardButton
+	if offset > 0 {
+		prevOffset := offset - upcomingPageSize
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		navRow = append(navRow, models.InlineKeyboardButton{
+			Text:         h.requestLocalizer(ctx).MustLocalize(locale.ArchiveButtonPrev),
+			CallbackData: mustEncodeCallback(cbUpcomingPage, prevOffset),
+		})
+	}
+	if end < len(events) {
+		navRow = append(navRow, models.InlineKeyboardButton{
+			Text:         h.requestLocalizer(ctx).MustLocalize(locale.ArchiveButtonNext),
+			CallbackData: mustEncodeCallback(cbUpcomingPage, end),
+		})
+	}
+
+	var kb *models.InlineKeyboardMarkup
+	if len(navRow) > 0 {
+		kb = &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{navRow}}
+	}
+
+	return sb.String(), kb
+}
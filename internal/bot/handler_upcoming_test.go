@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestHandleUpcoming(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	voterID := int64(300)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: voterID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	// Created with the latest deadline first, so the list order can't come from the IDs
+	var events []*domain.Event
+	for i := 7; i >= 1; i-- {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  fmt.Sprintf("Question %d?", i),
+			Options:   []string{"Yes", "No"},
+			CreatedAt: time.Now(),
+			Deadline:  time.Now().Add(time.Duration(i) * time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: adminID,
+			PollID:    fmt.Sprintf("poll-%d", i),
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	// The voter already voted on "Question 4?"
+	prediction := &domain.Prediction{EventID: events[3].ID, UserID: voterID, Option: 0, Timestamp: time.Now()}
+	if err := predictionRepo.SavePrediction(ctx, prediction); err != nil {
+		t.Fatalf("failed to save prediction: %v", err)
+	}
+
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC}
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		config:              cfg,
+		groupRepo:           groupRepo,
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      predictionRepo,
+		logger:              log,
+		localizer:           localizer,
+	}
+
+	h.HandleUpcoming(ctx, b, &models.Update{
+		Message: &models.Message{Text: "/upcoming", From: &models.User{ID: voterID}, Chat: models.Chat{ID: voterID}},
+	})
+	texts := rec.texts()
+	if len(texts) != 1 {
+		t.Fatalf("expected one message, got %d", len(texts))
+	}
+	first := texts[0]
+	if want := localizer.MustLocalizeWithTemplate(locale.UpcomingTitle, "6"); !strings.HasPrefix(first, want) {
+		t.Errorf("expected the title %q, got %q", want, first)
+	}
+	if strings.Contains(first, "Question 4?") {
+		t.Errorf("expected the voted event to be left out, got %q", first)
+	}
+	if !strings.Contains(first, "1. Question 1?") || !strings.Contains(first, "5. Question 6?") || strings.Contains(first, "Question 7?") {
+		t.Errorf("expected the 5 soonest events on the first page, got %q", first)
+	}
+	if strings.Index(first, "Question 1?") > strings.Index(first, "Question 2?") {
+		t.Errorf("expected the soonest deadline first, got %q", first)
+	}
+
+	_, kb := h.buildUpcomingPage(ctx, voterID, 0)
+	if kb == nil || len(kb.InlineKeyboard) != 1 || len(kb.InlineKeyboard[0]) != 1 ||
+		kb.InlineKeyboard[0][0].CallbackData != mustEncodeCallback(cbUpcomingPage, upcomingPageSize) {
+		t.Fatalf("expected a single next button on the first page, got %+v", kb)
+	}
+
+	second, kb := h.buildUpcomingPage(ctx, voterID, upcomingPageSize)
+	if !strings.Contains(second, "6. Question 7?") || strings.Contains(second, "Question 1?") {
+		t.Errorf("expected only the last event on the second page, got %q", second)
+	}
+	if kb == nil || len(kb.InlineKeyboard[0]) != 1 || kb.InlineKeyboard[0][0].CallbackData != mustEncodeCallback(cbUpcomingPage, 0) {
+		t.Errorf("expected a single previous button on the last page, got %+v", kb)
+	}
+
+	// A page past the end falls back to the last page
+	if past, _ := h.buildUpcomingPage(ctx, voterID, 50); past != second {
+		t.Errorf("expected an offset past the end to show the last page, got %q", past)
+	}
+
+	// Non-members get the no-membership message
+	if text, kb := h.buildUpcomingPage(ctx, 999, 0); text != localizer.MustLocalize(locale.GroupContextNoMembership) || kb != nil {
+		t.Errorf("expected the no-membership message, got %q", text)
+	}
+}
@@ -1,10 +1,18 @@
 package bot
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ad/gitelegram-prediction-market/internal/config"
 	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -45,7 +53,7 @@ func TestVotePercentageCalculation(t *testing.T) {
 			handler := &BotHandler{}
 
 			// Calculate vote distribution
-			distribution := handler.calculateVoteDistribution(predictions, numOptions)
+			distribution := handler.calculateVoteDistribution(predictions, numOptions, nil)
 
 			// Verify the calculation
 			// Count actual votes for each option
@@ -112,7 +120,7 @@ func TestVotePercentageCalculationEmptyPredictions(t *testing.T) {
 			}
 
 			handler := &BotHandler{}
-			distribution := handler.calculateVoteDistribution([]*domain.Prediction{}, numOptions)
+			distribution := handler.calculateVoteDistribution([]*domain.Prediction{}, numOptions, nil)
 
 			// All options should have 0%
 			for option := 0; option < numOptions; option++ {
@@ -155,7 +163,7 @@ func TestVotePercentageCalculationSingleVote(t *testing.T) {
 			}
 
 			handler := &BotHandler{}
-			distribution := handler.calculateVoteDistribution(predictions, numOptions)
+			distribution := handler.calculateVoteDistribution(predictions, numOptions, nil)
 
 			// Selected option should have 100%
 			if distribution[selectedOption] != 100.0 {
@@ -181,3 +189,114 @@ func TestVotePercentageCalculationSingleVote(t *testing.T) {
 
 	properties.TestingRun(t)
 }
+
+func TestFormatOdds(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	handler := &BotHandler{localizer: localizer}
+
+	tests := []struct {
+		percentage float64
+		expected   string
+	}{
+		{100, "odds 1.00"},
+		{40, "odds 2.50"},
+		{100.0 / 3, "odds 3.00"},
+		{0, "no odds"},
+	}
+	for _, tt := range tests {
+		if got := handler.formatOdds(context.Background(), tt.percentage); got != tt.expected {
+			t.Errorf("formatOdds(%.2f) = %q, want %q", tt.percentage, got, tt.expected)
+		}
+	}
+}
+
+func TestHandleEvents_Odds(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(100)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, userID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := logger.New(logger.ERROR)
+
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	event := &domain.Event{
+		GroupID:   groupID,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  time.Now().Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: userID,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	cfg := &config.Config{Timezone: time.UTC, EventsShowOdds: true}
+	h := &BotHandler{
+		config:         cfg,
+		groupRepo:      storage.NewGroupRepository(queue),
+		eventManager:   domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo: predictionRepo,
+		logger:         log,
+		localizer:      localizer,
+	}
+	events := func() string {
+		t.Helper()
+		h.HandleEvents(ctx, b, &models.Update{
+			Message: &models.Message{From: &models.User{ID: userID}, Chat: models.Chat{ID: userID}},
+		})
+		texts := rec.texts()
+		return texts[len(texts)-1]
+	}
+
+	// No votes yet: no percentages and no odds
+	text := events()
+	if !strings.Contains(text, localizer.MustLocalize(locale.EventsItemNoOddsYet)) || strings.Contains(text, "%") {
+		t.Errorf("expected no odds yet, got %q", text)
+	}
+
+	for i, option := range []int{0, 0, 0, 1} {
+		prediction := &domain.Prediction{EventID: event.ID, UserID: int64(200 + i), Option: option, Timestamp: time.Now()}
+		if err := predictionRepo.SavePrediction(ctx, prediction); err != nil {
+			t.Fatalf("failed to save prediction: %v", err)
+		}
+	}
+
+	text = events()
+	if !strings.Contains(text, "75.0% · odds 1.33") || !strings.Contains(text, "25.0% · odds 4.00") {
+		t.Errorf("expected odds next to percentages, got %q", text)
+	}
+
+	// Percentages only when odds are disabled
+	cfg.EventsShowOdds = false
+	if text := events(); strings.Contains(text, "odds") || !strings.Contains(text, "75.0%") {
+		t.Errorf("expected percentages without odds, got %q", text)
+	}
+
+	// Bars use the default segments unless configured
+	if text := events(); !strings.Contains(text, "▰▰▰▰▰▰▰▱▱▱ 75.0%") {
+		t.Errorf("expected the default progress bar, got %q", text)
+	}
+	cfg.ProgressBarFilled, cfg.ProgressBarEmpty = "#", "-"
+	if text := events(); !strings.Contains(text, "#######--- 75.0%") || !strings.Contains(text, "##-------- 25.0%") {
+		t.Errorf("expected the configured progress bar, got %q", text)
+	}
+}
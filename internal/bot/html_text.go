@@ -0,0 +1,22 @@
+package bot
+
+import "html"
+
+// htmlNameMaxLength bounds user-provided names (groups, topics, chats, users) in HTML messages;
+// it matches Telegram's chat title limit
+const htmlNameMaxLength = 128
+
+// escapeHTML escapes user-provided text for messages sent with ParseModeHTML,
+// so names and questions can't break the formatting or inject tags
+func escapeHTML(text string) string {
+	return html.EscapeString(text)
+}
+
+// truncateHTML shortens user-provided text to at most maxLength characters and escapes it.
+// The text is cut before escaping, so the cut never falls inside an entity such as &amp;.
+func truncateHTML(text string, maxLength int) string {
+	if maxLength <= 0 {
+		return ""
+	}
+	return escapeHTML(truncateButtonText(text, maxLength))
+}
@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/encoding"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestEscapeHTML(t *testing.T) {
+	if got := escapeHTML(`<b>Tom & "Jerry"</b>`); got != "&lt;b&gt;Tom &amp; &#34;Jerry&#34;&lt;/b&gt;" {
+		t.Errorf("unexpected escaped text: %q", got)
+	}
+	if got := escapeHTML("Прогнозы 2025"); got != "Прогнозы 2025" {
+		t.Errorf("expected plain text to stay unchanged, got %q", got)
+	}
+}
+
+func TestTruncateHTML(t *testing.T) {
+	tests := []struct {
+		text      string
+		maxLength int
+		want      string
+	}{
+		{"Short <name>", 20, "Short &lt;name&gt;"},
+		// The cut lands right after "&", which is escaped as a whole
+		{"Tom & Jerry", 6, "Tom &amp;…"},
+		{"<<<<<<<<<<", 4, "&lt;&lt;&lt;…"},
+		{"Будет ли дождь завтра?", 10, "Будет ли …"},
+		{"anything", 0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := truncateHTML(tt.text, tt.maxLength); got != tt.want {
+			t.Errorf("truncateHTML(%q, %d) = %q, want %q", tt.text, tt.maxLength, got, tt.want)
+		}
+	}
+
+	long := strings.Repeat("&", 1000)
+	got := truncateHTML(long, htmlNameMaxLength)
+	if !strings.HasSuffix(got, "&amp;…") || strings.Count(got, "&amp;") != htmlNameMaxLength-1 {
+		t.Errorf("expected %d whole entities and an ellipsis, got %q", htmlNameMaxLength-1, got)
+	}
+}
+
+func TestHTMLMessagesEscapeUserContent(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	encoder, err := encoding.NewBaseNEncoder("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+
+	groupRepo := storage.NewGroupRepository(queue)
+	if err := groupRepo.UpdateGroupName(ctx, groupID, "<b>Bold</b> & Co"); err != nil {
+		t.Fatalf("failed to rename group: %v", err)
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	h := &BotHandler{
+		bot:                 b,
+		config:              &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		groupRepo:           groupRepo,
+		groupMembershipRepo: storage.NewGroupMembershipRepository(queue),
+		forumTopicRepo:      storage.NewForumTopicRepository(queue),
+		deepLinkService:     domain.NewDeepLinkService("testbot", encoder),
+		logger:              logger.New(logger.ERROR),
+		localizer:           localizer,
+	}
+
+	// The group list shows the name as text
+	h.HandleListGroups(ctx, b, &models.Update{Message: &models.Message{
+		From: &models.User{ID: adminID},
+		Chat: models.Chat{ID: adminID},
+		Text: "/list_groups",
+	}})
+	texts := rec.texts()
+	if len(texts) == 0 || !strings.Contains(texts[0], "&lt;b&gt;Bold&lt;/b&gt; &amp; Co") || strings.Contains(texts[0], "<b>Bold") {
+		t.Errorf("expected an escaped group name in the list, got %q", texts)
+	}
+
+	// The bot-added notification escapes the chat title and the user's name
+	update := botAddedUpdate(-200600, "<i>Chat</i>", false)
+	update.MyChatMember.From = models.User{ID: 42, FirstName: "<u>Eve</u>"}
+	h.HandleMyChatMember(ctx, b, update)
+	texts = rec.texts()
+	notification := texts[len(texts)-1]
+	if !strings.Contains(notification, "&lt;i&gt;Chat&lt;/i&gt;") || !strings.Contains(notification, "&lt;u&gt;Eve&lt;/u&gt;") {
+		t.Errorf("expected escaped chat title and user name, got %q", notification)
+	}
+	if strings.Contains(notification, "<i>Chat") || strings.Contains(notification, "<u>Eve") {
+		t.Errorf("expected no raw user markup, got %q", notification)
+	}
+}
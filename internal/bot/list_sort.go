@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+)
+
+// List orders of LIST_SORT_ORDER; "default" keeps the stored order
+const (
+	listSortName   = "name"
+	listSortScore  = "score"
+	listSortJoined = "joined"
+)
+
+// groupMemberRow is a member of a /group_members list with the values shown and sorted by
+type groupMemberRow struct {
+	member       *domain.GroupMembership
+	displayName  string
+	score        int
+	achievements int
+}
+
+// userGroupRow is a group of a /groups list with the values shown and sorted by
+type userGroupRow struct {
+	group       *domain.Group
+	membership  *domain.GroupMembership
+	activeCount int
+	score       int
+}
+
+// sortName is the name a list is sorted by: display names are compared without the @ of usernames
+func sortName(name string) string {
+	return strings.TrimPrefix(name, "@")
+}
+
+// chatLanguage returns the language of a chat, e.g. of a group for sorting its members by name
+func (h *BotHandler) chatLanguage(ctx context.Context, chatID int64) string {
+	return h.chatLocalizer(ctx, chatID).GetLocale()
+}
+
+// sortGroupsByName sorts groups alphabetically in the request language when lists are sorted by name.
+// Other orders keep the stored order, groups have no score or join date of their own.
+func (h *BotHandler) sortGroupsByName(ctx context.Context, groups []*domain.Group) {
+	if h.config.ListSortOrder != listSortName {
+		return
+	}
+	locale.SortByName(groups, h.requestLocalizer(ctx).GetLocale(), func(g *domain.Group) string {
+		return g.Name
+	})
+}
+
+// sortGroupMemberRows orders the members of a group by LIST_SORT_ORDER; names are compared
+// in the language of the group chat
+func (h *BotHandler) sortGroupMemberRows(ctx context.Context, group *domain.Group, rows []groupMemberRow) {
+	switch h.config.ListSortOrder {
+	case listSortName:
+		locale.SortByName(rows, h.chatLanguage(ctx, group.TelegramChatID), func(r groupMemberRow) string {
+			return sortName(r.displayName)
+		})
+	case listSortScore:
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].score > rows[j].score })
+	case listSortJoined:
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].member.JoinedAt.Before(rows[j].member.JoinedAt) })
+	}
+}
+
+// sortUserGroupRows orders the groups of a user by LIST_SORT_ORDER; names are compared in the request language
+func (h *BotHandler) sortUserGroupRows(ctx context.Context, rows []userGroupRow) {
+	switch h.config.ListSortOrder {
+	case listSortName:
+		locale.SortByName(rows, h.requestLocalizer(ctx).GetLocale(), func(r userGroupRow) string {
+			return r.group.Name
+		})
+	case listSortScore:
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].score > rows[j].score })
+	case listSortJoined:
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].membership.JoinedAt.Before(rows[j].membership.JoinedAt) })
+	}
+}
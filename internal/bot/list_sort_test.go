@@ -0,0 +1,170 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/encoding"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestListSortOrder(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.Ru))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	encoder, err := encoding.NewBaseNEncoder("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+
+	groupRepo := storage.NewGroupRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	userRepo := storage.NewUserRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	eventRepo := storage.NewEventRepository(queue)
+
+	// Members of the test group, in join order, with their scores
+	members := []struct {
+		userID int64
+		name   string
+		score  int
+	}{
+		{101, "Яна", 5},
+		{102, "ёжик", 30},
+		{103, "Борис", 10},
+		{104, "Елена", 20},
+	}
+	joined := time.Now().Add(-time.Duration(len(members)) * time.Hour)
+	for i, m := range members {
+		if err := userRepo.UpsertUserProfile(ctx, m.userID, "", m.name, ""); err != nil {
+			t.Fatalf("failed to create profile: %v", err)
+		}
+		membership := &domain.GroupMembership{GroupID: groupID, UserID: m.userID, JoinedAt: joined.Add(time.Duration(i) * time.Hour), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+		if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: m.userID, GroupID: groupID, Score: m.score}); err != nil {
+			t.Fatalf("failed to create rating: %v", err)
+		}
+	}
+
+	// Groups of the first member, joined in list order after the test group
+	for i, name := range []string{"Яблоко", "Арбуз"} {
+		group := &domain.Group{TelegramChatID: -100600 - int64(i), Name: name, CreatedBy: adminID, CreatedAt: time.Now()}
+		if err := groupRepo.CreateGroup(ctx, group); err != nil {
+			t.Fatalf("failed to create group: %v", err)
+		}
+		membership := &domain.GroupMembership{GroupID: group.ID, UserID: 101, JoinedAt: time.Now().Add(time.Duration(i+1) * time.Minute), Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+		if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: 101, GroupID: group.ID, Score: 10 * (i + 1)}); err != nil {
+			t.Fatalf("failed to create rating: %v", err)
+		}
+	}
+
+	rec, b := newRecordingTelegramServer(t)
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC}
+	h := &BotHandler{
+		bot:                 b,
+		config:              cfg,
+		groupRepo:           groupRepo,
+		groupMembershipRepo: membershipRepo,
+		forumTopicRepo:      storage.NewForumTopicRepository(queue),
+		ratingRepo:          ratingRepo,
+		userRepo:            userRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		achievementTracker:  domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		deepLinkService:     domain.NewDeepLinkService("testbot", encoder),
+		logger:              log,
+		localizer:           localizer,
+	}
+
+	lastText := func() string {
+		t.Helper()
+		texts := rec.texts()
+		if len(texts) == 0 {
+			t.Fatal("expected a message")
+		}
+		return texts[len(texts)-1]
+	}
+	// assertOrder checks that the names appear in text in the given order
+	assertOrder := func(t *testing.T, text string, names ...string) {
+		t.Helper()
+		last := -1
+		for _, name := range names {
+			i := strings.Index(text, name)
+			if i < 0 {
+				t.Fatalf("expected %q in %q", name, text)
+			}
+			if i < last {
+				t.Fatalf("expected the order %v, got %q", names, text)
+			}
+			last = i
+		}
+	}
+	showMembers := func() string {
+		t.Helper()
+		data := mustEncodeCallback(cbGroupMembers, groupID)
+		cb, err := DecodeCallback(data)
+		if err != nil {
+			t.Fatalf("failed to decode callback: %v", err)
+		}
+		callback := &models.CallbackQuery{
+			ID:      "cb",
+			From:    models.User{ID: adminID},
+			Data:    data,
+			Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: models.Chat{ID: adminID}}},
+		}
+		h.handleGroupMembersCallback(ctx, b, callback, adminID, cb)
+		return lastText()
+	}
+	command := func(userID int64, text string) string {
+		t.Helper()
+		update := &models.Update{Message: &models.Message{From: &models.User{ID: userID}, Chat: models.Chat{ID: userID}, Text: text}}
+		switch text {
+		case "/groups":
+			h.HandleGroups(ctx, b, update)
+		case "/list_groups":
+			h.HandleListGroups(ctx, b, update)
+		}
+		return lastText()
+	}
+
+	t.Run("by name", func(t *testing.T) {
+		cfg.ListSortOrder = "name"
+		// Russian alphabet order: "ё" sorts as "е", so "ёжик" comes before "Елена"
+		assertOrder(t, showMembers(), "Борис", "ёжик", "Елена", "Яна")
+		assertOrder(t, command(101, "/groups"), "Test Group", "Арбуз", "Яблоко")
+		assertOrder(t, command(adminID, "/list_groups"), "Test Group", "Арбуз", "Яблоко")
+	})
+
+	t.Run("by score", func(t *testing.T) {
+		cfg.ListSortOrder = "score"
+		assertOrder(t, showMembers(), "ёжик", "Елена", "Борис", "Яна")
+		assertOrder(t, command(101, "/groups"), "Арбуз", "Яблоко", "Test Group")
+	})
+
+	t.Run("by join date", func(t *testing.T) {
+		cfg.ListSortOrder = "joined"
+		assertOrder(t, showMembers(), "Яна", "ёжик", "Борис", "Елена")
+		assertOrder(t, command(101, "/groups"), "Test Group", "Яблоко", "Арбуз")
+	})
+}
@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"unicode/utf16"
+)
+
+// telegramMessageLimit is the maximum length of a Telegram message text in UTF-16 code units
+const telegramMessageLimit = 4096
+
+// chunkMessage joins entries into as few messages as possible without exceeding limit.
+// The header starts the first message; entries are never split, so an entry longer
+// than the limit is sent as a message of its own.
+func chunkMessage(header string, entries []string, limit int) []string {
+	var chunks []string
+	current := header
+	currentLen := messageLength(header)
+
+	for _, entry := range entries {
+		entryLen := messageLength(entry)
+		if currentLen+entryLen > limit && currentLen > 0 {
+			chunks = append(chunks, current)
+			current, currentLen = "", 0
+		}
+		current += entry
+		currentLen += entryLen
+	}
+	if currentLen > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+// messageLength returns the length of a text as counted by Telegram (UTF-16 code units)
+func messageLength(text string) int {
+	return len(utf16.Encode([]rune(text)))
+}
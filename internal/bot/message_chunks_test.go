@@ -0,0 +1,25 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChunkMessage(t *testing.T) {
+	entries := []string{"aaaa\n", "bbbb\n", "cccc\n"}
+
+	if chunks := chunkMessage("head\n", entries, 100); len(chunks) != 1 || chunks[0] != "head\naaaa\nbbbb\ncccc\n" {
+		t.Errorf("expected a single message, got %q", chunks)
+	}
+
+	chunks := chunkMessage("head\n", entries, 12)
+	expected := []string{"head\naaaa\n", "bbbb\ncccc\n"}
+	if strings.Join(chunks, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %q, got %q", expected, chunks)
+	}
+
+	// Emoji count as two UTF-16 code units
+	if chunks := chunkMessage("", []string{"🏆🏆", "🏆"}, 4); len(chunks) != 2 {
+		t.Errorf("expected emoji to be counted in UTF-16 units, got %q", chunks)
+	}
+}
@@ -134,9 +134,12 @@ func isMessageNotFoundError(err error) bool {
 	if err == nil {
 		return false
 	}
-	// Telegram returns "message to delete not found" or similar
+	// Telegram returns "message to delete not found", "message to edit not found" or similar
 	errStr := err.Error()
 	return contains(errStr, "message to delete not found") ||
+		contains(errStr, "message to edit not found") ||
+		contains(errStr, "message to stop not found") ||
+		contains(errStr, "message with poll to stop not found") ||
 		contains(errStr, "message not found") ||
 		contains(errStr, "MESSAGE_ID_INVALID")
 }
@@ -72,8 +72,8 @@ func TestIntegration_UserJoiningAndParticipating(t *testing.T) {
 		t.Fatalf("Failed to create encoder: %v", err)
 	}
 	deepLinkService := domain.NewDeepLinkService(botUsername, encoder)
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
-	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
+	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log)
 
 	// Test data
 	adminUserID := int64(99999)
@@ -369,8 +369,8 @@ func TestIntegration_MultiGroupUser(t *testing.T) {
 	ratingRepo := storage.NewRatingRepository(queue)
 
 	// Create services
-	eventManager := domain.NewEventManager(eventRepo, predictionRepo, log)
-	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, log)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, nil, log)
+	ratingCalculator := domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log)
 
 	// Test data
 	adminUserID := int64(99999)
@@ -0,0 +1,279 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// pollCountdownTick is how often countdowns are checked; each event is edited at its own cadence
+	pollCountdownTick = time.Minute
+	// countdownFinished marks a countdown that needs no more edits (closed, or deleted in the chat)
+	countdownFinished = -1
+)
+
+// PollCountdownBot is the subset of bot methods used by PollCountdownUpdater (for testing)
+type PollCountdownBot interface {
+	SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error)
+	EditMessageText(ctx context.Context, params *bot.EditMessageTextParams) (*models.Message, error)
+}
+
+// PollCountdownRepository is the subset of event storage used by PollCountdownUpdater
+type PollCountdownRepository interface {
+	GetCountdownEvents(ctx context.Context) ([]*domain.Event, error)
+	SetCountdownMessageID(ctx context.Context, eventID int64, messageID int) error
+}
+
+// PollCountdownUpdater keeps a companion message under each poll showing the time left until
+// voting ends (the deadline, or the votes lock time when set). Edits are rare while the deadline is far and frequent during the last hour;
+// once voting closes the message says so and is no longer edited.
+type PollCountdownUpdater struct {
+	bot            PollCountdownBot
+	eventRepo      PollCountdownRepository
+	groupRepo      domain.GroupRepository
+	forumTopicRepo domain.ForumTopicRepository
+	logger         domain.Logger
+	localizer      locale.Localizer
+
+	nextEdit    map[int64]time.Time
+	pausedUntil time.Time
+}
+
+// NewPollCountdownUpdater creates a new PollCountdownUpdater
+func NewPollCountdownUpdater(
+	b PollCountdownBot,
+	eventRepo PollCountdownRepository,
+	groupRepo domain.GroupRepository,
+	forumTopicRepo domain.ForumTopicRepository,
+	logger domain.Logger,
+	localizer locale.Localizer,
+) *PollCountdownUpdater {
+	return &PollCountdownUpdater{
+		bot:            b,
+		eventRepo:      eventRepo,
+		groupRepo:      groupRepo,
+		forumTopicRepo: forumTopicRepo,
+		logger:         logger,
+		localizer:      localizer,
+		nextEdit:       make(map[int64]time.Time),
+	}
+}
+
+// StartScheduler updates countdowns every minute until ctx is cancelled
+func (u *PollCountdownUpdater) StartScheduler(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(pollCountdownTick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				u.logger.Info("poll countdown updater stopped")
+				return
+			case now := <-ticker.C:
+				_ = u.UpdateAll(ctx, now)
+			}
+		}
+	}()
+
+	u.logger.Info("poll countdown updater started", "tick", pollCountdownTick)
+}
+
+// UpdateAll sends, edits or closes the countdown message of every event that is due.
+// When Telegram rate limits the bot, the remaining events wait until the retry time.
+// It must not be called concurrently.
+func (u *PollCountdownUpdater) UpdateAll(ctx context.Context, now time.Time) error {
+	if now.Before(u.pausedUntil) {
+		return nil
+	}
+
+	events, err := u.eventRepo.GetCountdownEvents(ctx)
+	if err != nil {
+		u.logger.Error("failed to get countdown events", "error", err)
+		return err
+	}
+
+	for _, event := range events {
+		err := u.update(ctx, event, now)
+		if err == nil {
+			continue
+		}
+
+		var tooMany *bot.TooManyRequestsError
+		if errors.As(err, &tooMany) {
+			u.pausedUntil = now.Add(time.Duration(tooMany.RetryAfter) * time.Second)
+			u.logger.Warn("poll countdown updates rate limited", "event_id", event.ID, "retry_after", tooMany.RetryAfter)
+			return nil
+		}
+		u.logger.Error("failed to update poll countdown", "event_id", event.ID, "error", err)
+	}
+
+	return nil
+}
+
+// update handles the countdown of one event
+func (u *PollCountdownUpdater) update(ctx context.Context, event *domain.Event, now time.Time) error {
+	closed := !event.IsVotingOpen(now)
+
+	switch {
+	case event.CountdownMessageID == countdownFinished:
+		return nil
+	case event.CountdownMessageID == 0:
+		// Only open polls get a countdown
+		if closed || event.PollMessageID == 0 {
+			return nil
+		}
+		return u.send(ctx, event, now)
+	case closed:
+		return u.finish(ctx, event)
+	}
+
+	if now.Before(u.nextEdit[event.ID]) {
+		return nil
+	}
+
+	err := u.edit(ctx, event, u.countdownText(event.VotingEndsAt().Sub(now)))
+	if err != nil {
+		if isMessageNotFoundError(err) {
+			// Deleted in the chat, don't post it again
+			u.logger.Info("poll countdown message deleted", "event_id", event.ID, "message_id", event.CountdownMessageID)
+			delete(u.nextEdit, event.ID)
+			return u.eventRepo.SetCountdownMessageID(ctx, event.ID, countdownFinished)
+		}
+		return err
+	}
+
+	u.scheduleNextEdit(event, now)
+	return nil
+}
+
+// send posts the countdown message as a reply to the poll
+func (u *PollCountdownUpdater) send(ctx context.Context, event *domain.Event, now time.Time) error {
+	group, err := u.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group %d not found", event.GroupID)
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID: group.TelegramChatID,
+		Text:   u.countdownText(event.VotingEndsAt().Sub(now)),
+		ReplyParameters: &models.ReplyParameters{
+			MessageID:                event.PollMessageID,
+			AllowSendingWithoutReply: true,
+		},
+	}
+	if event.ForumTopicID != nil {
+		topic, err := u.forumTopicRepo.GetForumTopic(ctx, *event.ForumTopicID)
+		if err != nil {
+			u.logger.Error("failed to get forum topic for poll countdown", "event_id", event.ID, "forum_topic_id", *event.ForumTopicID, "error", err)
+		} else if topic != nil {
+			params.MessageThreadID = topic.MessageThreadID
+		}
+	}
+
+	msg, err := u.bot.SendMessage(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if err := u.eventRepo.SetCountdownMessageID(ctx, event.ID, msg.ID); err != nil {
+		return err
+	}
+	event.CountdownMessageID = msg.ID
+
+	u.scheduleNextEdit(event, now)
+	u.logger.Info("poll countdown message created", "event_id", event.ID, "message_id", msg.ID)
+	return nil
+}
+
+// finish shows that voting is closed and stops further edits
+func (u *PollCountdownUpdater) finish(ctx context.Context, event *domain.Event) error {
+	err := u.edit(ctx, event, u.localizer.MustLocalize(locale.PollCountdownClosed))
+	if err != nil {
+		var tooMany *bot.TooManyRequestsError
+		if errors.As(err, &tooMany) {
+			return err
+		}
+		// Retrying every tick would not help a message that cannot be edited
+		u.logger.Warn("failed to show closed poll countdown", "event_id", event.ID, "message_id", event.CountdownMessageID, "error", err)
+	}
+
+	delete(u.nextEdit, event.ID)
+	return u.eventRepo.SetCountdownMessageID(ctx, event.ID, countdownFinished)
+}
+
+// edit replaces the countdown message text; an unchanged text is not an error
+func (u *PollCountdownUpdater) edit(ctx context.Context, event *domain.Event, text string) error {
+	group, err := u.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group %d not found", event.GroupID)
+	}
+
+	_, err = u.bot.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    group.TelegramChatID,
+		MessageID: event.CountdownMessageID,
+		Text:      text,
+	})
+	if err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		return err
+	}
+	return nil
+}
+
+// scheduleNextEdit sets when the countdown of event is edited next, never later than the end of voting
+func (u *PollCountdownUpdater) scheduleNextEdit(event *domain.Event, now time.Time) {
+	votingEndsAt := event.VotingEndsAt()
+	next := now.Add(countdownEditInterval(votingEndsAt.Sub(now)))
+	if next.After(votingEndsAt) {
+		next = votingEndsAt
+	}
+	u.nextEdit[event.ID] = next
+}
+
+// countdownEditInterval returns how long a countdown stays unedited: hourly while the deadline
+// is more than a day away, then more often, and every tick during the last hour
+func countdownEditInterval(remaining time.Duration) time.Duration {
+	switch {
+	case remaining > 24*time.Hour:
+		return time.Hour
+	case remaining > 6*time.Hour:
+		return 15 * time.Minute
+	case remaining > time.Hour:
+		return 5 * time.Minute
+	default:
+		return pollCountdownTick
+	}
+}
+
+// countdownText formats the time left as days and hours, hours and minutes, or minutes (rounded up)
+func (u *PollCountdownUpdater) countdownText(remaining time.Duration) string {
+	minutes := int((remaining + time.Minute - 1) / time.Minute)
+
+	var left string
+	switch {
+	case minutes >= 24*60:
+		hours := minutes / 60
+		left = u.localizer.MustLocalizeWithTemplate(locale.PollCountdownDays, fmt.Sprintf("%d", hours/24), fmt.Sprintf("%d", hours%24))
+	case minutes >= 60:
+		left = u.localizer.MustLocalizeWithTemplate(locale.PollCountdownHours, fmt.Sprintf("%d", minutes/60), fmt.Sprintf("%d", minutes%60))
+	default:
+		left = u.localizer.MustLocalizeWithTemplate(locale.PollCountdownMinutes, fmt.Sprintf("%d", minutes))
+	}
+
+	return u.localizer.MustLocalizeWithTemplate(locale.PollCountdownClosesIn, left)
+}
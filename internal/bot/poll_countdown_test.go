@@ -0,0 +1,245 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// mockPollCountdownBot records sent and edited countdown messages and can fail edits
+type mockPollCountdownBot struct {
+	sent    []*bot.SendMessageParams
+	edited  []*bot.EditMessageTextParams
+	editErr error
+}
+
+func (m *mockPollCountdownBot) SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error) {
+	m.sent = append(m.sent, params)
+	return &models.Message{ID: 900 + len(m.sent)}, nil
+}
+
+func (m *mockPollCountdownBot) EditMessageText(ctx context.Context, params *bot.EditMessageTextParams) (*models.Message, error) {
+	if m.editErr != nil {
+		return nil, m.editErr
+	}
+	m.edited = append(m.edited, params)
+	return &models.Message{ID: params.MessageID}, nil
+}
+
+func setupPollCountdownUpdater(t *testing.T, deadline time.Time) (*PollCountdownUpdater, *mockPollCountdownBot, *storage.EventRepository, int64) {
+	ctx := context.Background()
+	queue, groupID := setupTestGroupAndDB(t, -1001, 1)
+	t.Cleanup(queue.Close)
+
+	eventRepo := storage.NewEventRepository(queue)
+	event := &domain.Event{
+		GroupID:       groupID,
+		Question:      "Will it rain?",
+		Options:       []string{"Yes", "No"},
+		CreatedAt:     time.Now(),
+		Deadline:      deadline,
+		Status:        domain.EventStatusActive,
+		EventType:     domain.EventTypeBinary,
+		CreatedBy:     1,
+		PollID:        "poll_1",
+		PollMessageID: 77,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	mockBot := &mockPollCountdownBot{}
+	updater := NewPollCountdownUpdater(
+		mockBot,
+		eventRepo,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		logger.New(logger.ERROR),
+		localizer,
+	)
+
+	return updater, mockBot, eventRepo, event.ID
+}
+
+func countdownMessageID(t *testing.T, eventRepo *storage.EventRepository, eventID int64) int {
+	t.Helper()
+	event, err := eventRepo.GetEvent(context.Background(), eventID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	return event.CountdownMessageID
+}
+
+func TestPollCountdownUpdater_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	deadline := now.Add(3*time.Hour + 12*time.Minute)
+	updater, mockBot, eventRepo, eventID := setupPollCountdownUpdater(t, deadline)
+
+	// First tick posts the countdown as a reply to the poll
+	if err := updater.UpdateAll(ctx, now); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.sent) != 1 {
+		t.Fatalf("expected 1 sent message, got %d", len(mockBot.sent))
+	}
+	sent := mockBot.sent[0]
+	if sent.ReplyParameters == nil || sent.ReplyParameters.MessageID != 77 {
+		t.Errorf("expected countdown to reply to poll message 77, got %+v", sent.ReplyParameters)
+	}
+	if sent.Text != "⏳ Voting closes in 3h 12m" {
+		t.Errorf("unexpected countdown text: %q", sent.Text)
+	}
+	if got := countdownMessageID(t, eventRepo, eventID); got != 901 {
+		t.Fatalf("expected stored countdown message 901, got %d", got)
+	}
+
+	// Not edited before the cadence interval has passed
+	if err := updater.UpdateAll(ctx, now.Add(time.Minute)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.edited) != 0 {
+		t.Fatalf("expected no edit within the interval, got %d", len(mockBot.edited))
+	}
+
+	if err := updater.UpdateAll(ctx, now.Add(5*time.Minute)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.edited) != 1 || mockBot.edited[0].MessageID != 901 || mockBot.edited[0].Text != "⏳ Voting closes in 3h 7m" {
+		t.Fatalf("expected one countdown edit, got %+v", mockBot.edited)
+	}
+
+	// After the deadline the message shows that voting is closed, once
+	if err := updater.UpdateAll(ctx, deadline.Add(time.Second)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.edited) != 2 || mockBot.edited[1].Text != "🔒 Voting is closed" {
+		t.Fatalf("expected closed edit, got %+v", mockBot.edited)
+	}
+	if got := countdownMessageID(t, eventRepo, eventID); got != countdownFinished {
+		t.Errorf("expected finished countdown, got %d", got)
+	}
+
+	if err := updater.UpdateAll(ctx, deadline.Add(time.Hour)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.sent) != 1 || len(mockBot.edited) != 2 {
+		t.Errorf("expected no more messages after closing, got %d sent and %d edited", len(mockBot.sent), len(mockBot.edited))
+	}
+}
+
+func TestPollCountdownUpdater_ClosesResolvedEvent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	updater, mockBot, eventRepo, eventID := setupPollCountdownUpdater(t, now.Add(48*time.Hour))
+
+	if err := updater.UpdateAll(ctx, now); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if err := eventRepo.ResolveEvent(ctx, eventID, 0); err != nil {
+		t.Fatalf("failed to resolve event: %v", err)
+	}
+
+	if err := updater.UpdateAll(ctx, now.Add(time.Minute)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.edited) != 1 || mockBot.edited[0].Text != "🔒 Voting is closed" {
+		t.Fatalf("expected closed edit for resolved event, got %+v", mockBot.edited)
+	}
+	if got := countdownMessageID(t, eventRepo, eventID); got != countdownFinished {
+		t.Errorf("expected finished countdown, got %d", got)
+	}
+}
+
+func TestPollCountdownUpdater_DeletedMessage(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	updater, mockBot, eventRepo, eventID := setupPollCountdownUpdater(t, now.Add(30*time.Minute))
+
+	if err := updater.UpdateAll(ctx, now); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+
+	mockBot.editErr = errors.New("bad request, Bad Request: message to edit not found")
+	if err := updater.UpdateAll(ctx, now.Add(time.Minute)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if got := countdownMessageID(t, eventRepo, eventID); got != countdownFinished {
+		t.Fatalf("expected finished countdown after deletion, got %d", got)
+	}
+
+	// The deleted countdown is not posted again
+	mockBot.editErr = nil
+	if err := updater.UpdateAll(ctx, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.sent) != 1 || len(mockBot.edited) != 0 {
+		t.Errorf("expected no new messages, got %d sent and %d edited", len(mockBot.sent), len(mockBot.edited))
+	}
+}
+
+func TestPollCountdownUpdater_RateLimited(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	updater, mockBot, eventRepo, eventID := setupPollCountdownUpdater(t, now.Add(30*time.Minute))
+
+	if err := updater.UpdateAll(ctx, now); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+
+	mockBot.editErr = &bot.TooManyRequestsError{Message: "too many requests", RetryAfter: 120}
+	if err := updater.UpdateAll(ctx, now.Add(time.Minute)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if got := countdownMessageID(t, eventRepo, eventID); got != 901 {
+		t.Fatalf("expected countdown to be kept when rate limited, got %d", got)
+	}
+
+	// Paused until the retry time
+	mockBot.editErr = nil
+	if err := updater.UpdateAll(ctx, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.edited) != 0 {
+		t.Fatalf("expected no edit while paused, got %d", len(mockBot.edited))
+	}
+
+	if err := updater.UpdateAll(ctx, now.Add(4*time.Minute)); err != nil {
+		t.Fatalf("UpdateAll failed: %v", err)
+	}
+	if len(mockBot.edited) != 1 || mockBot.edited[0].Text != "⏳ Voting closes in 26m" {
+		t.Errorf("expected countdown edit after the pause, got %+v", mockBot.edited)
+	}
+}
+
+func TestCountdownEditInterval(t *testing.T) {
+	tests := []struct {
+		remaining time.Duration
+		want      time.Duration
+	}{
+		{72 * time.Hour, time.Hour},
+		{12 * time.Hour, 15 * time.Minute},
+		{2 * time.Hour, 5 * time.Minute},
+		{30 * time.Minute, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := countdownEditInterval(tt.remaining); got != tt.want {
+			t.Errorf("countdownEditInterval(%s) = %s, want %s", tt.remaining, got, tt.want)
+		}
+	}
+}
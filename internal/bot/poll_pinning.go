@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+
+	tgbot "github.com/go-telegram/bot"
+)
+
+// pinPermissionErrorMarkers are substrings of Telegram error descriptions returned
+// when the bot is not allowed to pin messages in a chat
+var pinPermissionErrorMarkers = []string{
+	"not enough rights",
+	"chat_admin_required",
+	"can_pin_messages",
+}
+
+// isPinPermissionError reports whether err means the bot lacks permission to pin messages
+func isPinPermissionError(err error) bool {
+	if errors.Is(err, tgbot.ErrorForbidden) {
+		return true
+	}
+
+	description := strings.ToLower(err.Error())
+	for _, marker := range pinPermissionErrorMarkers {
+		if strings.Contains(description, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTooManyPinnedError reports whether err means the chat already has too many pinned messages
+func isTooManyPinnedError(err error) bool {
+	description := strings.ToLower(err.Error())
+	return strings.Contains(description, "too many") && strings.Contains(description, "pin")
+}
+
+// pinPollMessage pins a poll message in the group chat.
+// Failures are logged and never returned: a poll that cannot be pinned is still a valid poll.
+func pinPollMessage(ctx context.Context, b *tgbot.Bot, logger domain.Logger, chatID int64, messageID int) bool {
+	_, err := b.PinChatMessage(ctx, &tgbot.PinChatMessageParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+	})
+	if err == nil {
+		logger.Info("poll pinned", "telegram_chat_id", chatID, "message_id", messageID)
+		return true
+	}
+
+	switch {
+	case isPinPermissionError(err):
+		logger.Warn("bot lacks permission to pin poll", "telegram_chat_id", chatID, "message_id", messageID, "error", err)
+	case isTooManyPinnedError(err):
+		logger.Warn("too many pinned messages to pin poll", "telegram_chat_id", chatID, "message_id", messageID, "error", err)
+	default:
+		logger.Error("failed to pin poll", "telegram_chat_id", chatID, "message_id", messageID, "error", err)
+	}
+	return false
+}
+
+// unpinPollMessage unpins a previously pinned poll message, logging failures
+func unpinPollMessage(ctx context.Context, b *tgbot.Bot, logger domain.Logger, chatID int64, messageID int) {
+	_, err := b.UnpinChatMessage(ctx, &tgbot.UnpinChatMessageParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+	})
+	if err != nil {
+		logger.Warn("failed to unpin poll", "telegram_chat_id", chatID, "message_id", messageID, "error", err)
+		return
+	}
+	logger.Info("poll unpinned", "telegram_chat_id", chatID, "message_id", messageID)
+}
@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+
+	tgbot "github.com/go-telegram/bot"
+)
+
+// newPinTelegramServer returns a bot whose pinChatMessage calls fail with the given response
+func newPinTelegramServer(t *testing.T, pinResponse map[string]interface{}) *tgbot.Bot {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":     true,
+				"result": map[string]interface{}{"id": 1, "is_bot": true, "first_name": "Test"},
+			})
+		case strings.HasSuffix(r.URL.Path, "/pinChatMessage") && pinResponse != nil:
+			_ = json.NewEncoder(w).Encode(pinResponse)
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": true})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := tgbot.New("test-token", tgbot.WithServerURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create bot: %v", err)
+	}
+	return b
+}
+
+func TestPinPollMessage(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pinResponse map[string]interface{}
+		expected    bool
+	}{
+		{"pinned", nil, true},
+		{"no permission", map[string]interface{}{"ok": false, "error_code": 400, "description": "Bad Request: not enough rights to manage pinned messages in the chat"}, false},
+		{"too many pinned", map[string]interface{}{"ok": false, "error_code": 400, "description": "Bad Request: too many pinned messages"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newPinTelegramServer(t, tc.pinResponse)
+			if got := pinPollMessage(context.Background(), b, logger.New(logger.ERROR), -100500, 900); got != tc.expected {
+				t.Errorf("pinPollMessage() = %t, want %t", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPinErrorClassification(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		isPermission  bool
+		isTooManyPins bool
+	}{
+		{"forbidden", fmt.Errorf("%w, Forbidden: bot is not a member of the supergroup chat", tgbot.ErrorForbidden), true, false},
+		{"not enough rights", errors.New("bad request, Bad Request: not enough rights to manage pinned messages in the chat"), true, false},
+		{"admin required", errors.New("bad request, Bad Request: CHAT_ADMIN_REQUIRED"), true, false},
+		{"too many pinned", errors.New("bad request, Bad Request: too many pinned messages"), false, true},
+		{"other", errors.New("bad request, Bad Request: message to pin not found"), false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isPinPermissionError(tc.err); got != tc.isPermission {
+				t.Errorf("isPinPermissionError() = %t, want %t", got, tc.isPermission)
+			}
+			if got := isTooManyPinnedError(tc.err); got != tc.isTooManyPins {
+				t.Errorf("isTooManyPinnedError() = %t, want %t", got, tc.isTooManyPins)
+			}
+		})
+	}
+}
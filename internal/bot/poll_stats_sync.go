@@ -0,0 +1,226 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// PollStatsBot is the subset of bot methods used by PollStatsSyncer (for testing)
+type PollStatsBot interface {
+	SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error)
+	EditMessageText(ctx context.Context, params *bot.EditMessageTextParams) (*models.Message, error)
+}
+
+// PollStatsRepository is the subset of event storage used by PollStatsSyncer
+type PollStatsRepository interface {
+	GetEvent(ctx context.Context, eventID int64) (*domain.Event, error)
+	SetStatsMessageID(ctx context.Context, eventID int64, messageID int) error
+}
+
+// PollStatsSyncer keeps a companion message under each poll in sync with the vote
+// distribution calculated from recorded predictions. Telegram's native counts include
+// votes the bot rejected (e.g. from non-members), so this message shows the
+// authoritative distribution. Edits are debounced per event to avoid rate limits.
+type PollStatsSyncer struct {
+	bot            PollStatsBot
+	eventRepo      PollStatsRepository
+	predictionRepo domain.PredictionRepository
+	groupRepo      domain.GroupRepository
+	forumTopicRepo domain.ForumTopicRepository
+	interval       time.Duration
+	logger         domain.Logger
+	localizer      locale.Localizer
+
+	localizerResolver *locale.LocalizerResolver
+
+	mu       sync.Mutex
+	lastSync map[int64]time.Time
+	pending  map[int64]*time.Timer
+}
+
+// NewPollStatsSyncer creates a new PollStatsSyncer that edits each event's stats message
+// at most once per interval
+func NewPollStatsSyncer(
+	b PollStatsBot,
+	eventRepo PollStatsRepository,
+	predictionRepo domain.PredictionRepository,
+	groupRepo domain.GroupRepository,
+	forumTopicRepo domain.ForumTopicRepository,
+	interval time.Duration,
+	logger domain.Logger,
+	localizer locale.Localizer,
+) *PollStatsSyncer {
+	return &PollStatsSyncer{
+		bot:            b,
+		eventRepo:      eventRepo,
+		predictionRepo: predictionRepo,
+		groupRepo:      groupRepo,
+		forumTopicRepo: forumTopicRepo,
+		interval:       interval,
+		logger:         logger,
+		localizer:      localizer,
+		lastSync:       make(map[int64]time.Time),
+		pending:        make(map[int64]*time.Timer),
+	}
+}
+
+// SetLocalizerResolver enables stats messages in the language chosen for each group chat
+// (the syncer localizer is used by default)
+func (s *PollStatsSyncer) SetLocalizerResolver(localizerResolver *locale.LocalizerResolver) {
+	s.localizerResolver = localizerResolver
+}
+
+// ScheduleUpdate schedules a stats message update for an event.
+// If the event was synced less than interval ago, the update is delayed until the interval
+// has passed; multiple requests within that window are collapsed into a single edit.
+func (s *PollStatsSyncer) ScheduleUpdate(eventID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pending[eventID]; ok {
+		// An update is already scheduled and will pick up the latest predictions
+		return
+	}
+
+	wait := s.interval - time.Since(s.lastSync[eventID])
+	if wait < 0 {
+		wait = 0
+	}
+
+	s.pending[eventID] = time.AfterFunc(wait, func() {
+		s.runScheduled(eventID)
+	})
+}
+
+// runScheduled performs a scheduled update outside of the request context
+func (s *PollStatsSyncer) runScheduled(eventID int64) {
+	s.mu.Lock()
+	delete(s.pending, eventID)
+	s.lastSync[eventID] = time.Now()
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.Sync(ctx, eventID); err != nil {
+		s.logger.Error("failed to sync poll stats", "event_id", eventID, "error", err)
+	}
+}
+
+// Sync immediately sends or edits the stats message for an event
+func (s *PollStatsSyncer) Sync(ctx context.Context, eventID int64) error {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	// Stats are only shown for active polls whose results are visible
+	if event == nil || event.Status != domain.EventStatusActive || event.HideResultsUntilClose {
+		s.forget(eventID)
+		return nil
+	}
+
+	predictions, err := s.predictionRepo.GetPredictionsByEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	group, err := s.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("group %d not found", event.GroupID)
+	}
+
+	text := buildStatsText(resolveChatLocalizer(ctx, s.localizerResolver, group.TelegramChatID, s.localizer), event, predictions)
+
+	if event.StatsMessageID != 0 {
+		_, err := s.bot.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    group.TelegramChatID,
+			MessageID: event.StatsMessageID,
+			Text:      text,
+		})
+		if err != nil && !strings.Contains(err.Error(), "message is not modified") {
+			return err
+		}
+		s.logger.Debug("poll stats message updated", "event_id", eventID, "message_id", event.StatsMessageID)
+		return nil
+	}
+
+	// First sync: send the companion message as a reply to the poll
+	params := &bot.SendMessageParams{
+		ChatID: group.TelegramChatID,
+		Text:   text,
+	}
+	if event.PollMessageID != 0 {
+		params.ReplyParameters = &models.ReplyParameters{
+			MessageID:                event.PollMessageID,
+			AllowSendingWithoutReply: true,
+		}
+	}
+	if event.ForumTopicID != nil {
+		topic, err := s.forumTopicRepo.GetForumTopic(ctx, *event.ForumTopicID)
+		if err != nil {
+			s.logger.Error("failed to get forum topic for poll stats", "event_id", eventID, "forum_topic_id", *event.ForumTopicID, "error", err)
+		} else if topic != nil {
+			params.MessageThreadID = topic.MessageThreadID
+		}
+	}
+
+	msg, err := s.bot.SendMessage(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if err := s.eventRepo.SetStatsMessageID(ctx, eventID, msg.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info("poll stats message created", "event_id", eventID, "message_id", msg.ID)
+	return nil
+}
+
+// forget drops the sync time of an event that no longer gets stats, so the map doesn't grow with every event
+func (s *PollStatsSyncer) forget(eventID int64) {
+	s.mu.Lock()
+	delete(s.lastSync, eventID)
+	s.mu.Unlock()
+}
+
+// buildStatsText formats the vote distribution for an event
+func buildStatsText(localizer locale.Localizer, event *domain.Event, predictions []*domain.Prediction) string {
+	counts := make([]int, len(event.Options))
+	for _, pred := range predictions {
+		if pred.Option >= 0 && pred.Option < len(counts) {
+			counts[pred.Option]++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(localizer.MustLocalize(locale.LivePollStatsTitle))
+	sb.WriteString("\n\n")
+
+	for i, opt := range event.Options {
+		percentage := 0.0
+		if len(predictions) > 0 {
+			percentage = float64(counts[i]) / float64(len(predictions)) * 100.0
+		}
+		sb.WriteString(localizer.MustLocalizeWithTemplate(locale.LivePollStatsOption, opt, fmt.Sprintf("%.1f", percentage), fmt.Sprintf("%d", counts[i])))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(localizer.MustLocalizeWithTemplate(locale.LivePollStatsTotal, fmt.Sprintf("%d", len(predictions))))
+
+	return sb.String()
+}
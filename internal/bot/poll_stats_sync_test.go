@@ -0,0 +1,266 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// mockPollStatsBot records sent and edited stats messages
+type mockPollStatsBot struct {
+	mu     sync.Mutex
+	sent   []*bot.SendMessageParams
+	edited []*bot.EditMessageTextParams
+}
+
+func (m *mockPollStatsBot) SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, params)
+	return &models.Message{ID: 500 + len(m.sent)}, nil
+}
+
+func (m *mockPollStatsBot) EditMessageText(ctx context.Context, params *bot.EditMessageTextParams) (*models.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.edited = append(m.edited, params)
+	return &models.Message{ID: params.MessageID}, nil
+}
+
+func (m *mockPollStatsBot) counts() (int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sent), len(m.edited)
+}
+
+func setupPollStatsSyncer(t *testing.T, interval time.Duration) (*PollStatsSyncer, *mockPollStatsBot, *storage.EventRepository, *storage.PredictionRepository, int64) {
+	ctx := context.Background()
+	chatID := int64(-1001)
+	queue, groupID := setupTestGroupAndDB(t, chatID, 1)
+	t.Cleanup(queue.Close)
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+
+	event := &domain.Event{
+		GroupID:        groupID,
+		Question:       "Will it rain?",
+		Options:        []string{"Yes", "No"},
+		CreatedAt:      time.Now(),
+		Deadline:       time.Now().Add(24 * time.Hour),
+		Status:         domain.EventStatusActive,
+		EventType:      domain.EventTypeBinary,
+		CreatedBy:      1,
+		PollID:         "poll_1",
+		PollMessageID:  77,
+		AllowsRevoting: true,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	mockBot := &mockPollStatsBot{}
+	syncer := NewPollStatsSyncer(
+		mockBot,
+		eventRepo,
+		predictionRepo,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		interval,
+		logger.New(logger.ERROR),
+		localizer,
+	)
+
+	return syncer, mockBot, eventRepo, predictionRepo, event.ID
+}
+
+func TestPollStatsSyncer_SendThenEdit(t *testing.T) {
+	ctx := context.Background()
+	syncer, mockBot, eventRepo, predictionRepo, eventID := setupPollStatsSyncer(t, time.Minute)
+
+	for i, option := range []int{0, 0, 0, 1} {
+		if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{
+			EventID:   eventID,
+			UserID:    int64(100 + i),
+			Option:    option,
+			Timestamp: time.Now(),
+		}); err != nil {
+			t.Fatalf("failed to save prediction: %v", err)
+		}
+	}
+
+	if err := syncer.Sync(ctx, eventID); err != nil {
+		t.Fatalf("first sync failed: %v", err)
+	}
+
+	if len(mockBot.sent) != 1 {
+		t.Fatalf("expected 1 sent message, got %d", len(mockBot.sent))
+	}
+	sent := mockBot.sent[0]
+	if sent.ReplyParameters == nil || sent.ReplyParameters.MessageID != 77 {
+		t.Errorf("expected stats message to reply to poll message 77, got %+v", sent.ReplyParameters)
+	}
+	if !strings.Contains(sent.Text, "Yes: 75.0% (3)") || !strings.Contains(sent.Text, "No: 25.0% (1)") {
+		t.Errorf("unexpected stats text: %s", sent.Text)
+	}
+
+	event, err := eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if event.StatsMessageID != 501 {
+		t.Errorf("expected stats message ID 501 to be stored, got %d", event.StatsMessageID)
+	}
+
+	if err := syncer.Sync(ctx, eventID); err != nil {
+		t.Fatalf("second sync failed: %v", err)
+	}
+
+	if len(mockBot.sent) != 1 || len(mockBot.edited) != 1 {
+		t.Fatalf("expected second sync to edit, got sent=%d edited=%d", len(mockBot.sent), len(mockBot.edited))
+	}
+	if mockBot.edited[0].MessageID != 501 {
+		t.Errorf("expected edit of message 501, got %d", mockBot.edited[0].MessageID)
+	}
+}
+
+func TestPollStatsSyncer_HiddenResultsSkipped(t *testing.T) {
+	ctx := context.Background()
+	syncer, mockBot, eventRepo, _, eventID := setupPollStatsSyncer(t, time.Minute)
+
+	event, err := eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	event.HideResultsUntilClose = true
+	if err := eventRepo.UpdateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+
+	if err := syncer.Sync(ctx, eventID); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	if sent, edited := mockBot.counts(); sent != 0 || edited != 0 {
+		t.Errorf("expected no messages for hidden results, got sent=%d edited=%d", sent, edited)
+	}
+}
+
+func TestPollStatsSyncer_ScheduleUpdateDebounces(t *testing.T) {
+	syncer, mockBot, _, _, eventID := setupPollStatsSyncer(t, 200*time.Millisecond)
+
+	// First update runs immediately
+	syncer.ScheduleUpdate(eventID)
+	time.Sleep(100 * time.Millisecond)
+
+	if sent, edited := mockBot.counts(); sent+edited != 1 {
+		t.Fatalf("expected 1 update after first schedule, got sent=%d edited=%d", sent, edited)
+	}
+
+	// Burst within the interval collapses into a single delayed edit
+	for i := 0; i < 5; i++ {
+		syncer.ScheduleUpdate(eventID)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if sent, edited := mockBot.counts(); sent+edited != 1 {
+		t.Fatalf("expected burst to be delayed, got sent=%d edited=%d", sent, edited)
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	if sent, edited := mockBot.counts(); sent != 1 || edited != 1 {
+		t.Errorf("expected 1 send and 1 edit after interval, got sent=%d edited=%d", sent, edited)
+	}
+}
+
+// resolvingEventRepo resolves the event right after the syncer loaded it, like a /resolve racing a stats sync
+type resolvingEventRepo struct {
+	*storage.EventRepository
+}
+
+func (r *resolvingEventRepo) GetEvent(ctx context.Context, eventID int64) (*domain.Event, error) {
+	event, err := r.EventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *event
+	resolved.Status = domain.EventStatusResolved
+	if err := r.UpdateEvent(ctx, &resolved); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func TestPollStatsSyncer_KeepsConcurrentChanges(t *testing.T) {
+	ctx := context.Background()
+	syncer, _, eventRepo, _, eventID := setupPollStatsSyncer(t, time.Minute)
+	syncer.eventRepo = &resolvingEventRepo{EventRepository: eventRepo}
+
+	if err := syncer.Sync(ctx, eventID); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	event, err := eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		t.Fatalf("failed to get event: %v", err)
+	}
+	if event.Status != domain.EventStatusResolved {
+		t.Errorf("expected the event to stay resolved, got %s", event.Status)
+	}
+	if event.StatsMessageID != 501 {
+		t.Errorf("expected stats message ID 501 to be stored, got %d", event.StatsMessageID)
+	}
+
+	// Updates from copies loaded before the stats message was sent don't clear it
+	event.StatsMessageID = 0
+	if err := eventRepo.UpdateEvent(ctx, event); err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+	if event, _ := eventRepo.GetEvent(ctx, eventID); event.StatsMessageID != 501 {
+		t.Errorf("expected UpdateEvent to keep stats message ID 501, got %d", event.StatsMessageID)
+	}
+
+	// Once the event is resolved its sync time is no longer kept
+	syncer.eventRepo = eventRepo
+	syncer.lastSync[eventID] = time.Now()
+	if err := syncer.Sync(ctx, eventID); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if _, ok := syncer.lastSync[eventID]; ok {
+		t.Error("expected the sync time of a resolved event to be dropped")
+	}
+}
+
+func TestPollStatsSyncer_ChatLanguage(t *testing.T) {
+	ctx := context.Background()
+	syncer, mockBot, _, _, eventID := setupPollStatsSyncer(t, time.Minute)
+
+	resolver, err := locale.NewLocalizerResolver(locale.En, chatLanguages{-1001: locale.Ru})
+	if err != nil {
+		t.Fatalf("NewLocalizerResolver failed: %v", err)
+	}
+	syncer.SetLocalizerResolver(resolver)
+
+	if err := syncer.Sync(ctx, eventID); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if len(mockBot.sent) != 1 || !strings.Contains(mockBot.sent[0].Text, "Текущие результаты") {
+		t.Errorf("expected the stats in the group chat language, got %+v", mockBot.sent)
+	}
+}
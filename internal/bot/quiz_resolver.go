@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+const (
+	// quizResolverTick is how often quizzes past their deadline are looked for
+	quizResolverTick = time.Minute
+	// quizResolverLookback is how far back closed quizzes are looked for, so quizzes that
+	// closed while the bot was down are resolved after a restart
+	quizResolverLookback = 7 * 24 * time.Hour
+)
+
+// QuizResolverRepository is the subset of event storage used by QuizResolver
+type QuizResolverRepository interface {
+	GetEventsByDeadlineRange(ctx context.Context, start, end time.Time) ([]*domain.Event, error)
+}
+
+// QuizResolution resolves a quiz with its correct answer (implemented by EventResolutionFSM)
+type QuizResolution interface {
+	ResolveQuiz(ctx context.Context, event *domain.Event) error
+}
+
+// QuizResolver resolves quiz events once their deadline passes. Their correct answer is known
+// from creation, so unlike prediction events they don't wait for a manager.
+type QuizResolver struct {
+	eventRepo  QuizResolverRepository
+	resolution QuizResolution
+	logger     domain.Logger
+}
+
+// NewQuizResolver creates a new QuizResolver
+func NewQuizResolver(eventRepo QuizResolverRepository, resolution QuizResolution, logger domain.Logger) *QuizResolver {
+	return &QuizResolver{
+		eventRepo:  eventRepo,
+		resolution: resolution,
+		logger:     logger,
+	}
+}
+
+// StartScheduler resolves closed quizzes every minute until ctx is cancelled
+func (r *QuizResolver) StartScheduler(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(quizResolverTick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Info("quiz resolver stopped")
+				return
+			case now := <-ticker.C:
+				_ = r.ResolveClosed(ctx, now)
+			}
+		}
+	}()
+
+	r.logger.Info("quiz resolver started", "tick", quizResolverTick)
+}
+
+// ResolveClosed resolves the active quizzes whose deadline has passed and returns how many were resolved.
+// A quiz that fails to resolve is retried on the next run.
+func (r *QuizResolver) ResolveClosed(ctx context.Context, now time.Time) int {
+	events, err := r.eventRepo.GetEventsByDeadlineRange(ctx, now.Add(-quizResolverLookback), now)
+	if err != nil {
+		r.logger.Error("failed to get closed quizzes", "error", err)
+		return 0
+	}
+
+	resolved := 0
+	for _, event := range events {
+		if !event.IsQuiz() || event.Status != domain.EventStatusActive {
+			continue
+		}
+		if err := r.resolution.ResolveQuiz(ctx, event); err != nil {
+			r.logger.Error("failed to resolve closed quiz", "event_id", event.ID, "error", err)
+			continue
+		}
+		resolved++
+	}
+
+	if resolved > 0 {
+		r.logger.Info("closed quizzes resolved", "count", resolved)
+	}
+	return resolved
+}
@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+)
+
+func TestQuizResolver_ResolvesClosedQuizzes(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	_, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	eventManager := domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log)
+	fsm := NewEventResolutionFSM(
+		storage.NewFSMStorage(queue, log),
+		b,
+		eventManager,
+		domain.NewAchievementTracker(storage.NewAchievementRepository(queue), ratingRepo, predictionRepo, eventRepo, nil, log),
+		domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		predictionRepo,
+		storage.NewGroupRepository(queue),
+		storage.NewForumTopicRepository(queue),
+		domain.NewEventPermissionValidator(eventRepo, predictionRepo, membershipRepo, 0, log),
+		domain.NewNotificationService(b, eventRepo, predictionRepo, ratingRepo, storage.NewReminderRepository(queue), log, localizer),
+		&config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC},
+		log,
+		localizer,
+	)
+
+	now := time.Now()
+	answer := 1
+	createEvent := func(pollID string, deadline time.Time, quizAnswer *int) *domain.Event {
+		t.Helper()
+		event := &domain.Event{
+			GroupID:    groupID,
+			Question:   "Which planet is the largest?",
+			Options:    []string{"Mars", "Jupiter"},
+			CreatedAt:  now.Add(-48 * time.Hour),
+			Deadline:   deadline,
+			Status:     domain.EventStatusActive,
+			EventType:  domain.EventTypeBinary,
+			CreatedBy:  adminID,
+			PollID:     pollID,
+			QuizAnswer: quizAnswer,
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		return event
+	}
+	closedQuiz := createEvent("poll_closed_quiz", now.Add(-time.Hour), &answer)
+	openQuiz := createEvent("poll_open_quiz", now.Add(time.Hour), &answer)
+	closedPrediction := createEvent("poll_closed_prediction", now.Add(-time.Hour), nil)
+
+	for userID, option := range map[int64]int{100: 1, 101: 0} {
+		prediction := &domain.Prediction{EventID: closedQuiz.ID, UserID: userID, Option: option, Timestamp: now.Add(-24 * time.Hour)}
+		if err := predictionRepo.SavePrediction(ctx, prediction); err != nil {
+			t.Fatalf("failed to save prediction: %v", err)
+		}
+	}
+
+	resolver := NewQuizResolver(eventRepo, fsm, log)
+	if resolved := resolver.ResolveClosed(ctx, now); resolved != 1 {
+		t.Errorf("expected 1 quiz resolved, got %d", resolved)
+	}
+
+	status := func(eventID int64) (domain.EventStatus, *int) {
+		t.Helper()
+		event, err := eventRepo.GetEvent(ctx, eventID)
+		if err != nil {
+			t.Fatalf("failed to get event: %v", err)
+		}
+		return event.Status, event.CorrectOption
+	}
+	if got, correct := status(closedQuiz.ID); got != domain.EventStatusResolved || correct == nil || *correct != answer {
+		t.Errorf("expected the closed quiz resolved with option %d, got %s %v", answer, got, correct)
+	}
+	if got, _ := status(openQuiz.ID); got != domain.EventStatusActive {
+		t.Errorf("expected the open quiz to stay active, got %s", got)
+	}
+	if got, _ := status(closedPrediction.ID); got != domain.EventStatusActive {
+		t.Errorf("expected the prediction event to wait for its manager, got %s", got)
+	}
+
+	// Quiz answers are scored without penalties and don't count as predictions
+	correctRating, err := ratingRepo.GetRating(ctx, 100, groupID)
+	if err != nil {
+		t.Fatalf("failed to get rating: %v", err)
+	}
+	if correctRating.Score != domain.QuizCorrectPoints+domain.ParticipationPoints || correctRating.CorrectCount != 0 || correctRating.Streak != 0 {
+		t.Errorf("unexpected rating for the correct answer: %+v", correctRating)
+	}
+	wrongRating, err := ratingRepo.GetRating(ctx, 101, groupID)
+	if err != nil {
+		t.Fatalf("failed to get rating: %v", err)
+	}
+	if wrongRating.Score != domain.ParticipationPoints || wrongRating.WrongCount != 0 {
+		t.Errorf("unexpected rating for the wrong answer: %+v", wrongRating)
+	}
+
+	// Resolved quizzes are not resolved again
+	if resolved := resolver.ResolveClosed(ctx, now); resolved != 0 {
+		t.Errorf("expected nothing left to resolve, got %d", resolved)
+	}
+}
@@ -121,7 +121,7 @@ func (f *RenameFSM) HandleMessage(ctx context.Context, update *models.Update) er
 	if newName == "" {
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.RenameErrorEmptyName),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.RenameErrorEmptyName),
 		})
 		return nil
 	}
@@ -129,7 +129,7 @@ func (f *RenameFSM) HandleMessage(ctx context.Context, update *models.Update) er
 	if len(newName) > 100 {
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.RenameErrorNameTooLong),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.RenameErrorNameTooLong),
 		})
 		return nil
 	}
@@ -152,7 +152,7 @@ func (f *RenameFSM) handleGroupRename(ctx context.Context, userID int64, chatID
 		f.logger.Error("failed to get group_id from context", "context", contextData)
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.RenameErrorGetContext),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.RenameErrorGetContext),
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return fmt.Errorf("invalid group_id in context")
@@ -167,7 +167,7 @@ func (f *RenameFSM) handleGroupRename(ctx context.Context, userID int64, chatID
 		f.logger.Error("failed to update group name", "group_id", groupID, "error", err)
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.RenameErrorUpdateGroup),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.RenameErrorUpdateGroup),
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return err
@@ -178,7 +178,7 @@ func (f *RenameFSM) handleGroupRename(ctx context.Context, userID int64, chatID
 	// Send confirmation
 	_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   f.localizer.MustLocalizeWithTemplate(locale.RenameGroupSuccess, oldName, newName),
+		Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RenameGroupSuccess, oldName, newName),
 	})
 
 	// Clear session
@@ -194,7 +194,7 @@ func (f *RenameFSM) handleTopicRename(ctx context.Context, userID int64, chatID
 		f.logger.Error("failed to get topic_id from context", "context", contextData)
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.RenameErrorGetContext),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.RenameErrorGetContext),
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return fmt.Errorf("invalid topic_id in context")
@@ -209,7 +209,7 @@ func (f *RenameFSM) handleTopicRename(ctx context.Context, userID int64, chatID
 		f.logger.Error("failed to update topic name", "topic_id", topicID, "error", err)
 		_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   f.localizer.MustLocalize(locale.RenameErrorUpdateTopic),
+			Text:   f.requestLocalizer(ctx).MustLocalize(locale.RenameErrorUpdateTopic),
 		})
 		_ = f.storage.Delete(ctx, userID)
 		return err
@@ -220,7 +220,7 @@ func (f *RenameFSM) handleTopicRename(ctx context.Context, userID int64, chatID
 	// Send confirmation
 	_, _ = f.bot.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   f.localizer.MustLocalizeWithTemplate(locale.RenameTopicSuccess, oldName, newName),
+		Text:   f.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.RenameTopicSuccess, oldName, newName),
 	})
 
 	// Clear session
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// updateUserAndChat returns the acting user and the chat of an update, zero when absent
+func updateUserAndChat(update *models.Update) (userID, chatID int64) {
+	if update == nil {
+		return 0, 0
+	}
+
+	switch {
+	case update.Message != nil:
+		if update.Message.From != nil {
+			userID = update.Message.From.ID
+		}
+		chatID = update.Message.Chat.ID
+	case update.CallbackQuery != nil:
+		userID = update.CallbackQuery.From.ID
+		if update.CallbackQuery.Message.Message != nil {
+			chatID = update.CallbackQuery.Message.Message.Chat.ID
+		}
+	case update.PollAnswer != nil:
+		if update.PollAnswer.User != nil {
+			userID = update.PollAnswer.User.ID
+		}
+	case update.MyChatMember != nil:
+		userID = update.MyChatMember.From.ID
+		chatID = update.MyChatMember.Chat.ID
+	}
+	return userID, chatID
+}
+
+// localizerFor returns the localizer for the user and chat of update,
+// or the handler localizer when no resolver is configured
+func (h *BotHandler) localizerFor(ctx context.Context, update *models.Update) locale.Localizer {
+	if h.localizerResolver == nil {
+		return h.localizer
+	}
+	userID, chatID := updateUserAndChat(update)
+	return h.localizerResolver.Resolve(ctx, userID, chatID)
+}
+
+// WithRequestLocalizer returns a copy of ctx carrying the localizer for the user and chat of update.
+// Applied to every update by the bot middleware, so command handlers reply in the chosen language.
+func (h *BotHandler) WithRequestLocalizer(ctx context.Context, update *models.Update) context.Context {
+	return contextWithLocalizer(ctx, h.localizerFor(ctx, update))
+}
+
+// contextWithLocalizer returns a copy of ctx carrying the request localizer
+func contextWithLocalizer(ctx context.Context, loc locale.Localizer) context.Context {
+	return context.WithValue(ctx, requestLocalizerKey, loc)
+}
+
+// localizerFromContext returns the request localizer stored in ctx, falling back to fallback
+func localizerFromContext(ctx context.Context, fallback locale.Localizer) locale.Localizer {
+	if loc, ok := ctx.Value(requestLocalizerKey).(locale.Localizer); ok {
+		return loc
+	}
+	return fallback
+}
+
+// requestLocalizer returns the request localizer stored in ctx, falling back to the handler localizer
+func (h *BotHandler) requestLocalizer(ctx context.Context) locale.Localizer {
+	return localizerFromContext(ctx, h.localizer)
+}
+
+// requestLocalizer returns the request localizer stored in ctx, falling back to the FSM localizer
+func (f *EventCreationFSM) requestLocalizer(ctx context.Context) locale.Localizer {
+	return localizerFromContext(ctx, f.localizer)
+}
+
+// requestLocalizer returns the request localizer stored in ctx, falling back to the FSM localizer
+func (f *EventEditFSM) requestLocalizer(ctx context.Context) locale.Localizer {
+	return localizerFromContext(ctx, f.localizer)
+}
+
+// requestLocalizer returns the request localizer stored in ctx, falling back to the FSM localizer
+func (f *EventResolutionFSM) requestLocalizer(ctx context.Context) locale.Localizer {
+	return localizerFromContext(ctx, f.localizer)
+}
+
+// requestLocalizer returns the request localizer stored in ctx, falling back to the FSM localizer
+func (f *GroupCreationFSM) requestLocalizer(ctx context.Context) locale.Localizer {
+	return localizerFromContext(ctx, f.localizer)
+}
+
+// requestLocalizer returns the request localizer stored in ctx, falling back to the FSM localizer
+func (f *RenameFSM) requestLocalizer(ctx context.Context) locale.Localizer {
+	return localizerFromContext(ctx, f.localizer)
+}
+
+// resolveChatLocalizer returns the localizer for a message sent to chatID rather than in reply to the request:
+// the language of the user of a private chat or of a group chat, or fallback when no resolver is configured
+func resolveChatLocalizer(ctx context.Context, resolver *locale.LocalizerResolver, chatID int64, fallback locale.Localizer) locale.Localizer {
+	if resolver == nil {
+		return fallback
+	}
+	if chatID > 0 {
+		return resolver.Resolve(ctx, chatID, 0)
+	}
+	return resolver.Resolve(ctx, 0, chatID)
+}
+
+// chatLocalizer returns the localizer for a message sent to chatID, e.g. to an admin or a group chat
+func (h *BotHandler) chatLocalizer(ctx context.Context, chatID int64) locale.Localizer {
+	return resolveChatLocalizer(ctx, h.localizerResolver, chatID, h.localizer)
+}
+
+// contextForChat returns a copy of ctx whose request localizer is the one of chatID, so a message sent there
+// can be built with the helpers that localize with the request localizer
+func (h *BotHandler) contextForChat(ctx context.Context, chatID int64) context.Context {
+	return contextWithLocalizer(ctx, h.chatLocalizer(ctx, chatID))
+}
+
+// chatLocalizer returns the localizer for a message sent to chatID, e.g. to an admin or a group chat
+func (f *EventCreationFSM) chatLocalizer(ctx context.Context, chatID int64) locale.Localizer {
+	return resolveChatLocalizer(ctx, f.localizerResolver, chatID, f.localizer)
+}
+
+// chatLocalizer returns the localizer for a message sent to chatID, e.g. to an admin or a group chat
+func (f *EventEditFSM) chatLocalizer(ctx context.Context, chatID int64) locale.Localizer {
+	return resolveChatLocalizer(ctx, f.localizerResolver, chatID, f.localizer)
+}
+
+// chatLocalizer returns the localizer for a message sent to chatID, e.g. to an admin or a group chat
+func (f *EventResolutionFSM) chatLocalizer(ctx context.Context, chatID int64) locale.Localizer {
+	return resolveChatLocalizer(ctx, f.localizerResolver, chatID, f.localizer)
+}
+
+// chatLocalizer returns the localizer for a message sent to chatID, e.g. to an admin or a group chat
+func (f *GroupCreationFSM) chatLocalizer(ctx context.Context, chatID int64) locale.Localizer {
+	return resolveChatLocalizer(ctx, f.localizerResolver, chatID, f.localizer)
+}
+
+// localizeDynamic localizes a key chosen at run time without panicking. A missing key is logged
+// and shown as the default-language text, or as the key itself when no language has it.
+func localizeDynamic(loc locale.Localizer, log domain.Logger, key string) string {
+	text, err := loc.Localize(key)
+	if err != nil {
+		log.Warn("missing locale key", "key", key, "language", loc.GetLocale(), "error", err)
+		if text == "" {
+			return key
+		}
+	}
+	return text
+}
+
+// localizeKey is localizeDynamic with the handler localizer and logger
+func (h *BotHandler) localizeKey(ctx context.Context, key string) string {
+	return localizeDynamic(h.requestLocalizer(ctx), h.logger, key)
+}
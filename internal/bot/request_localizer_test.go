@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// chatLanguages serves a fixed language per chat
+type chatLanguages map[int64]string
+
+func (c chatLanguages) UserLanguage(ctx context.Context, userID int64) (string, error) {
+	return "", nil
+}
+
+func (c chatLanguages) ChatLanguage(ctx context.Context, chatID int64) (string, error) {
+	return c[chatID], nil
+}
+
+func TestLocalizerFor(t *testing.T) {
+	resolver, err := locale.NewLocalizerResolver(locale.En, chatLanguages{-100: locale.Ru})
+	if err != nil {
+		t.Fatalf("NewLocalizerResolver failed: %v", err)
+	}
+	h := &BotHandler{localizer: resolver.Default(), localizerResolver: resolver}
+
+	update := &models.Update{
+		Message: &models.Message{
+			From: &models.User{ID: 42},
+			Chat: models.Chat{ID: -100},
+		},
+	}
+
+	ctx := contextWithLocalizer(context.Background(), h.localizerFor(context.Background(), update))
+	if got := h.requestLocalizer(ctx).GetLocale(); got != locale.Ru {
+		t.Errorf("expected the chat language %q, got %q", locale.Ru, got)
+	}
+	if got := h.requestLocalizer(context.Background()).GetLocale(); got != locale.En {
+		t.Errorf("expected the handler localizer %q without a request localizer, got %q", locale.En, got)
+	}
+}
+
+func TestLocalizeDynamic_MissingKey(t *testing.T) {
+	// NewLocalizer builds a strict localizer whose MustLocalize panics on missing keys
+	loc, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.Ru))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	log := &capturingLogger{}
+
+	if got := localizeDynamic(loc, log, "NoSuchMessageKey"); got != "NoSuchMessageKey" {
+		t.Errorf("expected the key name for a missing key, got %q", got)
+	}
+	entries := log.getEntries()
+	if len(entries) != 1 || entries[0].level != "WARN" || entries[0].fields["key"] != "NoSuchMessageKey" {
+		t.Errorf("expected one warning naming the missing key, got %+v", entries)
+	}
+
+	if got, want := localizeDynamic(loc, log, locale.ErrorGeneric), loc.MustLocalize(locale.ErrorGeneric); got != want {
+		t.Errorf("expected %q for an existing key, got %q", want, got)
+	}
+	if len(log.getEntries()) != 1 {
+		t.Errorf("expected no warning for an existing key, got %+v", log.getEntries())
+	}
+}
@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// contextKey is the type of values the bot stores in a request context
+type contextKey int
+
+const (
+	// requestLoggerKey holds the logger derived for the current update
+	requestLoggerKey contextKey = iota
+	// requestLocalizerKey holds the localizer resolved for the current update
+	requestLocalizerKey
+)
+
+// fieldsLogger adds fixed key-value pairs to every message of a logger without With support
+type fieldsLogger struct {
+	base   domain.Logger
+	fields []interface{}
+}
+
+func (l *fieldsLogger) Debug(msg string, args ...interface{}) {
+	l.base.Debug(msg, l.withFields(args)...)
+}
+
+func (l *fieldsLogger) Info(msg string, args ...interface{}) {
+	l.base.Info(msg, l.withFields(args)...)
+}
+
+func (l *fieldsLogger) Warn(msg string, args ...interface{}) {
+	l.base.Warn(msg, l.withFields(args)...)
+}
+
+func (l *fieldsLogger) Error(msg string, args ...interface{}) {
+	l.base.Error(msg, l.withFields(args)...)
+}
+
+func (l *fieldsLogger) withFields(args []interface{}) []interface{} {
+	return append(append([]interface{}{}, l.fields...), args...)
+}
+
+// withLogFields returns a child of base that adds fields to every message
+func withLogFields(base domain.Logger, fields ...interface{}) domain.Logger {
+	if len(fields) == 0 {
+		return base
+	}
+	if l, ok := base.(*logger.Logger); ok {
+		return l.With(fields...)
+	}
+	return &fieldsLogger{base: base, fields: fields}
+}
+
+// updateLogFields returns the identifying fields of an update: its ID, the acting user and the chat
+func updateLogFields(update *models.Update) []interface{} {
+	if update == nil {
+		return nil
+	}
+
+	fields := []interface{}{"update_id", update.ID}
+	switch {
+	case update.Message != nil:
+		if update.Message.From != nil {
+			fields = append(fields, "user_id", update.Message.From.ID)
+		}
+		fields = append(fields, "chat_id", update.Message.Chat.ID)
+	case update.CallbackQuery != nil:
+		fields = append(fields, "user_id", update.CallbackQuery.From.ID)
+		if update.CallbackQuery.Message.Message != nil {
+			fields = append(fields, "chat_id", update.CallbackQuery.Message.Message.Chat.ID)
+		}
+	case update.PollAnswer != nil:
+		if update.PollAnswer.User != nil {
+			fields = append(fields, "user_id", update.PollAnswer.User.ID)
+		}
+	case update.MyChatMember != nil:
+		fields = append(fields, "user_id", update.MyChatMember.From.ID, "chat_id", update.MyChatMember.Chat.ID)
+	}
+	return fields
+}
+
+// loggerFor returns a logger that tags every message with the update, user and chat IDs of update
+func (h *BotHandler) loggerFor(update *models.Update) domain.Logger {
+	return withLogFields(h.logger, updateLogFields(update)...)
+}
+
+// contextWithLogger returns a copy of ctx carrying the request logger
+func contextWithLogger(ctx context.Context, log domain.Logger) context.Context {
+	return context.WithValue(ctx, requestLoggerKey, log)
+}
+
+// requestLogger returns the request logger stored in ctx, falling back to the handler logger
+func (h *BotHandler) requestLogger(ctx context.Context) domain.Logger {
+	if log, ok := ctx.Value(requestLoggerKey).(domain.Logger); ok {
+		return log
+	}
+	return h.logger
+}
@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestLoggerFor(t *testing.T) {
+	base := &capturingLogger{}
+	h := &BotHandler{logger: base}
+
+	update := &models.Update{
+		ID: 7,
+		CallbackQuery: &models.CallbackQuery{
+			From: models.User{ID: 42},
+			Message: models.MaybeInaccessibleMessage{
+				Message: &models.Message{Chat: models.Chat{ID: -100}},
+			},
+		},
+	}
+
+	log := withLogFields(h.loggerFor(update), "event_id", 5)
+	log.Error("failed to save prediction", "error", "database is locked")
+
+	entries := base.getEntries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	expected := map[string]interface{}{
+		"update_id": int64(7),
+		"user_id":   int64(42),
+		"chat_id":   int64(-100),
+		"event_id":  5,
+		"error":     "database is locked",
+	}
+	for key, value := range expected {
+		if entries[0].fields[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, entries[0].fields[key])
+		}
+	}
+	if entries[0].level != "ERROR" || entries[0].message != "failed to save prediction" {
+		t.Errorf("unexpected entry %+v", entries[0])
+	}
+}
+
+func TestRequestLoggerFromContext(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	base := &capturingLogger{}
+	h := &BotHandler{logger: base, localizer: localizer}
+
+	// Without a request logger the handler logger is used as is
+	if h.requestLogger(context.Background()) != base {
+		t.Error("expected the handler logger without a request logger in the context")
+	}
+
+	update := &models.Update{
+		ID:         3,
+		PollAnswer: &models.PollAnswer{PollID: "poll", User: &models.User{ID: 42}},
+	}
+	ctx := contextWithLogger(context.Background(), h.loggerFor(update))
+	_ = h.userErrorMessageWith(h.requestLogger(ctx), h.requestLocalizer(ctx), errors.New("database is locked"), "failed to get event")
+
+	entries := base.getEntries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	if entries[0].fields["update_id"] != int64(3) || entries[0].fields["user_id"] != int64(42) {
+		t.Errorf("expected request fields on the error log, got %v", entries[0].fields)
+	}
+	if _, ok := entries[0].fields["chat_id"]; ok {
+		t.Errorf("expected no chat_id for a poll answer, got %v", entries[0].fields)
+	}
+}
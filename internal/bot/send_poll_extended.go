@@ -6,9 +6,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	tgbot "github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -29,6 +31,8 @@ type ExtendedSendPollParams struct {
 	AllowsRevoting         *bool                    `json:"allows_revoting,omitempty"`
 	ShuffleOptions         bool                     `json:"shuffle_options,omitempty"`
 	HideResultsUntilCloses bool                     `json:"hide_results_until_closes,omitempty"`
+	Type                   string                   `json:"type,omitempty"`              // "quiz" for quiz polls, empty for regular polls
+	CorrectOptionID        *int                     `json:"correct_option_id,omitempty"` // Correct option of a quiz poll
 }
 
 type telegramAPIResponse struct {
@@ -38,6 +42,46 @@ type telegramAPIResponse struct {
 	ErrorCode   int             `json:"error_code,omitempty"`
 }
 
+// telegramAPIError is returned when the Telegram Bot API responds with ok=false
+type telegramAPIError struct {
+	Code        int
+	Description string
+}
+
+func (e *telegramAPIError) Error() string {
+	return fmt.Sprintf("telegram API error %d: %s", e.Code, e.Description)
+}
+
+// pollPermissionErrorMarkers are description fragments Telegram returns when the bot
+// is not allowed to post polls in a chat
+var pollPermissionErrorMarkers = []string{
+	"not enough rights",
+	"have no rights",
+	"need administrator rights",
+	"chat_send_polls_forbidden",
+	"chat_write_forbidden",
+	"polls can't be sent",
+}
+
+// isPollPermissionError reports whether err means the bot lacks permission to send polls
+func isPollPermissionError(err error) bool {
+	var apiErr *telegramAPIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Code == http.StatusForbidden {
+		return true
+	}
+
+	description := strings.ToLower(apiErr.Description)
+	for _, marker := range pollPermissionErrorMarkers {
+		if strings.Contains(description, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // telegramAPIBaseURL is the base URL for Telegram Bot API.
 // Overridden in tests.
 var telegramAPIBaseURL = "https://api.telegram.org"
@@ -73,7 +117,7 @@ func sendPollExtended(ctx context.Context, b *tgbot.Bot, params *ExtendedSendPol
 	}
 
 	if !apiResp.OK {
-		return nil, fmt.Errorf("telegram API error %d: %s", apiResp.ErrorCode, apiResp.Description)
+		return nil, &telegramAPIError{Code: apiResp.ErrorCode, Description: apiResp.Description}
 	}
 
 	var msg models.Message
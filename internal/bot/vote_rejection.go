@@ -0,0 +1,182 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+
+	"github.com/go-telegram/bot"
+)
+
+// voteRejectionCooldown is how long further rejected votes of a user on the same event get no new notice
+const voteRejectionCooldown = time.Hour
+
+// voteRejectionKey identifies the user and event of a rejected vote notice
+type voteRejectionKey struct {
+	userID  int64
+	eventID int64
+}
+
+// allowVoteRejectionNotice records a notice to the user about the event at now and reports whether it is allowed,
+// i.e. no notice about the same event was sent to them within voteRejectionCooldown. Of concurrent callers
+// only the one that records the notice is allowed.
+func (h *BotHandler) allowVoteRejectionNotice(key voteRejectionKey, now time.Time) bool {
+	last, loaded := h.voteRejectionNotices.LoadOrStore(key, now)
+	for loaded {
+		if now.Sub(last.(time.Time)) < voteRejectionCooldown {
+			return false
+		}
+		if h.voteRejectionNotices.CompareAndSwap(key, last, now) {
+			return true
+		}
+		// Another notice was recorded meanwhile, or the record was pruned
+		last, loaded = h.voteRejectionNotices.LoadOrStore(key, now)
+	}
+	return true
+}
+
+// pruneVoteRejectionNotices drops the notice records whose cooldown is over at now
+func (h *BotHandler) pruneVoteRejectionNotices(now time.Time) {
+	h.voteRejectionNotices.Range(func(k, v interface{}) bool {
+		if now.Sub(v.(time.Time)) >= voteRejectionCooldown {
+			h.voteRejectionNotices.Delete(k)
+		}
+		return true
+	})
+}
+
+// StartVoteRejectionPruner drops stale rejected vote notice records every voteRejectionCooldown until ctx is cancelled.
+// It does nothing unless VOTE_REJECTION_NOTICES is enabled.
+func (h *BotHandler) StartVoteRejectionPruner(ctx context.Context) {
+	if !h.config.VoteRejectionNotices {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(voteRejectionCooldown)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				h.pruneVoteRejectionNotices(now)
+			}
+		}
+	}()
+}
+
+// sendVoteRejectedNotice tells a user in private why their vote on an event wasn't counted, at most once per
+// voteRejectionCooldown for the same event. It does nothing unless VOTE_REJECTION_NOTICES is enabled;
+// users who never started the bot are skipped.
+func (h *BotHandler) sendVoteRejectedNotice(ctx context.Context, b *bot.Bot, userID int64, eventID int64, text string) {
+	if !h.config.VoteRejectionNotices {
+		return
+	}
+
+	log := h.requestLogger(ctx)
+	if !h.allowVoteRejectionNotice(voteRejectionKey{userID: userID, eventID: eventID}, time.Now()) {
+		log.Debug("vote rejected notice skipped: sent recently", "event_id", eventID)
+		return
+	}
+
+	var err error
+	if h.notificationService != nil {
+		err = h.notificationService.SendVoteRejectedNotification(ctx, userID, text)
+	} else {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: userID,
+			Text:   text,
+		})
+	}
+	if err != nil {
+		log.Warn("failed to send vote rejected notice", "event_id", eventID, "error", err)
+	}
+}
+
+// notifyVoteNotMember tells a user that their vote wasn't counted because they aren't a member of the
+// event's group, with a link to join it. It does nothing unless VOTE_REJECTION_NOTICES is enabled.
+func (h *BotHandler) notifyVoteNotMember(ctx context.Context, b *bot.Bot, userID int64, event *domain.Event, group *domain.Group) {
+	if !h.config.VoteRejectionNotices {
+		return
+	}
+
+	localizer := h.requestLocalizer(ctx)
+	text := localizer.MustLocalizeWithTemplate(locale.VoteRejectedNotMember, event.Question, group.Name)
+	if h.deepLinkService != nil {
+		if link, err := h.deepLinkService.GenerateGroupInviteLink(group.ID); err == nil {
+			text += "\n\n" + localizer.MustLocalizeWithTemplate(locale.VoteRejectedRejoin, link)
+		} else {
+			h.requestLogger(ctx).Warn("failed to generate rejoin link", "group_id", group.ID, "error", err)
+		}
+	}
+
+	h.sendVoteRejectedNotice(ctx, b, userID, event.ID, text)
+}
+
+// notifyVotingClosed tells a user that their vote wasn't counted because voting on the event is over.
+// It does nothing unless VOTE_REJECTION_NOTICES is enabled.
+func (h *BotHandler) notifyVotingClosed(ctx context.Context, b *bot.Bot, userID int64, event *domain.Event) {
+	if !h.config.VoteRejectionNotices {
+		return
+	}
+
+	h.sendVoteRejectedNotice(ctx, b, userID, event.ID,
+		h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.VoteRejectedVotingClosed, event.Question))
+}
+
+// notifyVotesLocked tells a user that their vote wasn't counted because votes on the event are locked.
+// It does nothing unless VOTE_REJECTION_NOTICES is enabled.
+func (h *BotHandler) notifyVotesLocked(ctx context.Context, b *bot.Bot, userID int64, event *domain.Event) {
+	if !h.config.VoteRejectionNotices {
+		return
+	}
+
+	h.sendVoteRejectedNotice(ctx, b, userID, event.ID, h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.VotesLockedRejected,
+		event.Question, event.LockVotesAt.In(h.config.Timezone).Format("02.01.2006 15:04")))
+}
+
+// notifyGroupPaused tells a user that their vote wasn't counted because the event's group is paused.
+// It does nothing unless VOTE_REJECTION_NOTICES is enabled.
+func (h *BotHandler) notifyGroupPaused(ctx context.Context, b *bot.Bot, userID int64, event *domain.Event, group *domain.Group) {
+	if !h.config.VoteRejectionNotices {
+		return
+	}
+
+	h.sendVoteRejectedNotice(ctx, b, userID, event.ID,
+		h.requestLocalizer(ctx).MustLocalizeWithTemplate(locale.GroupPausedVoteRejected, group.Name))
+}
+
+// notifyUnmatchedVote explains a vote on a poll that matched no active event of the user's groups:
+// the user isn't a member of the event's group, or the event was already resolved or cancelled.
+// It does nothing unless VOTE_REJECTION_NOTICES is enabled.
+func (h *BotHandler) notifyUnmatchedVote(ctx context.Context, b *bot.Bot, userID int64, pollID string) {
+	if !h.config.VoteRejectionNotices {
+		return
+	}
+
+	event, err := h.eventManager.GetEventByPollID(ctx, pollID)
+	if err != nil || event == nil {
+		return
+	}
+	if event.Status != domain.EventStatusActive {
+		h.notifyVotingClosed(ctx, b, userID, event)
+		return
+	}
+
+	group, err := h.groupRepo.GetGroup(ctx, event.GroupID)
+	if err != nil || group == nil || group.Status != domain.GroupStatusActive {
+		return
+	}
+	isMember, err := h.groupMembershipRepo.HasActiveMembership(ctx, event.GroupID, userID)
+	if err != nil {
+		h.requestLogger(ctx).Error("failed to check group membership", "group_id", event.GroupID, "error", err)
+		return
+	}
+	if !isMember {
+		h.notifyVoteNotMember(ctx, b, userID, event, group)
+	}
+}
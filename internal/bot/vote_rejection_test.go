@@ -0,0 +1,229 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/config"
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/encoding"
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+	"github.com/ad/gitelegram-prediction-market/internal/storage"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestHandlePollAnswer_RejectionNotices(t *testing.T) {
+	ctx := context.Background()
+	adminID := int64(1)
+	memberID := int64(200)
+	outsiderID := int64(300)
+	unreachableID := int64(400)
+	rec, b := newRecordingTelegramServer(t)
+
+	queue, groupID := setupTestGroupAndDB(t, -100500, adminID)
+	t.Cleanup(queue.Close)
+
+	log := logger.New(logger.ERROR)
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+	encoder, err := encoding.NewBaseNEncoder("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+
+	eventRepo := storage.NewEventRepository(queue)
+	predictionRepo := storage.NewPredictionRepository(queue)
+	membershipRepo := storage.NewGroupMembershipRepository(queue)
+	ratingRepo := storage.NewRatingRepository(queue)
+	userRepo := storage.NewUserRepository(queue)
+	membership := &domain.GroupMembership{GroupID: groupID, UserID: memberID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("failed to create membership: %v", err)
+	}
+	if _, err := userRepo.MarkUserUnreachable(ctx, unreachableID, time.Now()); err != nil {
+		t.Fatalf("failed to mark user unreachable: %v", err)
+	}
+
+	createEvent := func(pollID string, deadline time.Time, lockAt *time.Time) *domain.Event {
+		t.Helper()
+		event := &domain.Event{
+			GroupID:     groupID,
+			Question:    "Will it rain?",
+			Options:     []string{"Yes", "No"},
+			CreatedAt:   time.Now(),
+			Deadline:    deadline,
+			LockVotesAt: lockAt,
+			Status:      domain.EventStatusActive,
+			EventType:   domain.EventTypeBinary,
+			CreatedBy:   adminID,
+			PollID:      pollID,
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		return event
+	}
+	createEvent("poll_open", time.Now().Add(24*time.Hour), nil)
+	createEvent("poll_expired", time.Now().Add(-time.Minute), nil)
+	resolved := createEvent("poll_resolved", time.Now().Add(24*time.Hour), nil)
+	if err := eventRepo.ResolveEvent(ctx, resolved.ID, 0); err != nil {
+		t.Fatalf("failed to resolve event: %v", err)
+	}
+	lockAt := time.Now().Add(-time.Minute)
+	createEvent("poll_locked", time.Now().Add(24*time.Hour), &lockAt)
+
+	notificationService := domain.NewNotificationService(b, eventRepo, predictionRepo, ratingRepo, storage.NewReminderRepository(queue), log, localizer)
+	notificationService.SetReachabilityRepository(userRepo)
+	deepLinkService := domain.NewDeepLinkService("testbot", encoder)
+	cfg := &config.Config{AdminUserIDs: []int64{adminID}, Timezone: time.UTC}
+	h := &BotHandler{
+		bot:                 b,
+		config:              cfg,
+		groupRepo:           storage.NewGroupRepository(queue),
+		groupMembershipRepo: membershipRepo,
+		eventManager:        domain.NewEventManager(eventRepo, predictionRepo, membershipRepo, log),
+		predictionRepo:      predictionRepo,
+		ratingRepo:          ratingRepo,
+		ratingCalculator:    domain.NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, log),
+		deepLinkService:     deepLinkService,
+		notificationService: notificationService,
+		logger:              log,
+		localizer:           localizer,
+	}
+
+	// vote casts a vote and returns the messages sent in reply
+	vote := func(userID int64, pollID string) []string {
+		t.Helper()
+		before := len(rec.texts())
+		h.HandlePollAnswer(ctx, b, &models.Update{PollAnswer: &models.PollAnswer{
+			PollID:    pollID,
+			User:      &models.User{ID: userID, Username: "voter"},
+			OptionIDs: []int{0},
+		}})
+		return rec.texts()[before:]
+	}
+
+	t.Run("disabled notices keep rejected votes silent", func(t *testing.T) {
+		if sent := vote(outsiderID, "poll_open"); len(sent) != 0 {
+			t.Errorf("expected no notice, got %v", sent)
+		}
+		if sent := vote(memberID, "poll_expired"); len(sent) != 0 {
+			t.Errorf("expected no notice, got %v", sent)
+		}
+		if sent := vote(memberID, "poll_locked"); len(sent) != 0 {
+			t.Errorf("expected no notice, got %v", sent)
+		}
+	})
+
+	cfg.VoteRejectionNotices = true
+
+	t.Run("not a member gets a rejoin link", func(t *testing.T) {
+		link, err := deepLinkService.GenerateGroupInviteLink(groupID)
+		if err != nil {
+			t.Fatalf("failed to generate link: %v", err)
+		}
+		want := localizer.MustLocalizeWithTemplate(locale.VoteRejectedNotMember, "Will it rain?", "Test Group") +
+			"\n\n" + localizer.MustLocalizeWithTemplate(locale.VoteRejectedRejoin, link)
+		if sent := vote(outsiderID, "poll_open"); len(sent) != 1 || sent[0] != want {
+			t.Errorf("expected %q, got %v", want, sent)
+		}
+
+		// Repeated votes on the same event are not answered again
+		if sent := vote(outsiderID, "poll_open"); len(sent) != 0 {
+			t.Errorf("expected no second notice, got %v", sent)
+		}
+	})
+
+	t.Run("voting closed", func(t *testing.T) {
+		want := localizer.MustLocalizeWithTemplate(locale.VoteRejectedVotingClosed, "Will it rain?")
+		if sent := vote(memberID, "poll_expired"); len(sent) != 1 || sent[0] != want {
+			t.Errorf("expected %q after the deadline, got %v", want, sent)
+		}
+		if sent := vote(memberID, "poll_resolved"); len(sent) != 1 || sent[0] != want {
+			t.Errorf("expected %q for a resolved event, got %v", want, sent)
+		}
+	})
+
+	t.Run("votes locked", func(t *testing.T) {
+		want := localizer.MustLocalizeWithTemplate(locale.VotesLockedRejected, "Will it rain?", lockAt.In(time.UTC).Format("02.01.2006 15:04"))
+		if sent := vote(memberID, "poll_locked"); len(sent) != 1 || sent[0] != want {
+			t.Errorf("expected %q, got %v", want, sent)
+		}
+		if sent := vote(memberID, "poll_locked"); len(sent) != 0 {
+			t.Errorf("expected no second notice, got %v", sent)
+		}
+	})
+
+	t.Run("unreachable users are skipped", func(t *testing.T) {
+		if sent := vote(unreachableID, "poll_open"); len(sent) != 0 {
+			t.Errorf("expected no notice, got %v", sent)
+		}
+	})
+
+	predictions, err := predictionRepo.GetPredictionsByEvent(ctx, resolved.ID)
+	if err != nil {
+		t.Fatalf("failed to get predictions: %v", err)
+	}
+	if len(predictions) != 0 {
+		t.Errorf("expected no rejected vote to be saved, got %+v", predictions)
+	}
+}
+
+func TestPruneVoteRejectionNotices(t *testing.T) {
+	h := &BotHandler{config: &config.Config{VoteRejectionNotices: true}}
+	now := time.Now()
+	stale := voteRejectionKey{userID: 1, eventID: 1}
+	recent := voteRejectionKey{userID: 2, eventID: 1}
+
+	if !h.allowVoteRejectionNotice(stale, now.Add(-voteRejectionCooldown)) || !h.allowVoteRejectionNotice(recent, now.Add(-time.Minute)) {
+		t.Fatal("expected first notices to be allowed")
+	}
+	if h.allowVoteRejectionNotice(recent, now) {
+		t.Error("expected a notice within the cooldown to be refused")
+	}
+
+	h.pruneVoteRejectionNotices(now)
+
+	if _, ok := h.voteRejectionNotices.Load(stale); ok {
+		t.Error("expected the record past its cooldown to be dropped")
+	}
+	if _, ok := h.voteRejectionNotices.Load(recent); !ok {
+		t.Error("expected the record within its cooldown to be kept")
+	}
+	if !h.allowVoteRejectionNotice(stale, now) {
+		t.Error("expected a notice after the cooldown to be allowed")
+	}
+}
+
+func TestAllowVoteRejectionNotice_Concurrent(t *testing.T) {
+	h := &BotHandler{config: &config.Config{VoteRejectionNotices: true}}
+	key := voteRejectionKey{userID: 1, eventID: 1}
+	now := time.Now()
+
+	// An expired record is replaced by exactly one of the concurrent notices
+	h.voteRejectionNotices.Store(key, now.Add(-2*voteRejectionCooldown))
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if h.allowVoteRejectionNotice(key, now) {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := allowed.Load(); got != 1 {
+		t.Errorf("expected exactly one concurrent notice to be allowed, got %d", got)
+	}
+}
@@ -4,28 +4,92 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 const ConfigFileName = "/data/options.json"
 
+// Default leaderboard look: podium emojis for the top three places and progress bar segments
+const (
+	DefaultLeaderboardMedals = "🥇,🥈,🥉"
+	DefaultProgressBarFilled = "▰"
+	DefaultProgressBarEmpty  = "▱"
+)
+
 // Config holds application configuration
 type Config struct {
-	TelegramToken         string `json:"TELEGRAM_TOKEN"`
-	AdminUserIDs          []int64
-	AdminIDsStr           string `json:"ADMIN_USER_IDS"`
-	DatabasePath          string `json:"DATABASE"`
-	Locale                string `json:"LOCALE"`
-	LogLevel              string `json:"LOG_LEVEL"`
-	Timezone              *time.Location
-	TimezoneStr           string `json:"TIMEZONE"`
-	MinEventsToCreate     int    `json:"MIN_EVENTS_TO_CREATE"`
-	MaxGroupsPerAdmin     int    `json:"MAX_GROUPS_PER_ADMIN"`
-	MaxMembershipsPerUser int    `json:"MAX_MEMBERSHIPS_PER_USER"`
-	IDEncodingAlphabet    string `json:"ID_ENCODING_ALPHABET"`
+	TelegramToken                string `json:"TELEGRAM_TOKEN"`
+	AdminUserIDs                 []int64
+	AdminIDsStr                  string `json:"ADMIN_USER_IDS"`
+	DatabasePath                 string `json:"DATABASE"`
+	DBOperationTimeout           time.Duration
+	DBOperationTimeoutStr        string `json:"DB_OPERATION_TIMEOUT"`
+	Locale                       string `json:"LOCALE"`
+	LogLevel                     string `json:"LOG_LEVEL"`
+	Timezone                     *time.Location
+	TimezoneStr                  string `json:"TIMEZONE"`
+	MinEventsToCreate            int    `json:"MIN_EVENTS_TO_CREATE"`
+	ParticipationMode            string `json:"PARTICIPATION_MODE"`
+	DefaultPollType              string `json:"DEFAULT_POLL_TYPE"`
+	MaxGroupsPerAdmin            int    `json:"MAX_GROUPS_PER_ADMIN"`
+	MaxMembershipsPerUser        int    `json:"MAX_MEMBERSHIPS_PER_USER"`
+	IDEncodingAlphabet           string `json:"ID_ENCODING_ALPHABET"`
+	MinQuestionLength            int    `json:"MIN_QUESTION_LENGTH"`
+	MaxQuestionLength            int    `json:"MAX_QUESTION_LENGTH"`
+	MaxRatingEntries             int    `json:"MAX_RATING_ENTRIES"`
+	LivePollStats                bool   `json:"LIVE_POLL_STATS"`
+	LivePollStatsInterval        int    `json:"LIVE_POLL_STATS_INTERVAL"`
+	PollCountdown                bool   `json:"POLL_COUNTDOWN"`
+	EventArchiveDays             int    `json:"EVENT_ARCHIVE_DAYS"`
+	InactiveMemberDays           int    `json:"INACTIVE_MEMBER_DAYS"`
+	AdminLogRetentionDays        int    `json:"ADMIN_LOG_RETENTION_DAYS"`
+	ParticipationBonusCap        int    `json:"PARTICIPATION_BONUS_CAP"`
+	ParticipationBonusPeriodDays int    `json:"PARTICIPATION_BONUS_PERIOD_DAYS"`
+	ParticipationPointsAtVote    bool   `json:"PARTICIPATION_POINTS_AT_VOTE"`
+	CompactEventCreation         bool   `json:"COMPACT_EVENT_CREATION"`
+	EventSummaryToDM             bool   `json:"EVENT_SUMMARY_TO_DM"`
+	DuplicateEventTolerance      int    `json:"DUPLICATE_EVENT_TOLERANCE_MINUTES"`
+	VoteRejectionNotices         bool   `json:"VOTE_REJECTION_NOTICES"`
+	ListSortOrder                string `json:"LIST_SORT_ORDER"`
+	MaintenanceMode              bool   `json:"MAINTENANCE_MODE"`
+	ResolveMajorityConfirmation  bool   `json:"RESOLVE_MAJORITY_CONFIRMATION"`
+	ResolutionNoteVoteThreshold  int    `json:"RESOLUTION_NOTE_VOTE_THRESHOLD"`
+	EventsShowOdds               bool   `json:"EVENTS_SHOW_ODDS"`
+	LeaderboardMedals            []string
+	LeaderboardMedalsStr         string `json:"LEADERBOARD_MEDALS"`
+	ProgressBarFilled            string `json:"PROGRESS_BAR_FILLED"`
+	ProgressBarEmpty             string `json:"PROGRESS_BAR_EMPTY"`
+	ASCIIDisplayNames            bool   `json:"ASCII_DISPLAY_NAMES"`
+	HotEventsWindowHours         int    `json:"HOT_EVENTS_WINDOW_HOURS"`
+	AchievementSharpshooter      int    `json:"ACHIEVEMENT_SHARPSHOOTER_STREAK"`
+	AchievementProphet           int    `json:"ACHIEVEMENT_PROPHET_STREAK"`
+	AchievementRiskTaker         int    `json:"ACHIEVEMENT_RISK_TAKER_STREAK"`
+	AchievementVeteran           int    `json:"ACHIEVEMENT_VETERAN_COUNT"`
+	AchievementGlobeTrotter      int    `json:"ACHIEVEMENT_GLOBE_TROTTER_GROUPS"`
+	AchievementOrganizerTiers    []int
+	AchievementOrganizerTiersStr string `json:"ACHIEVEMENT_ORGANIZER_TIERS"`
+	NewMemberCreateCooldown      time.Duration
+	NewMemberCreateCooldownStr   string `json:"NEW_MEMBER_CREATE_COOLDOWN"`
+	JoinMinKnownAge              time.Duration
+	JoinMinKnownAgeStr           string `json:"JOIN_MIN_KNOWN_AGE"`
+	ResolutionNagDelay           time.Duration
+	ResolutionNagDelayStr        string `json:"RESOLUTION_NAG_DELAY"`
+	ResolutionNagInterval        time.Duration
+	ResolutionNagIntervalStr     string `json:"RESOLUTION_NAG_INTERVAL"`
+	ResolutionNagMaxCount        int    `json:"RESOLUTION_NAG_MAX_COUNT"`
+	ResolutionNagEscalation      time.Duration
+	ResolutionNagEscalationStr   string `json:"RESOLUTION_NAG_ESCALATE_AFTER"`
+	MinDeadlineOffset            time.Duration
+	MinDeadlineOffsetStr         string `json:"MIN_DEADLINE_OFFSET"`
+	MaxDeadlineOffset            time.Duration
+	MaxDeadlineOffsetStr         string `json:"MAX_DEADLINE_OFFSET"`
+	ResolutionWebhookURL         string `json:"RESOLUTION_WEBHOOK_URL"`
+	WebhookSecret                string `json:"WEBHOOK_SECRET"`
 }
 
 // Load loads configuration from environment variables
@@ -44,8 +108,53 @@ func Load() (*Config, error) {
 	}
 
 	config.MinEventsToCreate = config.LookupEnvOrInt("MIN_EVENTS_TO_CREATE", 0)
+	config.ParticipationMode = os.Getenv("PARTICIPATION_MODE")
+	config.DefaultPollType = os.Getenv("DEFAULT_POLL_TYPE")
 	config.MaxGroupsPerAdmin = config.LookupEnvOrInt("MAX_GROUPS_PER_ADMIN", 0)
 	config.MaxMembershipsPerUser = config.LookupEnvOrInt("MAX_MEMBERSHIPS_PER_USER", 0)
+	config.MinQuestionLength = config.LookupEnvOrInt("MIN_QUESTION_LENGTH", 0)
+	config.MaxQuestionLength = config.LookupEnvOrInt("MAX_QUESTION_LENGTH", 0)
+	config.MaxRatingEntries = config.LookupEnvOrInt("MAX_RATING_ENTRIES", 0)
+	config.LivePollStats = config.LookupEnvOrBool("LIVE_POLL_STATS", false)
+	config.LivePollStatsInterval = config.LookupEnvOrInt("LIVE_POLL_STATS_INTERVAL", 0)
+	config.PollCountdown = config.LookupEnvOrBool("POLL_COUNTDOWN", false)
+	config.EventArchiveDays = config.LookupEnvOrInt("EVENT_ARCHIVE_DAYS", 0)
+	config.InactiveMemberDays = config.LookupEnvOrInt("INACTIVE_MEMBER_DAYS", 180)
+	config.AdminLogRetentionDays = config.LookupEnvOrInt("ADMIN_LOG_RETENTION_DAYS", 0)
+	config.ParticipationBonusCap = config.LookupEnvOrInt("PARTICIPATION_BONUS_CAP", 0)
+	config.ParticipationBonusPeriodDays = config.LookupEnvOrInt("PARTICIPATION_BONUS_PERIOD_DAYS", 0)
+	config.ParticipationPointsAtVote = config.LookupEnvOrBool("PARTICIPATION_POINTS_AT_VOTE", false)
+	config.CompactEventCreation = config.LookupEnvOrBool("COMPACT_EVENT_CREATION", false)
+	config.EventSummaryToDM = config.LookupEnvOrBool("EVENT_SUMMARY_TO_DM", false)
+	config.DuplicateEventTolerance = config.LookupEnvOrInt("DUPLICATE_EVENT_TOLERANCE_MINUTES", 60)
+	config.VoteRejectionNotices = config.LookupEnvOrBool("VOTE_REJECTION_NOTICES", false)
+	config.ListSortOrder = os.Getenv("LIST_SORT_ORDER")
+	config.MaintenanceMode = config.LookupEnvOrBool("MAINTENANCE_MODE", false)
+	config.ResolveMajorityConfirmation = config.LookupEnvOrBool("RESOLVE_MAJORITY_CONFIRMATION", true)
+	config.ResolutionNoteVoteThreshold = config.LookupEnvOrInt("RESOLUTION_NOTE_VOTE_THRESHOLD", 0)
+	config.EventsShowOdds = config.LookupEnvOrBool("EVENTS_SHOW_ODDS", false)
+	config.ASCIIDisplayNames = config.LookupEnvOrBool("ASCII_DISPLAY_NAMES", false)
+	config.HotEventsWindowHours = config.LookupEnvOrInt("HOT_EVENTS_WINDOW_HOURS", 0)
+	config.AchievementSharpshooter = config.LookupEnvOrInt("ACHIEVEMENT_SHARPSHOOTER_STREAK", 0)
+	config.AchievementProphet = config.LookupEnvOrInt("ACHIEVEMENT_PROPHET_STREAK", 0)
+	config.AchievementRiskTaker = config.LookupEnvOrInt("ACHIEVEMENT_RISK_TAKER_STREAK", 0)
+	config.AchievementVeteran = config.LookupEnvOrInt("ACHIEVEMENT_VETERAN_COUNT", 0)
+	config.AchievementGlobeTrotter = config.LookupEnvOrInt("ACHIEVEMENT_GLOBE_TROTTER_GROUPS", 0)
+	config.AchievementOrganizerTiersStr = os.Getenv("ACHIEVEMENT_ORGANIZER_TIERS")
+	config.NewMemberCreateCooldownStr = os.Getenv("NEW_MEMBER_CREATE_COOLDOWN")
+	config.JoinMinKnownAgeStr = os.Getenv("JOIN_MIN_KNOWN_AGE")
+	config.DBOperationTimeoutStr = os.Getenv("DB_OPERATION_TIMEOUT")
+	config.ResolutionNagDelayStr = os.Getenv("RESOLUTION_NAG_DELAY")
+	config.ResolutionNagIntervalStr = os.Getenv("RESOLUTION_NAG_INTERVAL")
+	config.ResolutionNagMaxCount = config.LookupEnvOrInt("RESOLUTION_NAG_MAX_COUNT", 0)
+	config.ResolutionNagEscalationStr = os.Getenv("RESOLUTION_NAG_ESCALATE_AFTER")
+	config.MinDeadlineOffsetStr = os.Getenv("MIN_DEADLINE_OFFSET")
+	config.MaxDeadlineOffsetStr = os.Getenv("MAX_DEADLINE_OFFSET")
+	config.ResolutionWebhookURL = os.Getenv("RESOLUTION_WEBHOOK_URL")
+	config.WebhookSecret = os.Getenv("WEBHOOK_SECRET")
+	config.LeaderboardMedalsStr = os.Getenv("LEADERBOARD_MEDALS")
+	config.ProgressBarFilled = os.Getenv("PROGRESS_BAR_FILLED")
+	config.ProgressBarEmpty = os.Getenv("PROGRESS_BAR_EMPTY")
 
 	if _, err := os.Stat(ConfigFileName); err == nil {
 		jsonFile, err := os.Open(ConfigFileName)
@@ -96,6 +205,35 @@ func Load() (*Config, error) {
 		config.MinEventsToCreate = 3
 	}
 
+	// Load participation definition for MIN_EVENTS_TO_CREATE (default to resolved events only)
+	config.ParticipationMode = strings.ToLower(strings.TrimSpace(config.ParticipationMode))
+	if config.ParticipationMode == "" {
+		config.ParticipationMode = "resolved"
+	}
+	if config.ParticipationMode != "resolved" && config.ParticipationMode != "voted" {
+		return nil, fmt.Errorf("invalid PARTICIPATION_MODE '%s': must be resolved or voted", config.ParticipationMode)
+	}
+
+	// Load poll type preselected for new events (default to regular prediction polls)
+	config.DefaultPollType = strings.ToLower(strings.TrimSpace(config.DefaultPollType))
+	if config.DefaultPollType == "" {
+		config.DefaultPollType = "regular"
+	}
+	if config.DefaultPollType != "regular" && config.DefaultPollType != "quiz" {
+		return nil, fmt.Errorf("invalid DEFAULT_POLL_TYPE '%s': must be regular or quiz", config.DefaultPollType)
+	}
+
+	// Load order of member and group lists (default to the stored order)
+	config.ListSortOrder = strings.ToLower(strings.TrimSpace(config.ListSortOrder))
+	if config.ListSortOrder == "" {
+		config.ListSortOrder = "default"
+	}
+	switch config.ListSortOrder {
+	case "default", "name", "score", "joined":
+	default:
+		return nil, fmt.Errorf("invalid LIST_SORT_ORDER '%s': must be default, name, score or joined", config.ListSortOrder)
+	}
+
 	// Load max groups per admin (default to 10)
 	if config.MaxGroupsPerAdmin <= 0 {
 		config.MaxGroupsPerAdmin = 10
@@ -111,20 +249,357 @@ func Load() (*Config, error) {
 		config.IDEncodingAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 	}
 
+	// Load question length limits (default to 1..300, Telegram's poll question limit)
+	if config.MinQuestionLength <= 0 {
+		config.MinQuestionLength = 1
+	}
+	if config.MaxQuestionLength <= 0 || config.MaxQuestionLength > 300 {
+		config.MaxQuestionLength = 300
+	}
+	if config.MinQuestionLength > config.MaxQuestionLength {
+		return nil, fmt.Errorf("MIN_QUESTION_LENGTH (%d) must not exceed MAX_QUESTION_LENGTH (%d)", config.MinQuestionLength, config.MaxQuestionLength)
+	}
+
+	// Load maximum number of /rating entries (default to 50)
+	if config.MaxRatingEntries <= 0 {
+		config.MaxRatingEntries = 50
+	}
+
+	// Load live poll stats edit interval in seconds (default to 30)
+	if config.LivePollStatsInterval <= 0 {
+		config.LivePollStatsInterval = 30
+	}
+
+	// Load event archive retention in days (0 or negative disables archival)
+	if config.EventArchiveDays < 0 {
+		config.EventArchiveDays = 0
+	}
+
+	// Load inactivity window in days for groups with auto-removal enabled (default to 180; 0 disables removal)
+	if config.InactiveMemberDays < 0 {
+		config.InactiveMemberDays = 0
+	}
+
+	// Load deadline tolerance in minutes for the duplicate event warning (default to 60; negative disables the warning)
+	if config.DuplicateEventTolerance < 0 {
+		config.DuplicateEventTolerance = -1
+	}
+
+	// Load admin log retention in days (0 or negative keeps the log forever)
+	if config.AdminLogRetentionDays < 0 {
+		config.AdminLogRetentionDays = 0
+	}
+
+	// Load vote velocity window of /hot in hours (default to 24)
+	if config.HotEventsWindowHours <= 0 {
+		config.HotEventsWindowHours = 24
+	}
+
+	// Load vote count above which resolving an event requires a resolution note (0 or negative disables it)
+	if config.ResolutionNoteVoteThreshold < 0 {
+		config.ResolutionNoteVoteThreshold = 0
+	}
+
+	// Load participation bonus cap per user per period (0 or negative disables the cap)
+	if config.ParticipationBonusCap < 0 {
+		config.ParticipationBonusCap = 0
+	}
+
+	// Load participation bonus cap period in days (default to 7)
+	if config.ParticipationBonusPeriodDays <= 0 {
+		config.ParticipationBonusPeriodDays = 7
+	}
+
+	// Load achievement thresholds (defaults: 3, 10, 3, 50, 3)
+	if config.AchievementSharpshooter <= 0 {
+		config.AchievementSharpshooter = 3
+	}
+	if config.AchievementProphet <= 0 {
+		config.AchievementProphet = 10
+	}
+	if config.AchievementRiskTaker <= 0 {
+		config.AchievementRiskTaker = 3
+	}
+	if config.AchievementVeteran <= 0 {
+		config.AchievementVeteran = 50
+	}
+	if config.AchievementGlobeTrotter <= 0 {
+		config.AchievementGlobeTrotter = 3
+	}
+	if config.AchievementGlobeTrotter < 2 {
+		return nil, fmt.Errorf("ACHIEVEMENT_GLOBE_TROTTER_GROUPS (%d) must be at least 2", config.AchievementGlobeTrotter)
+	}
+	if config.AchievementSharpshooter >= config.AchievementProphet {
+		return nil, fmt.Errorf("ACHIEVEMENT_SHARPSHOOTER_STREAK (%d) must be less than ACHIEVEMENT_PROPHET_STREAK (%d)", config.AchievementSharpshooter, config.AchievementProphet)
+	}
+
+	// Load organizer achievement tiers (default to 1,5,25)
+	if strings.TrimSpace(config.AchievementOrganizerTiersStr) == "" {
+		config.AchievementOrganizerTiersStr = "1,5,25"
+	}
+	organizerTiers, err := parseAchievementTiers(config.AchievementOrganizerTiersStr, 3)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACHIEVEMENT_ORGANIZER_TIERS: %w", err)
+	}
+
+	// Load leaderboard podium emojis (default to 🥇,🥈,🥉)
+	if strings.TrimSpace(config.LeaderboardMedalsStr) == "" {
+		config.LeaderboardMedalsStr = DefaultLeaderboardMedals
+	}
+	leaderboardMedals, err := parseLeaderboardMedals(config.LeaderboardMedalsStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LEADERBOARD_MEDALS: %w", err)
+	}
+
+	// Load progress bar segments (default to ▰ and ▱)
+	config.ProgressBarFilled = strings.TrimSpace(config.ProgressBarFilled)
+	if config.ProgressBarFilled == "" {
+		config.ProgressBarFilled = DefaultProgressBarFilled
+	}
+	if !isSingleGlyph(config.ProgressBarFilled) {
+		return nil, fmt.Errorf("invalid PROGRESS_BAR_FILLED '%s': must be a single character", config.ProgressBarFilled)
+	}
+	config.ProgressBarEmpty = strings.TrimSpace(config.ProgressBarEmpty)
+	if config.ProgressBarEmpty == "" {
+		config.ProgressBarEmpty = DefaultProgressBarEmpty
+	}
+	if !isSingleGlyph(config.ProgressBarEmpty) {
+		return nil, fmt.Errorf("invalid PROGRESS_BAR_EMPTY '%s': must be a single character", config.ProgressBarEmpty)
+	}
+
+	// Load new member event creation cooldown (Go duration such as "24h"; empty or 0 disables it)
+	newMemberCreateCooldown, err := parseOptionalDuration("NEW_MEMBER_CREATE_COOLDOWN", config.NewMemberCreateCooldownStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load how long the bot must have known a user before a deep-link join (empty or 0 disables the gate)
+	joinMinKnownAge, err := parseOptionalDuration("JOIN_MIN_KNOWN_AGE", config.JoinMinKnownAgeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load database operation timeout (default to 30s; 0 disables it)
+	if strings.TrimSpace(config.DBOperationTimeoutStr) == "" {
+		config.DBOperationTimeoutStr = "30s"
+	}
+	dbOperationTimeout, err := parseOptionalDuration("DB_OPERATION_TIMEOUT", config.DBOperationTimeoutStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load resolution reminder grace period after the deadline (empty or 0 disables the reminders)
+	resolutionNagDelay, err := parseOptionalDuration("RESOLUTION_NAG_DELAY", config.ResolutionNagDelayStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load interval between resolution reminders (default to 24h)
+	if strings.TrimSpace(config.ResolutionNagIntervalStr) == "" {
+		config.ResolutionNagIntervalStr = "24h"
+	}
+	resolutionNagInterval, err := parseOptionalDuration("RESOLUTION_NAG_INTERVAL", config.ResolutionNagIntervalStr)
+	if err != nil {
+		return nil, err
+	}
+	if resolutionNagInterval == 0 {
+		return nil, fmt.Errorf("RESOLUTION_NAG_INTERVAL must be positive, got %s", config.ResolutionNagIntervalStr)
+	}
+
+	// Load maximum number of resolution reminders per event (default to 3)
+	if config.ResolutionNagMaxCount <= 0 {
+		config.ResolutionNagMaxCount = 3
+	}
+
+	// Load how long after the deadline unresolved events are escalated to all admins (empty or 0 disables it)
+	resolutionNagEscalation, err := parseOptionalDuration("RESOLUTION_NAG_ESCALATE_AFTER", config.ResolutionNagEscalationStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the allowed range of event deadlines from now (defaults to 10m..17520h, two years; 0 disables a bound)
+	if strings.TrimSpace(config.MinDeadlineOffsetStr) == "" {
+		config.MinDeadlineOffsetStr = "10m"
+	}
+	minDeadlineOffset, err := parseOptionalDuration("MIN_DEADLINE_OFFSET", config.MinDeadlineOffsetStr)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(config.MaxDeadlineOffsetStr) == "" {
+		config.MaxDeadlineOffsetStr = "17520h"
+	}
+	maxDeadlineOffset, err := parseOptionalDuration("MAX_DEADLINE_OFFSET", config.MaxDeadlineOffsetStr)
+	if err != nil {
+		return nil, err
+	}
+	if maxDeadlineOffset > 0 && minDeadlineOffset > maxDeadlineOffset {
+		return nil, fmt.Errorf("MIN_DEADLINE_OFFSET (%s) must not exceed MAX_DEADLINE_OFFSET (%s)", config.MinDeadlineOffsetStr, config.MaxDeadlineOffsetStr)
+	}
+
+	// Load resolution webhook (disabled without a URL; deliveries are always signed)
+	config.ResolutionWebhookURL = strings.TrimSpace(config.ResolutionWebhookURL)
+	if config.ResolutionWebhookURL != "" {
+		webhookURL, err := url.Parse(config.ResolutionWebhookURL)
+		if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+			return nil, fmt.Errorf("invalid RESOLUTION_WEBHOOK_URL '%s': must be an http or https URL", config.ResolutionWebhookURL)
+		}
+		if config.WebhookSecret == "" {
+			return nil, fmt.Errorf("WEBHOOK_SECRET is required when RESOLUTION_WEBHOOK_URL is set")
+		}
+	}
+
 	return &Config{
-		TelegramToken:         config.TelegramToken,
-		AdminUserIDs:          adminIDs,
-		DatabasePath:          config.DatabasePath,
-		Locale:                config.Locale,
-		LogLevel:              config.LogLevel,
-		Timezone:              timezone,
-		MinEventsToCreate:     config.MinEventsToCreate,
-		MaxGroupsPerAdmin:     config.MaxGroupsPerAdmin,
-		MaxMembershipsPerUser: config.MaxMembershipsPerUser,
-		IDEncodingAlphabet:    config.IDEncodingAlphabet,
+		TelegramToken:                config.TelegramToken,
+		AdminUserIDs:                 adminIDs,
+		DatabasePath:                 config.DatabasePath,
+		DBOperationTimeout:           dbOperationTimeout,
+		DBOperationTimeoutStr:        config.DBOperationTimeoutStr,
+		Locale:                       config.Locale,
+		LogLevel:                     config.LogLevel,
+		Timezone:                     timezone,
+		MinEventsToCreate:            config.MinEventsToCreate,
+		ParticipationMode:            config.ParticipationMode,
+		DefaultPollType:              config.DefaultPollType,
+		MaxGroupsPerAdmin:            config.MaxGroupsPerAdmin,
+		MaxMembershipsPerUser:        config.MaxMembershipsPerUser,
+		IDEncodingAlphabet:           config.IDEncodingAlphabet,
+		MinQuestionLength:            config.MinQuestionLength,
+		MaxQuestionLength:            config.MaxQuestionLength,
+		MaxRatingEntries:             config.MaxRatingEntries,
+		LivePollStats:                config.LivePollStats,
+		LivePollStatsInterval:        config.LivePollStatsInterval,
+		PollCountdown:                config.PollCountdown,
+		EventArchiveDays:             config.EventArchiveDays,
+		InactiveMemberDays:           config.InactiveMemberDays,
+		AdminLogRetentionDays:        config.AdminLogRetentionDays,
+		ParticipationBonusCap:        config.ParticipationBonusCap,
+		ParticipationBonusPeriodDays: config.ParticipationBonusPeriodDays,
+		ParticipationPointsAtVote:    config.ParticipationPointsAtVote,
+		CompactEventCreation:         config.CompactEventCreation,
+		EventSummaryToDM:             config.EventSummaryToDM,
+		DuplicateEventTolerance:      config.DuplicateEventTolerance,
+		VoteRejectionNotices:         config.VoteRejectionNotices,
+		ListSortOrder:                config.ListSortOrder,
+		MaintenanceMode:              config.MaintenanceMode,
+		ResolveMajorityConfirmation:  config.ResolveMajorityConfirmation,
+		ResolutionNoteVoteThreshold:  config.ResolutionNoteVoteThreshold,
+		EventsShowOdds:               config.EventsShowOdds,
+		LeaderboardMedals:            leaderboardMedals,
+		LeaderboardMedalsStr:         config.LeaderboardMedalsStr,
+		ProgressBarFilled:            config.ProgressBarFilled,
+		ProgressBarEmpty:             config.ProgressBarEmpty,
+		ASCIIDisplayNames:            config.ASCIIDisplayNames,
+		HotEventsWindowHours:         config.HotEventsWindowHours,
+		AchievementSharpshooter:      config.AchievementSharpshooter,
+		AchievementProphet:           config.AchievementProphet,
+		AchievementRiskTaker:         config.AchievementRiskTaker,
+		AchievementVeteran:           config.AchievementVeteran,
+		AchievementGlobeTrotter:      config.AchievementGlobeTrotter,
+		AchievementOrganizerTiers:    organizerTiers,
+		AchievementOrganizerTiersStr: config.AchievementOrganizerTiersStr,
+		NewMemberCreateCooldown:      newMemberCreateCooldown,
+		NewMemberCreateCooldownStr:   config.NewMemberCreateCooldownStr,
+		JoinMinKnownAge:              joinMinKnownAge,
+		JoinMinKnownAgeStr:           config.JoinMinKnownAgeStr,
+		ResolutionNagDelay:           resolutionNagDelay,
+		ResolutionNagDelayStr:        config.ResolutionNagDelayStr,
+		ResolutionNagInterval:        resolutionNagInterval,
+		ResolutionNagIntervalStr:     config.ResolutionNagIntervalStr,
+		ResolutionNagMaxCount:        config.ResolutionNagMaxCount,
+		ResolutionNagEscalation:      resolutionNagEscalation,
+		ResolutionNagEscalationStr:   config.ResolutionNagEscalationStr,
+		MinDeadlineOffset:            minDeadlineOffset,
+		MinDeadlineOffsetStr:         config.MinDeadlineOffsetStr,
+		MaxDeadlineOffset:            maxDeadlineOffset,
+		MaxDeadlineOffsetStr:         config.MaxDeadlineOffsetStr,
+		ResolutionWebhookURL:         config.ResolutionWebhookURL,
+		WebhookSecret:                config.WebhookSecret,
 	}, nil
 }
 
+// parseOptionalDuration parses a non-negative Go duration such as "24h"; an empty value yields 0
+func parseOptionalDuration(name string, s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s': %w", name, s, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %s", name, s)
+	}
+
+	return d, nil
+}
+
+// parseAchievementTiers parses comma-separated achievement tiers,
+// requiring exactly count positive and strictly increasing values
+func parseAchievementTiers(s string, count int) ([]int, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != count {
+		return nil, fmt.Errorf("expected %d comma-separated values, got %d", count, len(parts))
+	}
+
+	tiers := make([]int, 0, count)
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		tier, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tier '%s': %w", part, err)
+		}
+		if tier <= 0 {
+			return nil, fmt.Errorf("tier %d must be positive", tier)
+		}
+		if len(tiers) > 0 && tier <= tiers[len(tiers)-1] {
+			return nil, fmt.Errorf("tiers must be strictly increasing, got %d after %d", tier, tiers[len(tiers)-1])
+		}
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, nil
+}
+
+// parseLeaderboardMedals parses the comma-separated podium emojis for the first, second and third places
+func parseLeaderboardMedals(s string) ([]string, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected 3 comma-separated values, got %d", len(parts))
+	}
+
+	medals := make([]string, 0, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("medal %d must not be empty", i+1)
+		}
+		if strings.IndexFunc(part, unicode.IsSpace) >= 0 {
+			return nil, fmt.Errorf("medal '%s' must not contain spaces", part)
+		}
+		medals = append(medals, part)
+	}
+
+	return medals, nil
+}
+
+// isSingleGlyph reports whether s renders as one character: a single base character,
+// optionally followed by combining marks or variation selectors (as in "❤️")
+func isSingleGlyph(s string) bool {
+	base := 0
+	for _, r := range s {
+		if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Variation_Selector, r) {
+			if base == 0 {
+				return false
+			}
+			continue
+		}
+		base++
+	}
+	return base == 1
+}
+
 // parseAdminIDs parses comma-separated admin user IDs
 func parseAdminIDs(s string) ([]int64, error) {
 	parts := strings.Split(s, ",")
@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
+	"time"
 )
 
 // TestMinEventsToCreateValidValues tests that valid integer values are accepted
@@ -204,3 +206,951 @@ func TestMaxMembershipsPerUserValidValues(t *testing.T) {
 		})
 	}
 }
+
+// TestQuestionLengthDefaults tests that question length limits default to 1..300
+func TestQuestionLengthDefaults(t *testing.T) {
+	// Save original env vars
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origMin := os.Getenv("MIN_QUESTION_LENGTH")
+	origMax := os.Getenv("MAX_QUESTION_LENGTH")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("MIN_QUESTION_LENGTH", origMin)
+		_ = os.Setenv("MAX_QUESTION_LENGTH", origMax)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("MIN_QUESTION_LENGTH")
+	_ = os.Unsetenv("MAX_QUESTION_LENGTH")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.MinQuestionLength != 1 {
+		t.Errorf("Expected default MinQuestionLength to be 1, got: %d", config.MinQuestionLength)
+	}
+	if config.MaxQuestionLength != 300 {
+		t.Errorf("Expected default MaxQuestionLength to be 300, got: %d", config.MaxQuestionLength)
+	}
+}
+
+// TestQuestionLengthValidation tests custom values and rejection of min > max
+func TestQuestionLengthValidation(t *testing.T) {
+	// Save original env vars
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origMin := os.Getenv("MIN_QUESTION_LENGTH")
+	origMax := os.Getenv("MAX_QUESTION_LENGTH")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("MIN_QUESTION_LENGTH", origMin)
+		_ = os.Setenv("MAX_QUESTION_LENGTH", origMax)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+
+	_ = os.Setenv("MIN_QUESTION_LENGTH", "10")
+	_ = os.Setenv("MAX_QUESTION_LENGTH", "100")
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.MinQuestionLength != 10 || config.MaxQuestionLength != 100 {
+		t.Errorf("Expected limits 10..100, got: %d..%d", config.MinQuestionLength, config.MaxQuestionLength)
+	}
+
+	_ = os.Setenv("MIN_QUESTION_LENGTH", "200")
+	_ = os.Setenv("MAX_QUESTION_LENGTH", "100")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error when MIN_QUESTION_LENGTH exceeds MAX_QUESTION_LENGTH")
+	}
+}
+
+// TestParticipationBonusCapDefaults tests that the participation bonus cap is disabled by default
+func TestParticipationBonusCapDefaults(t *testing.T) {
+	// Save original env vars
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origCap := os.Getenv("PARTICIPATION_BONUS_CAP")
+	origPeriod := os.Getenv("PARTICIPATION_BONUS_PERIOD_DAYS")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("PARTICIPATION_BONUS_CAP", origCap)
+		_ = os.Setenv("PARTICIPATION_BONUS_PERIOD_DAYS", origPeriod)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("PARTICIPATION_BONUS_CAP")
+	_ = os.Unsetenv("PARTICIPATION_BONUS_PERIOD_DAYS")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.ParticipationBonusCap != 0 {
+		t.Errorf("Expected default ParticipationBonusCap to be 0, got: %d", config.ParticipationBonusCap)
+	}
+	if config.ParticipationBonusPeriodDays != 7 {
+		t.Errorf("Expected default ParticipationBonusPeriodDays to be 7, got: %d", config.ParticipationBonusPeriodDays)
+	}
+
+	_ = os.Setenv("PARTICIPATION_BONUS_CAP", "20")
+	_ = os.Setenv("PARTICIPATION_BONUS_PERIOD_DAYS", "30")
+
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.ParticipationBonusCap != 20 {
+		t.Errorf("Expected ParticipationBonusCap to be 20, got: %d", config.ParticipationBonusCap)
+	}
+	if config.ParticipationBonusPeriodDays != 30 {
+		t.Errorf("Expected ParticipationBonusPeriodDays to be 30, got: %d", config.ParticipationBonusPeriodDays)
+	}
+}
+
+func TestAchievementThresholds(t *testing.T) {
+	// Save original env vars
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origSharpshooter := os.Getenv("ACHIEVEMENT_SHARPSHOOTER_STREAK")
+	origProphet := os.Getenv("ACHIEVEMENT_PROPHET_STREAK")
+	origTiers := os.Getenv("ACHIEVEMENT_ORGANIZER_TIERS")
+	origGlobeTrotter := os.Getenv("ACHIEVEMENT_GLOBE_TROTTER_GROUPS")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("ACHIEVEMENT_SHARPSHOOTER_STREAK", origSharpshooter)
+		_ = os.Setenv("ACHIEVEMENT_PROPHET_STREAK", origProphet)
+		_ = os.Setenv("ACHIEVEMENT_ORGANIZER_TIERS", origTiers)
+		_ = os.Setenv("ACHIEVEMENT_GLOBE_TROTTER_GROUPS", origGlobeTrotter)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("ACHIEVEMENT_SHARPSHOOTER_STREAK")
+	_ = os.Unsetenv("ACHIEVEMENT_PROPHET_STREAK")
+	_ = os.Unsetenv("ACHIEVEMENT_ORGANIZER_TIERS")
+	_ = os.Unsetenv("ACHIEVEMENT_GLOBE_TROTTER_GROUPS")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.AchievementSharpshooter != 3 || config.AchievementProphet != 10 || config.AchievementRiskTaker != 3 || config.AchievementVeteran != 50 {
+		t.Errorf("Expected default streak thresholds 3/10/3/50, got: %d/%d/%d/%d",
+			config.AchievementSharpshooter, config.AchievementProphet, config.AchievementRiskTaker, config.AchievementVeteran)
+	}
+	if len(config.AchievementOrganizerTiers) != 3 || config.AchievementOrganizerTiers[0] != 1 || config.AchievementOrganizerTiers[1] != 5 || config.AchievementOrganizerTiers[2] != 25 {
+		t.Errorf("Expected default organizer tiers [1 5 25], got: %v", config.AchievementOrganizerTiers)
+	}
+	if config.AchievementGlobeTrotter != 3 {
+		t.Errorf("Expected default globe-trotter groups 3, got: %d", config.AchievementGlobeTrotter)
+	}
+
+	_ = os.Setenv("ACHIEVEMENT_GLOBE_TROTTER_GROUPS", "1")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error when globe-trotter needs a single group")
+	}
+	_ = os.Unsetenv("ACHIEVEMENT_GLOBE_TROTTER_GROUPS")
+
+	_ = os.Setenv("ACHIEVEMENT_ORGANIZER_TIERS", " 2, 10 ,50")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(config.AchievementOrganizerTiers) != 3 || config.AchievementOrganizerTiers[0] != 2 || config.AchievementOrganizerTiers[2] != 50 {
+		t.Errorf("Expected organizer tiers [2 10 50], got: %v", config.AchievementOrganizerTiers)
+	}
+
+	for _, tiers := range []string{"1,5", "1,5,5", "5,1,25", "0,5,25", "1,five,25"} {
+		_ = os.Setenv("ACHIEVEMENT_ORGANIZER_TIERS", tiers)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for ACHIEVEMENT_ORGANIZER_TIERS=%q", tiers)
+		}
+	}
+	_ = os.Unsetenv("ACHIEVEMENT_ORGANIZER_TIERS")
+
+	_ = os.Setenv("ACHIEVEMENT_SHARPSHOOTER_STREAK", "10")
+	_ = os.Setenv("ACHIEVEMENT_PROPHET_STREAK", "10")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error when sharpshooter streak is not below prophet streak")
+	}
+}
+
+func TestNewMemberCreateCooldown(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origCooldown := os.Getenv("NEW_MEMBER_CREATE_COOLDOWN")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("NEW_MEMBER_CREATE_COOLDOWN", origCooldown)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("NEW_MEMBER_CREATE_COOLDOWN")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.NewMemberCreateCooldown != 0 {
+		t.Errorf("Expected cooldown to be disabled by default, got: %s", config.NewMemberCreateCooldown)
+	}
+
+	_ = os.Setenv("NEW_MEMBER_CREATE_COOLDOWN", "36h")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.NewMemberCreateCooldown != 36*time.Hour {
+		t.Errorf("Expected cooldown 36h, got: %s", config.NewMemberCreateCooldown)
+	}
+
+	for _, value := range []string{"1 day", "-1h"} {
+		_ = os.Setenv("NEW_MEMBER_CREATE_COOLDOWN", value)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for NEW_MEMBER_CREATE_COOLDOWN=%q", value)
+		}
+	}
+}
+
+func TestJoinMinKnownAge(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origAge := os.Getenv("JOIN_MIN_KNOWN_AGE")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("JOIN_MIN_KNOWN_AGE", origAge)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("JOIN_MIN_KNOWN_AGE")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.JoinMinKnownAge != 0 {
+		t.Errorf("Expected join gate to be disabled by default, got: %s", config.JoinMinKnownAge)
+	}
+
+	_ = os.Setenv("JOIN_MIN_KNOWN_AGE", "72h")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.JoinMinKnownAge != 72*time.Hour {
+		t.Errorf("Expected join gate 72h, got: %s", config.JoinMinKnownAge)
+	}
+
+	_ = os.Setenv("JOIN_MIN_KNOWN_AGE", "-1h")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for a negative JOIN_MIN_KNOWN_AGE")
+	}
+}
+
+func TestResolutionNagConfig(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origDelay := os.Getenv("RESOLUTION_NAG_DELAY")
+	origInterval := os.Getenv("RESOLUTION_NAG_INTERVAL")
+	origMaxCount := os.Getenv("RESOLUTION_NAG_MAX_COUNT")
+	origEscalateAfter := os.Getenv("RESOLUTION_NAG_ESCALATE_AFTER")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("RESOLUTION_NAG_DELAY", origDelay)
+		_ = os.Setenv("RESOLUTION_NAG_INTERVAL", origInterval)
+		_ = os.Setenv("RESOLUTION_NAG_MAX_COUNT", origMaxCount)
+		_ = os.Setenv("RESOLUTION_NAG_ESCALATE_AFTER", origEscalateAfter)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("RESOLUTION_NAG_DELAY")
+	_ = os.Unsetenv("RESOLUTION_NAG_INTERVAL")
+	_ = os.Unsetenv("RESOLUTION_NAG_MAX_COUNT")
+	_ = os.Unsetenv("RESOLUTION_NAG_ESCALATE_AFTER")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ResolutionNagDelay != 0 {
+		t.Errorf("Expected resolution reminders to be disabled by default, got delay: %s", config.ResolutionNagDelay)
+	}
+	if config.ResolutionNagInterval != 24*time.Hour || config.ResolutionNagMaxCount != 3 {
+		t.Errorf("Expected default interval 24h and max count 3, got: %s, %d", config.ResolutionNagInterval, config.ResolutionNagMaxCount)
+	}
+	if config.ResolutionNagEscalation != 0 {
+		t.Errorf("Expected escalation to be disabled by default, got: %s", config.ResolutionNagEscalation)
+	}
+
+	_ = os.Setenv("RESOLUTION_NAG_DELAY", "12h")
+	_ = os.Setenv("RESOLUTION_NAG_INTERVAL", "6h")
+	_ = os.Setenv("RESOLUTION_NAG_MAX_COUNT", "5")
+	_ = os.Setenv("RESOLUTION_NAG_ESCALATE_AFTER", "72h")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ResolutionNagDelay != 12*time.Hour || config.ResolutionNagInterval != 6*time.Hour || config.ResolutionNagMaxCount != 5 {
+		t.Errorf("Expected 12h/6h/5, got: %s/%s/%d", config.ResolutionNagDelay, config.ResolutionNagInterval, config.ResolutionNagMaxCount)
+	}
+	if config.ResolutionNagEscalation != 72*time.Hour {
+		t.Errorf("Expected escalation after 72h, got: %s", config.ResolutionNagEscalation)
+	}
+
+	for _, tt := range []struct{ name, value string }{
+		{"RESOLUTION_NAG_DELAY", "-1h"},
+		{"RESOLUTION_NAG_DELAY", "soon"},
+		{"RESOLUTION_NAG_INTERVAL", "0s"},
+		{"RESOLUTION_NAG_ESCALATE_AFTER", "-1h"},
+	} {
+		_ = os.Setenv("RESOLUTION_NAG_DELAY", "12h")
+		_ = os.Setenv("RESOLUTION_NAG_INTERVAL", "6h")
+		_ = os.Setenv(tt.name, tt.value)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for %s=%q", tt.name, tt.value)
+		}
+	}
+}
+
+func TestDBOperationTimeout(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origTimeout := os.Getenv("DB_OPERATION_TIMEOUT")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("DB_OPERATION_TIMEOUT", origTimeout)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("DB_OPERATION_TIMEOUT")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.DBOperationTimeout != 30*time.Second {
+		t.Errorf("Expected default timeout 30s, got: %s", config.DBOperationTimeout)
+	}
+
+	_ = os.Setenv("DB_OPERATION_TIMEOUT", "0")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.DBOperationTimeout != 0 {
+		t.Errorf("Expected timeout to be disabled, got: %s", config.DBOperationTimeout)
+	}
+
+	_ = os.Setenv("DB_OPERATION_TIMEOUT", "-5s")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for negative DB_OPERATION_TIMEOUT")
+	}
+}
+
+func TestResolveMajorityConfirmation(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origConfirmation := os.Getenv("RESOLVE_MAJORITY_CONFIRMATION")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("RESOLVE_MAJORITY_CONFIRMATION", origConfirmation)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("RESOLVE_MAJORITY_CONFIRMATION")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !config.ResolveMajorityConfirmation {
+		t.Error("Expected majority confirmation to be enabled by default")
+	}
+
+	_ = os.Setenv("RESOLVE_MAJORITY_CONFIRMATION", "false")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ResolveMajorityConfirmation {
+		t.Error("Expected majority confirmation to be disabled")
+	}
+}
+
+func TestResolutionNoteVoteThreshold(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origThreshold := os.Getenv("RESOLUTION_NOTE_VOTE_THRESHOLD")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("RESOLUTION_NOTE_VOTE_THRESHOLD", origThreshold)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"", 0},
+		{"20", 20},
+		{"-5", 0},
+	}
+	for _, tt := range tests {
+		if tt.value == "" {
+			_ = os.Unsetenv("RESOLUTION_NOTE_VOTE_THRESHOLD")
+		} else {
+			_ = os.Setenv("RESOLUTION_NOTE_VOTE_THRESHOLD", tt.value)
+		}
+
+		config, err := Load()
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if config.ResolutionNoteVoteThreshold != tt.expected {
+			t.Errorf("RESOLUTION_NOTE_VOTE_THRESHOLD=%q: expected %d, got %d", tt.value, tt.expected, config.ResolutionNoteVoteThreshold)
+		}
+	}
+}
+
+func TestEventsShowOdds(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origShowOdds := os.Getenv("EVENTS_SHOW_ODDS")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("EVENTS_SHOW_ODDS", origShowOdds)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("EVENTS_SHOW_ODDS")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.EventsShowOdds {
+		t.Error("Expected odds to be hidden by default")
+	}
+
+	_ = os.Setenv("EVENTS_SHOW_ODDS", "true")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !config.EventsShowOdds {
+		t.Error("Expected odds to be shown")
+	}
+}
+
+func TestASCIIDisplayNames(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origASCII := os.Getenv("ASCII_DISPLAY_NAMES")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("ASCII_DISPLAY_NAMES", origASCII)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("ASCII_DISPLAY_NAMES")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ASCIIDisplayNames {
+		t.Error("Expected unicode display names by default")
+	}
+
+	_ = os.Setenv("ASCII_DISPLAY_NAMES", "true")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !config.ASCIIDisplayNames {
+		t.Error("Expected ASCII display names")
+	}
+}
+
+func TestInactiveMemberDays(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origDays := os.Getenv("INACTIVE_MEMBER_DAYS")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("INACTIVE_MEMBER_DAYS", origDays)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("INACTIVE_MEMBER_DAYS")
+
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"", 180},
+		{"90", 90},
+		{"0", 0},
+		{"-5", 0},
+	}
+
+	for _, tt := range tests {
+		if tt.value == "" {
+			_ = os.Unsetenv("INACTIVE_MEMBER_DAYS")
+		} else {
+			_ = os.Setenv("INACTIVE_MEMBER_DAYS", tt.value)
+		}
+
+		config, err := Load()
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if config.InactiveMemberDays != tt.expected {
+			t.Errorf("INACTIVE_MEMBER_DAYS=%q: expected %d, got %d", tt.value, tt.expected, config.InactiveMemberDays)
+		}
+	}
+}
+
+func TestHotEventsWindowHours(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origHours := os.Getenv("HOT_EVENTS_WINDOW_HOURS")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("HOT_EVENTS_WINDOW_HOURS", origHours)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"", 24},
+		{"6", 6},
+		{"0", 24},
+		{"-3", 24},
+	}
+
+	for _, tt := range tests {
+		if tt.value == "" {
+			_ = os.Unsetenv("HOT_EVENTS_WINDOW_HOURS")
+		} else {
+			_ = os.Setenv("HOT_EVENTS_WINDOW_HOURS", tt.value)
+		}
+
+		config, err := Load()
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if config.HotEventsWindowHours != tt.expected {
+			t.Errorf("HOT_EVENTS_WINDOW_HOURS=%q: expected %d, got %d", tt.value, tt.expected, config.HotEventsWindowHours)
+		}
+	}
+}
+
+func TestDeadlineOffsetConfig(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origMin := os.Getenv("MIN_DEADLINE_OFFSET")
+	origMax := os.Getenv("MAX_DEADLINE_OFFSET")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("MIN_DEADLINE_OFFSET", origMin)
+		_ = os.Setenv("MAX_DEADLINE_OFFSET", origMax)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("MIN_DEADLINE_OFFSET")
+	_ = os.Unsetenv("MAX_DEADLINE_OFFSET")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.MinDeadlineOffset != 10*time.Minute || config.MaxDeadlineOffset != 17520*time.Hour {
+		t.Errorf("Expected default range 10m..17520h, got: %s..%s", config.MinDeadlineOffset, config.MaxDeadlineOffset)
+	}
+
+	_ = os.Setenv("MIN_DEADLINE_OFFSET", "1h")
+	_ = os.Setenv("MAX_DEADLINE_OFFSET", "0")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.MinDeadlineOffset != time.Hour || config.MaxDeadlineOffset != 0 {
+		t.Errorf("Expected 1h with no maximum, got: %s..%s", config.MinDeadlineOffset, config.MaxDeadlineOffset)
+	}
+
+	for _, tt := range []struct{ min, max string }{
+		{"-1h", "24h"},
+		{"1h", "soon"},
+		{"48h", "24h"},
+	} {
+		_ = os.Setenv("MIN_DEADLINE_OFFSET", tt.min)
+		_ = os.Setenv("MAX_DEADLINE_OFFSET", tt.max)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for MIN_DEADLINE_OFFSET=%q MAX_DEADLINE_OFFSET=%q", tt.min, tt.max)
+		}
+	}
+}
+
+func TestParticipationModeConfig(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origMode := os.Getenv("PARTICIPATION_MODE")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("PARTICIPATION_MODE", origMode)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("PARTICIPATION_MODE")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ParticipationMode != "resolved" {
+		t.Errorf("Expected default participation mode resolved, got: %s", config.ParticipationMode)
+	}
+
+	_ = os.Setenv("PARTICIPATION_MODE", " Voted ")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ParticipationMode != "voted" {
+		t.Errorf("Expected participation mode voted, got: %s", config.ParticipationMode)
+	}
+
+	_ = os.Setenv("PARTICIPATION_MODE", "bogus")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for invalid PARTICIPATION_MODE")
+	}
+}
+
+func TestDefaultPollTypeConfig(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origPollType := os.Getenv("DEFAULT_POLL_TYPE")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("DEFAULT_POLL_TYPE", origPollType)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("DEFAULT_POLL_TYPE")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.DefaultPollType != "regular" {
+		t.Errorf("Expected default poll type regular, got: %s", config.DefaultPollType)
+	}
+
+	_ = os.Setenv("DEFAULT_POLL_TYPE", " Quiz ")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.DefaultPollType != "quiz" {
+		t.Errorf("Expected poll type quiz, got: %s", config.DefaultPollType)
+	}
+
+	_ = os.Setenv("DEFAULT_POLL_TYPE", "survey")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for invalid DEFAULT_POLL_TYPE")
+	}
+}
+
+func TestResolutionWebhookConfig(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origURL := os.Getenv("RESOLUTION_WEBHOOK_URL")
+	origSecret := os.Getenv("WEBHOOK_SECRET")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("RESOLUTION_WEBHOOK_URL", origURL)
+		_ = os.Setenv("WEBHOOK_SECRET", origSecret)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("RESOLUTION_WEBHOOK_URL")
+	_ = os.Unsetenv("WEBHOOK_SECRET")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ResolutionWebhookURL != "" {
+		t.Errorf("Expected webhook disabled by default, got: %s", config.ResolutionWebhookURL)
+	}
+
+	_ = os.Setenv("RESOLUTION_WEBHOOK_URL", "https://example.com/hook")
+	_ = os.Setenv("WEBHOOK_SECRET", "s3cret")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ResolutionWebhookURL != "https://example.com/hook" || config.WebhookSecret != "s3cret" {
+		t.Errorf("Expected webhook settings to be loaded, got: %s %s", config.ResolutionWebhookURL, config.WebhookSecret)
+	}
+
+	for _, tt := range []struct{ url, secret string }{
+		{"https://example.com/hook", ""},
+		{"ftp://example.com/hook", "s3cret"},
+		{"example.com/hook", "s3cret"},
+	} {
+		_ = os.Setenv("RESOLUTION_WEBHOOK_URL", tt.url)
+		_ = os.Setenv("WEBHOOK_SECRET", tt.secret)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for RESOLUTION_WEBHOOK_URL=%q WEBHOOK_SECRET=%q", tt.url, tt.secret)
+		}
+	}
+}
+
+func TestLeaderboardStyleConfig(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origMedals := os.Getenv("LEADERBOARD_MEDALS")
+	origFilled := os.Getenv("PROGRESS_BAR_FILLED")
+	origEmpty := os.Getenv("PROGRESS_BAR_EMPTY")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("LEADERBOARD_MEDALS", origMedals)
+		_ = os.Setenv("PROGRESS_BAR_FILLED", origFilled)
+		_ = os.Setenv("PROGRESS_BAR_EMPTY", origEmpty)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("LEADERBOARD_MEDALS")
+	_ = os.Unsetenv("PROGRESS_BAR_FILLED")
+	_ = os.Unsetenv("PROGRESS_BAR_EMPTY")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(config.LeaderboardMedals, []string{"🥇", "🥈", "🥉"}) || config.ProgressBarFilled != "▰" || config.ProgressBarEmpty != "▱" {
+		t.Errorf("Expected the default medals and bar, got: %v %q %q", config.LeaderboardMedals, config.ProgressBarFilled, config.ProgressBarEmpty)
+	}
+
+	_ = os.Setenv("LEADERBOARD_MEDALS", " 🏆, 2nd ,❤️")
+	_ = os.Setenv("PROGRESS_BAR_FILLED", "#")
+	_ = os.Setenv("PROGRESS_BAR_EMPTY", "☆")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(config.LeaderboardMedals, []string{"🏆", "2nd", "❤️"}) || config.ProgressBarFilled != "#" || config.ProgressBarEmpty != "☆" {
+		t.Errorf("Expected the configured medals and bar, got: %v %q %q", config.LeaderboardMedals, config.ProgressBarFilled, config.ProgressBarEmpty)
+	}
+
+	_ = os.Setenv("PROGRESS_BAR_FILLED", "❤️")
+	if _, err := Load(); err != nil {
+		t.Errorf("Expected an emoji with a variation selector to be accepted, got: %v", err)
+	}
+
+	for _, tt := range []struct{ medals, filled, empty string }{
+		{"🥇,🥈", "#", "-"},
+		{"🥇,,🥉", "#", "-"},
+		{"🥇,🥈 🥈,🥉", "#", "-"},
+		{"🥇,🥈,🥉", "##", "-"},
+		{"🥇,🥈,🥉", "#", "ab"},
+	} {
+		_ = os.Setenv("LEADERBOARD_MEDALS", tt.medals)
+		_ = os.Setenv("PROGRESS_BAR_FILLED", tt.filled)
+		_ = os.Setenv("PROGRESS_BAR_EMPTY", tt.empty)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for LEADERBOARD_MEDALS=%q PROGRESS_BAR_FILLED=%q PROGRESS_BAR_EMPTY=%q", tt.medals, tt.filled, tt.empty)
+		}
+	}
+}
+
+func TestDuplicateEventTolerance(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origTolerance := os.Getenv("DUPLICATE_EVENT_TOLERANCE_MINUTES")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("DUPLICATE_EVENT_TOLERANCE_MINUTES", origTolerance)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"", 60},
+		{"15", 15},
+		{"0", 0},
+		{"-5", -1},
+	}
+
+	for _, tt := range tests {
+		if tt.value == "" {
+			_ = os.Unsetenv("DUPLICATE_EVENT_TOLERANCE_MINUTES")
+		} else {
+			_ = os.Setenv("DUPLICATE_EVENT_TOLERANCE_MINUTES", tt.value)
+		}
+
+		config, err := Load()
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if config.DuplicateEventTolerance != tt.expected {
+			t.Errorf("DUPLICATE_EVENT_TOLERANCE_MINUTES=%q: expected %d, got %d", tt.value, tt.expected, config.DuplicateEventTolerance)
+		}
+	}
+}
+
+func TestListSortOrderConfig(t *testing.T) {
+	origToken := os.Getenv("TELEGRAM_TOKEN")
+	origAdminIDs := os.Getenv("ADMIN_USER_IDS")
+	origOrder := os.Getenv("LIST_SORT_ORDER")
+
+	defer func() {
+		// Restore original env vars
+		_ = os.Setenv("TELEGRAM_TOKEN", origToken)
+		_ = os.Setenv("ADMIN_USER_IDS", origAdminIDs)
+		_ = os.Setenv("LIST_SORT_ORDER", origOrder)
+	}()
+
+	// Set required valid env vars
+	_ = os.Setenv("TELEGRAM_TOKEN", "test_token")
+	_ = os.Setenv("ADMIN_USER_IDS", "111,222")
+	_ = os.Unsetenv("LIST_SORT_ORDER")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ListSortOrder != "default" {
+		t.Errorf("Expected default list order, got: %s", config.ListSortOrder)
+	}
+
+	_ = os.Setenv("LIST_SORT_ORDER", " Name ")
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.ListSortOrder != "name" {
+		t.Errorf("Expected list order name, got: %s", config.ListSortOrder)
+	}
+
+	_ = os.Setenv("LIST_SORT_ORDER", "random")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for invalid LIST_SORT_ORDER")
+	}
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -12,12 +14,65 @@ const (
 	RiskTakerStreak    = 3
 	VeteranCount       = 50
 
+	// GlobeTrotterGroups is the number of groups a user must vote in for Globe-Trotter
+	GlobeTrotterGroups = 3
+
 	// Creator achievement thresholds
 	EventOrganizerThreshold  = 1
 	ActiveOrganizerThreshold = 5
 	MasterOrganizerThreshold = 25
 )
 
+// ErrInvalidAchievementThresholds is returned when achievement thresholds are inconsistent
+var ErrInvalidAchievementThresholds = errors.New("invalid achievement thresholds")
+
+// AchievementThresholds configures when achievements are awarded
+type AchievementThresholds struct {
+	SharpshooterStreak int
+	ProphetStreak      int
+	RiskTakerStreak    int
+	VeteranCount       int
+	GlobeTrotterGroups int
+
+	// Creator achievement tiers, must be strictly increasing
+	EventOrganizer  int
+	ActiveOrganizer int
+	MasterOrganizer int
+}
+
+// DefaultAchievementThresholds returns the default achievement thresholds
+func DefaultAchievementThresholds() AchievementThresholds {
+	return AchievementThresholds{
+		SharpshooterStreak: SharpshooterStreak,
+		ProphetStreak:      ProphetStreak,
+		RiskTakerStreak:    RiskTakerStreak,
+		VeteranCount:       VeteranCount,
+		GlobeTrotterGroups: GlobeTrotterGroups,
+		EventOrganizer:     EventOrganizerThreshold,
+		ActiveOrganizer:    ActiveOrganizerThreshold,
+		MasterOrganizer:    MasterOrganizerThreshold,
+	}
+}
+
+// Validate checks that all thresholds are positive and that tiers are strictly increasing
+func (t AchievementThresholds) Validate() error {
+	if t.SharpshooterStreak <= 0 || t.ProphetStreak <= 0 || t.RiskTakerStreak <= 0 || t.VeteranCount <= 0 || t.EventOrganizer <= 0 {
+		return fmt.Errorf("%w: thresholds must be positive", ErrInvalidAchievementThresholds)
+	}
+	if t.GlobeTrotterGroups < 2 {
+		return fmt.Errorf("%w: globe-trotter groups (%d) must be at least 2", ErrInvalidAchievementThresholds, t.GlobeTrotterGroups)
+	}
+	if t.SharpshooterStreak >= t.ProphetStreak {
+		return fmt.Errorf("%w: sharpshooter streak (%d) must be less than prophet streak (%d)",
+			ErrInvalidAchievementThresholds, t.SharpshooterStreak, t.ProphetStreak)
+	}
+	if t.EventOrganizer >= t.ActiveOrganizer || t.ActiveOrganizer >= t.MasterOrganizer {
+		return fmt.Errorf("%w: organizer tiers (%d, %d, %d) must be strictly increasing",
+			ErrInvalidAchievementThresholds, t.EventOrganizer, t.ActiveOrganizer, t.MasterOrganizer)
+	}
+	return nil
+}
+
 // AchievementRepository interface for achievement operations
 type AchievementRepository interface {
 	SaveAchievement(ctx context.Context, achievement *Achievement) error
@@ -31,6 +86,8 @@ type AchievementTracker struct {
 	ratingRepo      RatingRepository
 	predictionRepo  PredictionRepository
 	eventRepo       EventRepository
+	groupRepo       GroupRepository
+	thresholds      AchievementThresholds
 	logger          Logger
 }
 
@@ -40,17 +97,42 @@ func NewAchievementTracker(
 	ratingRepo RatingRepository,
 	predictionRepo PredictionRepository,
 	eventRepo EventRepository,
+	thresholds *AchievementThresholds,
 	logger Logger,
 ) *AchievementTracker {
+	// A nil thresholds value keeps the default thresholds
+	effective := DefaultAchievementThresholds()
+	if thresholds != nil {
+		effective = *thresholds
+	}
+
 	return &AchievementTracker{
 		achievementRepo: achievementRepo,
 		ratingRepo:      ratingRepo,
 		predictionRepo:  predictionRepo,
 		eventRepo:       eventRepo,
+		thresholds:      effective,
 		logger:          logger,
 	}
 }
 
+// SetGroupRepository enables the groups' achievement settings (all achievements are enabled otherwise)
+func (at *AchievementTracker) SetGroupRepository(groupRepo GroupRepository) {
+	at.groupRepo = groupRepo
+}
+
+// enabledIn returns the group whose achievement settings apply, nil when all achievements are enabled
+func (at *AchievementTracker) enabledIn(ctx context.Context, groupID int64) (*Group, error) {
+	if at.groupRepo == nil {
+		return nil, nil
+	}
+	group, err := at.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group for achievement settings: %w", err)
+	}
+	return group, nil
+}
+
 // CheckAndAwardAchievements checks and awards achievements for a user in a specific group
 func (at *AchievementTracker) CheckAndAwardAchievements(ctx context.Context, userID int64, groupID int64) ([]*Achievement, error) {
 	var newAchievements []*Achievement
@@ -62,8 +144,8 @@ func (at *AchievementTracker) CheckAndAwardAchievements(ctx context.Context, use
 		return nil, err
 	}
 
-	// Check Sharpshooter (3 correct in a row)
-	if rating.Streak >= SharpshooterStreak {
+	// Check Sharpshooter (3 correct in a row by default)
+	if rating.Streak >= at.thresholds.SharpshooterStreak {
 		achievement, err := at.awardAchievementIfNew(ctx, userID, groupID, AchievementSharpshooter)
 		if err != nil {
 			at.logger.Error("failed to award sharpshooter", "user_id", userID, "group_id", groupID, "error", err)
@@ -72,8 +154,8 @@ func (at *AchievementTracker) CheckAndAwardAchievements(ctx context.Context, use
 		}
 	}
 
-	// Check Prophet (10 correct in a row)
-	if rating.Streak >= ProphetStreak {
+	// Check Prophet (10 correct in a row by default)
+	if rating.Streak >= at.thresholds.ProphetStreak {
 		achievement, err := at.awardAchievementIfNew(ctx, userID, groupID, AchievementProphet)
 		if err != nil {
 			at.logger.Error("failed to award prophet", "user_id", userID, "group_id", groupID, "error", err)
@@ -82,9 +164,9 @@ func (at *AchievementTracker) CheckAndAwardAchievements(ctx context.Context, use
 		}
 	}
 
-	// Check Veteran (50 participations)
+	// Check Veteran (50 participations by default)
 	totalParticipations := rating.CorrectCount + rating.WrongCount
-	if totalParticipations >= VeteranCount {
+	if totalParticipations >= at.thresholds.VeteranCount {
 		achievement, err := at.awardAchievementIfNew(ctx, userID, groupID, AchievementVeteran)
 		if err != nil {
 			at.logger.Error("failed to award veteran", "user_id", userID, "group_id", groupID, "error", err)
@@ -93,7 +175,7 @@ func (at *AchievementTracker) CheckAndAwardAchievements(ctx context.Context, use
 		}
 	}
 
-	// Check Risk Taker (3 minority correct in a row)
+	// Check Risk Taker (3 minority correct in a row by default)
 	// This requires checking recent predictions
 	isRiskTaker, err := at.checkRiskTakerAchievement(ctx, userID, groupID)
 	if err != nil {
@@ -107,14 +189,77 @@ func (at *AchievementTracker) CheckAndAwardAchievements(ctx context.Context, use
 		}
 	}
 
+	// Check Globe-Trotter (predictions in 3 groups by default). The activity is counted across
+	// groups, but like all achievements it is awarded per group: in each group where the
+	// user's predictions are checked once the threshold is reached
+	activeGroups, err := at.predictionRepo.GetUserActiveGroupCount(ctx, userID)
+	if err != nil {
+		at.logger.Error("failed to get active group count", "user_id", userID, "error", err)
+	} else if activeGroups >= at.thresholds.GlobeTrotterGroups {
+		achievement, err := at.awardAchievementIfNew(ctx, userID, groupID, AchievementGlobeTrotter)
+		if err != nil {
+			at.logger.Error("failed to award globe-trotter", "user_id", userID, "group_id", groupID, "error", err)
+		} else if achievement != nil {
+			newAchievements = append(newAchievements, achievement)
+		}
+	}
+
 	// Note: Weekly Analyst would be checked by a separate scheduled job
 	// that runs weekly and compares all users' scores for the week
 
 	return newAchievements, nil
 }
 
+// UserAchievements are the achievements newly awarded to one user
+type UserAchievements struct {
+	UserID       int64
+	Achievements []*Achievement
+}
+
+// CheckEventAchievements re-evaluates the achievements of every participant who had a prediction
+// on a resolved event and returns the users with newly awarded achievements. Awarding is idempotent,
+// so checking the same event again awards nothing.
+func (at *AchievementTracker) CheckEventAchievements(ctx context.Context, event *Event) ([]UserAchievements, error) {
+	predictions, err := at.predictionRepo.GetPredictionsByEvent(ctx, event.ID)
+	if err != nil {
+		at.logger.Error("failed to get predictions for achievements", "event_id", event.ID, "error", err)
+		return nil, err
+	}
+
+	var awarded []UserAchievements
+	checked := make(map[int64]bool)
+	for _, pred := range event.ParticipantPredictions(predictions) {
+		if checked[pred.UserID] {
+			continue
+		}
+		checked[pred.UserID] = true
+
+		achievements, err := at.CheckAndAwardAchievements(ctx, pred.UserID, event.GroupID)
+		if err != nil {
+			// CheckAndAwardAchievements logs the failure, the other users are still checked
+			continue
+		}
+		if len(achievements) > 0 {
+			awarded = append(awarded, UserAchievements{UserID: pred.UserID, Achievements: achievements})
+		}
+	}
+
+	at.logger.Info("event achievements checked", "event_id", event.ID, "users", len(checked), "awarded", len(awarded))
+	return awarded, nil
+}
+
 // awardAchievementIfNew awards an achievement if the user doesn't already have it
+// and the achievement is enabled in the group
 func (at *AchievementTracker) awardAchievementIfNew(ctx context.Context, userID int64, groupID int64, code AchievementCode) (*Achievement, error) {
+	group, err := at.enabledIn(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group != nil && !group.AchievementEnabled(code) {
+		at.logger.Debug("achievement disabled in group", "user_id", userID, "group_id", groupID, "code", code)
+		return nil, nil
+	}
+
 	// Check if achievement already exists
 	exists, err := at.achievementRepo.CheckAchievementExists(ctx, userID, groupID, code)
 	if err != nil {
@@ -142,7 +287,7 @@ func (at *AchievementTracker) awardAchievementIfNew(ctx context.Context, userID
 	return achievement, nil
 }
 
-// checkRiskTakerAchievement checks if user has 3 minority correct predictions in a row for a specific group
+// checkRiskTakerAchievement checks if user has enough minority correct predictions in a row for a specific group
 func (at *AchievementTracker) checkRiskTakerAchievement(ctx context.Context, userID int64, groupID int64) (bool, error) {
 	// Get all user's predictions
 	userPredictions, err := at.predictionRepo.GetUserPredictions(ctx, userID)
@@ -150,7 +295,7 @@ func (at *AchievementTracker) checkRiskTakerAchievement(ctx context.Context, use
 		return false, err
 	}
 
-	if len(userPredictions) < RiskTakerStreak {
+	if len(userPredictions) < at.thresholds.RiskTakerStreak {
 		return false, nil
 	}
 
@@ -220,7 +365,7 @@ func (at *AchievementTracker) checkRiskTakerAchievement(ctx context.Context, use
 				"percentage", percentage,
 			)
 
-			if consecutiveCount >= RiskTakerStreak {
+			if consecutiveCount >= at.thresholds.RiskTakerStreak {
 				return true, nil
 			}
 		} else {
@@ -232,7 +377,8 @@ func (at *AchievementTracker) checkRiskTakerAchievement(ctx context.Context, use
 	return false, nil
 }
 
-// GetUserAchievements retrieves all achievements for a user in a specific group
+// GetUserAchievements retrieves the achievements of a user in a specific group.
+// Achievements disabled in the group are left out, even if they were awarded before.
 func (at *AchievementTracker) GetUserAchievements(ctx context.Context, userID int64, groupID int64) ([]*Achievement, error) {
 	achievements, err := at.achievementRepo.GetUserAchievements(ctx, userID, groupID)
 	if err != nil {
@@ -240,7 +386,22 @@ func (at *AchievementTracker) GetUserAchievements(ctx context.Context, userID in
 		return nil, err
 	}
 
-	return achievements, nil
+	group, err := at.enabledIn(ctx, groupID)
+	if err != nil {
+		at.logger.Error("failed to get user achievements", "user_id", userID, "group_id", groupID, "error", err)
+		return nil, err
+	}
+	if group == nil || len(group.DisabledAchievements) == 0 {
+		return achievements, nil
+	}
+
+	enabled := make([]*Achievement, 0, len(achievements))
+	for _, achievement := range achievements {
+		if group.AchievementEnabled(achievement.Code) {
+			enabled = append(enabled, achievement)
+		}
+	}
+	return enabled, nil
 }
 
 // AwardWeeklyAnalyst awards the Weekly Analyst achievement to the user with most points in a week for a specific group
@@ -270,8 +431,8 @@ func (at *AchievementTracker) CheckCreatorAchievements(ctx context.Context, user
 		return nil, err
 	}
 
-	// Check Event Organizer (1 event created)
-	if createdCount >= EventOrganizerThreshold {
+	// Check Event Organizer (1 event created by default)
+	if createdCount >= at.thresholds.EventOrganizer {
 		achievement, err := at.awardAchievementIfNew(ctx, userID, groupID, AchievementEventOrganizer)
 		if err != nil {
 			at.logger.Error("failed to award event organizer", "user_id", userID, "group_id", groupID, "error", err)
@@ -280,8 +441,8 @@ func (at *AchievementTracker) CheckCreatorAchievements(ctx context.Context, user
 		}
 	}
 
-	// Check Active Organizer (5 events created)
-	if createdCount >= ActiveOrganizerThreshold {
+	// Check Active Organizer (5 events created by default)
+	if createdCount >= at.thresholds.ActiveOrganizer {
 		achievement, err := at.awardAchievementIfNew(ctx, userID, groupID, AchievementActiveOrganizer)
 		if err != nil {
 			at.logger.Error("failed to award active organizer", "user_id", userID, "group_id", groupID, "error", err)
@@ -290,8 +451,8 @@ func (at *AchievementTracker) CheckCreatorAchievements(ctx context.Context, user
 		}
 	}
 
-	// Check Master Organizer (25 events created)
-	if createdCount >= MasterOrganizerThreshold {
+	// Check Master Organizer (25 events created by default)
+	if createdCount >= at.thresholds.MasterOrganizer {
 		achievement, err := at.awardAchievementIfNew(ctx, userID, groupID, AchievementMasterOrganizer)
 		if err != nil {
 			at.logger.Error("failed to award master organizer", "user_id", userID, "group_id", groupID, "error", err)
@@ -20,7 +20,10 @@ func (m *mockLoggerForAchievements) Warn(msg string, args ...interface{})  {}
 func (m *mockLoggerForAchievements) Error(msg string, args ...interface{}) {}
 
 // mockPredictionRepoForAchievements implements PredictionRepository for testing
-type mockPredictionRepoForAchievements struct{}
+type mockPredictionRepoForAchievements struct {
+	activeGroups int
+	predictions  []*Prediction
+}
 
 func (m *mockPredictionRepoForAchievements) SavePrediction(ctx context.Context, prediction *Prediction) error {
 	return nil
@@ -30,16 +33,36 @@ func (m *mockPredictionRepoForAchievements) UpdatePrediction(ctx context.Context
 	return nil
 }
 
+func (m *mockPredictionRepoForAchievements) SetParticipationAwarded(ctx context.Context, eventID, userID int64, awarded bool) error {
+	return nil
+}
+
 func (m *mockPredictionRepoForAchievements) GetUserCompletedEventCount(ctx context.Context, userID int64, groupID int64) (int, error) {
 	return 0, nil
 }
 
+func (m *mockPredictionRepoForAchievements) GetUserVotedEventCount(ctx context.Context, userID int64, groupID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *mockPredictionRepoForAchievements) GetUserActiveGroupCount(ctx context.Context, userID int64) (int, error) {
+	return m.activeGroups, nil
+}
+
+func (m *mockPredictionRepoForAchievements) CountVotesSince(ctx context.Context, eventID int64, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockPredictionRepoForAchievements) ImportPredictions(ctx context.Context, groupID int64, rows []*PredictionImportRow, dryRun bool) (*PredictionImportReport, error) {
+	return &PredictionImportReport{DryRun: dryRun, Total: len(rows)}, nil
+}
+
 func (m *mockPredictionRepoForAchievements) GetUserPredictions(ctx context.Context, userID int64) ([]*Prediction, error) {
 	return nil, nil
 }
 
 func (m *mockPredictionRepoForAchievements) GetPredictionsByEvent(ctx context.Context, eventID int64) ([]*Prediction, error) {
-	return nil, nil
+	return m.predictions, nil
 }
 
 func (m *mockPredictionRepoForAchievements) GetPredictionByUserAndEvent(ctx context.Context, userID, eventID int64) (*Prediction, error) {
@@ -126,6 +149,22 @@ func (m *mockRatingRepo) UpdateStreak(ctx context.Context, userID int64, groupID
 	return nil
 }
 
+func (m *mockRatingRepo) GetTopStreaks(ctx context.Context, groupID int64, limit int) ([]*Rating, error) {
+	return nil, nil
+}
+
+func (m *mockRatingRepo) ReplaceGroupRatings(ctx context.Context, groupID int64, ratings []*Rating) error {
+	return nil
+}
+
+func (m *mockRatingRepo) GetUserRank(ctx context.Context, userID int64, groupID int64) (int, int, error) {
+	return 0, 0, nil
+}
+
+func (m *mockRatingRepo) UpdateUsername(ctx context.Context, userID int64, groupID int64, username string) (bool, error) {
+	return false, nil
+}
+
 // Mock EventRepository for creator achievements testing
 type mockEventRepoForCreator struct {
 	createdEventsCount int
@@ -148,6 +187,20 @@ func (m *mockEventRepoForCreator) GetActiveEvents(ctx context.Context, groupID i
 	return nil, nil
 }
 
+func (m *mockEventRepoForCreator) GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*Event, error) {
+	events, err := m.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	var visible []*Event
+	for _, event := range events {
+		if event.IsParticipant(userID) {
+			visible = append(visible, event)
+		}
+	}
+	return visible, nil
+}
+
 func (m *mockEventRepoForCreator) GetResolvedEvents(ctx context.Context) ([]*Event, error) {
 	return nil, nil
 }
@@ -171,6 +224,54 @@ func (m *mockEventRepoForCreator) GetEventsByDeadlineRange(ctx context.Context,
 	return nil, nil
 }
 
+func (m *mockEventRepoForCreator) ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockEventRepoForCreator) GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepoForCreator) UnarchiveEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *mockEventRepoForCreator) SetPollMessageMissing(ctx context.Context, eventID int64, missing bool) error {
+	return nil
+}
+
+func (m *mockEventRepoForCreator) SetResolutionNote(ctx context.Context, eventID int64, note string) error {
+	return nil
+}
+
+func (m *mockEventRepoForCreator) SetMinorityCorrect(ctx context.Context, eventID int64, minority bool) error {
+	return nil
+}
+
+func (m *mockEventRepoForCreator) GetPollMissingEvents(ctx context.Context) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepoForCreator) CloseVoting(ctx context.Context, eventID int64, closedAt time.Time) error {
+	return nil
+}
+
+func (m *mockEventRepoForCreator) ActivatePendingEvent(ctx context.Context, event *Event) error {
+	return nil
+}
+
+func (m *mockEventRepoForCreator) RejectPendingEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *mockEventRepoForCreator) FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepoForCreator) UpdateEventCreator(ctx context.Context, eventID int64, createdBy int64) error {
+	return nil
+}
+
 // TestAchievementPersistence tests: Achievement persistence
 func TestAchievementPersistence(t *testing.T) {
 	parameters := gopter.DefaultTestParameters()
@@ -188,6 +289,7 @@ func TestAchievementPersistence(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -332,6 +434,7 @@ func TestCheckCreatorAchievements_Thresholds(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -370,6 +473,7 @@ func TestCheckCreatorAchievements_DuplicatePrevention(t *testing.T) {
 		&mockRatingRepo{},
 		&mockPredictionRepoForAchievements{},
 		eventRepo,
+		nil,
 		&mockLoggerForAchievements{},
 	)
 
@@ -429,6 +533,7 @@ func TestCheckCreatorAchievements_CountingLogic(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -457,6 +562,7 @@ func TestCheckCreatorAchievements_Error(t *testing.T) {
 		&mockRatingRepo{},
 		&mockPredictionRepoForAchievements{},
 		eventRepo,
+		nil,
 		&mockLoggerForAchievements{},
 	)
 
@@ -497,6 +603,7 @@ func TestAchievementCalculationIsolation(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo1,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -506,6 +613,7 @@ func TestAchievementCalculationIsolation(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo2,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -605,6 +713,7 @@ func TestIndependentAchievementEarning(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -614,6 +723,7 @@ func TestIndependentAchievementEarning(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -729,6 +839,7 @@ func TestAchievementGroupAssociation(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -812,6 +923,7 @@ func TestAchievementsCalculatedPerGroup(t *testing.T) {
 		&mockRatingRepo{},
 		&mockPredictionRepoForAchievements{},
 		eventRepo1,
+		nil,
 		&mockLoggerForAchievements{},
 	)
 
@@ -822,6 +934,7 @@ func TestAchievementsCalculatedPerGroup(t *testing.T) {
 		&mockRatingRepo{},
 		&mockPredictionRepoForAchievements{},
 		eventRepo2,
+		nil,
 		&mockLoggerForAchievements{},
 	)
 
@@ -893,6 +1006,7 @@ func TestEventCountsPerGroup(t *testing.T) {
 				&mockRatingRepo{},
 				&mockPredictionRepoForAchievements{},
 				eventRepo,
+				nil,
 				&mockLoggerForAchievements{},
 			)
 
@@ -930,6 +1044,7 @@ func TestSameAchievementInMultipleGroups(t *testing.T) {
 			&mockRatingRepo{},
 			&mockPredictionRepoForAchievements{},
 			eventRepo,
+			nil,
 			&mockLoggerForAchievements{},
 		)
 
@@ -978,3 +1093,273 @@ func TestSameAchievementInMultipleGroups(t *testing.T) {
 		}
 	}
 }
+
+// TestAchievementThresholds_Validate tests validation of configured thresholds
+func TestAchievementThresholds_Validate(t *testing.T) {
+	if err := DefaultAchievementThresholds().Validate(); err != nil {
+		t.Fatalf("expected default thresholds to be valid, got %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(*AchievementThresholds)
+	}{
+		{"zero veteran count", func(th *AchievementThresholds) { th.VeteranCount = 0 }},
+		{"prophet not above sharpshooter", func(th *AchievementThresholds) { th.ProphetStreak = th.SharpshooterStreak }},
+		{"equal organizer tiers", func(th *AchievementThresholds) { th.ActiveOrganizer = th.EventOrganizer }},
+		{"decreasing organizer tiers", func(th *AchievementThresholds) { th.MasterOrganizer = 3 }},
+		{"single globe-trotter group", func(th *AchievementThresholds) { th.GlobeTrotterGroups = 1 }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			thresholds := DefaultAchievementThresholds()
+			tc.modify(&thresholds)
+			if err := thresholds.Validate(); !errors.Is(err, ErrInvalidAchievementThresholds) {
+				t.Errorf("expected ErrInvalidAchievementThresholds, got %v", err)
+			}
+		})
+	}
+}
+
+// TestCheckCreatorAchievements_CustomThresholds tests that configured organizer tiers change when achievements are awarded
+func TestCheckCreatorAchievements_CustomThresholds(t *testing.T) {
+	ctx := context.Background()
+	thresholds := DefaultAchievementThresholds()
+	thresholds.EventOrganizer = 2
+	thresholds.ActiveOrganizer = 3
+	thresholds.MasterOrganizer = 4
+
+	testCases := []struct {
+		createdCount int
+		expected     int
+	}{
+		{1, 0},
+		{2, 1},
+		{3, 2},
+		{4, 3},
+	}
+
+	for _, tc := range testCases {
+		tracker := NewAchievementTracker(
+			newMockAchievementRepo(),
+			&mockRatingRepo{},
+			&mockPredictionRepoForAchievements{},
+			&mockEventRepoForCreator{createdEventsCount: tc.createdCount},
+			&thresholds,
+			&mockLoggerForAchievements{},
+		)
+
+		achievements, err := tracker.CheckCreatorAchievements(ctx, 1, 1)
+		if err != nil {
+			t.Fatalf("Error checking achievements: %v", err)
+		}
+		if len(achievements) != tc.expected {
+			t.Errorf("%d events created: expected %d achievements, got %d", tc.createdCount, tc.expected, len(achievements))
+		}
+	}
+}
+
+// TestCheckAndAwardAchievements_CustomThresholds tests that configured streak and participation thresholds are used
+func TestCheckAndAwardAchievements_CustomThresholds(t *testing.T) {
+	ctx := context.Background()
+	ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+		{1, 1}: {UserID: 1, GroupID: 1, Streak: 2, CorrectCount: 6, WrongCount: 4},
+	}}
+
+	newTracker := func(thresholds *AchievementThresholds) *AchievementTracker {
+		return NewAchievementTracker(
+			newMockAchievementRepo(),
+			ratingRepo,
+			&mockPredictionRepoForAchievements{},
+			&mockEventRepoForCreator{},
+			thresholds,
+			&mockLoggerForAchievements{},
+		)
+	}
+
+	// Default thresholds: a streak of 2 and 10 participations earn nothing
+	achievements, err := newTracker(nil).CheckAndAwardAchievements(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("Error checking achievements: %v", err)
+	}
+	if len(achievements) != 0 {
+		t.Errorf("expected no achievements with default thresholds, got %d", len(achievements))
+	}
+
+	// Lowered thresholds: Sharpshooter and Veteran are awarded, Prophet is not
+	thresholds := DefaultAchievementThresholds()
+	thresholds.SharpshooterStreak = 2
+	thresholds.ProphetStreak = 5
+	thresholds.VeteranCount = 10
+
+	achievements, err = newTracker(&thresholds).CheckAndAwardAchievements(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("Error checking achievements: %v", err)
+	}
+	codes := make(map[AchievementCode]bool)
+	for _, achievement := range achievements {
+		codes[achievement.Code] = true
+	}
+	if len(codes) != 2 || !codes[AchievementSharpshooter] || !codes[AchievementVeteran] {
+		t.Errorf("expected Sharpshooter and Veteran, got %v", codes)
+	}
+}
+
+// TestGlobeTrotterAchievement tests that activity across groups earns Globe-Trotter once per group
+func TestGlobeTrotterAchievement(t *testing.T) {
+	ctx := context.Background()
+	ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+		{1, 1}: {UserID: 1, GroupID: 1},
+		{1, 2}: {UserID: 1, GroupID: 2},
+	}}
+	achievementRepo := newMockAchievementRepo()
+	predictionRepo := &mockPredictionRepoForAchievements{activeGroups: 2}
+	tracker := NewAchievementTracker(achievementRepo, ratingRepo, predictionRepo, &mockEventRepoForCreator{}, nil, &mockLoggerForAchievements{})
+
+	hasGlobeTrotter := func(achievements []*Achievement) bool {
+		for _, achievement := range achievements {
+			if achievement.Code == AchievementGlobeTrotter {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Below the threshold of 3 groups
+	achievements, err := tracker.CheckAndAwardAchievements(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("Error checking achievements: %v", err)
+	}
+	if hasGlobeTrotter(achievements) {
+		t.Error("expected no Globe-Trotter with predictions in 2 groups")
+	}
+
+	// At the threshold it is awarded in the checked group
+	predictionRepo.activeGroups = 3
+	achievements, err = tracker.CheckAndAwardAchievements(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("Error checking achievements: %v", err)
+	}
+	if !hasGlobeTrotter(achievements) {
+		t.Fatal("expected Globe-Trotter with predictions in 3 groups")
+	}
+	if exists, _ := achievementRepo.CheckAchievementExists(ctx, 1, 2, AchievementGlobeTrotter); exists {
+		t.Error("expected Globe-Trotter not to be awarded in a group that was not checked")
+	}
+
+	// Checking again does not award it twice
+	achievements, err = tracker.CheckAndAwardAchievements(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("Error checking achievements: %v", err)
+	}
+	if hasGlobeTrotter(achievements) {
+		t.Error("expected Globe-Trotter to be awarded only once per group")
+	}
+
+	// Another qualifying group gets its own award
+	achievements, err = tracker.CheckAndAwardAchievements(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("Error checking achievements: %v", err)
+	}
+	if !hasGlobeTrotter(achievements) || achievements[0].GroupID != 2 {
+		t.Errorf("expected Globe-Trotter in group 2, got %v", achievements)
+	}
+}
+
+// TestCheckEventAchievements tests that every participant of a resolved event is checked once
+func TestCheckEventAchievements(t *testing.T) {
+	ctx := context.Background()
+	ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+		{1, 1}: {UserID: 1, GroupID: 1, Streak: SharpshooterStreak},
+		{2, 1}: {UserID: 2, GroupID: 1, Streak: 1},
+		{3, 1}: {UserID: 3, GroupID: 1, Streak: SharpshooterStreak},
+	}}
+	predictionRepo := &mockPredictionRepoForAchievements{predictions: []*Prediction{
+		{EventID: 7, UserID: 1, Option: 0},
+		{EventID: 7, UserID: 2, Option: 1},
+		{EventID: 7, UserID: 1, Option: 0},
+		{EventID: 7, UserID: 3, Option: 0},
+	}}
+	tracker := NewAchievementTracker(newMockAchievementRepo(), ratingRepo, predictionRepo, &mockEventRepoForCreator{}, nil, &mockLoggerForAchievements{})
+
+	// User 3 voted but is not a participant of the restricted event
+	event := &Event{ID: 7, GroupID: 1, Participants: []int64{1, 2}}
+
+	awarded, err := tracker.CheckEventAchievements(ctx, event)
+	if err != nil {
+		t.Fatalf("Error checking event achievements: %v", err)
+	}
+	if len(awarded) != 1 || awarded[0].UserID != 1 {
+		t.Fatalf("expected achievements for user 1 only, got %+v", awarded)
+	}
+	if len(awarded[0].Achievements) != 1 || awarded[0].Achievements[0].Code != AchievementSharpshooter {
+		t.Errorf("expected one Sharpshooter, got %+v", awarded[0].Achievements)
+	}
+
+	// Checking the same event again awards nothing
+	awarded, err = tracker.CheckEventAchievements(ctx, event)
+	if err != nil {
+		t.Fatalf("Error checking event achievements: %v", err)
+	}
+	if len(awarded) != 0 {
+		t.Errorf("expected no achievements on a second check, got %+v", awarded)
+	}
+}
+
+// TestDisabledAchievementsNotAwarded tests that achievements disabled in a group are never awarded or shown there
+func TestDisabledAchievementsNotAwarded(t *testing.T) {
+	ctx := context.Background()
+	ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+		{1, 1}: {UserID: 1, GroupID: 1, Streak: 3},
+		{1, 2}: {UserID: 1, GroupID: 2, Streak: 3},
+	}}
+	achievementRepo := newMockAchievementRepo()
+	groupRepo := &mockGroupRepoForRemover{groups: []*Group{
+		{ID: 1, DisabledAchievements: []AchievementCode{AchievementSharpshooter, AchievementWeeklyAnalyst, AchievementEventOrganizer}},
+		{ID: 2},
+	}}
+	tracker := NewAchievementTracker(achievementRepo, ratingRepo, &mockPredictionRepoForAchievements{}, &mockEventRepoForCreator{createdEventsCount: 1}, nil, &mockLoggerForAchievements{})
+	tracker.SetGroupRepository(groupRepo)
+
+	// Streak, weekly and creator achievements are all skipped in group 1
+	achievements, err := tracker.CheckAndAwardAchievements(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("Error checking achievements: %v", err)
+	}
+	if len(achievements) != 0 {
+		t.Errorf("expected no achievements in group 1, got %v", achievements)
+	}
+	if err := tracker.AwardWeeklyAnalyst(ctx, 1, 1); err != nil {
+		t.Fatalf("Error awarding weekly analyst: %v", err)
+	}
+	if achievements, err := tracker.CheckCreatorAchievements(ctx, 1, 1); err != nil || len(achievements) != 0 {
+		t.Errorf("expected no creator achievements in group 1, got %v (err %v)", achievements, err)
+	}
+	for _, code := range []AchievementCode{AchievementSharpshooter, AchievementWeeklyAnalyst, AchievementEventOrganizer} {
+		if exists, _ := achievementRepo.CheckAchievementExists(ctx, 1, 1, code); exists {
+			t.Errorf("expected %s not to be awarded in group 1", code)
+		}
+	}
+
+	// Other groups keep all achievements
+	achievements, err = tracker.CheckAndAwardAchievements(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("Error checking achievements: %v", err)
+	}
+	if len(achievements) != 1 || achievements[0].Code != AchievementSharpshooter {
+		t.Errorf("expected Sharpshooter in group 2, got %v", achievements)
+	}
+
+	// Achievements earned before being disabled are hidden, and shown again once re-enabled
+	if err := achievementRepo.SaveAchievement(ctx, &Achievement{UserID: 1, GroupID: 1, Code: AchievementSharpshooter, Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Error saving achievement: %v", err)
+	}
+	if shown, err := tracker.GetUserAchievements(ctx, 1, 1); err != nil || len(shown) != 0 {
+		t.Errorf("expected the disabled achievement to be hidden, got %v (err %v)", shown, err)
+	}
+	groupRepo.groups[0].DisabledAchievements = nil
+	if shown, err := tracker.GetUserAchievements(ctx, 1, 1); err != nil || len(shown) != 1 {
+		t.Errorf("expected the re-enabled achievement to be shown, got %v (err %v)", shown, err)
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// AuditRetentionRepository deletes expired rows from audit tables
+type AuditRetentionRepository interface {
+	PurgeOlderThan(ctx context.Context, table string, cutoff time.Time, chunkSize int) (int64, error)
+}
+
+// AuditPurger periodically deletes audit rows older than the retention window of their table.
+// Only audit logs are purged; events, predictions and ratings are never touched.
+type AuditPurger struct {
+	repo       AuditRetentionRepository
+	retentions map[string]time.Duration
+	chunkSize  int
+	interval   time.Duration
+	logger     Logger
+}
+
+// NewAuditPurger creates a new AuditPurger with a retention window per table.
+// Tables with a non-positive retention are kept forever.
+func NewAuditPurger(repo AuditRetentionRepository, retentions map[string]time.Duration, chunkSize int, logger Logger) *AuditPurger {
+	enabled := make(map[string]time.Duration, len(retentions))
+	for table, retention := range retentions {
+		if retention > 0 {
+			enabled[table] = retention
+		}
+	}
+
+	return &AuditPurger{
+		repo:       repo,
+		retentions: enabled,
+		chunkSize:  chunkSize,
+		interval:   1 * time.Hour,
+		logger:     logger,
+	}
+}
+
+// PurgeExpired deletes expired rows from every table with a retention window and returns
+// the number of rows deleted per table. A failing table doesn't stop the others.
+func (p *AuditPurger) PurgeExpired(ctx context.Context) map[string]int64 {
+	tables := make([]string, 0, len(p.retentions))
+	for table := range p.retentions {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	purged := make(map[string]int64, len(tables))
+	for _, table := range tables {
+		cutoff := time.Now().Add(-p.retentions[table])
+		count, err := p.repo.PurgeOlderThan(ctx, table, cutoff, p.chunkSize)
+		if err != nil {
+			p.logger.Error("failed to purge audit rows", "table", table, "cutoff", cutoff, "purged", count, "error", err)
+		}
+		purged[table] = count
+
+		if count > 0 {
+			p.logger.Info("audit rows purged", "table", table, "count", count, "cutoff", cutoff)
+		} else if err == nil {
+			p.logger.Debug("no audit rows to purge", "table", table, "cutoff", cutoff)
+		}
+	}
+
+	return purged
+}
+
+// StartScheduler runs the purge once on startup and then hourly until ctx is cancelled
+func (p *AuditPurger) StartScheduler(ctx context.Context) {
+	if len(p.retentions) == 0 {
+		p.logger.Info("audit purge disabled")
+		return
+	}
+
+	p.PurgeExpired(ctx)
+
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				p.logger.Info("audit purger stopped")
+				return
+			case <-ticker.C:
+				p.PurgeExpired(ctx)
+			}
+		}
+	}()
+
+	p.logger.Info("audit purger started", "tables", len(p.retentions))
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"math"
+)
+
+const (
+	// MinCalibrationBucketPredictions is the number of predictions below which a bucket's hit rate is flagged as unreliable
+	MinCalibrationBucketPredictions = 5
+	// CalibrationTolerance is the gap (in probability, 0-1) between forecast and outcome still considered well calibrated
+	CalibrationTolerance = 0.05
+)
+
+// CalibrationBucket summarizes a user's probability predictions that picked the same range
+type CalibrationBucket struct {
+	Option      int // Index of the probability range
+	Predictions int // Number of resolved predictions in this range
+	Hits        int // Predictions whose range contained the outcome
+	outcomeSum  float64
+}
+
+// Forecast returns the stated probability (0-1) of the bucket, taken as its range midpoint
+func (b *CalibrationBucket) Forecast() float64 {
+	return ProbabilityOptionForecast(b.Option)
+}
+
+// HitRate returns the share (0-1) of predictions whose range contained the outcome
+func (b *CalibrationBucket) HitRate() float64 {
+	if b.Predictions == 0 {
+		return 0
+	}
+	return float64(b.Hits) / float64(b.Predictions)
+}
+
+// AverageOutcome returns the mean realized probability (0-1) of the bucket's events.
+// Outcomes are approximated by the midpoint of the range they fell into.
+func (b *CalibrationBucket) AverageOutcome() float64 {
+	if b.Predictions == 0 {
+		return 0
+	}
+	return b.outcomeSum / float64(b.Predictions)
+}
+
+// LowConfidence reports whether the bucket has too few predictions to draw conclusions
+func (b *CalibrationBucket) LowConfidence() bool {
+	return b.Predictions < MinCalibrationBucketPredictions
+}
+
+// CalibrationReport compares a user's probability forecasts with the realized outcomes
+type CalibrationReport struct {
+	Buckets     []*CalibrationBucket // One bucket per probability range, in ascending order
+	Predictions int                  // Total number of resolved probability predictions
+}
+
+// Bias returns the mean gap (-1..1) between forecasts and realized outcomes.
+// Positive values mean the user overestimates probabilities, negative values mean underestimation.
+func (r *CalibrationReport) Bias() float64 {
+	if r.Predictions == 0 {
+		return 0
+	}
+	var sum float64
+	for _, bucket := range r.Buckets {
+		sum += bucket.Forecast()*float64(bucket.Predictions) - bucket.outcomeSum
+	}
+	return sum / float64(r.Predictions)
+}
+
+// IsCalibrated reports whether the overall bias is within CalibrationTolerance
+func (r *CalibrationReport) IsCalibrated() bool {
+	return math.Abs(r.Bias()) <= CalibrationTolerance
+}
+
+// CalibrationReport builds the calibration report of a user's resolved probability predictions in a group
+func (rc *RatingCalculator) CalibrationReport(ctx context.Context, userID int64, groupID int64) (*CalibrationReport, error) {
+	predictions, err := rc.predictionRepo.GetUserPredictions(ctx, userID)
+	if err != nil {
+		rc.logger.Error("failed to get user predictions for calibration", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	report := &CalibrationReport{Buckets: make([]*CalibrationBucket, ProbabilityOptionCount)}
+	for i := range report.Buckets {
+		report.Buckets[i] = &CalibrationBucket{Option: i}
+	}
+
+	for _, pred := range predictions {
+		event, err := rc.eventRepo.GetEvent(ctx, pred.EventID)
+		if err != nil {
+			rc.logger.Error("failed to get event for calibration", "event_id", pred.EventID, "error", err)
+			return nil, err
+		}
+
+		// Only resolved probability events of this group count (archived events are resolved too)
+		if event.GroupID != groupID || event.EventType != EventTypeProbability || event.CorrectOption == nil {
+			continue
+		}
+		if event.Status != EventStatusResolved && event.Status != EventStatusArchived {
+			continue
+		}
+		// Votes of non-participants of restricted events are not scored
+		if !event.IsParticipant(userID) {
+			continue
+		}
+		if pred.Option < 0 || pred.Option >= ProbabilityOptionCount {
+			continue
+		}
+
+		bucket := report.Buckets[pred.Option]
+		bucket.Predictions++
+		bucket.outcomeSum += ProbabilityOptionForecast(*event.CorrectOption)
+		if pred.Option == *event.CorrectOption {
+			bucket.Hits++
+		}
+		report.Predictions++
+	}
+
+	return report, nil
+}
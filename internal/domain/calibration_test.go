@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestRatingCalculator_CalibrationReport(t *testing.T) {
+	const userID, groupID = int64(7), int64(1)
+
+	var events []*Event
+	var predictions []*Prediction
+	addEvent := func(groupID int64, eventType EventType, status EventStatus, correct *int, option int) {
+		id := int64(len(events) + 1)
+		events = append(events, &Event{ID: id, GroupID: groupID, EventType: eventType, Status: status, CorrectOption: correct})
+		predictions = append(predictions, &Prediction{ID: id, EventID: id, UserID: userID, Option: option})
+	}
+	option := func(o int) *int { return &o }
+
+	// Picks 75-100% five times, the outcome lands there twice and in 25-50% three times
+	for i := 0; i < 2; i++ {
+		addEvent(groupID, EventTypeProbability, EventStatusResolved, option(3), 3)
+	}
+	for i := 0; i < 3; i++ {
+		addEvent(groupID, EventTypeProbability, EventStatusArchived, option(1), 3)
+	}
+	// One hit in 0-25%
+	addEvent(groupID, EventTypeProbability, EventStatusResolved, option(0), 0)
+
+	// Ignored: unresolved, other group, other event type
+	addEvent(groupID, EventTypeProbability, EventStatusActive, nil, 2)
+	addEvent(2, EventTypeProbability, EventStatusResolved, option(2), 2)
+	addEvent(groupID, EventTypeBinary, EventStatusResolved, option(0), 0)
+
+	rc := NewRatingCalculator(&MockRatingRepo{}, &MockPredictionRepoWithData{predictions: predictions},
+		&MockEventRepoWithEvents{events: events}, nil, &MockLogger{})
+
+	report, err := rc.CalibrationReport(context.Background(), userID, groupID)
+	if err != nil {
+		t.Fatalf("CalibrationReport failed: %v", err)
+	}
+
+	if report.Predictions != 6 {
+		t.Fatalf("Expected 6 resolved probability predictions, got %d", report.Predictions)
+	}
+	if len(report.Buckets) != ProbabilityOptionCount {
+		t.Fatalf("Expected %d buckets, got %d", ProbabilityOptionCount, len(report.Buckets))
+	}
+
+	high := report.Buckets[3]
+	if high.Predictions != 5 || high.Hits != 2 {
+		t.Errorf("Expected 5 predictions with 2 hits in 75-100%%, got %d/%d", high.Predictions, high.Hits)
+	}
+	if math.Abs(high.HitRate()-0.4) > 1e-9 {
+		t.Errorf("Expected hit rate 0.4, got %f", high.HitRate())
+	}
+	// (2 * 0.875 + 3 * 0.375) / 5
+	if math.Abs(high.AverageOutcome()-0.575) > 1e-9 {
+		t.Errorf("Expected average outcome 0.575, got %f", high.AverageOutcome())
+	}
+	if high.LowConfidence() {
+		t.Error("Expected 5 predictions to be enough for confidence")
+	}
+
+	low := report.Buckets[0]
+	if low.Predictions != 1 || low.Hits != 1 || !low.LowConfidence() {
+		t.Errorf("Expected a single low-confidence hit in 0-25%%, got %d/%d", low.Predictions, low.Hits)
+	}
+	if report.Buckets[1].Predictions != 0 || report.Buckets[2].Predictions != 0 {
+		t.Error("Expected the middle buckets to be empty")
+	}
+
+	// Each of the three 75-100% misses overestimates by 50 pp: (3 * 0.5) / 6
+	if math.Abs(report.Bias()-0.25) > 1e-9 {
+		t.Errorf("Expected bias 0.25, got %f", report.Bias())
+	}
+	if report.IsCalibrated() {
+		t.Error("Expected overestimating user not to be calibrated")
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+var (
+	ErrContentTooShort = errors.New("content is too short")
+	ErrContentTooLong  = errors.New("content is too long")
+	ErrContentRejected = errors.New("content is not allowed")
+)
+
+// ContentValidator validates user-supplied text (e.g. event questions) before it is accepted.
+// Implementations return nil for acceptable content, ErrContentTooShort/ErrContentTooLong
+// for length violations or ErrContentRejected (possibly wrapped) for disallowed content.
+type ContentValidator interface {
+	Validate(text string) error
+}
+
+// NoopContentValidator accepts any content
+type NoopContentValidator struct{}
+
+// NewNoopContentValidator creates a validator that accepts any content
+func NewNoopContentValidator() *NoopContentValidator {
+	return &NoopContentValidator{}
+}
+
+// Validate always returns nil
+func (v *NoopContentValidator) Validate(text string) error {
+	return nil
+}
+
+// LengthValidator checks that content length (in characters) is within the configured bounds.
+// A non-positive bound disables the corresponding check.
+type LengthValidator struct {
+	minLength int
+	maxLength int
+}
+
+// NewLengthValidator creates a new LengthValidator
+func NewLengthValidator(minLength, maxLength int) *LengthValidator {
+	return &LengthValidator{
+		minLength: minLength,
+		maxLength: maxLength,
+	}
+}
+
+// MinLength returns the minimum allowed length
+func (v *LengthValidator) MinLength() int {
+	return v.minLength
+}
+
+// MaxLength returns the maximum allowed length
+func (v *LengthValidator) MaxLength() int {
+	return v.maxLength
+}
+
+// Validate checks the length of text counted in runes
+func (v *LengthValidator) Validate(text string) error {
+	length := utf8.RuneCountInString(text)
+
+	if v.minLength > 0 && length < v.minLength {
+		return ErrContentTooShort
+	}
+
+	if v.maxLength > 0 && length > v.maxLength {
+		return ErrContentTooLong
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNoopContentValidator_AcceptsAnything(t *testing.T) {
+	v := NewNoopContentValidator()
+
+	for _, text := range []string{"", "a", strings.Repeat("x", 10000)} {
+		if err := v.Validate(text); err != nil {
+			t.Errorf("expected no error for %q, got %v", text, err)
+		}
+	}
+}
+
+func TestLengthValidator(t *testing.T) {
+	testCases := []struct {
+		name     string
+		min      int
+		max      int
+		text     string
+		expected error
+	}{
+		{"within bounds", 3, 10, "hello", nil},
+		{"exactly min", 5, 10, "hello", nil},
+		{"exactly max", 1, 5, "hello", nil},
+		{"too short", 6, 10, "hello", ErrContentTooShort},
+		{"too long", 1, 4, "hello", ErrContentTooLong},
+		{"no limits", 0, 0, strings.Repeat("x", 1000), nil},
+		{"no max", 2, 0, strings.Repeat("x", 1000), nil},
+		{"cyrillic counted in characters", 1, 6, "привет", nil},
+		{"cyrillic too long", 1, 5, "привет", ErrContentTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewLengthValidator(tc.min, tc.max)
+			if err := v.Validate(tc.text); err != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+)
+
+func TestIsMinorityCorrect(t *testing.T) {
+	tests := []struct {
+		name    string
+		shares  map[int]float64
+		correct int
+		want    bool
+	}{
+		{"minority pick", map[int]float64{0: 0.25, 1: 0.75}, 0, true},
+		{"majority pick", map[int]float64{0: 0.25, 1: 0.75}, 1, false},
+		{"at the threshold", map[int]float64{0: MinorityThreshold, 1: 1 - MinorityThreshold}, 0, false},
+		{"nobody picked it", map[int]float64{1: 1}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMinorityCorrect(tt.shares, tt.correct); got != tt.want {
+				t.Errorf("IsMinorityCorrect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingCalculator_TagsMinorityCorrect(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		correct int
+		want    bool
+	}{{0, true}, {1, false}} {
+		event := &Event{ID: 1, GroupID: 1, EventType: EventTypeBinary, Status: EventStatusResolved, CreatedAt: created, Deadline: created.Add(24 * time.Hour)}
+		predictions := []*Prediction{
+			{EventID: 1, UserID: 10, Option: 0, Timestamp: created},
+			{EventID: 1, UserID: 20, Option: 1, Timestamp: created},
+			{EventID: 1, UserID: 30, Option: 1, Timestamp: created},
+			{EventID: 1, UserID: 40, Option: 1, Timestamp: created},
+		}
+		eventRepo := &MockEventRepoWithEvents{events: []*Event{event}}
+		ratingRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+		rc := NewRatingCalculator(ratingRepo, &MockPredictionRepoWithData{predictions: predictions}, eventRepo, nil, &MockLogger{})
+
+		if err := rc.CalculateScores(ctx, event.ID, tt.correct); err != nil {
+			t.Fatalf("CalculateScores failed: %v", err)
+		}
+		if event.MinorityCorrect != tt.want {
+			t.Errorf("correct option %d: expected MinorityCorrect %v, got %v", tt.correct, tt.want, event.MinorityCorrect)
+		}
+	}
+}
+
+func TestPublishEventResults_ContrarianHighlight(t *testing.T) {
+	ctx := context.Background()
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	event := &Event{ID: 1, GroupID: 1, Question: "Will it rain?", Options: []string{"Yes", "No"}, MinorityCorrect: true}
+	var predictions []*Prediction
+	for i := 0; i < contrarianHighlightLimit+2; i++ {
+		predictions = append(predictions, &Prediction{EventID: 1, UserID: int64(100 + i), Option: 0})
+	}
+	for i := 0; i < 30; i++ {
+		predictions = append(predictions, &Prediction{EventID: 1, UserID: int64(200 + i), Option: 1})
+	}
+	ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+		{100, 1}: {UserID: 100, GroupID: 1, Username: "alice"},
+	}}
+
+	mockBot := &MockBotForExpiredNotification{}
+	ns := NewNotificationService(mockBot, &MockEventRepoWithData{event: event}, &MockPredictionRepoWithData{predictions: predictions},
+		ratingRepo, &MockReminderRepo{}, &MockLogger{}, localizer)
+
+	if err := ns.PublishEventResults(ctx, event.ID, 0, 12345, &MockForumTopicRepo{}); err != nil {
+		t.Fatalf("PublishEventResults failed: %v", err)
+	}
+	if len(mockBot.sentMessages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(mockBot.sentMessages))
+	}
+
+	text := mockBot.sentMessages[0].Text
+	for _, want := range []string{"Contrarian win! Only 12 of 42", "alice, ", "and 2 more"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected results to contain %q, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "User id200") {
+		t.Errorf("expected only members with the correct answer to be named, got:\n%s", text)
+	}
+
+	// Events that weren't contrarian wins have no highlight
+	event.MinorityCorrect = false
+	mockBot.sentMessages = nil
+	if err := ns.PublishEventResults(ctx, event.ID, 0, 12345, &MockForumTopicRepo{}); err != nil {
+		t.Fatalf("PublishEventResults failed: %v", err)
+	}
+	if strings.Contains(mockBot.sentMessages[0].Text, "Contrarian") {
+		t.Errorf("expected no contrarian highlight, got:\n%s", mockBot.sentMessages[0].Text)
+	}
+}
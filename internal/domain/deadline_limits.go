@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrDeadlineTooSoon = errors.New("deadline is too soon after creation")
+	ErrDeadlineTooFar  = errors.New("deadline is too far after creation")
+)
+
+// DeadlineLimits bounds the time between the creation of an event and its deadline.
+// A zero bound is not enforced.
+type DeadlineLimits struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// DefaultDeadlineLimits allow deadlines from 10 minutes to two years ahead
+var DefaultDeadlineLimits = DeadlineLimits{
+	Min: 10 * time.Minute,
+	Max: 2 * 365 * 24 * time.Hour,
+}
+
+// Check returns ErrDeadlineTooSoon or ErrDeadlineTooFar when deadline is out of range of from
+func (l DeadlineLimits) Check(from, deadline time.Time) error {
+	offset := deadline.Sub(from)
+	if l.Min > 0 && offset < l.Min {
+		return ErrDeadlineTooSoon
+	}
+	if l.Max > 0 && offset > l.Max {
+		return ErrDeadlineTooFar
+	}
+	return nil
+}
+
+// eventDeadlineLimits are the limits enforced by Event.Validate
+var eventDeadlineLimits atomic.Pointer[DeadlineLimits]
+
+// SetEventDeadlineLimits sets the limits enforced by Event.Validate (DefaultDeadlineLimits until called)
+func SetEventDeadlineLimits(limits DeadlineLimits) {
+	eventDeadlineLimits.Store(&limits)
+}
+
+// EventDeadlineLimits returns the limits enforced by Event.Validate
+func EventDeadlineLimits() DeadlineLimits {
+	if limits := eventDeadlineLimits.Load(); limits != nil {
+		return *limits
+	}
+	return DefaultDeadlineLimits
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeadlineLimitsCheck(t *testing.T) {
+	limits := DeadlineLimits{Min: time.Hour, Max: 30 * 24 * time.Hour}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		deadline time.Time
+		expected error
+	}{
+		{"just under the minimum", now.Add(time.Hour - time.Second), ErrDeadlineTooSoon},
+		{"at the minimum", now.Add(time.Hour), nil},
+		{"within the range", now.Add(7 * 24 * time.Hour), nil},
+		{"at the maximum", now.Add(30 * 24 * time.Hour), nil},
+		{"just over the maximum", now.Add(30*24*time.Hour + time.Second), ErrDeadlineTooFar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := limits.Check(now, tt.deadline); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	if err := (DeadlineLimits{}).Check(now, now.AddDate(100, 0, 0)); err != nil {
+		t.Errorf("expected zero limits not to be enforced, got %v", err)
+	}
+}
+
+func TestEventValidateDeadlineLimits(t *testing.T) {
+	defer SetEventDeadlineLimits(EventDeadlineLimits())
+	SetEventDeadlineLimits(DeadlineLimits{Min: time.Hour, Max: 24 * time.Hour})
+
+	createdAt := time.Now()
+	newEvent := func(deadline time.Time) *Event {
+		return &Event{
+			GroupID:   1,
+			Question:  "Will it rain?",
+			Options:   []string{"Yes", "No"},
+			EventType: EventTypeBinary,
+			CreatedAt: createdAt,
+			Deadline:  deadline,
+			CreatedBy: 1,
+		}
+	}
+
+	if err := newEvent(createdAt.Add(59 * time.Minute)).Validate(); !errors.Is(err, ErrDeadlineTooSoon) {
+		t.Errorf("expected ErrDeadlineTooSoon, got %v", err)
+	}
+	if err := newEvent(createdAt.Add(25 * time.Hour)).Validate(); !errors.Is(err, ErrDeadlineTooFar) {
+		t.Errorf("expected ErrDeadlineTooFar, got %v", err)
+	}
+	if err := newEvent(createdAt.Add(12 * time.Hour)).Validate(); err != nil {
+		t.Errorf("expected a valid event, got %v", err)
+	}
+}
@@ -1,10 +1,28 @@
 package domain
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 )
 
+const (
+	// UsernameFetchAttempts is the number of attempts to get the bot username at startup
+	UsernameFetchAttempts = 5
+	// UsernameFetchBackoff is the wait after the first failed attempt, doubled after every further one
+	UsernameFetchBackoff = time.Second
+	// UsernameRefreshInterval is the interval of the background retries when the startup attempts failed
+	UsernameRefreshInterval = time.Minute
+)
+
+// ErrBotUsernameUnknown is returned by GenerateGroupInviteLink until the bot username is known
+var ErrBotUsernameUnknown = NewError(ErrorKindInternal, "bot username is not known yet")
+
+// UsernameFetcher returns the bot username, e.g. from the Telegram getMe method
+type UsernameFetcher func(ctx context.Context) (string, error)
+
 // IDEncoder defines the interface for encoding and decoding IDs
 type IDEncoder interface {
 	Encode(num int64) (string, error)
@@ -13,11 +31,13 @@ type IDEncoder interface {
 
 // DeepLinkService handles generation and parsing of Telegram deep-link URLs for group invitations
 type DeepLinkService struct {
+	mu          sync.RWMutex
 	botUsername string
 	encoder     IDEncoder
 }
 
-// NewDeepLinkService creates a new DeepLinkService with the specified bot username and ID encoder
+// NewDeepLinkService creates a new DeepLinkService with the specified bot username and ID encoder.
+// The username may be empty when it is not known yet, see SetUsername.
 func NewDeepLinkService(botUsername string, encoder IDEncoder) *DeepLinkService {
 	return &DeepLinkService{
 		botUsername: botUsername,
@@ -27,12 +47,84 @@ func NewDeepLinkService(botUsername string, encoder IDEncoder) *DeepLinkService
 
 // GenerateGroupInviteLink generates a Telegram deep-link URL for joining a specific group
 // Format: https://t.me/{bot_username}?start=group_{encodedGroupID}
+// It returns ErrBotUsernameUnknown while the bot username is not known.
 func (s *DeepLinkService) GenerateGroupInviteLink(groupID int64) (string, error) {
+	username := s.Username()
+	if username == "" {
+		return "", ErrBotUsernameUnknown
+	}
+
 	encodedID, err := s.encoder.Encode(groupID)
 	if err != nil {
 		return "", fmt.Errorf("failed to encode group ID: %w", err)
 	}
-	return fmt.Sprintf("https://t.me/%s?start=group_%s", s.botUsername, encodedID), nil
+	return fmt.Sprintf("https://t.me/%s?start=group_%s", username, encodedID), nil
+}
+
+// Username returns the bot username, empty while it is not known
+func (s *DeepLinkService) Username() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.botUsername
+}
+
+// SetUsername sets the bot username used in the generated links
+func (s *DeepLinkService) SetUsername(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.botUsername = username
+}
+
+// FetchUsername gets the bot username with fetch and sets it. It makes up to attempts attempts,
+// waiting backoff after the first failure and twice as long after every further one, and returns
+// the last error when all of them fail.
+func (s *DeepLinkService) FetchUsername(ctx context.Context, fetch UsernameFetcher, attempts int, backoff time.Duration) error {
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		var username string
+		username, err = fetch(ctx)
+		if err == nil && username == "" {
+			err = fmt.Errorf("empty bot username")
+		}
+		if err == nil {
+			s.SetUsername(username)
+			return nil
+		}
+	}
+	return err
+}
+
+// StartUsernameRefresh retries fetch every interval in the background until the bot username is
+// known or ctx is done. It is used when the username couldn't be fetched at startup, so invite
+// links become available once Telegram is reachable again.
+func (s *DeepLinkService) StartUsernameRefresh(ctx context.Context, fetch UsernameFetcher, interval time.Duration, logger Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for s.Username() == "" {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := s.FetchUsername(ctx, fetch, 1, 0); err != nil {
+				logger.Warn("failed to get bot username", "error", err)
+				continue
+			}
+			logger.Info("bot username retrieved, invite links are available", "username", s.Username())
+		}
+	}()
 }
 
 // ParseGroupIDFromStart parses a group ID from a /start command parameter
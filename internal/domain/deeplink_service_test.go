@@ -1,8 +1,12 @@
 package domain
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
@@ -360,3 +364,106 @@ func TestURLFormatValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestDeepLinkService_UnknownUsername(t *testing.T) {
+	service := NewDeepLinkService("", &mockEncoder{})
+
+	if _, err := service.GenerateGroupInviteLink(1); !errors.Is(err, ErrBotUsernameUnknown) {
+		t.Fatalf("expected ErrBotUsernameUnknown, got %v", err)
+	}
+
+	service.SetUsername("test_bot")
+
+	link, err := service.GenerateGroupInviteLink(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link != "https://t.me/test_bot?start=group_1" {
+		t.Errorf("unexpected link %q", link)
+	}
+}
+
+func TestDeepLinkService_FetchUsername(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		service := NewDeepLinkService("", &mockEncoder{})
+		calls := 0
+		fetch := func(ctx context.Context) (string, error) {
+			calls++
+			if calls < 3 {
+				return "", errors.New("network is unreachable")
+			}
+			return "test_bot", nil
+		}
+
+		if err := service.FetchUsername(ctx, fetch, 5, time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 calls, got %d", calls)
+		}
+		if service.Username() != "test_bot" {
+			t.Errorf("expected username test_bot, got %q", service.Username())
+		}
+	})
+
+	t.Run("returns the last error when all attempts fail", func(t *testing.T) {
+		service := NewDeepLinkService("", &mockEncoder{})
+		calls := 0
+		fetchErr := errors.New("network is unreachable")
+		fetch := func(ctx context.Context) (string, error) {
+			calls++
+			return "", fetchErr
+		}
+
+		if err := service.FetchUsername(ctx, fetch, 3, time.Millisecond); !errors.Is(err, fetchErr) {
+			t.Fatalf("expected fetch error, got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 calls, got %d", calls)
+		}
+		if service.Username() != "" {
+			t.Errorf("expected no username, got %q", service.Username())
+		}
+	})
+
+	t.Run("empty username is a failure", func(t *testing.T) {
+		service := NewDeepLinkService("", &mockEncoder{})
+		fetch := func(ctx context.Context) (string, error) { return "", nil }
+
+		if err := service.FetchUsername(ctx, fetch, 2, time.Millisecond); err == nil {
+			t.Fatal("expected an error for an empty username")
+		}
+	})
+}
+
+func TestDeepLinkService_StartUsernameRefresh(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := NewDeepLinkService("", &mockEncoder{})
+	var calls atomic.Int32
+	fetch := func(ctx context.Context) (string, error) {
+		if calls.Add(1) < 3 {
+			return "", errors.New("network is unreachable")
+		}
+		return "test_bot", nil
+	}
+
+	service.StartUsernameRefresh(ctx, fetch, time.Millisecond, &MockLogger{})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for service.Username() == "" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if service.Username() != "test_bot" {
+		t.Fatalf("expected username test_bot after refresh, got %q", service.Username())
+	}
+
+	// The refresh stops once the username is known
+	time.Sleep(10 * time.Millisecond)
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/go-telegram/bot"
+)
+
+// ErrUserUnreachable is returned instead of sending a direct message to a user known to have
+// no private chat with the bot
+var ErrUserUnreachable = errors.New("user can't receive direct messages")
+
+// unreachableErrorMarkers are substrings of Telegram error descriptions returned when the bot
+// can't message a user in private
+var unreachableErrorMarkers = []string{
+	"can't initiate conversation",
+	"bot was blocked by the user",
+}
+
+// IsUnreachableError reports whether err means the user never started the bot or blocked it
+func IsUnreachableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, bot.ErrorForbidden) {
+		return true
+	}
+
+	description := strings.ToLower(err.Error())
+	for _, marker := range unreachableErrorMarkers {
+		if strings.Contains(description, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserReachabilityRepository stores which users can't receive direct messages
+type UserReachabilityRepository interface {
+	IsUserUnreachable(ctx context.Context, userID int64) (bool, error)
+	// MarkUserUnreachable flags the user and reports whether the flag was newly set
+	MarkUserUnreachable(ctx context.Context, userID int64, at time.Time) (bool, error)
+	// ClearUserUnreachable removes the flag and reports whether it was set
+	ClearUserUnreachable(ctx context.Context, userID int64) (bool, error)
+}
+
+// SetReachabilityRepository enables tracking of users who can't receive direct messages.
+// Once a send fails because the user never started the bot, further direct messages to them
+// are skipped until they write to the bot in private.
+func (ns *NotificationService) SetReachabilityRepository(repo UserReachabilityRepository) {
+	ns.reachabilityRepo = repo
+}
+
+// MarkUserReachable clears the unreachable flag of a user who wrote to the bot in private,
+// so direct messages to them resume
+func (ns *NotificationService) MarkUserReachable(ctx context.Context, userID int64) {
+	if ns.reachabilityRepo == nil {
+		return
+	}
+
+	cleared, err := ns.reachabilityRepo.ClearUserUnreachable(ctx, userID)
+	if err != nil {
+		ns.logger.Error("failed to clear unreachable flag", "user_id", userID, "error", err)
+		return
+	}
+	if cleared {
+		ns.logger.Info("user reachable again", "user_id", userID)
+	}
+}
+
+// sendDirect sends a direct message to a user with send, unless the user is known to be
+// unreachable. When the send fails because the user never started the bot, the user is flagged
+// and, the first time, asked in the group groupID (zero for none) to start the bot.
+func (ns *NotificationService) sendDirect(ctx context.Context, userID int64, groupID int64, send func() error) error {
+	if ns.reachabilityRepo == nil {
+		return send()
+	}
+
+	unreachable, err := ns.reachabilityRepo.IsUserUnreachable(ctx, userID)
+	if err != nil {
+		ns.logger.Error("failed to check unreachable flag", "user_id", userID, "error", err)
+	} else if unreachable {
+		return ErrUserUnreachable
+	}
+
+	err = send()
+	if !IsUnreachableError(err) {
+		return err
+	}
+
+	marked, markErr := ns.reachabilityRepo.MarkUserUnreachable(ctx, userID, time.Now())
+	if markErr != nil {
+		ns.logger.Error("failed to mark user unreachable", "user_id", userID, "error", markErr)
+		return err
+	}
+	if marked {
+		ns.logger.Info("user unreachable by direct message", "user_id", userID)
+		ns.promptToStartBot(ctx, userID, groupID)
+	}
+	return err
+}
+
+// promptToStartBot asks an unreachable user in their group to start the bot in private
+func (ns *NotificationService) promptToStartBot(ctx context.Context, userID int64, groupID int64) {
+	if groupID == 0 || ns.groupRepo == nil {
+		return
+	}
+
+	group, err := ns.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		ns.logger.Warn("failed to get group for start prompt", "group_id", groupID, "error", err)
+		return
+	}
+
+	name := ns.localizer.MustLocalizeWithTemplate(locale.UserIDFormat, fmt.Sprintf("%d", userID))
+	if ns.ratingRepo != nil {
+		if rating, err := ns.ratingRepo.GetRating(ctx, userID, groupID); err == nil && rating != nil && rating.Username != "" {
+			name = "@" + strings.TrimPrefix(rating.Username, "@")
+		}
+	}
+
+	_, err = ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: group.TelegramChatID,
+		Text:   ns.localizer.MustLocalizeWithTemplate(locale.NotificationStartBotPrompt, name),
+	})
+	if err != nil {
+		ns.logger.Warn("failed to send start prompt", "user_id", userID, "group_id", groupID, "error", err)
+	}
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// mockReachabilityRepo keeps unreachable users in memory
+type mockReachabilityRepo struct {
+	unreachable map[int64]bool
+}
+
+func (m *mockReachabilityRepo) IsUserUnreachable(ctx context.Context, userID int64) (bool, error) {
+	return m.unreachable[userID], nil
+}
+
+func (m *mockReachabilityRepo) MarkUserUnreachable(ctx context.Context, userID int64, at time.Time) (bool, error) {
+	if m.unreachable[userID] {
+		return false, nil
+	}
+	m.unreachable[userID] = true
+	return true, nil
+}
+
+func (m *mockReachabilityRepo) ClearUserUnreachable(ctx context.Context, userID int64) (bool, error) {
+	if !m.unreachable[userID] {
+		return false, nil
+	}
+	delete(m.unreachable, userID)
+	return true, nil
+}
+
+// privacyBot fails private messages to users who never started the bot, like Telegram does
+type privacyBot struct {
+	MockNotificationBot
+	started  map[int64]bool
+	attempts map[int64]int
+}
+
+func (m *privacyBot) SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error) {
+	chatID := params.ChatID.(int64)
+	if chatID > 0 {
+		m.attempts[chatID]++
+		if !m.started[chatID] {
+			return nil, fmt.Errorf("%w, Forbidden: bot can't initiate conversation with a user", bot.ErrorForbidden)
+		}
+	}
+	return m.MockNotificationBot.SendMessage(ctx, params)
+}
+
+func TestIsUnreachableError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{fmt.Errorf("%w, Forbidden: bot was blocked by the user", bot.ErrorForbidden), true},
+		{errors.New("Forbidden: bot can't initiate conversation with a user"), true},
+		{errors.New("Bad Request: chat not found"), false},
+	}
+	for _, tt := range tests {
+		if got := IsUnreachableError(tt.err); got != tt.want {
+			t.Errorf("IsUnreachableError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestSendDirect_UnreachableFlagLifecycle(t *testing.T) {
+	ctx := context.Background()
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	const userID, chatID = int64(42), int64(-100500)
+	group := &Group{ID: 1, TelegramChatID: chatID, Name: "Friends"}
+	event := &Event{ID: 7, GroupID: group.ID, Question: "Rain tomorrow?", Deadline: time.Now().Add(48 * time.Hour)}
+
+	mockBot := &privacyBot{started: map[int64]bool{}, attempts: map[int64]int{}}
+	repo := &mockReachabilityRepo{unreachable: map[int64]bool{}}
+	ns := NewNotificationService(mockBot, nil, nil, nil, nil, &mockLogger{}, localizer)
+	ns.SetGroupRepository(&mockGroupRepoForRemover{groups: []*Group{group}})
+	ns.SetReachabilityRepository(repo)
+
+	countPrompts := func() int {
+		prompts := 0
+		for _, msg := range mockBot.sentMessages {
+			if msg.ChatID == chatID && strings.Contains(msg.Text, "press Start") {
+				prompts++
+			}
+		}
+		return prompts
+	}
+
+	// The first failed send flags the user and asks them in the group to start the bot
+	if err := ns.SendNewEventSubscriberNotification(ctx, userID, event, group); !IsUnreachableError(err) {
+		t.Fatalf("expected unreachable error, got %v", err)
+	}
+	if !repo.unreachable[userID] {
+		t.Fatal("expected user to be flagged unreachable")
+	}
+	if countPrompts() != 1 {
+		t.Fatalf("expected one start prompt in the group, got %d", countPrompts())
+	}
+
+	// Flagged users are not messaged again and not prompted twice
+	if err := ns.SendNewEventSubscriberNotification(ctx, userID, event, group); !errors.Is(err, ErrUserUnreachable) {
+		t.Fatalf("expected ErrUserUnreachable, got %v", err)
+	}
+	if mockBot.attempts[userID] != 1 {
+		t.Errorf("expected no new send attempt to a flagged user, got %d attempts", mockBot.attempts[userID])
+	}
+	if countPrompts() != 1 {
+		t.Errorf("expected the start prompt to be sent once, got %d", countPrompts())
+	}
+
+	// Writing to the bot clears the flag and direct messages resume
+	mockBot.started[userID] = true
+	ns.MarkUserReachable(ctx, userID)
+	if repo.unreachable[userID] {
+		t.Fatal("expected the flag to be cleared")
+	}
+	if err := ns.SendNewEventSubscriberNotification(ctx, userID, event, group); err != nil {
+		t.Fatalf("expected the message to be sent, got %v", err)
+	}
+	if mockBot.attempts[userID] != 2 {
+		t.Errorf("expected a new send attempt after the flag was cleared, got %d attempts", mockBot.attempts[userID])
+	}
+}
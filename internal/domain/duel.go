@@ -0,0 +1,385 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DuelStatus represents the state of a duel
+type DuelStatus string
+
+const (
+	DuelStatusPending  DuelStatus = "pending"  // Waiting for the challenger's answer and the opponent's acceptance
+	DuelStatusActive   DuelStatus = "active"   // Accepted, waiting for the deadline and both outcome reports
+	DuelStatusDeclined DuelStatus = "declined" // Declined by the opponent
+	DuelStatusExpired  DuelStatus = "expired"  // Not accepted before the deadline or not reported in time
+	DuelStatusResolved DuelStatus = "resolved" // Both participants reported the same outcome
+	DuelStatusDisputed DuelStatus = "disputed" // Participants reported different outcomes, not counted
+)
+
+const (
+	// DefaultDuelDuration is the time until the deadline when the challenger doesn't set one
+	DefaultDuelDuration = 24 * time.Hour
+	// MinDuelDuration and MaxDuelDuration bound the time until the deadline
+	MinDuelDuration = 10 * time.Minute
+	MaxDuelDuration = 30 * 24 * time.Hour
+	// DuelReportWindow is how long after the deadline participants may report the outcome
+	DuelReportWindow = 7 * 24 * time.Hour
+	// MaxDuelQuestionLength limits the length of a duel question (in characters)
+	MaxDuelQuestionLength = 300
+	// duelCleanupInterval is how often overdue duels are expired
+	duelCleanupInterval = 10 * time.Minute
+)
+
+var (
+	ErrDuelNotFound        = NewError(ErrorKindNotFound, "duel not found")
+	ErrDuelSelf            = NewError(ErrorKindValidation, "cannot challenge yourself")
+	ErrDuelInvalidQuestion = NewError(ErrorKindValidation, "invalid duel question")
+	ErrDuelInvalidDuration = NewError(ErrorKindValidation, "invalid duel duration")
+	ErrDuelNotParticipant  = NewError(ErrorKindPermission, "user is not a participant of the duel")
+	ErrDuelClosed          = NewError(ErrorKindConflict, "duel is no longer open for this action")
+	ErrDuelTooEarly        = NewError(ErrorKindConflict, "duel deadline has not passed yet")
+)
+
+// Duel is a private yes/no prediction between two users, tracked separately from group ratings
+type Duel struct {
+	ID               int64
+	ChallengerID     int64
+	OpponentID       int64
+	Question         string
+	ChallengerPick   *bool // Challenger's answer (nil until picked)
+	OpponentPick     *bool // Opponent's answer (nil until accepted)
+	ChallengerReport *bool // Outcome reported by the challenger after the deadline
+	OpponentReport   *bool // Outcome reported by the opponent after the deadline
+	Outcome          *bool // Agreed outcome of a resolved duel
+	Deadline         time.Time
+	Status           DuelStatus
+	CreatedAt        time.Time
+}
+
+// IsParticipant reports whether the user is the challenger or the opponent
+func (d *Duel) IsParticipant(userID int64) bool {
+	return userID == d.ChallengerID || userID == d.OpponentID
+}
+
+// OtherParticipant returns the ID of the other side of the duel
+func (d *Duel) OtherParticipant(userID int64) int64 {
+	if userID == d.ChallengerID {
+		return d.OpponentID
+	}
+	return d.ChallengerID
+}
+
+// Winner returns the ID of the winner of a resolved duel, or 0 for a draw
+// (both answers right or both wrong) and for unresolved duels
+func (d *Duel) Winner() int64 {
+	if d.Status != DuelStatusResolved || d.Outcome == nil || d.ChallengerPick == nil || d.OpponentPick == nil {
+		return 0
+	}
+	challengerRight := *d.ChallengerPick == *d.Outcome
+	opponentRight := *d.OpponentPick == *d.Outcome
+	switch {
+	case challengerRight && !opponentRight:
+		return d.ChallengerID
+	case opponentRight && !challengerRight:
+		return d.OpponentID
+	default:
+		return 0
+	}
+}
+
+// DuelRecord is a user's personal duel history
+type DuelRecord struct {
+	UserID int64
+	Wins   int
+	Losses int
+	Draws  int
+}
+
+// DuelRepository interface for duel storage.
+// State changes are conditional so concurrent button presses can't overwrite each other;
+// they report whether the duel was updated.
+type DuelRepository interface {
+	CreateDuel(ctx context.Context, duel *Duel) error
+	GetDuel(ctx context.Context, duelID int64) (*Duel, error)
+	SetChallengerPick(ctx context.Context, duelID int64, pick bool) (bool, error)
+	AcceptDuel(ctx context.Context, duelID int64, pick bool) (bool, error)
+	DeclineDuel(ctx context.Context, duelID int64) (bool, error)
+	ReportDuelOutcome(ctx context.Context, duelID int64, userID int64, outcome bool) (bool, error)
+	// FinishDuel stores the final status and outcome of an open duel and, for resolved duels,
+	// updates the records of both participants in the same transaction
+	FinishDuel(ctx context.Context, duel *Duel) (bool, error)
+	// GetOverdueDuels returns pending duels with a deadline before pendingBefore and
+	// active duels with a deadline before activeBefore
+	GetOverdueDuels(ctx context.Context, pendingBefore, activeBefore time.Time) ([]*Duel, error)
+	GetDuelRecord(ctx context.Context, userID int64) (*DuelRecord, error)
+}
+
+// DuelNotifier tells participants that a duel expired
+type DuelNotifier interface {
+	SendDuelExpiredNotification(ctx context.Context, duel *Duel) error
+}
+
+// DuelService manages duels: challenges, answers, outcome reports and expiry
+type DuelService struct {
+	duelRepo DuelRepository
+	notifier DuelNotifier
+	logger   Logger
+	now      func() time.Time
+}
+
+// NewDuelService creates a new DuelService
+func NewDuelService(duelRepo DuelRepository, notifier DuelNotifier, logger Logger) *DuelService {
+	return &DuelService{
+		duelRepo: duelRepo,
+		notifier: notifier,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// ParseDuelDuration parses a duel duration such as "12h" or "3d" (units m, h and d)
+func ParseDuelDuration(text string) (time.Duration, error) {
+	duration, err := parseReminderOffset(strings.ToLower(text))
+	if err != nil {
+		return 0, ErrDuelInvalidDuration
+	}
+	return duration, nil
+}
+
+// Challenge creates a pending duel. The opponent is invited once the challenger picks an answer.
+func (s *DuelService) Challenge(ctx context.Context, challengerID, opponentID int64, question string, duration time.Duration) (*Duel, error) {
+	if challengerID == opponentID {
+		return nil, ErrDuelSelf
+	}
+	question = strings.TrimSpace(question)
+	if question == "" || utf8.RuneCountInString(question) > MaxDuelQuestionLength {
+		return nil, ErrDuelInvalidQuestion
+	}
+	if duration < MinDuelDuration || duration > MaxDuelDuration {
+		return nil, ErrDuelInvalidDuration
+	}
+
+	now := s.now()
+	duel := &Duel{
+		ChallengerID: challengerID,
+		OpponentID:   opponentID,
+		Question:     question,
+		Deadline:     now.Add(duration),
+		Status:       DuelStatusPending,
+		CreatedAt:    now,
+	}
+	if err := s.duelRepo.CreateDuel(ctx, duel); err != nil {
+		s.logger.Error("failed to create duel", "challenger_id", challengerID, "opponent_id", opponentID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("duel created", "duel_id", duel.ID, "challenger_id", challengerID, "opponent_id", opponentID)
+	return duel, nil
+}
+
+// Pick records the challenger's answer
+func (s *DuelService) Pick(ctx context.Context, duelID, userID int64, pick bool) (*Duel, error) {
+	duel, err := s.getOpenDuel(ctx, duelID, DuelStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if userID != duel.ChallengerID {
+		return nil, ErrDuelNotParticipant
+	}
+
+	return s.apply(ctx, duel, func() (bool, error) {
+		return s.duelRepo.SetChallengerPick(ctx, duelID, pick)
+	})
+}
+
+// Accept records the opponent's answer and starts the duel
+func (s *DuelService) Accept(ctx context.Context, duelID, userID int64, pick bool) (*Duel, error) {
+	duel, err := s.getOpenDuel(ctx, duelID, DuelStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if userID != duel.OpponentID {
+		return nil, ErrDuelNotParticipant
+	}
+	if duel.ChallengerPick == nil {
+		return nil, ErrDuelClosed
+	}
+
+	return s.apply(ctx, duel, func() (bool, error) {
+		return s.duelRepo.AcceptDuel(ctx, duelID, pick)
+	})
+}
+
+// Decline declines a pending duel on behalf of the opponent
+func (s *DuelService) Decline(ctx context.Context, duelID, userID int64) (*Duel, error) {
+	duel, err := s.getOpenDuel(ctx, duelID, DuelStatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if userID != duel.OpponentID {
+		return nil, ErrDuelNotParticipant
+	}
+
+	return s.apply(ctx, duel, func() (bool, error) {
+		return s.duelRepo.DeclineDuel(ctx, duelID)
+	})
+}
+
+// Report records the outcome reported by a participant after the deadline. Once both sides
+// reported, the duel is resolved when the reports match and disputed otherwise.
+func (s *DuelService) Report(ctx context.Context, duelID, userID int64, outcome bool) (*Duel, error) {
+	duel, err := s.duelRepo.GetDuel(ctx, duelID)
+	if err != nil {
+		s.logger.Error("failed to get duel", "duel_id", duelID, "error", err)
+		return nil, err
+	}
+	if duel == nil {
+		return nil, ErrDuelNotFound
+	}
+	if !duel.IsParticipant(userID) {
+		return nil, ErrDuelNotParticipant
+	}
+	if duel.Status != DuelStatusActive {
+		return nil, ErrDuelClosed
+	}
+	if s.now().Before(duel.Deadline) {
+		return nil, ErrDuelTooEarly
+	}
+
+	duel, err = s.apply(ctx, duel, func() (bool, error) {
+		return s.duelRepo.ReportDuelOutcome(ctx, duelID, userID, outcome)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if duel.ChallengerReport == nil || duel.OpponentReport == nil {
+		return duel, nil
+	}
+
+	if *duel.ChallengerReport == *duel.OpponentReport {
+		duel.Status = DuelStatusResolved
+		duel.Outcome = duel.ChallengerReport
+	} else {
+		duel.Status = DuelStatusDisputed
+	}
+
+	finished, err := s.duelRepo.FinishDuel(ctx, duel)
+	if err != nil {
+		s.logger.Error("failed to finish duel", "duel_id", duelID, "error", err)
+		return nil, err
+	}
+	if !finished {
+		// Finished concurrently (e.g. by the other report or expiry), return the stored state
+		return s.duelRepo.GetDuel(ctx, duelID)
+	}
+
+	s.logger.Info("duel finished", "duel_id", duelID, "status", duel.Status, "winner_id", duel.Winner())
+	return duel, nil
+}
+
+// Record returns the user's duel record
+func (s *DuelService) Record(ctx context.Context, userID int64) (*DuelRecord, error) {
+	return s.duelRepo.GetDuelRecord(ctx, userID)
+}
+
+// ExpireOverdueDuels expires duels not accepted before their deadline and duels whose outcome
+// was not reported by both participants within DuelReportWindow, and notifies the participants
+func (s *DuelService) ExpireOverdueDuels(ctx context.Context) (int, error) {
+	now := s.now()
+	duels, err := s.duelRepo.GetOverdueDuels(ctx, now, now.Add(-DuelReportWindow))
+	if err != nil {
+		s.logger.Error("failed to get overdue duels", "error", err)
+		return 0, err
+	}
+
+	expired := 0
+	for _, duel := range duels {
+		duel.Status = DuelStatusExpired
+		finished, err := s.duelRepo.FinishDuel(ctx, duel)
+		if err != nil {
+			s.logger.Error("failed to expire duel", "duel_id", duel.ID, "error", err)
+			continue
+		}
+		if !finished {
+			continue
+		}
+		expired++
+
+		// Duels the challenger never picked an answer for were never sent to the opponent
+		if duel.ChallengerPick == nil {
+			continue
+		}
+		if err := s.notifier.SendDuelExpiredNotification(ctx, duel); err != nil {
+			s.logger.Error("failed to send duel expired notification", "duel_id", duel.ID, "error", err)
+		}
+	}
+
+	if expired > 0 {
+		s.logger.Info("duels expired", "count", expired)
+	}
+	return expired, nil
+}
+
+// StartScheduler expires overdue duels once on startup and then periodically until ctx is cancelled
+func (s *DuelService) StartScheduler(ctx context.Context) {
+	_, _ = s.ExpireOverdueDuels(ctx)
+
+	go func() {
+		ticker := time.NewTicker(duelCleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("duel scheduler stopped")
+				return
+			case <-ticker.C:
+				_, _ = s.ExpireOverdueDuels(ctx)
+			}
+		}
+	}()
+
+	s.logger.Info("duel scheduler started")
+}
+
+// getOpenDuel loads a duel that must be in the given status and before its deadline
+func (s *DuelService) getOpenDuel(ctx context.Context, duelID int64, status DuelStatus) (*Duel, error) {
+	duel, err := s.duelRepo.GetDuel(ctx, duelID)
+	if err != nil {
+		s.logger.Error("failed to get duel", "duel_id", duelID, "error", err)
+		return nil, err
+	}
+	if duel == nil {
+		return nil, ErrDuelNotFound
+	}
+	if duel.Status != status || !s.now().Before(duel.Deadline) {
+		return nil, ErrDuelClosed
+	}
+	return duel, nil
+}
+
+// apply runs a conditional update and returns the updated duel.
+// ErrDuelClosed is returned when the duel changed concurrently and the update did not apply.
+func (s *DuelService) apply(ctx context.Context, duel *Duel, update func() (bool, error)) (*Duel, error) {
+	updated, err := update()
+	if err != nil {
+		s.logger.Error("failed to update duel", "duel_id", duel.ID, "error", err)
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrDuelClosed
+	}
+
+	updatedDuel, err := s.duelRepo.GetDuel(ctx, duel.ID)
+	if err != nil {
+		s.logger.Error("failed to get duel", "duel_id", duel.ID, "error", err)
+		return nil, err
+	}
+	if updatedDuel == nil {
+		return nil, ErrDuelNotFound
+	}
+	return updatedDuel, nil
+}
@@ -0,0 +1,343 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockDuelRepo keeps duels and records in memory with the same conditional updates as storage
+type mockDuelRepo struct {
+	duels   map[int64]*Duel
+	records map[int64]*DuelRecord
+}
+
+func newMockDuelRepo() *mockDuelRepo {
+	return &mockDuelRepo{duels: make(map[int64]*Duel), records: make(map[int64]*DuelRecord)}
+}
+
+func (m *mockDuelRepo) CreateDuel(ctx context.Context, duel *Duel) error {
+	duel.ID = int64(len(m.duels) + 1)
+	stored := *duel
+	m.duels[duel.ID] = &stored
+	return nil
+}
+
+func (m *mockDuelRepo) GetDuel(ctx context.Context, duelID int64) (*Duel, error) {
+	duel, ok := m.duels[duelID]
+	if !ok {
+		return nil, nil
+	}
+	result := *duel
+	return &result, nil
+}
+
+func (m *mockDuelRepo) SetChallengerPick(ctx context.Context, duelID int64, pick bool) (bool, error) {
+	duel := m.duels[duelID]
+	if duel == nil || duel.Status != DuelStatusPending || duel.ChallengerPick != nil {
+		return false, nil
+	}
+	duel.ChallengerPick = &pick
+	return true, nil
+}
+
+func (m *mockDuelRepo) AcceptDuel(ctx context.Context, duelID int64, pick bool) (bool, error) {
+	duel := m.duels[duelID]
+	if duel == nil || duel.Status != DuelStatusPending || duel.ChallengerPick == nil {
+		return false, nil
+	}
+	duel.OpponentPick = &pick
+	duel.Status = DuelStatusActive
+	return true, nil
+}
+
+func (m *mockDuelRepo) DeclineDuel(ctx context.Context, duelID int64) (bool, error) {
+	duel := m.duels[duelID]
+	if duel == nil || duel.Status != DuelStatusPending {
+		return false, nil
+	}
+	duel.Status = DuelStatusDeclined
+	return true, nil
+}
+
+func (m *mockDuelRepo) ReportDuelOutcome(ctx context.Context, duelID int64, userID int64, outcome bool) (bool, error) {
+	duel := m.duels[duelID]
+	if duel == nil || duel.Status != DuelStatusActive {
+		return false, nil
+	}
+	switch {
+	case userID == duel.ChallengerID && duel.ChallengerReport == nil:
+		duel.ChallengerReport = &outcome
+	case userID == duel.OpponentID && duel.OpponentReport == nil:
+		duel.OpponentReport = &outcome
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (m *mockDuelRepo) FinishDuel(ctx context.Context, duel *Duel) (bool, error) {
+	stored := m.duels[duel.ID]
+	if stored == nil || (stored.Status != DuelStatusPending && stored.Status != DuelStatusActive) {
+		return false, nil
+	}
+	stored.Status = duel.Status
+	stored.Outcome = duel.Outcome
+
+	if duel.Status == DuelStatusResolved {
+		winnerID := duel.Winner()
+		for _, userID := range []int64{duel.ChallengerID, duel.OpponentID} {
+			record, _ := m.GetDuelRecord(ctx, userID)
+			switch winnerID {
+			case 0:
+				record.Draws++
+			case userID:
+				record.Wins++
+			default:
+				record.Losses++
+			}
+			m.records[userID] = record
+		}
+	}
+	return true, nil
+}
+
+func (m *mockDuelRepo) GetOverdueDuels(ctx context.Context, pendingBefore, activeBefore time.Time) ([]*Duel, error) {
+	var result []*Duel
+	for _, duel := range m.duels {
+		if (duel.Status == DuelStatusPending && duel.Deadline.Before(pendingBefore)) ||
+			(duel.Status == DuelStatusActive && duel.Deadline.Before(activeBefore)) {
+			copied := *duel
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockDuelRepo) GetDuelRecord(ctx context.Context, userID int64) (*DuelRecord, error) {
+	if record, ok := m.records[userID]; ok {
+		copied := *record
+		return &copied, nil
+	}
+	return &DuelRecord{UserID: userID}, nil
+}
+
+// mockDuelNotifier records expired duels
+type mockDuelNotifier struct {
+	expired []*Duel
+}
+
+func (m *mockDuelNotifier) SendDuelExpiredNotification(ctx context.Context, duel *Duel) error {
+	m.expired = append(m.expired, duel)
+	return nil
+}
+
+// startDuel creates a duel between users 1 and 2 and brings it to the active state
+func startDuel(t *testing.T, service *DuelService, challengerPick, opponentPick bool) *Duel {
+	t.Helper()
+	ctx := context.Background()
+
+	duel, err := service.Challenge(ctx, 1, 2, "Will it rain?", time.Hour)
+	if err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	if _, err := service.Pick(ctx, duel.ID, 1, challengerPick); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	duel, err = service.Accept(ctx, duel.ID, 2, opponentPick)
+	if err != nil {
+		t.Fatalf("Accept failed: %v", err)
+	}
+	if duel.Status != DuelStatusActive {
+		t.Fatalf("expected active duel, got %s", duel.Status)
+	}
+	return duel
+}
+
+func TestDuelService_Challenge(t *testing.T) {
+	ctx := context.Background()
+	service := NewDuelService(newMockDuelRepo(), &mockDuelNotifier{}, &mockLogger{})
+
+	if _, err := service.Challenge(ctx, 1, 1, "question", time.Hour); !errors.Is(err, ErrDuelSelf) {
+		t.Errorf("expected ErrDuelSelf, got %v", err)
+	}
+	if _, err := service.Challenge(ctx, 1, 2, "  ", time.Hour); !errors.Is(err, ErrDuelInvalidQuestion) {
+		t.Errorf("expected ErrDuelInvalidQuestion for an empty question, got %v", err)
+	}
+	if _, err := service.Challenge(ctx, 1, 2, strings.Repeat("я", MaxDuelQuestionLength+1), time.Hour); !errors.Is(err, ErrDuelInvalidQuestion) {
+		t.Errorf("expected ErrDuelInvalidQuestion for a long question, got %v", err)
+	}
+	if _, err := service.Challenge(ctx, 1, 2, "question", time.Minute); !errors.Is(err, ErrDuelInvalidDuration) {
+		t.Errorf("expected ErrDuelInvalidDuration, got %v", err)
+	}
+
+	duel, err := service.Challenge(ctx, 1, 2, " question ", time.Hour)
+	if err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	if duel.ID == 0 || duel.Question != "question" || duel.Status != DuelStatusPending {
+		t.Errorf("unexpected duel: %+v", duel)
+	}
+
+	// Only the opponent can accept, and only after the challenger picked an answer
+	if _, err := service.Accept(ctx, duel.ID, 2, true); !errors.Is(err, ErrDuelClosed) {
+		t.Errorf("expected ErrDuelClosed before the challenger's pick, got %v", err)
+	}
+	if _, err := service.Pick(ctx, duel.ID, 2, true); !errors.Is(err, ErrDuelNotParticipant) {
+		t.Errorf("expected ErrDuelNotParticipant for the opponent's pick, got %v", err)
+	}
+	if _, err := service.Pick(ctx, duel.ID, 1, true); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if _, err := service.Accept(ctx, duel.ID, 1, false); !errors.Is(err, ErrDuelNotParticipant) {
+		t.Errorf("expected ErrDuelNotParticipant for the challenger's acceptance, got %v", err)
+	}
+
+	duel, err = service.Decline(ctx, duel.ID, 2)
+	if err != nil {
+		t.Fatalf("Decline failed: %v", err)
+	}
+	if duel.Status != DuelStatusDeclined {
+		t.Errorf("expected declined duel, got %s", duel.Status)
+	}
+	if _, err := service.Accept(ctx, duel.ID, 2, false); !errors.Is(err, ErrDuelClosed) {
+		t.Errorf("expected ErrDuelClosed after declining, got %v", err)
+	}
+}
+
+func TestDuelService_Report(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		challengerPick   bool
+		opponentPick     bool
+		challengerReport bool
+		opponentReport   bool
+		status           DuelStatus
+		winnerID         int64
+		challenger       DuelRecord
+	}{
+		{"challenger wins", true, false, true, true, DuelStatusResolved, 1, DuelRecord{UserID: 1, Wins: 1}},
+		{"opponent wins", true, false, false, false, DuelStatusResolved, 2, DuelRecord{UserID: 1, Losses: 1}},
+		{"draw", true, true, true, true, DuelStatusResolved, 0, DuelRecord{UserID: 1, Draws: 1}},
+		{"disputed", true, false, true, false, DuelStatusDisputed, 0, DuelRecord{UserID: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewDuelService(newMockDuelRepo(), &mockDuelNotifier{}, &mockLogger{})
+			service.now = func() time.Time { return now }
+
+			duel := startDuel(t, service, tt.challengerPick, tt.opponentPick)
+
+			if _, err := service.Report(ctx, duel.ID, 1, tt.challengerReport); !errors.Is(err, ErrDuelTooEarly) {
+				t.Fatalf("expected ErrDuelTooEarly before the deadline, got %v", err)
+			}
+
+			service.now = func() time.Time { return duel.Deadline.Add(time.Minute) }
+			if _, err := service.Report(ctx, duel.ID, 3, true); !errors.Is(err, ErrDuelNotParticipant) {
+				t.Errorf("expected ErrDuelNotParticipant, got %v", err)
+			}
+
+			duel, err := service.Report(ctx, duel.ID, 1, tt.challengerReport)
+			if err != nil {
+				t.Fatalf("challenger Report failed: %v", err)
+			}
+			if duel.Status != DuelStatusActive {
+				t.Fatalf("expected the duel to wait for the second report, got %s", duel.Status)
+			}
+			if _, err := service.Report(ctx, duel.ID, 1, tt.challengerReport); !errors.Is(err, ErrDuelClosed) {
+				t.Errorf("expected ErrDuelClosed for a repeated report, got %v", err)
+			}
+
+			duel, err = service.Report(ctx, duel.ID, 2, tt.opponentReport)
+			if err != nil {
+				t.Fatalf("opponent Report failed: %v", err)
+			}
+			if duel.Status != tt.status || duel.Winner() != tt.winnerID {
+				t.Errorf("expected %s with winner %d, got %s with winner %d", tt.status, tt.winnerID, duel.Status, duel.Winner())
+			}
+
+			record, err := service.Record(ctx, 1)
+			if err != nil {
+				t.Fatalf("Record failed: %v", err)
+			}
+			if *record != tt.challenger {
+				t.Errorf("expected challenger record %+v, got %+v", tt.challenger, *record)
+			}
+		})
+	}
+}
+
+func TestDuelService_ExpireOverdueDuels(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	repo := newMockDuelRepo()
+	notifier := &mockDuelNotifier{}
+	service := NewDuelService(repo, notifier, &mockLogger{})
+	service.now = func() time.Time { return now }
+
+	// Never picked, picked but not accepted, and active
+	unpicked, _ := service.Challenge(ctx, 1, 2, "unpicked", time.Hour)
+	unanswered, _ := service.Challenge(ctx, 1, 2, "unanswered", time.Hour)
+	if _, err := service.Pick(ctx, unanswered.ID, 1, true); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	active := startDuel(t, service, true, false)
+
+	// Past the deadline only the pending duels expire
+	service.now = func() time.Time { return now.Add(2 * time.Hour) }
+	expired, err := service.ExpireOverdueDuels(ctx)
+	if err != nil {
+		t.Fatalf("ExpireOverdueDuels failed: %v", err)
+	}
+	if expired != 2 {
+		t.Errorf("expected 2 expired duels, got %d", expired)
+	}
+	for _, id := range []int64{unpicked.ID, unanswered.ID} {
+		if repo.duels[id].Status != DuelStatusExpired {
+			t.Errorf("expected duel %d to be expired, got %s", id, repo.duels[id].Status)
+		}
+	}
+	if len(notifier.expired) != 1 || notifier.expired[0].ID != unanswered.ID {
+		t.Errorf("expected a notification only for the invited duel, got %v", notifier.expired)
+	}
+
+	// The active duel expires once the report window has passed
+	service.now = func() time.Time { return now.Add(time.Hour + DuelReportWindow + time.Minute) }
+	if expired, _ := service.ExpireOverdueDuels(ctx); expired != 1 {
+		t.Errorf("expected the active duel to expire, got %d", expired)
+	}
+	if repo.duels[active.ID].Status != DuelStatusExpired {
+		t.Errorf("expected the active duel to be expired, got %s", repo.duels[active.ID].Status)
+	}
+	if _, err := service.Report(ctx, active.ID, 1, true); !errors.Is(err, ErrDuelClosed) {
+		t.Errorf("expected ErrDuelClosed after expiry, got %v", err)
+	}
+}
+
+func TestParseDuelDuration(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"12h", 12 * time.Hour, false},
+		{"3D", 72 * time.Hour, false},
+		{"30m", 30 * time.Minute, false},
+		{"rain", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		duration, err := ParseDuelDuration(tt.text)
+		if (err != nil) != tt.wantErr || duration != tt.expected {
+			t.Errorf("ParseDuelDuration(%q) = %v, %v; want %v, error %t", tt.text, duration, err, tt.expected, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "strings"
+
+// NormalizeQuestion lowercases an event question and collapses its whitespace, so that questions
+// differing only in case or spacing compare equal when looking for duplicate events
+func NormalizeQuestion(question string) string {
+	return strings.Join(strings.Fields(strings.ToLower(question)), " ")
+}
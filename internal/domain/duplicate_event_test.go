@@ -0,0 +1,21 @@
+package domain
+
+import "testing"
+
+func TestNormalizeQuestion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Will it rain?", "will it rain?"},
+		{"  Will   it\train?\n", "will it rain?"},
+		{"БУДЕТ ЛИ Дождь?", "будет ли дождь?"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeQuestion(tt.input); got != tt.expected {
+			t.Errorf("NormalizeQuestion(%q) = %q; want %q", tt.input, got, tt.expected)
+		}
+	}
+}
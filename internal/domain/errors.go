@@ -0,0 +1,76 @@
+package domain
+
+import "errors"
+
+// ErrorKind classifies domain errors so callers can react to a whole category
+// (e.g. show a "not found" message) without matching every sentinel
+type ErrorKind int
+
+const (
+	// ErrorKindInternal is an unexpected failure (storage, network, bugs)
+	ErrorKindInternal ErrorKind = iota
+	// ErrorKindValidation means the input was rejected
+	ErrorKindValidation
+	// ErrorKindPermission means the user is not allowed to perform the action
+	ErrorKindPermission
+	// ErrorKindNotFound means the requested entity doesn't exist
+	ErrorKindNotFound
+	// ErrorKindConflict means the action doesn't fit the entity's current state
+	ErrorKindConflict
+)
+
+// String returns the kind name used in logs
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindValidation:
+		return "validation"
+	case ErrorKindPermission:
+		return "permission"
+	case ErrorKindNotFound:
+		return "not_found"
+	case ErrorKindConflict:
+		return "conflict"
+	default:
+		return "internal"
+	}
+}
+
+// Error is a classified domain error
+type Error struct {
+	Kind ErrorKind
+	Msg  string
+	Err  error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Msg + ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+// Unwrap returns the underlying error, if any
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewError creates a classified error, typically used for sentinel errors
+func NewError(kind ErrorKind, msg string) error {
+	return &Error{Kind: kind, Msg: msg}
+}
+
+// WrapError classifies err with the given kind and message
+func WrapError(kind ErrorKind, msg string, err error) error {
+	return &Error{Kind: kind, Msg: msg, Err: err}
+}
+
+// KindOf returns the kind of the first classified error in err's chain.
+// Unclassified errors are internal.
+func KindOf(err error) ErrorKind {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+	return ErrorKindInternal
+}
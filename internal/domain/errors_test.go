@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind ErrorKind
+	}{
+		{"nil", nil, ErrorKindInternal},
+		{"unclassified", errors.New("database is locked"), ErrorKindInternal},
+		{"not found sentinel", ErrEventNotFound, ErrorKindNotFound},
+		{"permission sentinel", ErrUnauthorized, ErrorKindPermission},
+		{"conflict sentinel", ErrDuelClosed, ErrorKindConflict},
+		{"validation sentinel", ErrFeedbackEmpty, ErrorKindValidation},
+		{"wrapped sentinel", fmt.Errorf("resolve event 42: %w", ErrEventNotFound), ErrorKindNotFound},
+		{"classified cause", WrapError(ErrorKindConflict, "vote rejected", errors.New("poll closed")), ErrorKindConflict},
+	}
+
+	for _, tt := range tests {
+		if kind := KindOf(tt.err); kind != tt.kind {
+			t.Errorf("%s: expected kind %s, got %s", tt.name, tt.kind, kind)
+		}
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	cause := errors.New("no rows")
+	err := WrapError(ErrorKindNotFound, "load duel", cause)
+
+	if !errors.Is(err, cause) {
+		t.Error("expected the wrapped error to match its cause")
+	}
+	if err.Error() != "load duel: no rows" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(fmt.Errorf("context: %w", ErrDuelNotFound), ErrDuelNotFound) {
+		t.Error("expected classified sentinels to keep working with errors.Is")
+	}
+}
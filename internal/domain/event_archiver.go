@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EventArchiver periodically archives resolved events older than the retention window of their group.
+// Archived events are hidden from default queries but still count toward stats and achievements.
+type EventArchiver struct {
+	eventRepo EventRepository
+	groupRepo GroupRepository
+	retention time.Duration
+	interval  time.Duration
+	logger    Logger
+	now       func() time.Time
+}
+
+// NewEventArchiver creates a new EventArchiver.
+// The retention applies to groups without their own archive_days; a non-positive one disables archival there.
+func NewEventArchiver(eventRepo EventRepository, groupRepo GroupRepository, retention time.Duration, logger Logger) *EventArchiver {
+	return &EventArchiver{
+		eventRepo: eventRepo,
+		groupRepo: groupRepo,
+		retention: retention,
+		interval:  1 * time.Hour,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// groupRetention returns the retention window of a group, falling back to the global one
+func (a *EventArchiver) groupRetention(group *Group) time.Duration {
+	if group.ArchiveDays != nil {
+		return time.Duration(*group.ArchiveDays) * 24 * time.Hour
+	}
+	return a.retention
+}
+
+// ArchiveOldEvents archives resolved events whose deadline is older than the retention window of their group
+func (a *EventArchiver) ArchiveOldEvents(ctx context.Context) (int64, error) {
+	groups, err := a.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		a.logger.Error("failed to get groups for event archival", "error", err)
+		return 0, err
+	}
+
+	var total int64
+	for _, group := range groups {
+		retention := a.groupRetention(group)
+		if retention <= 0 {
+			continue
+		}
+
+		cutoff := a.now().Add(-retention)
+		count, err := a.eventRepo.ArchiveResolvedOlderThan(ctx, group.ID, cutoff)
+		if err != nil {
+			a.logger.Error("failed to archive resolved events", "group_id", group.ID, "cutoff", cutoff, "error", err)
+			continue
+		}
+		if count > 0 {
+			a.logger.Info("resolved events archived", "group_id", group.ID, "count", count, "cutoff", cutoff)
+		}
+		total += count
+	}
+
+	if total == 0 {
+		a.logger.Debug("no resolved events to archive")
+	}
+
+	return total, nil
+}
+
+// StartScheduler runs archival once on startup and then hourly until ctx is cancelled.
+// It always runs, since groups may enable archival on their own.
+func (a *EventArchiver) StartScheduler(ctx context.Context) {
+	_, _ = a.ArchiveOldEvents(ctx)
+
+	go func() {
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				a.logger.Info("event archiver stopped")
+				return
+			case <-ticker.C:
+				_, _ = a.ArchiveOldEvents(ctx)
+			}
+		}
+	}()
+
+	a.logger.Info("event archiver started", "retention", a.retention)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// archiveRecordingEventRepo records the cutoff of every archival call per group
+type archiveRecordingEventRepo struct {
+	mockEventRepo
+	cutoffs map[int64]time.Time
+}
+
+func (m *archiveRecordingEventRepo) ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error) {
+	m.cutoffs[groupID] = cutoff
+	return 1, nil
+}
+
+func TestEventArchiver_PerGroupRetention(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		retention time.Duration
+		expected  map[int64]time.Time
+	}{
+		{
+			name:      "global retention with overrides",
+			retention: 30 * day,
+			expected: map[int64]time.Time{
+				1: now.Add(-30 * day),
+				2: now.Add(-7 * day),
+			},
+		},
+		{
+			name:      "global archival disabled",
+			retention: 0,
+			expected: map[int64]time.Time{
+				2: now.Add(-7 * day),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := &archiveRecordingEventRepo{cutoffs: map[int64]time.Time{}}
+			groupRepo := &mockGroupRepoForRemover{groups: []*Group{
+				{ID: 1, Status: GroupStatusActive},
+				{ID: 2, Status: GroupStatusActive, ArchiveDays: intPtr(7)},
+				{ID: 3, Status: GroupStatusActive, ArchiveDays: intPtr(0)},
+			}}
+			archiver := NewEventArchiver(eventRepo, groupRepo, tt.retention, &mockLogger{})
+			archiver.now = func() time.Time { return now }
+
+			count, err := archiver.ArchiveOldEvents(context.Background())
+			if err != nil {
+				t.Fatalf("ArchiveOldEvents failed: %v", err)
+			}
+			if count != int64(len(tt.expected)) {
+				t.Errorf("expected %d archived events, got %d", len(tt.expected), count)
+			}
+			if len(eventRepo.cutoffs) != len(tt.expected) {
+				t.Fatalf("expected archival in %d groups, got %v", len(tt.expected), eventRepo.cutoffs)
+			}
+			for groupID, cutoff := range tt.expected {
+				if got, ok := eventRepo.cutoffs[groupID]; !ok || !got.Equal(cutoff) {
+					t.Errorf("group %d: expected cutoff %v, got %v", groupID, cutoff, got)
+				}
+			}
+		})
+	}
+}
@@ -15,20 +15,30 @@ var (
 
 // EventCreationContext holds data during event creation flow
 type EventCreationContext struct {
-	GroupID               int64     `json:"group_id"`
-	Question              string    `json:"question"`
-	EventType             EventType `json:"event_type"`
-	Options               []string  `json:"options"`
-	Deadline              time.Time `json:"deadline"`
-	LastBotMessageID      int       `json:"last_bot_message_id"`
-	LastUserMessageID     int       `json:"last_user_message_id"`
-	LastErrorMessageID    int       `json:"last_error_message_id"`
-	ConfirmationMessageID int       `json:"confirmation_message_id"`
-	ChatID                int64     `json:"chat_id"`
-	MessageThreadID       *int      `json:"message_thread_id,omitempty"` // Telegram forum topic thread ID
-	AllowsRevoting        bool      `json:"allows_revoting"`
-	ShuffleOptions        bool      `json:"shuffle_options"`
-	HideResultsUntilClose bool      `json:"hide_results_until_close"`
+	GroupID               int64           `json:"group_id"`
+	Question              string          `json:"question"`
+	EventType             EventType       `json:"event_type"`
+	Options               []string        `json:"options"`
+	Deadline              time.Time       `json:"deadline"`
+	LastBotMessageID      int             `json:"last_bot_message_id"`
+	LastUserMessageID     int             `json:"last_user_message_id"`
+	LastErrorMessageID    int             `json:"last_error_message_id"`
+	ConfirmationMessageID int             `json:"confirmation_message_id"`
+	ChatID                int64           `json:"chat_id"`
+	SummaryChatID         int64           `json:"summary_chat_id"`             // Chat that gets the summary of the created event (zero means ChatID)
+	MessageThreadID       *int            `json:"message_thread_id,omitempty"` // Telegram forum topic thread ID
+	AllowsRevoting        bool            `json:"allows_revoting"`
+	ShuffleOptions        bool            `json:"shuffle_options"`
+	HideResultsUntilClose bool            `json:"hide_results_until_close"`
+	CompactMode           bool            `json:"compact_mode"`        // Edit a single form message instead of sending a new one per step
+	Participants          []int64         `json:"participants"`        // Users allowed to vote (empty means all group members)
+	PhotoFileID           string          `json:"photo_file_id"`       // Telegram file_id of the attached photo (empty if none)
+	ReminderOffsets       []time.Duration `json:"reminder_offsets"`    // Custom reminder offsets before the deadline (empty means the default)
+	LockVotesAt           *time.Time      `json:"lock_votes_at"`       // When votes lock before the deadline (nil means votes can change until the deadline)
+	IsQuiz                bool            `json:"is_quiz"`             // Create a quiz poll with QuizAnswer as the correct option
+	QuizAnswer            int             `json:"quiz_answer"`         // Index of the correct option of a quiz
+	PreviewMessageIDs     []int           `json:"preview_message_ids"` // Messages previewing the poll next to the confirmation
+	ReturnToConfirm       bool            `json:"return_to_confirm"`   // A step was reopened from the preview; finishing it returns to the confirmation
 }
 
 // ToMap converts EventCreationContext to a map for JSON serialization
@@ -44,6 +54,7 @@ func (c *EventCreationContext) ToMap() map[string]interface{} {
 		"last_error_message_id":   c.LastErrorMessageID,
 		"confirmation_message_id": c.ConfirmationMessageID,
 		"chat_id":                 c.ChatID,
+		"summary_chat_id":         c.SummaryChatID,
 	}
 	if c.MessageThreadID != nil {
 		m["message_thread_id"] = *c.MessageThreadID
@@ -51,6 +62,17 @@ func (c *EventCreationContext) ToMap() map[string]interface{} {
 	m["allows_revoting"] = c.AllowsRevoting
 	m["shuffle_options"] = c.ShuffleOptions
 	m["hide_results_until_close"] = c.HideResultsUntilClose
+	m["compact_mode"] = c.CompactMode
+	m["participants"] = c.Participants
+	m["photo_file_id"] = c.PhotoFileID
+	m["reminder_offsets"] = FormatReminderOffsets(c.ReminderOffsets)
+	if c.LockVotesAt != nil {
+		m["lock_votes_at"] = c.LockVotesAt.Format(time.RFC3339)
+	}
+	m["is_quiz"] = c.IsQuiz
+	m["quiz_answer"] = c.QuizAnswer
+	m["preview_message_ids"] = c.PreviewMessageIDs
+	m["return_to_confirm"] = c.ReturnToConfirm
 	return m
 }
 
@@ -127,6 +149,13 @@ func (c *EventCreationContext) FromMap(data map[string]interface{}) error {
 		c.ChatID = int64(chatID)
 	}
 
+	// Parse summary_chat_id (optional, sessions started before it existed use chat_id)
+	if summaryChatID, ok := data["summary_chat_id"].(float64); ok {
+		c.SummaryChatID = int64(summaryChatID)
+	} else if summaryChatID, ok := data["summary_chat_id"].(int64); ok {
+		c.SummaryChatID = summaryChatID
+	}
+
 	// Parse message_thread_id (optional)
 	if threadID, ok := data["message_thread_id"].(float64); ok {
 		tid := int(threadID)
@@ -145,6 +174,71 @@ func (c *EventCreationContext) FromMap(data map[string]interface{}) error {
 	if v, ok := data["hide_results_until_close"].(bool); ok {
 		c.HideResultsUntilClose = v
 	}
+	if v, ok := data["compact_mode"].(bool); ok {
+		c.CompactMode = v
+	}
+
+	// Parse participants (numbers come back as float64 from JSON)
+	switch participants := data["participants"].(type) {
+	case []interface{}:
+		c.Participants = make([]int64, 0, len(participants))
+		for _, p := range participants {
+			if id, ok := p.(float64); ok {
+				c.Participants = append(c.Participants, int64(id))
+			}
+		}
+	case []int64:
+		c.Participants = participants
+	}
+
+	// Parse photo_file_id (optional)
+	if photoFileID, ok := data["photo_file_id"].(string); ok {
+		c.PhotoFileID = photoFileID
+	}
+
+	// Parse reminder_offsets (optional, empty means the default reminders)
+	if offsets, ok := data["reminder_offsets"].(string); ok && offsets != "" {
+		reminderOffsets, err := ParseReminderOffsets(offsets)
+		if err != nil {
+			return fmt.Errorf("failed to parse reminder offsets: %w", err)
+		}
+		c.ReminderOffsets = reminderOffsets
+	}
+
+	// Parse lock_votes_at (optional)
+	if lockVotesAtStr, ok := data["lock_votes_at"].(string); ok && lockVotesAtStr != "" {
+		lockVotesAt, err := time.Parse(time.RFC3339, lockVotesAtStr)
+		if err != nil {
+			return fmt.Errorf("failed to parse votes lock time: %w", err)
+		}
+		c.LockVotesAt = &lockVotesAt
+	}
+
+	// Parse quiz settings
+	if v, ok := data["is_quiz"].(bool); ok {
+		c.IsQuiz = v
+	}
+	if quizAnswer, ok := data["quiz_answer"].(float64); ok {
+		c.QuizAnswer = int(quizAnswer)
+	} else if quizAnswer, ok := data["quiz_answer"].(int); ok {
+		c.QuizAnswer = quizAnswer
+	}
+
+	// Parse preview_message_ids (numbers come back as float64 from JSON)
+	switch previewIDs := data["preview_message_ids"].(type) {
+	case []interface{}:
+		c.PreviewMessageIDs = make([]int, 0, len(previewIDs))
+		for _, id := range previewIDs {
+			if messageID, ok := id.(float64); ok {
+				c.PreviewMessageIDs = append(c.PreviewMessageIDs, int(messageID))
+			}
+		}
+	case []int:
+		c.PreviewMessageIDs = previewIDs
+	}
+	if v, ok := data["return_to_confirm"].(bool); ok {
+		c.ReturnToConfirm = v
+	}
 
 	return nil
 }
@@ -158,3 +252,33 @@ func (c *EventCreationContext) Validate() error {
 	// Other fields may be optional depending on the state
 	return nil
 }
+
+// SummaryChat returns the chat that gets the summary and management buttons of the created event.
+// The poll itself is always posted to the group.
+func (c *EventCreationContext) SummaryChat() int64 {
+	if c.SummaryChatID != 0 {
+		return c.SummaryChatID
+	}
+	return c.ChatID
+}
+
+// HasParticipant reports whether a user is in the selected participants
+func (c *EventCreationContext) HasParticipant(userID int64) bool {
+	for _, id := range c.Participants {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleParticipant adds a user to the selected participants or removes them if already selected
+func (c *EventCreationContext) ToggleParticipant(userID int64) {
+	for i, id := range c.Participants {
+		if id == userID {
+			c.Participants = append(c.Participants[:i], c.Participants[i+1:]...)
+			return
+		}
+	}
+	c.Participants = append(c.Participants, userID)
+}
@@ -109,3 +109,137 @@ func TestContextSerializationRoundTrip(t *testing.T) {
 
 	properties.TestingRun(t)
 }
+
+func TestContextParticipantsRoundTrip(t *testing.T) {
+	ctx := &EventCreationContext{ChatID: 1}
+	ctx.ToggleParticipant(100)
+	ctx.ToggleParticipant(200)
+	ctx.ToggleParticipant(300)
+	ctx.ToggleParticipant(200) // toggling again deselects
+
+	jsonBytes, err := json.Marshal(ctx.ToMap())
+	if err != nil {
+		t.Fatalf("Failed to marshal to JSON: %v", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &data); err != nil {
+		t.Fatalf("Failed to unmarshal from JSON: %v", err)
+	}
+
+	restored := &EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("FromMap failed: %v", err)
+	}
+
+	if len(restored.Participants) != 2 || !restored.HasParticipant(100) || !restored.HasParticipant(300) || restored.HasParticipant(200) {
+		t.Errorf("Expected participants [100 300], got %v", restored.Participants)
+	}
+}
+
+func TestContextPreviewRoundTrip(t *testing.T) {
+	ctx := &EventCreationContext{ChatID: 1, PreviewMessageIDs: []int{10, 11}, ReturnToConfirm: true}
+
+	jsonBytes, err := json.Marshal(ctx.ToMap())
+	if err != nil {
+		t.Fatalf("Failed to marshal to JSON: %v", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &data); err != nil {
+		t.Fatalf("Failed to unmarshal from JSON: %v", err)
+	}
+
+	restored := &EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("FromMap failed: %v", err)
+	}
+
+	if len(restored.PreviewMessageIDs) != 2 || restored.PreviewMessageIDs[0] != 10 || restored.PreviewMessageIDs[1] != 11 {
+		t.Errorf("Expected preview message IDs [10 11], got %v", restored.PreviewMessageIDs)
+	}
+	if !restored.ReturnToConfirm {
+		t.Error("Expected ReturnToConfirm to be restored")
+	}
+}
+
+func TestContextLockVotesRoundTrip(t *testing.T) {
+	lockAt := time.Date(2026, 3, 15, 18, 0, 0, 0, time.UTC)
+	ctx := &EventCreationContext{ChatID: 1, LockVotesAt: &lockAt}
+
+	jsonBytes, err := json.Marshal(ctx.ToMap())
+	if err != nil {
+		t.Fatalf("Failed to marshal to JSON: %v", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &data); err != nil {
+		t.Fatalf("Failed to unmarshal from JSON: %v", err)
+	}
+
+	restored := &EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("FromMap failed: %v", err)
+	}
+	if restored.LockVotesAt == nil || !restored.LockVotesAt.Equal(lockAt) {
+		t.Errorf("Expected votes lock %v, got %v", lockAt, restored.LockVotesAt)
+	}
+
+	// Contexts without a lock stay without one
+	restored = &EventCreationContext{}
+	if err := restored.FromMap((&EventCreationContext{ChatID: 1}).ToMap()); err != nil {
+		t.Fatalf("FromMap failed: %v", err)
+	}
+	if restored.LockVotesAt != nil {
+		t.Errorf("Expected no votes lock, got %v", restored.LockVotesAt)
+	}
+}
+
+func TestContextQuizRoundTrip(t *testing.T) {
+	ctx := &EventCreationContext{ChatID: 1, IsQuiz: true, QuizAnswer: 2}
+
+	jsonBytes, err := json.Marshal(ctx.ToMap())
+	if err != nil {
+		t.Fatalf("Failed to marshal to JSON: %v", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &data); err != nil {
+		t.Fatalf("Failed to unmarshal from JSON: %v", err)
+	}
+
+	restored := &EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("FromMap failed: %v", err)
+	}
+	if !restored.IsQuiz || restored.QuizAnswer != 2 {
+		t.Errorf("Expected quiz with answer 2, got quiz=%v answer=%d", restored.IsQuiz, restored.QuizAnswer)
+	}
+}
+
+func TestContextSummaryChatRoundTrip(t *testing.T) {
+	ctx := &EventCreationContext{ChatID: -100, SummaryChatID: 42}
+
+	jsonBytes, err := json.Marshal(ctx.ToMap())
+	if err != nil {
+		t.Fatalf("Failed to marshal to JSON: %v", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &data); err != nil {
+		t.Fatalf("Failed to unmarshal from JSON: %v", err)
+	}
+
+	restored := &EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("FromMap failed: %v", err)
+	}
+	if restored.ChatID != -100 || restored.SummaryChat() != 42 {
+		t.Errorf("Expected chat -100 with summary chat 42, got %d and %d", restored.ChatID, restored.SummaryChat())
+	}
+
+	// Sessions saved without a summary chat keep the summary in the dialog chat
+	delete(data, "summary_chat_id")
+	restored = &EventCreationContext{}
+	if err := restored.FromMap(data); err != nil {
+		t.Fatalf("FromMap failed: %v", err)
+	}
+	if restored.SummaryChat() != -100 {
+		t.Errorf("Expected the summary in chat -100, got %d", restored.SummaryChat())
+	}
+}
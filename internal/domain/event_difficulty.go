@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+)
+
+// EventDifficulty returns how hard a resolved event was, from 0 (everyone agreed and was right)
+// to 1 (the vote was evenly split and nobody was right). ok is false for events that are not
+// resolved or got no votes, they have no difficulty.
+func (s *StatsService) EventDifficulty(ctx context.Context, eventID int64) (difficulty float64, ok bool, err error) {
+	event, err := s.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		s.logger.Error("failed to get event for difficulty", "event_id", eventID, "error", err)
+		return 0, false, err
+	}
+
+	predictions, err := s.predictionRepo.GetPredictionsByEvent(ctx, eventID)
+	if err != nil {
+		s.logger.Error("failed to get predictions for difficulty", "event_id", eventID, "error", err)
+		return 0, false, err
+	}
+
+	difficulty, ok = CalculateEventDifficulty(event, event.ParticipantPredictions(predictions))
+	return difficulty, ok, nil
+}
+
+// CalculateEventDifficulty averages the share of wrong predictions and how split the vote was.
+// The split is 0 when all votes went to one option and 1 when they were spread evenly over all options.
+func CalculateEventDifficulty(event *Event, predictions []*Prediction) (float64, bool) {
+	if event.CorrectOption == nil || len(predictions) == 0 || len(event.Options) < 2 {
+		return 0, false
+	}
+
+	counts := make([]int, len(event.Options))
+	total := 0
+	for _, pred := range predictions {
+		if pred.Option < 0 || pred.Option >= len(counts) {
+			continue
+		}
+		counts[pred.Option]++
+		total++
+	}
+	if total == 0 {
+		return 0, false
+	}
+
+	maxCount := 0
+	for _, count := range counts {
+		maxCount = max(maxCount, count)
+	}
+
+	wrongShare := 1 - float64(counts[*event.CorrectOption])/float64(total)
+	evenShare := 1 / float64(len(counts))
+	split := (1 - float64(maxCount)/float64(total)) / (1 - evenShare)
+
+	return (wrongShare + split) / 2, true
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestCalculateEventDifficulty(t *testing.T) {
+	correct := func(option int) *int { return &option }
+	votes := func(options ...int) []*Prediction {
+		predictions := make([]*Prediction, 0, len(options))
+		for i, option := range options {
+			predictions = append(predictions, &Prediction{UserID: int64(i + 1), Option: option})
+		}
+		return predictions
+	}
+
+	tests := []struct {
+		name        string
+		event       *Event
+		predictions []*Prediction
+		want        float64
+		ok          bool
+	}{
+		{"unanimous and right", &Event{Options: []string{"Yes", "No"}, CorrectOption: correct(0)}, votes(0, 0, 0), 0, true},
+		{"unanimous and wrong", &Event{Options: []string{"Yes", "No"}, CorrectOption: correct(1)}, votes(0, 0, 0, 0), 0.5, true},
+		{"even split", &Event{Options: []string{"Yes", "No"}, CorrectOption: correct(0)}, votes(0, 1), 0.75, true},
+		{"even split, three options", &Event{Options: []string{"A", "B", "C"}, CorrectOption: correct(2)}, votes(0, 1, 2), (2.0/3 + 1) / 2, true},
+		{"no votes", &Event{Options: []string{"Yes", "No"}, CorrectOption: correct(0)}, nil, 0, false},
+		{"not resolved", &Event{Options: []string{"Yes", "No"}}, votes(0, 1), 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := CalculateEventDifficulty(tt.event, tt.predictions)
+		if ok != tt.ok || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: CalculateEventDifficulty() = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestStatsService_EventDifficulty(t *testing.T) {
+	ctx := context.Background()
+	correctOption := 0
+	event := &Event{ID: 1, Options: []string{"Yes", "No"}, CorrectOption: &correctOption, Participants: []int64{1, 2}}
+	predictions := []*Prediction{
+		{EventID: 1, UserID: 1, Option: 0},
+		{EventID: 1, UserID: 2, Option: 1},
+		// Not a participant of the restricted event, the vote doesn't count
+		{EventID: 1, UserID: 3, Option: 1},
+	}
+
+	service := NewStatsService(nil, nil, &MockEventRepoWithData{event: event}, &MockPredictionRepoWithData{predictions: predictions}, &mockLogger{})
+	difficulty, ok, err := service.EventDifficulty(ctx, 1)
+	if err != nil {
+		t.Fatalf("EventDifficulty returned error: %v", err)
+	}
+	if !ok || difficulty != 0.75 {
+		t.Errorf("EventDifficulty() = %v, %v; want 0.75, true", difficulty, ok)
+	}
+}
@@ -41,7 +41,7 @@ func setupTestDB(t *testing.T) (*storage.DBQueue, *domain.EventManager) {
 	predictionRepo := storage.NewPredictionRepository(queue)
 	logger := &mockLoggerEditability{}
 
-	manager := domain.NewEventManager(eventRepo, predictionRepo, logger)
+	manager := domain.NewEventManager(eventRepo, predictionRepo, nil, logger)
 
 	return queue, manager
 }
@@ -184,3 +184,58 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func TestIsOptionTextFix(t *testing.T) {
+	original := []string{"Real Madrid", "Barcelona", "Team A", "Team B", "🔥"}
+
+	tests := []struct {
+		name    string
+		options []string
+		want    bool
+	}{
+		{"unchanged", []string{"Real Madrid", "Barcelona", "Team A", "Team B", "🔥"}, true},
+		{"typos fixed", []string{"Real Madird", "barcelona!", "Team A", "Team B", "🔥"}, true},
+		{"option renamed", []string{"Real Madrid", "Atletico", "Team A", "Team B", "🔥"}, false},
+		{"similar options swapped", []string{"Real Madrid", "Barcelona", "Team B", "Team A", "🔥"}, false},
+		{"emoji option changed", []string{"Real Madrid", "Barcelona", "Team A", "Team B", "❄️"}, false},
+		{"option removed", []string{"Real Madrid", "Barcelona", "Team A", "Team B"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domain.IsOptionTextFix(original, tt.options); got != tt.want {
+				t.Errorf("IsOptionTextFix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckVotedEventEdit(t *testing.T) {
+	deadline := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	event := &domain.Event{Options: []string{"Yes", "No"}, Deadline: deadline}
+
+	tests := []struct {
+		name     string
+		options  []string
+		deadline time.Time
+		wantErr  bool
+	}{
+		{"unchanged", []string{"Yes", "No"}, deadline, false},
+		{"option texts fixed", []string{"Yes!", "NO"}, deadline, false},
+		{"deadline extended", []string{"Yes", "No"}, deadline.Add(24 * time.Hour), false},
+		{"option added", []string{"Yes", "No", "Maybe"}, deadline, true},
+		{"option removed", []string{"Yes"}, deadline, true},
+		{"deadline moved earlier", []string{"Yes", "No"}, deadline.Add(-time.Hour), true},
+		{"option renamed", []string{"Yes", "Maybe"}, deadline, true},
+		{"options swapped", []string{"No", "Yes"}, deadline, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := domain.CheckVotedEventEdit(event, tt.options, tt.deadline)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckVotedEventEdit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
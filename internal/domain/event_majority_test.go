@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEventManagerMajorityOption(t *testing.T) {
+	tests := []struct {
+		name           string
+		participants   []int64
+		votes          []int
+		expectedOption int
+		expectedOK     bool
+	}{
+		{"no votes", nil, nil, 0, false},
+		{"single vote", nil, []int{1}, 1, true},
+		{"clear plurality", nil, []int{0, 2, 2, 1, 2}, 2, true},
+		{"tie for the lead", nil, []int{0, 1, 0, 1, 2}, 0, false},
+		// Users 100 and 101 vote 0, only 102-104 are participants
+		{"non-participant votes are ignored", []int64{102, 103, 104}, []int{0, 0, 1, 1, 2}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{ID: 1, GroupID: 1, EventType: EventTypeMultiOption, Options: []string{"A", "B", "C"}, Participants: tt.participants}
+			var predictions []*Prediction
+			for i, option := range tt.votes {
+				predictions = append(predictions, &Prediction{EventID: event.ID, UserID: int64(100 + i), Option: option})
+			}
+
+			em := NewEventManager(&MockEventRepoWithEvents{events: []*Event{event}}, &MockPredictionRepoWithData{predictions: predictions}, nil, &MockLogger{})
+
+			option, ok, err := em.MajorityOption(context.Background(), event.ID)
+			if err != nil {
+				t.Fatalf("MajorityOption returned error: %v", err)
+			}
+			if ok != tt.expectedOK || (ok && option != tt.expectedOption) {
+				t.Errorf("MajorityOption = %d, %v; want %d, %v", option, ok, tt.expectedOption, tt.expectedOK)
+			}
+		})
+	}
+}
@@ -7,10 +7,16 @@ import (
 )
 
 var (
-	ErrEventNotFound     = errors.New("event not found")
-	ErrEventHasVotes     = errors.New("event has votes and cannot be edited")
-	ErrEventNotActive    = errors.New("event is not active")
-	ErrInvalidCorrectOpt = errors.New("invalid correct option")
+	ErrEventNotFound        = NewError(ErrorKindNotFound, "event not found")
+	ErrEventHasVotes        = NewError(ErrorKindConflict, "event has votes and cannot be changed this way")
+	ErrEventNotActive       = NewError(ErrorKindConflict, "event is not active")
+	ErrEventAlreadyResolved = NewError(ErrorKindConflict, "event is already resolved")
+	ErrEventNotArchived     = NewError(ErrorKindConflict, "event is not archived")
+	ErrVotingClosed         = NewError(ErrorKindConflict, "voting is already closed")
+	ErrEventNotPending      = NewError(ErrorKindConflict, "event is not pending approval")
+	ErrInvalidCorrectOpt    = NewError(ErrorKindValidation, "invalid correct option")
+	ErrNewOwnerNotMember    = NewError(ErrorKindValidation, "new owner is not an active member of the event's group")
+	ErrAlreadyOwner         = NewError(ErrorKindConflict, "user already owns the event")
 )
 
 // Logger interface for logging
@@ -27,27 +33,59 @@ type EventRepository interface {
 	GetEvent(ctx context.Context, eventID int64) (*Event, error)
 	GetEventByPollID(ctx context.Context, pollID string) (*Event, error)
 	GetActiveEvents(ctx context.Context, groupID int64) ([]*Event, error)
+	GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*Event, error)
+	// FindSimilarActiveEvent returns an active event of the group with the same question (see NormalizeQuestion)
+	// and a deadline within tolerance of the given one, or nil when there is none
+	FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*Event, error)
 	GetResolvedEvents(ctx context.Context) ([]*Event, error)
 	UpdateEvent(ctx context.Context, event *Event) error
+	// ResolveEvent resolves an active event; it returns ErrEventAlreadyResolved when the event is no longer active
 	ResolveEvent(ctx context.Context, eventID int64, correctOption int) error
+	// SetResolutionNote stores the evidence or source given for the outcome of an event
+	SetResolutionNote(ctx context.Context, eventID int64, note string) error
+	// SetMinorityCorrect records whether the correct option of a resolved event was a minority pick
+	SetMinorityCorrect(ctx context.Context, eventID int64, minority bool) error
 	GetUserCreatedEventsCount(ctx context.Context, userID int64, groupID int64) (int, error)
 	GetEventsByDeadlineRange(ctx context.Context, start, end time.Time) ([]*Event, error)
+	ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error)
+	GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*Event, error)
+	UnarchiveEvent(ctx context.Context, eventID int64) error
+	UpdateEventCreator(ctx context.Context, eventID int64, createdBy int64) error
+	// SetPollMessageMissing flags or clears an event whose poll message no longer exists in the chat
+	SetPollMessageMissing(ctx context.Context, eventID int64, missing bool) error
+	GetPollMissingEvents(ctx context.Context) ([]*Event, error)
+	// CloseVoting stops accepting votes for an active event; it returns ErrVotingClosed when voting is already closed
+	CloseVoting(ctx context.Context, eventID int64, closedAt time.Time) error
+	// ActivatePendingEvent stores the published poll of an event waiting for approval and makes it active;
+	// it returns ErrEventNotPending when the event is no longer waiting for approval
+	ActivatePendingEvent(ctx context.Context, event *Event) error
+	// RejectPendingEvent cancels an event waiting for approval; it returns ErrEventNotPending when it was already reviewed
+	RejectPendingEvent(ctx context.Context, eventID int64) error
 }
 
 // PredictionRepository interface for prediction operations
 type PredictionRepository interface {
 	SavePrediction(ctx context.Context, prediction *Prediction) error
 	UpdatePrediction(ctx context.Context, prediction *Prediction) error
+	// SetParticipationAwarded records whether the participation point of a vote was credited at vote time
+	SetParticipationAwarded(ctx context.Context, eventID, userID int64, awarded bool) error
 	GetPredictionsByEvent(ctx context.Context, eventID int64) ([]*Prediction, error)
 	GetPredictionByUserAndEvent(ctx context.Context, userID, eventID int64) (*Prediction, error)
 	GetUserPredictions(ctx context.Context, userID int64) ([]*Prediction, error)
 	GetUserCompletedEventCount(ctx context.Context, userID int64, groupID int64) (int, error)
+	// GetUserVotedEventCount counts distinct events the user voted in for a group, resolved or not (cancelled excluded)
+	GetUserVotedEventCount(ctx context.Context, userID int64, groupID int64) (int, error)
+	// GetUserActiveGroupCount counts the distinct groups in which the user has predictions
+	GetUserActiveGroupCount(ctx context.Context, userID int64) (int, error)
+	CountVotesSince(ctx context.Context, eventID int64, since time.Time) (int, error)
+	ImportPredictions(ctx context.Context, groupID int64, rows []*PredictionImportRow, dryRun bool) (*PredictionImportReport, error)
 }
 
 // EventManager manages event operations and business logic
 type EventManager struct {
 	eventRepo      EventRepository
 	predictionRepo PredictionRepository
+	membershipRepo GroupMembershipRepository
 	logger         Logger
 }
 
@@ -55,11 +93,13 @@ type EventManager struct {
 func NewEventManager(
 	eventRepo EventRepository,
 	predictionRepo PredictionRepository,
+	membershipRepo GroupMembershipRepository,
 	logger Logger,
 ) *EventManager {
 	return &EventManager{
 		eventRepo:      eventRepo,
 		predictionRepo: predictionRepo,
+		membershipRepo: membershipRepo,
 		logger:         logger,
 	}
 }
@@ -104,6 +144,30 @@ func (em *EventManager) GetActiveEvents(ctx context.Context, groupID int64) ([]*
 	return events, nil
 }
 
+// GetVisibleActiveEvents retrieves active events of a group that a user may see and vote on
+func (em *EventManager) GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*Event, error) {
+	events, err := em.eventRepo.GetVisibleActiveEvents(ctx, groupID, userID)
+	if err != nil {
+		em.logger.Error("failed to get visible active events", "group_id", groupID, "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	em.logger.Debug("retrieved visible active events", "group_id", groupID, "user_id", userID, "count", len(events))
+	return events, nil
+}
+
+// FindSimilarActiveEvent returns an active event of the group that looks like a duplicate of a new one:
+// the same question ignoring case and spacing, and a deadline within tolerance. It returns nil when there is none.
+func (em *EventManager) FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*Event, error) {
+	event, err := em.eventRepo.FindSimilarActiveEvent(ctx, groupID, question, deadline, tolerance)
+	if err != nil {
+		em.logger.Error("failed to find similar active event", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	return event, nil
+}
+
 // GetEvent retrieves a specific event by ID
 func (em *EventManager) GetEvent(ctx context.Context, eventID int64) (*Event, error) {
 	event, err := em.eventRepo.GetEvent(ctx, eventID)
@@ -119,6 +183,21 @@ func (em *EventManager) GetEvent(ctx context.Context, eventID int64) (*Event, er
 	return event, nil
 }
 
+// GetEventByPollID retrieves the event of a Telegram poll
+func (em *EventManager) GetEventByPollID(ctx context.Context, pollID string) (*Event, error) {
+	event, err := em.eventRepo.GetEventByPollID(ctx, pollID)
+	if err != nil {
+		em.logger.Error("failed to get event by poll ID", "poll_id", pollID, "error", err)
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	return event, nil
+}
+
 // UpdateEvent updates an existing event
 func (em *EventManager) UpdateEvent(ctx context.Context, event *Event) error {
 	// Validate event
@@ -146,7 +225,12 @@ func (em *EventManager) ResolveEvent(ctx context.Context, eventID int64, correct
 	}
 
 	// Check if event is active
-	if event.Status != EventStatusActive {
+	switch event.Status {
+	case EventStatusActive:
+	case EventStatusResolved, EventStatusArchived:
+		em.logger.Warn("attempted to resolve an already resolved event", "event_id", eventID, "status", event.Status)
+		return ErrEventAlreadyResolved
+	default:
 		em.logger.Warn("attempted to resolve non-active event", "event_id", eventID, "status", event.Status)
 		return ErrEventNotActive
 	}
@@ -157,8 +241,13 @@ func (em *EventManager) ResolveEvent(ctx context.Context, eventID int64, correct
 		return ErrInvalidCorrectOpt
 	}
 
-	// Resolve the event
+	// Resolve the event; the repository checks the status again atomically,
+	// so of two concurrent resolutions only one succeeds and gets scored
 	if err := em.eventRepo.ResolveEvent(ctx, eventID, correctOption); err != nil {
+		if errors.Is(err, ErrEventAlreadyResolved) {
+			em.logger.Warn("event was resolved concurrently", "event_id", eventID)
+			return err
+		}
 		em.logger.Error("failed to resolve event", "event_id", eventID, "error", err)
 		return err
 	}
@@ -167,6 +256,157 @@ func (em *EventManager) ResolveEvent(ctx context.Context, eventID int64, correct
 	return nil
 }
 
+// GetArchivedEvents retrieves a page of archived events for a specific group (most recent first)
+func (em *EventManager) GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*Event, error) {
+	events, err := em.eventRepo.GetArchivedEvents(ctx, groupID, limit, offset)
+	if err != nil {
+		em.logger.Error("failed to get archived events", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	em.logger.Debug("retrieved archived events", "group_id", groupID, "count", len(events))
+	return events, nil
+}
+
+// MarkPollMessageMissing records that the poll message of an event was deleted from the chat,
+// so it shows up in the orphaned polls report
+func (em *EventManager) MarkPollMessageMissing(ctx context.Context, eventID int64) error {
+	if err := em.eventRepo.SetPollMessageMissing(ctx, eventID, true); err != nil {
+		em.logger.Error("failed to mark poll message missing", "event_id", eventID, "error", err)
+		return err
+	}
+
+	em.logger.Warn("poll message missing", "event_id", eventID)
+	return nil
+}
+
+// ClearPollMessageMissing removes the missing poll flag, e.g. after the poll was re-posted
+func (em *EventManager) ClearPollMessageMissing(ctx context.Context, eventID int64) error {
+	if err := em.eventRepo.SetPollMessageMissing(ctx, eventID, false); err != nil {
+		em.logger.Error("failed to clear poll message missing", "event_id", eventID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetPollMissingEvents retrieves events whose poll message was found deleted
+func (em *EventManager) GetPollMissingEvents(ctx context.Context) ([]*Event, error) {
+	events, err := em.eventRepo.GetPollMissingEvents(ctx)
+	if err != nil {
+		em.logger.Error("failed to get events with missing polls", "error", err)
+		return nil, err
+	}
+	return events, nil
+}
+
+// CloseVoting stops accepting votes for an active event before its deadline without resolving it,
+// so the predictions are locked in until the outcome is known
+func (em *EventManager) CloseVoting(ctx context.Context, eventID int64) error {
+	event, err := em.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if event.Status != EventStatusActive {
+		em.logger.Warn("attempted to close voting of non-active event", "event_id", eventID, "status", event.Status)
+		return ErrEventNotActive
+	}
+	if !event.IsVotingOpen(time.Now()) {
+		return ErrVotingClosed
+	}
+
+	// The repository checks again atomically, so of two concurrent closes only one succeeds
+	if err := em.eventRepo.CloseVoting(ctx, eventID, time.Now()); err != nil {
+		if !errors.Is(err, ErrVotingClosed) {
+			em.logger.Error("failed to close voting", "event_id", eventID, "error", err)
+		}
+		return err
+	}
+
+	em.logger.Info("voting closed", "event_id", eventID)
+	return nil
+}
+
+// ApproveEvent activates an event that was waiting for approval once its poll has been published.
+// The event must carry the poll ID and message ID.
+func (em *EventManager) ApproveEvent(ctx context.Context, event *Event) error {
+	// The repository checks the status atomically, so an event is approved or rejected only once
+	if err := em.eventRepo.ActivatePendingEvent(ctx, event); err != nil {
+		if !errors.Is(err, ErrEventNotPending) {
+			em.logger.Error("failed to approve event", "event_id", event.ID, "error", err)
+		}
+		return err
+	}
+
+	event.Status = EventStatusActive
+	em.logger.Info("event approved", "event_id", event.ID, "poll_id", event.PollID)
+	return nil
+}
+
+// RejectEvent cancels an event that was waiting for approval, its poll is never posted
+func (em *EventManager) RejectEvent(ctx context.Context, eventID int64) error {
+	if err := em.eventRepo.RejectPendingEvent(ctx, eventID); err != nil {
+		if !errors.Is(err, ErrEventNotPending) {
+			em.logger.Error("failed to reject event", "event_id", eventID, "error", err)
+		}
+		return err
+	}
+
+	em.logger.Info("event rejected", "event_id", eventID)
+	return nil
+}
+
+// UnarchiveEvent restores an archived event back to resolved status
+func (em *EventManager) UnarchiveEvent(ctx context.Context, eventID int64) error {
+	event, err := em.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if event.Status != EventStatusArchived {
+		em.logger.Warn("attempted to unarchive non-archived event", "event_id", eventID, "status", event.Status)
+		return ErrEventNotArchived
+	}
+
+	if err := em.eventRepo.UnarchiveEvent(ctx, eventID); err != nil {
+		em.logger.Error("failed to unarchive event", "event_id", eventID, "error", err)
+		return err
+	}
+
+	em.logger.Info("event unarchived", "event_id", eventID)
+	return nil
+}
+
+// TransferOwnership reassigns an event to a new creator, who must be an active member of the event's group
+func (em *EventManager) TransferOwnership(ctx context.Context, eventID int64, newOwnerID int64) error {
+	event, err := em.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if event.CreatedBy == newOwnerID {
+		return ErrAlreadyOwner
+	}
+
+	membership, err := em.membershipRepo.GetMembership(ctx, event.GroupID, newOwnerID)
+	if err != nil {
+		em.logger.Error("failed to check new owner membership", "event_id", eventID, "user_id", newOwnerID, "error", err)
+		return err
+	}
+	if membership == nil || membership.Status != MembershipStatusActive {
+		em.logger.Warn("ownership transfer rejected: new owner is not a group member", "event_id", eventID, "user_id", newOwnerID, "group_id", event.GroupID)
+		return ErrNewOwnerNotMember
+	}
+
+	if err := em.eventRepo.UpdateEventCreator(ctx, eventID, newOwnerID); err != nil {
+		em.logger.Error("failed to transfer event ownership", "event_id", eventID, "error", err)
+		return err
+	}
+
+	em.logger.Info("event ownership transferred", "event_id", eventID, "old_owner", event.CreatedBy, "new_owner", newOwnerID)
+	return nil
+}
+
 // CanEditEvent checks if an event can be edited (no votes exist)
 func (em *EventManager) CanEditEvent(ctx context.Context, eventID int64) (bool, error) {
 	// Get predictions for this event
@@ -182,3 +422,48 @@ func (em *EventManager) CanEditEvent(ctx context.Context, eventID int64) (bool,
 
 	return canEdit, nil
 }
+
+// CheckVotedEventEdit checks an edit of an event that already has votes. Votes refer to options by
+// position, so the question may be fixed, options may only get typo fixes (see IsOptionTextFix)
+// and the deadline can only be extended. Returns ErrEventHasVotes for any other edit.
+func CheckVotedEventEdit(event *Event, options []string, deadline time.Time) error {
+	if !IsOptionTextFix(event.Options, options) || deadline.Before(event.Deadline) {
+		return ErrEventHasVotes
+	}
+	return nil
+}
+
+// MajorityOption returns the option most participants voted for.
+// ok is false when the event has no votes or several options share the highest vote count.
+func (em *EventManager) MajorityOption(ctx context.Context, eventID int64) (option int, ok bool, err error) {
+	event, err := em.GetEvent(ctx, eventID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	predictions, err := em.predictionRepo.GetPredictionsByEvent(ctx, eventID)
+	if err != nil {
+		em.logger.Error("failed to get predictions for event", "event_id", eventID, "error", err)
+		return 0, false, err
+	}
+
+	votes := make(map[int]int)
+	for _, pred := range event.ParticipantPredictions(predictions) {
+		votes[pred.Option]++
+	}
+
+	best, tie := 0, false
+	for opt, count := range votes {
+		switch {
+		case count > best:
+			option, best, tie = opt, count, false
+		case count == best:
+			tie = true
+		}
+	}
+
+	if best == 0 || tie {
+		return 0, false, nil
+	}
+	return option, true, nil
+}
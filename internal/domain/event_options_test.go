@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+func validMultiOptionEvent(options []string) *Event {
+	now := time.Now()
+	return &Event{
+		GroupID:   1,
+		Question:  "Who wins?",
+		Options:   options,
+		CreatedAt: now,
+		Deadline:  now.Add(time.Hour),
+		CreatedBy: 1,
+		EventType: EventTypeMultiOption,
+	}
+}
+
+func TestProperty_DuplicateOptionsRejected(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+	properties.Property("an option repeated with different case and padding is rejected", prop.ForAll(
+		func(options []string, index int, padding string) bool {
+			duplicate := padding + strings.ToUpper(options[index%len(options)]) + padding
+			options = append(options, duplicate)
+
+			if err := ValidateOptions(options); err != ErrDuplicateOption {
+				return false
+			}
+			if err := validMultiOptionEvent(options).Validate(); err != ErrDuplicateOption {
+				return false
+			}
+
+			// Cleaning keeps the first spelling of every option
+			clean := CleanOptions(options)
+			return len(clean) == len(options)-1 && ValidateOptions(clean) == nil
+		},
+		gen.SliceOfN(3, gen.Identifier()).SuchThat(func(options []string) bool {
+			return ValidateOptions(options) == nil
+		}),
+		gen.IntRange(0, 2),
+		gen.OneConstOf("", " ", "\t", "  "),
+	))
+
+	properties.TestingRun(t)
+}
+
+func TestProperty_BlankOptionsRejected(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+	properties.Property("a blank option is rejected and dropped by cleaning", prop.ForAll(
+		func(options []string, index int, blank string) bool {
+			index %= len(options) + 1
+			withBlank := append(append(append([]string{}, options[:index]...), blank), options[index:]...)
+
+			if err := ValidateOptions(withBlank); err != ErrEmptyOption {
+				return false
+			}
+			if err := validMultiOptionEvent(withBlank).Validate(); err != ErrEmptyOption {
+				return false
+			}
+
+			clean := CleanOptions(withBlank)
+			if len(clean) != len(options) {
+				return false
+			}
+			for i := range clean {
+				if clean[i] != options[i] {
+					return false
+				}
+			}
+			return true
+		},
+		gen.SliceOfN(3, gen.Identifier()).SuchThat(func(options []string) bool {
+			return ValidateOptions(options) == nil
+		}),
+		gen.IntRange(0, 3),
+		gen.OneConstOf("", " ", "\t", " \r"),
+	))
+
+	properties.TestingRun(t)
+}
+
+func TestEventValidate_BinaryOptionsMustDiffer(t *testing.T) {
+	event := validMultiOptionEvent([]string{"Yes", " yes "})
+	event.EventType = EventTypeBinary
+	if err := event.Validate(); err != ErrDuplicateOption {
+		t.Errorf("expected ErrDuplicateOption for identical binary options, got %v", err)
+	}
+
+	event.Options = []string{"Yes", "No"}
+	if err := event.Validate(); err != nil {
+		t.Errorf("expected distinct binary options to be valid, got %v", err)
+	}
+}
+
+func TestEventValidate_PollLimits(t *testing.T) {
+	event := validMultiOptionEvent([]string{"Red", strings.Repeat("ж", MaxPollOptionLength)})
+	if err := event.Validate(); err != nil {
+		t.Errorf("expected options at the limit to be valid, got %v", err)
+	}
+
+	event.Options = []string{"Red", strings.Repeat("ж", MaxPollOptionLength+1)}
+	if err := event.Validate(); err != ErrOptionTooLong {
+		t.Errorf("expected ErrOptionTooLong, got %v", err)
+	}
+
+	event.Options = []string{"Red", "Green"}
+	event.Question = strings.Repeat("?", MaxPollQuestionLength)
+	if err := event.Validate(); err != nil {
+		t.Errorf("expected question at the limit to be valid, got %v", err)
+	}
+
+	event.Question = strings.Repeat("?", MaxPollQuestionLength+1)
+	if err := event.Validate(); err != ErrQuestionTooLong {
+		t.Errorf("expected ErrQuestionTooLong, got %v", err)
+	}
+}
@@ -2,20 +2,39 @@ package domain
 
 import (
 	"context"
-	"errors"
 )
 
 var (
-	ErrUnauthorized              = errors.New("user is not authorized to manage this event")
-	ErrInsufficientParticipation = errors.New("insufficient participation to create events")
+	ErrUnauthorized              = NewError(ErrorKindPermission, "user is not authorized to manage this event")
+	ErrInsufficientParticipation = NewError(ErrorKindPermission, "insufficient participation to create events")
 )
 
+// ParticipationMode selects which votes count as participation for the event creation requirement
+type ParticipationMode string
+
+const (
+	// ParticipationResolved counts events the user voted in that have been resolved (default).
+	// Creation rights are earned only by seeing events through, so a user cannot qualify
+	// by voting in a burst of fresh events, but qualifying takes as long as events take to resolve.
+	ParticipationResolved ParticipationMode = "resolved"
+	// ParticipationVoted counts every event the user voted in, resolved or not (cancelled events
+	// never count). New members qualify quickly, at the cost of counting votes on events
+	// that may never be seen through.
+	ParticipationVoted ParticipationMode = "voted"
+)
+
+// IsValid checks if the participation mode is one of the valid values
+func (m ParticipationMode) IsValid() bool {
+	return m == ParticipationResolved || m == ParticipationVoted
+}
+
 // EventPermissionValidator validates user permissions for event operations
 type EventPermissionValidator struct {
 	eventRepo         EventRepository
 	predictionRepo    PredictionRepository
 	membershipRepo    GroupMembershipRepository
 	minEventsToCreate int
+	participationMode ParticipationMode
 	logger            Logger
 }
 
@@ -32,10 +51,24 @@ func NewEventPermissionValidator(
 		predictionRepo:    predictionRepo,
 		membershipRepo:    membershipRepo,
 		minEventsToCreate: minEventsToCreate,
+		participationMode: ParticipationResolved,
 		logger:            logger,
 	}
 }
 
+// SetParticipationMode selects which votes count toward the event creation requirement.
+// Invalid modes keep the current mode.
+func (v *EventPermissionValidator) SetParticipationMode(mode ParticipationMode) {
+	if mode.IsValid() {
+		v.participationMode = mode
+	}
+}
+
+// ParticipationMode returns the participation definition in use
+func (v *EventPermissionValidator) ParticipationMode() ParticipationMode {
+	return v.participationMode
+}
+
 // CanManageEvent checks if user can resolve/cancel event
 // Returns true if user is the creator or an administrator AND has membership in the event's group
 func (v *EventPermissionValidator) CanManageEvent(ctx context.Context, userID int64, eventID int64, adminIDs []int64) (bool, error) {
@@ -78,7 +111,8 @@ func (v *EventPermissionValidator) CanManageEvent(ctx context.Context, userID in
 	return false, nil
 }
 
-// CanCreateEvent checks if user has participated in enough completed events in a specific group
+// CanCreateEvent checks if user has participated in enough events in a specific group,
+// counting resolved or all voted events depending on the participation mode
 // Returns true if user meets the participation requirement or is an admin AND has membership in the group
 // Also returns the current participation count
 func (v *EventPermissionValidator) CanCreateEvent(ctx context.Context, userID int64, groupID int64, adminIDs []int64) (bool, int, error) {
@@ -100,15 +134,20 @@ func (v *EventPermissionValidator) CanCreateEvent(ctx context.Context, userID in
 		return true, 0, nil
 	}
 
-	// Count user's participation in completed events for this group
-	count, err := v.predictionRepo.GetUserCompletedEventCount(ctx, userID, groupID)
+	// Count user's participation in this group
+	var count int
+	if v.participationMode == ParticipationVoted {
+		count, err = v.predictionRepo.GetUserVotedEventCount(ctx, userID, groupID)
+	} else {
+		count, err = v.predictionRepo.GetUserCompletedEventCount(ctx, userID, groupID)
+	}
 	if err != nil {
-		v.logger.Error("failed to count user completed event participation", "user_id", userID, "group_id", groupID, "error", err)
+		v.logger.Error("failed to count user event participation", "user_id", userID, "group_id", groupID, "mode", v.participationMode, "error", err)
 		return false, 0, err
 	}
 
 	canCreate := count >= v.minEventsToCreate
-	v.logger.Debug("checked if user can create event", "user_id", userID, "participation_count", count, "required", v.minEventsToCreate, "can_create", canCreate)
+	v.logger.Debug("checked if user can create event", "user_id", userID, "participation_count", count, "mode", v.participationMode, "required", v.minEventsToCreate, "can_create", canCreate)
 
 	return canCreate, count, nil
 }
@@ -43,6 +43,20 @@ func (m *mockEventRepoForPermissions) GetActiveEvents(ctx context.Context, group
 	return nil, nil
 }
 
+func (m *mockEventRepoForPermissions) GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*Event, error) {
+	events, err := m.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	var visible []*Event
+	for _, event := range events {
+		if event.IsParticipant(userID) {
+			visible = append(visible, event)
+		}
+	}
+	return visible, nil
+}
+
 func (m *mockEventRepoForPermissions) GetResolvedEvents(ctx context.Context) ([]*Event, error) {
 	return nil, nil
 }
@@ -63,6 +77,57 @@ func (m *mockEventRepoForPermissions) GetEventsByDeadlineRange(ctx context.Conte
 	return nil, nil
 }
 
+func (m *mockEventRepoForPermissions) ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockEventRepoForPermissions) GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepoForPermissions) UnarchiveEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *mockEventRepoForPermissions) SetPollMessageMissing(ctx context.Context, eventID int64, missing bool) error {
+	return nil
+}
+
+func (m *mockEventRepoForPermissions) SetResolutionNote(ctx context.Context, eventID int64, note string) error {
+	return nil
+}
+
+func (m *mockEventRepoForPermissions) SetMinorityCorrect(ctx context.Context, eventID int64, minority bool) error {
+	return nil
+}
+
+func (m *mockEventRepoForPermissions) GetPollMissingEvents(ctx context.Context) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepoForPermissions) CloseVoting(ctx context.Context, eventID int64, closedAt time.Time) error {
+	return nil
+}
+
+func (m *mockEventRepoForPermissions) ActivatePendingEvent(ctx context.Context, event *Event) error {
+	return nil
+}
+
+func (m *mockEventRepoForPermissions) RejectPendingEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *mockEventRepoForPermissions) FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepoForPermissions) UpdateEventCreator(ctx context.Context, eventID int64, createdBy int64) error {
+	if event, ok := m.events[eventID]; ok {
+		event.CreatedBy = createdBy
+	}
+	return nil
+}
+
 // Mock GroupMembershipRepository for permission testing
 type mockGroupMembershipRepoForPermissions struct {
 	memberships map[string]bool // key: "groupID_userID"
@@ -73,7 +138,10 @@ func (m *mockGroupMembershipRepoForPermissions) CreateMembership(ctx context.Con
 }
 
 func (m *mockGroupMembershipRepoForPermissions) GetMembership(ctx context.Context, groupID int64, userID int64) (*GroupMembership, error) {
-	return nil, nil
+	if !m.memberships[formatMembershipKey(groupID, userID)] {
+		return nil, nil
+	}
+	return &GroupMembership{GroupID: groupID, UserID: userID, Status: MembershipStatusActive}, nil
 }
 
 func (m *mockGroupMembershipRepoForPermissions) GetGroupMembers(ctx context.Context, groupID int64) ([]*GroupMembership, error) {
@@ -92,6 +160,18 @@ func (m *mockGroupMembershipRepoForPermissions) HasActiveMembership(ctx context.
 	return m.memberships[key], nil
 }
 
+func (m *mockGroupMembershipRepoForPermissions) CountActiveMembers(ctx context.Context, groupID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *mockGroupMembershipRepoForPermissions) AcceptRules(ctx context.Context, groupID int64, userID int64) error {
+	return nil
+}
+
+func (m *mockGroupMembershipRepoForPermissions) FindInactiveMembers(ctx context.Context, groupID int64, since time.Time) ([]*GroupMembership, error) {
+	return nil, nil
+}
+
 func formatMembershipKey(groupID int64, userID int64) string {
 	return fmt.Sprintf("%d_%d", groupID, userID)
 }
@@ -1181,3 +1261,50 @@ func TestHasGroupMembership(t *testing.T) {
 		})
 	}
 }
+
+// TestTransferOwnership_UpdatesManagePermission tests that a transferred event can be managed by its new owner only
+func TestTransferOwnership_UpdatesManagePermission(t *testing.T) {
+	ctx := context.Background()
+	oldOwnerID := int64(100)
+	newOwnerID := int64(200)
+	outsiderID := int64(300)
+	eventID := int64(1)
+	groupID := int64(1)
+
+	eventRepo := &mockEventRepoForPermissions{
+		events: map[int64]*Event{
+			eventID: {ID: eventID, GroupID: groupID, CreatedBy: oldOwnerID, Status: EventStatusActive},
+		},
+	}
+	membershipRepo := &mockGroupMembershipRepoForPermissions{
+		memberships: map[string]bool{
+			formatMembershipKey(groupID, oldOwnerID): true,
+			formatMembershipKey(groupID, newOwnerID): true,
+		},
+	}
+	manager := NewEventManager(eventRepo, &mockPredictionRepo{}, membershipRepo, &mockLogger{})
+	validator := NewEventPermissionValidator(eventRepo, &mockPredictionRepo{}, membershipRepo, 3, &mockLogger{})
+
+	if err := manager.TransferOwnership(ctx, eventID, outsiderID); err != ErrNewOwnerNotMember {
+		t.Errorf("Expected ErrNewOwnerNotMember for a non-member, got %v", err)
+	}
+	if err := manager.TransferOwnership(ctx, eventID, oldOwnerID); err != ErrAlreadyOwner {
+		t.Errorf("Expected ErrAlreadyOwner for the current owner, got %v", err)
+	}
+	if err := manager.TransferOwnership(ctx, 99, newOwnerID); err != ErrEventNotFound {
+		t.Errorf("Expected ErrEventNotFound for a missing event, got %v", err)
+	}
+
+	if err := manager.TransferOwnership(ctx, eventID, newOwnerID); err != nil {
+		t.Fatalf("TransferOwnership failed: %v", err)
+	}
+
+	canManage, err := validator.CanManageEvent(ctx, newOwnerID, eventID, nil)
+	if err != nil || !canManage {
+		t.Errorf("Expected new owner to manage the event, got %v, %v", canManage, err)
+	}
+	canManage, err = validator.CanManageEvent(ctx, oldOwnerID, eventID, nil)
+	if err != nil || canManage {
+		t.Errorf("Expected previous owner to lose management rights, got %v, %v", canManage, err)
+	}
+}
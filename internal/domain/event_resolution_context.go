@@ -9,15 +9,29 @@ type EventResolutionContext struct {
 	EventID    int64 `json:"event_id"`
 	MessageIDs []int `json:"message_ids"` // All message IDs to delete at the end
 	ChatID     int64 `json:"chat_id"`
+	// The selected outcome while the resolution note is asked for
+	PendingOption  int      `json:"pending_option"`
+	PendingOutcome *float64 `json:"pending_outcome"` // Realized outcome percentage of probability events
+	ResolutionNote string   `json:"resolution_note"`
 }
 
 // ToMap converts EventResolutionContext to a map for JSON serialization
 func (c *EventResolutionContext) ToMap() map[string]interface{} {
-	return map[string]interface{}{
+	m := map[string]interface{}{
 		"event_id":    c.EventID,
 		"message_ids": c.MessageIDs,
 		"chat_id":     c.ChatID,
 	}
+	if c.PendingOption != 0 {
+		m["pending_option"] = c.PendingOption
+	}
+	if c.PendingOutcome != nil {
+		m["pending_outcome"] = *c.PendingOutcome
+	}
+	if c.ResolutionNote != "" {
+		m["resolution_note"] = c.ResolutionNote
+	}
+	return m
 }
 
 // FromMap populates EventResolutionContext from a map after JSON deserialization
@@ -56,6 +70,19 @@ func (c *EventResolutionContext) FromMap(data map[string]interface{}) error {
 		c.ChatID = int64(chatID)
 	}
 
+	// Parse the outcome waiting for the resolution note
+	if option, ok := data["pending_option"].(float64); ok {
+		c.PendingOption = int(option)
+	} else if option, ok := data["pending_option"].(int); ok {
+		c.PendingOption = option
+	}
+	if outcome, ok := data["pending_outcome"].(float64); ok {
+		c.PendingOutcome = &outcome
+	}
+	if note, ok := data["resolution_note"].(string); ok {
+		c.ResolutionNote = note
+	}
+
 	return nil
 }
 
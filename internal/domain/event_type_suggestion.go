@@ -0,0 +1,99 @@
+package domain
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// eventTypeHintsJSON holds the question words per language, kept out of the code like other localized text
+//
+//go:embed event_type_hints.json
+var eventTypeHintsJSON []byte
+
+// eventTypeHints are the words of one language that hint at an event type
+type eventTypeHints struct {
+	// Probability are words asking for a likelihood rather than an answer
+	Probability []string `json:"probability"`
+	// BinaryStarts are first words of yes/no questions
+	BinaryStarts []string `json:"binary_starts"`
+	// MultiOptionStarts are first words of questions with several possible answers
+	MultiOptionStarts []string `json:"multi_option_starts"`
+	// Alternatives separate listed answers ("A or B?")
+	Alternatives []string `json:"alternatives"`
+	// BinaryParticles mark yes/no questions anywhere in the sentence (Russian "li")
+	BinaryParticles []string `json:"binary_particles"`
+}
+
+// questionHints merges the hints of all languages, so a question is recognized in any of them
+var questionHints = loadEventTypeHints()
+
+// loadEventTypeHints parses the embedded hints and merges the languages
+func loadEventTypeHints() eventTypeHints {
+	var byLanguage map[string]eventTypeHints
+	if err := json.Unmarshal(eventTypeHintsJSON, &byLanguage); err != nil {
+		panic("invalid event type hints: " + err.Error())
+	}
+
+	var merged eventTypeHints
+	for _, hints := range byLanguage {
+		merged.Probability = append(merged.Probability, hints.Probability...)
+		merged.BinaryStarts = append(merged.BinaryStarts, hints.BinaryStarts...)
+		merged.MultiOptionStarts = append(merged.MultiOptionStarts, hints.MultiOptionStarts...)
+		merged.Alternatives = append(merged.Alternatives, hints.Alternatives...)
+		merged.BinaryParticles = append(merged.BinaryParticles, hints.BinaryParticles...)
+	}
+	return merged
+}
+
+// SuggestEventType guesses the event type from the wording of a question, in English or Russian.
+// Returns an empty type when the wording gives no hint.
+func SuggestEventType(question string) EventType {
+	words := strings.FieldsFunc(strings.ToLower(question), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '%'
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	if containsAnyWord(words, questionHints.Probability) {
+		return EventTypeProbability
+	}
+
+	// "A or B?" lists the answers
+	if containsAnyWord(words, questionHints.Alternatives) {
+		return EventTypeMultiOption
+	}
+
+	switch {
+	case containsWord(questionHints.BinaryStarts, words[0]):
+		return EventTypeBinary
+	case containsWord(questionHints.MultiOptionStarts, words[0]):
+		return EventTypeMultiOption
+	case containsAnyWord(words, questionHints.BinaryParticles):
+		return EventTypeBinary
+	}
+
+	return ""
+}
+
+// containsWord reports whether words contains word
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAnyWord reports whether words contains any of candidates
+func containsAnyWord(words, candidates []string) bool {
+	for _, c := range candidates {
+		if containsWord(words, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestSuggestEventType(t *testing.T) {
+	tests := []struct {
+		question string
+		expected EventType
+	}{
+		{"Will it rain tomorrow?", EventTypeBinary},
+		{"Is the release shipped by Friday?", EventTypeBinary},
+		{"Which team wins the cup?", EventTypeMultiOption},
+		{"Who will be the next CEO?", EventTypeMultiOption},
+		{"Will Alice or Bob win?", EventTypeMultiOption},
+		{"What is the chance of snow on New Year's Eve?", EventTypeProbability},
+		{"How likely is a rate cut in March?", EventTypeProbability},
+		{"Будет ли дождь завтра?", EventTypeBinary},
+		{"Выиграет ли «Зенит» чемпионат?", EventTypeBinary},
+		{"Какая команда выиграет кубок?", EventTypeMultiOption},
+		{"Кто станет чемпионом: Алиса или Боб?", EventTypeMultiOption},
+		{"Какова вероятность снега в декабре?", EventTypeProbability},
+		{"Шанс, что курс превысит 100?", EventTypeProbability},
+		{"Tomorrow's weather in the city center", ""},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := SuggestEventType(tt.question); got != tt.expected {
+			t.Errorf("SuggestEventType(%q) = %q, want %q", tt.question, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxFeedbackLength limits the length of a single feedback message (in characters)
+	MaxFeedbackLength = 1000
+	// FeedbackUserLimit is the number of feedback messages a user may send within FeedbackWindow
+	FeedbackUserLimit = 3
+	// FeedbackForwardLimit is the number of feedback messages forwarded to admins within FeedbackWindow.
+	// Feedback beyond the limit is still stored and can be reviewed with /feedback_list.
+	FeedbackForwardLimit = 20
+	// FeedbackWindow is the period of the per-user rate limit and the admin forwarding throttle
+	FeedbackWindow = time.Hour
+)
+
+var (
+	ErrFeedbackEmpty       = NewError(ErrorKindValidation, "feedback text is empty")
+	ErrFeedbackTooLong     = NewError(ErrorKindValidation, "feedback text is too long")
+	ErrFeedbackRateLimited = NewError(ErrorKindConflict, "feedback rate limit exceeded")
+)
+
+// Feedback is a bug report or suggestion sent by a user
+type Feedback struct {
+	ID          int64
+	UserID      int64
+	DisplayName string
+	Text        string
+	CreatedAt   time.Time
+}
+
+// FeedbackRepository interface for feedback storage
+type FeedbackRepository interface {
+	SaveFeedback(ctx context.Context, feedback *Feedback) error
+	CountUserFeedbackSince(ctx context.Context, userID int64, since time.Time) (int, error)
+	GetRecentFeedback(ctx context.Context, limit int) ([]*Feedback, error)
+}
+
+// FeedbackNotifier delivers feedback to admins
+type FeedbackNotifier interface {
+	SendFeedbackNotification(ctx context.Context, adminIDs []int64, feedback *Feedback) error
+}
+
+// FeedbackService stores user feedback and forwards it to admins
+type FeedbackService struct {
+	feedbackRepo FeedbackRepository
+	notifier     FeedbackNotifier
+	adminIDs     []int64
+	logger       Logger
+
+	mu        sync.Mutex
+	forwarded []time.Time // forwarding times within the current window
+	now       func() time.Time
+}
+
+// NewFeedbackService creates a new FeedbackService
+func NewFeedbackService(
+	feedbackRepo FeedbackRepository,
+	notifier FeedbackNotifier,
+	adminIDs []int64,
+	logger Logger,
+) *FeedbackService {
+	return &FeedbackService{
+		feedbackRepo: feedbackRepo,
+		notifier:     notifier,
+		adminIDs:     adminIDs,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// SubmitFeedback validates, rate-limits and stores feedback, then forwards it to admins.
+// Forwarding failures are logged but not returned: the feedback is already stored.
+func (s *FeedbackService) SubmitFeedback(ctx context.Context, userID int64, displayName string, text string) (*Feedback, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrFeedbackEmpty
+	}
+	if utf8.RuneCountInString(text) > MaxFeedbackLength {
+		return nil, ErrFeedbackTooLong
+	}
+
+	now := s.now()
+	count, err := s.feedbackRepo.CountUserFeedbackSince(ctx, userID, now.Add(-FeedbackWindow))
+	if err != nil {
+		s.logger.Error("failed to count user feedback", "user_id", userID, "error", err)
+		return nil, err
+	}
+	if count >= FeedbackUserLimit {
+		s.logger.Warn("feedback rate limit exceeded", "user_id", userID, "count", count)
+		return nil, ErrFeedbackRateLimited
+	}
+
+	feedback := &Feedback{
+		UserID:      userID,
+		DisplayName: displayName,
+		Text:        text,
+		CreatedAt:   now,
+	}
+	if err := s.feedbackRepo.SaveFeedback(ctx, feedback); err != nil {
+		s.logger.Error("failed to save feedback", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	if !s.allowForward(now) {
+		s.logger.Warn("feedback forwarding throttled, stored only", "feedback_id", feedback.ID, "user_id", userID)
+		return feedback, nil
+	}
+
+	if err := s.notifier.SendFeedbackNotification(ctx, s.adminIDs, feedback); err != nil {
+		s.logger.Error("failed to forward feedback to admins", "feedback_id", feedback.ID, "error", err)
+	}
+
+	s.logger.Info("feedback received", "feedback_id", feedback.ID, "user_id", userID)
+	return feedback, nil
+}
+
+// RecentFeedback returns the most recent feedback, newest first
+func (s *FeedbackService) RecentFeedback(ctx context.Context, limit int) ([]*Feedback, error) {
+	return s.feedbackRepo.GetRecentFeedback(ctx, limit)
+}
+
+// allowForward reports whether another feedback may be forwarded to admins and records it
+func (s *FeedbackService) allowForward(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := now.Add(-FeedbackWindow)
+	recent := s.forwarded[:0]
+	for _, t := range s.forwarded {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	s.forwarded = recent
+
+	if len(s.forwarded) >= FeedbackForwardLimit {
+		return false
+	}
+	s.forwarded = append(s.forwarded, now)
+	return true
+}
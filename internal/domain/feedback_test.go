@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockFeedbackRepo keeps feedback in memory
+type mockFeedbackRepo struct {
+	feedbacks []*Feedback
+}
+
+func (m *mockFeedbackRepo) SaveFeedback(ctx context.Context, feedback *Feedback) error {
+	feedback.ID = int64(len(m.feedbacks) + 1)
+	m.feedbacks = append(m.feedbacks, feedback)
+	return nil
+}
+
+func (m *mockFeedbackRepo) CountUserFeedbackSince(ctx context.Context, userID int64, since time.Time) (int, error) {
+	count := 0
+	for _, feedback := range m.feedbacks {
+		if feedback.UserID == userID && feedback.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockFeedbackRepo) GetRecentFeedback(ctx context.Context, limit int) ([]*Feedback, error) {
+	return m.feedbacks, nil
+}
+
+// mockFeedbackNotifier records forwarded feedback
+type mockFeedbackNotifier struct {
+	forwarded []*Feedback
+}
+
+func (m *mockFeedbackNotifier) SendFeedbackNotification(ctx context.Context, adminIDs []int64, feedback *Feedback) error {
+	m.forwarded = append(m.forwarded, feedback)
+	return nil
+}
+
+func TestFeedbackService_SubmitFeedback(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockFeedbackRepo{}
+	notifier := &mockFeedbackNotifier{}
+	service := NewFeedbackService(repo, notifier, []int64{1}, &mockLogger{})
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	if _, err := service.SubmitFeedback(ctx, 10, "@user", "   "); !errors.Is(err, ErrFeedbackEmpty) {
+		t.Errorf("expected ErrFeedbackEmpty, got %v", err)
+	}
+	if _, err := service.SubmitFeedback(ctx, 10, "@user", strings.Repeat("я", MaxFeedbackLength+1)); !errors.Is(err, ErrFeedbackTooLong) {
+		t.Errorf("expected ErrFeedbackTooLong, got %v", err)
+	}
+
+	for i := 0; i < FeedbackUserLimit; i++ {
+		feedback, err := service.SubmitFeedback(ctx, 10, "@user", " bug report ")
+		if err != nil {
+			t.Fatalf("submission %d failed: %v", i+1, err)
+		}
+		if feedback.Text != "bug report" || feedback.DisplayName != "@user" {
+			t.Errorf("unexpected feedback: %+v", feedback)
+		}
+	}
+
+	// The user is rate-limited, other users are not
+	if _, err := service.SubmitFeedback(ctx, 10, "@user", "one more"); !errors.Is(err, ErrFeedbackRateLimited) {
+		t.Errorf("expected ErrFeedbackRateLimited, got %v", err)
+	}
+	if _, err := service.SubmitFeedback(ctx, 11, "@other", "idea"); err != nil {
+		t.Errorf("expected another user to pass, got %v", err)
+	}
+
+	// The limit resets after the window
+	now = now.Add(FeedbackWindow)
+	if _, err := service.SubmitFeedback(ctx, 10, "@user", "later"); err != nil {
+		t.Errorf("expected submission after the window to pass, got %v", err)
+	}
+
+	if len(repo.feedbacks) != FeedbackUserLimit+2 || len(notifier.forwarded) != FeedbackUserLimit+2 {
+		t.Errorf("expected %d stored and forwarded, got %d stored and %d forwarded",
+			FeedbackUserLimit+2, len(repo.feedbacks), len(notifier.forwarded))
+	}
+}
+
+func TestFeedbackService_ForwardingThrottle(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockFeedbackRepo{}
+	notifier := &mockFeedbackNotifier{}
+	service := NewFeedbackService(repo, notifier, []int64{1}, &mockLogger{})
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	// Each user sends one message, so only the global forwarding throttle applies
+	for userID := int64(1); userID <= FeedbackForwardLimit+5; userID++ {
+		if _, err := service.SubmitFeedback(ctx, userID, "", "feedback"); err != nil {
+			t.Fatalf("submission from user %d failed: %v", userID, err)
+		}
+	}
+
+	if len(repo.feedbacks) != FeedbackForwardLimit+5 {
+		t.Errorf("expected all %d feedbacks to be stored, got %d", FeedbackForwardLimit+5, len(repo.feedbacks))
+	}
+	if len(notifier.forwarded) != FeedbackForwardLimit {
+		t.Errorf("expected %d feedbacks to be forwarded, got %d", FeedbackForwardLimit, len(notifier.forwarded))
+	}
+
+	// Forwarding resumes after the window
+	now = now.Add(FeedbackWindow + time.Second)
+	if _, err := service.SubmitFeedback(ctx, 1000, "", "feedback"); err != nil {
+		t.Fatalf("submission failed: %v", err)
+	}
+	if len(notifier.forwarded) != FeedbackForwardLimit+1 {
+		t.Errorf("expected forwarding to resume, got %d forwarded", len(notifier.forwarded))
+	}
+}
@@ -2,12 +2,13 @@ package domain
 
 import (
 	"context"
-	"errors"
+	"time"
 )
 
 var (
-	ErrNoGroupMembership        = errors.New("user has no group memberships")
-	ErrMultipleGroupsNeedChoice = errors.New("user has multiple groups, selection required")
+	ErrNoGroupMembership        = NewError(ErrorKindNotFound, "user has no group memberships")
+	ErrMultipleGroupsNeedChoice = NewError(ErrorKindValidation, "user has multiple groups, selection required")
+	ErrMergeSameGroup           = NewError(ErrorKindValidation, "cannot merge a group into itself")
 )
 
 // GroupRepository interface for group operations
@@ -20,6 +21,19 @@ type GroupRepository interface {
 	DeleteGroup(ctx context.Context, groupID int64) error
 	UpdateGroupStatus(ctx context.Context, groupID int64, status GroupStatus) error
 	UpdateGroupName(ctx context.Context, groupID int64, name string) error
+	UpdateGroupPinPolls(ctx context.Context, groupID int64, pinPolls bool) error
+	UpdateGroupDefaultEventType(ctx context.Context, groupID int64, eventType EventType) error
+	UpdateGroupRequireRules(ctx context.Context, groupID int64, requireRules bool) error
+	UpdateGroupAutoRemoveInactive(ctx context.Context, groupID int64, autoRemove bool) error
+	UpdateGroupMaxMembers(ctx context.Context, groupID int64, maxMembers *int) error
+	UpdateGroupReputationWeighting(ctx context.Context, groupID int64, reputationWeighting bool) error
+	UpdateGroupRequireApproval(ctx context.Context, groupID int64, requireApproval bool) error
+	UpdateGroupPointsLabel(ctx context.Context, groupID int64, pointsLabel string) error
+	UpdateGroupFirstVoteFinal(ctx context.Context, groupID int64, firstVoteFinal bool) error
+	UpdateGroupExcludeCreatorScoring(ctx context.Context, groupID int64, excludeCreatorScoring bool) error
+	UpdateGroupDisabledAchievements(ctx context.Context, groupID int64, codes []AchievementCode) error
+	UpdateGroupArchiveDays(ctx context.Context, groupID int64, archiveDays *int) error
+	MergeGroups(ctx context.Context, sourceGroupID int64, targetGroupID int64) error
 }
 
 // GroupMembershipRepository interface for group membership operations
@@ -29,6 +43,9 @@ type GroupMembershipRepository interface {
 	GetGroupMembers(ctx context.Context, groupID int64) ([]*GroupMembership, error)
 	UpdateMembershipStatus(ctx context.Context, groupID int64, userID int64, status MembershipStatus) error
 	HasActiveMembership(ctx context.Context, groupID int64, userID int64) (bool, error)
+	CountActiveMembers(ctx context.Context, groupID int64) (int, error)
+	AcceptRules(ctx context.Context, groupID int64, userID int64) error
+	FindInactiveMembers(ctx context.Context, groupID int64, since time.Time) ([]*GroupMembership, error)
 }
 
 // ForumTopicRepository interface for forum topic operations
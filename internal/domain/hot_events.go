@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// HotEvent is an active event with its recent and total vote counts
+type HotEvent struct {
+	Event       *Event
+	RecentVotes int
+	TotalVotes  int
+}
+
+// RankHotEvents orders events by recent votes, then total votes, then soonest deadline.
+// Events past their deadline or without recent votes are dropped. A positive limit caps the result.
+func RankHotEvents(events []*HotEvent, now time.Time, limit int) []*HotEvent {
+	var ranked []*HotEvent
+	for _, hot := range events {
+		if hot.RecentVotes > 0 && hot.Event.Deadline.After(now) {
+			ranked = append(ranked, hot)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.RecentVotes != b.RecentVotes {
+			return a.RecentVotes > b.RecentVotes
+		}
+		if a.TotalVotes != b.TotalVotes {
+			return a.TotalVotes > b.TotalVotes
+		}
+		return a.Event.Deadline.Before(b.Event.Deadline)
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// GetHotEvents ranks the active events a user may see in the given groups by votes cast within window before now
+func (em *EventManager) GetHotEvents(ctx context.Context, userID int64, groupIDs []int64, window time.Duration, now time.Time, limit int) ([]*HotEvent, error) {
+	since := now.Add(-window)
+
+	var candidates []*HotEvent
+	for _, groupID := range groupIDs {
+		events, err := em.GetVisibleActiveEvents(ctx, groupID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			if !event.Deadline.After(now) {
+				continue
+			}
+
+			recent, err := em.predictionRepo.CountVotesSince(ctx, event.ID, since)
+			if err != nil {
+				em.logger.Error("failed to count recent votes", "event_id", event.ID, "error", err)
+				return nil, err
+			}
+			if recent == 0 {
+				continue
+			}
+
+			total, err := em.predictionRepo.CountVotesSince(ctx, event.ID, time.Time{})
+			if err != nil {
+				em.logger.Error("failed to count votes", "event_id", event.ID, "error", err)
+				return nil, err
+			}
+
+			candidates = append(candidates, &HotEvent{Event: event, RecentVotes: recent, TotalVotes: total})
+		}
+	}
+
+	return RankHotEvents(candidates, now, limit), nil
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRankHotEvents(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	event := func(id int64, deadlineIn time.Duration) *Event {
+		return &Event{ID: id, Deadline: now.Add(deadlineIn)}
+	}
+
+	ranked := RankHotEvents([]*HotEvent{
+		{Event: event(1, 48*time.Hour), RecentVotes: 3, TotalVotes: 10},
+		{Event: event(2, 48*time.Hour), RecentVotes: 5, TotalVotes: 5},
+		{Event: event(3, 72*time.Hour), RecentVotes: 3, TotalVotes: 12},
+		{Event: event(4, 24*time.Hour), RecentVotes: 3, TotalVotes: 10},
+		{Event: event(5, -time.Hour), RecentVotes: 9, TotalVotes: 9},
+		{Event: event(6, 24*time.Hour), RecentVotes: 0, TotalVotes: 20},
+	}, now, 0)
+
+	// Recent votes first, then total votes, then the soonest deadline; expired and quiet events are dropped
+	expected := []int64{2, 3, 4, 1}
+	if len(ranked) != len(expected) {
+		t.Fatalf("expected %d ranked events, got %d", len(expected), len(ranked))
+	}
+	for i, id := range expected {
+		if ranked[i].Event.ID != id {
+			t.Errorf("position %d: expected event %d, got %d", i+1, id, ranked[i].Event.ID)
+		}
+	}
+
+	if limited := RankHotEvents([]*HotEvent{
+		{Event: event(1, time.Hour), RecentVotes: 1},
+		{Event: event(2, time.Hour), RecentVotes: 2},
+	}, now, 1); len(limited) != 1 || limited[0].Event.ID != 2 {
+		t.Errorf("expected only event 2 with limit 1, got %v", limited)
+	}
+}
+
+func TestEventManager_GetHotEvents(t *testing.T) {
+	now := time.Now()
+	eventRepo := &MockEventRepoWithEvents{events: []*Event{
+		{ID: 1, GroupID: 1, Status: EventStatusActive, Deadline: now.Add(24 * time.Hour)},
+		{ID: 2, GroupID: 1, Status: EventStatusActive, Deadline: now.Add(48 * time.Hour)},
+		{ID: 3, GroupID: 1, Status: EventStatusActive, Deadline: now.Add(-time.Hour)},
+		{ID: 4, GroupID: 1, Status: EventStatusActive, Deadline: now.Add(24 * time.Hour), Participants: []int64{99}},
+	}}
+	vote := func(eventID int64, age time.Duration) *Prediction {
+		return &Prediction{EventID: eventID, Timestamp: now.Add(-age)}
+	}
+	predictionRepo := &MockPredictionRepoWithData{predictions: []*Prediction{
+		vote(1, time.Hour), vote(1, 30*time.Hour), vote(1, 40*time.Hour),
+		vote(2, time.Hour), vote(2, 2*time.Hour),
+		vote(3, time.Hour), vote(3, time.Hour), vote(3, time.Hour),
+		vote(4, time.Hour), vote(4, time.Hour), vote(4, time.Hour),
+	}}
+	em := NewEventManager(eventRepo, predictionRepo, nil, &MockLogger{})
+
+	hot, err := em.GetHotEvents(context.Background(), 10, []int64{1}, 24*time.Hour, now, 10)
+	if err != nil {
+		t.Fatalf("GetHotEvents failed: %v", err)
+	}
+
+	// The expired event and the restricted event the user can't see are left out
+	if len(hot) != 2 {
+		t.Fatalf("expected 2 hot events, got %d", len(hot))
+	}
+	if hot[0].Event.ID != 2 || hot[0].RecentVotes != 2 || hot[0].TotalVotes != 2 {
+		t.Errorf("expected event 2 first with 2 recent of 2 votes, got %+v", hot[0])
+	}
+	if hot[1].Event.ID != 1 || hot[1].RecentVotes != 1 || hot[1].TotalVotes != 3 {
+		t.Errorf("expected event 1 second with 1 recent of 3 votes, got %+v", hot[1])
+	}
+}
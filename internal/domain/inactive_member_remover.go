@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// InactiveMemberNotifier tells a removed member how to rejoin the group
+type InactiveMemberNotifier interface {
+	SendInactiveRemovalNotification(ctx context.Context, userID int64, group *Group, rejoinLink string) error
+}
+
+// InactiveMemberRemover periodically removes members who haven't voted for a long time from groups
+// that opted in. Bot admins and group creators are never removed.
+type InactiveMemberRemover struct {
+	groupRepo       GroupRepository
+	membershipRepo  GroupMembershipRepository
+	deepLinkService *DeepLinkService
+	notifier        InactiveMemberNotifier
+	adminUserIDs    []int64
+	window          time.Duration
+	interval        time.Duration
+	logger          Logger
+	now             func() time.Time
+}
+
+// NewInactiveMemberRemover creates a new InactiveMemberRemover.
+// A non-positive window disables the removal.
+func NewInactiveMemberRemover(
+	groupRepo GroupRepository,
+	membershipRepo GroupMembershipRepository,
+	deepLinkService *DeepLinkService,
+	notifier InactiveMemberNotifier,
+	adminUserIDs []int64,
+	window time.Duration,
+	logger Logger,
+) *InactiveMemberRemover {
+	return &InactiveMemberRemover{
+		groupRepo:       groupRepo,
+		membershipRepo:  membershipRepo,
+		deepLinkService: deepLinkService,
+		notifier:        notifier,
+		adminUserIDs:    adminUserIDs,
+		window:          window,
+		interval:        24 * time.Hour,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// RemoveInactiveMembers removes members inactive for longer than the window from all groups
+// with auto-removal enabled and returns the number of removed members
+func (r *InactiveMemberRemover) RemoveInactiveMembers(ctx context.Context) (int, error) {
+	if r.window <= 0 {
+		return 0, nil
+	}
+
+	groups, err := r.groupRepo.GetAllGroups(ctx)
+	if err != nil {
+		r.logger.Error("failed to get groups for inactive member removal", "error", err)
+		return 0, err
+	}
+
+	since := r.now().Add(-r.window)
+	removed := 0
+	for _, group := range groups {
+		if !group.AutoRemoveInactive || group.Status != GroupStatusActive {
+			continue
+		}
+
+		members, err := r.membershipRepo.FindInactiveMembers(ctx, group.ID, since)
+		if err != nil {
+			r.logger.Error("failed to find inactive members", "group_id", group.ID, "error", err)
+			continue
+		}
+
+		for _, member := range members {
+			if r.isProtected(group, member.UserID) {
+				continue
+			}
+
+			if err := r.membershipRepo.UpdateMembershipStatus(ctx, group.ID, member.UserID, MembershipStatusRemoved); err != nil {
+				r.logger.Error("failed to remove inactive member", "group_id", group.ID, "user_id", member.UserID, "error", err)
+				continue
+			}
+			removed++
+
+			r.logger.Info("inactive member removed",
+				"group_id", group.ID,
+				"group_name", group.Name,
+				"user_id", member.UserID,
+				"inactive_since", since,
+				"timestamp", r.now(),
+			)
+
+			r.notifyRemoved(ctx, group, member.UserID)
+		}
+	}
+
+	if removed > 0 {
+		r.logger.Info("inactive members removed", "count", removed, "since", since)
+	}
+	return removed, nil
+}
+
+// StartScheduler runs the removal once on startup and then daily until ctx is cancelled
+func (r *InactiveMemberRemover) StartScheduler(ctx context.Context) {
+	if r.window <= 0 {
+		r.logger.Info("inactive member removal disabled")
+		return
+	}
+
+	_, _ = r.RemoveInactiveMembers(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Info("inactive member remover stopped")
+				return
+			case <-ticker.C:
+				_, _ = r.RemoveInactiveMembers(ctx)
+			}
+		}
+	}()
+
+	r.logger.Info("inactive member remover started", "window", r.window)
+}
+
+// isProtected reports whether the user must never be removed automatically
+func (r *InactiveMemberRemover) isProtected(group *Group, userID int64) bool {
+	if userID == group.CreatedBy {
+		return true
+	}
+	for _, adminID := range r.adminUserIDs {
+		if adminID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// notifyRemoved sends the removed member a deep-link to rejoin the group
+func (r *InactiveMemberRemover) notifyRemoved(ctx context.Context, group *Group, userID int64) {
+	rejoinLink, err := r.deepLinkService.GenerateGroupInviteLink(group.ID)
+	if err != nil {
+		r.logger.Error("failed to generate rejoin link", "group_id", group.ID, "error", err)
+		return
+	}
+
+	if err := r.notifier.SendInactiveRemovalNotification(ctx, userID, group, rejoinLink); err != nil {
+		r.logger.Warn("failed to notify removed member", "group_id", group.ID, "user_id", userID, "error", err)
+	}
+}
@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockGroupRepoForRemover serves a fixed list of groups
+type mockGroupRepoForRemover struct {
+	groups []*Group
+}
+
+func (m *mockGroupRepoForRemover) CreateGroup(ctx context.Context, group *Group) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) GetGroup(ctx context.Context, groupID int64) (*Group, error) {
+	for _, group := range m.groups {
+		if group.ID == groupID {
+			return group, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockGroupRepoForRemover) GetGroupByTelegramChatID(ctx context.Context, telegramChatID int64) (*Group, error) {
+	return nil, nil
+}
+
+func (m *mockGroupRepoForRemover) GetAllGroups(ctx context.Context) ([]*Group, error) {
+	return m.groups, nil
+}
+
+func (m *mockGroupRepoForRemover) GetUserGroups(ctx context.Context, userID int64) ([]*Group, error) {
+	return nil, nil
+}
+
+func (m *mockGroupRepoForRemover) DeleteGroup(ctx context.Context, groupID int64) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupStatus(ctx context.Context, groupID int64, status GroupStatus) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupName(ctx context.Context, groupID int64, name string) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupPinPolls(ctx context.Context, groupID int64, pinPolls bool) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupDefaultEventType(ctx context.Context, groupID int64, eventType EventType) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupRequireRules(ctx context.Context, groupID int64, requireRules bool) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupAutoRemoveInactive(ctx context.Context, groupID int64, autoRemove bool) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupMaxMembers(ctx context.Context, groupID int64, maxMembers *int) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupReputationWeighting(ctx context.Context, groupID int64, reputationWeighting bool) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupRequireApproval(ctx context.Context, groupID int64, requireApproval bool) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupPointsLabel(ctx context.Context, groupID int64, pointsLabel string) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupFirstVoteFinal(ctx context.Context, groupID int64, firstVoteFinal bool) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupExcludeCreatorScoring(ctx context.Context, groupID int64, excludeCreatorScoring bool) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupDisabledAchievements(ctx context.Context, groupID int64, codes []AchievementCode) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) UpdateGroupArchiveDays(ctx context.Context, groupID int64, archiveDays *int) error {
+	return nil
+}
+
+func (m *mockGroupRepoForRemover) MergeGroups(ctx context.Context, sourceGroupID int64, targetGroupID int64) error {
+	return nil
+}
+
+// mockMembershipRepoForRemover reports fixed inactive members per group and records removals
+type mockMembershipRepoForRemover struct {
+	mockGroupMembershipRepoForPermissions
+	inactive map[int64][]int64
+	since    time.Time
+	removed  []string
+	failFor  int64
+}
+
+func (m *mockMembershipRepoForRemover) FindInactiveMembers(ctx context.Context, groupID int64, since time.Time) ([]*GroupMembership, error) {
+	m.since = since
+	var members []*GroupMembership
+	for _, userID := range m.inactive[groupID] {
+		members = append(members, &GroupMembership{GroupID: groupID, UserID: userID, Status: MembershipStatusActive})
+	}
+	return members, nil
+}
+
+func (m *mockMembershipRepoForRemover) UpdateMembershipStatus(ctx context.Context, groupID int64, userID int64, status MembershipStatus) error {
+	if userID == m.failFor {
+		return errors.New("database is locked")
+	}
+	m.removed = append(m.removed, formatMembershipKey(groupID, userID)+":"+string(status))
+	return nil
+}
+
+// mockInactiveMemberNotifier records removal notifications
+type mockInactiveMemberNotifier struct {
+	notified []string
+}
+
+func (m *mockInactiveMemberNotifier) SendInactiveRemovalNotification(ctx context.Context, userID int64, group *Group, rejoinLink string) error {
+	m.notified = append(m.notified, formatMembershipKey(group.ID, userID)+" "+rejoinLink)
+	return nil
+}
+
+// mockEncoderForRemover encodes IDs as decimal strings
+type mockEncoderForRemover struct{}
+
+func (mockEncoderForRemover) Encode(num int64) (string, error) {
+	return strconv.FormatInt(num, 10), nil
+}
+
+func (mockEncoderForRemover) Decode(encoded string) (int64, error) {
+	return strconv.ParseInt(encoded, 10, 64)
+}
+
+func TestInactiveMemberRemover_RemoveInactiveMembers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	adminID := int64(100)
+
+	groupRepo := &mockGroupRepoForRemover{groups: []*Group{
+		{ID: 1, Name: "Opted in", CreatedBy: 200, Status: GroupStatusActive, AutoRemoveInactive: true},
+		{ID: 2, Name: "Opted out", CreatedBy: 200, Status: GroupStatusActive},
+		{ID: 3, Name: "Deleted", CreatedBy: 200, Status: GroupStatusDeleted, AutoRemoveInactive: true},
+	}}
+	membershipRepo := &mockMembershipRepoForRemover{
+		inactive: map[int64][]int64{
+			1: {1, adminID, 200, 2, 3},
+			2: {1},
+			3: {1},
+		},
+		failFor: 3,
+	}
+	notifier := &mockInactiveMemberNotifier{}
+
+	remover := NewInactiveMemberRemover(groupRepo, membershipRepo, NewDeepLinkService("testbot", mockEncoderForRemover{}),
+		notifier, []int64{adminID}, 180*24*time.Hour, &mockLogger{})
+	remover.now = func() time.Time { return now }
+
+	removed, err := remover.RemoveInactiveMembers(ctx)
+	if err != nil {
+		t.Fatalf("RemoveInactiveMembers failed: %v", err)
+	}
+
+	// The admin and the group creator are kept, the failed removal is not counted
+	if removed != 2 {
+		t.Errorf("expected 2 removed members, got %d", removed)
+	}
+	if expected := []string{"1_1:removed", "1_2:removed"}; strings.Join(membershipRepo.removed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected removals %v, got %v", expected, membershipRepo.removed)
+	}
+	if expected := []string{"1_1 https://t.me/testbot?start=group_1", "1_2 https://t.me/testbot?start=group_1"}; strings.Join(notifier.notified, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected notifications %v, got %v", expected, notifier.notified)
+	}
+	if expected := now.Add(-180 * 24 * time.Hour); !membershipRepo.since.Equal(expected) {
+		t.Errorf("expected inactivity cutoff %v, got %v", expected, membershipRepo.since)
+	}
+}
+
+func TestInactiveMemberRemover_Disabled(t *testing.T) {
+	groupRepo := &mockGroupRepoForRemover{groups: []*Group{
+		{ID: 1, Name: "Opted in", Status: GroupStatusActive, AutoRemoveInactive: true},
+	}}
+	membershipRepo := &mockMembershipRepoForRemover{inactive: map[int64][]int64{1: {1}}}
+
+	remover := NewInactiveMemberRemover(groupRepo, membershipRepo, NewDeepLinkService("testbot", mockEncoderForRemover{}),
+		&mockInactiveMemberNotifier{}, nil, 0, &mockLogger{})
+
+	removed, err := remover.RemoveInactiveMembers(context.Background())
+	if err != nil || removed != 0 || len(membershipRepo.removed) != 0 {
+		t.Errorf("expected no removals with a zero window, got %d, %v, %v", removed, err, membershipRepo.removed)
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+)
+
+// SettingMaintenanceMode is the settings key of the persisted maintenance flag
+const SettingMaintenanceMode = "maintenance_mode"
+
+// SettingsRepository interface for persisted runtime settings
+type SettingsRepository interface {
+	GetSetting(ctx context.Context, key string) (string, bool, error)
+	SetSetting(ctx context.Context, key string, value string) error
+}
+
+// MaintenanceMode holds the global maintenance flag.
+// While enabled, the bot only serves admins; the flag is persisted so it survives restarts.
+type MaintenanceMode struct {
+	settingsRepo SettingsRepository
+	enabled      atomic.Bool
+	logger       Logger
+}
+
+// NewMaintenanceMode creates a MaintenanceMode and loads the persisted flag.
+// forceEnabled turns maintenance on at startup regardless of the persisted value.
+func NewMaintenanceMode(ctx context.Context, settingsRepo SettingsRepository, forceEnabled bool, logger Logger) (*MaintenanceMode, error) {
+	m := &MaintenanceMode{
+		settingsRepo: settingsRepo,
+		logger:       logger,
+	}
+
+	value, found, err := settingsRepo.GetSetting(ctx, SettingMaintenanceMode)
+	if err != nil {
+		logger.Error("failed to load maintenance mode", "error", err)
+		return nil, err
+	}
+
+	enabled := forceEnabled
+	if found && !enabled {
+		enabled, _ = strconv.ParseBool(value)
+	}
+	m.enabled.Store(enabled)
+
+	return m, nil
+}
+
+// Enabled reports whether maintenance mode is on. A nil MaintenanceMode is always off.
+func (m *MaintenanceMode) Enabled() bool {
+	if m == nil {
+		return false
+	}
+	return m.enabled.Load()
+}
+
+// SetEnabled persists and applies the maintenance flag
+func (m *MaintenanceMode) SetEnabled(ctx context.Context, enabled bool) error {
+	if err := m.settingsRepo.SetSetting(ctx, SettingMaintenanceMode, strconv.FormatBool(enabled)); err != nil {
+		m.logger.Error("failed to persist maintenance mode", "enabled", enabled, "error", err)
+		return err
+	}
+
+	m.enabled.Store(enabled)
+	m.logger.Info("maintenance mode changed", "enabled", enabled)
+	return nil
+}
@@ -2,14 +2,27 @@ package domain
 
 import (
 	"errors"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
+)
+
+// Telegram poll limits, in characters
+const (
+	MaxPollQuestionLength = 300
+	MaxPollOptionLength   = 100
 )
 
 // Validation errors
 var (
 	ErrEmptyQuestion             = errors.New("question cannot be empty")
+	ErrQuestionTooLong           = errors.New("question is longer than the poll question limit")
 	ErrInsufficientOptions       = errors.New("must have at least 2 options")
 	ErrTooManyOptions            = errors.New("cannot have more than 6 options")
+	ErrEmptyOption               = errors.New("option cannot be empty")
+	ErrOptionTooLong             = errors.New("option is longer than the poll option limit")
+	ErrDuplicateOption           = errors.New("options must be unique")
 	ErrInvalidDeadline           = errors.New("deadline must be after creation time")
 	ErrInvalidCreator            = errors.New("creator ID must be set")
 	ErrInvalidBinaryOptions      = errors.New("binary event must have exactly 2 options")
@@ -34,9 +47,11 @@ var (
 type EventStatus string
 
 const (
-	EventStatusActive    EventStatus = "active"
-	EventStatusResolved  EventStatus = "resolved"
-	EventStatusCancelled EventStatus = "cancelled"
+	EventStatusActive          EventStatus = "active"
+	EventStatusResolved        EventStatus = "resolved"
+	EventStatusCancelled       EventStatus = "cancelled"
+	EventStatusArchived        EventStatus = "archived"         // Resolved event hidden from default queries after the retention window
+	EventStatusPendingApproval EventStatus = "pending_approval" // Member-created event waiting for an admin to approve it before the poll is posted
 )
 
 // EventType represents the type of an event
@@ -48,6 +63,16 @@ const (
 	EventTypeProbability EventType = "probability"
 )
 
+// IsValid reports whether the event type is one of the known types
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventTypeBinary, EventTypeMultiOption, EventTypeProbability:
+		return true
+	default:
+		return false
+	}
+}
+
 // Event represents a prediction event
 type Event struct {
 	ID            int64
@@ -66,15 +91,67 @@ type Event struct {
 	AllowsRevoting       bool   // Whether users can change their vote
 	ShuffleOptions       bool   // Whether to randomize option order per user
 	HideResultsUntilClose bool  // Whether to hide results until poll closes
+	StatsMessageID       int    // Telegram message ID of the companion live stats message (0 if none)
+	PollPinned           bool   // Whether the poll message was pinned in the group chat
+	Participants         []int64 // Users allowed to see and vote on the event (empty means all group members)
+	PhotoFileID          string // Telegram file_id of the attached photo (empty if none)
+	PhotoMessageID       int    // Telegram message ID of the photo posted with the poll (0 if none)
+	ReminderOffsets      []time.Duration // Custom reminder offsets before the deadline (empty means DefaultReminderOffsets)
+	CountdownMessageID   int    // Telegram message ID of the countdown companion message (0 if none, -1 when finished)
+	PollMessageMissing   bool   // Whether the poll message was found deleted when the bot tried to edit or stop it
+	VotingClosedAt       *time.Time // When a manager closed voting before the deadline (nil while voting follows the deadline)
+	ResolutionNote       string // Evidence or source given by the resolver for the outcome (empty if none)
+	MinorityCorrect      bool   // Whether the correct option was a minority pick (a contrarian win), set at resolution
+	LockVotesAt          *time.Time // When votes lock before the deadline (nil when votes can change until the deadline)
+	QuizAnswer           *int   // Index of the correct option of a quiz event, set at creation (nil for prediction events)
+	ResolvedAt           *time.Time // When the event was resolved (nil while unresolved and for events resolved before it was recorded)
+}
+
+// IsVotingOpen reports whether the event still accepts votes at the given time
+func (e *Event) IsVotingOpen(now time.Time) bool {
+	return e.Status == EventStatusActive && e.VotingClosedAt == nil && now.Before(e.VotingEndsAt())
+}
+
+// IsRestricted reports whether the event is limited to an allow-list of participants
+func (e *Event) IsRestricted() bool {
+	return len(e.Participants) > 0
+}
+
+// IsParticipant reports whether a user may see and vote on the event
+func (e *Event) IsParticipant(userID int64) bool {
+	if !e.IsRestricted() {
+		return true
+	}
+	for _, participantID := range e.Participants {
+		if participantID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ParticipantPredictions drops predictions of users who are not participants of a restricted event
+func (e *Event) ParticipantPredictions(predictions []*Prediction) []*Prediction {
+	if !e.IsRestricted() {
+		return predictions
+	}
+	filtered := make([]*Prediction, 0, len(predictions))
+	for _, pred := range predictions {
+		if e.IsParticipant(pred.UserID) {
+			filtered = append(filtered, pred)
+		}
+	}
+	return filtered
 }
 
 // Prediction represents a user's prediction
 type Prediction struct {
-	ID        int64
-	EventID   int64
-	UserID    int64
-	Option    int
-	Timestamp time.Time
+	ID                   int64
+	EventID              int64
+	UserID               int64
+	Option               int
+	Timestamp            time.Time
+	ParticipationAwarded bool // Participation point already credited when the vote was cast
 }
 
 // Rating represents a user's rating
@@ -86,6 +163,15 @@ type Rating struct {
 	CorrectCount int
 	WrongCount   int
 	Streak       int
+	BestStreak   int // Longest streak ever reached in the group (never decreases)
+}
+
+// IncrementStreak extends the current streak, raising the best streak when it is exceeded
+func (r *Rating) IncrementStreak() {
+	r.Streak++
+	if r.Streak > r.BestStreak {
+		r.BestStreak = r.Streak
+	}
 }
 
 // AchievementCode represents an achievement type
@@ -100,8 +186,22 @@ const (
 	AchievementEventOrganizer  AchievementCode = "event_organizer"
 	AchievementActiveOrganizer AchievementCode = "active_organizer"
 	AchievementMasterOrganizer AchievementCode = "master_organizer"
+	AchievementGlobeTrotter    AchievementCode = "globe_trotter"
 )
 
+// AchievementCodes lists all achievements in display order
+var AchievementCodes = []AchievementCode{
+	AchievementSharpshooter,
+	AchievementProphet,
+	AchievementRiskTaker,
+	AchievementWeeklyAnalyst,
+	AchievementVeteran,
+	AchievementGlobeTrotter,
+	AchievementEventOrganizer,
+	AchievementActiveOrganizer,
+	AchievementMasterOrganizer,
+}
+
 // Achievement represents a user achievement
 type Achievement struct {
 	ID        int64
@@ -117,17 +217,31 @@ type GroupStatus string
 
 const (
 	GroupStatusActive  GroupStatus = "active"
+	GroupStatusPending GroupStatus = "pending" // Draft created when the bot is added to a chat, hidden until an admin activates it
 	GroupStatusDeleted GroupStatus = "deleted"
+	GroupStatusPaused  GroupStatus = "paused" // Frozen between seasons: hidden, no new events or votes, all data kept
 )
 
 type Group struct {
-	ID             int64
-	TelegramChatID int64 // Unique Telegram chat ID
-	Name           string
-	CreatedAt      time.Time
-	CreatedBy      int64
-	IsForum        bool        // Whether this group is a forum (supergroup with topics)
-	Status         GroupStatus // Group status (active/deleted)
+	ID                    int64
+	TelegramChatID        int64 // Unique Telegram chat ID
+	Name                  string
+	CreatedAt             time.Time
+	CreatedBy             int64
+	IsForum               bool              // Whether this group is a forum (supergroup with topics)
+	Status                GroupStatus       // Group status (active/pending/paused/deleted)
+	PinPolls              bool              // Whether event polls are pinned in the group chat
+	DefaultEventType      EventType         // Event type pre-selected when creating events (empty means none)
+	RequireRules          bool              // Whether new members must accept the rules before voting
+	AutoRemoveInactive    bool              // Whether members inactive for a long time are removed automatically
+	MaxMembers            *int              // Maximum number of active members (nil means unlimited)
+	ReputationWeighting   bool              // Whether vote shares are weighted by the voters' ratings
+	RequireApproval       bool              // Whether events created by members wait for admin approval before the poll is posted
+	PointsLabel           string            // Custom name of points, comma-separated plural forms (empty means the localized default)
+	FirstVoteFinal        bool              // Whether the first vote of a member is final and later changes are ignored
+	ExcludeCreatorScoring bool              // Whether event creators earn no points on their own events
+	DisabledAchievements  []AchievementCode // Achievements not awarded or shown in this group (empty means all enabled)
+	ArchiveDays           *int              // Days after which resolved events are archived (nil means EVENT_ARCHIVE_DAYS, 0 means never)
 }
 
 // ForumTopic represents a topic within a forum group
@@ -151,11 +265,12 @@ const (
 
 // GroupMembership represents a user's membership in a group
 type GroupMembership struct {
-	ID       int64
-	GroupID  int64
-	UserID   int64
-	JoinedAt time.Time
-	Status   MembershipStatus
+	ID           int64
+	GroupID      int64
+	UserID       int64
+	JoinedAt     time.Time
+	Status       MembershipStatus
+	RulesPending bool // Joined a group that requires rules and has not accepted them yet
 }
 
 // Validation methods
@@ -165,6 +280,9 @@ func (e *Event) Validate() error {
 	if e.Question == "" {
 		return ErrEmptyQuestion
 	}
+	if utf8.RuneCountInString(e.Question) > MaxPollQuestionLength {
+		return ErrQuestionTooLong
+	}
 	if e.GroupID == 0 {
 		return ErrInvalidGroupID
 	}
@@ -174,12 +292,27 @@ func (e *Event) Validate() error {
 	if len(e.Options) > 6 {
 		return ErrTooManyOptions
 	}
+	if err := ValidateOptions(e.Options); err != nil {
+		return err
+	}
 	if e.Deadline.Before(e.CreatedAt) {
 		return ErrInvalidDeadline
 	}
+	// The range is checked from creation, so existing events stay valid as time passes
+	if !e.CreatedAt.IsZero() {
+		if err := EventDeadlineLimits().Check(e.CreatedAt, e.Deadline); err != nil {
+			return err
+		}
+	}
 	if e.CreatedBy == 0 {
 		return ErrInvalidCreator
 	}
+	if e.LockVotesAt != nil && !e.LockVotesAt.Before(e.Deadline) {
+		return ErrInvalidLockVotesAt
+	}
+	if err := e.validateQuiz(); err != nil {
+		return err
+	}
 
 	// Validate event type specific constraints
 	switch e.EventType {
@@ -202,6 +335,51 @@ func (e *Event) Validate() error {
 	return nil
 }
 
+// ValidateOptions checks that no option is blank or longer than MaxPollOptionLength and that
+// options are unique, ignoring case and surrounding whitespace
+func ValidateOptions(options []string) error {
+	seen := make(map[string]bool, len(options))
+	for _, opt := range options {
+		key := optionKey(opt)
+		if key == "" {
+			return ErrEmptyOption
+		}
+		if IsOptionTooLong(opt) {
+			return ErrOptionTooLong
+		}
+		if seen[key] {
+			return ErrDuplicateOption
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// CleanOptions trims options and drops blank ones and repeats of earlier options (ignoring case)
+func CleanOptions(options []string) []string {
+	seen := make(map[string]bool, len(options))
+	var clean []string
+	for _, opt := range options {
+		key := optionKey(opt)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		clean = append(clean, strings.TrimSpace(opt))
+	}
+	return clean
+}
+
+// IsOptionTooLong reports whether an option, without surrounding whitespace, exceeds MaxPollOptionLength
+func IsOptionTooLong(option string) bool {
+	return utf8.RuneCountInString(strings.TrimSpace(option)) > MaxPollOptionLength
+}
+
+// optionKey returns the form used to compare options
+func optionKey(option string) string {
+	return strings.ToLower(strings.TrimSpace(option))
+}
+
 // Validate validates a Prediction
 func (p *Prediction) Validate() error {
 	if p.EventID == 0 {
@@ -248,13 +426,23 @@ func (a *Achievement) Validate() error {
 	// Validate achievement code is one of the known codes
 	switch a.Code {
 	case AchievementSharpshooter, AchievementWeeklyAnalyst, AchievementProphet, AchievementRiskTaker, AchievementVeteran,
-		AchievementEventOrganizer, AchievementActiveOrganizer, AchievementMasterOrganizer:
+		AchievementEventOrganizer, AchievementActiveOrganizer, AchievementMasterOrganizer, AchievementGlobeTrotter:
 		return nil
 	default:
 		return ErrInvalidAchievementCode
 	}
 }
 
+// AchievementEnabled reports whether an achievement is awarded and shown in the group
+func (g *Group) AchievementEnabled(code AchievementCode) bool {
+	return !slices.Contains(g.DisabledAchievements, code)
+}
+
+// IsFull reports whether the group has reached its member cap with the given number of active members
+func (g *Group) IsFull(activeMembers int) bool {
+	return g.MaxMembers != nil && activeMembers >= *g.MaxMembers
+}
+
 // Validate validates a Group
 func (g *Group) Validate() error {
 	if g.TelegramChatID == 0 {
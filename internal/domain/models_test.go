@@ -382,3 +382,52 @@ func TestMembershipStatusConstants(t *testing.T) {
 		})
 	}
 }
+
+func TestEventParticipants(t *testing.T) {
+	predictions := []*Prediction{
+		{UserID: 1, Option: 0},
+		{UserID: 2, Option: 1},
+		{UserID: 3, Option: 0},
+	}
+
+	open := &Event{}
+	if open.IsRestricted() || !open.IsParticipant(42) {
+		t.Error("Expected event without participants to be open to everyone")
+	}
+	if len(open.ParticipantPredictions(predictions)) != 3 {
+		t.Error("Expected open event to keep all predictions")
+	}
+
+	restricted := &Event{Participants: []int64{1, 3}}
+	if !restricted.IsRestricted() {
+		t.Error("Expected event with participants to be restricted")
+	}
+	if !restricted.IsParticipant(1) || restricted.IsParticipant(2) {
+		t.Error("Expected only listed users to be participants")
+	}
+
+	filtered := restricted.ParticipantPredictions(predictions)
+	if len(filtered) != 2 || filtered[0].UserID != 1 || filtered[1].UserID != 3 {
+		t.Errorf("Expected predictions of users 1 and 3, got %d predictions", len(filtered))
+	}
+}
+
+func TestRatingIncrementStreak(t *testing.T) {
+	rating := &Rating{Streak: 2, BestStreak: 3}
+
+	rating.IncrementStreak()
+	if rating.Streak != 3 || rating.BestStreak != 3 {
+		t.Errorf("expected streak 3/3, got %d/%d", rating.Streak, rating.BestStreak)
+	}
+
+	rating.IncrementStreak()
+	if rating.Streak != 4 || rating.BestStreak != 4 {
+		t.Errorf("expected best streak to follow the current streak, got %d/%d", rating.Streak, rating.BestStreak)
+	}
+
+	rating.Streak = 0
+	rating.IncrementStreak()
+	if rating.Streak != 1 || rating.BestStreak != 4 {
+		t.Errorf("expected best streak to be kept after a reset, got %d/%d", rating.Streak, rating.BestStreak)
+	}
+}
@@ -3,7 +3,10 @@ package domain
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ad/gitelegram-prediction-market/internal/locale"
@@ -11,29 +14,80 @@ import (
 	"github.com/go-telegram/bot/models"
 )
 
+// ErrSchedulerAlreadyStarted is returned when StartScheduler is called while the scheduler is running
+var ErrSchedulerAlreadyStarted = NewError(ErrorKindConflict, "notification scheduler is already running")
+
 // BotInterface defines the interface for bot operations needed by NotificationService
 type BotInterface interface {
 	SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error)
+	SendPhoto(ctx context.Context, params *bot.SendPhotoParams) (*models.Message, error)
 }
 
 // ReminderRepository interface for reminder log operations
 type ReminderRepository interface {
 	WasReminderSent(ctx context.Context, eventID int64) (bool, error)
 	MarkReminderSent(ctx context.Context, eventID int64) error
+	ClaimDueReminders(ctx context.Context, instanceID string, start, end time.Time, limit int) ([]int64, error)
+	ScheduleEventReminders(ctx context.Context, eventID int64, reminders []ScheduledReminder) error
+	ClaimScheduledReminders(ctx context.Context, instanceID string, now time.Time, limit int) ([]ScheduledReminder, error)
+	MarkScheduledReminderSent(ctx context.Context, eventID int64, offset time.Duration) error
 	WasOrganizerNotificationSent(ctx context.Context, eventID int64) (bool, error)
 	MarkOrganizerNotificationSent(ctx context.Context, eventID int64) error
+	GetResolutionNagState(ctx context.Context, eventID int64) (int, time.Time, error)
+	MarkResolutionNagSent(ctx context.Context, eventID int64) error
+	WasResolutionNagEscalated(ctx context.Context, eventID int64) (bool, error)
+	MarkResolutionNagEscalated(ctx context.Context, eventID int64) error
+}
+
+// ResolutionNagPolicy configures repeated reminders to resolve events whose deadline has passed.
+// The first reminder is sent Delay after the deadline, then every Interval until the event
+// is resolved or MaxCount reminders were sent. A non-positive Delay disables the reminders.
+// Events still unresolved EscalateAfter the deadline are escalated once to every admin in Admins,
+// independently of the reminders. A non-positive EscalateAfter disables the escalation.
+type ResolutionNagPolicy struct {
+	Delay         time.Duration
+	Interval      time.Duration
+	MaxCount      int
+	EscalateAfter time.Duration
+	Admins        []int64
+}
+
+// Enabled reports whether resolution reminders should be sent
+func (p ResolutionNagPolicy) Enabled() bool {
+	return p.Delay > 0 && p.Interval > 0 && p.MaxCount > 0
 }
 
+// EscalationEnabled reports whether unresolved events should be escalated to the admins
+func (p ResolutionNagPolicy) EscalationEnabled() bool {
+	return p.EscalateAfter > 0 && len(p.Admins) > 0
+}
+
+// resolutionEscalationBatchSize bounds the escalations sent per scheduler pass, so a backlog
+// of forgotten events reaches the admins over a few passes instead of all at once
+const resolutionEscalationBatchSize = 5
+
 // NotificationService handles sending notifications to users and groups
 type NotificationService struct {
-	bot            BotInterface
-	eventRepo      EventRepository
-	predictionRepo PredictionRepository
-	ratingRepo     RatingRepository
-	reminderRepo   ReminderRepository
-	groupID        int64
-	logger         Logger
-	localizer      locale.Localizer
+	bot              BotInterface
+	eventRepo        EventRepository
+	predictionRepo   PredictionRepository
+	ratingRepo       RatingRepository
+	reminderRepo     ReminderRepository
+	settingsRepo     NotificationSettingsRepository
+	groupRepo        GroupRepository
+	outcomeRepo      ResolvedOutcomeRepository
+	reachabilityRepo UserReachabilityRepository
+	nagPolicy        ResolutionNagPolicy
+	podiumMedals     []string
+	webhook          *resolutionWebhook
+	instanceID       string
+	groupID          int64
+	lastRun          atomic.Int64 // Unix nanoseconds of the last scheduler pass, 0 before the scheduler started
+	schedulerMu      sync.Mutex
+	schedulerCancel  context.CancelFunc // Stops the running scheduler, nil when it isn't running
+	schedulerDone    chan struct{}      // Closed when the running scheduler loop exits
+	logger           Logger
+	localizer        locale.Localizer
 }
 
 // NewNotificationService creates a new NotificationService
@@ -52,11 +106,71 @@ func NewNotificationService(
 		predictionRepo: predictionRepo,
 		ratingRepo:     ratingRepo,
 		reminderRepo:   reminderRepo,
+		instanceID:     defaultInstanceID(),
+		podiumMedals:   []string{"🥇", "🥈", "🥉"},
 		logger:         logger,
 		localizer:      localizer,
 	}
 }
 
+// defaultInstanceID identifies this process when claiming reminders, so that two bot
+// instances sharing a database (e.g. during a blue/green deploy) don't send the same reminder
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// SetInstanceID overrides the identifier used when claiming reminders
+func (ns *NotificationService) SetInstanceID(instanceID string) {
+	ns.instanceID = instanceID
+}
+
+// SetGroupRepository enables the groups' points labels in published results (default labels otherwise)
+func (ns *NotificationService) SetGroupRepository(groupRepo GroupRepository) {
+	ns.groupRepo = groupRepo
+}
+
+// SetPodiumMedals overrides the emojis shown for the top three places in published results
+func (ns *NotificationService) SetPodiumMedals(medals []string) {
+	if len(medals) > 0 {
+		ns.podiumMedals = medals
+	}
+}
+
+// groupPointsLabel returns the points label of a group, empty for the default label
+func (ns *NotificationService) groupPointsLabel(ctx context.Context, groupID int64) string {
+	if ns.groupRepo == nil {
+		return ""
+	}
+	group, err := ns.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		ns.logger.Error("failed to get group for points label", "group_id", groupID, "error", err)
+		return ""
+	}
+	return group.PointsLabel
+}
+
+// groupPaused reports whether a group is paused. Without a group repository no group is.
+func (ns *NotificationService) groupPaused(ctx context.Context, groupID int64) bool {
+	if ns.groupRepo == nil {
+		return false
+	}
+	group, err := ns.groupRepo.GetGroup(ctx, groupID)
+	if err != nil || group == nil {
+		ns.logger.Error("failed to get group for status", "group_id", groupID, "error", err)
+		return false
+	}
+	return group.Status == GroupStatusPaused
+}
+
+// SetResolutionNagPolicy configures reminders to resolve expired events (disabled by default)
+func (ns *NotificationService) SetResolutionNagPolicy(policy ResolutionNagPolicy) {
+	ns.nagPolicy = policy
+}
+
 // SendNewEventNotification sends a notification to all participants when a new event is published
 func (ns *NotificationService) SendNewEventNotification(ctx context.Context, eventID int64) error {
 	// Get the event
@@ -95,20 +209,7 @@ func (ns *NotificationService) SendNewEventNotification(ctx context.Context, eve
 	sb.WriteString("\n")
 
 	// Deadline
-	timeUntil := time.Until(event.Deadline)
-	deadlineStr := ""
-	if timeUntil > 0 {
-		hours := int(timeUntil.Hours())
-		if hours > 24 {
-			days := hours / 24
-			deadlineStr = ns.localizer.MustLocalizeWithTemplate(locale.DeadlineDaysHours, fmt.Sprintf("%d", days), fmt.Sprintf("%d", hours%24))
-		} else {
-			deadlineStr = ns.localizer.MustLocalizeWithTemplate(locale.DeadlineHoursOnly, fmt.Sprintf("%d", hours))
-		}
-	} else {
-		deadlineStr = ns.localizer.MustLocalize(locale.DeadlineExpired)
-	}
-	sb.WriteString(deadlineStr + "\n\n")
+	sb.WriteString(ns.formatDeadline(event.Deadline) + "\n\n")
 	sb.WriteString(ns.localizer.MustLocalize(locale.NotificationNewEventCTA))
 
 	// Send notification to group
@@ -125,6 +226,27 @@ func (ns *NotificationService) SendNewEventNotification(ctx context.Context, eve
 	return nil
 }
 
+// formatDeadline formats the time left until a deadline
+func (ns *NotificationService) formatDeadline(deadline time.Time) string {
+	timeUntil := time.Until(deadline)
+	if timeUntil <= 0 {
+		return ns.localizer.MustLocalize(locale.DeadlineExpired)
+	}
+
+	hours := int(timeUntil.Hours())
+	if hours > 24 {
+		return ns.localizer.MustLocalizeWithTemplate(locale.DeadlineDaysHours, fmt.Sprintf("%d", hours/24), fmt.Sprintf("%d", hours%24))
+	}
+	return ns.localizer.MustLocalizeWithTemplate(locale.DeadlineHoursOnly, fmt.Sprintf("%d", hours))
+}
+
+// SendNewEventSubscriberNotification tells a subscribed member about a new event in one of their
+// groups, attaching the event photo when the event has one
+func (ns *NotificationService) SendNewEventSubscriberNotification(ctx context.Context, userID int64, event *Event, group *Group) error {
+	text := ns.localizer.MustLocalizeWithTemplate(locale.NotificationSubscribedNewEvent, group.Name, event.Question, ns.formatDeadline(event.Deadline))
+	return ns.sendReminder(ctx, userID, event.GroupID, event.PhotoFileID, text)
+}
+
 // SendAchievementNotification sends a notification to the user and publishes an announcement in the group
 // This method is deprecated - use SendAchievementNotificationWithGroup instead
 func (ns *NotificationService) SendAchievementNotification(ctx context.Context, userID int64, achievement *Achievement) error {
@@ -135,6 +257,7 @@ func (ns *NotificationService) SendAchievementNotification(ctx context.Context,
 		AchievementRiskTaker:     ns.localizer.MustLocalize(locale.AchievementRiskTakerName),
 		AchievementWeeklyAnalyst: ns.localizer.MustLocalize(locale.AchievementWeeklyAnalystName),
 		AchievementVeteran:       ns.localizer.MustLocalize(locale.AchievementVeteranName),
+		AchievementGlobeTrotter:  ns.localizer.MustLocalize(locale.AchievementGlobeTrotterName),
 	}
 
 	name := achievementNames[achievement.Code]
@@ -175,6 +298,30 @@ func (ns *NotificationService) PublishEventResults(ctx context.Context, eventID
 		return err
 	}
 
+	answerLine := ns.localizer.MustLocalizeWithTemplate(locale.NotificationResultsCorrectAnswer, event.Options[correctOption])
+	return ns.publishEventResults(ctx, eventID, event, correctOption, answerLine, telegramChatID, forumTopicRepo)
+}
+
+// PublishProbabilityEventResults publishes results of a probability event resolved
+// against the realized outcome percentage (0-100)
+func (ns *NotificationService) PublishProbabilityEventResults(ctx context.Context, eventID int64, outcomePercent float64, telegramChatID int64, forumTopicRepo ForumTopicRepository) error {
+	// Get the event
+	event, err := ns.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		ns.logger.Error("failed to get event for results", "event_id", eventID, "error", err)
+		return err
+	}
+
+	correctOption := ProbabilityOutcomeOption(outcomePercent)
+	answerLine := ns.localizer.MustLocalizeWithTemplate(locale.NotificationResultsProbabilityOutcome,
+		FormatProbabilityOutcome(outcomePercent),
+		event.Options[correctOption],
+	)
+	return ns.publishEventResults(ctx, eventID, event, correctOption, answerLine, telegramChatID, forumTopicRepo)
+}
+
+// publishEventResults sends the results message for a resolved event to the group
+func (ns *NotificationService) publishEventResults(ctx context.Context, eventID int64, event *Event, correctOption int, answerLine string, telegramChatID int64, forumTopicRepo ForumTopicRepository) error {
 	// Get MessageThreadID from ForumTopic if event has one
 	var messageThreadID *int
 	if event.ForumTopicID != nil {
@@ -192,6 +339,7 @@ func (ns *NotificationService) PublishEventResults(ctx context.Context, eventID
 		ns.logger.Error("failed to get predictions for results", "event_id", eventID, "error", err)
 		return err
 	}
+	predictions = event.ParticipantPredictions(predictions)
 
 	// Count correct predictions
 	correctCount := 0
@@ -207,23 +355,33 @@ func (ns *NotificationService) PublishEventResults(ctx context.Context, eventID
 		ns.logger.Error("failed to get top ratings", "group_id", event.GroupID, "error", err)
 		topRatings = []*Rating{} // Continue with empty list
 	}
+	pointsLabel := ns.groupPointsLabel(ctx, event.GroupID)
 
 	// Build results message
 	var sb strings.Builder
 	sb.WriteString(ns.localizer.MustLocalize(locale.NotificationResultsTitle) + "\n\n")
 	sb.WriteString(ns.localizer.MustLocalizeWithTemplate(locale.NotificationResultsQuestion, event.Question) + "\n\n")
-	sb.WriteString(ns.localizer.MustLocalizeWithTemplate(locale.NotificationResultsCorrectAnswer, event.Options[correctOption]) + "\n\n")
+	sb.WriteString(answerLine + "\n\n")
+	if event.ResolutionNote != "" {
+		sb.WriteString(ns.localizer.MustLocalizeWithTemplate(locale.NotificationResultsNote, event.ResolutionNote) + "\n\n")
+	}
 	sb.WriteString(ns.localizer.MustLocalizeWithTemplate(locale.NotificationResultsStats, fmt.Sprintf("%d", correctCount), fmt.Sprintf("%d", len(predictions))) + "\n")
+	if event.MinorityCorrect {
+		sb.WriteString(ns.contrarianHighlight(ctx, event.GroupID, predictions, correctOption, correctCount) + "\n")
+	}
 
 	if len(topRatings) > 0 {
 		sb.WriteString("\n" + ns.localizer.MustLocalize(locale.NotificationResultsTopTitle) + "\n")
-		medals := []string{"🥇", "🥈", "🥉", "4.", "5."}
 		for i, rating := range topRatings {
+			medal := fmt.Sprintf("%d.", i+1)
+			if i < len(ns.podiumMedals) {
+				medal = ns.podiumMedals[i]
+			}
 			displayName := rating.Username
 			if displayName == "" {
 				displayName = ns.localizer.MustLocalizeWithTemplate(locale.UserIDFormat, fmt.Sprintf("%d", rating.UserID))
 			}
-			sb.WriteString(ns.localizer.MustLocalizeWithTemplate(locale.RatingTopEntry, medals[i], displayName, fmt.Sprintf("%d", rating.Score)) + "\n")
+			sb.WriteString(ns.localizer.MustLocalizeWithTemplate(locale.RatingTopEntry, medal, displayName, FormatPoints(ns.localizer, rating.Score, pointsLabel)) + "\n")
 		}
 	}
 
@@ -249,6 +407,43 @@ func (ns *NotificationService) PublishEventResults(ctx context.Context, eventID
 	return nil
 }
 
+// contrarianHighlightLimit is the number of members named in the contrarian win highlight
+const contrarianHighlightLimit = 10
+
+// contrarianHighlight names the members who picked the correct option of a contrarian win,
+// up to contrarianHighlightLimit of them
+func (ns *NotificationService) contrarianHighlight(ctx context.Context, groupID int64, predictions []*Prediction, correctOption int, correctCount int) string {
+	var names []string
+	for _, pred := range predictions {
+		if pred.Option != correctOption {
+			continue
+		}
+		if len(names) == contrarianHighlightLimit {
+			break
+		}
+
+		displayName := ns.localizer.MustLocalizeWithTemplate(locale.UserIDFormat, fmt.Sprintf("%d", pred.UserID))
+		rating, err := ns.ratingRepo.GetRating(ctx, pred.UserID, groupID)
+		if err != nil {
+			ns.logger.Warn("failed to get rating for contrarian highlight", "user_id", pred.UserID, "group_id", groupID, "error", err)
+		} else if rating.Username != "" {
+			displayName = rating.Username
+		}
+		names = append(names, displayName)
+	}
+
+	list := strings.Join(names, ", ")
+	if correctCount > len(names) {
+		list = ns.localizer.MustLocalizeWithTemplate(locale.NotificationResultsContrarianMore, list, fmt.Sprintf("%d", correctCount-len(names)))
+	}
+
+	return ns.localizer.MustLocalizeWithTemplate(locale.NotificationResultsContrarian,
+		fmt.Sprintf("%d", correctCount),
+		fmt.Sprintf("%d", len(predictions)),
+		list,
+	)
+}
+
 // SendDeadlineReminder sends reminders to participants who haven't voted yet
 func (ns *NotificationService) SendDeadlineReminder(ctx context.Context, eventID int64) error {
 	// Get the event
@@ -264,6 +459,22 @@ func (ns *NotificationService) SendDeadlineReminder(ctx context.Context, eventID
 		return nil
 	}
 
+	// Nobody can vote any more once voting was closed early
+	if event.VotingClosedAt != nil {
+		ns.logger.Debug("skipping reminder for event with closed voting", "event_id", eventID)
+		return nil
+	}
+	if event.VotesLocked(time.Now()) {
+		ns.logger.Debug("skipping reminder for event with locked votes", "event_id", eventID)
+		return nil
+	}
+
+	// Votes in paused groups aren't counted, don't ask for them
+	if ns.groupPaused(ctx, event.GroupID) {
+		ns.logger.Debug("skipping reminder for event in paused group", "event_id", eventID, "group_id", event.GroupID)
+		return nil
+	}
+
 	// Get all predictions for this event
 	predictions, err := ns.predictionRepo.GetPredictionsByEvent(ctx, eventID)
 	if err != nil {
@@ -286,8 +497,12 @@ func (ns *NotificationService) SendDeadlineReminder(ctx context.Context, eventID
 	}
 
 	// Build reminder message
+	// Custom reminders may be less than an hour before the deadline
 	timeUntil := time.Until(event.Deadline)
 	hours := int(timeUntil.Hours())
+	if hours < 1 {
+		hours = 1
+	}
 
 	var sb strings.Builder
 	sb.WriteString(ns.localizer.MustLocalize(locale.NotificationReminderTitle) + "\n\n")
@@ -296,14 +511,11 @@ func (ns *NotificationService) SendDeadlineReminder(ctx context.Context, eventID
 	sb.WriteString(ns.localizer.MustLocalize(locale.NotificationReminderCTA))
 	reminderText := sb.String()
 
-	// Send reminders to users who haven't voted
+	// Send reminders to users who haven't voted (only participants for restricted events)
 	sentCount := 0
 	for _, rating := range allRatings {
-		if !votedUsers[rating.UserID] {
-			_, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: rating.UserID,
-				Text:   reminderText,
-			})
+		if !votedUsers[rating.UserID] && event.IsParticipant(rating.UserID) {
+			err := ns.sendReminder(ctx, rating.UserID, event.GroupID, event.PhotoFileID, reminderText)
 			if err != nil {
 				ns.logger.Warn("failed to send reminder to user", "user_id", rating.UserID, "error", err)
 				// Continue sending to other users
@@ -317,8 +529,46 @@ func (ns *NotificationService) SendDeadlineReminder(ctx context.Context, eventID
 	return nil
 }
 
-// StartScheduler starts the notification scheduler with hourly checks for deadline reminders
+// sendReminder sends a reminder about an event of groupID to a user, attaching the event photo
+// when the event has one
+func (ns *NotificationService) sendReminder(ctx context.Context, userID int64, groupID int64, photoFileID string, text string) error {
+	return ns.sendDirect(ctx, userID, groupID, func() error {
+		if photoFileID == "" {
+			_, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: userID,
+				Text:   text,
+			})
+			return err
+		}
+
+		// Reuse the file_id of the photo already uploaded to Telegram
+		_, err := ns.bot.SendPhoto(ctx, &bot.SendPhotoParams{
+			ChatID:  userID,
+			Photo:   &models.InputFileString{Data: photoFileID},
+			Caption: text,
+		})
+		return err
+	})
+}
+
+// StartScheduler starts the notification scheduler with hourly checks for deadline reminders.
+// It returns ErrSchedulerAlreadyStarted while a previous scheduler is still running, so
+// reminders are never sent by two loops at once. Stop allows starting it again.
 func (ns *NotificationService) StartScheduler(ctx context.Context) error {
+	ns.schedulerMu.Lock()
+	defer ns.schedulerMu.Unlock()
+
+	if ns.schedulerRunning() {
+		ns.logger.Warn("notification scheduler is already running")
+		return ErrSchedulerAlreadyStarted
+	}
+	if ns.schedulerCancel != nil {
+		// The previous scheduler exited with its parent context; release its context
+		ns.schedulerCancel()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
 	// Perform startup recovery first
 	if err := ns.performStartupRecovery(ctx); err != nil {
 		ns.logger.Error("startup recovery failed", "error", err)
@@ -326,17 +576,63 @@ func (ns *NotificationService) StartScheduler(ctx context.Context) error {
 	}
 
 	// Start the scheduler
-	go ns.runScheduler(ctx)
+	done := make(chan struct{})
+	ns.schedulerCancel = cancel
+	ns.schedulerDone = done
+	ns.lastRun.Store(time.Now().UnixNano())
+	go func() {
+		defer close(done)
+		ns.runScheduler(ctx)
+	}()
 
 	ns.logger.Info("notification scheduler started")
 	return nil
 }
 
+// Stop stops the scheduler and waits for its loop to exit. It does nothing if the scheduler isn't running.
+func (ns *NotificationService) Stop() {
+	ns.schedulerMu.Lock()
+	defer ns.schedulerMu.Unlock()
+
+	if ns.schedulerCancel == nil {
+		return
+	}
+
+	ns.schedulerCancel()
+	<-ns.schedulerDone
+	ns.schedulerCancel = nil
+	ns.schedulerDone = nil
+}
+
+// schedulerRunning reports whether a started scheduler loop is still running.
+// A scheduler whose context was cancelled counts as stopped. Callers hold schedulerMu.
+func (ns *NotificationService) schedulerRunning() bool {
+	if ns.schedulerDone == nil {
+		return false
+	}
+	select {
+	case <-ns.schedulerDone:
+		return false
+	default:
+		return true
+	}
+}
+
+// scheduledReminderInterval is how often custom reminders are checked, they can be as close
+// to the deadline as a few minutes so the hourly check is too coarse for them
+const scheduledReminderInterval = time.Minute
+
+// SchedulerInterval is how often the scheduler runs
+const SchedulerInterval = scheduledReminderInterval
+
 // runScheduler runs the scheduler loop
 func (ns *NotificationService) runScheduler(ctx context.Context) {
 	ticker := time.NewTicker(1 * time.Hour)
 	defer ticker.Stop()
 
+	scheduledTicker := time.NewTicker(scheduledReminderInterval)
+	defer scheduledTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -344,6 +640,84 @@ func (ns *NotificationService) runScheduler(ctx context.Context) {
 			return
 		case <-ticker.C:
 			ns.checkAndSendReminders(ctx)
+			_, _ = ns.SendDailyDigests(ctx)
+		case <-scheduledTicker.C:
+			ns.checkAndSendScheduledReminders(ctx)
+			ns.lastRun.Store(time.Now().UnixNano())
+		}
+	}
+}
+
+// LastSchedulerRun returns when the scheduler last checked for reminders, zero if it never started.
+// The scheduler runs every SchedulerInterval.
+func (ns *NotificationService) LastSchedulerRun() time.Time {
+	lastRun := ns.lastRun.Load()
+	if lastRun == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, lastRun)
+}
+
+// ScheduleEventReminders enqueues the custom reminders of a newly created event.
+// Events without custom offsets get the default reminder from the hourly check instead.
+// Offsets whose reminder time has already passed are skipped.
+func (ns *NotificationService) ScheduleEventReminders(ctx context.Context, event *Event) error {
+	if len(event.ReminderOffsets) == 0 {
+		return nil
+	}
+
+	reminders := PlanReminders(event.ID, event.ReminderOffsets, event.Deadline, time.Now())
+	if len(reminders) < len(event.ReminderOffsets) {
+		ns.logger.Warn("skipping past-due reminder offsets", "event_id", event.ID,
+			"offsets", FormatReminderOffsets(event.ReminderOffsets), "scheduled", len(reminders))
+	}
+
+	if err := ns.reminderRepo.ScheduleEventReminders(ctx, event.ID, reminders); err != nil {
+		ns.logger.Error("failed to schedule event reminders", "event_id", event.ID, "error", err)
+		return err
+	}
+
+	ns.logger.Info("event reminders scheduled", "event_id", event.ID, "count", len(reminders))
+	return nil
+}
+
+// checkAndSendScheduledReminders sends the due custom reminders claimed by this instance.
+// Several reminders of the same event that are due at once (e.g. after a downtime) are sent only once.
+func (ns *NotificationService) checkAndSendScheduledReminders(ctx context.Context) {
+	var reminders []ScheduledReminder
+	for {
+		batch, err := ns.reminderRepo.ClaimScheduledReminders(ctx, ns.instanceID, time.Now(), reminderClaimBatchSize)
+		if err != nil {
+			ns.logger.Error("failed to claim scheduled reminders", "error", err)
+			break
+		}
+		reminders = append(reminders, batch...)
+		if len(batch) < reminderClaimBatchSize {
+			break
+		}
+	}
+
+	offsetsByEvent := make(map[int64][]time.Duration)
+	var eventIDs []int64
+	for _, reminder := range reminders {
+		if _, ok := offsetsByEvent[reminder.EventID]; !ok {
+			eventIDs = append(eventIDs, reminder.EventID)
+		}
+		offsetsByEvent[reminder.EventID] = append(offsetsByEvent[reminder.EventID], reminder.Offset)
+	}
+
+	for _, eventID := range eventIDs {
+		// Send reminder
+		if err := ns.SendDeadlineReminder(ctx, eventID); err != nil {
+			ns.logger.Error("failed to send scheduled reminder", "event_id", eventID, "error", err)
+			continue
+		}
+
+		// Mark all due reminders of the event as sent
+		for _, offset := range offsetsByEvent[eventID] {
+			if err := ns.reminderRepo.MarkScheduledReminderSent(ctx, eventID, offset); err != nil {
+				ns.logger.Error("failed to mark scheduled reminder as sent", "event_id", eventID, "offset", offset, "error", err)
+			}
 		}
 	}
 }
@@ -354,38 +728,33 @@ func (ns *NotificationService) checkAndSendReminders(ctx context.Context) {
 	start := now.Add(24 * time.Hour)
 	end := now.Add(25 * time.Hour)
 
-	// Get events with deadline in the 24-25 hour window
-	events, err := ns.getEventsByDeadlineRange(ctx, start, end)
+	// Claim events with deadline in the 24-25 hour window
+	eventIDs, err := ns.claimAllDueReminders(ctx, start, end)
 	if err != nil {
-		ns.logger.Error("failed to get events for reminders", "error", err)
-		return
+		ns.logger.Error("failed to claim events for reminders", "error", err)
 	}
 
-	for _, event := range events {
-		// Skip if event is no longer active
-		if event.Status != EventStatusActive {
-			continue
-		}
-
-		// Check if reminder was already sent
-		if ns.wasReminderSent(ctx, event.ID) {
-			continue
-		}
-
+	for _, eventID := range eventIDs {
 		// Send reminder
-		if err := ns.SendDeadlineReminder(ctx, event.ID); err != nil {
-			ns.logger.Error("failed to send deadline reminder", "event_id", event.ID, "error", err)
+		if err := ns.SendDeadlineReminder(ctx, eventID); err != nil {
+			ns.logger.Error("failed to send deadline reminder", "event_id", eventID, "error", err)
 			continue
 		}
 
 		// Mark reminder as sent
-		if err := ns.markReminderSent(ctx, event.ID); err != nil {
-			ns.logger.Error("failed to mark reminder as sent", "event_id", event.ID, "error", err)
+		if err := ns.markReminderSent(ctx, eventID); err != nil {
+			ns.logger.Error("failed to mark reminder as sent", "event_id", eventID, "error", err)
 		}
 	}
 
 	// Check for expired events and send notifications to organizers
 	ns.checkAndSendExpiredNotifications(ctx)
+
+	// Remind organizers about expired events they still haven't resolved
+	ns.checkAndSendResolutionNags(ctx)
+
+	// Escalate events that stay unresolved long after the deadline to all admins
+	ns.checkAndSendResolutionEscalations(ctx)
 }
 
 // checkAndSendExpiredNotifications checks for expired events and sends notifications to organizers
@@ -426,40 +795,208 @@ func (ns *NotificationService) checkAndSendExpiredNotifications(ctx context.Cont
 	}
 }
 
-// performStartupRecovery checks for missed reminders during downtime
-func (ns *NotificationService) performStartupRecovery(ctx context.Context) error {
+// checkAndSendResolutionNags reminds organizers to resolve events whose grace period after the deadline has passed
+func (ns *NotificationService) checkAndSendResolutionNags(ctx context.Context) {
+	if !ns.nagPolicy.Enabled() {
+		return
+	}
+
 	now := time.Now()
-	start := now.Add(24 * time.Hour)
-	end := now.Add(48 * time.Hour)
+	// Look for events past the grace period that may still be due a reminder,
+	// with an extra day to catch up on reminders missed during downtime
+	end := now.Add(-ns.nagPolicy.Delay)
+	start := end.Add(-ns.nagPolicy.Interval*time.Duration(ns.nagPolicy.MaxCount) - 24*time.Hour)
 
-	// Get events with deadline in the next 24-48 hours
+	// Only active events are returned, so resolved and archived events are never nagged about
 	events, err := ns.getEventsByDeadlineRange(ctx, start, end)
 	if err != nil {
-		ns.logger.Error("failed to get events for startup recovery", "error", err)
+		ns.logger.Error("failed to get expired events for resolution reminders", "error", err)
+		return
+	}
+
+	for _, event := range events {
+		count, lastSentAt, err := ns.reminderRepo.GetResolutionNagState(ctx, event.ID)
+		if err != nil {
+			ns.logger.Error("failed to get resolution reminder state", "event_id", event.ID, "error", err)
+			continue
+		}
+
+		// Stop after the maximum number of reminders and wait for the interval between them
+		if count >= ns.nagPolicy.MaxCount {
+			continue
+		}
+		if count > 0 && now.Sub(lastSentAt) < ns.nagPolicy.Interval {
+			continue
+		}
+
+		if err := ns.SendResolutionNag(ctx, event.ID, count+1); err != nil {
+			ns.logger.Error("failed to send resolution reminder", "event_id", event.ID, "error", err)
+			continue
+		}
+
+		if err := ns.reminderRepo.MarkResolutionNagSent(ctx, event.ID); err != nil {
+			ns.logger.Error("failed to mark resolution reminder as sent", "event_id", event.ID, "error", err)
+		}
+	}
+}
+
+// SendResolutionNag reminds the event organizer to resolve an event whose deadline has passed.
+// number is the 1-based number of this reminder.
+func (ns *NotificationService) SendResolutionNag(ctx context.Context, eventID int64, number int) error {
+	event, err := ns.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		ns.logger.Error("failed to get event for resolution reminder", "event_id", eventID, "error", err)
 		return err
 	}
 
-	recoveredCount := 0
+	// Check if event is still waiting for resolution
+	if event.Status != EventStatusActive {
+		ns.logger.Debug("skipping resolution reminder for non-active event", "event_id", eventID, "status", event.Status)
+		return nil
+	}
+
+	hoursOverdue := int(time.Since(event.Deadline).Hours())
+	text := ns.localizer.MustLocalizeWithTemplate(locale.NotificationResolutionNag,
+		event.Question,
+		fmt.Sprintf("%d", hoursOverdue),
+		fmt.Sprintf("%d", number),
+		fmt.Sprintf("%d", ns.nagPolicy.MaxCount),
+	)
+
+	keyboard := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{
+					Text:         ns.localizer.MustLocalize(locale.NotificationEventExpiredButtonText),
+					CallbackData: fmt.Sprintf("resolve:%d", eventID),
+				},
+			},
+		},
+	}
+
+	_, err = ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      event.CreatedBy,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+	if err != nil {
+		ns.logger.Error("failed to send resolution reminder to organizer", "event_id", eventID, "organizer_id", event.CreatedBy, "error", err)
+		return err
+	}
+
+	ns.logger.Info("resolution reminder sent to organizer", "event_id", eventID, "organizer_id", event.CreatedBy, "number", number)
+	return nil
+}
+
+// checkAndSendResolutionEscalations notifies all admins once about each event still unresolved
+// past the escalation threshold
+func (ns *NotificationService) checkAndSendResolutionEscalations(ctx context.Context) {
+	if !ns.nagPolicy.EscalationEnabled() {
+		return
+	}
+
+	// Look back an extra day to catch up on escalations missed during downtime
+	end := time.Now().Add(-ns.nagPolicy.EscalateAfter)
+	start := end.Add(-24 * time.Hour)
+
+	// Only active events are returned, so resolved and archived events are never escalated
+	events, err := ns.getEventsByDeadlineRange(ctx, start, end)
+	if err != nil {
+		ns.logger.Error("failed to get expired events for resolution escalation", "error", err)
+		return
+	}
+
+	sent := 0
 	for _, event := range events {
-		// Skip if event is no longer active
-		if event.Status != EventStatusActive {
+		if sent >= resolutionEscalationBatchSize {
+			break
+		}
+
+		escalated, err := ns.reminderRepo.WasResolutionNagEscalated(ctx, event.ID)
+		if err != nil {
+			ns.logger.Error("failed to get resolution escalation state", "event_id", event.ID, "error", err)
+			continue
+		}
+		if escalated {
 			continue
 		}
 
-		// Check if reminder was already sent
-		if ns.wasReminderSent(ctx, event.ID) {
+		if err := ns.SendResolutionEscalation(ctx, event.ID); err != nil {
+			ns.logger.Error("failed to send resolution escalation", "event_id", event.ID, "error", err)
 			continue
 		}
+		sent++
+
+		if err := ns.reminderRepo.MarkResolutionNagEscalated(ctx, event.ID); err != nil {
+			ns.logger.Error("failed to mark resolution escalation as sent", "event_id", event.ID, "error", err)
+		}
+	}
+}
+
+// SendResolutionEscalation tells all admins that an event is still unresolved long after its
+// deadline, with a button to resolve it
+func (ns *NotificationService) SendResolutionEscalation(ctx context.Context, eventID int64) error {
+	event, err := ns.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		ns.logger.Error("failed to get event for resolution escalation", "event_id", eventID, "error", err)
+		return err
+	}
+
+	// Check if event is still waiting for resolution
+	if event.Status != EventStatusActive {
+		ns.logger.Debug("skipping resolution escalation for non-active event", "event_id", eventID, "status", event.Status)
+		return nil
+	}
+
+	hoursOverdue := int(time.Since(event.Deadline).Hours())
+	text := ns.localizer.MustLocalizeWithTemplate(locale.NotificationResolutionEscalation,
+		event.Question,
+		fmt.Sprintf("%d", hoursOverdue),
+		fmt.Sprintf("%d", event.CreatedBy),
+	)
+
+	keyboard := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{
+					Text:         ns.localizer.MustLocalize(locale.NotificationResolutionEscalationButton),
+					CallbackData: fmt.Sprintf("resolve:%d", eventID),
+				},
+			},
+		},
+	}
 
+	if err := ns.sendAdminMessage(ctx, ns.nagPolicy.Admins, text, keyboard); err != nil {
+		return err
+	}
+
+	ns.logger.Info("resolution escalation sent to admins", "event_id", eventID, "organizer_id", event.CreatedBy, "admins", len(ns.nagPolicy.Admins))
+	return nil
+}
+
+// performStartupRecovery checks for missed reminders during downtime
+func (ns *NotificationService) performStartupRecovery(ctx context.Context) error {
+	now := time.Now()
+	start := now.Add(24 * time.Hour)
+	end := now.Add(48 * time.Hour)
+
+	// Claim events with deadline in the next 24-48 hours
+	eventIDs, claimErr := ns.claimAllDueReminders(ctx, start, end)
+	if claimErr != nil {
+		ns.logger.Error("failed to claim events for startup recovery", "error", claimErr)
+	}
+
+	recoveredCount := 0
+	for _, eventID := range eventIDs {
 		// Send reminder immediately
-		if err := ns.SendDeadlineReminder(ctx, event.ID); err != nil {
-			ns.logger.Error("failed to send recovery reminder", "event_id", event.ID, "error", err)
+		if err := ns.SendDeadlineReminder(ctx, eventID); err != nil {
+			ns.logger.Error("failed to send recovery reminder", "event_id", eventID, "error", err)
 			continue
 		}
 
 		// Mark reminder as sent
-		if err := ns.markReminderSent(ctx, event.ID); err != nil {
-			ns.logger.Error("failed to mark recovery reminder as sent", "event_id", event.ID, "error", err)
+		if err := ns.markReminderSent(ctx, eventID); err != nil {
+			ns.logger.Error("failed to mark recovery reminder as sent", "event_id", eventID, "error", err)
 		}
 
 		recoveredCount++
@@ -469,10 +1006,13 @@ func (ns *NotificationService) performStartupRecovery(ctx context.Context) error
 		ns.logger.Info("startup recovery completed", "recovered_reminders", recoveredCount)
 	}
 
+	// Send custom reminders that became due during downtime
+	ns.checkAndSendScheduledReminders(ctx)
+
 	// Also check for expired events that might have been missed
 	ns.performExpiredEventsRecovery(ctx)
 
-	return nil
+	return claimErr
 }
 
 // performExpiredEventsRecovery checks for expired events that might have missed organizer notifications
@@ -520,7 +1060,8 @@ func (ns *NotificationService) performExpiredEventsRecovery(ctx context.Context)
 	}
 }
 
-// getEventsByDeadlineRange retrieves events with deadline in the specified range
+// getEventsByDeadlineRange retrieves the active events awaiting resolution with deadline in the specified range.
+// Quizzes resolve themselves at the deadline, so organizers are never asked to resolve them.
 // This uses the repository's GetEventsByDeadlineRange method which returns events from all groups
 func (ns *NotificationService) getEventsByDeadlineRange(ctx context.Context, start, end time.Time) ([]*Event, error) {
 	// Use the repository method that gets events by deadline range
@@ -531,7 +1072,7 @@ func (ns *NotificationService) getEventsByDeadlineRange(ctx context.Context, sta
 
 	var filtered []*Event
 	for _, event := range events {
-		if event.Status == EventStatusActive {
+		if event.Status == EventStatusActive && !event.IsQuiz() {
 			filtered = append(filtered, event)
 		}
 	}
@@ -539,14 +1080,23 @@ func (ns *NotificationService) getEventsByDeadlineRange(ctx context.Context, sta
 	return filtered, nil
 }
 
-// wasReminderSent checks if a reminder was already sent for an event
-func (ns *NotificationService) wasReminderSent(ctx context.Context, eventID int64) bool {
-	sent, err := ns.reminderRepo.WasReminderSent(ctx, eventID)
-	if err != nil {
-		ns.logger.Error("failed to check if reminder was sent", "event_id", eventID, "error", err)
-		return false // Assume not sent on error
+// reminderClaimBatchSize limits how many reminders are claimed by a single query
+const reminderClaimBatchSize = 50
+
+// claimAllDueReminders claims reminders in batches until no more are due in the range.
+// Events claimed before an error are still returned, so their reminders are not lost.
+func (ns *NotificationService) claimAllDueReminders(ctx context.Context, start, end time.Time) ([]int64, error) {
+	var eventIDs []int64
+	for {
+		batch, err := ns.reminderRepo.ClaimDueReminders(ctx, ns.instanceID, start, end, reminderClaimBatchSize)
+		if err != nil {
+			return eventIDs, err
+		}
+		eventIDs = append(eventIDs, batch...)
+		if len(batch) < reminderClaimBatchSize {
+			return eventIDs, nil
+		}
 	}
-	return sent
 }
 
 // markReminderSent marks a reminder as sent for an event
@@ -612,6 +1162,97 @@ func (ns *NotificationService) SendEventExpiredNotification(ctx context.Context,
 	return nil
 }
 
+// SendAdminNotification sends a message to every admin.
+// Returns an error only if the message could not be delivered to any admin.
+func (ns *NotificationService) SendAdminNotification(ctx context.Context, adminIDs []int64, text string) error {
+	return ns.sendAdminMessage(ctx, adminIDs, text, nil)
+}
+
+// sendAdminMessage sends a message with an optional keyboard to every admin.
+// Returns an error only if the message could not be delivered to any admin.
+func (ns *NotificationService) sendAdminMessage(ctx context.Context, adminIDs []int64, text string, replyMarkup models.ReplyMarkup) error {
+	var lastErr error
+	sentCount := 0
+	for _, adminID := range adminIDs {
+		_, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      adminID,
+			Text:        text,
+			ReplyMarkup: replyMarkup,
+		})
+		if err != nil {
+			ns.logger.Warn("failed to send admin notification", "admin_id", adminID, "error", err)
+			lastErr = err
+			// Continue sending to other admins
+			continue
+		}
+		sentCount++
+	}
+
+	if sentCount == 0 && lastErr != nil {
+		return lastErr
+	}
+	return nil
+}
+
+// SendFeedbackNotification forwards user feedback to admins
+func (ns *NotificationService) SendFeedbackNotification(ctx context.Context, adminIDs []int64, feedback *Feedback) error {
+	author := feedback.DisplayName
+	if author == "" {
+		author = fmt.Sprintf("User id%d", feedback.UserID)
+	}
+
+	text := ns.localizer.MustLocalizeWithTemplate(locale.NotificationFeedback,
+		fmt.Sprintf("%d", feedback.ID),
+		author,
+		fmt.Sprintf("%d", feedback.UserID),
+		feedback.Text,
+	)
+	return ns.SendAdminNotification(ctx, adminIDs, text)
+}
+
+// SendDuelExpiredNotification tells the participants of a duel that it expired. A challenge that
+// was never accepted is only reported to the challenger, an unreported duel to both sides.
+func (ns *NotificationService) SendDuelExpiredNotification(ctx context.Context, duel *Duel) error {
+	recipients := []int64{duel.ChallengerID}
+	key := locale.NotificationDuelExpiredPending
+	if duel.OpponentPick != nil {
+		recipients = append(recipients, duel.OpponentID)
+		key = locale.NotificationDuelExpiredUnreported
+	}
+
+	text := ns.localizer.MustLocalizeWithTemplate(key, duel.Question)
+	var lastErr error
+	for _, userID := range recipients {
+		if _, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, Text: text}); err != nil {
+			ns.logger.Warn("failed to send duel expired notification", "duel_id", duel.ID, "user_id", userID, "error", err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// SendInactiveRemovalNotification tells a member that they were removed from a group for
+// inactivity and how to rejoin it
+func (ns *NotificationService) SendInactiveRemovalNotification(ctx context.Context, userID int64, group *Group, rejoinLink string) error {
+	_, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: userID,
+		Text:   ns.localizer.MustLocalizeWithTemplate(locale.NotificationInactiveRemoved, group.Name, rejoinLink),
+	})
+	return err
+}
+
+// SendVoteRejectedNotification tells a user in private why their poll vote wasn't counted.
+// Users known to have no private chat with the bot are skipped with ErrUserUnreachable.
+func (ns *NotificationService) SendVoteRejectedNotification(ctx context.Context, userID int64, text string) error {
+	return ns.sendDirect(ctx, userID, 0, func() error {
+		_, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: userID,
+			Text:   text,
+		})
+		return err
+	})
+}
+
 // wasOrganizerNotificationSent checks if an organizer notification was already sent for an event
 func (ns *NotificationService) wasOrganizerNotificationSent(ctx context.Context, eventID int64) bool {
 	sent, err := ns.reminderRepo.WasOrganizerNotificationSent(ctx, eventID)
@@ -20,10 +20,17 @@ func (m *MockBotForExpiredNotification) SendMessage(ctx context.Context, params
 	return &models.Message{ID: 1}, nil
 }
 
+func (m *MockBotForExpiredNotification) SendPhoto(ctx context.Context, params *bot.SendPhotoParams) (*models.Message, error) {
+	return &models.Message{ID: 1}, nil
+}
+
 // MockReminderRepoForExpired tracks organizer notifications
 type MockReminderRepoForExpired struct {
 	organizerNotificationsSent map[int64]bool
 	remindersSent              map[int64]bool
+	resolutionNags             map[int64]int
+	resolutionNagLastSent      map[int64]time.Time
+	resolutionNagsEscalated    map[int64]bool
 }
 
 func (m *MockReminderRepoForExpired) WasReminderSent(ctx context.Context, eventID int64) (bool, error) {
@@ -38,6 +45,22 @@ func (m *MockReminderRepoForExpired) MarkReminderSent(ctx context.Context, event
 	return nil
 }
 
+func (m *MockReminderRepoForExpired) ClaimDueReminders(ctx context.Context, instanceID string, start, end time.Time, limit int) ([]int64, error) {
+	return nil, nil
+}
+
+func (m *MockReminderRepoForExpired) ScheduleEventReminders(ctx context.Context, eventID int64, reminders []ScheduledReminder) error {
+	return nil
+}
+
+func (m *MockReminderRepoForExpired) ClaimScheduledReminders(ctx context.Context, instanceID string, now time.Time, limit int) ([]ScheduledReminder, error) {
+	return nil, nil
+}
+
+func (m *MockReminderRepoForExpired) MarkScheduledReminderSent(ctx context.Context, eventID int64, offset time.Duration) error {
+	return nil
+}
+
 func (m *MockReminderRepoForExpired) WasOrganizerNotificationSent(ctx context.Context, eventID int64) (bool, error) {
 	return m.organizerNotificationsSent[eventID], nil
 }
@@ -50,6 +73,32 @@ func (m *MockReminderRepoForExpired) MarkOrganizerNotificationSent(ctx context.C
 	return nil
 }
 
+func (m *MockReminderRepoForExpired) GetResolutionNagState(ctx context.Context, eventID int64) (int, time.Time, error) {
+	return m.resolutionNags[eventID], m.resolutionNagLastSent[eventID], nil
+}
+
+func (m *MockReminderRepoForExpired) MarkResolutionNagSent(ctx context.Context, eventID int64) error {
+	if m.resolutionNags == nil {
+		m.resolutionNags = make(map[int64]int)
+		m.resolutionNagLastSent = make(map[int64]time.Time)
+	}
+	m.resolutionNags[eventID]++
+	m.resolutionNagLastSent[eventID] = time.Now()
+	return nil
+}
+
+func (m *MockReminderRepoForExpired) WasResolutionNagEscalated(ctx context.Context, eventID int64) (bool, error) {
+	return m.resolutionNagsEscalated[eventID], nil
+}
+
+func (m *MockReminderRepoForExpired) MarkResolutionNagEscalated(ctx context.Context, eventID int64) error {
+	if m.resolutionNagsEscalated == nil {
+		m.resolutionNagsEscalated = make(map[int64]bool)
+	}
+	m.resolutionNagsEscalated[eventID] = true
+	return nil
+}
+
 func TestNotificationService_SendEventExpiredNotification(t *testing.T) {
 	// Create expired event
 	event := &Event{
@@ -220,6 +269,230 @@ func TestNotificationService_CheckAndSendExpiredNotifications(t *testing.T) {
 	}
 }
 
+func TestNotificationService_CheckAndSendResolutionNags(t *testing.T) {
+	now := time.Now()
+
+	// Create events: one past the grace period, one still within it, one resolved, one archived
+	overdueEvent := &Event{
+		ID:        1,
+		Question:  "Overdue event",
+		CreatedBy: 123,
+		Status:    EventStatusActive,
+		Deadline:  now.Add(-30 * time.Hour),
+	}
+	graceEvent := &Event{
+		ID:        2,
+		Question:  "Event within grace period",
+		CreatedBy: 124,
+		Status:    EventStatusActive,
+		Deadline:  now.Add(-2 * time.Hour),
+	}
+	resolvedEvent := &Event{
+		ID:        3,
+		Question:  "Resolved event",
+		CreatedBy: 125,
+		Status:    EventStatusResolved,
+		Deadline:  now.Add(-30 * time.Hour),
+	}
+	archivedEvent := &Event{
+		ID:        4,
+		Question:  "Archived event",
+		CreatedBy: 126,
+		Status:    EventStatusArchived,
+		Deadline:  now.Add(-30 * time.Hour),
+	}
+
+	mockBot := &MockBotForExpiredNotification{}
+	mockReminderRepo := &MockReminderRepoForExpired{}
+	ns := NewNotificationService(
+		mockBot,
+		&MockEventRepoWithEvents{events: []*Event{overdueEvent, graceEvent, resolvedEvent, archivedEvent}},
+		&MockPredictionRepo{},
+		&MockRatingRepo{},
+		mockReminderRepo,
+		&MockLogger{},
+		&MockLocalizer{},
+	)
+
+	ctx := context.Background()
+
+	// Disabled by default
+	ns.checkAndSendResolutionNags(ctx)
+	if len(mockBot.sentMessages) != 0 {
+		t.Fatalf("Expected no reminders with the default policy, got %d", len(mockBot.sentMessages))
+	}
+
+	ns.SetResolutionNagPolicy(ResolutionNagPolicy{Delay: 24 * time.Hour, Interval: 12 * time.Hour, MaxCount: 2})
+
+	// First reminder goes to the organizer of the overdue event only
+	ns.checkAndSendResolutionNags(ctx)
+	if len(mockBot.sentMessages) != 1 {
+		t.Fatalf("Expected 1 reminder, got %d", len(mockBot.sentMessages))
+	}
+	sentMessage := mockBot.sentMessages[0]
+	if sentMessage.ChatID != overdueEvent.CreatedBy {
+		t.Errorf("Expected reminder to be sent to organizer %d, got %d", overdueEvent.CreatedBy, sentMessage.ChatID)
+	}
+	if !containsLocalizationKey(sentMessage.Text, "NotificationResolutionNag") {
+		t.Errorf("Expected reminder text, got: %s", sentMessage.Text)
+	}
+	keyboard, ok := sentMessage.ReplyMarkup.(*models.InlineKeyboardMarkup)
+	if !ok || len(keyboard.InlineKeyboard) == 0 || keyboard.InlineKeyboard[0][0].CallbackData != fmt.Sprintf("resolve:%d", overdueEvent.ID) {
+		t.Errorf("Expected resolve button, got %+v", sentMessage.ReplyMarkup)
+	}
+
+	// No repeat before the interval has passed
+	ns.checkAndSendResolutionNags(ctx)
+	if len(mockBot.sentMessages) != 1 {
+		t.Fatalf("Expected no repeat within the interval, got %d reminders", len(mockBot.sentMessages))
+	}
+
+	// Repeat once the interval has passed
+	mockReminderRepo.resolutionNagLastSent[overdueEvent.ID] = now.Add(-13 * time.Hour)
+	ns.checkAndSendResolutionNags(ctx)
+	if len(mockBot.sentMessages) != 2 {
+		t.Fatalf("Expected a second reminder after the interval, got %d", len(mockBot.sentMessages))
+	}
+
+	// Stop after the maximum number of reminders
+	mockReminderRepo.resolutionNagLastSent[overdueEvent.ID] = now.Add(-13 * time.Hour)
+	ns.checkAndSendResolutionNags(ctx)
+	if len(mockBot.sentMessages) != 2 {
+		t.Fatalf("Expected reminders to stop at the maximum, got %d", len(mockBot.sentMessages))
+	}
+	if mockReminderRepo.resolutionNags[overdueEvent.ID] != 2 {
+		t.Errorf("Expected 2 recorded reminders, got %d", mockReminderRepo.resolutionNags[overdueEvent.ID])
+	}
+
+	// Stop once the event is resolved
+	mockReminderRepo.resolutionNags[overdueEvent.ID] = 0
+	overdueEvent.Status = EventStatusResolved
+	ns.checkAndSendResolutionNags(ctx)
+	if len(mockBot.sentMessages) != 2 {
+		t.Fatalf("Expected no reminders for a resolved event, got %d", len(mockBot.sentMessages))
+	}
+}
+
+func TestNotificationService_CheckAndSendResolutionEscalations(t *testing.T) {
+	now := time.Now()
+
+	// One event past the escalation threshold, one not yet, one resolved
+	overdueEvent := &Event{
+		ID:        1,
+		Question:  "Long overdue event",
+		CreatedBy: 123,
+		Status:    EventStatusActive,
+		Deadline:  now.Add(-80 * time.Hour),
+	}
+	recentEvent := &Event{
+		ID:        2,
+		Question:  "Recently expired event",
+		CreatedBy: 124,
+		Status:    EventStatusActive,
+		Deadline:  now.Add(-30 * time.Hour),
+	}
+	resolvedEvent := &Event{
+		ID:        3,
+		Question:  "Resolved event",
+		CreatedBy: 125,
+		Status:    EventStatusResolved,
+		Deadline:  now.Add(-80 * time.Hour),
+	}
+
+	mockBot := &MockBotForExpiredNotification{}
+	mockReminderRepo := &MockReminderRepoForExpired{}
+	ns := NewNotificationService(
+		mockBot,
+		&MockEventRepoWithEvents{events: []*Event{overdueEvent, recentEvent, resolvedEvent}},
+		&MockPredictionRepo{},
+		&MockRatingRepo{},
+		mockReminderRepo,
+		&MockLogger{},
+		&MockLocalizer{},
+	)
+
+	ctx := context.Background()
+	admins := []int64{900, 901}
+
+	// Disabled without a threshold
+	ns.SetResolutionNagPolicy(ResolutionNagPolicy{Admins: admins})
+	ns.checkAndSendResolutionEscalations(ctx)
+	if len(mockBot.sentMessages) != 0 {
+		t.Fatalf("Expected no escalation without a threshold, got %d messages", len(mockBot.sentMessages))
+	}
+
+	ns.SetResolutionNagPolicy(ResolutionNagPolicy{EscalateAfter: 72 * time.Hour, Admins: admins})
+
+	// The long overdue event goes to every admin
+	ns.checkAndSendResolutionEscalations(ctx)
+	if len(mockBot.sentMessages) != len(admins) {
+		t.Fatalf("Expected %d escalation messages, got %d", len(admins), len(mockBot.sentMessages))
+	}
+	for i, sentMessage := range mockBot.sentMessages {
+		if sentMessage.ChatID != admins[i] {
+			t.Errorf("Expected escalation to admin %d, got %d", admins[i], sentMessage.ChatID)
+		}
+		if !containsLocalizationKey(sentMessage.Text, "NotificationResolutionEscalation") {
+			t.Errorf("Expected escalation text, got: %s", sentMessage.Text)
+		}
+		keyboard, ok := sentMessage.ReplyMarkup.(*models.InlineKeyboardMarkup)
+		if !ok || len(keyboard.InlineKeyboard) == 0 || keyboard.InlineKeyboard[0][0].CallbackData != fmt.Sprintf("resolve:%d", overdueEvent.ID) {
+			t.Errorf("Expected resolve button, got %+v", sentMessage.ReplyMarkup)
+		}
+	}
+	if !mockReminderRepo.resolutionNagsEscalated[overdueEvent.ID] {
+		t.Error("Expected the escalation to be recorded")
+	}
+
+	// Each event is escalated only once
+	ns.checkAndSendResolutionEscalations(ctx)
+	if len(mockBot.sentMessages) != len(admins) {
+		t.Fatalf("Expected no repeated escalation, got %d messages", len(mockBot.sentMessages))
+	}
+
+	// The organizer reminders are not affected
+	if mockReminderRepo.resolutionNags[overdueEvent.ID] != 0 {
+		t.Errorf("Expected no organizer reminders to be recorded, got %d", mockReminderRepo.resolutionNags[overdueEvent.ID])
+	}
+}
+
+func TestNotificationService_ResolutionEscalationBatch(t *testing.T) {
+	var events []*Event
+	for i := 1; i <= resolutionEscalationBatchSize+2; i++ {
+		events = append(events, &Event{
+			ID:        int64(i),
+			Question:  fmt.Sprintf("Event %d", i),
+			CreatedBy: 123,
+			Status:    EventStatusActive,
+			Deadline:  time.Now().Add(-80 * time.Hour),
+		})
+	}
+
+	mockBot := &MockBotForExpiredNotification{}
+	ns := NewNotificationService(
+		mockBot,
+		&MockEventRepoWithEvents{events: events},
+		&MockPredictionRepo{},
+		&MockRatingRepo{},
+		&MockReminderRepoForExpired{},
+		&MockLogger{},
+		&MockLocalizer{},
+	)
+	ns.SetResolutionNagPolicy(ResolutionNagPolicy{EscalateAfter: 72 * time.Hour, Admins: []int64{900}})
+
+	ctx := context.Background()
+
+	// A backlog is spread over scheduler passes
+	ns.checkAndSendResolutionEscalations(ctx)
+	if len(mockBot.sentMessages) != resolutionEscalationBatchSize {
+		t.Fatalf("Expected %d escalations in the first pass, got %d", resolutionEscalationBatchSize, len(mockBot.sentMessages))
+	}
+	ns.checkAndSendResolutionEscalations(ctx)
+	if len(mockBot.sentMessages) != len(events) {
+		t.Fatalf("Expected the rest to be escalated in the next pass, got %d", len(mockBot.sentMessages))
+	}
+}
+
 // MockEventRepoWithEvents returns events based on deadline range
 type MockEventRepoWithEvents struct {
 	events []*Event
@@ -248,6 +521,20 @@ func (m *MockEventRepoWithEvents) GetActiveEvents(ctx context.Context, groupID i
 	return result, nil
 }
 
+func (m *MockEventRepoWithEvents) GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*Event, error) {
+	events, err := m.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	var visible []*Event
+	for _, event := range events {
+		if event.IsParticipant(userID) {
+			visible = append(visible, event)
+		}
+	}
+	return visible, nil
+}
+
 func (m *MockEventRepoWithEvents) GetResolvedEvents(ctx context.Context) ([]*Event, error) {
 	var result []*Event
 	for _, event := range m.events {
@@ -268,6 +555,64 @@ func (m *MockEventRepoWithEvents) GetEventsByDeadlineRange(ctx context.Context,
 	return result, nil
 }
 
+func (m *MockEventRepoWithEvents) ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockEventRepoWithEvents) GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepoWithEvents) UnarchiveEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *MockEventRepoWithEvents) SetPollMessageMissing(ctx context.Context, eventID int64, missing bool) error {
+	return nil
+}
+
+func (m *MockEventRepoWithEvents) SetResolutionNote(ctx context.Context, eventID int64, note string) error {
+	for _, event := range m.events {
+		if event.ID == eventID {
+			event.ResolutionNote = note
+		}
+	}
+	return nil
+}
+
+func (m *MockEventRepoWithEvents) SetMinorityCorrect(ctx context.Context, eventID int64, minority bool) error {
+	for _, event := range m.events {
+		if event.ID == eventID {
+			event.MinorityCorrect = minority
+		}
+	}
+	return nil
+}
+
+func (m *MockEventRepoWithEvents) GetPollMissingEvents(ctx context.Context) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepoWithEvents) CloseVoting(ctx context.Context, eventID int64, closedAt time.Time) error {
+	return nil
+}
+
+func (m *MockEventRepoWithEvents) ActivatePendingEvent(ctx context.Context, event *Event) error {
+	return nil
+}
+
+func (m *MockEventRepoWithEvents) RejectPendingEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *MockEventRepoWithEvents) FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepoWithEvents) UpdateEventCreator(ctx context.Context, eventID int64, createdBy int64) error {
+	return nil
+}
+
 func (m *MockEventRepoWithEvents) CreateEvent(ctx context.Context, event *Event) error {
 	return nil
 }
@@ -138,6 +138,10 @@ func (m *MockForumBot) SendMessage(ctx context.Context, params *bot.SendMessageP
 	return &models.Message{}, nil
 }
 
+func (m *MockForumBot) SendPhoto(ctx context.Context, params *bot.SendPhotoParams) (*models.Message, error) {
+	return &models.Message{}, nil
+}
+
 // MockForumTopicRepo is a mock forum topic repository
 type MockForumTopicRepo struct {
 	topics map[int64]*ForumTopic
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// repeatingReminderRepo hands out the same due reminder on every claim, so each startup
+// recovery pass sends it again
+type repeatingReminderRepo struct {
+	MockReminderRepo
+	eventID int64
+}
+
+func (m *repeatingReminderRepo) ClaimDueReminders(ctx context.Context, instanceID string, start, end time.Time, limit int) ([]int64, error) {
+	return []int64{m.eventID}, nil
+}
+
+func TestStartScheduler_SecondStartRejected(t *testing.T) {
+	ctx := context.Background()
+	mockBot := &MockNotificationBot{}
+	event := &Event{
+		ID:       1,
+		GroupID:  1,
+		Question: "Will it rain?",
+		Status:   EventStatusActive,
+		Deadline: time.Now().Add(36 * time.Hour),
+	}
+	ns := NewNotificationService(
+		mockBot,
+		&MockEventRepoWithData{event: event},
+		&MockPredictionRepo{},
+		&MockRatingRepoWithData{topRatings: []*Rating{{UserID: 7, GroupID: 1}}},
+		&repeatingReminderRepo{eventID: event.ID},
+		&MockLogger{},
+		&MockLocalizer{},
+	)
+	t.Cleanup(ns.Stop)
+	reminders := func() int {
+		count := 0
+		for _, msg := range mockBot.sentMessages {
+			if msg.ChatID == 7 {
+				count++
+			}
+		}
+		return count
+	}
+
+	if err := ns.StartScheduler(ctx); err != nil {
+		t.Fatalf("StartScheduler failed: %v", err)
+	}
+	if got := reminders(); got != 1 {
+		t.Fatalf("expected 1 recovered reminder, got %d", got)
+	}
+
+	// A second start while running is refused and sends nothing
+	if err := ns.StartScheduler(ctx); !errors.Is(err, ErrSchedulerAlreadyStarted) {
+		t.Fatalf("expected ErrSchedulerAlreadyStarted, got %v", err)
+	}
+	if got := reminders(); got != 1 {
+		t.Errorf("expected no duplicate reminders, got %d", got)
+	}
+
+	// After Stop the scheduler can be started again
+	ns.Stop()
+	ns.Stop()
+	if err := ns.StartScheduler(ctx); err != nil {
+		t.Fatalf("StartScheduler after Stop failed: %v", err)
+	}
+	if got := reminders(); got != 2 {
+		t.Errorf("expected the restarted scheduler to run recovery again, got %d reminders", got)
+	}
+}
+
+func TestStartScheduler_RestartAfterContextCancelled(t *testing.T) {
+	ns := NewNotificationService(
+		&MockNotificationBot{},
+		&MockEventRepoWithData{event: &Event{ID: 1, GroupID: 1, Status: EventStatusResolved}},
+		&MockPredictionRepo{},
+		&MockRatingRepoWithData{},
+		&MockReminderRepo{},
+		&MockLogger{},
+		&MockLocalizer{},
+	)
+	t.Cleanup(ns.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := ns.StartScheduler(ctx); err != nil {
+		t.Fatalf("StartScheduler failed: %v", err)
+	}
+	done := ns.schedulerDone
+	cancel()
+	<-done
+
+	// A scheduler stopped by its context doesn't block a new start
+	if err := ns.StartScheduler(context.Background()); err != nil {
+		t.Errorf("expected a start after the context was cancelled, got %v", err)
+	}
+}
@@ -111,8 +111,9 @@ type MockNotificationBot struct {
 }
 
 type MockNotificationMessage struct {
-	ChatID int64
-	Text   string
+	ChatID      int64
+	Text        string
+	PhotoFileID string
 }
 
 func (m *MockNotificationBot) SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error) {
@@ -124,6 +125,18 @@ func (m *MockNotificationBot) SendMessage(ctx context.Context, params *bot.SendM
 	return &models.Message{}, nil
 }
 
+func (m *MockNotificationBot) SendPhoto(ctx context.Context, params *bot.SendPhotoParams) (*models.Message, error) {
+	msg := MockNotificationMessage{
+		ChatID: params.ChatID.(int64),
+		Text:   params.Caption,
+	}
+	if photo, ok := params.Photo.(*models.InputFileString); ok {
+		msg.PhotoFileID = photo.Data
+	}
+	m.sentMessages = append(m.sentMessages, msg)
+	return &models.Message{}, nil
+}
+
 type MockEventRepo struct{}
 
 func (m *MockEventRepo) CreateEvent(ctx context.Context, event *Event) error {
@@ -142,6 +155,20 @@ func (m *MockEventRepo) GetActiveEvents(ctx context.Context, groupID int64) ([]*
 	return []*Event{}, nil
 }
 
+func (m *MockEventRepo) GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*Event, error) {
+	events, err := m.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	var visible []*Event
+	for _, event := range events {
+		if event.IsParticipant(userID) {
+			visible = append(visible, event)
+		}
+	}
+	return visible, nil
+}
+
 func (m *MockEventRepo) UpdateEvent(ctx context.Context, event *Event) error {
 	return nil
 }
@@ -162,6 +189,54 @@ func (m *MockEventRepo) GetEventsByDeadlineRange(ctx context.Context, start, end
 	return []*Event{}, nil
 }
 
+func (m *MockEventRepo) ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockEventRepo) GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepo) UnarchiveEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *MockEventRepo) SetPollMessageMissing(ctx context.Context, eventID int64, missing bool) error {
+	return nil
+}
+
+func (m *MockEventRepo) SetResolutionNote(ctx context.Context, eventID int64, note string) error {
+	return nil
+}
+
+func (m *MockEventRepo) SetMinorityCorrect(ctx context.Context, eventID int64, minority bool) error {
+	return nil
+}
+
+func (m *MockEventRepo) GetPollMissingEvents(ctx context.Context) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepo) CloseVoting(ctx context.Context, eventID int64, closedAt time.Time) error {
+	return nil
+}
+
+func (m *MockEventRepo) ActivatePendingEvent(ctx context.Context, event *Event) error {
+	return nil
+}
+
+func (m *MockEventRepo) RejectPendingEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *MockEventRepo) FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepo) UpdateEventCreator(ctx context.Context, eventID int64, createdBy int64) error {
+	return nil
+}
+
 type MockPredictionRepo struct{}
 
 func (m *MockPredictionRepo) SavePrediction(ctx context.Context, prediction *Prediction) error {
@@ -172,6 +247,10 @@ func (m *MockPredictionRepo) UpdatePrediction(ctx context.Context, prediction *P
 	return nil
 }
 
+func (m *MockPredictionRepo) SetParticipationAwarded(ctx context.Context, eventID, userID int64, awarded bool) error {
+	return nil
+}
+
 func (m *MockPredictionRepo) GetPredictionsByEvent(ctx context.Context, eventID int64) ([]*Prediction, error) {
 	return []*Prediction{}, nil
 }
@@ -188,6 +267,22 @@ func (m *MockPredictionRepo) GetUserCompletedEventCount(ctx context.Context, use
 	return 0, nil
 }
 
+func (m *MockPredictionRepo) GetUserVotedEventCount(ctx context.Context, userID int64, groupID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *MockPredictionRepo) GetUserActiveGroupCount(ctx context.Context, userID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *MockPredictionRepo) CountVotesSince(ctx context.Context, eventID int64, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *MockPredictionRepo) ImportPredictions(ctx context.Context, groupID int64, rows []*PredictionImportRow, dryRun bool) (*PredictionImportReport, error) {
+	return &PredictionImportReport{DryRun: dryRun, Total: len(rows)}, nil
+}
+
 type MockRatingRepo struct{}
 
 func (m *MockRatingRepo) GetRating(ctx context.Context, userID int64, groupID int64) (*Rating, error) {
@@ -206,6 +301,22 @@ func (m *MockRatingRepo) UpdateStreak(ctx context.Context, userID int64, groupID
 	return nil
 }
 
+func (m *MockRatingRepo) GetTopStreaks(ctx context.Context, groupID int64, limit int) ([]*Rating, error) {
+	return nil, nil
+}
+
+func (m *MockRatingRepo) ReplaceGroupRatings(ctx context.Context, groupID int64, ratings []*Rating) error {
+	return nil
+}
+
+func (m *MockRatingRepo) GetUserRank(ctx context.Context, userID int64, groupID int64) (int, int, error) {
+	return 0, 0, nil
+}
+
+func (m *MockRatingRepo) UpdateUsername(ctx context.Context, userID int64, groupID int64, username string) (bool, error) {
+	return false, nil
+}
+
 type MockLogger struct{}
 
 func (m *MockLogger) Info(msg string, args ...interface{}) {}
@@ -252,6 +363,7 @@ func (m *MockLocalizer) MustLocalize(id string) string {
 		locale.NotificationResultsTopTitle:  "🏆 ТОП УЧАСТНИКОВ",
 		locale.NotificationReminderTitle:    "⏰ НАПОМИНАНИЕ!",
 		locale.NotificationReminderCTA:      "Не забудьте проголосовать! 🗳",
+		locale.PointsLabelDefault:           "очко, очка, очков",
 	}
 
 	if val, ok := translations[id]; ok {
@@ -260,6 +372,10 @@ func (m *MockLocalizer) MustLocalize(id string) string {
 	return id
 }
 
+func (m *MockLocalizer) Localize(id string) (string, error) {
+	return m.MustLocalize(id), nil
+}
+
 func (m *MockLocalizer) MustLocalizeWithTemplate(id string, fields ...string) string {
 	m.localizeWithTemplateCount++
 	m.lastTemplateID = id
@@ -312,7 +428,7 @@ func (m *MockLocalizer) MustLocalizeWithTemplate(id string, fields ...string) st
 		}
 	case locale.RatingTopEntry:
 		if len(fields) >= 3 {
-			return fmt.Sprintf("%s %s - %s очков", fields[0], fields[1], fields[2])
+			return fmt.Sprintf("%s %s - %s", fields[0], fields[1], fields[2])
 		}
 	case locale.NotificationReminderTime:
 		if len(fields) > 0 {
@@ -504,7 +620,7 @@ func TestResultsContainTop5(t *testing.T) {
 
 			// Verify all 5 ratings are present
 			for i := 0; i < 5; i++ {
-				scoreText := fmt.Sprintf("%d очков", topRatings[i].Score)
+				scoreText := FormatPoints(&MockLocalizer{}, topRatings[i].Score, "")
 				if !strings.Contains(message, scoreText) {
 					return false
 				}
@@ -542,6 +658,20 @@ func (m *MockEventRepoWithData) GetActiveEvents(ctx context.Context, groupID int
 	return []*Event{m.event}, nil
 }
 
+func (m *MockEventRepoWithData) GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*Event, error) {
+	events, err := m.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	var visible []*Event
+	for _, event := range events {
+		if event.IsParticipant(userID) {
+			visible = append(visible, event)
+		}
+	}
+	return visible, nil
+}
+
 func (m *MockEventRepoWithData) UpdateEvent(ctx context.Context, event *Event) error {
 	return nil
 }
@@ -562,6 +692,54 @@ func (m *MockEventRepoWithData) GetEventsByDeadlineRange(ctx context.Context, st
 	return []*Event{m.event}, nil
 }
 
+func (m *MockEventRepoWithData) ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockEventRepoWithData) GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepoWithData) UnarchiveEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *MockEventRepoWithData) SetPollMessageMissing(ctx context.Context, eventID int64, missing bool) error {
+	return nil
+}
+
+func (m *MockEventRepoWithData) SetResolutionNote(ctx context.Context, eventID int64, note string) error {
+	return nil
+}
+
+func (m *MockEventRepoWithData) SetMinorityCorrect(ctx context.Context, eventID int64, minority bool) error {
+	return nil
+}
+
+func (m *MockEventRepoWithData) GetPollMissingEvents(ctx context.Context) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepoWithData) CloseVoting(ctx context.Context, eventID int64, closedAt time.Time) error {
+	return nil
+}
+
+func (m *MockEventRepoWithData) ActivatePendingEvent(ctx context.Context, event *Event) error {
+	return nil
+}
+
+func (m *MockEventRepoWithData) RejectPendingEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *MockEventRepoWithData) FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*Event, error) {
+	return nil, nil
+}
+
+func (m *MockEventRepoWithData) UpdateEventCreator(ctx context.Context, eventID int64, createdBy int64) error {
+	return nil
+}
+
 type MockPredictionRepoWithData struct {
 	predictions []*Prediction
 }
@@ -574,6 +752,10 @@ func (m *MockPredictionRepoWithData) UpdatePrediction(ctx context.Context, predi
 	return nil
 }
 
+func (m *MockPredictionRepoWithData) SetParticipationAwarded(ctx context.Context, eventID, userID int64, awarded bool) error {
+	return nil
+}
+
 func (m *MockPredictionRepoWithData) GetPredictionsByEvent(ctx context.Context, eventID int64) ([]*Prediction, error) {
 	return m.predictions, nil
 }
@@ -590,6 +772,28 @@ func (m *MockPredictionRepoWithData) GetUserCompletedEventCount(ctx context.Cont
 	return 0, nil
 }
 
+func (m *MockPredictionRepoWithData) GetUserVotedEventCount(ctx context.Context, userID int64, groupID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *MockPredictionRepoWithData) GetUserActiveGroupCount(ctx context.Context, userID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *MockPredictionRepoWithData) CountVotesSince(ctx context.Context, eventID int64, since time.Time) (int, error) {
+	count := 0
+	for _, prediction := range m.predictions {
+		if prediction.EventID == eventID && prediction.Timestamp.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockPredictionRepoWithData) ImportPredictions(ctx context.Context, groupID int64, rows []*PredictionImportRow, dryRun bool) (*PredictionImportReport, error) {
+	return &PredictionImportReport{DryRun: dryRun, Total: len(rows)}, nil
+}
+
 type MockRatingRepoWithData struct {
 	topRatings []*Rating
 }
@@ -610,6 +814,22 @@ func (m *MockRatingRepoWithData) UpdateStreak(ctx context.Context, userID int64,
 	return nil
 }
 
+func (m *MockRatingRepoWithData) GetTopStreaks(ctx context.Context, groupID int64, limit int) ([]*Rating, error) {
+	return nil, nil
+}
+
+func (m *MockRatingRepoWithData) ReplaceGroupRatings(ctx context.Context, groupID int64, ratings []*Rating) error {
+	return nil
+}
+
+func (m *MockRatingRepoWithData) GetUserRank(ctx context.Context, userID int64, groupID int64) (int, int, error) {
+	return 0, 0, nil
+}
+
+func (m *MockRatingRepoWithData) UpdateUsername(ctx context.Context, userID int64, groupID int64, username string) (bool, error) {
+	return false, nil
+}
+
 type MockReminderRepo struct{}
 
 func (m *MockReminderRepo) WasReminderSent(ctx context.Context, eventID int64) (bool, error) {
@@ -620,6 +840,22 @@ func (m *MockReminderRepo) MarkReminderSent(ctx context.Context, eventID int64)
 	return nil
 }
 
+func (m *MockReminderRepo) ClaimDueReminders(ctx context.Context, instanceID string, start, end time.Time, limit int) ([]int64, error) {
+	return nil, nil
+}
+
+func (m *MockReminderRepo) ScheduleEventReminders(ctx context.Context, eventID int64, reminders []ScheduledReminder) error {
+	return nil
+}
+
+func (m *MockReminderRepo) ClaimScheduledReminders(ctx context.Context, instanceID string, now time.Time, limit int) ([]ScheduledReminder, error) {
+	return nil, nil
+}
+
+func (m *MockReminderRepo) MarkScheduledReminderSent(ctx context.Context, eventID int64, offset time.Duration) error {
+	return nil
+}
+
 func (m *MockReminderRepo) WasOrganizerNotificationSent(ctx context.Context, eventID int64) (bool, error) {
 	return false, nil
 }
@@ -628,6 +864,22 @@ func (m *MockReminderRepo) MarkOrganizerNotificationSent(ctx context.Context, ev
 	return nil
 }
 
+func (m *MockReminderRepo) GetResolutionNagState(ctx context.Context, eventID int64) (int, time.Time, error) {
+	return 0, time.Time{}, nil
+}
+
+func (m *MockReminderRepo) MarkResolutionNagSent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *MockReminderRepo) WasResolutionNagEscalated(ctx context.Context, eventID int64) (bool, error) {
+	return false, nil
+}
+
+func (m *MockReminderRepo) MarkResolutionNagEscalated(ctx context.Context, eventID int64) error {
+	return nil
+}
+
 func TestNotificationServiceUsesLocalizer(t *testing.T) {
 	properties := gopter.NewProperties(nil)
 
@@ -945,3 +1197,43 @@ func TestNotificationServiceUsesLocalizer(t *testing.T) {
 
 	properties.TestingRun(t)
 }
+
+func TestSendDeadlineReminder_AttachesEventPhoto(t *testing.T) {
+	ctx := context.Background()
+
+	for _, photoFileID := range []string{"", "photo-file-id"} {
+		mockBot := &MockNotificationBot{}
+		event := &Event{
+			ID:          1,
+			GroupID:     1,
+			Question:    "Will it rain?",
+			Status:      EventStatusActive,
+			Deadline:    time.Now().Add(12 * time.Hour),
+			PhotoFileID: photoFileID,
+		}
+		ns := NewNotificationService(
+			mockBot,
+			&MockEventRepoWithData{event: event},
+			&MockPredictionRepo{},
+			&MockRatingRepoWithData{topRatings: []*Rating{{UserID: 7, GroupID: 1}}},
+			&MockReminderRepo{},
+			&MockLogger{},
+			&MockLocalizer{},
+		)
+
+		if err := ns.SendDeadlineReminder(ctx, event.ID); err != nil {
+			t.Fatalf("SendDeadlineReminder failed: %v", err)
+		}
+
+		if len(mockBot.sentMessages) != 1 {
+			t.Fatalf("expected 1 reminder, got %d", len(mockBot.sentMessages))
+		}
+		sent := mockBot.sentMessages[0]
+		if sent.ChatID != 7 || sent.Text == "" {
+			t.Errorf("expected reminder text sent to user 7, got %+v", sent)
+		}
+		if sent.PhotoFileID != photoFileID {
+			t.Errorf("expected reminder photo %q, got %q", photoFileID, sent.PhotoFileID)
+		}
+	}
+}
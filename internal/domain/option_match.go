@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MatchOption maps a typed answer to an event option. It tries an exact match first, then a
+// case-insensitive one, then a best-effort match (ignoring punctuation and extra spaces, then
+// a prefix or substring of the option, then a small number of typos). It reports false when
+// nothing matches or the answer fits several options equally well; MatchOptionCandidates
+// lists those options.
+func MatchOption(options []string, input string) (int, bool) {
+	candidates := MatchOptionCandidates(options, input)
+	if len(candidates) != 1 {
+		return -1, false
+	}
+	return candidates[0], true
+}
+
+// MatchOptionCandidates returns the indexes of the options a typed answer matches at the
+// first matching stage of MatchOption, in option order. More than one index means the
+// answer is ambiguous; none means it matches no option.
+func MatchOptionCandidates(options []string, input string) []int {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil
+	}
+
+	stages := []func(option string) bool{
+		// Exact
+		func(option string) bool { return strings.TrimSpace(option) == input },
+		// Case-insensitive
+		func(option string) bool { return strings.EqualFold(strings.TrimSpace(option), input) },
+	}
+
+	normalizedInput := normalizeOptionText(input)
+	if normalizedInput != "" {
+		stages = append(stages,
+			// Same words, ignoring punctuation and spacing
+			func(option string) bool { return normalizeOptionText(option) == normalizedInput },
+			// Start of the option, e.g. "real" for "Real Madrid"
+			func(option string) bool { return strings.HasPrefix(normalizeOptionText(option), normalizedInput) },
+			// Part of the option, e.g. "madrid" for "Real Madrid"
+			func(option string) bool { return strings.Contains(normalizeOptionText(option), normalizedInput) },
+		)
+	}
+
+	for _, matches := range stages {
+		var candidates []int
+		for i, option := range options {
+			if matches(option) {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) > 0 {
+			return candidates
+		}
+	}
+
+	return closestOptions(options, normalizedInput)
+}
+
+// closestOptions returns the options within a few typos of the normalized input, keeping
+// only those with the smallest edit distance
+func closestOptions(options []string, normalizedInput string) []int {
+	inputLength := len([]rune(normalizedInput))
+	if inputLength == 0 {
+		return nil
+	}
+
+	maxDistance := maxTypos(inputLength)
+
+	var candidates []int
+	best := maxDistance + 1
+	for i, option := range options {
+		distance := editDistance(normalizeOptionText(option), normalizedInput)
+		switch {
+		case distance < best:
+			best = distance
+			candidates = []int{i}
+		case distance == best:
+			candidates = append(candidates, i)
+		}
+	}
+
+	return candidates
+}
+
+// IsOptionTextFix reports whether the options only fix typos in the original ones: there are as many,
+// and each stays within a few typos of the original at its position and closer to it than to any other
+func IsOptionTextFix(original, options []string) bool {
+	if len(options) != len(original) {
+		return false
+	}
+
+	for i, option := range options {
+		if strings.TrimSpace(option) == strings.TrimSpace(original[i]) {
+			continue
+		}
+		// Options without letters or digits, e.g. emoji, can't be compared for typos
+		normalized, normalizedOriginal := normalizeOptionText(option), normalizeOptionText(original[i])
+		if normalized == "" || normalizedOriginal == "" {
+			return false
+		}
+		distance := editDistance(normalizedOriginal, normalized)
+		if distance > maxTypos(max(len([]rune(normalized)), len([]rune(normalizedOriginal)))) {
+			return false
+		}
+		for j, other := range original {
+			if j != i && editDistance(normalizeOptionText(other), normalized) <= distance {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// maxTypos returns the number of typos tolerated in a text of the given length: one per four
+// characters, at least one
+func maxTypos(length int) int {
+	return max(length/4, 1)
+}
+
+// normalizeOptionText lowercases text and keeps only letters and digits, with single spaces between words
+func normalizeOptionText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// editDistance returns the Levenshtein distance between two strings, counted in runes
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j] + 1
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+			if previous[j-1]+cost < current[j] {
+				current[j] = previous[j-1] + cost
+			}
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchOption(t *testing.T) {
+	options := []string{"Real Madrid", "real madrid B", "Barcelona", "Atlético Madrid", "Draw"}
+
+	tests := []struct {
+		input    string
+		expected int
+		ok       bool
+	}{
+		// Exact wins over a case-insensitive match of another option
+		{"Real Madrid", 0, true},
+		{" Barcelona ", 2, true},
+		// Case-insensitive
+		{"DRAW", 4, true},
+		{"real madrid", 0, true},
+		// Punctuation and spacing are ignored
+		{"draw!", 4, true},
+		{"real   madrid b", 1, true},
+		// Start of an option
+		{"barc", 2, true},
+		{"atl", 3, true},
+		// Part of an option
+		{"lona", 2, true},
+		// Typos
+		{"Barcelonna", 2, true},
+		{"drew", 4, true},
+		// Ambiguous: both Real Madrid options start with "real"
+		{"real", -1, false},
+		// Ambiguous: three options contain "madrid"
+		{"madrid", -1, false},
+		// No match
+		{"Juventus", -1, false},
+		{"", -1, false},
+		{"  ", -1, false},
+		{"?!", -1, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := MatchOption(options, tt.input)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("MatchOption(%q) = %d, %t; want %d, %t", tt.input, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestMatchOptionCandidates(t *testing.T) {
+	options := []string{"Real Madrid", "Real Sociedad", "Barcelona", "Atlético Madrid"}
+
+	tests := []struct {
+		input    string
+		expected []int
+	}{
+		{"real", []int{0, 1}},
+		{"madrid", []int{0, 3}},
+		{"Barcelona", []int{2}},
+		{"chelsea", nil},
+	}
+
+	for _, tt := range tests {
+		if got := MatchOptionCandidates(options, tt.input); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("MatchOptionCandidates(%q) = %v; want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"draw", "drew", 1},
+		{"мяч", "мат", 2},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("editDistance(%q, %q) = %d; want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/go-telegram/bot"
+)
+
+// OutcomeNotificationMode is how a user is told about the outcomes of events they voted on
+type OutcomeNotificationMode string
+
+const (
+	// OutcomeNotificationsImmediate sends a direct message per resolved event (default)
+	OutcomeNotificationsImmediate OutcomeNotificationMode = "immediate"
+	// OutcomeNotificationsDigest batches outcomes into one direct message per day
+	OutcomeNotificationsDigest OutcomeNotificationMode = "digest"
+	// OutcomeNotificationsOff disables outcome messages
+	OutcomeNotificationsOff OutcomeNotificationMode = "off"
+)
+
+// IsValid checks if the outcome notification mode is one of the valid values
+func (m OutcomeNotificationMode) IsValid() bool {
+	switch m {
+	case OutcomeNotificationsImmediate, OutcomeNotificationsDigest, OutcomeNotificationsOff:
+		return true
+	}
+	return false
+}
+
+// ErrInvalidOutcomeNotificationMode is returned when an unknown outcome notification mode is set
+var ErrInvalidOutcomeNotificationMode = NewError(ErrorKindValidation, "invalid outcome notification mode")
+
+// digestInterval is the minimum time between two digests of a user
+const digestInterval = 24 * time.Hour
+
+// digestMaxItems caps the outcomes listed in one digest message
+const digestMaxItems = 30
+
+// DigestRecipient is a digest user with the time their last digest covered
+type DigestRecipient struct {
+	UserID int64
+	Since  time.Time
+}
+
+// ResolvedOutcome is the outcome of a resolved event together with a user's vote on it
+type ResolvedOutcome struct {
+	EventID       int64
+	Question      string
+	Options       []string
+	CorrectOption int
+	UserOption    int
+	ResolvedAt    time.Time
+}
+
+// IsCorrect reports whether the user voted for the correct option
+func (o *ResolvedOutcome) IsCorrect() bool {
+	return o.UserOption == o.CorrectOption
+}
+
+// NotificationSettingsRepository interface for per-user outcome notification preferences.
+// Users without stored settings get immediate notifications.
+type NotificationSettingsRepository interface {
+	GetOutcomeNotificationMode(ctx context.Context, userID int64) (OutcomeNotificationMode, error)
+	// SetOutcomeNotificationMode stores the mode; switching to digest starts the digest at the current time
+	SetOutcomeNotificationMode(ctx context.Context, userID int64, mode OutcomeNotificationMode) error
+	// GetDigestRecipients returns digest users whose last digest is older than before
+	GetDigestRecipients(ctx context.Context, before time.Time) ([]DigestRecipient, error)
+	MarkDigestSent(ctx context.Context, userID int64, sentAt time.Time) error
+}
+
+// ResolvedOutcomeRepository reads the outcomes of events a user voted on
+type ResolvedOutcomeRepository interface {
+	GetResolvedOutcomesSince(ctx context.Context, userID int64, since time.Time) ([]*ResolvedOutcome, error)
+}
+
+// SetOutcomeNotifications enables outcome direct messages to voters (disabled by default)
+func (ns *NotificationService) SetOutcomeNotifications(settingsRepo NotificationSettingsRepository, outcomeRepo ResolvedOutcomeRepository) {
+	ns.settingsRepo = settingsRepo
+	ns.outcomeRepo = outcomeRepo
+}
+
+// SendOutcomeNotifications sends the outcome of a resolved event to every voter who chose
+// immediate notifications. Digest users get it with their next SendDailyDigests.
+func (ns *NotificationService) SendOutcomeNotifications(ctx context.Context, event *Event, predictions []*Prediction) {
+	if ns.settingsRepo == nil || event.CorrectOption == nil {
+		return
+	}
+
+	sent := 0
+	for _, pred := range predictions {
+		mode, err := ns.settingsRepo.GetOutcomeNotificationMode(ctx, pred.UserID)
+		if err != nil {
+			ns.logger.Error("failed to get outcome notification mode", "user_id", pred.UserID, "error", err)
+			continue
+		}
+		if mode != OutcomeNotificationsImmediate {
+			continue
+		}
+
+		outcome := &ResolvedOutcome{
+			EventID:       event.ID,
+			Question:      event.Question,
+			Options:       event.Options,
+			CorrectOption: *event.CorrectOption,
+			UserOption:    pred.Option,
+		}
+		err = ns.sendDirect(ctx, pred.UserID, event.GroupID, func() error {
+			_, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: pred.UserID,
+				Text:   ns.localizer.MustLocalize(locale.OutcomeNotificationTitle) + "\n\n" + ns.formatOutcome(outcome),
+			})
+			return err
+		})
+		if err != nil {
+			ns.logger.Warn("failed to send outcome notification", "user_id", pred.UserID, "event_id", event.ID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	ns.logger.Info("outcome notifications sent", "event_id", event.ID, "count", sent)
+}
+
+// SendDailyDigests sends every digest user whose last digest is at least a day old one message
+// with the outcomes resolved since then, and returns the number of sent digests
+func (ns *NotificationService) SendDailyDigests(ctx context.Context) (int, error) {
+	if ns.settingsRepo == nil || ns.outcomeRepo == nil {
+		return 0, nil
+	}
+
+	now := time.Now()
+	recipients, err := ns.settingsRepo.GetDigestRecipients(ctx, now.Add(-digestInterval))
+	if err != nil {
+		ns.logger.Error("failed to get digest recipients", "error", err)
+		return 0, err
+	}
+
+	sent := 0
+	for _, recipient := range recipients {
+		outcomes, err := ns.outcomeRepo.GetResolvedOutcomesSince(ctx, recipient.UserID, recipient.Since)
+		if err != nil {
+			ns.logger.Error("failed to get outcomes for digest", "user_id", recipient.UserID, "error", err)
+			continue
+		}
+
+		if len(outcomes) > 0 {
+			err = ns.sendDirect(ctx, recipient.UserID, 0, func() error {
+				_, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+					ChatID: recipient.UserID,
+					Text:   ns.formatDigest(outcomes),
+				})
+				return err
+			})
+			if err != nil {
+				ns.logger.Warn("failed to send outcome digest", "user_id", recipient.UserID, "error", err)
+				continue
+			}
+			sent++
+		}
+
+		// Outcomes resolved until now are covered, also when there were none
+		if err := ns.settingsRepo.MarkDigestSent(ctx, recipient.UserID, now); err != nil {
+			ns.logger.Error("failed to mark digest sent", "user_id", recipient.UserID, "error", err)
+		}
+	}
+
+	if sent > 0 {
+		ns.logger.Info("outcome digests sent", "count", sent)
+	}
+	return sent, nil
+}
+
+// formatDigest lists outcomes in one message, capped at digestMaxItems
+func (ns *NotificationService) formatDigest(outcomes []*ResolvedOutcome) string {
+	var sb strings.Builder
+	sb.WriteString(ns.localizer.MustLocalizeWithTemplate(locale.OutcomeDigestTitle, fmt.Sprintf("%d", len(outcomes))) + "\n")
+
+	for i, outcome := range outcomes {
+		if i == digestMaxItems {
+			sb.WriteString("\n" + ns.localizer.MustLocalizeWithTemplate(locale.OutcomeDigestMore, fmt.Sprintf("%d", len(outcomes)-digestMaxItems)))
+			break
+		}
+		sb.WriteString("\n" + ns.formatOutcome(outcome) + "\n")
+	}
+
+	return sb.String()
+}
+
+// formatOutcome describes the correct answer of an event and the user's vote
+func (ns *NotificationService) formatOutcome(outcome *ResolvedOutcome) string {
+	mark := "❌"
+	if outcome.IsCorrect() {
+		mark = "✅"
+	}
+
+	return ns.localizer.MustLocalizeWithTemplate(locale.OutcomeNotificationItem,
+		mark,
+		outcome.Question,
+		optionText(outcome.Options, outcome.CorrectOption),
+		optionText(outcome.Options, outcome.UserOption),
+	)
+}
+
+// optionText returns the text of an option, or its number when it is out of range
+func optionText(options []string, index int) string {
+	if index >= 0 && index < len(options) {
+		return options[index]
+	}
+	return fmt.Sprintf("%d", index+1)
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+)
+
+// mockNotificationSettingsRepo keeps modes and digest times in memory
+type mockNotificationSettingsRepo struct {
+	modes      map[int64]OutcomeNotificationMode
+	lastDigest map[int64]time.Time
+}
+
+func (m *mockNotificationSettingsRepo) GetOutcomeNotificationMode(ctx context.Context, userID int64) (OutcomeNotificationMode, error) {
+	if mode, ok := m.modes[userID]; ok {
+		return mode, nil
+	}
+	return OutcomeNotificationsImmediate, nil
+}
+
+func (m *mockNotificationSettingsRepo) SetOutcomeNotificationMode(ctx context.Context, userID int64, mode OutcomeNotificationMode) error {
+	m.modes[userID] = mode
+	return nil
+}
+
+func (m *mockNotificationSettingsRepo) GetDigestRecipients(ctx context.Context, before time.Time) ([]DigestRecipient, error) {
+	var recipients []DigestRecipient
+	for userID, mode := range m.modes {
+		if mode == OutcomeNotificationsDigest && m.lastDigest[userID].Before(before) {
+			recipients = append(recipients, DigestRecipient{UserID: userID, Since: m.lastDigest[userID]})
+		}
+	}
+	return recipients, nil
+}
+
+func (m *mockNotificationSettingsRepo) MarkDigestSent(ctx context.Context, userID int64, sentAt time.Time) error {
+	m.lastDigest[userID] = sentAt
+	return nil
+}
+
+// mockResolvedOutcomeRepo serves fixed outcomes per user
+type mockResolvedOutcomeRepo struct {
+	outcomes map[int64][]*ResolvedOutcome
+}
+
+func (m *mockResolvedOutcomeRepo) GetResolvedOutcomesSince(ctx context.Context, userID int64, since time.Time) ([]*ResolvedOutcome, error) {
+	var outcomes []*ResolvedOutcome
+	for _, outcome := range m.outcomes[userID] {
+		if outcome.ResolvedAt.After(since) {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes, nil
+}
+
+func newOutcomeNotificationService(t *testing.T, settingsRepo *mockNotificationSettingsRepo, outcomeRepo *mockResolvedOutcomeRepo) (*NotificationService, *MockNotificationBot) {
+	t.Helper()
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	mockBot := &MockNotificationBot{}
+	ns := NewNotificationService(mockBot, nil, nil, nil, nil, &mockLogger{}, localizer)
+	ns.SetOutcomeNotifications(settingsRepo, outcomeRepo)
+	return ns, mockBot
+}
+
+func TestSendOutcomeNotifications_OnlyImmediateUsers(t *testing.T) {
+	settingsRepo := &mockNotificationSettingsRepo{
+		modes:      map[int64]OutcomeNotificationMode{2: OutcomeNotificationsDigest, 3: OutcomeNotificationsOff},
+		lastDigest: map[int64]time.Time{},
+	}
+	ns, mockBot := newOutcomeNotificationService(t, settingsRepo, &mockResolvedOutcomeRepo{})
+
+	correct := 0
+	event := &Event{ID: 10, Question: "Will it rain?", Options: []string{"Yes", "No"}, CorrectOption: &correct}
+	ns.SendOutcomeNotifications(context.Background(), event, []*Prediction{
+		{EventID: 10, UserID: 1, Option: 0},
+		{EventID: 10, UserID: 2, Option: 0},
+		{EventID: 10, UserID: 3, Option: 1},
+		{EventID: 10, UserID: 4, Option: 1},
+	})
+
+	if len(mockBot.sentMessages) != 2 {
+		t.Fatalf("expected messages to the 2 immediate users, got %+v", mockBot.sentMessages)
+	}
+	if mockBot.sentMessages[0].ChatID != 1 || !strings.Contains(mockBot.sentMessages[0].Text, "✅ Will it rain?") {
+		t.Errorf("unexpected message to user 1: %+v", mockBot.sentMessages[0])
+	}
+	if mockBot.sentMessages[1].ChatID != 4 || !strings.Contains(mockBot.sentMessages[1].Text, "❌ Will it rain?") {
+		t.Errorf("unexpected message to user 4: %+v", mockBot.sentMessages[1])
+	}
+}
+
+func TestSendDailyDigests(t *testing.T) {
+	now := time.Now()
+	settingsRepo := &mockNotificationSettingsRepo{
+		modes: map[int64]OutcomeNotificationMode{
+			1: OutcomeNotificationsDigest,
+			2: OutcomeNotificationsDigest,
+			3: OutcomeNotificationsDigest,
+			4: OutcomeNotificationsImmediate,
+		},
+		lastDigest: map[int64]time.Time{
+			1: now.Add(-25 * time.Hour),
+			2: now.Add(-2 * time.Hour), // Digest sent recently
+			3: now.Add(-48 * time.Hour),
+			4: now.Add(-48 * time.Hour),
+		},
+	}
+	outcomeRepo := &mockResolvedOutcomeRepo{outcomes: map[int64][]*ResolvedOutcome{
+		1: {
+			{EventID: 1, Question: "Old event?", Options: []string{"Yes", "No"}, ResolvedAt: now.Add(-30 * time.Hour)},
+			{EventID: 2, Question: "First event?", Options: []string{"Yes", "No"}, CorrectOption: 0, UserOption: 0, ResolvedAt: now.Add(-20 * time.Hour)},
+			{EventID: 3, Question: "Second event?", Options: []string{"Yes", "No"}, CorrectOption: 1, UserOption: 0, ResolvedAt: now.Add(-time.Hour)},
+		},
+		2: {{EventID: 3, Question: "Second event?", Options: []string{"Yes", "No"}, ResolvedAt: now.Add(-time.Hour)}},
+		4: {{EventID: 3, Question: "Second event?", Options: []string{"Yes", "No"}, ResolvedAt: now.Add(-time.Hour)}},
+	}}
+	ns, mockBot := newOutcomeNotificationService(t, settingsRepo, outcomeRepo)
+
+	sent, err := ns.SendDailyDigests(context.Background())
+	if err != nil {
+		t.Fatalf("SendDailyDigests failed: %v", err)
+	}
+
+	// Only user 1 is due with outcomes; user 3 is due without any
+	if sent != 1 || len(mockBot.sentMessages) != 1 || mockBot.sentMessages[0].ChatID != 1 {
+		t.Fatalf("expected one digest to user 1, got %d: %+v", sent, mockBot.sentMessages)
+	}
+	text := mockBot.sentMessages[0].Text
+	if !strings.Contains(text, "First event?") || !strings.Contains(text, "Second event?") || strings.Contains(text, "Old event?") {
+		t.Errorf("expected the two outcomes since the last digest, got %q", text)
+	}
+	if !settingsRepo.lastDigest[1].After(now.Add(-time.Minute)) || !settingsRepo.lastDigest[3].After(now.Add(-time.Minute)) {
+		t.Errorf("expected due digests to be marked sent, got %v", settingsRepo.lastDigest)
+	}
+	if !settingsRepo.lastDigest[2].Equal(now.Add(-2 * time.Hour)) {
+		t.Errorf("expected the recent digest to be kept, got %v", settingsRepo.lastDigest[2])
+	}
+
+	// A second run on the same day sends nothing
+	sent, err = ns.SendDailyDigests(context.Background())
+	if err != nil || sent != 0 || len(mockBot.sentMessages) != 1 {
+		t.Errorf("expected no repeated digests, got %d, %v", sent, err)
+	}
+}
+
+func TestFormatDigest_CapsItems(t *testing.T) {
+	ns, _ := newOutcomeNotificationService(t, &mockNotificationSettingsRepo{}, &mockResolvedOutcomeRepo{})
+
+	var outcomes []*ResolvedOutcome
+	for i := 0; i < digestMaxItems+5; i++ {
+		outcomes = append(outcomes, &ResolvedOutcome{Question: "Question?", Options: []string{"Yes", "No"}})
+	}
+
+	text := ns.formatDigest(outcomes)
+	if n := strings.Count(text, "Question?"); n != digestMaxItems {
+		t.Errorf("expected %d listed outcomes, got %d", digestMaxItems, n)
+	}
+	if !strings.Contains(text, "...and 5 more") {
+		t.Errorf("expected the remaining count, got %q", text)
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+// ParticipationBonusCap limits how many participation bonuses a user can earn in a group
+// within a period. Counters are kept in memory and reset when a new period starts.
+type ParticipationBonusCap struct {
+	limit  int
+	period time.Duration
+	now    func() time.Time
+
+	mu          sync.Mutex
+	periodStart time.Time
+	counts      map[participationBonusKey]int
+}
+
+// participationBonusKey identifies a user's counter within a group
+type participationBonusKey struct {
+	userID  int64
+	groupID int64
+}
+
+// NewParticipationBonusCap creates a new ParticipationBonusCap.
+// Periods are aligned to UTC multiples of period (a 7-day period starts on Monday 00:00 UTC).
+// A non-positive limit or period disables the cap.
+func NewParticipationBonusCap(limit int, period time.Duration) *ParticipationBonusCap {
+	return &ParticipationBonusCap{
+		limit:  limit,
+		period: period,
+		now:    time.Now,
+		counts: make(map[participationBonusKey]int),
+	}
+}
+
+// Enabled reports whether the cap is active
+func (c *ParticipationBonusCap) Enabled() bool {
+	return c != nil && c.limit > 0 && c.period > 0
+}
+
+// Allow reports whether the user may receive another participation bonus in the group
+// during the current period, and records the bonus if so
+func (c *ParticipationBonusCap) Allow(userID int64, groupID int64) bool {
+	if !c.Enabled() {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Reset counters when a new period starts
+	start := c.now().UTC().Truncate(c.period)
+	if !start.Equal(c.periodStart) {
+		c.periodStart = start
+		c.counts = make(map[participationBonusKey]int)
+	}
+
+	key := participationBonusKey{userID: userID, groupID: groupID}
+	if c.counts[key] >= c.limit {
+		return false
+	}
+
+	c.counts[key]++
+	return true
+}
+
+// withClock returns an empty cap with the same limit and period that reads the time from now.
+// Returns nil for a nil cap.
+func (c *ParticipationBonusCap) withClock(now func() time.Time) *ParticipationBonusCap {
+	if c == nil {
+		return nil
+	}
+	return &ParticipationBonusCap{
+		limit:  c.limit,
+		period: c.period,
+		now:    now,
+		counts: make(map[participationBonusKey]int),
+	}
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// mockRatingRepoStore keeps ratings in memory keyed by user and group
+type mockRatingRepoStore struct {
+	ratings map[[2]int64]*Rating
+}
+
+func (m *mockRatingRepoStore) GetRating(ctx context.Context, userID int64, groupID int64) (*Rating, error) {
+	if rating, ok := m.ratings[[2]int64{userID, groupID}]; ok {
+		return rating, nil
+	}
+	return &Rating{UserID: userID, GroupID: groupID}, nil
+}
+
+func (m *mockRatingRepoStore) UpdateRating(ctx context.Context, rating *Rating) error {
+	m.ratings[[2]int64{rating.UserID, rating.GroupID}] = rating
+	return nil
+}
+
+func (m *mockRatingRepoStore) GetTopRatings(ctx context.Context, groupID int64, limit int) ([]*Rating, error) {
+	return nil, nil
+}
+
+func (m *mockRatingRepoStore) UpdateStreak(ctx context.Context, userID int64, groupID int64, streak int) error {
+	return nil
+}
+
+func (m *mockRatingRepoStore) GetTopStreaks(ctx context.Context, groupID int64, limit int) ([]*Rating, error) {
+	return nil, nil
+}
+
+func (m *mockRatingRepoStore) ReplaceGroupRatings(ctx context.Context, groupID int64, ratings []*Rating) error {
+	for key := range m.ratings {
+		if key[1] == groupID {
+			delete(m.ratings, key)
+		}
+	}
+	for _, rating := range ratings {
+		m.ratings[[2]int64{rating.UserID, rating.GroupID}] = rating
+	}
+	return nil
+}
+
+func (m *mockRatingRepoStore) GetUserRank(ctx context.Context, userID int64, groupID int64) (int, int, error) {
+	return 0, 0, nil
+}
+
+func (m *mockRatingRepoStore) UpdateUsername(ctx context.Context, userID int64, groupID int64, username string) (bool, error) {
+	rating, ok := m.ratings[[2]int64{userID, groupID}]
+	if !ok || rating.Username == username {
+		return false, nil
+	}
+	rating.Username = username
+	return true, nil
+}
+
+func TestParticipationBonusCap_EnforcedAndResetsEachPeriod(t *testing.T) {
+	bonusCap := NewParticipationBonusCap(2, 7*24*time.Hour)
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC) // Tuesday
+	bonusCap.now = func() time.Time { return now }
+
+	if !bonusCap.Allow(1, 10) || !bonusCap.Allow(1, 10) {
+		t.Fatal("expected first two bonuses to be allowed")
+	}
+	if bonusCap.Allow(1, 10) {
+		t.Error("expected third bonus in the same period to be denied")
+	}
+
+	// Other users and other groups have their own counters
+	if !bonusCap.Allow(2, 10) {
+		t.Error("expected bonus for another user to be allowed")
+	}
+	if !bonusCap.Allow(1, 20) {
+		t.Error("expected bonus in another group to be allowed")
+	}
+
+	// Still the same week (Sunday evening)
+	now = time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC)
+	if bonusCap.Allow(1, 10) {
+		t.Error("expected bonus to stay capped until the period ends")
+	}
+
+	// Next week (Monday) resets the counter
+	now = time.Date(2024, 3, 11, 0, 30, 0, 0, time.UTC)
+	if !bonusCap.Allow(1, 10) {
+		t.Error("expected bonus to be allowed after the period reset")
+	}
+}
+
+func TestParticipationBonusCap_DisabledByDefault(t *testing.T) {
+	var nilCap *ParticipationBonusCap
+	zeroCap := NewParticipationBonusCap(0, 7*24*time.Hour)
+
+	for i := 0; i < 100; i++ {
+		if !nilCap.Allow(1, 10) || !zeroCap.Allow(1, 10) {
+			t.Fatal("expected disabled cap to always allow the bonus")
+		}
+	}
+}
+
+func TestRatingCalculator_ParticipationBonusCapped(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+	groupID := int64(10)
+
+	event := &Event{
+		ID:        1,
+		GroupID:   groupID,
+		EventType: EventTypeBinary,
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+	}
+	predictions := []*Prediction{
+		{EventID: 1, UserID: userID, Option: 1, Timestamp: time.Now().Add(-24 * time.Hour)},
+	}
+
+	bonusCap := NewParticipationBonusCap(2, 24*time.Hour)
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	bonusCap.now = func() time.Time { return now }
+
+	ratingRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+	calc := NewRatingCalculator(
+		ratingRepo,
+		&MockPredictionRepoWithData{predictions: predictions},
+		&MockEventRepoWithData{event: event},
+		bonusCap,
+		&mockLogger{},
+	)
+
+	// Incorrect votes: participation point plus penalty while under the cap, penalty only beyond it
+	expectedScores := []int{
+		ParticipationPoints + IncorrectPenalty,
+		2 * (ParticipationPoints + IncorrectPenalty),
+		2*(ParticipationPoints+IncorrectPenalty) + IncorrectPenalty,
+	}
+	for i, expected := range expectedScores {
+		if err := calc.CalculateScores(ctx, event.ID, 0); err != nil {
+			t.Fatalf("CalculateScores failed: %v", err)
+		}
+		rating, _ := ratingRepo.GetRating(ctx, userID, groupID)
+		if rating.Score != expected {
+			t.Errorf("resolution %d: expected score %d, got %d", i+1, expected, rating.Score)
+		}
+	}
+
+	// The prediction is still counted even without the bonus
+	rating, _ := ratingRepo.GetRating(ctx, userID, groupID)
+	if rating.WrongCount != 3 {
+		t.Errorf("expected 3 wrong predictions to be recorded, got %d", rating.WrongCount)
+	}
+
+	// A new period restores the bonus
+	now = now.Add(24 * time.Hour)
+	if err := calc.CalculateScores(ctx, event.ID, 0); err != nil {
+		t.Fatalf("CalculateScores failed: %v", err)
+	}
+	rating, _ = ratingRepo.GetRating(ctx, userID, groupID)
+	expected := expectedScores[2] + ParticipationPoints + IncorrectPenalty
+	if rating.Score != expected {
+		t.Errorf("after period reset: expected score %d, got %d", expected, rating.Score)
+	}
+}
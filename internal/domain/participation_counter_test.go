@@ -22,6 +22,7 @@ func (m *mockLogger) Warn(msg string, args ...interface{})  {}
 // Mock PredictionRepository for testing
 type mockPredictionRepo struct {
 	completedEventCount int
+	votedEventCount     int
 	err                 error
 }
 
@@ -33,6 +34,10 @@ func (m *mockPredictionRepo) UpdatePrediction(ctx context.Context, prediction *P
 	return nil
 }
 
+func (m *mockPredictionRepo) SetParticipationAwarded(ctx context.Context, eventID, userID int64, awarded bool) error {
+	return nil
+}
+
 func (m *mockPredictionRepo) GetUserCompletedEventCount(ctx context.Context, userID int64, groupID int64) (int, error) {
 	if m.err != nil {
 		return 0, m.err
@@ -40,6 +45,25 @@ func (m *mockPredictionRepo) GetUserCompletedEventCount(ctx context.Context, use
 	return m.completedEventCount, nil
 }
 
+func (m *mockPredictionRepo) GetUserVotedEventCount(ctx context.Context, userID int64, groupID int64) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.votedEventCount, nil
+}
+
+func (m *mockPredictionRepo) GetUserActiveGroupCount(ctx context.Context, userID int64) (int, error) {
+	return 0, nil
+}
+
+func (m *mockPredictionRepo) CountVotesSince(ctx context.Context, eventID int64, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockPredictionRepo) ImportPredictions(ctx context.Context, groupID int64, rows []*PredictionImportRow, dryRun bool) (*PredictionImportReport, error) {
+	return &PredictionImportReport{DryRun: dryRun, Total: len(rows)}, nil
+}
+
 func (m *mockPredictionRepo) GetUserPredictions(ctx context.Context, userID int64) ([]*Prediction, error) {
 	return nil, nil
 }
@@ -71,6 +95,20 @@ func (m *mockEventRepo) GetActiveEvents(ctx context.Context, groupID int64) ([]*
 	return nil, nil
 }
 
+func (m *mockEventRepo) GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*Event, error) {
+	events, err := m.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	var visible []*Event
+	for _, event := range events {
+		if event.IsParticipant(userID) {
+			visible = append(visible, event)
+		}
+	}
+	return visible, nil
+}
+
 func (m *mockEventRepo) GetResolvedEvents(ctx context.Context) ([]*Event, error) {
 	return nil, nil
 }
@@ -91,6 +129,54 @@ func (m *mockEventRepo) GetEventsByDeadlineRange(ctx context.Context, start, end
 	return nil, nil
 }
 
+func (m *mockEventRepo) ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockEventRepo) GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepo) UnarchiveEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *mockEventRepo) SetPollMessageMissing(ctx context.Context, eventID int64, missing bool) error {
+	return nil
+}
+
+func (m *mockEventRepo) SetResolutionNote(ctx context.Context, eventID int64, note string) error {
+	return nil
+}
+
+func (m *mockEventRepo) SetMinorityCorrect(ctx context.Context, eventID int64, minority bool) error {
+	return nil
+}
+
+func (m *mockEventRepo) GetPollMissingEvents(ctx context.Context) ([]*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepo) CloseVoting(ctx context.Context, eventID int64, closedAt time.Time) error {
+	return nil
+}
+
+func (m *mockEventRepo) ActivatePendingEvent(ctx context.Context, event *Event) error {
+	return nil
+}
+
+func (m *mockEventRepo) RejectPendingEvent(ctx context.Context, eventID int64) error {
+	return nil
+}
+
+func (m *mockEventRepo) FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*Event, error) {
+	return nil, nil
+}
+
+func (m *mockEventRepo) UpdateEventCreator(ctx context.Context, eventID int64, createdBy int64) error {
+	return nil
+}
+
 // TestParticipationRequirementCheck tests: Participation requirement check
 func TestParticipationRequirementCheck(t *testing.T) {
 	parameters := gopter.DefaultTestParameters()
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+)
+
+const (
+	// MaxPointsLabelLength is the maximum length of one form of a points label, in characters
+	MaxPointsLabelLength = 20
+	// maxPointsLabelForms is the number of plural forms a points label may have ("one, few, many")
+	maxPointsLabelForms = 3
+)
+
+// ErrInvalidPointsLabel is returned when a points label is empty, too long or has too many forms
+var ErrInvalidPointsLabel = NewError(ErrorKindValidation, "invalid points label")
+
+// NormalizePointsLabel validates a points label given as comma-separated plural forms
+// (e.g. "coin, coins") and returns it with the forms trimmed
+func NormalizePointsLabel(label string) (string, error) {
+	forms := strings.Split(label, ",")
+	if len(forms) > maxPointsLabelForms {
+		return "", ErrInvalidPointsLabel
+	}
+
+	for i, form := range forms {
+		form = strings.TrimSpace(form)
+		if form == "" || utf8.RuneCountInString(form) > MaxPointsLabelLength {
+			return "", ErrInvalidPointsLabel
+		}
+		// The label is inserted into plain-text messages, keep it on one line
+		if strings.IndexFunc(form, unicode.IsControl) >= 0 {
+			return "", ErrInvalidPointsLabel
+		}
+		forms[i] = form
+	}
+
+	return strings.Join(forms, ", "), nil
+}
+
+// FormatPoints formats a score with the group's points label in the plural form matching the score.
+// Groups without a label get the localized "points".
+func FormatPoints(localizer locale.Localizer, score int, label string) string {
+	if label == "" {
+		label = localizer.MustLocalize(locale.PointsLabelDefault)
+	}
+
+	forms := strings.Split(label, ",")
+	for i := range forms {
+		forms[i] = strings.TrimSpace(forms[i])
+	}
+
+	return fmt.Sprintf("%d %s", score, locale.PluralForm(localizer.GetLocale(), score, forms))
+}
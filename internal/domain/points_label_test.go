@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+)
+
+func TestNormalizePointsLabel(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+		valid bool
+	}{
+		{"coins", "coins", true},
+		{" coin ,coins ", "coin, coins", true},
+		{"монета, монеты, монет", "монета, монеты, монет", true},
+		{"", "", false},
+		{"coin,", "", false},
+		{"a, b, c, d", "", false},
+		{strings.Repeat("x", MaxPointsLabelLength+1), "", false},
+		{"co\nin", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizePointsLabel(tt.label)
+		if tt.valid {
+			if err != nil || got != tt.want {
+				t.Errorf("NormalizePointsLabel(%q) = %q, %v; want %q", tt.label, got, err, tt.want)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrInvalidPointsLabel) {
+			t.Errorf("NormalizePointsLabel(%q) = %q, %v; want ErrInvalidPointsLabel", tt.label, got, err)
+		}
+	}
+}
+
+func TestFormatPoints(t *testing.T) {
+	localizer, err := locale.NewLocalizer(context.Background(), locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	tests := []struct {
+		score int
+		label string
+		want  string
+	}{
+		{1, "", "1 point"},
+		{10, "", "10 points"},
+		{1, "coin, coins", "1 coin"},
+		{2, "coin, coins", "2 coins"},
+		{7, "karma", "7 karma"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatPoints(localizer, tt.score, tt.label); got != tt.want {
+			t.Errorf("FormatPoints(%d, %q) = %q, want %q", tt.score, tt.label, got, tt.want)
+		}
+	}
+}
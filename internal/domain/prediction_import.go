@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxPredictionImportRows limits the number of data rows in a single import file
+const MaxPredictionImportRows = 5000
+
+var (
+	ErrImportMissingColumn = errors.New("import file is missing a required column")
+	ErrImportTooManyRows   = errors.New("import file has too many rows")
+	ErrImportEmpty         = errors.New("import file has no data rows")
+)
+
+// PredictionImportSkipReason explains why an import row was not imported
+type PredictionImportSkipReason string
+
+const (
+	ImportSkipInvalidRow     PredictionImportSkipReason = "invalid_row"
+	ImportSkipUnknownEvent   PredictionImportSkipReason = "unknown_event"
+	ImportSkipAmbiguousEvent PredictionImportSkipReason = "ambiguous_event"
+	ImportSkipUnknownUser    PredictionImportSkipReason = "unknown_user"
+	ImportSkipNotParticipant PredictionImportSkipReason = "not_participant"
+	ImportSkipInvalidOption  PredictionImportSkipReason = "invalid_option"
+	ImportSkipDuplicate      PredictionImportSkipReason = "duplicate"
+)
+
+// PredictionImportRow is a single prediction parsed from an import file.
+// The event is referenced either by its internal ID or by question and deadline.
+type PredictionImportRow struct {
+	Line      int
+	UserID    int64
+	EventID   int64 // 0 when the event is matched by question and deadline
+	Question  string
+	Deadline  time.Time
+	Option    string    // option text or 0-based option index
+	Timestamp time.Time // zero means the event deadline
+}
+
+// PredictionImportSkip records a row that was not imported
+type PredictionImportSkip struct {
+	Line   int
+	Reason PredictionImportSkipReason
+}
+
+// PredictionImportReport summarizes an import run
+type PredictionImportReport struct {
+	DryRun   bool
+	Total    int
+	Imported int
+	Skipped  []PredictionImportSkip
+}
+
+// Skip records a skipped row
+func (r *PredictionImportReport) Skip(line int, reason PredictionImportSkipReason) {
+	r.Skipped = append(r.Skipped, PredictionImportSkip{Line: line, Reason: reason})
+}
+
+// Merge adds rows skipped while parsing to the report, keeping skipped rows in line order
+func (r *PredictionImportReport) Merge(parsed *PredictionImportReport) {
+	r.Total += len(parsed.Skipped)
+	r.Skipped = append(r.Skipped, parsed.Skipped...)
+	sort.SliceStable(r.Skipped, func(i, j int) bool {
+		return r.Skipped[i].Line < r.Skipped[j].Line
+	})
+}
+
+// ResolveOption maps an import option value to an option index of the event.
+// An exact (case-insensitive) option text match wins over a numeric index.
+func (row *PredictionImportRow) ResolveOption(options []string) (int, bool) {
+	value := strings.TrimSpace(row.Option)
+	for i, option := range options {
+		if strings.EqualFold(strings.TrimSpace(option), value) {
+			return i, true
+		}
+	}
+
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 || index >= len(options) {
+		return 0, false
+	}
+	return index, true
+}
+
+// predictionImportTimeLayouts are the accepted date formats of deadline and timestamp columns
+var predictionImportTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04",
+}
+
+// parseImportTime parses a date in one of the accepted layouts, using loc when no zone is given
+func parseImportTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range predictionImportTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// ParsePredictionImportCSV parses a predictions CSV file.
+// The header row is required; columns may come in any order and unknown columns are ignored:
+//
+//	user_id   - Telegram user ID (required)
+//	option    - option text or 0-based index (required)
+//	event_id  - internal event ID
+//	question  - event question, used with deadline when event_id is empty
+//	deadline  - event deadline
+//	timestamp - prediction time (defaults to the event deadline)
+//
+// Rows that cannot be parsed are returned in the report as skipped; the returned report
+// is meant to be completed by PredictionRepository.ImportPredictions.
+func ParsePredictionImportCSV(r io.Reader, loc *time.Location) ([]*PredictionImportRow, *PredictionImportReport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil, ErrImportEmpty
+		}
+		return nil, nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+
+	for _, required := range []string{"user_id", "option"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrImportMissingColumn, required)
+		}
+	}
+	_, hasEventID := columns["event_id"]
+	_, hasQuestion := columns["question"]
+	_, hasDeadline := columns["deadline"]
+	if !hasEventID && !(hasQuestion && hasDeadline) {
+		return nil, nil, fmt.Errorf("%w: event_id or question and deadline", ErrImportMissingColumn)
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	report := &PredictionImportReport{}
+	var rows []*PredictionImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		report.Total++
+		if report.Total > MaxPredictionImportRows {
+			return nil, nil, fmt.Errorf("%w: limit is %d", ErrImportTooManyRows, MaxPredictionImportRows)
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, nil, err
+			}
+			report.Skip(parseErr.StartLine, ImportSkipInvalidRow)
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, ok := parsePredictionImportRecord(line, field, record, loc)
+		if !ok {
+			report.Skip(line, ImportSkipInvalidRow)
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if report.Total == 0 {
+		return nil, nil, ErrImportEmpty
+	}
+
+	return rows, report, nil
+}
+
+// parsePredictionImportRecord validates a single CSV record
+func parsePredictionImportRecord(line int, field func([]string, string) string, record []string, loc *time.Location) (*PredictionImportRow, bool) {
+	row := &PredictionImportRow{
+		Line:     line,
+		Question: field(record, "question"),
+		Option:   field(record, "option"),
+	}
+
+	userID, err := strconv.ParseInt(field(record, "user_id"), 10, 64)
+	if err != nil || userID <= 0 {
+		return nil, false
+	}
+	row.UserID = userID
+
+	if row.Option == "" {
+		return nil, false
+	}
+
+	if value := field(record, "event_id"); value != "" {
+		eventID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || eventID <= 0 {
+			return nil, false
+		}
+		row.EventID = eventID
+	}
+
+	if value := field(record, "deadline"); value != "" {
+		deadline, err := parseImportTime(value, loc)
+		if err != nil {
+			return nil, false
+		}
+		row.Deadline = deadline
+	}
+
+	// Without an event ID the event is matched by question and deadline
+	if row.EventID == 0 && (row.Question == "" || row.Deadline.IsZero()) {
+		return nil, false
+	}
+
+	if value := field(record, "timestamp"); value != "" {
+		timestamp, err := parseImportTime(value, loc)
+		if err != nil {
+			return nil, false
+		}
+		row.Timestamp = timestamp
+	}
+
+	return row, true
+}
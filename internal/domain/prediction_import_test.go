@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePredictionImportCSV(t *testing.T) {
+	input := strings.Join([]string{
+		"user_id,event_id,question,deadline,option,timestamp,comment",
+		"100,5,,,Yes,2024-03-01 10:00,ignored",
+		"101,,Will it rain?,2024-03-02 18:00,1,,",
+		"abc,5,,,Yes,,",
+		"102,,Missing deadline,,0,,",
+		"103,5,,,,,",
+		"104,5,,,No,not a date,",
+	}, "\n")
+
+	rows, report, err := ParsePredictionImportCSV(strings.NewReader(input), time.UTC)
+	if err != nil {
+		t.Fatalf("ParsePredictionImportCSV failed: %v", err)
+	}
+
+	if report.Total != 6 {
+		t.Errorf("expected 6 rows, got %d", report.Total)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 valid rows, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first.Line != 2 || first.UserID != 100 || first.EventID != 5 || first.Option != "Yes" {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	if !first.Timestamp.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected timestamp: %v", first.Timestamp)
+	}
+
+	second := rows[1]
+	if second.EventID != 0 || second.Question != "Will it rain?" || !second.Deadline.Equal(time.Date(2024, 3, 2, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected second row: %+v", second)
+	}
+
+	var skippedLines []int
+	for _, skip := range report.Skipped {
+		if skip.Reason != ImportSkipInvalidRow {
+			t.Errorf("expected invalid row reason, got %s", skip.Reason)
+		}
+		skippedLines = append(skippedLines, skip.Line)
+	}
+	if len(skippedLines) != 4 || skippedLines[0] != 4 || skippedLines[3] != 7 {
+		t.Errorf("expected lines 4-7 to be skipped, got %v", skippedLines)
+	}
+}
+
+func TestParsePredictionImportCSV_HeaderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"empty file", "", ErrImportEmpty},
+		{"header only", "user_id,event_id,option\n", ErrImportEmpty},
+		{"missing option", "user_id,event_id\n1,2\n", ErrImportMissingColumn},
+		{"no event reference", "user_id,option,question\n1,Yes,Q\n", ErrImportMissingColumn},
+	}
+
+	for _, tt := range tests {
+		if _, _, err := ParsePredictionImportCSV(strings.NewReader(tt.input), time.UTC); !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestPredictionImportRow_ResolveOption(t *testing.T) {
+	options := []string{"Yes", "No", "0"}
+
+	tests := []struct {
+		value    string
+		expected int
+		ok       bool
+	}{
+		{"yes", 0, true},
+		{" No ", 1, true},
+		{"1", 1, true},
+		{"0", 2, true}, // text match wins over index
+		{"3", 0, false},
+		{"-1", 0, false},
+		{"Maybe", 0, false},
+	}
+
+	for _, tt := range tests {
+		row := &PredictionImportRow{Option: tt.value}
+		got, ok := row.ResolveOption(options)
+		if ok != tt.ok || (ok && got != tt.expected) {
+			t.Errorf("ResolveOption(%q) = %d, %t; want %d, %t", tt.value, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
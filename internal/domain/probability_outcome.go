@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ProbabilityOptionCount is the number of ranges offered for probability events (0-25, 25-50, 50-75, 75-100)
+	ProbabilityOptionCount = 4
+	// BrierBaseline is the Brier score of an uninformative 50% forecast; worse forecasts earn no base points
+	BrierBaseline = 0.25
+)
+
+// ErrInvalidProbabilityOutcome is returned when a realized outcome is not a number between 0 and 100
+var ErrInvalidProbabilityOutcome = errors.New("probability outcome must be a number between 0 and 100")
+
+// ParseProbabilityOutcome parses a realized outcome percentage entered by a user.
+// Accepts values like "73", "73%", "73.5" and "73,5".
+func ParseProbabilityOutcome(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	text = strings.TrimSuffix(text, "%")
+	text = strings.ReplaceAll(strings.TrimSpace(text), ",", ".")
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(value) {
+		return 0, ErrInvalidProbabilityOutcome
+	}
+
+	if err := ValidateProbabilityOutcome(value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// ValidateProbabilityOutcome checks that the outcome percentage is within 0-100
+func ValidateProbabilityOutcome(outcomePercent float64) error {
+	if math.IsNaN(outcomePercent) || outcomePercent < 0 || outcomePercent > 100 {
+		return ErrInvalidProbabilityOutcome
+	}
+	return nil
+}
+
+// FormatProbabilityOutcome formats an outcome percentage without trailing zeros
+func FormatProbabilityOutcome(outcomePercent float64) string {
+	return strconv.FormatFloat(outcomePercent, 'f', -1, 64)
+}
+
+// ProbabilityOutcomeOption returns the index of the probability range containing the outcome.
+// Range boundaries belong to the upper range, except 100 which belongs to the last one.
+func ProbabilityOutcomeOption(outcomePercent float64) int {
+	option := int(outcomePercent / (100 / ProbabilityOptionCount))
+	if option >= ProbabilityOptionCount {
+		option = ProbabilityOptionCount - 1
+	}
+	if option < 0 {
+		option = 0
+	}
+	return option
+}
+
+// ProbabilityOptionForecast returns the stated probability (0-1) of a range, taken as its midpoint
+func ProbabilityOptionForecast(option int) float64 {
+	width := 1.0 / ProbabilityOptionCount
+	return float64(option)*width + width/2
+}
+
+// BrierScore returns the squared error between a forecast and the realized outcome (both 0-1).
+// Lower is better: 0 is a perfect forecast, 1 is the worst possible one.
+func BrierScore(forecast, outcome float64) float64 {
+	diff := forecast - outcome
+	return diff * diff
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseProbabilityOutcome(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{"73", 73, false},
+		{" 73% ", 73, false},
+		{"0", 0, false},
+		{"100", 100, false},
+		{"12.5", 12.5, false},
+		{"12,5", 12.5, false},
+		{"101", 0, true},
+		{"-1", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+		{"NaN", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseProbabilityOutcome(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidProbabilityOutcome) {
+				t.Errorf("ParseProbabilityOutcome(%q): expected ErrInvalidProbabilityOutcome, got %v", tt.input, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseProbabilityOutcome(%q) = %v, %v; want %v", tt.input, got, err, tt.expected)
+		}
+	}
+}
+
+func TestProbabilityOutcomeOption(t *testing.T) {
+	tests := []struct {
+		outcome  float64
+		expected int
+	}{
+		{0, 0},
+		{24.9, 0},
+		{25, 1},
+		{49, 1},
+		{50, 2},
+		{74.5, 2},
+		{75, 3},
+		{100, 3},
+	}
+
+	for _, tt := range tests {
+		if got := ProbabilityOutcomeOption(tt.outcome); got != tt.expected {
+			t.Errorf("ProbabilityOutcomeOption(%v) = %d, want %d", tt.outcome, got, tt.expected)
+		}
+	}
+}
+
+func TestRatingCalculator_ProbabilityScores(t *testing.T) {
+	ctx := context.Background()
+	groupID := int64(10)
+	createdAt := time.Now().Add(-48 * time.Hour)
+	lateVote := time.Now().Add(-24 * time.Hour) // outside early voting window
+
+	event := &Event{
+		ID:        1,
+		GroupID:   groupID,
+		EventType: EventTypeProbability,
+		Options:   []string{"0-25%", "25-50%", "50-75%", "75-100%"},
+		CreatedAt: createdAt,
+	}
+	predictions := []*Prediction{
+		{EventID: 1, UserID: 1, Option: 3, Timestamp: lateVote},
+		{EventID: 1, UserID: 2, Option: 3, Timestamp: lateVote},
+		{EventID: 1, UserID: 3, Option: 2, Timestamp: lateVote},
+		{EventID: 1, UserID: 4, Option: 0, Timestamp: lateVote},
+	}
+
+	ratingRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+	calc := NewRatingCalculator(
+		ratingRepo,
+		&MockPredictionRepoWithData{predictions: predictions},
+		&MockEventRepoWithData{event: event},
+		nil,
+		&mockLogger{},
+	)
+
+	if err := calc.CalculateProbabilityScores(ctx, event.ID, 100); err != nil {
+		t.Fatalf("CalculateProbabilityScores failed: %v", err)
+	}
+
+	scores := make(map[int64]int)
+	for _, pred := range predictions {
+		rating, _ := ratingRepo.GetRating(ctx, pred.UserID, groupID)
+		scores[pred.UserID] = rating.Score
+	}
+
+	// Closer forecasts score strictly higher, the opposite forecast is penalized
+	if !(scores[1] > scores[3] && scores[3] > scores[4]) {
+		t.Errorf("expected scores to follow forecast accuracy, got %v", scores)
+	}
+	if scores[1] != scores[2] {
+		t.Errorf("expected equal forecasts to score equally, got %d and %d", scores[1], scores[2])
+	}
+	if scores[4] != ParticipationPoints+IncorrectPenalty {
+		t.Errorf("expected worst forecast to get participation plus penalty, got %d", scores[4])
+	}
+
+	// The range containing the outcome counts as correct for streaks and accuracy
+	rating, _ := ratingRepo.GetRating(ctx, 1, groupID)
+	if rating.CorrectCount != 1 || rating.Streak != 1 {
+		t.Errorf("expected prediction in outcome range to be correct, got %+v", rating)
+	}
+	rating, _ = ratingRepo.GetRating(ctx, 3, groupID)
+	if rating.WrongCount != 1 || rating.Streak != 0 {
+		t.Errorf("expected prediction outside outcome range to be wrong, got %+v", rating)
+	}
+
+	if err := calc.CalculateProbabilityScores(ctx, event.ID, 150); !errors.Is(err, ErrInvalidProbabilityOutcome) {
+		t.Errorf("expected ErrInvalidProbabilityOutcome for out-of-range outcome, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "errors"
+
+// QuizCorrectPoints are the points for a correct answer to a quiz event. Wrong answers cost
+// nothing and no bonuses apply, since the answer is known upfront rather than predicted.
+const QuizCorrectPoints = 5
+
+var (
+	// ErrInvalidQuizAnswer is returned when the answer of a quiz event is not one of its options
+	ErrInvalidQuizAnswer = errors.New("quiz answer must be one of the options")
+	// ErrInvalidQuizType is returned when a quiz event is a probability event or allows revoting,
+	// neither of which Telegram quiz polls support
+	ErrInvalidQuizType = errors.New("quiz event must be a binary or multi-option event without revoting")
+)
+
+// IsQuiz reports whether the event is a quiz: its correct answer is set at creation, revealed
+// to each voter by the Telegram quiz poll, and the event resolves itself at the deadline
+func (e *Event) IsQuiz() bool {
+	return e.QuizAnswer != nil
+}
+
+// validateQuiz checks the quiz answer and the quiz restrictions of a quiz event
+func (e *Event) validateQuiz() error {
+	if !e.IsQuiz() {
+		return nil
+	}
+	if *e.QuizAnswer < 0 || *e.QuizAnswer >= len(e.Options) {
+		return ErrInvalidQuizAnswer
+	}
+	if e.EventType == EventTypeProbability || e.AllowsRevoting {
+		return ErrInvalidQuizType
+	}
+	return nil
+}
+
+// recordOutcome counts a scored prediction in the rating's accuracy stats and streak.
+// Quiz answers are trivia rather than predictions, so they only change the score.
+func (r *Rating) recordOutcome(event *Event, isCorrect bool) {
+	if event.IsQuiz() {
+		return
+	}
+	if isCorrect {
+		r.CorrectCount++
+		r.IncrementStreak()
+	} else {
+		r.WrongCount++
+		r.Streak = 0
+	}
+}
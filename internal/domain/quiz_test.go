@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventValidate_Quiz(t *testing.T) {
+	answer := func(i int) *int { return &i }
+	newEvent := func() *Event {
+		return &Event{
+			GroupID:   1,
+			Question:  "Which planet is the largest?",
+			Options:   []string{"Mars", "Jupiter", "Venus"},
+			CreatedAt: time.Now(),
+			Deadline:  time.Now().Add(24 * time.Hour),
+			EventType: EventTypeMultiOption,
+			CreatedBy: 1,
+		}
+	}
+
+	event := newEvent()
+	event.QuizAnswer = answer(1)
+	if err := event.Validate(); err != nil {
+		t.Errorf("expected a valid quiz, got %v", err)
+	}
+
+	for _, index := range []int{-1, 3} {
+		event := newEvent()
+		event.QuizAnswer = answer(index)
+		if err := event.Validate(); !errors.Is(err, ErrInvalidQuizAnswer) {
+			t.Errorf("answer %d: expected ErrInvalidQuizAnswer, got %v", index, err)
+		}
+	}
+
+	event = newEvent()
+	event.QuizAnswer = answer(0)
+	event.AllowsRevoting = true
+	if err := event.Validate(); !errors.Is(err, ErrInvalidQuizType) {
+		t.Errorf("expected revoting quizzes to be rejected, got %v", err)
+	}
+
+	event = newEvent()
+	event.EventType = EventTypeProbability
+	event.Options = []string{"0-25%", "25-50%", "50-75%", "75-100%"}
+	event.QuizAnswer = answer(0)
+	if err := event.Validate(); !errors.Is(err, ErrInvalidQuizType) {
+		t.Errorf("expected probability quizzes to be rejected, got %v", err)
+	}
+}
+
+func TestCalculatePoints_Quiz(t *testing.T) {
+	rc := &RatingCalculator{}
+	answer := 0
+	event := &Event{EventType: EventTypeBinary, QuizAnswer: &answer, CreatedAt: time.Now(), Deadline: time.Now().Add(time.Hour)}
+	prediction := &Prediction{Option: 0, Timestamp: event.CreatedAt}
+	// A lone early minority vote would earn both bonuses on a prediction event
+	voteShares := map[int]float64{0: 0.1, 1: 0.9}
+
+	if got := rc.calculatePoints(event, prediction, true, true, voteShares); got != QuizCorrectPoints+ParticipationPoints {
+		t.Errorf("expected %d points for a correct answer, got %d", QuizCorrectPoints+ParticipationPoints, got)
+	}
+	if got := rc.calculatePoints(event, prediction, false, true, voteShares); got != ParticipationPoints {
+		t.Errorf("expected only participation for a wrong answer, got %d", got)
+	}
+
+	rating := &Rating{Streak: 2, CorrectCount: 2}
+	rating.recordOutcome(event, false)
+	if rating.Streak != 2 || rating.WrongCount != 0 {
+		t.Errorf("expected quiz answers to leave accuracy stats alone, got %+v", rating)
+	}
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"math"
+	"sync"
 	"time"
 )
 
@@ -23,33 +25,186 @@ type RatingRepository interface {
 	UpdateRating(ctx context.Context, rating *Rating) error
 	GetTopRatings(ctx context.Context, groupID int64, limit int) ([]*Rating, error)
 	UpdateStreak(ctx context.Context, userID int64, groupID int64, streak int) error
+	GetTopStreaks(ctx context.Context, groupID int64, limit int) ([]*Rating, error)
+	ReplaceGroupRatings(ctx context.Context, groupID int64, ratings []*Rating) error
+	// GetUserRank returns the user's 1-based rank by score among the rated users of a group
+	// (0 when the user has no rating) and the number of rated users. Equal scores share a rank.
+	GetUserRank(ctx context.Context, userID int64, groupID int64) (rank int, total int, err error)
+	// UpdateUsername sets the username of an existing rating and reports whether it changed.
+	// Users without a rating in the group are left alone.
+	UpdateUsername(ctx context.Context, userID int64, groupID int64, username string) (bool, error)
 }
 
 // RatingCalculator handles rating calculations and updates
 type RatingCalculator struct {
-	ratingRepo     RatingRepository
-	predictionRepo PredictionRepository
-	eventRepo      EventRepository
-	logger         Logger
+	ratingRepo       RatingRepository
+	predictionRepo   PredictionRepository
+	eventRepo        EventRepository
+	groupRepo        GroupRepository
+	seasonRepo       SeasonRepository
+	participationCap *ParticipationBonusCap
+	logger           Logger
+
+	// participationAtVote credits the participation point when a member votes instead of at resolution
+	participationAtVote bool
+
+	// mu serializes incremental scoring with RecomputeGroup
+	mu sync.Mutex
 }
 
-// NewRatingCalculator creates a new RatingCalculator
+// NewRatingCalculator creates a new RatingCalculator.
+// participationCap may be nil, in which case participation bonus is not capped.
 func NewRatingCalculator(
 	ratingRepo RatingRepository,
 	predictionRepo PredictionRepository,
 	eventRepo EventRepository,
+	participationCap *ParticipationBonusCap,
 	logger Logger,
 ) *RatingCalculator {
 	return &RatingCalculator{
-		ratingRepo:     ratingRepo,
-		predictionRepo: predictionRepo,
-		eventRepo:      eventRepo,
-		logger:         logger,
+		ratingRepo:       ratingRepo,
+		predictionRepo:   predictionRepo,
+		eventRepo:        eventRepo,
+		participationCap: participationCap,
+		logger:           logger,
 	}
 }
 
+// SetGroupRepository lets the calculator look up per-group scoring options such as
+// reputation weighting of the minority bonus. Without it every vote counts the same.
+func (rc *RatingCalculator) SetGroupRepository(groupRepo GroupRepository) {
+	rc.groupRepo = groupRepo
+}
+
+// SetParticipationAtVote makes the calculator credit the participation point when a member votes.
+// Correctness points are still credited at resolution. Disabled by default.
+func (rc *RatingCalculator) SetParticipationAtVote(enabled bool) {
+	rc.participationAtVote = enabled
+}
+
+// AwardVoteParticipation credits the participation point for a new vote when participation is
+// credited at vote time and the member is within the participation bonus cap. The prediction must
+// already be saved: it is marked as awarded before the point is credited, so resolution and
+// recomputation count the point from the prediction instead of crediting it again.
+func (rc *RatingCalculator) AwardVoteParticipation(ctx context.Context, prediction *Prediction, groupID int64) error {
+	if !rc.participationAtVote {
+		return nil
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	ownEvent, err := rc.ownExcludedEvent(ctx, prediction, groupID)
+	if err != nil {
+		rc.logger.Error("failed to check creator exclusion", "event_id", prediction.EventID, "group_id", groupID, "error", err)
+		return err
+	}
+	if ownEvent {
+		rc.logger.Debug("creator excluded from scoring own event", "user_id", prediction.UserID, "event_id", prediction.EventID)
+		return nil
+	}
+
+	if !rc.participationCap.Allow(prediction.UserID, groupID) {
+		rc.logger.Debug("participation bonus cap reached", "user_id", prediction.UserID, "group_id", groupID)
+		return nil
+	}
+
+	rating, err := rc.ratingRepo.GetRating(ctx, prediction.UserID, groupID)
+	if err != nil {
+		rc.logger.Error("failed to get rating", "user_id", prediction.UserID, "group_id", groupID, "error", err)
+		return err
+	}
+
+	prediction.ParticipationAwarded = true
+	if err := rc.predictionRepo.SetParticipationAwarded(ctx, prediction.EventID, prediction.UserID, true); err != nil {
+		prediction.ParticipationAwarded = false
+		rc.logger.Error("failed to mark participation awarded", "user_id", prediction.UserID, "event_id", prediction.EventID, "error", err)
+		return err
+	}
+
+	rating.Score += ParticipationPoints
+	if err := rc.ratingRepo.UpdateRating(ctx, rating); err != nil {
+		rc.logger.Error("failed to update rating", "user_id", prediction.UserID, "group_id", groupID, "error", err)
+		// Without the point the vote is credited at resolution as usual
+		prediction.ParticipationAwarded = false
+		if err := rc.predictionRepo.SetParticipationAwarded(ctx, prediction.EventID, prediction.UserID, false); err != nil {
+			rc.logger.Error("failed to unmark participation awarded", "user_id", prediction.UserID, "event_id", prediction.EventID, "error", err)
+		}
+		return err
+	}
+
+	rc.logger.Info("participation point credited at vote time", "user_id", prediction.UserID, "group_id", groupID, "event_id", prediction.EventID)
+	return nil
+}
+
+// creatorExcluded reports whether the group keeps event creators from scoring their own events.
+// Without a group repository creators score like everyone else.
+func (rc *RatingCalculator) creatorExcluded(ctx context.Context, groupID int64) (bool, error) {
+	if rc.groupRepo == nil {
+		return false, nil
+	}
+
+	group, err := rc.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+
+	return group != nil && group.ExcludeCreatorScoring, nil
+}
+
+// ownExcludedEvent reports whether a vote is the creator's vote on their own event in a group
+// that excludes creators from scoring their own events
+func (rc *RatingCalculator) ownExcludedEvent(ctx context.Context, prediction *Prediction, groupID int64) (bool, error) {
+	excluded, err := rc.creatorExcluded(ctx, groupID)
+	if err != nil || !excluded {
+		return false, err
+	}
+
+	event, err := rc.eventRepo.GetEvent(ctx, prediction.EventID)
+	if err != nil {
+		return false, err
+	}
+
+	return event.CreatedBy == prediction.UserID, nil
+}
+
+// resolutionParticipation reports whether a prediction earns the participation point at resolution.
+// Points credited at vote time are never credited again, and with participation at vote time
+// votes that got no point (the cap was reached) don't get one later either.
+func (rc *RatingCalculator) resolutionParticipation(participationCap *ParticipationBonusCap, prediction *Prediction, groupID int64) bool {
+	if prediction.ParticipationAwarded || rc.participationAtVote {
+		return false
+	}
+	if !participationCap.Allow(prediction.UserID, groupID) {
+		rc.logger.Debug("participation bonus cap reached", "user_id", prediction.UserID, "group_id", groupID)
+		return false
+	}
+	return true
+}
+
 // CalculateScores calculates and updates scores for all participants of an event
 func (rc *RatingCalculator) CalculateScores(ctx context.Context, eventID int64, correctOption int) error {
+	return rc.calculateScores(ctx, eventID, correctOption, nil)
+}
+
+// CalculateProbabilityScores calculates and updates scores for a probability event resolved
+// against the realized outcome percentage (0-100). Each prediction's range midpoint is scored
+// with the Brier score; a prediction counts as correct when its range contains the outcome.
+func (rc *RatingCalculator) CalculateProbabilityScores(ctx context.Context, eventID int64, outcomePercent float64) error {
+	if err := ValidateProbabilityOutcome(outcomePercent); err != nil {
+		return err
+	}
+
+	outcome := outcomePercent / 100
+	return rc.calculateScores(ctx, eventID, ProbabilityOutcomeOption(outcomePercent), &outcome)
+}
+
+// calculateScores updates ratings for all predictions of an event.
+// outcome is the realized probability (0-1) for probability events resolved by percentage, nil otherwise.
+func (rc *RatingCalculator) calculateScores(ctx context.Context, eventID int64, correctOption int, outcome *float64) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
 	// Get the event
 	event, err := rc.eventRepo.GetEvent(ctx, eventID)
 	if err != nil {
@@ -63,25 +218,63 @@ func (rc *RatingCalculator) CalculateScores(ctx context.Context, eventID int64,
 		rc.logger.Error("failed to get predictions", "event_id", eventID, "error", err)
 		return err
 	}
+	// Votes of non-participants of restricted events are not scored
+	predictions = event.ParticipantPredictions(predictions)
 
 	if len(predictions) == 0 {
 		rc.logger.Info("no predictions for event", "event_id", eventID)
 		return nil
 	}
 
-	// Calculate vote distribution for minority bonus
-	voteDistribution := make(map[int]int)
-	for _, pred := range predictions {
-		voteDistribution[pred.Option]++
+	// Calculate vote shares for minority bonus, weighted by the scores before this event when enabled
+	weighted, err := rc.reputationWeighted(ctx, event.GroupID)
+	if err != nil {
+		rc.logger.Error("failed to get group", "group_id", event.GroupID, "error", err)
+		return err
+	}
+	var weights map[int64]float64
+	if weighted {
+		weights, err = VoteWeights(ctx, rc.ratingRepo, event.GroupID, predictions)
+		if err != nil {
+			rc.logger.Error("failed to get vote weights", "event_id", eventID, "error", err)
+			return err
+		}
+	}
+	voteShares := VoteShares(predictions, weights)
+
+	// The creator's vote still counts in the vote shares, but earns nothing when the group excludes it
+	excludeCreator, err := rc.creatorExcluded(ctx, event.GroupID)
+	if err != nil {
+		rc.logger.Error("failed to get group", "group_id", event.GroupID, "error", err)
+		return err
+	}
+
+	// Tag contrarian wins for analytics; a failure doesn't stop scoring
+	if !event.IsQuiz() && IsMinorityCorrect(voteShares, correctOption) {
+		if err := rc.eventRepo.SetMinorityCorrect(ctx, eventID, true); err != nil {
+			rc.logger.Error("failed to tag minority correct event", "event_id", eventID, "error", err)
+		}
 	}
-	totalVotes := len(predictions)
 
 	// Process each prediction
 	for _, pred := range predictions {
+		if excludeCreator && pred.UserID == event.CreatedBy {
+			rc.logger.Info("creator excluded from scoring own event", "user_id", pred.UserID, "event_id", eventID)
+			continue
+		}
+
 		isCorrect := pred.Option == correctOption
 
+		// Participation bonus is subject to the per-period cap, unless it was credited at vote time
+		participationBonus := rc.resolutionParticipation(rc.participationCap, pred, event.GroupID)
+
 		// Calculate points for this prediction
-		points := rc.calculatePoints(event, pred, isCorrect, voteDistribution, totalVotes)
+		var points int
+		if outcome != nil {
+			points = rc.calculateProbabilityPoints(event, pred, *outcome, isCorrect, participationBonus, voteShares)
+		} else {
+			points = rc.calculatePoints(event, pred, isCorrect, participationBonus, voteShares)
+		}
 
 		// Get current rating for this group
 		rating, err := rc.ratingRepo.GetRating(ctx, pred.UserID, event.GroupID)
@@ -92,14 +285,7 @@ func (rc *RatingCalculator) CalculateScores(ctx context.Context, eventID int64,
 
 		// Update rating
 		rating.Score += points
-
-		if isCorrect {
-			rating.CorrectCount++
-			rating.Streak++
-		} else {
-			rating.WrongCount++
-			rating.Streak = 0
-		}
+		rating.recordOutcome(event, isCorrect)
 
 		// Save updated rating
 		if err := rc.ratingRepo.UpdateRating(ctx, rating); err != nil {
@@ -124,10 +310,21 @@ func (rc *RatingCalculator) calculatePoints(
 	event *Event,
 	prediction *Prediction,
 	isCorrect bool,
-	voteDistribution map[int]int,
-	totalVotes int,
+	participationBonus bool,
+	voteShares map[int]float64,
 ) int {
-	points := ParticipationPoints // Everyone gets participation point
+	points := 0
+	if participationBonus {
+		points += ParticipationPoints // Everyone gets participation point (unless capped)
+	}
+
+	// Quiz answers are known upfront: no penalty and no bonuses
+	if event.IsQuiz() {
+		if isCorrect {
+			points += QuizCorrectPoints
+		}
+		return points
+	}
 
 	if !isCorrect {
 		// Incorrect prediction penalty
@@ -143,17 +340,71 @@ func (rc *RatingCalculator) calculatePoints(
 		points += MultiOptionCorrectPoints
 	}
 
+	points += rc.calculateBonusPoints(event, prediction, voteShares)
+
+	return points
+}
+
+// calculateProbabilityPoints calculates points for a prediction on a probability event
+// resolved against the realized outcome (0-1)
+func (rc *RatingCalculator) calculateProbabilityPoints(
+	event *Event,
+	prediction *Prediction,
+	outcome float64,
+	isCorrect bool,
+	participationBonus bool,
+	voteShares map[int]float64,
+) int {
+	points := 0
+	if participationBonus {
+		points += ParticipationPoints
+	}
+
+	// Base points scale with forecast quality: full points for a perfect forecast,
+	// zero at the uninformative baseline, never below the incorrect penalty
+	brier := BrierScore(ProbabilityOptionForecast(prediction.Option), outcome)
+	basePoints := int(math.Round(MultiOptionCorrectPoints * (1 - brier/BrierBaseline)))
+	if basePoints < IncorrectPenalty {
+		basePoints = IncorrectPenalty
+	}
+	points += basePoints
+
+	rc.logger.Debug("brier score calculated",
+		"user_id", prediction.UserID,
+		"brier", brier,
+		"base_points", basePoints,
+	)
+
+	// Bonuses only apply when the chosen range contains the outcome
+	if isCorrect {
+		points += rc.calculateBonusPoints(event, prediction, voteShares)
+	}
+
+	return points
+}
+
+// IsMinorityCorrect reports whether the correct option was a minority pick (a contrarian win):
+// someone picked it, but with a vote share below MinorityThreshold, the same rule as the minority bonus
+func IsMinorityCorrect(voteShares map[int]float64, correctOption int) bool {
+	share := voteShares[correctOption]
+	return share > 0 && share < MinorityThreshold
+}
+
+// calculateBonusPoints calculates minority and early voting bonuses for a correct prediction
+func (rc *RatingCalculator) calculateBonusPoints(
+	event *Event,
+	prediction *Prediction,
+	voteShares map[int]float64,
+) int {
+	points := 0
+
 	// Minority bonus
-	optionVotes := voteDistribution[prediction.Option]
-	if totalVotes > 0 {
-		percentage := float64(optionVotes) / float64(totalVotes)
-		if percentage < MinorityThreshold {
-			points += MinorityBonusPoints
-			rc.logger.Debug("minority bonus awarded",
-				"user_id", prediction.UserID,
-				"percentage", percentage,
-			)
-		}
+	if percentage := voteShares[prediction.Option]; percentage < MinorityThreshold {
+		points += MinorityBonusPoints
+		rc.logger.Debug("minority bonus awarded",
+			"user_id", prediction.UserID,
+			"percentage", percentage,
+		)
 	}
 
 	// Early voting bonus
@@ -180,6 +431,17 @@ func (rc *RatingCalculator) GetTopRatings(ctx context.Context, groupID int64, li
 	return ratings, nil
 }
 
+// GetTopStreaks retrieves the top N users by best streak for a specific group
+func (rc *RatingCalculator) GetTopStreaks(ctx context.Context, groupID int64, limit int) ([]*Rating, error) {
+	ratings, err := rc.ratingRepo.GetTopStreaks(ctx, groupID, limit)
+	if err != nil {
+		rc.logger.Error("failed to get top streaks", "group_id", groupID, "limit", limit, "error", err)
+		return nil, err
+	}
+
+	return ratings, nil
+}
+
 // GetUserRating retrieves a specific user's rating for a specific group
 func (rc *RatingCalculator) GetUserRating(ctx context.Context, userID int64, groupID int64) (*Rating, error) {
 	rating, err := rc.ratingRepo.GetRating(ctx, userID, groupID)
@@ -191,6 +453,18 @@ func (rc *RatingCalculator) GetUserRating(ctx context.Context, userID int64, gro
 	return rating, nil
 }
 
+// GetUserRank returns the user's position in the group leaderboard and the number of users in it.
+// Users with the same score share a rank; rank is 0 when the user has no rating yet.
+func (rc *RatingCalculator) GetUserRank(ctx context.Context, userID int64, groupID int64) (int, int, error) {
+	rank, total, err := rc.ratingRepo.GetUserRank(ctx, userID, groupID)
+	if err != nil {
+		rc.logger.Error("failed to get user rank", "user_id", userID, "group_id", groupID, "error", err)
+		return 0, 0, err
+	}
+
+	return rank, total, nil
+}
+
 // UpdateStreak updates a user's streak for a specific group
 func (rc *RatingCalculator) UpdateStreak(ctx context.Context, userID int64, groupID int64, correct bool) error {
 	rating, err := rc.ratingRepo.GetRating(ctx, userID, groupID)
@@ -200,7 +474,7 @@ func (rc *RatingCalculator) UpdateStreak(ctx context.Context, userID int64, grou
 	}
 
 	if correct {
-		rating.Streak++
+		rating.IncrementStreak()
 	} else {
 		rating.Streak = 0
 	}
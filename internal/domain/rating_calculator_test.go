@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// votePredictions records votes the way HandlePollAnswer does: the participation point is
+// awarded after the prediction is saved
+func votePredictions(t *testing.T, rc *RatingCalculator, groupID int64, predictions []*Prediction) {
+	t.Helper()
+	for _, pred := range predictions {
+		if err := rc.AwardVoteParticipation(context.Background(), pred, groupID); err != nil {
+			t.Fatalf("AwardVoteParticipation failed: %v", err)
+		}
+	}
+}
+
+// storedScore returns the stored score of a member, 0 without a rating
+func storedScore(repo *mockRatingRepoStore, userID int64, groupID int64) int {
+	if rating, ok := repo.ratings[[2]int64{userID, groupID}]; ok {
+		return rating.Score
+	}
+	return 0
+}
+
+func TestRatingCalculator_ParticipationTiming(t *testing.T) {
+	ctx := context.Background()
+	const groupID = int64(1)
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	correct := 0
+
+	for _, atVote := range []bool{false, true} {
+		event := &Event{ID: 1, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusActive, CreatedAt: created, Deadline: created.Add(24 * time.Hour)}
+		predictions := []*Prediction{
+			{EventID: 1, UserID: 10, Option: 0, Timestamp: created.Add(time.Hour)},
+			{EventID: 1, UserID: 20, Option: 1, Timestamp: created.Add(time.Hour)},
+		}
+		ratingRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+		rc := NewRatingCalculator(ratingRepo, &MockPredictionRepoWithData{predictions: predictions}, &MockEventRepoWithData{event: event}, nil, &MockLogger{})
+		rc.SetParticipationAtVote(atVote)
+
+		votePredictions(t, rc, groupID, predictions)
+
+		// Before the resolution only the participation points are credited, and only at vote time
+		expectedBefore := 0
+		if atVote {
+			expectedBefore = ParticipationPoints
+		}
+		for _, pred := range predictions {
+			if pred.ParticipationAwarded != atVote {
+				t.Errorf("atVote=%v: expected ParticipationAwarded %v for user %d", atVote, atVote, pred.UserID)
+			}
+			if score := storedScore(ratingRepo, pred.UserID, groupID); score != expectedBefore {
+				t.Errorf("atVote=%v: expected score %d before resolution for user %d, got %d", atVote, expectedBefore, pred.UserID, score)
+			}
+		}
+
+		event.Status = EventStatusResolved
+		event.CorrectOption = &correct
+		if err := rc.CalculateScores(ctx, event.ID, correct); err != nil {
+			t.Fatalf("CalculateScores failed: %v", err)
+		}
+
+		// Both timings end with the same totals, the point is never credited twice
+		expected := map[int64]int{
+			10: ParticipationPoints + BinaryCorrectPoints + EarlyVotingBonusPoints,
+			20: ParticipationPoints + IncorrectPenalty,
+		}
+		for userID, want := range expected {
+			if score := ratingRepo.ratings[[2]int64{userID, groupID}].Score; score != want {
+				t.Errorf("atVote=%v: expected score %d for user %d, got %d", atVote, want, userID, score)
+			}
+		}
+	}
+}
+
+func TestRatingCalculator_ParticipationAtVoteCapped(t *testing.T) {
+	ctx := context.Background()
+	const groupID = int64(1)
+	created := time.Now().Add(-48 * time.Hour)
+	wrong := 1
+
+	var events []*Event
+	var predictions []*Prediction
+	for _, id := range []int64{1, 2} {
+		events = append(events, &Event{ID: id, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusActive, CreatedAt: created, Deadline: created.Add(24 * time.Hour)})
+		predictions = append(predictions, &Prediction{EventID: id, UserID: 10, Option: 0, Timestamp: created.Add(time.Hour)})
+	}
+
+	ratingRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+	rc := NewRatingCalculator(ratingRepo,
+		&mockPredictionRepoByEvent{MockPredictionRepoWithData{predictions: predictions}},
+		&MockEventRepoWithEvents{events: events},
+		NewParticipationBonusCap(1, 24*time.Hour),
+		&MockLogger{},
+	)
+	rc.SetParticipationAtVote(true)
+
+	votePredictions(t, rc, groupID, predictions)
+	if !predictions[0].ParticipationAwarded || predictions[1].ParticipationAwarded {
+		t.Fatalf("expected only the first vote to be awarded, got %v and %v", predictions[0].ParticipationAwarded, predictions[1].ParticipationAwarded)
+	}
+
+	// The capped vote doesn't get the point at resolution either
+	for _, event := range events {
+		event.Status = EventStatusResolved
+		event.CorrectOption = &wrong
+		if err := rc.CalculateScores(ctx, event.ID, wrong); err != nil {
+			t.Fatalf("CalculateScores failed: %v", err)
+		}
+	}
+
+	expected := 2*IncorrectPenalty + ParticipationPoints
+	if score := ratingRepo.ratings[[2]int64{10, groupID}].Score; score != expected {
+		t.Errorf("expected score %d, got %d", expected, score)
+	}
+}
+
+func TestRatingCalculator_RecomputeGroupParticipationAtVote(t *testing.T) {
+	ctx := context.Background()
+	const groupID = int64(1)
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	correct := 0
+
+	events := []*Event{
+		{ID: 1, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusActive, CreatedAt: created, Deadline: created.Add(24 * time.Hour)},
+		{ID: 2, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusActive, CreatedAt: created, Deadline: created.Add(96 * time.Hour)},
+	}
+	predictions := []*Prediction{
+		{EventID: 1, UserID: 10, Option: 0, Timestamp: created.Add(time.Hour)},
+		{EventID: 1, UserID: 20, Option: 1, Timestamp: created.Add(time.Hour)},
+		{EventID: 2, UserID: 30, Option: 0, Timestamp: created.Add(time.Hour)},
+	}
+	predictionRepo := &mockPredictionRepoByEvent{MockPredictionRepoWithData{predictions: predictions}}
+	eventRepo := &MockEventRepoWithEvents{events: events}
+
+	// Incremental scoring: everyone votes, then only the first event is resolved
+	expectedRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+	incremental := NewRatingCalculator(expectedRepo, predictionRepo, eventRepo, nil, &MockLogger{})
+	incremental.SetParticipationAtVote(true)
+	votePredictions(t, incremental, groupID, predictions)
+	events[0].Status = EventStatusResolved
+	events[0].CorrectOption = &correct
+	if err := incremental.CalculateScores(ctx, 1, correct); err != nil {
+		t.Fatalf("CalculateScores failed: %v", err)
+	}
+
+	ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+		{10, groupID}: {UserID: 10, GroupID: groupID, Score: 999},
+	}}
+	rc := NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, &MockLogger{})
+	rc.SetParticipationAtVote(true)
+	if _, err := rc.RecomputeGroup(ctx, groupID); err != nil {
+		t.Fatalf("RecomputeGroup failed: %v", err)
+	}
+
+	// The vote-time point of the unresolved event is kept
+	if score := storedScore(ratingRepo, 30, groupID); score != ParticipationPoints {
+		t.Errorf("expected score %d for the vote on the unresolved event, got %d", ParticipationPoints, score)
+	}
+	for _, userID := range []int64{10, 20, 30} {
+		key := [2]int64{userID, groupID}
+		if !reflect.DeepEqual(ratingRepo.ratings[key], expectedRepo.ratings[key]) {
+			t.Errorf("user %d: expected rating %+v, got %+v", userID, expectedRepo.ratings[key], ratingRepo.ratings[key])
+		}
+	}
+}
+
+func TestRatingCalculator_ExcludeCreatorScoring(t *testing.T) {
+	ctx := context.Background()
+	const groupID = int64(1)
+	const creatorID = int64(10)
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	correct := 0
+
+	for _, atVote := range []bool{false, true} {
+		for _, exclude := range []bool{false, true} {
+			event := &Event{ID: 1, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusActive, CreatedBy: creatorID, CreatedAt: created, Deadline: created.Add(24 * time.Hour)}
+			predictions := []*Prediction{
+				{EventID: 1, UserID: creatorID, Option: 0, Timestamp: created.Add(time.Hour)},
+				{EventID: 1, UserID: 20, Option: 0, Timestamp: created.Add(time.Hour)},
+			}
+			ratingRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+			predictionRepo := &mockPredictionRepoByEvent{MockPredictionRepoWithData{predictions: predictions}}
+			eventRepo := &MockEventRepoWithEvents{events: []*Event{event}}
+			rc := NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, &MockLogger{})
+			rc.SetParticipationAtVote(atVote)
+			rc.SetGroupRepository(&mockGroupRepoForRemover{groups: []*Group{
+				{ID: groupID, Status: GroupStatusActive, ExcludeCreatorScoring: exclude},
+			}})
+
+			votePredictions(t, rc, groupID, predictions)
+			event.Status = EventStatusResolved
+			event.CorrectOption = &correct
+			if err := rc.CalculateScores(ctx, event.ID, correct); err != nil {
+				t.Fatalf("CalculateScores failed: %v", err)
+			}
+
+			// The creator's vote stays recorded either way, only the points are withheld
+			voterPoints := ParticipationPoints + BinaryCorrectPoints + EarlyVotingBonusPoints
+			creatorPoints := voterPoints
+			if exclude {
+				creatorPoints = 0
+			}
+			if score := storedScore(ratingRepo, creatorID, groupID); score != creatorPoints {
+				t.Errorf("atVote=%v exclude=%v: expected creator score %d, got %d", atVote, exclude, creatorPoints, score)
+			}
+			if score := storedScore(ratingRepo, 20, groupID); score != voterPoints {
+				t.Errorf("atVote=%v exclude=%v: expected voter score %d, got %d", atVote, exclude, voterPoints, score)
+			}
+			if exclude && predictions[0].ParticipationAwarded {
+				t.Errorf("atVote=%v: expected no participation point for the creator's own event", atVote)
+			}
+
+			// A recomputation applies the same rule
+			if _, err := rc.RecomputeGroup(ctx, groupID); err != nil {
+				t.Fatalf("RecomputeGroup failed: %v", err)
+			}
+			if score := storedScore(ratingRepo, creatorID, groupID); score != creatorPoints {
+				t.Errorf("atVote=%v exclude=%v: expected recomputed creator score %d, got %d", atVote, exclude, creatorPoints, score)
+			}
+		}
+	}
+}
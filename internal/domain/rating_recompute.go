@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// RatingRecomputeResult summarizes a rating recomputation of a group
+type RatingRecomputeResult struct {
+	Events      int // Resolved events replayed
+	Predictions int // Scored predictions replayed
+	Users       int // Members with a recomputed rating
+}
+
+// RecomputeGroup rebuilds the current season ratings of a group by replaying every resolved
+// prediction through the current scoring rules, oldest deadline first. The new ratings are
+// swapped in atomically, so a failure leaves the stored ratings untouched.
+func (rc *RatingCalculator) RecomputeGroup(ctx context.Context, groupID int64) (*RatingRecomputeResult, error) {
+	// Keep incremental scoring from interleaving with the replay
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	events, err := rc.eventRepo.GetResolvedEvents(ctx)
+	if err != nil {
+		rc.logger.Error("failed to get resolved events for recompute", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	seasonStart, err := rc.seasonStart(ctx, groupID)
+	if err != nil {
+		rc.logger.Error("failed to get season start for recompute", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	var groupEvents []*Event
+	for _, event := range events {
+		if event.GroupID == groupID && event.CorrectOption != nil && event.resolvedIn(seasonStart) {
+			groupEvents = append(groupEvents, event)
+		}
+	}
+	sort.Slice(groupEvents, func(i, j int) bool {
+		if !groupEvents[i].Deadline.Equal(groupEvents[j].Deadline) {
+			return groupEvents[i].Deadline.Before(groupEvents[j].Deadline)
+		}
+		return groupEvents[i].ID < groupEvents[j].ID
+	})
+
+	weighted, err := rc.reputationWeighted(ctx, groupID)
+	if err != nil {
+		rc.logger.Error("failed to get group for recompute", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	excludeCreator, err := rc.creatorExcluded(ctx, groupID)
+	if err != nil {
+		rc.logger.Error("failed to get group for recompute", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	var resolvedAt time.Time
+	participationCap := rc.participationCap.withClock(func() time.Time { return resolvedAt })
+
+	result := &RatingRecomputeResult{Events: len(groupEvents)}
+	ratings := make(map[int64]*Rating)
+	var order []int64
+
+	for _, event := range groupEvents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		predictions, err := rc.predictionRepo.GetPredictionsByEvent(ctx, event.ID)
+		if err != nil {
+			rc.logger.Error("failed to get predictions for recompute", "event_id", event.ID, "error", err)
+			return nil, err
+		}
+		// Votes of non-participants of restricted events are not scored
+		predictions = event.ParticipantPredictions(predictions)
+		if len(predictions) == 0 {
+			continue
+		}
+
+		// Weights come from the replayed scores as they stood before this event
+		var weights map[int64]float64
+		if weighted {
+			weights = make(map[int64]float64, len(predictions))
+			for _, pred := range predictions {
+				score := 0
+				if rating, ok := ratings[pred.UserID]; ok {
+					score = rating.Score
+				}
+				weights[pred.UserID] = VoteWeight(score)
+			}
+		}
+		voteShares := VoteShares(predictions, weights)
+		correctOption := *event.CorrectOption
+		resolvedAt = event.Deadline
+
+		for _, pred := range predictions {
+			if excludeCreator && pred.UserID == event.CreatedBy {
+				continue
+			}
+
+			isCorrect := pred.Option == correctOption
+			participationBonus := rc.resolutionParticipation(participationCap, pred, groupID)
+
+			var points int
+			if event.EventType == EventTypeProbability {
+				points = rc.calculateProbabilityPoints(event, pred, ProbabilityOptionForecast(correctOption), isCorrect, participationBonus, voteShares)
+			} else {
+				points = rc.calculatePoints(event, pred, isCorrect, participationBonus, voteShares)
+			}
+
+			if pred.ParticipationAwarded && !pred.Timestamp.Before(seasonStart) {
+				points += ParticipationPoints
+			}
+
+			rating, ok := ratings[pred.UserID]
+			if !ok {
+				rating = &Rating{UserID: pred.UserID, GroupID: groupID}
+				ratings[pred.UserID] = rating
+				order = append(order, pred.UserID)
+			}
+
+			rating.Score += points
+			rating.recordOutcome(event, isCorrect)
+			result.Predictions++
+		}
+	}
+
+	// Votes of unresolved events only carry the participation points credited at vote time
+	activeEvents, err := rc.eventRepo.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		rc.logger.Error("failed to get active events for recompute", "group_id", groupID, "error", err)
+		return nil, err
+	}
+	for _, event := range activeEvents {
+		if event.GroupID != groupID {
+			continue
+		}
+		predictions, err := rc.predictionRepo.GetPredictionsByEvent(ctx, event.ID)
+		if err != nil {
+			rc.logger.Error("failed to get predictions for recompute", "event_id", event.ID, "error", err)
+			return nil, err
+		}
+		for _, pred := range event.ParticipantPredictions(predictions) {
+			if !pred.ParticipationAwarded || pred.Timestamp.Before(seasonStart) || (excludeCreator && pred.UserID == event.CreatedBy) {
+				continue
+			}
+			rating, ok := ratings[pred.UserID]
+			if !ok {
+				rating = &Rating{UserID: pred.UserID, GroupID: groupID}
+				ratings[pred.UserID] = rating
+				order = append(order, pred.UserID)
+			}
+			rating.Score += ParticipationPoints
+		}
+	}
+
+	recomputed := make([]*Rating, 0, len(order))
+	for _, userID := range order {
+		recomputed = append(recomputed, ratings[userID])
+	}
+	result.Users = len(recomputed)
+
+	if err := rc.ratingRepo.ReplaceGroupRatings(ctx, groupID, recomputed); err != nil {
+		rc.logger.Error("failed to replace group ratings", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	rc.logger.Info("ratings recomputed",
+		"group_id", groupID,
+		"events", result.Events,
+		"predictions", result.Predictions,
+		"users", result.Users,
+	)
+
+	return result, nil
+}
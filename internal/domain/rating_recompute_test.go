@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// mockPredictionRepoByEvent returns the predictions of the requested event only
+type mockPredictionRepoByEvent struct {
+	MockPredictionRepoWithData
+}
+
+func (m *mockPredictionRepoByEvent) GetPredictionsByEvent(ctx context.Context, eventID int64) ([]*Prediction, error) {
+	var result []*Prediction
+	for _, pred := range m.predictions {
+		if pred.EventID == eventID {
+			result = append(result, pred)
+		}
+	}
+	return result, nil
+}
+
+func TestRatingCalculator_RecomputeGroup(t *testing.T) {
+	ctx := context.Background()
+	const groupID, otherGroupID = int64(1), int64(2)
+	option := func(o int) *int { return &o }
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []*Event{
+		{ID: 1, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusResolved, CorrectOption: option(0), CreatedAt: created, Deadline: created.Add(24 * time.Hour)},
+		{ID: 2, GroupID: groupID, EventType: EventTypeMultiOption, Status: EventStatusResolved, CorrectOption: option(1), CreatedAt: created, Deadline: created.Add(48 * time.Hour)},
+		{ID: 3, GroupID: groupID, EventType: EventTypeProbability, Status: EventStatusResolved, CorrectOption: option(2), CreatedAt: created, Deadline: created.Add(72 * time.Hour)},
+		{ID: 4, GroupID: otherGroupID, EventType: EventTypeBinary, Status: EventStatusResolved, CorrectOption: option(0), CreatedAt: created, Deadline: created.Add(24 * time.Hour)},
+		{ID: 5, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusActive, CreatedAt: created, Deadline: created.Add(96 * time.Hour)},
+	}
+	predictions := []*Prediction{
+		{EventID: 1, UserID: 10, Option: 0, Timestamp: created.Add(time.Hour)},
+		{EventID: 1, UserID: 20, Option: 1, Timestamp: created.Add(20 * time.Hour)},
+		{EventID: 2, UserID: 10, Option: 1, Timestamp: created.Add(30 * time.Hour)},
+		{EventID: 2, UserID: 20, Option: 1, Timestamp: created.Add(time.Hour)},
+		{EventID: 2, UserID: 30, Option: 0, Timestamp: created.Add(time.Hour)},
+		{EventID: 3, UserID: 10, Option: 3, Timestamp: created.Add(time.Hour)},
+		{EventID: 3, UserID: 30, Option: 2, Timestamp: created.Add(time.Hour)},
+		{EventID: 4, UserID: 10, Option: 0, Timestamp: created.Add(time.Hour)},
+		{EventID: 5, UserID: 10, Option: 0, Timestamp: created.Add(time.Hour)},
+	}
+	predictionRepo := &mockPredictionRepoByEvent{MockPredictionRepoWithData{predictions: predictions}}
+	eventRepo := &MockEventRepoWithEvents{events: events}
+
+	// Expected ratings: the same events scored incrementally in deadline order
+	expectedRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+	incremental := NewRatingCalculator(expectedRepo, predictionRepo, eventRepo, nil, &MockLogger{})
+	for _, eventID := range []int64{1, 2} {
+		if err := incremental.CalculateScores(ctx, eventID, *events[eventID-1].CorrectOption); err != nil {
+			t.Fatalf("CalculateScores failed: %v", err)
+		}
+	}
+	if err := incremental.CalculateProbabilityScores(ctx, 3, ProbabilityOptionForecast(2)*100); err != nil {
+		t.Fatalf("CalculateProbabilityScores failed: %v", err)
+	}
+
+	// Stored ratings are inconsistent: wrong scores, a stale member and another group
+	ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+		{10, groupID}:      {UserID: 10, GroupID: groupID, Score: 999, CorrectCount: 50},
+		{99, groupID}:      {UserID: 99, GroupID: groupID, Score: 42},
+		{10, otherGroupID}: {UserID: 10, GroupID: otherGroupID, Score: 7},
+	}}
+	rc := NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, &MockLogger{})
+
+	result, err := rc.RecomputeGroup(ctx, groupID)
+	if err != nil {
+		t.Fatalf("RecomputeGroup failed: %v", err)
+	}
+
+	expectedResult := &RatingRecomputeResult{Events: 3, Predictions: 7, Users: 3}
+	if !reflect.DeepEqual(result, expectedResult) {
+		t.Errorf("Expected result %+v, got %+v", expectedResult, result)
+	}
+
+	for _, userID := range []int64{10, 20, 30} {
+		key := [2]int64{userID, groupID}
+		if !reflect.DeepEqual(ratingRepo.ratings[key], expectedRepo.ratings[key]) {
+			t.Errorf("User %d: expected rating %+v, got %+v", userID, expectedRepo.ratings[key], ratingRepo.ratings[key])
+		}
+	}
+	if _, ok := ratingRepo.ratings[[2]int64{99, groupID}]; ok {
+		t.Error("Expected the stale rating to be replaced")
+	}
+	if rating := ratingRepo.ratings[[2]int64{10, otherGroupID}]; rating == nil || rating.Score != 7 {
+		t.Errorf("Expected ratings of other groups to be untouched, got %+v", rating)
+	}
+}
+
+func TestRatingCalculator_RecomputeGroupAppliesParticipationCap(t *testing.T) {
+	ctx := context.Background()
+	const groupID = int64(1)
+	wrong := 1
+	day := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	// Three wrong votes on events resolved the same day, then one on the next day
+	var events []*Event
+	var predictions []*Prediction
+	for i, deadline := range []time.Time{day.Add(time.Hour), day.Add(2 * time.Hour), day.Add(3 * time.Hour), day.Add(25 * time.Hour)} {
+		id := int64(i + 1)
+		events = append(events, &Event{ID: id, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusResolved, CorrectOption: &wrong, CreatedAt: day.Add(-48 * time.Hour), Deadline: deadline})
+		predictions = append(predictions, &Prediction{EventID: id, UserID: 10, Option: 0, Timestamp: day.Add(-time.Hour)})
+	}
+
+	ratingRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+	rc := NewRatingCalculator(ratingRepo,
+		&mockPredictionRepoByEvent{MockPredictionRepoWithData{predictions: predictions}},
+		&MockEventRepoWithEvents{events: events},
+		NewParticipationBonusCap(2, 24*time.Hour),
+		&MockLogger{},
+	)
+
+	if _, err := rc.RecomputeGroup(ctx, groupID); err != nil {
+		t.Fatalf("RecomputeGroup failed: %v", err)
+	}
+
+	// Two capped bonuses on the first day, one more on the next day
+	expected := 4*IncorrectPenalty + 3*ParticipationPoints
+	if score := ratingRepo.ratings[[2]int64{10, groupID}].Score; score != expected {
+		t.Errorf("Expected score %d, got %d", expected, score)
+	}
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRelativeDeadline is returned when a deadline is not one of the supported relative phrases
+var ErrInvalidRelativeDeadline = errors.New("unrecognized relative deadline")
+
+// maxRelativeDeadlineAmount bounds the number in "in N units", so the offset can't overflow
+const maxRelativeDeadlineAmount = 10000
+
+// relativeUnit is a unit of a relative deadline. Day-based units land on a calendar day,
+// hours and minutes are added to the current time.
+type relativeUnit struct {
+	years, months, days int
+	duration            time.Duration
+}
+
+// relativeUnits maps the unit words to their units. Russian words are matched in their
+// transliterated form (see TransliterateToASCII), e.g. "nedelyu" for "неделю".
+var relativeUnits = map[string]relativeUnit{
+	"minute": {duration: time.Minute}, "minutes": {duration: time.Minute}, "min": {duration: time.Minute},
+	"minutu": {duration: time.Minute}, "minuty": {duration: time.Minute}, "minut": {duration: time.Minute},
+	"hour": {duration: time.Hour}, "hours": {duration: time.Hour},
+	"chas": {duration: time.Hour}, "chasa": {duration: time.Hour}, "chasov": {duration: time.Hour},
+	"day": {days: 1}, "days": {days: 1},
+	"den": {days: 1}, "dnya": {days: 1}, "dney": {days: 1},
+	"week": {days: 7}, "weeks": {days: 7},
+	"nedelyu": {days: 7}, "nedeli": {days: 7}, "nedel": {days: 7},
+	"month": {months: 1}, "months": {months: 1},
+	"mesyats": {months: 1}, "mesyatsa": {months: 1}, "mesyatsev": {months: 1},
+	"year": {years: 1}, "years": {years: 1},
+	"god": {years: 1}, "goda": {years: 1}, "let": {years: 1},
+}
+
+// ParseRelativeDeadline parses a deadline given relative to now and returns it in loc.
+// Supported phrases (case-insensitive, English and Russian):
+//
+//	today HH:MM, tomorrow, day after tomorrow       сегодня в HH:MM, завтра, послезавтра
+//	in N minutes|hours|days|weeks|months|years       через N минут|часов|дней|недель|месяцев|лет
+//	in a day|week|month|year, next week|month|year   через день|неделю|месяц|год
+//
+// Day-based phrases land at 12:00 like the deadline presets, or at the time given after them
+// ("tomorrow 18:00", "tomorrow at 18:00", "завтра в 18:00"). Minutes and hours are added to now
+// and don't take a time. Anything else returns ErrInvalidRelativeDeadline.
+func ParseRelativeDeadline(text string, now time.Time, loc *time.Location) (time.Time, error) {
+	words := strings.Fields(strings.ToLower(TransliterateToASCII(text)))
+
+	// Optional time of day at the end: "HH:MM", "at HH:MM" or "v HH:MM" ("в HH:MM")
+	hour, minute, hasClock := 12, 0, false
+	if n := len(words); n > 1 {
+		if clock, err := time.Parse("15:04", words[n-1]); err == nil {
+			hour, minute, hasClock = clock.Hour(), clock.Minute(), true
+			words = words[:n-1]
+			if last := words[len(words)-1]; last == "at" || last == "v" {
+				words = words[:len(words)-1]
+			}
+		}
+	}
+
+	unit, amount, ok := parseRelativePhrase(words)
+	if !ok {
+		return time.Time{}, ErrInvalidRelativeDeadline
+	}
+
+	if unit.duration > 0 {
+		if hasClock {
+			return time.Time{}, ErrInvalidRelativeDeadline
+		}
+		return now.Add(time.Duration(amount) * unit.duration).Truncate(time.Minute).In(loc), nil
+	}
+
+	// "today" needs a time, 12:00 alone is as likely to be past as not
+	if amount == 0 && !hasClock {
+		return time.Time{}, ErrInvalidRelativeDeadline
+	}
+
+	d := now.In(loc).AddDate(unit.years*amount, unit.months*amount, unit.days*amount)
+	return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, loc), nil
+}
+
+// parseRelativePhrase parses the words of a relative deadline without the time of day
+// into a unit and the number of units
+func parseRelativePhrase(words []string) (relativeUnit, int, bool) {
+	day := relativeUnits["day"]
+
+	switch strings.Join(words, " ") {
+	case "today", "segodnya":
+		return day, 0, true
+	case "tomorrow", "zavtra":
+		return day, 1, true
+	case "day after tomorrow", "poslezavtra":
+		return day, 2, true
+	}
+
+	if len(words) == 2 && words[0] == "next" {
+		unit, ok := relativeUnits[words[1]]
+		return unit, 1, ok && unit.duration == 0
+	}
+
+	if len(words) > 0 && (words[0] == "in" || words[0] == "cherez") {
+		words = words[1:]
+	}
+
+	var amount int
+	switch len(words) {
+	case 1:
+		amount = 1
+	case 2:
+		if words[0] == "a" || words[0] == "an" {
+			amount = 1
+			break
+		}
+		n, err := strconv.Atoi(words[0])
+		if err != nil || n <= 0 || n > maxRelativeDeadlineAmount {
+			return relativeUnit{}, 0, false
+		}
+		amount = n
+	default:
+		return relativeUnit{}, 0, false
+	}
+
+	unit, ok := relativeUnits[words[len(words)-1]]
+	return unit, amount, ok
+}
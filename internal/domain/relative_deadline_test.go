@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRelativeDeadline(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	// 21:30 UTC is already the next day in loc
+	now := time.Date(2026, 3, 1, 21, 30, 45, 0, time.UTC)
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"tomorrow", at(2026, 3, 3, 12, 0)},
+		{"Tomorrow 18:00", at(2026, 3, 3, 18, 0)},
+		{"tomorrow at 9:30", at(2026, 3, 3, 9, 30)},
+		{"day after tomorrow", at(2026, 3, 4, 12, 0)},
+		{"today 23:00", at(2026, 3, 2, 23, 0)},
+		{"in 2 days", at(2026, 3, 4, 12, 0)},
+		{"in a week", at(2026, 3, 9, 12, 0)},
+		{"in 3 weeks 10:00", at(2026, 3, 23, 10, 0)},
+		{"next month", at(2026, 4, 2, 12, 0)},
+		{"in 2 years", at(2028, 3, 2, 12, 0)},
+		{"in 90 minutes", at(2026, 3, 2, 2, 0)},
+		{"in 3 hours", at(2026, 3, 2, 3, 30)},
+		{"завтра", at(2026, 3, 3, 12, 0)},
+		{"Завтра в 18:00", at(2026, 3, 3, 18, 0)},
+		{"послезавтра", at(2026, 3, 4, 12, 0)},
+		{"сегодня в 23:00", at(2026, 3, 2, 23, 0)},
+		{"через неделю", at(2026, 3, 9, 12, 0)},
+		{"через 2 дня", at(2026, 3, 4, 12, 0)},
+		{"через 5 дней", at(2026, 3, 7, 12, 0)},
+		{"через месяц", at(2026, 4, 2, 12, 0)},
+		{"через 3 месяца", at(2026, 6, 2, 12, 0)},
+		{"через год", at(2027, 3, 2, 12, 0)},
+		{"через 2 часа", at(2026, 3, 2, 2, 30)},
+		{"через 30 минут", at(2026, 3, 2, 1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRelativeDeadline(tt.input, now, loc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.expected) || got.Location() != loc {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	invalid := []string{
+		"",
+		"05.03.2026 12:00",
+		"today",
+		"in 0 days",
+		"in -2 days",
+		"in 99999 days",
+		"in 2 hours 18:00",
+		"next hour",
+		"in two days",
+		"yesterday",
+		"через",
+	}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if _, err := ParseRelativeDeadline(input, now, loc); !errors.Is(err, ErrInvalidRelativeDeadline) {
+				t.Errorf("expected ErrInvalidRelativeDeadline, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrReminderOffsetsEmpty   = errors.New("no reminder offsets given")
+	ErrReminderOffsetInvalid  = errors.New("invalid reminder offset")
+	ErrReminderOffsetTooLate  = errors.New("reminder offset is not before the deadline")
+	ErrTooManyReminderOffsets = errors.New("too many reminder offsets")
+)
+
+// MaxReminderOffsets is the maximum number of custom reminders per event
+const MaxReminderOffsets = 5
+
+// DefaultReminderOffsets are used for events without custom reminder offsets:
+// a single reminder a day before the deadline, sent by the hourly scheduler
+var DefaultReminderOffsets = []time.Duration{24 * time.Hour}
+
+// ScheduledReminder is a custom reminder of an event, due at RemindAt (Offset before the deadline)
+type ScheduledReminder struct {
+	EventID  int64
+	Offset   time.Duration
+	RemindAt time.Time
+}
+
+// ParseReminderOffsets parses a list of offsets before the deadline such as "1d 1h" or "24h, 30m".
+// Supported units are m (minutes), h (hours) and d (days). Duplicates are removed and the
+// result is sorted from the earliest reminder (largest offset) to the latest.
+func ParseReminderOffsets(text string) ([]time.Duration, error) {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\n' || r == '\t'
+	})
+	if len(fields) == 0 {
+		return nil, ErrReminderOffsetsEmpty
+	}
+
+	offsets := make([]time.Duration, 0, len(fields))
+	for _, field := range fields {
+		offset, err := parseReminderOffset(field)
+		if err != nil {
+			return nil, err
+		}
+		offsets = append(offsets, offset)
+	}
+
+	offsets = normalizeReminderOffsets(offsets)
+	if len(offsets) > MaxReminderOffsets {
+		return nil, ErrTooManyReminderOffsets
+	}
+
+	return offsets, nil
+}
+
+// parseReminderOffset parses a single offset like "30m", "2h" or "1d"
+func parseReminderOffset(field string) (time.Duration, error) {
+	if len(field) < 2 {
+		return 0, fmt.Errorf("%w: %q", ErrReminderOffsetInvalid, field)
+	}
+
+	var unit time.Duration
+	switch field[len(field)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrReminderOffsetInvalid, field)
+	}
+
+	value, err := strconv.Atoi(field[:len(field)-1])
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrReminderOffsetInvalid, field)
+	}
+
+	return time.Duration(value) * unit, nil
+}
+
+// normalizeReminderOffsets removes duplicates and sorts offsets from largest to smallest
+func normalizeReminderOffsets(offsets []time.Duration) []time.Duration {
+	seen := make(map[time.Duration]bool, len(offsets))
+	result := make([]time.Duration, 0, len(offsets))
+	for _, offset := range offsets {
+		if seen[offset] {
+			continue
+		}
+		seen[offset] = true
+		result = append(result, offset)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] > result[j] })
+	return result
+}
+
+// FormatReminderOffsets formats offsets in the format accepted by ParseReminderOffsets, e.g. "1d 1h"
+func FormatReminderOffsets(offsets []time.Duration) string {
+	parts := make([]string, 0, len(offsets))
+	for _, offset := range offsets {
+		switch {
+		case offset%(24*time.Hour) == 0:
+			parts = append(parts, fmt.Sprintf("%dd", offset/(24*time.Hour)))
+		case offset%time.Hour == 0:
+			parts = append(parts, fmt.Sprintf("%dh", offset/time.Hour))
+		default:
+			parts = append(parts, fmt.Sprintf("%dm", offset/time.Minute))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ValidateReminderOffsets checks that all offsets are positive and fit before the deadline
+func ValidateReminderOffsets(offsets []time.Duration, now, deadline time.Time) error {
+	if len(offsets) > MaxReminderOffsets {
+		return ErrTooManyReminderOffsets
+	}
+
+	timeLeft := deadline.Sub(now)
+	for _, offset := range offsets {
+		if offset <= 0 {
+			return fmt.Errorf("%w: %s", ErrReminderOffsetInvalid, offset)
+		}
+		if offset >= timeLeft {
+			return fmt.Errorf("%w: %s", ErrReminderOffsetTooLate, offset)
+		}
+	}
+
+	return nil
+}
+
+// PlanReminders returns the reminders to schedule for an event. Offsets whose reminder
+// time has already passed at now are skipped.
+func PlanReminders(eventID int64, offsets []time.Duration, deadline, now time.Time) []ScheduledReminder {
+	var reminders []ScheduledReminder
+	for _, offset := range normalizeReminderOffsets(offsets) {
+		remindAt := deadline.Add(-offset)
+		if offset <= 0 || !remindAt.After(now) {
+			continue
+		}
+		reminders = append(reminders, ScheduledReminder{EventID: eventID, Offset: offset, RemindAt: remindAt})
+	}
+	return reminders
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseReminderOffsets(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []time.Duration
+		err      error
+	}{
+		{"days and hours", "1d 1h", []time.Duration{24 * time.Hour, time.Hour}, nil},
+		{"sorted and deduplicated", "30m, 2h;30M 2h", []time.Duration{2 * time.Hour, 30 * time.Minute}, nil},
+		{"empty", "  ", nil, ErrReminderOffsetsEmpty},
+		{"unknown unit", "2w", nil, ErrReminderOffsetInvalid},
+		{"zero", "0h", nil, ErrReminderOffsetInvalid},
+		{"negative", "-1h", nil, ErrReminderOffsetInvalid},
+		{"too many", "1m 2m 3m 4m 5m 6m", nil, ErrTooManyReminderOffsets},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offsets, err := ParseReminderOffsets(tt.text)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if !reflect.DeepEqual(offsets, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, offsets)
+			}
+		})
+	}
+}
+
+func TestFormatReminderOffsets(t *testing.T) {
+	offsets := []time.Duration{48 * time.Hour, 3 * time.Hour, 90 * time.Minute}
+	formatted := FormatReminderOffsets(offsets)
+	if formatted != "2d 3h 90m" {
+		t.Errorf("expected \"2d 3h 90m\", got %q", formatted)
+	}
+
+	parsed, err := ParseReminderOffsets(formatted)
+	if err != nil || !reflect.DeepEqual(parsed, offsets) {
+		t.Errorf("expected round trip to %v, got %v (%v)", offsets, parsed, err)
+	}
+}
+
+func TestValidateReminderOffsets(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(48 * time.Hour)
+
+	if err := ValidateReminderOffsets([]time.Duration{24 * time.Hour, time.Hour}, now, deadline); err != nil {
+		t.Errorf("expected offsets before the deadline to be valid, got %v", err)
+	}
+	if err := ValidateReminderOffsets([]time.Duration{48 * time.Hour}, now, deadline); !errors.Is(err, ErrReminderOffsetTooLate) {
+		t.Errorf("expected ErrReminderOffsetTooLate, got %v", err)
+	}
+	if err := ValidateReminderOffsets([]time.Duration{0}, now, deadline); !errors.Is(err, ErrReminderOffsetInvalid) {
+		t.Errorf("expected ErrReminderOffsetInvalid, got %v", err)
+	}
+}
+
+func TestPlanReminders(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(10 * time.Hour)
+
+	reminders := PlanReminders(7, []time.Duration{time.Hour, 24 * time.Hour, 3 * time.Hour}, deadline, now)
+
+	expected := []ScheduledReminder{
+		{EventID: 7, Offset: 3 * time.Hour, RemindAt: deadline.Add(-3 * time.Hour)},
+		{EventID: 7, Offset: time.Hour, RemindAt: deadline.Add(-time.Hour)},
+	}
+	if !reflect.DeepEqual(reminders, expected) {
+		t.Errorf("expected the past-due 24h offset to be skipped, got %v", reminders)
+	}
+}
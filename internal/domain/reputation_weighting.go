@@ -0,0 +1,81 @@
+package domain
+
+import "context"
+
+const (
+	// MinVoteWeight is the weight of a voter without a positive score (e.g. a new member)
+	MinVoteWeight = 1.0
+	// ReputationWeightScale is the score that adds one extra vote of weight
+	ReputationWeightScale = 100.0
+)
+
+// VoteWeight returns the weight of a vote cast by a predictor with the given score
+// in groups with reputation weighting. Scores of zero or below get the minimum weight.
+func VoteWeight(score int) float64 {
+	if score <= 0 {
+		return MinVoteWeight
+	}
+	return MinVoteWeight + float64(score)/ReputationWeightScale
+}
+
+// VoteWeights returns the reputation weight of every voter, based on their current score in the group
+func VoteWeights(ctx context.Context, ratingRepo RatingRepository, groupID int64, predictions []*Prediction) (map[int64]float64, error) {
+	weights := make(map[int64]float64, len(predictions))
+	for _, pred := range predictions {
+		if _, ok := weights[pred.UserID]; ok {
+			continue
+		}
+
+		rating, err := ratingRepo.GetRating(ctx, pred.UserID, groupID)
+		if err != nil {
+			return nil, err
+		}
+		weights[pred.UserID] = VoteWeight(rating.Score)
+	}
+
+	return weights, nil
+}
+
+// VoteShares returns the share (0-1) of the votes cast for each option.
+// With nil weights every vote counts once; otherwise each vote counts with its voter's weight,
+// and voters missing from the map get the minimum weight.
+func VoteShares(predictions []*Prediction, weights map[int64]float64) map[int]float64 {
+	shares := make(map[int]float64)
+
+	total := 0.0
+	for _, pred := range predictions {
+		weight := 1.0
+		if weights != nil {
+			weight = MinVoteWeight
+			if w, ok := weights[pred.UserID]; ok {
+				weight = w
+			}
+		}
+		shares[pred.Option] += weight
+		total += weight
+	}
+
+	if total == 0 {
+		return shares
+	}
+	for option := range shares {
+		shares[option] /= total
+	}
+
+	return shares
+}
+
+// reputationWeighted reports whether the group weights votes by the voters' ratings.
+// Without a group repository every vote counts the same.
+func (rc *RatingCalculator) reputationWeighted(ctx context.Context, groupID int64) (bool, error) {
+	if rc.groupRepo == nil {
+		return false, nil
+	}
+
+	group, err := rc.groupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return false, err
+	}
+
+	return group != nil && group.ReputationWeighting, nil
+}
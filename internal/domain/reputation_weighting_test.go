@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestVoteWeight(t *testing.T) {
+	tests := []struct {
+		score  int
+		weight float64
+	}{
+		{-50, MinVoteWeight},
+		{0, MinVoteWeight},
+		{50, 1.5},
+		{300, 4},
+	}
+
+	for _, tt := range tests {
+		if weight := VoteWeight(tt.score); weight != tt.weight {
+			t.Errorf("VoteWeight(%d) = %v, want %v", tt.score, weight, tt.weight)
+		}
+	}
+}
+
+func TestVoteShares(t *testing.T) {
+	predictions := []*Prediction{
+		{UserID: 1, Option: 0},
+		{UserID: 2, Option: 1},
+		{UserID: 3, Option: 1},
+		{UserID: 4, Option: 1},
+	}
+
+	shares := VoteShares(predictions, nil)
+	if shares[0] != 0.25 || shares[1] != 0.75 {
+		t.Errorf("expected one vote per person, got %v", shares)
+	}
+
+	// User 4 has no weight and counts with the minimum weight
+	shares = VoteShares(predictions, map[int64]float64{1: 7, 2: 1, 3: 1})
+	if math.Abs(shares[0]-0.7) > 1e-9 || math.Abs(shares[1]-0.3) > 1e-9 {
+		t.Errorf("expected weighted shares 0.7/0.3, got %v", shares)
+	}
+
+	if shares := VoteShares(nil, nil); len(shares) != 0 {
+		t.Errorf("expected no shares without votes, got %v", shares)
+	}
+}
+
+func TestRatingCalculator_ReputationWeightedMinorityBonus(t *testing.T) {
+	ctx := context.Background()
+	const groupID = int64(1)
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	correctOption := 0
+
+	// A high-rated predictor is alone on the correct option
+	events := []*Event{
+		{ID: 1, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusResolved, CorrectOption: &correctOption, CreatedAt: created, Deadline: created.Add(24 * time.Hour)},
+	}
+	predictions := []*Prediction{
+		{EventID: 1, UserID: 10, Option: 0, Timestamp: created.Add(time.Hour)},
+		{EventID: 1, UserID: 20, Option: 1, Timestamp: created.Add(time.Hour)},
+		{EventID: 1, UserID: 30, Option: 1, Timestamp: created.Add(time.Hour)},
+	}
+
+	scoreOf := func(reputationWeighting bool) int {
+		t.Helper()
+		ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+			{10, groupID}: {UserID: 10, GroupID: groupID, Score: 900},
+		}}
+		rc := NewRatingCalculator(ratingRepo, &mockPredictionRepoByEvent{MockPredictionRepoWithData{predictions: predictions}},
+			&MockEventRepoWithEvents{events: events}, nil, &MockLogger{})
+		rc.SetGroupRepository(&mockGroupRepoForRemover{groups: []*Group{
+			{ID: groupID, Status: GroupStatusActive, ReputationWeighting: reputationWeighting},
+		}})
+
+		if err := rc.CalculateScores(ctx, 1, correctOption); err != nil {
+			t.Fatalf("CalculateScores failed: %v", err)
+		}
+		rating, _ := ratingRepo.GetRating(ctx, 10, groupID)
+		return rating.Score - 900
+	}
+
+	base := ParticipationPoints + BinaryCorrectPoints + EarlyVotingBonusPoints
+
+	// One vote of three is a minority
+	if points := scoreOf(false); points != base+MinorityBonusPoints {
+		t.Errorf("expected %d points with one vote per person, got %d", base+MinorityBonusPoints, points)
+	}
+
+	// Weighted by rating the same vote holds the majority of the weight
+	if points := scoreOf(true); points != base {
+		t.Errorf("expected %d points with reputation weighting, got %d", base, points)
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxResolutionNoteLength is the maximum length of a resolution note, in characters
+const MaxResolutionNoteLength = 1000
+
+// ErrInvalidResolutionNote is returned when a resolution note is empty or too long
+var ErrInvalidResolutionNote = NewError(ErrorKindValidation, "invalid resolution note")
+
+// NormalizeResolutionNote validates a resolution note and returns it trimmed
+func NormalizeResolutionNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if note == "" || utf8.RuneCountInString(note) > MaxResolutionNoteLength {
+		return "", ErrInvalidResolutionNote
+	}
+	return note, nil
+}
+
+// RequiresResolutionNote reports whether resolving the event needs a resolution note,
+// i.e. whether it has more votes than threshold. A zero threshold never requires one.
+func (em *EventManager) RequiresResolutionNote(ctx context.Context, event *Event, threshold int) (bool, error) {
+	if threshold <= 0 {
+		return false, nil
+	}
+
+	predictions, err := em.predictionRepo.GetPredictionsByEvent(ctx, event.ID)
+	if err != nil {
+		em.logger.Error("failed to get predictions", "event_id", event.ID, "error", err)
+		return false, err
+	}
+
+	return len(event.ParticipantPredictions(predictions)) > threshold, nil
+}
+
+// SetResolutionNote stores the evidence or source given for the outcome of an event
+func (em *EventManager) SetResolutionNote(ctx context.Context, eventID int64, note string) error {
+	note, err := NormalizeResolutionNote(note)
+	if err != nil {
+		return err
+	}
+
+	if err := em.eventRepo.SetResolutionNote(ctx, eventID, note); err != nil {
+		em.logger.Error("failed to set resolution note", "event_id", eventID, "error", err)
+		return err
+	}
+
+	em.logger.Info("resolution note set", "event_id", eventID)
+	return nil
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// ResolutionWebhookSignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body keyed with the webhook secret
+	ResolutionWebhookSignatureHeader = "X-Signature-256"
+	// resolutionWebhookTimeout bounds a single delivery attempt
+	resolutionWebhookTimeout = 10 * time.Second
+	// resolutionWebhookAttempts is the number of delivery attempts before giving up
+	resolutionWebhookAttempts = 3
+)
+
+// ResolutionWebhookPayload is the JSON body posted when an event resolves
+type ResolutionWebhookPayload struct {
+	EventID        int64     `json:"event_id"`
+	Question       string    `json:"question"`
+	EventType      EventType `json:"event_type"`
+	Options        []string  `json:"options"`
+	CorrectOption  int       `json:"correct_option"`
+	CorrectAnswer  string    `json:"correct_answer"`
+	OutcomePercent *float64  `json:"outcome_percent,omitempty"` // Realized outcome of probability events
+	GroupID        int64     `json:"group_id"`
+	TelegramChatID int64     `json:"telegram_chat_id"`
+	ResolvedAt     time.Time `json:"resolved_at"`
+}
+
+// resolutionWebhook posts resolution payloads to an integrator's URL
+type resolutionWebhook struct {
+	url        string
+	secret     string
+	client     *http.Client
+	retryDelay time.Duration
+}
+
+// SetResolutionWebhook enables a signed POST to url whenever an event resolves (disabled by default).
+// An empty url disables the webhook.
+func (ns *NotificationService) SetResolutionWebhook(url, secret string) {
+	if url == "" {
+		ns.webhook = nil
+		return
+	}
+	ns.webhook = &resolutionWebhook{
+		url:        url,
+		secret:     secret,
+		client:     &http.Client{Timeout: resolutionWebhookTimeout},
+		retryDelay: 2 * time.Second,
+	}
+}
+
+// SendResolutionWebhook posts the outcome of a resolved event to the configured webhook in the
+// background. outcomePercent is set for probability events. Does nothing when no webhook is configured.
+func (ns *NotificationService) SendResolutionWebhook(event *Event, correctOption int, outcomePercent *float64, telegramChatID int64) {
+	if ns.webhook == nil {
+		return
+	}
+
+	payload := &ResolutionWebhookPayload{
+		EventID:        event.ID,
+		Question:       event.Question,
+		EventType:      event.EventType,
+		Options:        event.Options,
+		CorrectOption:  correctOption,
+		OutcomePercent: outcomePercent,
+		GroupID:        event.GroupID,
+		TelegramChatID: telegramChatID,
+		ResolvedAt:     time.Now().UTC(),
+	}
+	if correctOption >= 0 && correctOption < len(event.Options) {
+		payload.CorrectAnswer = event.Options[correctOption]
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		ns.logger.Error("failed to encode resolution webhook payload", "event_id", event.ID, "error", err)
+		return
+	}
+
+	// Deliver outside of the resolution request, which must not wait for the integrator
+	webhook := ns.webhook
+	go func() {
+		if err := webhook.deliver(body); err != nil {
+			ns.logger.Error("failed to deliver resolution webhook", "event_id", event.ID, "error", err)
+			return
+		}
+		ns.logger.Info("resolution webhook delivered", "event_id", event.ID)
+	}()
+}
+
+// deliver posts body, retrying network errors and 5xx/429 responses with a growing delay
+func (w *resolutionWebhook) deliver(body []byte) error {
+	var lastErr error
+	for attempt := 1; attempt <= resolutionWebhookAttempts; attempt++ {
+		retry, err := w.post(body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+		if attempt < resolutionWebhookAttempts {
+			time.Sleep(time.Duration(attempt) * w.retryDelay)
+		}
+	}
+	return lastErr
+}
+
+// post makes one delivery attempt and reports whether a failure is worth retrying
+func (w *resolutionWebhook) post(body []byte) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), resolutionWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ResolutionWebhookSignatureHeader, SignResolutionWebhook(w.secret, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+}
+
+// SignResolutionWebhook returns the signature header value of a webhook body
+func SignResolutionWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookRequest is a request received by the test webhook server
+type webhookRequest struct {
+	body      []byte
+	signature string
+	header    http.Header
+}
+
+// newWebhookServer starts a server answering with the given status codes in order (200 after the last)
+func newWebhookServer(t *testing.T, statuses ...int) (*httptest.Server, chan webhookRequest, *int32) {
+	t.Helper()
+	requests := make(chan webhookRequest, 10)
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		body, _ := io.ReadAll(r.Body)
+		requests <- webhookRequest{body: body, signature: r.Header.Get(ResolutionWebhookSignatureHeader), header: r.Header}
+
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests, &calls
+}
+
+func newWebhookNotificationService(url string) *NotificationService {
+	ns := NewNotificationService(nil, nil, nil, nil, nil, &mockLogger{}, nil)
+	ns.SetResolutionWebhook(url, "s3cret")
+	ns.webhook.retryDelay = time.Millisecond
+	return ns
+}
+
+func receiveWebhook(t *testing.T, requests chan webhookRequest) webhookRequest {
+	t.Helper()
+	select {
+	case req := <-requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+		return webhookRequest{}
+	}
+}
+
+func TestResolutionWebhook_PayloadAndSignature(t *testing.T) {
+	server, requests, _ := newWebhookServer(t)
+	ns := newWebhookNotificationService(server.URL)
+
+	event := &Event{
+		ID:        42,
+		GroupID:   7,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		EventType: EventTypeBinary,
+	}
+	ns.SendResolutionWebhook(event, 1, nil, -1001)
+
+	req := receiveWebhook(t, requests)
+	if got := req.header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected JSON content type, got %q", got)
+	}
+	if want := SignResolutionWebhook("s3cret", req.body); req.signature != want {
+		t.Errorf("expected signature %q, got %q", want, req.signature)
+	}
+	if req.signature == SignResolutionWebhook("other", req.body) {
+		t.Error("expected signature to depend on the secret")
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("invalid JSON payload: %v", err)
+	}
+	expected := map[string]any{
+		"event_id":         float64(42),
+		"question":         "Will it rain?",
+		"event_type":       "binary",
+		"correct_option":   float64(1),
+		"correct_answer":   "No",
+		"group_id":         float64(7),
+		"telegram_chat_id": float64(-1001),
+	}
+	for key, want := range expected {
+		if payload[key] != want {
+			t.Errorf("expected %s=%v, got %v", key, want, payload[key])
+		}
+	}
+	if _, ok := payload["outcome_percent"]; ok {
+		t.Error("expected no outcome_percent for a binary event")
+	}
+	if _, err := time.Parse(time.RFC3339, payload["resolved_at"].(string)); err != nil {
+		t.Errorf("expected RFC 3339 resolved_at, got %v", payload["resolved_at"])
+	}
+}
+
+func TestResolutionWebhook_ProbabilityOutcome(t *testing.T) {
+	server, requests, _ := newWebhookServer(t)
+	ns := newWebhookNotificationService(server.URL)
+
+	event := &Event{ID: 1, Options: []string{"0-50%", "51-100%"}, EventType: EventTypeProbability}
+	outcome := 63.5
+	ns.SendResolutionWebhook(event, 1, &outcome, -1001)
+
+	var payload ResolutionWebhookPayload
+	if err := json.Unmarshal(receiveWebhook(t, requests).body, &payload); err != nil {
+		t.Fatalf("invalid JSON payload: %v", err)
+	}
+	if payload.OutcomePercent == nil || *payload.OutcomePercent != 63.5 {
+		t.Errorf("expected outcome_percent 63.5, got %v", payload.OutcomePercent)
+	}
+}
+
+func TestResolutionWebhook_RetriesServerErrors(t *testing.T) {
+	server, requests, calls := newWebhookServer(t, http.StatusInternalServerError, http.StatusBadGateway)
+	ns := newWebhookNotificationService(server.URL)
+
+	ns.SendResolutionWebhook(&Event{ID: 1, Options: []string{"Yes", "No"}}, 0, nil, -1001)
+
+	first := receiveWebhook(t, requests)
+	receiveWebhook(t, requests)
+	third := receiveWebhook(t, requests)
+	if string(first.body) != string(third.body) || first.signature != third.signature {
+		t.Error("expected retries to resend the same signed body")
+	}
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestResolutionWebhook_NoRetryOnClientError(t *testing.T) {
+	server, requests, calls := newWebhookServer(t, http.StatusBadRequest)
+	ns := newWebhookNotificationService(server.URL)
+
+	ns.SendResolutionWebhook(&Event{ID: 1, Options: []string{"Yes", "No"}}, 0, nil, -1001)
+
+	receiveWebhook(t, requests)
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("expected a single attempt for a client error, got %d", got)
+	}
+}
+
+func TestResolutionWebhook_SkippedWhenUnconfigured(t *testing.T) {
+	_, _, calls := newWebhookServer(t)
+	ns := NewNotificationService(nil, nil, nil, nil, nil, &mockLogger{}, nil)
+	ns.SetResolutionWebhook("", "s3cret")
+
+	ns.SendResolutionWebhook(&Event{ID: 1, Options: []string{"Yes", "No"}}, 0, nil, -1001)
+
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(calls); got != 0 {
+		t.Errorf("expected no webhook without a URL, got %d calls", got)
+	}
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSeasonsDisabled is returned when a season is started without a season repository
+var ErrSeasonsDisabled = errors.New("seasons are not enabled")
+
+// SeasonResult is the final rating of a member in a finished season
+type SeasonResult struct {
+	GroupID      int64
+	Season       int
+	UserID       int64
+	Username     string
+	Score        int
+	CorrectCount int
+	WrongCount   int
+	BestStreak   int
+}
+
+// Season is a finished season of a group. Seasons are numbered from 1 per group.
+type Season struct {
+	GroupID   int64
+	Number    int
+	StartedAt time.Time // Zero for the first season, which started with the group
+	EndedAt   time.Time
+	Players   int           // Members with an archived rating
+	Champion  *SeasonResult // Highest score of the season (nil when nobody played)
+}
+
+// SeasonRepository archives ratings at the end of a season and reads past seasons
+type SeasonRepository interface {
+	// ArchiveSeason copies the live ratings of a group into the season results and resets them
+	// to zero in a single transaction, recording the season as ended at endedAt. Best streaks are kept.
+	ArchiveSeason(ctx context.Context, groupID int64, endedAt time.Time) (*Season, error)
+	// GetSeasonStart returns when the current season of a group started (zero before the first reset)
+	GetSeasonStart(ctx context.Context, groupID int64) (time.Time, error)
+	// GetSeasons returns the finished seasons of a group, newest first
+	GetSeasons(ctx context.Context, groupID int64) ([]*Season, error)
+}
+
+// SetSeasonRepository enables seasons: StartNewSeason and season-aware recomputation
+func (rc *RatingCalculator) SetSeasonRepository(seasonRepo SeasonRepository) {
+	rc.seasonRepo = seasonRepo
+}
+
+// StartNewSeason ends the current season of a group: the live ratings are archived into the
+// season results and reset to zero, predictions, achievements and best streaks are kept. Events still open
+// carry over and are scored into the new season when they resolve.
+func (rc *RatingCalculator) StartNewSeason(ctx context.Context, groupID int64) (*Season, error) {
+	if rc.seasonRepo == nil {
+		return nil, ErrSeasonsDisabled
+	}
+
+	// Keep incremental scoring from landing between the archive and the reset
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	season, err := rc.seasonRepo.ArchiveSeason(ctx, groupID, time.Now())
+	if err != nil {
+		rc.logger.Error("failed to archive season", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	rc.logger.Info("new season started", "group_id", groupID, "archived_season", season.Number, "players", season.Players)
+	return season, nil
+}
+
+// GetSeasons returns the finished seasons of a group, newest first (none without seasons)
+func (rc *RatingCalculator) GetSeasons(ctx context.Context, groupID int64) ([]*Season, error) {
+	if rc.seasonRepo == nil {
+		return nil, nil
+	}
+
+	seasons, err := rc.seasonRepo.GetSeasons(ctx, groupID)
+	if err != nil {
+		rc.logger.Error("failed to get seasons", "group_id", groupID, "error", err)
+		return nil, err
+	}
+	return seasons, nil
+}
+
+// seasonStart returns when the current season of a group started, zero without seasons
+func (rc *RatingCalculator) seasonStart(ctx context.Context, groupID int64) (time.Time, error) {
+	if rc.seasonRepo == nil {
+		return time.Time{}, nil
+	}
+	return rc.seasonRepo.GetSeasonStart(ctx, groupID)
+}
+
+// resolvedIn reports whether an event was resolved in the season that started at start.
+// Events resolved before resolution times were recorded count as resolved at their deadline.
+func (e *Event) resolvedIn(start time.Time) bool {
+	resolvedAt := e.Deadline
+	if e.ResolvedAt != nil {
+		resolvedAt = *e.ResolvedAt
+	}
+	return !resolvedAt.Before(start)
+}
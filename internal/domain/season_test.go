@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mockSeasonRepo records archives and serves a fixed season start
+type mockSeasonRepo struct {
+	start    time.Time
+	archived []int64
+}
+
+func (m *mockSeasonRepo) ArchiveSeason(ctx context.Context, groupID int64, endedAt time.Time) (*Season, error) {
+	m.archived = append(m.archived, groupID)
+	m.start = endedAt
+	return &Season{GroupID: groupID, Number: len(m.archived), EndedAt: endedAt}, nil
+}
+
+func (m *mockSeasonRepo) GetSeasonStart(ctx context.Context, groupID int64) (time.Time, error) {
+	return m.start, nil
+}
+
+func (m *mockSeasonRepo) GetSeasons(ctx context.Context, groupID int64) ([]*Season, error) {
+	return nil, nil
+}
+
+func TestStartNewSeason(t *testing.T) {
+	ctx := context.Background()
+	rc := NewRatingCalculator(&mockRatingRepoStore{ratings: map[[2]int64]*Rating{}}, nil, nil, nil, &MockLogger{})
+
+	if _, err := rc.StartNewSeason(ctx, 1); !errors.Is(err, ErrSeasonsDisabled) {
+		t.Fatalf("Expected ErrSeasonsDisabled without a season repository, got %v", err)
+	}
+
+	seasonRepo := &mockSeasonRepo{}
+	rc.SetSeasonRepository(seasonRepo)
+	season, err := rc.StartNewSeason(ctx, 1)
+	if err != nil {
+		t.Fatalf("StartNewSeason failed: %v", err)
+	}
+	if season.Number != 1 || len(seasonRepo.archived) != 1 || seasonRepo.archived[0] != 1 {
+		t.Errorf("Expected the group's season to be archived, got %+v (archived %v)", season, seasonRepo.archived)
+	}
+}
+
+func TestRecomputeGroup_CurrentSeasonOnly(t *testing.T) {
+	ctx := context.Background()
+	const groupID = int64(1)
+	option := func(o int) *int { return &o }
+	at := func(tm time.Time) *time.Time { return &tm }
+	seasonStart := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []*Event{
+		// Resolved in the archived season
+		{ID: 1, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusResolved, CorrectOption: option(0),
+			CreatedAt: seasonStart.AddDate(0, 0, -10), Deadline: seasonStart.AddDate(0, 0, -5), ResolvedAt: at(seasonStart.AddDate(0, 0, -4))},
+		// Carried over: the deadline passed before the reset, the resolution came after it
+		{ID: 2, GroupID: groupID, EventType: EventTypeBinary, Status: EventStatusResolved, CorrectOption: option(1),
+			CreatedAt: seasonStart.AddDate(0, 0, -3), Deadline: seasonStart.AddDate(0, 0, -1), ResolvedAt: at(seasonStart.Add(time.Hour))},
+	}
+	predictions := []*Prediction{
+		{EventID: 1, UserID: 10, Option: 0, Timestamp: seasonStart.AddDate(0, 0, -9)},
+		{EventID: 2, UserID: 10, Option: 1, Timestamp: seasonStart.AddDate(0, 0, -2)},
+		{EventID: 2, UserID: 20, Option: 0, Timestamp: seasonStart.AddDate(0, 0, -2)},
+	}
+	predictionRepo := &mockPredictionRepoByEvent{MockPredictionRepoWithData{predictions: predictions}}
+	eventRepo := &MockEventRepoWithEvents{events: events}
+
+	// Expected ratings: only the carried over event scored from zero
+	expectedRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+	incremental := NewRatingCalculator(expectedRepo, predictionRepo, eventRepo, nil, &MockLogger{})
+	if err := incremental.CalculateScores(ctx, 2, 1); err != nil {
+		t.Fatalf("CalculateScores failed: %v", err)
+	}
+
+	ratingRepo := &mockRatingRepoStore{ratings: make(map[[2]int64]*Rating)}
+	rc := NewRatingCalculator(ratingRepo, predictionRepo, eventRepo, nil, &MockLogger{})
+	rc.SetSeasonRepository(&mockSeasonRepo{start: seasonStart})
+
+	result, err := rc.RecomputeGroup(ctx, groupID)
+	if err != nil {
+		t.Fatalf("RecomputeGroup failed: %v", err)
+	}
+	if result.Events != 1 || result.Predictions != 2 {
+		t.Errorf("Expected only the carried over event to be replayed, got %+v", result)
+	}
+
+	for _, userID := range []int64{10, 20} {
+		got, _ := ratingRepo.GetRating(ctx, userID, groupID)
+		want, _ := expectedRepo.GetRating(ctx, userID, groupID)
+		if got.Score != want.Score || got.CorrectCount != want.CorrectCount || got.WrongCount != want.WrongCount {
+			t.Errorf("User %d: expected %+v, got %+v", userID, want, got)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+)
+
+// topPredictorsLimit is the number of top predictors included in group stats
+const topPredictorsLimit = 3
+
+// ContrarianWinsLimit is the number of recent contrarian wins included in group stats
+const ContrarianWinsLimit = 3
+
+// ContrarianWin is a resolved event whose correct option was a minority pick
+type ContrarianWin struct {
+	EventID      int64
+	Question     string
+	CorrectCount int // Predictions of the correct option
+	TotalCount   int // All predictions of the event
+}
+
+// GroupStats holds aggregated analytics for a group
+type GroupStats struct {
+	GroupID              int64
+	TotalEvents          int
+	ActiveEvents         int
+	ResolvedEvents       int // Includes archived events
+	CancelledEvents      int
+	TotalPredictions     int
+	UniqueParticipants   int
+	ActiveMembers        int
+	ResolvedPredictions  int              // Predictions on resolved events
+	CorrectPredictions   int              // Correct predictions on resolved events
+	ContrarianWins       int              // Resolved events whose correct option was a minority pick
+	RecentContrarianWins []*ContrarianWin // Most recently resolved contrarian wins, up to ContrarianWinsLimit
+	TopPredictors        []*Rating
+}
+
+// AveragePredictionsPerEvent returns the average number of predictions per event
+func (s *GroupStats) AveragePredictionsPerEvent() float64 {
+	if s.TotalEvents == 0 {
+		return 0
+	}
+	return float64(s.TotalPredictions) / float64(s.TotalEvents)
+}
+
+// AccuracyPercent returns the share of correct predictions on resolved events
+func (s *GroupStats) AccuracyPercent() float64 {
+	if s.ResolvedPredictions == 0 {
+		return 0
+	}
+	return float64(s.CorrectPredictions) / float64(s.ResolvedPredictions) * 100.0
+}
+
+// StatsRepository interface for aggregated statistics queries
+type StatsRepository interface {
+	GetGroupStats(ctx context.Context, groupID int64) (*GroupStats, error)
+}
+
+// StatsService provides group and event analytics
+type StatsService struct {
+	statsRepo      StatsRepository
+	ratingRepo     RatingRepository
+	eventRepo      EventRepository
+	predictionRepo PredictionRepository
+	logger         Logger
+}
+
+// NewStatsService creates a new StatsService
+func NewStatsService(
+	statsRepo StatsRepository,
+	ratingRepo RatingRepository,
+	eventRepo EventRepository,
+	predictionRepo PredictionRepository,
+	logger Logger,
+) *StatsService {
+	return &StatsService{
+		statsRepo:      statsRepo,
+		ratingRepo:     ratingRepo,
+		eventRepo:      eventRepo,
+		predictionRepo: predictionRepo,
+		logger:         logger,
+	}
+}
+
+// GetGroupStats retrieves analytics for a specific group
+func (s *StatsService) GetGroupStats(ctx context.Context, groupID int64) (*GroupStats, error) {
+	stats, err := s.statsRepo.GetGroupStats(ctx, groupID)
+	if err != nil {
+		s.logger.Error("failed to get group stats", "group_id", groupID, "error", err)
+		return nil, err
+	}
+
+	topPredictors, err := s.ratingRepo.GetTopRatings(ctx, groupID, topPredictorsLimit)
+	if err != nil {
+		s.logger.Error("failed to get top predictors for group stats", "group_id", groupID, "error", err)
+		return nil, err
+	}
+	stats.TopPredictors = topPredictors
+
+	s.logger.Debug("group stats calculated", "group_id", groupID, "total_events", stats.TotalEvents, "total_predictions", stats.TotalPredictions)
+	return stats, nil
+}
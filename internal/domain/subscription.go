@@ -0,0 +1,115 @@
+package domain
+
+import "context"
+
+// ErrNotSubscribable is returned when a user changes the subscription of a group they are not an active member of
+var ErrNotSubscribable = NewError(ErrorKindPermission, "user is not an active member of the group")
+
+// SubscriptionRepository interface for per-group new event subscriptions.
+// Members without a stored flag are subscribed.
+type SubscriptionRepository interface {
+	SetSubscribed(ctx context.Context, userID int64, groupID int64, subscribed bool) error
+	GetUnsubscribedGroupIDs(ctx context.Context, userID int64) ([]int64, error)
+	GetUnsubscribedUserIDs(ctx context.Context, groupID int64) ([]int64, error)
+}
+
+// NewEventNotifier sends a member a direct message about a newly published event
+type NewEventNotifier interface {
+	SendNewEventSubscriberNotification(ctx context.Context, userID int64, event *Event, group *Group) error
+}
+
+// SubscriptionService manages which groups members get direct messages about new events from.
+// Members are subscribed to all their groups until they unsubscribe.
+type SubscriptionService struct {
+	subscriptionRepo SubscriptionRepository
+	membershipRepo   GroupMembershipRepository
+	notifier         NewEventNotifier
+	logger           Logger
+}
+
+// NewSubscriptionService creates a new SubscriptionService
+func NewSubscriptionService(
+	subscriptionRepo SubscriptionRepository,
+	membershipRepo GroupMembershipRepository,
+	notifier NewEventNotifier,
+	logger Logger,
+) *SubscriptionService {
+	return &SubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		membershipRepo:   membershipRepo,
+		notifier:         notifier,
+		logger:           logger,
+	}
+}
+
+// SetSubscribed subscribes an active member to new event notifications of a group or unsubscribes them
+func (s *SubscriptionService) SetSubscribed(ctx context.Context, userID, groupID int64, subscribed bool) error {
+	isMember, err := s.membershipRepo.HasActiveMembership(ctx, groupID, userID)
+	if err != nil {
+		s.logger.Error("failed to check membership for subscription", "user_id", userID, "group_id", groupID, "error", err)
+		return err
+	}
+	if !isMember {
+		return ErrNotSubscribable
+	}
+
+	if err := s.subscriptionRepo.SetSubscribed(ctx, userID, groupID, subscribed); err != nil {
+		s.logger.Error("failed to update subscription", "user_id", userID, "group_id", groupID, "error", err)
+		return err
+	}
+
+	s.logger.Info("subscription updated", "user_id", userID, "group_id", groupID, "subscribed", subscribed)
+	return nil
+}
+
+// UnsubscribedGroups returns the set of groups the user unsubscribed from
+func (s *SubscriptionService) UnsubscribedGroups(ctx context.Context, userID int64) (map[int64]bool, error) {
+	groupIDs, err := s.subscriptionRepo.GetUnsubscribedGroupIDs(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to get unsubscribed groups", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	unsubscribed := make(map[int64]bool, len(groupIDs))
+	for _, groupID := range groupIDs {
+		unsubscribed[groupID] = true
+	}
+	return unsubscribed, nil
+}
+
+// NotifyNewEvent sends a direct message about a newly published event to every subscribed active
+// member who can vote on it, except its creator. Returns the number of notified members.
+func (s *SubscriptionService) NotifyNewEvent(ctx context.Context, event *Event, group *Group) (int, error) {
+	members, err := s.membershipRepo.GetGroupMembers(ctx, event.GroupID)
+	if err != nil {
+		s.logger.Error("failed to get group members for new event notification", "group_id", event.GroupID, "error", err)
+		return 0, err
+	}
+
+	unsubscribedIDs, err := s.subscriptionRepo.GetUnsubscribedUserIDs(ctx, event.GroupID)
+	if err != nil {
+		s.logger.Error("failed to get unsubscribed members", "group_id", event.GroupID, "error", err)
+		return 0, err
+	}
+	unsubscribed := make(map[int64]bool, len(unsubscribedIDs))
+	for _, userID := range unsubscribedIDs {
+		unsubscribed[userID] = true
+	}
+
+	sent := 0
+	for _, member := range members {
+		if member.Status != MembershipStatusActive || member.UserID == event.CreatedBy ||
+			unsubscribed[member.UserID] || !event.IsParticipant(member.UserID) {
+			continue
+		}
+
+		if err := s.notifier.SendNewEventSubscriberNotification(ctx, member.UserID, event, group); err != nil {
+			s.logger.Warn("failed to send new event notification", "event_id", event.ID, "user_id", member.UserID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	s.logger.Info("new event notifications sent", "event_id", event.ID, "group_id", event.GroupID, "sent_count", sent)
+	return sent, nil
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// mockSubscriptionRepo keeps subscription flags in memory
+type mockSubscriptionRepo struct {
+	flags map[[2]int64]bool // key: user ID, group ID
+}
+
+func (m *mockSubscriptionRepo) SetSubscribed(ctx context.Context, userID int64, groupID int64, subscribed bool) error {
+	m.flags[[2]int64{userID, groupID}] = subscribed
+	return nil
+}
+
+func (m *mockSubscriptionRepo) GetUnsubscribedGroupIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var groupIDs []int64
+	for key, subscribed := range m.flags {
+		if key[0] == userID && !subscribed {
+			groupIDs = append(groupIDs, key[1])
+		}
+	}
+	return groupIDs, nil
+}
+
+func (m *mockSubscriptionRepo) GetUnsubscribedUserIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	var userIDs []int64
+	for key, subscribed := range m.flags {
+		if key[1] == groupID && !subscribed {
+			userIDs = append(userIDs, key[0])
+		}
+	}
+	return userIDs, nil
+}
+
+// mockMembershipRepoForSubscriptions serves a fixed member list
+type mockMembershipRepoForSubscriptions struct {
+	mockGroupMembershipRepoForPermissions
+	members []*GroupMembership
+}
+
+func (m *mockMembershipRepoForSubscriptions) GetGroupMembers(ctx context.Context, groupID int64) ([]*GroupMembership, error) {
+	return m.members, nil
+}
+
+// mockNewEventNotifier records notified users
+type mockNewEventNotifier struct {
+	notified []int64
+	failFor  int64
+}
+
+func (m *mockNewEventNotifier) SendNewEventSubscriberNotification(ctx context.Context, userID int64, event *Event, group *Group) error {
+	if userID == m.failFor {
+		return errors.New("bot was blocked by the user")
+	}
+	m.notified = append(m.notified, userID)
+	return nil
+}
+
+func TestSubscriptionService_NotifyNewEvent(t *testing.T) {
+	ctx := context.Background()
+	const groupID, creatorID = int64(1), int64(10)
+
+	membershipRepo := &mockMembershipRepoForSubscriptions{
+		mockGroupMembershipRepoForPermissions: mockGroupMembershipRepoForPermissions{memberships: map[string]bool{
+			formatMembershipKey(groupID, 20): true,
+		}},
+		members: []*GroupMembership{
+			{GroupID: groupID, UserID: creatorID, Status: MembershipStatusActive},
+			{GroupID: groupID, UserID: 20, Status: MembershipStatusActive},
+			{GroupID: groupID, UserID: 30, Status: MembershipStatusActive},
+			{GroupID: groupID, UserID: 40, Status: MembershipStatusRemoved},
+			{GroupID: groupID, UserID: 50, Status: MembershipStatusActive},
+			{GroupID: groupID, UserID: 60, Status: MembershipStatusActive},
+		},
+	}
+	notifier := &mockNewEventNotifier{failFor: 50}
+	service := NewSubscriptionService(&mockSubscriptionRepo{flags: make(map[[2]int64]bool)}, membershipRepo, notifier, &mockLogger{})
+	group := &Group{ID: groupID, Name: "Test Group"}
+
+	// Only active members of the group can change their subscription
+	if err := service.SetSubscribed(ctx, 30, groupID, false); !errors.Is(err, ErrNotSubscribable) {
+		t.Errorf("expected ErrNotSubscribable for a user without membership, got %v", err)
+	}
+	if err := service.SetSubscribed(ctx, 20, groupID, false); err != nil {
+		t.Fatalf("SetSubscribed failed: %v", err)
+	}
+	unsubscribed, err := service.UnsubscribedGroups(ctx, 20)
+	if err != nil || !unsubscribed[groupID] {
+		t.Fatalf("expected user 20 to be unsubscribed from group %d, got %v, %v", groupID, unsubscribed, err)
+	}
+
+	// The creator, removed and unsubscribed members are skipped, failed messages aren't counted
+	event := &Event{ID: 1, GroupID: groupID, Question: "Will it rain?", CreatedBy: creatorID}
+	sent, err := service.NotifyNewEvent(ctx, event, group)
+	if err != nil {
+		t.Fatalf("NotifyNewEvent failed: %v", err)
+	}
+	if sent != 2 || !reflect.DeepEqual(notifier.notified, []int64{30, 60}) {
+		t.Errorf("expected users 30 and 60 to be notified, got %d: %v", sent, notifier.notified)
+	}
+
+	// Restricted events only reach their participants
+	notifier.notified = nil
+	restricted := &Event{ID: 2, GroupID: groupID, Question: "Will it snow?", CreatedBy: creatorID, Participants: []int64{60}}
+	if sent, _ := service.NotifyNewEvent(ctx, restricted, group); sent != 1 || !reflect.DeepEqual(notifier.notified, []int64{60}) {
+		t.Errorf("expected only participant 60 to be notified, got %d: %v", sent, notifier.notified)
+	}
+
+	// Subscribing again resumes the messages
+	if err := service.SetSubscribed(ctx, 20, groupID, true); err != nil {
+		t.Fatalf("SetSubscribed failed: %v", err)
+	}
+	notifier.notified = nil
+	if _, err := service.NotifyNewEvent(ctx, event, group); err != nil || !reflect.DeepEqual(notifier.notified, []int64{20, 30, 60}) {
+		t.Errorf("expected users 20, 30 and 60 to be notified, got %v, %v", notifier.notified, err)
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cyrillicToLatin maps lowercase Cyrillic letters (Russian, Ukrainian and Belarusian) to Latin,
+// following the common passport-style romanization
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u",
+}
+
+// TransliterateToASCII converts a display name to plain ASCII for places where unicode is a problem
+// (exports, logs). Cyrillic letters are romanized, ASCII is kept as is and any other character
+// (emoji, other scripts) is dropped. Runs of whitespace collapse to a single space.
+func TransliterateToASCII(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			sb.WriteRune(' ')
+		case r < unicode.MaxASCII:
+			sb.WriteRune(r)
+		default:
+			latin, ok := cyrillicToLatin[unicode.ToLower(r)]
+			if !ok || latin == "" {
+				continue
+			}
+			if unicode.IsUpper(r) {
+				// Only the first letter is capitalized, so "Жанна" becomes "Zhanna"
+				latin = strings.ToUpper(latin[:1]) + latin[1:]
+			}
+			sb.WriteString(latin)
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestTransliterateToASCII(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Иван Петров", "Ivan Petrov"},
+		{"Жанна Щукина", "Zhanna Shchukina"},
+		{"Юлия Хрусталёва", "Yuliya Khrustaleva"},
+		{"Артём Подъячев", "Artem Podyachev"},
+		{"Олександр Їжак", "Oleksandr Yizhak"},
+		{"Alex Смит", "Alex Smit"},
+		{"John Doe", "John Doe"},
+		{"Маша 🚀  Star", "Masha Star"},
+		{"🚀", ""},
+	}
+
+	for _, tt := range tests {
+		if got := TransliterateToASCII(tt.name); got != tt.want {
+			t.Errorf("TransliterateToASCII(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
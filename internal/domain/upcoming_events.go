@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// GetUpcomingEvents returns the active events of the given groups that the user may vote on and
+// hasn't voted on yet, soonest deadline first. Events past their deadline or with voting closed
+// early are left out.
+func (em *EventManager) GetUpcomingEvents(ctx context.Context, userID int64, groupIDs []int64, now time.Time) ([]*Event, error) {
+	predictions, err := em.predictionRepo.GetUserPredictions(ctx, userID)
+	if err != nil {
+		em.logger.Error("failed to get user predictions", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	voted := make(map[int64]bool, len(predictions))
+	for _, prediction := range predictions {
+		voted[prediction.EventID] = true
+	}
+
+	var upcoming []*Event
+	for _, groupID := range groupIDs {
+		events, err := em.GetVisibleActiveEvents(ctx, groupID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			if voted[event.ID] || !event.IsVotingOpen(now) {
+				continue
+			}
+			upcoming = append(upcoming, event)
+		}
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		if !upcoming[i].Deadline.Equal(upcoming[j].Deadline) {
+			return upcoming[i].Deadline.Before(upcoming[j].Deadline)
+		}
+		return upcoming[i].ID < upcoming[j].ID
+	})
+
+	return upcoming, nil
+}
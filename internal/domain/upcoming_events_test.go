@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEventManager_GetUpcomingEvents(t *testing.T) {
+	now := time.Now()
+	userID := int64(7)
+	closedAt := now.Add(-time.Hour)
+
+	events := []*Event{
+		{ID: 1, GroupID: 1, Question: "later", Deadline: now.Add(48 * time.Hour), Status: EventStatusActive},
+		{ID: 2, GroupID: 1, Question: "voted", Deadline: now.Add(time.Hour), Status: EventStatusActive},
+		{ID: 3, GroupID: 1, Question: "soon", Deadline: now.Add(2 * time.Hour), Status: EventStatusActive},
+		{ID: 4, GroupID: 1, Question: "expired", Deadline: now.Add(-time.Minute), Status: EventStatusActive},
+		{ID: 5, GroupID: 1, Question: "closed", Deadline: now.Add(3 * time.Hour), Status: EventStatusActive, VotingClosedAt: &closedAt},
+		{ID: 6, GroupID: 1, Question: "resolved", Deadline: now.Add(time.Hour), Status: EventStatusResolved},
+		{ID: 7, GroupID: 1, Question: "same deadline", Deadline: now.Add(48 * time.Hour), Status: EventStatusActive},
+	}
+	predictions := []*Prediction{{ID: 1, EventID: 2, UserID: userID}}
+
+	em := NewEventManager(&MockEventRepoWithEvents{events: events}, &MockPredictionRepoWithData{predictions: predictions}, nil, &MockLogger{})
+
+	upcoming, err := em.GetUpcomingEvents(context.Background(), userID, []int64{1}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []int64{3, 1, 7}
+	if len(upcoming) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(upcoming))
+	}
+	for i, id := range want {
+		if upcoming[i].ID != id {
+			t.Errorf("position %d: expected event %d, got %d", i, id, upcoming[i].ID)
+		}
+	}
+
+	empty, err := em.GetUpcomingEvents(context.Background(), userID, nil, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("expected no events without groups, got %d", len(empty))
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// UserProfile holds the last known Telegram identity of a user
+type UserProfile struct {
+	UserID      int64
+	Username    string
+	FirstName   string
+	LastName    string
+	UpdatedAt   time.Time
+	FirstSeenAt time.Time // When the bot first saw the user
+}
+
+// DisplayName returns @username, falling back to the full name.
+// Returns an empty string if neither is known.
+func (p *UserProfile) DisplayName() string {
+	if p.Username != "" {
+		return "@" + strings.TrimPrefix(p.Username, "@")
+	}
+	return strings.TrimSpace(p.FirstName + " " + p.LastName)
+}
+
+// RatingName returns the name stored with the user's ratings: the username without "@",
+// falling back to the full name. Returns an empty string if neither is known.
+func (p *UserProfile) RatingName() string {
+	if p.Username != "" {
+		return strings.TrimPrefix(p.Username, "@")
+	}
+	return strings.TrimSpace(p.FirstName + " " + p.LastName)
+}
+
+// UserRepository interface for user profile operations
+type UserRepository interface {
+	UpsertUserProfile(ctx context.Context, userID int64, username, firstName, lastName string) error
+	GetUserProfile(ctx context.Context, userID int64) (*UserProfile, error)
+	GetUserProfileByUsername(ctx context.Context, username string) (*UserProfile, error)
+}
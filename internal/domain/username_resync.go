@@ -0,0 +1,48 @@
+package domain
+
+import "context"
+
+// UsernameResyncResult summarizes a resync of the names stored with a group's ratings
+type UsernameResyncResult struct {
+	Members int // Active members checked
+	Updated int // Members whose stored name changed
+	Unseen  int // Members without a cached profile, left as-is
+}
+
+// ResyncGroupUsernames refreshes the names stored with the ratings of a group's active members
+// from the user profile cache. The bot can't look up arbitrary users, so the latest seen profile
+// is used and members the bot has never seen keep their stored names.
+func ResyncGroupUsernames(ctx context.Context, membershipRepo GroupMembershipRepository, userRepo UserRepository,
+	ratingRepo RatingRepository, groupID int64) (*UsernameResyncResult, error) {
+	members, err := membershipRepo.GetGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UsernameResyncResult{}
+	for _, member := range members {
+		if member.Status != MembershipStatusActive {
+			continue
+		}
+		result.Members++
+
+		profile, err := userRepo.GetUserProfile(ctx, member.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil || profile.RatingName() == "" {
+			result.Unseen++
+			continue
+		}
+
+		updated, err := ratingRepo.UpdateUsername(ctx, member.UserID, groupID, profile.RatingName())
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+// mockMembershipRepoForResync returns fixed members of a group
+type mockMembershipRepoForResync struct {
+	mockGroupMembershipRepoForPermissions
+	members []*GroupMembership
+}
+
+func (m *mockMembershipRepoForResync) GetGroupMembers(ctx context.Context, groupID int64) ([]*GroupMembership, error) {
+	return m.members, nil
+}
+
+// mockUserRepoForResync keeps user profiles in memory
+type mockUserRepoForResync struct {
+	profiles map[int64]*UserProfile
+}
+
+func (m *mockUserRepoForResync) UpsertUserProfile(ctx context.Context, userID int64, username, firstName, lastName string) error {
+	m.profiles[userID] = &UserProfile{UserID: userID, Username: username, FirstName: firstName, LastName: lastName}
+	return nil
+}
+
+func (m *mockUserRepoForResync) GetUserProfile(ctx context.Context, userID int64) (*UserProfile, error) {
+	return m.profiles[userID], nil
+}
+
+func (m *mockUserRepoForResync) GetUserProfileByUsername(ctx context.Context, username string) (*UserProfile, error) {
+	return nil, nil
+}
+
+func TestResyncGroupUsernames(t *testing.T) {
+	const groupID = 10
+	ratingRepo := &mockRatingRepoStore{ratings: map[[2]int64]*Rating{
+		{1, groupID}: {UserID: 1, GroupID: groupID, Username: "old_alice", Score: 30},
+		{2, groupID}: {UserID: 2, GroupID: groupID, Username: "bob"},
+		{3, groupID}: {UserID: 3, GroupID: groupID, Username: "Carol"},
+		{4, groupID}: {UserID: 4, GroupID: groupID, Username: "dave"},
+		{5, groupID}: {UserID: 5, GroupID: groupID, Username: "eve"},
+	}}
+	membershipRepo := &mockMembershipRepoForResync{members: []*GroupMembership{
+		{GroupID: groupID, UserID: 1, Status: MembershipStatusActive},
+		{GroupID: groupID, UserID: 2, Status: MembershipStatusActive},
+		{GroupID: groupID, UserID: 3, Status: MembershipStatusActive},
+		{GroupID: groupID, UserID: 4, Status: MembershipStatusActive},
+		{GroupID: groupID, UserID: 5, Status: MembershipStatusRemoved},
+		{GroupID: groupID, UserID: 6, Status: MembershipStatusActive},
+	}}
+	userRepo := &mockUserRepoForResync{profiles: map[int64]*UserProfile{
+		1: {UserID: 1, Username: "alice"},
+		2: {UserID: 2, Username: "bob"},
+		3: {UserID: 3, FirstName: "Carol", LastName: "Smith"},
+		// 4 has never been seen by the bot
+		5: {UserID: 5, Username: "eve_new"},
+		6: {UserID: 6, Username: "frank"},
+	}}
+
+	result, err := ResyncGroupUsernames(context.Background(), membershipRepo, userRepo, ratingRepo, groupID)
+	if err != nil {
+		t.Fatalf("ResyncGroupUsernames failed: %v", err)
+	}
+
+	if result.Members != 5 || result.Updated != 2 || result.Unseen != 1 {
+		t.Errorf("expected 5 members, 2 updated, 1 unseen, got %+v", result)
+	}
+
+	want := map[int64]string{1: "alice", 2: "bob", 3: "Carol Smith", 4: "dave", 5: "eve"}
+	for userID, name := range want {
+		if got := ratingRepo.ratings[[2]int64{userID, groupID}].Username; got != name {
+			t.Errorf("user %d: expected name %q, got %q", userID, name, got)
+		}
+	}
+	if ratingRepo.ratings[[2]int64{1, groupID}].Score != 30 {
+		t.Error("expected the score to be kept")
+	}
+	if _, ok := ratingRepo.ratings[[2]int64{6, groupID}]; ok {
+		t.Error("expected no rating to be created for a member without one")
+	}
+}
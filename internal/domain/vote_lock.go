@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidLockVotesAt is returned when the votes lock time is not between now and the deadline
+var ErrInvalidLockVotesAt = errors.New("votes lock time must be between now and the deadline")
+
+// ValidateLockVotesAt checks that votes lock after now and before the deadline
+func ValidateLockVotesAt(lockVotesAt, now, deadline time.Time) error {
+	if !lockVotesAt.After(now) || !lockVotesAt.Before(deadline) {
+		return ErrInvalidLockVotesAt
+	}
+	return nil
+}
+
+// VotingEndsAt returns when votes stop being accepted: the votes lock time if set, the deadline otherwise.
+// Voting closed early by a manager is not taken into account.
+func (e *Event) VotingEndsAt() time.Time {
+	if e.LockVotesAt != nil {
+		return *e.LockVotesAt
+	}
+	return e.Deadline
+}
+
+// VotesLocked reports whether the votes of the event were locked before the deadline at the given time.
+// The event stays open until the deadline, but votes can't be cast or changed any more.
+func (e *Event) VotesLocked(now time.Time) bool {
+	return e.LockVotesAt != nil && !now.Before(*e.LockVotesAt)
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateLockVotesAt(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	deadline := now.Add(48 * time.Hour)
+
+	tests := []struct {
+		name   string
+		lockAt time.Time
+		err    error
+	}{
+		{"between now and the deadline", now.Add(24 * time.Hour), nil},
+		{"in the past", now.Add(-time.Minute), ErrInvalidLockVotesAt},
+		{"now", now, ErrInvalidLockVotesAt},
+		{"at the deadline", deadline, ErrInvalidLockVotesAt},
+		{"after the deadline", deadline.Add(time.Hour), ErrInvalidLockVotesAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateLockVotesAt(tt.lockAt, now, deadline); !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestEventVotesLock(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	lockAt := now.Add(time.Hour)
+	event := &Event{Status: EventStatusActive, Deadline: now.Add(24 * time.Hour)}
+
+	// Without a lock votes are accepted until the deadline, as before
+	if event.VotesLocked(now.Add(23*time.Hour)) || !event.IsVotingOpen(now.Add(23*time.Hour)) {
+		t.Error("expected voting to stay open until the deadline without a lock")
+	}
+	if !event.VotingEndsAt().Equal(event.Deadline) {
+		t.Errorf("expected voting to end at the deadline, got %v", event.VotingEndsAt())
+	}
+
+	event.LockVotesAt = &lockAt
+	if event.VotesLocked(now) || !event.IsVotingOpen(now) {
+		t.Error("expected voting to be open before the lock")
+	}
+	if !event.VotesLocked(lockAt) || event.IsVotingOpen(lockAt) {
+		t.Error("expected votes to be locked from the lock time")
+	}
+	if !event.VotingEndsAt().Equal(lockAt) {
+		t.Errorf("expected voting to end at the lock, got %v", event.VotingEndsAt())
+	}
+}
+
+func TestEventValidateLockVotesAt(t *testing.T) {
+	now := time.Now()
+	lockAt := now.Add(24 * time.Hour)
+	event := &Event{
+		GroupID:   1,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: now,
+		Deadline:  now.Add(24 * time.Hour),
+		Status:    EventStatusActive,
+		EventType: EventTypeBinary,
+		CreatedBy: 1,
+	}
+
+	event.LockVotesAt = &lockAt
+	if err := event.Validate(); !errors.Is(err, ErrInvalidLockVotesAt) {
+		t.Errorf("expected a lock at the deadline to be rejected, got %v", err)
+	}
+
+	lockAt = now.Add(time.Hour)
+	if err := event.Validate(); err != nil {
+		t.Errorf("expected a lock before the deadline to be valid, got %v", err)
+	}
+}
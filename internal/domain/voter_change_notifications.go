@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/go-telegram/bot"
+)
+
+const (
+	// voterChangeNotificationBatch is the number of change notifications sent before pausing
+	voterChangeNotificationBatch = 20
+	// voterChangeNotificationPause is the pause between batches of change notifications
+	voterChangeNotificationPause = time.Second
+)
+
+// NotifyVotersOfChange tells everyone with a stored prediction on the event what changed in it,
+// pausing after every batch of messages to stay below Telegram's broadcast limits.
+// Voters who turned direct messages off with /notifications are skipped, failed sends are only
+// logged. Returns the number of notified voters.
+func (ns *NotificationService) NotifyVotersOfChange(ctx context.Context, eventID int64, changeDescription string) (int, error) {
+	return ns.notifyVotersOfChange(ctx, eventID, changeDescription, time.Sleep)
+}
+
+func (ns *NotificationService) notifyVotersOfChange(ctx context.Context, eventID int64, changeDescription string, sleep func(time.Duration)) (int, error) {
+	if changeDescription == "" {
+		return 0, nil
+	}
+
+	event, err := ns.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		ns.logger.Error("failed to get event for change notification", "event_id", eventID, "error", err)
+		return 0, err
+	}
+
+	predictions, err := ns.predictionRepo.GetPredictionsByEvent(ctx, eventID)
+	if err != nil {
+		ns.logger.Error("failed to get predictions for change notification", "event_id", eventID, "error", err)
+		return 0, err
+	}
+
+	text := ns.localizer.MustLocalizeWithTemplate(locale.VoterChangeNotification, event.Question, changeDescription)
+
+	sent, attempted := 0, 0
+	for _, pred := range predictions {
+		if ns.settingsRepo != nil {
+			mode, err := ns.settingsRepo.GetOutcomeNotificationMode(ctx, pred.UserID)
+			if err != nil {
+				ns.logger.Error("failed to get notification mode", "user_id", pred.UserID, "error", err)
+				continue
+			}
+			if mode == OutcomeNotificationsOff {
+				continue
+			}
+		}
+
+		if attempted > 0 && attempted%voterChangeNotificationBatch == 0 {
+			sleep(voterChangeNotificationPause)
+		}
+		attempted++
+
+		err := ns.sendDirect(ctx, pred.UserID, event.GroupID, func() error {
+			_, err := ns.bot.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: pred.UserID,
+				Text:   text,
+			})
+			return err
+		})
+		if err != nil {
+			ns.logger.Warn("failed to send change notification", "user_id", pred.UserID, "event_id", eventID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	ns.logger.Info("voters notified about event change", "event_id", eventID, "count", sent)
+	return sent, nil
+}
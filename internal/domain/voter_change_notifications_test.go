@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/locale"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// blockingNotificationBot fails to send to users who blocked the bot
+type blockingNotificationBot struct {
+	MockNotificationBot
+	blocked map[int64]bool
+}
+
+func (m *blockingNotificationBot) SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error) {
+	if m.blocked[params.ChatID.(int64)] {
+		return nil, errors.New("Forbidden: bot was blocked by the user")
+	}
+	return m.MockNotificationBot.SendMessage(ctx, params)
+}
+
+func TestNotifyVotersOfChange(t *testing.T) {
+	ctx := context.Background()
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	event := &Event{ID: 10, GroupID: 1, Question: "Will it rain?", Options: []string{"Yes", "No"}, Status: EventStatusActive}
+	predictionRepo := &MockPredictionRepoWithData{predictions: []*Prediction{
+		{EventID: 10, UserID: 1, Option: 0},
+		{EventID: 10, UserID: 2, Option: 1},
+		{EventID: 10, UserID: 3, Option: 0},
+		{EventID: 10, UserID: 4, Option: 1},
+	}}
+	settingsRepo := &mockNotificationSettingsRepo{
+		modes:      map[int64]OutcomeNotificationMode{2: OutcomeNotificationsOff, 3: OutcomeNotificationsDigest},
+		lastDigest: map[int64]time.Time{},
+	}
+
+	mockBot := &blockingNotificationBot{blocked: map[int64]bool{4: true}}
+	ns := NewNotificationService(mockBot, &MockEventRepoWithData{event: event}, predictionRepo, nil, nil, &mockLogger{}, localizer)
+	ns.SetOutcomeNotifications(settingsRepo, &mockResolvedOutcomeRepo{})
+
+	// Nothing material changed
+	sent, err := ns.NotifyVotersOfChange(ctx, event.ID, "")
+	if err != nil || sent != 0 || len(mockBot.sentMessages) != 0 {
+		t.Fatalf("expected no notifications without changes, got %d (%v)", sent, err)
+	}
+
+	// Muted user 2 is skipped, the failed send to user 4 doesn't stop the others
+	sent, err = ns.NotifyVotersOfChange(ctx, event.ID, "Deadline: 01.05.2026 12:00 → 08.05.2026 12:00")
+	if err != nil {
+		t.Fatalf("NotifyVotersOfChange failed: %v", err)
+	}
+	if sent != 2 || len(mockBot.sentMessages) != 2 {
+		t.Fatalf("expected 2 notifications, got %d: %+v", sent, mockBot.sentMessages)
+	}
+	for i, userID := range []int64{1, 3} {
+		msg := mockBot.sentMessages[i]
+		if msg.ChatID != userID {
+			t.Errorf("expected notification %d to user %d, got user %d", i, userID, msg.ChatID)
+		}
+		if !strings.Contains(msg.Text, "Will it rain?") || !strings.Contains(msg.Text, "08.05.2026 12:00") {
+			t.Errorf("expected the question and the change in the notification, got %q", msg.Text)
+		}
+	}
+}
+
+func TestNotifyVotersOfChange_Batches(t *testing.T) {
+	ctx := context.Background()
+	localizer, err := locale.NewLocalizer(ctx, locale.NewLocale(locale.En))
+	if err != nil {
+		t.Fatalf("failed to create localizer: %v", err)
+	}
+
+	event := &Event{ID: 10, GroupID: 1, Question: "Will it rain?", Options: []string{"Yes", "No"}, Status: EventStatusActive}
+	var predictions []*Prediction
+	for userID := int64(1); userID <= 45; userID++ {
+		predictions = append(predictions, &Prediction{EventID: 10, UserID: userID, Option: 0})
+	}
+
+	mockBot := &MockNotificationBot{}
+	ns := NewNotificationService(mockBot, &MockEventRepoWithData{event: event}, &MockPredictionRepoWithData{predictions: predictions}, nil, nil, &mockLogger{}, localizer)
+
+	var pauses []time.Duration
+	sent, err := ns.notifyVotersOfChange(ctx, event.ID, "Deadline: 01.05.2026 12:00 → 08.05.2026 12:00", func(d time.Duration) {
+		pauses = append(pauses, d)
+	})
+	if err != nil {
+		t.Fatalf("notifyVotersOfChange failed: %v", err)
+	}
+
+	// 45 messages are sent in batches of 20, with a pause before the second and the third batch
+	if sent != 45 || len(mockBot.sentMessages) != 45 {
+		t.Errorf("expected 45 notifications, got %d", sent)
+	}
+	if len(pauses) != 2 || pauses[0] != voterChangeNotificationPause {
+		t.Errorf("expected 2 pauses of %v, got %v", voterChangeNotificationPause, pauses)
+	}
+}
@@ -0,0 +1,23 @@
+package locale
+
+import (
+	"sort"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// SortByName sorts items alphabetically by the names returned by name, using the collation rules
+// of lang (e.g. Cyrillic letters in Russian alphabet order with "ё" next to "е") and ignoring case.
+// Items with equal names keep their order; an unknown language sorts by the root collation.
+func SortByName[T any](items []T, lang string, name func(T) string) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		tag = language.Und
+	}
+	c := collate.New(tag, collate.IgnoreCase)
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return c.CompareString(name(items[i]), name(items[j])) < 0
+	})
+}
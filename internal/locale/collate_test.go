@@ -0,0 +1,64 @@
+package locale
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		input    []string
+		expected []string
+	}{
+		{
+			// Byte order would put "ё" after "я"
+			name:     "cyrillic alphabet order",
+			lang:     Ru,
+			input:    []string{"Яна", "ёлка", "Борис", "Ежов", "жора", "Анна", "Эмма"},
+			expected: []string{"Анна", "Борис", "Ежов", "ёлка", "жора", "Эмма", "Яна"},
+		},
+		{
+			// "ё" sorts as "е", so the next letters decide
+			name:     "yo sorts with ye",
+			lang:     Ru,
+			input:    []string{"Елена", "ёжик"},
+			expected: []string{"ёжик", "Елена"},
+		},
+		{
+			name:     "case is ignored",
+			lang:     Ru,
+			input:    []string{"вера", "Алла", "Вадим", "аркадий"},
+			expected: []string{"Алла", "аркадий", "Вадим", "вера"},
+		},
+		{
+			name:     "latin before cyrillic",
+			lang:     Ru,
+			input:    []string{"Мария", "alice", "Bob"},
+			expected: []string{"alice", "Bob", "Мария"},
+		},
+		{
+			name:     "english with accents",
+			lang:     En,
+			input:    []string{"Zoe", "Émile", "adam", "Eva"},
+			expected: []string{"adam", "Émile", "Eva", "Zoe"},
+		},
+		{
+			name:     "equal names keep their order",
+			lang:     En,
+			input:    []string{"bob", "Bob", "alice"},
+			expected: []string{"alice", "bob", "Bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := append([]string(nil), tt.input...)
+			SortByName(got, tt.lang, func(s string) string { return s })
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SortByName(%v, %s) = %v; want %v", tt.input, tt.lang, got, tt.expected)
+			}
+		})
+	}
+}
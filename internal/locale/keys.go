@@ -17,21 +17,37 @@ const (
 	HelpAdminCommandsSection = "HelpAdminCommandsSection"
 
 	// User commands
-	HelpCommandHelp   = "HelpCommandHelp"
-	HelpCommandRating = "HelpCommandRating"
-	HelpCommandMy     = "HelpCommandMy"
-	HelpCommandEvents = "HelpCommandEvents"
-	HelpCommandGroups = "HelpCommandGroups"
+	HelpCommandHelp          = "HelpCommandHelp"
+	HelpCommandRating        = "HelpCommandRating"
+	HelpCommandStreaks       = "HelpCommandStreaks"
+	HelpCommandSeasonHistory = "HelpCommandSeasonHistory"
+	HelpCommandCalibration   = "HelpCommandCalibration"
+	HelpCommandMy            = "HelpCommandMy"
+	HelpCommandEvents        = "HelpCommandEvents"
+	HelpCommandGroups        = "HelpCommandGroups"
 
 	// Admin commands
-	HelpCommandCreateGroup  = "HelpCommandCreateGroup"
-	HelpCommandListGroups   = "HelpCommandListGroups"
-	HelpCommandGroupMembers = "HelpCommandGroupMembers"
-	HelpCommandRemoveMember = "HelpCommandRemoveMember"
-	HelpCommandCreateEvent  = "HelpCommandCreateEvent"
-	HelpCommandResolveEvent = "HelpCommandResolveEvent"
-	HelpCommandEditEvent    = "HelpCommandEditEvent"
-	HelpListGroupsHint      = "HelpListGroupsHint"
+	HelpCommandCreateGroup           = "HelpCommandCreateGroup"
+	HelpCommandListGroups            = "HelpCommandListGroups"
+	HelpCommandGroupMembers          = "HelpCommandGroupMembers"
+	HelpCommandRemoveMember          = "HelpCommandRemoveMember"
+	HelpCommandCreateEvent           = "HelpCommandCreateEvent"
+	HelpCommandResolveEvent          = "HelpCommandResolveEvent"
+	HelpCommandEditEvent             = "HelpCommandEditEvent"
+	HelpCommandArchive               = "HelpCommandArchive"
+	HelpCommandGroupStats            = "HelpCommandGroupStats"
+	HelpCommandPinPolls              = "HelpCommandPinPolls"
+	HelpCommandDefaultEventType      = "HelpCommandDefaultEventType"
+	HelpCommandRequireRules          = "HelpCommandRequireRules"
+	HelpCommandAutoRemoveInactive    = "HelpCommandAutoRemoveInactive"
+	HelpCommandReputationWeighting   = "HelpCommandReputationWeighting"
+	HelpCommandRequireApproval       = "HelpCommandRequireApproval"
+	HelpCommandFirstVoteFinal        = "HelpCommandFirstVoteFinal"
+	HelpCommandExcludeCreatorScoring = "HelpCommandExcludeCreatorScoring"
+	HelpCommandAchievementSettings   = "HelpCommandAchievementSettings"
+	HelpCommandImportPredictions     = "HelpCommandImportPredictions"
+	HelpCommandMaintenance           = "HelpCommandMaintenance"
+	HelpListGroupsHint               = "HelpListGroupsHint"
 
 	// Rules and scoring
 	HelpScoringRulesTitle      = "HelpScoringRulesTitle"
@@ -53,6 +69,7 @@ const (
 	HelpAchievementRiskTaker     = "HelpAchievementRiskTaker"
 	HelpAchievementWeeklyAnalyst = "HelpAchievementWeeklyAnalyst"
 	HelpAchievementVeteran       = "HelpAchievementVeteran"
+	HelpAchievementGlobeTrotter  = "HelpAchievementGlobeTrotter"
 
 	// Event types
 	HelpEventTypesTitle       = "HelpEventTypesTitle"
@@ -99,9 +116,12 @@ const (
 	EventCreationDeadlineSaved           = "EventCreationDeadlineSaved"
 
 	// Event creation success
-	EventCreationSuccess       = "EventCreationSuccess"
-	EventCreationPollPublished = "EventCreationPollPublished"
-	EventCreationPollReference = "EventCreationPollReference"
+	EventCreationSuccess          = "EventCreationSuccess"
+	EventCreationPollPublished    = "EventCreationPollPublished"
+	EventCreationPollReference    = "EventCreationPollReference"
+	EventCreationSummarySentToDM  = "EventCreationSummarySentToDM"
+	EventCreationDuplicateWarning = "EventCreationDuplicateWarning"
+	EventCreationDuplicateCreate  = "EventCreationDuplicateCreate"
 
 	// Event creation errors
 	EventCreationErrorInvalidQuestion       = "EventCreationErrorInvalidQuestion"
@@ -152,13 +172,86 @@ const (
 	PollSettingAllowsRevoting  = "PollSettingAllowsRevoting"
 	PollSettingShuffleOptions  = "PollSettingShuffleOptions"
 	PollSettingHideResults     = "PollSettingHideResults"
+	PollSettingQuiz            = "PollSettingQuiz"
 	PollSettingDone            = "PollSettingDone"
 	EventSummaryPollSettings   = "EventSummaryPollSettings"
 	EventSummaryAllowsRevoting = "EventSummaryAllowsRevoting"
 	EventSummaryShuffleOptions = "EventSummaryShuffleOptions"
 	EventSummaryHideResults    = "EventSummaryHideResults"
+	EventSummaryQuiz           = "EventSummaryQuiz"
+	EventQuizAnswerPrompt      = "EventQuizAnswerPrompt"
 	EventSummaryAutoClose      = "EventSummaryAutoClose"
 
+	// Event participants
+	ParticipantsTitle          = "ParticipantsTitle"
+	ParticipantsEveryone       = "ParticipantsEveryone"
+	ParticipantsSelectedCount  = "ParticipantsSelectedCount"
+	ParticipantsButtonEveryone = "ParticipantsButtonEveryone"
+	ParticipantsButtonDone     = "ParticipantsButtonDone"
+	ParticipantsButtonPrev     = "ParticipantsButtonPrev"
+	ParticipantsButtonNext     = "ParticipantsButtonNext"
+	ParticipantsErrorMembers   = "ParticipantsErrorMembers"
+	EventSummaryParticipants   = "EventSummaryParticipants"
+
+	// Event reminders
+	EventRemindersPrompt         = "EventRemindersPrompt"
+	EventRemindersButtonDefault  = "EventRemindersButtonDefault"
+	EventRemindersButtonPreset   = "EventRemindersButtonPreset"
+	EventRemindersErrorInvalid   = "EventRemindersErrorInvalid"
+	EventRemindersErrorTooLate   = "EventRemindersErrorTooLate"
+	EventSummaryReminders        = "EventSummaryReminders"
+	EventSummaryRemindersDefault = "EventSummaryRemindersDefault"
+	EventSummaryRemindersCustom  = "EventSummaryRemindersCustom"
+
+	// Event votes lock
+	EventLockVotesPrompt       = "EventLockVotesPrompt"
+	EventLockVotesButtonNone   = "EventLockVotesButtonNone"
+	EventLockVotesButtonPreset = "EventLockVotesButtonPreset"
+	EventLockVotesErrorFormat  = "EventLockVotesErrorFormat"
+	EventLockVotesErrorRange   = "EventLockVotesErrorRange"
+	EventSummaryLockVotes      = "EventSummaryLockVotes"
+	EventSummaryLockVotesNone  = "EventSummaryLockVotesNone"
+	VotesLockedRejected        = "VotesLockedRejected"
+
+	// Event photo
+	EventPhotoPrompt          = "EventPhotoPrompt"
+	EventPhotoButtonSkip      = "EventPhotoButtonSkip"
+	EventPhotoErrorNotPhoto   = "EventPhotoErrorNotPhoto"
+	EventSummaryPhoto         = "EventSummaryPhoto"
+	EventSummaryPhotoAttached = "EventSummaryPhotoAttached"
+	EventSummaryPhotoNone     = "EventSummaryPhotoNone"
+
+	// Event preview before confirmation
+	EventPreviewTitle            = "EventPreviewTitle"
+	EventPreviewQuestion         = "EventPreviewQuestion"
+	EventPreviewOption           = "EventPreviewOption"
+	EventPreviewDeadline         = "EventPreviewDeadline"
+	EventPreviewLockVotes        = "EventPreviewLockVotes"
+	EventPreviewRevoting         = "EventPreviewRevoting"
+	EventPreviewNoRevoting       = "EventPreviewNoRevoting"
+	EventPreviewShuffled         = "EventPreviewShuffled"
+	EventPreviewResultsHidden    = "EventPreviewResultsHidden"
+	EventPreviewQuiz             = "EventPreviewQuiz"
+	EventPreviewEditQuestion     = "EventPreviewEditQuestion"
+	EventPreviewEditType         = "EventPreviewEditType"
+	EventPreviewEditOptions      = "EventPreviewEditOptions"
+	EventPreviewEditDeadline     = "EventPreviewEditDeadline"
+	EventPreviewEditReminders    = "EventPreviewEditReminders"
+	EventPreviewEditLockVotes    = "EventPreviewEditLockVotes"
+	EventPreviewEditPhoto        = "EventPreviewEditPhoto"
+	EventPreviewEditSettings     = "EventPreviewEditSettings"
+	EventPreviewEditParticipants = "EventPreviewEditParticipants"
+	EventPreviewReorderOptions   = "EventPreviewReorderOptions"
+
+	// Reordering options before publishing
+	EventReorderTitle    = "EventReorderTitle"
+	EventReorderMoveUp   = "EventReorderMoveUp"
+	EventReorderMoveDown = "EventReorderMoveDown"
+	EventReorderDone     = "EventReorderDone"
+
+	// Discussion link on published polls
+	EventDiscussButton = "EventDiscussButton"
+
 	// Final event summary
 	EventFinalSummaryTitle = "EventFinalSummaryTitle"
 	EventFinalSummaryID    = "EventFinalSummaryID"
@@ -171,13 +264,19 @@ const (
 	EventCreationCancelled = "EventCreationCancelled"
 
 	// Event creation system errors
-	EventCreationErrorGeneric     = "EventCreationErrorGeneric"
-	EventCreationErrorGroupInfo   = "EventCreationErrorGroupInfo"
-	EventCreationErrorPollPublish = "EventCreationErrorPollPublish"
+	EventCreationErrorGeneric        = "EventCreationErrorGeneric"
+	EventCreationErrorGroupInfo      = "EventCreationErrorGroupInfo"
+	EventCreationErrorPollPublish    = "EventCreationErrorPollPublish"
+	EventCreationErrorPollPermission = "EventCreationErrorPollPermission"
 
 	// Action buttons
-	ActionButtonEdit    = "ActionButtonEdit"
-	ActionButtonResolve = "ActionButtonResolve"
+	ActionButtonEdit         = "ActionButtonEdit"
+	ActionButtonResolve      = "ActionButtonResolve"
+	ActionButtonCloseVoting  = "ActionButtonCloseVoting"
+	CloseVotingSuccess       = "CloseVotingSuccess"
+	CloseVotingAlreadyClosed = "CloseVotingAlreadyClosed"
+	CloseVotingNotActive     = "CloseVotingNotActive"
+	CloseVotingError         = "CloseVotingError"
 
 	// Achievement notification (in group context)
 	AchievementNotificationUser  = "AchievementNotificationUser"
@@ -189,11 +288,21 @@ const (
 	AchievementMasterOrganizerName = "AchievementMasterOrganizerName"
 
 	// Deadline error messages
-	EventCreationErrorDeadlineFormat = "EventCreationErrorDeadlineFormat"
-	EventCreationErrorDeadlinePast   = "EventCreationErrorDeadlinePast"
-
-	// Options count validation
-	EventCreationErrorOptionsCount = "EventCreationErrorOptionsCount"
+	EventCreationErrorDeadlineFormat  = "EventCreationErrorDeadlineFormat"
+	EventCreationErrorDeadlinePast    = "EventCreationErrorDeadlinePast"
+	EventCreationErrorDeadlineTooSoon = "EventCreationErrorDeadlineTooSoon"
+	EventCreationErrorDeadlineTooFar  = "EventCreationErrorDeadlineTooFar"
+
+	// Options validation
+	EventCreationErrorOptionsCount    = "EventCreationErrorOptionsCount"
+	EventCreationErrorEmptyOption     = "EventCreationErrorEmptyOption"
+	EventCreationErrorDuplicateOption = "EventCreationErrorDuplicateOption"
+	EventCreationErrorOptionTooLong   = "EventCreationErrorOptionTooLong"
+
+	// Question validation
+	EventCreationErrorQuestionTooShort = "EventCreationErrorQuestionTooShort"
+	EventCreationErrorQuestionTooLong  = "EventCreationErrorQuestionTooLong"
+	EventCreationErrorQuestionRejected = "EventCreationErrorQuestionRejected"
 
 	// Default options for event types
 	EventOptionYes = "EventOptionYes"
@@ -231,6 +340,7 @@ const (
 	EventResolutionErrorUnauthorized         = "EventResolutionErrorUnauthorized"
 	EventResolutionErrorGetEvent             = "EventResolutionErrorGetEvent"
 	EventResolutionErrorResolve              = "EventResolutionErrorResolve"
+	EventResolutionErrorAlreadyResolved      = "EventResolutionErrorAlreadyResolved"
 	EventResolutionAchievementNotification   = "EventResolutionAchievementNotification"
 
 	// ============================================================================
@@ -255,6 +365,7 @@ const (
 	// Group creation success
 	GroupCreationSuccess                = "GroupCreationSuccess"
 	GroupCreationSuccessExisting        = "GroupCreationSuccessExisting"
+	GroupCreationSuccessRestored        = "GroupCreationSuccessRestored"
 	GroupCreationSuccessNew             = "GroupCreationSuccessNew"
 	GroupCreationSuccessDetails         = "GroupCreationSuccessDetails"
 	GroupCreationSuccessForumType       = "GroupCreationSuccessForumType"
@@ -274,6 +385,7 @@ const (
 	GroupCreationErrorValidation        = "GroupCreationErrorValidation"
 	GroupCreationErrorCreate            = "GroupCreationErrorCreate"
 	GroupCreationErrorInviteLink        = "GroupCreationErrorInviteLink"
+	GroupCreationInviteLinkPending      = "GroupCreationInviteLinkPending"
 
 	// Group creation buttons
 	GroupCreationButtonForum   = "GroupCreationButtonForum"
@@ -321,6 +433,9 @@ const (
 	EventEditErrorDeadlinePast    = "EventEditErrorDeadlinePast"
 	EventEditErrorGetEvent        = "EventEditErrorGetEvent"
 	EventEditErrorHasVotes        = "EventEditErrorHasVotes"
+	EventEditVotedNote            = "EventEditVotedNote"
+	EventEditErrorVotedOptions    = "EventEditErrorVotedOptions"
+	EventEditErrorVotedDeadline   = "EventEditErrorVotedDeadline"
 	EventEditErrorSave            = "EventEditErrorSave"
 
 	// ============================================================================
@@ -365,6 +480,7 @@ const (
 	AchievementRiskTakerName     = "AchievementRiskTakerName"
 	AchievementWeeklyAnalystName = "AchievementWeeklyAnalystName"
 	AchievementVeteranName       = "AchievementVeteranName"
+	AchievementGlobeTrotterName  = "AchievementGlobeTrotterName"
 
 	// Event results notification
 	NotificationResultsTitle         = "NotificationResultsTitle"
@@ -386,6 +502,13 @@ const (
 	NotificationEventExpiredCTA        = "NotificationEventExpiredCTA"
 	NotificationEventExpiredButtonText = "NotificationEventExpiredButtonText"
 
+	// Repeated reminder to resolve an expired event
+	NotificationResolutionNag = "NotificationResolutionNag"
+
+	// Escalation of an event that stays unresolved to all admins
+	NotificationResolutionEscalation       = "NotificationResolutionEscalation"
+	NotificationResolutionEscalationButton = "NotificationResolutionEscalationButton"
+
 	// Deadline formatting
 	DeadlineExpired     = "DeadlineExpired"
 	DeadlineDaysHours   = "DeadlineDaysHours"
@@ -477,11 +600,17 @@ const (
 	ErrorInvalidFormat  = "ErrorInvalidFormat"
 	ErrorInvalidInput   = "ErrorInvalidInput"
 	ErrorInvalidCommand = "ErrorInvalidCommand"
+	ErrorInvalidRequest = "ErrorInvalidRequest"
+
+	// State errors
+	ErrorNotFound = "ErrorNotFound"
+	ErrorConflict = "ErrorConflict"
 
 	// System errors
 	ErrorGeneric            = "ErrorGeneric"
 	ErrorDatabaseFailed     = "ErrorDatabaseFailed"
 	ErrorNotificationFailed = "ErrorNotificationFailed"
+	ErrorItemsNotLoaded     = "ErrorItemsNotLoaded"
 
 	// Session errors
 	ErrorSessionConflict = "ErrorSessionConflict"
@@ -567,26 +696,51 @@ const (
 	HelpDeadlineReminder = "HelpDeadlineReminder"
 
 	// Rating command
-	RatingTop10Title   = "RatingTop10Title"
-	RatingGroupName    = "RatingGroupName"
-	RatingMedalFirst   = "RatingMedalFirst"
-	RatingMedalSecond  = "RatingMedalSecond"
-	RatingMedalThird   = "RatingMedalThird"
-	RatingPosition     = "RatingPosition"
-	RatingUserPoints   = "RatingUserPoints"
-	RatingUserAccuracy = "RatingUserAccuracy"
-	RatingUserStreak   = "RatingUserStreak"
-	RatingUserCorrect  = "RatingUserCorrect"
-	RatingUserWrong    = "RatingUserWrong"
+	RatingTop10Title        = "RatingTop10Title"
+	RatingTopNTitle         = "RatingTopNTitle"
+	RatingErrorInvalidCount = "RatingErrorInvalidCount"
+	RatingGroupName         = "RatingGroupName"
+	RatingMedalFirst        = "RatingMedalFirst"
+	RatingMedalSecond       = "RatingMedalSecond"
+	RatingMedalThird        = "RatingMedalThird"
+	RatingPosition          = "RatingPosition"
+	RatingUserPoints        = "RatingUserPoints"
+	RatingUserAccuracy      = "RatingUserAccuracy"
+	RatingUserStreak        = "RatingUserStreak"
+	RatingUserCorrect       = "RatingUserCorrect"
+	RatingUserWrong         = "RatingUserWrong"
+	RatingButtonRefresh     = "RatingButtonRefresh"
+	RatingRefreshTooSoon    = "RatingRefreshTooSoon"
+
+	// Streaks command
+	StreaksTitle     = "StreaksTitle"
+	StreaksEmpty     = "StreaksEmpty"
+	StreaksUserEntry = "StreaksUserEntry"
+
+	// Calibration command
+	CalibrationTitle          = "CalibrationTitle"
+	CalibrationEmpty          = "CalibrationEmpty"
+	CalibrationHeader         = "CalibrationHeader"
+	CalibrationRow            = "CalibrationRow"
+	CalibrationRowEmpty       = "CalibrationRowEmpty"
+	CalibrationLowConfidence  = "CalibrationLowConfidence"
+	CalibrationTotal          = "CalibrationTotal"
+	CalibrationOverestimate   = "CalibrationOverestimate"
+	CalibrationUnderestimate  = "CalibrationUnderestimate"
+	CalibrationWellCalibrated = "CalibrationWellCalibrated"
+	CalibrationNotEnoughData  = "CalibrationNotEnoughData"
 
 	// My stats command
 	MyStatsTitle2          = "MyStatsTitle2"
 	MyStatsGroupName       = "MyStatsGroupName"
 	MyStatsPoints2         = "MyStatsPoints2"
+	MyStatsRank            = "MyStatsRank"
+	MyStatsRankNone        = "MyStatsRankNone"
 	MyStatsCorrect2        = "MyStatsCorrect2"
 	MyStatsWrong2          = "MyStatsWrong2"
 	MyStatsAccuracy2       = "MyStatsAccuracy2"
 	MyStatsCurrentStreak   = "MyStatsCurrentStreak"
+	MyStatsBestStreak      = "MyStatsBestStreak"
 	MyStatsTotalPreds      = "MyStatsTotalPreds"
 	MyStatsAchievements    = "MyStatsAchievements"
 	MyStatsNoAchievements2 = "MyStatsNoAchievements2"
@@ -599,6 +753,10 @@ const (
 	EventsItemType                 = "EventsItemType"
 	EventsItemOptions              = "EventsItemOptions"
 	EventsItemVotes                = "EventsItemVotes"
+	EventsItemOdds                 = "EventsItemOdds"
+	EventsItemOddsNone             = "EventsItemOddsNone"
+	EventsItemNoOddsYet            = "EventsItemNoOddsYet"
+	EventsItemWeightedVotes        = "EventsItemWeightedVotes"
 	EventsItemTimeRemaining        = "EventsItemTimeRemaining"
 	EventsItemTimeRemainingDays    = "EventsItemTimeRemainingDays"
 	EventsItemTimeRemainingHours   = "EventsItemTimeRemainingHours"
@@ -625,6 +783,8 @@ const (
 	DeepLinkErrorReactivate = "DeepLinkErrorReactivate"
 	DeepLinkErrorValidation = "DeepLinkErrorValidation"
 	DeepLinkErrorCreate     = "DeepLinkErrorCreate"
+	DeepLinkGroupFull       = "DeepLinkGroupFull"
+	DeepLinkUserTooNew      = "DeepLinkUserTooNew"
 
 	// Session conflict
 	SessionConflictWarning        = "SessionConflictWarning"
@@ -635,14 +795,22 @@ const (
 	SessionErrorUnknown           = "SessionErrorUnknown"
 
 	// Event creation permission
-	EventCreationPermissionDenied  = "EventCreationPermissionDenied"
-	EventCreationErrorNoGroups     = "EventCreationErrorNoGroups"
-	EventCreationErrorNoGroupsHelp = "EventCreationErrorNoGroupsHelp"
-	EventCreationErrorStart        = "EventCreationErrorStart"
+	EventCreationPermissionDenied      = "EventCreationPermissionDenied"
+	EventCreationPermissionDeniedVoted = "EventCreationPermissionDeniedVoted"
+	EventCreationErrorNoGroups         = "EventCreationErrorNoGroups"
+	EventCreationErrorNoGroupsHelp     = "EventCreationErrorNoGroupsHelp"
+	EventCreationErrorStart            = "EventCreationErrorStart"
+	EventCreationNewMemberCooldown     = "EventCreationNewMemberCooldown"
+	CooldownWaitDays                   = "CooldownWaitDays"
+	CooldownWaitHours                  = "CooldownWaitHours"
+	CooldownWaitMinutes                = "CooldownWaitMinutes"
 
 	// Event resolution
 	EventResolutionTitle2       = "EventResolutionTitle2"
 	EventResolutionSelectPrompt = "EventResolutionSelectPrompt"
+	EventResolutionPageInfo     = "EventResolutionPageInfo"
+	EventResolutionButtonPrev   = "EventResolutionButtonPrev"
+	EventResolutionButtonNext   = "EventResolutionButtonNext"
 	EventResolutionNoEvents     = "EventResolutionNoEvents"
 	EventResolutionNoPermission = "EventResolutionNoPermission"
 	EventResolutionErrorStart   = "EventResolutionErrorStart"
@@ -670,10 +838,13 @@ const (
 	ListGroupsItemTopics        = "ListGroupsItemTopics"
 	ListGroupsItemNoTopics      = "ListGroupsItemNoTopics"
 	ListGroupsItemDeleted       = "ListGroupsItemDeleted"
+	ListGroupsItemPaused        = "ListGroupsItemPaused"
 	ListGroupsButtonRenameGroup = "ListGroupsButtonRenameGroup"
 	ListGroupsButtonRenameTopic = "ListGroupsButtonRenameTopic"
 	ListGroupsButtonSoftDelete  = "ListGroupsButtonSoftDelete"
 	ListGroupsButtonRestore     = "ListGroupsButtonRestore"
+	ListGroupsButtonPause       = "ListGroupsButtonPause"
+	ListGroupsButtonResume      = "ListGroupsButtonResume"
 	ListGroupsButtonDeleteTopic = "ListGroupsButtonDeleteTopic"
 	ListGroupsErrorGet          = "ListGroupsErrorGet"
 	ListGroupsErrorSend         = "ListGroupsErrorSend"
@@ -714,6 +885,18 @@ const (
 	BotAddedUserForumInstructions = "BotAddedUserForumInstructions"
 	BotAddedUserRegisterCommand   = "BotAddedUserRegisterCommand"
 
+	// Draft groups (created automatically when the bot is added to a chat)
+	BotAddedDraftCreated     = "BotAddedDraftCreated"
+	BotAddedDraftPending     = "BotAddedDraftPending"
+	BotAddedExistingGroup    = "BotAddedExistingGroup"
+	BotAddedDeletedGroup     = "BotAddedDeletedGroup"
+	BotAddedButtonRestore    = "BotAddedButtonRestore"
+	GroupDraftButtonActivate = "GroupDraftButtonActivate"
+	GroupDraftButtonRename   = "GroupDraftButtonRename"
+	GroupDraftActivated      = "GroupDraftActivated"
+	GroupDraftNotPending     = "GroupDraftNotPending"
+	GroupDraftActivateError  = "GroupDraftActivateError"
+
 	// Leave group
 	LeaveGroupButton  = "LeaveGroupButton"
 	LeaveGroupSuccess = "LeaveGroupSuccess"
@@ -749,6 +932,19 @@ const (
 	RestoreGroupSuccess      = "RestoreGroupSuccess"
 	RestoreGroupError        = "RestoreGroupError"
 
+	// Pause/resume group
+	PauseGroupTitle           = "PauseGroupTitle"
+	PauseGroupSelectPrompt    = "PauseGroupSelectPrompt"
+	PauseGroupEmpty           = "PauseGroupEmpty"
+	PauseGroupSuccess         = "PauseGroupSuccess"
+	PauseGroupError           = "PauseGroupError"
+	ResumeGroupTitle          = "ResumeGroupTitle"
+	ResumeGroupSelectPrompt   = "ResumeGroupSelectPrompt"
+	ResumeGroupEmpty          = "ResumeGroupEmpty"
+	ResumeGroupSuccess        = "ResumeGroupSuccess"
+	GroupPausedVoteRejected   = "GroupPausedVoteRejected"
+	GroupPausedCreateRejected = "GroupPausedCreateRejected"
+
 	// Rename group
 	RenameGroupTitle        = "RenameGroupTitle"
 	RenameGroupSelectPrompt = "RenameGroupSelectPrompt"
@@ -796,7 +992,6 @@ const (
 
 	// Edit event errors
 	ErrorEditEventNoPermission = "ErrorEditEventNoPermission"
-	ErrorEditEventHasVotes     = "ErrorEditEventHasVotes"
 	ErrorEditEventStart        = "ErrorEditEventStart"
 
 	// Group creation messages
@@ -862,4 +1057,436 @@ const (
 	// Success messages with names
 	GroupDeletedSuccess = "GroupDeletedSuccess"
 	TopicDeletedSuccess = "TopicDeletedSuccess"
+
+	// Live poll stats
+	LivePollStatsTitle  = "LivePollStatsTitle"
+	LivePollStatsOption = "LivePollStatsOption"
+	LivePollStatsTotal  = "LivePollStatsTotal"
+
+	// Poll countdown
+	PollCountdownClosesIn = "PollCountdownClosesIn"
+	PollCountdownDays     = "PollCountdownDays"
+	PollCountdownHours    = "PollCountdownHours"
+	PollCountdownMinutes  = "PollCountdownMinutes"
+	PollCountdownClosed   = "PollCountdownClosed"
+
+	// Event archive
+	ArchiveSelectGroup    = "ArchiveSelectGroup"
+	ArchiveTitle          = "ArchiveTitle"
+	ArchiveEmpty          = "ArchiveEmpty"
+	ArchiveItem           = "ArchiveItem"
+	ArchiveItemDifficulty = "ArchiveItemDifficulty"
+	ArchiveButtonRestore  = "ArchiveButtonRestore"
+	ArchiveButtonPrev     = "ArchiveButtonPrev"
+	ArchiveButtonNext     = "ArchiveButtonNext"
+	ArchiveEventRestored  = "ArchiveEventRestored"
+	ArchiveErrorRestore   = "ArchiveErrorRestore"
+	ArchiveErrorLoad      = "ArchiveErrorLoad"
+
+	// Group stats
+	GroupStatsSelectGroup     = "GroupStatsSelectGroup"
+	GroupStatsTitle           = "GroupStatsTitle"
+	GroupStatsEvents          = "GroupStatsEvents"
+	GroupStatsPredictions     = "GroupStatsPredictions"
+	GroupStatsParticipants    = "GroupStatsParticipants"
+	GroupStatsAccuracy        = "GroupStatsAccuracy"
+	GroupStatsTopPredictors   = "GroupStatsTopPredictors"
+	GroupStatsNoTopPredictors = "GroupStatsNoTopPredictors"
+	GroupStatsContrarianWins  = "GroupStatsContrarianWins"
+	GroupStatsContrarianItem  = "GroupStatsContrarianItem"
+	GroupStatsErrorLoad       = "GroupStatsErrorLoad"
+
+	// Poll pinning
+	PinPollsTitle       = "PinPollsTitle"
+	PinPollsEnabled     = "PinPollsEnabled"
+	PinPollsDisabled    = "PinPollsDisabled"
+	PinPollsErrorUpdate = "PinPollsErrorUpdate"
+
+	// Default event type
+	DefaultEventTypeTitle       = "DefaultEventTypeTitle"
+	DefaultEventTypeSelect      = "DefaultEventTypeSelect"
+	DefaultEventTypeNone        = "DefaultEventTypeNone"
+	DefaultEventTypeUpdated     = "DefaultEventTypeUpdated"
+	DefaultEventTypeErrorUpdate = "DefaultEventTypeErrorUpdate"
+	EventTypeDefaultButton      = "EventTypeDefaultButton"
+	EventTypeSuggestedButton    = "EventTypeSuggestedButton"
+
+	// Probability resolution
+	EventResolutionEnterOutcome           = "EventResolutionEnterOutcome"
+	EventResolutionOutcomeHappened        = "EventResolutionOutcomeHappened"
+	EventResolutionOutcomeNotHappened     = "EventResolutionOutcomeNotHappened"
+	EventResolutionErrorInvalidOutcome    = "EventResolutionErrorInvalidOutcome"
+	NotificationResultsProbabilityOutcome = "NotificationResultsProbabilityOutcome"
+
+	// Majority confirmation on resolution
+	EventResolutionMajorityWarning = "EventResolutionMajorityWarning"
+	EventResolutionMajorityConfirm = "EventResolutionMajorityConfirm"
+	EventResolutionMajorityBack    = "EventResolutionMajorityBack"
+
+	// Typed answer on resolution
+	EventResolutionTypeAnswerButton    = "EventResolutionTypeAnswerButton"
+	EventResolutionTypeAnswerPrompt    = "EventResolutionTypeAnswerPrompt"
+	EventResolutionTypeAnswerConfirm   = "EventResolutionTypeAnswerConfirm"
+	EventResolutionTypeAnswerYes       = "EventResolutionTypeAnswerYes"
+	EventResolutionTypeAnswerAmbiguous = "EventResolutionTypeAnswerAmbiguous"
+	EventResolutionTypeAnswerNoMatch   = "EventResolutionTypeAnswerNoMatch"
+	EventResolutionTypeAnswerRetry     = "EventResolutionTypeAnswerRetry"
+
+	// Resolution note
+	EventResolutionNotePrompt         = "EventResolutionNotePrompt"
+	EventResolutionNoteInvalid        = "EventResolutionNoteInvalid"
+	NotificationResultsNote           = "NotificationResultsNote"
+	NotificationResultsContrarian     = "NotificationResultsContrarian"
+	NotificationResultsContrarianMore = "NotificationResultsContrarianMore"
+
+	// Maintenance mode
+	MaintenanceUnavailable = "MaintenanceUnavailable"
+	MaintenanceEnabled     = "MaintenanceEnabled"
+	MaintenanceDisabled    = "MaintenanceDisabled"
+	MaintenanceStatusOn    = "MaintenanceStatusOn"
+	MaintenanceStatusOff   = "MaintenanceStatusOff"
+	MaintenanceErrorUpdate = "MaintenanceErrorUpdate"
+
+	// Prediction import
+	ImportPredictionsUsage             = "ImportPredictionsUsage"
+	ImportPredictionsGroupItem         = "ImportPredictionsGroupItem"
+	ImportPredictionsErrorCaption      = "ImportPredictionsErrorCaption"
+	ImportPredictionsErrorGroup        = "ImportPredictionsErrorGroup"
+	ImportPredictionsErrorFileTooLarge = "ImportPredictionsErrorFileTooLarge"
+	ImportPredictionsErrorDownload     = "ImportPredictionsErrorDownload"
+	ImportPredictionsErrorColumns      = "ImportPredictionsErrorColumns"
+	ImportPredictionsErrorTooManyRows  = "ImportPredictionsErrorTooManyRows"
+	ImportPredictionsErrorEmpty        = "ImportPredictionsErrorEmpty"
+	ImportPredictionsErrorParse        = "ImportPredictionsErrorParse"
+	ImportPredictionsErrorImport       = "ImportPredictionsErrorImport"
+	ImportPredictionsReportTitle       = "ImportPredictionsReportTitle"
+	ImportPredictionsReportDryRun      = "ImportPredictionsReportDryRun"
+	ImportPredictionsReportStats       = "ImportPredictionsReportStats"
+	ImportPredictionsReportSkipped     = "ImportPredictionsReportSkipped"
+	ImportPredictionsReportSkippedItem = "ImportPredictionsReportSkippedItem"
+	ImportPredictionsReportMore        = "ImportPredictionsReportMore"
+	ImportSkipInvalidRow               = "ImportSkipInvalidRow"
+	ImportSkipUnknownEvent             = "ImportSkipUnknownEvent"
+	ImportSkipAmbiguousEvent           = "ImportSkipAmbiguousEvent"
+	ImportSkipUnknownUser              = "ImportSkipUnknownUser"
+	ImportSkipNotParticipant           = "ImportSkipNotParticipant"
+	ImportSkipInvalidOption            = "ImportSkipInvalidOption"
+	ImportSkipDuplicate                = "ImportSkipDuplicate"
+
+	// Feedback
+	HelpCommandFeedback     = "HelpCommandFeedback"
+	HelpCommandFeedbackList = "HelpCommandFeedbackList"
+	FeedbackUsage           = "FeedbackUsage"
+	FeedbackTooLong         = "FeedbackTooLong"
+	FeedbackRateLimited     = "FeedbackRateLimited"
+	FeedbackError           = "FeedbackError"
+	FeedbackReceived        = "FeedbackReceived"
+	NotificationFeedback    = "NotificationFeedback"
+	FeedbackListTitle       = "FeedbackListTitle"
+	FeedbackListEmpty       = "FeedbackListEmpty"
+	FeedbackListItem        = "FeedbackListItem"
+	FeedbackListError       = "FeedbackListError"
+
+	// Event ownership transfer
+	HelpCommandTransferEvent          = "HelpCommandTransferEvent"
+	TransferEventUsage                = "TransferEventUsage"
+	TransferEventNotFound             = "TransferEventNotFound"
+	TransferEventNotMember            = "TransferEventNotMember"
+	TransferEventAlreadyOwner         = "TransferEventAlreadyOwner"
+	TransferEventError                = "TransferEventError"
+	TransferEventSuccess              = "TransferEventSuccess"
+	TransferEventNewOwnerNotification = "TransferEventNewOwnerNotification"
+
+	// Group merging
+	HelpCommandMergeGroups     = "HelpCommandMergeGroups"
+	MergeGroupsUsage           = "MergeGroupsUsage"
+	MergeGroupsDuplicatesTitle = "MergeGroupsDuplicatesTitle"
+	MergeGroupsDuplicateItem   = "MergeGroupsDuplicateItem"
+	MergeGroupsNoDuplicates    = "MergeGroupsNoDuplicates"
+	MergeGroupsNotFound        = "MergeGroupsNotFound"
+	MergeGroupsSameGroup       = "MergeGroupsSameGroup"
+	MergeGroupsTargetDeleted   = "MergeGroupsTargetDeleted"
+	MergeGroupsError           = "MergeGroupsError"
+	MergeGroupsSuccess         = "MergeGroupsSuccess"
+
+	// Rating recomputation
+	HelpCommandRecompute    = "HelpCommandRecompute"
+	RecomputeUsage          = "RecomputeUsage"
+	RecomputeStarted        = "RecomputeStarted"
+	RecomputeAlreadyRunning = "RecomputeAlreadyRunning"
+	RecomputeError          = "RecomputeError"
+	RecomputeSuccess        = "RecomputeSuccess"
+
+	// Seasons
+	HelpCommandSeason         = "HelpCommandSeason"
+	SeasonUsage               = "SeasonUsage"
+	SeasonStartError          = "SeasonStartError"
+	SeasonStarted             = "SeasonStarted"
+	SeasonStartedAnnouncement = "SeasonStartedAnnouncement"
+	SeasonChampion            = "SeasonChampion"
+	SeasonNoPlayers           = "SeasonNoPlayers"
+	SeasonHistoryTitle        = "SeasonHistoryTitle"
+	SeasonHistoryItem         = "SeasonHistoryItem"
+	SeasonHistoryEmpty        = "SeasonHistoryEmpty"
+
+	// Username resync
+	HelpCommandResyncUsernames = "HelpCommandResyncUsernames"
+	ResyncUsernamesUsage       = "ResyncUsernamesUsage"
+	ResyncUsernamesError       = "ResyncUsernamesError"
+	ResyncUsernamesSuccess     = "ResyncUsernamesSuccess"
+
+	// Rules acceptance
+	RequireRulesTitle       = "RequireRulesTitle"
+	RequireRulesEnabled     = "RequireRulesEnabled"
+	RequireRulesDisabled    = "RequireRulesDisabled"
+	RequireRulesErrorUpdate = "RequireRulesErrorUpdate"
+	RulesPrompt             = "RulesPrompt"
+	RulesAcceptButton       = "RulesAcceptButton"
+	RulesAccepted           = "RulesAccepted"
+	RulesAlreadyAccepted    = "RulesAlreadyAccepted"
+	RulesVoteRejected       = "RulesVoteRejected"
+	RulesErrorAccept        = "RulesErrorAccept"
+
+	// Duels
+	HelpCommandDuel                   = "HelpCommandDuel"
+	DuelUsage                         = "DuelUsage"
+	DuelRecord                        = "DuelRecord"
+	DuelOpponentUnknown               = "DuelOpponentUnknown"
+	DuelErrorSelf                     = "DuelErrorSelf"
+	DuelErrorQuestion                 = "DuelErrorQuestion"
+	DuelErrorDuration                 = "DuelErrorDuration"
+	DuelError                         = "DuelError"
+	DuelPickPrompt                    = "DuelPickPrompt"
+	DuelButtonYes                     = "DuelButtonYes"
+	DuelButtonNo                      = "DuelButtonNo"
+	DuelChallengeSent                 = "DuelChallengeSent"
+	DuelInvitation                    = "DuelInvitation"
+	DuelInvitationFailed              = "DuelInvitationFailed"
+	DuelButtonAcceptYes               = "DuelButtonAcceptYes"
+	DuelButtonAcceptNo                = "DuelButtonAcceptNo"
+	DuelButtonDecline                 = "DuelButtonDecline"
+	DuelAccepted                      = "DuelAccepted"
+	DuelButtonReportYes               = "DuelButtonReportYes"
+	DuelButtonReportNo                = "DuelButtonReportNo"
+	DuelDeclined                      = "DuelDeclined"
+	DuelDeclinedNotice                = "DuelDeclinedNotice"
+	DuelReportTooEarly                = "DuelReportTooEarly"
+	DuelReportWaiting                 = "DuelReportWaiting"
+	DuelResultWin                     = "DuelResultWin"
+	DuelResultLoss                    = "DuelResultLoss"
+	DuelResultDraw                    = "DuelResultDraw"
+	DuelResultDisputed                = "DuelResultDisputed"
+	DuelClosed                        = "DuelClosed"
+	DuelNotParticipant                = "DuelNotParticipant"
+	NotificationDuelExpiredPending    = "NotificationDuelExpiredPending"
+	NotificationDuelExpiredUnreported = "NotificationDuelExpiredUnreported"
+
+	// Inactive member removal
+	AutoRemoveInactiveTitle       = "AutoRemoveInactiveTitle"
+	AutoRemoveInactiveDisabledAll = "AutoRemoveInactiveDisabledAll"
+	AutoRemoveInactiveEnabled     = "AutoRemoveInactiveEnabled"
+	AutoRemoveInactiveDisabled    = "AutoRemoveInactiveDisabled"
+	AutoRemoveInactiveErrorUpdate = "AutoRemoveInactiveErrorUpdate"
+	NotificationInactiveRemoved   = "NotificationInactiveRemoved"
+
+	// Group member cap
+	HelpCommandMaxMembers = "HelpCommandMaxMembers"
+	MaxMembersUsage       = "MaxMembersUsage"
+	MaxMembersGroupItem   = "MaxMembersGroupItem"
+	MaxMembersUnlimited   = "MaxMembersUnlimited"
+	MaxMembersBelowActive = "MaxMembersBelowActive"
+	MaxMembersSet         = "MaxMembersSet"
+	MaxMembersRemoved     = "MaxMembersRemoved"
+	MaxMembersError       = "MaxMembersError"
+
+	// Per-group event archival
+	HelpCommandArchiveDays = "HelpCommandArchiveDays"
+	ArchiveDaysUsage       = "ArchiveDaysUsage"
+	ArchiveDaysGroupItem   = "ArchiveDaysGroupItem"
+	ArchiveDaysDefault     = "ArchiveDaysDefault"
+	ArchiveDaysAfter       = "ArchiveDaysAfter"
+	ArchiveDaysNever       = "ArchiveDaysNever"
+	ArchiveDaysSet         = "ArchiveDaysSet"
+	ArchiveDaysError       = "ArchiveDaysError"
+
+	// Points label
+	HelpCommandPointsLabel = "HelpCommandPointsLabel"
+	PointsLabelDefault     = "PointsLabelDefault"
+	PointsLabelUsage       = "PointsLabelUsage"
+	PointsLabelGroupItem   = "PointsLabelGroupItem"
+	PointsLabelSet         = "PointsLabelSet"
+	PointsLabelReset       = "PointsLabelReset"
+	PointsLabelInvalid     = "PointsLabelInvalid"
+	PointsLabelError       = "PointsLabelError"
+
+	// Reputation weighting
+	ReputationWeightingTitle       = "ReputationWeightingTitle"
+	ReputationWeightingEnabled     = "ReputationWeightingEnabled"
+	ReputationWeightingDisabled    = "ReputationWeightingDisabled"
+	ReputationWeightingErrorUpdate = "ReputationWeightingErrorUpdate"
+
+	// Event approval queue
+	RequireApprovalTitle          = "RequireApprovalTitle"
+	RequireApprovalEnabled        = "RequireApprovalEnabled"
+	RequireApprovalDisabled       = "RequireApprovalDisabled"
+	RequireApprovalErrorUpdate    = "RequireApprovalErrorUpdate"
+	EventApprovalSubmitted        = "EventApprovalSubmitted"
+	EventApprovalRequest          = "EventApprovalRequest"
+	EventApprovalButtonApprove    = "EventApprovalButtonApprove"
+	EventApprovalButtonReject     = "EventApprovalButtonReject"
+	EventApprovalButtonBack       = "EventApprovalButtonBack"
+	EventApprovalChooseReason     = "EventApprovalChooseReason"
+	EventApprovalApproved         = "EventApprovalApproved"
+	EventApprovalRejected         = "EventApprovalRejected"
+	EventApprovalApprovedCreator  = "EventApprovalApprovedCreator"
+	EventApprovalRejectedCreator  = "EventApprovalRejectedCreator"
+	EventApprovalAlreadyReviewed  = "EventApprovalAlreadyReviewed"
+	EventApprovalInProgress       = "EventApprovalInProgress"
+	EventApprovalDeadlinePassed   = "EventApprovalDeadlinePassed"
+	EventApprovalError            = "EventApprovalError"
+	EventRejectReasonDuplicate    = "EventRejectReasonDuplicate"
+	EventRejectReasonUnclear      = "EventRejectReasonUnclear"
+	EventRejectReasonUnverifiable = "EventRejectReasonUnverifiable"
+	EventRejectReasonOffTopic     = "EventRejectReasonOffTopic"
+	EventRejectReasonOther        = "EventRejectReasonOther"
+
+	// First vote is final
+	FirstVoteFinalTitle       = "FirstVoteFinalTitle"
+	FirstVoteFinalEnabled     = "FirstVoteFinalEnabled"
+	FirstVoteFinalDisabled    = "FirstVoteFinalDisabled"
+	FirstVoteFinalErrorUpdate = "FirstVoteFinalErrorUpdate"
+	FirstVoteFinalVoteKept    = "FirstVoteFinalVoteKept"
+
+	// Creators excluded from scoring their own events
+	ExcludeCreatorScoringTitle       = "ExcludeCreatorScoringTitle"
+	ExcludeCreatorScoringEnabled     = "ExcludeCreatorScoringEnabled"
+	ExcludeCreatorScoringDisabled    = "ExcludeCreatorScoringDisabled"
+	ExcludeCreatorScoringErrorUpdate = "ExcludeCreatorScoringErrorUpdate"
+
+	// Achievements enabled per group
+	AchievementSettingsTitle       = "AchievementSettingsTitle"
+	AchievementSettingsGroup       = "AchievementSettingsGroup"
+	AchievementSettingsEnabled     = "AchievementSettingsEnabled"
+	AchievementSettingsDisabled    = "AchievementSettingsDisabled"
+	AchievementSettingsBack        = "AchievementSettingsBack"
+	AchievementSettingsErrorUpdate = "AchievementSettingsErrorUpdate"
+
+	// New event subscriptions
+	HelpCommandSubscribe           = "HelpCommandSubscribe"
+	HelpCommandUnsubscribe         = "HelpCommandUnsubscribe"
+	SubscribeTitle                 = "SubscribeTitle"
+	UnsubscribeTitle               = "UnsubscribeTitle"
+	SubscribeAllSubscribed         = "SubscribeAllSubscribed"
+	UnsubscribeAllUnsubscribed     = "UnsubscribeAllUnsubscribed"
+	SubscribeDone                  = "SubscribeDone"
+	UnsubscribeDone                = "UnsubscribeDone"
+	SubscriptionErrorUpdate        = "SubscriptionErrorUpdate"
+	NotificationSubscribedNewEvent = "NotificationSubscribedNewEvent"
+	NotificationStartBotPrompt     = "NotificationStartBotPrompt"
+
+	// Hot events
+	HelpCommandHot  = "HelpCommandHot"
+	HotTitle        = "HotTitle"
+	HotEmpty        = "HotEmpty"
+	HotItem         = "HotItem"
+	HotItemVotes    = "HotItemVotes"
+	HotItemDeadline = "HotItemDeadline"
+
+	// Upcoming deadlines
+	HelpCommandUpcoming  = "HelpCommandUpcoming"
+	UpcomingTitle        = "UpcomingTitle"
+	UpcomingEmpty        = "UpcomingEmpty"
+	UpcomingItem         = "UpcomingItem"
+	UpcomingItemDeadline = "UpcomingItemDeadline"
+	UpcomingFooter       = "UpcomingFooter"
+
+	// Session inspection
+	HelpCommandSession         = "HelpCommandSession"
+	SessionInspectUsage        = "SessionInspectUsage"
+	SessionInspectNotFound     = "SessionInspectNotFound"
+	SessionInspectError        = "SessionInspectError"
+	SessionInspectInfo         = "SessionInspectInfo"
+	SessionInspectActive       = "SessionInspectActive"
+	SessionInspectExpired      = "SessionInspectExpired"
+	SessionInspectCorrupted    = "SessionInspectCorrupted"
+	SessionInspectButtonDelete = "SessionInspectButtonDelete"
+	SessionInspectDeleted      = "SessionInspectDeleted"
+	SessionInspectErrorDelete  = "SessionInspectErrorDelete"
+
+	// Self-check
+	HelpCommandDiag         = "HelpCommandDiag"
+	DiagReport              = "DiagReport"
+	DiagCheckPassed         = "DiagCheckPassed"
+	DiagCheckFailed         = "DiagCheckFailed"
+	DiagTimedOut            = "DiagTimedOut"
+	DiagAllPassed           = "DiagAllPassed"
+	DiagSomeFailed          = "DiagSomeFailed"
+	DiagCheckDatabase       = "DiagCheckDatabase"
+	DiagDatabaseOK          = "DiagDatabaseOK"
+	DiagCheckScheduler      = "DiagCheckScheduler"
+	DiagSchedulerLastRun    = "DiagSchedulerLastRun"
+	DiagSchedulerNotStarted = "DiagSchedulerNotStarted"
+	DiagSchedulerStuck      = "DiagSchedulerStuck"
+	DiagCheckSessions       = "DiagCheckSessions"
+	DiagSessionsStale       = "DiagSessionsStale"
+	DiagCheckTelegram       = "DiagCheckTelegram"
+
+	// Outcome notifications
+	HelpCommandNotifications        = "HelpCommandNotifications"
+	OutcomeNotificationTitle        = "OutcomeNotificationTitle"
+	OutcomeNotificationItem         = "OutcomeNotificationItem"
+	OutcomeDigestTitle              = "OutcomeDigestTitle"
+	OutcomeDigestMore               = "OutcomeDigestMore"
+	NotificationSettingsTitle       = "NotificationSettingsTitle"
+	NotificationSettingsImmediate   = "NotificationSettingsImmediate"
+	NotificationSettingsDigest      = "NotificationSettingsDigest"
+	NotificationSettingsOff         = "NotificationSettingsOff"
+	NotificationSettingsUpdated     = "NotificationSettingsUpdated"
+	NotificationSettingsErrorGet    = "NotificationSettingsErrorGet"
+	NotificationSettingsErrorUpdate = "NotificationSettingsErrorUpdate"
+
+	// Voter change notifications
+	VoterChangeNotification   = "VoterChangeNotification"
+	VoterChangeQuestion       = "VoterChangeQuestion"
+	VoterChangeOptions        = "VoterChangeOptions"
+	VoterChangeDeadline       = "VoterChangeDeadline"
+	EventEditCorrectionNotice = "EventEditCorrectionNotice"
+
+	// Orphaned polls report
+	HelpCommandOrphans   = "HelpCommandOrphans"
+	OrphansTitle         = "OrphansTitle"
+	OrphansActiveSection = "OrphansActiveSection"
+	OrphansClosedSection = "OrphansClosedSection"
+	OrphansItem          = "OrphansItem"
+	OrphansEmpty         = "OrphansEmpty"
+	OrphansError         = "OrphansError"
+
+	// Authoritative poll tally
+	HelpCommandPollSync = "HelpCommandPollSync"
+	PollSyncUsage       = "PollSyncUsage"
+	PollSyncNotFound    = "PollSyncNotFound"
+	PollSyncTitle       = "PollSyncTitle"
+	PollSyncPollStopped = "PollSyncPollStopped"
+	PollSyncPosted      = "PollSyncPosted"
+	PollSyncStopped     = "PollSyncStopped"
+	PollSyncStopFailed  = "PollSyncStopFailed"
+	PollSyncNoPoll      = "PollSyncNoPoll"
+	PollSyncError       = "PollSyncError"
+
+	// Rejected vote notices
+	VoteRejectedNotMember    = "VoteRejectedNotMember"
+	VoteRejectedRejoin       = "VoteRejectedRejoin"
+	VoteRejectedVotingClosed = "VoteRejectedVotingClosed"
+
+	// Language preferences
+	HelpCommandLanguage         = "HelpCommandLanguage"
+	LanguageSettingsTitleUser   = "LanguageSettingsTitleUser"
+	LanguageSettingsTitleChat   = "LanguageSettingsTitleChat"
+	LanguageSettingsDefault     = "LanguageSettingsDefault"
+	LanguageNameEn              = "LanguageNameEn"
+	LanguageNameRu              = "LanguageNameRu"
+	LanguageSettingsUpdated     = "LanguageSettingsUpdated"
+	LanguageSettingsAdminsOnly  = "LanguageSettingsAdminsOnly"
+	LanguageSettingsErrorGet    = "LanguageSettingsErrorGet"
+	LanguageSettingsErrorUpdate = "LanguageSettingsErrorUpdate"
 )
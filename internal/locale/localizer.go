@@ -40,11 +40,16 @@ func (l *locale) GetLocale() string {
 type localizer struct {
 	Locale
 	*i18n.Localizer
+	// lenient localizers fall back to English and then to the message ID instead of panicking on missing keys
+	lenient bool
 }
 
 type Localizer interface {
 	Locale
 	MustLocalize(id string) string
+	// Localize is MustLocalize without panicking: for a missing key it returns an error together
+	// with the English text, or an empty string when no language has the key
+	Localize(id string) (string, error)
 	MustLocalizeWithTemplate(id string, fields ...string) string
 }
 
@@ -56,6 +61,21 @@ func NewLocalizer(ctx context.Context, locale Locale) (Localizer, error) {
 	// 	return nil, fmt.Errorf("failed to validate translations: %w", err)
 	// }
 
+	bundle, err := newBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := defaultOptions(bundle)
+
+	return &localizer{
+		Locale:    locale,
+		Localizer: i18n.NewLocalizer(opts.bundle, locale.GetLocale()),
+	}, nil
+}
+
+// newBundle loads all embedded translations into a bundle with English as the fallback language
+func newBundle() (*i18n.Bundle, error) {
 	bundle := i18n.NewBundle(language.English)
 	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
 
@@ -73,12 +93,7 @@ func NewLocalizer(ctx context.Context, locale Locale) (Localizer, error) {
 		bundle.MustParseMessageFileBytes(data, f)
 	}
 
-	opts := defaultOptions(bundle)
-
-	return &localizer{
-		locale,
-		i18n.NewLocalizer(opts.bundle, locale.GetLocale()),
-	}, nil
+	return bundle, nil
 }
 
 type options struct {
@@ -92,11 +107,28 @@ func defaultOptions(bundle *i18n.Bundle) options {
 }
 
 func (l *localizer) MustLocalize(id string) string {
-	return l.Localizer.MustLocalize(createLocalizeConfig(id))
+	return l.localize(createLocalizeConfig(id))
+}
+
+func (l *localizer) Localize(id string) (string, error) {
+	return l.Localizer.Localize(createLocalizeConfig(id))
 }
 
 func (l *localizer) MustLocalizeWithTemplate(id string, fields ...string) string {
-	return l.Localizer.MustLocalize(createLocalizeConfigWithTemplate(id, fields...))
+	return l.localize(createLocalizeConfigWithTemplate(id, fields...))
+}
+
+func (l *localizer) localize(lc *i18n.LocalizeConfig) string {
+	if !l.lenient {
+		return l.Localizer.MustLocalize(lc)
+	}
+
+	// Localize returns the English text together with an error when only the translation is missing
+	msg, err := l.Localizer.Localize(lc)
+	if err != nil && msg == "" {
+		return lc.MessageID
+	}
+	return msg
 }
 
 func createLocalizeConfig(id string) *i18n.LocalizeConfig {
@@ -0,0 +1,35 @@
+package locale
+
+// PluralForm picks the form of a word for the count n in the language lang.
+// forms are ordered as "one, few, many" for Russian and "one, other" for English;
+// a single form is used for every count.
+func PluralForm(lang string, n int, forms []string) string {
+	if len(forms) == 0 {
+		return ""
+	}
+	if len(forms) == 1 {
+		return forms[0]
+	}
+	if n < 0 {
+		n = -n
+	}
+
+	if NormalizeLanguage(lang) != Ru {
+		if n == 1 {
+			return forms[0]
+		}
+		return forms[len(forms)-1]
+	}
+
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return forms[0]
+	case len(forms) == 2:
+		return forms[1]
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return forms[1]
+	default:
+		return forms[2]
+	}
+}
@@ -0,0 +1,37 @@
+package locale
+
+import (
+	"testing"
+)
+
+func TestPluralForm(t *testing.T) {
+	ru := []string{"очко", "очка", "очков"}
+	en := []string{"point", "points"}
+
+	tests := []struct {
+		lang  string
+		n     int
+		forms []string
+		want  string
+	}{
+		{"ru", 1, ru, "очко"},
+		{"ru", 21, ru, "очко"},
+		{"ru", 11, ru, "очков"},
+		{"ru", 3, ru, "очка"},
+		{"ru", 13, ru, "очков"},
+		{"ru", 0, ru, "очков"},
+		{"ru", -2, ru, "очка"},
+		{"ru", 5, []string{"монета", "монеты"}, "монеты"},
+		{"en", 1, en, "point"},
+		{"en", 21, en, "points"},
+		{"en", 0, en, "points"},
+		{"en", 3, []string{"coin"}, "coin"},
+		{"en", 3, nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := PluralForm(tt.lang, tt.n, tt.forms); got != tt.want {
+			t.Errorf("PluralForm(%q, %d, %v) = %q, want %q", tt.lang, tt.n, tt.forms, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package locale
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// SupportedLanguages lists the languages with embedded translations
+var SupportedLanguages = []string{En, Ru}
+
+// LanguagePreferences looks up the languages chosen for users and chats.
+// An empty language means no preference is stored.
+type LanguagePreferences interface {
+	UserLanguage(ctx context.Context, userID int64) (string, error)
+	ChatLanguage(ctx context.Context, chatID int64) (string, error)
+}
+
+// LocalizerResolver picks the localizer for a request: the user's preference wins over
+// the chat's language, which wins over the bot-wide default. Localizers are built once per
+// language and fall back to English and then to the message ID for missing keys.
+type LocalizerResolver struct {
+	bundle      *i18n.Bundle
+	defaultLang string
+	prefs       LanguagePreferences
+
+	mu         sync.RWMutex
+	localizers map[string]Localizer
+}
+
+// NewLocalizerResolver creates a resolver with the given default language.
+// An unsupported default falls back to English, prefs may be nil.
+func NewLocalizerResolver(defaultLanguage string, prefs LanguagePreferences) (*LocalizerResolver, error) {
+	bundle, err := newBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultLang := NormalizeLanguage(defaultLanguage)
+	if defaultLang == "" {
+		defaultLang = En
+	}
+
+	return &LocalizerResolver{
+		bundle:      bundle,
+		defaultLang: defaultLang,
+		prefs:       prefs,
+		localizers:  make(map[string]Localizer),
+	}, nil
+}
+
+// NormalizeLanguage maps a language code such as "en-US" to a supported language,
+// or returns an empty string when the language is not supported
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	for _, supported := range SupportedLanguages {
+		if lang == supported {
+			return supported
+		}
+	}
+	return ""
+}
+
+// DefaultLanguage returns the bot-wide default language
+func (r *LocalizerResolver) DefaultLanguage() string {
+	return r.defaultLang
+}
+
+// Default returns the localizer for the bot-wide default language
+func (r *LocalizerResolver) Default() Localizer {
+	return r.ForLanguage(r.defaultLang)
+}
+
+// ForLanguage returns the cached localizer for lang, using the default language when lang is not supported
+func (r *LocalizerResolver) ForLanguage(lang string) Localizer {
+	lang = NormalizeLanguage(lang)
+	if lang == "" {
+		lang = r.defaultLang
+	}
+
+	r.mu.RLock()
+	l, ok := r.localizers[lang]
+	r.mu.RUnlock()
+	if ok {
+		return l
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.localizers[lang]; ok {
+		return l
+	}
+	l = &localizer{
+		Locale:    NewLocale(lang),
+		Localizer: i18n.NewLocalizer(r.bundle, lang),
+		lenient:   true,
+	}
+	r.localizers[lang] = l
+	return l
+}
+
+// Resolve returns the localizer for a request by userID in chatID (zero IDs are skipped).
+// Lookup failures and unsupported languages fall through to the next source.
+func (r *LocalizerResolver) Resolve(ctx context.Context, userID, chatID int64) Localizer {
+	return r.ForLanguage(r.resolveLanguage(ctx, userID, chatID))
+}
+
+func (r *LocalizerResolver) resolveLanguage(ctx context.Context, userID, chatID int64) string {
+	if r.prefs == nil {
+		return r.defaultLang
+	}
+
+	if userID != 0 {
+		if lang, err := r.prefs.UserLanguage(ctx, userID); err == nil && NormalizeLanguage(lang) != "" {
+			return lang
+		}
+	}
+	if chatID != 0 {
+		if lang, err := r.prefs.ChatLanguage(ctx, chatID); err == nil && NormalizeLanguage(lang) != "" {
+			return lang
+		}
+	}
+	return r.defaultLang
+}
@@ -0,0 +1,137 @@
+package locale
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stubPreferences serves fixed user and chat languages
+type stubPreferences struct {
+	users map[int64]string
+	chats map[int64]string
+	err   error
+}
+
+func (p *stubPreferences) UserLanguage(ctx context.Context, userID int64) (string, error) {
+	return p.users[userID], p.err
+}
+
+func (p *stubPreferences) ChatLanguage(ctx context.Context, chatID int64) (string, error) {
+	return p.chats[chatID], p.err
+}
+
+func TestLocalizerResolver_Precedence(t *testing.T) {
+	prefs := &stubPreferences{
+		users: map[int64]string{1: Ru, 2: "", 3: "de"},
+		chats: map[int64]string{-10: En, -20: "ru-RU"},
+	}
+	resolver, err := NewLocalizerResolver(En, prefs)
+	if err != nil {
+		t.Fatalf("NewLocalizerResolver failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		userID   int64
+		chatID   int64
+		expected string
+	}{
+		{"user preference wins over chat", 1, -10, Ru},
+		{"chat language without user preference", 2, -20, Ru},
+		{"unsupported user language falls through to chat", 3, -20, Ru},
+		{"default without any preference", 2, -30, En},
+		{"default without user and chat", 0, 0, En},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolver.Resolve(context.Background(), tt.userID, tt.chatID).GetLocale(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLocalizerResolver_LookupErrorFallsBackToDefault(t *testing.T) {
+	prefs := &stubPreferences{
+		users: map[int64]string{1: En},
+		chats: map[int64]string{-10: En},
+		err:   errors.New("database is locked"),
+	}
+	resolver, err := NewLocalizerResolver(Ru, prefs)
+	if err != nil {
+		t.Fatalf("NewLocalizerResolver failed: %v", err)
+	}
+
+	if got := resolver.Resolve(context.Background(), 1, -10).GetLocale(); got != Ru {
+		t.Errorf("expected default %q, got %q", Ru, got)
+	}
+}
+
+func TestLocalizerResolver_Default(t *testing.T) {
+	resolver, err := NewLocalizerResolver("fr", nil)
+	if err != nil {
+		t.Fatalf("NewLocalizerResolver failed: %v", err)
+	}
+
+	if resolver.DefaultLanguage() != En {
+		t.Errorf("expected unsupported default to fall back to %q, got %q", En, resolver.DefaultLanguage())
+	}
+	if got := resolver.Resolve(context.Background(), 1, -10).GetLocale(); got != En {
+		t.Errorf("expected %q without preferences, got %q", En, got)
+	}
+}
+
+func TestLocalizerResolver_CachesLocalizers(t *testing.T) {
+	resolver, err := NewLocalizerResolver(Ru, nil)
+	if err != nil {
+		t.Fatalf("NewLocalizerResolver failed: %v", err)
+	}
+
+	if resolver.ForLanguage("en-US") != resolver.ForLanguage(En) {
+		t.Error("expected the same localizer for the same language")
+	}
+	if resolver.ForLanguage("") != resolver.Default() {
+		t.Error("expected the default localizer for an empty language")
+	}
+	if resolver.ForLanguage(En) == resolver.ForLanguage(Ru) {
+		t.Error("expected different localizers for different languages")
+	}
+}
+
+func TestLocalizerResolver_MissingKeyFallsBack(t *testing.T) {
+	resolver, err := NewLocalizerResolver(Ru, nil)
+	if err != nil {
+		t.Fatalf("NewLocalizerResolver failed: %v", err)
+	}
+
+	loc := resolver.Default()
+	if got := loc.MustLocalize("NoSuchMessageKey"); got != "NoSuchMessageKey" {
+		t.Errorf("expected the message ID for a missing key, got %q", got)
+	}
+	if got := loc.MustLocalizeWithTemplate("NoSuchMessageKey", "x"); got != "NoSuchMessageKey" {
+		t.Errorf("expected the message ID for a missing template key, got %q", got)
+	}
+	if got := loc.MustLocalize(HelpBotTitle); got == HelpBotTitle || got == "" {
+		t.Errorf("expected a translation for an existing key, got %q", got)
+	}
+}
+
+func TestLocalizer_Localize(t *testing.T) {
+	loc, err := NewLocalizer(context.Background(), NewLocale(Ru))
+	if err != nil {
+		t.Fatalf("NewLocalizer failed: %v", err)
+	}
+
+	text, err := loc.Localize(HelpBotTitle)
+	if err != nil || text != loc.MustLocalize(HelpBotTitle) {
+		t.Errorf("expected the translation without an error, got %q (err %v)", text, err)
+	}
+
+	// A missing key is reported instead of panicking
+	text, err = loc.Localize("NoSuchMessageKey")
+	if err == nil || text != "" {
+		t.Errorf("expected an error and no text for a missing key, got %q (err %v)", text, err)
+	}
+}
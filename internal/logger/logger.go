@@ -55,6 +55,7 @@ func ParseLevel(s string) Level {
 type Logger struct {
 	level  Level
 	logger *log.Logger
+	fields []interface{}
 }
 
 // New creates a new logger with specified level
@@ -79,6 +80,11 @@ func (l *Logger) log(level Level, msg string, fields ...interface{}) {
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
 	levelStr := level.String()
 
+	// Derived loggers put their fields first
+	if len(l.fields) > 0 {
+		fields = append(append([]interface{}{}, l.fields...), fields...)
+	}
+
 	// Build fields string
 	fieldsStr := ""
 	if len(fields) > 0 {
@@ -96,6 +102,16 @@ func (l *Logger) log(level Level, msg string, fields ...interface{}) {
 	l.logger.Printf("[%s] %s: %s%s", timestamp, levelStr, msg, fieldsStr)
 }
 
+// With returns a child logger that adds the given key-value pairs to every message.
+// The child shares the parent's output and starts with its level.
+func (l *Logger) With(fields ...interface{}) *Logger {
+	return &Logger{
+		level:  l.level,
+		logger: l.logger,
+		fields: append(append([]interface{}{}, l.fields...), fields...),
+	}
+}
+
 // Debug logs a debug message
 func (l *Logger) Debug(msg string, fields ...interface{}) {
 	l.log(DEBUG, msg, fields...)
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLoggerWith(t *testing.T) {
+	var buf bytes.Buffer
+	parent := NewWithWriter(INFO, &buf)
+
+	child := parent.With("user_id", 42, "chat_id", -100)
+	child.Info("vote saved", "event_id", 7)
+	if line := buf.String(); !strings.Contains(line, "INFO: vote saved user_id=42 chat_id=-100 event_id=7") {
+		t.Errorf("expected derived fields before message fields, got %q", line)
+	}
+
+	// Grandchildren keep the fields of every ancestor
+	buf.Reset()
+	child.With("update_id", 5).Warn("retry")
+	if line := buf.String(); !strings.Contains(line, "WARN: retry user_id=42 chat_id=-100 update_id=5") {
+		t.Errorf("expected inherited fields, got %q", line)
+	}
+
+	// The parent is not affected by its children
+	buf.Reset()
+	parent.Info("started")
+	if line := strings.TrimSpace(buf.String()); !strings.HasSuffix(line, "INFO: started") {
+		t.Errorf("expected no fields on the parent logger, got %q", line)
+	}
+
+	// The child starts with the parent's level
+	buf.Reset()
+	child.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("expected debug message to be filtered, got %q", buf.String())
+	}
+}
@@ -19,7 +19,7 @@ func NewAchievementRepository(queue *DBQueue) *AchievementRepository {
 
 // SaveAchievement saves a new achievement to the database
 func (r *AchievementRepository) SaveAchievement(ctx context.Context, achievement *domain.Achievement) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		result, err := db.ExecContext(ctx,
 			`INSERT INTO achievements (user_id, group_id, code, timestamp)
 			 VALUES (?, ?, ?, ?)`,
@@ -42,7 +42,7 @@ func (r *AchievementRepository) SaveAchievement(ctx context.Context, achievement
 func (r *AchievementRepository) GetUserAchievements(ctx context.Context, userID int64, groupID int64) ([]*domain.Achievement, error) {
 	var achievements []*domain.Achievement
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
 			`SELECT id, user_id, group_id, code, timestamp
 			 FROM achievements WHERE user_id = ? AND group_id = ? ORDER BY timestamp DESC`,
@@ -78,7 +78,7 @@ func (r *AchievementRepository) GetUserAchievements(ctx context.Context, userID
 func (r *AchievementRepository) CheckAchievementExists(ctx context.Context, userID int64, groupID int64, code domain.AchievementCode) (bool, error) {
 	var exists bool
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		var count int
 		err := db.QueryRowContext(ctx,
 			`SELECT COUNT(*) FROM achievements WHERE user_id = ? AND group_id = ? AND code = ?`,
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// DefaultAuditPurgeChunkSize is the number of rows deleted per write when purging audit tables
+const DefaultAuditPurgeChunkSize = 500
+
+// auditTimestampColumns maps each purgeable audit table to the column holding the time of a row.
+// Only append-only logs belong here: rows of these tables are never read back as current state.
+var auditTimestampColumns = map[string]string{
+	"admin_logs": "timestamp",
+}
+
+// AuditRetentionRepository deletes expired rows from audit tables
+type AuditRetentionRepository struct {
+	queue *DBQueue
+}
+
+// NewAuditRetentionRepository creates a new AuditRetentionRepository
+func NewAuditRetentionRepository(queue *DBQueue) *AuditRetentionRepository {
+	return &AuditRetentionRepository{queue: queue}
+}
+
+// PurgeOlderThan deletes rows of an audit table recorded before cutoff and returns how many were deleted.
+// Rows are deleted in chunks of chunkSize, each in its own queued write, so other writes can run
+// between chunks instead of waiting for the whole table.
+func (r *AuditRetentionRepository) PurgeOlderThan(ctx context.Context, table string, cutoff time.Time, chunkSize int) (int64, error) {
+	column, ok := auditTimestampColumns[table]
+	if !ok {
+		return 0, fmt.Errorf("unknown audit table %q", table)
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultAuditPurgeChunkSize
+	}
+
+	query := fmt.Sprintf(
+		`DELETE FROM %s WHERE rowid IN (SELECT rowid FROM %s WHERE %s < ? LIMIT ?)`,
+		table, table, column,
+	)
+
+	var total int64
+	for {
+		var deleted int64
+		err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+			result, err := db.ExecContext(ctx, query, cutoff, chunkSize)
+			if err != nil {
+				return err
+			}
+
+			deleted, err = result.RowsAffected()
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+
+		total += deleted
+		if deleted < int64(chunkSize) {
+			return total, nil
+		}
+	}
+}
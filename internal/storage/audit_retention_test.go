@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestAuditRetentionRepository_PurgeOlderThan(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-30 * 24 * time.Hour)
+	insert := func(action string, at time.Time) {
+		t.Helper()
+		err := queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+			_, err := db.ExecContext(ctx,
+				`INSERT INTO admin_logs (admin_user_id, action, timestamp) VALUES (?, ?, ?)`,
+				1, action, at,
+			)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("Failed to insert admin log: %v", err)
+		}
+	}
+
+	// More old rows than one chunk, so the purge takes several writes
+	for i := 0; i < 7; i++ {
+		insert("old", cutoff.Add(-time.Duration(i+1)*time.Hour))
+	}
+	insert("recent", cutoff.Add(time.Hour))
+	insert("recent", now)
+
+	repo := NewAuditRetentionRepository(queue)
+	purged, err := repo.PurgeOlderThan(ctx, "admin_logs", cutoff, 3)
+	if err != nil {
+		t.Fatalf("PurgeOlderThan failed: %v", err)
+	}
+	if purged != 7 {
+		t.Errorf("Expected 7 purged rows, got %d", purged)
+	}
+
+	var actions []string
+	err = queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx, `SELECT action FROM admin_logs`)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			var action string
+			if err := rows.Scan(&action); err != nil {
+				return err
+			}
+			actions = append(actions, action)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		t.Fatalf("Failed to read admin logs: %v", err)
+	}
+	if len(actions) != 2 || actions[0] != "recent" || actions[1] != "recent" {
+		t.Errorf("Expected only the recent rows to remain, got %v", actions)
+	}
+
+	// Nothing left to purge
+	if purged, err := repo.PurgeOlderThan(ctx, "admin_logs", cutoff, 3); err != nil || purged != 0 {
+		t.Errorf("Expected nothing to purge, got %d (err %v)", purged, err)
+	}
+
+	// Tables that aren't audit logs are refused
+	if _, err := repo.PurgeOlderThan(ctx, "predictions", cutoff, 3); err == nil {
+		t.Error("Expected an error for a table that isn't an audit log")
+	}
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ClearAllData deletes the rows of every table except the migrations bookkeeping and resets
+// the autoincrement counters, leaving an empty database with the current schema.
+// It is meant for development databases only.
+func ClearAllData(ctx context.Context, queue *DBQueue) error {
+	return queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'schema_migrations'`)
+		if err != nil {
+			return err
+		}
+		var tables []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			tables = append(tables, name)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+
+		var hasSequence bool
+		if err := db.QueryRowContext(ctx,
+			`SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'sqlite_sequence'`).Scan(&hasSequence); err != nil {
+			return err
+		}
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		for _, table := range tables {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM "`+table+`"`); err != nil {
+				return err
+			}
+		}
+		if hasSequence {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM sqlite_sequence`); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit()
+	})
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+func TestClearAllData(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	groupRepo := NewGroupRepository(queue)
+	group := &domain.Group{TelegramChatID: -100, Name: "Test Group", CreatedAt: time.Now(), CreatedBy: 1}
+	if err := groupRepo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	if err := NewUserRepository(queue).UpsertUserProfile(ctx, 1, "alice", "Alice", ""); err != nil {
+		t.Fatalf("UpsertUserProfile failed: %v", err)
+	}
+
+	if err := ClearAllData(ctx, queue); err != nil {
+		t.Fatalf("ClearAllData failed: %v", err)
+	}
+
+	for _, table := range []string{"groups", "user_profiles"} {
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&count); err != nil {
+			t.Fatalf("Failed to count %s: %v", table, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected %s to be empty, got %d rows", table, count)
+		}
+	}
+
+	var migrations int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&migrations); err != nil {
+		t.Fatalf("Failed to count migrations: %v", err)
+	}
+	if migrations == 0 {
+		t.Error("Expected applied migrations to be kept")
+	}
+
+	// Autoincrement counters start over
+	again := &domain.Group{TelegramChatID: -100, Name: "Test Group", CreatedAt: time.Now(), CreatedBy: 1}
+	if err := groupRepo.CreateGroup(ctx, again); err != nil {
+		t.Fatalf("CreateGroup after clear failed: %v", err)
+	}
+	if again.ID != group.ID {
+		t.Errorf("Expected group ID %d after clear, got %d", group.ID, again.ID)
+	}
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"strings"
@@ -12,11 +13,13 @@ type DBQueue struct {
 	db         *sql.DB
 	queryQueue chan *dbRequest
 	done       chan struct{}
+	timeout    time.Duration
 }
 
 // dbRequest represents a database operation request
 type dbRequest struct {
-	query    func(*sql.DB) error
+	ctx      context.Context
+	query    func(context.Context, *sql.DB) error
 	response chan error
 }
 
@@ -31,12 +34,24 @@ func NewDBQueue(db *sql.DB) *DBQueue {
 	return q
 }
 
+// SetOperationTimeout limits how long a single ExecuteContext operation may take,
+// including the time spent waiting in the queue. Zero disables the timeout.
+// It must be called before the queue is used.
+func (q *DBQueue) SetOperationTimeout(timeout time.Duration) {
+	q.timeout = timeout
+}
+
 // processQueue processes database requests sequentially
 func (q *DBQueue) processQueue() {
 	for {
 		select {
 		case req := <-q.queryQueue:
-			err := q.executeWithRetry(req.query)
+			// Skip operations abandoned by their caller while waiting in the queue
+			if err := req.ctx.Err(); err != nil {
+				req.response <- err
+				continue
+			}
+			err := q.executeWithRetry(req.ctx, req.query)
 			req.response <- err
 		case <-q.done:
 			return
@@ -45,15 +60,19 @@ func (q *DBQueue) processQueue() {
 }
 
 // executeWithRetry executes a query with retry logic for SQLITE_BUSY errors
-func (q *DBQueue) executeWithRetry(query func(*sql.DB) error) error {
+func (q *DBQueue) executeWithRetry(ctx context.Context, query func(context.Context, *sql.DB) error) error {
 	maxRetries := 3
 	for i := 0; i < maxRetries; i++ {
-		err := query(q.db)
+		err := query(ctx, q.db)
 		if err == nil {
 			return nil
 		}
 		if isBusyError(err) {
-			time.Sleep(time.Millisecond * time.Duration(100*(i+1)))
+			select {
+			case <-time.After(time.Millisecond * time.Duration(100*(i+1))):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 			continue
 		}
 		return err
@@ -71,14 +90,47 @@ func isBusyError(err error) bool {
 		strings.Contains(errStr, "SQLITE_BUSY")
 }
 
-// Execute executes a database operation through the queue
+// Execute executes a database operation through the queue without a deadline.
+// It is meant for schema setup and migrations; repositories should use ExecuteContext.
 func (q *DBQueue) Execute(query func(*sql.DB) error) error {
+	return q.execute(context.Background(), func(_ context.Context, db *sql.DB) error {
+		return query(db)
+	})
+}
+
+// ExecuteContext executes a database operation through the queue, bounded by ctx and the operation timeout.
+// The operation is skipped if ctx is done before it starts, and the call returns the context error
+// as soon as ctx is done. The query receives the bounded context to pass to the database calls.
+func (q *DBQueue) ExecuteContext(ctx context.Context, query func(context.Context, *sql.DB) error) error {
+	if q.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.timeout)
+		defer cancel()
+	}
+	return q.execute(ctx, query)
+}
+
+// execute enqueues a request and waits for its result or for ctx to be done
+func (q *DBQueue) execute(ctx context.Context, query func(context.Context, *sql.DB) error) error {
 	req := &dbRequest{
+		ctx:      ctx,
 		query:    query,
 		response: make(chan error, 1),
 	}
-	q.queryQueue <- req
-	return <-req.response
+
+	select {
+	case q.queryQueue <- req:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-req.response:
+		return err
+	case <-ctx.Done():
+		// The response channel is buffered, so the worker never blocks on an abandoned request
+		return ctx.Err()
+	}
 }
 
 // Close closes the DBQueue and stops processing
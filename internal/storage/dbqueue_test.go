@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestDBQueue_ExecuteContextCancelledWhileQueued(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	// Block the queue with a slow operation
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = queue.Execute(func(db *sql.DB) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan struct{}, 1)
+	result := make(chan error, 1)
+	go func() {
+		result <- queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+			ran <- struct{}{}
+			return nil
+		})
+	}()
+
+	cancel()
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected queued operation to return promptly after cancellation")
+	}
+
+	// The abandoned operation must not run once the queue is free
+	close(release)
+	if err := queue.ExecuteContext(context.Background(), func(ctx context.Context, db *sql.DB) error { return nil }); err != nil {
+		t.Fatalf("Expected queue to keep working, got %v", err)
+	}
+	select {
+	case <-ran:
+		t.Error("Expected cancelled operation to be skipped")
+	default:
+	}
+}
+
+func TestDBQueue_OperationTimeout(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	queue.SetOperationTimeout(50 * time.Millisecond)
+	defer queue.Close()
+
+	err = queue.ExecuteContext(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// Operations finishing in time are unaffected
+	var one int
+	err = queue.ExecuteContext(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+	})
+	if err != nil || one != 1 {
+		t.Errorf("Expected query to succeed, got %d, %v", one, err)
+	}
+}
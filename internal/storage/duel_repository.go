@@ -0,0 +1,297 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+// DuelRepository handles duel and duel record persistence
+type DuelRepository struct {
+	queue *DBQueue
+}
+
+// NewDuelRepository creates a new DuelRepository
+func NewDuelRepository(queue *DBQueue) *DuelRepository {
+	return &DuelRepository{queue: queue}
+}
+
+// duelSelectColumns lists the columns read by scanDuel, in order
+const duelSelectColumns = `id, challenger_id, opponent_id, question, challenger_pick, opponent_pick,
+	challenger_report, opponent_report, outcome, deadline, status, created_at`
+
+// CreateDuel stores a new duel and sets its ID
+func (r *DuelRepository) CreateDuel(ctx context.Context, duel *domain.Duel) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`INSERT INTO duels (challenger_id, opponent_id, question, challenger_pick, opponent_pick, deadline, status, created_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			duel.ChallengerID, duel.OpponentID, duel.Question, nullBool(duel.ChallengerPick), nullBool(duel.OpponentPick),
+			duel.Deadline, duel.Status, duel.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		duel.ID = id
+		return nil
+	})
+}
+
+// GetDuel retrieves a duel by ID (nil if it doesn't exist)
+func (r *DuelRepository) GetDuel(ctx context.Context, duelID int64) (*domain.Duel, error) {
+	var duel *domain.Duel
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		duel, err = scanDuel(db.QueryRowContext(ctx,
+			`SELECT `+duelSelectColumns+` FROM duels WHERE id = ?`, duelID,
+		))
+		return err
+	})
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return duel, nil
+}
+
+// SetChallengerPick stores the challenger's answer of a pending duel that has none yet
+func (r *DuelRepository) SetChallengerPick(ctx context.Context, duelID int64, pick bool) (bool, error) {
+	return r.update(ctx,
+		`UPDATE duels SET challenger_pick = ? WHERE id = ? AND status = ? AND challenger_pick IS NULL`,
+		pick, duelID, domain.DuelStatusPending,
+	)
+}
+
+// AcceptDuel stores the opponent's answer and activates a pending duel the challenger picked an answer for
+func (r *DuelRepository) AcceptDuel(ctx context.Context, duelID int64, pick bool) (bool, error) {
+	return r.update(ctx,
+		`UPDATE duels SET opponent_pick = ?, status = ? WHERE id = ? AND status = ? AND challenger_pick IS NOT NULL`,
+		pick, domain.DuelStatusActive, duelID, domain.DuelStatusPending,
+	)
+}
+
+// DeclineDuel marks a pending duel as declined
+func (r *DuelRepository) DeclineDuel(ctx context.Context, duelID int64) (bool, error) {
+	return r.update(ctx,
+		`UPDATE duels SET status = ? WHERE id = ? AND status = ?`,
+		domain.DuelStatusDeclined, duelID, domain.DuelStatusPending,
+	)
+}
+
+// ReportDuelOutcome stores the outcome reported by a participant of an active duel.
+// Each participant can report once.
+func (r *DuelRepository) ReportDuelOutcome(ctx context.Context, duelID int64, userID int64, outcome bool) (bool, error) {
+	return r.update(ctx,
+		`UPDATE duels SET
+		   challenger_report = CASE WHEN challenger_id = :user THEN :outcome ELSE challenger_report END,
+		   opponent_report = CASE WHEN opponent_id = :user THEN :outcome ELSE opponent_report END
+		 WHERE id = :id AND status = :active AND (
+		   (challenger_id = :user AND challenger_report IS NULL) OR
+		   (opponent_id = :user AND opponent_report IS NULL)
+		 )`,
+		sql.Named("user", userID),
+		sql.Named("outcome", outcome),
+		sql.Named("id", duelID),
+		sql.Named("active", domain.DuelStatusActive),
+	)
+}
+
+// FinishDuel stores the final status and outcome of a pending or active duel. For resolved
+// duels the records of both participants are updated in the same transaction.
+func (r *DuelRepository) FinishDuel(ctx context.Context, duel *domain.Duel) (bool, error) {
+	var finished bool
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		result, err := tx.ExecContext(ctx,
+			`UPDATE duels SET status = ?, outcome = ? WHERE id = ? AND status IN (?, ?)`,
+			duel.Status, nullBool(duel.Outcome), duel.ID, domain.DuelStatusPending, domain.DuelStatusActive,
+		)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return nil
+		}
+
+		if duel.Status == domain.DuelStatusResolved {
+			winnerID := duel.Winner()
+			for _, userID := range []int64{duel.ChallengerID, duel.OpponentID} {
+				var wins, losses, draws int
+				switch winnerID {
+				case 0:
+					draws = 1
+				case userID:
+					wins = 1
+				default:
+					losses = 1
+				}
+
+				if _, err := tx.ExecContext(ctx,
+					`INSERT INTO duel_records (user_id, wins, losses, draws) VALUES (?, ?, ?, ?)
+					 ON CONFLICT(user_id) DO UPDATE SET
+					   wins = wins + excluded.wins,
+					   losses = losses + excluded.losses,
+					   draws = draws + excluded.draws`,
+					userID, wins, losses, draws,
+				); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		finished = true
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return finished, nil
+}
+
+// GetOverdueDuels retrieves pending duels with a deadline before pendingBefore and
+// active duels with a deadline before activeBefore
+func (r *DuelRepository) GetOverdueDuels(ctx context.Context, pendingBefore, activeBefore time.Time) ([]*domain.Duel, error) {
+	var duels []*domain.Duel
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT `+duelSelectColumns+` FROM duels
+			 WHERE (status = ? AND deadline < ?) OR (status = ? AND deadline < ?)
+			 ORDER BY deadline ASC`,
+			domain.DuelStatusPending, pendingBefore, domain.DuelStatusActive, activeBefore,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			duel, err := scanDuel(rows)
+			if err != nil {
+				return err
+			}
+			duels = append(duels, duel)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return duels, nil
+}
+
+// GetDuelRecord retrieves a user's duel record (a zero record if the user has no finished duels)
+func (r *DuelRepository) GetDuelRecord(ctx context.Context, userID int64) (*domain.DuelRecord, error) {
+	record := &domain.DuelRecord{UserID: userID}
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT wins, losses, draws FROM duel_records WHERE user_id = ?`, userID,
+		).Scan(&record.Wins, &record.Losses, &record.Draws)
+	})
+
+	if err == sql.ErrNoRows {
+		return record, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// update runs a conditional update and reports whether a row was changed
+func (r *DuelRepository) update(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	var updated bool
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		updated = rows > 0
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return updated, nil
+}
+
+// scanDuel scans a row selected with duelSelectColumns
+func scanDuel(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.Duel, error) {
+	var duel domain.Duel
+	var challengerPick, opponentPick, challengerReport, opponentReport, outcome sql.NullBool
+
+	err := scanner.Scan(
+		&duel.ID, &duel.ChallengerID, &duel.OpponentID, &duel.Question,
+		&challengerPick, &opponentPick, &challengerReport, &opponentReport, &outcome,
+		&duel.Deadline, &duel.Status, &duel.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duel.ChallengerPick = boolPtr(challengerPick)
+	duel.OpponentPick = boolPtr(opponentPick)
+	duel.ChallengerReport = boolPtr(challengerReport)
+	duel.OpponentReport = boolPtr(opponentReport)
+	duel.Outcome = boolPtr(outcome)
+
+	return &duel, nil
+}
+
+// nullBool converts an optional bool to a nullable column value
+func nullBool(value *bool) sql.NullBool {
+	if value == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *value, Valid: true}
+}
+
+// boolPtr converts a nullable column value to an optional bool
+func boolPtr(value sql.NullBool) *bool {
+	if !value.Valid {
+		return nil
+	}
+	result := value.Bool
+	return &result
+}
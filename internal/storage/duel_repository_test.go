@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+func TestDuelRepository(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewDuelRepository(queue)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	duel := &domain.Duel{
+		ChallengerID: 1,
+		OpponentID:   2,
+		Question:     "Will it rain?",
+		Deadline:     now.Add(time.Hour),
+		Status:       domain.DuelStatusPending,
+		CreatedAt:    now,
+	}
+	if err := repo.CreateDuel(ctx, duel); err != nil {
+		t.Fatalf("CreateDuel failed: %v", err)
+	}
+	if duel.ID == 0 {
+		t.Fatal("Expected duel ID to be set")
+	}
+
+	missing, err := repo.GetDuel(ctx, duel.ID+1)
+	if err != nil || missing != nil {
+		t.Fatalf("Expected no duel for unknown ID, got %+v, %v", missing, err)
+	}
+
+	// Accepting requires the challenger's pick, which can be set only once
+	if ok, err := repo.AcceptDuel(ctx, duel.ID, false); err != nil || ok {
+		t.Errorf("Expected AcceptDuel to be rejected before the pick, got %t, %v", ok, err)
+	}
+	if ok, err := repo.SetChallengerPick(ctx, duel.ID, true); err != nil || !ok {
+		t.Fatalf("SetChallengerPick failed: %t, %v", ok, err)
+	}
+	if ok, _ := repo.SetChallengerPick(ctx, duel.ID, false); ok {
+		t.Error("Expected a second pick to be rejected")
+	}
+	if ok, err := repo.AcceptDuel(ctx, duel.ID, false); err != nil || !ok {
+		t.Fatalf("AcceptDuel failed: %t, %v", ok, err)
+	}
+	if ok, _ := repo.DeclineDuel(ctx, duel.ID); ok {
+		t.Error("Expected an active duel not to be declinable")
+	}
+
+	// Each participant reports once, outsiders can't report
+	if ok, err := repo.ReportDuelOutcome(ctx, duel.ID, 1, true); err != nil || !ok {
+		t.Fatalf("ReportDuelOutcome failed: %t, %v", ok, err)
+	}
+	if ok, _ := repo.ReportDuelOutcome(ctx, duel.ID, 1, false); ok {
+		t.Error("Expected a repeated report to be rejected")
+	}
+	if ok, _ := repo.ReportDuelOutcome(ctx, duel.ID, 3, false); ok {
+		t.Error("Expected a report by an outsider to be rejected")
+	}
+	if ok, err := repo.ReportDuelOutcome(ctx, duel.ID, 2, true); err != nil || !ok {
+		t.Fatalf("ReportDuelOutcome failed: %t, %v", ok, err)
+	}
+
+	stored, err := repo.GetDuel(ctx, duel.ID)
+	if err != nil {
+		t.Fatalf("GetDuel failed: %v", err)
+	}
+	if stored.Status != domain.DuelStatusActive || stored.ChallengerPick == nil || !*stored.ChallengerPick ||
+		stored.OpponentPick == nil || *stored.OpponentPick || stored.ChallengerReport == nil || !*stored.ChallengerReport ||
+		stored.OpponentReport == nil || !*stored.OpponentReport || stored.Outcome != nil {
+		t.Fatalf("Unexpected stored duel: %+v", stored)
+	}
+	if !stored.Deadline.Equal(duel.Deadline) || stored.Question != duel.Question {
+		t.Errorf("Expected deadline %v and question %q, got %v and %q", duel.Deadline, duel.Question, stored.Deadline, stored.Question)
+	}
+
+	// Resolving updates both records once
+	stored.Status = domain.DuelStatusResolved
+	stored.Outcome = stored.ChallengerReport
+	if ok, err := repo.FinishDuel(ctx, stored); err != nil || !ok {
+		t.Fatalf("FinishDuel failed: %t, %v", ok, err)
+	}
+	if ok, _ := repo.FinishDuel(ctx, stored); ok {
+		t.Error("Expected a finished duel not to be finished again")
+	}
+
+	winner, err := repo.GetDuelRecord(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetDuelRecord failed: %v", err)
+	}
+	if winner.Wins != 1 || winner.Losses != 0 || winner.Draws != 0 {
+		t.Errorf("Expected 1 win for the challenger, got %+v", winner)
+	}
+	loser, _ := repo.GetDuelRecord(ctx, 2)
+	if loser.Wins != 0 || loser.Losses != 1 || loser.Draws != 0 {
+		t.Errorf("Expected 1 loss for the opponent, got %+v", loser)
+	}
+	empty, _ := repo.GetDuelRecord(ctx, 3)
+	if empty.UserID != 3 || empty.Wins+empty.Losses+empty.Draws != 0 {
+		t.Errorf("Expected an empty record for user 3, got %+v", empty)
+	}
+}
+
+func TestDuelRepository_GetOverdueDuels(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewDuelRepository(queue)
+	now := time.Now().UTC()
+
+	create := func(question string, deadline time.Time, status domain.DuelStatus) *domain.Duel {
+		t.Helper()
+		duel := &domain.Duel{ChallengerID: 1, OpponentID: 2, Question: question, Deadline: deadline, Status: status, CreatedAt: now}
+		if err := repo.CreateDuel(ctx, duel); err != nil {
+			t.Fatalf("CreateDuel failed: %v", err)
+		}
+		return duel
+	}
+
+	overduePending := create("overdue pending", now.Add(-time.Hour), domain.DuelStatusPending)
+	create("open pending", now.Add(time.Hour), domain.DuelStatusPending)
+	create("active in report window", now.Add(-time.Hour), domain.DuelStatusActive)
+	overdueActive := create("overdue active", now.Add(-8*24*time.Hour), domain.DuelStatusActive)
+	create("declined", now.Add(-8*24*time.Hour), domain.DuelStatusDeclined)
+
+	duels, err := repo.GetOverdueDuels(ctx, now, now.Add(-domain.DuelReportWindow))
+	if err != nil {
+		t.Fatalf("GetOverdueDuels failed: %v", err)
+	}
+	if len(duels) != 2 || duels[0].ID != overdueActive.ID || duels[1].ID != overduePending.ID {
+		t.Fatalf("Expected the overdue active and pending duels, got %+v", duels)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/ad/gitelegram-prediction-market/internal/domain"
@@ -39,11 +40,27 @@ func scanEvent(scanner interface {
 	var allowsRevoting int
 	var shuffleOptions int
 	var hideResultsUntilClose int
+	var statsMessageID sql.NullInt64
+	var pollPinned int
+	var photoFileID sql.NullString
+	var photoMessageID sql.NullInt64
+	var reminderOffsets string
+	var countdownMessageID sql.NullInt64
+	var pollMessageMissing int
+	var votingClosedAt sql.NullTime
+	var resolutionNote sql.NullString
+	var minorityCorrect int
+	var lockVotesAt sql.NullTime
+	var quizAnswer sql.NullInt64
+	var resolvedAt sql.NullTime
 
 	err := scanner.Scan(
 		&event.ID, &event.GroupID, &forumTopicID, &event.Question, &optionsJSON, &event.CreatedAt,
 		&event.Deadline, &event.Status, &event.EventType, &correctOption, &event.CreatedBy, &pollID, &pollMessageID,
-		&allowsRevoting, &shuffleOptions, &hideResultsUntilClose,
+		&allowsRevoting, &shuffleOptions, &hideResultsUntilClose, &statsMessageID, &pollPinned,
+		&photoFileID, &photoMessageID, &reminderOffsets, &countdownMessageID,
+		&pollMessageMissing, &votingClosedAt, &resolutionNote, &minorityCorrect, &lockVotesAt, &quizAnswer,
+		&resolvedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -75,26 +92,170 @@ func scanEvent(scanner interface {
 	event.ShuffleOptions = shuffleOptions != 0
 	event.HideResultsUntilClose = hideResultsUntilClose != 0
 
+	if statsMessageID.Valid {
+		event.StatsMessageID = int(statsMessageID.Int64)
+	}
+
+	event.PollPinned = pollPinned != 0
+
+	if photoFileID.Valid {
+		event.PhotoFileID = photoFileID.String
+	}
+
+	if photoMessageID.Valid {
+		event.PhotoMessageID = int(photoMessageID.Int64)
+	}
+
+	if countdownMessageID.Valid {
+		event.CountdownMessageID = int(countdownMessageID.Int64)
+	}
+
+	event.PollMessageMissing = pollMessageMissing != 0
+
+	if votingClosedAt.Valid {
+		val := votingClosedAt.Time
+		event.VotingClosedAt = &val
+	}
+
+	if resolutionNote.Valid {
+		event.ResolutionNote = resolutionNote.String
+	}
+
+	event.MinorityCorrect = minorityCorrect != 0
+
+	if lockVotesAt.Valid {
+		val := lockVotesAt.Time
+		event.LockVotesAt = &val
+	}
+
+	if quizAnswer.Valid {
+		val := int(quizAnswer.Int64)
+		event.QuizAnswer = &val
+	}
+
+	if resolvedAt.Valid {
+		val := resolvedAt.Time
+		event.ResolvedAt = &val
+	}
+
+	if reminderOffsets != "" {
+		event.ReminderOffsets, err = domain.ParseReminderOffsets(reminderOffsets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &event, nil
 }
 
 // eventSelectColumns returns the standard SELECT columns for events
-const eventSelectColumns = `id, group_id, forum_topic_id, question, options_json, created_at, deadline, status, event_type, correct_option, created_by, poll_id, poll_message_id, allows_revoting, shuffle_options, hide_results_until_close`
+const eventSelectColumns = `id, group_id, forum_topic_id, question, options_json, created_at, deadline, status, event_type, correct_option, created_by, poll_id, poll_message_id, allows_revoting, shuffle_options, hide_results_until_close, stats_message_id, poll_pinned, photo_file_id, photo_message_id, reminder_offsets, countdown_message_id, poll_message_missing, voting_closed_at, resolution_note, minority_correct, lock_votes_at, quiz_answer, resolved_at`
 
-// CreateEvent creates a new event in the database
+// queryEvents runs an event SELECT query and loads the participants of the returned events
+func queryEvents(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]*domain.Event, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*domain.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	// Close rows before querying participants on the same connection
+	_ = rows.Close()
+
+	if err := loadEventParticipants(ctx, db, events...); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// participantsQueryChunkSize is the number of events whose participants are loaded per query,
+// well below SQLite's limit on bound variables
+const participantsQueryChunkSize = 500
+
+// loadEventParticipants fills the participant allow-lists of the given events,
+// querying them in chunks of participantsQueryChunkSize events
+func loadEventParticipants(ctx context.Context, db *sql.DB, events ...*domain.Event) error {
+	for start := 0; start < len(events); start += participantsQueryChunkSize {
+		end := start + participantsQueryChunkSize
+		if end > len(events) {
+			end = len(events)
+		}
+		if err := loadEventParticipantsChunk(ctx, db, events[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadEventParticipantsChunk fills the participant allow-lists of events with a single query
+func loadEventParticipantsChunk(ctx context.Context, db *sql.DB, events []*domain.Event) error {
+	byID := make(map[int64]*domain.Event, len(events))
+	placeholders := make([]string, 0, len(events))
+	args := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		event.Participants = nil
+		byID[event.ID] = event
+		placeholders = append(placeholders, "?")
+		args = append(args, event.ID)
+	}
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT event_id, user_id FROM event_participants WHERE event_id IN (`+strings.Join(placeholders, ", ")+`) ORDER BY event_id, user_id`,
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var eventID, userID int64
+		if err := rows.Scan(&eventID, &userID); err != nil {
+			return err
+		}
+		if event, ok := byID[eventID]; ok {
+			event.Participants = append(event.Participants, userID)
+		}
+	}
+
+	return rows.Err()
+}
+
+// CreateEvent creates a new event in the database together with its participant allow-list
 func (r *EventRepository) CreateEvent(ctx context.Context, event *domain.Event) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		optionsJSON, err := json.Marshal(event.Options)
 		if err != nil {
 			return err
 		}
 
-		result, err := db.ExecContext(ctx,
-			`INSERT INTO events (group_id, forum_topic_id, question, options_json, created_at, deadline, status, event_type, created_by, poll_id, poll_message_id, allows_revoting, shuffle_options, hide_results_until_close)
-			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO events (group_id, forum_topic_id, question, options_json, created_at, deadline, status, event_type, created_by, poll_id, poll_message_id, allows_revoting, shuffle_options, hide_results_until_close, stats_message_id, poll_pinned, photo_file_id, photo_message_id, reminder_offsets, lock_votes_at, quiz_answer)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			event.GroupID, event.ForumTopicID, event.Question, optionsJSON, event.CreatedAt, event.Deadline,
 			event.Status, event.EventType, event.CreatedBy, event.PollID, event.PollMessageID,
 			boolToInt(event.AllowsRevoting), boolToInt(event.ShuffleOptions), boolToInt(event.HideResultsUntilClose),
+			event.StatsMessageID, boolToInt(event.PollPinned), event.PhotoFileID, event.PhotoMessageID,
+			domain.FormatReminderOffsets(event.ReminderOffsets), event.LockVotesAt, event.QuizAnswer,
 		)
 		if err != nil {
 			return err
@@ -104,6 +265,20 @@ func (r *EventRepository) CreateEvent(ctx context.Context, event *domain.Event)
 		if err != nil {
 			return err
 		}
+
+		for _, userID := range event.Participants {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT OR IGNORE INTO event_participants (event_id, user_id) VALUES (?, ?)`,
+				id, userID,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
 		event.ID = id
 		return nil
 	})
@@ -113,14 +288,17 @@ func (r *EventRepository) CreateEvent(ctx context.Context, event *domain.Event)
 func (r *EventRepository) GetEvent(ctx context.Context, eventID int64) (*domain.Event, error) {
 	var event *domain.Event
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		row := db.QueryRowContext(ctx,
 			`SELECT `+eventSelectColumns+` FROM events WHERE id = ?`,
 			eventID,
 		)
 		var err error
 		event, err = scanEvent(row)
-		return err
+		if err != nil {
+			return err
+		}
+		return loadEventParticipants(ctx, db, event)
 	})
 
 	if err != nil {
@@ -134,25 +312,37 @@ func (r *EventRepository) GetEvent(ctx context.Context, eventID int64) (*domain.
 func (r *EventRepository) GetActiveEvents(ctx context.Context, groupID int64) ([]*domain.Event, error) {
 	var events []*domain.Event
 
-	err := r.queue.Execute(func(db *sql.DB) error {
-		rows, err := db.QueryContext(ctx,
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		events, err = queryEvents(ctx, db,
 			`SELECT `+eventSelectColumns+` FROM events WHERE status = ? AND group_id = ? ORDER BY created_at DESC`,
 			domain.EventStatusActive, groupID,
 		)
-		if err != nil {
-			return err
-		}
-		defer func() { _ = rows.Close() }()
+		return err
+	})
 
-		for rows.Next() {
-			event, err := scanEvent(rows)
-			if err != nil {
-				return err
-			}
-			events = append(events, event)
-		}
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetVisibleActiveEvents retrieves active events of a group that a user may see:
+// events without a participant allow-list and events listing the user as a participant
+func (r *EventRepository) GetVisibleActiveEvents(ctx context.Context, groupID int64, userID int64) ([]*domain.Event, error) {
+	var events []*domain.Event
 
-		return rows.Err()
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		events, err = queryEvents(ctx, db,
+			`SELECT `+eventSelectColumns+` FROM events WHERE status = ? AND group_id = ?
+			 AND (NOT EXISTS (SELECT 1 FROM event_participants ep WHERE ep.event_id = events.id)
+			      OR EXISTS (SELECT 1 FROM event_participants ep WHERE ep.event_id = events.id AND ep.user_id = ?))
+			 ORDER BY created_at DESC`,
+			domain.EventStatusActive, groupID, userID,
+		)
+		return err
 	})
 
 	if err != nil {
@@ -162,9 +352,36 @@ func (r *EventRepository) GetActiveEvents(ctx context.Context, groupID int64) ([
 	return events, nil
 }
 
-// UpdateEvent updates an existing event
+// FindSimilarActiveEvent returns the most recently created active event of a group whose question
+// matches after domain.NormalizeQuestion and whose deadline is within tolerance of the given one.
+// It returns nil when there is no such event.
+func (r *EventRepository) FindSimilarActiveEvent(ctx context.Context, groupID int64, question string, deadline time.Time, tolerance time.Duration) (*domain.Event, error) {
+	events, err := r.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := domain.NormalizeQuestion(question)
+	for _, event := range events {
+		if domain.NormalizeQuestion(event.Question) != normalized {
+			continue
+		}
+		diff := event.Deadline.Sub(deadline)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= tolerance {
+			return event, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// UpdateEvent updates an existing event.
+// Custom reminders that were not sent yet are moved along with the deadline.
 func (r *EventRepository) UpdateEvent(ctx context.Context, event *domain.Event) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		optionsJSON, err := json.Marshal(event.Options)
 		if err != nil {
 			return err
@@ -175,51 +392,229 @@ func (r *EventRepository) UpdateEvent(ctx context.Context, event *domain.Event)
 			correctOption = *event.CorrectOption
 		}
 
-		_, err = db.ExecContext(ctx,
-			`UPDATE events SET group_id = ?, forum_topic_id = ?, question = ?, options_json = ?, deadline = ?, status = ?, correct_option = ?, poll_id = ?, poll_message_id = ?, allows_revoting = ?, shuffle_options = ?, hide_results_until_close = ?
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE events SET group_id = ?, forum_topic_id = ?, question = ?, options_json = ?, deadline = ?, status = ?, correct_option = ?, poll_id = ?, poll_message_id = ?, allows_revoting = ?, shuffle_options = ?, hide_results_until_close = ?, poll_pinned = ?, photo_file_id = ?, photo_message_id = ?, reminder_offsets = ?, lock_votes_at = ?, quiz_answer = ?
 			 WHERE id = ?`,
 			event.GroupID, event.ForumTopicID, event.Question, optionsJSON, event.Deadline, event.Status, correctOption, event.PollID, event.PollMessageID,
-			boolToInt(event.AllowsRevoting), boolToInt(event.ShuffleOptions), boolToInt(event.HideResultsUntilClose),
+			boolToInt(event.AllowsRevoting), boolToInt(event.ShuffleOptions), boolToInt(event.HideResultsUntilClose), boolToInt(event.PollPinned),
+			event.PhotoFileID, event.PhotoMessageID, domain.FormatReminderOffsets(event.ReminderOffsets), event.LockVotesAt, event.QuizAnswer,
 			event.ID,
 		)
+		if err != nil {
+			return err
+		}
+
+		for _, offset := range event.ReminderOffsets {
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE event_reminders SET remind_at = ? WHERE event_id = ? AND offset_seconds = ? AND sent_at IS NULL`,
+				event.Deadline.Add(-offset), event.ID, int64(offset/time.Second),
+			); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit()
+	})
+}
+
+// UpdateEventCreator reassigns the creator (owner) of an event
+func (r *EventRepository) UpdateEventCreator(ctx context.Context, eventID int64, createdBy int64) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE events SET created_by = ? WHERE id = ?`, createdBy, eventID)
 		return err
 	})
 }
 
-// ResolveEvent marks an event as resolved with the correct option
-func (r *EventRepository) ResolveEvent(ctx context.Context, eventID int64, correctOption int) error {
-	return r.queue.Execute(func(db *sql.DB) error {
-		_, err := db.ExecContext(ctx,
-			`UPDATE events SET status = ?, correct_option = ? WHERE id = ?`,
-			domain.EventStatusResolved, correctOption, eventID,
+// SetCountdownMessageID stores the countdown companion message of an event.
+// It is not written by UpdateEvent, so concurrent updates from stale event copies cannot reset it.
+func (r *EventRepository) SetCountdownMessageID(ctx context.Context, eventID int64, messageID int) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE events SET countdown_message_id = ? WHERE id = ?`, messageID, eventID)
+		return err
+	})
+}
+
+// SetStatsMessageID stores the live stats companion message of an event.
+// It is not written by UpdateEvent, so a stats sync can't write back a stale copy of the event.
+func (r *EventRepository) SetStatsMessageID(ctx context.Context, eventID int64, messageID int) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE events SET stats_message_id = ? WHERE id = ?`, messageID, eventID)
+		return err
+	})
+}
+
+// SetPollMessageMissing flags or clears an event whose poll message no longer exists in the chat
+func (r *EventRepository) SetPollMessageMissing(ctx context.Context, eventID int64, missing bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE events SET poll_message_missing = ? WHERE id = ?`, boolToInt(missing), eventID)
+		return err
+	})
+}
+
+// GetPollMissingEvents retrieves events flagged with a missing poll message, newest first
+func (r *EventRepository) GetPollMissingEvents(ctx context.Context) ([]*domain.Event, error) {
+	var events []*domain.Event
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		events, err = queryEvents(ctx, db,
+			`SELECT `+eventSelectColumns+` FROM events WHERE poll_message_missing = 1 ORDER BY created_at DESC`,
 		)
 		return err
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
 }
 
-// GetEventsByDeadlineRange retrieves events with deadline in the specified range
-func (r *EventRepository) GetEventsByDeadlineRange(ctx context.Context, start, end time.Time) ([]*domain.Event, error) {
+// CloseVoting stops accepting votes for an active event
+func (r *EventRepository) CloseVoting(ctx context.Context, eventID int64, closedAt time.Time) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`UPDATE events SET voting_closed_at = ? WHERE id = ? AND status = ? AND voting_closed_at IS NULL`,
+			closedAt, eventID, domain.EventStatusActive,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrVotingClosed
+		}
+		return nil
+	})
+}
+
+// ActivatePendingEvent stores the published poll of an event waiting for approval and makes it active
+func (r *EventRepository) ActivatePendingEvent(ctx context.Context, event *domain.Event) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`UPDATE events SET status = ?, poll_id = ?, poll_message_id = ?, poll_pinned = ?, photo_message_id = ?
+			 WHERE id = ? AND status = ?`,
+			domain.EventStatusActive, event.PollID, event.PollMessageID, boolToInt(event.PollPinned), event.PhotoMessageID,
+			event.ID, domain.EventStatusPendingApproval,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrEventNotPending
+		}
+		return nil
+	})
+}
+
+// RejectPendingEvent cancels an event waiting for approval
+func (r *EventRepository) RejectPendingEvent(ctx context.Context, eventID int64) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`UPDATE events SET status = ? WHERE id = ? AND status = ?`,
+			domain.EventStatusCancelled, eventID, domain.EventStatusPendingApproval,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrEventNotPending
+		}
+		return nil
+	})
+}
+
+// GetCountdownEvents retrieves active events and events whose countdown message still shows a running countdown
+func (r *EventRepository) GetCountdownEvents(ctx context.Context) ([]*domain.Event, error) {
 	var events []*domain.Event
 
-	err := r.queue.Execute(func(db *sql.DB) error {
-		rows, err := db.QueryContext(ctx,
-			`SELECT `+eventSelectColumns+` FROM events WHERE deadline BETWEEN ? AND ? ORDER BY deadline ASC`,
-			start, end,
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		events, err = queryEvents(ctx, db,
+			`SELECT `+eventSelectColumns+` FROM events WHERE status = ? OR countdown_message_id > 0 ORDER BY deadline ASC`,
+			domain.EventStatusActive,
+		)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ResolveEvent marks an event as resolved with the correct option and records the resolution time
+func (r *EventRepository) ResolveEvent(ctx context.Context, eventID int64, correctOption int) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		// The status check and the update are one statement, so a concurrent resolution cannot slip in between
+		result, err := db.ExecContext(ctx,
+			`UPDATE events SET status = ?, correct_option = ?, resolved_at = ? WHERE id = ? AND status = ?`,
+			domain.EventStatusResolved, correctOption, time.Now(), eventID, domain.EventStatusActive,
 		)
 		if err != nil {
 			return err
 		}
-		defer func() { _ = rows.Close() }()
 
-		for rows.Next() {
-			event, err := scanEvent(rows)
-			if err != nil {
-				return err
-			}
-			events = append(events, event)
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domain.ErrEventAlreadyResolved
 		}
+		return nil
+	})
+}
+
+// SetResolutionNote stores the evidence or source given for the outcome of an event.
+// Like the countdown message, it is not written by UpdateEvent.
+func (r *EventRepository) SetResolutionNote(ctx context.Context, eventID int64, note string) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE events SET resolution_note = ? WHERE id = ?`, note, eventID)
+		return err
+	})
+}
+
+// SetMinorityCorrect records whether the correct option of a resolved event was a minority pick
+func (r *EventRepository) SetMinorityCorrect(ctx context.Context, eventID int64, minority bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE events SET minority_correct = ? WHERE id = ?`, boolToInt(minority), eventID)
+		return err
+	})
+}
+
+// GetEventsByDeadlineRange retrieves events with deadline in the specified range
+func (r *EventRepository) GetEventsByDeadlineRange(ctx context.Context, start, end time.Time) ([]*domain.Event, error) {
+	var events []*domain.Event
 
-		return rows.Err()
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		events, err = queryEvents(ctx, db,
+			`SELECT `+eventSelectColumns+` FROM events WHERE deadline BETWEEN ? AND ? ORDER BY deadline ASC`,
+			start, end,
+		)
+		return err
 	})
 
 	if err != nil {
@@ -233,14 +628,17 @@ func (r *EventRepository) GetEventsByDeadlineRange(ctx context.Context, start, e
 func (r *EventRepository) GetEventByPollID(ctx context.Context, pollID string) (*domain.Event, error) {
 	var event *domain.Event
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		row := db.QueryRowContext(ctx,
 			`SELECT `+eventSelectColumns+` FROM events WHERE poll_id = ?`,
 			pollID,
 		)
 		var err error
 		event, err = scanEvent(row)
-		return err
+		if err != nil {
+			return err
+		}
+		return loadEventParticipants(ctx, db, event)
 	})
 
 	if err == sql.ErrNoRows {
@@ -253,29 +651,62 @@ func (r *EventRepository) GetEventByPollID(ctx context.Context, pollID string) (
 	return event, nil
 }
 
-// GetResolvedEvents retrieves all resolved events
+// GetResolvedEvents retrieves all resolved events, including archived ones
 func (r *EventRepository) GetResolvedEvents(ctx context.Context) ([]*domain.Event, error) {
 	var events []*domain.Event
 
-	err := r.queue.Execute(func(db *sql.DB) error {
-		rows, err := db.QueryContext(ctx,
-			`SELECT `+eventSelectColumns+` FROM events WHERE status = ? ORDER BY created_at DESC`,
-			domain.EventStatusResolved,
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		events, err = queryEvents(ctx, db,
+			`SELECT `+eventSelectColumns+` FROM events WHERE status IN (?, ?) ORDER BY created_at DESC`,
+			domain.EventStatusResolved, domain.EventStatusArchived,
+		)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ArchiveResolvedOlderThan marks resolved events of a group with deadline before cutoff as archived
+// and returns the number of archived events
+func (r *EventRepository) ArchiveResolvedOlderThan(ctx context.Context, groupID int64, cutoff time.Time) (int64, error) {
+	var count int64
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`UPDATE events SET status = ? WHERE group_id = ? AND status = ? AND deadline < ?`,
+			domain.EventStatusArchived, groupID, domain.EventStatusResolved, cutoff,
 		)
 		if err != nil {
 			return err
 		}
-		defer func() { _ = rows.Close() }()
 
-		for rows.Next() {
-			event, err := scanEvent(rows)
-			if err != nil {
-				return err
-			}
-			events = append(events, event)
-		}
+		count, err = result.RowsAffected()
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
 
-		return rows.Err()
+	return count, nil
+}
+
+// GetArchivedEvents retrieves archived events for a specific group, most recent deadline first
+func (r *EventRepository) GetArchivedEvents(ctx context.Context, groupID int64, limit, offset int) ([]*domain.Event, error) {
+	var events []*domain.Event
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		events, err = queryEvents(ctx, db,
+			`SELECT `+eventSelectColumns+` FROM events WHERE status = ? AND group_id = ? ORDER BY deadline DESC, id DESC LIMIT ? OFFSET ?`,
+			domain.EventStatusArchived, groupID, limit, offset,
+		)
+		return err
 	})
 
 	if err != nil {
@@ -285,14 +716,25 @@ func (r *EventRepository) GetResolvedEvents(ctx context.Context) ([]*domain.Even
 	return events, nil
 }
 
-// GetUserCreatedEventsCount counts events created by user in a specific group
+// UnarchiveEvent restores an archived event back to resolved status
+func (r *EventRepository) UnarchiveEvent(ctx context.Context, eventID int64) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`UPDATE events SET status = ? WHERE id = ? AND status = ?`,
+			domain.EventStatusResolved, eventID, domain.EventStatusArchived,
+		)
+		return err
+	})
+}
+
+// GetUserCreatedEventsCount counts events created by user in a specific group (events waiting for approval are not counted)
 func (r *EventRepository) GetUserCreatedEventsCount(ctx context.Context, userID int64, groupID int64) (int, error) {
 	var count int
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
-			`SELECT COUNT(*) FROM events WHERE created_by = ? AND group_id = ?`,
-			userID, groupID,
+			`SELECT COUNT(*) FROM events WHERE created_by = ? AND group_id = ? AND status != ?`,
+			userID, groupID, domain.EventStatusPendingApproval,
 		).Scan(&count)
 	})
 
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -501,3 +502,851 @@ func TestGetUserCreatedEventsCount(t *testing.T) {
 		}
 	})
 }
+
+func TestArchiveResolvedOlderThan(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	predictionRepo := NewPredictionRepository(queue)
+	groupID := int64(1)
+	userID := int64(100)
+	now := time.Now()
+
+	newEvent := func(groupID int64, deadline time.Time, status domain.EventStatus) *domain.Event {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  "Question?",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: deadline.Add(-24 * time.Hour),
+			Deadline:  deadline,
+			Status:    status,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: userID,
+		}
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: userID, Option: 0, Timestamp: event.CreatedAt}); err != nil {
+			t.Fatalf("Failed to save prediction: %v", err)
+		}
+		return event
+	}
+
+	oldResolved := newEvent(groupID, now.Add(-60*24*time.Hour), domain.EventStatusResolved)
+	recentResolved := newEvent(groupID, now.Add(-5*24*time.Hour), domain.EventStatusResolved)
+	oldActive := newEvent(groupID, now.Add(-60*24*time.Hour), domain.EventStatusActive)
+	otherGroupResolved := newEvent(groupID+1, now.Add(-60*24*time.Hour), domain.EventStatusResolved)
+
+	count, err := repo.ArchiveResolvedOlderThan(ctx, groupID, now.Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("ArchiveResolvedOlderThan failed: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("Expected 1 archived event, got %d", count)
+	}
+
+	assertStatus := func(eventID int64, expected domain.EventStatus) {
+		t.Helper()
+		event, err := repo.GetEvent(ctx, eventID)
+		if err != nil {
+			t.Fatalf("Failed to get event: %v", err)
+		}
+		if event.Status != expected {
+			t.Errorf("Expected event %d status %s, got %s", eventID, expected, event.Status)
+		}
+	}
+	assertStatus(oldResolved.ID, domain.EventStatusArchived)
+	assertStatus(recentResolved.ID, domain.EventStatusResolved)
+	assertStatus(oldActive.ID, domain.EventStatusActive)
+	assertStatus(otherGroupResolved.ID, domain.EventStatusResolved)
+
+	// Archived events are browsable
+	archived, err := repo.GetArchivedEvents(ctx, groupID, 10, 0)
+	if err != nil {
+		t.Fatalf("GetArchivedEvents failed: %v", err)
+	}
+	if len(archived) != 1 || archived[0].ID != oldResolved.ID {
+		t.Errorf("Expected archived list to contain event %d, got %v", oldResolved.ID, archived)
+	}
+
+	// Archived events still count toward historical stats
+	completed, err := predictionRepo.GetUserCompletedEventCount(ctx, userID, groupID)
+	if err != nil {
+		t.Fatalf("GetUserCompletedEventCount failed: %v", err)
+	}
+	if completed != 2 {
+		t.Errorf("Expected 2 completed events (resolved + archived), got %d", completed)
+	}
+
+	resolved, err := repo.GetResolvedEvents(ctx)
+	if err != nil {
+		t.Fatalf("GetResolvedEvents failed: %v", err)
+	}
+	if len(resolved) != 3 {
+		t.Errorf("Expected GetResolvedEvents to include archived events of every group, got %d", len(resolved))
+	}
+
+	// Archival is reversible
+	if err := repo.UnarchiveEvent(ctx, oldResolved.ID); err != nil {
+		t.Fatalf("UnarchiveEvent failed: %v", err)
+	}
+	assertStatus(oldResolved.ID, domain.EventStatusResolved)
+
+	// Unarchiving a non-archived event does not change it
+	if err := repo.UnarchiveEvent(ctx, oldActive.ID); err != nil {
+		t.Fatalf("UnarchiveEvent failed: %v", err)
+	}
+	assertStatus(oldActive.ID, domain.EventStatusActive)
+}
+
+func TestEventParticipants(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	groupID := int64(1)
+	now := time.Now()
+
+	newEvent := func(question string, participants []int64) *domain.Event {
+		event := &domain.Event{
+			GroupID:      groupID,
+			Question:     question,
+			Options:      []string{"Yes", "No"},
+			CreatedAt:    now,
+			Deadline:     now.Add(24 * time.Hour),
+			Status:       domain.EventStatusActive,
+			EventType:    domain.EventTypeBinary,
+			CreatedBy:    100,
+			PollID:       "poll_" + question,
+			Participants: participants,
+		}
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		return event
+	}
+
+	open := newEvent("open", nil)
+	restricted := newEvent("restricted", []int64{200, 300, 200})
+
+	loaded, err := repo.GetEvent(ctx, restricted.ID)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if len(loaded.Participants) != 2 || !loaded.IsParticipant(200) || !loaded.IsParticipant(300) {
+		t.Errorf("Expected participants [200 300], got %v", loaded.Participants)
+	}
+
+	byPoll, err := repo.GetEventByPollID(ctx, restricted.PollID)
+	if err != nil {
+		t.Fatalf("GetEventByPollID failed: %v", err)
+	}
+	if !byPoll.IsRestricted() {
+		t.Error("Expected event loaded by poll ID to be restricted")
+	}
+
+	active, err := repo.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		t.Fatalf("GetActiveEvents failed: %v", err)
+	}
+	if len(active) != 2 {
+		t.Fatalf("Expected 2 active events, got %d", len(active))
+	}
+	for _, event := range active {
+		if event.ID == open.ID && event.IsRestricted() {
+			t.Errorf("Expected open event to have no participants, got %v", event.Participants)
+		}
+		if event.ID == restricted.ID && len(event.Participants) != 2 {
+			t.Errorf("Expected restricted event to have 2 participants, got %v", event.Participants)
+		}
+	}
+
+	tests := []struct {
+		userID   int64
+		expected int
+	}{
+		{200, 2}, // participant sees both events
+		{400, 1}, // other member sees only the open event
+	}
+	for _, tt := range tests {
+		visible, err := repo.GetVisibleActiveEvents(ctx, groupID, tt.userID)
+		if err != nil {
+			t.Fatalf("GetVisibleActiveEvents failed: %v", err)
+		}
+		if len(visible) != tt.expected {
+			t.Errorf("Expected user %d to see %d events, got %d", tt.userID, tt.expected, len(visible))
+		}
+	}
+}
+
+func TestEventPhotoRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	now := time.Now()
+
+	event := &domain.Event{
+		GroupID:        1,
+		Question:       "Will the chart go up?",
+		Options:        []string{"Yes", "No"},
+		CreatedAt:      now,
+		Deadline:       now.Add(24 * time.Hour),
+		Status:         domain.EventStatusActive,
+		EventType:      domain.EventTypeBinary,
+		CreatedBy:      100,
+		PollID:         "poll_photo",
+		PhotoFileID:    "photo-file-id",
+		PhotoMessageID: 41,
+	}
+	if err := repo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	loaded, err := repo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if loaded.PhotoFileID != "photo-file-id" || loaded.PhotoMessageID != 41 {
+		t.Errorf("expected photo photo-file-id/41, got %q/%d", loaded.PhotoFileID, loaded.PhotoMessageID)
+	}
+
+	// A re-post keeps the file_id but gets a new photo message
+	loaded.PhotoMessageID = 77
+	if err := repo.UpdateEvent(ctx, loaded); err != nil {
+		t.Fatalf("Failed to update event: %v", err)
+	}
+	loaded, err = repo.GetEventByPollID(ctx, "poll_photo")
+	if err != nil {
+		t.Fatalf("Failed to get event by poll ID: %v", err)
+	}
+	if loaded.PhotoFileID != "photo-file-id" || loaded.PhotoMessageID != 77 {
+		t.Errorf("expected photo photo-file-id/77 after update, got %q/%d", loaded.PhotoFileID, loaded.PhotoMessageID)
+	}
+
+	// Events without a photo load with empty fields
+	plain := &domain.Event{
+		GroupID:   1,
+		Question:  "No photo here?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: now,
+		Deadline:  now.Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: 100,
+		PollID:    "poll_plain",
+	}
+	if err := repo.CreateEvent(ctx, plain); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	loaded, err = repo.GetEvent(ctx, plain.ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if loaded.PhotoFileID != "" || loaded.PhotoMessageID != 0 {
+		t.Errorf("expected no photo, got %q/%d", loaded.PhotoFileID, loaded.PhotoMessageID)
+	}
+}
+
+func TestPollMessageMissingFlag(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	now := time.Now()
+
+	var ids []int64
+	for i := 0; i < 2; i++ {
+		event := &domain.Event{
+			GroupID:   1,
+			Question:  "Will it rain?",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now.Add(time.Duration(i) * time.Minute),
+			Deadline:  now.Add(24 * time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 100,
+		}
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		ids = append(ids, event.ID)
+	}
+
+	if err := repo.SetPollMessageMissing(ctx, ids[1], true); err != nil {
+		t.Fatalf("SetPollMessageMissing failed: %v", err)
+	}
+
+	missing, err := repo.GetPollMissingEvents(ctx)
+	if err != nil {
+		t.Fatalf("GetPollMissingEvents failed: %v", err)
+	}
+	if len(missing) != 1 || missing[0].ID != ids[1] || !missing[0].PollMessageMissing {
+		t.Fatalf("expected only event %d to be flagged, got %+v", ids[1], missing)
+	}
+
+	// A full update keeps the flag, which is only changed by SetPollMessageMissing
+	loaded, err := repo.GetEvent(ctx, ids[1])
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	loaded.PollMessageMissing = false
+	if err := repo.UpdateEvent(ctx, loaded); err != nil {
+		t.Fatalf("Failed to update event: %v", err)
+	}
+	if loaded, err = repo.GetEvent(ctx, ids[1]); err != nil || !loaded.PollMessageMissing {
+		t.Errorf("expected flag to survive UpdateEvent, got %v (err %v)", loaded.PollMessageMissing, err)
+	}
+
+	if err := repo.SetPollMessageMissing(ctx, ids[1], false); err != nil {
+		t.Fatalf("SetPollMessageMissing failed: %v", err)
+	}
+	missing, err = repo.GetPollMissingEvents(ctx)
+	if err != nil {
+		t.Fatalf("GetPollMissingEvents failed: %v", err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("expected no flagged events after clearing, got %d", len(missing))
+	}
+}
+
+func TestCloseVoting(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	now := time.Now()
+
+	var ids []int64
+	for i := 0; i < 2; i++ {
+		event := &domain.Event{
+			GroupID:   1,
+			Question:  "Will it rain?",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now,
+			Deadline:  now.Add(24 * time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 100,
+		}
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		ids = append(ids, event.ID)
+	}
+
+	if err := repo.CloseVoting(ctx, ids[0], now); err != nil {
+		t.Fatalf("CloseVoting failed: %v", err)
+	}
+	loaded, err := repo.GetEvent(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if loaded.VotingClosedAt == nil || !loaded.VotingClosedAt.Equal(now) {
+		t.Fatalf("expected voting closed at %v, got %v", now, loaded.VotingClosedAt)
+	}
+	if loaded.Status != domain.EventStatusActive || loaded.IsVotingOpen(now) {
+		t.Errorf("expected an active event with closed voting, got status %s", loaded.Status)
+	}
+
+	// Closing twice or closing a resolved event is rejected
+	if err := repo.CloseVoting(ctx, ids[0], now); !errors.Is(err, domain.ErrVotingClosed) {
+		t.Errorf("expected ErrVotingClosed when closing twice, got %v", err)
+	}
+	if err := repo.ResolveEvent(ctx, ids[1], 0); err != nil {
+		t.Fatalf("Failed to resolve event: %v", err)
+	}
+	if err := repo.CloseVoting(ctx, ids[1], now); !errors.Is(err, domain.ErrVotingClosed) {
+		t.Errorf("expected ErrVotingClosed for a resolved event, got %v", err)
+	}
+
+	// A closed event can still be resolved
+	if err := repo.ResolveEvent(ctx, ids[0], 1); err != nil {
+		t.Errorf("expected closed event to resolve, got %v", err)
+	}
+}
+
+func TestPendingApprovalEvents(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	now := time.Now()
+
+	var events []*domain.Event
+	for i := 0; i < 2; i++ {
+		event := &domain.Event{
+			GroupID:   1,
+			Question:  "Will it rain?",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now,
+			Deadline:  now.Add(24 * time.Hour),
+			Status:    domain.EventStatusPendingApproval,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 100,
+		}
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	// Pending events are neither active nor counted as created
+	active, err := repo.GetActiveEvents(ctx, 1)
+	if err != nil {
+		t.Fatalf("Failed to get active events: %v", err)
+	}
+	if len(active) != 0 {
+		t.Errorf("expected no active events, got %d", len(active))
+	}
+	if count, err := repo.GetUserCreatedEventsCount(ctx, 100, 1); err != nil || count != 0 {
+		t.Errorf("expected pending events not to count as created, got %d (%v)", count, err)
+	}
+
+	// Approval stores the poll and activates the event, only once
+	approved := events[0]
+	approved.PollID = "poll_1"
+	approved.PollMessageID = 77
+	approved.PollPinned = true
+	if err := repo.ActivatePendingEvent(ctx, approved); err != nil {
+		t.Fatalf("ActivatePendingEvent failed: %v", err)
+	}
+	loaded, err := repo.GetEvent(ctx, approved.ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if loaded.Status != domain.EventStatusActive || loaded.PollID != "poll_1" || loaded.PollMessageID != 77 || !loaded.PollPinned {
+		t.Errorf("expected an active event with its poll, got %+v", loaded)
+	}
+	if err := repo.ActivatePendingEvent(ctx, approved); !errors.Is(err, domain.ErrEventNotPending) {
+		t.Errorf("expected ErrEventNotPending when approving twice, got %v", err)
+	}
+	if err := repo.RejectPendingEvent(ctx, approved.ID); !errors.Is(err, domain.ErrEventNotPending) {
+		t.Errorf("expected ErrEventNotPending when rejecting an approved event, got %v", err)
+	}
+
+	// Rejection cancels the event
+	if err := repo.RejectPendingEvent(ctx, events[1].ID); err != nil {
+		t.Fatalf("RejectPendingEvent failed: %v", err)
+	}
+	loaded, err = repo.GetEvent(ctx, events[1].ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if loaded.Status != domain.EventStatusCancelled {
+		t.Errorf("expected a cancelled event, got %s", loaded.Status)
+	}
+}
+
+func TestResolutionNote(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	event := &domain.Event{
+		GroupID:   1,
+		Question:  "Will it rain?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  time.Now().Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: 100,
+	}
+	if err := repo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	stored, err := repo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if stored.ResolutionNote != "" {
+		t.Errorf("Expected no resolution note on a new event, got %q", stored.ResolutionNote)
+	}
+
+	if err := repo.ResolveEvent(ctx, event.ID, 0); err != nil {
+		t.Fatalf("ResolveEvent failed: %v", err)
+	}
+	if err := repo.SetResolutionNote(ctx, event.ID, "https://weather.example/report"); err != nil {
+		t.Fatalf("SetResolutionNote failed: %v", err)
+	}
+
+	stored, err = repo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if stored.ResolutionNote != "https://weather.example/report" {
+		t.Errorf("Expected the resolution note to be stored, got %q", stored.ResolutionNote)
+	}
+}
+
+func TestEventLockVotesAtRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	now := time.Now().Truncate(time.Second)
+	lockAt := now.Add(12 * time.Hour)
+
+	event := &domain.Event{
+		GroupID:     1,
+		Question:    "Will it rain?",
+		Options:     []string{"Yes", "No"},
+		CreatedAt:   now,
+		Deadline:    now.Add(24 * time.Hour),
+		LockVotesAt: &lockAt,
+		Status:      domain.EventStatusActive,
+		EventType:   domain.EventTypeBinary,
+		CreatedBy:   100,
+		PollID:      "poll_lock",
+	}
+	if err := repo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	loaded, err := repo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if loaded.LockVotesAt == nil || !loaded.LockVotesAt.Equal(lockAt) {
+		t.Errorf("expected votes lock %v, got %v", lockAt, loaded.LockVotesAt)
+	}
+
+	// Clearing the lock lets votes change until the deadline again
+	loaded.LockVotesAt = nil
+	if err := repo.UpdateEvent(ctx, loaded); err != nil {
+		t.Fatalf("Failed to update event: %v", err)
+	}
+	loaded, err = repo.GetEventByPollID(ctx, "poll_lock")
+	if err != nil {
+		t.Fatalf("Failed to get event by poll ID: %v", err)
+	}
+	if loaded.LockVotesAt != nil {
+		t.Errorf("expected no votes lock after update, got %v", loaded.LockVotesAt)
+	}
+}
+
+func TestEventQuizAnswerRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	now := time.Now().Truncate(time.Second)
+	answer := 2
+
+	event := &domain.Event{
+		GroupID:    1,
+		Question:   "Which planet is the largest?",
+		Options:    []string{"Mars", "Venus", "Jupiter"},
+		CreatedAt:  now,
+		Deadline:   now.Add(24 * time.Hour),
+		QuizAnswer: &answer,
+		Status:     domain.EventStatusActive,
+		EventType:  domain.EventTypeMultiOption,
+		CreatedBy:  100,
+		PollID:     "poll_quiz",
+	}
+	if err := repo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	loaded, err := repo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if !loaded.IsQuiz() || *loaded.QuizAnswer != answer {
+		t.Errorf("expected quiz answer %d, got %v", answer, loaded.QuizAnswer)
+	}
+
+	loaded.QuizAnswer = nil
+	if err := repo.UpdateEvent(ctx, loaded); err != nil {
+		t.Fatalf("Failed to update event: %v", err)
+	}
+	loaded, err = repo.GetEventByPollID(ctx, "poll_quiz")
+	if err != nil {
+		t.Fatalf("Failed to get event by poll ID: %v", err)
+	}
+	if loaded.IsQuiz() {
+		t.Errorf("expected a prediction event after update, got quiz answer %v", loaded.QuizAnswer)
+	}
+}
+
+func TestFindSimilarActiveEvent(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	deadline := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	existing := &domain.Event{
+		GroupID:   1,
+		Question:  "Will it rain tomorrow?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  deadline,
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: 100,
+	}
+	if err := repo.CreateEvent(ctx, existing); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	resolved := &domain.Event{
+		GroupID:   1,
+		Question:  "Will it snow tomorrow?",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: time.Now(),
+		Deadline:  deadline,
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: 100,
+	}
+	if err := repo.CreateEvent(ctx, resolved); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	if err := repo.ResolveEvent(ctx, resolved.ID, 1); err != nil {
+		t.Fatalf("ResolveEvent failed: %v", err)
+	}
+
+	tolerance := time.Hour
+	tests := []struct {
+		name     string
+		groupID  int64
+		question string
+		deadline time.Time
+		found    bool
+	}{
+		{"exact duplicate", 1, "Will it rain tomorrow?", deadline, true},
+		{"different case and spacing", 1, "  will it   RAIN tomorrow? ", deadline, true},
+		{"deadline within tolerance", 1, "Will it rain tomorrow?", deadline.Add(-45 * time.Minute), true},
+		{"deadline outside tolerance", 1, "Will it rain tomorrow?", deadline.Add(2 * time.Hour), false},
+		{"different question", 1, "Will it rain on Friday?", deadline, false},
+		{"other group", 2, "Will it rain tomorrow?", deadline, false},
+		{"resolved event", 1, "Will it snow tomorrow?", deadline, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := repo.FindSimilarActiveEvent(ctx, tt.groupID, tt.question, tt.deadline, tolerance)
+			if err != nil {
+				t.Fatalf("FindSimilarActiveEvent failed: %v", err)
+			}
+			if !tt.found {
+				if event != nil {
+					t.Errorf("Expected no similar event, got %d", event.ID)
+				}
+				return
+			}
+			if event == nil || event.ID != existing.ID {
+				t.Errorf("Expected event %d, got %v", existing.ID, event)
+			}
+		})
+	}
+}
+
+func TestEventParticipantsAcrossQueryChunks(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewEventRepository(queue)
+	groupID := int64(1)
+	now := time.Now()
+
+	// More events than fit in one participants query, with restricted events in every chunk
+	count := 2*participantsQueryChunkSize + 1
+	restricted := map[int64]int64{}
+	for i := 0; i < count; i++ {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  fmt.Sprintf("Event %d", i),
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now,
+			Deadline:  now.Add(24 * time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 100,
+		}
+		if i%participantsQueryChunkSize == 0 {
+			event.Participants = []int64{int64(1000 + i)}
+		}
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		if event.Participants != nil {
+			restricted[event.ID] = event.Participants[0]
+		}
+	}
+
+	events, err := repo.GetActiveEvents(ctx, groupID)
+	if err != nil {
+		t.Fatalf("GetActiveEvents failed: %v", err)
+	}
+	if len(events) != count {
+		t.Fatalf("Expected %d events, got %d", count, len(events))
+	}
+	for _, event := range events {
+		userID, ok := restricted[event.ID]
+		switch {
+		case ok && (len(event.Participants) != 1 || event.Participants[0] != userID):
+			t.Errorf("Expected event %d to have participant %d, got %v", event.ID, userID, event.Participants)
+		case !ok && event.IsRestricted():
+			t.Errorf("Expected event %d to have no participants, got %v", event.ID, event.Participants)
+		}
+	}
+}
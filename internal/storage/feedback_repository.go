@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+// FeedbackRepository handles user feedback persistence
+type FeedbackRepository struct {
+	queue *DBQueue
+}
+
+// NewFeedbackRepository creates a new FeedbackRepository
+func NewFeedbackRepository(queue *DBQueue) *FeedbackRepository {
+	return &FeedbackRepository{queue: queue}
+}
+
+// SaveFeedback stores a feedback message and sets its ID
+func (r *FeedbackRepository) SaveFeedback(ctx context.Context, feedback *domain.Feedback) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`INSERT INTO feedback (user_id, display_name, text, created_at) VALUES (?, ?, ?, ?)`,
+			feedback.UserID, feedback.DisplayName, feedback.Text, feedback.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		feedback.ID = id
+		return nil
+	})
+}
+
+// CountUserFeedbackSince counts feedback messages sent by a user after the given time
+func (r *FeedbackRepository) CountUserFeedbackSince(ctx context.Context, userID int64, since time.Time) (int, error) {
+	var count int
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM feedback WHERE user_id = ? AND created_at > ?`,
+			userID, since,
+		).Scan(&count)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetRecentFeedback retrieves the most recent feedback messages, newest first
+func (r *FeedbackRepository) GetRecentFeedback(ctx context.Context, limit int) ([]*domain.Feedback, error) {
+	var feedbacks []*domain.Feedback
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT id, user_id, display_name, text, created_at
+			 FROM feedback ORDER BY created_at DESC, id DESC LIMIT ?`,
+			limit,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			var feedback domain.Feedback
+			if err := rows.Scan(&feedback.ID, &feedback.UserID, &feedback.DisplayName, &feedback.Text, &feedback.CreatedAt); err != nil {
+				return err
+			}
+			feedbacks = append(feedbacks, &feedback)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return feedbacks, nil
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+func TestFeedbackRepository(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewFeedbackRepository(queue)
+	now := time.Now()
+
+	entries := []*domain.Feedback{
+		{UserID: 1, DisplayName: "@alice", Text: "old bug", CreatedAt: now.Add(-2 * time.Hour)},
+		{UserID: 1, DisplayName: "@alice", Text: "new bug", CreatedAt: now.Add(-10 * time.Minute)},
+		{UserID: 2, DisplayName: "Bob", Text: "idea", CreatedAt: now.Add(-5 * time.Minute)},
+	}
+	for _, feedback := range entries {
+		if err := repo.SaveFeedback(ctx, feedback); err != nil {
+			t.Fatalf("SaveFeedback failed: %v", err)
+		}
+		if feedback.ID == 0 {
+			t.Fatal("Expected feedback ID to be set")
+		}
+	}
+
+	count, err := repo.CountUserFeedbackSince(ctx, 1, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("CountUserFeedbackSince failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 feedback within the last hour, got %d", count)
+	}
+
+	recent, err := repo.GetRecentFeedback(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetRecentFeedback failed: %v", err)
+	}
+	if len(recent) != 2 || recent[0].Text != "idea" || recent[1].Text != "new bug" {
+		t.Fatalf("Expected the two newest entries, got %+v", recent)
+	}
+	if recent[0].UserID != 2 || recent[0].DisplayName != "Bob" {
+		t.Errorf("Unexpected feedback data: %+v", recent[0])
+	}
+}
@@ -19,7 +19,7 @@ func NewForumTopicRepository(queue *DBQueue) *ForumTopicRepository {
 
 // CreateForumTopic creates a new forum topic in the database
 func (r *ForumTopicRepository) CreateForumTopic(ctx context.Context, topic *domain.ForumTopic) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		result, err := db.ExecContext(ctx,
 			`INSERT INTO forum_topics (group_id, message_thread_id, name, created_at, created_by) VALUES (?, ?, ?, ?, ?)`,
 			topic.GroupID, topic.MessageThreadID, topic.Name, topic.CreatedAt, topic.CreatedBy,
@@ -41,7 +41,7 @@ func (r *ForumTopicRepository) CreateForumTopic(ctx context.Context, topic *doma
 func (r *ForumTopicRepository) GetForumTopic(ctx context.Context, topicID int64) (*domain.ForumTopic, error) {
 	var topic domain.ForumTopic
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
 			`SELECT id, group_id, message_thread_id, name, created_at, created_by FROM forum_topics WHERE id = ?`,
 			topicID,
@@ -62,7 +62,7 @@ func (r *ForumTopicRepository) GetForumTopic(ctx context.Context, topicID int64)
 func (r *ForumTopicRepository) GetForumTopicByGroupAndThread(ctx context.Context, groupID int64, messageThreadID int) (*domain.ForumTopic, error) {
 	var topic domain.ForumTopic
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
 			`SELECT id, group_id, message_thread_id, name, created_at, created_by FROM forum_topics WHERE group_id = ? AND message_thread_id = ?`,
 			groupID, messageThreadID,
@@ -83,7 +83,7 @@ func (r *ForumTopicRepository) GetForumTopicByGroupAndThread(ctx context.Context
 func (r *ForumTopicRepository) GetForumTopicsByGroup(ctx context.Context, groupID int64) ([]*domain.ForumTopic, error) {
 	var topics []*domain.ForumTopic
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
 			`SELECT id, group_id, message_thread_id, name, created_at, created_by FROM forum_topics WHERE group_id = ? ORDER BY created_at DESC`,
 			groupID,
@@ -113,7 +113,7 @@ func (r *ForumTopicRepository) GetForumTopicsByGroup(ctx context.Context, groupI
 
 // DeleteForumTopic deletes a forum topic by ID
 func (r *ForumTopicRepository) DeleteForumTopic(ctx context.Context, topicID int64) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx, `DELETE FROM forum_topics WHERE id = ?`, topicID)
 		return err
 	})
@@ -121,7 +121,7 @@ func (r *ForumTopicRepository) DeleteForumTopic(ctx context.Context, topicID int
 
 // UpdateForumTopicName updates the name of a forum topic
 func (r *ForumTopicRepository) UpdateForumTopicName(ctx context.Context, topicID int64, name string) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx, `UPDATE forum_topics SET name = ? WHERE id = ?`, name, topicID)
 		return err
 	})
@@ -17,6 +17,21 @@ var (
 	ErrSessionExpired = errors.New("session expired")
 )
 
+// sessionTTL is how long a session stays valid after its last update
+const sessionTTL = 30 * time.Minute
+
+// FSMSession is a stored FSM session as read for diagnosis, including expired ones
+type FSMSession struct {
+	UserID      int64
+	State       string
+	ContextJSON string
+	// Data is the decoded context, nil when ContextJSON is corrupted
+	Data      map[string]interface{}
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Expired   bool
+}
+
 // FSMStorage implements persistent storage for FSM sessions
 type FSMStorage struct {
 	queue  *DBQueue
@@ -36,7 +51,7 @@ func (s *FSMStorage) Get(ctx context.Context, userID int64) (state string, data
 	var contextJSON string
 	var updatedAt time.Time
 
-	err = s.queue.Execute(func(db *sql.DB) error {
+	err = s.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		row := db.QueryRowContext(ctx, `
 			SELECT state, context_json, updated_at
 			FROM fsm_sessions
@@ -56,7 +71,7 @@ func (s *FSMStorage) Get(ctx context.Context, userID int64) (state string, data
 	}
 
 	// Check if session is expired (older than 30 minutes)
-	if time.Since(updatedAt) > 30*time.Minute {
+	if time.Since(updatedAt) > sessionTTL {
 		s.logger.Info("session expired", "user_id", userID, "updated_at", updatedAt)
 		// Delete expired session
 		_ = s.Delete(ctx, userID)
@@ -75,6 +90,38 @@ func (s *FSMStorage) Get(ctx context.Context, userID int64) (state string, data
 	return state, data, nil
 }
 
+// GetRaw retrieves the session of a user without expiring or deleting it, for diagnosis.
+// A corrupted context is returned as is with nil Data.
+func (s *FSMStorage) GetRaw(ctx context.Context, userID int64) (*FSMSession, error) {
+	session := &FSMSession{UserID: userID}
+
+	err := s.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		row := db.QueryRowContext(ctx, `
+			SELECT state, context_json, created_at, updated_at
+			FROM fsm_sessions
+			WHERE user_id = ?
+		`, userID)
+
+		return row.Scan(&session.State, &session.ContextJSON, &session.CreatedAt, &session.UpdatedAt)
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		s.logger.Error("failed to get raw session", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	session.Expired = time.Since(session.UpdatedAt) > sessionTTL
+	if err := json.Unmarshal([]byte(session.ContextJSON), &session.Data); err != nil {
+		s.logger.Warn("failed to unmarshal raw session context", "user_id", userID, "error", err)
+		session.Data = nil
+	}
+
+	return session, nil
+}
+
 // Set stores FSM state and context for a user using atomic transaction
 func (s *FSMStorage) Set(ctx context.Context, userID int64, state string, data map[string]interface{}) error {
 	// Get old state for logging (if exists)
@@ -88,7 +135,7 @@ func (s *FSMStorage) Set(ctx context.Context, userID int64, state string, data m
 		return err
 	}
 
-	err = s.queue.Execute(func(db *sql.DB) error {
+	err = s.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		// Use transaction for atomic update
 		tx, err := db.BeginTx(ctx, nil)
 		if err != nil {
@@ -131,7 +178,7 @@ func (s *FSMStorage) Set(ctx context.Context, userID int64, state string, data m
 // Delete removes FSM session for a user
 func (s *FSMStorage) Delete(ctx context.Context, userID int64) error {
 	var rowsAffected int64
-	err := s.queue.Execute(func(db *sql.DB) error {
+	err := s.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		// Use transaction for atomic delete
 		tx, err := db.BeginTx(ctx, nil)
 		if err != nil {
@@ -171,11 +218,27 @@ func (s *FSMStorage) Delete(ctx context.Context, userID int64) error {
 	return nil
 }
 
+// CountStale returns the number of sessions older than 30 minutes that CleanupStale would remove
+func (s *FSMStorage) CountStale(ctx context.Context) (int, error) {
+	var count int
+	err := s.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM fsm_sessions
+			WHERE updated_at < datetime('now', '-30 minutes')
+		`).Scan(&count)
+	})
+	if err != nil {
+		s.logger.Error("failed to count stale sessions", "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
 // CleanupStale removes sessions older than 30 minutes
 func (s *FSMStorage) CleanupStale(ctx context.Context) error {
 	// First, get the list of user IDs that will be deleted for detailed logging
 	var userIDs []int64
-	err := s.queue.Execute(func(db *sql.DB) error {
+	err := s.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx, `
 			SELECT user_id FROM fsm_sessions
 			WHERE updated_at < datetime('now', '-30 minutes')
@@ -206,7 +269,7 @@ func (s *FSMStorage) CleanupStale(ctx context.Context) error {
 	}
 
 	var deletedCount int64
-	err = s.queue.Execute(func(db *sql.DB) error {
+	err = s.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		// Use transaction for atomic cleanup
 		tx, err := db.BeginTx(ctx, nil)
 		if err != nil {
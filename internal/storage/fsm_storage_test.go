@@ -830,3 +830,123 @@ func formatInt64(n int64) string {
 
 	return string(digits)
 }
+
+func TestFSMStorage_GetRaw(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+
+	storage := NewFSMStorage(queue, logger.New(logger.ERROR))
+	ctx := context.Background()
+
+	if _, err := storage.GetRaw(ctx, 1); err != ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+
+	if err := storage.Set(ctx, 1, "ask_question", map[string]interface{}{"question": "Will it rain?"}); err != nil {
+		t.Fatalf("Failed to set session: %v", err)
+	}
+	session, err := storage.GetRaw(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetRaw failed: %v", err)
+	}
+	if session.State != "ask_question" || session.Expired || session.Data["question"] != "Will it rain?" {
+		t.Errorf("unexpected active session: %+v", session)
+	}
+
+	// An expired session and a corrupted one are returned without being deleted
+	err = queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO fsm_sessions (user_id, state, context_json, created_at, updated_at)
+			VALUES (2, 'resolve_select_event', '{"event_id":5}', datetime('now', '-2 hours'), datetime('now', '-1 hours')),
+			       (3, 'ask_options', '{broken', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert sessions: %v", err)
+	}
+
+	session, err = storage.GetRaw(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetRaw failed for expired session: %v", err)
+	}
+	if !session.Expired || session.State != "resolve_select_event" || session.Data["event_id"] != float64(5) {
+		t.Errorf("unexpected expired session: %+v", session)
+	}
+	if _, err := storage.GetRaw(ctx, 2); err != nil {
+		t.Errorf("expected the expired session to be kept, got %v", err)
+	}
+
+	session, err = storage.GetRaw(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetRaw failed for corrupted session: %v", err)
+	}
+	if session.Data != nil || session.ContextJSON != "{broken" {
+		t.Errorf("expected raw corrupted context, got %+v", session)
+	}
+
+	// The normal Get still expires the session
+	if _, _, err := storage.Get(ctx, 2); err != ErrSessionExpired {
+		t.Errorf("expected ErrSessionExpired from Get, got %v", err)
+	}
+	if _, err := storage.GetRaw(ctx, 2); err != ErrSessionNotFound {
+		t.Errorf("expected the expired session to be deleted by Get, got %v", err)
+	}
+}
+
+func TestFSMStorage_CountStale(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+
+	storage := NewFSMStorage(queue, logger.New(logger.ERROR))
+	ctx := context.Background()
+
+	for userID := int64(1); userID <= 3; userID++ {
+		if err := storage.Set(ctx, userID, "ask_question", map[string]interface{}{}); err != nil {
+			t.Fatalf("Failed to set session: %v", err)
+		}
+	}
+	err = queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE fsm_sessions SET updated_at = datetime('now', '-31 minutes') WHERE user_id IN (1, 2)`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to age sessions: %v", err)
+	}
+
+	count, err := storage.CountStale(ctx)
+	if err != nil {
+		t.Fatalf("CountStale failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 stale sessions, got %d", count)
+	}
+
+	// CleanupStale removes the counted sessions
+	if err := storage.CleanupStale(ctx); err != nil {
+		t.Fatalf("CleanupStale failed: %v", err)
+	}
+	if count, _ := storage.CountStale(ctx); count != 0 {
+		t.Errorf("expected no stale sessions after cleanup, got %d", count)
+	}
+}
@@ -0,0 +1,368 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+// DefaultGroupCacheTTL is how long group and membership lookups are served from memory
+const DefaultGroupCacheTTL = 30 * time.Second
+
+// GroupCache keeps recent group and membership lookups in memory for a short time.
+// It is shared by CachedGroupRepository and CachedGroupMembershipRepository: any write
+// through either of them drops every cached lookup, since a membership change alters the
+// groups of a user and a group change alters what the membership checks return.
+// Writes are rare admin and join actions, so dropping everything keeps invalidation simple.
+type GroupCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	gen     uint64
+	entries map[groupCacheKey]groupCacheEntry
+}
+
+// groupCacheKey identifies a cached lookup by its kind and arguments
+type groupCacheKey struct {
+	kind string
+	a, b int64
+}
+
+// groupCacheEntry is a cached lookup result with its expiry
+type groupCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// Kinds of cached lookups
+const (
+	groupCacheGroup            = "group"
+	groupCacheGroupByChat      = "group_by_chat"
+	groupCacheAllGroups        = "all_groups"
+	groupCacheUserGroups       = "user_groups"
+	groupCacheMembership       = "membership"
+	groupCacheActiveMembership = "active_membership"
+)
+
+// NewGroupCache creates a new GroupCache. A non-positive ttl disables caching.
+func NewGroupCache(ttl time.Duration) *GroupCache {
+	return &GroupCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[groupCacheKey]groupCacheEntry),
+	}
+}
+
+// get returns a cached value that has not expired yet, together with the current generation.
+// The generation must be passed to put, so a lookup that raced with a write is not cached.
+func (c *GroupCache) get(key groupCacheKey) (value interface{}, gen uint64, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[key]
+	if found && c.now().Before(entry.expiresAt) {
+		return entry.value, c.gen, true
+	}
+	if found {
+		delete(c.entries, key)
+	}
+	return nil, c.gen, false
+}
+
+// put caches a lookup result unless the cache was invalidated since the lookup started
+func (c *GroupCache) put(key groupCacheKey, gen uint64, value interface{}) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+	c.entries[key] = groupCacheEntry{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+// Invalidate drops every cached lookup
+func (c *GroupCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.entries = make(map[groupCacheKey]groupCacheEntry)
+}
+
+// afterWrite drops every cached lookup once a write finished, whether it succeeded or not,
+// and passes its error through
+func (c *GroupCache) afterWrite(err error) error {
+	c.Invalidate()
+	return err
+}
+
+// cloneGroup copies a cached group, so callers can't change the cached value
+func cloneGroup(group *domain.Group) *domain.Group {
+	if group == nil {
+		return nil
+	}
+	clone := *group
+	if group.MaxMembers != nil {
+		maxMembers := *group.MaxMembers
+		clone.MaxMembers = &maxMembers
+	}
+	if group.DisabledAchievements != nil {
+		clone.DisabledAchievements = append([]domain.AchievementCode(nil), group.DisabledAchievements...)
+	}
+	return &clone
+}
+
+// cloneGroups copies a cached list of groups
+func cloneGroups(groups []*domain.Group) []*domain.Group {
+	if groups == nil {
+		return nil
+	}
+	clones := make([]*domain.Group, len(groups))
+	for i, group := range groups {
+		clones[i] = cloneGroup(group)
+	}
+	return clones
+}
+
+// cloneMembership copies a cached membership
+func cloneMembership(membership *domain.GroupMembership) *domain.GroupMembership {
+	if membership == nil {
+		return nil
+	}
+	clone := *membership
+	return &clone
+}
+
+// CachedGroupRepository serves group lookups from a GroupCache and invalidates it on writes
+type CachedGroupRepository struct {
+	repo  domain.GroupRepository
+	cache *GroupCache
+}
+
+// NewCachedGroupRepository wraps a group repository with the cache
+func NewCachedGroupRepository(repo domain.GroupRepository, cache *GroupCache) *CachedGroupRepository {
+	return &CachedGroupRepository{repo: repo, cache: cache}
+}
+
+// lookupGroup serves a single group lookup from the cache or loads and caches it
+func (r *CachedGroupRepository) lookupGroup(key groupCacheKey, load func() (*domain.Group, error)) (*domain.Group, error) {
+	value, gen, ok := r.cache.get(key)
+	if ok {
+		return cloneGroup(value.(*domain.Group)), nil
+	}
+
+	group, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.cache.put(key, gen, cloneGroup(group))
+	return group, nil
+}
+
+// lookupGroups serves a group list lookup from the cache or loads and caches it
+func (r *CachedGroupRepository) lookupGroups(key groupCacheKey, load func() ([]*domain.Group, error)) ([]*domain.Group, error) {
+	value, gen, ok := r.cache.get(key)
+	if ok {
+		return cloneGroups(value.([]*domain.Group)), nil
+	}
+
+	groups, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.cache.put(key, gen, cloneGroups(groups))
+	return groups, nil
+}
+
+// CreateGroup creates a new group
+func (r *CachedGroupRepository) CreateGroup(ctx context.Context, group *domain.Group) error {
+	return r.cache.afterWrite(r.repo.CreateGroup(ctx, group))
+}
+
+// GetGroup retrieves a group by ID
+func (r *CachedGroupRepository) GetGroup(ctx context.Context, groupID int64) (*domain.Group, error) {
+	return r.lookupGroup(groupCacheKey{kind: groupCacheGroup, a: groupID}, func() (*domain.Group, error) {
+		return r.repo.GetGroup(ctx, groupID)
+	})
+}
+
+// GetGroupByTelegramChatID retrieves a group by Telegram chat ID
+func (r *CachedGroupRepository) GetGroupByTelegramChatID(ctx context.Context, telegramChatID int64) (*domain.Group, error) {
+	return r.lookupGroup(groupCacheKey{kind: groupCacheGroupByChat, a: telegramChatID}, func() (*domain.Group, error) {
+		return r.repo.GetGroupByTelegramChatID(ctx, telegramChatID)
+	})
+}
+
+// GetAllGroups retrieves all groups
+func (r *CachedGroupRepository) GetAllGroups(ctx context.Context) ([]*domain.Group, error) {
+	return r.lookupGroups(groupCacheKey{kind: groupCacheAllGroups}, func() ([]*domain.Group, error) {
+		return r.repo.GetAllGroups(ctx)
+	})
+}
+
+// GetUserGroups retrieves the active groups of a user
+func (r *CachedGroupRepository) GetUserGroups(ctx context.Context, userID int64) ([]*domain.Group, error) {
+	return r.lookupGroups(groupCacheKey{kind: groupCacheUserGroups, a: userID}, func() ([]*domain.Group, error) {
+		return r.repo.GetUserGroups(ctx, userID)
+	})
+}
+
+// DeleteGroup deletes a group
+func (r *CachedGroupRepository) DeleteGroup(ctx context.Context, groupID int64) error {
+	return r.cache.afterWrite(r.repo.DeleteGroup(ctx, groupID))
+}
+
+// UpdateGroupStatus updates the status of a group
+func (r *CachedGroupRepository) UpdateGroupStatus(ctx context.Context, groupID int64, status domain.GroupStatus) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupStatus(ctx, groupID, status))
+}
+
+// UpdateGroupName updates the name of a group
+func (r *CachedGroupRepository) UpdateGroupName(ctx context.Context, groupID int64, name string) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupName(ctx, groupID, name))
+}
+
+// UpdateGroupPinPolls updates whether polls are pinned in a group
+func (r *CachedGroupRepository) UpdateGroupPinPolls(ctx context.Context, groupID int64, pinPolls bool) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupPinPolls(ctx, groupID, pinPolls))
+}
+
+// UpdateGroupDefaultEventType updates the default event type of a group
+func (r *CachedGroupRepository) UpdateGroupDefaultEventType(ctx context.Context, groupID int64, eventType domain.EventType) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupDefaultEventType(ctx, groupID, eventType))
+}
+
+// UpdateGroupRequireRules updates whether new members must accept the rules
+func (r *CachedGroupRepository) UpdateGroupRequireRules(ctx context.Context, groupID int64, requireRules bool) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupRequireRules(ctx, groupID, requireRules))
+}
+
+// UpdateGroupAutoRemoveInactive updates whether inactive members are removed automatically
+func (r *CachedGroupRepository) UpdateGroupAutoRemoveInactive(ctx context.Context, groupID int64, autoRemove bool) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupAutoRemoveInactive(ctx, groupID, autoRemove))
+}
+
+// UpdateGroupMaxMembers updates the maximum number of active members
+func (r *CachedGroupRepository) UpdateGroupMaxMembers(ctx context.Context, groupID int64, maxMembers *int) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupMaxMembers(ctx, groupID, maxMembers))
+}
+
+// UpdateGroupReputationWeighting updates whether vote shares are weighted by ratings
+func (r *CachedGroupRepository) UpdateGroupReputationWeighting(ctx context.Context, groupID int64, reputationWeighting bool) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupReputationWeighting(ctx, groupID, reputationWeighting))
+}
+
+// UpdateGroupRequireApproval updates whether member-created events need admin approval
+func (r *CachedGroupRepository) UpdateGroupRequireApproval(ctx context.Context, groupID int64, requireApproval bool) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupRequireApproval(ctx, groupID, requireApproval))
+}
+
+// UpdateGroupPointsLabel updates the custom name of points
+func (r *CachedGroupRepository) UpdateGroupPointsLabel(ctx context.Context, groupID int64, pointsLabel string) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupPointsLabel(ctx, groupID, pointsLabel))
+}
+
+// UpdateGroupFirstVoteFinal updates whether the first vote of a member is final
+func (r *CachedGroupRepository) UpdateGroupFirstVoteFinal(ctx context.Context, groupID int64, firstVoteFinal bool) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupFirstVoteFinal(ctx, groupID, firstVoteFinal))
+}
+
+// UpdateGroupExcludeCreatorScoring updates whether event creators are excluded from scoring their own events
+func (r *CachedGroupRepository) UpdateGroupExcludeCreatorScoring(ctx context.Context, groupID int64, excludeCreatorScoring bool) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupExcludeCreatorScoring(ctx, groupID, excludeCreatorScoring))
+}
+
+// UpdateGroupDisabledAchievements replaces the set of achievements that are not awarded in a group
+func (r *CachedGroupRepository) UpdateGroupDisabledAchievements(ctx context.Context, groupID int64, codes []domain.AchievementCode) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupDisabledAchievements(ctx, groupID, codes))
+}
+
+// UpdateGroupArchiveDays updates after how many days resolved events are archived
+func (r *CachedGroupRepository) UpdateGroupArchiveDays(ctx context.Context, groupID int64, archiveDays *int) error {
+	return r.cache.afterWrite(r.repo.UpdateGroupArchiveDays(ctx, groupID, archiveDays))
+}
+
+// MergeGroups merges a group into another, moving its memberships
+func (r *CachedGroupRepository) MergeGroups(ctx context.Context, sourceGroupID int64, targetGroupID int64) error {
+	return r.cache.afterWrite(r.repo.MergeGroups(ctx, sourceGroupID, targetGroupID))
+}
+
+// CachedGroupMembershipRepository serves membership checks from a GroupCache and invalidates it on writes
+type CachedGroupMembershipRepository struct {
+	repo  domain.GroupMembershipRepository
+	cache *GroupCache
+}
+
+// NewCachedGroupMembershipRepository wraps a membership repository with the cache
+func NewCachedGroupMembershipRepository(repo domain.GroupMembershipRepository, cache *GroupCache) *CachedGroupMembershipRepository {
+	return &CachedGroupMembershipRepository{repo: repo, cache: cache}
+}
+
+// CreateMembership creates a new group membership
+func (r *CachedGroupMembershipRepository) CreateMembership(ctx context.Context, membership *domain.GroupMembership) error {
+	return r.cache.afterWrite(r.repo.CreateMembership(ctx, membership))
+}
+
+// GetMembership retrieves a membership by group ID and user ID
+func (r *CachedGroupMembershipRepository) GetMembership(ctx context.Context, groupID int64, userID int64) (*domain.GroupMembership, error) {
+	key := groupCacheKey{kind: groupCacheMembership, a: groupID, b: userID}
+	value, gen, ok := r.cache.get(key)
+	if ok {
+		return cloneMembership(value.(*domain.GroupMembership)), nil
+	}
+
+	membership, err := r.repo.GetMembership(ctx, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.put(key, gen, cloneMembership(membership))
+	return membership, nil
+}
+
+// GetGroupMembers retrieves all members of a group
+func (r *CachedGroupMembershipRepository) GetGroupMembers(ctx context.Context, groupID int64) ([]*domain.GroupMembership, error) {
+	return r.repo.GetGroupMembers(ctx, groupID)
+}
+
+// UpdateMembershipStatus updates the status of a membership
+func (r *CachedGroupMembershipRepository) UpdateMembershipStatus(ctx context.Context, groupID int64, userID int64, status domain.MembershipStatus) error {
+	return r.cache.afterWrite(r.repo.UpdateMembershipStatus(ctx, groupID, userID, status))
+}
+
+// HasActiveMembership checks if a user has an active membership in a group
+func (r *CachedGroupMembershipRepository) HasActiveMembership(ctx context.Context, groupID int64, userID int64) (bool, error) {
+	key := groupCacheKey{kind: groupCacheActiveMembership, a: groupID, b: userID}
+	value, gen, ok := r.cache.get(key)
+	if ok {
+		return value.(bool), nil
+	}
+
+	active, err := r.repo.HasActiveMembership(ctx, groupID, userID)
+	if err != nil {
+		return false, err
+	}
+	r.cache.put(key, gen, active)
+	return active, nil
+}
+
+// CountActiveMembers counts the active members of a group
+func (r *CachedGroupMembershipRepository) CountActiveMembers(ctx context.Context, groupID int64) (int, error) {
+	return r.repo.CountActiveMembers(ctx, groupID)
+}
+
+// AcceptRules records that a member accepted the rules of a group
+func (r *CachedGroupMembershipRepository) AcceptRules(ctx context.Context, groupID int64, userID int64) error {
+	return r.cache.afterWrite(r.repo.AcceptRules(ctx, groupID, userID))
+}
+
+// FindInactiveMembers finds active members of a group who haven't voted since the given time
+func (r *CachedGroupMembershipRepository) FindInactiveMembers(ctx context.Context, groupID int64, since time.Time) ([]*domain.GroupMembership, error) {
+	return r.repo.FindInactiveMembers(ctx, groupID, since)
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+// setupGroupCacheTest returns the plain repositories, which bypass the cache, and the cached ones
+// sharing a cache with a controllable clock
+func setupGroupCacheTest(t *testing.T) (*GroupRepository, *GroupMembershipRepository, *CachedGroupRepository, *CachedGroupMembershipRepository, *time.Time) {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	queue := NewDBQueue(db)
+	t.Cleanup(queue.Close)
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewGroupCache(DefaultGroupCacheTTL)
+	cache.now = func() time.Time { return now }
+
+	groupRepo := NewGroupRepository(queue)
+	membershipRepo := NewGroupMembershipRepository(queue)
+	return groupRepo, membershipRepo,
+		NewCachedGroupRepository(groupRepo, cache), NewCachedGroupMembershipRepository(membershipRepo, cache), &now
+}
+
+func TestCachedGroupRepository_HitAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	groupRepo, _, cached, _, now := setupGroupCacheTest(t)
+
+	group := &domain.Group{TelegramChatID: -1001, Name: "Before", CreatedAt: time.Now(), CreatedBy: 1}
+	if err := cached.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+
+	// A miss loads the group from the database
+	retrieved, err := cached.GetGroup(ctx, group.ID)
+	if err != nil || retrieved == nil || retrieved.Name != "Before" {
+		t.Fatalf("Expected the group to be loaded, got %+v (err %v)", retrieved, err)
+	}
+
+	// Changing the returned group doesn't change the cached one
+	retrieved.Name = "Changed by caller"
+
+	// A change that bypasses the cache is not seen while the entry is fresh
+	if err := groupRepo.UpdateGroupName(ctx, group.ID, "After"); err != nil {
+		t.Fatalf("UpdateGroupName failed: %v", err)
+	}
+	if retrieved, _ := cached.GetGroup(ctx, group.ID); retrieved.Name != "Before" {
+		t.Errorf("Expected the cached group, got name %q", retrieved.Name)
+	}
+	if byChat, _ := cached.GetGroupByTelegramChatID(ctx, group.TelegramChatID); byChat.Name != "After" {
+		t.Errorf("Expected a lookup by chat ID to miss and load the current group, got name %q", byChat.Name)
+	}
+
+	// Once the entry expires the group is loaded again
+	*now = now.Add(DefaultGroupCacheTTL)
+	if retrieved, _ := cached.GetGroup(ctx, group.ID); retrieved.Name != "After" {
+		t.Errorf("Expected the expired entry to be reloaded, got name %q", retrieved.Name)
+	}
+}
+
+func TestCachedGroupRepository_InvalidatedOnWrite(t *testing.T) {
+	ctx := context.Background()
+	_, _, cached, cachedMemberships, _ := setupGroupCacheTest(t)
+	const userID = int64(42)
+
+	group := &domain.Group{TelegramChatID: -1001, Name: "Group", CreatedAt: time.Now(), CreatedBy: 1}
+	if err := cached.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+
+	// Warm the cache
+	if groups, _ := cached.GetAllGroups(ctx); len(groups) != 1 {
+		t.Fatalf("Expected one group, got %d", len(groups))
+	}
+	if groups, _ := cached.GetUserGroups(ctx, userID); len(groups) != 0 {
+		t.Fatalf("Expected no groups for the user, got %d", len(groups))
+	}
+	if active, _ := cachedMemberships.HasActiveMembership(ctx, group.ID, userID); active {
+		t.Fatal("Expected no active membership")
+	}
+
+	// Group writes are visible right away
+	if err := cached.UpdateGroupRequireRules(ctx, group.ID, true); err != nil {
+		t.Fatalf("UpdateGroupRequireRules failed: %v", err)
+	}
+	if groups, _ := cached.GetAllGroups(ctx); len(groups) != 1 || !groups[0].RequireRules {
+		t.Errorf("Expected the updated group, got %+v", groups)
+	}
+
+	// So are membership writes, including the groups of the user
+	membership := &domain.GroupMembership{GroupID: group.ID, UserID: userID, JoinedAt: time.Now(), Status: domain.MembershipStatusActive}
+	if err := cachedMemberships.CreateMembership(ctx, membership); err != nil {
+		t.Fatalf("CreateMembership failed: %v", err)
+	}
+	if active, _ := cachedMemberships.HasActiveMembership(ctx, group.ID, userID); !active {
+		t.Error("Expected the new membership to be active")
+	}
+	if groups, _ := cached.GetUserGroups(ctx, userID); len(groups) != 1 {
+		t.Errorf("Expected the user's new group, got %d groups", len(groups))
+	}
+
+	if err := cachedMemberships.UpdateMembershipStatus(ctx, group.ID, userID, domain.MembershipStatusRemoved); err != nil {
+		t.Fatalf("UpdateMembershipStatus failed: %v", err)
+	}
+	if active, _ := cachedMemberships.HasActiveMembership(ctx, group.ID, userID); active {
+		t.Error("Expected the removed membership to be inactive")
+	}
+	if retrieved, _ := cachedMemberships.GetMembership(ctx, group.ID, userID); retrieved == nil || retrieved.Status != domain.MembershipStatusRemoved {
+		t.Errorf("Expected the removed membership, got %+v", retrieved)
+	}
+
+	if retrieved, _ := cached.GetGroup(ctx, group.ID); retrieved == nil {
+		t.Fatal("Expected the group before deleting it")
+	}
+	if err := cached.DeleteGroup(ctx, group.ID); err != nil {
+		t.Fatalf("DeleteGroup failed: %v", err)
+	}
+	if retrieved, _ := cached.GetGroup(ctx, group.ID); retrieved != nil {
+		t.Errorf("Expected the deleted group to be gone, got %+v", retrieved)
+	}
+}
+
+func TestGroupCache_RacingLookupNotCached(t *testing.T) {
+	cache := NewGroupCache(DefaultGroupCacheTTL)
+	key := groupCacheKey{kind: groupCacheGroup, a: 1}
+
+	// A lookup that started before a write must not cache what it read
+	_, gen, ok := cache.get(key)
+	if ok {
+		t.Fatal("Expected a miss on an empty cache")
+	}
+	cache.Invalidate()
+	cache.put(key, gen, &domain.Group{ID: 1, Name: "Stale"})
+
+	if _, _, ok := cache.get(key); ok {
+		t.Error("Expected the stale lookup to be dropped")
+	}
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/ad/gitelegram-prediction-market/internal/domain"
 )
@@ -19,10 +20,10 @@ func NewGroupMembershipRepository(queue *DBQueue) *GroupMembershipRepository {
 
 // CreateMembership creates a new group membership in the database
 func (r *GroupMembershipRepository) CreateMembership(ctx context.Context, membership *domain.GroupMembership) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		result, err := db.ExecContext(ctx,
-			`INSERT INTO group_memberships (group_id, user_id, joined_at, status) VALUES (?, ?, ?, ?)`,
-			membership.GroupID, membership.UserID, membership.JoinedAt, membership.Status,
+			`INSERT INTO group_memberships (group_id, user_id, joined_at, status, rules_pending) VALUES (?, ?, ?, ?, ?)`,
+			membership.GroupID, membership.UserID, membership.JoinedAt, membership.Status, boolToInt(membership.RulesPending),
 		)
 		if err != nil {
 			return err
@@ -41,11 +42,11 @@ func (r *GroupMembershipRepository) CreateMembership(ctx context.Context, member
 func (r *GroupMembershipRepository) GetMembership(ctx context.Context, groupID int64, userID int64) (*domain.GroupMembership, error) {
 	var membership domain.GroupMembership
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
-			`SELECT id, group_id, user_id, joined_at, status FROM group_memberships WHERE group_id = ? AND user_id = ?`,
+			`SELECT id, group_id, user_id, joined_at, status, rules_pending FROM group_memberships WHERE group_id = ? AND user_id = ?`,
 			groupID, userID,
-		).Scan(&membership.ID, &membership.GroupID, &membership.UserID, &membership.JoinedAt, &membership.Status)
+		).Scan(&membership.ID, &membership.GroupID, &membership.UserID, &membership.JoinedAt, &membership.Status, &membership.RulesPending)
 	})
 
 	if err == sql.ErrNoRows {
@@ -62,9 +63,9 @@ func (r *GroupMembershipRepository) GetMembership(ctx context.Context, groupID i
 func (r *GroupMembershipRepository) GetGroupMembers(ctx context.Context, groupID int64) ([]*domain.GroupMembership, error) {
 	var memberships []*domain.GroupMembership
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
-			`SELECT id, group_id, user_id, joined_at, status FROM group_memberships WHERE group_id = ? ORDER BY joined_at DESC`,
+			`SELECT id, group_id, user_id, joined_at, status, rules_pending FROM group_memberships WHERE group_id = ? ORDER BY joined_at DESC`,
 			groupID,
 		)
 		if err != nil {
@@ -74,7 +75,7 @@ func (r *GroupMembershipRepository) GetGroupMembers(ctx context.Context, groupID
 
 		for rows.Next() {
 			var membership domain.GroupMembership
-			if err := rows.Scan(&membership.ID, &membership.GroupID, &membership.UserID, &membership.JoinedAt, &membership.Status); err != nil {
+			if err := rows.Scan(&membership.ID, &membership.GroupID, &membership.UserID, &membership.JoinedAt, &membership.Status, &membership.RulesPending); err != nil {
 				return err
 			}
 			memberships = append(memberships, &membership)
@@ -90,22 +91,93 @@ func (r *GroupMembershipRepository) GetGroupMembers(ctx context.Context, groupID
 	return memberships, nil
 }
 
-// UpdateMembershipStatus updates the status of a membership
+// UpdateMembershipStatus updates the status of a membership and records when it changed
 func (r *GroupMembershipRepository) UpdateMembershipStatus(ctx context.Context, groupID int64, userID int64, status domain.MembershipStatus) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx,
-			`UPDATE group_memberships SET status = ? WHERE group_id = ? AND user_id = ?`,
-			status, groupID, userID,
+			`UPDATE group_memberships SET status = ?, status_changed_at = ? WHERE group_id = ? AND user_id = ?`,
+			status, time.Now(), groupID, userID,
 		)
 		return err
 	})
 }
 
+// AcceptRules records that a member has accepted the rules of the group
+func (r *GroupMembershipRepository) AcceptRules(ctx context.Context, groupID int64, userID int64) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`UPDATE group_memberships SET rules_pending = 0 WHERE group_id = ? AND user_id = ?`,
+			groupID, userID,
+		)
+		return err
+	})
+}
+
+// FindInactiveMembers retrieves active members of a group who haven't voted on any of its events
+// since the given time. Members who joined or rejoined after that time are not considered inactive.
+func (r *GroupMembershipRepository) FindInactiveMembers(ctx context.Context, groupID int64, since time.Time) ([]*domain.GroupMembership, error) {
+	var memberships []*domain.GroupMembership
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT gm.id, gm.group_id, gm.user_id, gm.joined_at, gm.status, gm.rules_pending
+			 FROM group_memberships gm
+			 WHERE gm.group_id = ? AND gm.status = ? AND gm.joined_at < ?
+			   AND (gm.status_changed_at IS NULL OR gm.status_changed_at < ?)
+			   AND NOT EXISTS (
+			     SELECT 1 FROM predictions p
+			     INNER JOIN events e ON e.id = p.event_id
+			     WHERE e.group_id = gm.group_id AND p.user_id = gm.user_id AND p.timestamp >= ?
+			   )
+			 ORDER BY gm.joined_at ASC, gm.id ASC`,
+			groupID, domain.MembershipStatusActive, since, since, since,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			var membership domain.GroupMembership
+			if err := rows.Scan(&membership.ID, &membership.GroupID, &membership.UserID, &membership.JoinedAt, &membership.Status, &membership.RulesPending); err != nil {
+				return err
+			}
+			memberships = append(memberships, &membership)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+// CountActiveMembers returns the number of active members of a group
+func (r *GroupMembershipRepository) CountActiveMembers(ctx context.Context, groupID int64) (int, error) {
+	var count int
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM group_memberships WHERE group_id = ? AND status = ?`,
+			groupID, domain.MembershipStatusActive,
+		).Scan(&count)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // HasActiveMembership checks if a user has an active membership in a group
 func (r *GroupMembershipRepository) HasActiveMembership(ctx context.Context, groupID int64, userID int64) (bool, error) {
 	var count int
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
 			`SELECT COUNT(*) FROM group_memberships WHERE group_id = ? AND user_id = ? AND status = ?`,
 			groupID, userID, domain.MembershipStatusActive,
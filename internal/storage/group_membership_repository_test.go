@@ -1456,3 +1456,177 @@ func TestRemovedUserCanRejoin(t *testing.T) {
 		t.Errorf("Expected active status after rejoin, got %s", rejoined.Status)
 	}
 }
+
+func TestAcceptRules(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	groupRepo := NewGroupRepository(queue)
+	membershipRepo := NewGroupMembershipRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := groupRepo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	if err := groupRepo.UpdateGroupRequireRules(ctx, group.ID, true); err != nil {
+		t.Fatalf("Failed to enable rules: %v", err)
+	}
+	if retrieved, _ := groupRepo.GetGroup(ctx, group.ID); !retrieved.RequireRules {
+		t.Error("Expected group to require rules")
+	}
+
+	// Memberships created without a pending flag count as accepted
+	for _, m := range []*domain.GroupMembership{
+		{GroupID: group.ID, UserID: 1, JoinedAt: time.Now(), Status: domain.MembershipStatusActive},
+		{GroupID: group.ID, UserID: 2, JoinedAt: time.Now(), Status: domain.MembershipStatusActive, RulesPending: true},
+	} {
+		if err := membershipRepo.CreateMembership(ctx, m); err != nil {
+			t.Fatalf("Failed to create membership: %v", err)
+		}
+	}
+	if membership, _ := membershipRepo.GetMembership(ctx, group.ID, 1); membership.RulesPending {
+		t.Error("Expected existing membership to have the rules accepted")
+	}
+	if membership, _ := membershipRepo.GetMembership(ctx, group.ID, 2); !membership.RulesPending {
+		t.Error("Expected new membership to have the rules pending")
+	}
+
+	if err := membershipRepo.AcceptRules(ctx, group.ID, 2); err != nil {
+		t.Fatalf("Failed to accept rules: %v", err)
+	}
+	members, err := membershipRepo.GetGroupMembers(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to get members: %v", err)
+	}
+	for _, member := range members {
+		if member.RulesPending {
+			t.Errorf("Expected user %d to have the rules accepted", member.UserID)
+		}
+	}
+}
+
+func TestFindInactiveMembers(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	groupRepo := NewGroupRepository(queue)
+	membershipRepo := NewGroupMembershipRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	predictionRepo := NewPredictionRepository(queue)
+	ctx := context.Background()
+	now := time.Now()
+	since := now.Add(-180 * 24 * time.Hour)
+
+	var groupIDs []int64
+	for i, name := range []string{"Group A", "Group B"} {
+		group := &domain.Group{
+			TelegramChatID: -1001234567890 - int64(i),
+			Name:           name,
+			CreatedAt:      now.Add(-365 * 24 * time.Hour),
+			CreatedBy:      12345,
+		}
+		if err := groupRepo.CreateGroup(ctx, group); err != nil {
+			t.Fatalf("Failed to create group: %v", err)
+		}
+		groupIDs = append(groupIDs, group.ID)
+	}
+	if err := groupRepo.UpdateGroupAutoRemoveInactive(ctx, groupIDs[0], true); err != nil {
+		t.Fatalf("Failed to enable auto removal: %v", err)
+	}
+	if retrieved, _ := groupRepo.GetGroup(ctx, groupIDs[0]); !retrieved.AutoRemoveInactive {
+		t.Error("Expected group to have auto removal enabled")
+	}
+
+	vote := func(groupID, userID int64, at time.Time) {
+		t.Helper()
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  "Will it rain?",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: at.Add(-time.Hour),
+			Deadline:  at.Add(time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 12345,
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: userID, Option: 0, Timestamp: at}); err != nil {
+			t.Fatalf("Failed to save prediction: %v", err)
+		}
+	}
+
+	longAgo := now.Add(-200 * 24 * time.Hour)
+	for _, m := range []*domain.GroupMembership{
+		{GroupID: groupIDs[0], UserID: 1, JoinedAt: longAgo, Status: domain.MembershipStatusActive},
+		{GroupID: groupIDs[0], UserID: 2, JoinedAt: longAgo, Status: domain.MembershipStatusActive},
+		{GroupID: groupIDs[0], UserID: 3, JoinedAt: now.Add(-10 * 24 * time.Hour), Status: domain.MembershipStatusActive},
+		{GroupID: groupIDs[0], UserID: 4, JoinedAt: longAgo, Status: domain.MembershipStatusRemoved},
+		{GroupID: groupIDs[0], UserID: 5, JoinedAt: longAgo, Status: domain.MembershipStatusActive},
+		{GroupID: groupIDs[0], UserID: 6, JoinedAt: longAgo, Status: domain.MembershipStatusActive},
+	} {
+		if err := membershipRepo.CreateMembership(ctx, m); err != nil {
+			t.Fatalf("Failed to create membership: %v", err)
+		}
+	}
+
+	vote(groupIDs[0], 1, now.Add(-10*24*time.Hour))  // Recent vote
+	vote(groupIDs[0], 2, now.Add(-190*24*time.Hour)) // Only an old vote
+	vote(groupIDs[1], 5, now.Add(-10*24*time.Hour))  // Recent vote in another group
+
+	// Rejoining resets the inactivity period
+	if err := membershipRepo.UpdateMembershipStatus(ctx, groupIDs[0], 6, domain.MembershipStatusRemoved); err != nil {
+		t.Fatalf("Failed to remove membership: %v", err)
+	}
+	if err := membershipRepo.UpdateMembershipStatus(ctx, groupIDs[0], 6, domain.MembershipStatusActive); err != nil {
+		t.Fatalf("Failed to reactivate membership: %v", err)
+	}
+
+	inactive, err := membershipRepo.FindInactiveMembers(ctx, groupIDs[0], since)
+	if err != nil {
+		t.Fatalf("Failed to find inactive members: %v", err)
+	}
+	if len(inactive) != 2 || inactive[0].UserID != 2 || inactive[1].UserID != 5 {
+		t.Fatalf("Expected users 2 and 5 to be inactive, got %+v", inactive)
+	}
+	for _, member := range inactive {
+		if member.GroupID != groupIDs[0] || member.Status != domain.MembershipStatusActive {
+			t.Errorf("Unexpected inactive membership: %+v", member)
+		}
+	}
+}
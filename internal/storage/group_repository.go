@@ -3,6 +3,8 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 
 	"github.com/ad/gitelegram-prediction-market/internal/domain"
 )
@@ -19,15 +21,20 @@ func NewGroupRepository(queue *DBQueue) *GroupRepository {
 
 // CreateGroup creates a new group in the database
 func (r *GroupRepository) CreateGroup(ctx context.Context, group *domain.Group) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	disabledAchievements, err := encodeAchievementCodes(group.DisabledAchievements)
+	if err != nil {
+		return err
+	}
+
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		// Set default status if not provided
 		if group.Status == "" {
 			group.Status = domain.GroupStatusActive
 		}
 
 		result, err := db.ExecContext(ctx,
-			`INSERT INTO groups (telegram_chat_id, name, created_at, created_by, is_forum, status) VALUES (?, ?, ?, ?, ?, ?)`,
-			group.TelegramChatID, group.Name, group.CreatedAt, group.CreatedBy, group.IsForum, group.Status,
+			`INSERT INTO groups (telegram_chat_id, name, created_at, created_by, is_forum, status, pin_polls, default_event_type, require_rules, auto_remove_inactive, max_members, reputation_weighting, require_approval, points_label, first_vote_final, exclude_creator_scoring, disabled_achievements, archive_days) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			group.TelegramChatID, group.Name, group.CreatedAt, group.CreatedBy, group.IsForum, group.Status, group.PinPolls, group.DefaultEventType, group.RequireRules, group.AutoRemoveInactive, group.MaxMembers, group.ReputationWeighting, group.RequireApproval, group.PointsLabel, group.FirstVoteFinal, group.ExcludeCreatorScoring, disabledAchievements, group.ArchiveDays,
 		)
 		if err != nil {
 			return err
@@ -46,12 +53,15 @@ func (r *GroupRepository) CreateGroup(ctx context.Context, group *domain.Group)
 func (r *GroupRepository) GetGroup(ctx context.Context, groupID int64) (*domain.Group, error) {
 	var group domain.Group
 	var status sql.NullString
+	var maxMembers sql.NullInt64
+	var archiveDays sql.NullInt64
+	var disabledAchievements string
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
-			`SELECT id, telegram_chat_id, name, created_at, created_by, is_forum, COALESCE(status, 'active') FROM groups WHERE id = ?`,
+			`SELECT id, telegram_chat_id, name, created_at, created_by, is_forum, COALESCE(status, 'active'), pin_polls, default_event_type, require_rules, auto_remove_inactive, max_members, reputation_weighting, require_approval, points_label, first_vote_final, exclude_creator_scoring, disabled_achievements, archive_days FROM groups WHERE id = ?`,
 			groupID,
-		).Scan(&group.ID, &group.TelegramChatID, &group.Name, &group.CreatedAt, &group.CreatedBy, &group.IsForum, &status)
+		).Scan(&group.ID, &group.TelegramChatID, &group.Name, &group.CreatedAt, &group.CreatedBy, &group.IsForum, &status, &group.PinPolls, &group.DefaultEventType, &group.RequireRules, &group.AutoRemoveInactive, &maxMembers, &group.ReputationWeighting, &group.RequireApproval, &group.PointsLabel, &group.FirstVoteFinal, &group.ExcludeCreatorScoring, &disabledAchievements, &archiveDays)
 	})
 
 	if err == sql.ErrNoRows {
@@ -66,6 +76,11 @@ func (r *GroupRepository) GetGroup(ctx context.Context, groupID int64) (*domain.
 	} else {
 		group.Status = domain.GroupStatusActive
 	}
+	group.MaxMembers = nullIntPtr(maxMembers)
+	group.ArchiveDays = nullIntPtr(archiveDays)
+	if group.DisabledAchievements, err = decodeAchievementCodes(disabledAchievements); err != nil {
+		return nil, err
+	}
 
 	return &group, nil
 }
@@ -74,12 +89,15 @@ func (r *GroupRepository) GetGroup(ctx context.Context, groupID int64) (*domain.
 func (r *GroupRepository) GetGroupByTelegramChatID(ctx context.Context, telegramChatID int64) (*domain.Group, error) {
 	var group domain.Group
 	var status sql.NullString
+	var maxMembers sql.NullInt64
+	var archiveDays sql.NullInt64
+	var disabledAchievements string
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
-			`SELECT id, telegram_chat_id, name, created_at, created_by, is_forum, COALESCE(status, 'active') FROM groups WHERE telegram_chat_id = ?`,
+			`SELECT id, telegram_chat_id, name, created_at, created_by, is_forum, COALESCE(status, 'active'), pin_polls, default_event_type, require_rules, auto_remove_inactive, max_members, reputation_weighting, require_approval, points_label, first_vote_final, exclude_creator_scoring, disabled_achievements, archive_days FROM groups WHERE telegram_chat_id = ?`,
 			telegramChatID,
-		).Scan(&group.ID, &group.TelegramChatID, &group.Name, &group.CreatedAt, &group.CreatedBy, &group.IsForum, &status)
+		).Scan(&group.ID, &group.TelegramChatID, &group.Name, &group.CreatedAt, &group.CreatedBy, &group.IsForum, &status, &group.PinPolls, &group.DefaultEventType, &group.RequireRules, &group.AutoRemoveInactive, &maxMembers, &group.ReputationWeighting, &group.RequireApproval, &group.PointsLabel, &group.FirstVoteFinal, &group.ExcludeCreatorScoring, &disabledAchievements, &archiveDays)
 	})
 
 	if err == sql.ErrNoRows {
@@ -94,17 +112,23 @@ func (r *GroupRepository) GetGroupByTelegramChatID(ctx context.Context, telegram
 	} else {
 		group.Status = domain.GroupStatusActive
 	}
+	group.MaxMembers = nullIntPtr(maxMembers)
+	group.ArchiveDays = nullIntPtr(archiveDays)
+	if group.DisabledAchievements, err = decodeAchievementCodes(disabledAchievements); err != nil {
+		return nil, err
+	}
 
 	return &group, nil
 }
 
-// GetAllGroups retrieves all groups
+// GetAllGroups retrieves all groups except pending drafts
 func (r *GroupRepository) GetAllGroups(ctx context.Context) ([]*domain.Group, error) {
 	var groups []*domain.Group
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
-			`SELECT id, telegram_chat_id, name, created_at, created_by, is_forum, COALESCE(status, 'active') FROM groups ORDER BY created_at DESC`,
+			`SELECT id, telegram_chat_id, name, created_at, created_by, is_forum, COALESCE(status, 'active'), pin_polls, default_event_type, require_rules, auto_remove_inactive, max_members, reputation_weighting, require_approval, points_label, first_vote_final, exclude_creator_scoring, disabled_achievements, archive_days FROM groups WHERE COALESCE(status, 'active') != ? ORDER BY created_at DESC`,
+			domain.GroupStatusPending,
 		)
 		if err != nil {
 			return err
@@ -114,7 +138,10 @@ func (r *GroupRepository) GetAllGroups(ctx context.Context) ([]*domain.Group, er
 		for rows.Next() {
 			var group domain.Group
 			var status sql.NullString
-			if err := rows.Scan(&group.ID, &group.TelegramChatID, &group.Name, &group.CreatedAt, &group.CreatedBy, &group.IsForum, &status); err != nil {
+			var maxMembers sql.NullInt64
+			var archiveDays sql.NullInt64
+			var disabledAchievements string
+			if err := rows.Scan(&group.ID, &group.TelegramChatID, &group.Name, &group.CreatedAt, &group.CreatedBy, &group.IsForum, &status, &group.PinPolls, &group.DefaultEventType, &group.RequireRules, &group.AutoRemoveInactive, &maxMembers, &group.ReputationWeighting, &group.RequireApproval, &group.PointsLabel, &group.FirstVoteFinal, &group.ExcludeCreatorScoring, &disabledAchievements, &archiveDays); err != nil {
 				return err
 			}
 			if status.Valid {
@@ -122,6 +149,11 @@ func (r *GroupRepository) GetAllGroups(ctx context.Context) ([]*domain.Group, er
 			} else {
 				group.Status = domain.GroupStatusActive
 			}
+			group.MaxMembers = nullIntPtr(maxMembers)
+			group.ArchiveDays = nullIntPtr(archiveDays)
+			if group.DisabledAchievements, err = decodeAchievementCodes(disabledAchievements); err != nil {
+				return err
+			}
 			groups = append(groups, &group)
 		}
 
@@ -139,9 +171,9 @@ func (r *GroupRepository) GetAllGroups(ctx context.Context) ([]*domain.Group, er
 func (r *GroupRepository) GetUserGroups(ctx context.Context, userID int64) ([]*domain.Group, error) {
 	var groups []*domain.Group
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
-			`SELECT g.id, g.telegram_chat_id, g.name, g.created_at, g.created_by, g.is_forum, COALESCE(g.status, 'active')
+			`SELECT g.id, g.telegram_chat_id, g.name, g.created_at, g.created_by, g.is_forum, COALESCE(g.status, 'active'), g.pin_polls, g.default_event_type, g.require_rules, g.auto_remove_inactive, g.max_members, g.reputation_weighting, g.require_approval, g.points_label, g.first_vote_final, g.exclude_creator_scoring, g.disabled_achievements, g.archive_days
 			 FROM groups g
 			 INNER JOIN group_memberships gm ON g.id = gm.group_id
 			 WHERE gm.user_id = ? AND gm.status = ? AND COALESCE(g.status, 'active') = ?
@@ -156,7 +188,10 @@ func (r *GroupRepository) GetUserGroups(ctx context.Context, userID int64) ([]*d
 		for rows.Next() {
 			var group domain.Group
 			var status sql.NullString
-			if err := rows.Scan(&group.ID, &group.TelegramChatID, &group.Name, &group.CreatedAt, &group.CreatedBy, &group.IsForum, &status); err != nil {
+			var maxMembers sql.NullInt64
+			var archiveDays sql.NullInt64
+			var disabledAchievements string
+			if err := rows.Scan(&group.ID, &group.TelegramChatID, &group.Name, &group.CreatedAt, &group.CreatedBy, &group.IsForum, &status, &group.PinPolls, &group.DefaultEventType, &group.RequireRules, &group.AutoRemoveInactive, &maxMembers, &group.ReputationWeighting, &group.RequireApproval, &group.PointsLabel, &group.FirstVoteFinal, &group.ExcludeCreatorScoring, &disabledAchievements, &archiveDays); err != nil {
 				return err
 			}
 			if status.Valid {
@@ -164,6 +199,11 @@ func (r *GroupRepository) GetUserGroups(ctx context.Context, userID int64) ([]*d
 			} else {
 				group.Status = domain.GroupStatusActive
 			}
+			group.MaxMembers = nullIntPtr(maxMembers)
+			group.ArchiveDays = nullIntPtr(archiveDays)
+			if group.DisabledAchievements, err = decodeAchievementCodes(disabledAchievements); err != nil {
+				return err
+			}
 			groups = append(groups, &group)
 		}
 
@@ -179,7 +219,7 @@ func (r *GroupRepository) GetUserGroups(ctx context.Context, userID int64) ([]*d
 
 // DeleteGroup deletes a group by ID (hard delete)
 func (r *GroupRepository) DeleteGroup(ctx context.Context, groupID int64) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx, `DELETE FROM groups WHERE id = ?`, groupID)
 		return err
 	})
@@ -187,16 +227,228 @@ func (r *GroupRepository) DeleteGroup(ctx context.Context, groupID int64) error
 
 // UpdateGroupStatus updates the status of a group (soft delete/restore)
 func (r *GroupRepository) UpdateGroupStatus(ctx context.Context, groupID int64, status domain.GroupStatus) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx, `UPDATE groups SET status = ? WHERE id = ?`, status, groupID)
 		return err
 	})
 }
 
+// UpdateGroupPinPolls updates whether event polls are pinned in the group chat
+func (r *GroupRepository) UpdateGroupPinPolls(ctx context.Context, groupID int64, pinPolls bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET pin_polls = ? WHERE id = ?`, boolToInt(pinPolls), groupID)
+		return err
+	})
+}
+
+// UpdateGroupDefaultEventType updates the event type pre-selected when creating events in the group.
+// An empty type clears the default.
+func (r *GroupRepository) UpdateGroupDefaultEventType(ctx context.Context, groupID int64, eventType domain.EventType) error {
+	if eventType != "" && !eventType.IsValid() {
+		return domain.ErrInvalidEventType
+	}
+
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET default_event_type = ? WHERE id = ?`, eventType, groupID)
+		return err
+	})
+}
+
+// UpdateGroupRequireRules updates whether new members must accept the rules before voting
+func (r *GroupRepository) UpdateGroupRequireRules(ctx context.Context, groupID int64, requireRules bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET require_rules = ? WHERE id = ?`, boolToInt(requireRules), groupID)
+		return err
+	})
+}
+
+// UpdateGroupAutoRemoveInactive updates whether long inactive members are removed automatically
+func (r *GroupRepository) UpdateGroupAutoRemoveInactive(ctx context.Context, groupID int64, autoRemove bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET auto_remove_inactive = ? WHERE id = ?`, boolToInt(autoRemove), groupID)
+		return err
+	})
+}
+
+// UpdateGroupReputationWeighting updates whether votes are weighted by the voters' ratings
+func (r *GroupRepository) UpdateGroupReputationWeighting(ctx context.Context, groupID int64, reputationWeighting bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET reputation_weighting = ? WHERE id = ?`, boolToInt(reputationWeighting), groupID)
+		return err
+	})
+}
+
+// UpdateGroupRequireApproval updates whether events created by members wait for admin approval before the poll is posted
+func (r *GroupRepository) UpdateGroupRequireApproval(ctx context.Context, groupID int64, requireApproval bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET require_approval = ? WHERE id = ?`, boolToInt(requireApproval), groupID)
+		return err
+	})
+}
+
+// UpdateGroupPointsLabel updates the name of points shown in the group's ratings. An empty label restores the default.
+func (r *GroupRepository) UpdateGroupPointsLabel(ctx context.Context, groupID int64, pointsLabel string) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET points_label = ? WHERE id = ?`, pointsLabel, groupID)
+		return err
+	})
+}
+
+// UpdateGroupFirstVoteFinal updates whether the first vote of a member is final or can be changed
+func (r *GroupRepository) UpdateGroupFirstVoteFinal(ctx context.Context, groupID int64, firstVoteFinal bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET first_vote_final = ? WHERE id = ?`, boolToInt(firstVoteFinal), groupID)
+		return err
+	})
+}
+
+// UpdateGroupExcludeCreatorScoring updates whether event creators are excluded from scoring their own events
+func (r *GroupRepository) UpdateGroupExcludeCreatorScoring(ctx context.Context, groupID int64, excludeCreatorScoring bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET exclude_creator_scoring = ? WHERE id = ?`, boolToInt(excludeCreatorScoring), groupID)
+		return err
+	})
+}
+
+// UpdateGroupDisabledAchievements replaces the set of achievements that are not awarded in a group
+func (r *GroupRepository) UpdateGroupDisabledAchievements(ctx context.Context, groupID int64, codes []domain.AchievementCode) error {
+	disabledAchievements, err := encodeAchievementCodes(codes)
+	if err != nil {
+		return err
+	}
+
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET disabled_achievements = ? WHERE id = ?`, disabledAchievements, groupID)
+		return err
+	})
+}
+
+// UpdateGroupMaxMembers updates the maximum number of active members. A nil cap removes the limit.
+func (r *GroupRepository) UpdateGroupMaxMembers(ctx context.Context, groupID int64, maxMembers *int) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET max_members = ? WHERE id = ?`, maxMembers, groupID)
+		return err
+	})
+}
+
+// UpdateGroupArchiveDays updates after how many days resolved events are archived. A nil value falls back to the global setting.
+func (r *GroupRepository) UpdateGroupArchiveDays(ctx context.Context, groupID int64, archiveDays *int) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, `UPDATE groups SET archive_days = ? WHERE id = ?`, archiveDays, groupID)
+		return err
+	})
+}
+
 // UpdateGroupName updates the name of a group
 func (r *GroupRepository) UpdateGroupName(ctx context.Context, groupID int64, name string) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx, `UPDATE groups SET name = ? WHERE id = ?`, name, groupID)
 		return err
 	})
 }
+
+// MergeGroups moves everything owned by the source group into the target group in a single
+// transaction and marks the source group as deleted. Memberships, achievements and subscription
+// flags already present in the target are kept, ratings of users present in both groups are summed,
+// and events in a forum topic registered in both groups are moved to the target's topic.
+func (r *GroupRepository) MergeGroups(ctx context.Context, sourceGroupID int64, targetGroupID int64) error {
+	if sourceGroupID == targetGroupID {
+		return domain.ErrMergeSameGroup
+	}
+
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		statements := []string{
+			// Forum topics registered in both groups: point events at the target's topic and drop the duplicate
+			`UPDATE events SET forum_topic_id = (
+				SELECT t.id FROM forum_topics t JOIN forum_topics s ON s.message_thread_id = t.message_thread_id
+				WHERE s.id = events.forum_topic_id AND t.group_id = :target
+			) WHERE forum_topic_id IN (
+				SELECT s.id FROM forum_topics s JOIN forum_topics t ON t.message_thread_id = s.message_thread_id
+				WHERE s.group_id = :source AND t.group_id = :target
+			)`,
+			`DELETE FROM forum_topics WHERE group_id = :source AND message_thread_id IN (
+				SELECT message_thread_id FROM forum_topics WHERE group_id = :target
+			)`,
+			`UPDATE forum_topics SET group_id = :target WHERE group_id = :source`,
+
+			`UPDATE events SET group_id = :target WHERE group_id = :source`,
+
+			`UPDATE OR IGNORE group_memberships SET group_id = :target WHERE group_id = :source`,
+			`DELETE FROM group_memberships WHERE group_id = :source`,
+
+			// Ratings of users present in both groups are summed, the rest are moved
+			`UPDATE ratings SET
+				score = score + (SELECT s.score FROM ratings s WHERE s.group_id = :source AND s.user_id = ratings.user_id),
+				correct_count = correct_count + (SELECT s.correct_count FROM ratings s WHERE s.group_id = :source AND s.user_id = ratings.user_id),
+				wrong_count = wrong_count + (SELECT s.wrong_count FROM ratings s WHERE s.group_id = :source AND s.user_id = ratings.user_id),
+				best_streak = MAX(best_streak, (SELECT s.best_streak FROM ratings s WHERE s.group_id = :source AND s.user_id = ratings.user_id))
+			WHERE group_id = :target AND user_id IN (SELECT user_id FROM ratings WHERE group_id = :source)`,
+			`UPDATE OR IGNORE ratings SET group_id = :target WHERE group_id = :source`,
+			`DELETE FROM ratings WHERE group_id = :source`,
+
+			`UPDATE OR IGNORE achievements SET group_id = :target WHERE group_id = :source`,
+			`DELETE FROM achievements WHERE group_id = :source`,
+
+			`UPDATE OR IGNORE subscriptions SET group_id = :target WHERE group_id = :source`,
+			`DELETE FROM subscriptions WHERE group_id = :source`,
+
+			`UPDATE fsm_sessions SET group_id = :target WHERE group_id = :source`,
+
+			`UPDATE groups SET status = :deleted WHERE id = :source`,
+		}
+
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement,
+				sql.Named("source", sourceGroupID),
+				sql.Named("target", targetGroupID),
+				sql.Named("deleted", domain.GroupStatusDeleted),
+			); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit()
+	})
+}
+
+// encodeAchievementCodes stores a set of achievement codes as a JSON array
+func encodeAchievementCodes(codes []domain.AchievementCode) (string, error) {
+	if len(codes) == 0 {
+		return "[]", nil
+	}
+	data, err := json.Marshal(codes)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decodeAchievementCodes parses a JSON array of achievement codes; an empty value is an empty set
+func decodeAchievementCodes(s string) ([]domain.AchievementCode, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var codes []domain.AchievementCode
+	if err := json.Unmarshal([]byte(s), &codes); err != nil {
+		return nil, fmt.Errorf("invalid disabled achievements %q: %w", s, err)
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	return codes, nil
+}
+
+// nullIntPtr converts a nullable integer column to a pointer (nil for NULL)
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int64)
+	return &i
+}
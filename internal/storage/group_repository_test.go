@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 	"time"
 
@@ -462,3 +463,721 @@ func TestDeleteGroup(t *testing.T) {
 		t.Errorf("Expected no error when deleting non-existent group, got: %v", err)
 	}
 }
+
+func TestUpdateGroupPinPolls(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// Pinning is disabled by default
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.PinPolls {
+		t.Error("Expected poll pinning to be disabled by default")
+	}
+
+	if err := repo.UpdateGroupPinPolls(ctx, group.ID, true); err != nil {
+		t.Fatalf("Failed to enable poll pinning: %v", err)
+	}
+	retrieved, err = repo.GetGroupByTelegramChatID(ctx, group.TelegramChatID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if !retrieved.PinPolls {
+		t.Error("Expected poll pinning to be enabled")
+	}
+
+	// Events remember whether their poll was pinned
+	event := &domain.Event{
+		GroupID:       group.ID,
+		Question:      "Question?",
+		Options:       []string{"Yes", "No"},
+		CreatedAt:     time.Now(),
+		Deadline:      time.Now().Add(24 * time.Hour),
+		Status:        domain.EventStatusActive,
+		EventType:     domain.EventTypeBinary,
+		CreatedBy:     12345,
+		PollMessageID: 42,
+		PollPinned:    true,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+	storedEvent, err := eventRepo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve event: %v", err)
+	}
+	if !storedEvent.PollPinned || storedEvent.PollMessageID != 42 {
+		t.Errorf("Expected pinned poll message 42 to be stored, got pinned=%t message=%d", storedEvent.PollPinned, storedEvent.PollMessageID)
+	}
+}
+
+func TestUpdateGroupMaxMembers(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	membershipRepo := NewGroupMembershipRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// Groups are unlimited by default
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.MaxMembers != nil {
+		t.Errorf("Expected no member limit by default, got %d", *retrieved.MaxMembers)
+	}
+
+	maxMembers := 2
+	if err := repo.UpdateGroupMaxMembers(ctx, group.ID, &maxMembers); err != nil {
+		t.Fatalf("Failed to set member limit: %v", err)
+	}
+	retrieved, err = repo.GetGroupByTelegramChatID(ctx, group.TelegramChatID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.MaxMembers == nil || *retrieved.MaxMembers != 2 {
+		t.Fatalf("Expected member limit 2, got %v", retrieved.MaxMembers)
+	}
+
+	// Only active members count towards the limit
+	for i, status := range []domain.MembershipStatus{domain.MembershipStatusActive, domain.MembershipStatusActive, domain.MembershipStatusRemoved} {
+		membership := &domain.GroupMembership{GroupID: group.ID, UserID: int64(i + 1), JoinedAt: time.Now(), Status: status}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("Failed to create membership: %v", err)
+		}
+	}
+	activeMembers, err := membershipRepo.CountActiveMembers(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to count active members: %v", err)
+	}
+	if activeMembers != 2 {
+		t.Errorf("Expected 2 active members, got %d", activeMembers)
+	}
+	if !retrieved.IsFull(activeMembers) {
+		t.Error("Expected the group to be full")
+	}
+
+	if err := repo.UpdateGroupMaxMembers(ctx, group.ID, nil); err != nil {
+		t.Fatalf("Failed to remove member limit: %v", err)
+	}
+	retrieved, err = repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.MaxMembers != nil || retrieved.IsFull(activeMembers) {
+		t.Errorf("Expected the member limit to be removed, got %v", retrieved.MaxMembers)
+	}
+}
+
+func TestUpdateGroupReputationWeighting(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// Weighting is opt-in
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.ReputationWeighting {
+		t.Error("Expected reputation weighting to be disabled by default")
+	}
+
+	if err := repo.UpdateGroupReputationWeighting(ctx, group.ID, true); err != nil {
+		t.Fatalf("Failed to enable reputation weighting: %v", err)
+	}
+	groups, err := repo.GetAllGroups(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve groups: %v", err)
+	}
+	if len(groups) != 1 || !groups[0].ReputationWeighting {
+		t.Errorf("Expected reputation weighting to be enabled, got %+v", groups)
+	}
+}
+
+func TestUpdateGroupRequireApproval(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// Weighting is opt-in
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.RequireApproval {
+		t.Error("Expected approval to be disabled by default")
+	}
+
+	if err := repo.UpdateGroupRequireApproval(ctx, group.ID, true); err != nil {
+		t.Fatalf("Failed to enable approval: %v", err)
+	}
+	groups, err := repo.GetAllGroups(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve groups: %v", err)
+	}
+	if len(groups) != 1 || !groups[0].RequireApproval {
+		t.Errorf("Expected approval to be enabled, got %+v", groups)
+	}
+}
+
+func TestUpdateGroupDefaultEventType(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// No default event type by default
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.DefaultEventType != "" {
+		t.Errorf("Expected no default event type, got %q", retrieved.DefaultEventType)
+	}
+
+	if err := repo.UpdateGroupDefaultEventType(ctx, group.ID, domain.EventTypeProbability); err != nil {
+		t.Fatalf("Failed to set default event type: %v", err)
+	}
+	groups, err := repo.GetAllGroups(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve groups: %v", err)
+	}
+	if len(groups) != 1 || groups[0].DefaultEventType != domain.EventTypeProbability {
+		t.Errorf("Expected default event type %q, got %+v", domain.EventTypeProbability, groups)
+	}
+
+	// Unknown types are rejected and keep the stored value
+	if err := repo.UpdateGroupDefaultEventType(ctx, group.ID, domain.EventType("poll")); !errors.Is(err, domain.ErrInvalidEventType) {
+		t.Errorf("Expected ErrInvalidEventType, got %v", err)
+	}
+
+	// An empty type clears the default
+	if err := repo.UpdateGroupDefaultEventType(ctx, group.ID, ""); err != nil {
+		t.Fatalf("Failed to clear default event type: %v", err)
+	}
+	retrieved, err = repo.GetGroupByTelegramChatID(ctx, group.TelegramChatID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.DefaultEventType != "" {
+		t.Errorf("Expected default event type to be cleared, got %q", retrieved.DefaultEventType)
+	}
+}
+
+func TestUpdateGroupFirstVoteFinal(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// Vote changes are allowed by default
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.FirstVoteFinal {
+		t.Error("Expected vote changes to be allowed by default")
+	}
+
+	if err := repo.UpdateGroupFirstVoteFinal(ctx, group.ID, true); err != nil {
+		t.Fatalf("Failed to make the first vote final: %v", err)
+	}
+	groups, err := repo.GetAllGroups(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve groups: %v", err)
+	}
+	if len(groups) != 1 || !groups[0].FirstVoteFinal {
+		t.Errorf("Expected the first vote to be final, got %+v", groups)
+	}
+}
+
+func TestUpdateGroupPointsLabel(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// Groups start with the default label
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.PointsLabel != "" {
+		t.Errorf("Expected no points label by default, got %q", retrieved.PointsLabel)
+	}
+
+	if err := repo.UpdateGroupPointsLabel(ctx, group.ID, "coin, coins"); err != nil {
+		t.Fatalf("Failed to set points label: %v", err)
+	}
+	groups, err := repo.GetAllGroups(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve groups: %v", err)
+	}
+	if len(groups) != 1 || groups[0].PointsLabel != "coin, coins" {
+		t.Errorf("Expected points label %q, got %+v", "coin, coins", groups)
+	}
+
+	if err := repo.UpdateGroupPointsLabel(ctx, group.ID, ""); err != nil {
+		t.Fatalf("Failed to reset points label: %v", err)
+	}
+	retrieved, err = repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.PointsLabel != "" {
+		t.Errorf("Expected the points label to be reset, got %q", retrieved.PointsLabel)
+	}
+}
+
+func TestMergeGroups(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	membershipRepo := NewGroupMembershipRepository(queue)
+	ratingRepo := NewRatingRepository(queue)
+	achievementRepo := NewAchievementRepository(queue)
+	topicRepo := NewForumTopicRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	source := &domain.Group{TelegramChatID: -1001, Name: "Old", CreatedAt: now, CreatedBy: 1, IsForum: true}
+	target := &domain.Group{TelegramChatID: -1002, Name: "New", CreatedAt: now, CreatedBy: 1, IsForum: true}
+	for _, group := range []*domain.Group{source, target} {
+		if err := repo.CreateGroup(ctx, group); err != nil {
+			t.Fatalf("Failed to create group: %v", err)
+		}
+	}
+
+	// User 10 is in both groups, user 20 only in the source
+	for _, m := range []struct{ groupID, userID int64 }{{source.ID, 10}, {source.ID, 20}, {target.ID, 10}} {
+		membership := &domain.GroupMembership{GroupID: m.groupID, UserID: m.userID, JoinedAt: now, Status: domain.MembershipStatusActive}
+		if err := membershipRepo.CreateMembership(ctx, membership); err != nil {
+			t.Fatalf("Failed to create membership: %v", err)
+		}
+	}
+	for _, rating := range []*domain.Rating{
+		{UserID: 10, GroupID: source.ID, Score: 30, CorrectCount: 3, WrongCount: 1, BestStreak: 5},
+		{UserID: 20, GroupID: source.ID, Score: 7, CorrectCount: 1},
+		{UserID: 10, GroupID: target.ID, Score: 12, CorrectCount: 1, WrongCount: 2, BestStreak: 2},
+	} {
+		if err := ratingRepo.UpdateRating(ctx, rating); err != nil {
+			t.Fatalf("Failed to save rating: %v", err)
+		}
+	}
+	for _, groupID := range []int64{source.ID, target.ID} {
+		achievement := &domain.Achievement{UserID: 10, GroupID: groupID, Code: domain.AchievementSharpshooter, Timestamp: now}
+		if err := achievementRepo.SaveAchievement(ctx, achievement); err != nil {
+			t.Fatalf("Failed to save achievement: %v", err)
+		}
+	}
+
+	// The same forum thread is registered in both groups
+	sourceTopic := &domain.ForumTopic{GroupID: source.ID, MessageThreadID: 5, Name: "Bets", CreatedAt: now, CreatedBy: 1}
+	targetTopic := &domain.ForumTopic{GroupID: target.ID, MessageThreadID: 5, Name: "Bets", CreatedAt: now, CreatedBy: 1}
+	for _, topic := range []*domain.ForumTopic{sourceTopic, targetTopic} {
+		if err := topicRepo.CreateForumTopic(ctx, topic); err != nil {
+			t.Fatalf("Failed to create forum topic: %v", err)
+		}
+	}
+	event := &domain.Event{
+		GroupID:      source.ID,
+		ForumTopicID: &sourceTopic.ID,
+		Question:     "Will it rain?",
+		Options:      []string{"Yes", "No"},
+		CreatedAt:    now,
+		Deadline:     now.Add(time.Hour),
+		Status:       domain.EventStatusActive,
+		EventType:    domain.EventTypeBinary,
+		CreatedBy:    1,
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	if err := repo.MergeGroups(ctx, source.ID, source.ID); !errors.Is(err, domain.ErrMergeSameGroup) {
+		t.Errorf("Expected ErrMergeSameGroup, got %v", err)
+	}
+
+	if err := repo.MergeGroups(ctx, source.ID, target.ID); err != nil {
+		t.Fatalf("Failed to merge groups: %v", err)
+	}
+
+	merged, err := repo.GetGroup(ctx, source.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if merged.Status != domain.GroupStatusDeleted {
+		t.Errorf("Expected source group to be deleted, got %s", merged.Status)
+	}
+
+	members, err := membershipRepo.GetGroupMembers(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("Failed to get members: %v", err)
+	}
+	if len(members) != 2 {
+		t.Errorf("Expected 2 members in the target group, got %d", len(members))
+	}
+	if members, _ := membershipRepo.GetGroupMembers(ctx, source.ID); len(members) != 0 {
+		t.Errorf("Expected no members left in the source group, got %d", len(members))
+	}
+
+	rating, err := ratingRepo.GetRating(ctx, 10, target.ID)
+	if err != nil {
+		t.Fatalf("Failed to get rating: %v", err)
+	}
+	if rating.Score != 42 || rating.CorrectCount != 4 || rating.WrongCount != 3 || rating.BestStreak != 5 {
+		t.Errorf("Expected summed rating 42/4/3 with best streak 5, got %+v", rating)
+	}
+	rating, err = ratingRepo.GetRating(ctx, 20, target.ID)
+	if err != nil {
+		t.Fatalf("Failed to get rating: %v", err)
+	}
+	if rating.Score != 7 {
+		t.Errorf("Expected moved rating with score 7, got %+v", rating)
+	}
+
+	achievements, err := achievementRepo.GetUserAchievements(ctx, 10, target.ID)
+	if err != nil {
+		t.Fatalf("Failed to get achievements: %v", err)
+	}
+	if len(achievements) != 1 {
+		t.Errorf("Expected the duplicate achievement to be dropped, got %d", len(achievements))
+	}
+
+	movedEvent, err := eventRepo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("Failed to get event: %v", err)
+	}
+	if movedEvent.GroupID != target.ID || movedEvent.ForumTopicID == nil || *movedEvent.ForumTopicID != targetTopic.ID {
+		t.Errorf("Expected event in target group topic %d, got group %d topic %v", targetTopic.ID, movedEvent.GroupID, movedEvent.ForumTopicID)
+	}
+	if topic, _ := topicRepo.GetForumTopic(ctx, sourceTopic.ID); topic != nil {
+		t.Errorf("Expected duplicate forum topic to be removed, got %+v", topic)
+	}
+}
+
+func TestUpdateGroupExcludeCreatorScoring(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// Creators score on their own events by default
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if retrieved.ExcludeCreatorScoring {
+		t.Error("Expected creators to score on their own events by default")
+	}
+
+	if err := repo.UpdateGroupExcludeCreatorScoring(ctx, group.ID, true); err != nil {
+		t.Fatalf("Failed to exclude creators from scoring: %v", err)
+	}
+	byChat, err := repo.GetGroupByTelegramChatID(ctx, group.TelegramChatID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if !byChat.ExcludeCreatorScoring {
+		t.Error("Expected creators to be excluded from scoring")
+	}
+
+	if err := repo.UpdateGroupExcludeCreatorScoring(ctx, group.ID, false); err != nil {
+		t.Fatalf("Failed to include creators in scoring: %v", err)
+	}
+	groups, err := repo.GetAllGroups(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve groups: %v", err)
+	}
+	if len(groups) != 1 || groups[0].ExcludeCreatorScoring {
+		t.Errorf("Expected creators to score again, got %+v", groups)
+	}
+}
+
+func TestUpdateGroupDisabledAchievements(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewGroupRepository(queue)
+	ctx := context.Background()
+
+	group := &domain.Group{
+		TelegramChatID: -1001234567890,
+		Name:           "Test Group",
+		CreatedAt:      time.Now().Truncate(time.Second),
+		CreatedBy:      12345,
+	}
+	if err := repo.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	// All achievements are enabled by default
+	retrieved, err := repo.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if len(retrieved.DisabledAchievements) != 0 || !retrieved.AchievementEnabled(domain.AchievementVeteran) {
+		t.Errorf("Expected all achievements enabled by default, got %v", retrieved.DisabledAchievements)
+	}
+
+	disabled := []domain.AchievementCode{domain.AchievementSharpshooter, domain.AchievementVeteran}
+	if err := repo.UpdateGroupDisabledAchievements(ctx, group.ID, disabled); err != nil {
+		t.Fatalf("Failed to disable achievements: %v", err)
+	}
+	byChat, err := repo.GetGroupByTelegramChatID(ctx, group.TelegramChatID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve group: %v", err)
+	}
+	if len(byChat.DisabledAchievements) != 2 || byChat.AchievementEnabled(domain.AchievementVeteran) || !byChat.AchievementEnabled(domain.AchievementProphet) {
+		t.Errorf("Expected sharpshooter and veteran disabled, got %v", byChat.DisabledAchievements)
+	}
+
+	if err := repo.UpdateGroupDisabledAchievements(ctx, group.ID, nil); err != nil {
+		t.Fatalf("Failed to enable achievements: %v", err)
+	}
+	groups, err := repo.GetAllGroups(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve groups: %v", err)
+	}
+	if len(groups) != 1 || len(groups[0].DisabledAchievements) != 0 {
+		t.Errorf("Expected all achievements enabled again, got %+v", groups)
+	}
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// LanguageRepository handles the languages chosen for users and chats.
+// It implements locale.LanguagePreferences; lookups are cached, as every update resolves a language.
+type LanguageRepository struct {
+	queue *DBQueue
+
+	users sync.Map // Language by user ID, "" when none is stored
+	chats sync.Map // Language by chat ID, "" when none is stored
+}
+
+// NewLanguageRepository creates a new LanguageRepository
+func NewLanguageRepository(queue *DBQueue) *LanguageRepository {
+	return &LanguageRepository{queue: queue}
+}
+
+// UserLanguage returns the language chosen by a user, empty when none is stored
+func (r *LanguageRepository) UserLanguage(ctx context.Context, userID int64) (string, error) {
+	return r.language(ctx, &r.users, `SELECT language FROM user_languages WHERE user_id = ?`, userID)
+}
+
+// ChatLanguage returns the language chosen for a chat, empty when none is stored
+func (r *LanguageRepository) ChatLanguage(ctx context.Context, chatID int64) (string, error) {
+	return r.language(ctx, &r.chats, `SELECT language FROM chat_languages WHERE chat_id = ?`, chatID)
+}
+
+// SetUserLanguage stores the language of a user; an empty language removes the preference
+func (r *LanguageRepository) SetUserLanguage(ctx context.Context, userID int64, language string) error {
+	return r.setLanguage(ctx, &r.users, "user_languages", "user_id", userID, language)
+}
+
+// SetChatLanguage stores the language of a chat; an empty language removes the preference
+func (r *LanguageRepository) SetChatLanguage(ctx context.Context, chatID int64, language string) error {
+	return r.setLanguage(ctx, &r.chats, "chat_languages", "chat_id", chatID, language)
+}
+
+func (r *LanguageRepository) language(ctx context.Context, cache *sync.Map, query string, id int64) (string, error) {
+	if language, ok := cache.Load(id); ok {
+		return language.(string), nil
+	}
+
+	var language string
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx, query, id).Scan(&language)
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+
+	cache.Store(id, language)
+	return language, nil
+}
+
+func (r *LanguageRepository) setLanguage(ctx context.Context, cache *sync.Map, table, column string, id int64, language string) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		if language == "" {
+			_, err := db.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+column+` = ?`, id)
+			return err
+		}
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO `+table+` (`+column+`, language, updated_at) VALUES (?, ?, ?)
+			 ON CONFLICT(`+column+`) DO UPDATE SET language = excluded.language, updated_at = excluded.updated_at`,
+			id, language, time.Now(),
+		)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	cache.Store(id, language)
+	return nil
+}
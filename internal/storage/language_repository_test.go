@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestLanguageRepository(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewLanguageRepository(queue)
+	ctx := context.Background()
+
+	// Nothing is stored until a language is chosen
+	if lang, err := repo.UserLanguage(ctx, 100); err != nil || lang != "" {
+		t.Fatalf("Expected no user language, got %q (err %v)", lang, err)
+	}
+	if lang, err := repo.ChatLanguage(ctx, -100); err != nil || lang != "" {
+		t.Fatalf("Expected no chat language, got %q (err %v)", lang, err)
+	}
+
+	if err := repo.SetUserLanguage(ctx, 100, "ru"); err != nil {
+		t.Fatalf("SetUserLanguage failed: %v", err)
+	}
+	if err := repo.SetChatLanguage(ctx, -100, "en"); err != nil {
+		t.Fatalf("SetChatLanguage failed: %v", err)
+	}
+	if err := repo.SetUserLanguage(ctx, 100, "en"); err != nil {
+		t.Fatalf("SetUserLanguage failed: %v", err)
+	}
+
+	// A fresh repository reads the stored languages, not the cache
+	fresh := NewLanguageRepository(queue)
+	if lang, err := fresh.UserLanguage(ctx, 100); err != nil || lang != "en" {
+		t.Errorf("Expected user language en, got %q (err %v)", lang, err)
+	}
+	if lang, err := fresh.ChatLanguage(ctx, -100); err != nil || lang != "en" {
+		t.Errorf("Expected chat language en, got %q (err %v)", lang, err)
+	}
+	if lang, err := fresh.UserLanguage(ctx, 200); err != nil || lang != "" {
+		t.Errorf("Expected no language for another user, got %q (err %v)", lang, err)
+	}
+
+	// An empty language removes the preference
+	if err := repo.SetUserLanguage(ctx, 100, ""); err != nil {
+		t.Fatalf("SetUserLanguage failed: %v", err)
+	}
+	if lang, err := repo.UserLanguage(ctx, 100); err != nil || lang != "" {
+		t.Errorf("Expected the user language to be removed, got %q (err %v)", lang, err)
+	}
+	if lang, err := NewLanguageRepository(queue).UserLanguage(ctx, 100); err != nil || lang != "" {
+		t.Errorf("Expected the user language to be removed from the database, got %q (err %v)", lang, err)
+	}
+}
@@ -233,6 +233,419 @@ CREATE INDEX IF NOT EXISTS idx_groups_status ON groups(status);
 ALTER TABLE events ADD COLUMN allows_revoting INTEGER NOT NULL DEFAULT 1;
 ALTER TABLE events ADD COLUMN shuffle_options INTEGER NOT NULL DEFAULT 0;
 ALTER TABLE events ADD COLUMN hide_results_until_close INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     11,
+		Description: "Add stats_message_id column to events table for live poll stats",
+		SQL: `
+ALTER TABLE events ADD COLUMN stats_message_id INTEGER;
+`,
+	},
+	{
+		Version:     12,
+		Description: "Add poll_pinned column to events table for unpinning polls on resolution",
+		SQL: `
+ALTER TABLE events ADD COLUMN poll_pinned INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     13,
+		Description: "Add pin_polls column to groups table for per-group poll pinning",
+		SQL: `
+ALTER TABLE groups ADD COLUMN pin_polls INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     14,
+		Description: "Add user_profiles table for caching Telegram usernames",
+		SQL: `
+CREATE TABLE IF NOT EXISTS user_profiles (
+    user_id INTEGER PRIMARY KEY,
+    username TEXT NOT NULL DEFAULT '',
+    first_name TEXT NOT NULL DEFAULT '',
+    last_name TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`,
+	},
+	{
+		Version:     15,
+		Description: "Add bot_settings table for persisted runtime settings",
+		SQL: `
+CREATE TABLE IF NOT EXISTS bot_settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`,
+	},
+	{
+		Version:     16,
+		Description: "Add feedback table for user bug reports and suggestions",
+		SQL: `
+CREATE TABLE IF NOT EXISTS feedback (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    display_name TEXT NOT NULL DEFAULT '',
+    text TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_user_created ON feedback(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_feedback_created ON feedback(created_at);
+`,
+	},
+	{
+		Version:     17,
+		Description: "Add event_participants table for events restricted to specific members",
+		SQL: `
+CREATE TABLE IF NOT EXISTS event_participants (
+    event_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    PRIMARY KEY (event_id, user_id),
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_participants_user ON event_participants(user_id);
+`,
+	},
+	{
+		Version:     18,
+		Description: "Add photo_file_id and photo_message_id columns to events table for attached images",
+		SQL: `
+ALTER TABLE events ADD COLUMN photo_file_id TEXT;
+ALTER TABLE events ADD COLUMN photo_message_id INTEGER;
+`,
+	},
+	{
+		Version:     19,
+		Description: "Add best_streak column to ratings table and backfill it from the current streak",
+		SQL: `
+ALTER TABLE ratings ADD COLUMN best_streak INTEGER NOT NULL DEFAULT 0;
+UPDATE ratings SET best_streak = streak;
+`,
+	},
+	{
+		Version:     20,
+		Description: "Add resolution_nags table for repeated reminders to resolve expired events",
+		SQL: `
+CREATE TABLE IF NOT EXISTS resolution_nags (
+    event_id INTEGER PRIMARY KEY,
+    nag_count INTEGER NOT NULL DEFAULT 0,
+    last_sent_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+`,
+	},
+	{
+		Version:     21,
+		Description: "Add default_event_type column to groups table for per-group default event type",
+		SQL: `
+ALTER TABLE groups ADD COLUMN default_event_type TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version:     22,
+		Description: "Add require_rules column to groups table for the rules acceptance gate",
+		SQL: `
+ALTER TABLE groups ADD COLUMN require_rules INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     23,
+		Description: "Add rules_pending column to group_memberships table for the rules acceptance gate",
+		SQL: `
+-- Existing members are grandfathered in as having accepted the rules
+ALTER TABLE group_memberships ADD COLUMN rules_pending INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     24,
+		Description: "Add reminder_claims table so only one bot instance sends each deadline reminder",
+		SQL: `
+CREATE TABLE IF NOT EXISTS reminder_claims (
+    event_id INTEGER PRIMARY KEY,
+    instance_id TEXT NOT NULL,
+    claimed_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+`,
+	},
+	{
+		Version:     25,
+		Description: "Add reminder_offsets column to events table and event_reminders table for custom reminder schedules",
+		SQL: `
+ALTER TABLE events ADD COLUMN reminder_offsets TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS event_reminders (
+    event_id INTEGER NOT NULL,
+    offset_seconds INTEGER NOT NULL,
+    remind_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP,
+    claimed_by TEXT,
+    claimed_at TIMESTAMP,
+    PRIMARY KEY (event_id, offset_seconds),
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_reminders_remind_at ON event_reminders(remind_at);
+`,
+	},
+	{
+		Version:     26,
+		Description: "Add duels and duel_records tables for private prediction duels",
+		SQL: `
+CREATE TABLE IF NOT EXISTS duels (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    challenger_id INTEGER NOT NULL,
+    opponent_id INTEGER NOT NULL,
+    question TEXT NOT NULL,
+    challenger_pick INTEGER,
+    opponent_pick INTEGER,
+    challenger_report INTEGER,
+    opponent_report INTEGER,
+    outcome INTEGER,
+    deadline TIMESTAMP NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_duels_status_deadline ON duels(status, deadline);
+
+CREATE TABLE IF NOT EXISTS duel_records (
+    user_id INTEGER PRIMARY KEY,
+    wins INTEGER NOT NULL DEFAULT 0,
+    losses INTEGER NOT NULL DEFAULT 0,
+    draws INTEGER NOT NULL DEFAULT 0
+);
+`,
+	},
+	{
+		Version:     27,
+		Description: "Add auto_remove_inactive column to groups table for removing long inactive members",
+		SQL: `
+ALTER TABLE groups ADD COLUMN auto_remove_inactive INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     28,
+		Description: "Add status_changed_at column to group_memberships table so rejoined members count as active",
+		SQL: `
+ALTER TABLE group_memberships ADD COLUMN status_changed_at TIMESTAMP;
+`,
+	},
+	{
+		Version:     29,
+		Description: "Add max_members column to groups table for capping the number of active members",
+		SQL: `
+ALTER TABLE groups ADD COLUMN max_members INTEGER;
+`,
+	},
+	{
+		Version:     30,
+		Description: "Add reputation_weighting column to groups table for weighting votes by rating",
+		SQL: `
+ALTER TABLE groups ADD COLUMN reputation_weighting INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     31,
+		Description: "Add subscriptions table for per-group new event notifications",
+		SQL: `
+CREATE TABLE IF NOT EXISTS subscriptions (
+    user_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    subscribed INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, group_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_group ON subscriptions(group_id);
+`,
+	},
+	{
+		Version:     32,
+		Description: "Add resolved_at column to events table for outcome digests",
+		SQL: `
+ALTER TABLE events ADD COLUMN resolved_at TIMESTAMP;
+`,
+	},
+	{
+		Version:     33,
+		Description: "Add notification_settings table for outcome notification preferences",
+		SQL: `
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id INTEGER PRIMARY KEY,
+    outcome_mode TEXT NOT NULL DEFAULT 'immediate',
+    last_digest_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL
+);
+`,
+	},
+	{
+		Version:     34,
+		Description: "Add countdown_message_id column to events table for poll countdowns",
+		SQL: `
+ALTER TABLE events ADD COLUMN countdown_message_id INTEGER;
+`,
+	},
+	{
+		Version:     35,
+		Description: "Add poll_message_missing column to events table for orphaned poll reports",
+		SQL: `
+ALTER TABLE events ADD COLUMN poll_message_missing INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     36,
+		Description: "Add first_seen_at column to user_profiles table for the join account age gate",
+		SQL: `
+ALTER TABLE user_profiles ADD COLUMN first_seen_at TIMESTAMP;
+UPDATE user_profiles SET first_seen_at = updated_at WHERE first_seen_at IS NULL;
+`,
+	},
+	{
+		Version:     37,
+		Description: "Add voting_closed_at column to events table for closing voting before resolution",
+		SQL: `
+ALTER TABLE events ADD COLUMN voting_closed_at TIMESTAMP;
+`,
+	},
+	{
+		Version:     38,
+		Description: "Add require_approval column to groups table for the event approval queue",
+		SQL: `
+ALTER TABLE groups ADD COLUMN require_approval INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     39,
+		Description: "Add points_label column to groups table for custom names of points",
+		SQL: `
+ALTER TABLE groups ADD COLUMN points_label TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version:     40,
+		Description: "Add first_vote_final column to groups table to forbid changing votes",
+		SQL: `
+ALTER TABLE groups ADD COLUMN first_vote_final INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     41,
+		Description: "Add participation_awarded column to predictions table for participation points credited at vote time",
+		SQL: `
+ALTER TABLE predictions ADD COLUMN participation_awarded INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     42,
+		Description: "Add resolution_note column to events table for the evidence given when resolving",
+		SQL: `
+ALTER TABLE events ADD COLUMN resolution_note TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version:     43,
+		Description: "Add minority_correct column to events table for events whose correct option was a minority pick",
+		SQL: `
+ALTER TABLE events ADD COLUMN minority_correct INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     44,
+		Description: "Add escalated_at column to resolution_nags table for escalating unresolved events to admins",
+		SQL: `
+ALTER TABLE resolution_nags ADD COLUMN escalated_at TIMESTAMP;
+`,
+	},
+	{
+		Version:     45,
+		Description: "Add lock_votes_at column to events table for locking votes before the deadline",
+		SQL: `
+ALTER TABLE events ADD COLUMN lock_votes_at TIMESTAMP;
+`,
+	},
+	{
+		Version:     46,
+		Description: "Add quiz_answer column to events table for quiz events",
+		SQL: `
+ALTER TABLE events ADD COLUMN quiz_answer INTEGER;
+`,
+	},
+	{
+		Version:     47,
+		Description: "Add unreachable_users table for users who can't receive direct messages",
+		SQL: `
+CREATE TABLE IF NOT EXISTS unreachable_users (
+    user_id INTEGER PRIMARY KEY,
+    marked_at TIMESTAMP NOT NULL
+);
+`,
+	},
+	{
+		Version:     48,
+		Description: "Add seasons and season_results tables for archived season ratings",
+		SQL: `
+CREATE TABLE IF NOT EXISTS seasons (
+    group_id INTEGER NOT NULL,
+    number INTEGER NOT NULL,
+    started_at TIMESTAMP,
+    ended_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (group_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS season_results (
+    group_id INTEGER NOT NULL,
+    season INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    username TEXT NOT NULL DEFAULT '',
+    score INTEGER NOT NULL DEFAULT 0,
+    correct_count INTEGER NOT NULL DEFAULT 0,
+    wrong_count INTEGER NOT NULL DEFAULT 0,
+    best_streak INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (group_id, season, user_id)
+);
+`,
+	},
+	{
+		Version:     49,
+		Description: "Add exclude_creator_scoring column to groups table to keep creators from scoring their own events",
+		SQL: `
+ALTER TABLE groups ADD COLUMN exclude_creator_scoring INTEGER NOT NULL DEFAULT 0;
+`,
+	},
+	{
+		Version:     50,
+		Description: "Add disabled_achievements column to groups table to turn off achievements per group",
+		SQL: `
+ALTER TABLE groups ADD COLUMN disabled_achievements TEXT NOT NULL DEFAULT '[]';
+`,
+	},
+	{
+		Version:     51,
+		Description: "Add user_languages and chat_languages tables for language preferences",
+		SQL: `
+CREATE TABLE IF NOT EXISTS user_languages (
+    user_id INTEGER PRIMARY KEY,
+    language TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS chat_languages (
+    chat_id INTEGER PRIMARY KEY,
+    language TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+`,
+	},
+	{
+		Version:     52,
+		Description: "Add archive_days column to groups table for per-group event archival",
+		SQL: `
+ALTER TABLE groups ADD COLUMN archive_days INTEGER;
 `,
 	},
 }
@@ -434,6 +847,636 @@ CREATE TABLE IF NOT EXISTS schema_migrations (
 				}
 			}
 
+			// Special handling for migration 11 - check if column already exists
+			if migration.Version == 11 {
+				// Check if stats_message_id already exists in events table
+				exists, err := columnExists(db, "events", "stats_message_id")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 12 - check if column already exists
+			if migration.Version == 12 {
+				// Check if poll_pinned already exists in events table
+				exists, err := columnExists(db, "events", "poll_pinned")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 13 - check if column already exists
+			if migration.Version == 13 {
+				// Check if pin_polls already exists in groups table
+				exists, err := columnExists(db, "groups", "pin_polls")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 18 - check if columns already exist
+			if migration.Version == 18 {
+				// Check if photo_file_id already exists in events table
+				exists, err := columnExists(db, "events", "photo_file_id")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Columns already exist, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 19 - check if column already exists
+			if migration.Version == 19 {
+				// Check if best_streak already exists in ratings table
+				exists, err := columnExists(db, "ratings", "best_streak")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 21 - check if column already exists
+			if migration.Version == 21 {
+				// Check if default_event_type already exists in groups table
+				exists, err := columnExists(db, "groups", "default_event_type")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 22 - check if column already exists
+			if migration.Version == 22 {
+				// Check if require_rules already exists in groups table
+				exists, err := columnExists(db, "groups", "require_rules")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 23 - check if column already exists
+			if migration.Version == 23 {
+				// Check if rules_pending already exists in group_memberships table
+				exists, err := columnExists(db, "group_memberships", "rules_pending")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 25 - check if column already exists
+			if migration.Version == 25 {
+				// Check if reminder_offsets already exists in events table
+				exists, err := columnExists(db, "events", "reminder_offsets")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 27 - check if column already exists
+			if migration.Version == 27 {
+				// Check if auto_remove_inactive already exists in groups table
+				exists, err := columnExists(db, "groups", "auto_remove_inactive")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 28 - check if column already exists
+			if migration.Version == 28 {
+				// Check if status_changed_at already exists in group_memberships table
+				exists, err := columnExists(db, "group_memberships", "status_changed_at")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 29 - check if column already exists
+			if migration.Version == 29 {
+				// Check if max_members already exists in groups table
+				exists, err := columnExists(db, "groups", "max_members")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 30 - check if column already exists
+			if migration.Version == 30 {
+				// Check if reputation_weighting already exists in groups table
+				exists, err := columnExists(db, "groups", "reputation_weighting")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 32 - check if column already exists
+			if migration.Version == 32 {
+				// Check if resolved_at already exists in events table
+				exists, err := columnExists(db, "events", "resolved_at")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 34 - check if column already exists
+			if migration.Version == 34 {
+				// Check if countdown_message_id already exists in events table
+				exists, err := columnExists(db, "events", "countdown_message_id")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 35 - check if column already exists
+			if migration.Version == 35 {
+				// Check if poll_message_missing already exists in events table
+				exists, err := columnExists(db, "events", "poll_message_missing")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 36 - check if column already exists
+			if migration.Version == 36 {
+				// Check if first_seen_at already exists in user_profiles table
+				exists, err := columnExists(db, "user_profiles", "first_seen_at")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 37 - check if column already exists
+			if migration.Version == 37 {
+				// Check if voting_closed_at already exists in events table
+				exists, err := columnExists(db, "events", "voting_closed_at")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 38 - check if column already exists
+			if migration.Version == 38 {
+				// Check if require_approval already exists in groups table
+				exists, err := columnExists(db, "groups", "require_approval")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 39 - check if column already exists
+			if migration.Version == 39 {
+				// Check if points_label already exists in groups table
+				exists, err := columnExists(db, "groups", "points_label")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 40 - check if column already exists
+			if migration.Version == 40 {
+				// Check if first_vote_final already exists in groups table
+				exists, err := columnExists(db, "groups", "first_vote_final")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 41 - check if column already exists
+			if migration.Version == 41 {
+				// Check if participation_awarded already exists in predictions table
+				exists, err := columnExists(db, "predictions", "participation_awarded")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 42 - check if column already exists
+			if migration.Version == 42 {
+				// Check if resolution_note already exists in events table
+				exists, err := columnExists(db, "events", "resolution_note")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 43 - check if column already exists
+			if migration.Version == 43 {
+				// Check if minority_correct already exists in events table
+				exists, err := columnExists(db, "events", "minority_correct")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 44 - check if column already exists
+			if migration.Version == 44 {
+				// Check if escalated_at already exists in resolution_nags table
+				exists, err := columnExists(db, "resolution_nags", "escalated_at")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 45 - check if column already exists
+			if migration.Version == 45 {
+				// Check if lock_votes_at already exists in events table
+				exists, err := columnExists(db, "events", "lock_votes_at")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 46 - check if column already exists
+			if migration.Version == 46 {
+				// Check if quiz_answer already exists in events table
+				exists, err := columnExists(db, "events", "quiz_answer")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 49 - check if column already exists
+			if migration.Version == 49 {
+				// Check if exclude_creator_scoring already exists in groups table
+				exists, err := columnExists(db, "groups", "exclude_creator_scoring")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 50 - check if column already exists
+			if migration.Version == 50 {
+				// Check if disabled_achievements already exists in groups table
+				exists, err := columnExists(db, "groups", "disabled_achievements")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
+			// Special handling for migration 52 - check if column already exists
+			if migration.Version == 52 {
+				// Check if archive_days already exists in groups table
+				exists, err := columnExists(db, "groups", "archive_days")
+				if err != nil {
+					return fmt.Errorf("failed to check column existence: %w", err)
+				}
+				if exists {
+					// Column already exists, just mark migration as complete
+					_, err = db.Exec(
+						"INSERT OR IGNORE INTO schema_migrations (version, description) VALUES (?, ?)",
+						migration.Version,
+						migration.Description,
+					)
+					if err != nil {
+						return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+					}
+					continue
+				}
+			}
+
 			// Start transaction
 			tx, err := db.Begin()
 			if err != nil {
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+// NotificationSettingsRepository handles per-user outcome notification preferences
+type NotificationSettingsRepository struct {
+	queue *DBQueue
+}
+
+// NewNotificationSettingsRepository creates a new NotificationSettingsRepository
+func NewNotificationSettingsRepository(queue *DBQueue) *NotificationSettingsRepository {
+	return &NotificationSettingsRepository{queue: queue}
+}
+
+// GetOutcomeNotificationMode retrieves the outcome notification mode of a user, immediate when none is stored
+func (r *NotificationSettingsRepository) GetOutcomeNotificationMode(ctx context.Context, userID int64) (domain.OutcomeNotificationMode, error) {
+	var mode string
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT outcome_mode FROM notification_settings WHERE user_id = ?`,
+			userID,
+		).Scan(&mode)
+	})
+
+	if err == sql.ErrNoRows {
+		return domain.OutcomeNotificationsImmediate, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return domain.OutcomeNotificationMode(mode), nil
+}
+
+// SetOutcomeNotificationMode stores the outcome notification mode of a user.
+// Switching to digest starts the digest now, so outcomes already sent immediately are not repeated.
+func (r *NotificationSettingsRepository) SetOutcomeNotificationMode(ctx context.Context, userID int64, mode domain.OutcomeNotificationMode) error {
+	if !mode.IsValid() {
+		return domain.ErrInvalidOutcomeNotificationMode
+	}
+
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		now := time.Now()
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO notification_settings (user_id, outcome_mode, last_digest_at, updated_at) VALUES (?, ?, ?, ?)
+			 ON CONFLICT(user_id) DO UPDATE SET
+				last_digest_at = CASE
+					WHEN excluded.outcome_mode = ? AND notification_settings.outcome_mode != ? THEN excluded.updated_at
+					ELSE notification_settings.last_digest_at
+				END,
+				outcome_mode = excluded.outcome_mode,
+				updated_at = excluded.updated_at`,
+			userID, string(mode), now, now,
+			string(domain.OutcomeNotificationsDigest), string(domain.OutcomeNotificationsDigest),
+		)
+		return err
+	})
+}
+
+// GetDigestRecipients retrieves digest users whose last digest is older than before
+func (r *NotificationSettingsRepository) GetDigestRecipients(ctx context.Context, before time.Time) ([]domain.DigestRecipient, error) {
+	var recipients []domain.DigestRecipient
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT user_id, last_digest_at
+			 FROM notification_settings
+			 WHERE outcome_mode = ? AND last_digest_at < ?
+			 ORDER BY user_id`,
+			string(domain.OutcomeNotificationsDigest), before,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			var recipient domain.DigestRecipient
+			if err := rows.Scan(&recipient.UserID, &recipient.Since); err != nil {
+				return err
+			}
+			recipients = append(recipients, recipient)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return recipients, nil
+}
+
+// MarkDigestSent records the time the last digest of a user covered
+func (r *NotificationSettingsRepository) MarkDigestSent(ctx context.Context, userID int64, sentAt time.Time) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`UPDATE notification_settings SET last_digest_at = ? WHERE user_id = ?`,
+			sentAt, userID,
+		)
+		return err
+	})
+}
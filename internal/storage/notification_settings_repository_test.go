@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+func TestNotificationSettingsRepository(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewNotificationSettingsRepository(queue)
+	ctx := context.Background()
+
+	// Users get immediate notifications until they choose otherwise
+	mode, err := repo.GetOutcomeNotificationMode(ctx, 100)
+	if err != nil {
+		t.Fatalf("GetOutcomeNotificationMode failed: %v", err)
+	}
+	if mode != domain.OutcomeNotificationsImmediate {
+		t.Errorf("Expected immediate by default, got %q", mode)
+	}
+
+	if err := repo.SetOutcomeNotificationMode(ctx, 100, "weekly"); err != domain.ErrInvalidOutcomeNotificationMode {
+		t.Errorf("Expected ErrInvalidOutcomeNotificationMode, got %v", err)
+	}
+
+	before := time.Now()
+	for _, userID := range []int64{100, 200} {
+		if err := repo.SetOutcomeNotificationMode(ctx, userID, domain.OutcomeNotificationsDigest); err != nil {
+			t.Fatalf("SetOutcomeNotificationMode failed: %v", err)
+		}
+	}
+	if err := repo.SetOutcomeNotificationMode(ctx, 300, domain.OutcomeNotificationsOff); err != nil {
+		t.Fatalf("SetOutcomeNotificationMode failed: %v", err)
+	}
+
+	mode, err = repo.GetOutcomeNotificationMode(ctx, 100)
+	if err != nil || mode != domain.OutcomeNotificationsDigest {
+		t.Errorf("Expected digest, got %q (%v)", mode, err)
+	}
+
+	// A new digest starts when the mode is chosen
+	recipients, err := repo.GetDigestRecipients(ctx, before)
+	if err != nil {
+		t.Fatalf("GetDigestRecipients failed: %v", err)
+	}
+	if len(recipients) != 0 {
+		t.Errorf("Expected no recipients due before the mode was chosen, got %v", recipients)
+	}
+
+	recipients, err = repo.GetDigestRecipients(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetDigestRecipients failed: %v", err)
+	}
+	if len(recipients) != 2 || recipients[0].UserID != 100 || recipients[1].UserID != 200 {
+		t.Fatalf("Expected digest users 100 and 200, got %v", recipients)
+	}
+	if recipients[0].Since.Before(before.Add(-time.Second)) {
+		t.Errorf("Expected the digest to start when the mode was chosen, got %v", recipients[0].Since)
+	}
+
+	// A sent digest moves the start of the next one
+	sentAt := time.Now().Add(2 * time.Hour)
+	if err := repo.MarkDigestSent(ctx, 100, sentAt); err != nil {
+		t.Fatalf("MarkDigestSent failed: %v", err)
+	}
+	recipients, err = repo.GetDigestRecipients(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetDigestRecipients failed: %v", err)
+	}
+	if len(recipients) != 1 || recipients[0].UserID != 200 {
+		t.Errorf("Expected only user 200 to be due, got %v", recipients)
+	}
+
+	// Choosing digest again keeps the last digest time
+	if err := repo.SetOutcomeNotificationMode(ctx, 100, domain.OutcomeNotificationsDigest); err != nil {
+		t.Fatalf("SetOutcomeNotificationMode failed: %v", err)
+	}
+	recipients, err = repo.GetDigestRecipients(ctx, sentAt.Add(time.Second))
+	if err != nil {
+		t.Fatalf("GetDigestRecipients failed: %v", err)
+	}
+	if len(recipients) != 2 || !recipients[0].Since.Equal(sentAt) {
+		t.Errorf("Expected user 100 to keep the last digest time, got %v", recipients)
+	}
+}
+
+func TestGetResolvedOutcomesSince(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	predictionRepo := NewPredictionRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	ctx := context.Background()
+
+	now := time.Now()
+	var events []*domain.Event
+	for _, question := range []string{"Resolved?", "Active?"} {
+		event := &domain.Event{
+			GroupID:   1,
+			Question:  question,
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now.Add(-time.Hour),
+			Deadline:  now.Add(time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 1,
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: 100, Option: 1, Timestamp: now}); err != nil {
+			t.Fatalf("Failed to save prediction: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	since := time.Now()
+	if err := eventRepo.ResolveEvent(ctx, events[0].ID, 0); err != nil {
+		t.Fatalf("ResolveEvent failed: %v", err)
+	}
+
+	outcomes, err := predictionRepo.GetResolvedOutcomesSince(ctx, 100, since.Add(-time.Second))
+	if err != nil {
+		t.Fatalf("GetResolvedOutcomesSince failed: %v", err)
+	}
+	if len(outcomes) != 1 {
+		t.Fatalf("Expected 1 outcome, got %d", len(outcomes))
+	}
+	outcome := outcomes[0]
+	if outcome.EventID != events[0].ID || outcome.Question != "Resolved?" || outcome.CorrectOption != 0 || outcome.UserOption != 1 || len(outcome.Options) != 2 {
+		t.Errorf("Unexpected outcome: %+v", outcome)
+	}
+	if outcome.ResolvedAt.Before(since.Add(-time.Second)) {
+		t.Errorf("Expected the resolution time to be recorded, got %v", outcome.ResolvedAt)
+	}
+
+	// Outcomes before since and of other users are excluded
+	outcomes, err = predictionRepo.GetResolvedOutcomesSince(ctx, 100, time.Now().Add(time.Minute))
+	if err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected no outcomes after the resolution, got %v (%v)", outcomes, err)
+	}
+	outcomes, err = predictionRepo.GetResolvedOutcomesSince(ctx, 200, time.Time{})
+	if err != nil || len(outcomes) != 0 {
+		t.Errorf("Expected no outcomes for another user, got %v (%v)", outcomes, err)
+	}
+}
@@ -3,6 +3,8 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/ad/gitelegram-prediction-market/internal/domain"
 )
@@ -19,11 +21,11 @@ func NewPredictionRepository(queue *DBQueue) *PredictionRepository {
 
 // SavePrediction saves a new prediction to the database
 func (r *PredictionRepository) SavePrediction(ctx context.Context, prediction *domain.Prediction) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		result, err := db.ExecContext(ctx,
-			`INSERT INTO predictions (event_id, user_id, option, timestamp)
-			 VALUES (?, ?, ?, ?)`,
-			prediction.EventID, prediction.UserID, prediction.Option, prediction.Timestamp,
+			`INSERT INTO predictions (event_id, user_id, option, timestamp, participation_awarded)
+			 VALUES (?, ?, ?, ?, ?)`,
+			prediction.EventID, prediction.UserID, prediction.Option, prediction.Timestamp, prediction.ParticipationAwarded,
 		)
 		if err != nil {
 			return err
@@ -40,7 +42,7 @@ func (r *PredictionRepository) SavePrediction(ctx context.Context, prediction *d
 
 // UpdatePrediction updates an existing prediction
 func (r *PredictionRepository) UpdatePrediction(ctx context.Context, prediction *domain.Prediction) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx,
 			`UPDATE predictions SET option = ?, timestamp = ? WHERE event_id = ? AND user_id = ?`,
 			prediction.Option, prediction.Timestamp, prediction.EventID, prediction.UserID,
@@ -49,13 +51,24 @@ func (r *PredictionRepository) UpdatePrediction(ctx context.Context, prediction
 	})
 }
 
+// SetParticipationAwarded records whether the participation point of a vote was credited at vote time
+func (r *PredictionRepository) SetParticipationAwarded(ctx context.Context, eventID, userID int64, awarded bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`UPDATE predictions SET participation_awarded = ? WHERE event_id = ? AND user_id = ?`,
+			awarded, eventID, userID,
+		)
+		return err
+	})
+}
+
 // GetPredictionsByEvent retrieves all predictions for a specific event
 func (r *PredictionRepository) GetPredictionsByEvent(ctx context.Context, eventID int64) ([]*domain.Prediction, error) {
 	var predictions []*domain.Prediction
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
-			`SELECT id, event_id, user_id, option, timestamp
+			`SELECT id, event_id, user_id, option, timestamp, participation_awarded
 			 FROM predictions WHERE event_id = ? ORDER BY timestamp ASC`,
 			eventID,
 		)
@@ -68,7 +81,7 @@ func (r *PredictionRepository) GetPredictionsByEvent(ctx context.Context, eventI
 			var prediction domain.Prediction
 			if err := rows.Scan(
 				&prediction.ID, &prediction.EventID, &prediction.UserID,
-				&prediction.Option, &prediction.Timestamp,
+				&prediction.Option, &prediction.Timestamp, &prediction.ParticipationAwarded,
 			); err != nil {
 				return err
 			}
@@ -89,14 +102,14 @@ func (r *PredictionRepository) GetPredictionsByEvent(ctx context.Context, eventI
 func (r *PredictionRepository) GetPredictionByUserAndEvent(ctx context.Context, userID, eventID int64) (*domain.Prediction, error) {
 	var prediction domain.Prediction
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
-			`SELECT id, event_id, user_id, option, timestamp
+			`SELECT id, event_id, user_id, option, timestamp, participation_awarded
 			 FROM predictions WHERE user_id = ? AND event_id = ?`,
 			userID, eventID,
 		).Scan(
 			&prediction.ID, &prediction.EventID, &prediction.UserID,
-			&prediction.Option, &prediction.Timestamp,
+			&prediction.Option, &prediction.Timestamp, &prediction.ParticipationAwarded,
 		)
 	})
 
@@ -114,9 +127,9 @@ func (r *PredictionRepository) GetPredictionByUserAndEvent(ctx context.Context,
 func (r *PredictionRepository) GetUserPredictions(ctx context.Context, userID int64) ([]*domain.Prediction, error) {
 	var predictions []*domain.Prediction
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
-			`SELECT id, event_id, user_id, option, timestamp
+			`SELECT id, event_id, user_id, option, timestamp, participation_awarded
 			 FROM predictions WHERE user_id = ? ORDER BY timestamp ASC`,
 			userID,
 		)
@@ -129,7 +142,7 @@ func (r *PredictionRepository) GetUserPredictions(ctx context.Context, userID in
 			var prediction domain.Prediction
 			if err := rows.Scan(
 				&prediction.ID, &prediction.EventID, &prediction.UserID,
-				&prediction.Option, &prediction.Timestamp,
+				&prediction.Option, &prediction.Timestamp, &prediction.ParticipationAwarded,
 			); err != nil {
 				return err
 			}
@@ -150,13 +163,74 @@ func (r *PredictionRepository) GetUserPredictions(ctx context.Context, userID in
 func (r *PredictionRepository) GetUserCompletedEventCount(ctx context.Context, userID int64, groupID int64) (int, error) {
 	var count int
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT COUNT(DISTINCT p.event_id)
+			 FROM predictions p
+			 JOIN events e ON p.event_id = e.id
+			 WHERE p.user_id = ? AND e.status IN (?, ?) AND e.group_id = ?`,
+			userID, domain.EventStatusResolved, domain.EventStatusArchived, groupID,
+		).Scan(&count)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetUserVotedEventCount counts distinct events user voted in for a specific group, resolved or not.
+// Votes on cancelled events do not count.
+func (r *PredictionRepository) GetUserVotedEventCount(ctx context.Context, userID int64, groupID int64) (int, error) {
+	var count int
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
 			`SELECT COUNT(DISTINCT p.event_id)
 			 FROM predictions p
 			 JOIN events e ON p.event_id = e.id
-			 WHERE p.user_id = ? AND e.status = ? AND e.group_id = ?`,
-			userID, domain.EventStatusResolved, groupID,
+			 WHERE p.user_id = ? AND e.status != ? AND e.group_id = ?`,
+			userID, domain.EventStatusCancelled, groupID,
+		).Scan(&count)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetUserActiveGroupCount counts the distinct groups in which the user has predictions
+func (r *PredictionRepository) GetUserActiveGroupCount(ctx context.Context, userID int64) (int, error) {
+	var count int
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT COUNT(DISTINCT e.group_id)
+			 FROM predictions p
+			 JOIN events e ON p.event_id = e.id
+			 WHERE p.user_id = ?`,
+			userID,
+		).Scan(&count)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountVotesSince counts predictions on an event cast or changed after the given time
+func (r *PredictionRepository) CountVotesSince(ctx context.Context, eventID int64, since time.Time) (int, error) {
+	var count int
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM predictions WHERE event_id = ? AND timestamp > ?`,
+			eventID, since,
 		).Scan(&count)
 	})
 
@@ -171,9 +245,9 @@ func (r *PredictionRepository) GetUserCompletedEventCount(ctx context.Context, u
 func (r *PredictionRepository) GetUserPredictionsByGroup(ctx context.Context, userID int64, groupID int64) ([]*domain.Prediction, error) {
 	var predictions []*domain.Prediction
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
-			`SELECT p.id, p.event_id, p.user_id, p.option, p.timestamp
+			`SELECT p.id, p.event_id, p.user_id, p.option, p.timestamp, p.participation_awarded
 			 FROM predictions p
 			 JOIN events e ON p.event_id = e.id
 			 WHERE p.user_id = ? AND e.group_id = ?
@@ -189,7 +263,7 @@ func (r *PredictionRepository) GetUserPredictionsByGroup(ctx context.Context, us
 			var prediction domain.Prediction
 			if err := rows.Scan(
 				&prediction.ID, &prediction.EventID, &prediction.UserID,
-				&prediction.Option, &prediction.Timestamp,
+				&prediction.Option, &prediction.Timestamp, &prediction.ParticipationAwarded,
 			); err != nil {
 				return err
 			}
@@ -210,9 +284,9 @@ func (r *PredictionRepository) GetUserPredictionsByGroup(ctx context.Context, us
 func (r *PredictionRepository) GetPredictionsByEventInGroup(ctx context.Context, eventID int64, groupID int64) ([]*domain.Prediction, error) {
 	var predictions []*domain.Prediction
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
-			`SELECT p.id, p.event_id, p.user_id, p.option, p.timestamp
+			`SELECT p.id, p.event_id, p.user_id, p.option, p.timestamp, p.participation_awarded
 			 FROM predictions p
 			 JOIN events e ON p.event_id = e.id
 			 WHERE p.event_id = ? AND e.group_id = ?
@@ -228,7 +302,7 @@ func (r *PredictionRepository) GetPredictionsByEventInGroup(ctx context.Context,
 			var prediction domain.Prediction
 			if err := rows.Scan(
 				&prediction.ID, &prediction.EventID, &prediction.UserID,
-				&prediction.Option, &prediction.Timestamp,
+				&prediction.Option, &prediction.Timestamp, &prediction.ParticipationAwarded,
 			); err != nil {
 				return err
 			}
@@ -244,3 +318,213 @@ func (r *PredictionRepository) GetPredictionsByEventInGroup(ctx context.Context,
 
 	return predictions, nil
 }
+
+// importEvent holds the event fields needed to import predictions
+type importEvent struct {
+	id       int64
+	options  []string
+	deadline time.Time
+}
+
+// ImportPredictions imports predictions into events of a group in a single transaction.
+// Events are matched by ID or by question and deadline (to the minute); users must be active
+// members of the group and participants of restricted events. Rows with unknown references, invalid options or an existing prediction
+// are skipped and reported. In dry-run mode the transaction is rolled back, so the report shows
+// what would be imported without writing anything.
+func (r *PredictionRepository) ImportPredictions(ctx context.Context, groupID int64, rows []*domain.PredictionImportRow, dryRun bool) (*domain.PredictionImportReport, error) {
+	report := &domain.PredictionImportReport{DryRun: dryRun, Total: len(rows)}
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		eventsByID := make(map[int64]*importEvent)
+		members := make(map[int64]bool)
+
+		for _, row := range rows {
+			event, reason, err := findImportEvent(ctx, tx, groupID, row, eventsByID)
+			if err != nil {
+				return err
+			}
+			if event == nil {
+				report.Skip(row.Line, reason)
+				continue
+			}
+
+			isMember, ok := members[row.UserID]
+			if !ok {
+				var count int
+				if err := tx.QueryRowContext(ctx,
+					`SELECT COUNT(*) FROM group_memberships WHERE group_id = ? AND user_id = ? AND status = ?`,
+					groupID, row.UserID, domain.MembershipStatusActive,
+				).Scan(&count); err != nil {
+					return err
+				}
+				isMember = count > 0
+				members[row.UserID] = isMember
+			}
+			if !isMember {
+				report.Skip(row.Line, domain.ImportSkipUnknownUser)
+				continue
+			}
+
+			// Events restricted to specific members only accept their participants
+			var isParticipant bool
+			if err := tx.QueryRowContext(ctx,
+				`SELECT NOT EXISTS (SELECT 1 FROM event_participants WHERE event_id = ?)
+				     OR EXISTS (SELECT 1 FROM event_participants WHERE event_id = ? AND user_id = ?)`,
+				event.id, event.id, row.UserID,
+			).Scan(&isParticipant); err != nil {
+				return err
+			}
+			if !isParticipant {
+				report.Skip(row.Line, domain.ImportSkipNotParticipant)
+				continue
+			}
+
+			option, ok := row.ResolveOption(event.options)
+			if !ok {
+				report.Skip(row.Line, domain.ImportSkipInvalidOption)
+				continue
+			}
+
+			timestamp := row.Timestamp
+			if timestamp.IsZero() {
+				timestamp = event.deadline
+			}
+
+			result, err := tx.ExecContext(ctx,
+				`INSERT INTO predictions (event_id, user_id, option, timestamp)
+				 VALUES (?, ?, ?, ?)
+				 ON CONFLICT(event_id, user_id) DO NOTHING`,
+				event.id, row.UserID, option, timestamp,
+			)
+			if err != nil {
+				return err
+			}
+			inserted, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if inserted == 0 {
+				report.Skip(row.Line, domain.ImportSkipDuplicate)
+				continue
+			}
+			report.Imported++
+		}
+
+		if dryRun {
+			return nil
+		}
+		return tx.Commit()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// findImportEvent resolves the event referenced by an import row within the group.
+// Returns a nil event and the skip reason when the reference is unknown or ambiguous.
+func findImportEvent(ctx context.Context, tx *sql.Tx, groupID int64, row *domain.PredictionImportRow, cache map[int64]*importEvent) (*importEvent, domain.PredictionImportSkipReason, error) {
+	scan := func(rows *sql.Rows) (*importEvent, error) {
+		var event importEvent
+		var optionsJSON string
+		if err := rows.Scan(&event.id, &optionsJSON, &event.deadline); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(optionsJSON), &event.options); err != nil {
+			return nil, err
+		}
+		return &event, nil
+	}
+
+	var query string
+	var args []interface{}
+	if row.EventID != 0 {
+		if event, ok := cache[row.EventID]; ok {
+			return event, "", nil
+		}
+		query = `SELECT id, options_json, deadline FROM events WHERE id = ? AND group_id = ?`
+		args = []interface{}{row.EventID, groupID}
+	} else {
+		query = `SELECT id, options_json, deadline FROM events WHERE group_id = ? AND question = ?`
+		args = []interface{}{groupID, row.Question}
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var matches []*importEvent
+	for rows.Next() {
+		event, err := scan(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		if row.EventID == 0 && !event.deadline.Truncate(time.Minute).Equal(row.Deadline.Truncate(time.Minute)) {
+			continue
+		}
+		matches = append(matches, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, domain.ImportSkipUnknownEvent, nil
+	case 1:
+		cache[matches[0].id] = matches[0]
+		return matches[0], "", nil
+	default:
+		return nil, domain.ImportSkipAmbiguousEvent, nil
+	}
+}
+
+// GetResolvedOutcomesSince retrieves the events a user voted on that were resolved after since, oldest first
+func (r *PredictionRepository) GetResolvedOutcomesSince(ctx context.Context, userID int64, since time.Time) ([]*domain.ResolvedOutcome, error) {
+	var outcomes []*domain.ResolvedOutcome
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT e.id, e.question, e.options_json, e.correct_option, p.option, e.resolved_at
+			 FROM predictions p
+			 JOIN events e ON p.event_id = e.id
+			 WHERE p.user_id = ? AND e.correct_option IS NOT NULL AND e.resolved_at > ?
+			 ORDER BY e.resolved_at ASC, e.id ASC`,
+			userID, since,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			outcome := &domain.ResolvedOutcome{}
+			var optionsJSON string
+			if err := rows.Scan(&outcome.EventID, &outcome.Question, &optionsJSON, &outcome.CorrectOption, &outcome.UserOption, &outcome.ResolvedAt); err != nil {
+				return err
+			}
+			if err := json.Unmarshal([]byte(optionsJSON), &outcome.Options); err != nil {
+				return err
+			}
+			outcomes = append(outcomes, outcome)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
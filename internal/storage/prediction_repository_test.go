@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"strconv"
 	"testing"
 	"time"
 
@@ -451,3 +452,353 @@ func TestGetUserCompletedEventCount(t *testing.T) {
 		}
 	})
 }
+
+func TestImportPredictions(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	predictionRepo := NewPredictionRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	membershipRepo := NewGroupMembershipRepository(queue)
+	groupID := int64(1)
+	deadline := time.Date(2024, 3, 2, 18, 0, 0, 0, time.UTC)
+
+	newEvent := func(groupID int64, question string) *domain.Event {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  question,
+			Options:   []string{"Yes", "No"},
+			CreatedAt: deadline.Add(-48 * time.Hour),
+			Deadline:  deadline,
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 1,
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		return event
+	}
+
+	byID := newEvent(groupID, "First?")
+	byQuestion := newEvent(groupID, "Will it rain?")
+	otherGroup := newEvent(2, "Other group?")
+	newEvent(groupID, "Twice?")
+	newEvent(groupID, "Twice?")
+
+	for _, userID := range []int64{100, 101} {
+		if err := membershipRepo.CreateMembership(ctx, &domain.GroupMembership{GroupID: groupID, UserID: userID, JoinedAt: deadline, Status: domain.MembershipStatusActive}); err != nil {
+			t.Fatalf("Failed to create membership: %v", err)
+		}
+	}
+	if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: byID.ID, UserID: 101, Option: 0, Timestamp: deadline}); err != nil {
+		t.Fatalf("Failed to save prediction: %v", err)
+	}
+
+	rows := []*domain.PredictionImportRow{
+		{Line: 2, UserID: 100, EventID: byID.ID, Option: "No"},
+		{Line: 3, UserID: 100, Question: "Will it rain?", Deadline: deadline.Add(20 * time.Second), Option: "0"},
+		{Line: 4, UserID: 101, EventID: byID.ID, Option: "Yes"},
+		{Line: 5, UserID: 999, EventID: byID.ID, Option: "Yes"},
+		{Line: 6, UserID: 100, EventID: otherGroup.ID, Option: "Yes"},
+		{Line: 7, UserID: 100, Question: "Will it rain?", Deadline: deadline.Add(time.Hour), Option: "Yes"},
+		{Line: 8, UserID: 100, Question: "Twice?", Deadline: deadline, Option: "Yes"},
+		{Line: 9, UserID: 101, Question: "Will it rain?", Deadline: deadline, Option: "Maybe"},
+		{Line: 10, UserID: 100, EventID: byID.ID, Option: "Yes"},
+	}
+
+	expectedSkips := map[int]domain.PredictionImportSkipReason{
+		4:  domain.ImportSkipDuplicate,
+		5:  domain.ImportSkipUnknownUser,
+		6:  domain.ImportSkipUnknownEvent,
+		7:  domain.ImportSkipUnknownEvent,
+		8:  domain.ImportSkipAmbiguousEvent,
+		9:  domain.ImportSkipInvalidOption,
+		10: domain.ImportSkipDuplicate,
+	}
+
+	checkReport := func(report *domain.PredictionImportReport) {
+		t.Helper()
+		if report.Total != len(rows) || report.Imported != 2 {
+			t.Errorf("Expected 2 of %d rows imported, got %+v", len(rows), report)
+		}
+		if len(report.Skipped) != len(expectedSkips) {
+			t.Fatalf("Expected %d skipped rows, got %+v", len(expectedSkips), report.Skipped)
+		}
+		for _, skip := range report.Skipped {
+			if expectedSkips[skip.Line] != skip.Reason {
+				t.Errorf("Line %d: expected %s, got %s", skip.Line, expectedSkips[skip.Line], skip.Reason)
+			}
+		}
+	}
+
+	// Dry run reports the same result without writing
+	report, err := predictionRepo.ImportPredictions(ctx, groupID, rows, true)
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if !report.DryRun {
+		t.Error("Expected report to be marked as dry run")
+	}
+	checkReport(report)
+
+	predictions, err := predictionRepo.GetPredictionsByEvent(ctx, byQuestion.ID)
+	if err != nil {
+		t.Fatalf("Failed to get predictions: %v", err)
+	}
+	if len(predictions) != 0 {
+		t.Fatalf("Expected dry run to write nothing, got %d predictions", len(predictions))
+	}
+
+	report, err = predictionRepo.ImportPredictions(ctx, groupID, rows, false)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	checkReport(report)
+
+	imported, err := predictionRepo.GetPredictionByUserAndEvent(ctx, 100, byID.ID)
+	if err != nil || imported == nil || imported.Option != 1 {
+		t.Fatalf("Expected imported prediction for option No, got %+v (err %v)", imported, err)
+	}
+	imported, err = predictionRepo.GetPredictionByUserAndEvent(ctx, 100, byQuestion.ID)
+	if err != nil || imported == nil || imported.Option != 0 {
+		t.Fatalf("Expected prediction matched by question and deadline, got %+v (err %v)", imported, err)
+	}
+	if !imported.Timestamp.Equal(deadline) {
+		t.Errorf("Expected missing timestamp to default to the deadline, got %v", imported.Timestamp)
+	}
+}
+
+func TestCountVotesSince(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	predictionRepo := NewPredictionRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Second)
+	event := &domain.Event{
+		GroupID:   1,
+		Question:  "Hot question",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: now.Add(-72 * time.Hour),
+		Deadline:  now.Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: 1,
+		PollID:    "poll_hot",
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	// Two old votes and three recent ones
+	ages := []time.Duration{48 * time.Hour, 30 * time.Hour, 5 * time.Hour, 2 * time.Hour, time.Minute}
+	for i, age := range ages {
+		if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{
+			EventID:   event.ID,
+			UserID:    int64(100 + i),
+			Option:    i % 2,
+			Timestamp: now.Add(-age),
+		}); err != nil {
+			t.Fatalf("Failed to save prediction: %v", err)
+		}
+	}
+
+	recent, err := predictionRepo.CountVotesSince(ctx, event.ID, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("CountVotesSince failed: %v", err)
+	}
+	if recent != 3 {
+		t.Errorf("Expected 3 votes in the last 24 hours, got %d", recent)
+	}
+
+	total, err := predictionRepo.CountVotesSince(ctx, event.ID, time.Time{})
+	if err != nil {
+		t.Fatalf("CountVotesSince failed: %v", err)
+	}
+	if total != len(ages) {
+		t.Errorf("Expected %d votes in total, got %d", len(ages), total)
+	}
+
+	other, err := predictionRepo.CountVotesSince(ctx, event.ID+1, time.Time{})
+	if err != nil {
+		t.Fatalf("CountVotesSince failed: %v", err)
+	}
+	if other != 0 {
+		t.Errorf("Expected no votes for another event, got %d", other)
+	}
+}
+
+func TestGetUserActiveGroupCount(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	predictionRepo := NewPredictionRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Second)
+	// Two events in group 1 and one each in groups 2 and 3
+	var eventIDs []int64
+	for i, groupID := range []int64{1, 1, 2, 3} {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  "Question",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now,
+			Deadline:  now.Add(24 * time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 1,
+			PollID:    "poll_" + strconv.Itoa(i),
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	// User 100 votes in groups 1 and 2, user 200 only in group 3
+	votes := []struct {
+		userID  int64
+		eventID int64
+	}{
+		{100, eventIDs[0]},
+		{100, eventIDs[1]},
+		{100, eventIDs[2]},
+		{200, eventIDs[3]},
+	}
+	for _, vote := range votes {
+		if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: vote.eventID, UserID: vote.userID, Timestamp: now}); err != nil {
+			t.Fatalf("Failed to save prediction: %v", err)
+		}
+	}
+
+	for userID, expected := range map[int64]int{100: 2, 200: 1, 300: 0} {
+		count, err := predictionRepo.GetUserActiveGroupCount(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetUserActiveGroupCount failed: %v", err)
+		}
+		if count != expected {
+			t.Errorf("User %d: expected %d active groups, got %d", userID, expected, count)
+		}
+	}
+}
+
+func TestPredictionParticipationAwarded(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	predictionRepo := NewPredictionRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Second)
+	event := &domain.Event{
+		GroupID:   1,
+		Question:  "Question",
+		Options:   []string{"Yes", "No"},
+		CreatedAt: now,
+		Deadline:  now.Add(24 * time.Hour),
+		Status:    domain.EventStatusActive,
+		EventType: domain.EventTypeBinary,
+		CreatedBy: 1,
+		PollID:    "poll_awarded",
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	for userID, awarded := range map[int64]bool{100: true, 200: false} {
+		if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: userID, Option: 0, Timestamp: now, ParticipationAwarded: awarded}); err != nil {
+			t.Fatalf("Failed to save prediction: %v", err)
+		}
+	}
+
+	// Changing the vote keeps the flag
+	if err := predictionRepo.UpdatePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: 100, Option: 1, Timestamp: now}); err != nil {
+		t.Fatalf("Failed to update prediction: %v", err)
+	}
+
+	predictions, err := predictionRepo.GetPredictionsByEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("GetPredictionsByEvent failed: %v", err)
+	}
+	if len(predictions) != 2 {
+		t.Fatalf("Expected 2 predictions, got %d", len(predictions))
+	}
+	for _, prediction := range predictions {
+		if want := prediction.UserID == 100; prediction.ParticipationAwarded != want {
+			t.Errorf("User %d: expected ParticipationAwarded %v, got %v", prediction.UserID, want, prediction.ParticipationAwarded)
+		}
+	}
+	// Marking a saved vote as awarded
+	if err := predictionRepo.SetParticipationAwarded(ctx, event.ID, 200, true); err != nil {
+		t.Fatalf("SetParticipationAwarded failed: %v", err)
+	}
+	prediction, err := predictionRepo.GetPredictionByUserAndEvent(ctx, 200, event.ID)
+	if err != nil {
+		t.Fatalf("GetPredictionByUserAndEvent failed: %v", err)
+	}
+	if !prediction.ParticipationAwarded {
+		t.Error("User 200: expected ParticipationAwarded true after marking it")
+	}
+}
@@ -21,14 +21,14 @@ func NewRatingRepository(queue *DBQueue) *RatingRepository {
 func (r *RatingRepository) GetRating(ctx context.Context, userID int64, groupID int64) (*domain.Rating, error) {
 	var rating domain.Rating
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
-			`SELECT user_id, group_id, username, score, correct_count, wrong_count, streak
+			`SELECT user_id, group_id, username, score, correct_count, wrong_count, streak, best_streak
 			 FROM ratings WHERE user_id = ? AND group_id = ?`,
 			userID, groupID,
 		).Scan(
 			&rating.UserID, &rating.GroupID, &rating.Username, &rating.Score, &rating.CorrectCount,
-			&rating.WrongCount, &rating.Streak,
+			&rating.WrongCount, &rating.Streak, &rating.BestStreak,
 		)
 	})
 
@@ -42,6 +42,7 @@ func (r *RatingRepository) GetRating(ctx context.Context, userID int64, groupID
 			CorrectCount: 0,
 			WrongCount:   0,
 			Streak:       0,
+			BestStreak:   0,
 		}, nil
 	}
 	if err != nil {
@@ -53,30 +54,55 @@ func (r *RatingRepository) GetRating(ctx context.Context, userID int64, groupID
 
 // UpdateRating updates or inserts a user's rating for a specific group
 func (r *RatingRepository) UpdateRating(ctx context.Context, rating *domain.Rating) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx,
-			`INSERT INTO ratings (user_id, group_id, username, score, correct_count, wrong_count, streak)
-			 VALUES (?, ?, ?, ?, ?, ?, ?)
+			`INSERT INTO ratings (user_id, group_id, username, score, correct_count, wrong_count, streak, best_streak)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, MAX(?, ?))
 			 ON CONFLICT(user_id, group_id) DO UPDATE SET
 			   username = excluded.username,
 			   score = excluded.score,
 			   correct_count = excluded.correct_count,
 			   wrong_count = excluded.wrong_count,
-			   streak = excluded.streak`,
+			   streak = excluded.streak,
+			   best_streak = MAX(ratings.best_streak, excluded.best_streak)`,
 			rating.UserID, rating.GroupID, rating.Username, rating.Score, rating.CorrectCount,
-			rating.WrongCount, rating.Streak,
+			rating.WrongCount, rating.Streak, rating.BestStreak, rating.Streak,
 		)
 		return err
 	})
 }
 
+// UpdateUsername sets the username of an existing rating and reports whether it changed.
+// No rating is created for users without one.
+func (r *RatingRepository) UpdateUsername(ctx context.Context, userID int64, groupID int64, username string) (bool, error) {
+	var updated bool
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`UPDATE ratings SET username = ? WHERE user_id = ? AND group_id = ? AND username != ?`,
+			username, userID, groupID, username,
+		)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		updated = affected > 0
+		return nil
+	})
+
+	return updated, err
+}
+
 // GetTopRatings retrieves the top N users by score for a specific group
 func (r *RatingRepository) GetTopRatings(ctx context.Context, groupID int64, limit int) ([]*domain.Rating, error) {
 	var ratings []*domain.Rating
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		rows, err := db.QueryContext(ctx,
-			`SELECT user_id, group_id, username, score, correct_count, wrong_count, streak
+			`SELECT user_id, group_id, username, score, correct_count, wrong_count, streak, best_streak
 			 FROM ratings WHERE group_id = ? ORDER BY score DESC LIMIT ?`,
 			groupID, limit,
 		)
@@ -89,7 +115,7 @@ func (r *RatingRepository) GetTopRatings(ctx context.Context, groupID int64, lim
 			var rating domain.Rating
 			if err := rows.Scan(
 				&rating.UserID, &rating.GroupID, &rating.Username, &rating.Score, &rating.CorrectCount,
-				&rating.WrongCount, &rating.Streak,
+				&rating.WrongCount, &rating.Streak, &rating.BestStreak,
 			); err != nil {
 				return err
 			}
@@ -106,13 +132,115 @@ func (r *RatingRepository) GetTopRatings(ctx context.Context, groupID int64, lim
 	return ratings, nil
 }
 
-// UpdateStreak updates a user's streak for a specific group
+// GetUserRank returns the user's 1-based rank by score in a group and the number of rated users.
+// Equal scores share a rank (the rank is one more than the number of higher scores), so it matches
+// the order of GetTopRatings. The rank is 0 when the user has no rating in the group.
+func (r *RatingRepository) GetUserRank(ctx context.Context, userID int64, groupID int64) (int, int, error) {
+	var total, higher, rated int
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT COUNT(*), COALESCE(SUM(r.score > u.score), 0), COUNT(u.user_id)
+			 FROM ratings r LEFT JOIN ratings u ON u.user_id = ? AND u.group_id = r.group_id
+			 WHERE r.group_id = ?`,
+			userID, groupID,
+		).Scan(&total, &higher, &rated)
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if rated == 0 {
+		return 0, total, nil
+	}
+	return higher + 1, total, nil
+}
+
+// GetTopStreaks retrieves the top N users by best streak for a specific group
+func (r *RatingRepository) GetTopStreaks(ctx context.Context, groupID int64, limit int) ([]*domain.Rating, error) {
+	var ratings []*domain.Rating
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT user_id, group_id, username, score, correct_count, wrong_count, streak, best_streak
+			 FROM ratings WHERE group_id = ? AND best_streak > 0
+			 ORDER BY best_streak DESC, streak DESC, score DESC LIMIT ?`,
+			groupID, limit,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			var rating domain.Rating
+			if err := rows.Scan(
+				&rating.UserID, &rating.GroupID, &rating.Username, &rating.Score, &rating.CorrectCount,
+				&rating.WrongCount, &rating.Streak, &rating.BestStreak,
+			); err != nil {
+				return err
+			}
+			ratings = append(ratings, &rating)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ratings, nil
+}
+
+// UpdateStreak updates a user's streak for a specific group.
+// The best streak is raised when the new streak exceeds it and is never decreased.
 func (r *RatingRepository) UpdateStreak(ctx context.Context, userID int64, groupID int64, streak int) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx,
-			`UPDATE ratings SET streak = ? WHERE user_id = ? AND group_id = ?`,
-			streak, userID, groupID,
+			`UPDATE ratings SET streak = ?, best_streak = MAX(best_streak, ?) WHERE user_id = ? AND group_id = ?`,
+			streak, streak, userID, groupID,
 		)
 		return err
 	})
 }
+
+// ReplaceGroupRatings replaces all ratings of a group with the given ones in a single transaction.
+// Members without a new rating are reset to zero; usernames of existing rows are kept.
+// Best streaks are never lowered, as the ratings replayed for a season don't see the streaks of archived ones.
+func (r *RatingRepository) ReplaceGroupRatings(ctx context.Context, groupID int64, ratings []*domain.Rating) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE ratings SET score = 0, correct_count = 0, wrong_count = 0, streak = 0
+			 WHERE group_id = ?`,
+			groupID,
+		); err != nil {
+			return err
+		}
+
+		for _, rating := range ratings {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO ratings (user_id, group_id, username, score, correct_count, wrong_count, streak, best_streak)
+				 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				 ON CONFLICT(user_id, group_id) DO UPDATE SET
+				   score = excluded.score,
+				   correct_count = excluded.correct_count,
+				   wrong_count = excluded.wrong_count,
+				   streak = excluded.streak,
+				   best_streak = MAX(ratings.best_streak, excluded.best_streak)`,
+				rating.UserID, groupID, rating.Username, rating.Score, rating.CorrectCount,
+				rating.WrongCount, rating.Streak, rating.BestStreak,
+			); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit()
+	})
+}
@@ -108,3 +108,269 @@ func TestRatingDataRoundTrip(t *testing.T) {
 
 	properties.TestingRun(t)
 }
+
+func TestBestStreakTracking(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	repo := NewRatingRepository(queue)
+
+	save := func(userID, groupID int64, streak, best int) {
+		t.Helper()
+		rating := &domain.Rating{UserID: userID, GroupID: groupID, Streak: streak, BestStreak: best}
+		if err := repo.UpdateRating(ctx, rating); err != nil {
+			t.Fatalf("Failed to update rating: %v", err)
+		}
+	}
+	bestStreak := func(userID, groupID int64) int {
+		t.Helper()
+		rating, err := repo.GetRating(ctx, userID, groupID)
+		if err != nil {
+			t.Fatalf("Failed to get rating: %v", err)
+		}
+		return rating.BestStreak
+	}
+
+	save(1, 1, 5, 5)
+	save(1, 2, 2, 2)
+
+	// A broken streak never lowers the best streak
+	save(1, 1, 0, 0)
+	if got := bestStreak(1, 1); got != 5 {
+		t.Errorf("expected best streak 5 after reset, got %d", got)
+	}
+	if err := repo.UpdateStreak(ctx, 1, 1, 3); err != nil {
+		t.Fatalf("Failed to update streak: %v", err)
+	}
+	if got := bestStreak(1, 1); got != 5 {
+		t.Errorf("expected best streak 5 after shorter streak, got %d", got)
+	}
+	if err := repo.UpdateStreak(ctx, 1, 1, 7); err != nil {
+		t.Fatalf("Failed to update streak: %v", err)
+	}
+	if got := bestStreak(1, 1); got != 7 {
+		t.Errorf("expected best streak 7 after longer streak, got %d", got)
+	}
+
+	// Best streaks are kept per group
+	if got := bestStreak(1, 2); got != 2 {
+		t.Errorf("expected best streak 2 in the second group, got %d", got)
+	}
+
+	save(2, 1, 4, 9)
+	save(3, 1, 0, 0)
+
+	top, err := repo.GetTopStreaks(ctx, 1, 10)
+	if err != nil {
+		t.Fatalf("Failed to get top streaks: %v", err)
+	}
+	if len(top) != 2 {
+		t.Fatalf("expected 2 members with streaks, got %d", len(top))
+	}
+	if top[0].UserID != 2 || top[0].BestStreak != 9 || top[1].UserID != 1 || top[1].BestStreak != 7 {
+		t.Errorf("unexpected streak order: %d/%d, %d/%d", top[0].UserID, top[0].BestStreak, top[1].UserID, top[1].BestStreak)
+	}
+}
+
+func TestReplaceGroupRatings(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	repo := NewRatingRepository(queue)
+
+	for _, rating := range []*domain.Rating{
+		{UserID: 1, GroupID: 1, Username: "alice", Score: 100, CorrectCount: 9, Streak: 5, BestStreak: 8},
+		{UserID: 2, GroupID: 1, Username: "bob", Score: 40, WrongCount: 3},
+		{UserID: 1, GroupID: 2, Username: "alice", Score: 70, CorrectCount: 4},
+	} {
+		if err := repo.UpdateRating(ctx, rating); err != nil {
+			t.Fatalf("Failed to update rating: %v", err)
+		}
+	}
+
+	err = repo.ReplaceGroupRatings(ctx, 1, []*domain.Rating{
+		{UserID: 1, GroupID: 1, Score: 12, CorrectCount: 1, Streak: 1, BestStreak: 1},
+		{UserID: 3, GroupID: 1, Score: -2, WrongCount: 1},
+	})
+	if err != nil {
+		t.Fatalf("Failed to replace group ratings: %v", err)
+	}
+
+	get := func(userID, groupID int64) *domain.Rating {
+		t.Helper()
+		rating, err := repo.GetRating(ctx, userID, groupID)
+		if err != nil {
+			t.Fatalf("Failed to get rating: %v", err)
+		}
+		return rating
+	}
+
+	// Replaced values are stored as is except the best streak, which is never lowered; the username is kept
+	if rating := get(1, 1); rating.Score != 12 || rating.CorrectCount != 1 || rating.BestStreak != 8 || rating.Username != "alice" {
+		t.Errorf("unexpected replaced rating: %+v", rating)
+	}
+	// Members missing from the new ratings are reset
+	if rating := get(2, 1); rating.Score != 0 || rating.WrongCount != 0 || rating.Username != "bob" {
+		t.Errorf("expected rating without replacement to be reset, got %+v", rating)
+	}
+	if rating := get(3, 1); rating.Score != -2 || rating.WrongCount != 1 {
+		t.Errorf("expected new rating to be inserted, got %+v", rating)
+	}
+	// Other groups are untouched
+	if rating := get(1, 2); rating.Score != 70 || rating.CorrectCount != 4 {
+		t.Errorf("expected rating of another group to be untouched, got %+v", rating)
+	}
+}
+
+func TestGetUserRank(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewRatingRepository(queue)
+
+	// No ratings in the group yet
+	rank, total, err := repo.GetUserRank(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetUserRank failed: %v", err)
+	}
+	if rank != 0 || total != 0 {
+		t.Errorf("Expected rank 0 of 0 in an empty group, got %d of %d", rank, total)
+	}
+
+	// Users 2 and 3 share the second place; user 10 is rated in another group only
+	scores := []struct {
+		userID, groupID int64
+		score           int
+	}{
+		{1, 1, 50}, {2, 1, 30}, {3, 1, 30}, {4, 1, 10}, {5, 1, -5}, {10, 2, 100},
+	}
+	for _, s := range scores {
+		if err := repo.UpdateRating(ctx, &domain.Rating{UserID: s.userID, GroupID: s.groupID, Score: s.score}); err != nil {
+			t.Fatalf("UpdateRating failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		userID       int64
+		expectedRank int
+	}{
+		{1, 1}, {2, 2}, {3, 2}, {4, 4}, {5, 5}, {10, 0}, {99, 0},
+	}
+	for _, tt := range tests {
+		rank, total, err := repo.GetUserRank(ctx, tt.userID, 1)
+		if err != nil {
+			t.Fatalf("GetUserRank failed: %v", err)
+		}
+		if rank != tt.expectedRank || total != 5 {
+			t.Errorf("User %d: expected rank %d of 5, got %d of %d", tt.userID, tt.expectedRank, rank, total)
+		}
+	}
+}
+
+func TestUpdateUsername(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewRatingRepository(queue)
+
+	if err := repo.UpdateRating(ctx, &domain.Rating{UserID: 1, GroupID: 1, Username: "old", Score: 20, Streak: 2}); err != nil {
+		t.Fatalf("UpdateRating failed: %v", err)
+	}
+
+	updated, err := repo.UpdateUsername(ctx, 1, 1, "new")
+	if err != nil {
+		t.Fatalf("UpdateUsername failed: %v", err)
+	}
+	if !updated {
+		t.Error("Expected the username to be updated")
+	}
+
+	rating, err := repo.GetRating(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetRating failed: %v", err)
+	}
+	if rating.Username != "new" || rating.Score != 20 || rating.Streak != 2 {
+		t.Errorf("Expected only the username to change, got %+v", rating)
+	}
+
+	// Same name again is not an update
+	updated, err = repo.UpdateUsername(ctx, 1, 1, "new")
+	if err != nil {
+		t.Fatalf("UpdateUsername failed: %v", err)
+	}
+	if updated {
+		t.Error("Expected no update for an unchanged username")
+	}
+
+	// No rating is created for a user without one
+	updated, err = repo.UpdateUsername(ctx, 2, 1, "someone")
+	if err != nil {
+		t.Fatalf("UpdateUsername failed: %v", err)
+	}
+	if updated {
+		t.Error("Expected no update for a user without a rating")
+	}
+	_, total, err := repo.GetUserRank(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetUserRank failed: %v", err)
+	}
+	if total != 1 {
+		t.Errorf("Expected 1 rated user, got %d", total)
+	}
+}
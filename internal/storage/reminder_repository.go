@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
 )
 
 // ReminderRepository handles reminder log operations
@@ -20,7 +22,7 @@ func NewReminderRepository(queue *DBQueue) *ReminderRepository {
 func (r *ReminderRepository) WasReminderSent(ctx context.Context, eventID int64) (bool, error) {
 	var exists bool
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
 			`SELECT EXISTS(SELECT 1 FROM reminder_log WHERE event_id = ?)`,
 			eventID,
@@ -36,7 +38,7 @@ func (r *ReminderRepository) WasReminderSent(ctx context.Context, eventID int64)
 
 // MarkReminderSent marks a reminder as sent for an event
 func (r *ReminderRepository) MarkReminderSent(ctx context.Context, eventID int64) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx,
 			`INSERT INTO reminder_log (event_id, sent_at) VALUES (?, ?)
 			 ON CONFLICT(event_id) DO UPDATE SET sent_at = excluded.sent_at`,
@@ -46,11 +48,155 @@ func (r *ReminderRepository) MarkReminderSent(ctx context.Context, eventID int64
 	})
 }
 
+// reminderClaimTTL is how long a reminder claim is honored. A claim older than this is treated as
+// abandoned (e.g. the instance crashed before sending) and may be taken over by another instance.
+const reminderClaimTTL = 10 * time.Minute
+
+// ClaimDueReminders atomically claims up to limit active events with a deadline in [start, end] whose
+// reminder was neither sent nor claimed by another instance recently, and returns their IDs.
+// Events with custom reminder offsets are skipped, their reminders are claimed by ClaimScheduledReminders.
+// The claim is a single conditional write, so concurrent schedulers sharing the database never
+// receive the same event.
+func (r *ReminderRepository) ClaimDueReminders(ctx context.Context, instanceID string, start, end time.Time, limit int) ([]int64, error) {
+	var eventIDs []int64
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		now := time.Now()
+		rows, err := db.QueryContext(ctx,
+			`INSERT INTO reminder_claims (event_id, instance_id, claimed_at)
+			 SELECT e.id, @instance_id, @now FROM events e
+			 WHERE e.status = 'active'
+			   AND e.reminder_offsets = ''
+			   AND e.deadline BETWEEN @start AND @end
+			   AND NOT EXISTS (SELECT 1 FROM reminder_log rl WHERE rl.event_id = e.id)
+			   AND NOT EXISTS (SELECT 1 FROM reminder_claims rc WHERE rc.event_id = e.id AND rc.claimed_at > @stale_before)
+			 ORDER BY e.deadline ASC
+			 LIMIT @limit
+			 ON CONFLICT(event_id) DO UPDATE SET instance_id = excluded.instance_id, claimed_at = excluded.claimed_at
+			 RETURNING event_id`,
+			sql.Named("instance_id", instanceID),
+			sql.Named("now", now),
+			sql.Named("start", start),
+			sql.Named("end", end),
+			sql.Named("stale_before", now.Add(-reminderClaimTTL)),
+			sql.Named("limit", limit),
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			var eventID int64
+			if err := rows.Scan(&eventID); err != nil {
+				return err
+			}
+			eventIDs = append(eventIDs, eventID)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return eventIDs, nil
+}
+
+// ScheduleEventReminders replaces the not yet sent custom reminders of an event
+func (r *ReminderRepository) ScheduleEventReminders(ctx context.Context, eventID int64, reminders []domain.ScheduledReminder) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM event_reminders WHERE event_id = ? AND sent_at IS NULL`,
+			eventID,
+		); err != nil {
+			return err
+		}
+
+		for _, reminder := range reminders {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT OR IGNORE INTO event_reminders (event_id, offset_seconds, remind_at) VALUES (?, ?, ?)`,
+				eventID, int64(reminder.Offset/time.Second), reminder.RemindAt,
+			); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit()
+	})
+}
+
+// ClaimScheduledReminders atomically claims up to limit custom reminders that are due at now, belong
+// to an active event before its deadline and were neither sent nor claimed by another instance recently.
+func (r *ReminderRepository) ClaimScheduledReminders(ctx context.Context, instanceID string, now time.Time, limit int) ([]domain.ScheduledReminder, error) {
+	var reminders []domain.ScheduledReminder
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`UPDATE event_reminders SET claimed_by = @instance_id, claimed_at = @now
+			 WHERE rowid IN (
+			     SELECT er.rowid FROM event_reminders er
+			     JOIN events e ON e.id = er.event_id
+			     WHERE er.sent_at IS NULL
+			       AND er.remind_at <= @now
+			       AND e.status = 'active'
+			       AND e.deadline > @now
+			       AND (er.claimed_at IS NULL OR er.claimed_at <= @stale_before)
+			     ORDER BY er.remind_at ASC
+			     LIMIT @limit
+			 )
+			 RETURNING event_id, offset_seconds, remind_at`,
+			sql.Named("instance_id", instanceID),
+			sql.Named("now", now),
+			sql.Named("stale_before", now.Add(-reminderClaimTTL)),
+			sql.Named("limit", limit),
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			var reminder domain.ScheduledReminder
+			var offsetSeconds int64
+			if err := rows.Scan(&reminder.EventID, &offsetSeconds, &reminder.RemindAt); err != nil {
+				return err
+			}
+			reminder.Offset = time.Duration(offsetSeconds) * time.Second
+			reminders = append(reminders, reminder)
+		}
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// MarkScheduledReminderSent marks a custom reminder of an event as sent
+func (r *ReminderRepository) MarkScheduledReminderSent(ctx context.Context, eventID int64, offset time.Duration) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`UPDATE event_reminders SET sent_at = ? WHERE event_id = ? AND offset_seconds = ?`,
+			time.Now(), eventID, int64(offset/time.Second),
+		)
+		return err
+	})
+}
+
 // WasOrganizerNotificationSent checks if an organizer notification was already sent for an event
 func (r *ReminderRepository) WasOrganizerNotificationSent(ctx context.Context, eventID int64) (bool, error) {
 	var exists bool
 
-	err := r.queue.Execute(func(db *sql.DB) error {
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		return db.QueryRowContext(ctx,
 			`SELECT EXISTS(SELECT 1 FROM organizer_notifications WHERE event_id = ?)`,
 			eventID,
@@ -66,7 +212,7 @@ func (r *ReminderRepository) WasOrganizerNotificationSent(ctx context.Context, e
 
 // MarkOrganizerNotificationSent marks an organizer notification as sent for an event
 func (r *ReminderRepository) MarkOrganizerNotificationSent(ctx context.Context, eventID int64) error {
-	return r.queue.Execute(func(db *sql.DB) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
 		_, err := db.ExecContext(ctx,
 			`INSERT INTO organizer_notifications (event_id, sent_at) VALUES (?, ?)
 			 ON CONFLICT(event_id) DO UPDATE SET sent_at = excluded.sent_at`,
@@ -75,3 +221,71 @@ func (r *ReminderRepository) MarkOrganizerNotificationSent(ctx context.Context,
 		return err
 	})
 }
+
+// GetResolutionNagState returns how many resolution reminders were sent for an event and when the last one was sent.
+// Returns zero values if no reminder was sent yet.
+func (r *ReminderRepository) GetResolutionNagState(ctx context.Context, eventID int64) (int, time.Time, error) {
+	var count int
+	var lastSentAt time.Time
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		err := db.QueryRowContext(ctx,
+			`SELECT nag_count, last_sent_at FROM resolution_nags WHERE event_id = ?`,
+			eventID,
+		).Scan(&count, &lastSentAt)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	})
+
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return count, lastSentAt, nil
+}
+
+// MarkResolutionNagSent increments the resolution reminder counter for an event
+func (r *ReminderRepository) MarkResolutionNagSent(ctx context.Context, eventID int64) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO resolution_nags (event_id, nag_count, last_sent_at) VALUES (?, 1, ?)
+			 ON CONFLICT(event_id) DO UPDATE SET nag_count = resolution_nags.nag_count + 1, last_sent_at = excluded.last_sent_at`,
+			eventID, time.Now(),
+		)
+		return err
+	})
+}
+
+// WasResolutionNagEscalated checks if an unresolved event was already escalated to the admins
+func (r *ReminderRepository) WasResolutionNagEscalated(ctx context.Context, eventID int64) (bool, error) {
+	var escalated bool
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		err := db.QueryRowContext(ctx,
+			`SELECT escalated_at IS NOT NULL FROM resolution_nags WHERE event_id = ?`,
+			eventID,
+		).Scan(&escalated)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	})
+
+	return escalated, err
+}
+
+// MarkResolutionNagEscalated records that an unresolved event was escalated to the admins.
+// The reminder counter of the organizer is not changed.
+func (r *ReminderRepository) MarkResolutionNagEscalated(ctx context.Context, eventID int64) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		now := time.Now()
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO resolution_nags (event_id, nag_count, last_sent_at, escalated_at) VALUES (?, 0, ?, ?)
+			 ON CONFLICT(event_id) DO UPDATE SET escalated_at = excluded.escalated_at`,
+			eventID, now, now,
+		)
+		return err
+	})
+}
@@ -3,7 +3,12 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
 
 	_ "modernc.org/sqlite"
 )
@@ -62,3 +67,329 @@ func TestReminderRepository_OrganizerNotifications(t *testing.T) {
 		t.Error("Expected regular reminder not to be sent")
 	}
 }
+
+func TestReminderRepository_ResolutionNags(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+
+	repo := NewReminderRepository(queue)
+
+	ctx := context.Background()
+	eventID := int64(1)
+
+	// Initially, no resolution reminder should be sent
+	count, lastSentAt, err := repo.GetResolutionNagState(ctx, eventID)
+	if err != nil {
+		t.Fatalf("GetResolutionNagState failed: %v", err)
+	}
+	if count != 0 || !lastSentAt.IsZero() {
+		t.Errorf("Expected no resolution reminders initially, got %d at %v", count, lastSentAt)
+	}
+
+	// Each sent reminder increments the counter
+	for i := 0; i < 2; i++ {
+		if err := repo.MarkResolutionNagSent(ctx, eventID); err != nil {
+			t.Fatalf("MarkResolutionNagSent failed: %v", err)
+		}
+	}
+
+	count, lastSentAt, err = repo.GetResolutionNagState(ctx, eventID)
+	if err != nil {
+		t.Fatalf("GetResolutionNagState failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 resolution reminders, got %d", count)
+	}
+	if lastSentAt.IsZero() {
+		t.Error("Expected last sent time to be set")
+	}
+
+	// Escalation to the admins is recorded once and keeps the reminder counter
+	escalated, err := repo.WasResolutionNagEscalated(ctx, eventID)
+	if err != nil {
+		t.Fatalf("WasResolutionNagEscalated failed: %v", err)
+	}
+	if escalated {
+		t.Error("Expected event not to be escalated initially")
+	}
+	if err := repo.MarkResolutionNagEscalated(ctx, eventID); err != nil {
+		t.Fatalf("MarkResolutionNagEscalated failed: %v", err)
+	}
+	escalated, err = repo.WasResolutionNagEscalated(ctx, eventID)
+	if err != nil {
+		t.Fatalf("WasResolutionNagEscalated failed: %v", err)
+	}
+	if !escalated {
+		t.Error("Expected event to be escalated")
+	}
+	count, _, err = repo.GetResolutionNagState(ctx, eventID)
+	if err != nil {
+		t.Fatalf("GetResolutionNagState failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected escalation to keep 2 resolution reminders, got %d", count)
+	}
+
+	// An event escalated before any reminder still gets its first reminder
+	otherEventID := int64(2)
+	if err := repo.MarkResolutionNagEscalated(ctx, otherEventID); err != nil {
+		t.Fatalf("MarkResolutionNagEscalated failed: %v", err)
+	}
+	count, _, err = repo.GetResolutionNagState(ctx, otherEventID)
+	if err != nil {
+		t.Fatalf("GetResolutionNagState failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected no resolution reminders for an escalated event, got %d", count)
+	}
+
+	// Organizer notifications are tracked independently
+	sent, err := repo.WasOrganizerNotificationSent(ctx, eventID)
+	if err != nil {
+		t.Fatalf("WasOrganizerNotificationSent failed: %v", err)
+	}
+	if sent {
+		t.Error("Expected organizer notification not to be sent")
+	}
+}
+
+func TestReminderRepository_ClaimDueReminders(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewReminderRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	var eventIDs []int64
+	for i := 0; i < 20; i++ {
+		event := &domain.Event{
+			GroupID:   1,
+			Question:  fmt.Sprintf("Question %d?", i),
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now,
+			Deadline:  now.Add(24*time.Hour + time.Duration(i)*time.Minute),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 1,
+			PollID:    fmt.Sprintf("poll_%d", i),
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	// The reminder of the first event was already sent, the second one is claimed by a third instance
+	if err := repo.MarkReminderSent(ctx, eventIDs[0]); err != nil {
+		t.Fatalf("MarkReminderSent failed: %v", err)
+	}
+	claimed, err := repo.ClaimDueReminders(ctx, "c", now.Add(24*time.Hour), now.Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("ClaimDueReminders failed: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("Expected already sent reminder not to be claimed, got %v", claimed)
+	}
+	claimed, err = repo.ClaimDueReminders(ctx, "c", now.Add(24*time.Hour+time.Minute), now.Add(24*time.Hour+time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ClaimDueReminders failed: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0] != eventIDs[1] {
+		t.Fatalf("Expected event %d to be claimed, got %v", eventIDs[1], claimed)
+	}
+
+	// Two schedulers claim the same due set concurrently in small batches
+	start, end := now.Add(23*time.Hour), now.Add(25*time.Hour)
+	results := make(map[string][]int64)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, instanceID := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(instanceID string) {
+			defer wg.Done()
+			for {
+				batch, err := repo.ClaimDueReminders(ctx, instanceID, start, end, 3)
+				if err != nil {
+					t.Errorf("ClaimDueReminders(%s) failed: %v", instanceID, err)
+					return
+				}
+				mu.Lock()
+				results[instanceID] = append(results[instanceID], batch...)
+				mu.Unlock()
+				if len(batch) < 3 {
+					return
+				}
+			}
+		}(instanceID)
+	}
+	wg.Wait()
+
+	seen := make(map[int64]string)
+	for instanceID, ids := range results {
+		for _, id := range ids {
+			if other, ok := seen[id]; ok {
+				t.Errorf("Event %d claimed by both %s and %s", id, other, instanceID)
+			}
+			seen[id] = instanceID
+		}
+	}
+	if len(seen) != len(eventIDs)-2 {
+		t.Errorf("Expected %d claimed events, got %d", len(eventIDs)-2, len(seen))
+	}
+	for _, id := range eventIDs[2:] {
+		if _, ok := seen[id]; !ok {
+			t.Errorf("Event %d was not claimed", id)
+		}
+	}
+	if _, ok := seen[eventIDs[0]]; ok {
+		t.Error("Expected already sent reminder not to be claimed")
+	}
+	if _, ok := seen[eventIDs[1]]; ok {
+		t.Error("Expected freshly claimed reminder not to be claimed again")
+	}
+
+	// Nothing is left to claim
+	claimed, err = repo.ClaimDueReminders(ctx, "a", start, end, 10)
+	if err != nil {
+		t.Fatalf("ClaimDueReminders failed: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Errorf("Expected no events left to claim, got %v", claimed)
+	}
+}
+
+func TestReminderRepository_ScheduledReminders(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewReminderRepository(queue)
+	eventRepo := NewEventRepository(queue)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	deadline := now.Add(48 * time.Hour)
+
+	event := &domain.Event{
+		GroupID:         1,
+		Question:        "Will it rain?",
+		Options:         []string{"Yes", "No"},
+		CreatedAt:       now,
+		Deadline:        deadline,
+		Status:          domain.EventStatusActive,
+		EventType:       domain.EventTypeBinary,
+		CreatedBy:       1,
+		PollID:          "poll_reminders",
+		ReminderOffsets: []time.Duration{24 * time.Hour, time.Hour},
+	}
+	if err := eventRepo.CreateEvent(ctx, event); err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	loaded, err := eventRepo.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if len(loaded.ReminderOffsets) != 2 || loaded.ReminderOffsets[0] != 24*time.Hour || loaded.ReminderOffsets[1] != time.Hour {
+		t.Fatalf("Expected reminder offsets [24h 1h], got %v", loaded.ReminderOffsets)
+	}
+
+	if err := repo.ScheduleEventReminders(ctx, event.ID, domain.PlanReminders(event.ID, event.ReminderOffsets, deadline, now)); err != nil {
+		t.Fatalf("ScheduleEventReminders failed: %v", err)
+	}
+
+	// Events with custom offsets don't get the default reminder
+	claimedIDs, err := repo.ClaimDueReminders(ctx, "a", now, deadline, 10)
+	if err != nil {
+		t.Fatalf("ClaimDueReminders failed: %v", err)
+	}
+	if len(claimedIDs) != 0 {
+		t.Errorf("Expected no default reminders for an event with custom offsets, got %v", claimedIDs)
+	}
+
+	// Nothing is due yet
+	claimed, err := repo.ClaimScheduledReminders(ctx, "a", now, 10)
+	if err != nil {
+		t.Fatalf("ClaimScheduledReminders failed: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("Expected no due reminders, got %v", claimed)
+	}
+
+	// The 24h reminder is due and claimed by one instance only
+	at := deadline.Add(-23 * time.Hour)
+	claimed, err = repo.ClaimScheduledReminders(ctx, "a", at, 10)
+	if err != nil {
+		t.Fatalf("ClaimScheduledReminders failed: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].EventID != event.ID || claimed[0].Offset != 24*time.Hour {
+		t.Fatalf("Expected the 24h reminder to be claimed, got %v", claimed)
+	}
+	claimed, err = repo.ClaimScheduledReminders(ctx, "b", at, 10)
+	if err != nil {
+		t.Fatalf("ClaimScheduledReminders failed: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("Expected the claimed reminder not to be claimed again, got %v", claimed)
+	}
+
+	if err := repo.MarkScheduledReminderSent(ctx, event.ID, 24*time.Hour); err != nil {
+		t.Fatalf("MarkScheduledReminderSent failed: %v", err)
+	}
+
+	// Moving the deadline moves the reminders that were not sent yet
+	event.Deadline = deadline.Add(24 * time.Hour)
+	if err := eventRepo.UpdateEvent(ctx, event); err != nil {
+		t.Fatalf("UpdateEvent failed: %v", err)
+	}
+	claimed, err = repo.ClaimScheduledReminders(ctx, "b", deadline.Add(-time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ClaimScheduledReminders failed: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("Expected the 1h reminder to move with the deadline, got %v", claimed)
+	}
+	claimed, err = repo.ClaimScheduledReminders(ctx, "b", event.Deadline.Add(-time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ClaimScheduledReminders failed: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].Offset != time.Hour {
+		t.Fatalf("Expected only the unsent 1h reminder to be due, got %v", claimed)
+	}
+}
@@ -10,7 +10,19 @@ CREATE TABLE IF NOT EXISTS groups (
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     created_by INTEGER NOT NULL,
     message_thread_id INTEGER,
-    is_forum INTEGER NOT NULL DEFAULT 0
+    is_forum INTEGER NOT NULL DEFAULT 0,
+    pin_polls INTEGER NOT NULL DEFAULT 0,
+    default_event_type TEXT NOT NULL DEFAULT '',
+    require_rules INTEGER NOT NULL DEFAULT 0,
+    auto_remove_inactive INTEGER NOT NULL DEFAULT 0,
+    max_members INTEGER,
+    reputation_weighting INTEGER NOT NULL DEFAULT 0,
+    require_approval INTEGER NOT NULL DEFAULT 0,
+    points_label TEXT NOT NULL DEFAULT '',
+    first_vote_final INTEGER NOT NULL DEFAULT 0,
+    exclude_creator_scoring INTEGER NOT NULL DEFAULT 0,
+    disabled_achievements TEXT NOT NULL DEFAULT '[]',
+    archive_days INTEGER
 );
 
 CREATE INDEX IF NOT EXISTS idx_groups_telegram_chat_id ON groups(telegram_chat_id);
@@ -21,6 +33,8 @@ CREATE TABLE IF NOT EXISTS group_memberships (
     user_id INTEGER NOT NULL,
     joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     status TEXT NOT NULL DEFAULT 'active',
+    rules_pending INTEGER NOT NULL DEFAULT 0,
+    status_changed_at TIMESTAMP,
     FOREIGN KEY (group_id) REFERENCES groups(id),
     UNIQUE(group_id, user_id)
 );
@@ -46,6 +60,19 @@ CREATE TABLE IF NOT EXISTS events (
     allows_revoting INTEGER NOT NULL DEFAULT 1,
     shuffle_options INTEGER NOT NULL DEFAULT 0,
     hide_results_until_close INTEGER NOT NULL DEFAULT 0,
+    stats_message_id INTEGER,
+    poll_pinned INTEGER NOT NULL DEFAULT 0,
+    photo_file_id TEXT,
+    photo_message_id INTEGER,
+    reminder_offsets TEXT NOT NULL DEFAULT '',
+    resolved_at TIMESTAMP,
+    countdown_message_id INTEGER,
+    poll_message_missing INTEGER NOT NULL DEFAULT 0,
+    voting_closed_at TIMESTAMP,
+    resolution_note TEXT NOT NULL DEFAULT '',
+    minority_correct INTEGER NOT NULL DEFAULT 0,
+    lock_votes_at TIMESTAMP,
+    quiz_answer INTEGER,
     FOREIGN KEY (group_id) REFERENCES groups(id)
 );
 
@@ -60,6 +87,7 @@ CREATE TABLE IF NOT EXISTS predictions (
     user_id INTEGER NOT NULL,
     option INTEGER NOT NULL,
     timestamp TIMESTAMP NOT NULL,
+    participation_awarded INTEGER NOT NULL DEFAULT 0,
     FOREIGN KEY (event_id) REFERENCES events(id),
     UNIQUE(event_id, user_id)
 );
@@ -75,6 +103,7 @@ CREATE TABLE IF NOT EXISTS ratings (
     correct_count INTEGER NOT NULL DEFAULT 0,
     wrong_count INTEGER NOT NULL DEFAULT 0,
     streak INTEGER NOT NULL DEFAULT 0,
+    best_streak INTEGER NOT NULL DEFAULT 0,
     PRIMARY KEY (user_id, group_id),
     FOREIGN KEY (group_id) REFERENCES groups(id)
 );
@@ -117,6 +146,34 @@ CREATE TABLE IF NOT EXISTS organizer_notifications (
     FOREIGN KEY (event_id) REFERENCES events(id)
 );
 
+CREATE TABLE IF NOT EXISTS resolution_nags (
+    event_id INTEGER PRIMARY KEY,
+    nag_count INTEGER NOT NULL DEFAULT 0,
+    last_sent_at TIMESTAMP NOT NULL,
+    escalated_at TIMESTAMP,
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+CREATE TABLE IF NOT EXISTS reminder_claims (
+    event_id INTEGER PRIMARY KEY,
+    instance_id TEXT NOT NULL,
+    claimed_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+CREATE TABLE IF NOT EXISTS event_reminders (
+    event_id INTEGER NOT NULL,
+    offset_seconds INTEGER NOT NULL,
+    remind_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP,
+    claimed_by TEXT,
+    claimed_at TIMESTAMP,
+    PRIMARY KEY (event_id, offset_seconds),
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_reminders_remind_at ON event_reminders(remind_at);
+
 CREATE TABLE IF NOT EXISTS fsm_sessions (
     user_id INTEGER PRIMARY KEY,
     state TEXT NOT NULL,
@@ -129,6 +186,119 @@ CREATE TABLE IF NOT EXISTS fsm_sessions (
 
 CREATE INDEX IF NOT EXISTS idx_fsm_sessions_updated ON fsm_sessions(updated_at);
 CREATE INDEX IF NOT EXISTS idx_fsm_sessions_group_id ON fsm_sessions(group_id);
+
+CREATE TABLE IF NOT EXISTS user_profiles (
+    user_id INTEGER PRIMARY KEY,
+    username TEXT NOT NULL DEFAULT '',
+    first_name TEXT NOT NULL DEFAULT '',
+    last_name TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    first_seen_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS bot_settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS feedback (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    display_name TEXT NOT NULL DEFAULT '',
+    text TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_user_created ON feedback(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_feedback_created ON feedback(created_at);
+
+CREATE TABLE IF NOT EXISTS event_participants (
+    event_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    PRIMARY KEY (event_id, user_id),
+    FOREIGN KEY (event_id) REFERENCES events(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_participants_user ON event_participants(user_id);
+
+CREATE TABLE IF NOT EXISTS duels (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    challenger_id INTEGER NOT NULL,
+    opponent_id INTEGER NOT NULL,
+    question TEXT NOT NULL,
+    challenger_pick INTEGER,
+    opponent_pick INTEGER,
+    challenger_report INTEGER,
+    opponent_report INTEGER,
+    outcome INTEGER,
+    deadline TIMESTAMP NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_duels_status_deadline ON duels(status, deadline);
+
+CREATE TABLE IF NOT EXISTS duel_records (
+    user_id INTEGER PRIMARY KEY,
+    wins INTEGER NOT NULL DEFAULT 0,
+    losses INTEGER NOT NULL DEFAULT 0,
+    draws INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    user_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    subscribed INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, group_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_group ON subscriptions(group_id);
+
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id INTEGER PRIMARY KEY,
+    outcome_mode TEXT NOT NULL DEFAULT 'immediate',
+    last_digest_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS unreachable_users (
+    user_id INTEGER PRIMARY KEY,
+    marked_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS seasons (
+    group_id INTEGER NOT NULL,
+    number INTEGER NOT NULL,
+    started_at TIMESTAMP,
+    ended_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (group_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS season_results (
+    group_id INTEGER NOT NULL,
+    season INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    username TEXT NOT NULL DEFAULT '',
+    score INTEGER NOT NULL DEFAULT 0,
+    correct_count INTEGER NOT NULL DEFAULT 0,
+    wrong_count INTEGER NOT NULL DEFAULT 0,
+    best_streak INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (group_id, season, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_languages (
+    user_id INTEGER PRIMARY KEY,
+    language TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS chat_languages (
+    chat_id INTEGER PRIMARY KEY,
+    language TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
 `
 
 // InitSchema initializes the database schema
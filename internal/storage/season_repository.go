@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+// SeasonRepository handles season archives of group ratings
+type SeasonRepository struct {
+	queue *DBQueue
+}
+
+// NewSeasonRepository creates a new SeasonRepository
+func NewSeasonRepository(queue *DBQueue) *SeasonRepository {
+	return &SeasonRepository{queue: queue}
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// ArchiveSeason copies the live ratings of a group into season_results, resets them to zero
+// and records the season, all in one transaction. Best streaks are kept, they are never decreased.
+// Members who never scored or voted are not archived; their rating rows and usernames are kept.
+func (r *SeasonRepository) ArchiveSeason(ctx context.Context, groupID int64, endedAt time.Time) (*domain.Season, error) {
+	season := &domain.Season{GroupID: groupID, Number: 1, EndedAt: endedAt}
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		var lastNumber int
+		var lastEndedAt time.Time
+		err = tx.QueryRowContext(ctx,
+			`SELECT number, ended_at FROM seasons WHERE group_id = ? ORDER BY number DESC LIMIT 1`,
+			groupID,
+		).Scan(&lastNumber, &lastEndedAt)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return err
+		default:
+			season.Number = lastNumber + 1
+			season.StartedAt = lastEndedAt
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO season_results (group_id, season, user_id, username, score, correct_count, wrong_count, best_streak)
+			 SELECT group_id, ?, user_id, username, score, correct_count, wrong_count, best_streak
+			 FROM ratings
+			 WHERE group_id = ? AND (score != 0 OR correct_count > 0 OR wrong_count > 0)`,
+			season.Number, groupID,
+		); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE ratings SET score = 0, correct_count = 0, wrong_count = 0, streak = 0
+			 WHERE group_id = ?`,
+			groupID,
+		); err != nil {
+			return err
+		}
+
+		var startedAt sql.NullTime
+		if !season.StartedAt.IsZero() {
+			startedAt = sql.NullTime{Time: season.StartedAt, Valid: true}
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO seasons (group_id, number, started_at, ended_at) VALUES (?, ?, ?, ?)`,
+			groupID, season.Number, startedAt, endedAt,
+		); err != nil {
+			return err
+		}
+
+		if err := loadSeasonStandings(ctx, tx, season); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return season, nil
+}
+
+// GetSeasonStart returns when the current season of a group started, i.e. the end of the last
+// archived season (zero when no season was archived)
+func (r *SeasonRepository) GetSeasonStart(ctx context.Context, groupID int64) (time.Time, error) {
+	var start time.Time
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT ended_at FROM seasons WHERE group_id = ? ORDER BY number DESC LIMIT 1`,
+			groupID,
+		).Scan(&start)
+	})
+
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return start, nil
+}
+
+// GetSeasons retrieves the finished seasons of a group with their champions, newest first
+func (r *SeasonRepository) GetSeasons(ctx context.Context, groupID int64) ([]*domain.Season, error) {
+	var seasons []*domain.Season
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT number, started_at, ended_at FROM seasons WHERE group_id = ? ORDER BY number DESC`,
+			groupID,
+		)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			season := &domain.Season{GroupID: groupID}
+			var startedAt sql.NullTime
+			if err := rows.Scan(&season.Number, &startedAt, &season.EndedAt); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			if startedAt.Valid {
+				season.StartedAt = startedAt.Time
+			}
+			seasons = append(seasons, season)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+
+		for _, season := range seasons {
+			if err := loadSeasonStandings(ctx, db, season); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+// loadSeasonStandings fills in the number of players and the champion of an archived season.
+// Equal scores are decided by the number of correct predictions, then by the earlier user ID.
+func loadSeasonStandings(ctx context.Context, q rowQuerier, season *domain.Season) error {
+	if err := q.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM season_results WHERE group_id = ? AND season = ?`,
+		season.GroupID, season.Number,
+	).Scan(&season.Players); err != nil {
+		return err
+	}
+
+	if season.Players == 0 {
+		return nil
+	}
+
+	champion := &domain.SeasonResult{GroupID: season.GroupID, Season: season.Number}
+	if err := q.QueryRowContext(ctx,
+		`SELECT user_id, username, score, correct_count, wrong_count, best_streak
+		 FROM season_results
+		 WHERE group_id = ? AND season = ?
+		 ORDER BY score DESC, correct_count DESC, user_id ASC
+		 LIMIT 1`,
+		season.GroupID, season.Number,
+	).Scan(&champion.UserID, &champion.Username, &champion.Score, &champion.CorrectCount,
+		&champion.WrongCount, &champion.BestStreak); err != nil {
+		return err
+	}
+	season.Champion = champion
+
+	return nil
+}
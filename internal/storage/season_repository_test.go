@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+)
+
+func setupSeasonTestDB(t *testing.T) (*DBQueue, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	queue := NewDBQueue(db)
+	t.Cleanup(queue.Close)
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	return queue, db
+}
+
+func TestSeasonRepository_ArchiveAndReset(t *testing.T) {
+	ctx := context.Background()
+	queue, _ := setupSeasonTestDB(t)
+	ratingRepo := NewRatingRepository(queue)
+	seasonRepo := NewSeasonRepository(queue)
+
+	ratings := []*domain.Rating{
+		{UserID: 1, GroupID: 1, Username: "alice", Score: 40, CorrectCount: 5, WrongCount: 1, Streak: 3, BestStreak: 4},
+		{UserID: 2, GroupID: 1, Username: "bob", Score: 25, CorrectCount: 3, WrongCount: 2, Streak: 0, BestStreak: 2},
+		{UserID: 3, GroupID: 1, Username: "idle"},
+		{UserID: 1, GroupID: 2, Username: "alice", Score: 7, CorrectCount: 1},
+	}
+	for _, rating := range ratings {
+		if err := ratingRepo.UpdateRating(ctx, rating); err != nil {
+			t.Fatalf("UpdateRating failed: %v", err)
+		}
+	}
+
+	start, err := seasonRepo.GetSeasonStart(ctx, 1)
+	if err != nil || !start.IsZero() {
+		t.Fatalf("Expected no season start before the first season, got %v (err %v)", start, err)
+	}
+
+	endedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	season, err := seasonRepo.ArchiveSeason(ctx, 1, endedAt)
+	if err != nil {
+		t.Fatalf("ArchiveSeason failed: %v", err)
+	}
+	if season.Number != 1 || !season.StartedAt.IsZero() || season.Players != 2 {
+		t.Fatalf("Unexpected archived season: %+v", season)
+	}
+	if season.Champion == nil || season.Champion.UserID != 1 || season.Champion.Score != 40 || season.Champion.BestStreak != 4 {
+		t.Fatalf("Expected alice to be the champion, got %+v", season.Champion)
+	}
+
+	// Live ratings of the group are reset, usernames and best streaks stay
+	rating, err := ratingRepo.GetRating(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetRating failed: %v", err)
+	}
+	if rating.Score != 0 || rating.CorrectCount != 0 || rating.WrongCount != 0 || rating.Streak != 0 || rating.Username != "alice" {
+		t.Errorf("Expected a reset rating with the username kept, got %+v", rating)
+	}
+	if rating.BestStreak != 4 {
+		t.Errorf("Expected the best streak to be kept across seasons, got %d", rating.BestStreak)
+	}
+
+	// Other groups are untouched
+	other, err := ratingRepo.GetRating(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("GetRating failed: %v", err)
+	}
+	if other.Score != 7 {
+		t.Errorf("Expected the other group's rating to be kept, got %+v", other)
+	}
+
+	start, err = seasonRepo.GetSeasonStart(ctx, 1)
+	if err != nil || !start.Equal(endedAt) {
+		t.Fatalf("Expected the current season to start at %v, got %v (err %v)", endedAt, start, err)
+	}
+
+	// The next season starts where the previous one ended
+	if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: 2, GroupID: 1, Username: "bob", Score: 9, CorrectCount: 1}); err != nil {
+		t.Fatalf("UpdateRating failed: %v", err)
+	}
+	second, err := seasonRepo.ArchiveSeason(ctx, 1, endedAt.AddDate(0, 3, 0))
+	if err != nil {
+		t.Fatalf("ArchiveSeason failed: %v", err)
+	}
+	if second.Number != 2 || !second.StartedAt.Equal(endedAt) || second.Players != 1 || second.Champion.UserID != 2 {
+		t.Fatalf("Unexpected second season: %+v (champion %+v)", second, second.Champion)
+	}
+
+	seasons, err := seasonRepo.GetSeasons(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetSeasons failed: %v", err)
+	}
+	if len(seasons) != 2 || seasons[0].Number != 2 || seasons[1].Number != 1 {
+		t.Fatalf("Expected seasons newest first, got %+v", seasons)
+	}
+	if seasons[1].Champion == nil || seasons[1].Champion.Username != "alice" || seasons[1].Players != 2 {
+		t.Errorf("Unexpected first season in history: %+v", seasons[1])
+	}
+
+	if seasons, err := seasonRepo.GetSeasons(ctx, 2); err != nil || len(seasons) != 0 {
+		t.Errorf("Expected no seasons for the other group, got %d (err %v)", len(seasons), err)
+	}
+}
+
+func TestSeasonRepository_ArchiveKeepsBestStreak(t *testing.T) {
+	ctx := context.Background()
+	queue, _ := setupSeasonTestDB(t)
+	ratingRepo := NewRatingRepository(queue)
+	seasonRepo := NewSeasonRepository(queue)
+
+	if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: 1, GroupID: 1, Username: "alice", Score: 30, CorrectCount: 6, Streak: 3, BestStreak: 6}); err != nil {
+		t.Fatalf("UpdateRating failed: %v", err)
+	}
+	if _, err := seasonRepo.ArchiveSeason(ctx, 1, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("ArchiveSeason failed: %v", err)
+	}
+
+	// A shorter streak in the new season doesn't lower the best one
+	if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: 1, GroupID: 1, Username: "alice", Score: 10, CorrectCount: 2, Streak: 2, BestStreak: 2}); err != nil {
+		t.Fatalf("UpdateRating failed: %v", err)
+	}
+	rating, err := ratingRepo.GetRating(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetRating failed: %v", err)
+	}
+	if rating.Streak != 2 || rating.BestStreak != 6 {
+		t.Errorf("Expected streak 2 and best streak 6, got %d and %d", rating.Streak, rating.BestStreak)
+	}
+
+	// The next season archives the best streak so far
+	season, err := seasonRepo.ArchiveSeason(ctx, 1, time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ArchiveSeason failed: %v", err)
+	}
+	if season.Number != 2 || season.Champion == nil || season.Champion.BestStreak != 6 {
+		t.Errorf("Expected the second season to keep best streak 6, got %+v", season.Champion)
+	}
+}
+
+func TestSeasonRepository_RecomputeKeepsBestStreak(t *testing.T) {
+	ctx := context.Background()
+	queue, _ := setupSeasonTestDB(t)
+	ratingRepo := NewRatingRepository(queue)
+	seasonRepo := NewSeasonRepository(queue)
+
+	if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: 1, GroupID: 1, Username: "alice", Score: 30, CorrectCount: 6, Streak: 3, BestStreak: 6}); err != nil {
+		t.Fatalf("UpdateRating failed: %v", err)
+	}
+	if _, err := seasonRepo.ArchiveSeason(ctx, 1, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("ArchiveSeason failed: %v", err)
+	}
+
+	// The recompute replays only the current season, which has no events yet
+	rc := domain.NewRatingCalculator(ratingRepo, NewPredictionRepository(queue), NewEventRepository(queue), nil, logger.New(logger.ERROR))
+	rc.SetSeasonRepository(seasonRepo)
+	if _, err := rc.RecomputeGroup(ctx, 1); err != nil {
+		t.Fatalf("RecomputeGroup failed: %v", err)
+	}
+
+	rating, err := ratingRepo.GetRating(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetRating failed: %v", err)
+	}
+	if rating.Score != 0 || rating.BestStreak != 6 {
+		t.Errorf("Expected score 0 and best streak 6 after the recompute, got %d and %d", rating.Score, rating.BestStreak)
+	}
+}
+
+func TestSeasonRepository_ArchiveIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	queue, db := setupSeasonTestDB(t)
+	ratingRepo := NewRatingRepository(queue)
+	seasonRepo := NewSeasonRepository(queue)
+
+	if err := ratingRepo.UpdateRating(ctx, &domain.Rating{UserID: 1, GroupID: 1, Username: "alice", Score: 40, CorrectCount: 5, Streak: 2}); err != nil {
+		t.Fatalf("UpdateRating failed: %v", err)
+	}
+
+	// Make the reset fail after the ratings were copied into the archive
+	if _, err := db.Exec(`CREATE TRIGGER fail_rating_reset BEFORE UPDATE ON ratings
+		BEGIN SELECT RAISE(ABORT, 'reset failed'); END`); err != nil {
+		t.Fatalf("Failed to create trigger: %v", err)
+	}
+
+	if _, err := seasonRepo.ArchiveSeason(ctx, 1, time.Now()); err == nil {
+		t.Fatal("Expected ArchiveSeason to fail")
+	}
+
+	var archived, seasons int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM season_results`).Scan(&archived); err != nil {
+		t.Fatalf("Failed to count season results: %v", err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM seasons`).Scan(&seasons); err != nil {
+		t.Fatalf("Failed to count seasons: %v", err)
+	}
+	if archived != 0 || seasons != 0 {
+		t.Errorf("Expected a failed reset to leave no archive, got %d results and %d seasons", archived, seasons)
+	}
+
+	rating, err := ratingRepo.GetRating(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("GetRating failed: %v", err)
+	}
+	if rating.Score != 40 || rating.Streak != 2 {
+		t.Errorf("Expected the live rating to be untouched, got %+v", rating)
+	}
+}
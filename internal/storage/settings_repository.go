@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SettingsRepository handles persisted runtime settings
+type SettingsRepository struct {
+	queue *DBQueue
+}
+
+// NewSettingsRepository creates a new SettingsRepository
+func NewSettingsRepository(queue *DBQueue) *SettingsRepository {
+	return &SettingsRepository{queue: queue}
+}
+
+// GetSetting retrieves a setting value. found is false if the setting was never stored.
+func (r *SettingsRepository) GetSetting(ctx context.Context, key string) (string, bool, error) {
+	var value string
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT value FROM bot_settings WHERE key = ?`,
+			key,
+		).Scan(&value)
+	})
+
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return value, true, nil
+}
+
+// SetSetting stores a setting value, replacing any previous one
+func (r *SettingsRepository) SetSetting(ctx context.Context, key string, value string) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO bot_settings (key, value, updated_at) VALUES (?, ?, ?)
+			 ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+			key, value, time.Now(),
+		)
+		return err
+	})
+}
+
+// roundTripKey is the settings key written by CheckRoundTrip, the write is always rolled back
+const roundTripKey = "diag_roundtrip"
+
+// CheckRoundTrip writes a setting and reads it back in a transaction that is rolled back,
+// checking that the database accepts writes through the queue without changing any data
+func (r *SettingsRepository) CheckRoundTrip(ctx context.Context) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		written := strconv.FormatInt(time.Now().UnixNano(), 10)
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO bot_settings (key, value, updated_at) VALUES (?, ?, ?)
+			 ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+			roundTripKey, written, time.Now(),
+		)
+		if err != nil {
+			return err
+		}
+
+		var read string
+		if err := tx.QueryRowContext(ctx, `SELECT value FROM bot_settings WHERE key = ?`, roundTripKey).Scan(&read); err != nil {
+			return err
+		}
+		if read != written {
+			return fmt.Errorf("read %q after writing %q", read, written)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+	"github.com/ad/gitelegram-prediction-market/internal/logger"
+)
+
+func TestSettingsRepository_MaintenanceModeSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewSettingsRepository(queue)
+	log := logger.New(logger.ERROR)
+
+	// Unknown settings are reported as not found
+	if _, found, err := repo.GetSetting(ctx, domain.SettingMaintenanceMode); err != nil || found {
+		t.Fatalf("Expected no stored setting, got found=%t err=%v", found, err)
+	}
+
+	maintenance, err := domain.NewMaintenanceMode(ctx, repo, false, log)
+	if err != nil {
+		t.Fatalf("NewMaintenanceMode failed: %v", err)
+	}
+	if maintenance.Enabled() {
+		t.Fatal("Expected maintenance mode to be off by default")
+	}
+
+	if err := maintenance.SetEnabled(ctx, true); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+
+	// A new instance (bot restart) picks up the persisted flag
+	restarted, err := domain.NewMaintenanceMode(ctx, repo, false, log)
+	if err != nil {
+		t.Fatalf("NewMaintenanceMode failed: %v", err)
+	}
+	if !restarted.Enabled() {
+		t.Error("Expected maintenance mode to survive restart")
+	}
+
+	if err := restarted.SetEnabled(ctx, false); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	value, found, err := repo.GetSetting(ctx, domain.SettingMaintenanceMode)
+	if err != nil || !found || value != "false" {
+		t.Errorf("Expected stored value false, got %q found=%t err=%v", value, found, err)
+	}
+
+	// Config can force maintenance on at startup
+	forced, err := domain.NewMaintenanceMode(ctx, repo, true, log)
+	if err != nil {
+		t.Fatalf("NewMaintenanceMode failed: %v", err)
+	}
+	if !forced.Enabled() {
+		t.Error("Expected forced maintenance mode to be on")
+	}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+// StatsRepository handles aggregated statistics queries
+type StatsRepository struct {
+	queue *DBQueue
+}
+
+// NewStatsRepository creates a new StatsRepository
+func NewStatsRepository(queue *DBQueue) *StatsRepository {
+	return &StatsRepository{queue: queue}
+}
+
+// GetGroupStats calculates event, prediction and membership counts for a group
+func (r *StatsRepository) GetGroupStats(ctx context.Context, groupID int64) (*domain.GroupStats, error) {
+	stats := &domain.GroupStats{GroupID: groupID}
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		// Event counts by status (archived events are resolved events)
+		if err := db.QueryRowContext(ctx,
+			`SELECT COUNT(*),
+			        COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0),
+			        COALESCE(SUM(CASE WHEN status IN (?, ?) THEN 1 ELSE 0 END), 0),
+			        COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0)
+			 FROM events WHERE group_id = ?`,
+			domain.EventStatusActive, domain.EventStatusResolved, domain.EventStatusArchived, domain.EventStatusCancelled, groupID,
+		).Scan(&stats.TotalEvents, &stats.ActiveEvents, &stats.ResolvedEvents, &stats.CancelledEvents); err != nil {
+			return err
+		}
+
+		// Prediction counts
+		if err := db.QueryRowContext(ctx,
+			`SELECT COUNT(*), COUNT(DISTINCT p.user_id)
+			 FROM predictions p
+			 JOIN events e ON e.id = p.event_id
+			 WHERE e.group_id = ?`,
+			groupID,
+		).Scan(&stats.TotalPredictions, &stats.UniqueParticipants); err != nil {
+			return err
+		}
+
+		// Accuracy on resolved events
+		if err := db.QueryRowContext(ctx,
+			`SELECT COUNT(*), COALESCE(SUM(CASE WHEN p.option = e.correct_option THEN 1 ELSE 0 END), 0)
+			 FROM predictions p
+			 JOIN events e ON e.id = p.event_id
+			 WHERE e.group_id = ? AND e.status IN (?, ?) AND e.correct_option IS NOT NULL`,
+			groupID, domain.EventStatusResolved, domain.EventStatusArchived,
+		).Scan(&stats.ResolvedPredictions, &stats.CorrectPredictions); err != nil {
+			return err
+		}
+
+		// Contrarian wins: resolved events whose correct option was a minority pick
+		if err := db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM events WHERE group_id = ? AND status IN (?, ?) AND minority_correct = 1`,
+			groupID, domain.EventStatusResolved, domain.EventStatusArchived,
+		).Scan(&stats.ContrarianWins); err != nil {
+			return err
+		}
+
+		rows, err := db.QueryContext(ctx,
+			`SELECT e.id, e.question,
+			        COALESCE(SUM(CASE WHEN p.option = e.correct_option THEN 1 ELSE 0 END), 0), COUNT(p.id)
+			 FROM events e
+			 LEFT JOIN predictions p ON p.event_id = e.id
+			 WHERE e.group_id = ? AND e.status IN (?, ?) AND e.minority_correct = 1
+			 GROUP BY e.id
+			 ORDER BY e.resolved_at DESC, e.id DESC
+			 LIMIT ?`,
+			groupID, domain.EventStatusResolved, domain.EventStatusArchived, domain.ContrarianWinsLimit,
+		)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			win := &domain.ContrarianWin{}
+			if err := rows.Scan(&win.EventID, &win.Question, &win.CorrectCount, &win.TotalCount); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			stats.RecentContrarianWins = append(stats.RecentContrarianWins, win)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+
+		// Active members
+		return db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM group_memberships WHERE group_id = ? AND status = ?`,
+			groupID, domain.MembershipStatusActive,
+		).Scan(&stats.ActiveMembers)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+func TestGetGroupStats(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	eventRepo := NewEventRepository(queue)
+	predictionRepo := NewPredictionRepository(queue)
+	membershipRepo := NewGroupMembershipRepository(queue)
+	statsRepo := NewStatsRepository(queue)
+	groupID := int64(1)
+	otherGroupID := int64(2)
+	now := time.Now()
+
+	newEvent := func(groupID int64, status domain.EventStatus, votes []int) *domain.Event {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  "Question?",
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now.Add(-48 * time.Hour),
+			Deadline:  now.Add(-24 * time.Hour),
+			Status:    status,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 1,
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		for i, option := range votes {
+			if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: int64(100 + i), Option: option, Timestamp: now}); err != nil {
+				t.Fatalf("Failed to save prediction: %v", err)
+			}
+		}
+		return event
+	}
+
+	newEvent(groupID, domain.EventStatusActive, []int{0, 1})
+	resolved := newEvent(groupID, domain.EventStatusActive, []int{0, 0, 1})
+	if err := eventRepo.ResolveEvent(ctx, resolved.ID, 0); err != nil {
+		t.Fatalf("Failed to resolve event: %v", err)
+	}
+	archived := newEvent(groupID, domain.EventStatusActive, []int{1})
+	if err := eventRepo.ResolveEvent(ctx, archived.ID, 1); err != nil {
+		t.Fatalf("Failed to resolve event: %v", err)
+	}
+	if _, err := eventRepo.ArchiveResolvedOlderThan(ctx, groupID, now); err != nil {
+		t.Fatalf("Failed to archive events: %v", err)
+	}
+	newEvent(groupID, domain.EventStatusCancelled, nil)
+	newEvent(otherGroupID, domain.EventStatusActive, []int{0, 0, 0, 0})
+
+	for _, m := range []struct {
+		userID int64
+		status domain.MembershipStatus
+	}{{100, domain.MembershipStatusActive}, {101, domain.MembershipStatusActive}, {102, domain.MembershipStatusRemoved}} {
+		if err := membershipRepo.CreateMembership(ctx, &domain.GroupMembership{GroupID: groupID, UserID: m.userID, JoinedAt: now, Status: m.status}); err != nil {
+			t.Fatalf("Failed to create membership: %v", err)
+		}
+	}
+
+	stats, err := statsRepo.GetGroupStats(ctx, groupID)
+	if err != nil {
+		t.Fatalf("GetGroupStats failed: %v", err)
+	}
+
+	if stats.TotalEvents != 4 || stats.ActiveEvents != 1 || stats.ResolvedEvents != 2 || stats.CancelledEvents != 1 {
+		t.Errorf("unexpected event counts: %+v", stats)
+	}
+	if stats.TotalPredictions != 6 || stats.UniqueParticipants != 3 {
+		t.Errorf("unexpected prediction counts: total=%d unique=%d", stats.TotalPredictions, stats.UniqueParticipants)
+	}
+	if stats.ResolvedPredictions != 4 || stats.CorrectPredictions != 3 {
+		t.Errorf("unexpected accuracy counts: resolved=%d correct=%d", stats.ResolvedPredictions, stats.CorrectPredictions)
+	}
+	if stats.ActiveMembers != 2 {
+		t.Errorf("expected 2 active members, got %d", stats.ActiveMembers)
+	}
+
+	// Empty group returns zero counts
+	empty, err := statsRepo.GetGroupStats(ctx, 999)
+	if err != nil {
+		t.Fatalf("GetGroupStats failed for empty group: %v", err)
+	}
+	if empty.TotalEvents != 0 || empty.TotalPredictions != 0 || empty.ActiveMembers != 0 {
+		t.Errorf("expected zero stats for empty group, got %+v", empty)
+	}
+}
+
+func TestGetGroupStats_ContrarianWins(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	eventRepo := NewEventRepository(queue)
+	predictionRepo := NewPredictionRepository(queue)
+	statsRepo := NewStatsRepository(queue)
+	now := time.Now()
+
+	resolveEvent := func(groupID int64, question string, votes []int, correct int, minority bool) *domain.Event {
+		event := &domain.Event{
+			GroupID:   groupID,
+			Question:  question,
+			Options:   []string{"Yes", "No"},
+			CreatedAt: now.Add(-48 * time.Hour),
+			Deadline:  now.Add(-24 * time.Hour),
+			Status:    domain.EventStatusActive,
+			EventType: domain.EventTypeBinary,
+			CreatedBy: 1,
+		}
+		if err := eventRepo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+		for i, option := range votes {
+			if err := predictionRepo.SavePrediction(ctx, &domain.Prediction{EventID: event.ID, UserID: int64(100 + i), Option: option, Timestamp: now}); err != nil {
+				t.Fatalf("Failed to save prediction: %v", err)
+			}
+		}
+		if err := eventRepo.ResolveEvent(ctx, event.ID, correct); err != nil {
+			t.Fatalf("Failed to resolve event: %v", err)
+		}
+		if err := eventRepo.SetMinorityCorrect(ctx, event.ID, minority); err != nil {
+			t.Fatalf("SetMinorityCorrect failed: %v", err)
+		}
+		return event
+	}
+
+	first := resolveEvent(1, "First?", []int{0, 1, 1, 1}, 0, true)
+	resolveEvent(1, "Majority?", []int{0, 0, 1}, 0, false)
+	second := resolveEvent(1, "Second?", []int{1, 0, 0, 0, 0}, 1, true)
+	resolveEvent(2, "Other group?", []int{0, 1, 1}, 0, true)
+
+	loaded, err := eventRepo.GetEvent(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if !loaded.MinorityCorrect {
+		t.Error("Expected MinorityCorrect to be stored")
+	}
+
+	stats, err := statsRepo.GetGroupStats(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetGroupStats failed: %v", err)
+	}
+
+	if stats.ContrarianWins != 2 {
+		t.Errorf("Expected 2 contrarian wins, got %d", stats.ContrarianWins)
+	}
+	if len(stats.RecentContrarianWins) != 2 {
+		t.Fatalf("Expected 2 recent contrarian wins, got %d", len(stats.RecentContrarianWins))
+	}
+
+	// Most recently resolved first
+	latest := stats.RecentContrarianWins[0]
+	if latest.EventID != second.ID || latest.Question != "Second?" || latest.CorrectCount != 1 || latest.TotalCount != 5 {
+		t.Errorf("Unexpected latest contrarian win: %+v", latest)
+	}
+	if stats.RecentContrarianWins[1].EventID != first.ID {
+		t.Errorf("Expected event %d second, got %d", first.ID, stats.RecentContrarianWins[1].EventID)
+	}
+}
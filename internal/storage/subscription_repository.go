@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// SubscriptionRepository handles per-group new event subscription flags
+type SubscriptionRepository struct {
+	queue *DBQueue
+}
+
+// NewSubscriptionRepository creates a new SubscriptionRepository
+func NewSubscriptionRepository(queue *DBQueue) *SubscriptionRepository {
+	return &SubscriptionRepository{queue: queue}
+}
+
+// SetSubscribed stores whether a user is notified about new events of a group, replacing any previous flag
+func (r *SubscriptionRepository) SetSubscribed(ctx context.Context, userID int64, groupID int64, subscribed bool) error {
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO subscriptions (user_id, group_id, subscribed, updated_at) VALUES (?, ?, ?, ?)
+			 ON CONFLICT(user_id, group_id) DO UPDATE SET subscribed = excluded.subscribed, updated_at = excluded.updated_at`,
+			userID, groupID, boolToInt(subscribed), time.Now(),
+		)
+		return err
+	})
+}
+
+// GetUnsubscribedGroupIDs retrieves the groups a user unsubscribed from
+func (r *SubscriptionRepository) GetUnsubscribedGroupIDs(ctx context.Context, userID int64) ([]int64, error) {
+	return r.queryIDs(ctx, `SELECT group_id FROM subscriptions WHERE user_id = ? AND subscribed = 0 ORDER BY group_id`, userID)
+}
+
+// GetUnsubscribedUserIDs retrieves the users who unsubscribed from a group
+func (r *SubscriptionRepository) GetUnsubscribedUserIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	return r.queryIDs(ctx, `SELECT user_id FROM subscriptions WHERE group_id = ? AND subscribed = 0 ORDER BY user_id`, groupID)
+}
+
+// queryIDs runs a query returning a single integer column
+func (r *SubscriptionRepository) queryIDs(ctx context.Context, query string, args ...interface{}) ([]int64, error) {
+	var ids []int64
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
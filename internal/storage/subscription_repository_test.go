@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestSubscriptionRepository(t *testing.T) {
+	// Setup in-memory database
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewSubscriptionRepository(queue)
+	ctx := context.Background()
+
+	// Users are subscribed until they opt out
+	groupIDs, err := repo.GetUnsubscribedGroupIDs(ctx, 100)
+	if err != nil {
+		t.Fatalf("GetUnsubscribedGroupIDs failed: %v", err)
+	}
+	if len(groupIDs) != 0 {
+		t.Errorf("Expected no unsubscribed groups, got %v", groupIDs)
+	}
+
+	for _, groupID := range []int64{2, 1, 3} {
+		if err := repo.SetSubscribed(ctx, 100, groupID, false); err != nil {
+			t.Fatalf("SetSubscribed failed: %v", err)
+		}
+	}
+	if err := repo.SetSubscribed(ctx, 200, 1, false); err != nil {
+		t.Fatalf("SetSubscribed failed: %v", err)
+	}
+	// Subscribing again replaces the previous flag
+	if err := repo.SetSubscribed(ctx, 100, 3, true); err != nil {
+		t.Fatalf("SetSubscribed failed: %v", err)
+	}
+
+	groupIDs, err = repo.GetUnsubscribedGroupIDs(ctx, 100)
+	if err != nil {
+		t.Fatalf("GetUnsubscribedGroupIDs failed: %v", err)
+	}
+	if expected := []int64{1, 2}; !reflect.DeepEqual(groupIDs, expected) {
+		t.Errorf("Expected unsubscribed groups %v, got %v", expected, groupIDs)
+	}
+
+	userIDs, err := repo.GetUnsubscribedUserIDs(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetUnsubscribedUserIDs failed: %v", err)
+	}
+	if expected := []int64{100, 200}; !reflect.DeepEqual(userIDs, expected) {
+		t.Errorf("Expected unsubscribed users %v, got %v", expected, userIDs)
+	}
+
+	userIDs, err = repo.GetUnsubscribedUserIDs(ctx, 3)
+	if err != nil {
+		t.Fatalf("GetUnsubscribedUserIDs failed: %v", err)
+	}
+	if len(userIDs) != 0 {
+		t.Errorf("Expected no unsubscribed users for group 3, got %v", userIDs)
+	}
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/ad/gitelegram-prediction-market/internal/domain"
+)
+
+// UserRepository handles cached Telegram user profiles
+type UserRepository struct {
+	queue *DBQueue
+}
+
+// NewUserRepository creates a new UserRepository
+func NewUserRepository(queue *DBQueue) *UserRepository {
+	return &UserRepository{queue: queue}
+}
+
+// UpsertUserProfile stores the latest known username and name of a user.
+// The row is only rewritten when something changed, so stale names get refreshed
+// without touching unchanged profiles. first_seen_at is set once, when the user is first seen.
+func (r *UserRepository) UpsertUserProfile(ctx context.Context, userID int64, username, firstName, lastName string) error {
+	now := time.Now()
+	return r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO user_profiles (user_id, username, first_name, last_name, updated_at, first_seen_at)
+			 VALUES (?, ?, ?, ?, ?, ?)
+			 ON CONFLICT(user_id) DO UPDATE SET
+			   username = excluded.username,
+			   first_name = excluded.first_name,
+			   last_name = excluded.last_name,
+			   updated_at = excluded.updated_at
+			 WHERE user_profiles.username != excluded.username
+			    OR user_profiles.first_name != excluded.first_name
+			    OR user_profiles.last_name != excluded.last_name`,
+			userID, username, firstName, lastName, now, now,
+		)
+		return err
+	})
+}
+
+// GetUserProfile retrieves a cached user profile (nil if the user was never seen)
+func (r *UserRepository) GetUserProfile(ctx context.Context, userID int64) (*domain.UserProfile, error) {
+	var profile domain.UserProfile
+	var firstSeenAt sql.NullTime
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT user_id, username, first_name, last_name, updated_at, first_seen_at FROM user_profiles WHERE user_id = ?`,
+			userID,
+		).Scan(&profile.UserID, &profile.Username, &profile.FirstName, &profile.LastName, &profile.UpdatedAt, &firstSeenAt)
+	})
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	profile.FirstSeenAt = profile.UpdatedAt
+	if firstSeenAt.Valid {
+		profile.FirstSeenAt = firstSeenAt.Time
+	}
+
+	return &profile, nil
+}
+
+// GetUserProfileByUsername retrieves a cached user profile by username, ignoring case and a leading "@".
+// Returns nil if no user with this username was seen; if a username moved between accounts,
+// the most recently updated profile wins.
+func (r *UserRepository) GetUserProfileByUsername(ctx context.Context, username string) (*domain.UserProfile, error) {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+	if username == "" {
+		return nil, nil
+	}
+
+	var profile domain.UserProfile
+	var firstSeenAt sql.NullTime
+
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT user_id, username, first_name, last_name, updated_at, first_seen_at FROM user_profiles
+			 WHERE username = ? COLLATE NOCASE ORDER BY updated_at DESC LIMIT 1`,
+			username,
+		).Scan(&profile.UserID, &profile.Username, &profile.FirstName, &profile.LastName, &profile.UpdatedAt, &firstSeenAt)
+	})
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	profile.FirstSeenAt = profile.UpdatedAt
+	if firstSeenAt.Valid {
+		profile.FirstSeenAt = firstSeenAt.Time
+	}
+
+	return &profile, nil
+}
+
+// IsUserUnreachable reports whether the user is flagged as unable to receive direct messages
+func (r *UserRepository) IsUserUnreachable(ctx context.Context, userID int64) (bool, error) {
+	var unreachable bool
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		return db.QueryRowContext(ctx,
+			`SELECT COUNT(*) > 0 FROM unreachable_users WHERE user_id = ?`,
+			userID,
+		).Scan(&unreachable)
+	})
+	return unreachable, err
+}
+
+// MarkUserUnreachable flags the user as unable to receive direct messages.
+// Reports whether the flag was newly set; an existing flag keeps its time.
+func (r *UserRepository) MarkUserUnreachable(ctx context.Context, userID int64, at time.Time) (bool, error) {
+	var marked bool
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`INSERT OR IGNORE INTO unreachable_users (user_id, marked_at) VALUES (?, ?)`,
+			userID, at,
+		)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		marked = affected > 0
+		return err
+	})
+	return marked, err
+}
+
+// ClearUserUnreachable removes the unreachable flag of a user and reports whether it was set
+func (r *UserRepository) ClearUserUnreachable(ctx context.Context, userID int64) (bool, error) {
+	var cleared bool
+	err := r.queue.ExecuteContext(ctx, func(ctx context.Context, db *sql.DB) error {
+		result, err := db.ExecContext(ctx,
+			`DELETE FROM unreachable_users WHERE user_id = ?`,
+			userID,
+		)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		cleared = affected > 0
+		return err
+	})
+	return cleared, err
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestUserRepository_UpsertUserProfile(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewUserRepository(queue)
+
+	// Unknown users have no profile
+	profile, err := repo.GetUserProfile(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+	if profile != nil {
+		t.Fatalf("Expected no profile for unknown user, got %+v", profile)
+	}
+
+	if err := repo.UpsertUserProfile(ctx, 1, "alice", "Alice", "Smith"); err != nil {
+		t.Fatalf("UpsertUserProfile failed: %v", err)
+	}
+	profile, err = repo.GetUserProfile(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetUserProfile failed: %v", err)
+	}
+	if profile == nil || profile.Username != "alice" || profile.FirstName != "Alice" || profile.LastName != "Smith" {
+		t.Fatalf("Unexpected profile: %+v", profile)
+	}
+	firstSeen := profile.UpdatedAt
+
+	// Same data does not rewrite the row
+	if err := repo.UpsertUserProfile(ctx, 1, "alice", "Alice", "Smith"); err != nil {
+		t.Fatalf("UpsertUserProfile failed: %v", err)
+	}
+	profile, _ = repo.GetUserProfile(ctx, 1)
+	if !profile.UpdatedAt.Equal(firstSeen) {
+		t.Errorf("Expected unchanged profile to keep updated_at %v, got %v", firstSeen, profile.UpdatedAt)
+	}
+
+	// Stale username is refreshed
+	if err := repo.UpsertUserProfile(ctx, 1, "alice_new", "Alice", "Smith"); err != nil {
+		t.Fatalf("UpsertUserProfile failed: %v", err)
+	}
+	profile, _ = repo.GetUserProfile(ctx, 1)
+	if profile.Username != "alice_new" {
+		t.Errorf("Expected refreshed username alice_new, got %q", profile.Username)
+	}
+	if profile.DisplayName() != "@alice_new" {
+		t.Errorf("Expected display name @alice_new, got %q", profile.DisplayName())
+	}
+	// The first sighting is kept across refreshes
+	if !profile.FirstSeenAt.Equal(firstSeen) {
+		t.Errorf("Expected first_seen_at to stay %v, got %v", firstSeen, profile.FirstSeenAt)
+	}
+}
+
+func TestUserRepository_GetUserProfileByUsername(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewUserRepository(queue)
+	if err := repo.UpsertUserProfile(ctx, 1, "Alice", "Alice", ""); err != nil {
+		t.Fatalf("UpsertUserProfile failed: %v", err)
+	}
+
+	for _, username := range []string{"alice", "@ALICE", " Alice "} {
+		profile, err := repo.GetUserProfileByUsername(ctx, username)
+		if err != nil {
+			t.Fatalf("GetUserProfileByUsername failed: %v", err)
+		}
+		if profile == nil || profile.UserID != 1 {
+			t.Errorf("Expected profile of user 1 for %q, got %+v", username, profile)
+		}
+	}
+
+	for _, username := range []string{"bob", "@", ""} {
+		profile, err := repo.GetUserProfileByUsername(ctx, username)
+		if err != nil {
+			t.Fatalf("GetUserProfileByUsername failed: %v", err)
+		}
+		if profile != nil {
+			t.Errorf("Expected no profile for %q, got %+v", username, profile)
+		}
+	}
+}
+
+func TestUserRepository_UnreachableFlag(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	queue := NewDBQueue(db)
+	defer queue.Close()
+
+	if err := InitSchema(queue); err != nil {
+		t.Fatalf("Failed to initialize schema: %v", err)
+	}
+	if err := RunMigrations(queue); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	repo := NewUserRepository(queue)
+
+	unreachable, err := repo.IsUserUnreachable(ctx, 1)
+	if err != nil || unreachable {
+		t.Fatalf("Expected unknown user to be reachable, got %v (err %v)", unreachable, err)
+	}
+
+	marked, err := repo.MarkUserUnreachable(ctx, 1, time.Now())
+	if err != nil || !marked {
+		t.Fatalf("Expected first mark to set the flag, got %v (err %v)", marked, err)
+	}
+	marked, err = repo.MarkUserUnreachable(ctx, 1, time.Now())
+	if err != nil || marked {
+		t.Fatalf("Expected second mark to report an existing flag, got %v (err %v)", marked, err)
+	}
+
+	unreachable, err = repo.IsUserUnreachable(ctx, 1)
+	if err != nil || !unreachable {
+		t.Fatalf("Expected user to be unreachable, got %v (err %v)", unreachable, err)
+	}
+	if unreachable, _ := repo.IsUserUnreachable(ctx, 2); unreachable {
+		t.Error("Expected the flag not to affect other users")
+	}
+
+	cleared, err := repo.ClearUserUnreachable(ctx, 1)
+	if err != nil || !cleared {
+		t.Fatalf("Expected clear to remove the flag, got %v (err %v)", cleared, err)
+	}
+	cleared, err = repo.ClearUserUnreachable(ctx, 1)
+	if err != nil || cleared {
+		t.Fatalf("Expected second clear to find no flag, got %v (err %v)", cleared, err)
+	}
+
+	unreachable, err = repo.IsUserUnreachable(ctx, 1)
+	if err != nil || unreachable {
+		t.Fatalf("Expected user to be reachable again, got %v (err %v)", unreachable, err)
+	}
+}